@@ -1,6 +1,8 @@
 package main
 
 import (
+	"os"
+
 	"github.com/reearth/reearth-accounts/server/internal/admin/di"
 	"github.com/reearth/reearthx/log"
 )
@@ -13,6 +15,10 @@ import (
 // @in							header
 // @name						Authorization
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == verifyAuditLogCommand {
+		os.Exit(verifyAuditLog())
+	}
+
 	server, cleanup, err := di.InitializeEcho()
 	if err != nil {
 		log.Fatalf("failed to initialize admin api: %v", err)
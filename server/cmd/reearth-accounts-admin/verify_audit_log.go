@@ -0,0 +1,44 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/di"
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearthx/log"
+)
+
+// verifyAuditLogCommand walks the admin audit chain and exits non-zero when a
+// record is missing, edited or not linked to its predecessor. It prints the
+// head of an intact chain; comparing it with the output of an earlier run
+// shows whether records were cut off the end.
+const verifyAuditLogCommand = "verify-audit-log"
+
+func verifyAuditLog() int {
+	verifier, cleanup, err := di.InitializeAuditLogVerifier()
+	if err != nil {
+		log.Fatalf("failed to initialize audit log verifier: %v", err)
+	}
+	defer cleanup()
+
+	out, err := verifier.Execute(context.Background())
+	if err != nil {
+		var ce *adminaudit.ChainError
+		if errors.As(err, &ce) {
+			fmt.Fprintf(os.Stderr, "admin audit chain is broken: %v\n", ce)
+			return 1
+		}
+		fmt.Fprintf(os.Stderr, "failed to verify admin audit chain: %v\n", err)
+		return 2
+	}
+
+	if out.Head == nil {
+		fmt.Println("admin audit chain is empty")
+		return 0
+	}
+	fmt.Printf("admin audit chain is intact: %d records, head seq %d hash %s\n", out.Count, out.Head.Seq(), out.Head.Hash())
+	return 0
+}
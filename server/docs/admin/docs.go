@@ -15,6 +15,286 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
+        "/admin-approval-rules": {
+            "get": {
+                "description": "Lists the rules that approve new admin sign-ins automatically, oldest first. A group rule takes precedence over an email domain rule; among rules of the same kind the oldest matching one wins.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-approval-rules"
+                ],
+                "summary": "List admin approval rules",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListAdminApprovalRulesResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds a rule approving new and pending admins automatically at sign-in with the given role when their email domain or one of their groups matches.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-approval-rules"
+                ],
+                "summary": "Create an admin approval rule",
+                "parameters": [
+                    {
+                        "description": "Rule",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateAdminApprovalRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/AdminApprovalRule"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid kind / value / provider / role",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "duplicate rule",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin-approval-rules/{id}": {
+            "delete": {
+                "description": "Stops approving sign-ins by the rule. The admins it approved stay approved.",
+                "tags": [
+                    "admin-approval-rules"
+                ],
+                "summary": "Delete an admin approval rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Approval rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes the role the rule grants. Admins it approved before keep their current role.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-approval-rules"
+                ],
+                "summary": "Update an admin approval rule",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Approval rule ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateAdminApprovalRuleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/AdminApprovalRule"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / role",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/admin-audit-records": {
+            "get": {
+                "description": "Lists the records of admin API writes, newest first, optionally filtered by actor, action, target and time range, with offset pagination.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "admin-audit-records"
+                ],
+                "summary": "List admin audit records",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Filter by admin user ID",
+                        "name": "actor",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by method and route (e.g. PATCH /api/v1/users/:id)",
+                        "name": "action",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by target resource ID",
+                        "name": "target",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only records at or after this time (RFC 3339)",
+                        "name": "since",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only records before this time (RFC 3339)",
+                        "name": "until",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (max 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListAdminAuditRecordsResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
         "/admin-users": {
             "get": {
                 "description": "Lists admin users in creation order, optionally filtered by status and/or role, with offset pagination.",
@@ -143,7 +423,7 @@ const docTemplate = `{
         },
         "/admin-users/{id}/reject": {
             "post": {
-                "description": "Rejects a pending admin user or revokes an approved one. Cannot reject your own account, and the last approved admin cannot be rejected.",
+                "description": "Rejects a pending admin user or revokes an approved one, ending all of their sessions. Cannot reject your own account, and the last approved admin cannot be rejected.",
                 "produces": [
                     "application/json"
                 ],
@@ -196,7 +476,7 @@ const docTemplate = `{
         },
         "/admin-users/{id}/roles": {
             "put": {
-                "description": "Assigns a role (e.g. system_admin, viewer) to the target admin user. Changing your own role is allowed, but the last system_admin cannot be demoted (the system must never reach zero system_admins).",
+                "description": "Assigns a role (e.g. system_admin, viewer) to the target admin user. Changing your own role is allowed, but the last system_admin cannot be demoted (the system must never reach zero system_admins). Changing the role ends all of the target's sessions.",
                 "consumes": [
                     "application/json"
                 ],
@@ -261,7 +541,7 @@ const docTemplate = `{
         },
         "/auth/google": {
             "post": {
-                "description": "Verifies the Google id_token and issues an HttpOnly admin session cookie plus a readable (non-HttpOnly) admin_csrf double-submit cookie. New accounts are created as pending (approved when the email is bootstrapped).",
+                "description": "Verifies the Google id_token, starts a server-side session and issues an HttpOnly admin session cookie naming it plus a readable (non-HttpOnly) admin_csrf double-submit cookie. New accounts are created as pending (approved when the email is bootstrapped).",
                 "consumes": [
                     "application/json"
                 ],
@@ -279,7 +559,7 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/GoogleSignInRequest"
+                            "$ref": "#/definitions/SignInRequest"
                         }
                     }
                 ],
@@ -287,7 +567,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/GoogleSignInResponse"
+                            "$ref": "#/definitions/SignInResponse"
                         }
                     },
                     "400": {
@@ -313,7 +593,7 @@ const docTemplate = `{
         },
         "/auth/logout": {
             "post": {
-                "description": "Clears the admin session cookie and the admin_csrf double-submit cookie. Public endpoint so the cookies can be cleared even with an expired/invalid token.",
+                "description": "Ends the server-side session named by the session cookie, if any, and clears the admin session cookie and the admin_csrf double-submit cookie. Public endpoint so the cookies can be cleared even with an expired/invalid token.",
                 "produces": [
                     "application/json"
                 ],
@@ -328,31 +608,57 @@ const docTemplate = `{
                 }
             }
         },
-        "/me": {
-            "get": {
-                "description": "Returns the admin user record for the session cookie (any status).",
+        "/auth/oidc": {
+            "post": {
+                "description": "Verifies the id_token of the configured OpenID Connect provider (issuer, audience, signature against its discovered JWKS, required claims and allowed domains/groups), starts a server-side session and issues the same cookies as Google sign-in. New accounts are created as pending (approved when the email is bootstrapped).",
+                "consumes": [
+                    "application/json"
+                ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
                     "auth"
                 ],
-                "summary": "Get the current admin user",
+                "summary": "Sign in with an OIDC id_token",
+                "parameters": [
+                    {
+                        "description": "OIDC id_token",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SignInRequest"
+                        }
+                    }
+                ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/MeResponse"
+                            "$ref": "#/definitions/SignInResponse"
                         }
                     },
-                    "401": {
-                        "description": "unauthorized",
+                    "400": {
+                        "description": "invalid request",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "account not found",
+                    "401": {
+                        "description": "id_token verification failed",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "account not allowed / email not verified",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "no OIDC provider configured",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -360,33 +666,91 @@ const docTemplate = `{
                 }
             }
         },
-        "/users": {
+        "/auth/sessions": {
             "get": {
-                "description": "Lists users, optionally filtered by a name/alias/email keyword, with offset pagination.",
+                "description": "Lists the current admin user's unexpired sessions, newest first. The session of this request is marked as current.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "auth"
                 ],
-                "summary": "List users",
+                "summary": "List my sessions",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListSessionsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/sessions/{id}": {
+            "delete": {
+                "description": "Ends one of the current admin user's sessions; its cookie stops working immediately. Revoking the session of this request signs the caller out.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "auth"
+                ],
+                "summary": "Revoke one of my sessions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Search by name, alias or email",
-                        "name": "q",
-                        "in": "query"
+                        "description": "Session ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
                     },
-                    {
-                        "type": "integer",
-                        "description": "Page number (1-based)",
-                        "name": "page",
-                        "in": "query"
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
                     },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/ldap-sync/runs": {
+            "get": {
+                "description": "Lists the recent runs of the LDAP directory sync, newest first, with the number of users each one changed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "ldap-sync"
+                ],
+                "summary": "List LDAP sync runs",
+                "parameters": [
                     {
                         "type": "integer",
-                        "description": "Items per page (max 100)",
-                        "name": "per_page",
+                        "description": "Number of runs (default 20, max 100)",
+                        "name": "limit",
                         "in": "query"
                     }
                 ],
@@ -394,7 +758,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ListUsersResponse"
+                            "$ref": "#/definitions/ListLDAPSyncRunsResponse"
                         }
                     },
                     "400": {
@@ -410,7 +774,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "not approved",
+                        "description": "not approved / forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -418,20 +782,20 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}": {
+        "/ldap-sync/runs/{id}": {
             "get": {
-                "description": "Returns the detail of a single user by ID.",
+                "description": "Returns the diff of an LDAP sync run: the users it created, updated, reactivated and deactivated, the workspace memberships it changed and the directory entries it skipped.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
+                    "ldap-sync"
                 ],
-                "summary": "Get a user",
+                "summary": "Get an LDAP sync run",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID",
+                        "description": "Run ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -441,7 +805,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/UserDetail"
+                            "$ref": "#/definitions/LDAPSyncRunDetail"
                         }
                     },
                     "400": {
@@ -457,7 +821,7 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "not approved",
+                        "description": "not approved / forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -471,55 +835,63 @@ const docTemplate = `{
                 }
             }
         },
-        "/users/{id}/workspaces": {
+        "/me": {
             "get": {
-                "description": "Returns the workspaces the user belongs to, with the user's role in each. An existing user in no workspace returns an empty list; a non-existent user returns 404.",
+                "description": "Returns the admin user record for the session cookie (any status).",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "users"
-                ],
-                "summary": "List a user's workspaces",
-                "parameters": [
-                    {
-                        "type": "string",
-                        "description": "User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
-                    }
+                    "auth"
                 ],
+                "summary": "Get the current admin user",
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/UserWorkspace"
-                            }
+                            "$ref": "#/definitions/MeResponse"
                         }
                     },
-                    "400": {
-                        "description": "invalid id",
+                    "401": {
+                        "description": "unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "unauthorized",
+                    "404": {
+                        "description": "account not found",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
+                    }
+                }
+            }
+        },
+        "/roles": {
+            "get": {
+                "description": "Lists the roles sorted by name, with the number of users holding each globally. Bindings scoped to a workspace are not counted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "List platform roles",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListRolesResponse"
+                        }
                     },
-                    "403": {
-                        "description": "not approved",
+                    "401": {
+                        "description": "unauthorized",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "404": {
-                        "description": "user not found",
+                    "403": {
+                        "description": "not approved / forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -527,42 +899,23 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces": {
+        "/roles/{id}/users": {
             "get": {
-                "description": "Lists workspaces across all tenants, optionally filtered by a name/alias keyword, with offset pagination.\nUse ` + "`" + `type` + "`" + ` to filter by workspace type: ` + "`" + `personal` + "`" + ` returns only personal workspaces, ` + "`" + `team` + "`" + ` returns only team (non-personal) workspaces; when absent, both types are returned.\nWhen one or more ` + "`" + `ids` + "`" + ` query parameters are supplied, the endpoint instead resolves those workspaces by ID (existing ones only; unknown IDs are omitted) and ignores ` + "`" + `q` + "`" + `, ` + "`" + `type` + "`" + `, ` + "`" + `page` + "`" + ` and ` + "`" + `per_page` + "`" + `. At most 100 ids may be supplied per request.",
+                "description": "Lists the users holding the role globally, ordered by user ID, with offset pagination.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "workspaces"
+                    "roles"
                 ],
-                "summary": "List workspaces",
+                "summary": "List the users holding a role",
                 "parameters": [
                     {
-                        "type": "array",
-                        "items": {
-                            "type": "string"
-                        },
-                        "collectionFormat": "multi",
-                        "description": "Batch fetch by workspace ID (repeatable, max 100). When present, q/type/page/per_page are ignored.",
-                        "name": "ids",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by name or alias",
-                        "name": "q",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "personal",
-                            "team"
-                        ],
                         "type": "string",
-                        "description": "Filter by workspace type",
-                        "name": "type",
-                        "in": "query"
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
@@ -581,11 +934,11 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/ListWorkspacesResponse"
+                            "$ref": "#/definitions/ListRoleUsersResponse"
                         }
                     },
                     "400": {
-                        "description": "invalid query",
+                        "description": "invalid id / query",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -597,13 +950,13 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "not approved",
+                        "description": "not approved / forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
-                    "501": {
-                        "description": "not implemented on this backend",
+                    "404": {
+                        "description": "role not found",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -611,34 +964,48 @@ const docTemplate = `{
                 }
             }
         },
-        "/workspaces/{id}": {
-            "get": {
-                "description": "Returns the detail of a single workspace by ID.",
+        "/roles/{id}/users/{userId}": {
+            "put": {
+                "description": "Binds the role to the user globally or, on the workspace route, makes it the membership role of the user in the workspace, which must be one of owner, maintainer, writer and reader. The user must already be a member of the workspace. The membership change is recorded in the audit log like the member endpoints do.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "workspaces"
+                    "roles"
                 ],
-                "summary": "Get a workspace",
+                "summary": "Grant a role to a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace ID",
+                        "description": "Role ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace ID (workspace route only)",
+                        "name": "workspaceId",
+                        "in": "path",
+                        "required": false
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/Workspace"
+                            "$ref": "#/definitions/UserRoles"
                         }
                     },
                     "400": {
-                        "description": "invalid id",
+                        "description": "invalid id / not a membership role",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -650,51 +1017,66 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "not approved",
+                        "description": "not approved / forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "not found",
+                        "description": "role, user, workspace or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "already granted",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     }
                 }
-            }
-        },
-        "/workspaces/{id}/members": {
-            "get": {
-                "description": "Returns the members of a workspace, each with their role and (when resolvable) the underlying user's name and email.",
+            },
+            "delete": {
+                "description": "Unbinds the role from the user globally or, on the workspace route, removes the member holding it from the workspace, as a workspace binding follows the membership. For a user who is not a member, only a binding left in their roles is removed.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "workspaces"
+                    "roles"
                 ],
-                "summary": "List a workspace's members",
+                "summary": "Revoke a role from a user",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Workspace ID",
+                        "description": "Role ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace ID (workspace route only)",
+                        "name": "workspaceId",
+                        "in": "path",
+                        "required": false
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "type": "array",
-                            "items": {
-                                "$ref": "#/definitions/WorkspaceMember"
-                            }
+                            "$ref": "#/definitions/UserRoles"
                         }
                     },
                     "400": {
-                        "description": "invalid id",
+                        "description": "invalid id / not a membership role / last owner",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
@@ -706,71 +1088,3310 @@ const docTemplate = `{
                         }
                     },
                     "403": {
-                        "description": "not approved",
+                        "description": "not approved / forbidden",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "not found",
+                        "description": "not found / not granted",
                         "schema": {
                             "$ref": "#/definitions/ErrorResponse"
                         }
                     }
                 }
             }
-        }
-    },
-    "definitions": {
-        "AdminUser": {
-            "type": "object",
-            "properties": {
-                "approvedAt": {
-                    "type": "string"
-                },
-                "approvedBy": {
-                    "type": "string"
-                },
-                "createdAt": {
-                    "type": "string"
-                },
-                "email": {
-                    "type": "string"
-                },
-                "id": {
-                    "type": "string"
+        },
+        "/roles/{id}/workspaces/{workspaceId}/users/{userId}": {
+            "put": {
+                "description": "Binds the role to the user globally or, on the workspace route, makes it the membership role of the user in the workspace, which must be one of owner, maintainer, writer and reader. The user must already be a member of the workspace. The membership change is recorded in the audit log like the member endpoints do.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Grant a role to a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace ID (workspace route only)",
+                        "name": "workspaceId",
+                        "in": "path",
+                        "required": false
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserRoles"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / not a membership role",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "role, user, workspace or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "already granted",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Unbinds the role from the user globally or, on the workspace route, removes the member holding it from the workspace, as a workspace binding follows the membership. For a user who is not a member, only a binding left in their roles is removed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "roles"
+                ],
+                "summary": "Revoke a role from a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Role ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Workspace ID (workspace route only)",
+                        "name": "workspaceId",
+                        "in": "path",
+                        "required": false
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserRoles"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / not a membership role / last owner",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found / not granted",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim-tenants": {
+            "get": {
+                "description": "Lists the identity provider tenants allowed to provision users and workspaces through the SCIM API at /scim/v2.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim-tenants"
+                ],
+                "summary": "List SCIM tenants",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListSCIMTenantsResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Registers an identity provider tenant and issues its SCIM bearer token. The token is returned only in this response.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim-tenants"
+                ],
+                "summary": "Create a SCIM tenant",
+                "parameters": [
+                    {
+                        "description": "Tenant",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/CreateSCIMTenantRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMTenantToken"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid name / sub prefix / reserved sub prefix",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "sub prefix overlaps with another tenant",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim-tenants/{id}": {
+            "delete": {
+                "description": "Revokes the tenant's SCIM access. The users and workspaces it provisioned are kept.",
+                "tags": [
+                    "scim-tenants"
+                ],
+                "summary": "Delete a SCIM tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SCIM tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/scim-tenants/{id}/rotate-token": {
+            "post": {
+                "description": "Issues a new SCIM bearer token for the tenant. The previous token stops working immediately, so the identity provider must be updated with the returned one.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "scim-tenants"
+                ],
+                "summary": "Rotate the token of a SCIM tenant",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "SCIM tenant ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/SCIMTenantToken"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/signing-keys": {
+            "get": {
+                "description": "Lists the versions of the token signing key with their activation and retirement times. Every version that is not retired is published in the JWKS.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signing-keys"
+                ],
+                "summary": "List signing keys",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListSigningKeysResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/signing-keys/rotate": {
+            "post": {
+                "description": "Generates a new signing key version. It is published immediately and starts signing after the overlap; the current versions are retired one overlap after that. The overlap must exceed the JWKS cache lifetime of verifiers and the lifetime of issued tokens. A zero overlap replaces the key at once, invalidating every token it signed.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "signing-keys"
+                ],
+                "summary": "Rotate the signing key",
+                "parameters": [
+                    {
+                        "description": "Rotation options",
+                        "name": "body",
+                        "in": "body",
+                        "schema": {
+                            "$ref": "#/definitions/RotateSigningKeyRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListSigningKeysResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid overlap",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "a rotation is already in progress",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/stats": {
+            "get": {
+                "description": "Returns the counts of users and workspaces, the signups and deactivations of each UTC day of the window up to today, the workspace memberships by role and the users by auth provider. Users merged into another are not counted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "stats"
+                ],
+                "summary": "Get the dashboard statistics",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Number of days of daily figures (default 30, max 365)",
+                        "name": "days",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/AdminStats"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid days",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Lists users, optionally filtered by a name/alias/email keyword, with offset pagination.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List users",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by name, alias or email",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (max 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/export": {
+            "get": {
+                "description": "Downloads the active users, or the members of a team workspace, in the file format of the import: email, name, alias, lang, theme, the subs of their identity providers and their team workspace memberships. Passwords are not exported. The export is recorded in the audit log.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export users to a CSV or JSON file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv (default) or json",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Alias of a workspace whose members to export",
+                        "name": "workspace",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/Record"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid format",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/import": {
+            "post": {
+                "description": "Validates a file of users (email, name, alias, lang, theme, subs and team workspace memberships) and reports the errors of each row. The import is a dry run unless dry_run=false; it then creates the users with their personal workspaces, the team workspaces that do not exist yet and the memberships, in batches, and records the import in the audit log. Nothing is created when any row is invalid. A CSV file has a header row; its subs are separated by \";\" and its workspaces are \"\u003calias\u003e:\u003crole\u003e\" pairs separated by \";\".",
+                "consumes": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Import users from a CSV or JSON file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "csv or json (defaults to the Content-Type)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only validate the file (default true)",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "The file",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "dry run",
+                        "schema": {
+                            "$ref": "#/definitions/ImportUsersResponse"
+                        }
+                    },
+                    "201": {
+                        "description": "users created",
+                        "schema": {
+                            "$ref": "#/definitions/ImportUsersResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid file / format / too many users",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "file too large",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "invalid rows, nothing created",
+                        "schema": {
+                            "$ref": "#/definitions/ImportUsersResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/listing-export": {
+            "get": {
+                "description": "Streams every user matching the filters of the user list, in its order, with the number of team workspaces each user is a member of and the user's role in each. Unlike the list it is not paginated. The export is recorded in the audit log.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export the user list to a CSV or NDJSON file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by name, alias or email",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "active",
+                            "deleted"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status (default all)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv (default) or ndjson",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "one per line in NDJSON",
+                        "schema": {
+                            "$ref": "#/definitions/UserListingRow"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Returns the detail of a single user by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Get a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserDetail"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes the name, alias or email of the user. The alias and, unless it was renamed, the name of the personal workspace follow the user. The email is not pushed to external identity providers. The old and new values are recorded in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Edit a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / invalid body / nothing to update",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "alias or email already taken",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/auths/{sub}": {
+            "delete": {
+                "description": "Unlinks the sign-in method with the sub from the user. The last auth of a user and Auth0 auths can't be removed. The removal is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Remove an auth from a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "URL-encoded sub of the auth, e.g. google-oauth2%7C123",
+                        "name": "sub",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / last or Auth0 auth",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "user or auth not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/data-export": {
+            "post": {
+                "description": "Answers a data subject access request: archives the profile, auths, metadata, workspace memberships, roles, sign-in traces and audit log entries of the user as a ZIP file of JSON documents, uploads it to the storage and mails the user a signed link to download it, which expires in 24 hours. Secrets such as the password hash are left out. The export is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Export the personal data of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ExportUserDataResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/deactivate": {
+            "post": {
+                "description": "Soft-deletes the user, who can no longer sign in. The user is purged once the retention window has passed unless restored first. The deactivation is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Deactivate a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "user is deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/impersonate": {
+            "post": {
+                "description": "Issues a short-lived token with which the admin sees the main service exactly as the user does. The main service accepts it in the returned request header while the admin session that issued it is signed in, allows only reads with it, and records every request made with it in the admin audit trail with both identities. The issue is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Impersonate a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ImpersonateUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "user is deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "impersonation is not configured",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/merge": {
+            "post": {
+                "description": "Moves the auths, workspace memberships and role bindings of the source user to the user, keeping the higher role where both are members, and soft-deletes the source. The password of the source is not moved. The merge is recorded in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Merge a duplicate user into a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "ID of the user to keep",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Source user",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/MergeUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/MergeUserResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / same or deleted user",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "both users have an auth of the same provider",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/mfa": {
+            "delete": {
+                "description": "Removes the second factor of the user, including a pending enrollment, so that they can sign in with their password and enroll again. Second factors managed by Auth0 are reset in Auth0. The reset is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Reset the MFA of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / user has no second factor",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/password-reset": {
+            "post": {
+                "description": "Mails the user a link to choose a new password, like the \"forgot password\" flow. The current password keeps working until the link is followed. Only active users who sign in with a password can reset it. The reset is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Trigger a password reset for a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / user has no password",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "user is deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/restore": {
+            "post": {
+                "description": "Reactivates a deactivated user, including one who asked for the deletion of their account. Users merged into another can't be restored. The restoration is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Restore a deactivated user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / merged user",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "user is not deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/verify-email": {
+            "post": {
+                "description": "Marks the email of the user as verified without the verification mail, e.g. for users who can't receive it. The verification is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "Force-verify the email of a user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/UserActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "email is already verified",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/users/{id}/workspaces": {
+            "get": {
+                "description": "Returns the workspaces the user belongs to, with the user's role in each. An existing user in no workspace returns an empty list; a non-existent user returns 404.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "users"
+                ],
+                "summary": "List a user's workspaces",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/UserWorkspace"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "user not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces": {
+            "get": {
+                "description": "Lists workspaces across all tenants, optionally filtered by a name/alias keyword, with offset pagination.\nUse ` + "`" + `type` + "`" + ` to filter by workspace type: ` + "`" + `personal` + "`" + ` returns only personal workspaces, ` + "`" + `team` + "`" + ` returns only team (non-personal) workspaces; when absent, both types are returned.\nWhen one or more ` + "`" + `ids` + "`" + ` query parameters are supplied, the endpoint instead resolves those workspaces by ID (existing ones only; unknown IDs are omitted) and ignores ` + "`" + `q` + "`" + `, ` + "`" + `type` + "`" + `, ` + "`" + `page` + "`" + ` and ` + "`" + `per_page` + "`" + `. At most 100 ids may be supplied per request.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "List workspaces",
+                "parameters": [
+                    {
+                        "type": "array",
+                        "items": {
+                            "type": "string"
+                        },
+                        "collectionFormat": "multi",
+                        "description": "Batch fetch by workspace ID (repeatable, max 100). When present, q/type/page/per_page are ignored.",
+                        "name": "ids",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name or alias",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "personal",
+                            "team"
+                        ],
+                        "type": "string",
+                        "description": "Filter by workspace type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Page number (1-based)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (max 100)",
+                        "name": "per_page",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/ListWorkspacesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "not implemented on this backend",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/listing-export": {
+            "get": {
+                "description": "Streams every workspace matching the filters of the workspace list, in its order, with its member count, the number of members in each role and its integration count. Unlike the list it is not paginated. The export is recorded in the audit log.",
+                "produces": [
+                    "text/csv",
+                    "application/x-ndjson"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Export the workspace list to a CSV or NDJSON file",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Search by name or alias",
+                        "name": "q",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "personal",
+                            "team"
+                        ],
+                        "type": "string",
+                        "description": "Filter by workspace type",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "all",
+                            "active",
+                            "deleted"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status (default all)",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "csv (default) or ndjson",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "one per line in NDJSON",
+                        "schema": {
+                            "$ref": "#/definitions/WorkspaceListingRow"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid query",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "501": {
+                        "description": "not implemented on this backend",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}": {
+            "get": {
+                "description": "Returns the detail of a single workspace by ID.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Get a workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/Workspace"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Renames or re-aliases the workspace. An empty alias is replaced by one derived from the ID. Personal workspaces follow their user and can't be edited. The old and new values are recorded in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Edit a workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Fields to change",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateWorkspaceRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WorkspaceActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / invalid body / nothing to update / personal workspace",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "alias already taken",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/deactivate": {
+            "post": {
+                "description": "Soft-deletes the workspace. Its members and data are kept, and it is purged once the retention window has passed unless restored first. The deactivation is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Deactivate a workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WorkspaceActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / personal workspace",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "workspace is deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/integrations/{integrationId}": {
+            "delete": {
+                "description": "Removes the integration from the workspace. The removal is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Remove an integration from a workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Integration ID",
+                        "name": "integrationId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WorkspaceActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / personal workspace",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "workspace or integration not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/members": {
+            "get": {
+                "description": "Returns the members of a workspace, each with their role and (when resolvable) the underlying user's name and email.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "List a workspace's members",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "array",
+                            "items": {
+                                "$ref": "#/definitions/WorkspaceMember"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "post": {
+                "description": "Adds the users to the workspace with their roles and binds their permittables to it. Any role, including owner, can be given, e.g. to give a workspace whose owner left a new one. The added members are recorded in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Add members to a workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Members to add",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/AddWorkspaceMembersRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WorkspaceActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / invalid body / invalid role / personal workspace",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "workspace or user not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "user already joined",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/members/{userId}": {
+            "delete": {
+                "description": "Removes the member and unbinds their permittable from the workspace. The only owner can't be removed; make another member an owner first. The removal is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Remove a member from a workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID of the member",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WorkspaceActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / last owner / personal workspace",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "workspace or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "patch": {
+                "description": "Changes the role of the member and updates their permittable. A member can be made an owner, which is how a workspace whose owner left gets a new one; the only owner can't be demoted. The old and new roles are recorded in the audit log.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Change the role of a workspace member",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "User ID of the member",
+                        "name": "userId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/UpdateWorkspaceMemberRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WorkspaceActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / invalid role / last owner / personal workspace",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "workspace or member not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/restore": {
+            "post": {
+                "description": "Reactivates a deactivated workspace. The restoration is recorded in the audit log.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "workspaces"
+                ],
+                "summary": "Restore a deactivated workspace",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/WorkspaceActionResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / personal workspace",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "workspace is not deactivated",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/workspaces/{id}/role-mapping": {
+            "get": {
+                "description": "Returns the rules mapping the claims of the workspace's identity provider to workspace roles.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role-mappings"
+                ],
+                "summary": "Get a workspace's role mapping",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/RoleMapping"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Creates or replaces the rules mapping the claims of the workspace's identity provider to workspace roles. Memberships are updated the next time each user of the identity provider signs in or calls the API.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "role-mappings"
+                ],
+                "summary": "Set a workspace's role mapping",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Role mapping",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/SetRoleMappingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/RoleMapping"
+                        }
+                    },
+                    "400": {
+                        "description": "invalid id / issuer / rule",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "workspace not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Stops mapping the claims of the workspace's identity provider. The members it added are kept.",
+                "tags": [
+                    "role-mappings"
+                ],
+                "summary": "Delete a workspace's role mapping",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Workspace ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "invalid id",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "not approved / forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "not found",
+                        "schema": {
+                            "$ref": "#/definitions/ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
+    "definitions": {
+        "AddWorkspaceMemberRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "maintainer",
+                        "writer",
+                        "reader"
+                    ],
+                    "example": "writer"
+                },
+                "userId": {
+                    "type": "string",
+                    "example": "01h2x3y4z5a6b7c8d9e0f1g2h3"
+                }
+            }
+        },
+        "AddWorkspaceMembersRequest": {
+            "type": "object",
+            "properties": {
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AddWorkspaceMemberRequest"
+                    }
+                }
+            }
+        },
+        "AdminApprovalRule": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "createdBy": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "description": "Kind is email_domain or group.",
+                    "type": "string"
+                },
+                "provider": {
+                    "description": "Provider is google or oidc; empty when the rule applies to both.",
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "value": {
+                    "type": "string"
+                }
+            }
+        },
+        "AdminAuditRecord": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string"
+                },
+                "actor": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "diff": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "hash": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "prevHash": {
+                    "type": "string"
+                },
+                "requestId": {
+                    "type": "string"
+                },
+                "seq": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "integer"
+                },
+                "target": {
+                    "type": "string"
+                }
+            }
+        },
+        "AdminDailyStats": {
+            "type": "object",
+            "properties": {
+                "date": {
+                    "type": "string",
+                    "example": "2026-10-19"
+                },
+                "deactivations": {
+                    "type": "integer"
+                },
+                "signups": {
+                    "type": "integer"
+                }
+            }
+        },
+        "AdminSession": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
+                },
+                "current": {
+                    "type": "boolean"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "ip": {
+                    "type": "string"
+                },
+                "userAgent": {
+                    "type": "string"
+                }
+            }
+        },
+        "AdminStats": {
+            "type": "object",
+            "properties": {
+                "daily": {
+                    "description": "Daily has one entry per day of the window, oldest first.",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AdminDailyStats"
+                    }
+                },
+                "membersByRole": {
+                    "description": "MembersByRole counts the members of the active team workspaces by role.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "users": {
+                    "$ref": "#/definitions/AdminUserStats"
+                },
+                "usersByAuthProvider": {
+                    "description": "UsersByAuthProvider counts the active users that can sign in with each\nprovider; a user with several providers counts for each.",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer",
+                        "format": "int64"
+                    }
+                },
+                "workspaces": {
+                    "$ref": "#/definitions/AdminWorkspaceStats"
+                }
+            }
+        },
+        "AdminUser": {
+            "type": "object",
+            "properties": {
+                "approvedAt": {
+                    "type": "string"
+                },
+                "approvedBy": {
+                    "type": "string"
+                },
+                "approvedByRule": {
+                    "description": "ApprovedByRule is the approval rule that approved the admin at sign-in,\nset instead of ApprovedBy.",
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pictureUrl": {
+                    "type": "string"
+                },
+                "role": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "AdminUserStats": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "integer"
+                },
+                "deactivated": {
+                    "type": "integer"
+                },
+                "unverified": {
+                    "description": "Unverified counts the active users whose email is not verified.",
+                    "type": "integer"
+                }
+            }
+        },
+        "AdminWorkspaceStats": {
+            "type": "object",
+            "properties": {
+                "deactivated": {
+                    "type": "integer"
+                },
+                "personal": {
+                    "type": "integer"
+                },
+                "team": {
+                    "type": "integer"
+                }
+            }
+        },
+        "CreateAdminApprovalRuleRequest": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "description": "Kind is email_domain or group.",
+                    "type": "string",
+                    "example": "email_domain"
+                },
+                "provider": {
+                    "description": "Provider limits the rule to google or oidc sign-ins; empty applies it to\nboth.",
+                    "type": "string",
+                    "example": "google"
+                },
+                "role": {
+                    "type": "string",
+                    "example": "viewer"
+                },
+                "value": {
+                    "description": "Value is the email domain, or the group: a Google Workspace group email\naddress or an entry of the OIDC groups claim.",
+                    "type": "string",
+                    "example": "eukarya.io"
+                }
+            }
+        },
+        "CreateSCIMTenantRequest": {
+            "type": "object",
+            "properties": {
+                "name": {
+                    "type": "string",
+                    "example": "Okta"
+                },
+                "subPrefix": {
+                    "description": "SubPrefix is the auth sub prefix the identity provider signs users in\nwith, so that provisioned users match their SSO logins. Defaults to a\nprefix unique to the tenant. Prefixes of other providers and tenants are\nrefused.",
+                    "type": "string",
+                    "example": "samlp|okta"
+                }
+            }
+        },
+        "ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "code": {
+                    "type": "string",
+                    "example": "Bad Request"
+                },
+                "message": {
+                    "type": "string",
+                    "example": "invalid request"
+                }
+            }
+        },
+        "ExportUserDataResponse": {
+            "type": "object",
+            "properties": {
+                "auditLogId": {
+                    "type": "string"
+                },
+                "downloadUrl": {
+                    "description": "DownloadURL is the signed URL mailed to the user. It expires in 24\nhours.",
+                    "type": "string"
+                },
+                "objectName": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "ImpersonateUserResponse": {
+            "type": "object",
+            "properties": {
+                "auditLogId": {
+                    "type": "string"
+                },
+                "expiresAt": {
+                    "type": "string"
+                },
+                "header": {
+                    "type": "string"
+                },
+                "token": {
+                    "description": "Token is sent to the main service in the Header request header.",
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "ImportUserRow": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "row": {
+                    "description": "Row is the position of the record in the file, starting at 1 and not\ncounting the header of a CSV file.",
+                    "type": "integer"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "ImportUsersResponse": {
+            "type": "object",
+            "properties": {
+                "auditLogId": {
+                    "type": "string"
+                },
+                "dryRun": {
+                    "type": "boolean"
+                },
+                "imported": {
+                    "description": "Imported is whether the users were created.",
+                    "type": "boolean"
+                },
+                "newWorkspaces": {
+                    "description": "NewWorkspaces are the aliases of the team workspaces the import creates.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/ImportUserRow"
+                    }
+                },
+                "valid": {
+                    "description": "Valid is whether every row is valid.",
+                    "type": "boolean"
+                }
+            }
+        },
+        "LDAPSyncMembershipChange": {
+            "type": "object",
+            "properties": {
+                "from": {
+                    "type": "string"
+                },
+                "to": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "workspaceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "LDAPSyncRun": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "integer"
+                },
+                "deactivated": {
+                    "type": "integer"
+                },
+                "error": {
+                    "type": "string"
+                },
+                "finishedAt": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "memberships": {
+                    "type": "integer"
+                },
+                "reactivated": {
+                    "type": "integer"
+                },
+                "skipped": {
+                    "type": "integer"
+                },
+                "startedAt": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "userCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "LDAPSyncRunDetail": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LDAPSyncUserChange"
+                    }
+                },
+                "deactivated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LDAPSyncUserChange"
+                    }
+                },
+                "memberships": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LDAPSyncMembershipChange"
+                    }
+                },
+                "reactivated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LDAPSyncUserChange"
+                    }
+                },
+                "run": {
+                    "$ref": "#/definitions/LDAPSyncRun"
+                },
+                "skipped": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LDAPSyncSkip"
+                    }
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LDAPSyncUserChange"
+                    }
+                }
+            }
+        },
+        "LDAPSyncSkip": {
+            "type": "object",
+            "properties": {
+                "dn": {
+                    "type": "string"
+                },
+                "reason": {
+                    "type": "string"
+                }
+            }
+        },
+        "LDAPSyncUserChange": {
+            "type": "object",
+            "properties": {
+                "email": {
+                    "type": "string"
+                },
+                "sub": {
+                    "type": "string"
+                },
+                "userId": {
+                    "type": "string"
+                }
+            }
+        },
+        "ListAdminApprovalRulesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AdminApprovalRule"
+                    }
+                }
+            }
+        },
+        "ListAdminAuditRecordsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AdminAuditRecord"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "perPage": {
+                    "type": "integer"
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ListAdminUsersResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AdminUser"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "perPage": {
+                    "type": "integer"
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ListLDAPSyncRunsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/LDAPSyncRun"
+                    }
+                }
+            }
+        },
+        "ListRoleUsersResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RoleUser"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "perPage": {
+                    "type": "integer"
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ListRolesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Role"
+                    }
+                }
+            }
+        },
+        "ListSCIMTenantsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SCIMTenant"
+                    }
+                }
+            }
+        },
+        "ListSessionsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/AdminSession"
+                    }
+                }
+            }
+        },
+        "ListSigningKeysResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/SigningKey"
+                    }
+                }
+            }
+        },
+        "ListUsersResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/User"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "perPage": {
+                    "type": "integer"
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "ListWorkspacesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Workspace"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "perPage": {
+                    "type": "integer"
+                },
+                "totalCount": {
+                    "type": "integer"
+                }
+            }
+        },
+        "MeResponse": {
+            "type": "object",
+            "properties": {
+                "approvedAt": {
+                    "type": "string"
+                },
+                "approvedBy": {
+                    "type": "string"
+                },
+                "createdAt": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "pictureUrl": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                }
+            }
+        },
+        "Membership": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string"
+                },
+                "workspace": {
+                    "type": "string"
+                }
+            }
+        },
+        "MergeUserRequest": {
+            "type": "object",
+            "properties": {
+                "sourceId": {
+                    "description": "SourceID is the duplicate account, which is deleted by the merge.",
+                    "type": "string",
+                    "example": "01hxyz..."
+                }
+            }
+        },
+        "MergeUserResponse": {
+            "type": "object",
+            "properties": {
+                "auditLogId": {
+                    "type": "string"
+                },
+                "droppedAuths": {
+                    "description": "DroppedAuths are the auth subs of the source that were not moved.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "movedAuths": {
+                    "description": "MovedAuths are the auth subs moved from the source.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "user": {
+                    "$ref": "#/definitions/UserDetail"
+                },
+                "workspaceIds": {
+                    "description": "WorkspaceIDs are the workspaces the source was a member of.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "Record": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "description": "Alias is also the alias of the user's personal workspace. It defaults\nto the name, like a signup.",
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "lang": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "subs": {
+                    "description": "Subs are the subs of the identity providers the user signs in with,\ne.g. \"oidc-acme|1234\". Imported users have no password.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "theme": {
+                    "type": "string"
+                },
+                "workspaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/Membership"
+                    }
+                }
+            }
+        },
+        "Role": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "userCount": {
+                    "description": "UserCount is the number of users holding the role globally.",
+                    "type": "integer"
+                }
+            }
+        },
+        "RoleMapping": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "issuer": {
+                    "type": "string"
+                },
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RoleMappingRule"
+                    }
+                },
+                "subPrefix": {
+                    "type": "string"
+                },
+                "updatedAt": {
+                    "type": "string"
+                },
+                "workspaceId": {
+                    "type": "string"
+                }
+            }
+        },
+        "RoleMappingRule": {
+            "type": "object",
+            "properties": {
+                "claim": {
+                    "type": "string",
+                    "example": "groups"
+                },
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "maintainer",
+                        "writer",
+                        "reader"
+                    ],
+                    "example": "writer"
+                },
+                "value": {
+                    "type": "string",
+                    "example": "gis-editors"
+                }
+            }
+        },
+        "RoleUser": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string"
+                },
+                "email": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
                 },
                 "name": {
                     "type": "string"
+                }
+            }
+        },
+        "RotateSigningKeyRequest": {
+            "type": "object",
+            "properties": {
+                "overlap": {
+                    "description": "Overlap is a Go duration such as \"24h\". Defaults to 24h; \"0s\" switches\nand retires the keys at once.",
+                    "type": "string",
+                    "example": "24h"
+                }
+            }
+        },
+        "SCIMTenant": {
+            "type": "object",
+            "properties": {
+                "createdAt": {
+                    "type": "string"
                 },
-                "pictureUrl": {
+                "groupCount": {
+                    "type": "integer"
+                },
+                "id": {
                     "type": "string"
                 },
-                "role": {
+                "name": {
                     "type": "string"
                 },
-                "status": {
+                "subPrefix": {
                     "type": "string"
                 },
                 "updatedAt": {
                     "type": "string"
+                },
+                "userCount": {
+                    "type": "integer"
                 }
             }
         },
-        "ErrorResponse": {
+        "SCIMTenantToken": {
             "type": "object",
             "properties": {
-                "code": {
+                "tenant": {
+                    "$ref": "#/definitions/SCIMTenant"
+                },
+                "token": {
+                    "description": "Token is shown only once; it is stored hashed.",
+                    "type": "string"
+                }
+            }
+        },
+        "SetAdminUserRoleRequest": {
+            "type": "object",
+            "required": [
+                "role"
+            ],
+            "properties": {
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "SetRoleMappingRequest": {
+            "type": "object",
+            "properties": {
+                "issuer": {
                     "type": "string",
-                    "example": "Bad Request"
+                    "example": "https://example.auth0.com/"
                 },
-                "message": {
+                "rules": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/RoleMappingRule"
+                    }
+                },
+                "subPrefix": {
+                    "description": "SubPrefix narrows the identity provider down to one connection when\nseveral share the issuer. Empty matches every token of the issuer.",
                     "type": "string",
-                    "example": "invalid request"
+                    "example": "samlp|org_123"
                 }
             }
         },
-        "GoogleSignInRequest": {
+        "SignInRequest": {
             "type": "object",
             "required": [
                 "id_token"
@@ -781,7 +4402,7 @@ const docTemplate = `{
                 }
             }
         },
-        "GoogleSignInResponse": {
+        "SignInResponse": {
             "type": "object",
             "properties": {
                 "email": {
@@ -798,115 +4419,167 @@ const docTemplate = `{
                 }
             }
         },
-        "ListAdminUsersResponse": {
+        "SigningKey": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/AdminUser"
-                    }
+                "activatedAt": {
+                    "type": "string"
                 },
-                "page": {
-                    "type": "integer"
+                "kid": {
+                    "type": "string"
                 },
-                "perPage": {
-                    "type": "integer"
+                "retiredAt": {
+                    "type": "string"
                 },
-                "totalCount": {
-                    "type": "integer"
+                "status": {
+                    "type": "string",
+                    "enum": [
+                        "pending",
+                        "active",
+                        "retiring",
+                        "retired"
+                    ]
                 }
             }
         },
-        "ListUsersResponse": {
+        "UpdateAdminApprovalRuleRequest": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/User"
-                    }
+                "role": {
+                    "type": "string",
+                    "example": "system_admin"
+                }
+            }
+        },
+        "UpdateUserRequest": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string",
+                    "example": "alice"
                 },
-                "page": {
-                    "type": "integer"
+                "email": {
+                    "type": "string",
+                    "example": "alice@example.com"
                 },
-                "perPage": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "Alice"
+                }
+            }
+        },
+        "UpdateWorkspaceMemberRequest": {
+            "type": "object",
+            "properties": {
+                "role": {
+                    "type": "string",
+                    "enum": [
+                        "owner",
+                        "maintainer",
+                        "writer",
+                        "reader"
+                    ],
+                    "example": "owner"
+                }
+            }
+        },
+        "UpdateWorkspaceRequest": {
+            "type": "object",
+            "properties": {
+                "alias": {
+                    "type": "string",
+                    "example": "team"
                 },
-                "totalCount": {
-                    "type": "integer"
+                "name": {
+                    "type": "string",
+                    "example": "Team"
                 }
             }
         },
-        "ListWorkspacesResponse": {
+        "User": {
             "type": "object",
             "properties": {
-                "items": {
-                    "type": "array",
-                    "items": {
-                        "$ref": "#/definitions/Workspace"
-                    }
+                "alias": {
+                    "type": "string"
                 },
-                "page": {
-                    "type": "integer"
+                "email": {
+                    "type": "string"
                 },
-                "perPage": {
-                    "type": "integer"
+                "id": {
+                    "type": "string"
                 },
-                "totalCount": {
-                    "type": "integer"
+                "name": {
+                    "type": "string"
                 }
             }
         },
-        "MeResponse": {
+        "UserActionResponse": {
             "type": "object",
             "properties": {
-                "approvedAt": {
+                "auditLogId": {
                     "type": "string"
                 },
-                "approvedBy": {
+                "user": {
+                    "$ref": "#/definitions/UserDetail"
+                }
+            }
+        },
+        "UserDetail": {
+            "type": "object",
+            "properties": {
+                "alias": {
                     "type": "string"
                 },
-                "createdAt": {
-                    "type": "string"
+                "auths": {
+                    "description": "Auths are the subs the user signs in with.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "deactivated": {
+                    "type": "boolean"
                 },
                 "email": {
                     "type": "string"
                 },
-                "id": {
-                    "type": "string"
+                "emailVerified": {
+                    "type": "boolean"
                 },
-                "name": {
+                "host": {
                     "type": "string"
                 },
-                "pictureUrl": {
+                "id": {
                     "type": "string"
                 },
-                "status": {
-                    "type": "string"
+                "mfaEnabled": {
+                    "type": "boolean"
                 },
-                "updatedAt": {
+                "name": {
                     "type": "string"
                 }
             }
         },
-        "SetAdminUserRoleRequest": {
+        "UserListingRole": {
             "type": "object",
-            "required": [
-                "role"
-            ],
             "properties": {
                 "role": {
                     "type": "string"
+                },
+                "workspace": {
+                    "type": "string"
                 }
             }
         },
-        "User": {
+        "UserListingRow": {
             "type": "object",
             "properties": {
                 "alias": {
                     "type": "string"
                 },
+                "deactivated": {
+                    "type": "boolean"
+                },
                 "email": {
                     "type": "string"
                 },
@@ -915,69 +4588,146 @@ const docTemplate = `{
                 },
                 "name": {
                     "type": "string"
+                },
+                "workspaceCount": {
+                    "description": "WorkspaceCount is the number of team workspaces the user is a member of.",
+                    "type": "integer"
+                },
+                "workspaces": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UserListingRole"
+                    }
+                }
+            }
+        },
+        "UserRoles": {
+            "type": "object",
+            "properties": {
+                "roleIds": {
+                    "description": "RoleIDs are the roles the user holds globally.",
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "userId": {
+                    "type": "string"
+                },
+                "workspaceRoles": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/UserWorkspaceRole"
+                    }
                 }
             }
         },
-        "UserDetail": {
+        "UserWorkspace": {
             "type": "object",
             "properties": {
                 "alias": {
                     "type": "string"
                 },
-                "email": {
+                "id": {
                     "type": "string"
                 },
-                "host": {
+                "name": {
                     "type": "string"
                 },
-                "id": {
+                "personal": {
+                    "type": "boolean"
+                },
+                "role": {
+                    "type": "string"
+                }
+            }
+        },
+        "UserWorkspaceRole": {
+            "type": "object",
+            "properties": {
+                "roleId": {
                     "type": "string"
                 },
-                "name": {
+                "workspaceId": {
                     "type": "string"
                 }
             }
         },
-        "UserWorkspace": {
+        "Workspace": {
             "type": "object",
             "properties": {
                 "alias": {
                     "type": "string"
                 },
+                "deactivated": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
+                "memberCount": {
+                    "type": "integer"
+                },
                 "name": {
                     "type": "string"
                 },
                 "personal": {
                     "type": "boolean"
                 },
-                "role": {
+                "updatedAt": {
                     "type": "string"
                 }
             }
         },
-        "Workspace": {
+        "WorkspaceActionResponse": {
+            "type": "object",
+            "properties": {
+                "auditLogId": {
+                    "type": "string"
+                },
+                "workspace": {
+                    "$ref": "#/definitions/Workspace"
+                }
+            }
+        },
+        "WorkspaceListingRow": {
             "type": "object",
             "properties": {
                 "alias": {
                     "type": "string"
                 },
+                "deactivated": {
+                    "type": "boolean"
+                },
                 "id": {
                     "type": "string"
                 },
+                "integrationCount": {
+                    "type": "integer"
+                },
+                "maintainers": {
+                    "type": "integer"
+                },
                 "memberCount": {
                     "type": "integer"
                 },
                 "name": {
                     "type": "string"
                 },
+                "owners": {
+                    "type": "integer"
+                },
                 "personal": {
                     "type": "boolean"
                 },
+                "readers": {
+                    "type": "integer"
+                },
                 "updatedAt": {
                     "type": "string"
+                },
+                "writers": {
+                    "type": "integer"
                 }
             }
         },
@@ -20,7 +20,6 @@ import (
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/idx"
 	"github.com/reearth/reearthx/mailer"
-	"github.com/reearth/reearthx/rerror"
 	"github.com/samber/lo"
 	"github.com/stretchr/testify/assert"
 )
@@ -549,7 +548,11 @@ func TestRemoveMyAuth(t *testing.T) {
 	assert.Nil(t, err)
 	assert.Equal(t, &user.Auth{Provider: "reearth", Sub: "reearth|" + uId.String()}, u.Auths().GetByProvider("reearth"))
 
-	query := `mutation { removeMyAuth(input: {auth: "reearth"}){ me{ id name email metadata { lang theme } } }}`
+	// the last auth can't be removed, so link another one first
+	assert.NoError(t, u.LinkAuth(user.AuthFrom("google-oauth2|e2e"), user.AuthLink{}))
+	assert.NoError(t, r.User.Save(context.Background(), u))
+
+	query := `mutation { removeMyAuth(input: {auth: "reearth"}){ me{ id name email auths linkedAuths { provider } } }}`
 	request := GraphQLRequest{
 		Query: query,
 	}
@@ -557,15 +560,38 @@ func TestRemoveMyAuth(t *testing.T) {
 	if err != nil {
 		assert.NoError(t, err)
 	}
-	e.POST("/api/graphql").
+	o := e.POST("/api/graphql").
 		WithHeader("authorization", "Bearer test").
 		WithHeader("Content-Type", "application/json").
 		WithHeader("X-Reearth-Debug-User", uId.String()).
 		WithBytes(jsonData).Expect().Status(http.StatusOK).JSON().Object()
+	o.Value("data").Object().Value("removeMyAuth").Object().Value("me").Object().
+		Value("auths").Array().IsEqual([]string{"google-oauth2"})
 
 	u, err = r.User.FindByID(context.Background(), uId)
 	assert.Nil(t, err)
-	assert.Nil(t, u.Auths().Get("sub"))
+	assert.Nil(t, u.Auths().GetByProvider("reearth"))
+}
+
+func TestRemoveMyAuth_LastAuth(t *testing.T) {
+	e, r := StartServer(t, &app.Config{}, true, baseSeederUser)
+
+	query := `mutation { removeMyAuth(input: {auth: "reearth"}){ me{ id } }}`
+	request := GraphQLRequest{
+		Query: query,
+	}
+	jsonData, err := json.Marshal(request)
+	assert.NoError(t, err)
+	o := e.POST("/api/graphql").
+		WithHeader("authorization", "Bearer test").
+		WithHeader("Content-Type", "application/json").
+		WithHeader("X-Reearth-Debug-User", uId.String()).
+		WithBytes(jsonData).Expect().Status(http.StatusOK).JSON().Object()
+	o.Value("errors").Array().NotEmpty()
+
+	u, err := r.User.FindByID(context.Background(), uId)
+	assert.Nil(t, err)
+	assert.NotNil(t, u.Auths().GetByProvider("reearth"))
 }
 
 func TestDeleteMe(t *testing.T) {
@@ -589,78 +615,38 @@ func TestDeleteMe(t *testing.T) {
 		WithHeader("X-Reearth-Debug-User", uId.String()).
 		WithBytes(jsonData).Expect().Status(http.StatusOK).JSON().Object()
 
-	_, err = r.User.FindByID(context.Background(), uId)
-	assert.Equal(t, rerror.ErrNotFound, err)
-}
-
-func TestDeleteMe_LeavesSharedWorkspace(t *testing.T) {
-	ctx := context.Background()
-
-	sharedWID := id.NewWorkspaceID()
-
-	seeder := func(ctx context.Context, r *repo.Container) error {
-		if err := seedRoles(ctx, r); err != nil {
-			return err
-		}
-
-		auth := user.ReearthSub(uId.String())
-		u := user.New().ID(uId).Name("e2e").Email("e2e@e2e.com").Auths([]user.Auth{*auth}).Workspace(wId).MustBuild()
-		if err := r.User.Save(ctx, u); err != nil {
-			return err
-		}
-		u2 := user.New().ID(uId2).Name("e2e2").Email("e2e2@e2e.com").Workspace(wId2).MustBuild()
-		if err := r.User.Save(ctx, u2); err != nil {
-			return err
-		}
-
-		// Personal workspace for uId (will be deleted on DeleteMe)
-		personalWS := workspace.New().ID(wId).Name("e2e").Personal(true).
-			Members(map[idx.ID[id.User]]workspace.Member{
-				uId: {Role: role.RoleOwner, InvitedBy: uId},
-			}).MustBuild()
-		if err := r.Workspace.Save(ctx, personalWS); err != nil {
-			return err
-		}
-
-		// Shared workspace: uId2 is owner, uId is a writer
-		sharedWS := workspace.New().ID(sharedWID).Name("shared").
-			Members(map[idx.ID[id.User]]workspace.Member{
-				uId2: {Role: role.RoleOwner, InvitedBy: uId2},
-				uId:  {Role: role.RoleWriter, InvitedBy: uId2},
-			}).MustBuild()
-		if err := r.Workspace.Save(ctx, sharedWS); err != nil {
-			return err
-		}
-
-		return nil
-	}
-
-	e, r := StartServer(t, &app.Config{}, true, seeder)
-
-	query := fmt.Sprintf(`mutation { deleteMe(input: {userId: "%s"}){ userId }}`, uId)
-	request := GraphQLRequest{Query: query}
-	jsonData, err := json.Marshal(request)
+	// the user is deactivated and purged after the retention window
+	u, err = r.User.FindByID(context.Background(), uId)
 	assert.NoError(t, err)
+	assert.NotNil(t, u.DeletedAt())
+	assert.NotNil(t, u.DeletionRequestedAt())
 
-	e.POST("/api/graphql").
-		WithHeader("authorization", "Bearer test").
-		WithHeader("Content-Type", "application/json").
-		WithHeader("X-Reearth-Debug-User", uId.String()).
-		WithBytes(jsonData).Expect().Status(http.StatusOK).JSON().Object()
+	// the personal workspace is kept until then
+	_, err = r.Workspace.FindByID(context.Background(), wId)
+	assert.NoError(t, err)
+}
 
-	// User deleted
-	_, err = r.User.FindByID(ctx, uId)
-	assert.Equal(t, rerror.ErrNotFound, err)
+func TestCancelMyDeletion(t *testing.T) {
+	e, r := StartServer(t, &app.Config{}, true, baseSeederUser)
 
-	// Personal workspace deleted
-	_, err = r.Workspace.FindByID(ctx, wId)
-	assert.Equal(t, rerror.ErrNotFound, err)
+	for _, q := range []string{
+		fmt.Sprintf(`mutation { deleteMe(input: {userId: "%s"}){ userId }}`, uId),
+		`mutation { cancelMyDeletion { me { id deletionRequestedAt } } }`,
+	} {
+		jsonData, err := json.Marshal(GraphQLRequest{Query: q})
+		assert.NoError(t, err)
+		o := e.POST("/api/graphql").
+			WithHeader("authorization", "Bearer test").
+			WithHeader("Content-Type", "application/json").
+			WithHeader("X-Reearth-Debug-User", uId.String()).
+			WithBytes(jsonData).Expect().Status(http.StatusOK).JSON().Object()
+		o.NotContainsKey("errors")
+	}
 
-	// Shared workspace still exists, but uId is no longer a member
-	sharedWS, err := r.Workspace.FindByID(ctx, sharedWID)
+	u, err := r.User.FindByID(context.Background(), uId)
 	assert.NoError(t, err)
-	assert.False(t, sharedWS.Members().HasUser(uId), "deleted user should not remain in shared workspace")
-	assert.True(t, sharedWS.Members().HasUser(uId2), "other owner should still be in shared workspace")
+	assert.Nil(t, u.DeletedAt())
+	assert.Nil(t, u.DeletionRequestedAt())
 }
 
 func TestMe(t *testing.T) {
@@ -17,6 +17,15 @@ type AuthConfigData struct {
 	CIPAuthDomain *string
 	CIPProjectID  *string
 	CIPTenantID   *string
+	// OIDCProviders lists the generic OIDC providers users can sign in with.
+	OIDCProviders []OIDCProviderData
+}
+
+// OIDCProviderData is the public configuration of a generic OIDC provider.
+type OIDCProviderData struct {
+	Name     string
+	Issuer   string
+	ClientID string
 }
 
 // AuthConfigProvider is implemented by app.Config; it avoids an import cycle.
@@ -31,6 +40,12 @@ type AuthConfigProvider interface {
 	GetCIPTenantID() string
 }
 
+// OIDCProvidersConfigProvider is optionally implemented by AuthConfigProvider
+// when generic OIDC providers are configured.
+type OIDCProvidersConfigProvider interface {
+	GetOIDCProviders() []OIDCProviderData
+}
+
 // Auth0ConfigProvider is retained as an alias for backward compatibility.
 type Auth0ConfigProvider = AuthConfigProvider
 
@@ -71,6 +86,10 @@ func ExtractAuthConfigData(provider AuthConfigProvider) *AuthConfigData {
 		ac.CIPTenantID = lo.ToPtr(v)
 	}
 
+	if p, ok := provider.(OIDCProvidersConfigProvider); ok {
+		ac.OIDCProviders = p.GetOIDCProviders()
+	}
+
 	// AuthProvider: explicit config, defaulting to auth0.
 	// GetAuthProvider always returns a non-empty value ("auth0" by default), so
 	// "cip" must be opted into explicitly via REEARTH_ACCOUNTS_AUTH_PROVIDER.
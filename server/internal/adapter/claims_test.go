@@ -0,0 +1,18 @@
+package adapter
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenClaims(t *testing.T) {
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"groups":["a","b"]}`))
+	claims, err := TokenClaims("e30." + payload + ".sig")
+	assert.NoError(t, err)
+	assert.Equal(t, []any{"a", "b"}, claims["groups"])
+
+	_, err = TokenClaims("opaque-token")
+	assert.Error(t, err)
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/impersonation"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/appx"
@@ -20,6 +21,9 @@ const (
 	contextOperator ContextKey = "operator"
 	contextUsecases ContextKey = "usecases"
 	contextConfig   ContextKey = "config"
+	// contextImpersonation marks a request made by an admin viewing the
+	// service as the user in contextUser.
+	contextImpersonation ContextKey = "impersonation"
 )
 
 func AttachUser(ctx context.Context, u *user.User) context.Context {
@@ -30,6 +34,10 @@ func AttachOperator(ctx context.Context, o *workspace.Operator) context.Context
 	return context.WithValue(ctx, contextOperator, o)
 }
 
+func AttachImpersonation(ctx context.Context, g *impersonation.Grant) context.Context {
+	return context.WithValue(ctx, contextImpersonation, g)
+}
+
 func AttachUsecases(ctx context.Context, u *interfaces.Container) context.Context {
 	ctx = context.WithValue(ctx, contextUsecases, u)
 	return ctx
@@ -53,6 +61,16 @@ func Operator(ctx context.Context) *workspace.Operator {
 	return nil
 }
 
+// Impersonation returns the grant of an impersonated request, or nil.
+func Impersonation(ctx context.Context) *impersonation.Grant {
+	if v := ctx.Value(contextImpersonation); v != nil {
+		if g, ok := v.(*impersonation.Grant); ok {
+			return g
+		}
+	}
+	return nil
+}
+
 func GetAuthInfo(ctx context.Context) *appx.AuthInfo {
 	if v := ctx.Value(AuthInfoKey); v != nil {
 		if v2, ok := v.(appx.AuthInfo); ok {
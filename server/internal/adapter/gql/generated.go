@@ -62,6 +62,7 @@ type ComplexityRoot struct {
 		CipAuthDomain func(childComplexity int) int
 		CipProjectID  func(childComplexity int) int
 		CipTenantID   func(childComplexity int) int
+		OidcProviders func(childComplexity int) int
 	}
 
 	CheckPermissionPayload struct {
@@ -80,12 +81,21 @@ type ComplexityRoot struct {
 		WorkspaceID func(childComplexity int) int
 	}
 
+	LinkedAuth struct {
+		Email    func(childComplexity int) int
+		Issuer   func(childComplexity int) int
+		LinkedAt func(childComplexity int) int
+		Provider func(childComplexity int) int
+		Sub      func(childComplexity int) int
+	}
+
 	MFAEnrollResult struct {
 		EnrollmentURL func(childComplexity int) int
 	}
 
 	MFARecoveryCodeResult struct {
-		RecoveryCode func(childComplexity int) int
+		RecoveryCode  func(childComplexity int) int
+		RecoveryCodes func(childComplexity int) int
 	}
 
 	MFAStatus struct {
@@ -93,21 +103,26 @@ type ComplexityRoot struct {
 	}
 
 	Me struct {
-		Alias          func(childComplexity int) int
-		Auths          func(childComplexity int) int
-		Email          func(childComplexity int) int
-		Host           func(childComplexity int) int
-		ID             func(childComplexity int) int
-		LatestLogoutAt func(childComplexity int) int
-		Metadata       func(childComplexity int) int
-		MyWorkspace    func(childComplexity int) int
-		MyWorkspaceID  func(childComplexity int) int
-		Name           func(childComplexity int) int
+		Alias               func(childComplexity int) int
+		Auths               func(childComplexity int) int
+		DeletionRequestedAt func(childComplexity int) int
+		Email               func(childComplexity int) int
+		Host                func(childComplexity int) int
+		ID                  func(childComplexity int) int
+		LatestLogoutAt      func(childComplexity int) int
+		LinkedAuths         func(childComplexity int) int
+		Metadata            func(childComplexity int) int
+		MyWorkspace         func(childComplexity int) int
+		MyWorkspaceID       func(childComplexity int) int
+		Name                func(childComplexity int) int
+		Passkeys            func(childComplexity int) int
 	}
 
 	Mutation struct {
 		AddIntegrationToWorkspace        func(childComplexity int, input gqlmodel.AddIntegrationToWorkspaceInput) int
 		AddUsersToWorkspace              func(childComplexity int, input gqlmodel.AddUsersToWorkspaceInput) int
+		CancelMyDeletion                 func(childComplexity int) int
+		ConfirmMfa                       func(childComplexity int, input gqlmodel.ConfirmMFAInput) int
 		CreateVerification               func(childComplexity int, input gqlmodel.CreateVerificationInput) int
 		CreateWorkspace                  func(childComplexity int, input gqlmodel.CreateWorkspaceInput) int
 		DeleteMe                         func(childComplexity int, input gqlmodel.DeleteMeInput) int
@@ -115,6 +130,7 @@ type ComplexityRoot struct {
 		DisableMfa                       func(childComplexity int) int
 		EnableMfa                        func(childComplexity int) int
 		FindOrCreate                     func(childComplexity int, input gqlmodel.FindOrCreateInput) int
+		LinkMyAuth                       func(childComplexity int, input gqlmodel.LinkMyAuthInput) int
 		Logout                           func(childComplexity int) int
 		PasswordReset                    func(childComplexity int, input gqlmodel.PasswordResetInput) int
 		RegenerateMFARecoveryCode        func(childComplexity int) int
@@ -122,7 +138,9 @@ type ComplexityRoot struct {
 		RemoveIntegrationsFromWorkspace  func(childComplexity int, input gqlmodel.RemoveIntegrationsFromWorkspaceInput) int
 		RemoveMultipleUsersFromWorkspace func(childComplexity int, input gqlmodel.RemoveMultipleUsersFromWorkspaceInput) int
 		RemoveMyAuth                     func(childComplexity int, input gqlmodel.RemoveMyAuthInput) int
+		RemoveMyPasskey                  func(childComplexity int, input gqlmodel.RemoveMyPasskeyInput) int
 		RemoveUserFromWorkspace          func(childComplexity int, input gqlmodel.RemoveUserFromWorkspaceInput) int
+		RequestMyDataExport              func(childComplexity int) int
 		Signup                           func(childComplexity int, input gqlmodel.SignupInput) int
 		SignupOidc                       func(childComplexity int, input gqlmodel.SignupOIDCInput) int
 		StartPasswordReset               func(childComplexity int, input gqlmodel.StartPasswordResetInput) int
@@ -131,9 +149,26 @@ type ComplexityRoot struct {
 		UpdateMe                         func(childComplexity int, input gqlmodel.UpdateMeInput) int
 		UpdateUserOfWorkspace            func(childComplexity int, input gqlmodel.UpdateUserOfWorkspaceInput) int
 		UpdateWorkspace                  func(childComplexity int, input gqlmodel.UpdateWorkspaceInput) int
+		UploadMyPhoto                    func(childComplexity int, input gqlmodel.UploadMyPhotoInput) int
+		UploadWorkspacePhoto             func(childComplexity int, input gqlmodel.UploadWorkspacePhotoInput) int
 		VerifyUser                       func(childComplexity int, input gqlmodel.VerifyUserInput) int
 	}
 
+	OIDCProvider struct {
+		ClientID func(childComplexity int) int
+		Issuer   func(childComplexity int) int
+		Name     func(childComplexity int) int
+	}
+
+	Passkey struct {
+		BackupEligible func(childComplexity int) int
+		CreatedAt      func(childComplexity int) int
+		ID             func(childComplexity int) int
+		LastUsedAt     func(childComplexity int) int
+		Nickname       func(childComplexity int) int
+		Transports     func(childComplexity int) int
+	}
+
 	Query struct {
 		AuthConfig                   func(childComplexity int) int
 		CheckPermission              func(childComplexity int, input gqlmodel.CheckPermissionInput) int
@@ -257,23 +292,30 @@ type MeResolver interface {
 	MyWorkspace(ctx context.Context, obj *gqlmodel.Me) (*gqlmodel.Workspace, error)
 }
 type MutationResolver interface {
+	CancelMyDeletion(ctx context.Context) (*gqlmodel.UpdateMePayload, error)
+	ConfirmMfa(ctx context.Context, input gqlmodel.ConfirmMFAInput) (*gqlmodel.MFARecoveryCodeResult, error)
 	CreateVerification(ctx context.Context, input gqlmodel.CreateVerificationInput) (*bool, error)
 	DeleteMe(ctx context.Context, input gqlmodel.DeleteMeInput) (*gqlmodel.DeleteMePayload, error)
 	DisableMfa(ctx context.Context) (bool, error)
 	EnableMfa(ctx context.Context) (*gqlmodel.MFAEnrollResult, error)
 	FindOrCreate(ctx context.Context, input gqlmodel.FindOrCreateInput) (*gqlmodel.UserPayload, error)
+	LinkMyAuth(ctx context.Context, input gqlmodel.LinkMyAuthInput) (*gqlmodel.UpdateMePayload, error)
 	Logout(ctx context.Context) (*gqlmodel.Me, error)
 	PasswordReset(ctx context.Context, input gqlmodel.PasswordResetInput) (*bool, error)
 	RegenerateMFARecoveryCode(ctx context.Context) (*gqlmodel.MFARecoveryCodeResult, error)
 	RemoveMyAuth(ctx context.Context, input gqlmodel.RemoveMyAuthInput) (*gqlmodel.UpdateMePayload, error)
+	RemoveMyPasskey(ctx context.Context, input gqlmodel.RemoveMyPasskeyInput) (*gqlmodel.UpdateMePayload, error)
+	RequestMyDataExport(ctx context.Context) (bool, error)
 	Signup(ctx context.Context, input gqlmodel.SignupInput) (*gqlmodel.UserPayload, error)
 	SignupOidc(ctx context.Context, input gqlmodel.SignupOIDCInput) (*gqlmodel.UserPayload, error)
 	StartPasswordReset(ctx context.Context, input gqlmodel.StartPasswordResetInput) (*bool, error)
 	UpdateMe(ctx context.Context, input gqlmodel.UpdateMeInput) (*gqlmodel.UpdateMePayload, error)
+	UploadMyPhoto(ctx context.Context, input gqlmodel.UploadMyPhotoInput) (*gqlmodel.UpdateMePayload, error)
 	VerifyUser(ctx context.Context, input gqlmodel.VerifyUserInput) (*gqlmodel.UserPayload, error)
 	CreateWorkspace(ctx context.Context, input gqlmodel.CreateWorkspaceInput) (*gqlmodel.CreateWorkspacePayload, error)
 	DeleteWorkspace(ctx context.Context, input gqlmodel.DeleteWorkspaceInput) (*gqlmodel.DeleteWorkspacePayload, error)
 	UpdateWorkspace(ctx context.Context, input gqlmodel.UpdateWorkspaceInput) (*gqlmodel.UpdateWorkspacePayload, error)
+	UploadWorkspacePhoto(ctx context.Context, input gqlmodel.UploadWorkspacePhotoInput) (*gqlmodel.UpdateWorkspacePayload, error)
 	AddUsersToWorkspace(ctx context.Context, input gqlmodel.AddUsersToWorkspaceInput) (*gqlmodel.AddUsersToWorkspacePayload, error)
 	AddIntegrationToWorkspace(ctx context.Context, input gqlmodel.AddIntegrationToWorkspaceInput) (*gqlmodel.AddUsersToWorkspacePayload, error)
 	RemoveUserFromWorkspace(ctx context.Context, input gqlmodel.RemoveUserFromWorkspaceInput) (*gqlmodel.RemoveMemberFromWorkspacePayload, error)
@@ -383,6 +425,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.AuthConfig.CipTenantID(childComplexity), true
+	case "AuthConfig.oidcProviders":
+		if e.complexity.AuthConfig.OidcProviders == nil {
+			break
+		}
+
+		return e.complexity.AuthConfig.OidcProviders(childComplexity), true
 
 	case "CheckPermissionPayload.allowed":
 		if e.complexity.CheckPermissionPayload.Allowed == nil {
@@ -412,6 +460,37 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.DeleteWorkspacePayload.WorkspaceID(childComplexity), true
 
+	case "LinkedAuth.email":
+		if e.complexity.LinkedAuth.Email == nil {
+			break
+		}
+
+		return e.complexity.LinkedAuth.Email(childComplexity), true
+	case "LinkedAuth.issuer":
+		if e.complexity.LinkedAuth.Issuer == nil {
+			break
+		}
+
+		return e.complexity.LinkedAuth.Issuer(childComplexity), true
+	case "LinkedAuth.linkedAt":
+		if e.complexity.LinkedAuth.LinkedAt == nil {
+			break
+		}
+
+		return e.complexity.LinkedAuth.LinkedAt(childComplexity), true
+	case "LinkedAuth.provider":
+		if e.complexity.LinkedAuth.Provider == nil {
+			break
+		}
+
+		return e.complexity.LinkedAuth.Provider(childComplexity), true
+	case "LinkedAuth.sub":
+		if e.complexity.LinkedAuth.Sub == nil {
+			break
+		}
+
+		return e.complexity.LinkedAuth.Sub(childComplexity), true
+
 	case "MFAEnrollResult.enrollmentUrl":
 		if e.complexity.MFAEnrollResult.EnrollmentURL == nil {
 			break
@@ -426,6 +505,13 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.MFARecoveryCodeResult.RecoveryCode(childComplexity), true
 
+	case "MFARecoveryCodeResult.recoveryCodes":
+		if e.complexity.MFARecoveryCodeResult.RecoveryCodes == nil {
+			break
+		}
+
+		return e.complexity.MFARecoveryCodeResult.RecoveryCodes(childComplexity), true
+
 	case "MFAStatus.enrolled":
 		if e.complexity.MFAStatus.Enrolled == nil {
 			break
@@ -445,6 +531,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Me.Auths(childComplexity), true
+	case "Me.deletionRequestedAt":
+		if e.complexity.Me.DeletionRequestedAt == nil {
+			break
+		}
+
+		return e.complexity.Me.DeletionRequestedAt(childComplexity), true
 	case "Me.email":
 		if e.complexity.Me.Email == nil {
 			break
@@ -469,6 +561,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Me.LatestLogoutAt(childComplexity), true
+	case "Me.linkedAuths":
+		if e.complexity.Me.LinkedAuths == nil {
+			break
+		}
+
+		return e.complexity.Me.LinkedAuths(childComplexity), true
 	case "Me.metadata":
 		if e.complexity.Me.Metadata == nil {
 			break
@@ -493,6 +591,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Me.Name(childComplexity), true
+	case "Me.passkeys":
+		if e.complexity.Me.Passkeys == nil {
+			break
+		}
+
+		return e.complexity.Me.Passkeys(childComplexity), true
 
 	case "Mutation.addIntegrationToWorkspace":
 		if e.complexity.Mutation.AddIntegrationToWorkspace == nil {
@@ -516,6 +620,23 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.AddUsersToWorkspace(childComplexity, args["input"].(gqlmodel.AddUsersToWorkspaceInput)), true
+	case "Mutation.cancelMyDeletion":
+		if e.complexity.Mutation.CancelMyDeletion == nil {
+			break
+		}
+
+		return e.complexity.Mutation.CancelMyDeletion(childComplexity), true
+	case "Mutation.confirmMFA":
+		if e.complexity.Mutation.ConfirmMfa == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_confirmMFA_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ConfirmMfa(childComplexity, args["input"].(gqlmodel.ConfirmMFAInput)), true
 	case "Mutation.createVerification":
 		if e.complexity.Mutation.CreateVerification == nil {
 			break
@@ -583,6 +704,17 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.FindOrCreate(childComplexity, args["input"].(gqlmodel.FindOrCreateInput)), true
+	case "Mutation.linkMyAuth":
+		if e.complexity.Mutation.LinkMyAuth == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_linkMyAuth_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.LinkMyAuth(childComplexity, args["input"].(gqlmodel.LinkMyAuthInput)), true
 	case "Mutation.logout":
 		if e.complexity.Mutation.Logout == nil {
 			break
@@ -650,6 +782,17 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.RemoveMyAuth(childComplexity, args["input"].(gqlmodel.RemoveMyAuthInput)), true
+	case "Mutation.removeMyPasskey":
+		if e.complexity.Mutation.RemoveMyPasskey == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_removeMyPasskey_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RemoveMyPasskey(childComplexity, args["input"].(gqlmodel.RemoveMyPasskeyInput)), true
 	case "Mutation.removeUserFromWorkspace":
 		if e.complexity.Mutation.RemoveUserFromWorkspace == nil {
 			break
@@ -661,6 +804,12 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.RemoveUserFromWorkspace(childComplexity, args["input"].(gqlmodel.RemoveUserFromWorkspaceInput)), true
+	case "Mutation.requestMyDataExport":
+		if e.complexity.Mutation.RequestMyDataExport == nil {
+			break
+		}
+
+		return e.complexity.Mutation.RequestMyDataExport(childComplexity), true
 	case "Mutation.signup":
 		if e.complexity.Mutation.Signup == nil {
 			break
@@ -749,6 +898,28 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 		}
 
 		return e.complexity.Mutation.UpdateWorkspace(childComplexity, args["input"].(gqlmodel.UpdateWorkspaceInput)), true
+	case "Mutation.uploadMyPhoto":
+		if e.complexity.Mutation.UploadMyPhoto == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_uploadMyPhoto_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UploadMyPhoto(childComplexity, args["input"].(gqlmodel.UploadMyPhotoInput)), true
+	case "Mutation.uploadWorkspacePhoto":
+		if e.complexity.Mutation.UploadWorkspacePhoto == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_uploadWorkspacePhoto_args(ctx, rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.UploadWorkspacePhoto(childComplexity, args["input"].(gqlmodel.UploadWorkspacePhotoInput)), true
 	case "Mutation.verifyUser":
 		if e.complexity.Mutation.VerifyUser == nil {
 			break
@@ -761,6 +932,62 @@ func (e *executableSchema) Complexity(ctx context.Context, typeName, field strin
 
 		return e.complexity.Mutation.VerifyUser(childComplexity, args["input"].(gqlmodel.VerifyUserInput)), true
 
+	case "OIDCProvider.clientId":
+		if e.complexity.OIDCProvider.ClientID == nil {
+			break
+		}
+
+		return e.complexity.OIDCProvider.ClientID(childComplexity), true
+	case "OIDCProvider.issuer":
+		if e.complexity.OIDCProvider.Issuer == nil {
+			break
+		}
+
+		return e.complexity.OIDCProvider.Issuer(childComplexity), true
+	case "OIDCProvider.name":
+		if e.complexity.OIDCProvider.Name == nil {
+			break
+		}
+
+		return e.complexity.OIDCProvider.Name(childComplexity), true
+
+	case "Passkey.backupEligible":
+		if e.complexity.Passkey.BackupEligible == nil {
+			break
+		}
+
+		return e.complexity.Passkey.BackupEligible(childComplexity), true
+	case "Passkey.createdAt":
+		if e.complexity.Passkey.CreatedAt == nil {
+			break
+		}
+
+		return e.complexity.Passkey.CreatedAt(childComplexity), true
+	case "Passkey.id":
+		if e.complexity.Passkey.ID == nil {
+			break
+		}
+
+		return e.complexity.Passkey.ID(childComplexity), true
+	case "Passkey.lastUsedAt":
+		if e.complexity.Passkey.LastUsedAt == nil {
+			break
+		}
+
+		return e.complexity.Passkey.LastUsedAt(childComplexity), true
+	case "Passkey.nickname":
+		if e.complexity.Passkey.Nickname == nil {
+			break
+		}
+
+		return e.complexity.Passkey.Nickname(childComplexity), true
+	case "Passkey.transports":
+		if e.complexity.Passkey.Transports == nil {
+			break
+		}
+
+		return e.complexity.Passkey.Transports(childComplexity), true
+
 	case "Query.authConfig":
 		if e.complexity.Query.AuthConfig == nil {
 			break
@@ -1271,11 +1498,13 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 		ec.unmarshalInputAddIntegrationToWorkspaceInput,
 		ec.unmarshalInputAddUsersToWorkspaceInput,
 		ec.unmarshalInputCheckPermissionInput,
+		ec.unmarshalInputConfirmMFAInput,
 		ec.unmarshalInputCreateVerificationInput,
 		ec.unmarshalInputCreateWorkspaceInput,
 		ec.unmarshalInputDeleteMeInput,
 		ec.unmarshalInputDeleteWorkspaceInput,
 		ec.unmarshalInputFindOrCreateInput,
+		ec.unmarshalInputLinkMyAuthInput,
 		ec.unmarshalInputMemberInput,
 		ec.unmarshalInputPagination,
 		ec.unmarshalInputPasswordResetInput,
@@ -1283,6 +1512,7 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 		ec.unmarshalInputRemoveIntegrationsFromWorkspaceInput,
 		ec.unmarshalInputRemoveMultipleUsersFromWorkspaceInput,
 		ec.unmarshalInputRemoveMyAuthInput,
+		ec.unmarshalInputRemoveMyPasskeyInput,
 		ec.unmarshalInputRemoveUserFromWorkspaceInput,
 		ec.unmarshalInputSignupInput,
 		ec.unmarshalInputSignupOIDCInput,
@@ -1292,6 +1522,8 @@ func (e *executableSchema) Exec(ctx context.Context) graphql.ResponseHandler {
 		ec.unmarshalInputUpdateMeInput,
 		ec.unmarshalInputUpdateUserOfWorkspaceInput,
 		ec.unmarshalInputUpdateWorkspaceInput,
+		ec.unmarshalInputUploadMyPhotoInput,
+		ec.unmarshalInputUploadWorkspacePhotoInput,
 		ec.unmarshalInputVerifyUserInput,
 	)
 	first := true
@@ -1450,6 +1682,23 @@ type AuthConfig {
 
   """CIP GCIP tenant id (optional)"""
   cipTenantId: String
+
+  """Generic OIDC identity providers users can sign in with"""
+  oidcProviders: [OIDCProvider!]!
+}
+
+"""
+Public configuration of a generic OIDC identity provider (e.g. Keycloak or Azure AD).
+"""
+type OIDCProvider {
+  """Provider name, also the prefix of the subs of its users"""
+  name: String!
+
+  """Issuer URL"""
+  issuer: String!
+
+  """OAuth client ID of this service at the provider"""
+  clientId: String!
 }
 
 extend type Query {
@@ -1496,8 +1745,45 @@ type Me {
   host: String
   latestLogoutAt: DateTime
   myWorkspaceId: ID!
+  """
+  Providers of the identities the user can sign in with.
+  """
   auths: [String!]!
+  """
+  Identities the user can sign in with, including when and how each was linked.
+  """
+  linkedAuths: [LinkedAuth!]!
+  passkeys: [Passkey!]!
   myWorkspace: Workspace!
+  """
+  When the user asked to delete their account. Null unless the deletion is
+  pending, in which case it can still be cancelled with cancelMyDeletion.
+  """
+  deletionRequestedAt: DateTime
+}
+
+type LinkedAuth {
+  provider: String!
+  sub: String!
+  """
+  Issuer and email of the token that proved the identity. Null for the identity
+  the user signed up with.
+  """
+  issuer: String
+  email: String
+  linkedAt: DateTime
+}
+
+type Passkey {
+  """
+  Base64url-encoded WebAuthn credential ID.
+  """
+  id: String!
+  nickname: String!
+  transports: [String!]!
+  backupEligible: Boolean!
+  createdAt: DateTime!
+  lastUsedAt: DateTime
 }
 
 type UserMetadata {
@@ -1523,7 +1809,15 @@ type MFAStatus {
 }
 
 type MFARecoveryCodeResult {
+  """
+  The first of recoveryCodes, for clients that show a single code.
+  """
   recoveryCode: String!
+  """
+  Single-use codes that each stand in for a TOTP code once. Only their hashes
+  are kept, so they can't be shown again.
+  """
+  recoveryCodes: [String!]!
 }
 
 type UsersWithPagination {
@@ -1590,14 +1884,36 @@ input UpdateMeInput {
   website: String
 }
 
+input LinkMyAuthInput {
+  """
+  Token the provider of the identity to link issued within the last 10 minutes.
+  """
+  token: String!
+}
+
 input RemoveMyAuthInput {
   auth: String!
 }
 
+input RemoveMyPasskeyInput {
+  id: String!
+}
+
+input ConfirmMFAInput {
+  code: String!
+}
+
 input DeleteMeInput {
   userId: ID!
 }
 
+input UploadMyPhotoInput {
+  """
+  PNG, JPEG or GIF file of up to 5 MB.
+  """
+  file: Upload!
+}
+
 extend type Query {
   findUserByAlias(alias: String!): User
   findUsersByIDsWithPagination(ids: [ID!]!, alias: String, pagination: Pagination!): UsersWithPagination!
@@ -1623,19 +1939,42 @@ type DeleteMePayload {
 }
 
 extend type Mutation {
+  """
+  Reactivates the signed-in user who scheduled the deletion of their account.
+  """
+  cancelMyDeletion: UpdateMePayload
+  confirmMFA(input: ConfirmMFAInput!): MFARecoveryCodeResult!
   createVerification(input: CreateVerificationInput!): Boolean
+  """
+  Deactivates the signed-in user and schedules the permanent deletion of the
+  account and the workspaces only they own once the retention window has
+  passed. The user is mailed before the deletion and can cancel it until then.
+  """
   deleteMe(input: DeleteMeInput!): DeleteMePayload
   disableMFA: Boolean!
   enableMFA: MFAEnrollResult!
   findOrCreate(input: FindOrCreateInput!): UserPayload
+  linkMyAuth(input: LinkMyAuthInput!): UpdateMePayload
   logout: Me
   passwordReset(input: PasswordResetInput!): Boolean
   regenerateMFARecoveryCode: MFARecoveryCodeResult!
   removeMyAuth(input: RemoveMyAuthInput!): UpdateMePayload
+  removeMyPasskey(input: RemoveMyPasskeyInput!): UpdateMePayload
+  """
+  Mails the signed-in user a link to download an archive of their personal
+  data. The link expires in 24 hours.
+  """
+  requestMyDataExport: Boolean!
   signup(input: SignupInput!): UserPayload
   signupOIDC(input: SignupOIDCInput!): UserPayload
   startPasswordReset(input: StartPasswordResetInput!): Boolean
   updateMe(input: UpdateMeInput!): UpdateMePayload
+  """
+  Crops the photo to a square, resizes it to the standard thumbnails and sets
+  it as the photo of the signed-in user and their personal workspace. The
+  previous photo is deleted.
+  """
+  uploadMyPhoto(input: UploadMyPhotoInput!): UpdateMePayload
   verifyUser(input: VerifyUserInput!): UserPayload
 }
 `, BuiltIn: false},
@@ -1709,6 +2048,14 @@ input UpdateWorkspaceInput {
     photoURL: String
 }
 
+input UploadWorkspacePhotoInput {
+    workspaceId: ID!
+    """
+    PNG, JPEG or GIF file of up to 5 MB.
+    """
+    file: Upload!
+}
+
 input MemberInput {
     userId: ID!
     role: Role!
@@ -1814,6 +2161,11 @@ extend type Mutation {
     createWorkspace(input: CreateWorkspaceInput!): CreateWorkspacePayload
     deleteWorkspace(input: DeleteWorkspaceInput!): DeleteWorkspacePayload
     updateWorkspace(input: UpdateWorkspaceInput!): UpdateWorkspacePayload
+    """
+    Crops the photo to a square, resizes it to the standard thumbnails and sets
+    it as the photo of the workspace. The previous photo is deleted.
+    """
+    uploadWorkspacePhoto(input: UploadWorkspacePhotoInput!): UpdateWorkspacePayload
     addUsersToWorkspace(input: AddUsersToWorkspaceInput!): AddUsersToWorkspacePayload
     addIntegrationToWorkspace(input: AddIntegrationToWorkspaceInput!): AddUsersToWorkspacePayload
     removeUserFromWorkspace(input: RemoveUserFromWorkspaceInput!): RemoveMemberFromWorkspacePayload
@@ -1853,6 +2205,17 @@ func (ec *executionContext) field_Mutation_addUsersToWorkspace_args(ctx context.
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_confirmMFA_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNConfirmMFAInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐConfirmMFAInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_createVerification_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -1908,6 +2271,17 @@ func (ec *executionContext) field_Mutation_findOrCreate_args(ctx context.Context
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_linkMyAuth_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNLinkMyAuthInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐLinkMyAuthInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_passwordReset_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -1963,6 +2337,17 @@ func (ec *executionContext) field_Mutation_removeMyAuth_args(ctx context.Context
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_removeMyPasskey_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNRemoveMyPasskeyInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐRemoveMyPasskeyInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_removeUserFromWorkspace_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -2062,6 +2447,28 @@ func (ec *executionContext) field_Mutation_updateWorkspace_args(ctx context.Cont
 	return args, nil
 }
 
+func (ec *executionContext) field_Mutation_uploadMyPhoto_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNUploadMyPhotoInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUploadMyPhotoInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Mutation_uploadWorkspacePhoto_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
+	var err error
+	args := map[string]any{}
+	arg0, err := graphql.ProcessArgField(ctx, rawArgs, "input", ec.unmarshalNUploadWorkspacePhotoInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUploadWorkspacePhotoInput)
+	if err != nil {
+		return nil, err
+	}
+	args["input"] = arg0
+	return args, nil
+}
+
 func (ec *executionContext) field_Mutation_verifyUser_args(ctx context.Context, rawArgs map[string]any) (map[string]any, error) {
 	var err error
 	args := map[string]any{}
@@ -2612,6 +3019,43 @@ func (ec *executionContext) fieldContext_AuthConfig_cipTenantId(_ context.Contex
 	return fc, nil
 }
 
+func (ec *executionContext) _AuthConfig_oidcProviders(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.AuthConfig) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_AuthConfig_oidcProviders,
+		func(ctx context.Context) (any, error) {
+			return obj.OidcProviders, nil
+		},
+		nil,
+		ec.marshalNOIDCProvider2ᚕᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐOIDCProviderᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_AuthConfig_oidcProviders(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuthConfig",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_OIDCProvider_name(ctx, field)
+			case "issuer":
+				return ec.fieldContext_OIDCProvider_issuer(ctx, field)
+			case "clientId":
+				return ec.fieldContext_OIDCProvider_clientId(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OIDCProvider", field.Name)
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _CheckPermissionPayload_allowed(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.CheckPermissionPayload) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -2742,14 +3186,14 @@ func (ec *executionContext) fieldContext_DeleteWorkspacePayload_workspaceId(_ co
 	return fc, nil
 }
 
-func (ec *executionContext) _MFAEnrollResult_enrollmentUrl(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MFAEnrollResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _LinkedAuth_provider(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.LinkedAuth) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_MFAEnrollResult_enrollmentUrl,
+		ec.fieldContext_LinkedAuth_provider,
 		func(ctx context.Context) (any, error) {
-			return obj.EnrollmentURL, nil
+			return obj.Provider, nil
 		},
 		nil,
 		ec.marshalNString2string,
@@ -2758,9 +3202,9 @@ func (ec *executionContext) _MFAEnrollResult_enrollmentUrl(ctx context.Context,
 	)
 }
 
-func (ec *executionContext) fieldContext_MFAEnrollResult_enrollmentUrl(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_LinkedAuth_provider(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MFAEnrollResult",
+		Object:     "LinkedAuth",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2771,14 +3215,14 @@ func (ec *executionContext) fieldContext_MFAEnrollResult_enrollmentUrl(_ context
 	return fc, nil
 }
 
-func (ec *executionContext) _MFARecoveryCodeResult_recoveryCode(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MFARecoveryCodeResult) (ret graphql.Marshaler) {
+func (ec *executionContext) _LinkedAuth_sub(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.LinkedAuth) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_MFARecoveryCodeResult_recoveryCode,
+		ec.fieldContext_LinkedAuth_sub,
 		func(ctx context.Context) (any, error) {
-			return obj.RecoveryCode, nil
+			return obj.Sub, nil
 		},
 		nil,
 		ec.marshalNString2string,
@@ -2787,9 +3231,9 @@ func (ec *executionContext) _MFARecoveryCodeResult_recoveryCode(ctx context.Cont
 	)
 }
 
-func (ec *executionContext) fieldContext_MFARecoveryCodeResult_recoveryCode(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_LinkedAuth_sub(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MFARecoveryCodeResult",
+		Object:     "LinkedAuth",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -2800,30 +3244,204 @@ func (ec *executionContext) fieldContext_MFARecoveryCodeResult_recoveryCode(_ co
 	return fc, nil
 }
 
-func (ec *executionContext) _MFAStatus_enrolled(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MFAStatus) (ret graphql.Marshaler) {
+func (ec *executionContext) _LinkedAuth_issuer(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.LinkedAuth) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_MFAStatus_enrolled,
+		ec.fieldContext_LinkedAuth_issuer,
 		func(ctx context.Context) (any, error) {
-			return obj.Enrolled, nil
+			return obj.Issuer, nil
 		},
 		nil,
-		ec.marshalNBoolean2bool,
-		true,
+		ec.marshalOString2ᚖstring,
 		true,
+		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_MFAStatus_enrolled(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_LinkedAuth_issuer(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MFAStatus",
+		Object:     "LinkedAuth",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _LinkedAuth_email(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.LinkedAuth) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_LinkedAuth_email,
+		func(ctx context.Context) (any, error) {
+			return obj.Email, nil
+		},
+		nil,
+		ec.marshalOString2ᚖstring,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_LinkedAuth_email(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "LinkedAuth",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _LinkedAuth_linkedAt(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.LinkedAuth) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_LinkedAuth_linkedAt,
+		func(ctx context.Context) (any, error) {
+			return obj.LinkedAt, nil
+		},
+		nil,
+		ec.marshalODateTime2ᚖtimeᚐTime,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_LinkedAuth_linkedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "LinkedAuth",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type DateTime does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MFAEnrollResult_enrollmentUrl(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MFAEnrollResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_MFAEnrollResult_enrollmentUrl,
+		func(ctx context.Context) (any, error) {
+			return obj.EnrollmentURL, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_MFAEnrollResult_enrollmentUrl(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MFAEnrollResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MFARecoveryCodeResult_recoveryCode(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MFARecoveryCodeResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_MFARecoveryCodeResult_recoveryCode,
+		func(ctx context.Context) (any, error) {
+			return obj.RecoveryCode, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_MFARecoveryCodeResult_recoveryCode(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MFARecoveryCodeResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MFARecoveryCodeResult_recoveryCodes(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MFARecoveryCodeResult) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_MFARecoveryCodeResult_recoveryCodes,
+		func(ctx context.Context) (any, error) {
+			return obj.RecoveryCodes, nil
+		},
+		nil,
+		ec.marshalNString2ᚕstringᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_MFARecoveryCodeResult_recoveryCodes(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MFARecoveryCodeResult",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _MFAStatus_enrolled(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.MFAStatus) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_MFAStatus_enrolled,
+		func(ctx context.Context) (any, error) {
+			return obj.Enrolled, nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_MFAStatus_enrolled(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "MFAStatus",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
@@ -3102,6 +3720,90 @@ func (ec *executionContext) fieldContext_Me_auths(_ context.Context, field graph
 	return fc, nil
 }
 
+func (ec *executionContext) _Me_linkedAuths(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Me) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Me_linkedAuths,
+		func(ctx context.Context) (any, error) {
+			return obj.LinkedAuths, nil
+		},
+		nil,
+		ec.marshalNLinkedAuth2ᚕᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐLinkedAuthᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Me_linkedAuths(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Me",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "provider":
+				return ec.fieldContext_LinkedAuth_provider(ctx, field)
+			case "sub":
+				return ec.fieldContext_LinkedAuth_sub(ctx, field)
+			case "issuer":
+				return ec.fieldContext_LinkedAuth_issuer(ctx, field)
+			case "email":
+				return ec.fieldContext_LinkedAuth_email(ctx, field)
+			case "linkedAt":
+				return ec.fieldContext_LinkedAuth_linkedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type LinkedAuth", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Me_passkeys(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Me) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Me_passkeys,
+		func(ctx context.Context) (any, error) {
+			return obj.Passkeys, nil
+		},
+		nil,
+		ec.marshalNPasskey2ᚕᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐPasskeyᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Me_passkeys(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Me",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Passkey_id(ctx, field)
+			case "nickname":
+				return ec.fieldContext_Passkey_nickname(ctx, field)
+			case "transports":
+				return ec.fieldContext_Passkey_transports(ctx, field)
+			case "backupEligible":
+				return ec.fieldContext_Passkey_backupEligible(ctx, field)
+			case "createdAt":
+				return ec.fieldContext_Passkey_createdAt(ctx, field)
+			case "lastUsedAt":
+				return ec.fieldContext_Passkey_lastUsedAt(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Passkey", field.Name)
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Me_myWorkspace(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Me) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -3145,6 +3847,115 @@ func (ec *executionContext) fieldContext_Me_myWorkspace(_ context.Context, field
 	return fc, nil
 }
 
+func (ec *executionContext) _Me_deletionRequestedAt(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Me) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Me_deletionRequestedAt,
+		func(ctx context.Context) (any, error) {
+			return obj.DeletionRequestedAt, nil
+		},
+		nil,
+		ec.marshalODateTime2ᚖtimeᚐTime,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Me_deletionRequestedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Me",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type DateTime does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_cancelMyDeletion(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_cancelMyDeletion,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Mutation().CancelMyDeletion(ctx)
+		},
+		nil,
+		ec.marshalOUpdateMePayload2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUpdateMePayload,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_cancelMyDeletion(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "me":
+				return ec.fieldContext_UpdateMePayload_me(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UpdateMePayload", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_confirmMFA(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_confirmMFA,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().ConfirmMfa(ctx, fc.Args["input"].(gqlmodel.ConfirmMFAInput))
+		},
+		nil,
+		ec.marshalNMFARecoveryCodeResult2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐMFARecoveryCodeResult,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_confirmMFA(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "recoveryCode":
+				return ec.fieldContext_MFARecoveryCodeResult_recoveryCode(ctx, field)
+			case "recoveryCodes":
+				return ec.fieldContext_MFARecoveryCodeResult_recoveryCodes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MFARecoveryCodeResult", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_confirmMFA_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Mutation_createVerification(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -3338,6 +4149,51 @@ func (ec *executionContext) fieldContext_Mutation_findOrCreate(ctx context.Conte
 	return fc, nil
 }
 
+func (ec *executionContext) _Mutation_linkMyAuth(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_linkMyAuth,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().LinkMyAuth(ctx, fc.Args["input"].(gqlmodel.LinkMyAuthInput))
+		},
+		nil,
+		ec.marshalOUpdateMePayload2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUpdateMePayload,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_linkMyAuth(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "me":
+				return ec.fieldContext_UpdateMePayload_me(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UpdateMePayload", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_linkMyAuth_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Mutation_logout(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -3380,8 +4236,14 @@ func (ec *executionContext) fieldContext_Mutation_logout(_ context.Context, fiel
 				return ec.fieldContext_Me_myWorkspaceId(ctx, field)
 			case "auths":
 				return ec.fieldContext_Me_auths(ctx, field)
+			case "linkedAuths":
+				return ec.fieldContext_Me_linkedAuths(ctx, field)
+			case "passkeys":
+				return ec.fieldContext_Me_passkeys(ctx, field)
 			case "myWorkspace":
 				return ec.fieldContext_Me_myWorkspace(ctx, field)
+			case "deletionRequestedAt":
+				return ec.fieldContext_Me_deletionRequestedAt(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Me", field.Name)
 		},
@@ -3456,6 +4318,8 @@ func (ec *executionContext) fieldContext_Mutation_regenerateMFARecoveryCode(_ co
 			switch field.Name {
 			case "recoveryCode":
 				return ec.fieldContext_MFARecoveryCodeResult_recoveryCode(ctx, field)
+			case "recoveryCodes":
+				return ec.fieldContext_MFARecoveryCodeResult_recoveryCodes(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type MFARecoveryCodeResult", field.Name)
 		},
@@ -3508,6 +4372,80 @@ func (ec *executionContext) fieldContext_Mutation_removeMyAuth(ctx context.Conte
 	return fc, nil
 }
 
+func (ec *executionContext) _Mutation_removeMyPasskey(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_removeMyPasskey,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().RemoveMyPasskey(ctx, fc.Args["input"].(gqlmodel.RemoveMyPasskeyInput))
+		},
+		nil,
+		ec.marshalOUpdateMePayload2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUpdateMePayload,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_removeMyPasskey(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "me":
+				return ec.fieldContext_UpdateMePayload_me(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UpdateMePayload", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_removeMyPasskey_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_requestMyDataExport(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_requestMyDataExport,
+		func(ctx context.Context) (any, error) {
+			return ec.resolvers.Mutation().RequestMyDataExport(ctx)
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_requestMyDataExport(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Mutation_signup(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -3684,6 +4622,51 @@ func (ec *executionContext) fieldContext_Mutation_updateMe(ctx context.Context,
 	return fc, nil
 }
 
+func (ec *executionContext) _Mutation_uploadMyPhoto(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_uploadMyPhoto,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().UploadMyPhoto(ctx, fc.Args["input"].(gqlmodel.UploadMyPhotoInput))
+		},
+		nil,
+		ec.marshalOUpdateMePayload2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUpdateMePayload,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_uploadMyPhoto(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "me":
+				return ec.fieldContext_UpdateMePayload_me(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UpdateMePayload", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_uploadMyPhoto_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
 func (ec *executionContext) _Mutation_verifyUser(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
@@ -3827,7 +4810,52 @@ func (ec *executionContext) _Mutation_updateWorkspace(ctx context.Context, field
 		ec.fieldContext_Mutation_updateWorkspace,
 		func(ctx context.Context) (any, error) {
 			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().UpdateWorkspace(ctx, fc.Args["input"].(gqlmodel.UpdateWorkspaceInput))
+			return ec.resolvers.Mutation().UpdateWorkspace(ctx, fc.Args["input"].(gqlmodel.UpdateWorkspaceInput))
+		},
+		nil,
+		ec.marshalOUpdateWorkspacePayload2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUpdateWorkspacePayload,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_updateWorkspace(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "workspace":
+				return ec.fieldContext_UpdateWorkspacePayload_workspace(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UpdateWorkspacePayload", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_updateWorkspace_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_uploadWorkspacePhoto(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_uploadWorkspacePhoto,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().UploadWorkspacePhoto(ctx, fc.Args["input"].(gqlmodel.UploadWorkspacePhotoInput))
 		},
 		nil,
 		ec.marshalOUpdateWorkspacePayload2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUpdateWorkspacePayload,
@@ -3836,7 +4864,7 @@ func (ec *executionContext) _Mutation_updateWorkspace(ctx context.Context, field
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_updateWorkspace(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_uploadWorkspacePhoto(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Mutation",
 		Field:      field,
@@ -3857,7 +4885,7 @@ func (ec *executionContext) fieldContext_Mutation_updateWorkspace(ctx context.Co
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_updateWorkspace_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Mutation_uploadWorkspacePhoto_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return fc, err
 	}
@@ -4203,69 +5231,330 @@ func (ec *executionContext) fieldContext_Mutation_updateIntegrationOfWorkspace(c
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "workspace":
-				return ec.fieldContext_UpdateMemberOfWorkspacePayload_workspace(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type UpdateMemberOfWorkspacePayload", field.Name)
+			switch field.Name {
+			case "workspace":
+				return ec.fieldContext_UpdateMemberOfWorkspacePayload_workspace(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UpdateMemberOfWorkspacePayload", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_updateIntegrationOfWorkspace_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Mutation_transferWorkspaceOwnership(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Mutation_transferWorkspaceOwnership,
+		func(ctx context.Context) (any, error) {
+			fc := graphql.GetFieldContext(ctx)
+			return ec.resolvers.Mutation().TransferWorkspaceOwnership(ctx, fc.Args["input"].(gqlmodel.TransferWorkspaceOwnershipInput))
+		},
+		nil,
+		ec.marshalOUpdateMemberOfWorkspacePayload2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUpdateMemberOfWorkspacePayload,
+		true,
+		false,
+	)
+}
+
+func (ec *executionContext) fieldContext_Mutation_transferWorkspaceOwnership(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Mutation",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "workspace":
+				return ec.fieldContext_UpdateMemberOfWorkspacePayload_workspace(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type UpdateMemberOfWorkspacePayload", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_transferWorkspaceOwnership_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return fc, err
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OIDCProvider_name(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.OIDCProvider) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OIDCProvider_name,
+		func(ctx context.Context) (any, error) {
+			return obj.Name, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OIDCProvider_name(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OIDCProvider",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OIDCProvider_issuer(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.OIDCProvider) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OIDCProvider_issuer,
+		func(ctx context.Context) (any, error) {
+			return obj.Issuer, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OIDCProvider_issuer(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OIDCProvider",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _OIDCProvider_clientId(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.OIDCProvider) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_OIDCProvider_clientId,
+		func(ctx context.Context) (any, error) {
+			return obj.ClientID, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_OIDCProvider_clientId(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "OIDCProvider",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Passkey_id(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Passkey) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Passkey_id,
+		func(ctx context.Context) (any, error) {
+			return obj.ID, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Passkey_id(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Passkey",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Passkey_nickname(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Passkey) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Passkey_nickname,
+		func(ctx context.Context) (any, error) {
+			return obj.Nickname, nil
+		},
+		nil,
+		ec.marshalNString2string,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Passkey_nickname(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Passkey",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Passkey_transports(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Passkey) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Passkey_transports,
+		func(ctx context.Context) (any, error) {
+			return obj.Transports, nil
+		},
+		nil,
+		ec.marshalNString2ᚕstringᚄ,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Passkey_transports(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Passkey",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Passkey_backupEligible(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Passkey) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Passkey_backupEligible,
+		func(ctx context.Context) (any, error) {
+			return obj.BackupEligible, nil
+		},
+		nil,
+		ec.marshalNBoolean2bool,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Passkey_backupEligible(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Passkey",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Boolean does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Passkey_createdAt(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Passkey) (ret graphql.Marshaler) {
+	return graphql.ResolveField(
+		ctx,
+		ec.OperationContext,
+		field,
+		ec.fieldContext_Passkey_createdAt,
+		func(ctx context.Context) (any, error) {
+			return obj.CreatedAt, nil
+		},
+		nil,
+		ec.marshalNDateTime2timeᚐTime,
+		true,
+		true,
+	)
+}
+
+func (ec *executionContext) fieldContext_Passkey_createdAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Passkey",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type DateTime does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_updateIntegrationOfWorkspace_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_transferWorkspaceOwnership(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+func (ec *executionContext) _Passkey_lastUsedAt(ctx context.Context, field graphql.CollectedField, obj *gqlmodel.Passkey) (ret graphql.Marshaler) {
 	return graphql.ResolveField(
 		ctx,
 		ec.OperationContext,
 		field,
-		ec.fieldContext_Mutation_transferWorkspaceOwnership,
+		ec.fieldContext_Passkey_lastUsedAt,
 		func(ctx context.Context) (any, error) {
-			fc := graphql.GetFieldContext(ctx)
-			return ec.resolvers.Mutation().TransferWorkspaceOwnership(ctx, fc.Args["input"].(gqlmodel.TransferWorkspaceOwnershipInput))
+			return obj.LastUsedAt, nil
 		},
 		nil,
-		ec.marshalOUpdateMemberOfWorkspacePayload2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUpdateMemberOfWorkspacePayload,
+		ec.marshalODateTime2ᚖtimeᚐTime,
 		true,
 		false,
 	)
 }
 
-func (ec *executionContext) fieldContext_Mutation_transferWorkspaceOwnership(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Passkey_lastUsedAt(_ context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "Passkey",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "workspace":
-				return ec.fieldContext_UpdateMemberOfWorkspacePayload_workspace(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type UpdateMemberOfWorkspacePayload", field.Name)
+			return nil, errors.New("field of type DateTime does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_transferWorkspaceOwnership_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return fc, err
-	}
 	return fc, nil
 }
 
@@ -4391,6 +5680,8 @@ func (ec *executionContext) fieldContext_Query_authConfig(_ context.Context, fie
 				return ec.fieldContext_AuthConfig_cipProjectId(ctx, field)
 			case "cipTenantId":
 				return ec.fieldContext_AuthConfig_cipTenantId(ctx, field)
+			case "oidcProviders":
+				return ec.fieldContext_AuthConfig_oidcProviders(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type AuthConfig", field.Name)
 		},
@@ -4654,8 +5945,14 @@ func (ec *executionContext) fieldContext_Query_me(_ context.Context, field graph
 				return ec.fieldContext_Me_myWorkspaceId(ctx, field)
 			case "auths":
 				return ec.fieldContext_Me_auths(ctx, field)
+			case "linkedAuths":
+				return ec.fieldContext_Me_linkedAuths(ctx, field)
+			case "passkeys":
+				return ec.fieldContext_Me_passkeys(ctx, field)
 			case "myWorkspace":
 				return ec.fieldContext_Me_myWorkspace(ctx, field)
+			case "deletionRequestedAt":
+				return ec.fieldContext_Me_deletionRequestedAt(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Me", field.Name)
 		},
@@ -5541,8 +6838,14 @@ func (ec *executionContext) fieldContext_UpdateMePayload_me(_ context.Context, f
 				return ec.fieldContext_Me_myWorkspaceId(ctx, field)
 			case "auths":
 				return ec.fieldContext_Me_auths(ctx, field)
+			case "linkedAuths":
+				return ec.fieldContext_Me_linkedAuths(ctx, field)
+			case "passkeys":
+				return ec.fieldContext_Me_passkeys(ctx, field)
 			case "myWorkspace":
 				return ec.fieldContext_Me_myWorkspace(ctx, field)
+			case "deletionRequestedAt":
+				return ec.fieldContext_Me_deletionRequestedAt(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Me", field.Name)
 		},
@@ -8549,6 +9852,33 @@ func (ec *executionContext) unmarshalInputCheckPermissionInput(ctx context.Conte
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputConfirmMFAInput(ctx context.Context, obj any) (gqlmodel.ConfirmMFAInput, error) {
+	var it gqlmodel.ConfirmMFAInput
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"code"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "code":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("code"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Code = data
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputCreateVerificationInput(ctx context.Context, obj any) (gqlmodel.CreateVerificationInput, error) {
 	var it gqlmodel.CreateVerificationInput
 	asMap := map[string]any{}
@@ -8712,6 +10042,33 @@ func (ec *executionContext) unmarshalInputFindOrCreateInput(ctx context.Context,
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputLinkMyAuthInput(ctx context.Context, obj any) (gqlmodel.LinkMyAuthInput, error) {
+	var it gqlmodel.LinkMyAuthInput
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"token"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "token":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("token"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.Token = data
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputMemberInput(ctx context.Context, obj any) (gqlmodel.MemberInput, error) {
 	var it gqlmodel.MemberInput
 	asMap := map[string]any{}
@@ -8943,6 +10300,33 @@ func (ec *executionContext) unmarshalInputRemoveMyAuthInput(ctx context.Context,
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputRemoveMyPasskeyInput(ctx context.Context, obj any) (gqlmodel.RemoveMyPasskeyInput, error) {
+	var it gqlmodel.RemoveMyPasskeyInput
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"id"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "id":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+			data, err := ec.unmarshalNString2string(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.ID = data
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputRemoveUserFromWorkspaceInput(ctx context.Context, obj any) (gqlmodel.RemoveUserFromWorkspaceInput, error) {
 	var it gqlmodel.RemoveUserFromWorkspaceInput
 	asMap := map[string]any{}
@@ -9424,6 +10808,67 @@ func (ec *executionContext) unmarshalInputUpdateWorkspaceInput(ctx context.Conte
 	return it, nil
 }
 
+func (ec *executionContext) unmarshalInputUploadMyPhotoInput(ctx context.Context, obj any) (gqlmodel.UploadMyPhotoInput, error) {
+	var it gqlmodel.UploadMyPhotoInput
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"file"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "file":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("file"))
+			data, err := ec.unmarshalNUpload2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚐUpload(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.File = data
+		}
+	}
+
+	return it, nil
+}
+
+func (ec *executionContext) unmarshalInputUploadWorkspacePhotoInput(ctx context.Context, obj any) (gqlmodel.UploadWorkspacePhotoInput, error) {
+	var it gqlmodel.UploadWorkspacePhotoInput
+	asMap := map[string]any{}
+	for k, v := range obj.(map[string]any) {
+		asMap[k] = v
+	}
+
+	fieldsInOrder := [...]string{"workspaceId", "file"}
+	for _, k := range fieldsInOrder {
+		v, ok := asMap[k]
+		if !ok {
+			continue
+		}
+		switch k {
+		case "workspaceId":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("workspaceId"))
+			data, err := ec.unmarshalNID2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐID(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.WorkspaceID = data
+		case "file":
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("file"))
+			data, err := ec.unmarshalNUpload2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚐUpload(ctx, v)
+			if err != nil {
+				return it, err
+			}
+			it.File = data
+		}
+	}
+
+	return it, nil
+}
+
 func (ec *executionContext) unmarshalInputVerifyUserInput(ctx context.Context, obj any) (gqlmodel.VerifyUserInput, error) {
 	var it gqlmodel.VerifyUserInput
 	asMap := map[string]any{}
@@ -9571,6 +11016,11 @@ func (ec *executionContext) _AuthConfig(ctx context.Context, sel ast.SelectionSe
 			out.Values[i] = ec._AuthConfig_cipProjectId(ctx, field, obj)
 		case "cipTenantId":
 			out.Values[i] = ec._AuthConfig_cipTenantId(ctx, field, obj)
+		case "oidcProviders":
+			out.Values[i] = ec._AuthConfig_oidcProviders(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -9750,6 +11200,56 @@ func (ec *executionContext) _DeleteWorkspacePayload(ctx context.Context, sel ast
 	return out
 }
 
+var linkedAuthImplementors = []string{"LinkedAuth"}
+
+func (ec *executionContext) _LinkedAuth(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.LinkedAuth) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, linkedAuthImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("LinkedAuth")
+		case "provider":
+			out.Values[i] = ec._LinkedAuth_provider(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "sub":
+			out.Values[i] = ec._LinkedAuth_sub(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "issuer":
+			out.Values[i] = ec._LinkedAuth_issuer(ctx, field, obj)
+		case "email":
+			out.Values[i] = ec._LinkedAuth_email(ctx, field, obj)
+		case "linkedAt":
+			out.Values[i] = ec._LinkedAuth_linkedAt(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var mFAEnrollResultImplementors = []string{"MFAEnrollResult"}
 
 func (ec *executionContext) _MFAEnrollResult(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.MFAEnrollResult) graphql.Marshaler {
@@ -9805,6 +11305,11 @@ func (ec *executionContext) _MFARecoveryCodeResult(ctx context.Context, sel ast.
 			if out.Values[i] == graphql.Null {
 				out.Invalids++
 			}
+		case "recoveryCodes":
+			out.Values[i] = ec._MFARecoveryCodeResult_recoveryCodes(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -9917,6 +11422,16 @@ func (ec *executionContext) _Me(ctx context.Context, sel ast.SelectionSet, obj *
 			if out.Values[i] == graphql.Null {
 				atomic.AddUint32(&out.Invalids, 1)
 			}
+		case "linkedAuths":
+			out.Values[i] = ec._Me_linkedAuths(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
+		case "passkeys":
+			out.Values[i] = ec._Me_passkeys(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&out.Invalids, 1)
+			}
 		case "myWorkspace":
 			field := field
 
@@ -9953,6 +11468,8 @@ func (ec *executionContext) _Me(ctx context.Context, sel ast.SelectionSet, obj *
 			}
 
 			out.Concurrently(i, func(ctx context.Context) graphql.Marshaler { return innerFunc(ctx, out) })
+		case "deletionRequestedAt":
+			out.Values[i] = ec._Me_deletionRequestedAt(ctx, field, obj)
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -9995,6 +11512,17 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 		switch field.Name {
 		case "__typename":
 			out.Values[i] = graphql.MarshalString("Mutation")
+		case "cancelMyDeletion":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_cancelMyDeletion(ctx, field)
+			})
+		case "confirmMFA":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_confirmMFA(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "createVerification":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_createVerification(ctx, field)
@@ -10021,6 +11549,10 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_findOrCreate(ctx, field)
 			})
+		case "linkMyAuth":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_linkMyAuth(ctx, field)
+			})
 		case "logout":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_logout(ctx, field)
@@ -10040,6 +11572,17 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_removeMyAuth(ctx, field)
 			})
+		case "removeMyPasskey":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_removeMyPasskey(ctx, field)
+			})
+		case "requestMyDataExport":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_requestMyDataExport(ctx, field)
+			})
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
 		case "signup":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_signup(ctx, field)
@@ -10056,6 +11599,10 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_updateMe(ctx, field)
 			})
+		case "uploadMyPhoto":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_uploadMyPhoto(ctx, field)
+			})
 		case "verifyUser":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_verifyUser(ctx, field)
@@ -10072,6 +11619,10 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_updateWorkspace(ctx, field)
 			})
+		case "uploadWorkspacePhoto":
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_uploadWorkspacePhoto(ctx, field)
+			})
 		case "addUsersToWorkspace":
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
 				return ec._Mutation_addUsersToWorkspace(ctx, field)
@@ -10131,6 +11682,116 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 	return out
 }
 
+var oIDCProviderImplementors = []string{"OIDCProvider"}
+
+func (ec *executionContext) _OIDCProvider(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.OIDCProvider) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, oIDCProviderImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("OIDCProvider")
+		case "name":
+			out.Values[i] = ec._OIDCProvider_name(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "issuer":
+			out.Values[i] = ec._OIDCProvider_issuer(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "clientId":
+			out.Values[i] = ec._OIDCProvider_clientId(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
+var passkeyImplementors = []string{"Passkey"}
+
+func (ec *executionContext) _Passkey(ctx context.Context, sel ast.SelectionSet, obj *gqlmodel.Passkey) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, passkeyImplementors)
+
+	out := graphql.NewFieldSet(fields)
+	deferred := make(map[string]*graphql.FieldSet)
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Passkey")
+		case "id":
+			out.Values[i] = ec._Passkey_id(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "nickname":
+			out.Values[i] = ec._Passkey_nickname(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "transports":
+			out.Values[i] = ec._Passkey_transports(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "backupEligible":
+			out.Values[i] = ec._Passkey_backupEligible(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "createdAt":
+			out.Values[i] = ec._Passkey_createdAt(ctx, field, obj)
+			if out.Values[i] == graphql.Null {
+				out.Invalids++
+			}
+		case "lastUsedAt":
+			out.Values[i] = ec._Passkey_lastUsedAt(ctx, field, obj)
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch(ctx)
+	if out.Invalids > 0 {
+		return graphql.Null
+	}
+
+	atomic.AddInt32(&ec.deferred, int32(len(deferred)))
+
+	for label, dfs := range deferred {
+		ec.processDeferredGroup(graphql.DeferredGroup{
+			Label:    label,
+			Path:     graphql.GetPath(ctx),
+			FieldSet: dfs,
+			Context:  ctx,
+		})
+	}
+
+	return out
+}
+
 var queryImplementors = []string{"Query"}
 
 func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) graphql.Marshaler {
@@ -11732,6 +13393,11 @@ func (ec *executionContext) unmarshalNCheckPermissionInput2githubᚗcomᚋreeart
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalNConfirmMFAInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐConfirmMFAInput(ctx context.Context, v any) (gqlmodel.ConfirmMFAInput, error) {
+	res, err := ec.unmarshalInputConfirmMFAInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalNCreateVerificationInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐCreateVerificationInput(ctx context.Context, v any) (gqlmodel.CreateVerificationInput, error) {
 	res, err := ec.unmarshalInputCreateVerificationInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -11742,6 +13408,22 @@ func (ec *executionContext) unmarshalNCreateWorkspaceInput2githubᚗcomᚋreeart
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalNDateTime2timeᚐTime(ctx context.Context, v any) (time.Time, error) {
+	res, err := graphql.UnmarshalTime(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNDateTime2timeᚐTime(ctx context.Context, sel ast.SelectionSet, v time.Time) graphql.Marshaler {
+	_ = sel
+	res := graphql.MarshalTime(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) unmarshalNDeleteMeInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐDeleteMeInput(ctx context.Context, v any) (gqlmodel.DeleteMeInput, error) {
 	res, err := ec.unmarshalInputDeleteMeInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -11836,6 +13518,65 @@ func (ec *executionContext) marshalNLang2string(ctx context.Context, sel ast.Sel
 	return res
 }
 
+func (ec *executionContext) unmarshalNLinkMyAuthInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐLinkMyAuthInput(ctx context.Context, v any) (gqlmodel.LinkMyAuthInput, error) {
+	res, err := ec.unmarshalInputLinkMyAuthInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNLinkedAuth2ᚕᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐLinkedAuthᚄ(ctx context.Context, sel ast.SelectionSet, v []*gqlmodel.LinkedAuth) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNLinkedAuth2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐLinkedAuth(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNLinkedAuth2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐLinkedAuth(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.LinkedAuth) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._LinkedAuth(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNMFAEnrollResult2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐMFAEnrollResult(ctx context.Context, sel ast.SelectionSet, v gqlmodel.MFAEnrollResult) graphql.Marshaler {
 	return ec._MFAEnrollResult(ctx, sel, &v)
 }
@@ -11918,11 +13659,119 @@ func (ec *executionContext) marshalNNodeType2githubᚗcomᚋreearthᚋreearthᚑ
 	return v
 }
 
+func (ec *executionContext) marshalNOIDCProvider2ᚕᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐOIDCProviderᚄ(ctx context.Context, sel ast.SelectionSet, v []*gqlmodel.OIDCProvider) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNOIDCProvider2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐOIDCProvider(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNOIDCProvider2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐOIDCProvider(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.OIDCProvider) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._OIDCProvider(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNPagination2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐPagination(ctx context.Context, v any) (gqlmodel.Pagination, error) {
 	res, err := ec.unmarshalInputPagination(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) marshalNPasskey2ᚕᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐPasskeyᚄ(ctx context.Context, sel ast.SelectionSet, v []*gqlmodel.Passkey) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNPasskey2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐPasskey(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNPasskey2ᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐPasskey(ctx context.Context, sel ast.SelectionSet, v *gqlmodel.Passkey) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._Passkey(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNPasswordResetInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐPasswordResetInput(ctx context.Context, v any) (gqlmodel.PasswordResetInput, error) {
 	res, err := ec.unmarshalInputPasswordResetInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -11948,6 +13797,11 @@ func (ec *executionContext) unmarshalNRemoveMyAuthInput2githubᚗcomᚋreearth
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalNRemoveMyPasskeyInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐRemoveMyPasskeyInput(ctx context.Context, v any) (gqlmodel.RemoveMyPasskeyInput, error) {
+	res, err := ec.unmarshalInputRemoveMyPasskeyInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) unmarshalNRemoveUserFromWorkspaceInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐRemoveUserFromWorkspaceInput(ctx context.Context, v any) (gqlmodel.RemoveUserFromWorkspaceInput, error) {
 	res, err := ec.unmarshalInputRemoveUserFromWorkspaceInput(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -12059,6 +13913,32 @@ func (ec *executionContext) unmarshalNUpdateWorkspaceInput2githubᚗcomᚋreeart
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
+func (ec *executionContext) unmarshalNUpload2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚐUpload(ctx context.Context, v any) (graphql.Upload, error) {
+	res, err := graphql.UnmarshalUpload(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNUpload2githubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚐUpload(ctx context.Context, sel ast.SelectionSet, v graphql.Upload) graphql.Marshaler {
+	_ = sel
+	res := graphql.MarshalUpload(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			graphql.AddErrorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
+func (ec *executionContext) unmarshalNUploadMyPhotoInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUploadMyPhotoInput(ctx context.Context, v any) (gqlmodel.UploadMyPhotoInput, error) {
+	res, err := ec.unmarshalInputUploadMyPhotoInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) unmarshalNUploadWorkspacePhotoInput2githubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUploadWorkspacePhotoInput(ctx context.Context, v any) (gqlmodel.UploadWorkspacePhotoInput, error) {
+	res, err := ec.unmarshalInputUploadWorkspacePhotoInput(ctx, v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
 func (ec *executionContext) marshalNUser2ᚕᚖgithubᚗcomᚋreearthᚋreearthᚑaccountsᚋserverᚋinternalᚋadapterᚋgqlᚋgqlmodelᚐUserᚄ(ctx context.Context, sel ast.SelectionSet, v []*gqlmodel.User) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
@@ -0,0 +1,20 @@
+package gqlmodel
+
+import (
+	"io"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/reearth/reearthx/asset/domain/file"
+)
+
+func FromFile(f *graphql.Upload) *file.File {
+	if f == nil {
+		return nil
+	}
+	return &file.File{
+		Content:     io.NopCloser(f.File),
+		Name:        f.Filename,
+		Size:        f.Size,
+		ContentType: f.ContentType,
+	}
+}
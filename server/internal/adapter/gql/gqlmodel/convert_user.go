@@ -1,8 +1,11 @@
 package gqlmodel
 
 import (
+	"context"
 	"time"
 
+	"github.com/labstack/gommon/log"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/pkg/role"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/samber/lo"
@@ -10,18 +13,12 @@ import (
 	"github.com/reearth/reearthx/util"
 )
 
-func ToUser(u *user.User) *User {
+func ToUser(ctx context.Context, u *user.User, storage gateway.Storage) *User {
 	if u == nil {
 		return nil
 	}
 
-	metadata := UserMetadata{
-		Description: u.Metadata().Description(),
-		Lang:        u.Metadata().Lang().String(),
-		PhotoURL:    u.Metadata().PhotoURL(),
-		Theme:       Theme(u.Metadata().Theme()),
-		Website:     u.Metadata().Website(),
-	}
+	metadata := toUserMetadata(ctx, u, storage)
 
 	var v *Verification
 	if u.Verification() != nil {
@@ -47,14 +44,14 @@ func ToUser(u *user.User) *User {
 	}
 }
 
-func ToUsers(ul user.List) []*User {
+func ToUsers(ctx context.Context, ul user.List, storage gateway.Storage) []*User {
 	if ul == nil {
 		return nil
 	}
 
 	users := make([]*User, 0, len(ul))
 	for _, u := range ul {
-		users = append(users, ToUser(u))
+		users = append(users, ToUser(ctx, u, storage))
 	}
 	return users
 }
@@ -83,18 +80,12 @@ func ToUserForAuthorization(u *user.User) *User {
 	}
 }
 
-func ToMe(u *user.User) *Me {
+func ToMe(ctx context.Context, u *user.User, storage gateway.Storage) *Me {
 	if u == nil {
 		return nil
 	}
 
-	metadata := UserMetadata{
-		Description: u.Metadata().Description(),
-		Lang:        u.Metadata().Lang().String(),
-		PhotoURL:    u.Metadata().PhotoURL(),
-		Theme:       Theme(u.Metadata().Theme()),
-		Website:     u.Metadata().Website(),
-	}
+	metadata := toUserMetadata(ctx, u, storage)
 
 	var latestLogoutAt *time.Time
 	if !u.LatestLogoutAt().IsZero() {
@@ -113,6 +104,61 @@ func ToMe(u *user.User) *Me {
 		Auths: util.Map(u.Auths(), func(a user.Auth) string {
 			return a.Provider
 		}),
+		LinkedAuths: util.Map(u.Auths(), func(a user.Auth) *LinkedAuth {
+			return ToLinkedAuth(a, u.AuthLink(a.Sub))
+		}),
+		Passkeys:            util.Map(u.Passkeys(), ToPasskey),
+		DeletionRequestedAt: u.DeletionRequestedAt(),
+	}
+}
+
+func toUserMetadata(ctx context.Context, u *user.User, storage gateway.Storage) UserMetadata {
+	photoURL, err := gateway.SignedPhotoURL(ctx, storage, u.Metadata().PhotoURL())
+	if err != nil {
+		log.Errorf("[ToUser] failed to get signed url: %s, user id: %s", err.Error(), u.ID())
+	}
+
+	return UserMetadata{
+		Description: u.Metadata().Description(),
+		Lang:        u.Metadata().Lang().String(),
+		PhotoURL:    photoURL,
+		Theme:       Theme(u.Metadata().Theme()),
+		Website:     u.Metadata().Website(),
+	}
+}
+
+func ToLinkedAuth(a user.Auth, l *user.AuthLink) *LinkedAuth {
+	res := &LinkedAuth{
+		Provider: a.Provider,
+		Sub:      a.Sub,
+	}
+	if l != nil {
+		res.Issuer = lo.EmptyableToPtr(l.Issuer)
+		res.Email = lo.EmptyableToPtr(l.Email)
+		res.LinkedAt = lo.ToPtr(l.LinkedAt)
+	}
+	return res
+}
+
+func ToPasskey(p user.Passkey) *Passkey {
+	transports := p.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+	return &Passkey{
+		ID:             p.IDString(),
+		Nickname:       p.Nickname,
+		Transports:     transports,
+		BackupEligible: p.BackupEligible,
+		CreatedAt:      p.CreatedAt,
+		LastUsedAt:     p.LastUsedAt,
+	}
+}
+
+func ToMFARecoveryCodeResult(codes []string) *MFARecoveryCodeResult {
+	return &MFARecoveryCodeResult{
+		RecoveryCode:  lo.FirstOrEmpty(codes),
+		RecoveryCodes: codes,
 	}
 }
 
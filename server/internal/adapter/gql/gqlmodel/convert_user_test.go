@@ -1,10 +1,16 @@
 package gqlmodel
 
 import (
+	"context"
+	"errors"
 	"testing"
 
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway/mock"
 	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
 )
 
 func TestToRole(t *testing.T) {
@@ -78,3 +84,50 @@ func TestFromRole(t *testing.T) {
 		})
 	}
 }
+
+func TestToMe_PhotoURL(t *testing.T) {
+	ctx := context.Background()
+	key := "users/01/photos/02/256.png"
+
+	tests := []struct {
+		name     string
+		photoURL string
+		mock     func(s *mock.MockStorage)
+		want     string
+	}{
+		{
+			name:     "uploaded photo is signed",
+			photoURL: key,
+			mock: func(s *mock.MockStorage) {
+				s.EXPECT().GetSignedURL(ctx, key).Return("https://storage.example.com/signed", nil).Times(2)
+			},
+			want: "https://storage.example.com/signed",
+		},
+		{
+			name:     "external url is kept",
+			photoURL: "https://example.com/me.png",
+			want:     "https://example.com/me.png",
+		},
+		{
+			name:     "signing failure renders no photo",
+			photoURL: key,
+			mock: func(s *mock.MockStorage) {
+				s.EXPECT().GetSignedURL(ctx, key).Return("", errors.New("boom")).Times(2)
+			},
+			want: "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := mock.NewMockStorage(gomock.NewController(t))
+			if tt.mock != nil {
+				tt.mock(storage)
+			}
+			u := user.New().NewID().Name("alice").Email("alice@example.com").
+				Metadata(user.MetadataFrom(tt.photoURL, "", "", language.English, user.ThemeDefault)).MustBuild()
+
+			assert.Equal(t, tt.want, ToMe(ctx, u, storage).Metadata.PhotoURL)
+			assert.Equal(t, tt.want, ToUser(ctx, u, storage).Metadata.PhotoURL)
+		})
+	}
+}
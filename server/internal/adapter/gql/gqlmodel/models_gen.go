@@ -8,6 +8,8 @@ import (
 	"io"
 	"strconv"
 	"time"
+
+	"github.com/99designs/gqlgen/graphql"
 )
 
 type Node interface {
@@ -53,6 +55,8 @@ type AuthConfig struct {
 	CipProjectID *string `json:"cipProjectId,omitempty"`
 	// CIP GCIP tenant id (optional)
 	CipTenantID *string `json:"cipTenantId,omitempty"`
+	// Generic OIDC identity providers users can sign in with
+	OidcProviders []*OIDCProvider `json:"oidcProviders"`
 }
 
 type CheckPermissionInput struct {
@@ -66,6 +70,10 @@ type CheckPermissionPayload struct {
 	Allowed bool `json:"allowed"`
 }
 
+type ConfirmMFAInput struct {
+	Code string `json:"code"`
+}
+
 type CreateVerificationInput struct {
 	Email string `json:"email"`
 }
@@ -102,12 +110,31 @@ type FindOrCreateInput struct {
 	Token string `json:"token"`
 }
 
+type LinkMyAuthInput struct {
+	// Token the provider of the identity to link issued within the last 10 minutes.
+	Token string `json:"token"`
+}
+
+type LinkedAuth struct {
+	Provider string `json:"provider"`
+	Sub      string `json:"sub"`
+	// Issuer and email of the token that proved the identity. Null for the identity
+	// the user signed up with.
+	Issuer   *string    `json:"issuer,omitempty"`
+	Email    *string    `json:"email,omitempty"`
+	LinkedAt *time.Time `json:"linkedAt,omitempty"`
+}
+
 type MFAEnrollResult struct {
 	EnrollmentURL string `json:"enrollmentUrl"`
 }
 
 type MFARecoveryCodeResult struct {
+	// The first of recoveryCodes, for clients that show a single code.
 	RecoveryCode string `json:"recoveryCode"`
+	// Single-use codes that each stand in for a TOTP code once. Only their hashes
+	// are kept, so they can't be shown again.
+	RecoveryCodes []string `json:"recoveryCodes"`
 }
 
 type MFAStatus struct {
@@ -123,8 +150,15 @@ type Me struct {
 	Host           *string       `json:"host,omitempty"`
 	LatestLogoutAt *time.Time    `json:"latestLogoutAt,omitempty"`
 	MyWorkspaceID  ID            `json:"myWorkspaceId"`
-	Auths          []string      `json:"auths"`
-	MyWorkspace    *Workspace    `json:"myWorkspace"`
+	// Providers of the identities the user can sign in with.
+	Auths []string `json:"auths"`
+	// Identities the user can sign in with, including when and how each was linked.
+	LinkedAuths []*LinkedAuth `json:"linkedAuths"`
+	Passkeys    []*Passkey    `json:"passkeys"`
+	MyWorkspace *Workspace    `json:"myWorkspace"`
+	// When the user asked to delete their account. Null unless the deletion is
+	// pending, in which case it can still be cancelled with cancelMyDeletion.
+	DeletionRequestedAt *time.Time `json:"deletionRequestedAt,omitempty"`
 }
 
 type MemberInput struct {
@@ -135,11 +169,31 @@ type MemberInput struct {
 type Mutation struct {
 }
 
+// Public configuration of a generic OIDC identity provider (e.g. Keycloak or Azure AD).
+type OIDCProvider struct {
+	// Provider name, also the prefix of the subs of its users
+	Name string `json:"name"`
+	// Issuer URL
+	Issuer string `json:"issuer"`
+	// OAuth client ID of this service at the provider
+	ClientID string `json:"clientId"`
+}
+
 type Pagination struct {
 	Page int `json:"page"`
 	Size int `json:"size"`
 }
 
+type Passkey struct {
+	// Base64url-encoded WebAuthn credential ID.
+	ID             string     `json:"id"`
+	Nickname       string     `json:"nickname"`
+	Transports     []string   `json:"transports"`
+	BackupEligible bool       `json:"backupEligible"`
+	CreatedAt      time.Time  `json:"createdAt"`
+	LastUsedAt     *time.Time `json:"lastUsedAt,omitempty"`
+}
+
 type PasswordResetInput struct {
 	Password string `json:"password"`
 	Token    string `json:"token"`
@@ -179,6 +233,10 @@ type RemoveMyAuthInput struct {
 	Auth string `json:"auth"`
 }
 
+type RemoveMyPasskeyInput struct {
+	ID string `json:"id"`
+}
+
 type RemoveUserFromWorkspaceInput struct {
 	WorkspaceID ID `json:"workspaceId"`
 	UserID      ID `json:"userId"`
@@ -261,6 +319,17 @@ type UpdateWorkspacePayload struct {
 	Workspace *Workspace `json:"workspace"`
 }
 
+type UploadMyPhotoInput struct {
+	// PNG, JPEG or GIF file of up to 5 MB.
+	File graphql.Upload `json:"file"`
+}
+
+type UploadWorkspacePhotoInput struct {
+	WorkspaceID ID `json:"workspaceId"`
+	// PNG, JPEG or GIF file of up to 5 MB.
+	File graphql.Upload `json:"file"`
+}
+
 type User struct {
 	ID           ID            `json:"id"`
 	Name         string        `json:"name"`
@@ -31,7 +31,7 @@ func NewLoaders(usecases *interfaces.Container, storage gateway.Storage) *Loader
 	return &Loaders{
 		usecases:  *usecases,
 		Workspace: NewWorkspaceLoader(usecases.Workspace, storage),
-		User:      NewUserLoader(usecases.User),
+		User:      NewUserLoader(usecases.User, storage),
 	}
 }
 
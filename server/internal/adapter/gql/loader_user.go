@@ -5,6 +5,7 @@ import (
 
 	"github.com/reearth/reearth-accounts/server/internal/adapter/gql/gqldataloader"
 	"github.com/reearth/reearth-accounts/server/internal/adapter/gql/gqlmodel"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearthx/util"
@@ -12,10 +13,11 @@ import (
 
 type UserLoader struct {
 	usecase interfaces.User
+	storage gateway.Storage
 }
 
-func NewUserLoader(usecase interfaces.User) *UserLoader {
-	return &UserLoader{usecase: usecase}
+func NewUserLoader(usecase interfaces.User, storage gateway.Storage) *UserLoader {
+	return &UserLoader{usecase: usecase, storage: storage}
 }
 
 func (c *UserLoader) Fetch(ctx context.Context, ids []gqlmodel.ID) ([]*gqlmodel.User, []error) {
@@ -31,7 +33,7 @@ func (c *UserLoader) Fetch(ctx context.Context, ids []gqlmodel.ID) ([]*gqlmodel.
 
 	users := make([]*gqlmodel.User, 0, len(res))
 	for _, u := range res {
-		users = append(users, gqlmodel.ToUser(u))
+		users = append(users, gqlmodel.ToUser(ctx, u, c.storage))
 	}
 
 	return users, nil
@@ -52,7 +54,7 @@ func (c *UserLoader) FetchByID(ctx context.Context, userID gqlmodel.ID) (*gqlmod
 		return nil, nil
 	}
 
-	return gqlmodel.ToUser(users[0]), nil
+	return gqlmodel.ToUser(ctx, users[0], c.storage), nil
 }
 
 func (c *UserLoader) UserByNameOrEmail(ctx context.Context, nameOrEmail string) (*gqlmodel.User, error) {
@@ -78,7 +80,7 @@ func (c *UserLoader) SearchUser(ctx context.Context, keyword string) ([]*gqlmode
 		return nil, nil
 	}
 
-	return gqlmodel.ToUsers(res), nil
+	return gqlmodel.ToUsers(ctx, res, c.storage), nil
 }
 
 func (c *UserLoader) FetchByAlias(ctx context.Context, alias string) (*gqlmodel.User, error) {
@@ -91,7 +93,7 @@ func (c *UserLoader) FetchByAlias(ctx context.Context, alias string) (*gqlmodel.
 		return nil, nil
 	}
 
-	return gqlmodel.ToUser(res), nil
+	return gqlmodel.ToUser(ctx, res, c.storage), nil
 }
 
 func (c *UserLoader) FetchByNameOrAlias(ctx context.Context, nameOrAlias string) ([]*gqlmodel.User, error) {
@@ -104,7 +106,7 @@ func (c *UserLoader) FetchByNameOrAlias(ctx context.Context, nameOrAlias string)
 		return nil, nil
 	}
 
-	return gqlmodel.ToUsers(res), nil
+	return gqlmodel.ToUsers(ctx, res, c.storage), nil
 }
 
 // data loader
@@ -7,6 +7,7 @@ import (
 	"github.com/reearth/reearth-accounts/server/internal/adapter/gql/gqlmodel"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/samber/lo"
 	"golang.org/x/text/language"
 )
@@ -32,7 +33,25 @@ func (r *mutationResolver) UpdateMe(ctx context.Context, input gqlmodel.UpdateMe
 		return nil, err
 	}
 
-	return &gqlmodel.UpdateMePayload{Me: gqlmodel.ToMe(res)}, nil
+	return &gqlmodel.UpdateMePayload{Me: gqlmodel.ToMe(ctx, res, r.Storage)}, nil
+}
+
+func (r *mutationResolver) UploadMyPhoto(ctx context.Context, input gqlmodel.UploadMyPhotoInput) (*gqlmodel.UpdateMePayload, error) {
+	res, err := usecases(ctx).User.UploadMyPhoto(ctx, gqlmodel.FromFile(&input.File), getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateMePayload{Me: gqlmodel.ToMe(ctx, res, r.Storage)}, nil
+}
+
+func (r *mutationResolver) LinkMyAuth(ctx context.Context, input gqlmodel.LinkMyAuthInput) (*gqlmodel.UpdateMePayload, error) {
+	res, err := usecases(ctx).User.LinkMyAuth(ctx, input.Token, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateMePayload{Me: gqlmodel.ToMe(ctx, res, r.Storage)}, nil
 }
 
 func (r *mutationResolver) RemoveMyAuth(ctx context.Context, input gqlmodel.RemoveMyAuthInput) (*gqlmodel.UpdateMePayload, error) {
@@ -41,7 +60,21 @@ func (r *mutationResolver) RemoveMyAuth(ctx context.Context, input gqlmodel.Remo
 		return nil, err
 	}
 
-	return &gqlmodel.UpdateMePayload{Me: gqlmodel.ToMe(res)}, nil
+	return &gqlmodel.UpdateMePayload{Me: gqlmodel.ToMe(ctx, res, r.Storage)}, nil
+}
+
+func (r *mutationResolver) RemoveMyPasskey(ctx context.Context, input gqlmodel.RemoveMyPasskeyInput) (*gqlmodel.UpdateMePayload, error) {
+	pid, err := user.PasskeyIDFrom(input.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := usecases(ctx).User.RemoveMyPasskey(ctx, pid, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateMePayload{Me: gqlmodel.ToMe(ctx, res, r.Storage)}, nil
 }
 
 func (r *mutationResolver) DeleteMe(ctx context.Context, input gqlmodel.DeleteMeInput) (*gqlmodel.DeleteMePayload, error) {
@@ -57,6 +90,15 @@ func (r *mutationResolver) DeleteMe(ctx context.Context, input gqlmodel.DeleteMe
 	return &gqlmodel.DeleteMePayload{UserID: input.UserID}, nil
 }
 
+func (r *mutationResolver) CancelMyDeletion(ctx context.Context) (*gqlmodel.UpdateMePayload, error) {
+	res, err := usecases(ctx).User.CancelMyDeletion(ctx, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateMePayload{Me: gqlmodel.ToMe(ctx, res, r.Storage)}, nil
+}
+
 func (r *mutationResolver) Signup(ctx context.Context, input gqlmodel.SignupInput) (*gqlmodel.UserPayload, error) {
 	var lang language.Tag
 	if input.Lang != nil {
@@ -77,7 +119,7 @@ func (r *mutationResolver) Signup(ctx context.Context, input gqlmodel.SignupInpu
 		return nil, err
 	}
 
-	return &gqlmodel.UserPayload{User: gqlmodel.ToUser(u)}, nil
+	return &gqlmodel.UserPayload{User: gqlmodel.ToUser(ctx, u, r.Storage)}, nil
 }
 
 
@@ -126,7 +168,7 @@ func (r *mutationResolver) SignupOidc(ctx context.Context, input gqlmodel.Signup
 		return nil, err
 	}
 
-	return &gqlmodel.UserPayload{User: gqlmodel.ToUser(u)}, nil
+	return &gqlmodel.UserPayload{User: gqlmodel.ToUser(ctx, u, r.Storage)}, nil
 }
 
 func (r *mutationResolver) Logout(ctx context.Context) (*gqlmodel.Me, error) {
@@ -135,7 +177,7 @@ func (r *mutationResolver) Logout(ctx context.Context) (*gqlmodel.Me, error) {
 		return nil, err
 	}
 
-	return gqlmodel.ToMe(res), nil
+	return gqlmodel.ToMe(ctx, res, r.Storage), nil
 }
 
 func (r *mutationResolver) VerifyUser(ctx context.Context, input gqlmodel.VerifyUserInput) (*gqlmodel.UserPayload, error) {
@@ -144,7 +186,7 @@ func (r *mutationResolver) VerifyUser(ctx context.Context, input gqlmodel.Verify
 		return nil, err
 	}
 
-	return &gqlmodel.UserPayload{User: gqlmodel.ToUser(u)}, nil
+	return &gqlmodel.UserPayload{User: gqlmodel.ToUser(ctx, u, r.Storage)}, nil
 }
 
 // Temporary stub implementation to satisfy gqlgen after migrating GraphQL files from reearthx/account.
@@ -172,6 +214,11 @@ func (r *mutationResolver) StartPasswordReset(ctx context.Context, input gqlmode
 	return lo.ToPtr(true), nil
 }
 
+func (r *mutationResolver) RequestMyDataExport(ctx context.Context) (bool, error) {
+	err := usecases(ctx).User.RequestMyDataExport(ctx, getOperator(ctx))
+	return err == nil, err
+}
+
 func (r *mutationResolver) DisableMfa(ctx context.Context) (bool, error) {
 	err := usecases(ctx).User.DisableMFA(ctx, getOperator(ctx))
 	return err == nil, err
@@ -185,12 +232,20 @@ func (r *mutationResolver) EnableMfa(ctx context.Context) (*gqlmodel.MFAEnrollRe
 	return &gqlmodel.MFAEnrollResult{EnrollmentURL: enrollmentURL}, nil
 }
 
+func (r *mutationResolver) ConfirmMfa(ctx context.Context, input gqlmodel.ConfirmMFAInput) (*gqlmodel.MFARecoveryCodeResult, error) {
+	recoveryCodes, err := usecases(ctx).User.ConfirmMFA(ctx, input.Code, getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return gqlmodel.ToMFARecoveryCodeResult(recoveryCodes), nil
+}
+
 func (r *mutationResolver) RegenerateMFARecoveryCode(ctx context.Context) (*gqlmodel.MFARecoveryCodeResult, error) {
-	recoveryCode, err := usecases(ctx).User.RegenerateMFARecoveryCode(ctx, getOperator(ctx))
+	recoveryCodes, err := usecases(ctx).User.RegenerateMFARecoveryCode(ctx, getOperator(ctx))
 	if err != nil {
 		return nil, err
 	}
-	return &gqlmodel.MFARecoveryCodeResult{RecoveryCode: recoveryCode}, nil
+	return gqlmodel.ToMFARecoveryCodeResult(recoveryCodes), nil
 }
 
 func (r *mutationResolver) PasswordReset(ctx context.Context, input gqlmodel.PasswordResetInput) (*bool, error) {
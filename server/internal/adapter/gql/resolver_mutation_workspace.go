@@ -84,6 +84,31 @@ func (r *mutationResolver) UpdateWorkspace(ctx context.Context, input gqlmodel.U
 	return &gqlmodel.UpdateWorkspacePayload{Workspace: converted}, nil
 }
 
+func (r *mutationResolver) UploadWorkspacePhoto(ctx context.Context, input gqlmodel.UploadWorkspacePhotoInput) (*gqlmodel.UpdateWorkspacePayload, error) {
+	tid, err := gqlmodel.ToID[id.Workspace](input.WorkspaceID)
+	if err != nil {
+		return nil, err
+	}
+
+	w, err := usecases(ctx).Workspace.UploadPhoto(ctx, tid, gqlmodel.FromFile(&input.File), getOperator(ctx))
+	if err != nil {
+		return nil, err
+	}
+
+	exists, err := buildExistingUserSetFromWorkspace(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+
+	converted, err := gqlmodel.ToWorkspace(ctx, w, exists, r.Storage)
+	if err != nil {
+		log.Errorf("failed to convert workspace: %s", err.Error())
+		return nil, err
+	}
+
+	return &gqlmodel.UpdateWorkspacePayload{Workspace: converted}, nil
+}
+
 func (r *mutationResolver) AddUsersToWorkspace(ctx context.Context, input gqlmodel.AddUsersToWorkspaceInput) (*gqlmodel.AddUsersToWorkspacePayload, error) {
 	wid, err := gqlmodel.ToID[id.Workspace](input.WorkspaceID)
 	if err != nil {
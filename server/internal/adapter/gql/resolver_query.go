@@ -19,15 +19,7 @@ func (r *queryResolver) Me(ctx context.Context) (*gqlmodel.Me, error) {
 		return nil, nil
 	}
 
-	if u.Metadata().PhotoURL() != "" {
-		signedURL, err := r.Storage.GetSignedURL(ctx, u.Metadata().PhotoURL())
-		if err != nil {
-			return nil, err
-		}
-		u.Metadata().SetPhotoURL(signedURL)
-	}
-
-	return gqlmodel.ToMe(u), nil
+	return gqlmodel.ToMe(ctx, u, r.Storage), nil
 }
 
 func (r *queryResolver) Node(ctx context.Context, i gqlmodel.ID, typeArg gqlmodel.NodeType) (gqlmodel.Node, error) {
@@ -122,16 +114,25 @@ func (r *queryResolver) MfaStatus(ctx context.Context) (*gqlmodel.MFAStatus, err
 func (r *queryResolver) AuthConfig(ctx context.Context) (*gqlmodel.AuthConfig, error) {
 	cfgInterface := adapter.GetConfig(ctx)
 	if cfgInterface == nil {
-		return &gqlmodel.AuthConfig{}, nil
+		return &gqlmodel.AuthConfig{OidcProviders: []*gqlmodel.OIDCProvider{}}, nil
 	}
 
 	provider, ok := cfgInterface.(adapter.Auth0ConfigProvider)
 	if !ok {
-		return &gqlmodel.AuthConfig{}, nil
+		return &gqlmodel.AuthConfig{OidcProviders: []*gqlmodel.OIDCProvider{}}, nil
 	}
 
 	authData := adapter.ExtractAuthConfigData(provider)
 
+	oidcProviders := make([]*gqlmodel.OIDCProvider, 0, len(authData.OIDCProviders))
+	for _, p := range authData.OIDCProviders {
+		oidcProviders = append(oidcProviders, &gqlmodel.OIDCProvider{
+			Name:     p.Name,
+			Issuer:   p.Issuer,
+			ClientID: p.ClientID,
+		})
+	}
+
 	return &gqlmodel.AuthConfig{
 		Auth0Domain:   authData.Auth0Domain,
 		Auth0Audience: authData.Auth0Audience,
@@ -141,5 +142,6 @@ func (r *queryResolver) AuthConfig(ctx context.Context) (*gqlmodel.AuthConfig, e
 		CipAuthDomain: authData.CIPAuthDomain,
 		CipProjectID:  authData.CIPProjectID,
 		CipTenantID:   authData.CIPTenantID,
+		OidcProviders: oidcProviders,
 	}, nil
 }
@@ -33,7 +33,7 @@ func (r *queryResolver) FindUsersByIDs(ctx context.Context, userIds []gqlmodel.I
 		return nil, err
 	}
 
-	return gqlmodel.ToUsers(res), nil
+	return gqlmodel.ToUsers(ctx, res, r.Storage), nil
 }
 
 func (r *queryResolver) FindUsersByIDsWithPagination(ctx context.Context, userIds []gqlmodel.ID, alias *string, pagination gqlmodel.Pagination) (*gqlmodel.UsersWithPagination, error) {
@@ -51,7 +51,7 @@ func (r *queryResolver) FindUsersByIDsWithPagination(ctx context.Context, userId
 	}
 
 	return &gqlmodel.UsersWithPagination{
-		Users:      gqlmodel.ToUsers(res.Users),
+		Users:      gqlmodel.ToUsers(ctx, res.Users, r.Storage),
 		TotalCount: res.TotalCount,
 	}, nil
 }
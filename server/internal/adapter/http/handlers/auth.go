@@ -7,14 +7,18 @@ import (
 	"github.com/reearth/reearth-accounts/server/internal/adapter"
 	"github.com/reearth/reearth-accounts/server/internal/adapter/http/httpmodel"
 	httpinternal "github.com/reearth/reearth-accounts/server/internal/adapter/http/internal"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/samber/lo"
 )
 
 type AuthHandler struct {
 	authConfig adapter.Auth0ConfigProvider
+	storage    gateway.Storage
 }
 
-func NewAuthHandler(authConfig adapter.Auth0ConfigProvider) *AuthHandler {
-	return &AuthHandler{authConfig: authConfig}
+func NewAuthHandler(authConfig adapter.Auth0ConfigProvider, storage gateway.Storage) *AuthHandler {
+	return &AuthHandler{authConfig: authConfig, storage: storage}
 }
 
 // Config godoc
@@ -43,5 +47,30 @@ func (h *AuthHandler) Logout(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(u))
+	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(ctx, u, h.storage))
+}
+
+// StartMagicLink godoc
+// @Tags Auth
+// @Summary Email a single-use sign-in link
+// @Description Always succeeds for unknown, unverified or rate-limited emails so that registered addresses are not disclosed. The link is redeemed by posting its token to the login of the built-in OIDC provider, from the browser that opened the login page, so the route only exists when that provider is enabled.
+// @Accept json
+// @Produce json
+// @Param body body httpmodel.StartMagicLinkRequest true "email"
+// @Success 202 {object} httpmodel.MessageResponse
+// @Failure 400 {object} internal.ErrorResponse
+// @Router /api/auth/magic-link [post]
+func (h *AuthHandler) StartMagicLink(c echo.Context) error {
+	ctx := c.Request().Context()
+	req := &httpmodel.StartMagicLinkRequest{}
+	if err := httpinternal.BindValidate(c, req); err != nil {
+		return err
+	}
+	if err := httpinternal.Usecases(c).User.StartMagicLink(ctx, interfaces.StartMagicLinkParam{
+		Email:     req.Email,
+		RequestID: lo.FromPtr(req.ID),
+	}); err != nil {
+		return err
+	}
+	return c.JSON(http.StatusAccepted, httpmodel.MessageResponse{Success: true})
 }
@@ -10,18 +10,24 @@ import (
 	"github.com/reearth/reearth-accounts/server/internal/adapter"
 	"github.com/reearth/reearth-accounts/server/internal/adapter/http/httpmodel"
 	httpinternal "github.com/reearth/reearth-accounts/server/internal/adapter/http/internal"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/appx"
+	"github.com/reearth/reearthx/asset/domain/file"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/samber/lo"
 )
 
-type UserHandler struct{}
+type UserHandler struct {
+	storage gateway.Storage
+}
 
-func NewUserHandler() *UserHandler { return &UserHandler{} }
+func NewUserHandler(storage gateway.Storage) *UserHandler {
+	return &UserHandler{storage: storage}
+}
 
 // Me godoc
 // @Tags User
@@ -36,7 +42,7 @@ func (h *UserHandler) Me(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(u))
+	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(c.Request().Context(), u, h.storage))
 }
 
 // UpdateMe godoc
@@ -60,7 +66,7 @@ func (h *UserHandler) UpdateMe(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(u))
+	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(ctx, u, h.storage))
 }
 
 // DeleteMe godoc
@@ -83,6 +89,58 @@ func (h *UserHandler) DeleteMe(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// UploadMyPhoto godoc
+// @Tags User
+// @Summary Upload the photo of the current user
+// @Description Crops the photo to a square, resizes it to the standard thumbnails and sets it as the photo of the current user and their personal workspace. The previous photo is deleted.
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param file formData file true "PNG, JPEG or GIF file of up to 5 MB"
+// @Success 200 {object} httpmodel.MeResponse
+// @Failure 400 {object} internal.ErrorResponse
+// @Failure 401 {object} internal.ErrorResponse
+// @Failure 413 {object} internal.ErrorResponse
+// @Router /api/users/me/photo [post]
+func (h *UserHandler) UploadMyPhoto(c echo.Context) error {
+	ctx := c.Request().Context()
+	f, err := formFile(c, "file")
+	if err != nil {
+		return err
+	}
+	u, err := httpinternal.Usecases(c).User.UploadMyPhoto(ctx, f, httpinternal.Operator(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(ctx, u, h.storage))
+}
+
+// LinkMyAuth godoc
+// @Tags User
+// @Summary Link another identity provider to the current user
+// @Description The token must be issued by the provider to link within the last 10 minutes.
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body httpmodel.LinkMyAuthRequest true "token of the identity to link"
+// @Success 200 {object} httpmodel.MeResponse
+// @Failure 400 {object} internal.ErrorResponse
+// @Failure 401 {object} internal.ErrorResponse
+// @Failure 409 {object} internal.ErrorResponse
+// @Router /api/users/me/auths [post]
+func (h *UserHandler) LinkMyAuth(c echo.Context) error {
+	ctx := c.Request().Context()
+	req := &httpmodel.LinkMyAuthRequest{}
+	if err := httpinternal.BindValidate(c, req); err != nil {
+		return err
+	}
+	u, err := httpinternal.Usecases(c).User.LinkMyAuth(ctx, req.Token, httpinternal.Operator(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(ctx, u, h.storage))
+}
+
 // RemoveMyAuth godoc
 // @Tags User
 // @Summary Remove an auth provider from the current user
@@ -98,7 +156,90 @@ func (h *UserHandler) RemoveMyAuth(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(u))
+	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(ctx, u, h.storage))
+}
+
+// ListMyPasskeys godoc
+// @Tags User
+// @Summary List the current user's passkeys
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {array} httpmodel.PasskeyResponse
+// @Failure 401 {object} internal.ErrorResponse
+// @Router /api/users/me/passkeys [get]
+func (h *UserHandler) ListMyPasskeys(c echo.Context) error {
+	u, err := httpinternal.RequireUser(c)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, httpmodel.NewPasskeyResponses(u.Passkeys()))
+}
+
+// BeginPasskeyRegistration godoc
+// @Tags User
+// @Summary Start registering a passkey for the current user
+// @Description Returns PublicKeyCredentialCreationOptions for navigator.credentials.create. The challenge expires after 5 minutes.
+// @Security BearerAuth
+// @Produce json
+// @Success 200 {object} object
+// @Failure 401 {object} internal.ErrorResponse
+// @Router /api/users/me/passkeys/registration/begin [post]
+func (h *UserHandler) BeginPasskeyRegistration(c echo.Context) error {
+	ctx := c.Request().Context()
+	options, err := httpinternal.Usecases(c).User.BeginPasskeyRegistration(ctx, httpinternal.Operator(c))
+	if err != nil {
+		return err
+	}
+	return c.JSONBlob(http.StatusOK, options)
+}
+
+// FinishPasskeyRegistration godoc
+// @Tags User
+// @Summary Finish registering a passkey for the current user
+// @Security BearerAuth
+// @Accept json
+// @Produce json
+// @Param body body httpmodel.FinishPasskeyRegistrationRequest true "attestation response"
+// @Success 201 {object} httpmodel.PasskeyResponse
+// @Failure 400 {object} internal.ErrorResponse
+// @Failure 401 {object} internal.ErrorResponse
+// @Router /api/users/me/passkeys/registration/finish [post]
+func (h *UserHandler) FinishPasskeyRegistration(c echo.Context) error {
+	ctx := c.Request().Context()
+	req := &httpmodel.FinishPasskeyRegistrationRequest{}
+	if err := httpinternal.BindValidate(c, req); err != nil {
+		return err
+	}
+	pk, err := httpinternal.Usecases(c).User.FinishPasskeyRegistration(ctx, interfaces.FinishPasskeyRegistrationParam{
+		Nickname: req.Nickname,
+		Response: req.Credential,
+	}, httpinternal.Operator(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, httpmodel.NewPasskeyResponse(*pk))
+}
+
+// RemoveMyPasskey godoc
+// @Tags User
+// @Summary Remove a passkey from the current user
+// @Security BearerAuth
+// @Param id path string true "base64url credential ID"
+// @Produce json
+// @Success 200 {object} httpmodel.MeResponse
+// @Failure 404 {object} internal.ErrorResponse
+// @Router /api/users/me/passkeys/{id} [delete]
+func (h *UserHandler) RemoveMyPasskey(c echo.Context) error {
+	ctx := c.Request().Context()
+	pid, err := user.PasskeyIDFrom(c.Param("id"))
+	if err != nil {
+		return badRequest("invalid passkey id")
+	}
+	u, err := httpinternal.Usecases(c).User.RemoveMyPasskey(ctx, pid, httpinternal.Operator(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, httpmodel.NewMeResponse(ctx, u, h.storage))
 }
 
 // Deactivate godoc
@@ -121,7 +262,7 @@ func (h *UserHandler) Deactivate(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(u))
+	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(ctx, u, h.storage))
 }
 
 // Restore godoc
@@ -144,7 +285,7 @@ func (h *UserHandler) Restore(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(u))
+	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(ctx, u, h.storage))
 }
 
 // Get godoc
@@ -170,7 +311,7 @@ func (h *UserHandler) Get(c echo.Context) error {
 		return rerror.ErrNotFound
 	}
 
-	userResponses := httpmodel.NewUserResponses(res)
+	userResponses := httpmodel.NewUserResponses(ctx, res, h.storage)
 
 	// platform_roles/workspaces expose cross-tenant role and membership info, so
 	// only enrich when the caller is requesting their own record.
@@ -224,14 +365,14 @@ func (h *UserHandler) List(c echo.Context) error {
 		if err != nil {
 			return err
 		}
-		return c.JSON(http.StatusOK, httpinternal.NewPageResult(httpmodel.NewUserResponses(res.Users), page, size, res.TotalCount))
+		return c.JSON(http.StatusOK, httpinternal.NewPageResult(httpmodel.NewUserResponses(ctx, res.Users, h.storage), page, size, res.TotalCount))
 	}
 
 	res, err := uc.FetchByID(ctx, ids)
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewUserResponses(res))
+	return c.JSON(http.StatusOK, httpmodel.NewUserResponses(ctx, res, h.storage))
 }
 
 // ListAll godoc
@@ -277,7 +418,7 @@ func (h *UserHandler) ListAll(c echo.Context) error {
 		return err
 	}
 
-	userResponses := httpmodel.NewUserResponses(res.Users)
+	userResponses := httpmodel.NewUserResponses(ctx, res.Users, h.storage)
 	if err := applyPermittablesToResponses(ctx, c, userResponses, res.Users); err != nil {
 		return err
 	}
@@ -362,7 +503,7 @@ func (h *UserHandler) Search(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewUserResponses(res))
+	return c.JSON(http.StatusOK, httpmodel.NewUserResponses(ctx, res, h.storage))
 }
 
 // FindByAlias godoc
@@ -384,7 +525,7 @@ func (h *UserHandler) FindByAlias(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(res))
+	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(ctx, res, h.storage))
 }
 
 // FindByNameOrEmail godoc
@@ -427,7 +568,7 @@ func (h *UserHandler) FindByNameOrAlias(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewUserResponses(res))
+	return c.JSON(http.StatusOK, httpmodel.NewUserResponses(ctx, res, h.storage))
 }
 
 // Signup godoc
@@ -469,7 +610,7 @@ func (h *UserHandler) Signup(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(u))
+	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(ctx, u, h.storage))
 }
 
 // SignupOIDC godoc
@@ -516,17 +657,19 @@ func (h *UserHandler) SignupOIDC(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(u))
+	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(ctx, u, h.storage))
 }
 
 // SyncSSOUser godoc
 // @Tags User
 // @Summary Sync (provision) a SAML SSO user into accounts
+// @Description When called with the token of the user, the role mappings of the identity provider are applied from its claims.
 // @Accept json
 // @Produce json
 // @Param body body httpmodel.SyncSSOUserRequest true "SSO user fields"
 // @Success 200 {object} httpmodel.UserResponse
 // @Failure 400 {object} internal.ErrorResponse
+// @Failure 401 {object} internal.ErrorResponse
 // @Failure 409 {object} internal.ErrorResponse
 // @Router /api/users/sync-sso [post]
 func (h *UserHandler) SyncSSOUser(c echo.Context) error {
@@ -552,11 +695,19 @@ func (h *UserHandler) SyncSSOUser(c echo.Context) error {
 		UserID:      uid,
 		WorkspaceID: wid,
 	}
+	// Only the claims of a verified token of the same user are mapped; the
+	// M2M key carries none.
+	if ai := adapter.GetAuthInfo(ctx); ai != nil && ai.Token != "" && ai.Sub == req.Sub {
+		if claims, err := adapter.TokenClaims(ai.Token); err == nil {
+			param.Issuer = ai.Iss
+			param.Claims = claims
+		}
+	}
 	u, err := httpinternal.Usecases(c).User.SyncSSOUser(ctx, param)
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(u))
+	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(ctx, u, h.storage))
 }
 
 // CreateVerification godoc
@@ -597,7 +748,7 @@ func (h *UserHandler) VerifyUser(c echo.Context) error {
 	if err != nil {
 		return err
 	}
-	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(u))
+	return c.JSON(http.StatusOK, httpmodel.NewUserResponse(ctx, u, h.storage))
 }
 
 // StartPasswordReset godoc
@@ -745,6 +896,24 @@ func parseWorkspaceIDRef(s *string) (*id.WorkspaceID, error) {
 	return &wid, nil
 }
 
+// formFile opens the file uploaded in the multipart form field.
+func formFile(c echo.Context, field string) (*file.File, error) {
+	fh, err := c.FormFile(field)
+	if err != nil {
+		return nil, httpinternal.NewError(http.StatusBadRequest, field+" is required", err)
+	}
+	src, err := fh.Open()
+	if err != nil {
+		return nil, err
+	}
+	return &file.File{
+		Content:     src,
+		Name:        fh.Filename,
+		Size:        fh.Size,
+		ContentType: fh.Header.Get("Content-Type"),
+	}, nil
+}
+
 func adapterAuthInfo(c echo.Context) *appx.AuthInfo {
 	return adapter.GetAuthInfo(c.Request().Context())
 }
@@ -228,6 +228,37 @@ func (h *WorkspaceHandler) Update(c echo.Context) error {
 	return c.JSON(http.StatusOK, httpmodel.NewWorkspaceResponse(w))
 }
 
+// UploadPhoto godoc
+// @Tags Workspace
+// @Summary Upload the photo of a workspace
+// @Description Crops the photo to a square, resizes it to the standard thumbnails and sets it as the photo of the workspace. The previous photo is deleted.
+// @Security BearerAuth
+// @Accept multipart/form-data
+// @Produce json
+// @Param id path string true "workspace ID"
+// @Param file formData file true "PNG, JPEG or GIF file of up to 5 MB"
+// @Success 200 {object} httpmodel.WorkspaceResponse
+// @Failure 400 {object} internal.ErrorResponse
+// @Failure 403 {object} internal.ErrorResponse
+// @Failure 413 {object} internal.ErrorResponse
+// @Router /api/workspaces/{id}/photo [post]
+func (h *WorkspaceHandler) UploadPhoto(c echo.Context) error {
+	ctx := c.Request().Context()
+	wid, err := id.WorkspaceIDFrom(c.Param("id"))
+	if err != nil {
+		return badRequest("invalid workspace id")
+	}
+	f, err := formFile(c, "file")
+	if err != nil {
+		return err
+	}
+	w, err := httpinternal.Usecases(c).Workspace.UploadPhoto(ctx, wid, f, httpinternal.Operator(c))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, httpmodel.NewWorkspaceResponse(w))
+}
+
 // Delete godoc
 // @Tags Workspace
 // @Summary Delete a workspace
@@ -8,21 +8,43 @@ type AuthConfigResponse struct {
 	Auth0Audience *string `json:"auth0_audience,omitempty"`
 	Auth0ClientID *string `json:"auth0_client_id,omitempty"`
 	AuthProvider  *string `json:"auth_provider,omitempty"`
+
+	OIDCProviders []OIDCProviderResponse `json:"oidc_providers"`
+}
+
+// OIDCProviderResponse is the public configuration of a generic OIDC provider.
+type OIDCProviderResponse struct {
+	Name     string `json:"name"`
+	Issuer   string `json:"issuer"`
+	ClientID string `json:"client_id"`
 }
 
 // NewAuthConfigResponse converts the adapter AuthConfigData to a REST response.
 func NewAuthConfigResponse(d *adapter.AuthConfigData) *AuthConfigResponse {
 	if d == nil {
-		return &AuthConfigResponse{}
+		return &AuthConfigResponse{OIDCProviders: []OIDCProviderResponse{}}
+	}
+	providers := make([]OIDCProviderResponse, 0, len(d.OIDCProviders))
+	for _, p := range d.OIDCProviders {
+		providers = append(providers, OIDCProviderResponse{Name: p.Name, Issuer: p.Issuer, ClientID: p.ClientID})
 	}
 	return &AuthConfigResponse{
 		Auth0Domain:   d.Auth0Domain,
 		Auth0Audience: d.Auth0Audience,
 		Auth0ClientID: d.Auth0ClientID,
 		AuthProvider:  d.AuthProvider,
+		OIDCProviders: providers,
 	}
 }
 
+// StartMagicLinkRequest is the request body for POST /api/auth/magic-link.
+type StartMagicLinkRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	// ID is the pending sign-in request of the built-in OIDC provider the
+	// login page was opened with. The link carries it back to the page.
+	ID *string `json:"id,omitempty"`
+}
+
 // CheckPermissionRequest mirrors checkPermission input.
 type CheckPermissionRequest struct {
 	Service        string  `json:"service" validate:"required"`
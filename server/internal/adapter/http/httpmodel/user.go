@@ -1,12 +1,16 @@
 package httpmodel
 
 import (
+	"context"
+	"encoding/json"
 	"time"
 
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth-accounts/server/pkg/dregexp"
 	"github.com/reearth/reearth-accounts/server/pkg/permittable"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/util"
 )
 
@@ -66,6 +70,29 @@ type MeResponse struct {
 	LatestLogoutAt *time.Time            `json:"latest_logout_at,omitempty"`
 	MyWorkspaceID  string                `json:"my_workspace_id"`
 	Auths          []string              `json:"auths"`
+	LinkedAuths    []LinkedAuthResponse  `json:"linked_auths"`
+	Passkeys       []PasskeyResponse     `json:"passkeys"`
+}
+
+// LinkedAuthResponse describes an identity the user can sign in with. Issuer,
+// Email and LinkedAt are empty for the identity the user signed up with.
+type LinkedAuthResponse struct {
+	Provider string     `json:"provider"`
+	Sub      string     `json:"sub"`
+	Issuer   string     `json:"issuer,omitempty"`
+	Email    string     `json:"email,omitempty"`
+	LinkedAt *time.Time `json:"linked_at,omitempty"`
+}
+
+// PasskeyResponse describes a registered passkey. Key material is not exposed.
+type PasskeyResponse struct {
+	// ID is the base64url credential ID, used to remove the passkey.
+	ID             string     `json:"id"`
+	Nickname       string     `json:"nickname"`
+	Transports     []string   `json:"transports"`
+	BackupEligible bool       `json:"backup_eligible"`
+	CreatedAt      time.Time  `json:"created_at"`
+	LastUsedAt     *time.Time `json:"last_used_at,omitempty"`
 }
 
 // SimpleUserResponse mirrors the user.Simple shape used by userByNameOrEmail.
@@ -75,19 +102,24 @@ type SimpleUserResponse struct {
 	Email string `json:"email"`
 }
 
-func metadataResponse(u *user.User) *UserMetadataResponse {
+func metadataResponse(ctx context.Context, u *user.User, storage gateway.Storage) *UserMetadataResponse {
 	m := u.Metadata()
+	photoURL, err := gateway.SignedPhotoURL(ctx, storage, m.PhotoURL())
+	if err != nil {
+		log.Errorfc(ctx, "httpmodel: failed to sign photo url of user %s: %v", u.ID(), err)
+	}
 	return &UserMetadataResponse{
 		Description: m.Description(),
 		Website:     m.Website(),
-		PhotoURL:    m.PhotoURL(),
+		PhotoURL:    photoURL,
 		Lang:        m.Lang().String(),
 		Theme:       string(m.Theme()),
 	}
 }
 
-// NewUserResponse converts a domain user to a UserResponse.
-func NewUserResponse(u *user.User) *UserResponse {
+// NewUserResponse converts a domain user to a UserResponse. An uploaded photo
+// is rendered as a signed URL.
+func NewUserResponse(ctx context.Context, u *user.User, storage gateway.Storage) *UserResponse {
 	if u == nil {
 		return nil
 	}
@@ -112,7 +144,7 @@ func NewUserResponse(u *user.User) *UserResponse {
 		Host:         hp,
 		Workspace:    u.Workspace().String(),
 		Auths:        util.Map(u.Auths(), func(a user.Auth) string { return a.Provider }),
-		Metadata:     metadataResponse(u),
+		Metadata:     metadataResponse(ctx, u, storage),
 		Verification: v,
 		IsDeleted:    u.IsDeleted(),
 		DeletedAt:    u.DeletedAt(),
@@ -121,10 +153,10 @@ func NewUserResponse(u *user.User) *UserResponse {
 }
 
 // NewUserResponses converts a domain user list.
-func NewUserResponses(ul user.List) []*UserResponse {
+func NewUserResponses(ctx context.Context, ul user.List, storage gateway.Storage) []*UserResponse {
 	out := make([]*UserResponse, 0, len(ul))
 	for _, u := range ul {
-		out = append(out, NewUserResponse(u))
+		out = append(out, NewUserResponse(ctx, u, storage))
 	}
 	return out
 }
@@ -186,8 +218,9 @@ func ApplyPermittables(responses []*UserResponse, perms permittable.List, roleNa
 	}
 }
 
-// NewMeResponse converts a domain user to a MeResponse.
-func NewMeResponse(u *user.User) *MeResponse {
+// NewMeResponse converts a domain user to a MeResponse. An uploaded photo is
+// rendered as a signed URL.
+func NewMeResponse(ctx context.Context, u *user.User, storage gateway.Storage) *MeResponse {
 	if u == nil {
 		return nil
 	}
@@ -206,14 +239,52 @@ func NewMeResponse(u *user.User) *MeResponse {
 		Name:           u.Name(),
 		Alias:          u.Alias(),
 		Email:          u.Email(),
-		Metadata:       metadataResponse(u),
+		Metadata:       metadataResponse(ctx, u, storage),
 		Host:           hp,
 		LatestLogoutAt: ll,
 		MyWorkspaceID:  u.Workspace().String(),
 		Auths:          util.Map(u.Auths(), func(a user.Auth) string { return a.Provider }),
+		LinkedAuths:    util.Map(u.Auths(), func(a user.Auth) LinkedAuthResponse { return newLinkedAuthResponse(a, u.AuthLink(a.Sub)) }),
+		Passkeys:       NewPasskeyResponses(u.Passkeys()),
 	}
 }
 
+func newLinkedAuthResponse(a user.Auth, l *user.AuthLink) LinkedAuthResponse {
+	res := LinkedAuthResponse{Provider: a.Provider, Sub: a.Sub}
+	if l != nil {
+		linkedAt := l.LinkedAt
+		res.Issuer = l.Issuer
+		res.Email = l.Email
+		res.LinkedAt = &linkedAt
+	}
+	return res
+}
+
+// NewPasskeyResponse converts a domain passkey to a PasskeyResponse.
+func NewPasskeyResponse(p user.Passkey) PasskeyResponse {
+	transports := p.Transports
+	if transports == nil {
+		transports = []string{}
+	}
+	return PasskeyResponse{
+		ID:             p.IDString(),
+		Nickname:       p.Nickname,
+		Transports:     transports,
+		BackupEligible: p.BackupEligible,
+		CreatedAt:      p.CreatedAt,
+		LastUsedAt:     p.LastUsedAt,
+	}
+}
+
+// NewPasskeyResponses converts a passkey list, never returning nil.
+func NewPasskeyResponses(ps []user.Passkey) []PasskeyResponse {
+	out := make([]PasskeyResponse, 0, len(ps))
+	for _, p := range ps {
+		out = append(out, NewPasskeyResponse(p))
+	}
+	return out
+}
+
 // NewSimpleUserResponse converts a *user.Simple.
 func NewSimpleUserResponse(u *user.Simple) *SimpleUserResponse {
 	if u == nil {
@@ -254,7 +325,20 @@ func (r *UpdateMeRequest) ToInteractorInput() interfaces.UpdateMeParam {
 	}
 }
 
+// LinkMyAuthRequest mirrors linkMyAuth input.
+type LinkMyAuthRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
 // SignupRequest mirrors signup input.
+// FinishPasskeyRegistrationRequest completes a registration ceremony.
+// Credential is the PublicKeyCredential returned by navigator.credentials.create,
+// serialized with its binary fields base64url-encoded.
+type FinishPasskeyRegistrationRequest struct {
+	Nickname   string          `json:"nickname,omitempty" validate:"max=64"`
+	Credential json.RawMessage `json:"credential" validate:"required" swaggertype:"object"`
+}
+
 type SignupRequest struct {
 	ID          *string `json:"id,omitempty"`
 	WorkspaceID *string `json:"workspace_id,omitempty"`
@@ -7,6 +7,8 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/photo"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
@@ -97,19 +99,33 @@ func mapError(err error) *ErrorResponse {
 	}
 
 	switch {
-	case errors.Is(err, rerror.ErrNotFound):
+	case errors.Is(err, rerror.ErrNotFound),
+		errors.Is(err, user.ErrPasskeyNotFound):
 		return &ErrorResponse{Status: http.StatusNotFound, Message: "not found", Description: "the requested resource was not found"}
 	case errors.Is(err, interfaces.ErrUserAlreadyExists),
 		errors.Is(err, interfaces.ErrUserAliasAlreadyExists),
-		errors.Is(err, interfaces.ErrWorkspaceAliasAlreadyExists):
+		errors.Is(err, interfaces.ErrWorkspaceAliasAlreadyExists),
+		errors.Is(err, user.ErrPasskeyAlreadyRegistered),
+		errors.Is(err, interfaces.ErrAuthAlreadyLinked),
+		errors.Is(err, interfaces.ErrAuthLinkedToAnotherUser),
+		errors.Is(err, user.ErrAuthProviderAlreadyLinked),
+		errors.Is(err, user.ErrLastAuth):
 		return &ErrorResponse{Status: http.StatusConflict, Message: "conflict", Description: err.Error(), Err: err}
 	case errors.Is(err, ErrForbidden),
 		errors.Is(err, interfaces.ErrPermissionDenied),
 		errors.Is(err, interfaces.ErrOperationDenied),
+		errors.Is(err, interfaces.ErrImpersonationDenied),
 		errors.Is(err, interfaces.ErrCannotChangeOwnerRole),
 		errors.Is(err, interfaces.ErrCannotSelfPromote),
 		errors.Is(err, interfaces.ErrOwnerCannotLeaveTheWorkspace):
 		return &ErrorResponse{Status: http.StatusForbidden, Message: "forbidden", Description: err.Error(), Err: err}
+	case errors.Is(err, user.ErrMagicLinkRateLimited):
+		return &ErrorResponse{Status: http.StatusTooManyRequests, Message: "too many requests", Description: err.Error(), Err: err}
+	case errors.Is(err, photo.ErrTooLarge):
+		return &ErrorResponse{Status: http.StatusRequestEntityTooLarge, Message: "request entity too large", Description: err.Error(), Err: err}
+	case errors.Is(err, interfaces.ErrPasskeyNotConfigured),
+		errors.Is(err, interfaces.ErrAuthLinkNotConfigured):
+		return &ErrorResponse{Status: http.StatusNotImplemented, Message: "not implemented", Description: err.Error(), Err: err}
 	case errors.Is(err, ErrUnauthorized),
 		errors.Is(err, interfaces.ErrInvalidOperator):
 		return &ErrorResponse{Status: http.StatusUnauthorized, Message: "unauthorized", Description: "authentication is required"}
@@ -120,8 +136,14 @@ func mapError(err error) *ErrorResponse {
 		errors.Is(err, interfaces.ErrUserInvalidLang),
 		errors.Is(err, interfaces.ErrSignupInvalidSecret),
 		errors.Is(err, interfaces.ErrInvalidPhotoURL),
+		errors.Is(err, photo.ErrUnsupportedType),
+		errors.Is(err, photo.ErrInvalid),
 		errors.Is(err, interfaces.ErrNotVerifiedUser),
 		errors.Is(err, interfaces.ErrTooManyWorkspaceIDs),
+		errors.Is(err, interfaces.ErrInvalidPasskey),
+		errors.Is(err, interfaces.ErrInvalidPasskeyChallenge),
+		errors.Is(err, interfaces.ErrInvalidMagicLink),
+		errors.Is(err, interfaces.ErrInvalidLinkToken),
 		errors.Is(err, workspace.ErrCannotChangeRoleToOwner):
 		return &ErrorResponse{Status: http.StatusBadRequest, Message: "bad request", Description: err.Error(), Err: err}
 	default:
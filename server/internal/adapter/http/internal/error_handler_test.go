@@ -8,6 +8,8 @@ import (
 	"github.com/labstack/echo/v4"
 	httpinternal "github.com/reearth/reearth-accounts/server/internal/adapter/http/internal"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/photo"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/stretchr/testify/assert"
 )
@@ -30,5 +32,13 @@ func TestCustomHTTPErrorHandler(t *testing.T) {
 	assert.Equal(t, http.StatusUnauthorized, handleStatus(t, httpinternal.ErrUnauthorized))
 	assert.Equal(t, http.StatusUnauthorized, handleStatus(t, interfaces.ErrInvalidOperator))
 	assert.Equal(t, http.StatusBadRequest, handleStatus(t, interfaces.ErrInvalidPhotoURL))
+	assert.Equal(t, http.StatusBadRequest, handleStatus(t, photo.ErrUnsupportedType))
+	assert.Equal(t, http.StatusRequestEntityTooLarge, handleStatus(t, photo.ErrTooLarge))
+	assert.Equal(t, http.StatusNotFound, handleStatus(t, user.ErrPasskeyNotFound))
+	assert.Equal(t, http.StatusConflict, handleStatus(t, user.ErrPasskeyAlreadyRegistered))
+	assert.Equal(t, http.StatusBadRequest, handleStatus(t, interfaces.ErrInvalidPasskeyChallenge))
+	assert.Equal(t, http.StatusNotImplemented, handleStatus(t, interfaces.ErrPasskeyNotConfigured))
+	assert.Equal(t, http.StatusTooManyRequests, handleStatus(t, user.ErrMagicLinkRateLimited))
+	assert.Equal(t, http.StatusBadRequest, handleStatus(t, interfaces.ErrInvalidMagicLink))
 	assert.Equal(t, http.StatusInternalServerError, handleStatus(t, assert.AnError))
 }
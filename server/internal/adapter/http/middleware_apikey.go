@@ -51,19 +51,22 @@ func skipJWTOnAPIKey(jwt echo.MiddlewareFunc, apiKeys ...string) echo.Middleware
 func APIKeyOrAuth(cfgKey string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			if httpinternal.User(c) != nil {
+			if httpinternal.User(c) != nil || hasAPIKey(c, cfgKey) {
 				return next(c)
 			}
-			if cfgKey != "" {
-				h := c.Request().Header.Get(echo.HeaderAuthorization)
-				if strings.HasPrefix(h, bearerPrefix) {
-					token := strings.TrimPrefix(h, bearerPrefix)
-					if subtle.ConstantTimeCompare([]byte(token), []byte(cfgKey)) == 1 {
-						return next(c)
-					}
-				}
-			}
 			return httpinternal.ErrUnauthorized
 		}
 	}
 }
+
+func hasAPIKey(c echo.Context, cfgKey string) bool {
+	if cfgKey == "" {
+		return false
+	}
+	h := c.Request().Header.Get(echo.HeaderAuthorization)
+	if !strings.HasPrefix(h, bearerPrefix) {
+		return false
+	}
+	token := strings.TrimPrefix(h, bearerPrefix)
+	return subtle.ConstantTimeCompare([]byte(token), []byte(cfgKey)) == 1
+}
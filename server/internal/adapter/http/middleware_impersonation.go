@@ -0,0 +1,41 @@
+package http
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/adapter"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearthx/log"
+)
+
+// ImpersonationReadOnly rejects the request with 403 when an admin is
+// impersonating the user and the request may change data: anything but GET,
+// HEAD and OPTIONS, except the routes in readOnly (matched against the route
+// pattern, e.g. "/api/permissions/check") that use POST only to carry a query.
+// It is an allowlist so that routes added later are denied until someone
+// decides they are safe; the app layer denies GraphQL mutations the same way.
+func ImpersonationReadOnly(readOnly ...string) echo.MiddlewareFunc {
+	allowed := make(map[string]struct{}, len(readOnly))
+	for _, p := range readOnly {
+		allowed[p] = struct{}{}
+	}
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := c.Request().Context()
+			g := adapter.Impersonation(ctx)
+			if g == nil {
+				return next(c)
+			}
+			switch c.Request().Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(c)
+			}
+			if _, ok := allowed[c.Path()]; ok {
+				return next(c)
+			}
+			log.Warnfc(ctx, "rest: denied %s %s: admin=%s impersonating user=%s", c.Request().Method, c.Path(), g.Admin, g.User)
+			return interfaces.ErrImpersonationDenied
+		}
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/reearth/reearth-accounts/server/internal/adapter"
 	"github.com/reearth/reearth-accounts/server/internal/adapter/http/handlers"
 	httpinternal "github.com/reearth/reearth-accounts/server/internal/adapter/http/internal"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	echoSwagger "github.com/swaggo/echo-swagger"
 )
 
@@ -16,6 +17,9 @@ import (
 type RouterConfig struct {
 	// AuthResolver reuses app/auth.go's FindBySub + operator builder pipeline.
 	AuthResolver AuthResolver
+	// Impersonation authenticates requests carrying an admin-issued
+	// impersonation token, ahead of AuthResolver. May be nil.
+	Impersonation echo.MiddlewareFunc
 	// CacheControl sets response cache headers (e.g. Cache-Control: private) on all REST responses.
 	CacheControl echo.MiddlewareFunc
 	// UsecaseMiddleware attaches *interfaces.Container to context (same as GraphQL path).
@@ -28,6 +32,12 @@ type RouterConfig struct {
 	APIKey string
 	// SyncSSOAPIKey is the dedicated M2M key for the sync-sso route.
 	SyncSSOAPIKey string
+	// MagicLink mounts POST /api/auth/magic-link. The links it mails are
+	// redeemed by the login of the built-in OIDC provider, so it is only set
+	// when that provider is enabled.
+	MagicLink bool
+	// Storage signs uploaded user photos in responses. May be nil.
+	Storage gateway.Storage
 	// Swagger basic-auth (optional).
 	SwaggerUser, SwaggerPass string
 	// Debug enables serving /swagger without credentials (debug/dev only); in
@@ -57,6 +67,9 @@ func RegisterRESTRouter(e *echo.Echo, cfg RouterConfig) {
 		// configured this is a no-op.
 		base = append(base, skipJWTOnAPIKey(cfg.JWTMiddleware, cfg.APIKey, cfg.SyncSSOAPIKey))
 	}
+	if cfg.Impersonation != nil {
+		base = append(base, cfg.Impersonation)
+	}
 	if cfg.UsecaseMiddleware != nil {
 		base = append(base, cfg.UsecaseMiddleware)
 	}
@@ -69,19 +82,29 @@ func RegisterRESTRouter(e *echo.Echo, cfg RouterConfig) {
 	apikeyOrAuth := APIKeyOrAuth(cfg.APIKey)
 	syncSSOApikeyOrAuth := APIKeyOrAuth(cfg.SyncSSOAPIKey)
 
-	api := e.Group("/api", base...)
+	// An admin impersonating a user may only read on their behalf.
+	api := e.Group("/api", append(base, ImpersonationReadOnly("/api/permissions/check"))...)
 
 	// --- Auth ---
-	ah := handlers.NewAuthHandler(cfg.AuthConfigProvider)
+	ah := handlers.NewAuthHandler(cfg.AuthConfigProvider, cfg.Storage)
 	api.GET("/auth/config", ah.Config)            // public
 	api.POST("/auth/logout", ah.Logout, required) // JWT
+	if cfg.MagicLink {
+		api.POST("/auth/magic-link", ah.StartMagicLink) // public
+	}
 
 	// --- Users ---
-	uh := handlers.NewUserHandler()
+	uh := handlers.NewUserHandler(cfg.Storage)
 	api.GET("/users/me", uh.Me, required)
 	api.PATCH("/users/me", uh.UpdateMe, required)
 	api.DELETE("/users/me", uh.DeleteMe, required)
+	api.POST("/users/me/photo", uh.UploadMyPhoto, required) // multipart: file
+	api.POST("/users/me/auths", uh.LinkMyAuth, required)
 	api.DELETE("/users/me/auths/:sub", uh.RemoveMyAuth, required)
+	api.GET("/users/me/passkeys", uh.ListMyPasskeys, required)
+	api.POST("/users/me/passkeys/registration/begin", uh.BeginPasskeyRegistration, required)
+	api.POST("/users/me/passkeys/registration/finish", uh.FinishPasskeyRegistration, required)
+	api.DELETE("/users/me/passkeys/:id", uh.RemoveMyPasskey, required)
 	api.GET("/users/search", uh.Search, required)
 	api.GET("/users/by-alias", uh.FindByAlias, required)
 	api.GET("/users/by-name-or-email", uh.FindByNameOrEmail, required)
@@ -118,6 +141,7 @@ func RegisterRESTRouter(e *echo.Echo, cfg RouterConfig) {
 	api.GET("/workspaces/all", wh.ListAll, required) // ?keyword=&status=active|deleted|all&page=&page_size=
 	api.GET("/workspaces/:id", wh.Get, required)
 	api.PATCH("/workspaces/:id", wh.Update, required)
+	api.POST("/workspaces/:id/photo", wh.UploadPhoto, required) // multipart: file
 	api.DELETE("/workspaces/:id", wh.Delete, required)
 	api.POST("/workspaces/:id/deactivate", wh.Deactivate, required) // soft delete (owner-only)
 	api.POST("/workspaces/:id/restore", wh.Restore, required)       // undo deactivate (owner-only)
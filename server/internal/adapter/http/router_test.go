@@ -3,10 +3,14 @@ package http_test
 import (
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 
 	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/adapter"
 	adapterhttp "github.com/reearth/reearth-accounts/server/internal/adapter/http"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/impersonation"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/appx"
@@ -122,3 +126,82 @@ func TestSyncSSORoute_KeyIsolation(t *testing.T) {
 		assert.Equal(t, http.StatusUnauthorized, rec.Code, "SyncSSOAPIKey must not grant access to %s", path)
 	}
 }
+
+// TestRegisterRESTRouter_MagicLink verifies that magic links are only served
+// when the built-in OIDC provider that redeems them is enabled.
+func TestRegisterRESTRouter_MagicLink(t *testing.T) {
+	for _, enabled := range []bool{false, true} {
+		e := newRESTEcho(adapterhttp.RouterConfig{
+			AuthConfigProvider: stubAuthConfigProvider{},
+			MagicLink:          enabled,
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/auth/magic-link", strings.NewReader(`{}`))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		if enabled {
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		} else {
+			assert.Equal(t, http.StatusNotFound, rec.Code)
+		}
+	}
+}
+
+// TestImpersonation_DeniedRoutes verifies that an admin impersonating a user
+// gets 403 from every route that may change data, and only from those.
+func TestImpersonation_DeniedRoutes(t *testing.T) {
+	u := user.New().NewID().Name("target").Email("target@example.com").MustBuild()
+	impersonate := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			ctx := adapter.AttachUser(c.Request().Context(), u)
+			ctx = adapter.AttachOperator(ctx, &workspace.Operator{User: u.ID().Ref()})
+			ctx = adapter.AttachImpersonation(ctx, &impersonation.Grant{Admin: adminuser.NewID(), User: u.ID()})
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+	resolver := func(c echo.Context, _ *appx.AuthInfo) (*user.User, *workspace.Operator, error) {
+		ctx := c.Request().Context()
+		return adapter.User(ctx), adapter.Operator(ctx), nil
+	}
+
+	e := newRESTEcho(adapterhttp.RouterConfig{
+		AuthConfigProvider: stubAuthConfigProvider{},
+		AuthResolver:       resolver,
+		Impersonation:      impersonate,
+	})
+
+	for _, r := range []struct{ method, path string }{
+		{http.MethodDelete, "/api/users/me"},
+		{http.MethodPatch, "/api/users/me"},
+		{http.MethodDelete, "/api/users/me/passkeys/p1"},
+		{http.MethodDelete, "/api/workspaces/w1"},
+		{http.MethodDelete, "/api/workspaces/w1/members/u1"},
+		{http.MethodPost, "/api/workspaces/w1/transfer-ownership"},
+		{http.MethodPost, "/api/users/me/photo"},
+		{http.MethodPost, "/api/users/u1/restore"},
+		{http.MethodPost, "/api/workspaces"},
+		{http.MethodPatch, "/api/workspaces/w1"},
+		{http.MethodPost, "/api/workspaces/w1/photo"},
+		{http.MethodPost, "/api/workspaces/w1/restore"},
+		{http.MethodPost, "/api/workspaces/w1/members"},
+		{http.MethodPatch, "/api/workspaces/w1/members/u1"},
+		{http.MethodPost, "/api/workspaces/w1/integrations"},
+		{http.MethodPatch, "/api/workspaces/w1/integrations/i1"},
+	} {
+		req := httptest.NewRequest(r.method, r.path, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusForbidden, rec.Code, "%s %s", r.method, r.path)
+	}
+
+	for _, r := range []struct{ method, path string }{
+		{http.MethodGet, "/api/auth/config"},
+		{http.MethodPost, "/api/permissions/check"},
+	} {
+		req := httptest.NewRequest(r.method, r.path, nil)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.NotEqual(t, http.StatusForbidden, rec.Code, "%s %s", r.method, r.path)
+	}
+}
@@ -0,0 +1,222 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/config"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/util"
+	jose "gopkg.in/go-jose/go-jose.v2"
+)
+
+const signingAlg = "RS256"
+
+var (
+	errInvalidKey   = errors.New("oidc: invalid signing key")
+	errNoSigningKey = errors.New("oidc: no active signing key")
+)
+
+// Key is one version of the RSA signing key of the provider together with its
+// self-signed certificate, which is published as x5c in the JWKS.
+type Key struct {
+	// ID is the kid of the key version, or the RFC 7638 thumbprint of the
+	// public key for the pair saved before keys were versioned.
+	ID          string
+	ActivatedAt time.Time
+	RetiredAt   *time.Time
+	private     *rsa.PrivateKey
+	cert        *x509.Certificate
+}
+
+// KeySet holds the published versions of the signing key. Which version signs
+// and which ones verify is decided by their activation and retirement times
+// whenever a key is used, so a scheduled rotation takes effect on every
+// instance at the same time. Refresh picks up rotations made after loading.
+type KeySet struct {
+	repo config.Repo
+	mu   sync.RWMutex
+	keys []*Key
+}
+
+// LoadKeys returns the signing keys stored in the config repo. On first start
+// it generates a key and saves it, holding the config lock so that several
+// instances starting at once agree on a single key.
+func LoadKeys(ctx context.Context, r config.Repo) (*KeySet, error) {
+	cfg, err := r.LockAndLoad(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := r.Unlock(ctx); err != nil {
+			log.Errorfc(ctx, "oidc: could not release config lock: %s", err)
+		}
+	}()
+
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	if len(cfg.AuthKeys()) == 0 {
+		a, err := config.GenerateAuth(util.Now())
+		if err != nil {
+			return nil, err
+		}
+		cfg.Keys = []config.Auth{*a}
+		if err := r.Save(ctx, cfg); err != nil {
+			return nil, err
+		}
+		log.Infoc(ctx, "oidc: generated a new signing key")
+	}
+
+	s := &KeySet{repo: r}
+	if err := s.set(cfg); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Refresh reloads the keys from the config repo. It does not take the config
+// lock, so it neither waits for nor delays a rotation or migration.
+func (s *KeySet) Refresh(ctx context.Context) error {
+	cfg, err := s.repo.Load(ctx)
+	if err != nil {
+		return err
+	}
+	return s.set(cfg)
+}
+
+// RefreshEvery calls Refresh at the given interval until ctx is done.
+func (s *KeySet) RefreshEvery(ctx context.Context, interval time.Duration) {
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			if err := s.Refresh(ctx); err != nil {
+				log.Errorfc(ctx, "oidc: failed to refresh signing keys: %s", err)
+			}
+		}
+	}
+}
+
+func (s *KeySet) set(cfg *config.Config) error {
+	var keys []*Key
+	for _, a := range cfg.PublishedAuthKeys(util.Now()) {
+		k, err := ParseKey(&a)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return errNoSigningKey
+	}
+	s.mu.Lock()
+	s.keys = keys
+	s.mu.Unlock()
+	return nil
+}
+
+// Published returns the keys verifiers must accept now, including a key that
+// is about to start signing.
+func (s *KeySet) Published() []*Key {
+	now := util.Now()
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var res []*Key
+	for _, k := range s.keys {
+		if k.RetiredAt == nil || k.RetiredAt.After(now) {
+			res = append(res, k)
+		}
+	}
+	return res
+}
+
+// Signing returns the most recently activated key.
+func (s *KeySet) Signing() *Key {
+	now := util.Now()
+	var res *Key
+	for _, k := range s.Published() {
+		if k.ActivatedAt.After(now) {
+			continue
+		}
+		if res == nil || k.ActivatedAt.After(res.ActivatedAt) {
+			res = k
+		}
+	}
+	return res
+}
+
+// Lookup returns the published key with the given kid.
+func (s *KeySet) Lookup(kid string) *Key {
+	for _, k := range s.Published() {
+		if k.ID == kid {
+			return k
+		}
+	}
+	return nil
+}
+
+// ParseKey decodes a PEM key pair as stored in config.Auth. Both PKCS#1 and
+// PKCS#8 private keys are accepted.
+func ParseKey(a *config.Auth) (*Key, error) {
+	if a == nil {
+		return nil, errInvalidKey
+	}
+	kb, _ := pem.Decode([]byte(a.Key))
+	if kb == nil {
+		return nil, errInvalidKey
+	}
+	var priv *rsa.PrivateKey
+	if k, err := x509.ParsePKCS1PrivateKey(kb.Bytes); err == nil {
+		priv = k
+	} else if k, err := x509.ParsePKCS8PrivateKey(kb.Bytes); err == nil {
+		rk, ok := k.(*rsa.PrivateKey)
+		if !ok {
+			return nil, errInvalidKey
+		}
+		priv = rk
+	} else {
+		return nil, errInvalidKey
+	}
+
+	k := &Key{
+		ID:          a.KID,
+		ActivatedAt: a.ActivatedAt,
+		RetiredAt:   a.RetiredAt,
+		private:     priv,
+	}
+	if k.ID == "" {
+		k.ID = config.Thumbprint(&priv.PublicKey)
+	}
+	if cb, _ := pem.Decode([]byte(a.Cert)); cb != nil {
+		cert, err := x509.ParseCertificate(cb.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("oidc: failed to parse certificate: %w", err)
+		}
+		k.cert = cert
+	}
+	return k, nil
+}
+
+// JWK returns the public half of the key as published in the JWKS.
+func (k *Key) JWK() jose.JSONWebKey {
+	jwk := jose.JSONWebKey{
+		Key:       k.private.Public(),
+		KeyID:     k.ID,
+		Algorithm: signingAlg,
+		Use:       "sig",
+	}
+	if k.cert != nil {
+		jwk.Certificates = []*x509.Certificate{k.cert}
+	}
+	return jwk
+}
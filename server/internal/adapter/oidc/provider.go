@@ -0,0 +1,580 @@
+// Package oidc implements the optional built-in OpenID Connect provider. It
+// issues reearth-accounts' own RS256 tokens to users of the reearth password
+// provider, so self-hosted deployments can run without Auth0 or CIP.
+//
+// Only the authorization code flow with PKCE (S256) and the refresh token grant
+// are supported, for a single public client. The login page itself is served
+// by the web app, which posts the credentials, a passkey assertion or the token
+// of a magic link back to LoginPath. The pending request is bound to the
+// browser that made it by a cookie, so a login form can't be posted on behalf
+// of another browser, and refresh tokens are rotated on every use.
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/util"
+	jose "gopkg.in/go-jose/go-jose.v2"
+)
+
+const (
+	DiscoveryPath = "/.well-known/openid-configuration"
+	JWKSPath      = "/.well-known/jwks.json"
+	AuthorizePath = "/oauth/authorize"
+	TokenPath     = "/oauth/token"
+	UserInfoPath  = "/oauth/userinfo"
+	// LoginPath receives the login form of the web app; the path is the one the
+	// Re:Earth web login page already posts to.
+	LoginPath = "/api/login"
+	// LoginPasskeyPath returns the WebAuthn options for signing in with a passkey.
+	LoginPasskeyPath = "/api/login/passkey"
+
+	// authRequestTTL bounds how long the user may take on the login page.
+	authRequestTTL = 10 * time.Minute
+	// loginCookie identifies the browser that made an authorization request.
+	loginCookie = "reearth_oidc_login"
+)
+
+const (
+	scopeOpenID        = "openid"
+	scopeProfile       = "profile"
+	scopeEmail         = "email"
+	scopeOfflineAccess = "offline_access"
+)
+
+var supportedScopes = []string{scopeOpenID, scopeProfile, scopeEmail, scopeOfflineAccess}
+
+type Config struct {
+	// Issuer is the public base URL of the service, used verbatim as iss.
+	Issuer       string
+	ClientID     string
+	RedirectURIs []string
+	// LoginURL is the web app login page. The pending request is passed as ?id=.
+	LoginURL        string
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+	// RefreshTokenMaxLifetime ends a sign-in however often it is refreshed.
+	RefreshTokenMaxLifetime time.Duration
+}
+
+// Users is the part of the user interactor the provider depends on.
+type Users interface {
+	GetUserByCredentials(context.Context, interfaces.GetUserByCredentials) (*user.User, error)
+	BeginPasskeyLogin(context.Context, string) (options json.RawMessage, session string, err error)
+	GetUserByPasskey(context.Context, interfaces.GetUserByPasskey) (*user.User, error)
+	GetUserByMagicLink(context.Context, interfaces.GetUserByMagicLink) (*user.User, error)
+	IssueAuthCode(context.Context, user.ID, user.AuthCode) (string, error)
+	RedeemAuthCode(context.Context, interfaces.RedeemAuthCodeParam) (*user.User, *user.AuthCode, error)
+	StartRefreshTokenFamily(ctx context.Context, uid user.ID, expiresAt, maxExpiresAt time.Time) (user.RefreshTokenFamily, error)
+	RotateRefreshToken(ctx context.Context, uid user.ID, family string, generation int, expiresAt time.Time) (user.RefreshTokenFamily, error)
+	FetchBySub(context.Context, string) (*user.User, error)
+}
+
+type Provider struct {
+	cfg   Config
+	keys  *KeySet
+	users Users
+}
+
+func New(cfg Config, keys *KeySet, users Users) *Provider {
+	return &Provider{cfg: cfg, keys: keys, users: users}
+}
+
+// Register mounts the provider endpoints on e.
+func (p *Provider) Register(e *echo.Echo) {
+	e.GET(DiscoveryPath, p.Discovery)
+	e.GET(JWKSPath, p.JWKS)
+	e.GET(AuthorizePath, p.Authorize)
+	e.POST(LoginPath, p.Login)
+	e.POST(LoginPasskeyPath, p.BeginPasskeyLogin)
+	e.POST(TokenPath, p.Token)
+	e.GET(UserInfoPath, p.UserInfo)
+	e.POST(UserInfoPath, p.UserInfo)
+}
+
+type discoveryResponse struct {
+	Issuer                            string   `json:"issuer"`
+	AuthorizationEndpoint             string   `json:"authorization_endpoint"`
+	TokenEndpoint                     string   `json:"token_endpoint"`
+	UserinfoEndpoint                  string   `json:"userinfo_endpoint"`
+	JWKSURI                           string   `json:"jwks_uri"`
+	ScopesSupported                   []string `json:"scopes_supported"`
+	ResponseTypesSupported            []string `json:"response_types_supported"`
+	GrantTypesSupported               []string `json:"grant_types_supported"`
+	SubjectTypesSupported             []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported  []string `json:"id_token_signing_alg_values_supported"`
+	TokenEndpointAuthMethodsSupported []string `json:"token_endpoint_auth_methods_supported"`
+	CodeChallengeMethodsSupported     []string `json:"code_challenge_methods_supported"`
+	ClaimsSupported                   []string `json:"claims_supported"`
+}
+
+func (p *Provider) Discovery(c echo.Context) error {
+	return c.JSON(http.StatusOK, discoveryResponse{
+		Issuer:                            p.cfg.Issuer,
+		AuthorizationEndpoint:             p.endpoint(AuthorizePath),
+		TokenEndpoint:                     p.endpoint(TokenPath),
+		UserinfoEndpoint:                  p.endpoint(UserInfoPath),
+		JWKSURI:                           p.endpoint(JWKSPath),
+		ScopesSupported:                   supportedScopes,
+		ResponseTypesSupported:            []string{"code"},
+		GrantTypesSupported:               []string{"authorization_code", "refresh_token"},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{signingAlg},
+		TokenEndpointAuthMethodsSupported: []string{"none"},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "nonce", "name", "email", "email_verified"},
+	})
+}
+
+// JWKS publishes every key that has not been retired, so tokens signed before
+// a rotation and after it both verify.
+func (p *Provider) JWKS(c echo.Context) error {
+	set := jose.JSONWebKeySet{Keys: []jose.JSONWebKey{}}
+	for _, k := range p.keys.Published() {
+		set.Keys = append(set.Keys, k.JWK())
+	}
+	return c.JSON(http.StatusOK, set)
+}
+
+// Authorize validates an authorization request and sends the user to the login
+// page. Errors about the client or redirect URI are shown directly, since
+// redirecting to an unverified URI would make the endpoint an open redirector.
+func (p *Provider) Authorize(c echo.Context) error {
+	clientID := c.QueryParam("client_id")
+	redirectURI := c.QueryParam("redirect_uri")
+	if clientID != p.cfg.ClientID {
+		return oauthError(c, http.StatusBadRequest, "invalid_client", "unknown client_id")
+	}
+	if !slices.Contains(p.cfg.RedirectURIs, redirectURI) {
+		return oauthError(c, http.StatusBadRequest, "invalid_request", "redirect_uri is not registered for the client")
+	}
+
+	state := c.QueryParam("state")
+	fail := func(code, description string) error {
+		return c.Redirect(http.StatusFound, withQuery(redirectURI, url.Values{
+			"error":             {code},
+			"error_description": {description},
+			"state":             {state},
+		}))
+	}
+
+	if c.QueryParam("response_type") != "code" {
+		return fail("unsupported_response_type", "only the authorization code flow is supported")
+	}
+	scopes := parseScopes(c.QueryParam("scope"))
+	if !slices.Contains(scopes, scopeOpenID) {
+		return fail("invalid_scope", "the openid scope is required")
+	}
+	challenge := c.QueryParam("code_challenge")
+	if challenge == "" || c.QueryParam("code_challenge_method") != "S256" {
+		return fail("invalid_request", "PKCE with code_challenge_method=S256 is required")
+	}
+
+	browser, err := p.loginCookie(c)
+	if err != nil {
+		return err
+	}
+	id, err := p.sign(typAuthRequest, authRequestClaims{
+		RegisteredClaims: p.registered("", []string{p.cfg.Issuer}, authRequestTTL),
+		ClientID:         clientID,
+		RedirectURI:      redirectURI,
+		Scope:            scopeString(scopes),
+		State:            state,
+		Nonce:            c.QueryParam("nonce"),
+		CodeChallenge:    challenge,
+		Browser:          browserHash(browser),
+	})
+	if err != nil {
+		return err
+	}
+	return c.Redirect(http.StatusFound, withQuery(p.cfg.LoginURL, url.Values{"id": {id}}))
+}
+
+type loginForm struct {
+	Email    string `json:"username" form:"username"`
+	Password string `json:"password" form:"password"`
+	MFACode  string `json:"mfa_code" form:"mfa_code"`
+	// Passkey is the JSON-encoded PublicKeyCredential from
+	// navigator.credentials.get, posted instead of the password with the
+	// session returned by LoginPasskeyPath.
+	Passkey        string `json:"passkey" form:"passkey"`
+	PasskeySession string `json:"passkey_session" form:"passkey_session"`
+	// MagicLinkToken is the token of a link mailed by StartMagicLink, posted
+	// instead of the password.
+	MagicLinkToken string `json:"magic_link_token" form:"magic_link_token"`
+	ID             string `json:"id" form:"id"`
+}
+
+// BeginPasskeyLogin returns the WebAuthn request options for signing in to a
+// pending authorization request with a passkey, with the session of the
+// ceremony added as "session". The login page passes the options to
+// navigator.credentials.get and posts the assertion and the session to
+// LoginPath.
+func (p *Provider) BeginPasskeyLogin(c echo.Context) error {
+	ctx := c.Request().Context()
+	f := loginForm{}
+	if err := c.Bind(&f); err != nil {
+		return oauthError(c, http.StatusBadRequest, "invalid_request", "bad request")
+	}
+	if err := p.parse(f.ID, typAuthRequest, p.cfg.Issuer, &authRequestClaims{}); err != nil {
+		return oauthError(c, http.StatusBadRequest, "invalid_request", "the sign-in request has expired")
+	}
+
+	options, session, err := p.users.BeginPasskeyLogin(ctx, f.Email)
+	switch {
+	case errors.Is(err, interfaces.ErrPasskeyNotConfigured):
+		return oauthError(c, http.StatusNotImplemented, "invalid_request", err.Error())
+	case err != nil:
+		log.Debugfc(ctx, "oidc: passkey login failed: %s", err)
+		return oauthError(c, http.StatusBadRequest, "invalid_request", "the account cannot sign in with a passkey")
+	}
+	res := map[string]json.RawMessage{}
+	if err := json.Unmarshal(options, &res); err != nil {
+		return err
+	}
+	res["session"], err = json.Marshal(session)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, res)
+}
+
+// Login authenticates the user for a pending authorization request and
+// redirects back to the client with an authorization code. Failures go back to
+// the login page with an error message, keeping the request ID so the user can
+// retry.
+func (p *Provider) Login(c echo.Context) error {
+	ctx := c.Request().Context()
+	f := loginForm{}
+	if err := c.Bind(&f); err != nil {
+		return c.Redirect(http.StatusFound, withQuery(p.cfg.LoginURL, url.Values{"error": {"bad request"}}))
+	}
+
+	req := authRequestClaims{}
+	if err := p.parse(f.ID, typAuthRequest, p.cfg.Issuer, &req); err != nil {
+		return c.Redirect(http.StatusFound, withQuery(p.cfg.LoginURL, url.Values{"error": {"the sign-in request has expired"}}))
+	}
+	if !fromBrowser(c, req) {
+		return c.Redirect(http.StatusFound, withQuery(p.cfg.LoginURL, url.Values{"error": {"the sign-in request was started in another browser"}}))
+	}
+	retry := func(msg string) error {
+		return c.Redirect(http.StatusFound, withQuery(p.cfg.LoginURL, url.Values{"id": {f.ID}, "error": {msg}}))
+	}
+
+	u, failed, err := p.authenticate(ctx, f)
+	switch {
+	case errors.Is(err, interfaces.ErrMFARequired), errors.Is(err, user.ErrInvalidMFACode), errors.Is(err, interfaces.ErrNotVerifiedUser):
+		return retry(err.Error())
+	case err != nil:
+		log.Debugfc(ctx, "oidc: login failed: %s", err)
+		return retry(failed)
+	case !u.Auths().HasProvider(user.ProviderReearth):
+		return retry(failed)
+	}
+
+	code, err := p.users.IssueAuthCode(ctx, u.ID(), user.AuthCode{
+		ClientID:      req.ClientID,
+		RedirectURI:   req.RedirectURI,
+		Scopes:        parseScopes(req.Scope),
+		Nonce:         req.Nonce,
+		CodeChallenge: req.CodeChallenge,
+		AuthTime:      util.Now(),
+	})
+	if err != nil {
+		return err
+	}
+	return c.Redirect(http.StatusFound, withQuery(req.RedirectURI, url.Values{"code": {code}, "state": {req.State}}))
+}
+
+// authenticate checks the password, the passkey assertion or the magic link
+// token of the login form.
+// It also returns the message shown when the check fails, which doesn't tell
+// which part of the form was wrong.
+func (p *Provider) authenticate(ctx context.Context, f loginForm) (*user.User, string, error) {
+	if f.Passkey != "" {
+		u, err := p.users.GetUserByPasskey(ctx, interfaces.GetUserByPasskey{
+			Session:  f.PasskeySession,
+			Response: []byte(f.Passkey),
+		})
+		return u, "invalid passkey", err
+	}
+	if f.MagicLinkToken != "" {
+		u, err := p.users.GetUserByMagicLink(ctx, interfaces.GetUserByMagicLink{
+			Email:   f.Email,
+			Token:   f.MagicLinkToken,
+			MFACode: f.MFACode,
+		})
+		return u, "the sign-in link is invalid or has expired", err
+	}
+	u, err := p.users.GetUserByCredentials(ctx, interfaces.GetUserByCredentials{
+		Email:    f.Email,
+		Password: f.Password,
+		MFACode:  f.MFACode,
+	})
+	return u, "invalid email or password", err
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	IDToken      string `json:"id_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope,omitempty"`
+}
+
+func (p *Provider) Token(c echo.Context) error {
+	ctx := c.Request().Context()
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	if c.FormValue("client_id") != p.cfg.ClientID {
+		return oauthError(c, http.StatusUnauthorized, "invalid_client", "unknown client_id")
+	}
+
+	switch c.FormValue("grant_type") {
+	case "authorization_code":
+		u, ac, err := p.users.RedeemAuthCode(ctx, interfaces.RedeemAuthCodeParam{
+			Code:         c.FormValue("code"),
+			ClientID:     c.FormValue("client_id"),
+			RedirectURI:  c.FormValue("redirect_uri"),
+			CodeVerifier: c.FormValue("code_verifier"),
+		})
+		if errors.Is(err, interfaces.ErrInvalidAuthCode) {
+			return oauthError(c, http.StatusBadRequest, "invalid_grant", "invalid authorization code")
+		} else if err != nil {
+			return err
+		}
+		var fam *user.RefreshTokenFamily
+		if slices.Contains(ac.Scopes, scopeOfflineAccess) {
+			now := util.Now()
+			f, err := p.users.StartRefreshTokenFamily(ctx, u.ID(), now.Add(p.cfg.RefreshTokenTTL), now.Add(p.cfg.RefreshTokenMaxLifetime))
+			if err != nil {
+				return err
+			}
+			fam = &f
+		}
+		return p.issueTokens(c, u, ac.Scopes, ac.AuthTime, ac.Nonce, fam)
+
+	case "refresh_token":
+		rt := refreshTokenClaims{}
+		if err := p.parse(c.FormValue("refresh_token"), typRefreshToken, p.cfg.Issuer, &rt); err != nil || rt.ClientID != p.cfg.ClientID {
+			return oauthError(c, http.StatusBadRequest, "invalid_grant", "invalid refresh token")
+		}
+		u, err := p.users.FetchBySub(ctx, rt.Subject)
+		if err != nil || u.IsDeleted() {
+			return oauthError(c, http.StatusBadRequest, "invalid_grant", "invalid refresh token")
+		}
+		// Logging out revokes refresh tokens issued before it.
+		if !u.LatestLogoutAt().IsZero() && !rt.IssuedAt.After(u.LatestLogoutAt()) {
+			return oauthError(c, http.StatusBadRequest, "invalid_grant", "invalid refresh token")
+		}
+		// Each refresh token is accepted once; presenting a rotated one again
+		// revokes every token of the sign-in.
+		fam, err := p.users.RotateRefreshToken(ctx, u.ID(), rt.Family, rt.Generation, util.Now().Add(p.cfg.RefreshTokenTTL))
+		if errors.Is(err, user.ErrRefreshTokenReused) {
+			log.Warnfc(ctx, "oidc: refresh token reused, sign-in revoked: user=%s", u.ID())
+			return oauthError(c, http.StatusBadRequest, "invalid_grant", "invalid refresh token")
+		} else if errors.Is(err, user.ErrInvalidRefreshToken) {
+			return oauthError(c, http.StatusBadRequest, "invalid_grant", "invalid refresh token")
+		} else if err != nil {
+			return err
+		}
+		var authTime time.Time
+		if rt.AuthTime != nil {
+			authTime = rt.AuthTime.Time
+		}
+		return p.issueTokens(c, u, parseScopes(rt.Scope), authTime, "", &fam)
+
+	default:
+		return oauthError(c, http.StatusBadRequest, "unsupported_grant_type", "only authorization_code and refresh_token are supported")
+	}
+}
+
+// issueTokens issues the access and ID tokens, and a refresh token of fam if
+// it is not nil.
+func (p *Provider) issueTokens(c echo.Context, u *user.User, scopes []string, authTime time.Time, nonce string, fam *user.RefreshTokenFamily) error {
+	a := u.Auths().GetByProvider(user.ProviderReearth)
+	if a == nil {
+		return oauthError(c, http.StatusBadRequest, "invalid_grant", "the user cannot sign in with this provider")
+	}
+	sub := a.Sub
+	uc := newUserClaims(u, scopes)
+
+	res := tokenResponse{
+		TokenType: "Bearer",
+		ExpiresIn: int64(p.cfg.AccessTokenTTL / time.Second),
+		Scope:     scopeString(scopes),
+	}
+
+	var err error
+	res.AccessToken, err = p.sign(typAccessToken, accessTokenClaims{
+		RegisteredClaims: p.registered(sub, []string{p.cfg.ClientID}, p.cfg.AccessTokenTTL),
+		userClaims:       uc,
+		ClientID:         p.cfg.ClientID,
+		Scope:            res.Scope,
+	})
+	if err != nil {
+		return err
+	}
+
+	var at *jwt.NumericDate
+	if !authTime.IsZero() {
+		at = jwt.NewNumericDate(authTime)
+	}
+	res.IDToken, err = p.sign(typIDToken, idTokenClaims{
+		RegisteredClaims: p.registered(sub, []string{p.cfg.ClientID}, p.cfg.AccessTokenTTL),
+		userClaims:       uc,
+		AuthTime:         at,
+		Nonce:            nonce,
+	})
+	if err != nil {
+		return err
+	}
+
+	if fam != nil {
+		rc := p.registered(sub, []string{p.cfg.Issuer}, p.cfg.RefreshTokenTTL)
+		rc.ExpiresAt = jwt.NewNumericDate(fam.ExpiresAt)
+		res.RefreshToken, err = p.sign(typRefreshToken, refreshTokenClaims{
+			RegisteredClaims: rc,
+			ClientID:         p.cfg.ClientID,
+			Scope:            res.Scope,
+			AuthTime:         at,
+			Family:           fam.ID,
+			Generation:       fam.Generation,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return c.JSON(http.StatusOK, res)
+}
+
+type userInfoResponse struct {
+	Sub string `json:"sub"`
+	userClaims
+}
+
+func (p *Provider) UserInfo(c echo.Context) error {
+	ctx := c.Request().Context()
+	token, ok := strings.CutPrefix(c.Request().Header.Get(echo.HeaderAuthorization), "Bearer ")
+	claims := accessTokenClaims{}
+	if !ok || p.parse(token, typAccessToken, p.cfg.ClientID, &claims) != nil {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+		return oauthError(c, http.StatusUnauthorized, "invalid_token", "invalid access token")
+	}
+	u, err := p.users.FetchBySub(ctx, claims.Subject)
+	if err != nil || u.IsDeleted() {
+		c.Response().Header().Set(echo.HeaderWWWAuthenticate, `Bearer error="invalid_token"`)
+		return oauthError(c, http.StatusUnauthorized, "invalid_token", "invalid access token")
+	}
+	return c.JSON(http.StatusOK, userInfoResponse{
+		Sub:        claims.Subject,
+		userClaims: newUserClaims(u, parseScopes(claims.Scope)),
+	})
+}
+
+// loginCookie returns the login cookie of the browser, setting a new one if it
+// has none yet. An existing cookie is kept so that requests pending in other
+// tabs stay valid.
+func (p *Provider) loginCookie(c echo.Context) (string, error) {
+	value := ""
+	if ck, err := c.Cookie(loginCookie); err == nil && len(ck.Value) == base64.RawURLEncoding.EncodedLen(32) {
+		value = ck.Value
+	} else {
+		b := make([]byte, 32)
+		if _, err := rand.Read(b); err != nil {
+			return "", err
+		}
+		value = base64.RawURLEncoding.EncodeToString(b)
+	}
+	// The login form may be posted from the web app on another site, which
+	// only sends SameSite=None cookies.
+	secure := strings.HasPrefix(p.cfg.Issuer, "https://")
+	sameSite := http.SameSiteLaxMode
+	if secure {
+		sameSite = http.SameSiteNoneMode
+	}
+	c.SetCookie(&http.Cookie{
+		Name:     loginCookie,
+		Value:    value,
+		Path:     "/",
+		MaxAge:   int(authRequestTTL / time.Second),
+		HttpOnly: true,
+		Secure:   secure,
+		SameSite: sameSite,
+	})
+	return value, nil
+}
+
+// fromBrowser reports whether the request carries the login cookie of the
+// browser that made the authorization request.
+func fromBrowser(c echo.Context, req authRequestClaims) bool {
+	ck, err := c.Cookie(loginCookie)
+	if err != nil || req.Browser == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(browserHash(ck.Value)), []byte(req.Browser)) == 1
+}
+
+func browserHash(cookie string) string {
+	sum := sha256.Sum256([]byte(cookie))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (p *Provider) endpoint(path string) string {
+	return strings.TrimSuffix(p.cfg.Issuer, "/") + path
+}
+
+type oauthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+func oauthError(c echo.Context, status int, code, description string) error {
+	return c.JSON(status, oauthErrorResponse{Error: code, ErrorDescription: description})
+}
+
+// parseScopes keeps the supported scopes of a space-delimited scope parameter,
+// without duplicates and in a stable order.
+func parseScopes(s string) []string {
+	requested := strings.Fields(s)
+	res := make([]string, 0, len(supportedScopes))
+	for _, sc := range supportedScopes {
+		if slices.Contains(requested, sc) {
+			res = append(res, sc)
+		}
+	}
+	return res
+}
+
+func withQuery(base string, q url.Values) string {
+	u, err := url.Parse(base)
+	if err != nil {
+		return base
+	}
+	v := u.Query()
+	for k, vs := range q {
+		if len(vs) > 0 && vs[0] != "" {
+			v[k] = vs
+		}
+	}
+	u.RawQuery = v.Encode()
+	return u.String()
+}
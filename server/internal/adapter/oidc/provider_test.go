@@ -0,0 +1,433 @@
+package oidc
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interactor"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/config"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/go-jose/go-jose.v2"
+)
+
+const (
+	testIssuer   = "https://accounts.example.com"
+	testClientID = "reearth"
+	testRedirect = "https://app.example.com/callback"
+	testVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+)
+
+func TestLoadKeys(t *testing.T) {
+	ctx := context.Background()
+	r := memory.NewConfig()
+
+	k1, err := LoadKeys(ctx, r)
+	require.NoError(t, err)
+	require.NotNil(t, k1.Signing())
+	assert.NotEmpty(t, k1.Signing().ID)
+
+	// The generated key is persisted and reused.
+	k2, err := LoadKeys(ctx, r)
+	require.NoError(t, err)
+	assert.Equal(t, k1.Signing().ID, k2.Signing().ID)
+
+	// A pair saved before keys were versioned is identified by its thumbprint.
+	legacy := memory.NewConfig()
+	a, err := config.GenerateAuth(time.Now())
+	require.NoError(t, err)
+	require.NoError(t, legacy.SaveAuth(ctx, &config.Auth{Cert: a.Cert, Key: a.Key}))
+	k3, err := LoadKeys(ctx, legacy)
+	require.NoError(t, err)
+	require.NotNil(t, k3.Signing())
+	assert.Equal(t, a.KID, k3.Signing().ID)
+}
+
+func TestKeySet_Rotation(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().Truncate(time.Second)
+	defer util.MockNow(now)()
+	r := memory.NewConfig()
+	keys, err := LoadKeys(ctx, r)
+	require.NoError(t, err)
+	p := New(Config{Issuer: testIssuer, ClientID: testClientID}, keys, nil)
+	e := echo.New()
+	p.Register(e)
+
+	old := keys.Signing().ID
+	token, err := p.sign(typAccessToken, p.registered("sub", []string{testClientID}, 72*time.Hour))
+	require.NoError(t, err)
+
+	cfg, err := r.LockAndLoad(ctx)
+	require.NoError(t, err)
+	next, err := config.GenerateAuth(now)
+	require.NoError(t, err)
+	require.NoError(t, cfg.RotateAuthKey(*next, now, time.Hour))
+	require.NoError(t, r.SaveAndUnlock(ctx, cfg))
+	require.NoError(t, keys.Refresh(ctx))
+
+	// the new key is published but does not sign yet
+	assert.ElementsMatch(t, []string{old, next.KID}, jwksKeyIDs(t, e))
+	assert.Equal(t, old, keys.Signing().ID)
+
+	// after the overlap it signs, and tokens of the old key still verify
+	util.MockNow(now.Add(time.Hour))
+	assert.Equal(t, next.KID, keys.Signing().ID)
+	assert.NoError(t, p.parse(token, typAccessToken, testClientID, &accessTokenClaims{}))
+	assert.ElementsMatch(t, []string{old, next.KID}, jwksKeyIDs(t, e))
+
+	// once retired, the old key is withdrawn
+	util.MockNow(now.Add(2 * time.Hour))
+	assert.Equal(t, []string{next.KID}, jwksKeyIDs(t, e))
+	assert.ErrorIs(t, p.parse(token, typAccessToken, testClientID, &accessTokenClaims{}), errInvalidToken)
+}
+
+func jwksKeyIDs(t *testing.T, e *echo.Echo) []string {
+	t.Helper()
+	res := serve(e, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	var jwks jose.JSONWebKeySet
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &jwks))
+	var ids []string
+	for _, k := range jwks.Keys {
+		ids = append(ids, k.KeyID)
+	}
+	return ids
+}
+
+func TestProvider(t *testing.T) {
+	user.DefaultPasswordEncoder = &user.NoopPasswordEncoder{}
+	ctx := context.Background()
+	r := memory.New()
+
+	uid := id.NewUserID()
+	u := user.New().
+		ID(uid).
+		Workspace(id.NewWorkspaceID()).
+		Name("Test User").
+		Email("test@example.com").
+		PasswordPlainText("PAss00!!").
+		Auths([]user.Auth{*user.ReearthSub(uid.String())}).
+		Verification(user.VerificationFrom("code", time.Now().Add(time.Hour), true)).
+		MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	keys, err := LoadKeys(ctx, r.Config)
+	require.NoError(t, err)
+	users := interactor.NewUser(r, &gateway.Container{}, nil, "", "").(*interactor.User)
+	p := New(Config{
+		Issuer:          testIssuer,
+		ClientID:        testClientID,
+		RedirectURIs:    []string{testRedirect},
+		LoginURL:        "https://app.example.com/login",
+		AccessTokenTTL:  time.Hour,
+		RefreshTokenTTL: 24 * time.Hour,
+
+		RefreshTokenMaxLifetime: 90 * 24 * time.Hour,
+	}, keys, users)
+	e := echo.New()
+	p.Register(e)
+
+	// discovery and JWKS
+	res := serve(e, httptest.NewRequest(http.MethodGet, DiscoveryPath, nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	var disc discoveryResponse
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &disc))
+	assert.Equal(t, testIssuer, disc.Issuer)
+	assert.Equal(t, testIssuer+JWKSPath, disc.JWKSURI)
+	assert.Equal(t, []string{"S256"}, disc.CodeChallengeMethodsSupported)
+
+	res = serve(e, httptest.NewRequest(http.MethodGet, JWKSPath, nil))
+	require.Equal(t, http.StatusOK, res.Code)
+	var jwks jose.JSONWebKeySet
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &jwks))
+	require.Len(t, jwks.Keys, 1)
+	assert.Equal(t, keys.Signing().ID, jwks.Keys[0].KeyID)
+	assert.True(t, jwks.Keys[0].IsPublic())
+
+	// authorization request
+	res = serve(e, authorizeRequest(url.Values{"redirect_uri": {"https://evil.example.com"}}))
+	assert.Equal(t, http.StatusBadRequest, res.Code, "unregistered redirect URIs must not be redirected to")
+
+	res = serve(e, authorizeRequest(url.Values{"code_challenge_method": {"plain"}}))
+	require.Equal(t, http.StatusFound, res.Code)
+	loc := location(t, res)
+	assert.Equal(t, "invalid_request", loc.Query().Get("error"))
+	assert.Equal(t, "xyz", loc.Query().Get("state"))
+
+	authorized := serve(e, authorizeRequest(nil))
+	require.Equal(t, http.StatusFound, authorized.Code)
+	loc = location(t, authorized)
+	assert.Equal(t, "/login", loc.Path)
+	reqID := loc.Query().Get("id")
+	require.NotEmpty(t, reqID)
+	again := serve(e, withCookies(authorizeRequest(nil), authorized))
+	assert.Equal(t, authorized.Result().Cookies()[0].Value, again.Result().Cookies()[0].Value, "requests of other tabs stay valid")
+
+	// login
+	res = serve(e, formRequest(LoginPath, url.Values{"username": {"test@example.com"}, "password": {"PAss00!!"}, "id": {reqID}}))
+	require.Equal(t, http.StatusFound, res.Code)
+	loc = location(t, res)
+	assert.Equal(t, "/login", loc.Path, "the form must be posted from the browser that made the request")
+	assert.Empty(t, loc.Query().Get("code"))
+
+	res = serve(e, withCookies(formRequest(LoginPath, url.Values{"username": {"test@example.com"}, "password": {"wrong"}, "id": {reqID}}), authorized))
+	require.Equal(t, http.StatusFound, res.Code)
+	loc = location(t, res)
+	assert.Equal(t, "/login", loc.Path)
+	assert.Equal(t, reqID, loc.Query().Get("id"))
+	assert.NotEmpty(t, loc.Query().Get("error"))
+
+	res = serve(e, withCookies(formRequest(LoginPath, url.Values{"username": {"test@example.com"}, "password": {"PAss00!!"}, "id": {reqID}}), authorized))
+	require.Equal(t, http.StatusFound, res.Code)
+	loc = location(t, res)
+	assert.Equal(t, testRedirect, loc.Scheme+"://"+loc.Host+loc.Path)
+	assert.Equal(t, "xyz", loc.Query().Get("state"))
+	code := loc.Query().Get("code")
+	require.NotEmpty(t, code)
+
+	// token exchange
+	exchange := url.Values{
+		"grant_type":    {"authorization_code"},
+		"client_id":     {testClientID},
+		"redirect_uri":  {testRedirect},
+		"code":          {code},
+		"code_verifier": {"wrong-verifier"},
+	}
+	res = serve(e, formRequest(TokenPath, exchange))
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+	assert.Contains(t, res.Body.String(), "invalid_grant")
+
+	exchange.Set("code_verifier", testVerifier)
+	res = serve(e, formRequest(TokenPath, exchange))
+	assert.Equal(t, http.StatusBadRequest, res.Code, "a code can be tried only once")
+
+	authorized = serve(e, authorizeRequest(nil))
+	require.Equal(t, http.StatusFound, authorized.Code)
+	reqID = location(t, authorized).Query().Get("id")
+	res = serve(e, withCookies(formRequest(LoginPath, url.Values{"username": {"test@example.com"}, "password": {"PAss00!!"}, "id": {reqID}}), authorized))
+	require.Equal(t, http.StatusFound, res.Code)
+	exchange.Set("code", location(t, res).Query().Get("code"))
+	res = serve(e, formRequest(TokenPath, exchange))
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	assert.Equal(t, "no-store", res.Header().Get("Cache-Control"))
+	var tok tokenResponse
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &tok))
+	assert.Equal(t, "Bearer", tok.TokenType)
+	assert.Equal(t, "openid email offline_access", tok.Scope)
+	assert.NotEmpty(t, tok.RefreshToken)
+
+	idc := idTokenClaims{}
+	_, err = jwt.ParseWithClaims(tok.IDToken, &idc, func(*jwt.Token) (any, error) { return jwks.Keys[0].Key, nil },
+		jwt.WithIssuer(testIssuer), jwt.WithAudience(testClientID))
+	require.NoError(t, err)
+	assert.Equal(t, "reearth|"+uid.String(), idc.Subject)
+	assert.Equal(t, "n-0S6_WzA2Mj", idc.Nonce)
+	assert.Equal(t, "test@example.com", idc.Email)
+	assert.Empty(t, idc.Name, "profile scope was not requested")
+
+	// codes are single use
+	res = serve(e, formRequest(TokenPath, exchange))
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+
+	// userinfo
+	req := httptest.NewRequest(http.MethodGet, UserInfoPath, nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+tok.AccessToken)
+	res = serve(e, req)
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"sub":"reearth|`+uid.String()+`","email":"test@example.com","email_verified":true}`, res.Body.String())
+
+	req = httptest.NewRequest(http.MethodGet, UserInfoPath, nil)
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+tok.IDToken)
+	assert.Equal(t, http.StatusUnauthorized, serve(e, req).Code, "ID tokens are not access tokens")
+
+	// refresh
+	res = serve(e, formRequest(TokenPath, url.Values{"grant_type": {"refresh_token"}, "client_id": {testClientID}, "refresh_token": {tok.AccessToken}}))
+	assert.Equal(t, http.StatusBadRequest, res.Code, "access tokens are not refresh tokens")
+
+	res = serve(e, formRequest(TokenPath, url.Values{"grant_type": {"refresh_token"}, "client_id": {testClientID}, "refresh_token": {tok.RefreshToken}}))
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	var refreshed tokenResponse
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &refreshed))
+	assert.NotEmpty(t, refreshed.AccessToken)
+	assert.Equal(t, tok.Scope, refreshed.Scope)
+	assert.NotEqual(t, tok.RefreshToken, refreshed.RefreshToken, "refresh tokens are rotated")
+
+	res = serve(e, formRequest(TokenPath, url.Values{"grant_type": {"refresh_token"}, "client_id": {testClientID}, "refresh_token": {refreshed.RefreshToken}}))
+	require.Equal(t, http.StatusOK, res.Code, res.Body.String())
+	var rotated tokenResponse
+	require.NoError(t, json.Unmarshal(res.Body.Bytes(), &rotated))
+
+	// reusing a rotated token revokes the sign-in
+	res = serve(e, formRequest(TokenPath, url.Values{"grant_type": {"refresh_token"}, "client_id": {testClientID}, "refresh_token": {tok.RefreshToken}}))
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+	assert.Contains(t, res.Body.String(), "invalid_grant")
+	res = serve(e, formRequest(TokenPath, url.Values{"grant_type": {"refresh_token"}, "client_id": {testClientID}, "refresh_token": {rotated.RefreshToken}}))
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+func TestProvider_MagicLinkLogin(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	uid := id.NewUserID()
+	ml, token, err := user.IssueMagicLink(nil)
+	require.NoError(t, err)
+	u := user.New().ID(uid).Workspace(id.NewWorkspaceID()).Name("Test User").Email("test@example.com").
+		Auths([]user.Auth{*user.ReearthSub(uid.String())}).MagicLink(ml).MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	keys, err := LoadKeys(ctx, r.Config)
+	require.NoError(t, err)
+	e := echo.New()
+	New(Config{
+		Issuer:         testIssuer,
+		ClientID:       testClientID,
+		RedirectURIs:   []string{testRedirect},
+		LoginURL:       "https://app.example.com/login",
+		AccessTokenTTL: time.Hour,
+	}, keys, interactor.NewUser(r, &gateway.Container{}, nil, "", "").(*interactor.User)).Register(e)
+
+	authorized := serve(e, authorizeRequest(nil))
+	reqID := location(t, authorized).Query().Get("id")
+	require.NotEmpty(t, reqID)
+
+	res := serve(e, withCookies(formRequest(LoginPath, url.Values{"username": {"test@example.com"}, "magic_link_token": {"wrong"}, "id": {reqID}}), authorized))
+	require.Equal(t, http.StatusFound, res.Code)
+	assert.Equal(t, "the sign-in link is invalid or has expired", location(t, res).Query().Get("error"))
+
+	res = serve(e, withCookies(formRequest(LoginPath, url.Values{"username": {"test@example.com"}, "magic_link_token": {token}, "id": {reqID}}), authorized))
+	require.Equal(t, http.StatusFound, res.Code)
+	loc := location(t, res)
+	assert.Equal(t, testRedirect, loc.Scheme+"://"+loc.Host+loc.Path)
+	assert.NotEmpty(t, loc.Query().Get("code"))
+
+	// the link is single use
+	res = serve(e, withCookies(formRequest(LoginPath, url.Values{"username": {"test@example.com"}, "magic_link_token": {token}, "id": {reqID}}), authorized))
+	require.Equal(t, http.StatusFound, res.Code)
+	assert.Equal(t, "/login", location(t, res).Path)
+}
+
+// passkeyUsers accepts the passkey assertion "valid" of its user in the
+// session "s".
+type passkeyUsers struct {
+	*interactor.User
+	u *user.User
+}
+
+func (p passkeyUsers) BeginPasskeyLogin(_ context.Context, email string) (json.RawMessage, string, error) {
+	if email != p.u.Email() {
+		return nil, "", interfaces.ErrInvalidPasskey
+	}
+	return json.RawMessage(`{"publicKey":{"challenge":"c"}}`), "s", nil
+}
+
+func (p passkeyUsers) GetUserByPasskey(_ context.Context, inp interfaces.GetUserByPasskey) (*user.User, error) {
+	if inp.Session != "s" || string(inp.Response) != "valid" {
+		return nil, interfaces.ErrInvalidPasskey
+	}
+	return p.u, nil
+}
+
+func TestProvider_PasskeyLogin(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	uid := id.NewUserID()
+	u := user.New().ID(uid).Workspace(id.NewWorkspaceID()).Name("Test User").Email("test@example.com").
+		Auths([]user.Auth{*user.ReearthSub(uid.String())}).MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	keys, err := LoadKeys(ctx, r.Config)
+	require.NoError(t, err)
+	users := passkeyUsers{User: interactor.NewUser(r, &gateway.Container{}, nil, "", "").(*interactor.User), u: u}
+	e := echo.New()
+	New(Config{
+		Issuer:         testIssuer,
+		ClientID:       testClientID,
+		RedirectURIs:   []string{testRedirect},
+		LoginURL:       "https://app.example.com/login",
+		AccessTokenTTL: time.Hour,
+	}, keys, users).Register(e)
+
+	authorized := serve(e, authorizeRequest(nil))
+	reqID := location(t, authorized).Query().Get("id")
+	require.NotEmpty(t, reqID)
+
+	res := serve(e, formRequest(LoginPasskeyPath, url.Values{"username": {"test@example.com"}, "id": {"expired"}}))
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+	res = serve(e, formRequest(LoginPasskeyPath, url.Values{"username": {"test@example.com"}, "id": {reqID}}))
+	require.Equal(t, http.StatusOK, res.Code)
+	assert.JSONEq(t, `{"publicKey":{"challenge":"c"},"session":"s"}`, res.Body.String())
+
+	res = serve(e, withCookies(formRequest(LoginPath, url.Values{"passkey": {"forged"}, "passkey_session": {"s"}, "id": {reqID}}), authorized))
+	require.Equal(t, http.StatusFound, res.Code)
+	loc := location(t, res)
+	assert.Equal(t, "/login", loc.Path)
+	assert.Equal(t, "invalid passkey", loc.Query().Get("error"))
+
+	res = serve(e, withCookies(formRequest(LoginPath, url.Values{"passkey": {"valid"}, "passkey_session": {"s"}, "id": {reqID}}), authorized))
+	require.Equal(t, http.StatusFound, res.Code)
+	loc = location(t, res)
+	assert.Equal(t, testRedirect, loc.Scheme+"://"+loc.Host+loc.Path)
+	assert.NotEmpty(t, loc.Query().Get("code"))
+}
+
+func authorizeRequest(override url.Values) *http.Request {
+	sum := sha256.Sum256([]byte(testVerifier))
+	q := url.Values{
+		"client_id":             {testClientID},
+		"redirect_uri":          {testRedirect},
+		"response_type":         {"code"},
+		"scope":                 {"openid email offline_access unknown"},
+		"state":                 {"xyz"},
+		"nonce":                 {"n-0S6_WzA2Mj"},
+		"code_challenge":        {base64.RawURLEncoding.EncodeToString(sum[:])},
+		"code_challenge_method": {"S256"},
+	}
+	for k, v := range override {
+		q[k] = v
+	}
+	return httptest.NewRequest(http.MethodGet, AuthorizePath+"?"+q.Encode(), nil)
+}
+
+func formRequest(path string, form url.Values) *http.Request {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	return req
+}
+
+// withCookies adds the cookies set by res to req, as the browser would.
+func withCookies(req *http.Request, res *httptest.ResponseRecorder) *http.Request {
+	for _, c := range res.Result().Cookies() {
+		req.AddCookie(c)
+	}
+	return req
+}
+
+func serve(e *echo.Echo, req *http.Request) *httptest.ResponseRecorder {
+	res := httptest.NewRecorder()
+	e.ServeHTTP(res, req)
+	return res
+}
+
+func location(t *testing.T, res *httptest.ResponseRecorder) *url.URL {
+	t.Helper()
+	u, err := url.Parse(res.Header().Get(echo.HeaderLocation))
+	require.NoError(t, err)
+	return u
+}
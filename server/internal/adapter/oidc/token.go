@@ -0,0 +1,139 @@
+package oidc
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/util"
+)
+
+// JWS typ header values. Every token the provider issues is signed with the
+// same keys, so the typ header and audience keep one kind of token from being
+// accepted in place of another.
+const (
+	typAccessToken  = "at+jwt"
+	typIDToken      = "JWT"
+	typRefreshToken = "rt+jwt"
+	typAuthRequest  = "ar+jwt"
+)
+
+var errInvalidToken = errors.New("oidc: invalid token")
+
+// userClaims are the standard OIDC claims about the user, released according
+// to the granted scopes.
+type userClaims struct {
+	Name          string `json:"name,omitempty"`
+	Email         string `json:"email,omitempty"`
+	EmailVerified *bool  `json:"email_verified,omitempty"`
+}
+
+type accessTokenClaims struct {
+	jwt.RegisteredClaims
+	userClaims
+	ClientID string `json:"client_id"`
+	Scope    string `json:"scope,omitempty"`
+}
+
+type idTokenClaims struct {
+	jwt.RegisteredClaims
+	userClaims
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	Nonce    string           `json:"nonce,omitempty"`
+}
+
+// refreshTokenClaims make refresh tokens self-contained. Their audience is the
+// issuer itself, so resource servers expecting the client ID reject them.
+type refreshTokenClaims struct {
+	jwt.RegisteredClaims
+	ClientID string           `json:"client_id"`
+	Scope    string           `json:"scope"`
+	AuthTime *jwt.NumericDate `json:"auth_time,omitempty"`
+	// Family and Generation identify the token among those rotated from the
+	// same sign-in; see user.RefreshTokenFamily.
+	Family     string `json:"fid"`
+	Generation int    `json:"gen"`
+}
+
+// authRequestClaims carry a validated authorization request through the login
+// UI, so no server-side state is needed until the user has authenticated.
+type authRequestClaims struct {
+	jwt.RegisteredClaims
+	ClientID      string `json:"client_id"`
+	RedirectURI   string `json:"redirect_uri"`
+	Scope         string `json:"scope"`
+	State         string `json:"state,omitempty"`
+	Nonce         string `json:"nonce,omitempty"`
+	CodeChallenge string `json:"code_challenge"`
+	// Browser is the hash of the login cookie of the browser that made the
+	// request, so that the login form can't be posted from another one.
+	Browser string `json:"browser"`
+}
+
+func (p *Provider) sign(typ string, claims jwt.Claims) (string, error) {
+	k := p.keys.Signing()
+	if k == nil {
+		return "", errNoSigningKey
+	}
+	t := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	t.Header["typ"] = typ
+	t.Header["kid"] = k.ID
+	return t.SignedString(k.private)
+}
+
+func (p *Provider) parse(token, typ, audience string, claims jwt.Claims) error {
+	_, err := jwt.ParseWithClaims(token, claims, func(t *jwt.Token) (any, error) {
+		if t.Header["typ"] != typ {
+			return nil, errInvalidToken
+		}
+		kid, _ := t.Header["kid"].(string)
+		k := p.keys.Lookup(kid)
+		if k == nil {
+			return nil, errInvalidToken
+		}
+		return k.private.Public(), nil
+	},
+		jwt.WithValidMethods([]string{signingAlg}),
+		jwt.WithIssuer(p.cfg.Issuer),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+		jwt.WithTimeFunc(util.Now),
+	)
+	if err != nil {
+		return errInvalidToken
+	}
+	return nil
+}
+
+func (p *Provider) registered(sub string, aud []string, ttl time.Duration) jwt.RegisteredClaims {
+	now := util.Now()
+	return jwt.RegisteredClaims{
+		ID:        uuid.NewString(),
+		Issuer:    p.cfg.Issuer,
+		Subject:   sub,
+		Audience:  aud,
+		IssuedAt:  jwt.NewNumericDate(now),
+		ExpiresAt: jwt.NewNumericDate(now.Add(ttl)),
+	}
+}
+
+func newUserClaims(u *user.User, scopes []string) userClaims {
+	var c userClaims
+	if slices.Contains(scopes, scopeProfile) {
+		c.Name = u.Name()
+	}
+	if slices.Contains(scopes, scopeEmail) {
+		verified := u.Verification() != nil && u.Verification().IsVerified()
+		c.Email = u.Email()
+		c.EmailVerified = &verified
+	}
+	return c
+}
+
+func scopeString(scopes []string) string {
+	return strings.Join(scopes, " ")
+}
@@ -0,0 +1,236 @@
+package scim
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type filterSupported struct {
+	Supported  bool `json:"supported"`
+	MaxResults int  `json:"maxResults"`
+}
+
+type bulkSupported struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type authenticationScheme struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type serviceProviderConfig struct {
+	Schemas               []string               `json:"schemas"`
+	Patch                 supported              `json:"patch"`
+	Bulk                  bulkSupported          `json:"bulk"`
+	Filter                filterSupported        `json:"filter"`
+	ChangePassword        supported              `json:"changePassword"`
+	Sort                  supported              `json:"sort"`
+	ETag                  supported              `json:"etag"`
+	AuthenticationSchemes []authenticationScheme `json:"authenticationSchemes"`
+	Meta                  meta                   `json:"meta"`
+}
+
+func (s *Server) ServiceProviderConfig(c echo.Context) error {
+	return respond(c, http.StatusOK, serviceProviderConfig{
+		Schemas: []string{schemaSPConfig},
+		Patch:   supported{Supported: true},
+		Filter:  filterSupported{Supported: true, MaxResults: maxCount},
+		AuthenticationSchemes: []authenticationScheme{{
+			Type:        "oauthbearertoken",
+			Name:        "Bearer Token",
+			Description: "Per-tenant bearer token issued from the admin console",
+			Primary:     true,
+		}},
+		Meta: meta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     baseURL(c) + "/ServiceProviderConfig",
+		},
+	})
+}
+
+type schemaExtension struct {
+	Schema   string `json:"schema"`
+	Required bool   `json:"required"`
+}
+
+type resourceType struct {
+	Schemas          []string          `json:"schemas"`
+	ID               string            `json:"id"`
+	Name             string            `json:"name"`
+	Endpoint         string            `json:"endpoint"`
+	Description      string            `json:"description"`
+	Schema           string            `json:"schema"`
+	SchemaExtensions []schemaExtension `json:"schemaExtensions,omitempty"`
+	Meta             meta              `json:"meta"`
+}
+
+func resourceTypes(c echo.Context) []resourceType {
+	return []resourceType{
+		{
+			Schemas:     []string{schemaResourceType},
+			ID:          "User",
+			Name:        "User",
+			Endpoint:    "/Users",
+			Description: "User account",
+			Schema:      schemaUser,
+			Meta:        meta{ResourceType: "ResourceType", Location: baseURL(c) + "/ResourceTypes/User"},
+		},
+		{
+			Schemas:          []string{schemaResourceType},
+			ID:               "Group",
+			Name:             "Group",
+			Endpoint:         "/Groups",
+			Description:      "Workspace whose members hold the same role",
+			Schema:           schemaGroup,
+			SchemaExtensions: []schemaExtension{{Schema: schemaWorkspace}},
+			Meta:             meta{ResourceType: "ResourceType", Location: baseURL(c) + "/ResourceTypes/Group"},
+		},
+	}
+}
+
+func (s *Server) ResourceTypes(c echo.Context) error {
+	var res []any
+	for _, r := range resourceTypes(c) {
+		res = append(res, r)
+	}
+	return respond(c, http.StatusOK, newListResponse(res, len(res), 1))
+}
+
+func (s *Server) ResourceType(c echo.Context) error {
+	for _, r := range resourceTypes(c) {
+		if r.ID == c.Param("id") {
+			return respond(c, http.StatusOK, r)
+		}
+	}
+	return respondError(c, rerror.ErrNotFound)
+}
+
+type attribute struct {
+	Name            string      `json:"name"`
+	Type            string      `json:"type"`
+	MultiValued     bool        `json:"multiValued"`
+	Required        bool        `json:"required"`
+	CaseExact       bool        `json:"caseExact"`
+	Mutability      string      `json:"mutability"`
+	Returned        string      `json:"returned"`
+	Uniqueness      string      `json:"uniqueness"`
+	CanonicalValues []string    `json:"canonicalValues,omitempty"`
+	SubAttributes   []attribute `json:"subAttributes,omitempty"`
+}
+
+func attr(name, typ string, required bool, uniqueness string) attribute {
+	return attribute{
+		Name:       name,
+		Type:       typ,
+		Required:   required,
+		Mutability: "readWrite",
+		Returned:   "default",
+		Uniqueness: uniqueness,
+	}
+}
+
+type schema struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id"`
+	Name        string      `json:"name"`
+	Description string      `json:"description"`
+	Attributes  []attribute `json:"attributes"`
+	Meta        meta        `json:"meta"`
+}
+
+// schemas describes the attributes the service stores. Other attributes of the
+// core schemas are accepted and ignored.
+func schemas(c echo.Context) []schema {
+	userName := attr("userName", "string", true, "server")
+	name := attr("name", "complex", false, "none")
+	name.SubAttributes = []attribute{
+		attr("formatted", "string", false, "none"),
+		attr("givenName", "string", false, "none"),
+		attr("familyName", "string", false, "none"),
+	}
+	emails := attr("emails", "complex", true, "none")
+	emails.MultiValued = true
+	emails.SubAttributes = []attribute{
+		attr("value", "string", true, "server"),
+		attr("type", "string", false, "none"),
+		attr("primary", "boolean", false, "none"),
+	}
+
+	members := attr("members", "complex", false, "none")
+	members.MultiValued = true
+	value := attr("value", "string", false, "none")
+	value.Mutability = "immutable"
+	ref := attr("$ref", "reference", false, "none")
+	ref.Mutability = "immutable"
+	members.SubAttributes = []attribute{value, ref}
+
+	workspaceRole := attr("role", "string", false, "none")
+	workspaceRole.CanonicalValues = []string{"reader", "writer", "maintainer"}
+
+	loc := func(id string) meta {
+		return meta{ResourceType: "Schema", Location: baseURL(c) + "/Schemas/" + id}
+	}
+	return []schema{
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          schemaUser,
+			Name:        "User",
+			Description: "User account",
+			Attributes: []attribute{
+				userName,
+				name,
+				attr("displayName", "string", false, "none"),
+				emails,
+				attr("active", "boolean", false, "none"),
+			},
+			Meta: loc(schemaUser),
+		},
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          schemaGroup,
+			Name:        "Group",
+			Description: "Workspace whose members hold the same role",
+			Attributes: []attribute{
+				attr("displayName", "string", true, "server"),
+				members,
+			},
+			Meta: loc(schemaGroup),
+		},
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          schemaWorkspace,
+			Name:        "WorkspaceGroup",
+			Description: "Role the members of the group hold in its workspace; defaults to reader",
+			Attributes:  []attribute{workspaceRole},
+			Meta:        loc(schemaWorkspace),
+		},
+	}
+}
+
+func (s *Server) Schemas(c echo.Context) error {
+	var res []any
+	for _, sc := range schemas(c) {
+		res = append(res, sc)
+	}
+	return respond(c, http.StatusOK, newListResponse(res, len(res), 1))
+}
+
+func (s *Server) Schema(c echo.Context) error {
+	for _, sc := range schemas(c) {
+		if sc.ID == c.Param("id") {
+			return respond(c, http.StatusOK, sc)
+		}
+	}
+	return respondError(c, rerror.ErrNotFound)
+}
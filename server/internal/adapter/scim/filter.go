@@ -0,0 +1,85 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// filter is a parsed SCIM filter. Identity providers only use equality filters
+// to look up a resource before creating it, so `attr eq "value"` is the only
+// supported form.
+type filter struct {
+	Attr  string
+	Value string
+}
+
+var filterRegexp = regexp.MustCompile(`(?i)^\s*([a-z][a-z0-9._:$\[\]" ]*?)\s+eq\s+("(?:[^"\\]|\\.)*")\s*$`)
+
+func parseFilter(s string) (*filter, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	m := filterRegexp.FindStringSubmatch(s)
+	if m == nil {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidFilter, "only 'attribute eq \"value\"' filters are supported")
+	}
+	var v string
+	if err := json.Unmarshal([]byte(m[2]), &v); err != nil {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidFilter, "invalid filter value")
+	}
+	return &filter{Attr: normalizeAttr(m[1]), Value: v}, nil
+}
+
+// normalizeAttr lower-cases an attribute path and strips the schema URN, as
+// attribute names are case-insensitive and may be fully qualified.
+func normalizeAttr(attr string) string {
+	return strings.ToLower(stripSchema(strings.TrimSpace(attr)))
+}
+
+func stripSchema(path string) string {
+	for _, s := range []string{schemaUser, schemaGroup, schemaWorkspace} {
+		if len(path) > len(s) && strings.EqualFold(path[:len(s)+1], s+":") {
+			return path[len(s)+1:]
+		}
+	}
+	return path
+}
+
+// valuePathRegexp matches a path with a value filter such as
+// `members[value eq "id"]` or `emails[type eq "work"].value`.
+var valuePathRegexp = regexp.MustCompile(`(?i)^([a-z]+)\[\s*([a-z.]+)\s+eq\s+("(?:[^"\\]|\\.)*")\s*\](?:\.([a-z]+))?$`)
+
+type valuePath struct {
+	Attr      string
+	Filter    filter
+	SubAttr   string
+	HasFilter bool
+}
+
+func parsePath(path string) (*valuePath, error) {
+	path = strings.TrimSpace(path)
+	if strings.EqualFold(path, schemaWorkspace) {
+		return &valuePath{Attr: strings.ToLower(schemaWorkspace)}, nil
+	}
+	path = stripSchema(path)
+	m := valuePathRegexp.FindStringSubmatch(path)
+	if m == nil {
+		if strings.ContainsAny(path, "[]") {
+			return nil, newError(http.StatusBadRequest, scimTypeInvalidPath, "unsupported path: "+path)
+		}
+		attr, sub, _ := strings.Cut(strings.ToLower(path), ".")
+		return &valuePath{Attr: attr, SubAttr: sub}, nil
+	}
+	var v string
+	if err := json.Unmarshal([]byte(m[3]), &v); err != nil {
+		return nil, newError(http.StatusBadRequest, scimTypeInvalidPath, "invalid path value")
+	}
+	return &valuePath{
+		Attr:      strings.ToLower(m[1]),
+		Filter:    filter{Attr: strings.ToLower(m[2]), Value: v},
+		SubAttr:   strings.ToLower(m[4]),
+		HasFilter: true,
+	}, nil
+}
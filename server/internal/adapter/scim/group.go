@@ -0,0 +1,325 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+)
+
+type groupResource struct {
+	Schemas     []string      `json:"schemas"`
+	ID          string        `json:"id"`
+	DisplayName string        `json:"displayName"`
+	Members     []memberAttr  `json:"members,omitempty"`
+	Workspace   *workspaceExt `json:"urn:reearth:params:scim:schemas:extension:workspace:2.0:Group,omitempty"`
+	Meta        meta          `json:"meta"`
+}
+
+type memberAttr struct {
+	Value string `json:"value"`
+	Ref   string `json:"$ref,omitempty"`
+	Type  string `json:"type,omitempty"`
+}
+
+// workspaceExt is the schema extension carrying the role the members of the
+// group hold in its workspace.
+type workspaceExt struct {
+	Role string `json:"role"`
+}
+
+type groupRequest struct {
+	DisplayName *string       `json:"displayName"`
+	Members     *[]memberAttr `json:"members"`
+	Workspace   *workspaceExt `json:"urn:reearth:params:scim:schemas:extension:workspace:2.0:Group"`
+}
+
+func (r groupRequest) param() (interfaces.SCIMGroupParam, error) {
+	p := interfaces.SCIMGroupParam{Name: r.DisplayName}
+	if r.Workspace != nil {
+		p.Role = lo.ToPtr(role.RoleType(r.Workspace.Role))
+	}
+	if r.Members != nil {
+		ids, err := memberIDs(*r.Members)
+		if err != nil {
+			return p, err
+		}
+		p.Members = &ids
+	}
+	return p, nil
+}
+
+func newGroupResource(c echo.Context, g *interfaces.SCIMGroup, withMembers bool) groupResource {
+	ws := g.Workspace
+	m := meta{
+		ResourceType: "Group",
+		Location:     baseURL(c) + "/Groups/" + ws.ID().String(),
+	}
+	if created := ws.CreatedAt(); created != nil {
+		m.Created = created.UTC().Format(time.RFC3339)
+	}
+	if updated := ws.UpdatedAt(); !updated.IsZero() {
+		m.LastModified = updated.UTC().Format(time.RFC3339)
+	}
+	var members []memberAttr
+	if withMembers {
+		for _, uid := range ws.Members().UserIDs() {
+			members = append(members, memberAttr{
+				Value: uid.String(),
+				Ref:   baseURL(c) + "/Users/" + uid.String(),
+				Type:  "User",
+			})
+		}
+	}
+	return groupResource{
+		Schemas:     []string{schemaGroup, schemaWorkspace},
+		ID:          ws.ID().String(),
+		DisplayName: ws.Name(),
+		Members:     members,
+		Workspace:   &workspaceExt{Role: g.Role.String()},
+		Meta:        m,
+	}
+}
+
+// excludesMembers reports whether the client asked to leave out members, as
+// Entra ID does when it only checks that a group exists.
+func excludesMembers(c echo.Context) bool {
+	for _, a := range strings.Split(c.QueryParam("excludedAttributes"), ",") {
+		if normalizeAttr(a) == "members" {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) ListGroups(c echo.Context) error {
+	startIndex, count, err := pagination(c)
+	if err != nil {
+		return respondError(c, err)
+	}
+	f, err := parseFilter(c.QueryParam("filter"))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	gf := interfaces.SCIMGroupFilter{Offset: startIndex - 1, Limit: count}
+	if f != nil {
+		if f.Attr != "displayname" {
+			return respondError(c, newError(http.StatusBadRequest, scimTypeInvalidFilter, "unsupported filter attribute: "+f.Attr))
+		}
+		gf.Name = &f.Value
+	}
+
+	groups, total, err := s.scim.FindGroups(c.Request().Context(), tenant(c).ID(), gf)
+	if err != nil {
+		return respondError(c, err)
+	}
+	withMembers := !excludesMembers(c)
+	res := make([]any, 0, len(groups))
+	for _, g := range groups {
+		res = append(res, newGroupResource(c, &g, withMembers))
+	}
+	return respond(c, http.StatusOK, newListResponse(res, total, startIndex))
+}
+
+func (s *Server) GetGroup(c echo.Context) error {
+	wid, err := workspaceID(c.Param("id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	g, err := s.scim.FindGroup(c.Request().Context(), tenant(c).ID(), wid)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return respond(c, http.StatusOK, newGroupResource(c, g, !excludesMembers(c)))
+}
+
+func (s *Server) CreateGroup(c echo.Context) error {
+	var req groupRequest
+	if err := decode(c, &req); err != nil {
+		return respondError(c, err)
+	}
+	p, err := req.param()
+	if err != nil {
+		return respondError(c, err)
+	}
+	g, err := s.scim.CreateGroup(c.Request().Context(), tenant(c).ID(), p)
+	if err != nil {
+		return respondError(c, err)
+	}
+	r := newGroupResource(c, g, true)
+	c.Response().Header().Set(echo.HeaderLocation, r.Meta.Location)
+	return respond(c, http.StatusCreated, r)
+}
+
+func (s *Server) ReplaceGroup(c echo.Context) error {
+	wid, err := workspaceID(c.Param("id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	var req groupRequest
+	if err := decode(c, &req); err != nil {
+		return respondError(c, err)
+	}
+	if req.DisplayName == nil {
+		return respondError(c, newError(http.StatusBadRequest, scimTypeInvalidValue, "displayName is required"))
+	}
+	// PUT replaces the membership, so a missing members attribute empties it
+	if req.Members == nil {
+		req.Members = &[]memberAttr{}
+	}
+	p, err := req.param()
+	if err != nil {
+		return respondError(c, err)
+	}
+	return s.updateGroup(c, wid, p)
+}
+
+func (s *Server) PatchGroup(c echo.Context) error {
+	wid, err := workspaceID(c.Param("id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	var req patchRequest
+	if err := decode(c, &req); err != nil {
+		return respondError(c, err)
+	}
+	var p interfaces.SCIMGroupParam
+	if err := req.apply(func(op string, path *valuePath, value json.RawMessage) error {
+		return patchGroup(&p, op, path, value)
+	}); err != nil {
+		return respondError(c, err)
+	}
+	return s.updateGroup(c, wid, p)
+}
+
+func (s *Server) updateGroup(c echo.Context, wid workspace.ID, p interfaces.SCIMGroupParam) error {
+	g, err := s.scim.UpdateGroup(c.Request().Context(), tenant(c).ID(), wid, p)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return respond(c, http.StatusOK, newGroupResource(c, g, !excludesMembers(c)))
+}
+
+// DeleteGroup removes every member from the workspace and deletes it.
+func (s *Server) DeleteGroup(c echo.Context) error {
+	wid, err := workspaceID(c.Param("id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	if err := s.scim.DeleteGroup(c.Request().Context(), tenant(c).ID(), wid); err != nil {
+		return respondError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// patchGroup applies a PATCH operation to p. Operations are folded into a
+// single update: once the membership is replaced, later additions and
+// removals edit the replacement directly.
+func patchGroup(p *interfaces.SCIMGroupParam, op string, path *valuePath, value json.RawMessage) error {
+	switch path.Attr {
+	case "displayname":
+		if op == patchOpRemove {
+			return newError(http.StatusBadRequest, "mutability", "displayName can't be removed")
+		}
+		return unmarshalValue(value, &p.Name)
+	case "role", strings.ToLower(schemaWorkspace):
+		if op == patchOpRemove {
+			p.Role = lo.ToPtr(role.RoleReader)
+			return nil
+		}
+		if path.Attr == "role" {
+			return unmarshalValue(value, &p.Role)
+		}
+		var ext workspaceExt
+		if err := unmarshalValue(value, &ext); err != nil {
+			return err
+		}
+		p.Role = lo.ToPtr(role.RoleType(ext.Role))
+	case "members":
+		return patchMembers(p, op, path, value)
+	default:
+		return newError(http.StatusBadRequest, scimTypeInvalidPath, "unsupported path: "+path.Attr)
+	}
+	return nil
+}
+
+func patchMembers(p *interfaces.SCIMGroupParam, op string, path *valuePath, value json.RawMessage) error {
+	var ids user.IDList
+	switch {
+	case path.HasFilter:
+		if op != patchOpRemove || path.Filter.Attr != "value" || path.SubAttr != "" {
+			return newError(http.StatusBadRequest, scimTypeInvalidPath, "only removing members by value is supported")
+		}
+		uid, err := user.IDFrom(path.Filter.Value)
+		if err != nil {
+			return newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid member: "+path.Filter.Value)
+		}
+		ids = user.IDList{uid}
+	case op == patchOpRemove && len(value) == 0:
+		p.Members = &user.IDList{}
+		p.AddMembers, p.RemoveMembers = nil, nil
+		return nil
+	default:
+		var members []memberAttr
+		if err := unmarshalValue(value, &members); err != nil {
+			return err
+		}
+		var err error
+		if ids, err = memberIDs(members); err != nil {
+			return err
+		}
+	}
+
+	switch op {
+	case patchOpReplace:
+		p.Members = &ids
+		p.AddMembers, p.RemoveMembers = nil, nil
+	case patchOpAdd:
+		if p.Members != nil {
+			*p.Members = p.Members.AddUniq(ids...)
+			return nil
+		}
+		p.RemoveMembers = p.RemoveMembers.Delete(ids...)
+		p.AddMembers = p.AddMembers.AddUniq(ids...)
+	case patchOpRemove:
+		if p.Members != nil {
+			*p.Members = p.Members.Delete(ids...)
+			return nil
+		}
+		p.AddMembers = p.AddMembers.Delete(ids...)
+		p.RemoveMembers = p.RemoveMembers.AddUniq(ids...)
+	}
+	return nil
+}
+
+func memberIDs(members []memberAttr) (user.IDList, error) {
+	ids := make(user.IDList, 0, len(members))
+	for _, m := range members {
+		uid, err := user.IDFrom(m.Value)
+		if err != nil {
+			return nil, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid member: "+m.Value)
+		}
+		if !slices.Contains(ids, uid) {
+			ids = append(ids, uid)
+		}
+	}
+	return ids, nil
+}
+
+func workspaceID(s string) (workspace.ID, error) {
+	wid, err := workspace.IDFrom(s)
+	if err != nil {
+		return workspace.ID{}, rerror.ErrNotFound
+	}
+	return wid, nil
+}
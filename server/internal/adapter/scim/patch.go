@@ -0,0 +1,90 @@
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"slices"
+	"strings"
+)
+
+const (
+	patchOpAdd     = "add"
+	patchOpRemove  = "remove"
+	patchOpReplace = "replace"
+)
+
+type patchRequest struct {
+	Schemas    []string         `json:"schemas"`
+	Operations []patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path"`
+	Value json.RawMessage `json:"value"`
+}
+
+// apply calls fn for each operation in order. An operation without a path
+// carries an object whose keys are the paths to set, and is split into one
+// call per key.
+func (r patchRequest) apply(fn func(op string, path *valuePath, value json.RawMessage) error) error {
+	if !slices.Contains(r.Schemas, schemaPatchOp) {
+		return newError(http.StatusBadRequest, scimTypeInvalidSyntax, "missing PatchOp schema")
+	}
+	for _, o := range r.Operations {
+		op := strings.ToLower(o.Op)
+		if op != patchOpAdd && op != patchOpRemove && op != patchOpReplace {
+			return newError(http.StatusBadRequest, scimTypeInvalidSyntax, "unsupported patch op: "+o.Op)
+		}
+
+		if o.Path != "" {
+			path, err := parsePath(o.Path)
+			if err != nil {
+				return err
+			}
+			if err := fn(op, path, o.Value); err != nil {
+				return err
+			}
+			continue
+		}
+
+		if op == patchOpRemove {
+			return newError(http.StatusBadRequest, scimTypeNoTarget, "remove requires a path")
+		}
+		var values map[string]json.RawMessage
+		if err := unmarshalValue(o.Value, &values); err != nil {
+			return err
+		}
+		// sort the keys so that the result does not depend on map order
+		keys := make([]string, 0, len(values))
+		for k := range values {
+			keys = append(keys, k)
+		}
+		slices.Sort(keys)
+		for _, k := range keys {
+			path, err := parsePath(k)
+			if err != nil {
+				return err
+			}
+			if err := fn(op, path, values[k]); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func unmarshalValue(value json.RawMessage, v any) error {
+	if len(value) == 0 {
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, "missing value")
+	}
+	if err := json.Unmarshal(value, v); err != nil {
+		var e *Error
+		if errors.As(err, &e) {
+			return e
+		}
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid value")
+	}
+	return nil
+}
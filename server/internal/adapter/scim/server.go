@@ -0,0 +1,234 @@
+// Package scim implements a SCIM 2.0 (RFC 7643, RFC 7644) service provider so
+// that identity providers such as Okta or Entra ID can provision users and
+// workspaces instead of calling /api/users/sync-sso.
+//
+// Users map onto user.User with the auth sub derived from the tenant's sub
+// prefix and the SCIM userName; Groups map onto workspaces whose members all
+// hold the role of the group. Each tenant authenticates with its own bearer
+// token and only sees the resources it has provisioned. Bulk operations,
+// sorting and ETags are not supported.
+package scim
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+)
+
+const (
+	BasePath = "/scim/v2"
+
+	contentType = "application/scim+json; charset=utf-8"
+
+	// defaultCount and maxCount bound the page size of list responses.
+	defaultCount = 100
+	maxCount     = 1000
+
+	tenantKey = "scim_tenant"
+)
+
+const (
+	schemaUser         = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup        = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaWorkspace    = "urn:reearth:params:scim:schemas:extension:workspace:2.0:Group"
+	schemaListResponse = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaPatchOp      = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError        = "urn:ietf:params:scim:api:messages:2.0:Error"
+	schemaSPConfig     = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	schemaSchema       = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+)
+
+type Server struct {
+	scim interfaces.SCIM
+}
+
+func New(scim interfaces.SCIM) *Server {
+	return &Server{scim: scim}
+}
+
+// Register mounts the SCIM endpoints on e under BasePath.
+func (s *Server) Register(e *echo.Echo) {
+	g := e.Group(BasePath)
+
+	// discovery endpoints are public as allowed by RFC 7644 section 4
+	g.GET("/ServiceProviderConfig", s.ServiceProviderConfig)
+	g.GET("/ResourceTypes", s.ResourceTypes)
+	g.GET("/ResourceTypes/:id", s.ResourceType)
+	g.GET("/Schemas", s.Schemas)
+	g.GET("/Schemas/:id", s.Schema)
+
+	g.GET("/Users", s.ListUsers, s.auth)
+	g.POST("/Users", s.CreateUser, s.auth)
+	g.GET("/Users/:id", s.GetUser, s.auth)
+	g.PUT("/Users/:id", s.ReplaceUser, s.auth)
+	g.PATCH("/Users/:id", s.PatchUser, s.auth)
+	g.DELETE("/Users/:id", s.DeleteUser, s.auth)
+
+	g.GET("/Groups", s.ListGroups, s.auth)
+	g.POST("/Groups", s.CreateGroup, s.auth)
+	g.GET("/Groups/:id", s.GetGroup, s.auth)
+	g.PUT("/Groups/:id", s.ReplaceGroup, s.auth)
+	g.PATCH("/Groups/:id", s.PatchGroup, s.auth)
+	g.DELETE("/Groups/:id", s.DeleteGroup, s.auth)
+}
+
+// auth resolves the tenant from the bearer token.
+func (s *Server) auth(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		scheme, token, _ := strings.Cut(c.Request().Header.Get(echo.HeaderAuthorization), " ")
+		if !strings.EqualFold(scheme, "Bearer") {
+			token = ""
+		}
+		t, err := s.scim.Authenticate(c.Request().Context(), strings.TrimSpace(token))
+		if err != nil {
+			return respondError(c, err)
+		}
+		c.Set(tenantKey, t)
+		return next(c)
+	}
+}
+
+func tenant(c echo.Context) *scimtenant.Tenant {
+	t, _ := c.Get(tenantKey).(*scimtenant.Tenant)
+	return t
+}
+
+func baseURL(c echo.Context) string {
+	return c.Scheme() + "://" + c.Request().Host + BasePath
+}
+
+type meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location"`
+}
+
+type listResponse struct {
+	Schemas      []string `json:"schemas"`
+	TotalResults int      `json:"totalResults"`
+	StartIndex   int      `json:"startIndex"`
+	ItemsPerPage int      `json:"itemsPerPage"`
+	Resources    []any    `json:"Resources"`
+}
+
+func newListResponse(resources []any, total, startIndex int) listResponse {
+	if resources == nil {
+		resources = []any{}
+	}
+	return listResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: total,
+		StartIndex:   startIndex,
+		ItemsPerPage: len(resources),
+		Resources:    resources,
+	}
+}
+
+// pagination reads startIndex (1-based) and count from the query.
+func pagination(c echo.Context) (startIndex, count int, err error) {
+	startIndex, count = 1, defaultCount
+	if v := c.QueryParam("startIndex"); v != "" {
+		if startIndex, err = strconv.Atoi(v); err != nil {
+			return 0, 0, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid startIndex")
+		}
+		startIndex = max(startIndex, 1)
+	}
+	if v := c.QueryParam("count"); v != "" {
+		if count, err = strconv.Atoi(v); err != nil {
+			return 0, 0, newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid count")
+		}
+		count = min(max(count, 0), maxCount)
+	}
+	return startIndex, count, nil
+}
+
+func respond(c echo.Context, status int, v any) error {
+	c.Response().Header().Set(echo.HeaderContentType, contentType)
+	c.Response().WriteHeader(status)
+	return json.NewEncoder(c.Response()).Encode(v)
+}
+
+// decode reads a JSON body. echo's binder only accepts application/json, while
+// SCIM clients send application/scim+json.
+func decode(c echo.Context, v any) error {
+	if err := json.NewDecoder(c.Request().Body).Decode(v); err != nil {
+		return newError(http.StatusBadRequest, scimTypeInvalidSyntax, "invalid request body")
+	}
+	return nil
+}
+
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeNoTarget      = "noTarget"
+	scimTypeUniqueness    = "uniqueness"
+)
+
+// Error is a SCIM error response.
+type Error struct {
+	Status   int
+	SCIMType string
+	Detail   string
+}
+
+func newError(status int, scimType, detail string) *Error {
+	return &Error{Status: status, SCIMType: scimType, Detail: detail}
+}
+
+func (e *Error) Error() string {
+	return e.Detail
+}
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	Status   string   `json:"status"`
+	SCIMType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+}
+
+func respondError(c echo.Context, err error) error {
+	e := toError(err)
+	if e.Status >= http.StatusInternalServerError {
+		log.Errorfc(c.Request().Context(), "scim: %v", err)
+	}
+	return respond(c, e.Status, errorResponse{
+		Schemas:  []string{schemaError},
+		Status:   strconv.Itoa(e.Status),
+		SCIMType: e.SCIMType,
+		Detail:   e.Detail,
+	})
+}
+
+func toError(err error) *Error {
+	var e *Error
+	switch {
+	case errors.As(err, &e):
+		return e
+	case errors.Is(err, interfaces.ErrInvalidSCIMToken):
+		return newError(http.StatusUnauthorized, "", err.Error())
+	case errors.Is(err, rerror.ErrNotFound):
+		return newError(http.StatusNotFound, "", "resource not found")
+	case errors.Is(err, interfaces.ErrSCIMUserNameTaken),
+		errors.Is(err, interfaces.ErrSCIMGroupNameConflict),
+		errors.Is(err, interfaces.ErrUserAlreadyExists):
+		return newError(http.StatusConflict, scimTypeUniqueness, err.Error())
+	case errors.Is(err, interfaces.ErrSCIMInvalidUserName),
+		errors.Is(err, interfaces.ErrSCIMInvalidGroupName),
+		errors.Is(err, interfaces.ErrSCIMMemberNotFound),
+		errors.Is(err, scimtenant.ErrInvalidRole):
+		return newError(http.StatusBadRequest, scimTypeInvalidValue, err.Error())
+	}
+	return newError(http.StatusInternalServerError, "", "internal server error")
+}
@@ -0,0 +1,271 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interactor"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testServer struct {
+	e     *echo.Echo
+	repos *repo.Container
+	token string
+}
+
+func newTestServer(t *testing.T) *testServer {
+	t.Helper()
+	ctx := context.Background()
+	r := memory.New()
+	for _, name := range []string{interfaces.RoleSelf, role.RoleOwner.String(), role.RoleMaintainer.String(), role.RoleWriter.String(), role.RoleReader.String()} {
+		require.NoError(t, r.Role.Save(ctx, *role.New().NewID().Name(name).MustBuild()))
+	}
+	tn := scimtenant.New().NewID().Name("okta").SubPrefix("samlp|okta").MustBuild()
+	token, err := tn.RotateToken()
+	require.NoError(t, err)
+	require.NoError(t, r.SCIMTenant.Save(ctx, tn))
+
+	e := echo.New()
+	New(interactor.NewSCIM(r)).Register(e)
+	return &testServer{e: e, repos: r, token: token}
+}
+
+func (s *testServer) do(t *testing.T, method, path, body string) (int, map[string]any) {
+	t.Helper()
+	var req *http.Request
+	if body != "" {
+		req = httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, "application/scim+json")
+	} else {
+		req = httptest.NewRequest(method, path, nil)
+	}
+	req.Header.Set(echo.HeaderAuthorization, "Bearer "+s.token)
+	rec := httptest.NewRecorder()
+	s.e.ServeHTTP(rec, req)
+
+	var res map[string]any
+	if rec.Body.Len() > 0 {
+		assert.Equal(t, contentType, rec.Header().Get(echo.HeaderContentType))
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	}
+	return rec.Code, res
+}
+
+func TestServer_Auth(t *testing.T) {
+	s := newTestServer(t)
+
+	s.token = "wrong"
+	code, res := s.do(t, http.MethodGet, "/scim/v2/Users", "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	assert.Equal(t, []any{schemaError}, res["schemas"])
+	assert.Equal(t, "401", res["status"])
+
+	// discovery does not require a token
+	code, res = s.do(t, http.MethodGet, "/scim/v2/ServiceProviderConfig", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, true, res["patch"].(map[string]any)["supported"])
+	assert.Equal(t, false, res["bulk"].(map[string]any)["supported"])
+
+	code, res = s.do(t, http.MethodGet, "/scim/v2/Schemas", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(3), res["totalResults"])
+	code, _ = s.do(t, http.MethodGet, "/scim/v2/ResourceTypes/Group", "")
+	assert.Equal(t, http.StatusOK, code)
+}
+
+func TestServer_Users(t *testing.T) {
+	s := newTestServer(t)
+
+	code, res := s.do(t, http.MethodPost, "/scim/v2/Users", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:User"],
+		"userName": "alice@example.com",
+		"name": {"givenName": "Alice", "familyName": "Smith"},
+		"emails": [{"value": "alice@home.example.com"}, {"value": "alice@example.com", "primary": true}],
+		"active": true
+	}`)
+	require.Equal(t, http.StatusCreated, code, res)
+	id := res["id"].(string)
+	assert.Equal(t, "alice@example.com", res["userName"])
+	assert.Equal(t, "Alice Smith", res["displayName"])
+	assert.Equal(t, true, res["active"])
+	assert.Equal(t, "http://example.com/scim/v2/Users/"+id, res["meta"].(map[string]any)["location"])
+
+	u, err := s.repos.User.FindBySub(context.Background(), "samlp|okta|alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "alice@example.com", u.Email())
+
+	code, res = s.do(t, http.MethodPost, "/scim/v2/Users", `{"userName": "alice@example.com", "emails": [{"value": "alice@example.com"}]}`)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "uniqueness", res["scimType"])
+
+	code, res = s.do(t, http.MethodGet, `/scim/v2/Users?filter=userName%20eq%20%22alice@example.com%22`, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(1), res["totalResults"])
+	assert.Equal(t, id, res["Resources"].([]any)[0].(map[string]any)["id"])
+
+	code, res = s.do(t, http.MethodGet, `/scim/v2/Users?filter=userName%20eq%20%22bob%22`, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(0), res["totalResults"])
+	assert.Equal(t, []any{}, res["Resources"])
+
+	code, res = s.do(t, http.MethodGet, `/scim/v2/Users?filter=title%20sw%20%22x%22`, "")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "invalidFilter", res["scimType"])
+
+	// Entra ID style PATCH with string booleans and a valueless operation
+	code, res = s.do(t, http.MethodPatch, "/scim/v2/Users/"+id, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "Replace", "path": "active", "value": "False"},
+			{"op": "Replace", "value": {"displayName": "Alice S.", "name.givenName": "Alice"}},
+			{"op": "replace", "path": "emails[type eq \"work\"].value", "value": "alice.s@example.com"}
+		]
+	}`)
+	require.Equal(t, http.StatusOK, code, res)
+	assert.Equal(t, false, res["active"])
+	assert.Equal(t, "Alice S.", res["displayName"])
+	assert.Equal(t, "alice.s@example.com", res["emails"].([]any)[0].(map[string]any)["value"])
+
+	code, res = s.do(t, http.MethodPut, "/scim/v2/Users/"+id, `{
+		"userName": "alice.s@example.com",
+		"displayName": "Alice",
+		"emails": [{"value": "alice.s@example.com"}],
+		"active": true
+	}`)
+	require.Equal(t, http.StatusOK, code, res)
+	assert.Equal(t, "alice.s@example.com", res["userName"])
+	assert.Equal(t, true, res["active"])
+
+	code, _ = s.do(t, http.MethodDelete, "/scim/v2/Users/"+id, "")
+	assert.Equal(t, http.StatusNoContent, code)
+	u, err = s.repos.User.FindByID(context.Background(), u.ID())
+	require.NoError(t, err)
+	assert.True(t, u.IsDeleted())
+
+	code, _ = s.do(t, http.MethodGet, "/scim/v2/Users/"+id, "")
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = s.do(t, http.MethodGet, "/scim/v2/Users/invalid", "")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestServer_Groups(t *testing.T) {
+	s := newTestServer(t)
+
+	var ids []string
+	for _, name := range []string{"alice", "bob", "carol"} {
+		code, res := s.do(t, http.MethodPost, "/scim/v2/Users", `{"userName": "`+name+`", "emails": [{"value": "`+name+`@example.com"}]}`)
+		require.Equal(t, http.StatusCreated, code, res)
+		ids = append(ids, res["id"].(string))
+	}
+
+	code, res := s.do(t, http.MethodPost, "/scim/v2/Groups", `{
+		"schemas": ["urn:ietf:params:scim:schemas:core:2.0:Group", "urn:reearth:params:scim:schemas:extension:workspace:2.0:Group"],
+		"displayName": "Team",
+		"members": [{"value": "`+ids[0]+`"}, {"value": "`+ids[1]+`"}],
+		"urn:reearth:params:scim:schemas:extension:workspace:2.0:Group": {"role": "writer"}
+	}`)
+	require.Equal(t, http.StatusCreated, code, res)
+	gid := res["id"].(string)
+	assert.Len(t, res["members"], 2)
+	assert.Equal(t, "writer", res[schemaWorkspace].(map[string]any)["role"])
+
+	code, res = s.do(t, http.MethodPost, "/scim/v2/Groups", `{"displayName": "Team"}`)
+	assert.Equal(t, http.StatusConflict, code)
+	assert.Equal(t, "uniqueness", res["scimType"])
+
+	code, res = s.do(t, http.MethodPatch, "/scim/v2/Groups/"+gid, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [
+			{"op": "add", "path": "members", "value": [{"value": "`+ids[2]+`"}]},
+			{"op": "remove", "path": "members[value eq \"`+ids[0]+`\"]"},
+			{"op": "replace", "path": "urn:reearth:params:scim:schemas:extension:workspace:2.0:Group:role", "value": "maintainer"},
+			{"op": "replace", "value": {"displayName": "Renamed"}}
+		]
+	}`)
+	require.Equal(t, http.StatusOK, code, res)
+	assert.Equal(t, "Renamed", res["displayName"])
+	assert.Equal(t, "maintainer", res[schemaWorkspace].(map[string]any)["role"])
+	var members []string
+	for _, m := range res["members"].([]any) {
+		members = append(members, m.(map[string]any)["value"].(string))
+	}
+	assert.ElementsMatch(t, []string{ids[1], ids[2]}, members)
+
+	uid := user.MustID(ids[2])
+	p, err := s.repos.Permittable.FindByUserID(context.Background(), uid)
+	require.NoError(t, err)
+	assert.Len(t, p.WorkspaceRoles(), 2, "personal workspace and the group")
+
+	code, res = s.do(t, http.MethodGet, `/scim/v2/Groups?filter=displayName%20eq%20%22Renamed%22&excludedAttributes=members`, "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, float64(1), res["totalResults"])
+	g := res["Resources"].([]any)[0].(map[string]any)
+	assert.Equal(t, gid, g["id"])
+	assert.NotContains(t, g, "members")
+
+	code, res = s.do(t, http.MethodPatch, "/scim/v2/Groups/"+gid, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "remove", "path": "members"}]
+	}`)
+	require.Equal(t, http.StatusOK, code, res)
+	assert.NotContains(t, res, "members")
+
+	code, res = s.do(t, http.MethodPatch, "/scim/v2/Groups/"+gid, `{
+		"schemas": ["urn:ietf:params:scim:api:messages:2.0:PatchOp"],
+		"Operations": [{"op": "replace", "path": "urn:reearth:params:scim:schemas:extension:workspace:2.0:Group:role", "value": "owner"}]
+	}`)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, "invalidValue", res["scimType"])
+
+	code, _ = s.do(t, http.MethodDelete, "/scim/v2/Groups/"+gid, "")
+	assert.Equal(t, http.StatusNoContent, code)
+	code, _ = s.do(t, http.MethodGet, "/scim/v2/Groups/"+gid, "")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestParseFilter(t *testing.T) {
+	f, err := parseFilter(`userName Eq "a\"b@example.com"`)
+	require.NoError(t, err)
+	assert.Equal(t, &filter{Attr: "username", Value: `a"b@example.com`}, f)
+
+	f, err = parseFilter(`urn:ietf:params:scim:schemas:core:2.0:User:emails.value eq "a@example.com"`)
+	require.NoError(t, err)
+	assert.Equal(t, &filter{Attr: "emails.value", Value: "a@example.com"}, f)
+
+	f, err = parseFilter("")
+	assert.NoError(t, err)
+	assert.Nil(t, f)
+
+	_, err = parseFilter(`userName eq "a" and active eq true`)
+	assert.Error(t, err)
+}
+
+func TestParsePath(t *testing.T) {
+	p, err := parsePath(`members[value eq "01ABC"]`)
+	require.NoError(t, err)
+	assert.Equal(t, &valuePath{Attr: "members", Filter: filter{Attr: "value", Value: "01ABC"}, HasFilter: true}, p)
+
+	p, err = parsePath("name.givenName")
+	require.NoError(t, err)
+	assert.Equal(t, &valuePath{Attr: "name", SubAttr: "givenname"}, p)
+
+	p, err = parsePath(schemaWorkspace + ":role")
+	require.NoError(t, err)
+	assert.Equal(t, &valuePath{Attr: "role"}, p)
+
+	_, err = parsePath("members[value pr]")
+	assert.Error(t, err)
+}
@@ -0,0 +1,311 @@
+package scim
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+)
+
+type userResource struct {
+	Schemas     []string    `json:"schemas"`
+	ID          string      `json:"id"`
+	UserName    string      `json:"userName"`
+	Name        *nameAttr   `json:"name,omitempty"`
+	DisplayName string      `json:"displayName,omitempty"`
+	Emails      []emailAttr `json:"emails,omitempty"`
+	Active      bool        `json:"active"`
+	Meta        meta        `json:"meta"`
+}
+
+type nameAttr struct {
+	Formatted  string `json:"formatted,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+}
+
+// String returns the name the user is stored with.
+func (n *nameAttr) String() string {
+	if n == nil {
+		return ""
+	}
+	if f := strings.TrimSpace(n.Formatted); f != "" {
+		return f
+	}
+	return strings.TrimSpace(n.GivenName + " " + n.FamilyName)
+}
+
+type emailAttr struct {
+	Value   string `json:"value"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// primaryEmail returns the primary email, or the first one if none is marked.
+func primaryEmail(emails []emailAttr) *string {
+	if len(emails) == 0 {
+		return nil
+	}
+	e, ok := lo.Find(emails, func(e emailAttr) bool { return e.Primary })
+	if !ok {
+		e = emails[0]
+	}
+	return lo.ToPtr(e.Value)
+}
+
+// boolValue accepts "True" and "False" strings as well, which Entra ID sends
+// in PATCH requests.
+type boolValue bool
+
+func (b *boolValue) UnmarshalJSON(data []byte) error {
+	var v any
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	switch v := v.(type) {
+	case bool:
+		*b = boolValue(v)
+		return nil
+	case string:
+		switch strings.ToLower(v) {
+		case "true":
+			*b = true
+			return nil
+		case "false":
+			*b = false
+			return nil
+		}
+	}
+	return newError(http.StatusBadRequest, scimTypeInvalidValue, "invalid boolean value")
+}
+
+type userRequest struct {
+	UserName    *string     `json:"userName"`
+	Name        *nameAttr   `json:"name"`
+	DisplayName *string     `json:"displayName"`
+	Emails      []emailAttr `json:"emails"`
+	Active      *boolValue  `json:"active"`
+}
+
+// param maps the request onto the user attributes. displayName takes
+// precedence over name, as it is what the user is shown as.
+func (r userRequest) param() interfaces.SCIMUserParam {
+	p := interfaces.SCIMUserParam{
+		UserName: r.UserName,
+		Email:    primaryEmail(r.Emails),
+	}
+	if r.DisplayName != nil && strings.TrimSpace(*r.DisplayName) != "" {
+		p.Name = r.DisplayName
+	} else if n := r.Name.String(); n != "" {
+		p.Name = &n
+	}
+	if r.Active != nil {
+		p.Active = lo.ToPtr(bool(*r.Active))
+	}
+	return p
+}
+
+func newUserResource(c echo.Context, t *scimtenant.Tenant, u *user.User) userResource {
+	m := meta{
+		ResourceType: "User",
+		Location:     baseURL(c) + "/Users/" + u.ID().String(),
+	}
+	if created := u.CreatedAt(); created != nil {
+		m.Created = created.UTC().Format(time.RFC3339)
+	}
+	if updated := u.UpdatedAt(); !updated.IsZero() {
+		m.LastModified = updated.UTC().Format(time.RFC3339)
+	}
+	var emails []emailAttr
+	if u.Email() != "" {
+		emails = []emailAttr{{Value: u.Email(), Type: "work", Primary: true}}
+	}
+	return userResource{
+		Schemas:     []string{schemaUser},
+		ID:          u.ID().String(),
+		UserName:    t.UserName(u.Auths()),
+		Name:        &nameAttr{Formatted: u.Name()},
+		DisplayName: u.Name(),
+		Emails:      emails,
+		Active:      !u.IsDeleted(),
+		Meta:        m,
+	}
+}
+
+func (s *Server) ListUsers(c echo.Context) error {
+	startIndex, count, err := pagination(c)
+	if err != nil {
+		return respondError(c, err)
+	}
+	f, err := parseFilter(c.QueryParam("filter"))
+	if err != nil {
+		return respondError(c, err)
+	}
+
+	uf := interfaces.SCIMUserFilter{Offset: startIndex - 1, Limit: count}
+	if f != nil {
+		switch f.Attr {
+		case "username":
+			uf.UserName = &f.Value
+		case "emails", "emails.value":
+			uf.Email = &f.Value
+		default:
+			return respondError(c, newError(http.StatusBadRequest, scimTypeInvalidFilter, "unsupported filter attribute: "+f.Attr))
+		}
+	}
+
+	t := tenant(c)
+	users, total, err := s.scim.FindUsers(c.Request().Context(), t.ID(), uf)
+	if err != nil {
+		return respondError(c, err)
+	}
+	res := make([]any, 0, len(users))
+	for _, u := range users {
+		res = append(res, newUserResource(c, t, u))
+	}
+	return respond(c, http.StatusOK, newListResponse(res, total, startIndex))
+}
+
+func (s *Server) GetUser(c echo.Context) error {
+	uid, err := userID(c.Param("id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	t := tenant(c)
+	u, err := s.scim.FindUser(c.Request().Context(), t.ID(), uid)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return respond(c, http.StatusOK, newUserResource(c, t, u))
+}
+
+func (s *Server) CreateUser(c echo.Context) error {
+	var req userRequest
+	if err := decode(c, &req); err != nil {
+		return respondError(c, err)
+	}
+	t := tenant(c)
+	u, err := s.scim.CreateUser(c.Request().Context(), t.ID(), req.param())
+	if err != nil {
+		return respondError(c, err)
+	}
+	r := newUserResource(c, t, u)
+	c.Response().Header().Set(echo.HeaderLocation, r.Meta.Location)
+	return respond(c, http.StatusCreated, r)
+}
+
+func (s *Server) ReplaceUser(c echo.Context) error {
+	uid, err := userID(c.Param("id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	var req userRequest
+	if err := decode(c, &req); err != nil {
+		return respondError(c, err)
+	}
+	if req.UserName == nil {
+		return respondError(c, newError(http.StatusBadRequest, scimTypeInvalidValue, "userName is required"))
+	}
+	return s.updateUser(c, uid, req.param())
+}
+
+func (s *Server) PatchUser(c echo.Context) error {
+	uid, err := userID(c.Param("id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	var req patchRequest
+	if err := decode(c, &req); err != nil {
+		return respondError(c, err)
+	}
+	var p interfaces.SCIMUserParam
+	if err := req.apply(func(op string, path *valuePath, value json.RawMessage) error {
+		return patchUser(&p, op, path, value)
+	}); err != nil {
+		return respondError(c, err)
+	}
+	return s.updateUser(c, uid, p)
+}
+
+func (s *Server) updateUser(c echo.Context, uid user.ID, p interfaces.SCIMUserParam) error {
+	t := tenant(c)
+	u, err := s.scim.UpdateUser(c.Request().Context(), t.ID(), uid, p)
+	if err != nil {
+		return respondError(c, err)
+	}
+	return respond(c, http.StatusOK, newUserResource(c, t, u))
+}
+
+// DeleteUser deprovisions the user. The user is deactivated rather than
+// removed, so that provisioning it again restores its data.
+func (s *Server) DeleteUser(c echo.Context) error {
+	uid, err := userID(c.Param("id"))
+	if err != nil {
+		return respondError(c, err)
+	}
+	if err := s.scim.DeleteUser(c.Request().Context(), tenant(c).ID(), uid); err != nil {
+		return respondError(c, err)
+	}
+	return c.NoContent(http.StatusNoContent)
+}
+
+// patchUser applies a PATCH operation to p. Attributes the service does not
+// store, such as phoneNumbers or externalId, are ignored so that identity
+// providers can keep sending them.
+func patchUser(p *interfaces.SCIMUserParam, op string, path *valuePath, value json.RawMessage) error {
+	if op == patchOpRemove {
+		return nil
+	}
+	switch path.Attr {
+	case "active":
+		var v boolValue
+		if err := unmarshalValue(value, &v); err != nil {
+			return err
+		}
+		p.Active = lo.ToPtr(bool(v))
+	case "username":
+		return unmarshalValue(value, &p.UserName)
+	case "displayname":
+		return unmarshalValue(value, &p.Name)
+	case "name":
+		switch path.SubAttr {
+		case "":
+			var n nameAttr
+			if err := unmarshalValue(value, &n); err != nil {
+				return err
+			}
+			if s := n.String(); s != "" {
+				p.Name = &s
+			}
+		case "formatted":
+			return unmarshalValue(value, &p.Name)
+		}
+	case "emails":
+		if path.HasFilter || path.SubAttr == "value" {
+			return unmarshalValue(value, &p.Email)
+		}
+		var emails []emailAttr
+		if err := unmarshalValue(value, &emails); err != nil {
+			return err
+		}
+		if e := primaryEmail(emails); e != nil {
+			p.Email = e
+		}
+	}
+	return nil
+}
+
+func userID(s string) (user.ID, error) {
+	uid, err := user.IDFrom(s)
+	if err != nil {
+		return user.ID{}, rerror.ErrNotFound
+	}
+	return uid, nil
+}
@@ -1,7 +1,8 @@
 // Package session issues and verifies the admin app's own session token: a
 // short-lived HS256 JWT that is stored in an HttpOnly cookie. Google's id_token
 // is only used once at sign-in; the session token is what authenticates
-// subsequent requests.
+// subsequent requests. The token names a server-side adminsession.Session in
+// its jti claim so the session can be revoked before the token expires.
 package session
 
 import (
@@ -9,12 +10,13 @@ import (
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 )
 
 var (
 	// ErrInvalidToken is returned when a token is malformed, has a bad
-	// signature, is expired, or carries an unusable subject.
+	// signature, is expired, or carries an unusable subject or session ID.
 	ErrInvalidToken = errors.New("invalid session token")
 	// ErrEmptySecret is returned by Issue/Parse when no signing secret is
 	// configured (NewManager itself never fails).
@@ -41,12 +43,20 @@ func NewManager(secret string, ttl time.Duration) *Manager {
 // TTL returns the configured token lifetime.
 func (m *Manager) TTL() time.Duration { return m.ttl }
 
-// Issue creates a signed session token for the given admin user, valid for TTL.
-func (m *Manager) Issue(id adminuser.ID, now time.Time) (string, error) {
+// Claims are the identifiers carried by a valid session token.
+type Claims struct {
+	AdminUser adminuser.ID
+	Session   adminsession.ID
+}
+
+// Issue creates a signed session token for the given admin user and
+// server-side session, valid for TTL.
+func (m *Manager) Issue(id adminuser.ID, sid adminsession.ID, now time.Time) (string, error) {
 	if len(m.secret) == 0 {
 		return "", ErrEmptySecret
 	}
 	claims := jwt.RegisteredClaims{
+		ID:        sid.String(),
 		Subject:   id.String(),
 		Issuer:    issuer,
 		IssuedAt:  jwt.NewNumericDate(now),
@@ -56,10 +66,12 @@ func (m *Manager) Issue(id adminuser.ID, now time.Time) (string, error) {
 	return token.SignedString(m.secret)
 }
 
-// Parse validates a session token and returns the admin user ID it carries.
-func (m *Manager) Parse(token string) (adminuser.ID, error) {
+// Parse validates a session token and returns the admin user and session IDs
+// it carries. Tokens issued without a session ID are rejected. Parse does not
+// consult the session store; callers must check the session still exists.
+func (m *Manager) Parse(token string) (Claims, error) {
 	if len(m.secret) == 0 {
-		return adminuser.ID{}, ErrEmptySecret
+		return Claims{}, ErrEmptySecret
 	}
 
 	claims := &jwt.RegisteredClaims{}
@@ -70,12 +82,16 @@ func (m *Manager) Parse(token string) (adminuser.ID, error) {
 		return m.secret, nil
 	}, jwt.WithValidMethods([]string{"HS256"}), jwt.WithIssuer(issuer))
 	if err != nil {
-		return adminuser.ID{}, ErrInvalidToken
+		return Claims{}, ErrInvalidToken
 	}
 
 	id, err := adminuser.IDFrom(claims.Subject)
 	if err != nil {
-		return adminuser.ID{}, ErrInvalidToken
+		return Claims{}, ErrInvalidToken
+	}
+	sid, err := adminsession.IDFrom(claims.ID)
+	if err != nil {
+		return Claims{}, ErrInvalidToken
 	}
-	return id, nil
+	return Claims{AdminUser: id, Session: sid}, nil
 }
@@ -4,6 +4,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 	"github.com/stretchr/testify/assert"
 )
@@ -11,22 +13,23 @@ import (
 func TestManager_IssueParse_RoundTrip(t *testing.T) {
 	m := NewManager("test-secret-that-is-long-enough-000", time.Hour)
 	id := adminuser.NewID()
+	sid := adminsession.NewID()
 	now := time.Now()
 
-	tok, err := m.Issue(id, now)
+	tok, err := m.Issue(id, sid, now)
 	assert.NoError(t, err)
 	assert.NotEmpty(t, tok)
 
 	got, err := m.Parse(tok)
 	assert.NoError(t, err)
-	assert.Equal(t, id, got)
+	assert.Equal(t, Claims{AdminUser: id, Session: sid}, got)
 }
 
 func TestManager_Parse_Expired(t *testing.T) {
 	m := NewManager("test-secret-that-is-long-enough-000", time.Hour)
 	id := adminuser.NewID()
 
-	tok, err := m.Issue(id, time.Now().Add(-2*time.Hour)) // expired 1h ago
+	tok, err := m.Issue(id, adminsession.NewID(), time.Now().Add(-2*time.Hour)) // expired 1h ago
 	assert.NoError(t, err)
 
 	_, err = m.Parse(tok)
@@ -37,7 +40,7 @@ func TestManager_Parse_WrongSecret(t *testing.T) {
 	m := NewManager("secret-a-secret-a-secret-a-secret-a", time.Hour)
 	other := NewManager("secret-b-secret-b-secret-b-secret-b", time.Hour)
 
-	tok, err := m.Issue(adminuser.NewID(), time.Now())
+	tok, err := m.Issue(adminuser.NewID(), adminsession.NewID(), time.Now())
 	assert.NoError(t, err)
 
 	_, err = other.Parse(tok)
@@ -50,8 +53,23 @@ func TestManager_Parse_Garbage(t *testing.T) {
 	assert.ErrorIs(t, err, ErrInvalidToken)
 }
 
+func TestManager_Parse_MissingSessionID(t *testing.T) {
+	secret := "test-secret-that-is-long-enough-000"
+	m := NewManager(secret, time.Hour)
+	// A token minted before sessions were tracked server-side has no jti.
+	tok, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   adminuser.NewID().String(),
+		Issuer:    issuer,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte(secret))
+	assert.NoError(t, err)
+
+	_, err = m.Parse(tok)
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
+
 func TestManager_EmptySecret(t *testing.T) {
 	m := NewManager("", time.Hour)
-	_, err := m.Issue(adminuser.NewID(), time.Now())
+	_, err := m.Issue(adminuser.NewID(), adminsession.NewID(), time.Now())
 	assert.ErrorIs(t, err, ErrEmptySecret)
 }
@@ -14,14 +14,23 @@ import (
 	"github.com/kelseyhightower/envconfig"
 	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
 	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/google"
+	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/oidc"
 	authhandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/auth"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/authuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/scimtenantuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
 	mongorepo "github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/storage"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/impersonation"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearthx/appx"
 	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/mailer"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -47,6 +56,17 @@ type Config struct {
 	Auth_TTL *int
 	Auth     AuthConfigs
 
+	// storage and mailer — shared with the main service; they deliver the
+	// personal data exports of users.
+	StorageIsLocal          bool   `envconfig:"REEARTH_ACCOUNTS_STORAGE_IS_LOCAL"`
+	StorageBucketName       string `envconfig:"REEARTH_ACCOUNTS_STORAGE_BUCKET_NAME" default:"reearth"`
+	StorageEmulatorEnabled  bool   `envconfig:"REEARTH_ACCOUNTS_STORAGE_EMULATOR_ENABLED"`
+	StorageEmulatorEndpoint string `envconfig:"REEARTH_ACCOUNTS_STORAGE_EMULATOR_ENDPOINT"`
+
+	// HostWeb is the sign-in UI of the main service, which the password reset
+	// links mailed on behalf of users point at.
+	HostWeb string `envconfig:"REEARTH_HOSTWEB"`
+
 	// cerbos
 	CerbosHost   string `envconfig:"CERBOS_HOST"`
 	CerbosUseSSL bool   `default:"true" envconfig:"REEARTH_ACCOUNTS_CERBOS_USE_SSL"`
@@ -57,6 +77,32 @@ type Config struct {
 	SessionTTL          time.Duration `default:"12h" envconfig:"REEARTH_ACCOUNTS_ADMIN_SESSION_TTL"`
 	BootstrapEmails     []string      `envconfig:"REEARTH_ACCOUNTS_ADMIN_BOOTSTRAP_EMAILS"`
 	AllowedEmailDomain  string        `default:"eukarya.io" envconfig:"REEARTH_ACCOUNTS_ADMIN_ALLOWED_EMAIL_DOMAIN"`
+	// GoogleGroupsLookup looks up the Google Workspace groups of admins through
+	// the Cloud Identity API so group approval rules match Google sign-ins.
+	GoogleGroupsLookup bool `envconfig:"REEARTH_ACCOUNTS_ADMIN_GOOGLE_GROUPS_LOOKUP"`
+
+	// admin OIDC sign-in — a generic OpenID Connect IdP (e.g. on-prem
+	// Keycloak) next to or instead of Google; disabled when the issuer is empty.
+	OIDCIssuer               string        `envconfig:"REEARTH_ACCOUNTS_ADMIN_OIDC_ISSUER"`
+	OIDCClientID             string        `envconfig:"REEARTH_ACCOUNTS_ADMIN_OIDC_CLIENT_ID"`
+	OIDCJWKSURI              string        `envconfig:"REEARTH_ACCOUNTS_ADMIN_OIDC_JWKS_URI"`
+	OIDCAlgorithm            string        `default:"RS256" envconfig:"REEARTH_ACCOUNTS_ADMIN_OIDC_ALG"`
+	OIDCJWKSCacheTTL         time.Duration `default:"15m" envconfig:"REEARTH_ACCOUNTS_ADMIN_OIDC_JWKS_CACHE_TTL"`
+	OIDCRequiredClaims       []string      `envconfig:"REEARTH_ACCOUNTS_ADMIN_OIDC_REQUIRED_CLAIMS"`
+	OIDCRequireEmailVerified bool          `default:"true" envconfig:"REEARTH_ACCOUNTS_ADMIN_OIDC_REQUIRE_EMAIL_VERIFIED"`
+	OIDCAllowedDomains       []string      `envconfig:"REEARTH_ACCOUNTS_ADMIN_OIDC_ALLOWED_DOMAINS"`
+	OIDCAllowedGroups        []string      `envconfig:"REEARTH_ACCOUNTS_ADMIN_OIDC_ALLOWED_GROUPS"`
+	OIDCGroupsClaim          string        `default:"groups" envconfig:"REEARTH_ACCOUNTS_ADMIN_OIDC_GROUPS_CLAIM"`
+
+	// impersonation — the secret is shared with the main service, which
+	// accepts the tokens; when empty, impersonation is disabled.
+	ImpersonationSecret string        `envconfig:"REEARTH_ACCOUNTS_IMPERSONATION_SECRET"`
+	ImpersonationTTL    time.Duration `default:"15m" envconfig:"REEARTH_ACCOUNTS_ADMIN_IMPERSONATION_TTL"`
+
+	// identity providers of the main service, whose sub prefixes SCIM tenants
+	// can't take
+	OIDCIdPs      OIDCProviderNames `envconfig:"REEARTH_ACCOUNTS_OIDC_IDPS"`
+	LDAPSubPrefix string            `default:"ldap" envconfig:"REEARTH_ACCOUNTS_LDAP_SUB_PREFIX"`
 }
 
 type Auth0Config struct {
@@ -83,6 +129,30 @@ func (ipd *AuthConfigs) Decode(value string) error {
 	return nil
 }
 
+// OIDCProviderNames are the names of the generic OIDC providers, which prefix
+// the subs of their users.
+type OIDCProviderNames []string
+
+// Decode reads the names out of the JSON-encoded providers of the main service.
+func (n *OIDCProviderNames) Decode(value string) error {
+	if strings.TrimSpace(value) == "" {
+		*n = nil
+		return nil
+	}
+	var providers []struct {
+		Name string `json:"name"`
+	}
+	if err := json.Unmarshal([]byte(value), &providers); err != nil {
+		return fmt.Errorf("invalid oidc identity providers json: %w", err)
+	}
+	names := make([]string, 0, len(providers))
+	for _, p := range providers {
+		names = append(names, p.Name)
+	}
+	*n = names
+	return nil
+}
+
 func (c Auth0Config) authConfig() *AuthConfig {
 	domain := c.Domain
 	if domain == "" {
@@ -147,6 +217,9 @@ func (c *Config) Print() {
 	if masked.SessionSecret != "" {
 		masked.SessionSecret = "***"
 	}
+	if masked.ImpersonationSecret != "" {
+		masked.ImpersonationSecret = "***"
+	}
 	log.Infof("admin config: %+v", masked)
 }
 
@@ -168,17 +241,58 @@ func LoadConfig() *Config {
 	return &cfg
 }
 
-// provideGoogleVerifier builds the Google id_token verifier bound to the admin
-// OAuth client ID. A missing client ID is a misconfiguration that would reject
-// every sign-in, so we fail fast in production (and warn in development).
-func provideGoogleVerifier(cfg *Config) (google.Verifier, error) {
+// provideGoogleSignInUseCase builds Google sign-in with the id_token verifier
+// bound to the admin OAuth client ID. A missing client ID rejects every Google
+// sign-in, so we fail fast in production unless OIDC sign-in is configured
+// instead (and warn in development).
+func provideGoogleSignInUseCase(cfg *Config, repo adminuser.Repo, rules adminapprovalrule.Repo) (*authuc.GoogleSignInUseCase, error) {
 	if cfg.GoogleOAuthClientID == "" {
-		if cfg.IsProduction() {
-			return nil, fmt.Errorf("REEARTH_ACCOUNTS_ADMIN_GOOGLE_OAUTH_CLIENT_ID is required in production")
+		if cfg.IsProduction() && cfg.OIDCIssuer == "" {
+			return nil, fmt.Errorf("REEARTH_ACCOUNTS_ADMIN_GOOGLE_OAUTH_CLIENT_ID or REEARTH_ACCOUNTS_ADMIN_OIDC_ISSUER is required in production")
 		}
 		log.Warnf("admin Google OAuth client ID not configured; Google sign-in will reject all tokens")
 	}
-	return google.NewVerifier(cfg.GoogleOAuthClientID), nil
+	opts := authuc.GoogleSignInOptions{
+		AllowedDomain:   cfg.AllowedEmailDomain,
+		BootstrapEmails: cfg.BootstrapEmails,
+	}
+	if cfg.GoogleGroupsLookup {
+		groups, err := google.NewGroupLister(context.Background())
+		if err != nil {
+			return nil, fmt.Errorf("admin google groups lookup: %w", err)
+		}
+		opts.Groups = groups
+	}
+	return authuc.NewGoogleSignInUseCase(repo, rules, google.NewVerifier(cfg.GoogleOAuthClientID), opts), nil
+}
+
+// provideOIDCSignInUseCase builds OIDC sign-in. Without an issuer the
+// endpoint answers 501; an issuer with an unusable configuration fails at
+// start rather than on every sign-in.
+func provideOIDCSignInUseCase(cfg *Config, repo adminuser.Repo, rules adminapprovalrule.Repo) (*authuc.OIDCSignInUseCase, error) {
+	opts := authuc.OIDCSignInOptions{BootstrapEmails: cfg.BootstrapEmails}
+	if cfg.OIDCIssuer == "" {
+		return authuc.NewOIDCSignInUseCase(repo, rules, nil, opts), nil
+	}
+	v, err := oidc.NewVerifier(oidc.Config{
+		Issuer:               cfg.OIDCIssuer,
+		ClientID:             cfg.OIDCClientID,
+		JWKSURI:              cfg.OIDCJWKSURI,
+		Algorithm:            cfg.OIDCAlgorithm,
+		CacheTTL:             cfg.OIDCJWKSCacheTTL,
+		RequiredClaims:       cfg.OIDCRequiredClaims,
+		RequireEmailVerified: cfg.OIDCRequireEmailVerified,
+		AllowedDomains:       cfg.OIDCAllowedDomains,
+		AllowedGroups:        cfg.OIDCAllowedGroups,
+		GroupsClaim:          cfg.OIDCGroupsClaim,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("admin oidc sign-in: %w", err)
+	}
+	if len(cfg.OIDCAllowedDomains) == 0 && len(cfg.OIDCAllowedGroups) == 0 {
+		log.Warnf("admin OIDC sign-in admits every account of %s; new accounts still need approval", cfg.OIDCIssuer)
+	}
+	return authuc.NewOIDCSignInUseCase(repo, rules, v, opts), nil
 }
 
 // provideSessionManager builds the session-token issuer/parser. An empty secret
@@ -198,12 +312,23 @@ func provideSessionManager(cfg *Config) (*session.Manager, error) {
 	return session.NewManager(cfg.SessionSecret, cfg.SessionTTL), nil
 }
 
-// provideGoogleSignInOptions maps config to the sign-in policy.
-func provideGoogleSignInOptions(cfg *Config) authuc.GoogleSignInOptions {
-	return authuc.GoogleSignInOptions{
-		AllowedDomain:   cfg.AllowedEmailDomain,
-		BootstrapEmails: cfg.BootstrapEmails,
+// provideImpersonationSigner builds the impersonation token signer. Unlike the
+// session secret the impersonation secret is optional: without it the
+// impersonate endpoint answers 501. The TTL is validated up front so a bad
+// value fails at start rather than on every impersonation.
+func provideImpersonationSigner(cfg *Config) (*impersonation.Signer, error) {
+	if cfg.ImpersonationTTL <= 0 || cfg.ImpersonationTTL > impersonation.MaxTTL {
+		return nil, fmt.Errorf("REEARTH_ACCOUNTS_ADMIN_IMPERSONATION_TTL must be positive and at most %s", impersonation.MaxTTL)
+	}
+	if cfg.ImpersonationSecret == "" {
+		log.Infof("impersonation secret not configured; impersonation is disabled")
 	}
+	return impersonation.NewSigner(cfg.ImpersonationSecret), nil
+}
+
+// provideImpersonationTTL is the lifetime of impersonation tokens.
+func provideImpersonationTTL(cfg *Config) useruc.ImpersonationTTL {
+	return useruc.ImpersonationTTL(cfg.ImpersonationTTL)
 }
 
 // provideCookieSecure sets the session cookie's Secure attribute (on in prod).
@@ -280,3 +405,30 @@ func provideCerbosClient(cfg *Config) (*cerbos.GRPCClient, error) {
 	}
 	return client, nil
 }
+
+// provideStorage builds the GCS storage the personal data exports of users are
+// uploaded to, with the bucket of the main service.
+func provideStorage(cfg *Config) (gateway.Storage, error) {
+	return storage.NewGCPStorage(&storage.Config{
+		IsLocal:          cfg.StorageIsLocal,
+		BucketName:       cfg.StorageBucketName,
+		EmulatorEnabled:  cfg.StorageEmulatorEnabled,
+		EmulatorEndpoint: cfg.StorageEmulatorEndpoint,
+	})
+}
+
+// provideHostWeb is the base URL of the password reset links.
+// provideReservedSubPrefixes keeps SCIM tenants off the subs of the identity
+// providers configured on the main service.
+func provideReservedSubPrefixes(cfg *Config) scimtenantuc.ReservedSubPrefixes {
+	return append(scimtenantuc.ReservedSubPrefixes{cfg.LDAPSubPrefix}, cfg.OIDCIdPs...)
+}
+
+func provideHostWeb(cfg *Config) useruc.HostWeb {
+	return useruc.HostWeb(cfg.HostWeb)
+}
+
+// provideMailer builds the mailer the same way the main service does.
+func provideMailer() mailer.Mailer {
+	return mailer.New(context.Background(), &mailer.Config{})
+}
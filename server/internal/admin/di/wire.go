@@ -5,6 +5,8 @@ package di
 
 import (
 	"github.com/goforj/wire"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminaudituc"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
 )
 
 // InitializeEcho builds the fully-wired admin Echo server. The returned cleanup
@@ -34,3 +36,16 @@ func InitializeEcho() (*Server, func(), error) {
 	)
 	return nil, nil, nil
 }
+
+// InitializeAuditLogVerifier builds the admin audit chain verifier run by the
+// verify-audit-log command. It only needs the repository container; the
+// returned cleanup closes the DB connection.
+func InitializeAuditLogVerifier() (*adminaudituc.VerifyUseCase, func(), error) {
+	wire.Build(
+		LoadConfig,
+		provideRepoContainer,
+		wire.FieldsOf(new(*repo.Container), "AdminAudit"),
+		adminaudituc.NewVerifyUseCase,
+	)
+	return nil, nil, nil
+}
@@ -7,7 +7,9 @@ import (
 	"github.com/goforj/wire"
 )
 
-// gatewayWire provides external-service clients (Cerbos).
+// gatewayWire provides external-service clients (Cerbos, storage, mailer).
 var gatewayWire = wire.NewSet(
 	provideCerbosClient,
+	provideStorage,
+	provideMailer,
 )
@@ -7,14 +7,31 @@ package di
 
 import (
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/adminaudit"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/adminuser"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/approvalrule"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/auth"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/ldapsync"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/platformrole"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/rolemapping"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/scimtenant"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/signingkey"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/stats"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/user"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/workspace"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminaudituc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminuseruc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/approvalruleuc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/authuc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/authz"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/ldapsyncuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/platformroleuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/rolemappinguc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/scimtenantuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/sessionuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/signingkeyuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/statsuc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
 )
@@ -29,49 +46,149 @@ func InitializeEcho() (*Server, func(), error) {
 	if err != nil {
 		return nil, nil, err
 	}
-	repo := container.AdminUser
-	listAdminUsersUseCase := adminuseruc.NewListAdminUsersUseCase(repo)
-	approveAdminUserUseCase := adminuseruc.NewApproveAdminUserUseCase(repo)
-	rejectAdminUserUseCase := adminuseruc.NewRejectAdminUserUseCase(repo)
-	setRoleUseCase := adminuseruc.NewSetRoleUseCase(repo)
-	handler := adminuser.NewHandler(listAdminUsersUseCase, approveAdminUserUseCase, rejectAdminUserUseCase, setRoleUseCase)
-	verifier, err := provideGoogleVerifier(config)
+	repo := container.AdminAudit
+	listUseCase := adminaudituc.NewListUseCase(repo)
+	handler := adminaudit.NewHandler(listUseCase)
+	adminuserRepo := container.AdminUser
+	listAdminUsersUseCase := adminuseruc.NewListAdminUsersUseCase(adminuserRepo)
+	approveAdminUserUseCase := adminuseruc.NewApproveAdminUserUseCase(adminuserRepo)
+	adminsessionRepo := container.AdminSession
+	rejectAdminUserUseCase := adminuseruc.NewRejectAdminUserUseCase(adminuserRepo, adminsessionRepo)
+	setRoleUseCase := adminuseruc.NewSetRoleUseCase(adminuserRepo, adminsessionRepo)
+	adminuserHandler := adminuser.NewHandler(listAdminUsersUseCase, approveAdminUserUseCase, rejectAdminUserUseCase, setRoleUseCase)
+	adminapprovalruleRepo := container.AdminApprovalRule
+	listApprovalRulesUseCase := approvalruleuc.NewListApprovalRulesUseCase(adminapprovalruleRepo)
+	createApprovalRuleUseCase := approvalruleuc.NewCreateApprovalRuleUseCase(adminapprovalruleRepo)
+	updateApprovalRuleUseCase := approvalruleuc.NewUpdateApprovalRuleUseCase(adminapprovalruleRepo)
+	deleteApprovalRuleUseCase := approvalruleuc.NewDeleteApprovalRuleUseCase(adminapprovalruleRepo)
+	approvalruleHandler := approvalrule.NewHandler(listApprovalRulesUseCase, createApprovalRuleUseCase, updateApprovalRuleUseCase, deleteApprovalRuleUseCase)
+	googleSignInUseCase, err := provideGoogleSignInUseCase(config, adminuserRepo, adminapprovalruleRepo)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
-	googleSignInOptions := provideGoogleSignInOptions(config)
-	googleSignInUseCase := authuc.NewGoogleSignInUseCase(repo, verifier, googleSignInOptions)
-	getMeUseCase := authuc.NewGetMeUseCase(repo)
+	oidcSignInUseCase, err := provideOIDCSignInUseCase(config, adminuserRepo, adminapprovalruleRepo)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	getMeUseCase := authuc.NewGetMeUseCase(adminuserRepo)
 	manager, err := provideSessionManager(config)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
+	createSessionUseCase := sessionuc.NewCreateSessionUseCase(adminsessionRepo, manager)
+	listSessionsUseCase := sessionuc.NewListSessionsUseCase(adminsessionRepo)
+	revokeSessionUseCase := sessionuc.NewRevokeSessionUseCase(adminsessionRepo)
 	cookieSecure := provideCookieSecure(config)
-	authHandler := auth.NewHandler(googleSignInUseCase, getMeUseCase, manager, cookieSecure)
+	authHandler := auth.NewHandler(googleSignInUseCase, oidcSignInUseCase, getMeUseCase, createSessionUseCase, listSessionsUseCase, revokeSessionUseCase, manager, cookieSecure)
+	ldapsyncRepo := container.LDAPSync
+	listLDAPSyncRunsUseCase := ldapsyncuc.NewListLDAPSyncRunsUseCase(ldapsyncRepo)
+	getLDAPSyncRunUseCase := ldapsyncuc.NewGetLDAPSyncRunUseCase(ldapsyncRepo)
+	ldapsyncHandler := ldapsync.NewHandler(listLDAPSyncRunsUseCase, getLDAPSyncRunUseCase)
+	roleRepo := container.Role
+	permittableRepo := container.Permittable
+	listRolesUseCase := platformroleuc.NewListRolesUseCase(roleRepo, permittableRepo)
 	userRepo := container.User
-	getUserUseCase := useruc.NewGetUserUseCase(userRepo)
+	listRoleUsersUseCase := platformroleuc.NewListRoleUsersUseCase(roleRepo, permittableRepo, userRepo)
 	workspaceRepo := container.Workspace
+	transaction := container.Transaction
+	auditlogRepo := container.AuditLog
+	updateWorkspaceMemberUseCase := workspaceuc.NewUpdateWorkspaceMemberUseCase(workspaceRepo, roleRepo, permittableRepo, auditlogRepo, transaction)
+	grantRoleUseCase := platformroleuc.NewGrantRoleUseCase(roleRepo, permittableRepo, userRepo, workspaceRepo, transaction, updateWorkspaceMemberUseCase)
+	removeWorkspaceMemberUseCase := workspaceuc.NewRemoveWorkspaceMemberUseCase(workspaceRepo, roleRepo, permittableRepo, auditlogRepo, transaction)
+	revokeRoleUseCase := platformroleuc.NewRevokeRoleUseCase(roleRepo, permittableRepo, userRepo, workspaceRepo, transaction, removeWorkspaceMemberUseCase)
+	platformroleHandler := platformrole.NewHandler(listRolesUseCase, listRoleUsersUseCase, grantRoleUseCase, revokeRoleUseCase)
+	rolemappingRepo := container.RoleMapping
+	getRoleMappingUseCase := rolemappinguc.NewGetRoleMappingUseCase(rolemappingRepo)
+	setRoleMappingUseCase := rolemappinguc.NewSetRoleMappingUseCase(rolemappingRepo, workspaceRepo)
+	deleteRoleMappingUseCase := rolemappinguc.NewDeleteRoleMappingUseCase(rolemappingRepo)
+	rolemappingHandler := rolemapping.NewHandler(getRoleMappingUseCase, setRoleMappingUseCase, deleteRoleMappingUseCase)
+	scimtenantRepo := container.SCIMTenant
+	listSCIMTenantsUseCase := scimtenantuc.NewListSCIMTenantsUseCase(scimtenantRepo)
+	reservedSubPrefixes := provideReservedSubPrefixes(config)
+	createSCIMTenantUseCase := scimtenantuc.NewCreateSCIMTenantUseCase(scimtenantRepo, reservedSubPrefixes)
+	rotateSCIMTenantTokenUseCase := scimtenantuc.NewRotateSCIMTenantTokenUseCase(scimtenantRepo)
+	deleteSCIMTenantUseCase := scimtenantuc.NewDeleteSCIMTenantUseCase(scimtenantRepo)
+	scimtenantHandler := scimtenant.NewHandler(listSCIMTenantsUseCase, createSCIMTenantUseCase, rotateSCIMTenantTokenUseCase, deleteSCIMTenantUseCase)
+	configRepo := container.Config
+	listSigningKeysUseCase := signingkeyuc.NewListSigningKeysUseCase(configRepo)
+	rotateSigningKeyUseCase := signingkeyuc.NewRotateSigningKeyUseCase(configRepo)
+	signingkeyHandler := signingkey.NewHandler(listSigningKeysUseCase, rotateSigningKeyUseCase)
+	adminstatsRepo := container.AdminStats
+	getStatsUseCase := statsuc.NewGetStatsUseCase(adminstatsRepo)
+	statsHandler := stats.NewHandler(getStatsUseCase)
+	getUserUseCase := useruc.NewGetUserUseCase(userRepo)
 	getUserWorkspacesUseCase := useruc.NewGetUserWorkspacesUseCase(userRepo, workspaceRepo)
 	listUsersUseCase := useruc.NewListUsersUseCase(userRepo)
-	userHandler := user.NewHandler(getUserUseCase, getUserWorkspacesUseCase, listUsersUseCase)
+	mergeUsersUseCase := useruc.NewMergeUsersUseCase(userRepo, workspaceRepo, roleRepo, permittableRepo, auditlogRepo, transaction)
+	importUsersUseCase := useruc.NewImportUsersUseCase(userRepo, workspaceRepo, roleRepo, permittableRepo, auditlogRepo, transaction)
+	exportUsersUseCase := useruc.NewExportUsersUseCase(userRepo, workspaceRepo, auditlogRepo)
+	exportUserListingUseCase := useruc.NewExportUserListingUseCase(userRepo, workspaceRepo, auditlogRepo)
+	storage, err := provideStorage(config)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	mailer := provideMailer()
+	exportUserDataUseCase := useruc.NewExportUserDataUseCase(userRepo, workspaceRepo, roleRepo, permittableRepo, auditlogRepo, storage, mailer)
+	deactivateUserUseCase := useruc.NewDeactivateUserUseCase(userRepo, auditlogRepo, transaction)
+	restoreUserUseCase := useruc.NewRestoreUserUseCase(userRepo, auditlogRepo, transaction)
+	updateUserUseCase := useruc.NewUpdateUserUseCase(userRepo, workspaceRepo, auditlogRepo, transaction)
+	verifyUserEmailUseCase := useruc.NewVerifyUserEmailUseCase(userRepo, auditlogRepo, transaction)
+	hostWeb := provideHostWeb(config)
+	resetUserPasswordUseCase := useruc.NewResetUserPasswordUseCase(userRepo, auditlogRepo, transaction, mailer, hostWeb)
+	removeUserAuthUseCase := useruc.NewRemoveUserAuthUseCase(userRepo, auditlogRepo, transaction)
+	resetUserMFAUseCase := useruc.NewResetUserMFAUseCase(userRepo, auditlogRepo, transaction)
+	signer, err := provideImpersonationSigner(config)
+	if err != nil {
+		cleanup()
+		return nil, nil, err
+	}
+	impersonationTTL := provideImpersonationTTL(config)
+	impersonateUserUseCase := useruc.NewImpersonateUserUseCase(userRepo, auditlogRepo, signer, impersonationTTL)
+	userHandler := user.NewHandler(getUserUseCase, getUserWorkspacesUseCase, listUsersUseCase, mergeUsersUseCase, importUsersUseCase, exportUsersUseCase, exportUserListingUseCase, exportUserDataUseCase, deactivateUserUseCase, restoreUserUseCase, updateUserUseCase, verifyUserEmailUseCase, resetUserPasswordUseCase, removeUserAuthUseCase, resetUserMFAUseCase, impersonateUserUseCase)
 	getWorkspaceUseCase := workspaceuc.NewGetWorkspaceUseCase(workspaceRepo)
 	listWorkspacesUseCase := workspaceuc.NewListWorkspacesUseCase(workspaceRepo)
+	exportWorkspaceListingUseCase := workspaceuc.NewExportWorkspaceListingUseCase(workspaceRepo, auditlogRepo)
 	listWorkspaceMembersUseCase := workspaceuc.NewListWorkspaceMembersUseCase(workspaceRepo, userRepo)
-	workspaceHandler := workspace.NewHandler(getWorkspaceUseCase, listWorkspacesUseCase, listWorkspaceMembersUseCase)
-	sessionMiddleware := middleware.NewSessionMiddleware(manager)
-	requireApprovedMiddleware := middleware.NewRequireApprovedMiddleware(manager, repo)
+	updateWorkspaceUseCase := workspaceuc.NewUpdateWorkspaceUseCase(workspaceRepo, auditlogRepo, transaction)
+	deactivateWorkspaceUseCase := workspaceuc.NewDeactivateWorkspaceUseCase(workspaceRepo, auditlogRepo, transaction)
+	restoreWorkspaceUseCase := workspaceuc.NewRestoreWorkspaceUseCase(workspaceRepo, auditlogRepo, transaction)
+	addWorkspaceMembersUseCase := workspaceuc.NewAddWorkspaceMembersUseCase(workspaceRepo, userRepo, roleRepo, permittableRepo, auditlogRepo, transaction)
+	removeWorkspaceIntegrationUseCase := workspaceuc.NewRemoveWorkspaceIntegrationUseCase(workspaceRepo, auditlogRepo, transaction)
+	workspaceHandler := workspace.NewHandler(getWorkspaceUseCase, listWorkspacesUseCase, exportWorkspaceListingUseCase, listWorkspaceMembersUseCase, updateWorkspaceUseCase, deactivateWorkspaceUseCase, restoreWorkspaceUseCase, addWorkspaceMembersUseCase, updateWorkspaceMemberUseCase, removeWorkspaceMemberUseCase, removeWorkspaceIntegrationUseCase)
+	sessionMiddleware := middleware.NewSessionMiddleware(manager, adminsessionRepo)
+	requireApprovedMiddleware := middleware.NewRequireApprovedMiddleware(manager, adminsessionRepo, adminuserRepo)
+	recordUseCase := adminaudituc.NewRecordUseCase(repo)
+	auditTrailMiddleware := middleware.NewAuditTrailMiddleware(recordUseCase)
 	grpcClient, err := provideCerbosClient(config)
 	if err != nil {
 		cleanup()
 		return nil, nil, err
 	}
 	checker := authz.NewChecker(grpcClient)
-	presentationHandler := presentation.NewHandler(handler, authHandler, userHandler, workspaceHandler, sessionMiddleware, requireApprovedMiddleware, checker)
+	presentationHandler := presentation.NewHandler(handler, adminuserHandler, approvalruleHandler, authHandler, ldapsyncHandler, platformroleHandler, rolemappingHandler, scimtenantHandler, signingkeyHandler, statsHandler, userHandler, workspaceHandler, sessionMiddleware, requireApprovedMiddleware, auditTrailMiddleware, checker)
 	appMiddlewares := presentation.NewAppMiddlewares()
 	server := NewAppEchoServer(config, presentationHandler, appMiddlewares)
 	return server, func() {
 		cleanup()
 	}, nil
 }
+
+// InitializeAuditLogVerifier builds the admin audit chain verifier run by the
+// verify-audit-log command. It only needs the repository container; the
+// returned cleanup closes the DB connection.
+func InitializeAuditLogVerifier() (*adminaudituc.VerifyUseCase, func(), error) {
+	config := LoadConfig()
+	container, cleanup, err := provideRepoContainer(config)
+	if err != nil {
+		return nil, nil, err
+	}
+	repo := container.AdminAudit
+	verifyUseCase := adminaudituc.NewVerifyUseCase(repo)
+	return verifyUseCase, func() {
+		cleanup()
+	}, nil
+}
@@ -6,17 +6,33 @@ package di
 import (
 	"github.com/goforj/wire"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation"
+	adminaudithandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/adminaudit"
 	adminuserhandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/adminuser"
+	approvalrulehandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/approvalrule"
 	authhandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/auth"
+	ldapsynchandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/ldapsync"
+	platformrolehandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/platformrole"
+	rolemappinghandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/rolemapping"
+	scimtenanthandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/scimtenant"
+	signingkeyhandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/signingkey"
+	statshandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/stats"
 	userhandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/user"
 	workspacehandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/workspace"
 )
 
 // handlerWire provides the per-resource handlers and the aggregated Handler.
 var handlerWire = wire.NewSet(
+	adminaudithandler.NewHandler,
 	adminuserhandler.NewHandler,
+	approvalrulehandler.NewHandler,
 	authhandler.NewHandler,
+	ldapsynchandler.NewHandler,
+	platformrolehandler.NewHandler,
 	provideCookieSecure,
+	rolemappinghandler.NewHandler,
+	scimtenanthandler.NewHandler,
+	signingkeyhandler.NewHandler,
+	statshandler.NewHandler,
 	userhandler.NewHandler,
 	workspacehandler.NewHandler,
 	presentation.NewHandler,
@@ -9,10 +9,11 @@ import (
 	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
 )
 
-// middlewareWire provides the session/approval middlewares and the application
-// middleware bundle.
+// middlewareWire provides the session, approval and audit trail middlewares
+// and the application middleware bundle.
 var middlewareWire = wire.NewSet(
 	mw.NewSessionMiddleware,
 	mw.NewRequireApprovedMiddleware,
+	mw.NewAuditTrailMiddleware,
 	presentation.NewAppMiddlewares,
 )
@@ -12,5 +12,5 @@ import (
 // the individual repository interfaces consumed by the usecase layer.
 var repoWire = wire.NewSet(
 	provideRepoContainer,
-	wire.FieldsOf(new(*repo.Container), "AdminUser", "User", "Workspace", "Role", "Permittable"),
+	wire.FieldsOf(new(*repo.Container), "AdminUser", "User", "Workspace", "Role", "Permittable", "Config", "RoleMapping", "SCIMTenant", "AuditLog", "LDAPSync", "AdminAudit", "AdminSession", "AdminApprovalRule", "AdminStats", "Transaction"),
 )
@@ -5,9 +5,18 @@ package di
 
 import (
 	"github.com/goforj/wire"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminaudituc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminuseruc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/approvalruleuc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/authuc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/authz"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/ldapsyncuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/platformroleuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/rolemappinguc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/scimtenantuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/sessionuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/signingkeyuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/statsuc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
 )
@@ -19,13 +28,31 @@ var usecaseWire = wire.NewSet(
 	useruc.NewGetUserUseCase,
 	useruc.NewGetUserWorkspacesUseCase,
 	useruc.NewListUsersUseCase,
+	useruc.NewMergeUsersUseCase,
+	useruc.NewImportUsersUseCase,
+	useruc.NewExportUsersUseCase,
+	useruc.NewExportUserListingUseCase,
+	useruc.NewExportUserDataUseCase,
+	useruc.NewDeactivateUserUseCase,
+	useruc.NewRestoreUserUseCase,
+	useruc.NewUpdateUserUseCase,
+	useruc.NewVerifyUserEmailUseCase,
+	useruc.NewResetUserPasswordUseCase,
+	useruc.NewRemoveUserAuthUseCase,
+	useruc.NewResetUserMFAUseCase,
+	useruc.NewImpersonateUserUseCase,
+	provideHostWeb,
+	provideImpersonationSigner,
+	provideImpersonationTTL,
 
 	// session auth dependencies + usecases
-	provideGoogleVerifier,
 	provideSessionManager,
-	provideGoogleSignInOptions,
-	authuc.NewGoogleSignInUseCase,
+	provideGoogleSignInUseCase,
+	provideOIDCSignInUseCase,
 	authuc.NewGetMeUseCase,
+	sessionuc.NewCreateSessionUseCase,
+	sessionuc.NewListSessionsUseCase,
+	sessionuc.NewRevokeSessionUseCase,
 
 	// admin-user management usecases
 	adminuseruc.NewListAdminUsersUseCase,
@@ -37,4 +64,51 @@ var usecaseWire = wire.NewSet(
 	workspaceuc.NewGetWorkspaceUseCase,
 	workspaceuc.NewListWorkspaceMembersUseCase,
 	workspaceuc.NewListWorkspacesUseCase,
+	workspaceuc.NewExportWorkspaceListingUseCase,
+	workspaceuc.NewUpdateWorkspaceUseCase,
+	workspaceuc.NewDeactivateWorkspaceUseCase,
+	workspaceuc.NewRestoreWorkspaceUseCase,
+	workspaceuc.NewAddWorkspaceMembersUseCase,
+	workspaceuc.NewUpdateWorkspaceMemberUseCase,
+	workspaceuc.NewRemoveWorkspaceMemberUseCase,
+	workspaceuc.NewRemoveWorkspaceIntegrationUseCase,
+
+	// signing key rotation usecases
+	signingkeyuc.NewListSigningKeysUseCase,
+	signingkeyuc.NewRotateSigningKeyUseCase,
+
+	// SCIM tenant usecases
+	provideReservedSubPrefixes,
+	scimtenantuc.NewListSCIMTenantsUseCase,
+	scimtenantuc.NewCreateSCIMTenantUseCase,
+	scimtenantuc.NewRotateSCIMTenantTokenUseCase,
+	scimtenantuc.NewDeleteSCIMTenantUseCase,
+
+	// workspace role mapping usecases
+	rolemappinguc.NewGetRoleMappingUseCase,
+	rolemappinguc.NewSetRoleMappingUseCase,
+	rolemappinguc.NewDeleteRoleMappingUseCase,
+
+	// platform role and role binding usecases
+	platformroleuc.NewListRolesUseCase,
+	platformroleuc.NewListRoleUsersUseCase,
+	platformroleuc.NewGrantRoleUseCase,
+	platformroleuc.NewRevokeRoleUseCase,
+
+	// LDAP sync run usecases
+	ldapsyncuc.NewListLDAPSyncRunsUseCase,
+	ldapsyncuc.NewGetLDAPSyncRunUseCase,
+
+	// dashboard statistics usecases
+	statsuc.NewGetStatsUseCase,
+
+	// admin approval rule usecases
+	approvalruleuc.NewListApprovalRulesUseCase,
+	approvalruleuc.NewCreateApprovalRuleUseCase,
+	approvalruleuc.NewUpdateApprovalRuleUseCase,
+	approvalruleuc.NewDeleteApprovalRuleUseCase,
+
+	// admin audit trail usecases
+	adminaudituc.NewRecordUseCase,
+	adminaudituc.NewListUseCase,
 )
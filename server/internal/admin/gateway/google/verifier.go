@@ -1,32 +1,14 @@
-// Package google verifies Google Identity Services id_tokens for the admin app.
+// Package google verifies Google Identity Services id_tokens for the admin app
+// and looks up the Google Workspace groups of admins.
 package google
 
 import (
 	"context"
-	"errors"
 
+	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/identity"
 	"google.golang.org/api/idtoken"
 )
 
-// ErrInvalidIDToken is returned when the id_token fails signature/audience/
-// expiry validation.
-var ErrInvalidIDToken = errors.New("invalid google id_token")
-
-// Claims holds the subset of Google id_token claims the admin auth flow needs.
-type Claims struct {
-	Email         string
-	EmailVerified bool
-	HD            string // Google Workspace hosted domain
-	Name          string
-	PictureURL    string
-}
-
-// Verifier validates a Google id_token and extracts its claims. It is an
-// interface so the usecase layer can be tested with a fake.
-type Verifier interface {
-	Verify(ctx context.Context, idToken string) (*Claims, error)
-}
-
 type verifier struct {
 	clientID string
 }
@@ -34,17 +16,17 @@ type verifier struct {
 // NewVerifier returns a Verifier that validates tokens against the given OAuth
 // client ID (the token's audience). The underlying idtoken package fetches and
 // caches Google's JWKS internally.
-func NewVerifier(clientID string) Verifier {
+func NewVerifier(clientID string) identity.Verifier {
 	return &verifier{clientID: clientID}
 }
 
-func (v *verifier) Verify(ctx context.Context, idToken string) (*Claims, error) {
+func (v *verifier) Verify(ctx context.Context, idToken string) (*identity.Claims, error) {
 	payload, err := idtoken.Validate(ctx, idToken, v.clientID)
 	if err != nil {
-		return nil, ErrInvalidIDToken
+		return nil, identity.ErrInvalidIDToken
 	}
 
-	return &Claims{
+	return &identity.Claims{
 		Email:         stringClaim(payload.Claims, "email"),
 		EmailVerified: boolClaim(payload.Claims, "email_verified"),
 		HD:            stringClaim(payload.Claims, "hd"),
@@ -0,0 +1,45 @@
+// Package identity defines how the admin app verifies the ID tokens of the
+// identity providers admins sign in with. Each provider (Google, a generic
+// OIDC issuer) implements Verifier; the sign-in usecases only see Claims.
+package identity
+
+import (
+	"context"
+	"errors"
+)
+
+var (
+	// ErrInvalidIDToken is returned when the ID token fails signature,
+	// issuer, audience or expiry validation, or lacks a required claim.
+	ErrInvalidIDToken = errors.New("invalid id token")
+	// ErrEmailNotVerified is returned by verifiers that enforce a verified
+	// email themselves.
+	ErrEmailNotVerified = errors.New("email not verified")
+	// ErrNotAllowed is returned when a valid token belongs to an account the
+	// verifier's allow-list (domains, groups) does not admit.
+	ErrNotAllowed = errors.New("account not allowed")
+)
+
+// Claims holds the subset of ID token claims the admin auth flow needs.
+type Claims struct {
+	Email         string
+	EmailVerified bool
+	HD            string // Google Workspace hosted domain; empty for other providers
+	Name          string
+	PictureURL    string
+	// Groups are the groups the token lists the account in; empty for
+	// providers (like Google) whose tokens carry none.
+	Groups []string
+}
+
+// Verifier validates an ID token and extracts its claims. It is an interface
+// so the usecase layer can be tested with a fake.
+type Verifier interface {
+	Verify(ctx context.Context, idToken string) (*Claims, error)
+}
+
+// GroupLister resolves the groups of an account for providers whose ID
+// tokens don't list them, such as Google Workspace.
+type GroupLister interface {
+	Groups(ctx context.Context, email string) ([]string, error)
+}
@@ -0,0 +1,262 @@
+// Package oidc verifies the ID tokens of a generic OpenID Connect provider
+// (Keycloak, Azure AD, Okta, ...) so on-prem deployments can sign into the
+// admin app with their own IdP. The provider's endpoints are found through
+// issuer discovery and its JWKS is cached.
+package oidc
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/auth0/go-jwt-middleware/v2/jwks"
+	"github.com/auth0/go-jwt-middleware/v2/validator"
+	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/identity"
+)
+
+const (
+	defaultAlgorithm   = "RS256"
+	defaultCacheTTL    = 15 * time.Minute
+	defaultGroupsClaim = "groups"
+	clockSkew          = time.Minute
+)
+
+// Config describes the OIDC provider and which of its accounts are admitted.
+type Config struct {
+	Issuer   string
+	ClientID string
+	// JWKSURI overrides the jwks_uri found through discovery.
+	JWKSURI string
+	// Algorithm is the signing algorithm of the ID tokens; RS256 by default.
+	Algorithm string
+	// CacheTTL is how long the JWKS is cached; 15 minutes by default.
+	CacheTTL time.Duration
+	// RequiredClaims must be present and non-empty in every token, on top of
+	// email. Dotted names reach into nested objects.
+	RequiredClaims []string
+	// RequireEmailVerified rejects tokens whose email_verified is not true.
+	// Some IdPs (e.g. Azure AD) never send it; without it, their users can
+	// only create pending accounts, as admin accounts are matched by email.
+	RequireEmailVerified bool
+	// AllowedDomains, when set, admits only emails in one of the domains.
+	AllowedDomains []string
+	// AllowedGroups, when set, admits only members of one of the groups.
+	AllowedGroups []string
+	// GroupsClaim names the claim listing the groups; "groups" by default.
+	GroupsClaim string
+}
+
+// Validate reports configuration errors that would make every sign-in fail.
+func (c Config) Validate() error {
+	u, err := url.Parse(c.Issuer)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("oidc issuer must be an http(s) URL")
+	}
+	if c.ClientID == "" {
+		return fmt.Errorf("oidc client id is required")
+	}
+	if c.JWKSURI != "" {
+		if _, err := url.Parse(c.JWKSURI); err != nil {
+			return fmt.Errorf("oidc jwks uri is invalid: %w", err)
+		}
+	}
+	return nil
+}
+
+type verifier struct {
+	validator            *validator.Validator
+	requiredClaims       []string
+	requireEmailVerified bool
+	allowedDomains       []string
+	allowedGroups        []string
+	groupsClaim          string
+}
+
+// NewVerifier returns a Verifier for tokens issued by conf.Issuer to
+// conf.ClientID. Discovery and the JWKS fetch happen lazily on the first
+// verification, so an unreachable IdP doesn't prevent start-up.
+func NewVerifier(conf Config) (identity.Verifier, error) {
+	if err := conf.Validate(); err != nil {
+		return nil, err
+	}
+
+	issuer, _ := url.Parse(conf.Issuer)
+	ttl := conf.CacheTTL
+	if ttl <= 0 {
+		ttl = defaultCacheTTL
+	}
+	var opts []any
+	if conf.JWKSURI != "" {
+		u, _ := url.Parse(conf.JWKSURI)
+		opts = append(opts, jwks.WithCustomJWKSURI(u))
+	}
+	provider := jwks.NewCachingProvider(issuer, ttl, opts...)
+
+	alg := conf.Algorithm
+	if alg == "" {
+		alg = defaultAlgorithm
+	}
+	v, err := validator.New(
+		provider.KeyFunc,
+		validator.SignatureAlgorithm(alg),
+		conf.Issuer,
+		[]string{conf.ClientID},
+		validator.WithCustomClaims(func() validator.CustomClaims { return &rawClaims{} }),
+		validator.WithAllowedClockSkew(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("oidc validator: %w", err)
+	}
+
+	groupsClaim := conf.GroupsClaim
+	if groupsClaim == "" {
+		groupsClaim = defaultGroupsClaim
+	}
+	domains := make([]string, 0, len(conf.AllowedDomains))
+	for _, d := range conf.AllowedDomains {
+		if d = strings.ToLower(strings.TrimSpace(d)); d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return &verifier{
+		validator:            v,
+		requiredClaims:       conf.RequiredClaims,
+		requireEmailVerified: conf.RequireEmailVerified,
+		allowedDomains:       domains,
+		allowedGroups:        conf.AllowedGroups,
+		groupsClaim:          groupsClaim,
+	}, nil
+}
+
+func (v *verifier) Verify(ctx context.Context, idToken string) (*identity.Claims, error) {
+	res, err := v.validator.ValidateToken(ctx, idToken)
+	if err != nil {
+		return nil, identity.ErrInvalidIDToken
+	}
+	validated, ok := res.(*validator.ValidatedClaims)
+	if !ok || validated.RegisteredClaims.Expiry == 0 {
+		return nil, identity.ErrInvalidIDToken
+	}
+	raw, ok := validated.CustomClaims.(*rawClaims)
+	if !ok {
+		return nil, identity.ErrInvalidIDToken
+	}
+	claims := map[string]any(*raw)
+
+	email := strings.ToLower(strings.TrimSpace(stringClaim(claims, "email")))
+	if email == "" {
+		return nil, identity.ErrInvalidIDToken
+	}
+	for _, name := range v.requiredClaims {
+		if !present(claims, name) {
+			return nil, identity.ErrInvalidIDToken
+		}
+	}
+	verified := boolClaim(claims, "email_verified")
+	if v.requireEmailVerified && !verified {
+		return nil, identity.ErrEmailNotVerified
+	}
+	if !v.domainAllowed(email) || !v.groupAllowed(claims) {
+		return nil, identity.ErrNotAllowed
+	}
+
+	return &identity.Claims{
+		Email:         email,
+		EmailVerified: verified,
+		Name:          stringClaim(claims, "name"),
+		PictureURL:    stringClaim(claims, "picture"),
+		Groups:        stringsClaim(claims, v.groupsClaim),
+	}, nil
+}
+
+func (v *verifier) domainAllowed(email string) bool {
+	if len(v.allowedDomains) == 0 {
+		return true
+	}
+	_, domain, ok := strings.Cut(email, "@")
+	return ok && slices.Contains(v.allowedDomains, domain)
+}
+
+func (v *verifier) groupAllowed(claims map[string]any) bool {
+	if len(v.allowedGroups) == 0 {
+		return true
+	}
+	for _, g := range stringsClaim(claims, v.groupsClaim) {
+		if slices.Contains(v.allowedGroups, g) {
+			return true
+		}
+	}
+	return false
+}
+
+// rawClaims keeps every claim of the token so that configured claim names
+// can be looked up after validation.
+type rawClaims map[string]any
+
+func (*rawClaims) Validate(context.Context) error { return nil }
+
+func stringClaim(claims map[string]any, name string) string {
+	s, _ := lookup(claims, name).(string)
+	return s
+}
+
+// boolClaim also accepts "true", which some providers send as a string.
+func boolClaim(claims map[string]any, name string) bool {
+	switch v := lookup(claims, name).(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+// stringsClaim reads a list claim; a single string counts as a one-element
+// list, as some providers collapse lists of one.
+func stringsClaim(claims map[string]any, name string) []string {
+	switch v := lookup(claims, name).(type) {
+	case string:
+		return []string{v}
+	case []any:
+		res := make([]string, 0, len(v))
+		for _, e := range v {
+			if s, ok := e.(string); ok {
+				res = append(res, s)
+			}
+		}
+		return res
+	}
+	return nil
+}
+
+func present(claims map[string]any, name string) bool {
+	switch v := lookup(claims, name).(type) {
+	case nil:
+		return false
+	case string:
+		return v != ""
+	case []any:
+		return len(v) > 0
+	}
+	return true
+}
+
+// lookup reads a claim, following dots into nested objects so that claims
+// such as Keycloak's "realm_access.roles" can be used.
+func lookup(claims map[string]any, name string) any {
+	if v, ok := claims[name]; ok {
+		return v
+	}
+	head, rest, found := strings.Cut(name, ".")
+	if !found {
+		return nil
+	}
+	nested, ok := claims[head].(map[string]any)
+	if !ok {
+		return nil
+	}
+	return lookup(nested, rest)
+}
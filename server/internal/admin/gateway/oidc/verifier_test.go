@@ -0,0 +1,214 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/go-jose/go-jose.v2"
+	"gopkg.in/go-jose/go-jose.v2/jwt"
+)
+
+const testClientID = "admin-console"
+
+type testIdP struct {
+	server *httptest.Server
+	signer jose.Signer
+}
+
+// newTestIdP serves discovery and a JWKS with a single RSA key, like a real
+// provider would.
+func newTestIdP(t *testing.T) *testIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.RS256, Key: jose.JSONWebKey{Key: key, KeyID: "k1"}},
+		(&jose.SignerOptions{}).WithType("JWT"),
+	)
+	require.NoError(t, err)
+
+	idp := &testIdP{signer: signer}
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":   idp.issuer(),
+			"jwks_uri": idp.issuer() + "/keys",
+		})
+	})
+	mux.HandleFunc("/keys", func(w http.ResponseWriter, _ *http.Request) {
+		_ = json.NewEncoder(w).Encode(jose.JSONWebKeySet{Keys: []jose.JSONWebKey{
+			{Key: &key.PublicKey, KeyID: "k1", Algorithm: "RS256", Use: "sig"},
+		}})
+	})
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (p *testIdP) issuer() string { return p.server.URL }
+
+func (p *testIdP) token(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	std := jwt.Claims{
+		Issuer:   p.issuer(),
+		Audience: jwt.Audience{testClientID},
+		Subject:  "sub-1",
+		IssuedAt: jwt.NewNumericDate(time.Now()),
+		Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}
+	tok, err := jwt.Signed(p.signer).Claims(std).Claims(claims).CompactSerialize()
+	require.NoError(t, err)
+	return tok
+}
+
+func (p *testIdP) verifier(t *testing.T, conf Config) identity.Verifier {
+	t.Helper()
+	conf.Issuer = p.issuer()
+	if conf.ClientID == "" {
+		conf.ClientID = testClientID
+	}
+	v, err := NewVerifier(conf)
+	require.NoError(t, err)
+	return v
+}
+
+func TestVerifier_Valid(t *testing.T) {
+	idp := newTestIdP(t)
+	v := idp.verifier(t, Config{})
+
+	c, err := v.Verify(context.Background(), idp.token(t, map[string]any{
+		"email":          "Carol@Example.com",
+		"email_verified": true,
+		"name":           "Carol",
+		"picture":        "https://example.com/c.png",
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, &identity.Claims{
+		Email:         "carol@example.com",
+		EmailVerified: true,
+		Name:          "Carol",
+		PictureURL:    "https://example.com/c.png",
+	}, c)
+}
+
+func TestVerifier_Rejects(t *testing.T) {
+	idp := newTestIdP(t)
+	other := newTestIdP(t)
+	base := map[string]any{"email": "carol@example.com", "email_verified": true}
+	with := func(extra map[string]any) map[string]any {
+		m := map[string]any{}
+		for k, v := range base {
+			m[k] = v
+		}
+		for k, v := range extra {
+			m[k] = v
+		}
+		return m
+	}
+
+	tests := []struct {
+		name  string
+		conf  Config
+		token func(t *testing.T) string
+		err   error
+	}{
+		{
+			name:  "wrong audience",
+			conf:  Config{ClientID: "someone-else"},
+			token: func(t *testing.T) string { return idp.token(t, base) },
+			err:   identity.ErrInvalidIDToken,
+		},
+		{
+			name: "expired",
+			token: func(t *testing.T) string {
+				return idp.token(t, with(map[string]any{"exp": time.Now().Add(-time.Hour).Unix()}))
+			},
+			err: identity.ErrInvalidIDToken,
+		},
+		{
+			name: "signed by another key",
+			token: func(t *testing.T) string {
+				tok, err := jwt.Signed(other.signer).Claims(jwt.Claims{
+					Issuer:   idp.issuer(),
+					Audience: jwt.Audience{testClientID},
+					Expiry:   jwt.NewNumericDate(time.Now().Add(time.Hour)),
+				}).Claims(base).CompactSerialize()
+				require.NoError(t, err)
+				return tok
+			},
+			err: identity.ErrInvalidIDToken,
+		},
+		{
+			name:  "no email",
+			token: func(t *testing.T) string { return idp.token(t, map[string]any{"name": "x"}) },
+			err:   identity.ErrInvalidIDToken,
+		},
+		{
+			name:  "missing required claim",
+			conf:  Config{RequiredClaims: []string{"tenant"}},
+			token: func(t *testing.T) string { return idp.token(t, base) },
+			err:   identity.ErrInvalidIDToken,
+		},
+		{
+			name:  "email not verified",
+			conf:  Config{RequireEmailVerified: true},
+			token: func(t *testing.T) string { return idp.token(t, with(map[string]any{"email_verified": false})) },
+			err:   identity.ErrEmailNotVerified,
+		},
+		{
+			name:  "domain not allowed",
+			conf:  Config{AllowedDomains: []string{"corp.example"}},
+			token: func(t *testing.T) string { return idp.token(t, base) },
+			err:   identity.ErrNotAllowed,
+		},
+		{
+			name:  "group not allowed",
+			conf:  Config{AllowedGroups: []string{"reearth-admins"}},
+			token: func(t *testing.T) string { return idp.token(t, with(map[string]any{"groups": []string{"staff"}})) },
+			err:   identity.ErrNotAllowed,
+		},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			v := idp.verifier(t, tt.conf)
+			_, err := v.Verify(context.Background(), tt.token(t))
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestVerifier_PolicyAdmits(t *testing.T) {
+	idp := newTestIdP(t)
+	v := idp.verifier(t, Config{
+		RequiredClaims:       []string{"realm_access.roles"},
+		RequireEmailVerified: true,
+		AllowedDomains:       []string{" Example.com "},
+		AllowedGroups:        []string{"reearth-admins"},
+		GroupsClaim:          "realm_access.roles",
+	})
+
+	c, err := v.Verify(context.Background(), idp.token(t, map[string]any{
+		"email":          "carol@example.com",
+		"email_verified": "true",
+		"realm_access":   map[string]any{"roles": []string{"staff", "reearth-admins"}},
+	}))
+	require.NoError(t, err)
+	assert.Equal(t, "carol@example.com", c.Email)
+	assert.True(t, c.EmailVerified)
+}
+
+func TestConfig_Validate(t *testing.T) {
+	assert.NoError(t, Config{Issuer: "https://idp.example.com/realms/x", ClientID: "c"}.Validate())
+	assert.Error(t, Config{Issuer: "idp.example.com", ClientID: "c"}.Validate())
+	assert.Error(t, Config{Issuer: "https://idp.example.com"}.Validate())
+}
@@ -7,7 +7,19 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminuseruc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/approvalruleuc"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/authuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/platformroleuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/rolemappinguc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/scimtenantuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearth-accounts/server/pkg/config"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/log"
@@ -50,12 +62,116 @@ func classify(err error) (status int, code, msg string) {
 		return http.StatusForbidden, http.StatusText(http.StatusForbidden), "email not verified"
 	case errors.Is(err, authuc.ErrDomainNotAllowed):
 		return http.StatusForbidden, http.StatusText(http.StatusForbidden), "email domain not allowed"
+	case errors.Is(err, authuc.ErrAccountNotAllowed):
+		return http.StatusForbidden, http.StatusText(http.StatusForbidden), "account not allowed"
+	case errors.Is(err, authuc.ErrProviderNotConfigured):
+		return http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented), "identity provider is not configured"
 	case errors.Is(err, adminuseruc.ErrCannotModifySelf):
 		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "cannot modify your own admin account"
 	case errors.Is(err, adminuseruc.ErrLastApprovedAdmin):
 		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "cannot reject the last approved admin"
 	case errors.Is(err, adminuseruc.ErrLastSystemAdmin):
 		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "cannot demote the last system admin"
+	case errors.Is(err, adminapprovalrule.ErrEmptyValue):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "value is required"
+	case errors.Is(err, adminapprovalrule.ErrInvalidValue):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "invalid email domain"
+	case errors.Is(err, approvalruleuc.ErrDuplicateRule):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "an approval rule for the same domain or group already exists"
+	case errors.Is(err, config.ErrInvalidOverlap):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "invalid overlap"
+	case errors.Is(err, config.ErrRotationInProgress):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "a signing key rotation is already in progress"
+	case errors.Is(err, scimtenant.ErrEmptyName):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "name is required"
+	case errors.Is(err, scimtenant.ErrInvalidSubPrefix):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "invalid sub prefix"
+	case errors.Is(err, scimtenant.ErrReservedSubPrefix):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "the sub prefix is reserved for another provider"
+	case errors.Is(err, scimtenantuc.ErrDuplicateSubPrefix):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "the sub prefix overlaps with another scim tenant"
+	case errors.Is(err, platformroleuc.ErrRoleAlreadyGranted):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "user already has the role"
+	case errors.Is(err, platformroleuc.ErrRoleNotGranted):
+		return http.StatusNotFound, http.StatusText(http.StatusNotFound), "user does not have the role"
+	case errors.Is(err, platformroleuc.ErrNotMembershipRole):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "only the workspace membership roles can be bound in a workspace"
+	case errors.Is(err, rolemappinguc.ErrPersonalWorkspace):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "role mappings can't be set on a personal workspace"
+	case errors.Is(err, rolemapping.ErrEmptyIssuer):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "issuer is required"
+	case errors.Is(err, rolemapping.ErrInvalidSubPrefix):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "invalid sub prefix"
+	case errors.Is(err, rolemapping.ErrInvalidRule):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "each rule needs a claim and a value"
+	case errors.Is(err, rolemapping.ErrInvalidRole):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "invalid role"
+	case errors.Is(err, useruc.ErrMergeSameUser):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "cannot merge a user into itself"
+	case errors.Is(err, useruc.ErrMergeDeletedUser):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "cannot merge a deleted user"
+	case errors.Is(err, useruc.ErrMergeAuthConflict):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "both users have an auth of the same provider"
+	case errors.Is(err, useruc.ErrUnsupportedFormat):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "format must be csv or json"
+	case errors.Is(err, useruc.ErrInvalidImportFile):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), err.Error()
+	case errors.Is(err, useruc.ErrTooManyImportRows):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "too many users in the import file"
+	case errors.Is(err, useruc.ErrUserDeactivated):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "user is deactivated"
+	case errors.Is(err, useruc.ErrUserNotDeactivated):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "user is not deactivated"
+	case errors.Is(err, useruc.ErrRestoreMergedUser):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "cannot restore a merged user"
+	case errors.Is(err, useruc.ErrNothingToUpdate):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "nothing to update"
+	case errors.Is(err, useruc.ErrEmptyName):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "name can't be empty"
+	case errors.Is(err, user.ErrInvalidEmail):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "invalid email"
+	case errors.Is(err, useruc.ErrAliasTaken):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "alias is already taken"
+	case errors.Is(err, useruc.ErrEmailTaken):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "email is already taken"
+	case errors.Is(err, useruc.ErrEmailAlreadyVerified):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "email is already verified"
+	case errors.Is(err, useruc.ErrNoPasswordAuth):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "user has no password"
+	case errors.Is(err, useruc.ErrAuthNotFound):
+		return http.StatusNotFound, http.StatusText(http.StatusNotFound), "auth not found"
+	case errors.Is(err, useruc.ErrAuthNotRemovable):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "Auth0 auths can't be removed"
+	case errors.Is(err, user.ErrLastAuth):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "cannot remove the last auth of a user"
+	case errors.Is(err, useruc.ErrMFANotEnabled):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "user has no second factor"
+	case errors.Is(err, useruc.ErrImpersonationDisabled):
+		return http.StatusNotImplemented, http.StatusText(http.StatusNotImplemented), "impersonation is not configured"
+	case errors.Is(err, workspaceuc.ErrWorkspaceDeactivated):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "workspace is deactivated"
+	case errors.Is(err, workspaceuc.ErrWorkspaceNotDeactivated):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "workspace is not deactivated"
+	case errors.Is(err, workspaceuc.ErrNothingToUpdate):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "nothing to update"
+	case errors.Is(err, workspaceuc.ErrEmptyName):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "name can't be empty"
+	case errors.Is(err, workspaceuc.ErrAliasTaken):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "alias is already taken"
+	case errors.Is(err, workspaceuc.ErrLastOwner):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "cannot remove the last owner of a workspace"
+	case errors.Is(err, workspaceuc.ErrNoMembers):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "no members to add"
+	case errors.Is(err, role.ErrInvalidRole):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "invalid role"
+	case errors.Is(err, workspace.ErrCannotModifyPersonalWorkspace):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "personal workspaces can't be modified"
+	case errors.Is(err, workspace.ErrUserAlreadyJoined):
+		return http.StatusConflict, http.StatusText(http.StatusConflict), "user already joined"
+	case errors.Is(err, workspace.ErrTargetUserNotInTheWorkspace):
+		return http.StatusNotFound, http.StatusText(http.StatusNotFound), "member not found"
+	case errors.Is(err, adminaudit.ErrCursorPaginationUnsupported):
+		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "cursor pagination is not supported"
 	case errors.Is(err, user.ErrCursorPaginationUnsupported):
 		return http.StatusBadRequest, http.StatusText(http.StatusBadRequest), "cursor pagination is not supported"
 	case errors.Is(err, workspace.ErrCursorPaginationUnsupported):
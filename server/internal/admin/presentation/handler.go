@@ -1,8 +1,16 @@
 package presentation
 
 import (
+	adminaudithandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/adminaudit"
 	adminuserhandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/adminuser"
+	approvalrulehandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/approvalrule"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/auth"
+	ldapsynchandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/ldapsync"
+	platformrolehandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/platformrole"
+	rolemappinghandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/rolemapping"
+	scimtenanthandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/scimtenant"
+	signingkeyhandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/signingkey"
+	statshandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/stats"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/user"
 	workspacehandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/workspace"
 	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
@@ -11,32 +19,59 @@ import (
 
 // Handler aggregates all resource-specific admin handlers plus the middlewares.
 type Handler struct {
+	AdminAudit      *adminaudithandler.Handler
 	AdminUser       *adminuserhandler.Handler
+	ApprovalRule    *approvalrulehandler.Handler
 	Auth            *auth.Handler
+	LDAPSync        *ldapsynchandler.Handler
+	PlatformRole    *platformrolehandler.Handler
+	RoleMapping     *rolemappinghandler.Handler
+	SCIMTenant      *scimtenanthandler.Handler
+	SigningKey      *signingkeyhandler.Handler
+	Stats           *statshandler.Handler
 	User            *user.Handler
 	Workspace       *workspacehandler.Handler
 	SessionMw       mw.SessionMiddleware
 	RequireApproved mw.RequireApprovedMiddleware
+	AuditTrail      mw.AuditTrailMiddleware
 	Checker         *authz.Checker
 }
 
 // NewHandler is a Wire provider that assembles the top-level admin Handler.
 func NewHandler(
+	adminAuditHandler *adminaudithandler.Handler,
 	adminUserHandler *adminuserhandler.Handler,
+	approvalRuleHandler *approvalrulehandler.Handler,
 	authHandler *auth.Handler,
+	ldapSyncHandler *ldapsynchandler.Handler,
+	platformRoleHandler *platformrolehandler.Handler,
+	roleMappingHandler *rolemappinghandler.Handler,
+	scimTenantHandler *scimtenanthandler.Handler,
+	signingKeyHandler *signingkeyhandler.Handler,
+	statsHandler *statshandler.Handler,
 	userHandler *user.Handler,
 	workspaceHandler *workspacehandler.Handler,
 	sessionMw mw.SessionMiddleware,
 	requireApproved mw.RequireApprovedMiddleware,
+	auditTrail mw.AuditTrailMiddleware,
 	checker *authz.Checker,
 ) *Handler {
 	return &Handler{
+		AdminAudit:      adminAuditHandler,
 		AdminUser:       adminUserHandler,
+		ApprovalRule:    approvalRuleHandler,
 		Auth:            authHandler,
+		LDAPSync:        ldapSyncHandler,
+		PlatformRole:    platformRoleHandler,
+		RoleMapping:     roleMappingHandler,
+		SCIMTenant:      scimTenantHandler,
+		SigningKey:      signingKeyHandler,
+		Stats:           statsHandler,
 		User:            userHandler,
 		Workspace:       workspaceHandler,
 		SessionMw:       sessionMw,
 		RequireApproved: requireApproved,
+		AuditTrail:      auditTrail,
 		Checker:         checker,
 	}
 }
@@ -0,0 +1,17 @@
+// Package adminaudit implements the admin audit trail endpoint, behind the
+// RequireApproved middleware.
+package adminaudit
+
+import (
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminaudituc"
+)
+
+// Handler serves the /admin-audit-records endpoint.
+type Handler struct {
+	list *adminaudituc.ListUseCase
+}
+
+// NewHandler is a Wire provider for the admin audit Handler.
+func NewHandler(list *adminaudituc.ListUseCase) *Handler {
+	return &Handler{list: list}
+}
@@ -0,0 +1,86 @@
+package adminaudit
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/pagination"
+)
+
+// ListAdminAuditRecords godoc
+//
+//	@Summary		List admin audit records
+//	@Description	Lists the records of admin API writes, newest first, optionally filtered by actor, action, target and time range, with offset pagination.
+//	@Tags			admin-audit-records
+//	@Produce		json
+//	@Param			actor		query		string	false	"Filter by admin user ID"
+//	@Param			action		query		string	false	"Filter by method and route (e.g. PATCH /api/v1/users/:id)"
+//	@Param			target		query		string	false	"Filter by target resource ID"
+//	@Param			since		query		string	false	"Only records at or after this time (RFC 3339)"
+//	@Param			until		query		string	false	"Only records before this time (RFC 3339)"
+//	@Param			page		query		int		false	"Page number (1-based)"
+//	@Param			per_page	query		int		false	"Items per page (max 100)"
+//	@Success		200			{object}	ListAdminAuditRecordsResponse
+//	@Failure		400			{object}	internal.ErrorResponse	"invalid query"
+//	@Failure		401			{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403			{object}	internal.ErrorResponse	"not approved"
+//	@Router			/admin-audit-records [get]
+func (h *Handler) ListAdminAuditRecords(c echo.Context) error {
+	filter := adminaudit.ListFilter{
+		Action: c.QueryParam("action"),
+		Target: c.QueryParam("target"),
+	}
+
+	if v := c.QueryParam("actor"); v != "" {
+		actor, err := adminuser.IDFrom(v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid actor")
+		}
+		filter.Actor = &actor
+	}
+	if v := c.QueryParam("since"); v != "" {
+		since, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid since")
+		}
+		filter.Since = &since
+	}
+	if v := c.QueryParam("until"); v != "" {
+		until, err := time.Parse(time.RFC3339, v)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid until")
+		}
+		filter.Until = &until
+	}
+
+	page, err := internal.ParsePageParam(c.QueryParam("page"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid page")
+	}
+	perPage, err := internal.ParsePageParam(c.QueryParam("per_page"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid per_page")
+	}
+	p := pagination.ToPagination(page, perPage)
+	filter.Pagination = p
+
+	list, pi, err := h.list.Execute(c.Request().Context(), filter)
+	if err != nil {
+		return err
+	}
+
+	effectivePage := int64(1)
+	if page > 0 {
+		effectivePage = page
+	}
+	return c.JSON(http.StatusOK, ListAdminAuditRecordsResponse{
+		Items:      newAdminAuditRecordResponses(list),
+		TotalCount: pi.TotalCount,
+		Page:       effectivePage,
+		PerPage:    p.Offset.Limit,
+	})
+}
@@ -0,0 +1,117 @@
+package adminaudit_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	adminpresentation "github.com/reearth/reearth-accounts/server/internal/admin/presentation"
+	adminaudithandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/adminaudit"
+	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminaudituc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-test-secret-test-secret"
+
+type testEnv struct {
+	e        *echo.Echo
+	sess     *session.Manager
+	sessions adminsession.Repo
+	op       *adminuser.AdminUser
+	record   *adminaudituc.RecordUseCase
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	op := adminuser.New().NewID().Name("op@eukarya.io").Email("op@eukarya.io").Status(adminuser.StatusApproved).MustBuild()
+	repo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	sessions := memory.NewAdminSession()
+	auditRepo := memory.NewAdminAudit()
+
+	h := adminaudithandler.NewHandler(adminaudituc.NewListUseCase(auditRepo))
+	e := echo.New()
+	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
+	g := e.Group("/api/v1/admin-audit-records", echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, repo)))
+	g.GET("", h.ListAdminAuditRecords)
+	return &testEnv{e: e, sess: sess, sessions: sessions, op: op, record: adminaudituc.NewRecordUseCase(auditRepo)}
+}
+
+func (env *testEnv) get(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(env.op.ID()).CreatedAt(now).ExpiresAt(now.Add(env.sess.TTL())).MustBuild()
+	require.NoError(t, env.sessions.Save(context.Background(), s))
+	tok, err := env.sess.Issue(env.op.ID(), s.ID(), now)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: session.CookieName, Value: tok})
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestListAdminAuditRecords(t *testing.T) {
+	ctx := context.Background()
+	env := newTestEnv(t)
+	other := adminuser.NewID()
+	for _, in := range []adminaudituc.RecordInput{
+		{Actor: env.op.ID(), Action: "PATCH /api/v1/users/:id", Target: "u1", Status: 200, Diff: map[string]string{"name": `"a"`}},
+		{Actor: other, Action: "POST /api/v1/users/:id/deactivate", Target: "u1", Status: 200},
+		{Actor: env.op.ID(), Action: "DELETE /api/v1/users/:id/mfa", Target: "u2", Status: 204},
+	} {
+		_, err := env.record.Execute(ctx, in)
+		require.NoError(t, err)
+	}
+
+	list := func(t *testing.T, query string) adminaudithandler.ListAdminAuditRecordsResponse {
+		t.Helper()
+		rec := env.get(t, "/api/v1/admin-audit-records"+query)
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		var body adminaudithandler.ListAdminAuditRecordsResponse
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return body
+	}
+	seqs := func(items []adminaudithandler.AdminAuditRecordResponse) []int64 {
+		res := make([]int64, 0, len(items))
+		for _, i := range items {
+			res = append(res, i.Seq)
+		}
+		return res
+	}
+
+	all := list(t, "")
+	assert.Equal(t, int64(3), all.TotalCount)
+	assert.Equal(t, []int64{3, 2, 1}, seqs(all.Items))
+	assert.Equal(t, all.Items[1].Hash, all.Items[0].PrevHash)
+	assert.Equal(t, map[string]string{"name": `"a"`}, all.Items[2].Diff)
+
+	assert.Equal(t, []int64{3, 1}, seqs(list(t, "?actor="+env.op.ID().String()).Items))
+	assert.Equal(t, []int64{2, 1}, seqs(list(t, "?target=u1").Items))
+	assert.Equal(t, []int64{2}, seqs(list(t, "?action="+url.QueryEscape("POST /api/v1/users/:id/deactivate")).Items))
+	assert.Empty(t, list(t, "?since="+url.QueryEscape(time.Now().Add(time.Hour).Format(time.RFC3339))).Items)
+
+	paged := list(t, "?page=2&per_page=2")
+	assert.Equal(t, []int64{1}, seqs(paged.Items))
+	assert.Equal(t, int64(2), paged.Page)
+	assert.Equal(t, int64(2), paged.PerPage)
+}
+
+func TestListAdminAuditRecords_InvalidQuery(t *testing.T) {
+	env := newTestEnv(t)
+	for _, q := range []string{"?actor=nope", "?since=yesterday", "?until=1", "?page=0"} {
+		rec := env.get(t, "/api/v1/admin-audit-records"+q)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, q)
+	}
+}
@@ -0,0 +1,60 @@
+package adminaudit
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+)
+
+// AdminAuditRecordResponse is a single admin audit record in the admin API.
+type AdminAuditRecordResponse struct {
+	ID        string            `json:"id"`
+	Seq       int64             `json:"seq"`
+	Actor     string            `json:"actor"`
+	Action    string            `json:"action"`
+	Target    string            `json:"target,omitempty"`
+	RequestID string            `json:"requestId,omitempty"`
+	IP        string            `json:"ip,omitempty"`
+	Status    int               `json:"status"`
+	Diff      map[string]string `json:"diff"`
+	CreatedAt time.Time         `json:"createdAt"`
+	PrevHash  string            `json:"prevHash,omitempty"`
+	Hash      string            `json:"hash"`
+} // @name AdminAuditRecord
+
+// ListAdminAuditRecordsResponse is the paginated list of admin audit records.
+type ListAdminAuditRecordsResponse struct {
+	Items      []AdminAuditRecordResponse `json:"items"`
+	TotalCount int64                      `json:"totalCount"`
+	Page       int64                      `json:"page"`
+	PerPage    int64                      `json:"perPage"`
+} // @name ListAdminAuditRecordsResponse
+
+func newAdminAuditRecordResponse(r *adminaudit.Record) AdminAuditRecordResponse {
+	diff := r.Diff()
+	if diff == nil {
+		diff = map[string]string{}
+	}
+	return AdminAuditRecordResponse{
+		ID:        r.ID().String(),
+		Seq:       r.Seq(),
+		Actor:     r.Actor().String(),
+		Action:    r.Action(),
+		Target:    r.Target(),
+		RequestID: r.RequestID(),
+		IP:        r.IP(),
+		Status:    r.Status(),
+		Diff:      diff,
+		CreatedAt: r.CreatedAt(),
+		PrevHash:  r.PrevHash(),
+		Hash:      r.Hash(),
+	}
+}
+
+func newAdminAuditRecordResponses(list adminaudit.List) []AdminAuditRecordResponse {
+	items := make([]AdminAuditRecordResponse, 0, len(list))
+	for _, r := range list {
+		items = append(items, newAdminAuditRecordResponse(r))
+	}
+	return items
+}
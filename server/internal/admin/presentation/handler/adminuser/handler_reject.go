@@ -11,7 +11,7 @@ import (
 // RejectAdminUser godoc
 //
 //	@Summary		Reject or revoke an admin user
-//	@Description	Rejects a pending admin user or revokes an approved one. Cannot reject your own account, and the last approved admin cannot be rejected.
+//	@Description	Rejects a pending admin user or revokes an approved one, ending all of their sessions. Cannot reject your own account, and the last approved admin cannot be rejected.
 //	@Tags			admin-users
 //	@Produce		json
 //	@Param			id	path		string	true	"Admin user ID"
@@ -17,7 +17,7 @@ type SetAdminUserRoleRequest struct {
 // SetAdminUserRole godoc
 //
 //	@Summary		Assign a role to an admin user
-//	@Description	Assigns a role (e.g. system_admin, viewer) to the target admin user. Changing your own role is allowed, but the last system_admin cannot be demoted (the system must never reach zero system_admins).
+//	@Description	Assigns a role (e.g. system_admin, viewer) to the target admin user. Changing your own role is allowed, but the last system_admin cannot be demoted (the system must never reach zero system_admins). Changing the role ends all of the target's sessions.
 //	@Tags			admin-users
 //	@Accept			json
 //	@Produce		json
@@ -1,6 +1,7 @@
 package adminuser_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
@@ -15,6 +16,7 @@ import (
 	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminuseruc"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -33,10 +35,10 @@ func newTestEcho(repo adminuser.Repo, sess *session.Manager) *echo.Echo {
 	h := adminuserhandler.NewHandler(
 		adminuseruc.NewListAdminUsersUseCase(repo),
 		adminuseruc.NewApproveAdminUserUseCase(repo),
-		adminuseruc.NewRejectAdminUserUseCase(repo),
-		adminuseruc.NewSetRoleUseCase(repo),
+		adminuseruc.NewRejectAdminUserUseCase(repo, sessions),
+		adminuseruc.NewSetRoleUseCase(repo, sessions),
 	)
-	requireApproved := echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, repo))
+	requireApproved := echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, repo))
 
 	e := echo.New()
 	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
@@ -48,9 +50,16 @@ func newTestEcho(repo adminuser.Repo, sess *session.Manager) *echo.Echo {
 	return e
 }
 
+// sessions backs the admin sessions of every test in the package; session IDs
+// are unique, so tests don't see each other's sessions.
+var sessions = memory.NewAdminSession()
+
 func cookieFor(t *testing.T, sess *session.Manager, id adminuser.ID) *http.Cookie {
 	t.Helper()
-	tok, err := sess.Issue(id, time.Now())
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(id).CreatedAt(now).ExpiresAt(now.Add(sess.TTL())).MustBuild()
+	require.NoError(t, sessions.Save(context.Background(), s))
+	tok, err := sess.Issue(id, s.ID(), now)
 	require.NoError(t, err)
 	return &http.Cookie{Name: session.CookieName, Value: tok}
 }
@@ -256,6 +265,33 @@ func TestRejectAdminUser_OK(t *testing.T) {
 	assert.Equal(t, "rejected", body.Status)
 }
 
+func TestRejectAdminUser_EndsSessions(t *testing.T) {
+	op := approvedUser("op@eukarya.io")
+	other := approvedUser("other@eukarya.io")
+	repo := memory.NewAdminUserWith(op, other)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(repo, sess)
+	otherCookie := cookieFor(t, sess, other.ID())
+
+	list := func() int {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/admin-users", nil)
+		req.AddCookie(otherCookie)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	require.Equal(t, http.StatusOK, list())
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/admin-users/"+other.ID().String()+"/reject", nil)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	// The session is gone, not merely unapproved.
+	assert.Equal(t, http.StatusUnauthorized, list())
+}
+
 func TestRejectAdminUser_CannotRejectSelf(t *testing.T) {
 	op := approvedUser("op@eukarya.io")
 	other := approvedUser("other@eukarya.io") // keep >1 approved so self-guard is what triggers
@@ -16,8 +16,11 @@ type AdminUserResponse struct {
 	Status     string     `json:"status"`
 	ApprovedBy string     `json:"approvedBy,omitempty"`
 	ApprovedAt *time.Time `json:"approvedAt,omitempty"`
-	CreatedAt  time.Time  `json:"createdAt"`
-	UpdatedAt  time.Time  `json:"updatedAt"`
+	// ApprovedByRule is the approval rule that approved the admin at sign-in,
+	// set instead of ApprovedBy.
+	ApprovedByRule string    `json:"approvedByRule,omitempty"`
+	CreatedAt      time.Time `json:"createdAt"`
+	UpdatedAt      time.Time `json:"updatedAt"`
 } // @name AdminUser
 
 // ListAdminUsersResponse is the paginated list of admin users.
@@ -42,6 +45,9 @@ func newAdminUserResponse(u *adminuser.AdminUser) AdminUserResponse {
 	if by := u.ApprovedBy(); !by.IsEmpty() {
 		res.ApprovedBy = by.String()
 	}
+	if r := u.ApprovedByRule(); !r.IsEmpty() {
+		res.ApprovedByRule = r.String()
+	}
 	if at := u.ApprovedAt(); !at.IsZero() {
 		res.ApprovedAt = &at
 	}
@@ -0,0 +1,26 @@
+// Package approvalrule implements the endpoints managing the rules that
+// approve new admin sign-ins automatically, behind the RequireApproved
+// middleware.
+package approvalrule
+
+import (
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/approvalruleuc"
+)
+
+// Handler serves the /admin-approval-rules endpoints.
+type Handler struct {
+	list   *approvalruleuc.ListApprovalRulesUseCase
+	create *approvalruleuc.CreateApprovalRuleUseCase
+	update *approvalruleuc.UpdateApprovalRuleUseCase
+	delete *approvalruleuc.DeleteApprovalRuleUseCase
+}
+
+// NewHandler is a Wire provider for the approval rule Handler.
+func NewHandler(
+	list *approvalruleuc.ListApprovalRulesUseCase,
+	create *approvalruleuc.CreateApprovalRuleUseCase,
+	update *approvalruleuc.UpdateApprovalRuleUseCase,
+	delete *approvalruleuc.DeleteApprovalRuleUseCase,
+) *Handler {
+	return &Handler{list: list, create: create, update: update, delete: delete}
+}
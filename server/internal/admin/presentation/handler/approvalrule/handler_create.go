@@ -0,0 +1,76 @@
+package approvalrule
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/approvalruleuc"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+// CreateApprovalRuleRequest is the request body for creating an approval rule.
+type CreateApprovalRuleRequest struct {
+	// Kind is email_domain or group.
+	Kind string `json:"kind" example:"email_domain"`
+	// Value is the email domain, or the group: a Google Workspace group email
+	// address or an entry of the OIDC groups claim.
+	Value string `json:"value" example:"eukarya.io"`
+	// Provider limits the rule to google or oidc sign-ins; empty applies it to
+	// both.
+	Provider string `json:"provider" example:"google"`
+	Role     string `json:"role" example:"viewer"`
+} // @name CreateAdminApprovalRuleRequest
+
+// CreateApprovalRule godoc
+//
+//	@Summary		Create an admin approval rule
+//	@Description	Adds a rule approving new and pending admins automatically at sign-in with the given role when their email domain or one of their groups matches.
+//	@Tags			admin-approval-rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		CreateApprovalRuleRequest	true	"Rule"
+//	@Success		201		{object}	ApprovalRuleResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid kind / value / provider / role"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		409		{object}	internal.ErrorResponse	"duplicate rule"
+//	@Router			/admin-approval-rules [post]
+func (h *Handler) CreateApprovalRule(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+
+	var body CreateApprovalRuleRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	kind, err := adminapprovalrule.KindFrom(body.Kind)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid kind")
+	}
+	var provider adminapprovalrule.Provider
+	if body.Provider != "" {
+		if provider, err = adminapprovalrule.ProviderFrom(body.Provider); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid provider")
+		}
+	}
+	role, err := adminuser.RoleFrom(body.Role)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid role")
+	}
+
+	r, err := h.create.Execute(c.Request().Context(), approvalruleuc.CreateInput{
+		Operator: operator.ID(),
+		Kind:     kind,
+		Value:    body.Value,
+		Provider: provider,
+		Role:     role,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, newApprovalRuleResponse(r))
+}
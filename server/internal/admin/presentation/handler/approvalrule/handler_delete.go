@@ -0,0 +1,38 @@
+package approvalrule
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/approvalruleuc"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+)
+
+// DeleteApprovalRule godoc
+//
+//	@Summary		Delete an admin approval rule
+//	@Description	Stops approving sign-ins by the rule. The admins it approved stay approved.
+//	@Tags			admin-approval-rules
+//	@Param			id	path	string	true	"Approval rule ID"
+//	@Success		204
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Router			/admin-approval-rules/{id} [delete]
+func (h *Handler) DeleteApprovalRule(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+	id, err := adminapprovalrule.IDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	if err := h.delete.Execute(c.Request().Context(), approvalruleuc.DeleteInput{Operator: operator.ID(), ID: id}); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
@@ -0,0 +1,25 @@
+package approvalrule
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListApprovalRules godoc
+//
+//	@Summary		List admin approval rules
+//	@Description	Lists the rules that approve new admin sign-ins automatically, oldest first. A group rule takes precedence over an email domain rule; among rules of the same kind the oldest matching one wins.
+//	@Tags			admin-approval-rules
+//	@Produce		json
+//	@Success		200	{object}	ListApprovalRulesResponse
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Router			/admin-approval-rules [get]
+func (h *Handler) ListApprovalRules(c echo.Context) error {
+	list, err := h.list.Execute(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newListApprovalRulesResponse(list))
+}
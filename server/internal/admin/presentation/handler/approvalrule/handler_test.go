@@ -0,0 +1,124 @@
+package approvalrule_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	adminpresentation "github.com/reearth/reearth-accounts/server/internal/admin/presentation"
+	approvalrulehandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/approvalrule"
+	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/approvalruleuc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-test-secret-test-secret"
+
+type testEnv struct {
+	e        *echo.Echo
+	sess     *session.Manager
+	sessions adminsession.Repo
+	op       *adminuser.AdminUser
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	op := adminuser.New().NewID().Name("op@eukarya.io").Email("op@eukarya.io").Status(adminuser.StatusApproved).MustBuild()
+	repo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	sessions := memory.NewAdminSession()
+	rules := memory.NewAdminApprovalRule()
+
+	h := approvalrulehandler.NewHandler(
+		approvalruleuc.NewListApprovalRulesUseCase(rules),
+		approvalruleuc.NewCreateApprovalRuleUseCase(rules),
+		approvalruleuc.NewUpdateApprovalRuleUseCase(rules),
+		approvalruleuc.NewDeleteApprovalRuleUseCase(rules),
+	)
+	e := echo.New()
+	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
+	g := e.Group("/api/v1/admin-approval-rules", echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, repo)))
+	g.GET("", h.ListApprovalRules)
+	g.POST("", h.CreateApprovalRule)
+	g.PATCH("/:id", h.UpdateApprovalRule)
+	g.DELETE("/:id", h.DeleteApprovalRule)
+	return &testEnv{e: e, sess: sess, sessions: sessions, op: op}
+}
+
+func (env *testEnv) do(t *testing.T, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(env.op.ID()).CreatedAt(now).ExpiresAt(now.Add(env.sess.TTL())).MustBuild()
+	require.NoError(t, env.sessions.Save(context.Background(), s))
+	tok, err := env.sess.Issue(env.op.ID(), s.ID(), now)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: session.CookieName, Value: tok})
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestApprovalRule_Lifecycle(t *testing.T) {
+	env := newTestEnv(t)
+
+	rec := env.do(t, http.MethodPost, "/api/v1/admin-approval-rules", `{"kind":"email_domain","value":"@Eukarya.io","role":"viewer"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var created approvalrulehandler.ApprovalRuleResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, "email_domain", created.Kind)
+	assert.Equal(t, "eukarya.io", created.Value)
+	assert.Empty(t, created.Provider)
+	assert.Equal(t, "viewer", created.Role)
+	assert.Equal(t, env.op.ID().String(), created.CreatedBy)
+
+	rec = env.do(t, http.MethodPost, "/api/v1/admin-approval-rules", `{"kind":"email_domain","value":"eukarya.io","role":"system_admin"}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = env.do(t, http.MethodPatch, "/api/v1/admin-approval-rules/"+created.ID, `{"role":"system_admin"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var updated approvalrulehandler.ApprovalRuleResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &updated))
+	assert.Equal(t, "system_admin", updated.Role)
+
+	rec = env.do(t, http.MethodGet, "/api/v1/admin-approval-rules", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list approvalrulehandler.ListApprovalRulesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Items, 1)
+	assert.Equal(t, created.ID, list.Items[0].ID)
+	assert.Equal(t, "system_admin", list.Items[0].Role)
+
+	rec = env.do(t, http.MethodDelete, "/api/v1/admin-approval-rules/"+created.ID, "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = env.do(t, http.MethodDelete, "/api/v1/admin-approval-rules/"+created.ID, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCreateApprovalRule_Invalid(t *testing.T) {
+	env := newTestEnv(t)
+	for _, body := range []string{
+		`{"kind":"team","value":"eukarya.io","role":"viewer"}`,
+		`{"kind":"email_domain","value":"","role":"viewer"}`,
+		`{"kind":"email_domain","value":"eukarya","role":"viewer"}`,
+		`{"kind":"group","value":"admins","provider":"saml","role":"viewer"}`,
+		`{"kind":"group","value":"admins","role":"owner"}`,
+		`{`,
+	} {
+		rec := env.do(t, http.MethodPost, "/api/v1/admin-approval-rules", body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+	rec := env.do(t, http.MethodPatch, "/api/v1/admin-approval-rules/invalid", `{"role":"viewer"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
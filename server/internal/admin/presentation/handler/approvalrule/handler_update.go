@@ -0,0 +1,61 @@
+package approvalrule
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/approvalruleuc"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+// UpdateApprovalRuleRequest is the request body for updating an approval rule.
+type UpdateApprovalRuleRequest struct {
+	Role string `json:"role" example:"system_admin"`
+} // @name UpdateAdminApprovalRuleRequest
+
+// UpdateApprovalRule godoc
+//
+//	@Summary		Update an admin approval rule
+//	@Description	Changes the role the rule grants. Admins it approved before keep their current role.
+//	@Tags			admin-approval-rules
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Approval rule ID"
+//	@Param			body	body		UpdateApprovalRuleRequest	true	"Role"
+//	@Success		200		{object}	ApprovalRuleResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid id / role"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404		{object}	internal.ErrorResponse	"not found"
+//	@Router			/admin-approval-rules/{id} [patch]
+func (h *Handler) UpdateApprovalRule(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+	id, err := adminapprovalrule.IDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	var body UpdateApprovalRuleRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	role, err := adminuser.RoleFrom(body.Role)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid role")
+	}
+
+	r, err := h.update.Execute(c.Request().Context(), approvalruleuc.UpdateInput{
+		Operator: operator.ID(),
+		ID:       id,
+		Role:     role,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newApprovalRuleResponse(r))
+}
@@ -0,0 +1,50 @@
+package approvalrule
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+)
+
+// ApprovalRuleResponse is an automatic approval rule in the admin API.
+type ApprovalRuleResponse struct {
+	ID string `json:"id"`
+	// Kind is email_domain or group.
+	Kind  string `json:"kind"`
+	Value string `json:"value"`
+	// Provider is google or oidc; empty when the rule applies to both.
+	Provider  string    `json:"provider,omitempty"`
+	Role      string    `json:"role"`
+	CreatedBy string    `json:"createdBy,omitempty"`
+	CreatedAt time.Time `json:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt"`
+} // @name AdminApprovalRule
+
+// ListApprovalRulesResponse is the list of approval rules.
+type ListApprovalRulesResponse struct {
+	Items []ApprovalRuleResponse `json:"items"`
+} // @name ListAdminApprovalRulesResponse
+
+func newApprovalRuleResponse(r *adminapprovalrule.Rule) ApprovalRuleResponse {
+	res := ApprovalRuleResponse{
+		ID:        r.ID().String(),
+		Kind:      r.Kind().String(),
+		Value:     r.Value(),
+		Provider:  r.Provider().String(),
+		Role:      r.Role().String(),
+		CreatedAt: r.CreatedAt(),
+		UpdatedAt: r.UpdatedAt(),
+	}
+	if by := r.CreatedBy(); !by.IsEmpty() {
+		res.CreatedBy = by.String()
+	}
+	return res
+}
+
+func newListApprovalRulesResponse(list adminapprovalrule.List) ListApprovalRulesResponse {
+	items := make([]ApprovalRuleResponse, 0, len(list))
+	for _, r := range list {
+		items = append(items, newApprovalRuleResponse(r))
+	}
+	return ListApprovalRulesResponse{Items: items}
+}
@@ -1,6 +1,7 @@
-// Package auth implements the admin authentication endpoints: Google sign-in,
-// logout, and the current-user lookup, backed by an HttpOnly session cookie
-// plus a readable (non-HttpOnly) admin_csrf double-submit companion cookie.
+// Package auth implements the admin authentication endpoints: Google and OIDC
+// sign-in, logout, the current-user lookup and the admin's own session management,
+// backed by an HttpOnly session cookie plus a readable (non-HttpOnly)
+// admin_csrf double-submit companion cookie.
 package auth
 
 import (
@@ -9,8 +10,11 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/authuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/sessionuc"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 )
 
 // csrfCookieName is the name of the non-HttpOnly companion cookie carrying the
@@ -24,15 +28,59 @@ type CookieSecure bool
 
 // Handler serves the admin auth endpoints.
 type Handler struct {
-	signIn *authuc.GoogleSignInUseCase
-	getMe  *authuc.GetMeUseCase
-	sess   *session.Manager
-	secure bool
+	googleSignIn  *authuc.GoogleSignInUseCase
+	oidcSignIn    *authuc.OIDCSignInUseCase
+	getMe         *authuc.GetMeUseCase
+	createSession *sessionuc.CreateSessionUseCase
+	listSessions  *sessionuc.ListSessionsUseCase
+	revokeSession *sessionuc.RevokeSessionUseCase
+	sess          *session.Manager
+	secure        bool
 }
 
 // NewHandler is a Wire provider for the auth Handler.
-func NewHandler(signIn *authuc.GoogleSignInUseCase, getMe *authuc.GetMeUseCase, sess *session.Manager, secure CookieSecure) *Handler {
-	return &Handler{signIn: signIn, getMe: getMe, sess: sess, secure: bool(secure)}
+func NewHandler(
+	googleSignIn *authuc.GoogleSignInUseCase,
+	oidcSignIn *authuc.OIDCSignInUseCase,
+	getMe *authuc.GetMeUseCase,
+	createSession *sessionuc.CreateSessionUseCase,
+	listSessions *sessionuc.ListSessionsUseCase,
+	revokeSession *sessionuc.RevokeSessionUseCase,
+	sess *session.Manager,
+	secure CookieSecure,
+) *Handler {
+	return &Handler{
+		googleSignIn:  googleSignIn,
+		oidcSignIn:    oidcSignIn,
+		getMe:         getMe,
+		createSession: createSession,
+		listSessions:  listSessions,
+		revokeSession: revokeSession,
+		sess:          sess,
+		secure:        bool(secure),
+	}
+}
+
+// startSession starts a server-side session for the signed-in admin user and
+// answers with the session and CSRF cookies.
+func (h *Handler) startSession(c echo.Context, u *adminuser.AdminUser) error {
+	s, token, err := h.createSession.Execute(c.Request().Context(), sessionuc.CreateInput{
+		AdminUser: u.ID(),
+		UserAgent: c.Request().UserAgent(),
+		IP:        c.RealIP(),
+	})
+	if err != nil {
+		return err
+	}
+	now := s.CreatedAt()
+	csrf, err := newCSRFToken()
+	if err != nil {
+		return err
+	}
+	c.SetCookie(h.newSessionCookie(token, now))
+	c.SetCookie(h.newCSRFCookie(csrf, now))
+
+	return c.JSON(http.StatusOK, newSignInResponse(u))
 }
 
 func (h *Handler) newSessionCookie(value string, now time.Time) *http.Cookie {
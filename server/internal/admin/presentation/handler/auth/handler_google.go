@@ -2,7 +2,6 @@ package auth
 
 import (
 	"net/http"
-	"time"
 
 	"github.com/labstack/echo/v4"
 )
@@ -10,18 +9,18 @@ import (
 // GoogleSignIn godoc
 //
 //	@Summary		Sign in with a Google id_token
-//	@Description	Verifies the Google id_token and issues an HttpOnly admin session cookie plus a readable (non-HttpOnly) admin_csrf double-submit cookie. New accounts are created as pending (approved when the email is bootstrapped).
+//	@Description	Verifies the Google id_token, starts a server-side session and issues an HttpOnly admin session cookie naming it plus a readable (non-HttpOnly) admin_csrf double-submit cookie. New accounts are created as pending (approved when the email is bootstrapped).
 //	@Tags			auth
 //	@Accept			json
 //	@Produce		json
-//	@Param			body	body		GoogleSignInRequest	true	"Google id_token"
-//	@Success		200		{object}	GoogleSignInResponse
+//	@Param			body	body		SignInRequest	true	"Google id_token"
+//	@Success		200		{object}	SignInResponse
 //	@Failure		400		{object}	internal.ErrorResponse	"invalid request"
 //	@Failure		401		{object}	internal.ErrorResponse	"id_token verification failed"
 //	@Failure		403		{object}	internal.ErrorResponse	"domain not allowed / email not verified"
 //	@Router			/auth/google [post]
 func (h *Handler) GoogleSignIn(c echo.Context) error {
-	var req GoogleSignInRequest
+	var req SignInRequest
 	if err := c.Bind(&req); err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
 	}
@@ -29,23 +28,9 @@ func (h *Handler) GoogleSignIn(c echo.Context) error {
 		return err
 	}
 
-	ctx := c.Request().Context()
-	u, err := h.signIn.Execute(ctx, req.IDToken)
+	u, err := h.googleSignIn.Execute(c.Request().Context(), req.IDToken)
 	if err != nil {
 		return err
 	}
-
-	now := time.Now()
-	token, err := h.sess.Issue(u.ID(), now)
-	if err != nil {
-		return err
-	}
-	csrf, err := newCSRFToken()
-	if err != nil {
-		return err
-	}
-	c.SetCookie(h.newSessionCookie(token, now))
-	c.SetCookie(h.newCSRFCookie(csrf, now))
-
-	return c.JSON(http.StatusOK, newGoogleSignInResponse(u))
+	return h.startSession(c, u)
 }
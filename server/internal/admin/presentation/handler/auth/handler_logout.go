@@ -1,20 +1,36 @@
 package auth
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/sessionuc"
+	"github.com/reearth/reearthx/rerror"
 )
 
 // Logout godoc
 //
 //	@Summary		Log out
-//	@Description	Clears the admin session cookie and the admin_csrf double-submit cookie. Public endpoint so the cookies can be cleared even with an expired/invalid token.
+//	@Description	Ends the server-side session named by the session cookie, if any, and clears the admin session cookie and the admin_csrf double-submit cookie. Public endpoint so the cookies can be cleared even with an expired/invalid token.
 //	@Tags			auth
 //	@Produce		json
 //	@Success		204	"No Content"
 //	@Router			/auth/logout [post]
 func (h *Handler) Logout(c echo.Context) error {
+	if cookie, err := c.Cookie(session.CookieName); err == nil && cookie.Value != "" {
+		if claims, err := h.sess.Parse(cookie.Value); err == nil {
+			err := h.revokeSession.Execute(c.Request().Context(), sessionuc.RevokeInput{
+				Operator: claims.AdminUser,
+				Session:  claims.Session,
+			})
+			if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+				return err
+			}
+		}
+	}
+
 	c.SetCookie(h.clearSessionCookie())
 	c.SetCookie(h.clearCSRFCookie())
 	return c.NoContent(http.StatusNoContent)
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// OIDCSignIn godoc
+//
+//	@Summary		Sign in with an OIDC id_token
+//	@Description	Verifies the id_token of the configured OpenID Connect provider (issuer, audience, signature against its discovered JWKS, required claims and allowed domains/groups), starts a server-side session and issues the same cookies as Google sign-in. New accounts are created as pending (approved when the email is bootstrapped).
+//	@Tags			auth
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		SignInRequest	true	"OIDC id_token"
+//	@Success		200		{object}	SignInResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid request"
+//	@Failure		401		{object}	internal.ErrorResponse	"id_token verification failed"
+//	@Failure		403		{object}	internal.ErrorResponse	"account not allowed / email not verified"
+//	@Failure		501		{object}	internal.ErrorResponse	"no OIDC provider configured"
+//	@Router			/auth/oidc [post]
+func (h *Handler) OIDCSignIn(c echo.Context) error {
+	var req SignInRequest
+	if err := c.Bind(&req); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	if err := c.Validate(&req); err != nil {
+		return err
+	}
+
+	u, err := h.oidcSignIn.Execute(c.Request().Context(), req.IDToken)
+	if err != nil {
+		return err
+	}
+	return h.startSession(c, u)
+}
@@ -0,0 +1,72 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/sessionuc"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+)
+
+// ListSessions godoc
+//
+//	@Summary		List my sessions
+//	@Description	Lists the current admin user's unexpired sessions, newest first. The session of this request is marked as current.
+//	@Tags			auth
+//	@Produce		json
+//	@Success		200	{object}	ListSessionsResponse
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Router			/auth/sessions [get]
+func (h *Handler) ListSessions(c echo.Context) error {
+	id, err := internal.GetSessionAdminUserID(c)
+	if err != nil {
+		return err
+	}
+	current, err := internal.GetSessionID(c)
+	if err != nil {
+		return err
+	}
+
+	l, err := h.listSessions.Execute(c.Request().Context(), id)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newListSessionsResponse(l, current))
+}
+
+// RevokeSession godoc
+//
+//	@Summary		Revoke one of my sessions
+//	@Description	Ends one of the current admin user's sessions; its cookie stops working immediately. Revoking the session of this request signs the caller out.
+//	@Tags			auth
+//	@Produce		json
+//	@Param			id	path	string	true	"Session ID"
+//	@Success		204	"No Content"
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Router			/auth/sessions/{id} [delete]
+func (h *Handler) RevokeSession(c echo.Context) error {
+	id, err := internal.GetSessionAdminUserID(c)
+	if err != nil {
+		return err
+	}
+	current, err := internal.GetSessionID(c)
+	if err != nil {
+		return err
+	}
+	sid, err := adminsession.IDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	if err := h.revokeSession.Execute(c.Request().Context(), sessionuc.RevokeInput{Operator: id, Session: sid}); err != nil {
+		return err
+	}
+	if sid == current {
+		c.SetCookie(h.clearSessionCookie())
+		c.SetCookie(h.clearCSRFCookie())
+	}
+	return c.NoContent(http.StatusNoContent)
+}
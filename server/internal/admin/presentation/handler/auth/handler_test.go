@@ -12,19 +12,20 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
-	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/google"
+	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/identity"
 	adminhttp "github.com/reearth/reearth-accounts/server/internal/admin/presentation"
 	authhandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/auth"
 	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/authuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/sessionuc"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
 
-type fakeVerifier struct{ claims *google.Claims }
+type fakeVerifier struct{ claims *identity.Claims }
 
-func (f fakeVerifier) Verify(_ context.Context, _ string) (*google.Claims, error) {
+func (f fakeVerifier) Verify(_ context.Context, _ string) (*identity.Claims, error) {
 	return f.claims, nil
 }
 
@@ -37,26 +38,47 @@ func (t testValidator) Validate(i any) error {
 	return nil
 }
 
-func newTestEcho(t *testing.T, claims *google.Claims) *echo.Echo {
+func newTestEcho(t *testing.T, claims *identity.Claims) *echo.Echo {
+	t.Helper()
+	return newTestEchoWithOIDC(t, claims, fakeVerifier{claims: claims})
+}
+
+// newTestEchoWithOIDC is newTestEcho with an explicit OIDC verifier; nil
+// leaves OIDC sign-in unconfigured.
+func newTestEchoWithOIDC(t *testing.T, claims *identity.Claims, oidcVerifier identity.Verifier) *echo.Echo {
 	t.Helper()
 	repo := memory.NewAdminUser()
-	signIn := authuc.NewGoogleSignInUseCase(repo, fakeVerifier{claims: claims}, authuc.GoogleSignInOptions{AllowedDomain: "eukarya.io"})
+	signIn := authuc.NewGoogleSignInUseCase(repo, memory.NewAdminApprovalRule(), fakeVerifier{claims: claims}, authuc.GoogleSignInOptions{AllowedDomain: "eukarya.io"})
+	oidcSignIn := authuc.NewOIDCSignInUseCase(repo, memory.NewAdminApprovalRule(), oidcVerifier, authuc.OIDCSignInOptions{})
 	getMe := authuc.NewGetMeUseCase(repo)
 	sess := session.NewManager("test-secret-test-secret-test-secret", time.Hour)
-	h := authhandler.NewHandler(signIn, getMe, sess, authhandler.CookieSecure(false))
-	sessionMw := echo.MiddlewareFunc(mw.NewSessionMiddleware(sess))
+	sessions := memory.NewAdminSession()
+	h := authhandler.NewHandler(
+		signIn,
+		oidcSignIn,
+		getMe,
+		sessionuc.NewCreateSessionUseCase(sessions, sess),
+		sessionuc.NewListSessionsUseCase(sessions),
+		sessionuc.NewRevokeSessionUseCase(sessions),
+		sess,
+		authhandler.CookieSecure(false),
+	)
+	sessionMw := echo.MiddlewareFunc(mw.NewSessionMiddleware(sess, sessions))
 
 	e := echo.New()
 	e.Validator = testValidator{v: validator.New()}
 	e.HTTPErrorHandler = adminhttp.CustomHTTPErrorHandler
 	e.POST("/api/v1/auth/google", h.GoogleSignIn)
+	e.POST("/api/v1/auth/oidc", h.OIDCSignIn)
 	e.POST("/api/v1/auth/logout", h.Logout) // public, mirrors the real router
 	e.GET("/api/v1/me", h.Me, sessionMw)
+	e.GET("/api/v1/auth/sessions", h.ListSessions, sessionMw)
+	e.DELETE("/api/v1/auth/sessions/:id", h.RevokeSession, sessionMw)
 	return e
 }
 
 func TestAuthFlow_GoogleThenMeThenLogout(t *testing.T) {
-	e := newTestEcho(t, &google.Claims{Email: "alice@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Alice"})
+	e := newTestEcho(t, &identity.Claims{Email: "alice@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Alice"})
 
 	// 1. sign in
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/google", strings.NewReader(`{"id_token":"tok"}`))
@@ -65,7 +87,7 @@ func TestAuthFlow_GoogleThenMeThenLogout(t *testing.T) {
 	e.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusOK, rec.Code)
 
-	var signInBody authhandler.GoogleSignInResponse
+	var signInBody authhandler.SignInResponse
 	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &signInBody))
 	assert.Equal(t, "pending", signInBody.Status)
 	assert.Equal(t, "alice@eukarya.io", signInBody.Email)
@@ -126,6 +148,10 @@ func TestAuthFlow_GoogleThenMeThenLogout(t *testing.T) {
 	}
 	assert.True(t, cleared, "logout must expire the session cookie")
 	assert.True(t, csrfCleared, "logout must expire the csrf cookie")
+
+	// 4. the logged-out session is gone server-side, so a copy of the cookie
+	// no longer authenticates
+	assert.Equal(t, http.StatusUnauthorized, serve(e, http.MethodGet, "/api/v1/me", sessionCookie).Code)
 }
 
 func TestLogout_NoCookie_Succeeds(t *testing.T) {
@@ -158,7 +184,7 @@ func TestMe_Unauthorized_NoCookie(t *testing.T) {
 }
 
 func TestGoogleSignIn_DomainRejected(t *testing.T) {
-	e := newTestEcho(t, &google.Claims{Email: "x@gmail.com", EmailVerified: true, HD: ""})
+	e := newTestEcho(t, &identity.Claims{Email: "x@gmail.com", EmailVerified: true, HD: ""})
 	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/google", strings.NewReader(`{"id_token":"tok"}`))
 	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 	rec := httptest.NewRecorder()
@@ -174,3 +200,108 @@ func TestGoogleSignIn_MissingIDToken(t *testing.T) {
 	e.ServeHTTP(rec, req)
 	assert.Equal(t, http.StatusBadRequest, rec.Code)
 }
+
+func TestOIDCSignIn_StartsSession(t *testing.T) {
+	// any domain is fine here: OIDC account policy lives in the verifier
+	e := newTestEcho(t, &identity.Claims{Email: "carol@example.com", EmailVerified: true, Name: "Carol"})
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/oidc", strings.NewReader(`{"id_token":"tok"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body authhandler.SignInResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, "carol@example.com", body.Email)
+	assert.Equal(t, "pending", body.Status)
+
+	var sessionCookie *http.Cookie
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == session.CookieName {
+			sessionCookie = c
+		}
+	}
+	require.NotNil(t, sessionCookie)
+	assert.Equal(t, http.StatusOK, serve(e, http.MethodGet, "/api/v1/me", sessionCookie).Code)
+}
+
+func TestOIDCSignIn_NotConfigured(t *testing.T) {
+	e := newTestEchoWithOIDC(t, nil, nil)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/oidc", strings.NewReader(`{"id_token":"tok"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotImplemented, rec.Code)
+}
+
+func serve(e *echo.Echo, method, path string, cookie *http.Cookie) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	if cookie != nil {
+		req.AddCookie(cookie)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func signIn(t *testing.T, e *echo.Echo) *http.Cookie {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/google", strings.NewReader(`{"id_token":"tok"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == session.CookieName {
+			return c
+		}
+	}
+	t.Fatal("session cookie must be set")
+	return nil
+}
+
+func TestSessions_ListAndRevoke(t *testing.T) {
+	e := newTestEcho(t, &identity.Claims{Email: "alice@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Alice"})
+	laptop := signIn(t, e)
+	phone := signIn(t, e)
+
+	rec := serve(e, http.MethodGet, "/api/v1/auth/sessions", laptop)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var list authhandler.ListSessionsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Items, 2)
+	var current, other authhandler.SessionResponse
+	for _, s := range list.Items {
+		if s.Current {
+			current = s
+		} else {
+			other = s
+		}
+	}
+	require.NotEmpty(t, current.ID, "the laptop session must be marked current")
+	require.NotEmpty(t, other.ID)
+	assert.Equal(t, "192.0.2.1", other.IP)
+
+	// revoking the other session ends it immediately
+	rec = serve(e, http.MethodDelete, "/api/v1/auth/sessions/"+other.ID, laptop)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Empty(t, rec.Result().Cookies(), "revoking another session must keep the caller's cookies")
+	assert.Equal(t, http.StatusUnauthorized, serve(e, http.MethodGet, "/api/v1/me", phone).Code)
+	assert.Equal(t, http.StatusOK, serve(e, http.MethodGet, "/api/v1/me", laptop).Code)
+
+	// an unknown or already revoked session is not found
+	assert.Equal(t, http.StatusNotFound, serve(e, http.MethodDelete, "/api/v1/auth/sessions/"+other.ID, laptop).Code)
+	assert.Equal(t, http.StatusBadRequest, serve(e, http.MethodDelete, "/api/v1/auth/sessions/bad", laptop).Code)
+
+	// revoking the current session signs the caller out
+	rec = serve(e, http.MethodDelete, "/api/v1/auth/sessions/"+current.ID, laptop)
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	var cleared bool
+	for _, c := range rec.Result().Cookies() {
+		if c.Name == session.CookieName && c.MaxAge < 0 {
+			cleared = true
+		}
+	}
+	assert.True(t, cleared, "revoking the current session must expire the session cookie")
+	assert.Equal(t, http.StatusUnauthorized, serve(e, http.MethodGet, "/api/v1/auth/sessions", laptop).Code)
+}
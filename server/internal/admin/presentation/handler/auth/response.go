@@ -3,22 +3,23 @@ package auth
 import (
 	"time"
 
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 )
 
-// GoogleSignInRequest is the body of POST /auth/google.
-type GoogleSignInRequest struct {
+// SignInRequest is the body of POST /auth/google and POST /auth/oidc.
+type SignInRequest struct {
 	IDToken string `json:"id_token" validate:"required"`
-} // @name GoogleSignInRequest
+} // @name SignInRequest
 
-// GoogleSignInResponse is returned after a successful Google sign-in. It carries
-// just enough for the frontend to route to the pending/approved/rejected screen.
-type GoogleSignInResponse struct {
+// SignInResponse is returned after a successful sign-in. It carries just
+// enough for the frontend to route to the pending/approved/rejected screen.
+type SignInResponse struct {
 	Status     string `json:"status"`
 	Email      string `json:"email"`
 	Name       string `json:"name"`
 	PictureURL string `json:"pictureUrl"`
-} // @name GoogleSignInResponse
+} // @name SignInResponse
 
 // MeResponse is the current admin user's record returned by GET /me.
 type MeResponse struct {
@@ -33,8 +34,23 @@ type MeResponse struct {
 	UpdatedAt  time.Time  `json:"updatedAt"`
 } // @name MeResponse
 
-func newGoogleSignInResponse(u *adminuser.AdminUser) GoogleSignInResponse {
-	return GoogleSignInResponse{
+// SessionResponse is one of the current admin user's sessions.
+type SessionResponse struct {
+	ID        string    `json:"id"`
+	UserAgent string    `json:"userAgent"`
+	IP        string    `json:"ip"`
+	Current   bool      `json:"current"`
+	CreatedAt time.Time `json:"createdAt"`
+	ExpiresAt time.Time `json:"expiresAt"`
+} // @name AdminSession
+
+// ListSessionsResponse is returned by GET /auth/sessions.
+type ListSessionsResponse struct {
+	Items []SessionResponse `json:"items"`
+} // @name ListSessionsResponse
+
+func newSignInResponse(u *adminuser.AdminUser) SignInResponse {
+	return SignInResponse{
 		Status:     u.Status().String(),
 		Email:      u.Email(),
 		Name:       u.Name(),
@@ -60,3 +76,18 @@ func newMeResponse(u *adminuser.AdminUser) MeResponse {
 	}
 	return res
 }
+
+func newListSessionsResponse(l adminsession.List, current adminsession.ID) ListSessionsResponse {
+	items := make([]SessionResponse, 0, len(l))
+	for _, s := range l {
+		items = append(items, SessionResponse{
+			ID:        s.ID().String(),
+			UserAgent: s.UserAgent(),
+			IP:        s.IP(),
+			Current:   s.ID() == current,
+			CreatedAt: s.CreatedAt(),
+			ExpiresAt: s.ExpiresAt(),
+		})
+	}
+	return ListSessionsResponse{Items: items}
+}
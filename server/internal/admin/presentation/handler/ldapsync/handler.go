@@ -0,0 +1,21 @@
+// Package ldapsync implements the endpoints reporting the runs of the LDAP
+// directory sync, behind the RequireApproved middleware.
+package ldapsync
+
+import (
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/ldapsyncuc"
+)
+
+// Handler serves the /ldap-sync endpoints.
+type Handler struct {
+	list *ldapsyncuc.ListLDAPSyncRunsUseCase
+	get  *ldapsyncuc.GetLDAPSyncRunUseCase
+}
+
+// NewHandler is a Wire provider for the LDAP sync Handler.
+func NewHandler(
+	list *ldapsyncuc.ListLDAPSyncRunsUseCase,
+	get *ldapsyncuc.GetLDAPSyncRunUseCase,
+) *Handler {
+	return &Handler{list: list, get: get}
+}
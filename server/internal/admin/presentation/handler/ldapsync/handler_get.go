@@ -0,0 +1,34 @@
+package ldapsync
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+)
+
+// GetLDAPSyncRun godoc
+//
+//	@Summary		Get an LDAP sync run
+//	@Description	Returns the diff of an LDAP sync run: the users it created, updated, reactivated and deactivated, the workspace memberships it changed and the directory entries it skipped.
+//	@Tags			ldap-sync
+//	@Produce		json
+//	@Param			id	path		string	true	"Run ID"
+//	@Success		200	{object}	LDAPSyncRunDetailResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Router			/ldap-sync/runs/{id} [get]
+func (h *Handler) GetLDAPSyncRun(c echo.Context) error {
+	rid, err := ldapsync.IDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	r, err := h.get.Execute(c.Request().Context(), rid)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newLDAPSyncRunDetailResponse(r))
+}
@@ -0,0 +1,33 @@
+package ldapsync
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+)
+
+// ListLDAPSyncRuns godoc
+//
+//	@Summary		List LDAP sync runs
+//	@Description	Lists the recent runs of the LDAP directory sync, newest first, with the number of users each one changed.
+//	@Tags			ldap-sync
+//	@Produce		json
+//	@Param			limit	query		int	false	"Number of runs (default 20, max 100)"
+//	@Success		200		{object}	ListLDAPSyncRunsResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid query"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Router			/ldap-sync/runs [get]
+func (h *Handler) ListLDAPSyncRuns(c echo.Context) error {
+	limit, err := internal.ParsePageParam(c.QueryParam("limit"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid limit")
+	}
+
+	list, err := h.list.Execute(c.Request().Context(), int(limit))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newListLDAPSyncRunsResponse(list))
+}
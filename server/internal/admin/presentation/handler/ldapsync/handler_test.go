@@ -0,0 +1,123 @@
+package ldapsync_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	adminpresentation "github.com/reearth/reearth-accounts/server/internal/admin/presentation"
+	ldapsynchandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/ldapsync"
+	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/ldapsyncuc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-test-secret-test-secret"
+
+type testEnv struct {
+	e        *echo.Echo
+	sess     *session.Manager
+	sessions adminsession.Repo
+	op       *adminuser.AdminUser
+}
+
+func newTestEnv(t *testing.T, runs ...*ldapsync.Run) *testEnv {
+	t.Helper()
+	op := adminuser.New().NewID().Name("op@eukarya.io").Email("op@eukarya.io").Status(adminuser.StatusApproved).MustBuild()
+	repo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	sessions := memory.NewAdminSession()
+	runRepo := memory.NewLDAPSyncWith(runs...)
+
+	h := ldapsynchandler.NewHandler(
+		ldapsyncuc.NewListLDAPSyncRunsUseCase(runRepo),
+		ldapsyncuc.NewGetLDAPSyncRunUseCase(runRepo),
+	)
+	e := echo.New()
+	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
+	g := e.Group("/api/v1/ldap-sync", echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, repo)))
+	g.GET("/runs", h.ListLDAPSyncRuns)
+	g.GET("/runs/:id", h.GetLDAPSyncRun)
+	return &testEnv{e: e, sess: sess, sessions: sessions, op: op}
+}
+
+func (env *testEnv) get(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(env.op.ID()).CreatedAt(now).ExpiresAt(now.Add(env.sess.TTL())).MustBuild()
+	require.NoError(t, env.sessions.Save(context.Background(), s))
+	tok, err := env.sess.Issue(env.op.ID(), s.ID(), now)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: session.CookieName, Value: tok})
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestLDAPSyncRuns(t *testing.T) {
+	now := time.Now().Truncate(time.Second)
+	uid := user.NewID()
+	wid := workspace.NewID()
+	older := ldapsync.NewRun(now.Add(-time.Hour))
+	older.Fail(now.Add(-time.Hour), assert.AnError)
+	latest := ldapsync.NewRun(now)
+	latest.AddUser(uid)
+	latest.AddCreated(ldapsync.UserChange{User: uid, Sub: "ldap|alice", Email: "alice@example.org"})
+	latest.AddMemberships(ldapsync.MembershipChange{Workspace: wid, User: uid, To: role.RoleWriter})
+	latest.AddSkipped(ldapsync.Skip{DN: "uid=bob,dc=example,dc=org", Reason: "missing email"})
+	latest.Succeed(now.Add(time.Second))
+	env := newTestEnv(t, older, latest)
+
+	rec := env.get(t, "/api/v1/ldap-sync/runs")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var list ldapsynchandler.ListLDAPSyncRunsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	require.Len(t, list.Items, 2)
+	assert.Equal(t, latest.ID().String(), list.Items[0].ID)
+	assert.Equal(t, "succeeded", list.Items[0].Status)
+	assert.Equal(t, 1, list.Items[0].Created)
+	assert.Equal(t, 1, list.Items[0].Skipped)
+	assert.Equal(t, "failed", list.Items[1].Status)
+	assert.NotEmpty(t, list.Items[1].Error)
+
+	rec = env.get(t, "/api/v1/ldap-sync/runs?limit=1")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Len(t, list.Items, 1)
+
+	rec = env.get(t, "/api/v1/ldap-sync/runs/"+latest.ID().String())
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var detail ldapsynchandler.LDAPSyncRunDetailResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &detail))
+	assert.Equal(t, []ldapsynchandler.LDAPSyncUserChangeResponse{
+		{UserID: uid.String(), Sub: "ldap|alice", Email: "alice@example.org"},
+	}, detail.Created)
+	assert.Equal(t, []ldapsynchandler.LDAPSyncMembershipChangeResponse{
+		{WorkspaceID: wid.String(), UserID: uid.String(), To: "writer"},
+	}, detail.Memberships)
+	assert.Equal(t, []ldapsynchandler.LDAPSyncSkipResponse{
+		{DN: "uid=bob,dc=example,dc=org", Reason: "missing email"},
+	}, detail.Skipped)
+	assert.Empty(t, detail.Deactivated)
+}
+
+func TestLDAPSyncRuns_Invalid(t *testing.T) {
+	env := newTestEnv(t)
+	assert.Equal(t, http.StatusBadRequest, env.get(t, "/api/v1/ldap-sync/runs?limit=0").Code)
+	assert.Equal(t, http.StatusBadRequest, env.get(t, "/api/v1/ldap-sync/runs/invalid").Code)
+	assert.Equal(t, http.StatusNotFound, env.get(t, "/api/v1/ldap-sync/runs/"+ldapsync.NewID().String()).Code)
+}
@@ -0,0 +1,120 @@
+package ldapsync
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+)
+
+// LDAPSyncRunResponse is an LDAP sync run in the admin API. Status is
+// "running", "succeeded" or "failed"; Error is set for failed runs.
+type LDAPSyncRunResponse struct {
+	ID          string     `json:"id"`
+	Status      string     `json:"status"`
+	Error       string     `json:"error,omitempty"`
+	StartedAt   time.Time  `json:"startedAt"`
+	FinishedAt  *time.Time `json:"finishedAt,omitempty"`
+	UserCount   int        `json:"userCount"`
+	Created     int        `json:"created"`
+	Updated     int        `json:"updated"`
+	Reactivated int        `json:"reactivated"`
+	Deactivated int        `json:"deactivated"`
+	Memberships int        `json:"memberships"`
+	Skipped     int        `json:"skipped"`
+} // @name LDAPSyncRun
+
+// LDAPSyncUserChangeResponse is a user an LDAP sync run changed.
+type LDAPSyncUserChangeResponse struct {
+	UserID string `json:"userId"`
+	Sub    string `json:"sub,omitempty"`
+	Email  string `json:"email"`
+} // @name LDAPSyncUserChange
+
+// LDAPSyncMembershipChangeResponse is a workspace membership an LDAP sync run
+// changed. From is empty when the user joined and To when it left.
+type LDAPSyncMembershipChangeResponse struct {
+	WorkspaceID string `json:"workspaceId"`
+	UserID      string `json:"userId"`
+	From        string `json:"from,omitempty"`
+	To          string `json:"to,omitempty"`
+} // @name LDAPSyncMembershipChange
+
+// LDAPSyncSkipResponse is a directory entry an LDAP sync run skipped.
+type LDAPSyncSkipResponse struct {
+	DN     string `json:"dn"`
+	Reason string `json:"reason"`
+} // @name LDAPSyncSkip
+
+// LDAPSyncRunDetailResponse is an LDAP sync run with its changes.
+type LDAPSyncRunDetailResponse struct {
+	Run         LDAPSyncRunResponse                `json:"run"`
+	Created     []LDAPSyncUserChangeResponse       `json:"created"`
+	Updated     []LDAPSyncUserChangeResponse       `json:"updated"`
+	Reactivated []LDAPSyncUserChangeResponse       `json:"reactivated"`
+	Deactivated []LDAPSyncUserChangeResponse       `json:"deactivated"`
+	Memberships []LDAPSyncMembershipChangeResponse `json:"memberships"`
+	Skipped     []LDAPSyncSkipResponse             `json:"skipped"`
+} // @name LDAPSyncRunDetail
+
+// ListLDAPSyncRunsResponse is the list of recent LDAP sync runs.
+type ListLDAPSyncRunsResponse struct {
+	Items []LDAPSyncRunResponse `json:"items"`
+} // @name ListLDAPSyncRunsResponse
+
+func newLDAPSyncRunResponse(r *ldapsync.Run) LDAPSyncRunResponse {
+	return LDAPSyncRunResponse{
+		ID:          r.ID().String(),
+		Status:      string(r.Status()),
+		Error:       r.Err(),
+		StartedAt:   r.StartedAt(),
+		FinishedAt:  r.FinishedAt(),
+		UserCount:   len(r.Users()),
+		Created:     len(r.Created()),
+		Updated:     len(r.Updated()),
+		Reactivated: len(r.Reactivated()),
+		Deactivated: len(r.Deactivated()),
+		Memberships: len(r.Memberships()),
+		Skipped:     len(r.Skipped()),
+	}
+}
+
+func newUserChangeResponses(l []ldapsync.UserChange) []LDAPSyncUserChangeResponse {
+	res := make([]LDAPSyncUserChangeResponse, 0, len(l))
+	for _, c := range l {
+		res = append(res, LDAPSyncUserChangeResponse{UserID: c.User.String(), Sub: c.Sub, Email: c.Email})
+	}
+	return res
+}
+
+func newLDAPSyncRunDetailResponse(r *ldapsync.Run) LDAPSyncRunDetailResponse {
+	memberships := make([]LDAPSyncMembershipChangeResponse, 0, len(r.Memberships()))
+	for _, c := range r.Memberships() {
+		memberships = append(memberships, LDAPSyncMembershipChangeResponse{
+			WorkspaceID: c.Workspace.String(),
+			UserID:      c.User.String(),
+			From:        c.From.String(),
+			To:          c.To.String(),
+		})
+	}
+	skipped := make([]LDAPSyncSkipResponse, 0, len(r.Skipped()))
+	for _, s := range r.Skipped() {
+		skipped = append(skipped, LDAPSyncSkipResponse{DN: s.DN, Reason: s.Reason})
+	}
+	return LDAPSyncRunDetailResponse{
+		Run:         newLDAPSyncRunResponse(r),
+		Created:     newUserChangeResponses(r.Created()),
+		Updated:     newUserChangeResponses(r.Updated()),
+		Reactivated: newUserChangeResponses(r.Reactivated()),
+		Deactivated: newUserChangeResponses(r.Deactivated()),
+		Memberships: memberships,
+		Skipped:     skipped,
+	}
+}
+
+func newListLDAPSyncRunsResponse(list ldapsync.List) ListLDAPSyncRunsResponse {
+	items := make([]LDAPSyncRunResponse, 0, len(list))
+	for _, r := range list {
+		items = append(items, newLDAPSyncRunResponse(r))
+	}
+	return ListLDAPSyncRunsResponse{Items: items}
+}
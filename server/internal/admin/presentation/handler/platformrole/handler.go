@@ -0,0 +1,56 @@
+// Package platformrole implements the endpoints managing the platform roles and
+// their bindings to users, behind the RequireApproved middleware.
+package platformrole
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/platformroleuc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+// Handler serves the /roles endpoints.
+type Handler struct {
+	list      *platformroleuc.ListRolesUseCase
+	listUsers *platformroleuc.ListRoleUsersUseCase
+	grant     *platformroleuc.GrantRoleUseCase
+	revoke    *platformroleuc.RevokeRoleUseCase
+}
+
+// NewHandler is a Wire provider for the platform role Handler.
+func NewHandler(
+	list *platformroleuc.ListRolesUseCase,
+	listUsers *platformroleuc.ListRoleUsersUseCase,
+	grant *platformroleuc.GrantRoleUseCase,
+	revoke *platformroleuc.RevokeRoleUseCase,
+) *Handler {
+	return &Handler{list: list, listUsers: listUsers, grant: grant, revoke: revoke}
+}
+
+// bindingInput reads the role, the user and, on the workspace routes, the
+// workspace of a grant or revoke from the path.
+func bindingInput(c echo.Context) (platformroleuc.BindingInput, error) {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return platformroleuc.BindingInput{}, err
+	}
+	rid, err := id.RoleIDFrom(c.Param("id"))
+	if err != nil {
+		return platformroleuc.BindingInput{}, echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+	uid, err := id.UserIDFrom(c.Param("userId"))
+	if err != nil {
+		return platformroleuc.BindingInput{}, echo.NewHTTPError(http.StatusBadRequest, "invalid user id")
+	}
+	in := platformroleuc.BindingInput{Operator: operator.ID(), Role: rid, User: uid}
+	if raw := c.Param("workspaceId"); raw != "" {
+		wid, err := id.WorkspaceIDFrom(raw)
+		if err != nil {
+			return platformroleuc.BindingInput{}, echo.NewHTTPError(http.StatusBadRequest, "invalid workspace id")
+		}
+		in.Workspace = &wid
+	}
+	return in, nil
+}
@@ -0,0 +1,37 @@
+package platformrole
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// GrantRole godoc
+//
+//	@Summary		Grant a role to a user
+//	@Description	Binds the role to the user globally or, on the workspace route, makes it the membership role of the user in the workspace, which must be one of owner, maintainer, writer and reader. The user must already be a member of the workspace. The membership change is recorded in the audit log like the member endpoints do.
+//	@Tags			roles
+//	@Produce		json
+//	@Param			id			path		string	true	"Role ID"
+//	@Param			workspaceId	path		string	false	"Workspace ID (workspace route only)"
+//	@Param			userId		path		string	true	"User ID"
+//	@Success		200			{object}	UserRolesResponse
+//	@Failure		400			{object}	internal.ErrorResponse	"invalid id / not a membership role"
+//	@Failure		401			{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403			{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404			{object}	internal.ErrorResponse	"role, user, workspace or member not found"
+//	@Failure		409			{object}	internal.ErrorResponse	"already granted"
+//	@Router			/roles/{id}/users/{userId} [put]
+//	@Router			/roles/{id}/workspaces/{workspaceId}/users/{userId} [put]
+func (h *Handler) GrantRole(c echo.Context) error {
+	in, err := bindingInput(c)
+	if err != nil {
+		return err
+	}
+
+	p, err := h.grant.Execute(c.Request().Context(), in)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserRolesResponse(p))
+}
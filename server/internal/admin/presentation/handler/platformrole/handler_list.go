@@ -0,0 +1,25 @@
+package platformrole
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListRoles godoc
+//
+//	@Summary		List platform roles
+//	@Description	Lists the roles sorted by name, with the number of users holding each globally. Bindings scoped to a workspace are not counted.
+//	@Tags			roles
+//	@Produce		json
+//	@Success		200	{object}	ListRolesResponse
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Router			/roles [get]
+func (h *Handler) ListRoles(c echo.Context) error {
+	roles, err := h.list.Execute(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newListRolesResponse(roles))
+}
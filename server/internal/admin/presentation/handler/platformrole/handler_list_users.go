@@ -0,0 +1,58 @@
+package platformrole
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/pagination"
+)
+
+// ListRoleUsers godoc
+//
+//	@Summary		List the users holding a role
+//	@Description	Lists the users holding the role globally, ordered by user ID, with offset pagination.
+//	@Tags			roles
+//	@Produce		json
+//	@Param			id			path		string	true	"Role ID"
+//	@Param			page		query		int		false	"Page number (1-based)"
+//	@Param			per_page	query		int		false	"Items per page (max 100)"
+//	@Success		200			{object}	ListRoleUsersResponse
+//	@Failure		400			{object}	internal.ErrorResponse	"invalid id / query"
+//	@Failure		401			{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403			{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404			{object}	internal.ErrorResponse	"role not found"
+//	@Router			/roles/{id}/users [get]
+func (h *Handler) ListRoleUsers(c echo.Context) error {
+	rid, err := id.RoleIDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	page, err := internal.ParsePageParam(c.QueryParam("page"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid page")
+	}
+	perPage, err := internal.ParsePageParam(c.QueryParam("per_page"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid per_page")
+	}
+	p := pagination.ToPagination(page, perPage)
+
+	list, pi, err := h.listUsers.Execute(c.Request().Context(), rid, p)
+	if err != nil {
+		return err
+	}
+
+	effectivePage := int64(1)
+	if page > 0 {
+		effectivePage = page
+	}
+	return c.JSON(http.StatusOK, ListRoleUsersResponse{
+		Items:      newRoleUserResponses(list),
+		TotalCount: pi.TotalCount,
+		Page:       effectivePage,
+		PerPage:    p.Offset.Limit,
+	})
+}
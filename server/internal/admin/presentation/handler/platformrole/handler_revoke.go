@@ -0,0 +1,36 @@
+package platformrole
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// RevokeRole godoc
+//
+//	@Summary		Revoke a role from a user
+//	@Description	Unbinds the role from the user globally or, on the workspace route, removes the member holding it from the workspace, as a workspace binding follows the membership. For a user who is not a member, only a binding left in their roles is removed.
+//	@Tags			roles
+//	@Produce		json
+//	@Param			id			path		string	true	"Role ID"
+//	@Param			workspaceId	path		string	false	"Workspace ID (workspace route only)"
+//	@Param			userId		path		string	true	"User ID"
+//	@Success		200			{object}	UserRolesResponse
+//	@Failure		400			{object}	internal.ErrorResponse	"invalid id / not a membership role / last owner"
+//	@Failure		401			{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403			{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404			{object}	internal.ErrorResponse	"not found / not granted"
+//	@Router			/roles/{id}/users/{userId} [delete]
+//	@Router			/roles/{id}/workspaces/{workspaceId}/users/{userId} [delete]
+func (h *Handler) RevokeRole(c echo.Context) error {
+	in, err := bindingInput(c)
+	if err != nil {
+		return err
+	}
+
+	p, err := h.revoke.Execute(c.Request().Context(), in)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserRolesResponse(p))
+}
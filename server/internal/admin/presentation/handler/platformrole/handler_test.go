@@ -0,0 +1,178 @@
+package platformrole_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	adminpresentation "github.com/reearth/reearth-accounts/server/internal/admin/presentation"
+	platformrolehandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/platformrole"
+	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/platformroleuc"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-test-secret-test-secret"
+
+type testEnv struct {
+	e        *echo.Echo
+	sess     *session.Manager
+	sessions adminsession.Repo
+	op       *adminuser.AdminUser
+	role     *role.Role
+	writer   *role.Role
+	user     *user.User
+	ws       *workspace.Workspace
+	other    *workspace.Workspace
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	op := adminuser.New().NewID().Name("op@eukarya.io").Email("op@eukarya.io").Status(adminuser.StatusApproved).MustBuild()
+	repo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	sessions := memory.NewAdminSession()
+
+	r := memory.New()
+	ctx := context.Background()
+	rl := role.New().NewID().Name("admin").MustBuild()
+	require.NoError(t, r.Role.Save(ctx, *rl))
+	writer := role.New().NewID().Name(string(role.RoleWriter)).MustBuild()
+	require.NoError(t, r.Role.Save(ctx, *writer))
+	u := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	ws := workspace.New().NewID().Name("gis").Members(map[user.ID]workspace.Member{
+		user.NewID(): {Role: role.RoleOwner},
+		u.ID():       {Role: role.RoleReader},
+	}).MustBuild()
+	require.NoError(t, r.Workspace.Save(ctx, ws))
+	other := workspace.New().NewID().Name("ops").MustBuild()
+	require.NoError(t, r.Workspace.Save(ctx, other))
+
+	h := platformrolehandler.NewHandler(
+		platformroleuc.NewListRolesUseCase(r.Role, r.Permittable),
+		platformroleuc.NewListRoleUsersUseCase(r.Role, r.Permittable, r.User),
+		platformroleuc.NewGrantRoleUseCase(r.Role, r.Permittable, r.User, r.Workspace, r.Transaction,
+			workspaceuc.NewUpdateWorkspaceMemberUseCase(r.Workspace, r.Role, r.Permittable, r.AuditLog, r.Transaction)),
+		platformroleuc.NewRevokeRoleUseCase(r.Role, r.Permittable, r.User, r.Workspace, r.Transaction,
+			workspaceuc.NewRemoveWorkspaceMemberUseCase(r.Workspace, r.Role, r.Permittable, r.AuditLog, r.Transaction)),
+	)
+	e := echo.New()
+	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
+	g := e.Group("/api/v1/roles", echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, repo)))
+	g.GET("", h.ListRoles)
+	g.GET("/:id/users", h.ListRoleUsers)
+	g.PUT("/:id/users/:userId", h.GrantRole)
+	g.DELETE("/:id/users/:userId", h.RevokeRole)
+	g.PUT("/:id/workspaces/:workspaceId/users/:userId", h.GrantRole)
+	g.DELETE("/:id/workspaces/:workspaceId/users/:userId", h.RevokeRole)
+	return &testEnv{e: e, sess: sess, sessions: sessions, op: op, role: rl, writer: writer, user: u, ws: ws, other: other}
+}
+
+func (env *testEnv) do(t *testing.T, method, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, nil)
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(env.op.ID()).CreatedAt(now).ExpiresAt(now.Add(env.sess.TTL())).MustBuild()
+	require.NoError(t, env.sessions.Save(context.Background(), s))
+	tok, err := env.sess.Issue(env.op.ID(), s.ID(), now)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: session.CookieName, Value: tok})
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestPlatformRole_Lifecycle(t *testing.T) {
+	env := newTestEnv(t)
+	rolePath := "/api/v1/roles/" + env.role.ID().String()
+	globalPath := rolePath + "/users/" + env.user.ID().String()
+	scopedPath := "/api/v1/roles/" + env.writer.ID().String() + "/workspaces/" + env.ws.ID().String() + "/users/" + env.user.ID().String()
+
+	rec := env.do(t, http.MethodPut, globalPath)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var granted platformrolehandler.UserRolesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &granted))
+	assert.Equal(t, env.user.ID().String(), granted.UserID)
+	assert.Equal(t, []string{env.role.ID().String()}, granted.RoleIDs)
+
+	rec = env.do(t, http.MethodPut, globalPath)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = env.do(t, http.MethodPut, scopedPath)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &granted))
+	assert.Equal(t, []platformrolehandler.UserWorkspaceRoleResponse{
+		{WorkspaceID: env.ws.ID().String(), RoleID: env.writer.ID().String()},
+	}, granted.WorkspaceRoles)
+	rec = env.do(t, http.MethodPut, scopedPath)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	rec = env.do(t, http.MethodGet, "/api/v1/roles")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var roles platformrolehandler.ListRolesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &roles))
+	require.Len(t, roles.Items, 2)
+	assert.Equal(t, "admin", roles.Items[0].Name)
+	assert.Equal(t, 1, roles.Items[0].UserCount)
+	assert.Equal(t, "writer", roles.Items[1].Name)
+	assert.Equal(t, 0, roles.Items[1].UserCount)
+
+	rec = env.do(t, http.MethodGet, rolePath+"/users?page=1&per_page=10")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var users platformrolehandler.ListRoleUsersResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &users))
+	assert.Equal(t, int64(1), users.TotalCount)
+	require.Len(t, users.Items, 1)
+	assert.Equal(t, "alice@example.com", users.Items[0].Email)
+
+	rec = env.do(t, http.MethodDelete, scopedPath)
+	require.Equal(t, http.StatusOK, rec.Code)
+	rec = env.do(t, http.MethodDelete, globalPath)
+	require.Equal(t, http.StatusOK, rec.Code)
+	var revoked platformrolehandler.UserRolesResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &revoked))
+	assert.Empty(t, revoked.RoleIDs)
+	assert.Empty(t, revoked.WorkspaceRoles)
+
+	rec = env.do(t, http.MethodDelete, globalPath)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+	rec = env.do(t, http.MethodDelete, scopedPath)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestPlatformRole_Invalid(t *testing.T) {
+	env := newTestEnv(t)
+	rolePath := "/api/v1/roles/" + env.role.ID().String()
+	writerPath := "/api/v1/roles/" + env.writer.ID().String()
+	uid := env.user.ID().String()
+	for path, want := range map[string]int{
+		"/api/v1/roles/invalid/users/" + uid:                                       http.StatusBadRequest,
+		rolePath + "/users/invalid":                                                http.StatusBadRequest,
+		writerPath + "/workspaces/invalid/users/" + uid:                            http.StatusBadRequest,
+		"/api/v1/roles/" + role.NewID().String() + "/users/" + uid:                 http.StatusNotFound,
+		rolePath + "/users/" + user.NewID().String():                               http.StatusNotFound,
+		writerPath + "/workspaces/" + workspace.NewID().String() + "/users/" + uid: http.StatusNotFound,
+		writerPath + "/workspaces/" + env.other.ID().String() + "/users/" + uid:    http.StatusNotFound,
+		rolePath + "/workspaces/" + env.ws.ID().String() + "/users/" + uid:         http.StatusBadRequest,
+	} {
+		rec := env.do(t, http.MethodPut, path)
+		assert.Equal(t, want, rec.Code, path)
+	}
+
+	rec := env.do(t, http.MethodGet, "/api/v1/roles/"+role.NewID().String()+"/users")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
@@ -0,0 +1,91 @@
+package platformrole
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/platformroleuc"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+)
+
+// RoleResponse is a platform role in the admin API.
+type RoleResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	// UserCount is the number of users holding the role globally.
+	UserCount int       `json:"userCount"`
+	UpdatedAt time.Time `json:"updatedAt"`
+} // @name Role
+
+// ListRolesResponse is the list of platform roles.
+type ListRolesResponse struct {
+	Items []RoleResponse `json:"items"`
+} // @name ListRolesResponse
+
+// RoleUserResponse is a user holding a role.
+type RoleUserResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Email string `json:"email"`
+	Alias string `json:"alias"`
+} // @name RoleUser
+
+// ListRoleUsersResponse is the paginated list of the users holding a role.
+type ListRoleUsersResponse struct {
+	Items      []RoleUserResponse `json:"items"`
+	TotalCount int64              `json:"totalCount"`
+	Page       int64              `json:"page"`
+	PerPage    int64              `json:"perPage"`
+} // @name ListRoleUsersResponse
+
+// UserRolesResponse is the roles a user is bound to after a grant or revoke.
+type UserRolesResponse struct {
+	UserID string `json:"userId"`
+	// RoleIDs are the roles the user holds globally.
+	RoleIDs        []string                    `json:"roleIds"`
+	WorkspaceRoles []UserWorkspaceRoleResponse `json:"workspaceRoles"`
+} // @name UserRoles
+
+// UserWorkspaceRoleResponse is the role a user is bound to in a workspace.
+type UserWorkspaceRoleResponse struct {
+	WorkspaceID string `json:"workspaceId"`
+	RoleID      string `json:"roleId"`
+} // @name UserWorkspaceRole
+
+func newListRolesResponse(roles []platformroleuc.RoleSummary) ListRolesResponse {
+	items := make([]RoleResponse, 0, len(roles))
+	for _, r := range roles {
+		items = append(items, RoleResponse{
+			ID:        r.Role.ID().String(),
+			Name:      r.Role.Name(),
+			UserCount: r.Users,
+			UpdatedAt: r.Role.UpdatedAt(),
+		})
+	}
+	return ListRolesResponse{Items: items}
+}
+
+func newRoleUserResponses(list user.List) []RoleUserResponse {
+	items := make([]RoleUserResponse, 0, len(list))
+	for _, u := range list {
+		items = append(items, RoleUserResponse{
+			ID:    u.ID().String(),
+			Name:  u.Name(),
+			Email: u.Email(),
+			Alias: u.Alias(),
+		})
+	}
+	return items
+}
+
+func newUserRolesResponse(p *permittable.Permittable) UserRolesResponse {
+	roleIDs := make([]string, 0, len(p.RoleIDs()))
+	for _, rid := range p.RoleIDs() {
+		roleIDs = append(roleIDs, rid.String())
+	}
+	wrs := make([]UserWorkspaceRoleResponse, 0, len(p.WorkspaceRoles()))
+	for _, wr := range p.WorkspaceRoles() {
+		wrs = append(wrs, UserWorkspaceRoleResponse{WorkspaceID: wr.ID().String(), RoleID: wr.RoleID().String()})
+	}
+	return UserRolesResponse{UserID: p.UserID().String(), RoleIDs: roleIDs, WorkspaceRoles: wrs}
+}
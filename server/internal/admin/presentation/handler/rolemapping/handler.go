@@ -0,0 +1,23 @@
+// Package rolemapping implements the endpoints managing the role mappings of
+// workspaces, behind the RequireApproved middleware.
+package rolemapping
+
+import (
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/rolemappinguc"
+)
+
+// Handler serves the /workspaces/{id}/role-mapping endpoints.
+type Handler struct {
+	get    *rolemappinguc.GetRoleMappingUseCase
+	set    *rolemappinguc.SetRoleMappingUseCase
+	delete *rolemappinguc.DeleteRoleMappingUseCase
+}
+
+// NewHandler is a Wire provider for the role mapping Handler.
+func NewHandler(
+	get *rolemappinguc.GetRoleMappingUseCase,
+	set *rolemappinguc.SetRoleMappingUseCase,
+	delete *rolemappinguc.DeleteRoleMappingUseCase,
+) *Handler {
+	return &Handler{get: get, set: set, delete: delete}
+}
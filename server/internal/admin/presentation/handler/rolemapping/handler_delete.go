@@ -0,0 +1,38 @@
+package rolemapping
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/rolemappinguc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+// DeleteRoleMapping godoc
+//
+//	@Summary		Delete a workspace's role mapping
+//	@Description	Stops mapping the claims of the workspace's identity provider. The members it added are kept.
+//	@Tags			role-mappings
+//	@Param			id	path	string	true	"Workspace ID"
+//	@Success		204
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Router			/workspaces/{id}/role-mapping [delete]
+func (h *Handler) DeleteRoleMapping(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+	wid, err := id.WorkspaceIDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	if err := h.delete.Execute(c.Request().Context(), rolemappinguc.DeleteInput{Operator: operator.ID(), Workspace: wid}); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
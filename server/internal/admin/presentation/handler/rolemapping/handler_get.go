@@ -0,0 +1,34 @@
+package rolemapping
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+// GetRoleMapping godoc
+//
+//	@Summary		Get a workspace's role mapping
+//	@Description	Returns the rules mapping the claims of the workspace's identity provider to workspace roles.
+//	@Tags			role-mappings
+//	@Produce		json
+//	@Param			id	path		string	true	"Workspace ID"
+//	@Success		200	{object}	RoleMappingResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Router			/workspaces/{id}/role-mapping [get]
+func (h *Handler) GetRoleMapping(c echo.Context) error {
+	wid, err := id.WorkspaceIDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	m, err := h.get.Execute(c.Request().Context(), wid)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newRoleMappingResponse(m))
+}
@@ -0,0 +1,76 @@
+package rolemapping
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/rolemappinguc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+)
+
+// SetRoleMappingRequest is the request body for setting a role mapping.
+type SetRoleMappingRequest struct {
+	Issuer string `json:"issuer" example:"https://example.auth0.com/"`
+	// SubPrefix narrows the identity provider down to one connection when
+	// several share the issuer. Empty matches every token of the issuer.
+	SubPrefix string                `json:"subPrefix" example:"samlp|org_123"`
+	Rules     []RoleMappingRuleBody `json:"rules"`
+} // @name SetRoleMappingRequest
+
+// RoleMappingRuleBody grants Role to the users whose token has Value in the
+// claim Claim. Dots in Claim read nested claims.
+type RoleMappingRuleBody struct {
+	Claim string `json:"claim" example:"groups"`
+	Value string `json:"value" example:"gis-editors"`
+	Role  string `json:"role" example:"writer" enums:"owner,maintainer,writer,reader"`
+} // @name RoleMappingRule
+
+// SetRoleMapping godoc
+//
+//	@Summary		Set a workspace's role mapping
+//	@Description	Creates or replaces the rules mapping the claims of the workspace's identity provider to workspace roles. Memberships are updated the next time each user of the identity provider signs in or calls the API.
+//	@Tags			role-mappings
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Workspace ID"
+//	@Param			body	body		SetRoleMappingRequest	true	"Role mapping"
+//	@Success		200		{object}	RoleMappingResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid id / issuer / rule"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404		{object}	internal.ErrorResponse	"workspace not found"
+//	@Router			/workspaces/{id}/role-mapping [put]
+func (h *Handler) SetRoleMapping(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+	wid, err := id.WorkspaceIDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	var body SetRoleMappingRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	rules := make([]rolemapping.Rule, 0, len(body.Rules))
+	for _, r := range body.Rules {
+		rules = append(rules, rolemapping.Rule{Claim: r.Claim, Value: r.Value, Role: role.RoleType(r.Role)})
+	}
+
+	m, err := h.set.Execute(c.Request().Context(), rolemappinguc.SetInput{
+		Operator:  operator.ID(),
+		Workspace: wid,
+		Issuer:    body.Issuer,
+		SubPrefix: body.SubPrefix,
+		Rules:     rules,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newRoleMappingResponse(m))
+}
@@ -0,0 +1,120 @@
+package rolemapping_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	adminpresentation "github.com/reearth/reearth-accounts/server/internal/admin/presentation"
+	rolemappinghandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/rolemapping"
+	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/rolemappinguc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-test-secret-test-secret"
+
+type testEnv struct {
+	e        *echo.Echo
+	sess     *session.Manager
+	sessions adminsession.Repo
+	op       *adminuser.AdminUser
+	ws       *workspace.Workspace
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	op := adminuser.New().NewID().Name("op@eukarya.io").Email("op@eukarya.io").Status(adminuser.StatusApproved).MustBuild()
+	repo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	sessions := memory.NewAdminSession()
+	mappings := memory.NewRoleMapping()
+	ws := workspace.New().NewID().Name("gis").MustBuild()
+	wsRepo := memory.NewWorkspaceWith(ws)
+
+	h := rolemappinghandler.NewHandler(
+		rolemappinguc.NewGetRoleMappingUseCase(mappings),
+		rolemappinguc.NewSetRoleMappingUseCase(mappings, wsRepo),
+		rolemappinguc.NewDeleteRoleMappingUseCase(mappings),
+	)
+	e := echo.New()
+	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
+	g := e.Group("/api/v1/workspaces", echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, repo)))
+	g.GET("/:id/role-mapping", h.GetRoleMapping)
+	g.PUT("/:id/role-mapping", h.SetRoleMapping)
+	g.DELETE("/:id/role-mapping", h.DeleteRoleMapping)
+	return &testEnv{e: e, sess: sess, sessions: sessions, op: op, ws: ws}
+}
+
+func (env *testEnv) do(t *testing.T, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(env.op.ID()).CreatedAt(now).ExpiresAt(now.Add(env.sess.TTL())).MustBuild()
+	require.NoError(t, env.sessions.Save(context.Background(), s))
+	tok, err := env.sess.Issue(env.op.ID(), s.ID(), now)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: session.CookieName, Value: tok})
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestRoleMapping_Lifecycle(t *testing.T) {
+	env := newTestEnv(t)
+	path := "/api/v1/workspaces/" + env.ws.ID().String() + "/role-mapping"
+
+	rec := env.do(t, http.MethodGet, path, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+
+	rec = env.do(t, http.MethodPut, path, `{"issuer":"https://example.auth0.com/","subPrefix":"samlp|org_1","rules":[{"claim":"groups","value":"gis-editors","role":"writer"}]}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var set rolemappinghandler.RoleMappingResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &set))
+	assert.Equal(t, env.ws.ID().String(), set.WorkspaceID)
+	assert.Equal(t, "samlp|org_1", set.SubPrefix)
+	assert.Equal(t, []rolemappinghandler.RoleMappingRuleBody{{Claim: "groups", Value: "gis-editors", Role: "writer"}}, set.Rules)
+
+	rec = env.do(t, http.MethodGet, path, "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var got rolemappinghandler.RoleMappingResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &got))
+	assert.Equal(t, set.ID, got.ID)
+	assert.Equal(t, set.Rules, got.Rules)
+
+	rec = env.do(t, http.MethodDelete, path, "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = env.do(t, http.MethodDelete, path, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestSetRoleMapping_Invalid(t *testing.T) {
+	env := newTestEnv(t)
+	path := "/api/v1/workspaces/" + env.ws.ID().String() + "/role-mapping"
+	for _, body := range []string{
+		`{"issuer":""}`,
+		`{"issuer":"https://idp.example.com/","subPrefix":"samlp|"}`,
+		`{"issuer":"https://idp.example.com/","rules":[{"claim":"groups","value":"","role":"reader"}]}`,
+		`{"issuer":"https://idp.example.com/","rules":[{"claim":"groups","value":"x","role":"admin"}]}`,
+		`{`,
+	} {
+		rec := env.do(t, http.MethodPut, path, body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+	rec := env.do(t, http.MethodPut, "/api/v1/workspaces/invalid/role-mapping", `{"issuer":"https://idp.example.com/"}`)
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	rec = env.do(t, http.MethodPut, "/api/v1/workspaces/"+workspace.NewID().String()+"/role-mapping", `{"issuer":"https://idp.example.com/"}`)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
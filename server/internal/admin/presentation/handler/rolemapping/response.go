@@ -0,0 +1,32 @@
+package rolemapping
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+)
+
+// RoleMappingResponse is the role mapping of a workspace in the admin API.
+type RoleMappingResponse struct {
+	ID          string                `json:"id"`
+	WorkspaceID string                `json:"workspaceId"`
+	Issuer      string                `json:"issuer"`
+	SubPrefix   string                `json:"subPrefix"`
+	Rules       []RoleMappingRuleBody `json:"rules"`
+	UpdatedAt   time.Time             `json:"updatedAt"`
+} // @name RoleMapping
+
+func newRoleMappingResponse(m *rolemapping.RoleMapping) RoleMappingResponse {
+	rules := make([]RoleMappingRuleBody, 0, len(m.Rules()))
+	for _, r := range m.Rules() {
+		rules = append(rules, RoleMappingRuleBody{Claim: r.Claim, Value: r.Value, Role: r.Role.String()})
+	}
+	return RoleMappingResponse{
+		ID:          m.ID().String(),
+		WorkspaceID: m.Workspace().String(),
+		Issuer:      m.Issuer(),
+		SubPrefix:   m.SubPrefix(),
+		Rules:       rules,
+		UpdatedAt:   m.UpdatedAt(),
+	}
+}
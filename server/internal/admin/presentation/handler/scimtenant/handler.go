@@ -0,0 +1,25 @@
+// Package scimtenant implements the endpoints managing the SCIM tenants,
+// behind the RequireApproved middleware.
+package scimtenant
+
+import (
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/scimtenantuc"
+)
+
+// Handler serves the /scim-tenants endpoints.
+type Handler struct {
+	list   *scimtenantuc.ListSCIMTenantsUseCase
+	create *scimtenantuc.CreateSCIMTenantUseCase
+	rotate *scimtenantuc.RotateSCIMTenantTokenUseCase
+	delete *scimtenantuc.DeleteSCIMTenantUseCase
+}
+
+// NewHandler is a Wire provider for the SCIM tenant Handler.
+func NewHandler(
+	list *scimtenantuc.ListSCIMTenantsUseCase,
+	create *scimtenantuc.CreateSCIMTenantUseCase,
+	rotate *scimtenantuc.RotateSCIMTenantTokenUseCase,
+	delete *scimtenantuc.DeleteSCIMTenantUseCase,
+) *Handler {
+	return &Handler{list: list, create: create, rotate: rotate, delete: delete}
+}
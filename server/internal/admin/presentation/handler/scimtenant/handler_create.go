@@ -0,0 +1,55 @@
+package scimtenant
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/scimtenantuc"
+)
+
+// CreateSCIMTenantRequest is the request body for creating a SCIM tenant.
+type CreateSCIMTenantRequest struct {
+	Name string `json:"name" example:"Okta"`
+	// SubPrefix is the auth sub prefix the identity provider signs users in
+	// with, so that provisioned users match their SSO logins. Defaults to a
+	// prefix unique to the tenant. Prefixes of other providers and tenants are
+	// refused.
+	SubPrefix string `json:"subPrefix" example:"samlp|okta"`
+} // @name CreateSCIMTenantRequest
+
+// CreateSCIMTenant godoc
+//
+//	@Summary		Create a SCIM tenant
+//	@Description	Registers an identity provider tenant and issues its SCIM bearer token. The token is returned only in this response.
+//	@Tags			scim-tenants
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		CreateSCIMTenantRequest	true	"Tenant"
+//	@Success		201		{object}	SCIMTenantTokenResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid name / sub prefix / reserved sub prefix"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		409		{object}	internal.ErrorResponse	"sub prefix overlaps with another tenant"
+//	@Router			/scim-tenants [post]
+func (h *Handler) CreateSCIMTenant(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+
+	var body CreateSCIMTenantRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	t, token, err := h.create.Execute(c.Request().Context(), scimtenantuc.CreateInput{
+		Operator:  operator.ID(),
+		Name:      body.Name,
+		SubPrefix: body.SubPrefix,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusCreated, SCIMTenantTokenResponse{Tenant: newSCIMTenantResponse(t), Token: token})
+}
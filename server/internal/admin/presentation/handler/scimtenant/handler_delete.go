@@ -0,0 +1,38 @@
+package scimtenant
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/scimtenantuc"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+)
+
+// DeleteSCIMTenant godoc
+//
+//	@Summary		Delete a SCIM tenant
+//	@Description	Revokes the tenant's SCIM access. The users and workspaces it provisioned are kept.
+//	@Tags			scim-tenants
+//	@Param			id	path	string	true	"SCIM tenant ID"
+//	@Success		204
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Router			/scim-tenants/{id} [delete]
+func (h *Handler) DeleteSCIMTenant(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+	id, err := scimtenant.IDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	if err := h.delete.Execute(c.Request().Context(), scimtenantuc.DeleteInput{Operator: operator.ID(), ID: id}); err != nil {
+		return err
+	}
+	return c.NoContent(http.StatusNoContent)
+}
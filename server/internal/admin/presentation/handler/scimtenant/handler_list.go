@@ -0,0 +1,25 @@
+package scimtenant
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ListSCIMTenants godoc
+//
+//	@Summary		List SCIM tenants
+//	@Description	Lists the identity provider tenants allowed to provision users and workspaces through the SCIM API at /scim/v2.
+//	@Tags			scim-tenants
+//	@Produce		json
+//	@Success		200	{object}	ListSCIMTenantsResponse
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Router			/scim-tenants [get]
+func (h *Handler) ListSCIMTenants(c echo.Context) error {
+	list, err := h.list.Execute(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newListSCIMTenantsResponse(list))
+}
@@ -0,0 +1,40 @@
+package scimtenant
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/scimtenantuc"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+)
+
+// RotateSCIMTenantToken godoc
+//
+//	@Summary		Rotate the token of a SCIM tenant
+//	@Description	Issues a new SCIM bearer token for the tenant. The previous token stops working immediately, so the identity provider must be updated with the returned one.
+//	@Tags			scim-tenants
+//	@Produce		json
+//	@Param			id	path		string	true	"SCIM tenant ID"
+//	@Success		200	{object}	SCIMTenantTokenResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Router			/scim-tenants/{id}/rotate-token [post]
+func (h *Handler) RotateSCIMTenantToken(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+	id, err := scimtenant.IDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	t, token, err := h.rotate.Execute(c.Request().Context(), scimtenantuc.RotateInput{Operator: operator.ID(), ID: id})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, SCIMTenantTokenResponse{Tenant: newSCIMTenantResponse(t), Token: token})
+}
@@ -0,0 +1,116 @@
+package scimtenant_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	adminpresentation "github.com/reearth/reearth-accounts/server/internal/admin/presentation"
+	scimtenanthandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/scimtenant"
+	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/scimtenantuc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-test-secret-test-secret"
+
+type testEnv struct {
+	e        *echo.Echo
+	sess     *session.Manager
+	sessions adminsession.Repo
+	tenant   scimtenant.Repo
+	op       *adminuser.AdminUser
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	op := adminuser.New().NewID().Name("op@eukarya.io").Email("op@eukarya.io").Status(adminuser.StatusApproved).MustBuild()
+	repo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	sessions := memory.NewAdminSession()
+	tenants := memory.NewSCIMTenant()
+
+	h := scimtenanthandler.NewHandler(
+		scimtenantuc.NewListSCIMTenantsUseCase(tenants),
+		scimtenantuc.NewCreateSCIMTenantUseCase(tenants, nil),
+		scimtenantuc.NewRotateSCIMTenantTokenUseCase(tenants),
+		scimtenantuc.NewDeleteSCIMTenantUseCase(tenants),
+	)
+	e := echo.New()
+	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
+	g := e.Group("/api/v1/scim-tenants", echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, repo)))
+	g.GET("", h.ListSCIMTenants)
+	g.POST("", h.CreateSCIMTenant)
+	g.POST("/:id/rotate-token", h.RotateSCIMTenantToken)
+	g.DELETE("/:id", h.DeleteSCIMTenant)
+	return &testEnv{e: e, sess: sess, sessions: sessions, tenant: tenants, op: op}
+}
+
+func (env *testEnv) do(t *testing.T, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(env.op.ID()).CreatedAt(now).ExpiresAt(now.Add(env.sess.TTL())).MustBuild()
+	require.NoError(t, env.sessions.Save(context.Background(), s))
+	tok, err := env.sess.Issue(env.op.ID(), s.ID(), now)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: session.CookieName, Value: tok})
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestSCIMTenant_Lifecycle(t *testing.T) {
+	env := newTestEnv(t)
+
+	rec := env.do(t, http.MethodPost, "/api/v1/scim-tenants", `{"name":"Okta","subPrefix":"samlp|okta"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var created scimtenanthandler.SCIMTenantTokenResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &created))
+	assert.Equal(t, "Okta", created.Tenant.Name)
+	assert.Equal(t, "samlp|okta", created.Tenant.SubPrefix)
+	require.NotEmpty(t, created.Token)
+
+	rec = env.do(t, http.MethodGet, "/api/v1/scim-tenants", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.NotContains(t, rec.Body.String(), created.Token)
+	var list scimtenanthandler.ListSCIMTenantsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &list))
+	assert.Equal(t, []scimtenanthandler.SCIMTenantResponse{created.Tenant}, list.Items)
+
+	rec = env.do(t, http.MethodPost, "/api/v1/scim-tenants/"+created.Tenant.ID+"/rotate-token", "")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var rotated scimtenanthandler.SCIMTenantTokenResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &rotated))
+	assert.NotEqual(t, created.Token, rotated.Token)
+	tn, err := env.tenant.FindByTokenHash(t.Context(), scimtenant.HashToken(rotated.Token))
+	require.NoError(t, err)
+	assert.Equal(t, created.Tenant.ID, tn.ID().String())
+
+	rec = env.do(t, http.MethodDelete, "/api/v1/scim-tenants/"+created.Tenant.ID, "")
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+	rec = env.do(t, http.MethodDelete, "/api/v1/scim-tenants/"+created.Tenant.ID, "")
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestCreateSCIMTenant_Invalid(t *testing.T) {
+	env := newTestEnv(t)
+	for _, body := range []string{`{"name":""}`, `{"name":"Okta","subPrefix":"samlp|"}`, `{`} {
+		rec := env.do(t, http.MethodPost, "/api/v1/scim-tenants", body)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, body)
+	}
+	rec := env.do(t, http.MethodPost, "/api/v1/scim-tenants/invalid/rotate-token", "")
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+}
@@ -0,0 +1,51 @@
+package scimtenant
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+)
+
+// SCIMTenantResponse is a SCIM tenant in the admin API. The bearer token is
+// never returned after it is issued.
+type SCIMTenantResponse struct {
+	ID         string    `json:"id"`
+	Name       string    `json:"name"`
+	SubPrefix  string    `json:"subPrefix"`
+	UserCount  int       `json:"userCount"`
+	GroupCount int       `json:"groupCount"`
+	CreatedAt  time.Time `json:"createdAt"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+} // @name SCIMTenant
+
+// SCIMTenantTokenResponse is a SCIM tenant with its newly issued bearer token.
+type SCIMTenantTokenResponse struct {
+	Tenant SCIMTenantResponse `json:"tenant"`
+	// Token is shown only once; it is stored hashed.
+	Token string `json:"token"`
+} // @name SCIMTenantToken
+
+// ListSCIMTenantsResponse is the list of SCIM tenants.
+type ListSCIMTenantsResponse struct {
+	Items []SCIMTenantResponse `json:"items"`
+} // @name ListSCIMTenantsResponse
+
+func newSCIMTenantResponse(t *scimtenant.Tenant) SCIMTenantResponse {
+	return SCIMTenantResponse{
+		ID:         t.ID().String(),
+		Name:       t.Name(),
+		SubPrefix:  t.SubPrefix(),
+		UserCount:  len(t.Users()),
+		GroupCount: len(t.Groups()),
+		CreatedAt:  t.CreatedAt(),
+		UpdatedAt:  t.UpdatedAt(),
+	}
+}
+
+func newListSCIMTenantsResponse(list scimtenant.List) ListSCIMTenantsResponse {
+	items := make([]SCIMTenantResponse, 0, len(list))
+	for _, t := range list {
+		items = append(items, newSCIMTenantResponse(t))
+	}
+	return ListSCIMTenantsResponse{Items: items}
+}
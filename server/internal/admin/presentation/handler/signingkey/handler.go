@@ -0,0 +1,18 @@
+// Package signingkey implements the endpoints managing the token signing key
+// versions, behind the RequireApproved middleware.
+package signingkey
+
+import (
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/signingkeyuc"
+)
+
+// Handler serves the /signing-keys endpoints.
+type Handler struct {
+	list   *signingkeyuc.ListSigningKeysUseCase
+	rotate *signingkeyuc.RotateSigningKeyUseCase
+}
+
+// NewHandler is a Wire provider for the signing key Handler.
+func NewHandler(list *signingkeyuc.ListSigningKeysUseCase, rotate *signingkeyuc.RotateSigningKeyUseCase) *Handler {
+	return &Handler{list: list, rotate: rotate}
+}
@@ -0,0 +1,26 @@
+package signingkey
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearthx/util"
+)
+
+// ListSigningKeys godoc
+//
+//	@Summary		List signing keys
+//	@Description	Lists the versions of the token signing key with their activation and retirement times. Every version that is not retired is published in the JWKS.
+//	@Tags			signing-keys
+//	@Produce		json
+//	@Success		200	{object}	ListSigningKeysResponse
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Router			/signing-keys [get]
+func (h *Handler) ListSigningKeys(c echo.Context) error {
+	keys, err := h.list.Execute(c.Request().Context())
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newListSigningKeysResponse(keys, util.Now()))
+}
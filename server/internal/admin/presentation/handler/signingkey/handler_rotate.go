@@ -0,0 +1,62 @@
+package signingkey
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/signingkeyuc"
+	"github.com/reearth/reearthx/util"
+)
+
+const defaultOverlap = 24 * time.Hour
+
+// RotateSigningKeyRequest is the request body for rotating the signing key.
+type RotateSigningKeyRequest struct {
+	// Overlap is a Go duration such as "24h". Defaults to 24h; "0s" switches
+	// and retires the keys at once.
+	Overlap string `json:"overlap" example:"24h"`
+} // @name RotateSigningKeyRequest
+
+// RotateSigningKey godoc
+//
+//	@Summary		Rotate the signing key
+//	@Description	Generates a new signing key version. It is published immediately and starts signing after the overlap; the current versions are retired one overlap after that. The overlap must exceed the JWKS cache lifetime of verifiers and the lifetime of issued tokens. A zero overlap replaces the key at once, invalidating every token it signed.
+//	@Tags			signing-keys
+//	@Accept			json
+//	@Produce		json
+//	@Param			body	body		RotateSigningKeyRequest	false	"Rotation options"
+//	@Success		200		{object}	ListSigningKeysResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid overlap"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		409		{object}	internal.ErrorResponse	"a rotation is already in progress"
+//	@Router			/signing-keys/rotate [post]
+func (h *Handler) RotateSigningKey(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+
+	var body RotateSigningKeyRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	overlap := defaultOverlap
+	if body.Overlap != "" {
+		overlap, err = time.ParseDuration(body.Overlap)
+		if err != nil || overlap < 0 {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid overlap")
+		}
+	}
+
+	keys, err := h.rotate.Execute(c.Request().Context(), signingkeyuc.RotateInput{
+		Operator: operator.ID(),
+		Overlap:  overlap,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newListSigningKeysResponse(keys, util.Now()))
+}
@@ -0,0 +1,122 @@
+package signingkey_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	adminpresentation "github.com/reearth/reearth-accounts/server/internal/admin/presentation"
+	signingkeyhandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/signingkey"
+	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/signingkeyuc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/config"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-test-secret-test-secret"
+
+type testEnv struct {
+	e        *echo.Echo
+	sess     *session.Manager
+	sessions adminsession.Repo
+	config   config.Repo
+	op       *adminuser.AdminUser
+}
+
+func newTestEnv(t *testing.T) *testEnv {
+	t.Helper()
+	op := adminuser.New().NewID().Name("op@eukarya.io").Email("op@eukarya.io").Status(adminuser.StatusApproved).MustBuild()
+	repo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	sessions := memory.NewAdminSession()
+	cfg := memory.NewConfig()
+
+	h := signingkeyhandler.NewHandler(
+		signingkeyuc.NewListSigningKeysUseCase(cfg),
+		signingkeyuc.NewRotateSigningKeyUseCase(cfg),
+	)
+	e := echo.New()
+	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
+	g := e.Group("/api/v1/signing-keys", echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, repo)))
+	g.GET("", h.ListSigningKeys)
+	g.POST("/rotate", h.RotateSigningKey)
+	return &testEnv{e: e, sess: sess, sessions: sessions, config: cfg, op: op}
+}
+
+func (env *testEnv) do(t *testing.T, method, path, body string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(env.op.ID()).CreatedAt(now).ExpiresAt(now.Add(env.sess.TTL())).MustBuild()
+	require.NoError(t, env.sessions.Save(context.Background(), s))
+	tok, err := env.sess.Issue(env.op.ID(), s.ID(), now)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: session.CookieName, Value: tok})
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func decode(t *testing.T, rec *httptest.ResponseRecorder) signingkeyhandler.ListSigningKeysResponse {
+	t.Helper()
+	var body signingkeyhandler.ListSigningKeysResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	return body
+}
+
+func TestRotateSigningKey_OK(t *testing.T) {
+	env := newTestEnv(t)
+	current, err := config.GenerateAuth(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, env.config.SaveAuth(t.Context(), current))
+
+	rec := env.do(t, http.MethodPost, "/api/v1/signing-keys/rotate", `{"overlap":"1h"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	body := decode(t, rec)
+	require.Len(t, body.Items, 2)
+	assert.Equal(t, current.KID, body.Items[0].KID)
+	assert.Equal(t, "active", body.Items[0].Status)
+	assert.NotNil(t, body.Items[0].RetiredAt)
+	assert.Equal(t, "pending", body.Items[1].Status)
+	assert.NotContains(t, rec.Body.String(), "PRIVATE KEY")
+
+	rec = env.do(t, http.MethodGet, "/api/v1/signing-keys", "")
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, body, decode(t, rec))
+
+	rec = env.do(t, http.MethodPost, "/api/v1/signing-keys/rotate", `{}`)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+}
+
+func TestRotateSigningKey_ZeroOverlap(t *testing.T) {
+	env := newTestEnv(t)
+	current, err := config.GenerateAuth(time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, env.config.SaveAuth(t.Context(), current))
+
+	rec := env.do(t, http.MethodPost, "/api/v1/signing-keys/rotate", `{"overlap":"0s"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	body := decode(t, rec)
+	require.Len(t, body.Items, 2)
+	assert.Equal(t, "retired", body.Items[0].Status)
+	assert.Equal(t, "active", body.Items[1].Status)
+}
+
+func TestRotateSigningKey_InvalidOverlap(t *testing.T) {
+	env := newTestEnv(t)
+	for _, overlap := range []string{"soon", "-1h"} {
+		rec := env.do(t, http.MethodPost, "/api/v1/signing-keys/rotate", `{"overlap":"`+overlap+`"}`)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, overlap)
+	}
+}
@@ -0,0 +1,57 @@
+package signingkey
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/config"
+)
+
+const (
+	statusPending  = "pending"
+	statusActive   = "active"
+	statusRetiring = "retiring"
+	statusRetired  = "retired"
+)
+
+// SigningKeyResponse is a signing key version in the admin API. Key material
+// is never returned.
+type SigningKeyResponse struct {
+	KID         string     `json:"kid"`
+	Status      string     `json:"status" enums:"pending,active,retiring,retired"`
+	ActivatedAt *time.Time `json:"activatedAt,omitempty"`
+	RetiredAt   *time.Time `json:"retiredAt,omitempty"`
+} // @name SigningKey
+
+// ListSigningKeysResponse is the list of signing key versions.
+type ListSigningKeysResponse struct {
+	Items []SigningKeyResponse `json:"items"`
+} // @name ListSigningKeysResponse
+
+func newListSigningKeysResponse(keys []config.Auth, now time.Time) ListSigningKeysResponse {
+	cfg := config.Config{Keys: keys}
+	var signing string
+	if k := cfg.SigningAuthKey(now); k != nil {
+		signing = k.KID
+	}
+
+	items := make([]SigningKeyResponse, 0, len(keys))
+	for _, k := range keys {
+		res := SigningKeyResponse{KID: k.KID, RetiredAt: k.RetiredAt}
+		if !k.ActivatedAt.IsZero() {
+			at := k.ActivatedAt
+			res.ActivatedAt = &at
+		}
+		switch {
+		case k.IsRetired(now):
+			res.Status = statusRetired
+		case k.IsPending(now):
+			res.Status = statusPending
+		case k.KID == signing:
+			res.Status = statusActive
+		default:
+			res.Status = statusRetiring
+		}
+		items = append(items, res)
+	}
+	return ListSigningKeysResponse{Items: items}
+}
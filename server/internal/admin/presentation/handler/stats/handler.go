@@ -0,0 +1,17 @@
+// Package stats implements the admin dashboard overview endpoint, behind the
+// RequireApproved middleware.
+package stats
+
+import (
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/statsuc"
+)
+
+// Handler serves the /stats endpoint.
+type Handler struct {
+	get *statsuc.GetStatsUseCase
+}
+
+// NewHandler is a Wire provider for the stats Handler.
+func NewHandler(get *statsuc.GetStatsUseCase) *Handler {
+	return &Handler{get: get}
+}
@@ -0,0 +1,34 @@
+package stats
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/pkg/adminstats"
+)
+
+// GetStats godoc
+//
+//	@Summary		Get the dashboard statistics
+//	@Description	Returns the counts of users and workspaces, the signups and deactivations of each UTC day of the window up to today, the workspace memberships by role and the users by auth provider. Users merged into another are not counted.
+//	@Tags			stats
+//	@Produce		json
+//	@Param			days	query		int	false	"Number of days of daily figures (default 30, max 365)"
+//	@Success		200		{object}	StatsResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid days"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Router			/stats [get]
+func (h *Handler) GetStats(c echo.Context) error {
+	days, err := internal.ParsePageParam(c.QueryParam("days"))
+	if err != nil || days > adminstats.MaxDays {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid days")
+	}
+
+	s, err := h.get.Execute(c.Request().Context(), int(days))
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newStatsResponse(s))
+}
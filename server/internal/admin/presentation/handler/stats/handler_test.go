@@ -0,0 +1,96 @@
+package stats_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	adminpresentation "github.com/reearth/reearth-accounts/server/internal/admin/presentation"
+	statshandler "github.com/reearth/reearth-accounts/server/internal/admin/presentation/handler/stats"
+	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/statsuc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-test-secret-test-secret"
+
+type testEnv struct {
+	e        *echo.Echo
+	sess     *session.Manager
+	sessions adminsession.Repo
+	op       *adminuser.AdminUser
+}
+
+func newTestEnv(t *testing.T, users *memory.User, workspaces *memory.Workspace) *testEnv {
+	t.Helper()
+	op := adminuser.New().NewID().Name("op@eukarya.io").Email("op@eukarya.io").Status(adminuser.StatusApproved).MustBuild()
+	repo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	sessions := memory.NewAdminSession()
+
+	h := statshandler.NewHandler(statsuc.NewGetStatsUseCase(memory.NewAdminStats(users, workspaces)))
+	e := echo.New()
+	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
+	g := e.Group("/api/v1/stats", echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, repo)))
+	g.GET("", h.GetStats)
+	return &testEnv{e: e, sess: sess, sessions: sessions, op: op}
+}
+
+func (env *testEnv) get(t *testing.T, path string) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(env.op.ID()).CreatedAt(now).ExpiresAt(now.Add(env.sess.TTL())).MustBuild()
+	require.NoError(t, env.sessions.Save(context.Background(), s))
+	tok, err := env.sess.Issue(env.op.ID(), s.ID(), now)
+	require.NoError(t, err)
+	req.AddCookie(&http.Cookie{Name: session.CookieName, Value: tok})
+	rec := httptest.NewRecorder()
+	env.e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestGetStats(t *testing.T) {
+	now := time.Now()
+	alice := user.New().NewID().Name("alice").Email("alice@example.com").Workspace(id.NewWorkspaceID()).
+		CreatedAt(&now).Auths([]user.Auth{user.NewAuth("auth0", "alice")}).MustBuild()
+	team := workspace.New().NewID().Name("team").
+		Members(map[id.UserID]workspace.Member{alice.ID(): {Role: role.RoleOwner, InvitedBy: alice.ID()}}).MustBuild()
+	env := newTestEnv(t, memory.NewUserWith(alice), memory.NewWorkspaceWith(team))
+
+	rec := env.get(t, "/api/v1/stats?days=7")
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var res statshandler.StatsResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Equal(t, statshandler.UserStatsResponse{Active: 1, Unverified: 1}, res.Users)
+	assert.Equal(t, statshandler.WorkspaceStatsResponse{Team: 1}, res.Workspaces)
+	assert.Equal(t, map[string]int64{"owner": 1}, res.MembersByRole)
+	assert.Equal(t, map[string]int64{"auth0": 1}, res.UsersByAuthProvider)
+	require.Len(t, res.Daily, 7)
+	assert.Equal(t, statshandler.DailyStatsResponse{Date: now.UTC().Format("2006-01-02"), Signups: 1}, res.Daily[6])
+
+	rec = env.get(t, "/api/v1/stats")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &res))
+	assert.Len(t, res.Daily, 30)
+}
+
+func TestGetStats_Invalid(t *testing.T) {
+	env := newTestEnv(t, memory.NewUser(), memory.NewWorkspace())
+	for _, q := range []string{"days=0", "days=366", "days=x"} {
+		assert.Equal(t, http.StatusBadRequest, env.get(t, "/api/v1/stats?"+q).Code, q)
+	}
+}
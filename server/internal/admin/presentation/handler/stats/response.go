@@ -0,0 +1,73 @@
+package stats
+
+import (
+	"github.com/reearth/reearth-accounts/server/pkg/adminstats"
+)
+
+// StatsResponse is the admin dashboard overview.
+type StatsResponse struct {
+	Users      UserStatsResponse      `json:"users"`
+	Workspaces WorkspaceStatsResponse `json:"workspaces"`
+	// Daily has one entry per day of the window, oldest first.
+	Daily []DailyStatsResponse `json:"daily"`
+	// MembersByRole counts the members of the active team workspaces by role.
+	MembersByRole map[string]int64 `json:"membersByRole"`
+	// UsersByAuthProvider counts the active users that can sign in with each
+	// provider; a user with several providers counts for each.
+	UsersByAuthProvider map[string]int64 `json:"usersByAuthProvider"`
+} // @name AdminStats
+
+// UserStatsResponse counts the users.
+type UserStatsResponse struct {
+	Active      int64 `json:"active"`
+	Deactivated int64 `json:"deactivated"`
+	// Unverified counts the active users whose email is not verified.
+	Unverified int64 `json:"unverified"`
+} // @name AdminUserStats
+
+// WorkspaceStatsResponse counts the workspaces; personal and team count the
+// active ones.
+type WorkspaceStatsResponse struct {
+	Personal    int64 `json:"personal"`
+	Team        int64 `json:"team"`
+	Deactivated int64 `json:"deactivated"`
+} // @name AdminWorkspaceStats
+
+// DailyStatsResponse holds the figures of one UTC day.
+type DailyStatsResponse struct {
+	Date          string `json:"date" example:"2026-10-19"`
+	Signups       int64  `json:"signups"`
+	Deactivations int64  `json:"deactivations"`
+} // @name AdminDailyStats
+
+func newStatsResponse(s *adminstats.Stats) StatsResponse {
+	res := StatsResponse{
+		Users: UserStatsResponse{
+			Active:      s.Users.Active,
+			Deactivated: s.Users.Deactivated,
+			Unverified:  s.Users.Unverified,
+		},
+		Workspaces: WorkspaceStatsResponse{
+			Personal:    s.Workspaces.Personal,
+			Team:        s.Workspaces.Team,
+			Deactivated: s.Workspaces.Deactivated,
+		},
+		Daily:               make([]DailyStatsResponse, 0, len(s.Days)),
+		MembersByRole:       make(map[string]int64, len(s.MembersByRole)),
+		UsersByAuthProvider: make(map[string]int64, len(s.UsersByAuthProvider)),
+	}
+	for _, d := range s.Days {
+		res.Daily = append(res.Daily, DailyStatsResponse{
+			Date:          d.Date.Format("2006-01-02"),
+			Signups:       d.Signups,
+			Deactivations: d.Deactivations,
+		})
+	}
+	for r, n := range s.MembersByRole {
+		res.MembersByRole[r.String()] = n
+	}
+	for p, n := range s.UsersByAuthProvider {
+		res.UsersByAuthProvider[p] = n
+	}
+	return res
+}
@@ -11,9 +11,56 @@ type Handler struct {
 	getUC           *useruc.GetUserUseCase
 	getWorkspacesUC *useruc.GetUserWorkspacesUseCase
 	listUC          *useruc.ListUsersUseCase
+	mergeUC         *useruc.MergeUsersUseCase
+	importUC        *useruc.ImportUsersUseCase
+	exportUC        *useruc.ExportUsersUseCase
+	exportListingUC *useruc.ExportUserListingUseCase
+	exportDataUC    *useruc.ExportUserDataUseCase
+	deactivateUC    *useruc.DeactivateUserUseCase
+	restoreUC       *useruc.RestoreUserUseCase
+	updateUC        *useruc.UpdateUserUseCase
+	verifyEmailUC   *useruc.VerifyUserEmailUseCase
+	resetPasswordUC *useruc.ResetUserPasswordUseCase
+	removeAuthUC    *useruc.RemoveUserAuthUseCase
+	resetMFAUC      *useruc.ResetUserMFAUseCase
+	impersonateUC   *useruc.ImpersonateUserUseCase
 }
 
 // NewHandler is a Wire provider for the user Handler.
-func NewHandler(getUC *useruc.GetUserUseCase, getWorkspacesUC *useruc.GetUserWorkspacesUseCase, listUC *useruc.ListUsersUseCase) *Handler {
-	return &Handler{getUC: getUC, getWorkspacesUC: getWorkspacesUC, listUC: listUC}
+func NewHandler(
+	getUC *useruc.GetUserUseCase,
+	getWorkspacesUC *useruc.GetUserWorkspacesUseCase,
+	listUC *useruc.ListUsersUseCase,
+	mergeUC *useruc.MergeUsersUseCase,
+	importUC *useruc.ImportUsersUseCase,
+	exportUC *useruc.ExportUsersUseCase,
+	exportListingUC *useruc.ExportUserListingUseCase,
+	exportDataUC *useruc.ExportUserDataUseCase,
+	deactivateUC *useruc.DeactivateUserUseCase,
+	restoreUC *useruc.RestoreUserUseCase,
+	updateUC *useruc.UpdateUserUseCase,
+	verifyEmailUC *useruc.VerifyUserEmailUseCase,
+	resetPasswordUC *useruc.ResetUserPasswordUseCase,
+	removeAuthUC *useruc.RemoveUserAuthUseCase,
+	resetMFAUC *useruc.ResetUserMFAUseCase,
+	impersonateUC *useruc.ImpersonateUserUseCase,
+) *Handler {
+	return &Handler{
+		getUC:           getUC,
+		getWorkspacesUC: getWorkspacesUC,
+		listUC:          listUC,
+		mergeUC:         mergeUC,
+		importUC:        importUC,
+		exportUC:        exportUC,
+		exportListingUC: exportListingUC,
+		exportDataUC:    exportDataUC,
+		deactivateUC:    deactivateUC,
+		restoreUC:       restoreUC,
+		updateUC:        updateUC,
+		verifyEmailUC:   verifyEmailUC,
+		resetPasswordUC: resetPasswordUC,
+		removeAuthUC:    removeAuthUC,
+		resetMFAUC:      resetMFAUC,
+		impersonateUC:   impersonateUC,
+	}
 }
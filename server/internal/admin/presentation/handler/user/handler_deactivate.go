@@ -0,0 +1,75 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+// DeactivateUser godoc
+//
+//	@Summary		Deactivate a user
+//	@Description	Soft-deletes the user, who can no longer sign in. The user is purged once the retention window has passed unless restored first. The deactivation is recorded in the audit log.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	UserActionResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Failure		409	{object}	internal.ErrorResponse	"user is deactivated"
+//	@Router			/users/{id}/deactivate [post]
+func (h *Handler) DeactivateUser(c echo.Context) error {
+	in, err := userActionInput(c)
+	if err != nil {
+		return err
+	}
+	out, err := h.deactivateUC.Execute(c.Request().Context(), in)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserActionResponse(out))
+}
+
+// RestoreUser godoc
+//
+//	@Summary		Restore a deactivated user
+//	@Description	Reactivates a deactivated user, including one who asked for the deletion of their account. Users merged into another can't be restored. The restoration is recorded in the audit log.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	UserActionResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id / merged user"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Failure		409	{object}	internal.ErrorResponse	"user is not deactivated"
+//	@Router			/users/{id}/restore [post]
+func (h *Handler) RestoreUser(c echo.Context) error {
+	in, err := userActionInput(c)
+	if err != nil {
+		return err
+	}
+	out, err := h.restoreUC.Execute(c.Request().Context(), in)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserActionResponse(out))
+}
+
+// userActionInput reads the operator and the user of the :id path parameter.
+func userActionInput(c echo.Context) (useruc.UserActionInput, error) {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return useruc.UserActionInput{}, err
+	}
+	uid, err := id.UserIDFrom(c.Param("id"))
+	if err != nil {
+		return useruc.UserActionInput{}, echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+	return useruc.UserActionInput{Operator: operator.ID(), User: uid}, nil
+}
@@ -0,0 +1,60 @@
+package user
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+	"github.com/reearth/reearthx/util"
+)
+
+// ExportUsers godoc
+//
+//	@Summary		Export users to a CSV or JSON file
+//	@Description	Downloads the active users, or the members of a team workspace, in the file format of the import: email, name, alias, lang, theme, the subs of their identity providers and their team workspace memberships. Passwords are not exported. The export is recorded in the audit log.
+//	@Tags			users
+//	@Produce		json,text/csv
+//	@Param			format		query		string	false	"csv (default) or json"
+//	@Param			workspace	query		string	false	"Alias of a workspace whose members to export"
+//	@Success		200			{array}		useruc.Record
+//	@Failure		400			{object}	internal.ErrorResponse	"invalid format"
+//	@Failure		401			{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403			{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404			{object}	internal.ErrorResponse	"workspace not found"
+//	@Router			/users/export [get]
+func (h *Handler) ExportUsers(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+
+	f := useruc.FormatCSV
+	if v := c.QueryParam("format"); v != "" {
+		if f, err = useruc.FormatFrom(v); err != nil {
+			return err
+		}
+	}
+
+	records, err := h.exportUC.Execute(c.Request().Context(), useruc.ExportInput{
+		Operator:  operator.ID(),
+		Workspace: c.QueryParam("workspace"),
+	})
+	if err != nil {
+		return err
+	}
+
+	var buf bytes.Buffer
+	if err := useruc.EncodeRecords(&buf, f, records); err != nil {
+		return err
+	}
+	contentType := "text/csv; charset=utf-8"
+	if f == useruc.FormatJSON {
+		contentType = echo.MIMEApplicationJSONCharsetUTF8
+	}
+	name := fmt.Sprintf("users-%s.%s", util.Now().Format("20060102"), f)
+	c.Response().Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name))
+	return c.Blob(http.StatusOK, contentType, buf.Bytes())
+}
@@ -0,0 +1,43 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+// ExportUserData godoc
+//
+//	@Summary		Export the personal data of a user
+//	@Description	Answers a data subject access request: archives the profile, auths, metadata, workspace memberships, roles, sign-in traces and audit log entries of the user as a ZIP file of JSON documents, uploads it to the storage and mails the user a signed link to download it, which expires in 24 hours. Secrets such as the password hash are left out. The export is recorded in the audit log.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	ExportUserDataResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Router			/users/{id}/data-export [post]
+func (h *Handler) ExportUserData(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+	uid, err := id.UserIDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	out, err := h.exportDataUC.Execute(c.Request().Context(), useruc.ExportUserDataInput{
+		Operator: operator.ID(),
+		User:     uid,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newExportUserDataResponse(uid.String(), out))
+}
@@ -0,0 +1,64 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+)
+
+// ExportUserListing godoc
+//
+//	@Summary		Export the user list to a CSV or NDJSON file
+//	@Description	Streams every user matching the filters of the user list, in its order, with the number of team workspaces each user is a member of and the user's role in each. Unlike the list it is not paginated. The export is recorded in the audit log.
+//	@Tags			users
+//	@Produce		text/csv,application/x-ndjson
+//	@Param			q		query		string	false	"Search by name, alias or email"
+//	@Param			status	query		string	false	"Filter by status (default all)"	Enums(all, active, deleted)
+//	@Param			format	query		string	false	"csv (default) or ndjson"
+//	@Success		200		{object}	UserListingRowResponse	"one per line in NDJSON"
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid query"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Router			/users/listing-export [get]
+func (h *Handler) ExportUserListing(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+
+	f, err := internal.ParseListingFormat(c.QueryParam("format"))
+	if err != nil {
+		return err
+	}
+
+	var keyword *string
+	if q := c.QueryParam("q"); q != "" {
+		keyword = &q
+	}
+
+	status := user.StatusAll
+	if s := c.QueryParam("status"); s != "" {
+		switch user.StatusFilter(s) {
+		case user.StatusAll, user.StatusActive, user.StatusDeleted:
+			status = user.StatusFilter(s)
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid status")
+		}
+	}
+
+	w := internal.NewListingWriter(c, f, "users", userListingColumns)
+	if err := h.exportListingUC.Execute(c.Request().Context(), useruc.ExportUserListingInput{
+		Operator: operator.ID(),
+		Keyword:  keyword,
+		Status:   status,
+	}, func(r useruc.ListingRow) error {
+		row := newUserListingRowResponse(r)
+		return w.Write(row, row.csvRecord())
+	}); err != nil {
+		return err
+	}
+	return w.Close()
+}
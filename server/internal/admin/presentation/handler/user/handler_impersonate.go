@@ -0,0 +1,40 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+)
+
+// ImpersonateUser godoc
+//
+//	@Summary		Impersonate a user
+//	@Description	Issues a short-lived token with which the admin sees the main service exactly as the user does. The main service accepts it in the returned request header while the admin session that issued it is signed in, allows only reads with it, and records every request made with it in the admin audit trail with both identities. The issue is recorded in the audit log.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	ImpersonateUserResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Failure		409	{object}	internal.ErrorResponse	"user is deactivated"
+//	@Failure		501	{object}	internal.ErrorResponse	"impersonation is not configured"
+//	@Router			/users/{id}/impersonate [post]
+func (h *Handler) ImpersonateUser(c echo.Context) error {
+	in, err := userActionInput(c)
+	if err != nil {
+		return err
+	}
+	sid, err := internal.GetSessionID(c)
+	if err != nil {
+		return err
+	}
+	out, err := h.impersonateUC.Execute(c.Request().Context(), useruc.ImpersonateUserInput{UserActionInput: in, Session: sid})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newImpersonateUserResponse(in.User.String(), out))
+}
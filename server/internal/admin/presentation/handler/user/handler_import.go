@@ -0,0 +1,95 @@
+package user
+
+import (
+	"errors"
+	"mime"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+)
+
+// maxImportBytes bounds the size of an import file.
+const maxImportBytes = 10 << 20
+
+// ImportUsers godoc
+//
+//	@Summary		Import users from a CSV or JSON file
+//	@Description	Validates a file of users (email, name, alias, lang, theme, subs and team workspace memberships) and reports the errors of each row. The import is a dry run unless dry_run=false; it then creates the users with their personal workspaces, the team workspaces that do not exist yet and the memberships, in batches, and records the import in the audit log. Nothing is created when any row is invalid. A CSV file has a header row; its subs are separated by ";" and its workspaces are "<alias>:<role>" pairs separated by ";".
+//	@Tags			users
+//	@Accept			json,text/csv
+//	@Produce		json
+//	@Param			format	query		string	false	"csv or json (defaults to the Content-Type)"
+//	@Param			dry_run	query		bool	false	"Only validate the file (default true)"
+//	@Param			body	body		string	true	"The file"
+//	@Success		200		{object}	ImportUsersResponse	"dry run"
+//	@Success		201		{object}	ImportUsersResponse	"users created"
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid file / format / too many users"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		413		{object}	internal.ErrorResponse	"file too large"
+//	@Failure		422		{object}	ImportUsersResponse	"invalid rows, nothing created"
+//	@Router			/users/import [post]
+func (h *Handler) ImportUsers(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+
+	f, err := importFormat(c)
+	if err != nil {
+		return err
+	}
+	dryRun := true
+	if v := c.QueryParam("dry_run"); v != "" {
+		if dryRun, err = strconv.ParseBool(v); err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid dry_run")
+		}
+	}
+
+	body := http.MaxBytesReader(c.Response(), c.Request().Body, maxImportBytes)
+	records, err := useruc.DecodeRecords(body, f)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			return echo.NewHTTPError(http.StatusRequestEntityTooLarge, "file too large")
+		}
+		return err
+	}
+
+	out, err := h.importUC.Execute(c.Request().Context(), useruc.ImportInput{
+		Operator: operator.ID(),
+		Records:  records,
+		DryRun:   dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	status := http.StatusOK
+	switch {
+	case out.Imported():
+		status = http.StatusCreated
+	case !dryRun:
+		status = http.StatusUnprocessableEntity
+	}
+	return c.JSON(status, newImportUsersResponse(out))
+}
+
+// importFormat returns the format query parameter, or the format of the
+// Content-Type when it is absent.
+func importFormat(c echo.Context) (useruc.Format, error) {
+	if v := c.QueryParam("format"); v != "" {
+		return useruc.FormatFrom(v)
+	}
+	mt, _, _ := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	switch mt {
+	case "text/csv":
+		return useruc.FormatCSV, nil
+	case echo.MIMEApplicationJSON:
+		return useruc.FormatJSON, nil
+	}
+	return "", echo.NewHTTPError(http.StatusBadRequest, "format is required")
+}
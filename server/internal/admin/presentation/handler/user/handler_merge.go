@@ -0,0 +1,62 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+// MergeUserRequest is the request body for merging a user into another.
+type MergeUserRequest struct {
+	// SourceID is the duplicate account, which is deleted by the merge.
+	SourceID string `json:"sourceId" example:"01hxyz..."`
+} // @name MergeUserRequest
+
+// MergeUser godoc
+//
+//	@Summary		Merge a duplicate user into a user
+//	@Description	Moves the auths, workspace memberships and role bindings of the source user to the user, keeping the higher role where both are members, and soft-deletes the source. The password of the source is not moved. The merge is recorded in the audit log.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"ID of the user to keep"
+//	@Param			body	body		MergeUserRequest	true	"Source user"
+//	@Success		200		{object}	MergeUserResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid id / same or deleted user"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404		{object}	internal.ErrorResponse	"not found"
+//	@Failure		409		{object}	internal.ErrorResponse	"both users have an auth of the same provider"
+//	@Router			/users/{id}/merge [post]
+func (h *Handler) MergeUser(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+	target, err := id.UserIDFrom(c.Param("id"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+
+	var body MergeUserRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	source, err := id.UserIDFrom(body.SourceID)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid source id")
+	}
+
+	out, err := h.mergeUC.Execute(c.Request().Context(), useruc.MergeInput{
+		Operator: operator.ID(),
+		Source:   source,
+		Target:   target,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newMergeUserResponse(out))
+}
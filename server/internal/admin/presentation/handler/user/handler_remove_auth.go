@@ -0,0 +1,43 @@
+package user
+
+import (
+	"net/http"
+	"net/url"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+)
+
+// RemoveUserAuth godoc
+//
+//	@Summary		Remove an auth from a user
+//	@Description	Unlinks the sign-in method with the sub from the user. The last auth of a user and Auth0 auths can't be removed. The removal is recorded in the audit log.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Param			sub	path		string	true	"URL-encoded sub of the auth, e.g. google-oauth2%7C123"
+//	@Success		200	{object}	UserActionResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id / last or Auth0 auth"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"user or auth not found"
+//	@Router			/users/{id}/auths/{sub} [delete]
+func (h *Handler) RemoveUserAuth(c echo.Context) error {
+	in, err := userActionInput(c)
+	if err != nil {
+		return err
+	}
+	sub, err := url.PathUnescape(c.Param("sub"))
+	if err != nil || sub == "" {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid sub")
+	}
+
+	out, err := h.removeAuthUC.Execute(c.Request().Context(), useruc.RemoveUserAuthInput{
+		UserActionInput: in,
+		Sub:             sub,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserActionResponse(out))
+}
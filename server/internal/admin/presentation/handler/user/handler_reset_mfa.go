@@ -0,0 +1,32 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ResetUserMFA godoc
+//
+//	@Summary		Reset the MFA of a user
+//	@Description	Removes the second factor of the user, including a pending enrollment, so that they can sign in with their password and enroll again. Second factors managed by Auth0 are reset in Auth0. The reset is recorded in the audit log.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	UserActionResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id / user has no second factor"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Router			/users/{id}/mfa [delete]
+func (h *Handler) ResetUserMFA(c echo.Context) error {
+	in, err := userActionInput(c)
+	if err != nil {
+		return err
+	}
+	out, err := h.resetMFAUC.Execute(c.Request().Context(), in)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserActionResponse(out))
+}
@@ -0,0 +1,33 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ResetUserPassword godoc
+//
+//	@Summary		Trigger a password reset for a user
+//	@Description	Mails the user a link to choose a new password, like the "forgot password" flow. The current password keeps working until the link is followed. Only active users who sign in with a password can reset it. The reset is recorded in the audit log.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	UserActionResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id / user has no password"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Failure		409	{object}	internal.ErrorResponse	"user is deactivated"
+//	@Router			/users/{id}/password-reset [post]
+func (h *Handler) ResetUserPassword(c echo.Context) error {
+	in, err := userActionInput(c)
+	if err != nil {
+		return err
+	}
+	out, err := h.resetPasswordUC.Execute(c.Request().Context(), in)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserActionResponse(out))
+}
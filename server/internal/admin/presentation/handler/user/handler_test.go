@@ -1,9 +1,11 @@
 package user_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
 	"time"
 
@@ -14,15 +16,25 @@ import (
 	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway/mock"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/impersonation"
 	"github.com/reearth/reearth-accounts/server/pkg/role"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/reearth/reearthx/usecasex"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
 )
 
-const testSecret = "test-secret-test-secret-test-secret"
+const (
+	testSecret              = "test-secret-test-secret-test-secret"
+	testImpersonationSecret = "impersonation-secret-impersonation"
+)
 
 func approvedAdmin(email string) *adminuser.AdminUser {
 	return adminuser.New().NewID().Name(email).Email(email).Status(adminuser.StatusApproved).MustBuild()
@@ -37,25 +49,66 @@ func newTestEcho(userRepo user.Repo, adminRepo adminuser.Repo, sess *session.Man
 }
 
 func newTestEchoWithWorkspaces(userRepo user.Repo, wsRepo workspace.Repo, adminRepo adminuser.Repo, sess *session.Manager) *echo.Echo {
+	return newTestEchoWithStorage(userRepo, wsRepo, adminRepo, nil, sess)
+}
+
+func newTestEchoWithStorage(userRepo user.Repo, wsRepo workspace.Repo, adminRepo adminuser.Repo, storage gateway.Storage, sess *session.Manager) *echo.Echo {
+	auditLogRepo := memory.NewAuditLog()
 	h := userhandler.NewHandler(
 		useruc.NewGetUserUseCase(userRepo),
 		useruc.NewGetUserWorkspacesUseCase(userRepo, wsRepo),
 		useruc.NewListUsersUseCase(userRepo),
+		useruc.NewMergeUsersUseCase(userRepo, wsRepo, memory.NewRole(), memory.NewPermittable(), auditLogRepo, &usecasex.NopTransaction{}),
+		useruc.NewImportUsersUseCase(userRepo, wsRepo, memory.NewRoleWith(
+			role.New().NewID().Name(role.RoleSelf.String()).MustBuild(),
+			role.New().NewID().Name(role.RoleOwner.String()).MustBuild(),
+		), memory.NewPermittable(), auditLogRepo, &usecasex.NopTransaction{}),
+		useruc.NewExportUsersUseCase(userRepo, wsRepo, auditLogRepo),
+		useruc.NewExportUserListingUseCase(userRepo, wsRepo, auditLogRepo),
+		useruc.NewExportUserDataUseCase(userRepo, wsRepo, memory.NewRole(), memory.NewPermittable(), auditLogRepo, storage, mailer.NewMock()),
+		useruc.NewDeactivateUserUseCase(userRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		useruc.NewRestoreUserUseCase(userRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		useruc.NewUpdateUserUseCase(userRepo, wsRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		useruc.NewVerifyUserEmailUseCase(userRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		useruc.NewResetUserPasswordUseCase(userRepo, auditLogRepo, &usecasex.NopTransaction{}, mailer.NewMock(), "https://reearth.example.com"),
+		useruc.NewRemoveUserAuthUseCase(userRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		useruc.NewResetUserMFAUseCase(userRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		useruc.NewImpersonateUserUseCase(userRepo, auditLogRepo, impersonation.NewSigner(testImpersonationSecret), useruc.ImpersonationTTL(15*time.Minute)),
 	)
-	requireApproved := echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, adminRepo))
+	requireApproved := echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, adminRepo))
 
 	e := echo.New()
 	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
 	g := e.Group("/api/v1/users", requireApproved)
 	g.GET("", h.ListUsers)
+	g.POST("/import", h.ImportUsers)
+	g.GET("/export", h.ExportUsers)
+	g.GET("/listing-export", h.ExportUserListing)
 	g.GET("/:id", h.GetUser)
 	g.GET("/:id/workspaces", h.GetUserWorkspaces)
+	g.POST("/:id/merge", h.MergeUser)
+	g.POST("/:id/data-export", h.ExportUserData)
+	g.PATCH("/:id", h.UpdateUser)
+	g.POST("/:id/deactivate", h.DeactivateUser)
+	g.POST("/:id/restore", h.RestoreUser)
+	g.POST("/:id/verify-email", h.VerifyUserEmail)
+	g.POST("/:id/password-reset", h.ResetUserPassword)
+	g.DELETE("/:id/auths/:sub", h.RemoveUserAuth)
+	g.DELETE("/:id/mfa", h.ResetUserMFA)
+	g.POST("/:id/impersonate", h.ImpersonateUser)
 	return e
 }
 
+// sessions backs the admin sessions of every test in the package; session IDs
+// are unique, so tests don't see each other's sessions.
+var sessions = memory.NewAdminSession()
+
 func cookieFor(t *testing.T, sess *session.Manager, id adminuser.ID) *http.Cookie {
 	t.Helper()
-	tok, err := sess.Issue(id, time.Now())
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(id).CreatedAt(now).ExpiresAt(now.Add(sess.TTL())).MustBuild()
+	require.NoError(t, sessions.Save(context.Background(), s))
+	tok, err := sess.Issue(id, s.ID(), now)
 	require.NoError(t, err)
 	return &http.Cookie{Name: session.CookieName, Value: tok}
 }
@@ -239,3 +292,468 @@ func TestGetUserWorkspaces_NotFound(t *testing.T) {
 	e.ServeHTTP(rec, req)
 	require.Equal(t, http.StatusNotFound, rec.Code)
 }
+
+func TestMergeUser_OK(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	src := user.New().NewID().Name("Alice").Email("alice@example.com").
+		Auths([]user.Auth{user.AuthFrom("google-oauth2|1")}).MustBuild()
+	dst := user.New().NewID().Name("Alice").Email("alice@example.org").
+		Auths([]user.Auth{user.AuthFrom("auth0|1")}).MustBuild()
+	userRepo := memory.NewUserWith(src, dst)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+dst.ID().String()+"/merge",
+		strings.NewReader(`{"sourceId":"`+src.ID().String()+`"}`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body userhandler.MergeUserResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, dst.ID().String(), body.User.ID)
+	assert.Equal(t, []string{"google-oauth2|1"}, body.MovedAuths)
+	assert.Empty(t, body.WorkspaceIDs)
+	assert.NotEmpty(t, body.AuditLogID)
+}
+
+func TestMergeUser_BadRequest(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	u := usr("Alice", "alice", "alice@example.com")
+	userRepo := memory.NewUserWith(u)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	cases := []struct {
+		name string
+		body string
+	}{
+		{name: "invalid source id", body: `{"sourceId":"nope"}`},
+		{name: "same user", body: `{"sourceId":"` + u.ID().String() + `"}`},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+u.ID().String()+"/merge", strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.AddCookie(cookieFor(t, sess, op.ID()))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestImportUsers_DryRunByDefault(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	userRepo := memory.NewUserWith(usr("Alice", "alice", "alice@example.com"))
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	csv := "email,name\nbob@example.com,bob\nalice@example.com,alice2\n"
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/import", strings.NewReader(csv))
+	req.Header.Set(echo.HeaderContentType, "text/csv")
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body userhandler.ImportUsersResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.True(t, body.DryRun)
+	assert.False(t, body.Valid)
+	assert.False(t, body.Imported)
+	require.Len(t, body.Rows, 2)
+	assert.Empty(t, body.Rows[0].Errors)
+	assert.Equal(t, []string{"a user with this email already exists"}, body.Rows[1].Errors)
+}
+
+func TestImportUsers_BadRequest(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(memory.NewUser(), adminRepo, sess)
+
+	cases := []struct {
+		name        string
+		query       string
+		contentType string
+		body        string
+	}{
+		{name: "no format", body: `[]`},
+		{name: "unknown format", query: "?format=xml", body: `[]`},
+		{name: "invalid dry_run", query: "?format=json&dry_run=maybe", body: `[]`},
+		{name: "malformed json", contentType: echo.MIMEApplicationJSON, body: `{`},
+		{name: "unknown csv column", contentType: "text/csv", body: "email,name,password\n"},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/import"+tc.query, strings.NewReader(tc.body))
+			if tc.contentType != "" {
+				req.Header.Set(echo.HeaderContentType, tc.contentType)
+			}
+			req.AddCookie(cookieFor(t, sess, op.ID()))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, http.StatusBadRequest, rec.Code)
+		})
+	}
+}
+
+func TestImportUsers_Create(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	userRepo := memory.NewUser()
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/import?dry_run=false",
+		strings.NewReader(`[{"email":"bob@example.com","name":"bob"}]`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusCreated, rec.Code)
+
+	var body userhandler.ImportUsersResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.True(t, body.Imported)
+	assert.NotEmpty(t, body.AuditLogID)
+	require.Len(t, body.Rows, 1)
+	assert.NotEmpty(t, body.Rows[0].UserID)
+}
+
+func TestImportUsers_InvalidRows(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	userRepo := memory.NewUser()
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/import?dry_run=false",
+		strings.NewReader(`[{"email":"bob@example.com","name":"bob"},{"email":"nope","name":"x"}]`))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	var body userhandler.ImportUsersResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.False(t, body.Imported)
+	assert.Empty(t, body.Rows[0].UserID)
+	assert.Equal(t, []string{"invalid email"}, body.Rows[1].Errors)
+}
+
+func TestExportUsers_CSV(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	userRepo := memory.NewUserWith(usr("Beta", "beta", "beta@example.com"), usr("Alpha", "alpha", "alpha@example.com"))
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/export", nil)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/csv")
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "attachment")
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.Equal(t, "email,name,alias,lang,theme,subs,workspaces", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], "alpha@example.com,Alpha,alpha,"))
+	assert.True(t, strings.HasPrefix(lines[2], "beta@example.com,Beta,beta,"))
+}
+
+func TestExportUsers_WorkspaceNotFound(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(memory.NewUser(), adminRepo, sess)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/export?format=json&workspace=missing", nil)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusNotFound, rec.Code)
+}
+
+func TestExportUserListing_CSV(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	alpha := usr("Alpha", "alpha", "alpha@example.com")
+	beta := usr("Beta", "beta", "beta@example.com")
+	beta.Deactivate()
+	userRepo := memory.NewUserWith(alpha, beta)
+	wsRepo := memory.NewWorkspaceWith(workspace.New().NewID().Name("GIS").Alias("gis").Members(map[user.ID]workspace.Member{
+		alpha.ID(): {Role: role.RoleWriter},
+	}).MustBuild())
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEchoWithWorkspaces(userRepo, wsRepo, adminRepo, sess)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/listing-export?status=active", nil)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/csv")
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "users-")
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "id,name,email,alias,deactivated,workspace_count,workspaces", lines[0])
+	assert.Equal(t, alpha.ID().String()+",Alpha,alpha@example.com,alpha,false,1,gis:writer", lines[1])
+}
+
+func TestExportUserListing_NDJSON(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	userRepo := memory.NewUserWith(usr("Alpha", "alpha", "alpha@example.com"), usr("Beta", "beta", "beta@example.com"))
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/listing-export?format=ndjson&q=beta", nil)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 1)
+	var row userhandler.UserListingRowResponse
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &row))
+	assert.Equal(t, "beta@example.com", row.Email)
+	assert.Equal(t, 0, row.WorkspaceCount)
+	assert.Empty(t, row.Workspaces)
+}
+
+func TestExportUserListing_Empty(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(memory.NewUser(), adminRepo, sess)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/users/listing-export", nil)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "id,name,email,alias,deactivated,workspace_count,workspaces\n", rec.Body.String())
+}
+
+func TestExportUserListing_InvalidQuery(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(memory.NewUser(), adminRepo, sess)
+
+	for _, q := range []string{"format=xml", "status=gone"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/users/listing-export?"+q, nil)
+		req.AddCookie(cookieFor(t, sess, op.ID()))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, q)
+	}
+}
+
+func TestExportUserData_OK(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	u := usr("Alice", "alice", "alice@example.com")
+	userRepo := memory.NewUserWith(u)
+	storage := mock.NewMockStorage(gomock.NewController(t))
+	storage.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil)
+	storage.EXPECT().GetSignedURL(gomock.Any(), gomock.Any()).Return("https://storage.example.com/export.zip", nil)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEchoWithStorage(userRepo, memory.NewWorkspaceWith(), adminRepo, storage, sess)
+
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+u.ID().String()+"/data-export", nil)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	var body userhandler.ExportUserDataResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, u.ID().String(), body.UserID)
+	assert.Equal(t, "https://storage.example.com/export.zip", body.DownloadURL)
+	assert.True(t, strings.HasPrefix(body.ObjectName, "users/"+u.ID().String()+"/data-exports/"))
+	assert.NotEmpty(t, body.AuditLogID)
+}
+
+func TestExportUserData_Errors(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(memory.NewUser(), adminRepo, sess)
+
+	cases := []struct {
+		name   string
+		id     string
+		status int
+	}{
+		{name: "invalid id", id: "nope", status: http.StatusBadRequest},
+		{name: "not found", id: user.NewID().String(), status: http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+tc.id+"/data-export", nil)
+			req.AddCookie(cookieFor(t, sess, op.ID()))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tc.status, rec.Code)
+		})
+	}
+}
+
+func TestDeactivateAndRestoreUser(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	u := usr("Alice", "alice", "alice@example.com")
+	userRepo := memory.NewUserWith(u)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	do := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+u.ID().String()+path, nil)
+		req.AddCookie(cookieFor(t, sess, op.ID()))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("/deactivate")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body userhandler.UserActionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.True(t, body.User.Deactivated)
+	assert.NotEmpty(t, body.AuditLogID)
+
+	assert.Equal(t, http.StatusConflict, do("/deactivate").Code)
+
+	rec = do("/restore")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.False(t, body.User.Deactivated)
+
+	assert.Equal(t, http.StatusConflict, do("/restore").Code)
+}
+
+func TestUpdateUser(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	u := usr("Alice", "alice", "alice@example.com")
+	userRepo := memory.NewUserWith(u, usr("Bob", "bob", "bob@example.com"))
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	cases := []struct {
+		name string
+		body string
+		want int
+	}{
+		{name: "ok", body: `{"name":"Alice Liddell","email":"alice@example.org"}`, want: http.StatusOK},
+		{name: "nothing to update", body: `{}`, want: http.StatusBadRequest},
+		{name: "invalid email", body: `{"email":"alice"}`, want: http.StatusBadRequest},
+		{name: "alias taken", body: `{"alias":"bob"}`, want: http.StatusConflict},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/users/"+u.ID().String(), strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.AddCookie(cookieFor(t, sess, op.ID()))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tc.want, rec.Code)
+		})
+	}
+
+	got, err := userRepo.FindByID(t.Context(), u.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Liddell", got.Name())
+	assert.Equal(t, "alice@example.org", got.Email())
+}
+
+func TestUserCredentialActions(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	u := user.New().NewID().Name("Alice").Email("alice@example.com").
+		Auths([]user.Auth{user.NewReearthAuth("alice"), user.AuthFrom("google-oauth2|1")}).MustBuild()
+	u.SetMFA(user.MFAFrom("secret", true, nil, 0, 0, nil))
+	userRepo := memory.NewUserWith(u)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		want   int
+	}{
+		{name: "verify email", method: http.MethodPost, path: "/verify-email", want: http.StatusOK},
+		{name: "verify email again", method: http.MethodPost, path: "/verify-email", want: http.StatusConflict},
+		{name: "password reset", method: http.MethodPost, path: "/password-reset", want: http.StatusOK},
+		{name: "remove auth", method: http.MethodDelete, path: "/auths/google-oauth2%7C1", want: http.StatusOK},
+		{name: "remove missing auth", method: http.MethodDelete, path: "/auths/google-oauth2%7C1", want: http.StatusNotFound},
+		{name: "remove last auth", method: http.MethodDelete, path: "/auths/reearth%7Calice", want: http.StatusBadRequest},
+		{name: "reset mfa", method: http.MethodDelete, path: "/mfa", want: http.StatusOK},
+		{name: "reset mfa again", method: http.MethodDelete, path: "/mfa", want: http.StatusBadRequest},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, "/api/v1/users/"+u.ID().String()+tc.path, nil)
+			req.AddCookie(cookieFor(t, sess, op.ID()))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tc.want, rec.Code, rec.Body.String())
+		})
+	}
+
+	got, err := userRepo.FindByID(t.Context(), u.ID())
+	require.NoError(t, err)
+	assert.True(t, got.Verification().IsVerified())
+	assert.NotNil(t, got.PasswordReset())
+	assert.Equal(t, user.Auths{user.NewReearthAuth("alice")}, got.Auths())
+	assert.Nil(t, got.MFA())
+}
+
+func TestImpersonateUser(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	u := usr("Alice", "alice", "alice@example.com")
+	gone := usr("Bob", "bob", "bob@example.com")
+	gone.Deactivate()
+	userRepo := memory.NewUserWith(u, gone)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(userRepo, adminRepo, sess)
+
+	do := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/users/"+id+"/impersonate", nil)
+		req.AddCookie(cookieFor(t, sess, op.ID()))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(u.ID().String())
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body userhandler.ImpersonateUserResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.Equal(t, impersonation.Header, body.Header)
+	assert.NotEmpty(t, body.AuditLogID)
+	g, err := impersonation.NewSigner(testImpersonationSecret).Parse(body.Token)
+	require.NoError(t, err)
+	assert.Equal(t, op.ID(), g.Admin)
+	assert.Equal(t, u.ID(), g.User)
+
+	assert.Equal(t, http.StatusConflict, do(gone.ID().String()).Code)
+	assert.Equal(t, http.StatusNotFound, do(user.NewID().String()).Code)
+	assert.Equal(t, http.StatusBadRequest, do("invalid").Code)
+}
@@ -0,0 +1,55 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+)
+
+// UpdateUserRequest is the request body for editing a user. Omitted fields
+// are left as they are.
+type UpdateUserRequest struct {
+	Name  *string `json:"name,omitempty" example:"Alice"`
+	Alias *string `json:"alias,omitempty" example:"alice"`
+	Email *string `json:"email,omitempty" example:"alice@example.com"`
+} // @name UpdateUserRequest
+
+// UpdateUser godoc
+//
+//	@Summary		Edit a user
+//	@Description	Changes the name, alias or email of the user. The alias and, unless it was renamed, the name of the personal workspace follow the user. The email is not pushed to external identity providers. The old and new values are recorded in the audit log.
+//	@Tags			users
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string				true	"User ID"
+//	@Param			body	body		UpdateUserRequest	true	"Fields to change"
+//	@Success		200		{object}	UserActionResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid id / invalid body / nothing to update"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404		{object}	internal.ErrorResponse	"not found"
+//	@Failure		409		{object}	internal.ErrorResponse	"alias or email already taken"
+//	@Router			/users/{id} [patch]
+func (h *Handler) UpdateUser(c echo.Context) error {
+	in, err := userActionInput(c)
+	if err != nil {
+		return err
+	}
+
+	var body UpdateUserRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	out, err := h.updateUC.Execute(c.Request().Context(), useruc.UpdateUserInput{
+		UserActionInput: in,
+		Name:            body.Name,
+		Alias:           body.Alias,
+		Email:           body.Email,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserActionResponse(out))
+}
@@ -0,0 +1,33 @@
+package user
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// VerifyUserEmail godoc
+//
+//	@Summary		Force-verify the email of a user
+//	@Description	Marks the email of the user as verified without the verification mail, e.g. for users who can't receive it. The verification is recorded in the audit log.
+//	@Tags			users
+//	@Produce		json
+//	@Param			id	path		string	true	"User ID"
+//	@Success		200	{object}	UserActionResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Failure		409	{object}	internal.ErrorResponse	"email is already verified"
+//	@Router			/users/{id}/verify-email [post]
+func (h *Handler) VerifyUserEmail(c echo.Context) error {
+	in, err := userActionInput(c)
+	if err != nil {
+		return err
+	}
+	out, err := h.verifyEmailUC.Execute(c.Request().Context(), in)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newUserActionResponse(out))
+}
@@ -1,6 +1,12 @@
 package user
 
 import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/useruc"
+	"github.com/reearth/reearth-accounts/server/pkg/impersonation"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 )
 
@@ -27,8 +33,27 @@ type UserDetailResponse struct {
 	Email string `json:"email"`
 	Alias string `json:"alias"`
 	Host  string `json:"host"`
+	// Auths are the subs the user signs in with.
+	Auths         []string `json:"auths"`
+	EmailVerified bool     `json:"emailVerified"`
+	MFAEnabled    bool     `json:"mfaEnabled"`
+	Deactivated   bool     `json:"deactivated"`
 } // @name UserDetail
 
+// UserActionResponse is a user after an admin action and the audit log entry
+// of the action.
+type UserActionResponse struct {
+	User       UserDetailResponse `json:"user"`
+	AuditLogID string             `json:"auditLogId"`
+} // @name UserActionResponse
+
+func newUserActionResponse(out *useruc.UserActionOutput) UserActionResponse {
+	return UserActionResponse{
+		User:       newUserDetailResponse(out.User),
+		AuditLogID: out.AuditLog.ID().String(),
+	}
+}
+
 // UserWorkspaceResponse is a workspace a user belongs to, with the user's role.
 type UserWorkspaceResponse struct {
 	ID       string `json:"id"`
@@ -38,13 +63,188 @@ type UserWorkspaceResponse struct {
 	Role     string `json:"role"`
 } // @name UserWorkspace
 
+// MergeUserResponse is the result of a user merge.
+type MergeUserResponse struct {
+	User UserDetailResponse `json:"user"`
+	// MovedAuths are the auth subs moved from the source.
+	MovedAuths []string `json:"movedAuths"`
+	// DroppedAuths are the auth subs of the source that were not moved.
+	DroppedAuths []string `json:"droppedAuths"`
+	// WorkspaceIDs are the workspaces the source was a member of.
+	WorkspaceIDs []string `json:"workspaceIds"`
+	AuditLogID   string   `json:"auditLogId"`
+} // @name MergeUserResponse
+
+func newMergeUserResponse(out *useruc.MergeOutput) MergeUserResponse {
+	return MergeUserResponse{
+		User:         newUserDetailResponse(out.Target),
+		MovedAuths:   nonNil(out.MovedAuths),
+		DroppedAuths: nonNil(out.DroppedAuths),
+		WorkspaceIDs: nonNil(out.Workspaces.Strings()),
+		AuditLogID:   out.AuditLog.ID().String(),
+	}
+}
+
+// ImportUsersResponse is the per-row report of a user import.
+type ImportUsersResponse struct {
+	DryRun bool `json:"dryRun"`
+	// Valid is whether every row is valid.
+	Valid bool `json:"valid"`
+	// Imported is whether the users were created.
+	Imported bool `json:"imported"`
+	// NewWorkspaces are the aliases of the team workspaces the import creates.
+	NewWorkspaces []string            `json:"newWorkspaces"`
+	Rows          []ImportRowResponse `json:"rows"`
+	AuditLogID    string              `json:"auditLogId,omitempty"`
+} // @name ImportUsersResponse
+
+// ImportRowResponse is the result of a row of an import file.
+type ImportRowResponse struct {
+	// Row is the position of the record in the file, starting at 1 and not
+	// counting the header of a CSV file.
+	Row    int      `json:"row"`
+	Email  string   `json:"email"`
+	UserID string   `json:"userId,omitempty"`
+	Errors []string `json:"errors"`
+} // @name ImportUserRow
+
+func newImportUsersResponse(out *useruc.ImportOutput) ImportUsersResponse {
+	rows := make([]ImportRowResponse, 0, len(out.Rows))
+	for _, r := range out.Rows {
+		row := ImportRowResponse{Row: r.Row, Email: r.Email, Errors: nonNil(r.Errors)}
+		if r.User != nil {
+			row.UserID = r.User.String()
+		}
+		rows = append(rows, row)
+	}
+	res := ImportUsersResponse{
+		DryRun:        out.DryRun,
+		Valid:         out.Valid(),
+		Imported:      out.Imported(),
+		NewWorkspaces: nonNil(out.NewWorkspaces),
+		Rows:          rows,
+	}
+	if out.AuditLog != nil {
+		res.AuditLogID = out.AuditLog.ID().String()
+	}
+	return res
+}
+
+// ExportUserDataResponse is where the personal data export of a user was
+// stored.
+type ExportUserDataResponse struct {
+	UserID     string `json:"userId"`
+	ObjectName string `json:"objectName"`
+	// DownloadURL is the signed URL mailed to the user. It expires in 24
+	// hours.
+	DownloadURL string `json:"downloadUrl"`
+	AuditLogID  string `json:"auditLogId"`
+} // @name ExportUserDataResponse
+
+func newExportUserDataResponse(uid string, out *useruc.ExportUserDataOutput) ExportUserDataResponse {
+	return ExportUserDataResponse{
+		UserID:      uid,
+		ObjectName:  out.ObjectName,
+		DownloadURL: out.DownloadURL,
+		AuditLogID:  out.AuditLog.ID().String(),
+	}
+}
+
+// ImpersonateUserResponse is a token with which the admin views the main
+// service as the user.
+type ImpersonateUserResponse struct {
+	UserID string `json:"userId"`
+	// Token is sent to the main service in the Header request header.
+	Token      string    `json:"token"`
+	Header     string    `json:"header"`
+	ExpiresAt  time.Time `json:"expiresAt"`
+	AuditLogID string    `json:"auditLogId"`
+} // @name ImpersonateUserResponse
+
+func newImpersonateUserResponse(uid string, out *useruc.ImpersonateUserOutput) ImpersonateUserResponse {
+	return ImpersonateUserResponse{
+		UserID:     uid,
+		Token:      out.Token,
+		Header:     impersonation.Header,
+		ExpiresAt:  out.ExpiresAt,
+		AuditLogID: out.AuditLog.ID().String(),
+	}
+}
+
+// UserListingRowResponse is a line of an NDJSON user listing export.
+type UserListingRowResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Alias       string `json:"alias"`
+	Deactivated bool   `json:"deactivated"`
+	// WorkspaceCount is the number of team workspaces the user is a member of.
+	WorkspaceCount int                       `json:"workspaceCount"`
+	Workspaces     []UserListingRoleResponse `json:"workspaces"`
+} // @name UserListingRow
+
+// UserListingRoleResponse is a team workspace of a UserListingRowResponse, by
+// alias, and the user's role in it.
+type UserListingRoleResponse struct {
+	Workspace string `json:"workspace"`
+	Role      string `json:"role"`
+} // @name UserListingRole
+
+// userListingColumns are the columns of a CSV user listing export. Workspaces
+// are "<alias>:<role>" pairs separated by ";", as in the user export.
+var userListingColumns = []string{"id", "name", "email", "alias", "deactivated", "workspace_count", "workspaces"}
+
+func newUserListingRowResponse(r useruc.ListingRow) UserListingRowResponse {
+	ws := make([]UserListingRoleResponse, 0, len(r.Workspaces))
+	for _, m := range r.Workspaces {
+		ws = append(ws, UserListingRoleResponse{Workspace: m.Workspace, Role: m.Role})
+	}
+	return UserListingRowResponse{
+		ID:             r.User.ID().String(),
+		Name:           r.User.Name(),
+		Email:          r.User.Email(),
+		Alias:          r.User.Alias(),
+		Deactivated:    r.User.IsDeleted(),
+		WorkspaceCount: len(ws),
+		Workspaces:     ws,
+	}
+}
+
+func (r UserListingRowResponse) csvRecord() []string {
+	ws := make([]string, 0, len(r.Workspaces))
+	for _, m := range r.Workspaces {
+		ws = append(ws, m.Workspace+":"+m.Role)
+	}
+	return []string{
+		r.ID, r.Name, r.Email, r.Alias,
+		strconv.FormatBool(r.Deactivated),
+		strconv.Itoa(r.WorkspaceCount),
+		strings.Join(ws, ";"),
+	}
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
 func newUserDetailResponse(u *user.User) UserDetailResponse {
+	auths := make([]string, 0, len(u.Auths()))
+	for _, a := range u.Auths() {
+		auths = append(auths, a.Sub)
+	}
 	return UserDetailResponse{
-		ID:    u.ID().String(),
-		Name:  u.Name(),
-		Email: u.Email(),
-		Alias: u.Alias(),
-		Host:  u.Host(),
+		ID:            u.ID().String(),
+		Name:          u.Name(),
+		Email:         u.Email(),
+		Alias:         u.Alias(),
+		Host:          u.Host(),
+		Auths:         auths,
+		EmailVerified: u.Verification().IsVerified(),
+		MFAEnabled:    u.MFA().IsEnabled(),
+		Deactivated:   u.IsDeleted(),
 	}
 }
 
@@ -1,5 +1,5 @@
-// Package workspace implements the admin cross-tenant workspace endpoints
-// (V1: read-only list), behind the RequireApproved middleware.
+// Package workspace implements the admin cross-tenant workspace endpoints,
+// behind the RequireApproved middleware.
 package workspace
 
 import (
@@ -8,12 +8,44 @@ import (
 
 // Handler serves the /workspaces endpoints.
 type Handler struct {
-	get     *workspaceuc.GetWorkspaceUseCase
-	list    *workspaceuc.ListWorkspacesUseCase
-	members *workspaceuc.ListWorkspaceMembersUseCase
+	get               *workspaceuc.GetWorkspaceUseCase
+	list              *workspaceuc.ListWorkspacesUseCase
+	exportListing     *workspaceuc.ExportWorkspaceListingUseCase
+	members           *workspaceuc.ListWorkspaceMembersUseCase
+	update            *workspaceuc.UpdateWorkspaceUseCase
+	deactivate        *workspaceuc.DeactivateWorkspaceUseCase
+	restore           *workspaceuc.RestoreWorkspaceUseCase
+	addMembers        *workspaceuc.AddWorkspaceMembersUseCase
+	updateMember      *workspaceuc.UpdateWorkspaceMemberUseCase
+	removeMember      *workspaceuc.RemoveWorkspaceMemberUseCase
+	removeIntegration *workspaceuc.RemoveWorkspaceIntegrationUseCase
 }
 
 // NewHandler is a Wire provider for the workspace Handler.
-func NewHandler(get *workspaceuc.GetWorkspaceUseCase, list *workspaceuc.ListWorkspacesUseCase, members *workspaceuc.ListWorkspaceMembersUseCase) *Handler {
-	return &Handler{get: get, list: list, members: members}
+func NewHandler(
+	get *workspaceuc.GetWorkspaceUseCase,
+	list *workspaceuc.ListWorkspacesUseCase,
+	exportListing *workspaceuc.ExportWorkspaceListingUseCase,
+	members *workspaceuc.ListWorkspaceMembersUseCase,
+	update *workspaceuc.UpdateWorkspaceUseCase,
+	deactivate *workspaceuc.DeactivateWorkspaceUseCase,
+	restore *workspaceuc.RestoreWorkspaceUseCase,
+	addMembers *workspaceuc.AddWorkspaceMembersUseCase,
+	updateMember *workspaceuc.UpdateWorkspaceMemberUseCase,
+	removeMember *workspaceuc.RemoveWorkspaceMemberUseCase,
+	removeIntegration *workspaceuc.RemoveWorkspaceIntegrationUseCase,
+) *Handler {
+	return &Handler{
+		get:               get,
+		list:              list,
+		exportListing:     exportListing,
+		members:           members,
+		update:            update,
+		deactivate:        deactivate,
+		restore:           restore,
+		addMembers:        addMembers,
+		updateMember:      updateMember,
+		removeMember:      removeMember,
+		removeIntegration: removeIntegration,
+	}
 }
@@ -0,0 +1,75 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+)
+
+// AddWorkspaceMemberRequest is a user to add to a workspace with their role.
+type AddWorkspaceMemberRequest struct {
+	UserID string `json:"userId" example:"01h2x3y4z5a6b7c8d9e0f1g2h3"`
+	Role   string `json:"role" example:"writer" enums:"owner,maintainer,writer,reader"`
+} // @name AddWorkspaceMemberRequest
+
+// AddWorkspaceMembersRequest is the request body for adding members to a
+// workspace.
+type AddWorkspaceMembersRequest struct {
+	Members []AddWorkspaceMemberRequest `json:"members"`
+} // @name AddWorkspaceMembersRequest
+
+// AddWorkspaceMembers godoc
+//
+//	@Summary		Add members to a workspace
+//	@Description	Adds the users to the workspace with their roles and binds their permittables to it. Any role, including owner, can be given, e.g. to give a workspace whose owner left a new one. The added members are recorded in the audit log.
+//	@Tags			workspaces
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string						true	"Workspace ID"
+//	@Param			body	body		AddWorkspaceMembersRequest	true	"Members to add"
+//	@Success		200		{object}	WorkspaceActionResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid id / invalid body / invalid role / personal workspace"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404		{object}	internal.ErrorResponse	"workspace or user not found"
+//	@Failure		409		{object}	internal.ErrorResponse	"user already joined"
+//	@Router			/workspaces/{id}/members [post]
+func (h *Handler) AddWorkspaceMembers(c echo.Context) error {
+	in, err := workspaceActionInput(c)
+	if err != nil {
+		return err
+	}
+
+	var body AddWorkspaceMembersRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	members := make(map[user.ID]role.RoleType, len(body.Members))
+	for _, m := range body.Members {
+		uid, err := id.UserIDFrom(m.UserID)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid user id")
+		}
+		if _, ok := members[uid]; ok {
+			return echo.NewHTTPError(http.StatusBadRequest, "duplicate user id")
+		}
+		r, err := role.RoleFrom(m.Role)
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid role")
+		}
+		members[uid] = r
+	}
+
+	out, err := h.addMembers.Execute(c.Request().Context(), workspaceuc.AddWorkspaceMembersInput{
+		WorkspaceActionInput: in,
+		Members:              members,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newWorkspaceActionResponse(out))
+}
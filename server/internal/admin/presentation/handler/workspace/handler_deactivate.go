@@ -0,0 +1,76 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+// DeactivateWorkspace godoc
+//
+//	@Summary		Deactivate a workspace
+//	@Description	Soft-deletes the workspace. Its members and data are kept, and it is purged once the retention window has passed unless restored first. The deactivation is recorded in the audit log.
+//	@Tags			workspaces
+//	@Produce		json
+//	@Param			id	path		string	true	"Workspace ID"
+//	@Success		200	{object}	WorkspaceActionResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id / personal workspace"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Failure		409	{object}	internal.ErrorResponse	"workspace is deactivated"
+//	@Router			/workspaces/{id}/deactivate [post]
+func (h *Handler) DeactivateWorkspace(c echo.Context) error {
+	in, err := workspaceActionInput(c)
+	if err != nil {
+		return err
+	}
+	out, err := h.deactivate.Execute(c.Request().Context(), in)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newWorkspaceActionResponse(out))
+}
+
+// RestoreWorkspace godoc
+//
+//	@Summary		Restore a deactivated workspace
+//	@Description	Reactivates a deactivated workspace. The restoration is recorded in the audit log.
+//	@Tags			workspaces
+//	@Produce		json
+//	@Param			id	path		string	true	"Workspace ID"
+//	@Success		200	{object}	WorkspaceActionResponse
+//	@Failure		400	{object}	internal.ErrorResponse	"invalid id / personal workspace"
+//	@Failure		401	{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403	{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404	{object}	internal.ErrorResponse	"not found"
+//	@Failure		409	{object}	internal.ErrorResponse	"workspace is not deactivated"
+//	@Router			/workspaces/{id}/restore [post]
+func (h *Handler) RestoreWorkspace(c echo.Context) error {
+	in, err := workspaceActionInput(c)
+	if err != nil {
+		return err
+	}
+	out, err := h.restore.Execute(c.Request().Context(), in)
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newWorkspaceActionResponse(out))
+}
+
+// workspaceActionInput reads the operator and the workspace of the :id path
+// parameter.
+func workspaceActionInput(c echo.Context) (workspaceuc.WorkspaceActionInput, error) {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return workspaceuc.WorkspaceActionInput{}, err
+	}
+	wid, err := id.WorkspaceIDFrom(c.Param("id"))
+	if err != nil {
+		return workspaceuc.WorkspaceActionInput{}, echo.NewHTTPError(http.StatusBadRequest, "invalid id")
+	}
+	return workspaceuc.WorkspaceActionInput{Operator: operator.ID(), Workspace: wid}, nil
+}
@@ -0,0 +1,81 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+)
+
+// ExportWorkspaceListing godoc
+//
+//	@Summary		Export the workspace list to a CSV or NDJSON file
+//	@Description	Streams every workspace matching the filters of the workspace list, in its order, with its member count, the number of members in each role and its integration count. Unlike the list it is not paginated. The export is recorded in the audit log.
+//	@Tags			workspaces
+//	@Produce		text/csv,application/x-ndjson
+//	@Param			q		query		string	false	"Search by name or alias"
+//	@Param			type	query		string	false	"Filter by workspace type"	Enums(personal, team)
+//	@Param			status	query		string	false	"Filter by status (default all)"	Enums(all, active, deleted)
+//	@Param			format	query		string	false	"csv (default) or ndjson"
+//	@Success		200		{object}	WorkspaceListingRowResponse	"one per line in NDJSON"
+//	@Failure		400		{object}	internal.ErrorResponse		"invalid query"
+//	@Failure		401		{object}	internal.ErrorResponse		"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse		"not approved / forbidden"
+//	@Failure		501		{object}	internal.ErrorResponse		"not implemented on this backend"
+//	@Router			/workspaces/listing-export [get]
+func (h *Handler) ExportWorkspaceListing(c echo.Context) error {
+	operator, err := internal.GetAdminUser(c)
+	if err != nil {
+		return err
+	}
+
+	f, err := internal.ParseListingFormat(c.QueryParam("format"))
+	if err != nil {
+		return err
+	}
+
+	var keyword *string
+	if q := c.QueryParam("q"); q != "" {
+		keyword = &q
+	}
+
+	var personal *bool
+	switch c.QueryParam("type") {
+	case "":
+		// no filter
+	case "personal":
+		v := true
+		personal = &v
+	case "team":
+		v := false
+		personal = &v
+	default:
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid type")
+	}
+
+	status := workspace.StatusAll
+	if s := c.QueryParam("status"); s != "" {
+		switch workspace.StatusFilter(s) {
+		case workspace.StatusAll, workspace.StatusActive, workspace.StatusDeleted:
+			status = workspace.StatusFilter(s)
+		default:
+			return echo.NewHTTPError(http.StatusBadRequest, "invalid status")
+		}
+	}
+
+	w := internal.NewListingWriter(c, f, "workspaces", workspaceListingColumns)
+	if err := h.exportListing.Execute(c.Request().Context(), workspaceuc.ExportWorkspaceListingInput{
+		Operator: operator.ID(),
+		Keyword:  keyword,
+		Personal: personal,
+		Status:   status,
+	}, func(ws *workspace.Workspace) error {
+		row := newWorkspaceListingRowResponse(ws)
+		return w.Write(row, row.csvRecord())
+	}); err != nil {
+		return err
+	}
+	return w.Close()
+}
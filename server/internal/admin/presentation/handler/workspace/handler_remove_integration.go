@@ -0,0 +1,43 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+// RemoveWorkspaceIntegration godoc
+//
+//	@Summary		Remove an integration from a workspace
+//	@Description	Removes the integration from the workspace. The removal is recorded in the audit log.
+//	@Tags			workspaces
+//	@Produce		json
+//	@Param			id				path		string	true	"Workspace ID"
+//	@Param			integrationId	path		string	true	"Integration ID"
+//	@Success		200				{object}	WorkspaceActionResponse
+//	@Failure		400				{object}	internal.ErrorResponse	"invalid id / personal workspace"
+//	@Failure		401				{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403				{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404				{object}	internal.ErrorResponse	"workspace or integration not found"
+//	@Router			/workspaces/{id}/integrations/{integrationId} [delete]
+func (h *Handler) RemoveWorkspaceIntegration(c echo.Context) error {
+	in, err := workspaceActionInput(c)
+	if err != nil {
+		return err
+	}
+	iid, err := id.IntegrationIDFrom(c.Param("integrationId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid integration id")
+	}
+
+	out, err := h.removeIntegration.Execute(c.Request().Context(), workspaceuc.RemoveWorkspaceIntegrationInput{
+		WorkspaceActionInput: in,
+		Integration:          iid,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newWorkspaceActionResponse(out))
+}
@@ -0,0 +1,43 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+// RemoveWorkspaceMember godoc
+//
+//	@Summary		Remove a member from a workspace
+//	@Description	Removes the member and unbinds their permittable from the workspace. The only owner can't be removed; make another member an owner first. The removal is recorded in the audit log.
+//	@Tags			workspaces
+//	@Produce		json
+//	@Param			id		path		string	true	"Workspace ID"
+//	@Param			userId	path		string	true	"User ID of the member"
+//	@Success		200		{object}	WorkspaceActionResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid id / last owner / personal workspace"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404		{object}	internal.ErrorResponse	"workspace or member not found"
+//	@Router			/workspaces/{id}/members/{userId} [delete]
+func (h *Handler) RemoveWorkspaceMember(c echo.Context) error {
+	in, err := workspaceActionInput(c)
+	if err != nil {
+		return err
+	}
+	uid, err := id.UserIDFrom(c.Param("userId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid user id")
+	}
+
+	out, err := h.removeMember.Execute(c.Request().Context(), workspaceuc.RemoveWorkspaceMemberInput{
+		WorkspaceActionInput: in,
+		User:                 uid,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newWorkspaceActionResponse(out))
+}
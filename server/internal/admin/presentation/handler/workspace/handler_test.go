@@ -1,10 +1,12 @@
 package workspace_test
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
+	"strings"
 	"testing"
 	"time"
 
@@ -15,10 +17,12 @@ import (
 	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
 	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 	"github.com/reearth/reearth-accounts/server/pkg/role"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/usecasex"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -42,29 +46,125 @@ func newTestEcho(wsRepo workspace.Repo, adminRepo adminuser.Repo, sess *session.
 }
 
 func newTestEchoWithUsers(wsRepo workspace.Repo, userRepo user.Repo, adminRepo adminuser.Repo, sess *session.Manager) *echo.Echo {
+	auditLogRepo := memory.NewAuditLog()
+	roleRepo := memory.NewRoleWith(
+		role.New().NewID().Name(role.RoleOwner.String()).MustBuild(),
+		role.New().NewID().Name(role.RoleWriter.String()).MustBuild(),
+		role.New().NewID().Name(role.RoleReader.String()).MustBuild(),
+	)
+	permittableRepo := memory.NewPermittable()
 	h := workspacehandler.NewHandler(
 		workspaceuc.NewGetWorkspaceUseCase(wsRepo),
 		workspaceuc.NewListWorkspacesUseCase(wsRepo),
+		workspaceuc.NewExportWorkspaceListingUseCase(wsRepo, auditLogRepo),
 		workspaceuc.NewListWorkspaceMembersUseCase(wsRepo, userRepo),
+		workspaceuc.NewUpdateWorkspaceUseCase(wsRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		workspaceuc.NewDeactivateWorkspaceUseCase(wsRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		workspaceuc.NewRestoreWorkspaceUseCase(wsRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		workspaceuc.NewAddWorkspaceMembersUseCase(wsRepo, userRepo, roleRepo, permittableRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		workspaceuc.NewUpdateWorkspaceMemberUseCase(wsRepo, roleRepo, permittableRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		workspaceuc.NewRemoveWorkspaceMemberUseCase(wsRepo, roleRepo, permittableRepo, auditLogRepo, &usecasex.NopTransaction{}),
+		workspaceuc.NewRemoveWorkspaceIntegrationUseCase(wsRepo, auditLogRepo, &usecasex.NopTransaction{}),
 	)
-	requireApproved := echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, adminRepo))
+	requireApproved := echo.MiddlewareFunc(mw.NewRequireApprovedMiddleware(sess, sessions, adminRepo))
 
 	e := echo.New()
 	e.HTTPErrorHandler = adminpresentation.CustomHTTPErrorHandler
 	g := e.Group("/api/v1/workspaces", requireApproved)
 	g.GET("", h.ListWorkspaces)
+	g.GET("/listing-export", h.ExportWorkspaceListing)
 	g.GET("/:id", h.GetWorkspace)
 	g.GET("/:id/members", h.GetWorkspaceMembers)
+	g.PATCH("/:id", h.UpdateWorkspace)
+	g.POST("/:id/deactivate", h.DeactivateWorkspace)
+	g.POST("/:id/restore", h.RestoreWorkspace)
+	g.POST("/:id/members", h.AddWorkspaceMembers)
+	g.PATCH("/:id/members/:userId", h.UpdateWorkspaceMember)
+	g.DELETE("/:id/members/:userId", h.RemoveWorkspaceMember)
+	g.DELETE("/:id/integrations/:integrationId", h.RemoveWorkspaceIntegration)
 	return e
 }
 
+// sessions backs the admin sessions of every test in the package; session IDs
+// are unique, so tests don't see each other's sessions.
+var sessions = memory.NewAdminSession()
+
 func cookieFor(t *testing.T, sess *session.Manager, id adminuser.ID) *http.Cookie {
 	t.Helper()
-	tok, err := sess.Issue(id, time.Now())
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(id).CreatedAt(now).ExpiresAt(now.Add(sess.TTL())).MustBuild()
+	require.NoError(t, sessions.Save(context.Background(), s))
+	tok, err := sess.Issue(id, s.ID(), now)
 	require.NoError(t, err)
 	return &http.Cookie{Name: session.CookieName, Value: tok}
 }
 
+func TestExportWorkspaceListing_CSV(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	team := workspace.New().NewID().Name("GIS").Alias("gis").Members(map[user.ID]workspace.Member{
+		user.NewID(): {Role: role.RoleOwner},
+		user.NewID(): {Role: role.RoleWriter},
+		user.NewID(): {Role: role.RoleWriter},
+	}).MustBuild()
+	wsRepo := memory.NewWorkspaceWith(team, personalWs("Alice", "alice"))
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(wsRepo, adminRepo, sess)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/workspaces/listing-export?type=team", nil)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentType), "text/csv")
+	assert.Contains(t, rec.Header().Get(echo.HeaderContentDisposition), "workspaces-")
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.Equal(t, "id,name,alias,personal,deactivated,member_count,owners,maintainers,writers,readers,integration_count,updated_at", lines[0])
+	assert.True(t, strings.HasPrefix(lines[1], team.ID().String()+",GIS,gis,false,false,3,1,0,2,0,0,"), lines[1])
+}
+
+func TestExportWorkspaceListing_NDJSON(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	wsRepo := memory.NewWorkspaceWith(ws("Alpha", "alpha"), ws("Beta", "beta"))
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(wsRepo, adminRepo, sess)
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/workspaces/listing-export?format=ndjson", nil)
+	req.AddCookie(cookieFor(t, sess, op.ID()))
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+
+	lines := strings.Split(strings.TrimSpace(rec.Body.String()), "\n")
+	require.Len(t, lines, 2)
+	aliases := make([]string, 0, len(lines))
+	for _, l := range lines {
+		var row workspacehandler.WorkspaceListingRowResponse
+		require.NoError(t, json.Unmarshal([]byte(l), &row))
+		aliases = append(aliases, row.Alias)
+	}
+	assert.ElementsMatch(t, []string{"alpha", "beta"}, aliases)
+}
+
+func TestExportWorkspaceListing_InvalidQuery(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(memory.NewWorkspaceWith(), adminRepo, sess)
+
+	for _, q := range []string{"format=xml", "status=gone", "type=shared"} {
+		req := httptest.NewRequest(http.MethodGet, "/api/v1/workspaces/listing-export?"+q, nil)
+		req.AddCookie(cookieFor(t, sess, op.ID()))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusBadRequest, rec.Code, q)
+	}
+}
+
 func TestListWorkspaces_OK(t *testing.T) {
 	op := approvedAdmin("op@eukarya.io")
 	adminRepo := memory.NewAdminUserWith(op)
@@ -427,3 +527,125 @@ func TestGetWorkspaceMembers_NotFound(t *testing.T) {
 	e.ServeHTTP(rec, req)
 	assert.Equal(t, http.StatusNotFound, rec.Code)
 }
+
+func TestUpdateWorkspace(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	w := ws("Alpha", "alpha")
+	personal := personalWs("Alice", "alice")
+	wsRepo := memory.NewWorkspaceWith(w, ws("Beta", "beta"), personal)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(wsRepo, adminRepo, sess)
+
+	cases := []struct {
+		name string
+		id   workspace.ID
+		body string
+		want int
+	}{
+		{name: "ok", id: w.ID(), body: `{"name":"Gamma","alias":"gamma"}`, want: http.StatusOK},
+		{name: "nothing to update", id: w.ID(), body: `{}`, want: http.StatusBadRequest},
+		{name: "alias taken", id: w.ID(), body: `{"alias":"beta"}`, want: http.StatusConflict},
+		{name: "personal", id: personal.ID(), body: `{"name":"Bob"}`, want: http.StatusBadRequest},
+		{name: "not found", id: workspace.NewID(), body: `{"name":"Bob"}`, want: http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/api/v1/workspaces/"+tc.id.String(), strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.AddCookie(cookieFor(t, sess, op.ID()))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tc.want, rec.Code, rec.Body.String())
+		})
+	}
+
+	got, err := wsRepo.FindByID(t.Context(), w.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Gamma", got.Name())
+	assert.Equal(t, "gamma", got.Alias())
+}
+
+func TestDeactivateAndRestoreWorkspace(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	w := ws("Alpha", "alpha")
+	wsRepo := memory.NewWorkspaceWith(w)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEcho(wsRepo, adminRepo, sess)
+
+	do := func(path string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/workspaces/"+w.ID().String()+path, nil)
+		req.AddCookie(cookieFor(t, sess, op.ID()))
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do("/deactivate")
+	require.Equal(t, http.StatusOK, rec.Code)
+	var body workspacehandler.WorkspaceActionResponse
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.True(t, body.Workspace.Deactivated)
+	assert.NotEmpty(t, body.AuditLogID)
+
+	assert.Equal(t, http.StatusConflict, do("/deactivate").Code)
+
+	rec = do("/restore")
+	require.Equal(t, http.StatusOK, rec.Code)
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+	assert.False(t, body.Workspace.Deactivated)
+
+	assert.Equal(t, http.StatusConflict, do("/restore").Code)
+}
+
+func TestWorkspaceMemberActions(t *testing.T) {
+	op := approvedAdmin("op@eukarya.io")
+	adminRepo := memory.NewAdminUserWith(op)
+	owner := user.New().NewID().Name("Owner").Email("owner@example.com").MustBuild()
+	alice := user.New().NewID().Name("Alice").Email("alice@example.com").MustBuild()
+	iid := workspace.NewIntegrationID()
+	w := workspace.New().NewID().Name("Team").Alias("team").
+		Members(map[workspace.UserID]workspace.Member{owner.ID(): {Role: role.RoleOwner}}).
+		Integrations(map[workspace.IntegrationID]workspace.Member{iid: {Role: role.RoleWriter}}).
+		MustBuild()
+	wsRepo := memory.NewWorkspaceWith(w)
+	userRepo := memory.NewUserWith(owner, alice)
+	sess := session.NewManager(testSecret, time.Hour)
+	e := newTestEchoWithUsers(wsRepo, userRepo, adminRepo, sess)
+
+	base := "/api/v1/workspaces/" + w.ID().String()
+	cases := []struct {
+		name   string
+		method string
+		path   string
+		body   string
+		want   int
+	}{
+		{name: "add member", method: http.MethodPost, path: "/members", body: `{"members":[{"userId":"` + alice.ID().String() + `","role":"writer"}]}`, want: http.StatusOK},
+		{name: "add member again", method: http.MethodPost, path: "/members", body: `{"members":[{"userId":"` + alice.ID().String() + `","role":"writer"}]}`, want: http.StatusConflict},
+		{name: "add member with invalid role", method: http.MethodPost, path: "/members", body: `{"members":[{"userId":"` + alice.ID().String() + `","role":"admin"}]}`, want: http.StatusBadRequest},
+		{name: "demote last owner", method: http.MethodPatch, path: "/members/" + owner.ID().String(), body: `{"role":"reader"}`, want: http.StatusBadRequest},
+		{name: "make owner", method: http.MethodPatch, path: "/members/" + alice.ID().String(), body: `{"role":"owner"}`, want: http.StatusOK},
+		{name: "remove former owner", method: http.MethodDelete, path: "/members/" + owner.ID().String(), want: http.StatusOK},
+		{name: "remove missing member", method: http.MethodDelete, path: "/members/" + owner.ID().String(), want: http.StatusNotFound},
+		{name: "remove integration", method: http.MethodDelete, path: "/integrations/" + iid.String(), want: http.StatusOK},
+		{name: "remove missing integration", method: http.MethodDelete, path: "/integrations/" + iid.String(), want: http.StatusNotFound},
+	}
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(tc.method, base+tc.path, strings.NewReader(tc.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			req.AddCookie(cookieFor(t, sess, op.ID()))
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+			assert.Equal(t, tc.want, rec.Code, rec.Body.String())
+		})
+	}
+
+	got, err := wsRepo.FindByID(t.Context(), w.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []workspace.UserID{alice.ID()}, got.Members().UsersByRole(role.RoleOwner))
+	assert.False(t, got.Members().HasUser(owner.ID()))
+	assert.False(t, got.Members().HasIntegration(iid))
+}
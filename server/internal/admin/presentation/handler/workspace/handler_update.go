@@ -0,0 +1,53 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+)
+
+// UpdateWorkspaceRequest is the request body for editing a workspace. Omitted
+// fields are left as they are.
+type UpdateWorkspaceRequest struct {
+	Name  *string `json:"name,omitempty" example:"Team"`
+	Alias *string `json:"alias,omitempty" example:"team"`
+} // @name UpdateWorkspaceRequest
+
+// UpdateWorkspace godoc
+//
+//	@Summary		Edit a workspace
+//	@Description	Renames or re-aliases the workspace. An empty alias is replaced by one derived from the ID. Personal workspaces follow their user and can't be edited. The old and new values are recorded in the audit log.
+//	@Tags			workspaces
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string					true	"Workspace ID"
+//	@Param			body	body		UpdateWorkspaceRequest	true	"Fields to change"
+//	@Success		200		{object}	WorkspaceActionResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid id / invalid body / nothing to update / personal workspace"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404		{object}	internal.ErrorResponse	"not found"
+//	@Failure		409		{object}	internal.ErrorResponse	"alias already taken"
+//	@Router			/workspaces/{id} [patch]
+func (h *Handler) UpdateWorkspace(c echo.Context) error {
+	in, err := workspaceActionInput(c)
+	if err != nil {
+		return err
+	}
+
+	var body UpdateWorkspaceRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+
+	out, err := h.update.Execute(c.Request().Context(), workspaceuc.UpdateWorkspaceInput{
+		WorkspaceActionInput: in,
+		Name:                 body.Name,
+		Alias:                body.Alias,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newWorkspaceActionResponse(out))
+}
@@ -0,0 +1,62 @@
+package workspace
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+)
+
+// UpdateWorkspaceMemberRequest is the request body for changing the role of a
+// member.
+type UpdateWorkspaceMemberRequest struct {
+	Role string `json:"role" example:"owner" enums:"owner,maintainer,writer,reader"`
+} // @name UpdateWorkspaceMemberRequest
+
+// UpdateWorkspaceMember godoc
+//
+//	@Summary		Change the role of a workspace member
+//	@Description	Changes the role of the member and updates their permittable. A member can be made an owner, which is how a workspace whose owner left gets a new one; the only owner can't be demoted. The old and new roles are recorded in the audit log.
+//	@Tags			workspaces
+//	@Accept			json
+//	@Produce		json
+//	@Param			id		path		string							true	"Workspace ID"
+//	@Param			userId	path		string							true	"User ID of the member"
+//	@Param			body	body		UpdateWorkspaceMemberRequest	true	"New role"
+//	@Success		200		{object}	WorkspaceActionResponse
+//	@Failure		400		{object}	internal.ErrorResponse	"invalid id / invalid role / last owner / personal workspace"
+//	@Failure		401		{object}	internal.ErrorResponse	"unauthorized"
+//	@Failure		403		{object}	internal.ErrorResponse	"not approved / forbidden"
+//	@Failure		404		{object}	internal.ErrorResponse	"workspace or member not found"
+//	@Router			/workspaces/{id}/members/{userId} [patch]
+func (h *Handler) UpdateWorkspaceMember(c echo.Context) error {
+	in, err := workspaceActionInput(c)
+	if err != nil {
+		return err
+	}
+	uid, err := id.UserIDFrom(c.Param("userId"))
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid user id")
+	}
+
+	var body UpdateWorkspaceMemberRequest
+	if err := c.Bind(&body); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid request body")
+	}
+	r, err := role.RoleFrom(body.Role)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, "invalid role")
+	}
+
+	out, err := h.updateMember.Execute(c.Request().Context(), workspaceuc.UpdateWorkspaceMemberInput{
+		WorkspaceActionInput: in,
+		User:                 uid,
+		Role:                 r,
+	})
+	if err != nil {
+		return err
+	}
+	return c.JSON(http.StatusOK, newWorkspaceActionResponse(out))
+}
@@ -1,8 +1,11 @@
 package workspace
 
 import (
+	"strconv"
 	"time"
 
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 )
 
@@ -13,6 +16,7 @@ type WorkspaceResponse struct {
 	Alias       string    `json:"alias"`
 	Personal    bool      `json:"personal"`
 	MemberCount int       `json:"memberCount"`
+	Deactivated bool      `json:"deactivated"`
 	UpdatedAt   time.Time `json:"updatedAt"`
 } // @name Workspace
 
@@ -35,13 +39,90 @@ type WorkspaceMemberResponse struct {
 	Disabled bool   `json:"disabled"`
 } // @name WorkspaceMember
 
+// WorkspaceActionResponse is a workspace after an admin action and the audit
+// log entry of the action.
+type WorkspaceActionResponse struct {
+	Workspace  WorkspaceResponse `json:"workspace"`
+	AuditLogID string            `json:"auditLogId"`
+} // @name WorkspaceActionResponse
+
+// WorkspaceListingRowResponse is a line of an NDJSON workspace listing
+// export: a workspace with the number of its members in each role.
+type WorkspaceListingRowResponse struct {
+	ID               string    `json:"id"`
+	Name             string    `json:"name"`
+	Alias            string    `json:"alias"`
+	Personal         bool      `json:"personal"`
+	Deactivated      bool      `json:"deactivated"`
+	MemberCount      int       `json:"memberCount"`
+	Owners           int       `json:"owners"`
+	Maintainers      int       `json:"maintainers"`
+	Writers          int       `json:"writers"`
+	Readers          int       `json:"readers"`
+	IntegrationCount int       `json:"integrationCount"`
+	UpdatedAt        time.Time `json:"updatedAt"`
+} // @name WorkspaceListingRow
+
+// workspaceListingColumns are the columns of a CSV workspace listing export.
+var workspaceListingColumns = []string{
+	"id", "name", "alias", "personal", "deactivated",
+	"member_count", "owners", "maintainers", "writers", "readers",
+	"integration_count", "updated_at",
+}
+
+func newWorkspaceListingRowResponse(w *workspace.Workspace) WorkspaceListingRowResponse {
+	res := WorkspaceListingRowResponse{
+		ID:          w.ID().String(),
+		Name:        w.Name(),
+		Alias:       w.Alias(),
+		Personal:    w.IsPersonal(),
+		Deactivated: w.IsDeleted(),
+		UpdatedAt:   w.UpdatedAt(),
+	}
+	m := w.Members()
+	if m == nil {
+		return res
+	}
+	res.MemberCount = m.Count()
+	res.IntegrationCount = len(m.Integrations())
+	for _, u := range m.Users() {
+		switch u.Role {
+		case role.RoleOwner:
+			res.Owners++
+		case role.RoleMaintainer:
+			res.Maintainers++
+		case role.RoleWriter:
+			res.Writers++
+		case role.RoleReader:
+			res.Readers++
+		}
+	}
+	return res
+}
+
+func (r WorkspaceListingRowResponse) csvRecord() []string {
+	return []string{
+		r.ID, r.Name, r.Alias,
+		strconv.FormatBool(r.Personal),
+		strconv.FormatBool(r.Deactivated),
+		strconv.Itoa(r.MemberCount),
+		strconv.Itoa(r.Owners),
+		strconv.Itoa(r.Maintainers),
+		strconv.Itoa(r.Writers),
+		strconv.Itoa(r.Readers),
+		strconv.Itoa(r.IntegrationCount),
+		r.UpdatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
 func newWorkspaceResponse(w *workspace.Workspace) WorkspaceResponse {
 	res := WorkspaceResponse{
-		ID:        w.ID().String(),
-		Name:      w.Name(),
-		Alias:     w.Alias(),
-		Personal:  w.IsPersonal(),
-		UpdatedAt: w.UpdatedAt(),
+		ID:          w.ID().String(),
+		Name:        w.Name(),
+		Alias:       w.Alias(),
+		Personal:    w.IsPersonal(),
+		Deactivated: w.IsDeleted(),
+		UpdatedAt:   w.UpdatedAt(),
 	}
 	if m := w.Members(); m != nil {
 		res.MemberCount = m.Count()
@@ -56,3 +137,10 @@ func newWorkspaceResponses(list workspace.List) []WorkspaceResponse {
 	}
 	return items
 }
+
+func newWorkspaceActionResponse(out *workspaceuc.WorkspaceActionOutput) WorkspaceActionResponse {
+	return WorkspaceActionResponse{
+		Workspace:  newWorkspaceResponse(out.Workspace),
+		AuditLogID: out.AuditLog.ID().String(),
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 )
 
@@ -13,6 +14,8 @@ const sessionAdminUserIDKey = "admin:session:adminuserid"
 
 const adminUserContextKey = "admin:session:adminuser"
 
+const sessionIDKey = "admin:session:id"
+
 // SetSessionAdminUserID stores the admin user ID parsed from the session token
 // in the echo context. It must only be called from the session middleware.
 func SetSessionAdminUserID(c echo.Context, id adminuser.ID) {
@@ -28,6 +31,21 @@ func GetSessionAdminUserID(c echo.Context) (adminuser.ID, error) {
 	return adminuser.ID{}, echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
 }
 
+// SetSessionID stores the ID of the server-side session backing the request in
+// the echo context. It must only be called from the session middlewares.
+func SetSessionID(c echo.Context, id adminsession.ID) {
+	c.Set(sessionIDKey, id)
+}
+
+// GetSessionID retrieves the current session ID, returning 401 if absent. It
+// must only be called from handlers behind a session middleware.
+func GetSessionID(c echo.Context) (adminsession.ID, error) {
+	if id, ok := c.Get(sessionIDKey).(adminsession.ID); ok && !id.IsEmpty() {
+		return id, nil
+	}
+	return adminsession.ID{}, echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+}
+
 // SetAdminUser stores the fully-loaded, approved admin user in the echo context.
 // It must only be called from the RequireApproved middleware.
 func SetAdminUser(c echo.Context, u *adminuser.AdminUser) {
@@ -0,0 +1,106 @@
+package internal
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearthx/util"
+)
+
+// ListingFormat is the file format of a listing export.
+type ListingFormat string
+
+const (
+	ListingFormatCSV    ListingFormat = "csv"
+	ListingFormatNDJSON ListingFormat = "ndjson"
+)
+
+// ParseListingFormat parses the format query parameter of a listing export,
+// case-insensitively. An empty value is CSV; any other unknown value is 400.
+func ParseListingFormat(s string) (ListingFormat, error) {
+	switch f := ListingFormat(strings.ToLower(strings.TrimSpace(s))); f {
+	case "":
+		return ListingFormatCSV, nil
+	case ListingFormatCSV, ListingFormatNDJSON:
+		return f, nil
+	}
+	return "", echo.NewHTTPError(http.StatusBadRequest, "invalid format")
+}
+
+// ListingWriter streams the rows of a listing export as a file download: CSV
+// with a header row, or NDJSON with one JSON object per line. Rows go to the
+// response as they are written, so a listing is never held in memory.
+//
+// The response is only committed by the first row (or by Close for an empty
+// listing), so an error returned before that is still rendered by the error
+// handler. An error after that can only cut the download short.
+type ListingWriter struct {
+	c       echo.Context
+	format  ListingFormat
+	name    string
+	columns []string
+
+	csv     *csv.Writer
+	json    *json.Encoder
+	started bool
+}
+
+// NewListingWriter returns a ListingWriter that names the download
+// "<name>-<date>.<format>". columns is the CSV header.
+func NewListingWriter(c echo.Context, format ListingFormat, name string, columns []string) *ListingWriter {
+	return &ListingWriter{c: c, format: format, name: name, columns: columns}
+}
+
+// Write writes a row: v as an NDJSON line, or record as a CSV row.
+func (w *ListingWriter) Write(v any, record []string) error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.format == ListingFormatNDJSON {
+		return w.json.Encode(v)
+	}
+	return w.csv.Write(record)
+}
+
+// Close commits an empty listing and flushes the rows written so far.
+func (w *ListingWriter) Close() error {
+	if err := w.start(); err != nil {
+		return err
+	}
+	if w.csv != nil {
+		w.csv.Flush()
+		if err := w.csv.Error(); err != nil {
+			return err
+		}
+	}
+	w.c.Response().Flush()
+	return nil
+}
+
+func (w *ListingWriter) start() error {
+	if w.started {
+		return nil
+	}
+	w.started = true
+
+	res := w.c.Response()
+	contentType := "text/csv; charset=utf-8"
+	if w.format == ListingFormatNDJSON {
+		contentType = "application/x-ndjson"
+	}
+	name := fmt.Sprintf("%s-%s.%s", w.name, util.Now().Format("20060102"), w.format)
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, fmt.Sprintf("attachment; filename=%q", name))
+	res.WriteHeader(http.StatusOK)
+
+	if w.format == ListingFormatNDJSON {
+		w.json = json.NewEncoder(res)
+		return nil
+	}
+	w.csv = csv.NewWriter(res)
+	return w.csv.Write(w.columns)
+}
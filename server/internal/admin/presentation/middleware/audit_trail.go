@@ -0,0 +1,190 @@
+package middleware
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminaudituc"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/log"
+)
+
+// auditBodyLimit is the largest request body recorded field by field; a larger
+// or non-JSON body is noted as omitted.
+const auditBodyLimit = 64 << 10
+
+// auditRedacted replaces the values of body fields that look like secrets.
+const auditRedacted = "[redacted]"
+
+// AuditTrailMiddleware is a named type so Wire can distinguish it from the
+// other middlewares (all echo.MiddlewareFunc underneath).
+type AuditTrailMiddleware echo.MiddlewareFunc
+
+// NewAuditTrailMiddleware builds middleware that appends an admin audit record
+// for every mutating request made by a signed-in admin. It must be placed
+// outside RequireApproved or SessionMiddleware so the admin user is in the
+// context once the route returns. The error of the route is rendered here so
+// the recorded status is the one sent to the client.
+//
+// The response is held back until the record is appended. When that fails the
+// client gets a 500 instead, so no admin write is reported as done without a
+// record of it; what the route changed is in its auditlog entry either way.
+func NewAuditTrailMiddleware(record *adminaudituc.RecordUseCase) AuditTrailMiddleware {
+	return AuditTrailMiddleware(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			switch req.Method {
+			case http.MethodGet, http.MethodHead, http.MethodOptions:
+				return next(c)
+			}
+
+			body := peekBody(req)
+			res := c.Response()
+			w := res.Writer
+			buf := newBufferedResponse(w.Header())
+			res.Writer = buf
+			if err := next(c); err != nil {
+				c.Error(err)
+			}
+			res.Writer = w
+
+			actor, ok := auditActor(c)
+			if !ok {
+				// rejected before the admin user was loaded
+				return buf.flush(w)
+			}
+
+			ctx := req.Context()
+			r, err := record.Execute(ctx, adminaudituc.RecordInput{
+				Actor:     actor,
+				Action:    req.Method + " " + c.Path(),
+				Target:    c.Param("id"),
+				RequestID: requestID(c),
+				IP:        c.RealIP(),
+				Status:    res.Status,
+				Diff:      auditDiff(c, req.Header.Get(echo.HeaderContentType), body),
+			})
+			if err != nil {
+				log.Errorfc(ctx, "[admin] failed to record admin audit for %s %s: %v", req.Method, c.Path(), err)
+				// drop what the route wrote so the error can be rendered
+				res.Committed, res.Status, res.Size = false, http.StatusOK, 0
+				c.Error(echo.NewHTTPError(http.StatusInternalServerError, "failed to record the request in the audit trail"))
+				return nil
+			}
+			log.Debugfc(ctx, "[admin] admin audit record %d: %s", r.Seq(), r.Action())
+			return buf.flush(w)
+		}
+	})
+}
+
+// bufferedResponse holds the headers, status and body the route writes until
+// flush sends them.
+type bufferedResponse struct {
+	header http.Header
+	status int
+	body   bytes.Buffer
+}
+
+func newBufferedResponse(h http.Header) *bufferedResponse {
+	return &bufferedResponse{header: h.Clone(), status: http.StatusOK}
+}
+
+func (b *bufferedResponse) Header() http.Header { return b.header }
+
+func (b *bufferedResponse) WriteHeader(status int) { b.status = status }
+
+func (b *bufferedResponse) Write(p []byte) (int, error) { return b.body.Write(p) }
+
+func (b *bufferedResponse) flush(w http.ResponseWriter) error {
+	h := w.Header()
+	clear(h)
+	for k, v := range b.header {
+		h[k] = v
+	}
+	w.WriteHeader(b.status)
+	_, err := b.body.WriteTo(w)
+	return err
+}
+
+// auditActor is the admin user RequireApproved loaded or, on the routes open
+// to admins of any status, the one SessionMiddleware authenticated.
+func auditActor(c echo.Context) (adminuser.ID, bool) {
+	if u, err := internal.GetAdminUser(c); err == nil {
+		return u.ID(), true
+	}
+	if id, err := internal.GetSessionAdminUserID(c); err == nil {
+		return id, true
+	}
+	return adminuser.ID{}, false
+}
+
+// peekBody reads up to auditBodyLimit+1 bytes of the request body and puts
+// them back in front of the rest, so the route still reads the whole body.
+func peekBody(req *http.Request) []byte {
+	if req.Body == nil {
+		return nil
+	}
+	buf, _ := io.ReadAll(io.LimitReader(req.Body, auditBodyLimit+1))
+	req.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(buf), req.Body), req.Body}
+	return buf
+}
+
+func requestID(c echo.Context) string {
+	if id := c.Response().Header().Get(echo.HeaderXRequestID); id != "" {
+		return id
+	}
+	return c.Request().Header.Get(echo.HeaderXRequestID)
+}
+
+// auditDiff collects the path parameters other than id, and the top-level
+// fields of a JSON object body as compact JSON.
+func auditDiff(c echo.Context, contentType string, body []byte) map[string]string {
+	diff := map[string]string{}
+	for i, name := range c.ParamNames() {
+		if name == "id" || i >= len(c.ParamValues()) {
+			continue
+		}
+		diff["path."+name] = c.ParamValues()[i]
+	}
+
+	if len(bytes.TrimSpace(body)) == 0 {
+		return diff
+	}
+	var fields map[string]json.RawMessage
+	if len(body) > auditBodyLimit ||
+		!strings.HasPrefix(contentType, echo.MIMEApplicationJSON) ||
+		json.Unmarshal(body, &fields) != nil {
+		diff["body"] = "omitted"
+		return diff
+	}
+	for k, v := range fields {
+		if isSecretField(k) {
+			diff[k] = auditRedacted
+			continue
+		}
+		var b bytes.Buffer
+		if err := json.Compact(&b, v); err != nil {
+			continue
+		}
+		diff[k] = b.String()
+	}
+	return diff
+}
+
+func isSecretField(name string) bool {
+	n := strings.ToLower(name)
+	for _, s := range []string{"password", "secret", "token", "credential"} {
+		if strings.Contains(n, s) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,170 @@
+package middleware_test
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminaudituc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serveAudited routes one request through the audit trail and a stand-in for
+// RequireApproved that loads op, when given.
+func serveAudited(t *testing.T, repo adminaudit.Repo, op *adminuser.AdminUser, method, path, route, body string, h echo.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	e := echo.New()
+	e.Use(func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			c.Response().Header().Set(echo.HeaderXRequestID, "req-1")
+			return next(c)
+		}
+	})
+	approved := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if op == nil {
+				return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+			}
+			internal.SetAdminUser(c, op)
+			return next(c)
+		}
+	}
+	audit := echo.MiddlewareFunc(mw.NewAuditTrailMiddleware(adminaudituc.NewRecordUseCase(repo)))
+	g := e.Group("", audit, approved)
+	g.Add(method, route, h)
+
+	req := httptest.NewRequest(method, path, strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func records(t *testing.T, repo adminaudit.Repo) adminaudit.List {
+	t.Helper()
+	l, err := repo.FindAfter(context.Background(), 0, 0)
+	require.NoError(t, err)
+	return l
+}
+
+func TestAuditTrail_RecordsWrite(t *testing.T) {
+	repo := memory.NewAdminAudit()
+	op := adminuser.New().NewID().Email("op@eukarya.io").Name("op").Status(adminuser.StatusApproved).MustBuild()
+
+	var seen string
+	rec := serveAudited(t, repo, op, http.MethodPatch, "/users/u1/members/m1", "/users/:id/members/:userId",
+		`{"name": "Alice", "password":"hunter2", "tags":[1, 2]}`,
+		func(c echo.Context) error {
+			b, _ := io.ReadAll(c.Request().Body)
+			seen = string(b)
+			return c.NoContent(http.StatusNoContent)
+		})
+	require.Equal(t, http.StatusNoContent, rec.Code)
+	assert.Contains(t, seen, "hunter2", "the route still reads the whole body")
+
+	l := records(t, repo)
+	require.Len(t, l, 1)
+	r := l[0]
+	assert.Equal(t, op.ID(), r.Actor())
+	assert.Equal(t, "PATCH /users/:id/members/:userId", r.Action())
+	assert.Equal(t, "u1", r.Target())
+	assert.Equal(t, "req-1", r.RequestID())
+	assert.Equal(t, http.StatusNoContent, r.Status())
+	assert.Equal(t, map[string]string{
+		"path.userId": "m1",
+		"name":        `"Alice"`,
+		"password":    "[redacted]",
+		"tags":        "[1,2]",
+	}, r.Diff())
+}
+
+func TestAuditTrail_RecordsErrorStatus(t *testing.T) {
+	repo := memory.NewAdminAudit()
+	op := adminuser.New().NewID().Email("op@eukarya.io").Name("op").Status(adminuser.StatusApproved).MustBuild()
+
+	rec := serveAudited(t, repo, op, http.MethodPost, "/users/u1/deactivate", "/users/:id/deactivate", "",
+		func(c echo.Context) error {
+			return echo.NewHTTPError(http.StatusConflict, "already deactivated")
+		})
+	require.Equal(t, http.StatusConflict, rec.Code)
+
+	l := records(t, repo)
+	require.Len(t, l, 1)
+	assert.Equal(t, http.StatusConflict, l[0].Status())
+	assert.Empty(t, l[0].Diff())
+}
+
+func TestAuditTrail_Skips(t *testing.T) {
+	repo := memory.NewAdminAudit()
+	op := adminuser.New().NewID().Email("op@eukarya.io").Name("op").Status(adminuser.StatusApproved).MustBuild()
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+
+	// reads are not recorded
+	rec := serveAudited(t, repo, op, http.MethodGet, "/users/u1", "/users/:id", "", ok)
+	require.Equal(t, http.StatusOK, rec.Code)
+	// nor requests rejected before an admin user was loaded
+	rec = serveAudited(t, repo, nil, http.MethodDelete, "/users/u1", "/users/:id", "", ok)
+	require.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	assert.Empty(t, records(t, repo))
+}
+
+func TestAuditTrail_SessionOnlyRoute(t *testing.T) {
+	repo := memory.NewAdminAudit()
+	op := adminuser.NewID()
+
+	e := echo.New()
+	session := func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			internal.SetSessionAdminUserID(c, op)
+			return next(c)
+		}
+	}
+	audit := echo.MiddlewareFunc(mw.NewAuditTrailMiddleware(adminaudituc.NewRecordUseCase(repo)))
+	e.DELETE("/auth/sessions/:id", func(c echo.Context) error {
+		return c.NoContent(http.StatusNoContent)
+	}, audit, session)
+
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodDelete, "/auth/sessions/s1", nil))
+	require.Equal(t, http.StatusNoContent, rec.Code)
+
+	l := records(t, repo)
+	require.Len(t, l, 1)
+	assert.Equal(t, op, l[0].Actor())
+	assert.Equal(t, "DELETE /auth/sessions/:id", l[0].Action())
+	assert.Equal(t, "s1", l[0].Target())
+}
+
+// failingAudit is an audit repo whose appends fail.
+type failingAudit struct{ adminaudit.Repo }
+
+func (failingAudit) Append(context.Context, *adminaudit.Record) error {
+	return errors.New("audit store unavailable")
+}
+
+func TestAuditTrail_FailsWhenNotRecorded(t *testing.T) {
+	repo := failingAudit{memory.NewAdminAudit()}
+	op := adminuser.New().NewID().Email("op@eukarya.io").Name("op").Status(adminuser.StatusApproved).MustBuild()
+
+	rec := serveAudited(t, repo, op, http.MethodPost, "/users/u1/deactivate", "/users/:id/deactivate", "",
+		func(c echo.Context) error {
+			c.Response().Header().Set("X-Route", "1")
+			return c.JSON(http.StatusOK, map[string]string{"id": "u1"})
+		})
+	assert.Equal(t, http.StatusInternalServerError, rec.Code)
+	assert.NotContains(t, rec.Body.String(), "u1", "the response of the route is not sent")
+	assert.Empty(t, rec.Header().Get("X-Route"))
+	assert.Equal(t, "req-1", rec.Header().Get(echo.HeaderXRequestID))
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
@@ -17,32 +18,22 @@ import (
 type RequireApprovedMiddleware echo.MiddlewareFunc
 
 // NewRequireApprovedMiddleware builds middleware that authenticates via the
-// admin_session cookie AND requires the admin user to be approved. It loads the
-// user on every request (so a revoked admin loses access immediately) and
-// stores it in the context as the operator. Unauthenticated → 401; a
-// non-approved (pending/rejected) user → 403.
-func NewRequireApprovedMiddleware(sess *session.Manager, repo adminuser.Repo) RequireApprovedMiddleware {
+// admin_session cookie AND requires the admin user to be approved. It checks
+// the session store and loads the user on every request (so a revoked session
+// or admin loses access immediately) and stores the user in the context as the
+// operator. Unauthenticated → 401; a non-approved (pending/rejected) user →
+// 403.
+func NewRequireApprovedMiddleware(sess *session.Manager, sessions adminsession.Repo, repo adminuser.Repo) RequireApprovedMiddleware {
 	return RequireApprovedMiddleware(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			ctx := c.Request().Context()
 
-			cookie, err := c.Cookie(session.CookieName)
-			if err != nil || cookie.Value == "" {
-				return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
-			}
-
-			id, err := sess.Parse(cookie.Value)
+			claims, err := authenticate(c, sess, sessions)
 			if err != nil {
-				// An empty signing secret is a server misconfiguration, not a
-				// client auth failure — surface it as 500 so it isn't hidden.
-				if errors.Is(err, session.ErrEmptySecret) {
-					log.Errorfc(ctx, "[admin] session secret not configured: %v", err)
-					return echo.NewHTTPError(http.StatusInternalServerError)
-				}
-				return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+				return err
 			}
 
-			u, err := repo.FindByID(ctx, id)
+			u, err := repo.FindByID(ctx, claims.AdminUser)
 			if err != nil {
 				if errors.Is(err, rerror.ErrNotFound) {
 					return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
@@ -55,6 +46,7 @@ func NewRequireApprovedMiddleware(sess *session.Manager, repo adminuser.Repo) Re
 				return echo.NewHTTPError(http.StatusForbidden, "not approved")
 			}
 
+			internal.SetSessionID(c, claims.Session)
 			internal.SetAdminUser(c, u)
 			return next(c)
 		}
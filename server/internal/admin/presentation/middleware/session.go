@@ -3,11 +3,14 @@ package middleware
 import (
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
 	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
 )
 
 // SessionMiddleware is a named type so Wire can distinguish it from the Auth0
@@ -15,31 +18,59 @@ import (
 type SessionMiddleware echo.MiddlewareFunc
 
 // NewSessionMiddleware builds middleware that authenticates a request from the
-// admin_session cookie: it validates the session token and stores the admin
-// user ID in the context. It does NOT enforce approval status (any status
-// passes) — approval gating is applied per-route in a later unit. Missing or
-// invalid cookies yield 401.
-func NewSessionMiddleware(sess *session.Manager) SessionMiddleware {
+// admin_session cookie: it validates the session token, checks the session it
+// names is still live in the store, and stores the admin user and session IDs
+// in the context. It does NOT enforce approval status (any status passes) —
+// approval gating is applied per-route in a later unit. Missing or invalid
+// cookies and revoked sessions yield 401.
+func NewSessionMiddleware(sess *session.Manager, sessions adminsession.Repo) SessionMiddleware {
 	return SessionMiddleware(func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			cookie, err := c.Cookie(session.CookieName)
-			if err != nil || cookie.Value == "" {
-				return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
-			}
-
-			id, err := sess.Parse(cookie.Value)
+			claims, err := authenticate(c, sess, sessions)
 			if err != nil {
-				// An empty signing secret is a server misconfiguration, not a
-				// client auth failure — surface it as 500 so it isn't hidden.
-				if errors.Is(err, session.ErrEmptySecret) {
-					log.Errorfc(c.Request().Context(), "[admin] session secret not configured: %v", err)
-					return echo.NewHTTPError(http.StatusInternalServerError)
-				}
-				return echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+				return err
 			}
 
-			internal.SetSessionAdminUserID(c, id)
+			internal.SetSessionAdminUserID(c, claims.AdminUser)
+			internal.SetSessionID(c, claims.Session)
 			return next(c)
 		}
 	})
 }
+
+// authenticate parses the admin_session cookie and looks up the server-side
+// session it names. The session must exist, belong to the token's admin user
+// and not have expired; otherwise the request is unauthorized, which is how a
+// revoked session or a rejected admin loses access before the token expires.
+func authenticate(c echo.Context, sess *session.Manager, sessions adminsession.Repo) (session.Claims, error) {
+	ctx := c.Request().Context()
+
+	cookie, err := c.Cookie(session.CookieName)
+	if err != nil || cookie.Value == "" {
+		return session.Claims{}, echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	}
+
+	claims, err := sess.Parse(cookie.Value)
+	if err != nil {
+		// An empty signing secret is a server misconfiguration, not a
+		// client auth failure — surface it as 500 so it isn't hidden.
+		if errors.Is(err, session.ErrEmptySecret) {
+			log.Errorfc(ctx, "[admin] session secret not configured: %v", err)
+			return session.Claims{}, echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		return session.Claims{}, echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	}
+
+	s, err := sessions.FindByID(ctx, claims.Session)
+	if err != nil {
+		if errors.Is(err, rerror.ErrNotFound) {
+			return session.Claims{}, echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+		}
+		log.Errorfc(ctx, "[admin] error loading admin session: %v", err)
+		return session.Claims{}, echo.NewHTTPError(http.StatusInternalServerError)
+	}
+	if s.AdminUser() != claims.AdminUser || s.IsExpired(time.Now()) {
+		return session.Claims{}, echo.NewHTTPError(http.StatusUnauthorized, "unauthorized")
+	}
+	return claims, nil
+}
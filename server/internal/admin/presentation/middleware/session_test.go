@@ -0,0 +1,71 @@
+package middleware_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	"github.com/reearth/reearth-accounts/server/internal/admin/presentation/internal"
+	mw "github.com/reearth/reearth-accounts/server/internal/admin/presentation/middleware"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSessionMiddleware(t *testing.T) {
+	sess := session.NewManager("test-secret-test-secret-test-secret", time.Hour)
+	admin := adminuser.NewID()
+	now := time.Now()
+	live := adminsession.New().NewID().AdminUser(admin).CreatedAt(now).ExpiresAt(now.Add(time.Hour)).MustBuild()
+	expired := adminsession.New().NewID().AdminUser(admin).CreatedAt(now.Add(-time.Hour)).ExpiresAt(now.Add(-time.Minute)).MustBuild()
+	sessions := memory.NewAdminSessionWith(live, expired)
+	m := mw.NewSessionMiddleware(sess, sessions)
+
+	serve := func(t *testing.T, id adminuser.ID, sid adminsession.ID) (echo.Context, error) {
+		t.Helper()
+		tok, err := sess.Issue(id, sid, now)
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.AddCookie(&http.Cookie{Name: session.CookieName, Value: tok})
+		c := echo.New().NewContext(req, httptest.NewRecorder())
+		return c, m(func(c echo.Context) error { return nil })(c)
+	}
+
+	t.Run("live session", func(t *testing.T) {
+		c, err := serve(t, admin, live.ID())
+		require.NoError(t, err)
+		got, err := internal.GetSessionID(c)
+		require.NoError(t, err)
+		assert.Equal(t, live.ID(), got)
+	})
+
+	for name, tc := range map[string]struct {
+		admin adminuser.ID
+		sid   adminsession.ID
+	}{
+		"revoked session":       {admin, adminsession.NewID()},
+		"expired session":       {admin, expired.ID()},
+		"another admin's token": {adminuser.NewID(), live.ID()},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := serve(t, tc.admin, tc.sid)
+			var httpErr *echo.HTTPError
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+		})
+	}
+
+	t.Run("revoked after issue", func(t *testing.T) {
+		require.NoError(t, sessions.Remove(context.Background(), live.ID()))
+		_, err := serve(t, admin, live.ID())
+		var httpErr *echo.HTTPError
+		require.ErrorAs(t, err, &httpErr)
+		assert.Equal(t, http.StatusUnauthorized, httpErr.Code)
+	})
+}
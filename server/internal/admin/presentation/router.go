@@ -18,14 +18,21 @@ func RegisterRoutes(e *echo.Echo, h *Handler) {
 	v1 := api.Group("/v1")
 	{
 		sessionMw := echo.MiddlewareFunc(h.SessionMw)
+		audit := echo.MiddlewareFunc(h.AuditTrail)
 
-		// Auth. Sign-in and logout are public: logout only clears the cookie,
-		// and it must work even when the session token is expired/invalid since
-		// the browser cannot delete an HttpOnly cookie itself.
+		// Auth. Sign-in and logout are public: logout must clear the cookie
+		// even when the session token is expired/invalid since the browser
+		// cannot delete an HttpOnly cookie itself.
 		authg := v1.Group("/auth")
 		authg.POST("/google", h.Auth.GoogleSignIn)
+		authg.POST("/oidc", h.Auth.OIDCSignIn)
 		authg.POST("/logout", h.Auth.Logout)
 
+		// The current admin user's own sessions (any status). Revoking one is
+		// recorded in the audit trail like the other admin writes.
+		authg.GET("/sessions", h.Auth.ListSessions, sessionMw)
+		authg.DELETE("/sessions/:id", h.Auth.RevokeSession, audit, sessionMw)
+
 		// Current admin user (any status)
 		v1.GET("/me", h.Auth.Me, sessionMw)
 
@@ -33,23 +40,95 @@ func RegisterRoutes(e *echo.Echo, h *Handler) {
 		// requireApproved loads the AdminUser (and its role) into the context;
 		// each route then adds a per-route RequirePermission that authorizes the
 		// route's (resource, action) against the admin Cerbos policy.
+		//
+		// Every group behind requireApproved is also wrapped by the audit trail,
+		// which records each mutating request of an approved admin once the
+		// route has run, and fails the request when the record cannot be
+		// appended. It must stay outside requireApproved.
 		requireApproved := echo.MiddlewareFunc(h.RequireApproved)
-		adminUsers := v1.Group("/admin-users", requireApproved)
+		adminUsers := v1.Group("/admin-users", audit, requireApproved)
 		adminUsers.GET("", h.AdminUser.ListAdminUsers, mw.RequirePermission(h.Checker, adminrbac.ResourceAdminUser, adminrbac.ActionList))
 		adminUsers.POST("/:id/approve", h.AdminUser.ApproveAdminUser, mw.RequirePermission(h.Checker, adminrbac.ResourceAdminUser, adminrbac.ActionApprove))
 		adminUsers.POST("/:id/reject", h.AdminUser.RejectAdminUser, mw.RequirePermission(h.Checker, adminrbac.ResourceAdminUser, adminrbac.ActionReject))
 		adminUsers.PUT("/:id/roles", h.AdminUser.SetAdminUserRole, mw.RequirePermission(h.Checker, adminrbac.ResourceAdminUser, adminrbac.ActionAssignRole))
 
 		// Users (requires an approved admin session)
-		users := v1.Group("/users", requireApproved)
+		users := v1.Group("/users", audit, requireApproved)
 		users.GET("", h.User.ListUsers, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionList))
+		users.POST("/import", h.User.ImportUsers, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionImport))
+		users.GET("/export", h.User.ExportUsers, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionExport))
+		users.GET("/listing-export", h.User.ExportUserListing, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionExport))
 		users.GET("/:id", h.User.GetUser, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionRead))
 		users.GET("/:id/workspaces", h.User.GetUserWorkspaces, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionRead))
+		users.POST("/:id/merge", h.User.MergeUser, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionMerge))
+		users.POST("/:id/data-export", h.User.ExportUserData, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionExport))
+		users.PATCH("/:id", h.User.UpdateUser, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionEdit))
+		users.POST("/:id/deactivate", h.User.DeactivateUser, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionDeactivate))
+		users.POST("/:id/restore", h.User.RestoreUser, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionRestore))
+		users.POST("/:id/verify-email", h.User.VerifyUserEmail, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionVerifyEmail))
+		users.POST("/:id/password-reset", h.User.ResetUserPassword, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionResetPassword))
+		users.DELETE("/:id/auths/:sub", h.User.RemoveUserAuth, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionRemoveAuth))
+		users.DELETE("/:id/mfa", h.User.ResetUserMFA, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionResetMFA))
+		users.POST("/:id/impersonate", h.User.ImpersonateUser, mw.RequirePermission(h.Checker, adminrbac.ResourceUser, adminrbac.ActionImpersonate))
 
-		// Cross-tenant workspace listing (requires an approved admin session)
-		workspaces := v1.Group("/workspaces", requireApproved)
+		// Cross-tenant workspace management (requires an approved admin session)
+		workspaces := v1.Group("/workspaces", audit, requireApproved)
 		workspaces.GET("", h.Workspace.ListWorkspaces, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionList))
+		workspaces.GET("/listing-export", h.Workspace.ExportWorkspaceListing, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionExport))
 		workspaces.GET("/:id", h.Workspace.GetWorkspace, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionRead))
 		workspaces.GET("/:id/members", h.Workspace.GetWorkspaceMembers, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionReadMember))
+		workspaces.PATCH("/:id", h.Workspace.UpdateWorkspace, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionEdit))
+		workspaces.POST("/:id/deactivate", h.Workspace.DeactivateWorkspace, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionDeactivate))
+		workspaces.POST("/:id/restore", h.Workspace.RestoreWorkspace, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionRestore))
+		workspaces.POST("/:id/members", h.Workspace.AddWorkspaceMembers, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionAddMember))
+		workspaces.PATCH("/:id/members/:userId", h.Workspace.UpdateWorkspaceMember, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionEditMember))
+		workspaces.DELETE("/:id/members/:userId", h.Workspace.RemoveWorkspaceMember, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionRemoveMember))
+		workspaces.DELETE("/:id/integrations/:integrationId", h.Workspace.RemoveWorkspaceIntegration, mw.RequirePermission(h.Checker, adminrbac.ResourceWorkspace, adminrbac.ActionRemoveIntegration))
+		workspaces.GET("/:id/role-mapping", h.RoleMapping.GetRoleMapping, mw.RequirePermission(h.Checker, adminrbac.ResourceRoleMapping, adminrbac.ActionRead))
+		workspaces.PUT("/:id/role-mapping", h.RoleMapping.SetRoleMapping, mw.RequirePermission(h.Checker, adminrbac.ResourceRoleMapping, adminrbac.ActionEdit))
+		workspaces.DELETE("/:id/role-mapping", h.RoleMapping.DeleteRoleMapping, mw.RequirePermission(h.Checker, adminrbac.ResourceRoleMapping, adminrbac.ActionDelete))
+
+		// Platform roles and their bindings to users (requires an approved admin
+		// session). A binding is global, or scoped to a workspace on the
+		// /workspaces/:workspaceId routes.
+		roles := v1.Group("/roles", audit, requireApproved)
+		roles.GET("", h.PlatformRole.ListRoles, mw.RequirePermission(h.Checker, adminrbac.ResourceRole, adminrbac.ActionList))
+		roles.GET("/:id/users", h.PlatformRole.ListRoleUsers, mw.RequirePermission(h.Checker, adminrbac.ResourceRole, adminrbac.ActionReadMember))
+		roles.PUT("/:id/users/:userId", h.PlatformRole.GrantRole, mw.RequirePermission(h.Checker, adminrbac.ResourceRole, adminrbac.ActionGrant))
+		roles.DELETE("/:id/users/:userId", h.PlatformRole.RevokeRole, mw.RequirePermission(h.Checker, adminrbac.ResourceRole, adminrbac.ActionRevoke))
+		roles.PUT("/:id/workspaces/:workspaceId/users/:userId", h.PlatformRole.GrantRole, mw.RequirePermission(h.Checker, adminrbac.ResourceRole, adminrbac.ActionGrant))
+		roles.DELETE("/:id/workspaces/:workspaceId/users/:userId", h.PlatformRole.RevokeRole, mw.RequirePermission(h.Checker, adminrbac.ResourceRole, adminrbac.ActionRevoke))
+
+		// LDAP directory sync runs (requires an approved admin session)
+		ldapSync := v1.Group("/ldap-sync", audit, requireApproved)
+		ldapSync.GET("/runs", h.LDAPSync.ListLDAPSyncRuns, mw.RequirePermission(h.Checker, adminrbac.ResourceLDAPSync, adminrbac.ActionList))
+		ldapSync.GET("/runs/:id", h.LDAPSync.GetLDAPSyncRun, mw.RequirePermission(h.Checker, adminrbac.ResourceLDAPSync, adminrbac.ActionRead))
+
+		// Token signing key versions (requires an approved admin session)
+		signingKeys := v1.Group("/signing-keys", audit, requireApproved)
+		signingKeys.GET("", h.SigningKey.ListSigningKeys, mw.RequirePermission(h.Checker, adminrbac.ResourceSigningKey, adminrbac.ActionList))
+		signingKeys.POST("/rotate", h.SigningKey.RotateSigningKey, mw.RequirePermission(h.Checker, adminrbac.ResourceSigningKey, adminrbac.ActionRotate))
+
+		// SCIM provisioning tenants (requires an approved admin session)
+		scimTenants := v1.Group("/scim-tenants", audit, requireApproved)
+		scimTenants.GET("", h.SCIMTenant.ListSCIMTenants, mw.RequirePermission(h.Checker, adminrbac.ResourceSCIMTenant, adminrbac.ActionList))
+		scimTenants.POST("", h.SCIMTenant.CreateSCIMTenant, mw.RequirePermission(h.Checker, adminrbac.ResourceSCIMTenant, adminrbac.ActionCreate))
+		scimTenants.POST("/:id/rotate-token", h.SCIMTenant.RotateSCIMTenantToken, mw.RequirePermission(h.Checker, adminrbac.ResourceSCIMTenant, adminrbac.ActionRotate))
+		scimTenants.DELETE("/:id", h.SCIMTenant.DeleteSCIMTenant, mw.RequirePermission(h.Checker, adminrbac.ResourceSCIMTenant, adminrbac.ActionDelete))
+
+		// Dashboard statistics (requires an approved admin session)
+		stats := v1.Group("/stats", audit, requireApproved)
+		stats.GET("", h.Stats.GetStats, mw.RequirePermission(h.Checker, adminrbac.ResourceStats, adminrbac.ActionRead))
+
+		// Automatic admin approval rules (requires an approved admin session)
+		approvalRules := v1.Group("/admin-approval-rules", audit, requireApproved)
+		approvalRules.GET("", h.ApprovalRule.ListApprovalRules, mw.RequirePermission(h.Checker, adminrbac.ResourceAdminApprovalRule, adminrbac.ActionList))
+		approvalRules.POST("", h.ApprovalRule.CreateApprovalRule, mw.RequirePermission(h.Checker, adminrbac.ResourceAdminApprovalRule, adminrbac.ActionCreate))
+		approvalRules.PATCH("/:id", h.ApprovalRule.UpdateApprovalRule, mw.RequirePermission(h.Checker, adminrbac.ResourceAdminApprovalRule, adminrbac.ActionEdit))
+		approvalRules.DELETE("/:id", h.ApprovalRule.DeleteApprovalRule, mw.RequirePermission(h.Checker, adminrbac.ResourceAdminApprovalRule, adminrbac.ActionDelete))
+
+		// Admin audit trail (requires an approved admin session)
+		adminAudit := v1.Group("/admin-audit-records", audit, requireApproved)
+		adminAudit.GET("", h.AdminAudit.ListAdminAuditRecords, mw.RequirePermission(h.Checker, adminrbac.ResourceAdminAuditRecord, adminrbac.ActionList))
 	}
 }
@@ -16,20 +16,46 @@ const (
 )
 
 const (
-	ResourceAdminUser = "admin_user"
-	ResourceUser      = "user"
-	ResourceWorkspace = "workspace"
+	ResourceAdminApprovalRule = "admin_approval_rule"
+	ResourceAdminAuditRecord  = "admin_audit_record"
+	ResourceAdminUser         = "admin_user"
+	ResourceLDAPSync          = "ldap_sync"
+	ResourceRole              = "role"
+	ResourceRoleMapping       = "role_mapping"
+	ResourceSCIMTenant        = "scim_tenant"
+	ResourceSigningKey        = "signing_key"
+	ResourceStats             = "stats"
+	ResourceUser              = "user"
+	ResourceWorkspace         = "workspace"
 )
 
 const (
-	ActionApprove    = "approve"
-	ActionAssignRole = "assign_role"
-	ActionDelete     = "delete"
-	ActionEdit       = "edit"
-	ActionList       = "list"
-	ActionRead       = "read"
-	ActionReadMember = "read_member"
-	ActionReject     = "reject"
+	ActionAddMember         = "add_member"
+	ActionApprove           = "approve"
+	ActionAssignRole        = "assign_role"
+	ActionCreate            = "create"
+	ActionDeactivate        = "deactivate"
+	ActionDelete            = "delete"
+	ActionEdit              = "edit"
+	ActionEditMember        = "edit_member"
+	ActionExport            = "export"
+	ActionGrant             = "grant"
+	ActionImpersonate       = "impersonate"
+	ActionImport            = "import"
+	ActionList              = "list"
+	ActionMerge             = "merge"
+	ActionRead              = "read"
+	ActionReadMember        = "read_member"
+	ActionReject            = "reject"
+	ActionRemoveAuth        = "remove_auth"
+	ActionRemoveIntegration = "remove_integration"
+	ActionRemoveMember      = "remove_member"
+	ActionResetMFA          = "reset_mfa"
+	ActionResetPassword     = "reset_password"
+	ActionRestore           = "restore"
+	ActionRevoke            = "revoke"
+	ActionRotate            = "rotate"
+	ActionVerifyEmail       = "verify_email"
 )
 
 // roleSystemAdmin and roleViewer are the admin console roles. They reference the
@@ -46,6 +72,21 @@ type ResourceRule struct {
 }
 
 var resourceRules = []ResourceRule{
+	{
+		Resource: ResourceAdminApprovalRule,
+		Actions: map[string][]string{
+			ActionList:   {roleSystemAdmin, roleViewer},
+			ActionCreate: {roleSystemAdmin},
+			ActionEdit:   {roleSystemAdmin},
+			ActionDelete: {roleSystemAdmin},
+		},
+	},
+	{
+		Resource: ResourceAdminAuditRecord,
+		Actions: map[string][]string{
+			ActionList: {roleSystemAdmin, roleViewer},
+		},
+	},
 	{
 		Resource: ResourceAdminUser,
 		Actions: map[string][]string{
@@ -56,22 +97,85 @@ var resourceRules = []ResourceRule{
 		},
 	},
 	{
-		Resource: ResourceUser,
+		Resource: ResourceLDAPSync,
+		Actions: map[string][]string{
+			ActionList: {roleSystemAdmin, roleViewer},
+			ActionRead: {roleSystemAdmin, roleViewer},
+		},
+	},
+	{
+		Resource: ResourceRole,
+		Actions: map[string][]string{
+			ActionList:       {roleSystemAdmin, roleViewer},
+			ActionReadMember: {roleSystemAdmin, roleViewer},
+			ActionGrant:      {roleSystemAdmin},
+			ActionRevoke:     {roleSystemAdmin},
+		},
+	},
+	{
+		Resource: ResourceRoleMapping,
 		Actions: map[string][]string{
-			ActionList:   {roleSystemAdmin, roleViewer},
 			ActionRead:   {roleSystemAdmin, roleViewer},
 			ActionEdit:   {roleSystemAdmin},
 			ActionDelete: {roleSystemAdmin},
 		},
 	},
+	{
+		Resource: ResourceSCIMTenant,
+		Actions: map[string][]string{
+			ActionList:   {roleSystemAdmin, roleViewer},
+			ActionCreate: {roleSystemAdmin},
+			ActionRotate: {roleSystemAdmin},
+			ActionDelete: {roleSystemAdmin},
+		},
+	},
+	{
+		Resource: ResourceSigningKey,
+		Actions: map[string][]string{
+			ActionList:   {roleSystemAdmin, roleViewer},
+			ActionRotate: {roleSystemAdmin},
+		},
+	},
+	{
+		Resource: ResourceStats,
+		Actions: map[string][]string{
+			ActionRead: {roleSystemAdmin, roleViewer},
+		},
+	},
+	{
+		Resource: ResourceUser,
+		Actions: map[string][]string{
+			ActionList:          {roleSystemAdmin, roleViewer},
+			ActionRead:          {roleSystemAdmin, roleViewer},
+			ActionEdit:          {roleSystemAdmin},
+			ActionDelete:        {roleSystemAdmin},
+			ActionMerge:         {roleSystemAdmin},
+			ActionImport:        {roleSystemAdmin},
+			ActionExport:        {roleSystemAdmin},
+			ActionDeactivate:    {roleSystemAdmin},
+			ActionRestore:       {roleSystemAdmin},
+			ActionVerifyEmail:   {roleSystemAdmin},
+			ActionResetPassword: {roleSystemAdmin},
+			ActionRemoveAuth:    {roleSystemAdmin},
+			ActionResetMFA:      {roleSystemAdmin},
+			ActionImpersonate:   {roleSystemAdmin},
+		},
+	},
 	{
 		Resource: ResourceWorkspace,
 		Actions: map[string][]string{
-			ActionList:       {roleSystemAdmin, roleViewer},
-			ActionRead:       {roleSystemAdmin, roleViewer},
-			ActionReadMember: {roleSystemAdmin, roleViewer},
-			ActionEdit:       {roleSystemAdmin},
-			ActionDelete:     {roleSystemAdmin},
+			ActionList:              {roleSystemAdmin, roleViewer},
+			ActionRead:              {roleSystemAdmin, roleViewer},
+			ActionReadMember:        {roleSystemAdmin, roleViewer},
+			ActionEdit:              {roleSystemAdmin},
+			ActionDelete:            {roleSystemAdmin},
+			ActionExport:            {roleSystemAdmin},
+			ActionDeactivate:        {roleSystemAdmin},
+			ActionRestore:           {roleSystemAdmin},
+			ActionAddMember:         {roleSystemAdmin},
+			ActionEditMember:        {roleSystemAdmin},
+			ActionRemoveMember:      {roleSystemAdmin},
+			ActionRemoveIntegration: {roleSystemAdmin},
 		},
 	},
 }
@@ -0,0 +1,24 @@
+package adminaudituc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// ListUseCase lists admin audit records, newest first, with offset
+// pagination.
+type ListUseCase struct {
+	repo adminaudit.Repo
+}
+
+// NewListUseCase is a Wire provider for ListUseCase.
+func NewListUseCase(repo adminaudit.Repo) *ListUseCase {
+	return &ListUseCase{repo: repo}
+}
+
+// Execute returns the records matching the filter and pagination info.
+func (uc *ListUseCase) Execute(ctx context.Context, filter adminaudit.ListFilter) (adminaudit.List, *usecasex.PageInfo, error) {
+	return uc.repo.List(ctx, filter)
+}
@@ -0,0 +1,78 @@
+// Package adminaudituc holds the usecases of the admin audit trail: appending a
+// record for each admin API write, listing the records and verifying that the
+// hash chain over them is intact.
+package adminaudituc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// maxAppendAttempts bounds how often Execute re-reads the head of the chain
+// after losing an append race to a concurrent request.
+const maxAppendAttempts = 5
+
+// RecordInput describes one admin API write.
+type RecordInput struct {
+	Actor     adminuser.ID
+	Action    string
+	Target    string
+	RequestID string
+	IP        string
+	Status    int
+	Diff      map[string]string
+}
+
+// RecordUseCase appends a record to the end of the admin audit chain.
+type RecordUseCase struct {
+	repo adminaudit.Repo
+}
+
+// NewRecordUseCase is a Wire provider for RecordUseCase.
+func NewRecordUseCase(repo adminaudit.Repo) *RecordUseCase {
+	return &RecordUseCase{repo: repo}
+}
+
+// Execute links a new record to the current head of the chain and appends it.
+// When another request appends first, the head is read again and the record
+// relinked, up to maxAppendAttempts times.
+func (uc *RecordUseCase) Execute(ctx context.Context, in RecordInput) (*adminaudit.Record, error) {
+	rid := adminaudit.NewID()
+	var err error
+	for range maxAppendAttempts {
+		var prev *adminaudit.Record
+		prev, err = uc.repo.FindLast(ctx)
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return nil, err
+		}
+
+		var r *adminaudit.Record
+		r, err = adminaudit.New().
+			ID(rid).
+			Chain(prev).
+			Actor(in.Actor).
+			Action(in.Action).
+			Target(in.Target).
+			RequestID(in.RequestID).
+			IP(in.IP).
+			Status(in.Status).
+			Diff(in.Diff).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
+		err = uc.repo.Append(ctx, r)
+		if err == nil {
+			return r, nil
+		}
+		if !errors.Is(err, adminaudit.ErrSeqTaken) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
@@ -0,0 +1,66 @@
+package adminaudituc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRecord_Chains(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewAdminAudit()
+	uc := NewRecordUseCase(repo)
+	actor := adminuser.NewID()
+
+	first, err := uc.Execute(ctx, RecordInput{Actor: actor, Action: "PATCH /api/v1/users/:id", Target: "u1", Status: 200})
+	require.NoError(t, err)
+	second, err := uc.Execute(ctx, RecordInput{Actor: actor, Action: "DELETE /api/v1/users/:id", Target: "u2", Status: 204})
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), first.Seq())
+	assert.Empty(t, first.PrevHash())
+	assert.Equal(t, int64(2), second.Seq())
+	assert.Equal(t, first.Hash(), second.PrevHash())
+}
+
+// racingRepo appends a record of another request right before the first
+// Append, as a concurrent admin write would.
+type racingRepo struct {
+	*memory.AdminAudit
+	raced bool
+}
+
+func (r *racingRepo) Append(ctx context.Context, rec *adminaudit.Record) error {
+	if !r.raced {
+		r.raced = true
+		prev, _ := r.FindLast(ctx)
+		other := adminaudit.New().NewID().Chain(prev).Actor(adminuser.NewID()).Action("POST /api/v1/users/import").MustBuild()
+		if err := r.AdminAudit.Append(ctx, other); err != nil {
+			return err
+		}
+	}
+	return r.AdminAudit.Append(ctx, rec)
+}
+
+func TestRecord_RelinksAfterLostRace(t *testing.T) {
+	ctx := context.Background()
+	repo := &racingRepo{AdminAudit: memory.NewAdminAudit()}
+
+	got, err := NewRecordUseCase(repo).Execute(ctx, RecordInput{Actor: adminuser.NewID(), Action: "PATCH /api/v1/users/:id"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), got.Seq())
+
+	all, err := repo.FindAfter(ctx, 0, 0)
+	require.NoError(t, err)
+	assert.NoError(t, adminaudit.Verify(nil, all))
+}
+
+func TestRecord_Invalid(t *testing.T) {
+	_, err := NewRecordUseCase(memory.NewAdminAudit()).Execute(context.Background(), RecordInput{Action: "PATCH /api/v1/users/:id"})
+	assert.ErrorIs(t, err, adminaudit.ErrEmptyActor)
+}
@@ -0,0 +1,52 @@
+package adminaudituc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+)
+
+// verifyBatchSize is how many records are read from the store at a time.
+const verifyBatchSize = 1000
+
+// VerifyOutput summarizes an intact chain. Head is the last record, nil when
+// the chain is empty; noting its hash lets a later run tell whether records
+// were cut off the end.
+type VerifyOutput struct {
+	Count int64
+	Head  *adminaudit.Record
+}
+
+// VerifyUseCase walks the whole admin audit chain and checks every link.
+type VerifyUseCase struct {
+	repo adminaudit.Repo
+}
+
+// NewVerifyUseCase is a Wire provider for VerifyUseCase.
+func NewVerifyUseCase(repo adminaudit.Repo) *VerifyUseCase {
+	return &VerifyUseCase{repo: repo}
+}
+
+// Execute returns the summary of an intact chain, or an *adminaudit.ChainError
+// at the first record that is missing, edited or not linked to its
+// predecessor.
+func (uc *VerifyUseCase) Execute(ctx context.Context) (*VerifyOutput, error) {
+	out := &VerifyOutput{}
+	for {
+		batch, err := uc.repo.FindAfter(ctx, out.Head.Seq(), verifyBatchSize)
+		if err != nil {
+			return nil, err
+		}
+		if err := adminaudit.Verify(out.Head, batch); err != nil {
+			return nil, err
+		}
+		if len(batch) == 0 {
+			return out, nil
+		}
+		out.Count += int64(len(batch))
+		out.Head = batch[len(batch)-1]
+		if len(batch) < verifyBatchSize {
+			return out, nil
+		}
+	}
+}
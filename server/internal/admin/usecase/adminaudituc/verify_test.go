@@ -0,0 +1,75 @@
+package adminaudituc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerify_Intact(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewAdminAudit()
+
+	out, err := NewVerifyUseCase(repo).Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), out.Count)
+	assert.Nil(t, out.Head)
+
+	record := NewRecordUseCase(repo)
+	var last *adminaudit.Record
+	for range 3 {
+		last, err = record.Execute(ctx, RecordInput{Actor: adminuser.NewID(), Action: "PATCH /api/v1/users/:id"})
+		require.NoError(t, err)
+	}
+
+	out, err = NewVerifyUseCase(repo).Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), out.Count)
+	assert.Equal(t, last.Hash(), out.Head.Hash())
+}
+
+func TestVerify_Breaches(t *testing.T) {
+	ctx := context.Background()
+	actor := adminuser.NewID()
+	first := adminaudit.New().NewID().Chain(nil).Actor(actor).Action("PATCH /api/v1/users/:id").Target("u1").MustBuild()
+
+	tests := []struct {
+		name string
+		next *adminaudit.Record
+		want adminaudit.ChainError
+	}{
+		{
+			name: "gap",
+			next: adminaudit.New().NewID().Seq(3).PrevHash(first.Hash()).Actor(actor).Action("PATCH /api/v1/users/:id").MustBuild(),
+			want: adminaudit.ChainError{Seq: 2, Breach: adminaudit.BreachGap},
+		},
+		{
+			name: "edited",
+			next: adminaudit.New().NewID().Chain(first).Actor(actor).Action("PATCH /api/v1/users/:id").Hash("0000").MustBuild(),
+			want: adminaudit.ChainError{Seq: 2, Breach: adminaudit.BreachEdited},
+		},
+		{
+			name: "link",
+			next: adminaudit.New().NewID().Seq(2).PrevHash("0000").Actor(actor).Action("PATCH /api/v1/users/:id").MustBuild(),
+			want: adminaudit.ChainError{Seq: 2, Breach: adminaudit.BreachLink},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewAdminAudit()
+			require.NoError(t, repo.Append(ctx, first))
+			require.NoError(t, repo.Append(ctx, tt.next))
+
+			_, err := NewVerifyUseCase(repo).Execute(ctx)
+			var ce *adminaudit.ChainError
+			require.ErrorAs(t, err, &ce)
+			assert.Equal(t, tt.want, *ce)
+			assert.ErrorIs(t, err, adminaudit.ErrChainBroken)
+		})
+	}
+}
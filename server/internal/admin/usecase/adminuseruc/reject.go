@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
@@ -11,16 +12,18 @@ import (
 
 // RejectAdminUserUseCase rejects a pending admin user or revokes an approved one.
 type RejectAdminUserUseCase struct {
-	repo adminuser.Repo
+	repo     adminuser.Repo
+	sessions adminsession.Repo
 }
 
 // NewRejectAdminUserUseCase is a Wire provider for RejectAdminUserUseCase.
-func NewRejectAdminUserUseCase(repo adminuser.Repo) *RejectAdminUserUseCase {
-	return &RejectAdminUserUseCase{repo: repo}
+func NewRejectAdminUserUseCase(repo adminuser.Repo, sessions adminsession.Repo) *RejectAdminUserUseCase {
+	return &RejectAdminUserUseCase{repo: repo, sessions: sessions}
 }
 
-// Execute rejects/revokes the target admin user. An admin cannot reject their
-// own account, and the last remaining approved admin cannot be rejected.
+// Execute rejects/revokes the target admin user and ends all of their
+// sessions. An admin cannot reject their own account, and the last remaining
+// approved admin cannot be rejected.
 func (uc *RejectAdminUserUseCase) Execute(ctx context.Context, operatorID, targetID adminuser.ID) (*adminuser.AdminUser, error) {
 	if operatorID == targetID {
 		return nil, ErrCannotModifySelf
@@ -68,5 +71,8 @@ func (uc *RejectAdminUserUseCase) Execute(ctx context.Context, operatorID, targe
 	if err := uc.repo.Save(ctx, target); err != nil {
 		return nil, err
 	}
+	if err := uc.sessions.RemoveByAdminUser(ctx, target.ID()); err != nil {
+		return nil, err
+	}
 	return target, nil
 }
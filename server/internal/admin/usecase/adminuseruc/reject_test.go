@@ -3,8 +3,10 @@ package adminuseruc
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/stretchr/testify/assert"
@@ -16,7 +18,7 @@ func TestReject_Pending(t *testing.T) {
 	operator := approved("op@eukarya.io")
 	target := pending("new@eukarya.io")
 	repo := memory.NewAdminUserWith(operator, target)
-	uc := NewRejectAdminUserUseCase(repo)
+	uc := NewRejectAdminUserUseCase(repo, memory.NewAdminSession())
 
 	got, err := uc.Execute(ctx, operator.ID(), target.ID())
 	require.NoError(t, err)
@@ -28,18 +30,38 @@ func TestReject_RevokeApproved(t *testing.T) {
 	operator := approved("op@eukarya.io")
 	other := approved("other@eukarya.io")
 	repo := memory.NewAdminUserWith(operator, other)
-	uc := NewRejectAdminUserUseCase(repo)
+	uc := NewRejectAdminUserUseCase(repo, memory.NewAdminSession())
 
 	got, err := uc.Execute(ctx, operator.ID(), other.ID())
 	require.NoError(t, err)
 	assert.True(t, got.IsRejected())
 }
 
+func TestReject_EndsSessions(t *testing.T) {
+	ctx := context.Background()
+	operator := approved("op@eukarya.io")
+	other := approved("other@eukarya.io")
+	repo := memory.NewAdminUserWith(operator, other)
+	now := time.Now()
+	theirs := adminsession.New().NewID().AdminUser(other.ID()).CreatedAt(now).ExpiresAt(now.Add(time.Hour)).MustBuild()
+	mine := adminsession.New().NewID().AdminUser(operator.ID()).CreatedAt(now).ExpiresAt(now.Add(time.Hour)).MustBuild()
+	sessions := memory.NewAdminSessionWith(theirs, mine)
+	uc := NewRejectAdminUserUseCase(repo, sessions)
+
+	_, err := uc.Execute(ctx, operator.ID(), other.ID())
+	require.NoError(t, err)
+
+	_, err = sessions.FindByID(ctx, theirs.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	_, err = sessions.FindByID(ctx, mine.ID())
+	assert.NoError(t, err)
+}
+
 func TestReject_CannotRejectSelf(t *testing.T) {
 	ctx := context.Background()
 	operator := approved("op@eukarya.io")
 	repo := memory.NewAdminUserWith(operator)
-	uc := NewRejectAdminUserUseCase(repo)
+	uc := NewRejectAdminUserUseCase(repo, memory.NewAdminSession())
 
 	_, err := uc.Execute(ctx, operator.ID(), operator.ID())
 	assert.ErrorIs(t, err, ErrCannotModifySelf)
@@ -49,7 +71,7 @@ func TestReject_NotFound(t *testing.T) {
 	ctx := context.Background()
 	operator := approved("op@eukarya.io")
 	repo := memory.NewAdminUserWith(operator)
-	uc := NewRejectAdminUserUseCase(repo)
+	uc := NewRejectAdminUserUseCase(repo, memory.NewAdminSession())
 
 	_, err := uc.Execute(ctx, operator.ID(), adminuser.NewID())
 	assert.ErrorIs(t, err, rerror.ErrNotFound)
@@ -63,7 +85,7 @@ func TestReject_LastApprovedAdminBlocked(t *testing.T) {
 	operator := pending("op@eukarya.io")
 	target := approved("solo@eukarya.io")
 	repo := memory.NewAdminUserWith(operator, target)
-	uc := NewRejectAdminUserUseCase(repo)
+	uc := NewRejectAdminUserUseCase(repo, memory.NewAdminSession())
 
 	_, err := uc.Execute(ctx, operator.ID(), target.ID())
 	assert.ErrorIs(t, err, ErrLastApprovedAdmin)
@@ -3,17 +3,19 @@ package adminuseruc
 import (
 	"context"
 
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 )
 
 // SetRoleUseCase assigns a role to an admin user.
 type SetRoleUseCase struct {
 	adminUserRepo adminuser.Repo
+	sessionRepo   adminsession.Repo
 }
 
 // NewSetRoleUseCase is a Wire provider for SetRoleUseCase.
-func NewSetRoleUseCase(adminUserRepo adminuser.Repo) *SetRoleUseCase {
-	return &SetRoleUseCase{adminUserRepo: adminUserRepo}
+func NewSetRoleUseCase(adminUserRepo adminuser.Repo, sessionRepo adminsession.Repo) *SetRoleUseCase {
+	return &SetRoleUseCase{adminUserRepo: adminUserRepo, sessionRepo: sessionRepo}
 }
 
 // SetRoleInput is the input for SetRoleUseCase.Execute.
@@ -24,7 +26,8 @@ type SetRoleInput struct {
 
 // Execute assigns a role to the target admin user. Self-role changes are allowed
 // (RBAC is enforced in the middleware), but the last approved system_admin
-// cannot be demoted.
+// cannot be demoted. Changing the role ends all of the target's sessions so
+// they sign in again under the new role.
 func (uc *SetRoleUseCase) Execute(ctx context.Context, in SetRoleInput) (*adminuser.AdminUser, error) {
 	// Validate before loading the target so a bad input maps to ErrInvalidRole.
 	if !in.Role.Valid() {
@@ -41,6 +44,7 @@ func (uc *SetRoleUseCase) Execute(ctx context.Context, in SetRoleInput) (*adminu
 	// operation by the repo (SaveGuardingLastSystemAdmin) so two concurrent
 	// demotions of the last two admins can't both pass an independent check.
 	demotingLastSystemAdmin := target.IsApproved() && target.Role() == adminuser.RoleSystemAdmin && in.Role != adminuser.RoleSystemAdmin
+	changed := target.Role() != in.Role
 
 	if err := target.SetRole(in.Role); err != nil {
 		return nil, err
@@ -53,5 +57,10 @@ func (uc *SetRoleUseCase) Execute(ctx context.Context, in SetRoleInput) (*adminu
 	if !ok {
 		return nil, ErrLastSystemAdmin
 	}
+	if changed {
+		if err := uc.sessionRepo.RemoveByAdminUser(ctx, target.ID()); err != nil {
+			return nil, err
+		}
+	}
 	return target, nil
 }
@@ -4,8 +4,10 @@ import (
 	"context"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/stretchr/testify/assert"
@@ -34,7 +36,7 @@ func TestSetRole_DemoteSystemAdmin_OK(t *testing.T) {
 	target := approvedWithRole("target@eukarya.io", adminuser.RoleSystemAdmin)
 	other := approvedWithRole("other@eukarya.io", adminuser.RoleSystemAdmin)
 	repo := memory.NewAdminUserWith(operator, target, other)
-	uc := NewSetRoleUseCase(repo)
+	uc := NewSetRoleUseCase(repo, memory.NewAdminSession())
 
 	got, err := uc.Execute(ctx, SetRoleInput{TargetID: target.ID(), Role: adminuser.RoleViewer})
 	require.NoError(t, err)
@@ -45,13 +47,34 @@ func TestSetRole_DemoteSystemAdmin_OK(t *testing.T) {
 	assert.Equal(t, adminuser.RoleViewer, reloaded.Role())
 }
 
+func TestSetRole_EndsSessionsOnChange(t *testing.T) {
+	ctx := context.Background()
+	target := approvedWithRole("target@eukarya.io", adminuser.RoleViewer)
+	repo := memory.NewAdminUserWith(target)
+	now := time.Now()
+	s := adminsession.New().NewID().AdminUser(target.ID()).CreatedAt(now).ExpiresAt(now.Add(time.Hour)).MustBuild()
+	sessions := memory.NewAdminSessionWith(s)
+	uc := NewSetRoleUseCase(repo, sessions)
+
+	// Re-assigning the current role keeps the sessions.
+	_, err := uc.Execute(ctx, SetRoleInput{TargetID: target.ID(), Role: adminuser.RoleViewer})
+	require.NoError(t, err)
+	_, err = sessions.FindByID(ctx, s.ID())
+	assert.NoError(t, err)
+
+	_, err = uc.Execute(ctx, SetRoleInput{TargetID: target.ID(), Role: adminuser.RoleSystemAdmin})
+	require.NoError(t, err)
+	_, err = sessions.FindByID(ctx, s.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
 func TestSetRole_DemoteLastSystemAdminBlocked(t *testing.T) {
 	ctx := context.Background()
 	// target is the only approved system_admin, so demoting it is blocked.
 	target := approvedWithRole("solo@eukarya.io", adminuser.RoleSystemAdmin)
 	viewer := approvedWithRole("viewer@eukarya.io", adminuser.RoleViewer)
 	repo := memory.NewAdminUserWith(target, viewer)
-	uc := NewSetRoleUseCase(repo)
+	uc := NewSetRoleUseCase(repo, memory.NewAdminSession())
 
 	_, err := uc.Execute(ctx, SetRoleInput{TargetID: target.ID(), Role: adminuser.RoleViewer})
 	assert.ErrorIs(t, err, ErrLastSystemAdmin)
@@ -63,7 +86,7 @@ func TestSetRole_DemoteRejectedSystemAdmin_OK(t *testing.T) {
 	operator := approvedWithRole("op@eukarya.io", adminuser.RoleSystemAdmin)
 	target := rejectedWithRole("target@eukarya.io", adminuser.RoleSystemAdmin)
 	repo := memory.NewAdminUserWith(operator, target)
-	uc := NewSetRoleUseCase(repo)
+	uc := NewSetRoleUseCase(repo, memory.NewAdminSession())
 
 	got, err := uc.Execute(ctx, SetRoleInput{TargetID: target.ID(), Role: adminuser.RoleViewer})
 	require.NoError(t, err)
@@ -79,7 +102,7 @@ func TestSetRole_PromoteViewer_OK(t *testing.T) {
 	operator := approvedWithRole("op@eukarya.io", adminuser.RoleSystemAdmin)
 	target := approvedWithRole("target@eukarya.io", adminuser.RoleViewer)
 	repo := memory.NewAdminUserWith(operator, target)
-	uc := NewSetRoleUseCase(repo)
+	uc := NewSetRoleUseCase(repo, memory.NewAdminSession())
 
 	got, err := uc.Execute(ctx, SetRoleInput{TargetID: target.ID(), Role: adminuser.RoleSystemAdmin})
 	require.NoError(t, err)
@@ -91,7 +114,7 @@ func TestSetRole_InvalidRole(t *testing.T) {
 	operator := approvedWithRole("op@eukarya.io", adminuser.RoleSystemAdmin)
 	target := approvedWithRole("target@eukarya.io", adminuser.RoleViewer)
 	repo := memory.NewAdminUserWith(operator, target)
-	uc := NewSetRoleUseCase(repo)
+	uc := NewSetRoleUseCase(repo, memory.NewAdminSession())
 
 	_, err := uc.Execute(ctx, SetRoleInput{TargetID: target.ID(), Role: adminuser.Role("bogus")})
 	assert.ErrorIs(t, err, adminuser.ErrInvalidRole)
@@ -102,7 +125,7 @@ func TestSetRole_InvalidRole_LastSystemAdmin(t *testing.T) {
 	ctx := context.Background()
 	operator := approvedWithRole("op@eukarya.io", adminuser.RoleSystemAdmin)
 	repo := memory.NewAdminUserWith(operator)
-	uc := NewSetRoleUseCase(repo)
+	uc := NewSetRoleUseCase(repo, memory.NewAdminSession())
 
 	_, err := uc.Execute(ctx, SetRoleInput{TargetID: operator.ID(), Role: adminuser.Role("bogus")})
 	assert.ErrorIs(t, err, adminuser.ErrInvalidRole)
@@ -117,7 +140,7 @@ func TestSetRole_ConcurrentDemoteLastTwoSystemAdmins(t *testing.T) {
 	a := approvedWithRole("a@eukarya.io", adminuser.RoleSystemAdmin)
 	b := approvedWithRole("b@eukarya.io", adminuser.RoleSystemAdmin)
 	repo := memory.NewAdminUserWith(a, b)
-	uc := NewSetRoleUseCase(repo)
+	uc := NewSetRoleUseCase(repo, memory.NewAdminSession())
 
 	var wg sync.WaitGroup
 	errs := make([]error, 2)
@@ -152,7 +175,7 @@ func TestSetRole_NotFound(t *testing.T) {
 	ctx := context.Background()
 	operator := approvedWithRole("op@eukarya.io", adminuser.RoleSystemAdmin)
 	repo := memory.NewAdminUserWith(operator)
-	uc := NewSetRoleUseCase(repo)
+	uc := NewSetRoleUseCase(repo, memory.NewAdminSession())
 
 	_, err := uc.Execute(ctx, SetRoleInput{TargetID: adminuser.NewID(), Role: adminuser.RoleViewer})
 	assert.ErrorIs(t, err, rerror.ErrNotFound)
@@ -0,0 +1,66 @@
+package approvalruleuc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/log"
+)
+
+// ErrDuplicateRule is returned when a rule with the same kind, value and
+// provider already exists.
+var ErrDuplicateRule = errors.New("an approval rule for the same domain or group already exists")
+
+// CreateApprovalRuleUseCase adds an approval rule.
+type CreateApprovalRuleUseCase struct {
+	ruleRepo adminapprovalrule.Repo
+}
+
+// NewCreateApprovalRuleUseCase is a Wire provider for CreateApprovalRuleUseCase.
+func NewCreateApprovalRuleUseCase(ruleRepo adminapprovalrule.Repo) *CreateApprovalRuleUseCase {
+	return &CreateApprovalRuleUseCase{ruleRepo: ruleRepo}
+}
+
+// CreateInput is the input for CreateApprovalRuleUseCase.Execute.
+type CreateInput struct {
+	Operator adminuser.ID
+	Kind     adminapprovalrule.Kind
+	// Value is the email domain or the group name to match.
+	Value string
+	// Provider limits the rule to sign-ins through one identity provider;
+	// empty matches any.
+	Provider adminapprovalrule.Provider
+	Role     adminuser.Role
+}
+
+// Execute saves the rule. It applies from the next sign-in on, to new admins
+// and to pending ones alike; admins already approved or rejected are not
+// affected.
+func (uc *CreateApprovalRuleUseCase) Execute(ctx context.Context, in CreateInput) (*adminapprovalrule.Rule, error) {
+	r, err := adminapprovalrule.New().NewID().
+		Kind(in.Kind).
+		Value(in.Value).
+		Provider(in.Provider).
+		Role(in.Role).
+		CreatedBy(in.Operator).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := uc.ruleRepo.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if rules.Find(r.Kind(), r.Value(), r.Provider()) != nil {
+		return nil, ErrDuplicateRule
+	}
+	if err := uc.ruleRepo.Save(ctx, r); err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] approval rule %s (%s %s -> %s) created by %s", r.ID(), r.Kind(), r.Value(), r.Role(), in.Operator)
+	return r, nil
+}
@@ -0,0 +1,65 @@
+package approvalruleuc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateUpdateDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewAdminApprovalRule()
+	op := adminuser.NewID()
+
+	r, err := NewCreateApprovalRuleUseCase(repo).Execute(ctx, CreateInput{
+		Operator: op,
+		Kind:     adminapprovalrule.KindGroup,
+		Value:    "admins@eukarya.io",
+		Provider: adminapprovalrule.ProviderGoogle,
+		Role:     adminuser.RoleViewer,
+	})
+	require.NoError(t, err)
+	assert.Equal(t, op, r.CreatedBy())
+
+	_, err = NewCreateApprovalRuleUseCase(repo).Execute(ctx, CreateInput{
+		Operator: op,
+		Kind:     adminapprovalrule.KindGroup,
+		Value:    "Admins@eukarya.io",
+		Provider: adminapprovalrule.ProviderGoogle,
+		Role:     adminuser.RoleSystemAdmin,
+	})
+	assert.ErrorIs(t, err, ErrDuplicateRule)
+
+	// The same group from another provider is a different rule.
+	_, err = NewCreateApprovalRuleUseCase(repo).Execute(ctx, CreateInput{
+		Operator: op,
+		Kind:     adminapprovalrule.KindGroup,
+		Value:    "admins@eukarya.io",
+		Provider: adminapprovalrule.ProviderOIDC,
+		Role:     adminuser.RoleViewer,
+	})
+	require.NoError(t, err)
+
+	updated, err := NewUpdateApprovalRuleUseCase(repo).Execute(ctx, UpdateInput{Operator: op, ID: r.ID(), Role: adminuser.RoleSystemAdmin})
+	require.NoError(t, err)
+	assert.Equal(t, adminuser.RoleSystemAdmin, updated.Role())
+	_, err = NewUpdateApprovalRuleUseCase(repo).Execute(ctx, UpdateInput{Operator: op, ID: r.ID(), Role: adminuser.Role("owner")})
+	assert.ErrorIs(t, err, adminuser.ErrInvalidRole)
+
+	listed, err := NewListApprovalRulesUseCase(repo).Execute(ctx)
+	require.NoError(t, err)
+	assert.Len(t, listed, 2)
+	assert.Equal(t, r.ID(), listed[0].ID())
+
+	require.NoError(t, NewDeleteApprovalRuleUseCase(repo).Execute(ctx, DeleteInput{Operator: op, ID: r.ID()}))
+	_, err = repo.FindByID(ctx, r.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	err = NewDeleteApprovalRuleUseCase(repo).Execute(ctx, DeleteInput{Operator: op, ID: r.ID()})
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
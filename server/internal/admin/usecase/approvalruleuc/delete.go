@@ -0,0 +1,40 @@
+package approvalruleuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/log"
+)
+
+// DeleteApprovalRuleUseCase removes an approval rule.
+type DeleteApprovalRuleUseCase struct {
+	ruleRepo adminapprovalrule.Repo
+}
+
+// NewDeleteApprovalRuleUseCase is a Wire provider for DeleteApprovalRuleUseCase.
+func NewDeleteApprovalRuleUseCase(ruleRepo adminapprovalrule.Repo) *DeleteApprovalRuleUseCase {
+	return &DeleteApprovalRuleUseCase{ruleRepo: ruleRepo}
+}
+
+// DeleteInput is the input for DeleteApprovalRuleUseCase.Execute.
+type DeleteInput struct {
+	Operator adminuser.ID
+	ID       adminapprovalrule.ID
+}
+
+// Execute stops approving sign-ins by the rule. The admins it approved stay
+// approved and can be rejected like any other.
+func (uc *DeleteApprovalRuleUseCase) Execute(ctx context.Context, in DeleteInput) error {
+	r, err := uc.ruleRepo.FindByID(ctx, in.ID)
+	if err != nil {
+		return err
+	}
+	if err := uc.ruleRepo.Remove(ctx, r.ID()); err != nil {
+		return err
+	}
+
+	log.Infofc(ctx, "[admin] approval rule %s (%s %s) deleted by %s", r.ID(), r.Kind(), r.Value(), in.Operator)
+	return nil
+}
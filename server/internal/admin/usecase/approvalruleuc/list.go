@@ -0,0 +1,25 @@
+// Package approvalruleuc holds the usecases managing the rules that approve
+// new admin sign-ins automatically by email domain or group.
+package approvalruleuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+)
+
+// ListApprovalRulesUseCase lists the approval rules.
+type ListApprovalRulesUseCase struct {
+	ruleRepo adminapprovalrule.Repo
+}
+
+// NewListApprovalRulesUseCase is a Wire provider for ListApprovalRulesUseCase.
+func NewListApprovalRulesUseCase(ruleRepo adminapprovalrule.Repo) *ListApprovalRulesUseCase {
+	return &ListApprovalRulesUseCase{ruleRepo: ruleRepo}
+}
+
+// Execute returns the rules oldest first, the order in which rules of the
+// same kind are tried.
+func (uc *ListApprovalRulesUseCase) Execute(ctx context.Context) (adminapprovalrule.List, error) {
+	return uc.ruleRepo.FindAll(ctx)
+}
@@ -0,0 +1,47 @@
+package approvalruleuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/log"
+)
+
+// UpdateApprovalRuleUseCase changes the role an approval rule grants.
+type UpdateApprovalRuleUseCase struct {
+	ruleRepo adminapprovalrule.Repo
+}
+
+// NewUpdateApprovalRuleUseCase is a Wire provider for UpdateApprovalRuleUseCase.
+func NewUpdateApprovalRuleUseCase(ruleRepo adminapprovalrule.Repo) *UpdateApprovalRuleUseCase {
+	return &UpdateApprovalRuleUseCase{ruleRepo: ruleRepo}
+}
+
+// UpdateInput is the input for UpdateApprovalRuleUseCase.Execute.
+type UpdateInput struct {
+	Operator adminuser.ID
+	ID       adminapprovalrule.ID
+	Role     adminuser.Role
+}
+
+// Execute sets the role. Admins the rule approved before keep their role;
+// it can be changed on each of them.
+func (uc *UpdateApprovalRuleUseCase) Execute(ctx context.Context, in UpdateInput) (*adminapprovalrule.Rule, error) {
+	r, err := uc.ruleRepo.FindByID(ctx, in.ID)
+	if err != nil {
+		return nil, err
+	}
+	if r.Role() == in.Role {
+		return r, nil
+	}
+	if err := r.SetRole(in.Role); err != nil {
+		return nil, err
+	}
+	if err := uc.ruleRepo.Save(ctx, r); err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] approval rule %s now grants %s, changed by %s", r.ID(), r.Role(), in.Operator)
+	return r, nil
+}
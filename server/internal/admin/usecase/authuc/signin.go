@@ -1,55 +1,62 @@
 // Package authuc holds the admin authentication usecases: exchanging a Google
-// id_token for an admin session and loading the current admin user.
+// or OIDC id_token for an admin session and loading the current admin user.
+// New admins are pending until approved, by hand or by an approval rule.
 package authuc
 
 import (
 	"context"
 	"errors"
+	"slices"
 	"strings"
 
-	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/google"
+	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/identity"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/rerror"
 )
 
 var (
 	// ErrInvalidToken is returned when the Google id_token cannot be verified.
 	ErrInvalidToken = rerror.NewE(i18n.T("invalid id token"))
-	// ErrEmailNotVerified is returned when the Google account's email is not verified.
+	// ErrEmailNotVerified is returned when the email of the account is not
+	// verified by the identity provider and the sign-in relies on it.
 	ErrEmailNotVerified = rerror.NewE(i18n.T("email not verified"))
 	// ErrDomainNotAllowed is returned when the account is not in the allowed domain.
 	ErrDomainNotAllowed = rerror.NewE(i18n.T("email domain not allowed"))
+	// ErrAccountNotAllowed is returned when the identity provider's allow-list
+	// (domains, groups) does not admit the account.
+	ErrAccountNotAllowed = rerror.NewE(i18n.T("account not allowed"))
+	// ErrProviderNotConfigured is returned when signing in with an identity
+	// provider that is not configured.
+	ErrProviderNotConfigured = rerror.NewE(i18n.T("identity provider not configured"))
 )
 
 // GoogleSignInOptions configures the sign-in policy.
 type GoogleSignInOptions struct {
 	AllowedDomain   string
 	BootstrapEmails []string
+	// Groups resolves the Google Workspace groups group approval rules are
+	// matched against; nil leaves them unmatched for Google sign-ins.
+	Groups identity.GroupLister
 }
 
 // GoogleSignInUseCase verifies a Google id_token and upserts the corresponding
-// admin user (pending, or approved when the email is bootstrapped).
+// admin user (pending, or approved when the email is bootstrapped or an
+// approval rule matches).
 type GoogleSignInUseCase struct {
-	repo          adminuser.Repo
-	verifier      google.Verifier
+	accounts      accounts
+	verifier      identity.Verifier
 	allowedDomain string
-	bootstrap     map[string]bool
 }
 
-// NewGoogleSignInUseCase is a Wire provider for GoogleSignInUseCase.
-func NewGoogleSignInUseCase(repo adminuser.Repo, verifier google.Verifier, opts GoogleSignInOptions) *GoogleSignInUseCase {
-	bootstrap := make(map[string]bool, len(opts.BootstrapEmails))
-	for _, e := range opts.BootstrapEmails {
-		if n := adminuser.NormalizeEmail(e); n != "" {
-			bootstrap[n] = true
-		}
-	}
+// NewGoogleSignInUseCase creates a GoogleSignInUseCase.
+func NewGoogleSignInUseCase(repo adminuser.Repo, rules adminapprovalrule.Repo, verifier identity.Verifier, opts GoogleSignInOptions) *GoogleSignInUseCase {
 	return &GoogleSignInUseCase{
-		repo:          repo,
+		accounts:      newAccounts(repo, rules, adminapprovalrule.ProviderGoogle, opts.BootstrapEmails, opts.Groups),
 		verifier:      verifier,
 		allowedDomain: strings.ToLower(strings.TrimSpace(opts.AllowedDomain)),
-		bootstrap:     bootstrap,
 	}
 }
 
@@ -72,17 +79,63 @@ func (uc *GoogleSignInUseCase) Execute(ctx context.Context, idToken string) (*ad
 		return nil, err
 	}
 
-	u, err := uc.repo.FindByEmail(ctx, email)
+	return uc.accounts.signIn(ctx, email, claims)
+}
+
+func (uc *GoogleSignInUseCase) checkDomain(email, hd string) error {
+	if uc.allowedDomain == "" {
+		return nil
+	}
+	if !strings.EqualFold(hd, uc.allowedDomain) {
+		return ErrDomainNotAllowed
+	}
+	if !strings.HasSuffix(email, "@"+uc.allowedDomain) {
+		return ErrDomainNotAllowed
+	}
+	return nil
+}
+
+// accounts upserts the admin user behind a verified identity. New accounts
+// are pending unless their email is bootstrapped or an approval rule matches,
+// so every identity provider shares the same approval flow.
+type accounts struct {
+	repo      adminuser.Repo
+	rules     adminapprovalrule.Repo
+	provider  adminapprovalrule.Provider
+	bootstrap map[string]bool
+	groups    identity.GroupLister
+}
+
+func newAccounts(repo adminuser.Repo, rules adminapprovalrule.Repo, provider adminapprovalrule.Provider, bootstrapEmails []string, groups identity.GroupLister) accounts {
+	bootstrap := make(map[string]bool, len(bootstrapEmails))
+	for _, e := range bootstrapEmails {
+		if n := adminuser.NormalizeEmail(e); n != "" {
+			bootstrap[n] = true
+		}
+	}
+	return accounts{repo: repo, rules: rules, provider: provider, bootstrap: bootstrap, groups: groups}
+}
+
+// signIn returns the admin user with the (normalized) email, creating it or
+// refreshing its profile from the claims. Accounts are matched by email, so an
+// email the identity provider has not verified can neither sign in to an
+// existing account nor be approved by bootstrap or a rule; it only creates a
+// pending account.
+func (a accounts) signIn(ctx context.Context, email string, claims *identity.Claims) (*adminuser.AdminUser, error) {
+	u, err := a.repo.FindByEmail(ctx, email)
 	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
 		return nil, err
 	}
 
 	if u != nil {
+		if !claims.EmailVerified {
+			return nil, ErrEmailNotVerified
+		}
 		changed := false
 
-		// Refresh the profile from the latest Google data on each sign-in.
-		// Name and picture are updated independently, and an empty Google claim
-		// keeps the stored value (UpdateProfile requires a non-empty name).
+		// Refresh the profile from the latest provider data on each sign-in.
+		// Name and picture are updated independently, and an empty claim keeps
+		// the stored value (UpdateProfile requires a non-empty name).
 		name := u.Name()
 		if claims.Name != "" {
 			name = claims.Name
@@ -102,7 +155,7 @@ func (uc *GoogleSignInUseCase) Execute(ctx context.Context, idToken string) (*ad
 		// bootstrap env var re-grants access (the lock-out recovery valve). This
 		// only ever approves/elevates; the system_admin guard means it never
 		// downgrades an already-system_admin record.
-		if uc.bootstrap[email] {
+		if a.bootstrap[email] {
 			if !u.IsApproved() {
 				u.Approve(adminuser.ID{}) // bootstrap has no human approver
 				changed = true
@@ -115,17 +168,33 @@ func (uc *GoogleSignInUseCase) Execute(ctx context.Context, idToken string) (*ad
 			}
 		}
 
+		// Rules approve pending accounts only: a rejected admin stays
+		// rejected, and one who was pending before the rule was added is
+		// approved on the next sign-in.
+		if u.IsPending() {
+			rule, err := a.matchRule(ctx, email, claims)
+			if err != nil {
+				return nil, err
+			}
+			if rule != nil {
+				if err := approveByRule(u, rule); err != nil {
+					return nil, err
+				}
+				changed = true
+			}
+		}
+
 		if changed {
-			if err := uc.repo.Save(ctx, u); err != nil {
+			if err := a.repo.Save(ctx, u); err != nil {
 				return nil, err
 			}
 		}
 		return u, nil
 	}
 
-	// new account: pending unless bootstrapped
+	// new account: pending unless bootstrapped or approved by a rule
 	b := adminuser.New().NewID().Email(email).Name(displayName(claims.Name, email)).PictureURL(claims.PictureURL)
-	if uc.bootstrap[email] {
+	if a.bootstrap[email] && claims.EmailVerified {
 		// Bootstrap admins are auto-approved and seeded as system_admin so a
 		// fresh DB can gain its first system_admin.
 		b = b.Status(adminuser.StatusApproved).Role(adminuser.RoleSystemAdmin)
@@ -136,12 +205,23 @@ func (uc *GoogleSignInUseCase) Execute(ctx context.Context, idToken string) (*ad
 	if err != nil {
 		return nil, err
 	}
-	if err := uc.repo.Save(ctx, created); err != nil {
+	if created.IsPending() && claims.EmailVerified {
+		rule, err := a.matchRule(ctx, email, claims)
+		if err != nil {
+			return nil, err
+		}
+		if rule != nil {
+			if err := approveByRule(created, rule); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := a.repo.Save(ctx, created); err != nil {
 		// Lost a race with a concurrent first sign-in for the same email: the
 		// unique-email constraint rejected our insert, but the account now
 		// exists — return it instead of failing.
-		if errors.Is(err, adminuser.ErrDuplicatedAdminUser) {
-			if existing, ferr := uc.repo.FindByEmail(ctx, email); ferr == nil && existing != nil {
+		if errors.Is(err, adminuser.ErrDuplicatedAdminUser) && claims.EmailVerified {
+			if existing, ferr := a.repo.FindByEmail(ctx, email); ferr == nil && existing != nil {
 				return existing, nil
 			}
 		}
@@ -150,21 +230,48 @@ func (uc *GoogleSignInUseCase) Execute(ctx context.Context, idToken string) (*ad
 	return created, nil
 }
 
-func (uc *GoogleSignInUseCase) checkDomain(email, hd string) error {
-	if uc.allowedDomain == "" {
-		return nil
+// matchRule returns the approval rule that approves the sign-in, or nil. The
+// groups of providers whose tokens carry none are only looked up when a group
+// rule could match; a failed lookup leaves the account to the other rules.
+func (a accounts) matchRule(ctx context.Context, email string, claims *identity.Claims) (*adminapprovalrule.Rule, error) {
+	if a.rules == nil {
+		return nil, nil
 	}
-	if !strings.EqualFold(hd, uc.allowedDomain) {
-		return ErrDomainNotAllowed
+	rules, err := a.rules.FindAll(ctx)
+	if err != nil {
+		return nil, err
 	}
-	if !strings.HasSuffix(email, "@"+uc.allowedDomain) {
-		return ErrDomainNotAllowed
+	if len(rules) == 0 {
+		return nil, nil
+	}
+
+	groups := claims.Groups
+	if a.groups != nil && rules.HasGroupRules(a.provider) {
+		resolved, err := a.groups.Groups(ctx, email)
+		if err != nil {
+			log.Warnfc(ctx, "[admin] could not look up the groups of %s: %v", email, err)
+		}
+		groups = append(slices.Clone(groups), resolved...)
+	}
+
+	return rules.Match(adminapprovalrule.Subject{
+		Provider: a.provider,
+		Email:    email,
+		Groups:   groups,
+	}), nil
+}
+
+// approveByRule approves the user with the rule's role and records the rule.
+func approveByRule(u *adminuser.AdminUser, rule *adminapprovalrule.Rule) error {
+	if err := u.SetRole(rule.Role()); err != nil {
+		return err
 	}
+	u.ApproveByRule(rule.ID())
 	return nil
 }
 
-// displayName falls back to the local part of the email when Google supplies no
-// name (the domain requires a non-empty name).
+// displayName falls back to the local part of the email when the provider
+// supplies no name (the domain requires a non-empty name).
 func displayName(name, email string) string {
 	if name != "" {
 		return name
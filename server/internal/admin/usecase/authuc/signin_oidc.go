@@ -0,0 +1,56 @@
+package authuc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/identity"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+// OIDCSignInOptions configures the OIDC sign-in policy. Which accounts the
+// provider admits (domains, groups) is enforced by its verifier.
+type OIDCSignInOptions struct {
+	BootstrapEmails []string
+}
+
+// OIDCSignInUseCase verifies the id_token of the configured generic OIDC
+// provider and upserts the corresponding admin user, like Google sign-in.
+type OIDCSignInUseCase struct {
+	accounts accounts
+	verifier identity.Verifier
+}
+
+// NewOIDCSignInUseCase creates an OIDCSignInUseCase. A nil verifier means no
+// OIDC provider is configured.
+func NewOIDCSignInUseCase(repo adminuser.Repo, rules adminapprovalrule.Repo, verifier identity.Verifier, opts OIDCSignInOptions) *OIDCSignInUseCase {
+	return &OIDCSignInUseCase{
+		accounts: newAccounts(repo, rules, adminapprovalrule.ProviderOIDC, opts.BootstrapEmails, nil),
+		verifier: verifier,
+	}
+}
+
+// Execute verifies the id_token and returns the (created or existing) admin
+// user.
+func (uc *OIDCSignInUseCase) Execute(ctx context.Context, idToken string) (*adminuser.AdminUser, error) {
+	if uc.verifier == nil {
+		return nil, ErrProviderNotConfigured
+	}
+
+	claims, err := uc.verifier.Verify(ctx, idToken)
+	switch {
+	case errors.Is(err, identity.ErrEmailNotVerified):
+		return nil, ErrEmailNotVerified
+	case errors.Is(err, identity.ErrNotAllowed):
+		return nil, ErrAccountNotAllowed
+	case err != nil || claims == nil:
+		return nil, ErrInvalidToken
+	}
+
+	email := adminuser.NormalizeEmail(claims.Email)
+	if email == "" {
+		return nil, ErrInvalidToken
+	}
+	return uc.accounts.signIn(ctx, email, claims)
+}
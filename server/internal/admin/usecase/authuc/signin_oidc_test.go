@@ -0,0 +1,102 @@
+package authuc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/identity"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOIDCSignIn_NewUser_Pending(t *testing.T) {
+	repo := memory.NewAdminUser()
+	v := fakeVerifier{claims: &identity.Claims{Email: "Carol@Example.com", EmailVerified: true, Name: "Carol"}}
+	uc := NewOIDCSignInUseCase(repo, memory.NewAdminApprovalRule(), v, OIDCSignInOptions{})
+
+	u, err := uc.Execute(context.Background(), "tok")
+	require.NoError(t, err)
+	assert.Equal(t, "carol@example.com", u.Email())
+	assert.True(t, u.IsPending())
+
+	got, err := repo.FindByEmail(context.Background(), "carol@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, u.ID(), got.ID())
+}
+
+func TestOIDCSignIn_NewUser_Bootstrapped(t *testing.T) {
+	v := fakeVerifier{claims: &identity.Claims{Email: "root@example.com", EmailVerified: true, Name: "Root"}}
+	uc := NewOIDCSignInUseCase(memory.NewAdminUser(), memory.NewAdminApprovalRule(), v, OIDCSignInOptions{BootstrapEmails: []string{"root@example.com"}})
+
+	u, err := uc.Execute(context.Background(), "tok")
+	require.NoError(t, err)
+	assert.True(t, u.IsApproved())
+	assert.Equal(t, adminuser.RoleSystemAdmin, u.Role())
+}
+
+func TestOIDCSignIn_ApprovedByGroupClaim(t *testing.T) {
+	ctx := context.Background()
+	rules := memory.NewAdminApprovalRule()
+	rule := adminapprovalrule.New().NewID().Kind(adminapprovalrule.KindGroup).Value("ops").Provider(adminapprovalrule.ProviderOIDC).Role(adminuser.RoleViewer).MustBuild()
+	require.NoError(t, rules.Save(ctx, rule))
+	v := fakeVerifier{claims: &identity.Claims{Email: "dave@example.com", EmailVerified: true, Groups: []string{"OPS"}}}
+	uc := NewOIDCSignInUseCase(memory.NewAdminUser(), rules, v, OIDCSignInOptions{})
+
+	u, err := uc.Execute(ctx, "tok")
+	require.NoError(t, err)
+	assert.True(t, u.IsApproved())
+	assert.Equal(t, adminuser.RoleViewer, u.Role())
+	assert.Equal(t, rule.ID(), u.ApprovedByRule())
+}
+
+func TestOIDCSignIn_EmailNotVerified(t *testing.T) {
+	ctx := context.Background()
+	rules := memory.NewAdminApprovalRule()
+	require.NoError(t, rules.Save(ctx, adminapprovalrule.New().NewID().Kind(adminapprovalrule.KindEmailDomain).Value("example.com").
+		Provider(adminapprovalrule.ProviderOIDC).Role(adminuser.RoleViewer).MustBuild()))
+	repo := memory.NewAdminUser()
+	existing := adminuser.New().NewID().Email("alice@example.com").Name("Alice").Status(adminuser.StatusApproved).Role(adminuser.RoleSystemAdmin).MustBuild()
+	require.NoError(t, repo.Save(ctx, existing))
+	opts := OIDCSignInOptions{BootstrapEmails: []string{"root@example.com"}}
+
+	// an existing account is not matched by an unverified email
+	v := fakeVerifier{claims: &identity.Claims{Email: "alice@example.com", Name: "Mallory"}}
+	_, err := NewOIDCSignInUseCase(repo, rules, v, opts).Execute(ctx, "tok")
+	assert.ErrorIs(t, err, ErrEmailNotVerified)
+
+	// neither bootstrap nor the rules approve an unverified email
+	for _, email := range []string{"root@example.com", "bob@example.com"} {
+		v := fakeVerifier{claims: &identity.Claims{Email: email}}
+		u, err := NewOIDCSignInUseCase(repo, rules, v, opts).Execute(ctx, "tok")
+		require.NoError(t, err)
+		assert.True(t, u.IsPending(), email)
+		assert.NotEqual(t, adminuser.RoleSystemAdmin, u.Role(), email)
+	}
+}
+
+func TestOIDCSignIn_Errors(t *testing.T) {
+	tests := []struct {
+		name string
+		v    identity.Verifier
+		err  error
+	}{
+		{name: "not configured", v: nil, err: ErrProviderNotConfigured},
+		{name: "invalid token", v: fakeVerifier{err: errors.New("bad")}, err: ErrInvalidToken},
+		{name: "email not verified", v: fakeVerifier{err: fmt.Errorf("x: %w", identity.ErrEmailNotVerified)}, err: ErrEmailNotVerified},
+		{name: "not allowed", v: fakeVerifier{err: fmt.Errorf("x: %w", identity.ErrNotAllowed)}, err: ErrAccountNotAllowed},
+		{name: "no email", v: fakeVerifier{claims: &identity.Claims{Email: "  "}}, err: ErrInvalidToken},
+	}
+	for _, tt := range tests {
+		tt := tt
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewOIDCSignInUseCase(memory.NewAdminUser(), memory.NewAdminApprovalRule(), tt.v, OIDCSignInOptions{})
+			_, err := uc.Execute(context.Background(), "tok")
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
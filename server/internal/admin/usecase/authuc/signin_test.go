@@ -5,8 +5,9 @@ import (
 	"errors"
 	"testing"
 
-	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/google"
+	"github.com/reearth/reearth-accounts/server/internal/admin/gateway/identity"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/reearth/reearthx/usecasex"
@@ -15,22 +16,22 @@ import (
 )
 
 type fakeVerifier struct {
-	claims *google.Claims
+	claims *identity.Claims
 	err    error
 }
 
-func (f fakeVerifier) Verify(_ context.Context, _ string) (*google.Claims, error) {
+func (f fakeVerifier) Verify(_ context.Context, _ string) (*identity.Claims, error) {
 	return f.claims, f.err
 }
 
-func newUC(t *testing.T, v google.Verifier, opts GoogleSignInOptions) (*GoogleSignInUseCase, adminuser.Repo) {
+func newUC(t *testing.T, v identity.Verifier, opts GoogleSignInOptions) (*GoogleSignInUseCase, adminuser.Repo) {
 	t.Helper()
 	repo := memory.NewAdminUser()
-	return NewGoogleSignInUseCase(repo, v, opts), repo
+	return NewGoogleSignInUseCase(repo, memory.NewAdminApprovalRule(), v, opts), repo
 }
 
 func TestGoogleSignIn_NewUser_Pending(t *testing.T) {
-	v := fakeVerifier{claims: &google.Claims{Email: "Alice@Eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Alice", PictureURL: "https://x/y.png"}}
+	v := fakeVerifier{claims: &identity.Claims{Email: "Alice@Eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Alice", PictureURL: "https://x/y.png"}}
 	uc, repo := newUC(t, v, GoogleSignInOptions{AllowedDomain: "eukarya.io"})
 
 	u, err := uc.Execute(context.Background(), "tok")
@@ -47,7 +48,7 @@ func TestGoogleSignIn_NewUser_Pending(t *testing.T) {
 }
 
 func TestGoogleSignIn_NewUser_Bootstrapped(t *testing.T) {
-	v := fakeVerifier{claims: &google.Claims{Email: "boss@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Boss"}}
+	v := fakeVerifier{claims: &identity.Claims{Email: "boss@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Boss"}}
 	uc, _ := newUC(t, v, GoogleSignInOptions{AllowedDomain: "eukarya.io", BootstrapEmails: []string{"BOSS@eukarya.io"}})
 
 	u, err := uc.Execute(context.Background(), "tok")
@@ -62,8 +63,8 @@ func TestGoogleSignIn_ExistingUser_BootstrapElevatesAndApproves(t *testing.T) {
 	// list is approved and elevated to system_admin on next sign-in.
 	existing := adminuser.New().NewID().Email("boss@eukarya.io").Name("Boss").Status(adminuser.StatusPending).Role(adminuser.RoleViewer).MustBuild()
 	repo := memory.NewAdminUserWith(existing)
-	v := fakeVerifier{claims: &google.Claims{Email: "boss@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Boss"}}
-	uc := NewGoogleSignInUseCase(repo, v, GoogleSignInOptions{AllowedDomain: "eukarya.io", BootstrapEmails: []string{"boss@eukarya.io"}})
+	v := fakeVerifier{claims: &identity.Claims{Email: "boss@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Boss"}}
+	uc := NewGoogleSignInUseCase(repo, memory.NewAdminApprovalRule(), v, GoogleSignInOptions{AllowedDomain: "eukarya.io", BootstrapEmails: []string{"boss@eukarya.io"}})
 
 	u, err := uc.Execute(context.Background(), "tok")
 	require.NoError(t, err)
@@ -80,8 +81,8 @@ func TestGoogleSignIn_ExistingUser_BootstrapElevatesAndApproves(t *testing.T) {
 func TestGoogleSignIn_ExistingUser_BootstrapSystemAdminNotDowngraded(t *testing.T) {
 	existing := adminuser.New().NewID().Email("boss@eukarya.io").Name("Boss").Status(adminuser.StatusApproved).Role(adminuser.RoleSystemAdmin).MustBuild()
 	repo := memory.NewAdminUserWith(existing)
-	v := fakeVerifier{claims: &google.Claims{Email: "boss@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Boss"}}
-	uc := NewGoogleSignInUseCase(repo, v, GoogleSignInOptions{AllowedDomain: "eukarya.io", BootstrapEmails: []string{"boss@eukarya.io"}})
+	v := fakeVerifier{claims: &identity.Claims{Email: "boss@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Boss"}}
+	uc := NewGoogleSignInUseCase(repo, memory.NewAdminApprovalRule(), v, GoogleSignInOptions{AllowedDomain: "eukarya.io", BootstrapEmails: []string{"boss@eukarya.io"}})
 
 	u, err := uc.Execute(context.Background(), "tok")
 	require.NoError(t, err)
@@ -92,8 +93,8 @@ func TestGoogleSignIn_ExistingUser_BootstrapSystemAdminNotDowngraded(t *testing.
 func TestGoogleSignIn_ExistingUser_NonBootstrapRoleUntouched(t *testing.T) {
 	existing := adminuser.New().NewID().Email("alice@eukarya.io").Name("Alice").Status(adminuser.StatusApproved).Role(adminuser.RoleViewer).MustBuild()
 	repo := memory.NewAdminUserWith(existing)
-	v := fakeVerifier{claims: &google.Claims{Email: "alice@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Alice"}}
-	uc := NewGoogleSignInUseCase(repo, v, GoogleSignInOptions{AllowedDomain: "eukarya.io"})
+	v := fakeVerifier{claims: &identity.Claims{Email: "alice@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Alice"}}
+	uc := NewGoogleSignInUseCase(repo, memory.NewAdminApprovalRule(), v, GoogleSignInOptions{AllowedDomain: "eukarya.io"})
 
 	u, err := uc.Execute(context.Background(), "tok")
 	require.NoError(t, err)
@@ -104,8 +105,8 @@ func TestGoogleSignIn_ExistingUser_NonBootstrapRoleUntouched(t *testing.T) {
 func TestGoogleSignIn_ExistingUser_RefreshesProfileAndKeepsStatus(t *testing.T) {
 	existing := adminuser.New().NewID().Email("alice@eukarya.io").Name("Old").Status(adminuser.StatusApproved).MustBuild()
 	repo := memory.NewAdminUserWith(existing)
-	v := fakeVerifier{claims: &google.Claims{Email: "alice@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "New Name", PictureURL: "https://new"}}
-	uc := NewGoogleSignInUseCase(repo, v, GoogleSignInOptions{AllowedDomain: "eukarya.io"})
+	v := fakeVerifier{claims: &identity.Claims{Email: "alice@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "New Name", PictureURL: "https://new"}}
+	uc := NewGoogleSignInUseCase(repo, memory.NewAdminApprovalRule(), v, GoogleSignInOptions{AllowedDomain: "eukarya.io"})
 
 	u, err := uc.Execute(context.Background(), "tok")
 	require.NoError(t, err)
@@ -115,6 +116,74 @@ func TestGoogleSignIn_ExistingUser_RefreshesProfileAndKeepsStatus(t *testing.T)
 	assert.True(t, u.IsApproved()) // status unchanged
 }
 
+type fakeGroupLister struct {
+	groups []string
+	err    error
+}
+
+func (f fakeGroupLister) Groups(_ context.Context, _ string) ([]string, error) {
+	return f.groups, f.err
+}
+
+func TestGoogleSignIn_ApprovalRules(t *testing.T) {
+	ctx := context.Background()
+	domain := adminapprovalrule.New().NewID().Kind(adminapprovalrule.KindEmailDomain).Value("eukarya.io").Role(adminuser.RoleViewer).MustBuild()
+	group := adminapprovalrule.New().NewID().Kind(adminapprovalrule.KindGroup).Value("admins@eukarya.io").Provider(adminapprovalrule.ProviderGoogle).Role(adminuser.RoleSystemAdmin).MustBuild()
+	oidcGroup := adminapprovalrule.New().NewID().Kind(adminapprovalrule.KindGroup).Value("ops").Provider(adminapprovalrule.ProviderOIDC).Role(adminuser.RoleSystemAdmin).MustBuild()
+	claims := &identity.Claims{Email: "alice@eukarya.io", EmailVerified: true, HD: "eukarya.io", Groups: []string{"ops"}}
+
+	tests := []struct {
+		name     string
+		existing *adminuser.AdminUser
+		groups   identity.GroupLister
+		want     *adminapprovalrule.Rule
+		status   adminuser.Status
+	}{
+		{name: "new user by domain", want: domain, status: adminuser.StatusApproved},
+		{name: "new user by group", groups: fakeGroupLister{groups: []string{"Admins@eukarya.io"}}, want: group, status: adminuser.StatusApproved},
+		{name: "group lookup failure falls back to domain", groups: fakeGroupLister{err: errors.New("boom")}, want: domain, status: adminuser.StatusApproved},
+		{
+			name:     "pending user",
+			existing: adminuser.New().NewID().Email("alice@eukarya.io").Name("alice").MustBuild(),
+			want:     domain,
+			status:   adminuser.StatusApproved,
+		},
+		{
+			name:     "rejected user is left alone",
+			existing: adminuser.New().NewID().Email("alice@eukarya.io").Name("alice").Status(adminuser.StatusRejected).MustBuild(),
+			status:   adminuser.StatusRejected,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := memory.NewAdminUser()
+			if tt.existing != nil {
+				repo = memory.NewAdminUserWith(tt.existing)
+			}
+			rules := memory.NewAdminApprovalRule()
+			for _, r := range []*adminapprovalrule.Rule{domain, group, oidcGroup} {
+				require.NoError(t, rules.Save(ctx, r))
+			}
+			uc := NewGoogleSignInUseCase(repo, rules, fakeVerifier{claims: claims}, GoogleSignInOptions{AllowedDomain: "eukarya.io", Groups: tt.groups})
+
+			u, err := uc.Execute(ctx, "tok")
+			require.NoError(t, err)
+			assert.Equal(t, tt.status, u.Status())
+			if tt.want == nil {
+				assert.True(t, u.ApprovedByRule().IsEmpty())
+				return
+			}
+			assert.Equal(t, tt.want.ID(), u.ApprovedByRule())
+			assert.Equal(t, tt.want.Role(), u.Role())
+			assert.True(t, u.ApprovedBy().IsEmpty())
+
+			got, err := repo.FindByEmail(ctx, "alice@eukarya.io")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want.ID(), got.ApprovedByRule())
+		})
+	}
+}
+
 // raceRepo simulates a concurrent first sign-in: FindByEmail initially reports
 // NotFound, Save fails with ErrDuplicatedAdminUser (another request won the
 // race) but makes the record visible to the next FindByEmail.
@@ -150,8 +219,8 @@ func (r *raceRepo) SaveGuardingLastSystemAdmin(ctx context.Context, u *adminuser
 
 func TestGoogleSignIn_NewUser_DuplicateRaceReturnsExisting(t *testing.T) {
 	repo := &raceRepo{}
-	v := fakeVerifier{claims: &google.Claims{Email: "alice@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Alice"}}
-	uc := NewGoogleSignInUseCase(repo, v, GoogleSignInOptions{AllowedDomain: "eukarya.io"})
+	v := fakeVerifier{claims: &identity.Claims{Email: "alice@eukarya.io", EmailVerified: true, HD: "eukarya.io", Name: "Alice"}}
+	uc := NewGoogleSignInUseCase(repo, memory.NewAdminApprovalRule(), v, GoogleSignInOptions{AllowedDomain: "eukarya.io"})
 
 	u, err := uc.Execute(context.Background(), "tok")
 	require.NoError(t, err)
@@ -174,19 +243,19 @@ func TestGoogleSignIn_Errors(t *testing.T) {
 		},
 		{
 			name: "email not verified",
-			v:    fakeVerifier{claims: &google.Claims{Email: "a@eukarya.io", EmailVerified: false, HD: "eukarya.io"}},
+			v:    fakeVerifier{claims: &identity.Claims{Email: "a@eukarya.io", EmailVerified: false, HD: "eukarya.io"}},
 			opts: GoogleSignInOptions{AllowedDomain: "eukarya.io"},
 			err:  ErrEmailNotVerified,
 		},
 		{
 			name: "wrong hd",
-			v:    fakeVerifier{claims: &google.Claims{Email: "a@gmail.com", EmailVerified: true, HD: ""}},
+			v:    fakeVerifier{claims: &identity.Claims{Email: "a@gmail.com", EmailVerified: true, HD: ""}},
 			opts: GoogleSignInOptions{AllowedDomain: "eukarya.io"},
 			err:  ErrDomainNotAllowed,
 		},
 		{
 			name: "hd ok but email other domain",
-			v:    fakeVerifier{claims: &google.Claims{Email: "a@evil.com", EmailVerified: true, HD: "eukarya.io"}},
+			v:    fakeVerifier{claims: &identity.Claims{Email: "a@evil.com", EmailVerified: true, HD: "eukarya.io"}},
 			opts: GoogleSignInOptions{AllowedDomain: "eukarya.io"},
 			err:  ErrDomainNotAllowed,
 		},
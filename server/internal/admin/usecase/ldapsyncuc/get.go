@@ -0,0 +1,22 @@
+package ldapsyncuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+)
+
+// GetLDAPSyncRunUseCase fetches an LDAP sync run with what it changed.
+type GetLDAPSyncRunUseCase struct {
+	ldapSyncRepo ldapsync.Repo
+}
+
+// NewGetLDAPSyncRunUseCase is a Wire provider for GetLDAPSyncRunUseCase.
+func NewGetLDAPSyncRunUseCase(ldapSyncRepo ldapsync.Repo) *GetLDAPSyncRunUseCase {
+	return &GetLDAPSyncRunUseCase{ldapSyncRepo: ldapSyncRepo}
+}
+
+// Execute returns the run, or rerror.ErrNotFound if there is none.
+func (uc *GetLDAPSyncRunUseCase) Execute(ctx context.Context, id ldapsync.ID) (*ldapsync.Run, error) {
+	return uc.ldapSyncRepo.FindByID(ctx, id)
+}
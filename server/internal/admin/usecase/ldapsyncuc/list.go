@@ -0,0 +1,36 @@
+// Package ldapsyncuc holds the usecases reporting the runs of the LDAP
+// directory sync. The runs themselves are scheduled by the accounts server.
+package ldapsyncuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+)
+
+const (
+	defaultListLimit = 20
+	maxListLimit     = 100
+)
+
+// ListLDAPSyncRunsUseCase lists the recent LDAP sync runs.
+type ListLDAPSyncRunsUseCase struct {
+	ldapSyncRepo ldapsync.Repo
+}
+
+// NewListLDAPSyncRunsUseCase is a Wire provider for ListLDAPSyncRunsUseCase.
+func NewListLDAPSyncRunsUseCase(ldapSyncRepo ldapsync.Repo) *ListLDAPSyncRunsUseCase {
+	return &ListLDAPSyncRunsUseCase{ldapSyncRepo: ldapSyncRepo}
+}
+
+// Execute returns up to limit runs, newest first. A limit of zero returns the
+// default number of runs and a larger one than allowed is capped.
+func (uc *ListLDAPSyncRunsUseCase) Execute(ctx context.Context, limit int) (ldapsync.List, error) {
+	if limit < 1 {
+		limit = defaultListLimit
+	}
+	if limit > maxListLimit {
+		limit = maxListLimit
+	}
+	return uc.ldapSyncRepo.FindRecent(ctx, limit)
+}
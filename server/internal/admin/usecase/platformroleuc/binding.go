@@ -0,0 +1,118 @@
+package platformroleuc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// BindingInput is the input of GrantRoleUseCase and RevokeRoleUseCase.
+type BindingInput struct {
+	Operator adminuser.ID
+	Role     id.RoleID
+	User     user.ID
+	// Workspace scopes the binding to a workspace. The binding is global when
+	// it is nil.
+	Workspace *workspace.ID
+}
+
+// scope describes where the binding applies, for logs.
+func (in BindingInput) scope() string {
+	if in.Workspace == nil {
+		return "global"
+	}
+	return "workspace " + in.Workspace.String()
+}
+
+// roleBinding changes the permittable of a user.
+type roleBinding struct {
+	roleRepo        role.Repo
+	permittableRepo permittable.Repo
+	userRepo        user.Repo
+	workspaceRepo   workspace.Repo
+	transaction     usecasex.Transaction
+}
+
+// run loads the role, the user and, for a workspace binding, the workspace,
+// lets apply change the permittable of the user and saves it, in one
+// transaction. A user without a permittable gets a new one.
+func (b roleBinding) run(
+	ctx context.Context,
+	in BindingInput,
+	apply func(p *permittable.Permittable) error,
+) (*permittable.Permittable, error) {
+	var out *permittable.Permittable
+	err := usecasex.DoTransaction(ctx, b.transaction, 0, func(ctx context.Context) error {
+		if _, err := b.roleRepo.FindByID(ctx, in.Role); err != nil {
+			return err
+		}
+		if _, err := b.userRepo.FindByID(ctx, in.User); err != nil {
+			return err
+		}
+		if in.Workspace != nil {
+			if _, err := b.workspaceRepo.FindByID(ctx, *in.Workspace); err != nil {
+				return err
+			}
+		}
+
+		p, err := b.permittable(ctx, in.User)
+		if err != nil {
+			return err
+		}
+		if err := apply(p); err != nil {
+			return err
+		}
+		if err := b.permittableRepo.Save(ctx, *p); err != nil {
+			return err
+		}
+		out = p
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// permittable returns the permittable of the user, or a new unsaved one when
+// the user has none.
+func (b roleBinding) permittable(ctx context.Context, uid user.ID) (*permittable.Permittable, error) {
+	p, err := b.permittableRepo.FindByUserID(ctx, uid)
+	if errors.Is(err, rerror.ErrNotFound) {
+		return permittable.New().NewID().UserID(uid).Build()
+	}
+	return p, err
+}
+
+// membershipRole returns the membership role the role is named after. The
+// workspace bindings of a permittable follow the memberships of the user, so
+// only these roles can be bound in a workspace.
+func (b roleBinding) membershipRole(ctx context.Context, rid id.RoleID) (role.RoleType, error) {
+	r, err := b.roleRepo.FindByID(ctx, rid)
+	if err != nil {
+		return "", err
+	}
+	rt := role.RoleType(r.Name())
+	if !rt.Valid() || rt == role.RoleSelf {
+		return "", ErrNotMembershipRole
+	}
+	return rt, nil
+}
+
+// workspaceRole returns the role the permittable binds in the workspace.
+func workspaceRole(p *permittable.Permittable, wid workspace.ID) (id.RoleID, bool) {
+	for _, wr := range p.WorkspaceRoles() {
+		if wr.ID() == wid {
+			return wr.RoleID(), true
+		}
+	}
+	return id.RoleID{}, false
+}
@@ -0,0 +1,19 @@
+package platformroleuc
+
+import (
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var (
+	// ErrRoleAlreadyGranted is returned when granting a role the user already
+	// holds in the same scope.
+	ErrRoleAlreadyGranted = rerror.NewE(i18n.T("user already has the role"))
+	// ErrRoleNotGranted is returned when revoking a role the user does not
+	// hold in the given scope.
+	ErrRoleNotGranted = rerror.NewE(i18n.T("user does not have the role"))
+	// ErrNotMembershipRole is returned when binding a role in a workspace that
+	// is not one of the membership roles. Workspace bindings follow the
+	// memberships, so only the roles named after them can be bound there.
+	ErrNotMembershipRole = rerror.NewE(i18n.T("role is not a workspace membership role"))
+)
@@ -0,0 +1,87 @@
+package platformroleuc
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// GrantRoleUseCase binds a role to a user, globally or in a workspace.
+type GrantRoleUseCase struct {
+	binding      roleBinding
+	updateMember *workspaceuc.UpdateWorkspaceMemberUseCase
+}
+
+// NewGrantRoleUseCase is a Wire provider for GrantRoleUseCase.
+func NewGrantRoleUseCase(
+	roleRepo role.Repo,
+	permittableRepo permittable.Repo,
+	userRepo user.Repo,
+	workspaceRepo workspace.Repo,
+	transaction usecasex.Transaction,
+	updateMember *workspaceuc.UpdateWorkspaceMemberUseCase,
+) *GrantRoleUseCase {
+	return &GrantRoleUseCase{
+		binding: roleBinding{
+			roleRepo:        roleRepo,
+			permittableRepo: permittableRepo,
+			userRepo:        userRepo,
+			workspaceRepo:   workspaceRepo,
+			transaction:     transaction,
+		},
+		updateMember: updateMember,
+	}
+}
+
+// Execute adds the role to the global roles of the user or, for a workspace
+// binding, changes the membership role of the user in the workspace to the
+// role with UpdateWorkspaceMemberUseCase, which binds the permittable to it.
+// The user must already be a member of the workspace.
+func (uc *GrantRoleUseCase) Execute(ctx context.Context, in BindingInput) (*permittable.Permittable, error) {
+	var p *permittable.Permittable
+	var err error
+	if in.Workspace != nil {
+		p, err = uc.grantMembership(ctx, in)
+	} else {
+		p, err = uc.binding.run(ctx, in, func(p *permittable.Permittable) error {
+			if slices.Contains(p.RoleIDs(), in.Role) {
+				return ErrRoleAlreadyGranted
+			}
+			p.EditRoleIDs(append(slices.Clone(p.RoleIDs()), in.Role))
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] role %s granted to user %s (%s) by %s", in.Role, in.User, in.scope(), in.Operator)
+	return p, nil
+}
+
+func (uc *GrantRoleUseCase) grantMembership(ctx context.Context, in BindingInput) (*permittable.Permittable, error) {
+	rt, err := uc.binding.membershipRole(ctx, in.Role)
+	if err != nil {
+		return nil, err
+	}
+	_, err = uc.updateMember.Execute(ctx, workspaceuc.UpdateWorkspaceMemberInput{
+		WorkspaceActionInput: workspaceuc.WorkspaceActionInput{Operator: in.Operator, Workspace: *in.Workspace},
+		User:                 in.User,
+		Role:                 rt,
+	})
+	if errors.Is(err, workspaceuc.ErrNothingToUpdate) {
+		return nil, ErrRoleAlreadyGranted
+	}
+	if err != nil {
+		return nil, err
+	}
+	return uc.binding.permittable(ctx, in.User)
+}
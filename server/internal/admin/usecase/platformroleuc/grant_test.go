@@ -0,0 +1,172 @@
+package platformroleuc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newBindingUseCases(r *repo.Container) (*GrantRoleUseCase, *RevokeRoleUseCase) {
+	return NewGrantRoleUseCase(r.Role, r.Permittable, r.User, r.Workspace, r.Transaction,
+			workspaceuc.NewUpdateWorkspaceMemberUseCase(r.Workspace, r.Role, r.Permittable, r.AuditLog, r.Transaction)),
+		NewRevokeRoleUseCase(r.Role, r.Permittable, r.User, r.Workspace, r.Transaction,
+			workspaceuc.NewRemoveWorkspaceMemberUseCase(r.Workspace, r.Role, r.Permittable, r.AuditLog, r.Transaction))
+}
+
+func saveRole(t *testing.T, r *repo.Container, name string) *role.Role {
+	t.Helper()
+	rl := role.New().NewID().Name(name).MustBuild()
+	require.NoError(t, r.Role.Save(context.Background(), *rl))
+	return rl
+}
+
+func TestGrantAndRevokeRole_Global(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	op := adminuser.NewID()
+	admin := saveRole(t, r, "admin")
+	u := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	grant, revoke := newBindingUseCases(r)
+	in := BindingInput{Operator: op, Role: admin.ID(), User: u.ID()}
+
+	_, err := revoke.Execute(ctx, in)
+	assert.ErrorIs(t, err, ErrRoleNotGranted)
+
+	p, err := grant.Execute(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, u.ID(), p.UserID())
+	assert.Equal(t, []id.RoleID{admin.ID()}, p.RoleIDs())
+	_, err = grant.Execute(ctx, in)
+	assert.ErrorIs(t, err, ErrRoleAlreadyGranted)
+
+	holders, err := r.Permittable.FindByRoleID(ctx, admin.ID())
+	require.NoError(t, err)
+	assert.Len(t, holders, 1)
+
+	p, err = revoke.Execute(ctx, in)
+	require.NoError(t, err)
+	assert.Empty(t, p.RoleIDs())
+	_, err = revoke.Execute(ctx, in)
+	assert.ErrorIs(t, err, ErrRoleNotGranted)
+}
+
+func TestGrantAndRevokeRole_Workspace(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	op := adminuser.NewID()
+	reader := saveRole(t, r, string(role.RoleReader))
+	writer := saveRole(t, r, string(role.RoleWriter))
+	u := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	owner := user.New().NewID().Name("bob").Email("bob@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	require.NoError(t, r.User.Save(ctx, owner))
+	ws := workspace.New().NewID().Name("gis").Members(map[user.ID]workspace.Member{
+		owner.ID(): {Role: role.RoleOwner},
+		u.ID():     {Role: role.RoleReader},
+	}).MustBuild()
+	require.NoError(t, r.Workspace.Save(ctx, ws))
+	wid := ws.ID()
+
+	grant, revoke := newBindingUseCases(r)
+	in := BindingInput{Operator: op, Role: writer.ID(), User: u.ID(), Workspace: &wid}
+
+	p, err := grant.Execute(ctx, in)
+	require.NoError(t, err)
+	assert.Equal(t, []permittable.WorkspaceRole{permittable.NewWorkspaceRole(wid, writer.ID())}, p.WorkspaceRoles())
+	got, err := r.Workspace.FindByID(ctx, wid)
+	require.NoError(t, err)
+	assert.Equal(t, role.RoleWriter, got.Members().UserRole(u.ID()))
+
+	_, err = grant.Execute(ctx, in)
+	assert.ErrorIs(t, err, ErrRoleAlreadyGranted)
+	_, err = revoke.Execute(ctx, BindingInput{Operator: op, Role: reader.ID(), User: u.ID(), Workspace: &wid})
+	assert.ErrorIs(t, err, ErrRoleNotGranted)
+
+	p, err = revoke.Execute(ctx, in)
+	require.NoError(t, err)
+	assert.Empty(t, p.WorkspaceRoles())
+	got, err = r.Workspace.FindByID(ctx, wid)
+	require.NoError(t, err)
+	assert.False(t, got.Members().HasUser(u.ID()))
+
+	entries, err := r.AuditLog.FindByTarget(ctx, wid.String())
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	_, err = grant.Execute(ctx, in)
+	assert.ErrorIs(t, err, workspace.ErrTargetUserNotInTheWorkspace)
+	_, err = r.Permittable.FindByRoleID(ctx, writer.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func TestRevokeRole_LeftoverBinding(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	writer := saveRole(t, r, string(role.RoleWriter))
+	u := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	ws := workspace.New().NewID().Name("gis").MustBuild()
+	require.NoError(t, r.Workspace.Save(ctx, ws))
+	wid := ws.ID()
+	require.NoError(t, r.Permittable.Save(ctx, *permittable.New().NewID().UserID(u.ID()).
+		WorkspaceRoles([]permittable.WorkspaceRole{permittable.NewWorkspaceRole(wid, writer.ID())}).MustBuild()))
+
+	_, revoke := newBindingUseCases(r)
+	in := BindingInput{Operator: adminuser.NewID(), Role: writer.ID(), User: u.ID(), Workspace: &wid}
+	p, err := revoke.Execute(ctx, in)
+	require.NoError(t, err)
+	assert.Empty(t, p.WorkspaceRoles())
+	_, err = revoke.Execute(ctx, in)
+	assert.ErrorIs(t, err, ErrRoleNotGranted)
+}
+
+func TestGrantRole_Invalid(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	op := adminuser.NewID()
+	admin := saveRole(t, r, "admin")
+	writer := saveRole(t, r, string(role.RoleWriter))
+	u := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	member := workspace.New().NewID().Name("gis").Members(map[user.ID]workspace.Member{
+		u.ID(): {Role: role.RoleReader},
+	}).MustBuild()
+	other := workspace.New().NewID().Name("ops").MustBuild()
+	require.NoError(t, r.Workspace.Save(ctx, member))
+	require.NoError(t, r.Workspace.Save(ctx, other))
+	memberID, otherID, missingID := member.ID(), other.ID(), workspace.NewID()
+
+	grant, _ := newBindingUseCases(r)
+	for name, tc := range map[string]struct {
+		in   BindingInput
+		want error
+	}{
+		"missing role":        {BindingInput{Operator: op, Role: role.NewID(), User: u.ID()}, rerror.ErrNotFound},
+		"missing user":        {BindingInput{Operator: op, Role: admin.ID(), User: user.NewID()}, rerror.ErrNotFound},
+		"missing workspace":   {BindingInput{Operator: op, Role: writer.ID(), User: u.ID(), Workspace: &missingID}, rerror.ErrNotFound},
+		"not a member":        {BindingInput{Operator: op, Role: writer.ID(), User: u.ID(), Workspace: &otherID}, workspace.ErrTargetUserNotInTheWorkspace},
+		"not membership role": {BindingInput{Operator: op, Role: admin.ID(), User: u.ID(), Workspace: &memberID}, ErrNotMembershipRole},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := grant.Execute(ctx, tc.in)
+			assert.ErrorIs(t, err, tc.want)
+		})
+	}
+
+	_, err := r.Permittable.FindByUserID(ctx, u.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
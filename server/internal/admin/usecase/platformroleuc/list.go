@@ -0,0 +1,51 @@
+// Package platformroleuc holds the admin usecases of the platform roles
+// (role.Role) and of their bindings to users (permittable.Permittable).
+package platformroleuc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// ListRolesUseCase lists the roles with the number of users holding each.
+type ListRolesUseCase struct {
+	roleRepo        role.Repo
+	permittableRepo permittable.Repo
+}
+
+// NewListRolesUseCase is a Wire provider for ListRolesUseCase.
+func NewListRolesUseCase(roleRepo role.Repo, permittableRepo permittable.Repo) *ListRolesUseCase {
+	return &ListRolesUseCase{roleRepo: roleRepo, permittableRepo: permittableRepo}
+}
+
+// RoleSummary is a role and the number of users holding it globally.
+type RoleSummary struct {
+	Role  *role.Role
+	Users int
+}
+
+// Execute returns every role sorted by name. Users counts the global bindings
+// only, like FindByRoleID; bindings scoped to a workspace are not counted.
+func (uc *ListRolesUseCase) Execute(ctx context.Context) ([]RoleSummary, error) {
+	roles, err := uc.roleRepo.FindAll(ctx)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, err
+	}
+
+	res := make([]RoleSummary, 0, len(roles))
+	for _, r := range roles {
+		holders, err := uc.permittableRepo.FindByRoleID(ctx, r.ID())
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return nil, err
+		}
+		res = append(res, RoleSummary{Role: r, Users: len(holders)})
+	}
+	slices.SortFunc(res, func(a, b RoleSummary) int { return strings.Compare(a.Role.Name(), b.Role.Name()) })
+	return res, nil
+}
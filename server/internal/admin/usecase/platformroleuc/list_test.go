@@ -0,0 +1,65 @@
+package platformroleuc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListRolesAndUsers(t *testing.T) {
+	ctx := context.Background()
+	admin := role.New().NewID().Name("admin").MustBuild()
+	support := role.New().NewID().Name("support").MustBuild()
+	roleRepo := memory.NewRoleWith(support, admin)
+
+	users := make([]*user.User, 3)
+	for i, name := range []string{"alice", "bob", "carol"} {
+		users[i] = user.New().NewID().Name(name).Email(name + "@example.com").MustBuild()
+	}
+	userRepo := memory.NewUserWith(users...)
+	ghost := user.NewID()
+	permittableRepo := memory.NewPermittableWith(
+		permittable.New().NewID().UserID(users[0].ID()).RoleIDs([]id.RoleID{admin.ID(), support.ID()}).MustBuild(),
+		permittable.New().NewID().UserID(users[1].ID()).RoleIDs([]id.RoleID{admin.ID()}).MustBuild(),
+		permittable.New().NewID().UserID(ghost).RoleIDs([]id.RoleID{admin.ID()}).MustBuild(),
+	)
+
+	summaries, err := NewListRolesUseCase(roleRepo, permittableRepo).Execute(ctx)
+	require.NoError(t, err)
+	require.Len(t, summaries, 2)
+	assert.Equal(t, admin.ID(), summaries[0].Role.ID())
+	assert.Equal(t, 3, summaries[0].Users)
+	assert.Equal(t, support.ID(), summaries[1].Role.ID())
+	assert.Equal(t, 1, summaries[1].Users)
+
+	uc := NewListRoleUsersUseCase(roleRepo, permittableRepo, userRepo)
+	list, pi, err := uc.Execute(ctx, admin.ID(), usecasex.OffsetPagination{Offset: 0, Limit: 10}.Wrap())
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), pi.TotalCount)
+	assert.ElementsMatch(t, []user.ID{users[0].ID(), users[1].ID()}, userIDs(list))
+
+	list, pi, err = uc.Execute(ctx, support.ID(), nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pi.TotalCount)
+	assert.Equal(t, []user.ID{users[0].ID()}, userIDs(list))
+
+	_, _, err = uc.Execute(ctx, role.NewID(), nil)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func userIDs(list user.List) []user.ID {
+	res := make([]user.ID, 0, len(list))
+	for _, u := range list {
+		res = append(res, u.ID())
+	}
+	return res
+}
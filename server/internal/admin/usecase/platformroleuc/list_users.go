@@ -0,0 +1,70 @@
+package platformroleuc
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// ListRoleUsersUseCase lists the users holding a role globally, with offset
+// pagination.
+type ListRoleUsersUseCase struct {
+	roleRepo        role.Repo
+	permittableRepo permittable.Repo
+	userRepo        user.Repo
+}
+
+// NewListRoleUsersUseCase is a Wire provider for ListRoleUsersUseCase.
+func NewListRoleUsersUseCase(roleRepo role.Repo, permittableRepo permittable.Repo, userRepo user.Repo) *ListRoleUsersUseCase {
+	return &ListRoleUsersUseCase{roleRepo: roleRepo, permittableRepo: permittableRepo, userRepo: userRepo}
+}
+
+// Execute returns a page of the users holding the role, ordered by user ID.
+// The total counts the bindings, so a binding whose user no longer exists is
+// counted but not listed.
+func (uc *ListRoleUsersUseCase) Execute(ctx context.Context, rid id.RoleID, pagination *usecasex.Pagination) (user.List, *usecasex.PageInfo, error) {
+	if pagination != nil && pagination.Cursor != nil {
+		return nil, nil, user.ErrCursorPaginationUnsupported
+	}
+	if _, err := uc.roleRepo.FindByID(ctx, rid); err != nil {
+		return nil, nil, err
+	}
+
+	holders, err := uc.permittableRepo.FindByRoleID(ctx, rid)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, nil, err
+	}
+	uids := make(user.IDList, 0, len(holders))
+	for _, p := range holders {
+		uids = append(uids, p.UserID())
+	}
+	slices.SortFunc(uids, func(a, b user.ID) int { return a.Compare(b) })
+
+	total := int64(len(uids))
+	offset, end := int64(0), total
+	if pagination != nil && pagination.Offset != nil {
+		offset = min(pagination.Offset.Offset, total)
+		end = min(offset+pagination.Offset.Limit, total)
+	}
+	pi := usecasex.NewPageInfo(total, nil, nil, end < total, offset > 0)
+	page := uids[offset:end]
+	if len(page) == 0 {
+		return user.List{}, pi, nil
+	}
+
+	list, err := uc.userRepo.FindByIDs(ctx, page)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, nil, err
+	}
+	// FindByIDs order is backend-dependent; keep the page in user ID order.
+	list = slices.DeleteFunc(list, func(u *user.User) bool { return u == nil })
+	slices.SortFunc(list, func(a, b *user.User) int { return a.ID().Compare(b.ID()) })
+	return list, pi, nil
+}
@@ -0,0 +1,101 @@
+package platformroleuc
+
+import (
+	"context"
+	"slices"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/workspaceuc"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// RevokeRoleUseCase unbinds a role from a user, globally or in a workspace.
+type RevokeRoleUseCase struct {
+	binding      roleBinding
+	removeMember *workspaceuc.RemoveWorkspaceMemberUseCase
+}
+
+// NewRevokeRoleUseCase is a Wire provider for RevokeRoleUseCase.
+func NewRevokeRoleUseCase(
+	roleRepo role.Repo,
+	permittableRepo permittable.Repo,
+	userRepo user.Repo,
+	workspaceRepo workspace.Repo,
+	transaction usecasex.Transaction,
+	removeMember *workspaceuc.RemoveWorkspaceMemberUseCase,
+) *RevokeRoleUseCase {
+	return &RevokeRoleUseCase{
+		binding: roleBinding{
+			roleRepo:        roleRepo,
+			permittableRepo: permittableRepo,
+			userRepo:        userRepo,
+			workspaceRepo:   workspaceRepo,
+			transaction:     transaction,
+		},
+		removeMember: removeMember,
+	}
+}
+
+// Execute removes the role from the global roles of the user or, for a
+// workspace binding, removes the member holding the role from the workspace
+// with RemoveWorkspaceMemberUseCase, which unbinds the permittable. For a user
+// who is not a member, it only removes a binding left in the permittable, as
+// bindings made before they followed the memberships may be.
+func (uc *RevokeRoleUseCase) Execute(ctx context.Context, in BindingInput) (*permittable.Permittable, error) {
+	var p *permittable.Permittable
+	var err error
+	if in.Workspace != nil {
+		p, err = uc.revokeMembership(ctx, in)
+	} else {
+		p, err = uc.binding.run(ctx, in, func(p *permittable.Permittable) error {
+			if !slices.Contains(p.RoleIDs(), in.Role) {
+				return ErrRoleNotGranted
+			}
+			p.EditRoleIDs(slices.DeleteFunc(slices.Clone(p.RoleIDs()), func(rid id.RoleID) bool { return rid == in.Role }))
+			return nil
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] role %s revoked from user %s (%s) by %s", in.Role, in.User, in.scope(), in.Operator)
+	return p, nil
+}
+
+func (uc *RevokeRoleUseCase) revokeMembership(ctx context.Context, in BindingInput) (*permittable.Permittable, error) {
+	rt, err := uc.binding.membershipRole(ctx, in.Role)
+	if err != nil {
+		return nil, err
+	}
+	ws, err := uc.binding.workspaceRepo.FindByID(ctx, *in.Workspace)
+	if err != nil {
+		return nil, err
+	}
+
+	if !ws.Members().HasUser(in.User) {
+		return uc.binding.run(ctx, in, func(p *permittable.Permittable) error {
+			if rid, ok := workspaceRole(p, *in.Workspace); !ok || rid != in.Role {
+				return ErrRoleNotGranted
+			}
+			p.RemoveWorkspaceRole(*in.Workspace)
+			return nil
+		})
+	}
+
+	if ws.Members().UserRole(in.User) != rt {
+		return nil, ErrRoleNotGranted
+	}
+	if _, err := uc.removeMember.Execute(ctx, workspaceuc.RemoveWorkspaceMemberInput{
+		WorkspaceActionInput: workspaceuc.WorkspaceActionInput{Operator: in.Operator, Workspace: *in.Workspace},
+		User:                 in.User,
+	}); err != nil {
+		return nil, err
+	}
+	return uc.binding.permittable(ctx, in.User)
+}
@@ -0,0 +1,40 @@
+package rolemappinguc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+)
+
+// DeleteRoleMappingUseCase removes the role mapping of a workspace.
+type DeleteRoleMappingUseCase struct {
+	roleMappingRepo rolemapping.Repo
+}
+
+// NewDeleteRoleMappingUseCase is a Wire provider for DeleteRoleMappingUseCase.
+func NewDeleteRoleMappingUseCase(roleMappingRepo rolemapping.Repo) *DeleteRoleMappingUseCase {
+	return &DeleteRoleMappingUseCase{roleMappingRepo: roleMappingRepo}
+}
+
+// DeleteInput is the input for DeleteRoleMappingUseCase.Execute.
+type DeleteInput struct {
+	Operator  adminuser.ID
+	Workspace workspace.ID
+}
+
+// Execute stops mapping the identity provider's claims. The members it added
+// are kept and can be managed like any other.
+func (uc *DeleteRoleMappingUseCase) Execute(ctx context.Context, in DeleteInput) error {
+	if _, err := uc.roleMappingRepo.FindByWorkspace(ctx, in.Workspace); err != nil {
+		return err
+	}
+	if err := uc.roleMappingRepo.RemoveByWorkspace(ctx, in.Workspace); err != nil {
+		return err
+	}
+
+	log.Infofc(ctx, "[admin] role mapping of workspace %s deleted by %s", in.Workspace, in.Operator)
+	return nil
+}
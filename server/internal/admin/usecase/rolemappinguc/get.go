@@ -0,0 +1,26 @@
+// Package rolemappinguc holds the usecases managing the rules that map the
+// claims of a workspace's identity provider to workspace roles.
+package rolemappinguc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+)
+
+// GetRoleMappingUseCase fetches the role mapping of a workspace.
+type GetRoleMappingUseCase struct {
+	roleMappingRepo rolemapping.Repo
+}
+
+// NewGetRoleMappingUseCase is a Wire provider for GetRoleMappingUseCase.
+func NewGetRoleMappingUseCase(roleMappingRepo rolemapping.Repo) *GetRoleMappingUseCase {
+	return &GetRoleMappingUseCase{roleMappingRepo: roleMappingRepo}
+}
+
+// Execute returns the role mapping of the workspace, or rerror.ErrNotFound if
+// it has none.
+func (uc *GetRoleMappingUseCase) Execute(ctx context.Context, wid workspace.ID) (*rolemapping.RoleMapping, error) {
+	return uc.roleMappingRepo.FindByWorkspace(ctx, wid)
+}
@@ -0,0 +1,73 @@
+package rolemappinguc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// ErrPersonalWorkspace is returned when a role mapping is set on a personal
+// workspace, whose only member is its owner.
+var ErrPersonalWorkspace = errors.New("role mappings can't be set on a personal workspace")
+
+// SetRoleMappingUseCase creates or replaces the role mapping of a workspace.
+type SetRoleMappingUseCase struct {
+	roleMappingRepo rolemapping.Repo
+	workspaceRepo   workspace.Repo
+}
+
+// NewSetRoleMappingUseCase is a Wire provider for SetRoleMappingUseCase.
+func NewSetRoleMappingUseCase(roleMappingRepo rolemapping.Repo, workspaceRepo workspace.Repo) *SetRoleMappingUseCase {
+	return &SetRoleMappingUseCase{roleMappingRepo: roleMappingRepo, workspaceRepo: workspaceRepo}
+}
+
+// SetInput is the input for SetRoleMappingUseCase.Execute.
+type SetInput struct {
+	Operator  adminuser.ID
+	Workspace workspace.ID
+	// Issuer is the iss of the identity provider's tokens.
+	Issuer string
+	// SubPrefix narrows the identity provider down to one connection when
+	// several share the issuer, e.g. "samlp|org_123".
+	SubPrefix string
+	Rules     []rolemapping.Rule
+}
+
+// Execute saves the mapping. It takes effect the next time a user of the
+// identity provider signs in or calls the API; existing memberships are not
+// re-evaluated until then.
+func (uc *SetRoleMappingUseCase) Execute(ctx context.Context, in SetInput) (*rolemapping.RoleMapping, error) {
+	ws, err := uc.workspaceRepo.FindByID(ctx, in.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	if ws.IsPersonal() {
+		return nil, ErrPersonalWorkspace
+	}
+
+	b := rolemapping.New().Workspace(ws.ID()).Issuer(in.Issuer).SubPrefix(in.SubPrefix).Rules(in.Rules)
+	existing, err := uc.roleMappingRepo.FindByWorkspace(ctx, ws.ID())
+	switch {
+	case err == nil:
+		b = b.ID(existing.ID())
+	case errors.Is(err, rerror.ErrNotFound):
+		b = b.NewID()
+	default:
+		return nil, err
+	}
+	m, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.roleMappingRepo.Save(ctx, m); err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] role mapping of workspace %s set by %s: issuer=%s rules=%d", ws.ID(), in.Operator, m.Issuer(), len(m.Rules()))
+	return m, nil
+}
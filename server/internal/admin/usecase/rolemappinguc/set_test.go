@@ -0,0 +1,76 @@
+package rolemappinguc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetGetDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewRoleMapping()
+	wsRepo := memory.NewWorkspace()
+	ws := workspace.New().NewID().Name("gis").MustBuild()
+	require.NoError(t, wsRepo.Save(ctx, ws))
+	op := adminuser.NewID()
+
+	set := NewSetRoleMappingUseCase(repo, wsRepo)
+	first, err := set.Execute(ctx, SetInput{
+		Operator:  op,
+		Workspace: ws.ID(),
+		Issuer:    "https://example.auth0.com/",
+		SubPrefix: "samlp|org_1",
+		Rules:     []rolemapping.Rule{{Claim: "groups", Value: "gis-viewers", Role: role.RoleReader}},
+	})
+	require.NoError(t, err)
+
+	second, err := set.Execute(ctx, SetInput{
+		Operator:  op,
+		Workspace: ws.ID(),
+		Issuer:    "https://example.auth0.com/",
+		Rules:     []rolemapping.Rule{{Claim: "groups", Value: "gis-editors", Role: role.RoleWriter}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, first.ID(), second.ID())
+
+	got, err := NewGetRoleMappingUseCase(repo).Execute(ctx, ws.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "", got.SubPrefix())
+	assert.Equal(t, second.Rules(), got.Rules())
+
+	del := NewDeleteRoleMappingUseCase(repo)
+	require.NoError(t, del.Execute(ctx, DeleteInput{Operator: op, Workspace: ws.ID()}))
+	_, err = repo.FindByWorkspace(ctx, ws.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	assert.ErrorIs(t, del.Execute(ctx, DeleteInput{Operator: op, Workspace: ws.ID()}), rerror.ErrNotFound)
+}
+
+func TestSet_Invalid(t *testing.T) {
+	ctx := context.Background()
+	wsRepo := memory.NewWorkspace()
+	personal := workspace.New().NewID().Name("alice").Personal(true).MustBuild()
+	shared := workspace.New().NewID().Name("gis").MustBuild()
+	require.NoError(t, wsRepo.SaveAll(ctx, workspace.List{personal, shared}))
+	set := NewSetRoleMappingUseCase(memory.NewRoleMapping(), wsRepo)
+
+	_, err := set.Execute(ctx, SetInput{Workspace: workspace.NewID(), Issuer: "https://idp.example.com/"})
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	_, err = set.Execute(ctx, SetInput{Workspace: personal.ID(), Issuer: "https://idp.example.com/"})
+	assert.ErrorIs(t, err, ErrPersonalWorkspace)
+	_, err = set.Execute(ctx, SetInput{Workspace: shared.ID(), Issuer: " "})
+	assert.ErrorIs(t, err, rolemapping.ErrEmptyIssuer)
+	_, err = set.Execute(ctx, SetInput{
+		Workspace: shared.ID(),
+		Issuer:    "https://idp.example.com/",
+		Rules:     []rolemapping.Rule{{Claim: "groups", Value: "x", Role: role.RoleType("admin")}},
+	})
+	assert.ErrorIs(t, err, rolemapping.ErrInvalidRole)
+}
@@ -0,0 +1,88 @@
+package scimtenantuc
+
+import (
+	"context"
+	"errors"
+	"slices"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/log"
+)
+
+// ErrDuplicateSubPrefix is returned when the sub prefix overlaps with the one
+// of another tenant, which could then see and take over its users.
+var ErrDuplicateSubPrefix = errors.New("the sub prefix overlaps with another scim tenant")
+
+// builtinSubPrefixes are the providers whose subs a tenant must never issue.
+// "scim" holds the default prefixes, which are unique to each tenant.
+var builtinSubPrefixes = []string{user.ProviderReearth, user.ProviderAuth0, string(gateway.ProviderCIP), "scim"}
+
+// ReservedSubPrefixes are the sub prefixes of the identity providers
+// configured on the main service, i.e. the generic OIDC providers and LDAP.
+type ReservedSubPrefixes []string
+
+// CreateSCIMTenantUseCase registers a SCIM tenant and issues its bearer token.
+type CreateSCIMTenantUseCase struct {
+	scimTenantRepo scimtenant.Repo
+	reserved       ReservedSubPrefixes
+}
+
+// NewCreateSCIMTenantUseCase is a Wire provider for CreateSCIMTenantUseCase.
+func NewCreateSCIMTenantUseCase(scimTenantRepo scimtenant.Repo, reserved ReservedSubPrefixes) *CreateSCIMTenantUseCase {
+	return &CreateSCIMTenantUseCase{scimTenantRepo: scimTenantRepo, reserved: reserved}
+}
+
+// CreateInput is the input for CreateSCIMTenantUseCase.Execute.
+type CreateInput struct {
+	Operator adminuser.ID
+	Name     string
+	// SubPrefix is the auth sub prefix the identity provider signs users in
+	// with, e.g. "samlp|okta". It defaults to a prefix unique to the tenant, so
+	// that provisioned users are not matched with any existing auth.
+	// Prefixes of other providers and other tenants are refused.
+	SubPrefix string
+}
+
+// Execute saves the tenant and returns it with its bearer token, which is only
+// stored hashed and can't be retrieved again.
+func (uc *CreateSCIMTenantUseCase) Execute(ctx context.Context, in CreateInput) (*scimtenant.Tenant, string, error) {
+	t, err := scimtenant.New().NewID().Name(in.Name).SubPrefix(in.SubPrefix).Build()
+	if err != nil {
+		return nil, "", err
+	}
+	if in.SubPrefix != "" {
+		if err := uc.checkSubPrefix(ctx, t.SubPrefix()); err != nil {
+			return nil, "", err
+		}
+	}
+	token, err := t.RotateToken()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := uc.scimTenantRepo.Save(ctx, t); err != nil {
+		return nil, "", err
+	}
+
+	log.Infofc(ctx, "[admin] scim tenant %s (%s) created by %s", t.ID(), t.Name(), in.Operator)
+	return t, token, nil
+}
+
+func (uc *CreateSCIMTenantUseCase) checkSubPrefix(ctx context.Context, p string) error {
+	overlaps := func(q string) bool { return q != "" && scimtenant.SubPrefixesOverlap(p, q) }
+	if slices.ContainsFunc(builtinSubPrefixes, overlaps) || slices.ContainsFunc(uc.reserved, overlaps) {
+		return scimtenant.ErrReservedSubPrefix
+	}
+	tenants, err := uc.scimTenantRepo.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	for _, t := range tenants {
+		if overlaps(t.SubPrefix()) {
+			return ErrDuplicateSubPrefix
+		}
+	}
+	return nil
+}
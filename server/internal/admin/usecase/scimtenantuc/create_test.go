@@ -0,0 +1,77 @@
+package scimtenantuc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateRotateDelete(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewSCIMTenant()
+	op := adminuser.NewID()
+
+	tn, token, err := NewCreateSCIMTenantUseCase(repo, nil).Execute(ctx, CreateInput{Operator: op, Name: "okta", SubPrefix: "samlp|okta"})
+	require.NoError(t, err)
+	assert.Equal(t, "samlp|okta", tn.SubPrefix())
+	got, err := repo.FindByTokenHash(ctx, scimtenant.HashToken(token))
+	require.NoError(t, err)
+	assert.Equal(t, tn.ID(), got.ID())
+
+	_, next, err := NewRotateSCIMTenantTokenUseCase(repo).Execute(ctx, RotateInput{Operator: op, ID: tn.ID()})
+	require.NoError(t, err)
+	_, err = repo.FindByTokenHash(ctx, scimtenant.HashToken(token))
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	_, err = repo.FindByTokenHash(ctx, scimtenant.HashToken(next))
+	assert.NoError(t, err)
+
+	listed, err := NewListSCIMTenantsUseCase(repo).Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, scimtenant.IDList{tn.ID()}, listed.IDs())
+
+	require.NoError(t, NewDeleteSCIMTenantUseCase(repo).Execute(ctx, DeleteInput{Operator: op, ID: tn.ID()}))
+	_, err = repo.FindByID(ctx, tn.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	err = NewDeleteSCIMTenantUseCase(repo).Execute(ctx, DeleteInput{Operator: op, ID: tn.ID()})
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func TestCreate_Invalid(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewSCIMTenant()
+
+	_, _, err := NewCreateSCIMTenantUseCase(repo, nil).Execute(ctx, CreateInput{Name: " "})
+	assert.ErrorIs(t, err, scimtenant.ErrEmptyName)
+	_, _, err = NewCreateSCIMTenantUseCase(repo, nil).Execute(ctx, CreateInput{Name: "okta", SubPrefix: "samlp|"})
+	assert.ErrorIs(t, err, scimtenant.ErrInvalidSubPrefix)
+}
+
+func TestCreate_SubPrefix(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewSCIMTenant()
+	uc := NewCreateSCIMTenantUseCase(repo, ReservedSubPrefixes{"ldap", "keycloak"})
+
+	for _, p := range []string{"reearth", "auth0|okta", "cip", "scim|01h", "ldap", "keycloak|realm"} {
+		_, _, err := uc.Execute(ctx, CreateInput{Name: "okta", SubPrefix: p})
+		assert.ErrorIs(t, err, scimtenant.ErrReservedSubPrefix, p)
+	}
+
+	_, _, err := uc.Execute(ctx, CreateInput{Name: "okta", SubPrefix: "samlp|okta"})
+	require.NoError(t, err)
+	for _, p := range []string{"samlp|okta", "samlp|okta|eu", "samlp"} {
+		_, _, err = uc.Execute(ctx, CreateInput{Name: "okta", SubPrefix: p})
+		assert.ErrorIs(t, err, ErrDuplicateSubPrefix, p)
+	}
+	_, _, err = uc.Execute(ctx, CreateInput{Name: "entra", SubPrefix: "samlp|entra"})
+	assert.NoError(t, err)
+
+	// the default prefix is unique to the tenant
+	_, _, err = uc.Execute(ctx, CreateInput{Name: "default"})
+	assert.NoError(t, err)
+}
@@ -0,0 +1,40 @@
+package scimtenantuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearthx/log"
+)
+
+// DeleteSCIMTenantUseCase removes a SCIM tenant.
+type DeleteSCIMTenantUseCase struct {
+	scimTenantRepo scimtenant.Repo
+}
+
+// NewDeleteSCIMTenantUseCase is a Wire provider for DeleteSCIMTenantUseCase.
+func NewDeleteSCIMTenantUseCase(scimTenantRepo scimtenant.Repo) *DeleteSCIMTenantUseCase {
+	return &DeleteSCIMTenantUseCase{scimTenantRepo: scimTenantRepo}
+}
+
+// DeleteInput is the input for DeleteSCIMTenantUseCase.Execute.
+type DeleteInput struct {
+	Operator adminuser.ID
+	ID       scimtenant.ID
+}
+
+// Execute revokes the tenant's access. The users and workspaces it provisioned
+// are kept and can still be managed like any other.
+func (uc *DeleteSCIMTenantUseCase) Execute(ctx context.Context, in DeleteInput) error {
+	t, err := uc.scimTenantRepo.FindByID(ctx, in.ID)
+	if err != nil {
+		return err
+	}
+	if err := uc.scimTenantRepo.Remove(ctx, t.ID()); err != nil {
+		return err
+	}
+
+	log.Infofc(ctx, "[admin] scim tenant %s (%s) deleted by %s", t.ID(), t.Name(), in.Operator)
+	return nil
+}
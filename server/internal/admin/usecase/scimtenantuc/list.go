@@ -0,0 +1,23 @@
+// Package scimtenantuc holds the usecases managing the identity provider
+// tenants allowed to provision users and workspaces through the SCIM API.
+package scimtenantuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+)
+
+// ListSCIMTenantsUseCase lists the SCIM tenants.
+type ListSCIMTenantsUseCase struct {
+	scimTenantRepo scimtenant.Repo
+}
+
+// NewListSCIMTenantsUseCase is a Wire provider for ListSCIMTenantsUseCase.
+func NewListSCIMTenantsUseCase(scimTenantRepo scimtenant.Repo) *ListSCIMTenantsUseCase {
+	return &ListSCIMTenantsUseCase{scimTenantRepo: scimTenantRepo}
+}
+
+func (uc *ListSCIMTenantsUseCase) Execute(ctx context.Context) (scimtenant.List, error) {
+	return uc.scimTenantRepo.FindAll(ctx)
+}
@@ -0,0 +1,45 @@
+package scimtenantuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearthx/log"
+)
+
+// RotateSCIMTenantTokenUseCase replaces the bearer token of a SCIM tenant.
+type RotateSCIMTenantTokenUseCase struct {
+	scimTenantRepo scimtenant.Repo
+}
+
+// NewRotateSCIMTenantTokenUseCase is a Wire provider for
+// RotateSCIMTenantTokenUseCase.
+func NewRotateSCIMTenantTokenUseCase(scimTenantRepo scimtenant.Repo) *RotateSCIMTenantTokenUseCase {
+	return &RotateSCIMTenantTokenUseCase{scimTenantRepo: scimTenantRepo}
+}
+
+// RotateInput is the input for RotateSCIMTenantTokenUseCase.Execute.
+type RotateInput struct {
+	Operator adminuser.ID
+	ID       scimtenant.ID
+}
+
+// Execute returns the tenant with its new token. The previous token stops
+// working immediately.
+func (uc *RotateSCIMTenantTokenUseCase) Execute(ctx context.Context, in RotateInput) (*scimtenant.Tenant, string, error) {
+	t, err := uc.scimTenantRepo.FindByID(ctx, in.ID)
+	if err != nil {
+		return nil, "", err
+	}
+	token, err := t.RotateToken()
+	if err != nil {
+		return nil, "", err
+	}
+	if err := uc.scimTenantRepo.Save(ctx, t); err != nil {
+		return nil, "", err
+	}
+
+	log.Infofc(ctx, "[admin] scim tenant %s token rotated by %s", t.ID(), in.Operator)
+	return t, token, nil
+}
@@ -0,0 +1,70 @@
+// Package sessionuc holds the usecases managing the server-side admin
+// sessions behind the admin_session cookie: starting one at sign-in, listing
+// an admin's own sessions, and revoking them.
+package sessionuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/util"
+)
+
+// maxUserAgentLength bounds the stored User-Agent; it is only shown to the
+// admin to tell their sessions apart.
+const maxUserAgentLength = 512
+
+// CreateSessionUseCase starts a server-side session and issues the session
+// token naming it.
+type CreateSessionUseCase struct {
+	repo adminsession.Repo
+	sess *session.Manager
+}
+
+// NewCreateSessionUseCase is a Wire provider for CreateSessionUseCase.
+func NewCreateSessionUseCase(repo adminsession.Repo, sess *session.Manager) *CreateSessionUseCase {
+	return &CreateSessionUseCase{repo: repo, sess: sess}
+}
+
+// CreateInput is the input for CreateSessionUseCase.Execute.
+type CreateInput struct {
+	AdminUser adminuser.ID
+	UserAgent string
+	IP        string
+}
+
+// Execute saves a session lasting the token TTL and returns it together with
+// the signed token. Expired sessions are swept on the way, best effort.
+func (uc *CreateSessionUseCase) Execute(ctx context.Context, in CreateInput) (*adminsession.Session, string, error) {
+	now := util.Now()
+	if err := uc.repo.RemoveExpired(ctx, now); err != nil {
+		log.Warnfc(ctx, "[admin] could not remove expired sessions: %v", err)
+	}
+
+	ua := in.UserAgent
+	if len(ua) > maxUserAgentLength {
+		ua = ua[:maxUserAgentLength]
+	}
+	s, err := adminsession.New().NewID().
+		AdminUser(in.AdminUser).
+		UserAgent(ua).
+		IP(in.IP).
+		CreatedAt(now).
+		ExpiresAt(now.Add(uc.sess.TTL())).
+		Build()
+	if err != nil {
+		return nil, "", err
+	}
+
+	token, err := uc.sess.Issue(in.AdminUser, s.ID(), now)
+	if err != nil {
+		return nil, "", err
+	}
+	if err := uc.repo.Save(ctx, s); err != nil {
+		return nil, "", err
+	}
+	return s, token, nil
+}
@@ -0,0 +1,59 @@
+package sessionuc
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/admin/auth/session"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-test-secret-test-secret"
+
+func TestCreate_SavesSessionAndIssuesToken(t *testing.T) {
+	ctx := context.Background()
+	admin := adminuser.NewID()
+	stale := adminsession.New().NewID().AdminUser(admin).
+		CreatedAt(time.Now().Add(-2 * time.Hour)).ExpiresAt(time.Now().Add(-time.Hour)).MustBuild()
+	repo := memory.NewAdminSessionWith(stale)
+	sess := session.NewManager(testSecret, time.Hour)
+	uc := NewCreateSessionUseCase(repo, sess)
+
+	s, token, err := uc.Execute(ctx, CreateInput{AdminUser: admin, UserAgent: strings.Repeat("a", 600), IP: "203.0.113.7"})
+	require.NoError(t, err)
+	assert.Equal(t, admin, s.AdminUser())
+	assert.Len(t, s.UserAgent(), maxUserAgentLength)
+	assert.Equal(t, "203.0.113.7", s.IP())
+	assert.Equal(t, time.Hour, s.ExpiresAt().Sub(s.CreatedAt()))
+
+	claims, err := sess.Parse(token)
+	require.NoError(t, err)
+	assert.Equal(t, session.Claims{AdminUser: admin, Session: s.ID()}, claims)
+
+	saved, err := repo.FindByID(ctx, s.ID())
+	require.NoError(t, err)
+	assert.Equal(t, s.ID(), saved.ID())
+
+	// Expired sessions are swept when a new one starts.
+	_, err = repo.FindByID(ctx, stale.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func TestCreate_EmptySecret(t *testing.T) {
+	repo := memory.NewAdminSession()
+	uc := NewCreateSessionUseCase(repo, session.NewManager("", time.Hour))
+
+	_, _, err := uc.Execute(context.Background(), CreateInput{AdminUser: adminuser.NewID()})
+	assert.ErrorIs(t, err, session.ErrEmptySecret)
+
+	l, err := repo.FindByAdminUser(context.Background(), adminuser.NewID())
+	require.NoError(t, err)
+	assert.Empty(t, l)
+}
@@ -0,0 +1,28 @@
+package sessionuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/util"
+)
+
+// ListSessionsUseCase lists an admin user's own sessions.
+type ListSessionsUseCase struct {
+	repo adminsession.Repo
+}
+
+// NewListSessionsUseCase is a Wire provider for ListSessionsUseCase.
+func NewListSessionsUseCase(repo adminsession.Repo) *ListSessionsUseCase {
+	return &ListSessionsUseCase{repo: repo}
+}
+
+// Execute returns the admin user's unexpired sessions, newest first.
+func (uc *ListSessionsUseCase) Execute(ctx context.Context, admin adminuser.ID) (adminsession.List, error) {
+	l, err := uc.repo.FindByAdminUser(ctx, admin)
+	if err != nil {
+		return nil, err
+	}
+	return l.Active(util.Now()), nil
+}
@@ -0,0 +1,27 @@
+package sessionuc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestList_ActiveNewestFirst(t *testing.T) {
+	ctx := context.Background()
+	admin := adminuser.NewID()
+	now := time.Now()
+	older := newSession(admin, now.Add(-30*time.Minute))
+	newer := newSession(admin, now)
+	expired := newSession(admin, now.Add(-2*time.Hour))
+	repo := memory.NewAdminSessionWith(older, newer, expired, newSession(adminuser.NewID(), now))
+
+	l, err := NewListSessionsUseCase(repo).Execute(ctx, admin)
+	require.NoError(t, err)
+	assert.Equal(t, adminsession.IDList{newer.ID(), older.ID()}, l.IDs())
+}
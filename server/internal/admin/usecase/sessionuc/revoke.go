@@ -0,0 +1,38 @@
+package sessionuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// RevokeSessionUseCase ends one of an admin user's own sessions.
+type RevokeSessionUseCase struct {
+	repo adminsession.Repo
+}
+
+// NewRevokeSessionUseCase is a Wire provider for RevokeSessionUseCase.
+func NewRevokeSessionUseCase(repo adminsession.Repo) *RevokeSessionUseCase {
+	return &RevokeSessionUseCase{repo: repo}
+}
+
+// RevokeInput is the input for RevokeSessionUseCase.Execute.
+type RevokeInput struct {
+	Operator adminuser.ID
+	Session  adminsession.ID
+}
+
+// Execute removes the session. A session of another admin user is reported
+// as not found so session IDs can't be probed.
+func (uc *RevokeSessionUseCase) Execute(ctx context.Context, in RevokeInput) error {
+	s, err := uc.repo.FindByID(ctx, in.Session)
+	if err != nil {
+		return err
+	}
+	if s.AdminUser() != in.Operator {
+		return rerror.ErrNotFound
+	}
+	return uc.repo.Remove(ctx, in.Session)
+}
@@ -0,0 +1,42 @@
+package sessionuc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSession(admin adminuser.ID, created time.Time) *adminsession.Session {
+	return adminsession.New().NewID().AdminUser(admin).CreatedAt(created).ExpiresAt(created.Add(time.Hour)).MustBuild()
+}
+
+func TestRevoke_OwnSession(t *testing.T) {
+	ctx := context.Background()
+	admin := adminuser.NewID()
+	s := newSession(admin, time.Now())
+	repo := memory.NewAdminSessionWith(s)
+
+	require.NoError(t, NewRevokeSessionUseCase(repo).Execute(ctx, RevokeInput{Operator: admin, Session: s.ID()}))
+
+	_, err := repo.FindByID(ctx, s.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func TestRevoke_OtherAdminsSession(t *testing.T) {
+	ctx := context.Background()
+	s := newSession(adminuser.NewID(), time.Now())
+	repo := memory.NewAdminSessionWith(s)
+
+	err := NewRevokeSessionUseCase(repo).Execute(ctx, RevokeInput{Operator: adminuser.NewID(), Session: s.ID()})
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	_, err = repo.FindByID(ctx, s.ID())
+	assert.NoError(t, err)
+}
@@ -0,0 +1,29 @@
+// Package signingkeyuc holds the usecases managing the versions of the token
+// signing key stored in the config repo.
+package signingkeyuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/config"
+)
+
+// ListSigningKeysUseCase lists the stored signing key versions.
+type ListSigningKeysUseCase struct {
+	configRepo config.Repo
+}
+
+// NewListSigningKeysUseCase is a Wire provider for ListSigningKeysUseCase.
+func NewListSigningKeysUseCase(configRepo config.Repo) *ListSigningKeysUseCase {
+	return &ListSigningKeysUseCase{configRepo: configRepo}
+}
+
+// Execute returns every stored key version, retired ones included until the
+// next rotation drops them.
+func (uc *ListSigningKeysUseCase) Execute(ctx context.Context) ([]config.Auth, error) {
+	cfg, err := uc.configRepo.Load(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return cfg.AuthKeys(), nil
+}
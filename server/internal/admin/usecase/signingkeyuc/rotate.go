@@ -0,0 +1,70 @@
+package signingkeyuc
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/config"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/util"
+)
+
+// RotateSigningKeyUseCase generates a new signing key version and schedules
+// the switch to it.
+type RotateSigningKeyUseCase struct {
+	configRepo config.Repo
+}
+
+// NewRotateSigningKeyUseCase is a Wire provider for RotateSigningKeyUseCase.
+func NewRotateSigningKeyUseCase(configRepo config.Repo) *RotateSigningKeyUseCase {
+	return &RotateSigningKeyUseCase{configRepo: configRepo}
+}
+
+// RotateInput is the input for RotateSigningKeyUseCase.Execute.
+type RotateInput struct {
+	Operator adminuser.ID
+	// Overlap is how long the new key is published before it signs, and how
+	// long the current keys stay published after that. It must exceed both the
+	// key cache lifetime of verifiers and the lifetime of issued tokens.
+	Overlap time.Duration
+}
+
+// Execute adds the new key version under the config lock and returns all
+// stored versions.
+func (uc *RotateSigningKeyUseCase) Execute(ctx context.Context, in RotateInput) (_ []config.Auth, err error) {
+	if in.Overlap < 0 {
+		return nil, config.ErrInvalidOverlap
+	}
+
+	cfg, err := uc.configRepo.LockAndLoad(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err == nil {
+			return
+		}
+		if uerr := uc.configRepo.Unlock(ctx); uerr != nil {
+			log.Errorfc(ctx, "[admin] could not release config lock: %v", uerr)
+		}
+	}()
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+
+	now := util.Now()
+	next, err := config.GenerateAuth(now)
+	if err != nil {
+		return nil, err
+	}
+	if err := cfg.RotateAuthKey(*next, now, in.Overlap); err != nil {
+		return nil, err
+	}
+	if err := uc.configRepo.SaveAndUnlock(ctx, cfg); err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] signing key rotated by %s: kid=%s activates_at=%s", in.Operator, next.KID, now.Add(in.Overlap).Format(time.RFC3339))
+	return cfg.AuthKeys(), nil
+}
@@ -0,0 +1,61 @@
+package signingkeyuc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/config"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRotate_OK(t *testing.T) {
+	ctx := context.Background()
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+	repo := memory.NewConfig()
+	current, err := config.GenerateAuth(now.Add(-time.Hour))
+	require.NoError(t, err)
+	require.NoError(t, repo.SaveAuth(ctx, current))
+	uc := NewRotateSigningKeyUseCase(repo)
+
+	got, err := uc.Execute(ctx, RotateInput{Operator: adminuser.NewID(), Overlap: time.Hour})
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+	assert.Equal(t, current.KID, got[0].KID)
+	require.NotNil(t, got[0].RetiredAt)
+	assert.Equal(t, now.Add(2*time.Hour), *got[0].RetiredAt)
+	assert.NotEqual(t, current.KID, got[1].KID)
+	assert.Equal(t, now.Add(time.Hour), got[1].ActivatedAt)
+
+	listed, err := NewListSigningKeysUseCase(repo).Execute(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, got, listed)
+
+	// the lock was released, so a second rotation is evaluated and refused
+	_, err = uc.Execute(ctx, RotateInput{Overlap: time.Hour})
+	assert.ErrorIs(t, err, config.ErrRotationInProgress)
+	_, err = uc.Execute(ctx, RotateInput{Overlap: time.Hour})
+	assert.ErrorIs(t, err, config.ErrRotationInProgress)
+}
+
+func TestRotate_NoKeyYet(t *testing.T) {
+	ctx := context.Background()
+	repo := memory.NewConfig()
+	uc := NewRotateSigningKeyUseCase(repo)
+
+	got, err := uc.Execute(ctx, RotateInput{})
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Nil(t, got[0].RetiredAt)
+}
+
+func TestRotate_NegativeOverlap(t *testing.T) {
+	uc := NewRotateSigningKeyUseCase(memory.NewConfig())
+	_, err := uc.Execute(context.Background(), RotateInput{Overlap: -time.Second})
+	assert.ErrorIs(t, err, config.ErrInvalidOverlap)
+}
@@ -0,0 +1,38 @@
+// Package statsuc holds the usecase computing the admin dashboard overview.
+package statsuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminstats"
+	"github.com/reearth/reearthx/util"
+)
+
+// defaultDays is the window of the daily figures when none is asked for.
+const defaultDays = 30
+
+// GetStatsUseCase computes the dashboard figures.
+type GetStatsUseCase struct {
+	statsRepo adminstats.Repo
+}
+
+// NewGetStatsUseCase is a Wire provider for GetStatsUseCase.
+func NewGetStatsUseCase(statsRepo adminstats.Repo) *GetStatsUseCase {
+	return &GetStatsUseCase{statsRepo: statsRepo}
+}
+
+// Execute returns the current counts with the daily figures of the given
+// number of days up to today, one entry per day oldest first. Zero days
+// returns the default window; more than adminstats.MaxDays are capped.
+func (uc *GetStatsUseCase) Execute(ctx context.Context, days int) (*adminstats.Stats, error) {
+	if days < 1 {
+		days = defaultDays
+	}
+	w := adminstats.NewWindow(days, util.Now())
+	s, err := uc.statsRepo.Compute(ctx, w)
+	if err != nil {
+		return nil, err
+	}
+	s.Days = w.Fill(s.Days)
+	return s, nil
+}
@@ -0,0 +1,39 @@
+package statsuc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminstats"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetStats(t *testing.T) {
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+	yesterday := now.AddDate(0, 0, -1)
+
+	users := memory.NewUserWith(
+		user.New().NewID().Name("a").Email("a@example.com").Workspace(id.NewWorkspaceID()).CreatedAt(&yesterday).MustBuild(),
+		user.New().NewID().Name("b").Email("b@example.com").Workspace(id.NewWorkspaceID()).CreatedAt(&now).DeletedAt(&now).MustBuild(),
+	)
+	uc := NewGetStatsUseCase(memory.NewAdminStats(users, memory.NewWorkspace()))
+
+	s, err := uc.Execute(context.Background(), 0)
+	require.NoError(t, err)
+	assert.Equal(t, adminstats.Users{Active: 1, Deactivated: 1, Unverified: 1}, s.Users)
+	require.Len(t, s.Days, defaultDays)
+	assert.Equal(t, time.Date(2026, 9, 20, 0, 0, 0, 0, time.UTC), s.Days[0].Date)
+	assert.Equal(t, adminstats.Day{Date: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Signups: 1}, s.Days[28])
+	assert.Equal(t, adminstats.Day{Date: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC), Signups: 1, Deactivations: 1}, s.Days[29])
+
+	s, err = uc.Execute(context.Background(), 1)
+	require.NoError(t, err)
+	assert.Len(t, s.Days, 1)
+}
@@ -0,0 +1,75 @@
+package useruc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// DeactivateUserUseCase soft-deletes a user, who can no longer sign in. The
+// user is purged once the retention window of the main service has passed
+// unless they are restored first.
+type DeactivateUserUseCase struct {
+	action userAction
+}
+
+// NewDeactivateUserUseCase is a Wire provider for DeactivateUserUseCase.
+func NewDeactivateUserUseCase(userRepo user.Repo, auditLogRepo auditlog.Repo, transaction usecasex.Transaction) *DeactivateUserUseCase {
+	return &DeactivateUserUseCase{action: userAction{userRepo: userRepo, auditLogRepo: auditLogRepo, transaction: transaction}}
+}
+
+// Execute deactivates the user and records it in the audit log.
+func (uc *DeactivateUserUseCase) Execute(ctx context.Context, in UserActionInput) (*UserActionOutput, error) {
+	out, err := uc.action.run(ctx, in, auditlog.ActionUserDeactivate, func(_ context.Context, u *user.User) (map[string]string, error) {
+		if u.IsDeleted() {
+			return nil, ErrUserDeactivated
+		}
+		u.Deactivate()
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] user %s deactivated by %s", in.User, in.Operator)
+	return out, nil
+}
+
+// RestoreUserUseCase reactivates a deactivated user, including one who asked
+// for the deletion of their account.
+type RestoreUserUseCase struct {
+	action userAction
+}
+
+// NewRestoreUserUseCase is a Wire provider for RestoreUserUseCase.
+func NewRestoreUserUseCase(userRepo user.Repo, auditLogRepo auditlog.Repo, transaction usecasex.Transaction) *RestoreUserUseCase {
+	return &RestoreUserUseCase{action: userAction{userRepo: userRepo, auditLogRepo: auditLogRepo, transaction: transaction}}
+}
+
+// Execute reactivates the user and records it in the audit log. Users merged
+// into another stay deleted.
+func (uc *RestoreUserUseCase) Execute(ctx context.Context, in UserActionInput) (*UserActionOutput, error) {
+	out, err := uc.action.run(ctx, in, auditlog.ActionUserRestore, func(_ context.Context, u *user.User) (map[string]string, error) {
+		if !u.IsDeleted() {
+			return nil, ErrUserNotDeactivated
+		}
+		if u.MergedInto() != nil {
+			return nil, ErrRestoreMergedUser
+		}
+		var detail map[string]string
+		if u.DeletionRequestedAt() != nil {
+			detail = map[string]string{"deletionRequested": "true"}
+		}
+		u.Reactivate()
+		return detail, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] user %s restored by %s", in.User, in.Operator)
+	return out, nil
+}
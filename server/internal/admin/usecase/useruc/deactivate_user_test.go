@@ -0,0 +1,73 @@
+package useruc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeactivateAndRestoreUser(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	op := adminuser.NewID()
+	u := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	in := UserActionInput{Operator: op, User: u.ID()}
+
+	_, err := NewRestoreUserUseCase(r.User, r.AuditLog, r.Transaction).Execute(ctx, in)
+	assert.ErrorIs(t, err, ErrUserNotDeactivated)
+
+	out, err := NewDeactivateUserUseCase(r.User, r.AuditLog, r.Transaction).Execute(ctx, in)
+	require.NoError(t, err)
+	assert.True(t, out.User.IsDeleted())
+	assert.Equal(t, auditlog.ActionUserDeactivate, out.AuditLog.Action())
+	assert.Equal(t, op, out.AuditLog.Actor())
+
+	_, err = NewDeactivateUserUseCase(r.User, r.AuditLog, r.Transaction).Execute(ctx, in)
+	assert.ErrorIs(t, err, ErrUserDeactivated)
+
+	out, err = NewRestoreUserUseCase(r.User, r.AuditLog, r.Transaction).Execute(ctx, in)
+	require.NoError(t, err)
+	assert.False(t, out.User.IsDeleted())
+
+	got, err := r.User.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	assert.False(t, got.IsDeleted())
+	entries, err := r.AuditLog.FindByTarget(ctx, u.ID().String())
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
+
+func TestRestoreUser_DeletionRequested(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	u.RequestDeletion()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	out, err := NewRestoreUserUseCase(r.User, r.AuditLog, r.Transaction).Execute(ctx, UserActionInput{Operator: adminuser.NewID(), User: u.ID()})
+	require.NoError(t, err)
+	assert.Nil(t, out.User.DeletionRequestedAt())
+	assert.Equal(t, map[string]string{"deletionRequested": "true"}, out.AuditLog.Detail())
+}
+
+func TestRestoreUser_Merged(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	u.MarkMergedInto(user.NewID())
+	require.NoError(t, r.User.Save(ctx, u))
+
+	_, err := NewRestoreUserUseCase(r.User, r.AuditLog, r.Transaction).Execute(ctx, UserActionInput{Operator: adminuser.NewID(), User: u.ID()})
+	assert.ErrorIs(t, err, ErrRestoreMergedUser)
+
+	entries, err := r.AuditLog.FindByTarget(ctx, u.ID().String())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -0,0 +1,61 @@
+package useruc
+
+import (
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var (
+	// ErrMergeSameUser is returned when the source and target of a merge are
+	// the same user.
+	ErrMergeSameUser = rerror.NewE(i18n.T("cannot merge a user into itself"))
+	// ErrMergeDeletedUser is returned when the source or target of a merge is
+	// deleted.
+	ErrMergeDeletedUser = rerror.NewE(i18n.T("cannot merge a deleted user"))
+	// ErrMergeAuthConflict is returned when both users hold an auth of the same
+	// provider. A user holds at most one auth per provider, so one of them has
+	// to be removed first.
+	ErrMergeAuthConflict = rerror.NewE(i18n.T("both users have an auth of the same provider"))
+	// ErrUnsupportedFormat is returned for an import or export format other
+	// than csv and json.
+	ErrUnsupportedFormat = rerror.NewE(i18n.T("unsupported format"))
+	// ErrInvalidImportFile is returned when an import file can't be parsed.
+	ErrInvalidImportFile = rerror.NewE(i18n.T("invalid import file"))
+	// ErrTooManyImportRows is returned when an import file has more than
+	// MaxImportRows users.
+	ErrTooManyImportRows = rerror.NewE(i18n.T("too many users in the import file"))
+	// ErrUserDeactivated is returned when deactivating a user who is already
+	// deactivated, or when acting on a deactivated user in a way that only
+	// makes sense for active users.
+	ErrUserDeactivated = rerror.NewE(i18n.T("user is deactivated"))
+	// ErrUserNotDeactivated is returned when restoring an active user.
+	ErrUserNotDeactivated = rerror.NewE(i18n.T("user is not deactivated"))
+	// ErrRestoreMergedUser is returned when restoring a user who was merged
+	// into another. Its auths live on in the other user.
+	ErrRestoreMergedUser = rerror.NewE(i18n.T("cannot restore a merged user"))
+	// ErrNothingToUpdate is returned when an update changes no field.
+	ErrNothingToUpdate = rerror.NewE(i18n.T("nothing to update"))
+	// ErrEmptyName is returned when a user is renamed to an empty name.
+	ErrEmptyName = rerror.NewE(i18n.T("name can't be empty"))
+	// ErrAliasTaken is returned when another user already has the alias.
+	ErrAliasTaken = rerror.NewE(i18n.T("alias is already taken"))
+	// ErrEmailTaken is returned when another user already has the email.
+	ErrEmailTaken = rerror.NewE(i18n.T("email is already taken"))
+	// ErrEmailAlreadyVerified is returned when force-verifying a verified
+	// email.
+	ErrEmailAlreadyVerified = rerror.NewE(i18n.T("email is already verified"))
+	// ErrNoPasswordAuth is returned when resetting the password of a user who
+	// doesn't sign in with a password.
+	ErrNoPasswordAuth = rerror.NewE(i18n.T("user has no password"))
+	// ErrAuthNotFound is returned when removing an auth the user doesn't have.
+	ErrAuthNotFound = rerror.NewE(i18n.T("auth not found"))
+	// ErrAuthNotRemovable is returned for Auth0 auths, which can't be removed
+	// from the accounts side.
+	ErrAuthNotRemovable = rerror.NewE(i18n.T("auth can't be removed"))
+	// ErrMFANotEnabled is returned when resetting the MFA of a user who has no
+	// second factor.
+	ErrMFANotEnabled = rerror.NewE(i18n.T("user has no second factor"))
+	// ErrImpersonationDisabled is returned when impersonating a user while no
+	// impersonation secret is configured.
+	ErrImpersonationDisabled = rerror.NewE(i18n.T("impersonation is not configured"))
+)
@@ -0,0 +1,110 @@
+package useruc
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/dataexport"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/reearth/reearthx/util"
+)
+
+// ExportUserDataUseCase answers a data subject access request on behalf of a
+// user: it archives the personal data of the user and mails them a link to
+// download it, like the requestMyDataExport mutation does.
+type ExportUserDataUseCase struct {
+	repos        dataexport.Repos
+	auditLogRepo auditlog.Repo
+	storage      gateway.Storage
+	mailer       mailer.Mailer
+}
+
+// NewExportUserDataUseCase is a Wire provider for ExportUserDataUseCase.
+func NewExportUserDataUseCase(
+	userRepo user.Repo,
+	workspaceRepo workspace.Repo,
+	roleRepo role.Repo,
+	permittableRepo permittable.Repo,
+	auditLogRepo auditlog.Repo,
+	storage gateway.Storage,
+	m mailer.Mailer,
+) *ExportUserDataUseCase {
+	return &ExportUserDataUseCase{
+		repos: dataexport.Repos{
+			User:        userRepo,
+			Workspace:   workspaceRepo,
+			Role:        roleRepo,
+			Permittable: permittableRepo,
+			AuditLog:    auditLogRepo,
+		},
+		auditLogRepo: auditLogRepo,
+		storage:      storage,
+		mailer:       m,
+	}
+}
+
+// ExportUserDataInput is the input for ExportUserDataUseCase.Execute.
+type ExportUserDataInput struct {
+	Operator adminuser.ID
+	User     user.ID
+}
+
+// ExportUserDataOutput is where the archive was stored.
+type ExportUserDataOutput struct {
+	ObjectName string
+	// DownloadURL is the signed URL mailed to the user. It expires.
+	DownloadURL string
+	AuditLog    *auditlog.Entry
+}
+
+// Execute uploads the archive of the user, mails the download link to the
+// email of the user and records the export in the audit log.
+func (uc *ExportUserDataUseCase) Execute(ctx context.Context, in ExportUserDataInput) (*ExportUserDataOutput, error) {
+	a, err := dataexport.Collect(ctx, uc.repos, in.User, util.Now())
+	if err != nil {
+		return nil, err
+	}
+	link, err := a.Upload(ctx, uc.storage)
+	if err != nil {
+		return nil, err
+	}
+
+	text := fmt.Sprintf("Hi %s,\n\nAs requested, we've prepared a copy of the personal data Re:Earth holds about you. Download it as a ZIP archive from the link below within 24 hours:\n\n%s\n", a.User.Name, link)
+	htmlContent := fmt.Sprintf(`<p>Hi %s,</p><p>As requested, we've prepared a copy of the personal data Re:Earth holds about you. Download it as a ZIP archive from the link below within 24 hours:</p><p><a href="%s">Download your data</a></p>`,
+		html.EscapeString(a.User.Name), html.EscapeString(link))
+	if err := uc.mailer.SendMail(ctx, []mailer.Contact{{Email: a.User.Email, Name: a.User.Name}},
+		"Your Re:Earth data export", text, htmlContent); err != nil {
+		return nil, err
+	}
+
+	entry, err := auditlog.New().
+		NewID().
+		Actor(in.Operator).
+		Action(auditlog.ActionUserDataExport).
+		Target(in.User.String()).
+		Detail(map[string]string{"object": a.ObjectName()}).
+		CreatedAt(util.Now()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.auditLogRepo.Save(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] data export of user %s mailed by %s: object=%s", in.User, in.Operator, a.ObjectName())
+	return &ExportUserDataOutput{
+		ObjectName:  a.ObjectName(),
+		DownloadURL: link,
+		AuditLog:    entry,
+	}, nil
+}
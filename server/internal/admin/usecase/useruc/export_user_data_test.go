@@ -0,0 +1,88 @@
+package useruc
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway/mock"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportUserData(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+	ctx := context.Background()
+	r := memory.New()
+	op := adminuser.NewID()
+
+	u := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	ctrl := gomock.NewController(t)
+	storage := mock.NewMockStorage(ctrl)
+	name := "users/" + u.ID().String() + "/data-exports/20261018T120000Z.zip"
+	storage.EXPECT().Upload(gomock.Any(), name, gomock.Any()).Return(nil)
+	storage.EXPECT().GetSignedURL(gomock.Any(), name).Return("https://storage.example.com/export.zip?sig=1", nil)
+	m := mailer.NewMock()
+	uc := NewExportUserDataUseCase(r.User, r.Workspace, r.Role, r.Permittable, r.AuditLog, storage, m)
+
+	out, err := uc.Execute(ctx, ExportUserDataInput{Operator: op, User: u.ID()})
+	require.NoError(t, err)
+	assert.Equal(t, name, out.ObjectName)
+	assert.Equal(t, "https://storage.example.com/export.zip?sig=1", out.DownloadURL)
+
+	mails := m.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, []mailer.Contact{{Email: "alice@example.com", Name: "alice"}}, mails[0].To)
+	assert.Contains(t, mails[0].PlainContent, out.DownloadURL)
+
+	entries, err := r.AuditLog.FindByTarget(ctx, u.ID().String())
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, auditlog.ActionUserDataExport, entries[0].Action())
+	assert.Equal(t, op, entries[0].Actor())
+	assert.Equal(t, map[string]string{"object": name}, entries[0].Detail())
+}
+
+func TestExportUserData_Errors(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	t.Run("user not found", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		m := mailer.NewMock()
+		uc := NewExportUserDataUseCase(r.User, r.Workspace, r.Role, r.Permittable, r.AuditLog, mock.NewMockStorage(ctrl), m)
+
+		_, err := uc.Execute(ctx, ExportUserDataInput{Operator: adminuser.NewID(), User: user.NewID()})
+		assert.ErrorIs(t, err, rerror.ErrNotFound)
+		assert.Empty(t, m.Mails())
+	})
+
+	t.Run("upload fails", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		storage := mock.NewMockStorage(ctrl)
+		storage.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).Return(errors.New("boom"))
+		m := mailer.NewMock()
+		uc := NewExportUserDataUseCase(r.User, r.Workspace, r.Role, r.Permittable, r.AuditLog, storage, m)
+
+		_, err := uc.Execute(ctx, ExportUserDataInput{Operator: adminuser.NewID(), User: u.ID()})
+		assert.EqualError(t, err, "boom")
+		assert.Empty(t, m.Mails())
+		entries, err := r.AuditLog.FindByTarget(ctx, u.ID().String())
+		require.NoError(t, err)
+		assert.Empty(t, entries)
+	})
+}
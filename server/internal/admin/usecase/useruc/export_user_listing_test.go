@@ -0,0 +1,92 @@
+package useruc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportUserListing(t *testing.T) {
+	ctx := context.Background()
+
+	// More users than a page, so the export has to walk several pages.
+	users := make([]*user.User, 0, 150)
+	for i := range 150 {
+		users = append(users, user.New().NewID().
+			Name(fmt.Sprintf("User %d", i)).
+			Alias(fmt.Sprintf("user%d", i)).
+			Email(fmt.Sprintf("user%d@example.com", i)).
+			MustBuild())
+	}
+	users[1].Deactivate()
+	userRepo := memory.NewUserWith(users...)
+	wsRepo := memory.NewWorkspaceWith(
+		workspace.New().NewID().Name("GIS").Alias("gis").Members(map[user.ID]workspace.Member{
+			users[0].ID(): {Role: role.RoleOwner},
+		}).MustBuild(),
+		workspace.New().NewID().Name("Personal").Alias("user0").Personal(true).Members(map[user.ID]workspace.Member{
+			users[0].ID(): {Role: role.RoleOwner},
+		}).MustBuild(),
+	)
+	auditLogRepo := memory.NewAuditLog()
+	uc := NewExportUserListingUseCase(userRepo, wsRepo, auditLogRepo)
+
+	var rows []ListingRow
+	require.NoError(t, uc.Execute(ctx, ExportUserListingInput{Operator: adminuser.NewID()}, func(r ListingRow) error {
+		rows = append(rows, r)
+		return nil
+	}))
+	require.Len(t, rows, 150)
+	seen := map[user.ID]bool{}
+	for _, r := range rows {
+		assert.False(t, seen[r.User.ID()], "user %s emitted twice", r.User.ID())
+		seen[r.User.ID()] = true
+		if r.User.ID() == users[0].ID() {
+			assert.Equal(t, []Membership{{Workspace: "gis", Role: "owner"}}, r.Workspaces)
+		}
+	}
+
+	entries, err := auditLogRepo.FindByTarget(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, auditlog.ActionUserListingExport, entries[len(entries)-1].Action())
+	assert.Equal(t, "150", entries[len(entries)-1].Detail()["users"])
+
+	t.Run("filters", func(t *testing.T) {
+		kw := "user1@"
+		rows = nil
+		require.NoError(t, uc.Execute(ctx, ExportUserListingInput{Operator: adminuser.NewID(), Keyword: &kw}, func(r ListingRow) error {
+			rows = append(rows, r)
+			return nil
+		}))
+		require.Len(t, rows, 1)
+		assert.Equal(t, users[1].ID(), rows[0].User.ID())
+
+		rows = nil
+		require.NoError(t, uc.Execute(ctx, ExportUserListingInput{Operator: adminuser.NewID(), Status: user.StatusActive}, func(r ListingRow) error {
+			rows = append(rows, r)
+			return nil
+		}))
+		assert.Len(t, rows, 149)
+	})
+
+	t.Run("emit error stops the export", func(t *testing.T) {
+		before, err := auditLogRepo.FindByTarget(ctx, "")
+		require.NoError(t, err)
+		boom := fmt.Errorf("boom")
+		assert.ErrorIs(t, uc.Execute(ctx, ExportUserListingInput{Operator: adminuser.NewID()}, func(ListingRow) error {
+			return boom
+		}), boom)
+		after, err := auditLogRepo.FindByTarget(ctx, "")
+		require.NoError(t, err)
+		assert.Len(t, after, len(before))
+	})
+}
@@ -0,0 +1,125 @@
+package useruc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+	"golang.org/x/text/language"
+)
+
+// ExportUsersUseCase exports users in the shape ImportUsersUseCase imports,
+// e.g. to hand the users of an organization over when it is offboarded.
+type ExportUsersUseCase struct {
+	userRepo      user.Repo
+	workspaceRepo workspace.Repo
+	auditLogRepo  auditlog.Repo
+}
+
+// NewExportUsersUseCase is a Wire provider for ExportUsersUseCase.
+func NewExportUsersUseCase(userRepo user.Repo, workspaceRepo workspace.Repo, auditLogRepo auditlog.Repo) *ExportUsersUseCase {
+	return &ExportUsersUseCase{userRepo: userRepo, workspaceRepo: workspaceRepo, auditLogRepo: auditLogRepo}
+}
+
+// ExportInput is the input for ExportUsersUseCase.Execute.
+type ExportInput struct {
+	Operator adminuser.ID
+	// Workspace limits the export to the members of the team workspace with
+	// this alias. All users are exported when it is empty.
+	Workspace string
+}
+
+// Execute returns the active users sorted by email, with the subs of their
+// identity providers and their team workspace memberships, and records the
+// export in the audit log. Passwords are not exported.
+func (uc *ExportUsersUseCase) Execute(ctx context.Context, in ExportInput) ([]Record, error) {
+	var users user.List
+	var err error
+	if in.Workspace != "" {
+		ws, err := uc.workspaceRepo.FindByAlias(ctx, in.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		users, err = uc.userRepo.FindByIDs(ctx, ws.Members().UserIDs())
+		if err != nil {
+			return nil, err
+		}
+	} else if users, err = uc.userRepo.FindAll(ctx); err != nil {
+		return nil, err
+	}
+
+	records := make([]Record, 0, len(users))
+	for _, u := range users {
+		if u == nil || u.IsDeleted() {
+			continue
+		}
+		r, err := uc.record(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, r)
+	}
+	slices.SortFunc(records, func(a, b Record) int {
+		return strings.Compare(strings.ToLower(a.Email), strings.ToLower(b.Email))
+	})
+
+	entry, err := auditlog.New().
+		NewID().
+		Actor(in.Operator).
+		Action(auditlog.ActionUserExport).
+		Target(in.Workspace).
+		Detail(map[string]string{"users": strconv.Itoa(len(records))}).
+		CreatedAt(util.Now()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.auditLogRepo.Save(ctx, entry); err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] %d users exported by %s: workspace=%q", len(records), in.Operator, in.Workspace)
+	return records, nil
+}
+
+func (uc *ExportUsersUseCase) record(ctx context.Context, u *user.User) (Record, error) {
+	r := Record{
+		Email: u.Email(),
+		Name:  u.Name(),
+		Alias: u.Alias(),
+		Theme: string(u.Metadata().Theme()),
+	}
+	if l := u.Metadata().Lang(); l != language.Und {
+		r.Lang = l.String()
+	}
+	for _, a := range u.Auths() {
+		if !a.IsReearth() {
+			r.Subs = append(r.Subs, a.Sub)
+		}
+	}
+
+	list, err := uc.workspaceRepo.FindByUser(ctx, u.ID())
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return Record{}, err
+	}
+	for _, ws := range list {
+		if ws.IsPersonal() || ws.IsDeleted() {
+			continue
+		}
+		r.Workspaces = append(r.Workspaces, Membership{
+			Workspace: ws.Alias(),
+			Role:      ws.Members().UserRole(u.ID()).String(),
+		})
+	}
+	slices.SortFunc(r.Workspaces, func(a, b Membership) int { return strings.Compare(a.Workspace, b.Workspace) })
+	return r, nil
+}
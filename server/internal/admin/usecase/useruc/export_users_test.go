@@ -0,0 +1,59 @@
+package useruc
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportUsers(t *testing.T) {
+	ctx := context.Background()
+	f := newImportFixture(t)
+	require.NoError(t, f.repos.Workspace.Save(ctx, workspace.New().NewID().Name("Existing").Alias("existing").MustBuild()))
+	records, err := DecodeRecords(strings.NewReader(importCSV), FormatCSV)
+	require.NoError(t, err)
+	out, err := f.uc.Execute(ctx, ImportInput{Operator: adminuser.NewID(), Records: records})
+	require.NoError(t, err)
+	require.True(t, out.Imported())
+
+	bob, err := f.repos.User.FindByEmail(ctx, "bob@example.com")
+	require.NoError(t, err)
+	bob.Deactivate()
+	require.NoError(t, f.repos.User.Save(ctx, bob))
+
+	uc := NewExportUsersUseCase(f.repos.User, f.repos.Workspace, f.repos.AuditLog)
+	got, err := uc.Execute(ctx, ExportInput{Operator: adminuser.NewID()})
+	require.NoError(t, err)
+	assert.Equal(t, []Record{{
+		Email: "alice@example.com", Name: "Alice", Alias: "alice", Lang: "ja", Theme: "dark",
+		Subs:       []string{"oidc-acme|alice"},
+		Workspaces: []Membership{{Workspace: "existing", Role: "reader"}, {Workspace: "gis", Role: "owner"}},
+	}}, got)
+
+	// The export is the import file of the same users.
+	var buf bytes.Buffer
+	require.NoError(t, EncodeRecords(&buf, FormatCSV, got))
+	assert.Equal(t, "email,name,alias,lang,theme,subs,workspaces\nalice@example.com,Alice,alice,ja,dark,oidc-acme|alice,existing:reader;gis:owner\n", buf.String())
+	decoded, err := DecodeRecords(&buf, FormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, got, decoded)
+
+	entries, err := f.repos.AuditLog.FindByTarget(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, auditlog.ActionUserExport, entries[len(entries)-1].Action())
+
+	got, err = uc.Execute(ctx, ExportInput{Operator: adminuser.NewID(), Workspace: "gis"})
+	require.NoError(t, err)
+	assert.Len(t, got, 1)
+
+	_, err = uc.Execute(ctx, ExportInput{Operator: adminuser.NewID(), Workspace: "nope"})
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
@@ -0,0 +1,51 @@
+package useruc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/impersonation"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImpersonateUser(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	active := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	deactivated := user.New().NewID().Name("bob").Email("bob@example.com").MustBuild()
+	deactivated.Deactivate()
+	for _, u := range []*user.User{active, deactivated} {
+		require.NoError(t, r.User.Save(ctx, u))
+	}
+	signer := impersonation.NewSigner("impersonation-secret-impersonation")
+	uc := NewImpersonateUserUseCase(r.User, r.AuditLog, signer, ImpersonationTTL(15*time.Minute))
+	op := adminuser.NewID()
+	sid := adminsession.NewID()
+
+	out, err := uc.Execute(ctx, ImpersonateUserInput{UserActionInput: UserActionInput{Operator: op, User: active.ID()}, Session: sid})
+	require.NoError(t, err)
+	g, err := signer.Parse(out.Token)
+	require.NoError(t, err)
+	assert.Equal(t, op, g.Admin)
+	assert.Equal(t, sid, g.Session)
+	assert.Equal(t, active.ID(), g.User)
+	assert.True(t, out.ExpiresAt.Equal(g.ExpiresAt))
+	assert.WithinDuration(t, time.Now().Add(15*time.Minute), out.ExpiresAt, time.Minute)
+	assert.Equal(t, auditlog.ActionUserImpersonate, out.AuditLog.Action())
+	assert.Equal(t, active.ID().String(), out.AuditLog.Target())
+	assert.Equal(t, g.TokenID, out.AuditLog.Detail()["token_id"])
+
+	_, err = uc.Execute(ctx, ImpersonateUserInput{UserActionInput: UserActionInput{Operator: op, User: deactivated.ID()}, Session: sid})
+	assert.ErrorIs(t, err, ErrUserDeactivated)
+
+	disabled := NewImpersonateUserUseCase(r.User, r.AuditLog, impersonation.NewSigner(""), ImpersonationTTL(time.Minute))
+	_, err = disabled.Execute(ctx, ImpersonateUserInput{UserActionInput: UserActionInput{Operator: op, User: active.ID()}, Session: sid})
+	assert.ErrorIs(t, err, ErrImpersonationDisabled)
+}
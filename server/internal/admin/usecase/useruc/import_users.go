@@ -0,0 +1,509 @@
+package useruc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/mail"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/reearth/reearthx/util"
+	"golang.org/x/text/language"
+)
+
+const (
+	// MaxImportRows is the largest number of users in an import file.
+	MaxImportRows = 10000
+	// importBatchSize is the number of users created per transaction.
+	importBatchSize = 100
+)
+
+// ImportUsersUseCase creates the users of an organization being onboarded,
+// with their personal workspaces and their memberships in team workspaces.
+type ImportUsersUseCase struct {
+	userRepo        user.Repo
+	workspaceRepo   workspace.Repo
+	roleRepo        role.Repo
+	permittableRepo permittable.Repo
+	auditLogRepo    auditlog.Repo
+	transaction     usecasex.Transaction
+}
+
+// NewImportUsersUseCase is a Wire provider for ImportUsersUseCase.
+func NewImportUsersUseCase(
+	userRepo user.Repo,
+	workspaceRepo workspace.Repo,
+	roleRepo role.Repo,
+	permittableRepo permittable.Repo,
+	auditLogRepo auditlog.Repo,
+	transaction usecasex.Transaction,
+) *ImportUsersUseCase {
+	return &ImportUsersUseCase{
+		userRepo:        userRepo,
+		workspaceRepo:   workspaceRepo,
+		roleRepo:        roleRepo,
+		permittableRepo: permittableRepo,
+		auditLogRepo:    auditLogRepo,
+		transaction:     transaction,
+	}
+}
+
+// ImportInput is the input for ImportUsersUseCase.Execute.
+type ImportInput struct {
+	Operator adminuser.ID
+	Records  []Record
+	// DryRun validates the records without creating anything.
+	DryRun bool
+}
+
+// ImportRow is the result of a record of the file.
+type ImportRow struct {
+	// Row is the position of the record in the file, starting at 1.
+	Row   int
+	Email string
+	// User is the created user. It is nil in a dry run.
+	User   *user.ID
+	Errors []string
+}
+
+// ImportOutput is the per-row report of an import.
+type ImportOutput struct {
+	DryRun bool
+	Rows   []ImportRow
+	// NewWorkspaces are the aliases of the team workspaces that do not exist
+	// yet and are created for the members listed in the file.
+	NewWorkspaces []string
+	// AuditLog is nil unless the users were created.
+	AuditLog *auditlog.Entry
+}
+
+// Valid reports whether every record of the file is valid.
+func (o *ImportOutput) Valid() bool {
+	for _, r := range o.Rows {
+		if len(r.Errors) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// Imported reports whether the users were created.
+func (o *ImportOutput) Imported() bool {
+	return o.AuditLog != nil
+}
+
+// Execute validates every record against the file and the existing users and
+// workspaces, and reports the errors of each row. Nothing is created in a dry
+// run or when any row is invalid. Otherwise the users are created in batches
+// of importBatchSize, each in its own transaction, so a failure part way
+// keeps the earlier batches: importing the same file again then reports their
+// users as existing.
+func (uc *ImportUsersUseCase) Execute(ctx context.Context, in ImportInput) (*ImportOutput, error) {
+	if len(in.Records) > MaxImportRows {
+		return nil, ErrTooManyImportRows
+	}
+
+	plan, out, err := uc.validate(ctx, in.Records)
+	if err != nil {
+		return nil, err
+	}
+	out.DryRun = in.DryRun
+	if in.DryRun || !out.Valid() || len(plan.rows) == 0 {
+		return out, nil
+	}
+
+	for start := 0; start < len(plan.rows); start += importBatchSize {
+		end := min(start+importBatchSize, len(plan.rows))
+		err := usecasex.DoTransaction(ctx, uc.transaction, 0, func(ctx context.Context) error {
+			return uc.importBatch(ctx, plan, plan.rows[start:end], out.Rows[start:end])
+		})
+		if err != nil {
+			log.Errorfc(ctx, "[admin] user import by %s failed after %d of %d users: %v", in.Operator, start, len(plan.rows), err)
+			return nil, err
+		}
+	}
+
+	out.AuditLog, err = auditlog.New().
+		NewID().
+		Actor(in.Operator).
+		Action(auditlog.ActionUserImport).
+		Detail(map[string]string{
+			"users":         strconv.Itoa(len(plan.rows)),
+			"newWorkspaces": strings.Join(out.NewWorkspaces, ","),
+		}).
+		CreatedAt(util.Now()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.auditLogRepo.Save(ctx, out.AuditLog); err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] %d users imported by %s: newWorkspaces=%v", len(plan.rows), in.Operator, out.NewWorkspaces)
+	return out, nil
+}
+
+type importPlan struct {
+	rows []importRow
+	// workspaces are the team workspaces of the file by alias. A workspace
+	// that does not exist yet is nil until its first member is imported.
+	workspaces map[string]*workspace.Workspace
+	// created holds the workspaces that did not exist before the import, and
+	// whether they have been created since.
+	created map[string]bool
+	roleIDs map[role.RoleType]id.RoleID
+}
+
+type importRow struct {
+	email       string
+	name        string
+	alias       string
+	lang        language.Tag
+	theme       user.Theme
+	auths       []user.Auth
+	memberships []importMembership
+}
+
+type importMembership struct {
+	workspace string
+	role      role.RoleType
+}
+
+func (uc *ImportUsersUseCase) validate(ctx context.Context, records []Record) (*importPlan, *ImportOutput, error) {
+	plan := &importPlan{
+		workspaces: map[string]*workspace.Workspace{},
+		created:    map[string]bool{},
+		roleIDs:    map[role.RoleType]id.RoleID{},
+	}
+	out := &ImportOutput{Rows: make([]ImportRow, 0, len(records))}
+
+	emails := map[string]int{}
+	aliases := map[string]int{}
+	subs := map[string]int{}
+	owners := map[string]int{}
+	members := map[string][]int{}
+
+	for i, rec := range records {
+		n := i + 1
+		r := importRow{
+			email: strings.TrimSpace(rec.Email),
+			name:  strings.TrimSpace(rec.Name),
+			alias: strings.TrimSpace(rec.Alias),
+			theme: user.ThemeDefault,
+		}
+		var errs []string
+
+		switch key := strings.ToLower(r.email); {
+		case r.email == "":
+			errs = append(errs, "email is required")
+		case !validEmail(r.email):
+			errs = append(errs, "invalid email")
+		case emails[key] != 0:
+			errs = append(errs, fmt.Sprintf("email is also in row %d", emails[key]))
+		default:
+			emails[key] = n
+			taken, err := found(uc.userRepo.FindByEmail(ctx, r.email))
+			if err != nil {
+				return nil, nil, err
+			}
+			if taken {
+				errs = append(errs, "a user with this email already exists")
+			}
+		}
+
+		if r.name == "" {
+			errs = append(errs, "name is required")
+		}
+		if r.alias == "" {
+			r.alias = r.name
+		}
+		if r.alias != "" {
+			msg, err := uc.checkAlias(ctx, r.alias, aliases)
+			if err != nil {
+				return nil, nil, err
+			}
+			if msg != "" {
+				errs = append(errs, msg)
+			} else {
+				aliases[r.alias] = n
+			}
+		}
+
+		if rec.Lang != "" {
+			l, err := language.Parse(rec.Lang)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("invalid lang %q", rec.Lang))
+			}
+			r.lang = l
+		}
+		if rec.Theme != "" {
+			r.theme = user.Theme(strings.ToLower(rec.Theme))
+			if !r.theme.Valid() {
+				errs = append(errs, fmt.Sprintf("invalid theme %q", rec.Theme))
+			}
+		}
+
+		providers := map[string]bool{}
+		for _, s := range rec.Subs {
+			a := user.AuthFrom(strings.TrimSpace(s))
+			switch {
+			case a.Provider == "":
+				errs = append(errs, fmt.Sprintf("invalid sub %q", s))
+			case a.IsReearth():
+				errs = append(errs, fmt.Sprintf("sub %q: password accounts can't be imported", s))
+			case providers[a.Provider]:
+				errs = append(errs, fmt.Sprintf("more than one sub of provider %q", a.Provider))
+			case subs[a.Sub] != 0:
+				errs = append(errs, fmt.Sprintf("sub %q is also in row %d", a.Sub, subs[a.Sub]))
+			default:
+				providers[a.Provider] = true
+				subs[a.Sub] = n
+				taken, err := found(uc.userRepo.FindBySub(ctx, a.Sub))
+				if err != nil {
+					return nil, nil, err
+				}
+				if taken {
+					errs = append(errs, fmt.Sprintf("sub %q belongs to an existing user", a.Sub))
+				}
+				r.auths = append(r.auths, a)
+			}
+		}
+
+		listed := map[string]bool{}
+		for _, m := range rec.Workspaces {
+			alias := strings.TrimSpace(m.Workspace)
+			rt, err := role.RoleFrom(strings.TrimSpace(m.Role))
+			switch {
+			case alias == "":
+				errs = append(errs, "workspace alias is required")
+				continue
+			case err != nil || rt == role.RoleSelf:
+				errs = append(errs, fmt.Sprintf("invalid role %q in workspace %q", m.Role, alias))
+				continue
+			case listed[alias]:
+				errs = append(errs, fmt.Sprintf("workspace %q is listed twice", alias))
+				continue
+			}
+			listed[alias] = true
+
+			msg, err := uc.checkWorkspace(ctx, plan, out, alias)
+			if err != nil {
+				return nil, nil, err
+			}
+			if msg != "" {
+				errs = append(errs, msg)
+				continue
+			}
+			members[alias] = append(members[alias], i)
+			if rt == role.RoleOwner {
+				owners[alias]++
+			}
+			r.memberships = append(r.memberships, importMembership{workspace: alias, role: rt})
+		}
+
+		plan.rows = append(plan.rows, r)
+		out.Rows = append(out.Rows, ImportRow{Row: n, Email: rec.Email, Errors: errs})
+	}
+
+	for _, alias := range out.NewWorkspaces {
+		var msg string
+		switch {
+		case owners[alias] == 0:
+			msg = fmt.Sprintf("workspace %q does not exist and no row is its owner", alias)
+		case aliases[alias] != 0:
+			msg = fmt.Sprintf("workspace %q has the alias of the user in row %d", alias, aliases[alias])
+		default:
+			continue
+		}
+		for _, i := range members[alias] {
+			out.Rows[i].Errors = append(out.Rows[i].Errors, msg)
+		}
+	}
+	return plan, out, nil
+}
+
+// checkAlias returns why alias can't be the alias of a new user and of its
+// personal workspace, or "" if it can.
+func (uc *ImportUsersUseCase) checkAlias(ctx context.Context, alias string, aliases map[string]int) (string, error) {
+	if row := aliases[alias]; row != 0 {
+		return fmt.Sprintf("alias is also in row %d", row), nil
+	}
+	taken, err := found(uc.userRepo.FindByAlias(ctx, alias))
+	if err != nil || taken {
+		return "a user with this alias already exists", err
+	}
+	taken, err = found(uc.workspaceRepo.FindByAlias(ctx, alias))
+	if err != nil || taken {
+		return "a workspace with this alias already exists", err
+	}
+	return "", nil
+}
+
+// checkWorkspace looks up the team workspace of alias, registering it in the
+// plan, and returns why users can't be imported into it, or "" if they can.
+func (uc *ImportUsersUseCase) checkWorkspace(ctx context.Context, plan *importPlan, out *ImportOutput, alias string) (string, error) {
+	ws, ok := plan.workspaces[alias]
+	if !ok {
+		var err error
+		ws, err = uc.workspaceRepo.FindByAlias(ctx, alias)
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return "", err
+		}
+		plan.workspaces[alias] = ws
+		if ws == nil {
+			plan.created[alias] = false
+			out.NewWorkspaces = append(out.NewWorkspaces, alias)
+		}
+	}
+	switch {
+	case ws == nil:
+		return "", nil
+	case ws.IsPersonal():
+		return fmt.Sprintf("workspace %q is a personal workspace", alias), nil
+	case ws.IsDeleted():
+		return fmt.Sprintf("workspace %q is deleted", alias), nil
+	}
+	return "", nil
+}
+
+// importBatch creates the users of rows, joins them to their workspaces and
+// records the results in res. It must be called in a transaction.
+func (uc *ImportUsersUseCase) importBatch(ctx context.Context, plan *importPlan, rows []importRow, res []ImportRow) error {
+	selfID, err := uc.roleID(ctx, plan, role.RoleSelf)
+	if err != nil {
+		return err
+	}
+	ownerID, err := uc.roleID(ctx, plan, role.RoleOwner)
+	if err != nil {
+		return err
+	}
+
+	now := util.Now()
+	var touched []string
+	perms := make(permittable.List, 0, len(rows))
+	for k, r := range rows {
+		var sub *user.Auth
+		if len(r.auths) > 0 {
+			sub = &r.auths[0]
+		}
+		u, personal, err := workspace.Init(workspace.InitParams{
+			Email: r.email,
+			Name:  r.name,
+			Sub:   sub,
+			Lang:  &r.lang,
+			Theme: &r.theme,
+		})
+		if err != nil {
+			return err
+		}
+		u.UpdateAlias(r.alias)
+		personal.UpdateAlias(r.alias)
+		for _, a := range r.auths[min(1, len(r.auths)):] {
+			u.AddAuth(a)
+		}
+
+		if err := uc.userRepo.Create(ctx, u); err != nil {
+			return err
+		}
+		if err := uc.workspaceRepo.Save(ctx, personal); err != nil {
+			return err
+		}
+
+		wroles := []permittable.WorkspaceRole{permittable.NewWorkspaceRole(personal.ID(), ownerID)}
+		for _, m := range r.memberships {
+			ws := plan.workspaces[m.workspace]
+			if ws == nil {
+				ws, err = workspace.New().
+					NewID().
+					Name(m.workspace).
+					Alias(m.workspace).
+					Metadata(workspace.NewMetadata()).
+					CreatedAt(&now).
+					Build()
+				if err != nil {
+					return err
+				}
+				plan.workspaces[m.workspace] = ws
+			}
+			if err := ws.Members().Join(u, m.role, u.ID()); err != nil {
+				return err
+			}
+			if !slices.Contains(touched, m.workspace) {
+				touched = append(touched, m.workspace)
+			}
+
+			rid, err := uc.roleID(ctx, plan, m.role)
+			if err != nil {
+				return err
+			}
+			wroles = append(wroles, permittable.NewWorkspaceRole(ws.ID(), rid))
+		}
+
+		p, err := permittable.New().
+			NewID().
+			UserID(u.ID()).
+			RoleIDs([]id.RoleID{selfID}).
+			WorkspaceRoles(wroles).
+			Build()
+		if err != nil {
+			return err
+		}
+		perms = append(perms, p)
+		res[k].User = u.ID().Ref()
+	}
+
+	for _, alias := range touched {
+		ws := plan.workspaces[alias]
+		created, isNew := plan.created[alias]
+		if isNew && !created {
+			if err := uc.workspaceRepo.Create(ctx, ws); err != nil {
+				return err
+			}
+			plan.created[alias] = true
+			continue
+		}
+		if err := uc.workspaceRepo.Save(ctx, ws); err != nil {
+			return err
+		}
+	}
+	return uc.permittableRepo.SaveMany(ctx, perms)
+}
+
+func (uc *ImportUsersUseCase) roleID(ctx context.Context, plan *importPlan, rt role.RoleType) (id.RoleID, error) {
+	if rid, ok := plan.roleIDs[rt]; ok {
+		return rid, nil
+	}
+	r, err := uc.roleRepo.FindByName(ctx, rt.String())
+	if err != nil {
+		return id.RoleID{}, err
+	}
+	plan.roleIDs[rt] = r.ID()
+	return r.ID(), nil
+}
+
+func validEmail(s string) bool {
+	a, err := mail.ParseAddress(s)
+	return err == nil && a.Address == s
+}
+
+// found reports whether a lookup found something, treating not found as no
+// error.
+func found[T any](v *T, err error) (bool, error) {
+	if errors.Is(err, rerror.ErrNotFound) {
+		return false, nil
+	}
+	return err == nil && v != nil, err
+}
@@ -0,0 +1,216 @@
+package useruc
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+type importFixture struct {
+	repos *repo.Container
+	roles map[role.RoleType]*role.Role
+	uc    *ImportUsersUseCase
+}
+
+func newImportFixture(t *testing.T) *importFixture {
+	t.Helper()
+	f := &importFixture{repos: memory.New(), roles: map[role.RoleType]*role.Role{}}
+	for _, rt := range []role.RoleType{role.RoleSelf, role.RoleOwner, role.RoleMaintainer, role.RoleWriter, role.RoleReader} {
+		r := role.New().NewID().Name(rt.String()).MustBuild()
+		require.NoError(t, f.repos.Role.Save(context.Background(), *r))
+		f.roles[rt] = r
+	}
+	r := f.repos
+	f.uc = NewImportUsersUseCase(r.User, r.Workspace, r.Role, r.Permittable, r.AuditLog, r.Transaction)
+	return f
+}
+
+const importCSV = `email,name,alias,lang,theme,subs,workspaces
+alice@example.com,Alice,alice,ja,dark,oidc-acme|alice,gis:owner;existing:reader
+bob@example.com,Bob,,,,,gis:writer
+`
+
+func TestImportUsers(t *testing.T) {
+	ctx := context.Background()
+	f := newImportFixture(t)
+	existing := workspace.New().NewID().Name("Existing").Alias("existing").MustBuild()
+	require.NoError(t, f.repos.Workspace.Save(ctx, existing))
+	op := adminuser.NewID()
+
+	records, err := DecodeRecords(strings.NewReader(importCSV), FormatCSV)
+	require.NoError(t, err)
+
+	// A dry run reports the plan and creates nothing.
+	out, err := f.uc.Execute(ctx, ImportInput{Operator: op, Records: records, DryRun: true})
+	require.NoError(t, err)
+	assert.True(t, out.Valid())
+	assert.False(t, out.Imported())
+	assert.Equal(t, []string{"gis"}, out.NewWorkspaces)
+	_, err = f.repos.User.FindByEmail(ctx, "alice@example.com")
+	assert.Error(t, err)
+
+	out, err = f.uc.Execute(ctx, ImportInput{Operator: op, Records: records})
+	require.NoError(t, err)
+	require.True(t, out.Imported())
+	require.Len(t, out.Rows, 2)
+
+	alice, err := f.repos.User.FindByEmail(ctx, "alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID(), *out.Rows[0].User)
+	assert.Equal(t, "alice", alice.Alias())
+	assert.Equal(t, language.Japanese, alice.Metadata().Lang())
+	assert.Equal(t, user.ThemeDark, alice.Metadata().Theme())
+	assert.True(t, alice.Auths().Has("oidc-acme|alice"))
+	bySub, err := f.repos.User.FindBySub(ctx, "oidc-acme|alice")
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID(), bySub.ID())
+	bob, err := f.repos.User.FindByEmail(ctx, "bob@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, "Bob", bob.Alias())
+
+	personal, err := f.repos.Workspace.FindByID(ctx, alice.Workspace())
+	require.NoError(t, err)
+	assert.True(t, personal.IsPersonal())
+	assert.Equal(t, "alice", personal.Alias())
+
+	gis, err := f.repos.Workspace.FindByAlias(ctx, "gis")
+	require.NoError(t, err)
+	assert.Equal(t, role.RoleOwner, gis.Members().UserRole(alice.ID()))
+	assert.Equal(t, role.RoleWriter, gis.Members().UserRole(bob.ID()))
+	existing, err = f.repos.Workspace.FindByID(ctx, existing.ID())
+	require.NoError(t, err)
+	assert.Equal(t, role.RoleReader, existing.Members().UserRole(alice.ID()))
+
+	p, err := f.repos.Permittable.FindByUserID(ctx, alice.ID())
+	require.NoError(t, err)
+	assert.Equal(t, []id.RoleID{f.roles[role.RoleSelf].ID()}, p.RoleIDs())
+	assert.Equal(t, map[workspace.ID]id.RoleID{
+		personal.ID(): f.roles[role.RoleOwner].ID(),
+		gis.ID():      f.roles[role.RoleOwner].ID(),
+		existing.ID(): f.roles[role.RoleReader].ID(),
+	}, workspaceRoles(p))
+
+	entries, err := f.repos.AuditLog.FindByTarget(ctx, "")
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, auditlog.ActionUserImport, entries[0].Action())
+	assert.Equal(t, "2", entries[0].Detail()["users"])
+
+	// Importing the same file again reports the users as existing.
+	out, err = f.uc.Execute(ctx, ImportInput{Operator: op, Records: records})
+	require.NoError(t, err)
+	assert.False(t, out.Valid())
+	assert.False(t, out.Imported())
+	assert.Equal(t, []string{
+		"a user with this email already exists",
+		"a user with this alias already exists",
+		`sub "oidc-acme|alice" belongs to an existing user`,
+	}, out.Rows[0].Errors)
+}
+
+func TestImportUsers_Errors(t *testing.T) {
+	ctx := context.Background()
+	f := newImportFixture(t)
+	personal := workspace.New().NewID().Name("carol").Alias("carol-ws").Personal(true).MustBuild()
+	require.NoError(t, f.repos.Workspace.Save(ctx, personal))
+
+	records := []Record{
+		{Email: "not an email", Name: "A", Lang: "??", Theme: "blue"},
+		{Email: "b@example.com", Name: "B", Subs: []string{"reearth|1", "nopipe"}},
+		{Email: "B@example.com", Name: "", Subs: []string{"oidc|1", "oidc|2"}},
+		{Email: "d@example.com", Name: "D", Alias: "B", Workspaces: []Membership{
+			{Workspace: "team", Role: "writer"},
+			{Workspace: "team", Role: "reader"},
+			{Workspace: "carol-ws", Role: "reader"},
+			{Workspace: "x", Role: "self"},
+		}},
+	}
+	out, err := f.uc.Execute(ctx, ImportInput{Operator: adminuser.NewID(), Records: records})
+	require.NoError(t, err)
+	assert.False(t, out.Valid())
+	assert.False(t, out.Imported())
+	assert.Equal(t, []string{"team"}, out.NewWorkspaces)
+
+	assert.Equal(t, []string{"invalid email", `invalid lang "??"`, `invalid theme "blue"`}, out.Rows[0].Errors)
+	assert.Equal(t, []string{`sub "reearth|1": password accounts can't be imported`, `invalid sub "nopipe"`}, out.Rows[1].Errors)
+	assert.Equal(t, []string{"email is also in row 2", "name is required", `more than one sub of provider "oidc"`}, out.Rows[2].Errors)
+	assert.Equal(t, []string{
+		"alias is also in row 2",
+		`workspace "team" is listed twice`,
+		`workspace "carol-ws" is a personal workspace`,
+		`invalid role "self" in workspace "x"`,
+		`workspace "team" does not exist and no row is its owner`,
+	}, out.Rows[3].Errors)
+
+	users, err := f.repos.User.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, users)
+}
+
+func TestImportUsers_Batches(t *testing.T) {
+	ctx := context.Background()
+	f := newImportFixture(t)
+
+	n := importBatchSize*2 + 1
+	records := make([]Record, 0, n)
+	for i := range n {
+		r := Record{Email: fmt.Sprintf("u%d@example.com", i), Name: fmt.Sprintf("u%d", i), Workspaces: []Membership{{Workspace: "big", Role: "reader"}}}
+		if i == n-1 {
+			r.Workspaces[0].Role = "owner"
+		}
+		records = append(records, r)
+	}
+	out, err := f.uc.Execute(ctx, ImportInput{Operator: adminuser.NewID(), Records: records})
+	require.NoError(t, err)
+	require.True(t, out.Imported())
+
+	big, err := f.repos.Workspace.FindByAlias(ctx, "big")
+	require.NoError(t, err)
+	assert.Equal(t, n, big.Members().Count())
+	assert.Equal(t, role.RoleOwner, big.Members().UserRole(*out.Rows[n-1].User))
+}
+
+func TestImportUsers_TooManyRows(t *testing.T) {
+	f := newImportFixture(t)
+	_, err := f.uc.Execute(context.Background(), ImportInput{Operator: adminuser.NewID(), Records: make([]Record, MaxImportRows+1)})
+	assert.ErrorIs(t, err, ErrTooManyImportRows)
+}
+
+func TestDecodeRecords(t *testing.T) {
+	_, err := DecodeRecords(strings.NewReader("email,nickname\n"), FormatCSV)
+	assert.ErrorIs(t, err, ErrInvalidImportFile)
+	_, err = DecodeRecords(strings.NewReader("alias\n"), FormatCSV)
+	assert.ErrorIs(t, err, ErrInvalidImportFile)
+	_, err = DecodeRecords(strings.NewReader("{"), FormatJSON)
+	assert.ErrorIs(t, err, ErrInvalidImportFile)
+
+	records, err := DecodeRecords(strings.NewReader("\ufeffName,Email\nAlice,alice@example.com\n"), FormatCSV)
+	require.NoError(t, err)
+	assert.Equal(t, []Record{{Email: "alice@example.com", Name: "Alice"}}, records)
+
+	records, err = DecodeRecords(strings.NewReader(`[{"email":"a@example.com","name":"A","workspaces":[{"workspace":"gis","role":"owner"}]}]`), FormatJSON)
+	require.NoError(t, err)
+	assert.Equal(t, []Record{{Email: "a@example.com", Name: "A", Workspaces: []Membership{{Workspace: "gis", Role: "owner"}}}}, records)
+}
+
+func workspaceRoles(p *permittable.Permittable) map[workspace.ID]id.RoleID {
+	res := map[workspace.ID]id.RoleID{}
+	for _, r := range p.WorkspaceRoles() {
+		res[r.ID()] = r.RoleID()
+	}
+	return res
+}
@@ -0,0 +1,256 @@
+package useruc
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/reearth/reearthx/util"
+)
+
+// MergeUsersUseCase merges a duplicate account of a person into their other
+// account.
+type MergeUsersUseCase struct {
+	userRepo        user.Repo
+	workspaceRepo   workspace.Repo
+	roleRepo        role.Repo
+	permittableRepo permittable.Repo
+	auditLogRepo    auditlog.Repo
+	transaction     usecasex.Transaction
+}
+
+// NewMergeUsersUseCase is a Wire provider for MergeUsersUseCase.
+func NewMergeUsersUseCase(
+	userRepo user.Repo,
+	workspaceRepo workspace.Repo,
+	roleRepo role.Repo,
+	permittableRepo permittable.Repo,
+	auditLogRepo auditlog.Repo,
+	transaction usecasex.Transaction,
+) *MergeUsersUseCase {
+	return &MergeUsersUseCase{
+		userRepo:        userRepo,
+		workspaceRepo:   workspaceRepo,
+		roleRepo:        roleRepo,
+		permittableRepo: permittableRepo,
+		auditLogRepo:    auditLogRepo,
+		transaction:     transaction,
+	}
+}
+
+// MergeInput is the input for MergeUsersUseCase.Execute.
+type MergeInput struct {
+	Operator adminuser.ID
+	Source   user.ID
+	Target   user.ID
+}
+
+// MergeOutput describes what was moved from the source to the target.
+type MergeOutput struct {
+	Target *user.User
+	// MovedAuths are the subs the target can now sign in with.
+	MovedAuths []string
+	// DroppedAuths are the subs of the source that were not moved: the password
+	// of the source stays with it.
+	DroppedAuths []string
+	Workspaces   workspace.IDList
+	AuditLog     *auditlog.Entry
+}
+
+// Execute moves the auths, workspace memberships and permittable bindings of
+// the source to the target, soft-deletes the source with a pointer to the
+// target and records the merge in the audit log, all in one transaction.
+// Where both users are members of a workspace, the target keeps the higher
+// role.
+func (uc *MergeUsersUseCase) Execute(ctx context.Context, in MergeInput) (*MergeOutput, error) {
+	if in.Source == in.Target {
+		return nil, ErrMergeSameUser
+	}
+
+	var out *MergeOutput
+	err := usecasex.DoTransaction(ctx, uc.transaction, 0, func(ctx context.Context) error {
+		var err error
+		out, err = uc.merge(ctx, in)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] user %s merged into %s by %s: auths=%v workspaces=%v", in.Source, in.Target, in.Operator, out.MovedAuths, out.Workspaces)
+	return out, nil
+}
+
+func (uc *MergeUsersUseCase) merge(ctx context.Context, in MergeInput) (*MergeOutput, error) {
+	src, err := uc.userRepo.FindByID(ctx, in.Source)
+	if err != nil {
+		return nil, err
+	}
+	dst, err := uc.userRepo.FindByID(ctx, in.Target)
+	if err != nil {
+		return nil, err
+	}
+	if src.IsDeleted() || dst.IsDeleted() {
+		return nil, ErrMergeDeletedUser
+	}
+
+	out := &MergeOutput{Target: dst}
+	now := util.Now()
+
+	for _, a := range src.Auths() {
+		switch {
+		case a.Provider == user.ProviderReearth:
+			out.DroppedAuths = append(out.DroppedAuths, a.Sub)
+			continue
+		case dst.Auths().Has(a.Sub):
+			continue
+		case dst.HasAuthProvider(a.Provider):
+			return nil, ErrMergeAuthConflict
+		}
+		link := user.AuthLink{LinkedAt: now}
+		if l := src.AuthLink(a.Sub); l != nil {
+			link = *l
+		}
+		if err := dst.LinkAuth(a, link); err != nil {
+			return nil, err
+		}
+		out.MovedAuths = append(out.MovedAuths, a.Sub)
+	}
+
+	roles, err := uc.mergeWorkspaces(ctx, in.Source, in.Target)
+	if err != nil {
+		return nil, err
+	}
+	for wid := range roles {
+		out.Workspaces = append(out.Workspaces, wid)
+	}
+	slices.SortFunc(out.Workspaces, func(a, b workspace.ID) int { return a.Compare(b) })
+
+	if err := uc.mergePermittables(ctx, in.Source, in.Target, roles); err != nil {
+		return nil, err
+	}
+
+	src.MarkMergedInto(in.Target)
+	if err := uc.userRepo.Save(ctx, src); err != nil {
+		return nil, err
+	}
+	if err := uc.userRepo.Save(ctx, dst); err != nil {
+		return nil, err
+	}
+
+	out.AuditLog, err = auditlog.New().
+		NewID().
+		Actor(in.Operator).
+		Action(auditlog.ActionUserMerge).
+		Target(in.Target.String()).
+		Detail(map[string]string{
+			"source":       in.Source.String(),
+			"auths":        strings.Join(out.MovedAuths, ","),
+			"droppedAuths": strings.Join(out.DroppedAuths, ","),
+			"workspaces":   strings.Join(out.Workspaces.Strings(), ","),
+		}).
+		CreatedAt(now).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	if err := uc.auditLogRepo.Save(ctx, out.AuditLog); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// mergeWorkspaces moves the memberships of the source to the target, including
+// the ownership of the personal workspace of the source, and returns the role
+// the target ends up with in each workspace.
+func (uc *MergeUsersUseCase) mergeWorkspaces(ctx context.Context, source, target user.ID) (map[workspace.ID]role.RoleType, error) {
+	list, err := uc.workspaceRepo.FindByUser(ctx, source)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, err
+	}
+
+	roles := make(map[workspace.ID]role.RoleType, len(list))
+	for _, ws := range list {
+		r, err := ws.Members().MergeUser(source, target)
+		if err != nil {
+			return nil, err
+		}
+		roles[ws.ID()] = r
+	}
+	if len(list) == 0 {
+		return roles, nil
+	}
+	if err := uc.workspaceRepo.SaveAll(ctx, list); err != nil {
+		return nil, err
+	}
+	return roles, nil
+}
+
+// mergePermittables moves the global roles of the source to the target and
+// keeps the workspace roles of the target in sync with its memberships.
+func (uc *MergeUsersUseCase) mergePermittables(ctx context.Context, source, target user.ID, roles map[workspace.ID]role.RoleType) error {
+	existing, err := uc.permittableRepo.FindByUserIDs(ctx, user.IDList{source, target})
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return err
+	}
+	var sp, tp *permittable.Permittable
+	for _, p := range existing {
+		switch p.UserID() {
+		case source:
+			sp = p
+		case target:
+			tp = p
+		}
+	}
+	if sp == nil && len(roles) == 0 {
+		return nil
+	}
+	if tp == nil {
+		if tp, err = permittable.New().NewID().UserID(target).Build(); err != nil {
+			return err
+		}
+	}
+
+	if sp != nil && len(sp.RoleIDs()) > 0 {
+		roleIDs := slices.Clone(tp.RoleIDs())
+		for _, rid := range sp.RoleIDs() {
+			if !slices.Contains(roleIDs, rid) {
+				roleIDs = append(roleIDs, rid)
+			}
+		}
+		tp.EditRoleIDs(roleIDs)
+	}
+
+	roleIDs := make(map[role.RoleType]id.RoleID, len(roles))
+	for wid, rt := range roles {
+		rid, ok := roleIDs[rt]
+		if !ok {
+			r, err := uc.roleRepo.FindByName(ctx, rt.String())
+			if err != nil {
+				return err
+			}
+			rid = r.ID()
+			roleIDs[rt] = rid
+		}
+		tp.UpdateWorkspaceRole(wid, rid)
+	}
+
+	toSave := permittable.List{tp}
+	if sp != nil {
+		sp.EditRoleIDs(nil)
+		sp.EditWorkspaceRoles(nil)
+		toSave = append(toSave, sp)
+	}
+	return uc.permittableRepo.SaveMany(ctx, toSave)
+}
@@ -0,0 +1,183 @@
+package useruc
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mergeFixture struct {
+	uc       *MergeUsersUseCase
+	users    *memory.User
+	ws       *memory.Workspace
+	roles    map[role.RoleType]*role.Role
+	perms    *memory.Permittable
+	auditLog *memory.AuditLog
+}
+
+func newMergeFixture(t *testing.T) *mergeFixture {
+	t.Helper()
+	f := &mergeFixture{
+		users:    memory.NewUser(),
+		ws:       memory.NewWorkspace(),
+		roles:    map[role.RoleType]*role.Role{},
+		perms:    memory.NewPermittable(),
+		auditLog: memory.NewAuditLog(),
+	}
+	roleRepo := memory.NewRole()
+	for _, rt := range []role.RoleType{role.RoleOwner, role.RoleMaintainer, role.RoleWriter, role.RoleReader} {
+		r := role.New().NewID().Name(rt.String()).MustBuild()
+		require.NoError(t, roleRepo.Save(context.Background(), *r))
+		f.roles[rt] = r
+	}
+	f.uc = NewMergeUsersUseCase(f.users, f.ws, roleRepo, f.perms, f.auditLog, memory.New().Transaction)
+	return f
+}
+
+func TestMergeUsers(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+	ctx := context.Background()
+	f := newMergeFixture(t)
+	op := adminuser.NewID()
+
+	srcPersonal := id.NewWorkspaceID()
+	src := user.New().NewID().Name("alice").Email("alice@example.com").Workspace(srcPersonal).
+		Auths([]user.Auth{user.AuthFrom("google-oauth2|1"), user.AuthFrom("reearth|1")}).MustBuild()
+	dst := user.New().NewID().Name("alice").Email("alice@example.org").Workspace(id.NewWorkspaceID()).
+		Auths([]user.Auth{user.AuthFrom("auth0|1")}).MustBuild()
+	require.NoError(t, f.users.Save(ctx, src))
+	require.NoError(t, f.users.Save(ctx, dst))
+
+	personal := workspace.New().ID(srcPersonal).Name("alice").Personal(true).Members(map[workspace.UserID]workspace.Member{
+		src.ID(): {Role: role.RoleOwner},
+	}).MustBuild()
+	shared := workspace.New().NewID().Name("team").Members(map[workspace.UserID]workspace.Member{
+		src.ID(): {Role: role.RoleMaintainer},
+		dst.ID(): {Role: role.RoleReader},
+	}).MustBuild()
+	other := workspace.New().NewID().Name("other").Members(map[workspace.UserID]workspace.Member{
+		dst.ID(): {Role: role.RoleWriter},
+	}).MustBuild()
+	require.NoError(t, f.ws.SaveAll(ctx, workspace.List{personal, shared, other}))
+
+	globalRole := id.NewRoleID()
+	require.NoError(t, f.perms.SaveMany(ctx, permittable.List{
+		permittable.New().NewID().UserID(src.ID()).RoleIDs([]id.RoleID{globalRole}).
+			WorkspaceRoles([]permittable.WorkspaceRole{
+				permittable.NewWorkspaceRole(personal.ID(), f.roles[role.RoleOwner].ID()),
+				permittable.NewWorkspaceRole(shared.ID(), f.roles[role.RoleMaintainer].ID()),
+			}).MustBuild(),
+		permittable.New().NewID().UserID(dst.ID()).
+			WorkspaceRoles([]permittable.WorkspaceRole{
+				permittable.NewWorkspaceRole(shared.ID(), f.roles[role.RoleReader].ID()),
+				permittable.NewWorkspaceRole(other.ID(), f.roles[role.RoleWriter].ID()),
+			}).MustBuild(),
+	}))
+
+	out, err := f.uc.Execute(ctx, MergeInput{Operator: op, Source: src.ID(), Target: dst.ID()})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"google-oauth2|1"}, out.MovedAuths)
+	assert.Equal(t, []string{"reearth|1"}, out.DroppedAuths)
+	assert.ElementsMatch(t, workspace.IDList{personal.ID(), shared.ID()}, out.Workspaces)
+
+	gotDst, err := f.users.FindByID(ctx, dst.ID())
+	require.NoError(t, err)
+	assert.True(t, gotDst.Auths().Has("auth0|1"))
+	assert.True(t, gotDst.Auths().Has("google-oauth2|1"))
+	assert.False(t, gotDst.Auths().Has("reearth|1"))
+
+	gotSrc, err := f.users.FindByID(ctx, src.ID())
+	require.NoError(t, err)
+	assert.True(t, gotSrc.IsDeleted())
+	assert.Equal(t, dst.ID(), *gotSrc.MergedInto())
+	assert.Empty(t, gotSrc.Auths())
+
+	gotPersonal, err := f.ws.FindByID(ctx, personal.ID())
+	require.NoError(t, err)
+	assert.True(t, gotPersonal.IsPersonal())
+	assert.Equal(t, role.RoleOwner, gotPersonal.Members().UserRole(dst.ID()))
+	assert.False(t, gotPersonal.Members().HasUser(src.ID()))
+
+	gotShared, err := f.ws.FindByID(ctx, shared.ID())
+	require.NoError(t, err)
+	assert.Equal(t, role.RoleMaintainer, gotShared.Members().UserRole(dst.ID()))
+	assert.False(t, gotShared.Members().HasUser(src.ID()))
+
+	perms, err := f.perms.FindByUserIDs(ctx, user.IDList{src.ID(), dst.ID()})
+	require.NoError(t, err)
+	for _, p := range perms {
+		if p.UserID() == src.ID() {
+			assert.Empty(t, p.RoleIDs())
+			assert.Empty(t, p.WorkspaceRoles())
+			continue
+		}
+		assert.Equal(t, []id.RoleID{globalRole}, p.RoleIDs())
+		assert.ElementsMatch(t, []permittable.WorkspaceRole{
+			permittable.NewWorkspaceRole(personal.ID(), f.roles[role.RoleOwner].ID()),
+			permittable.NewWorkspaceRole(shared.ID(), f.roles[role.RoleMaintainer].ID()),
+			permittable.NewWorkspaceRole(other.ID(), f.roles[role.RoleWriter].ID()),
+		}, p.WorkspaceRoles())
+	}
+
+	logs, err := f.auditLog.FindByTarget(ctx, dst.ID().String())
+	require.NoError(t, err)
+	require.Len(t, logs, 1)
+	assert.Equal(t, out.AuditLog.ID(), logs[0].ID())
+	assert.Equal(t, op, logs[0].Actor())
+	assert.Equal(t, auditlog.ActionUserMerge, logs[0].Action())
+	assert.Equal(t, src.ID().String(), logs[0].Detail()["source"])
+	assert.Equal(t, now, logs[0].CreatedAt())
+}
+
+func TestMergeUsers_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("same user", func(t *testing.T) {
+		f := newMergeFixture(t)
+		uid := user.NewID()
+		_, err := f.uc.Execute(ctx, MergeInput{Source: uid, Target: uid})
+		assert.ErrorIs(t, err, ErrMergeSameUser)
+	})
+
+	t.Run("deleted user", func(t *testing.T) {
+		f := newMergeFixture(t)
+		src := user.New().NewID().Name("a").Email("a@example.com").MustBuild()
+		dst := user.New().NewID().Name("b").Email("b@example.com").MustBuild()
+		src.MarkMergedInto(user.NewID())
+		require.NoError(t, f.users.Save(ctx, src))
+		require.NoError(t, f.users.Save(ctx, dst))
+
+		_, err := f.uc.Execute(ctx, MergeInput{Source: src.ID(), Target: dst.ID()})
+		assert.ErrorIs(t, err, ErrMergeDeletedUser)
+	})
+
+	t.Run("auth conflict", func(t *testing.T) {
+		f := newMergeFixture(t)
+		src := user.New().NewID().Name("a").Email("a@example.com").
+			Auths([]user.Auth{user.AuthFrom("google-oauth2|1")}).MustBuild()
+		dst := user.New().NewID().Name("b").Email("b@example.com").
+			Auths([]user.Auth{user.AuthFrom("google-oauth2|2")}).MustBuild()
+		require.NoError(t, f.users.Save(ctx, src))
+		require.NoError(t, f.users.Save(ctx, dst))
+
+		_, err := f.uc.Execute(ctx, MergeInput{Source: src.ID(), Target: dst.ID()})
+		assert.ErrorIs(t, err, ErrMergeAuthConflict)
+
+		got, err := f.users.FindByID(ctx, src.ID())
+		require.NoError(t, err)
+		assert.False(t, got.IsDeleted())
+	})
+}
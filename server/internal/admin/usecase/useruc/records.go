@@ -0,0 +1,180 @@
+package useruc
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+)
+
+// Format is the file format of a user import or export.
+type Format string
+
+const (
+	FormatCSV  Format = "csv"
+	FormatJSON Format = "json"
+)
+
+// FormatFrom parses a format name, case-insensitively.
+func FormatFrom(s string) (Format, error) {
+	switch f := Format(strings.ToLower(strings.TrimSpace(s))); f {
+	case FormatCSV, FormatJSON:
+		return f, nil
+	}
+	return "", ErrUnsupportedFormat
+}
+
+// Record is a user in an import or export file.
+type Record struct {
+	Email string `json:"email"`
+	Name  string `json:"name"`
+	// Alias is also the alias of the user's personal workspace. It defaults
+	// to the name, like a signup.
+	Alias string `json:"alias,omitempty"`
+	Lang  string `json:"lang,omitempty"`
+	Theme string `json:"theme,omitempty"`
+	// Subs are the subs of the identity providers the user signs in with,
+	// e.g. "oidc-acme|1234". Imported users have no password.
+	Subs       []string     `json:"subs,omitempty"`
+	Workspaces []Membership `json:"workspaces,omitempty"`
+}
+
+// Membership is a team workspace of a Record, by alias, and the user's role
+// in it.
+type Membership struct {
+	Workspace string `json:"workspace"`
+	Role      string `json:"role"`
+}
+
+// csvColumns are the columns of a CSV file. In a CSV file, subs are
+// separated by ";" and workspaces are "<alias>:<role>" pairs separated by
+// ";".
+var csvColumns = []string{"email", "name", "alias", "lang", "theme", "subs", "workspaces"}
+
+const csvListSeparator = ";"
+
+// DecodeRecords reads the records of an import file. A CSV file starts with a
+// header naming its columns, of which email and name are required.
+func DecodeRecords(r io.Reader, f Format) ([]Record, error) {
+	switch f {
+	case FormatCSV:
+		return decodeCSV(r)
+	case FormatJSON:
+		var records []Record
+		if err := json.NewDecoder(r).Decode(&records); err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		return records, nil
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+func decodeCSV(r io.Reader) ([]Record, error) {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+	}
+
+	cols := make(map[string]int, len(header))
+	for i, h := range header {
+		// Spreadsheet applications prefix the file with a byte order mark.
+		h = strings.ToLower(strings.TrimSpace(strings.TrimPrefix(h, "\ufeff")))
+		if !slices.Contains(csvColumns, h) {
+			return nil, fmt.Errorf("%w: unknown column %q", ErrInvalidImportFile, h)
+		}
+		if _, ok := cols[h]; ok {
+			return nil, fmt.Errorf("%w: duplicate column %q", ErrInvalidImportFile, h)
+		}
+		cols[h] = i
+	}
+	for _, c := range []string{"email", "name"} {
+		if _, ok := cols[c]; !ok {
+			return nil, fmt.Errorf("%w: missing column %q", ErrInvalidImportFile, c)
+		}
+	}
+
+	var records []Record
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return records, nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrInvalidImportFile, err)
+		}
+		get := func(c string) string {
+			if i, ok := cols[c]; ok {
+				return strings.TrimSpace(row[i])
+			}
+			return ""
+		}
+		rec := Record{
+			Email: get("email"),
+			Name:  get("name"),
+			Alias: get("alias"),
+			Lang:  get("lang"),
+			Theme: get("theme"),
+			Subs:  splitList(get("subs")),
+		}
+		for _, m := range splitList(get("workspaces")) {
+			alias, r, _ := strings.Cut(m, ":")
+			rec.Workspaces = append(rec.Workspaces, Membership{
+				Workspace: strings.TrimSpace(alias),
+				Role:      strings.TrimSpace(r),
+			})
+		}
+		records = append(records, rec)
+	}
+}
+
+func splitList(s string) []string {
+	var res []string
+	for _, v := range strings.Split(s, csvListSeparator) {
+		if v = strings.TrimSpace(v); v != "" {
+			res = append(res, v)
+		}
+	}
+	return res
+}
+
+// EncodeRecords writes records in the format DecodeRecords reads.
+func EncodeRecords(w io.Writer, f Format, records []Record) error {
+	switch f {
+	case FormatCSV:
+		cw := csv.NewWriter(w)
+		if err := cw.Write(csvColumns); err != nil {
+			return err
+		}
+		for _, r := range records {
+			ms := make([]string, 0, len(r.Workspaces))
+			for _, m := range r.Workspaces {
+				ms = append(ms, m.Workspace+":"+m.Role)
+			}
+			if err := cw.Write([]string{
+				r.Email, r.Name, r.Alias, r.Lang, r.Theme,
+				strings.Join(r.Subs, csvListSeparator),
+				strings.Join(ms, csvListSeparator),
+			}); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+	case FormatJSON:
+		if records == nil {
+			records = []Record{}
+		}
+		enc := json.NewEncoder(w)
+		enc.SetIndent("", "  ")
+		return enc.Encode(records)
+	}
+	return ErrUnsupportedFormat
+}
@@ -0,0 +1,51 @@
+package useruc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// RemoveUserAuthUseCase unlinks a sign-in method from a user, e.g. an identity
+// provider account the user lost access to.
+type RemoveUserAuthUseCase struct {
+	action userAction
+}
+
+// NewRemoveUserAuthUseCase is a Wire provider for RemoveUserAuthUseCase.
+func NewRemoveUserAuthUseCase(userRepo user.Repo, auditLogRepo auditlog.Repo, transaction usecasex.Transaction) *RemoveUserAuthUseCase {
+	return &RemoveUserAuthUseCase{action: userAction{userRepo: userRepo, auditLogRepo: auditLogRepo, transaction: transaction}}
+}
+
+// RemoveUserAuthInput is the input for RemoveUserAuthUseCase.Execute.
+type RemoveUserAuthInput struct {
+	UserActionInput
+	Sub string
+}
+
+// Execute removes the auth with the sub and records it in the audit log. The
+// user must keep a way to sign in, so the last auth can't be removed.
+func (uc *RemoveUserAuthUseCase) Execute(ctx context.Context, in RemoveUserAuthInput) (*UserActionOutput, error) {
+	out, err := uc.action.run(ctx, in.UserActionInput, auditlog.ActionUserRemoveAuth, func(_ context.Context, u *user.User) (map[string]string, error) {
+		a := u.Auths().Get(in.Sub)
+		if a == nil {
+			return nil, ErrAuthNotFound
+		}
+		if len(u.Auths()) == 1 {
+			return nil, user.ErrLastAuth
+		}
+		if !u.RemoveAuth(*a) {
+			return nil, ErrAuthNotRemovable
+		}
+		return map[string]string{"provider": a.Provider, "sub": a.Sub}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] auth %s of user %s removed by %s", in.Sub, in.User, in.Operator)
+	return out, nil
+}
@@ -0,0 +1,43 @@
+package useruc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveUserAuth(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().NewID().Name("alice").Email("alice@example.com").Auths([]user.Auth{
+		user.AuthFrom("auth0|alice"),
+		user.AuthFrom("google-oauth2|alice"),
+		user.NewReearthAuth("alice"),
+	}).MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	uc := NewRemoveUserAuthUseCase(r.User, r.AuditLog, r.Transaction)
+	in := UserActionInput{Operator: adminuser.NewID(), User: u.ID()}
+
+	out, err := uc.Execute(ctx, RemoveUserAuthInput{UserActionInput: in, Sub: "google-oauth2|alice"})
+	require.NoError(t, err)
+	assert.False(t, out.User.Auths().Has("google-oauth2|alice"))
+	assert.Equal(t, auditlog.ActionUserRemoveAuth, out.AuditLog.Action())
+	assert.Equal(t, map[string]string{"provider": "google-oauth2", "sub": "google-oauth2|alice"}, out.AuditLog.Detail())
+
+	_, err = uc.Execute(ctx, RemoveUserAuthInput{UserActionInput: in, Sub: "google-oauth2|alice"})
+	assert.ErrorIs(t, err, ErrAuthNotFound)
+	_, err = uc.Execute(ctx, RemoveUserAuthInput{UserActionInput: in, Sub: "auth0|alice"})
+	assert.ErrorIs(t, err, ErrAuthNotRemovable)
+
+	out, err = uc.Execute(ctx, RemoveUserAuthInput{UserActionInput: in, Sub: "reearth|alice"})
+	require.NoError(t, err)
+	assert.Len(t, out.User.Auths(), 1)
+	_, err = uc.Execute(ctx, RemoveUserAuthInput{UserActionInput: in, Sub: "auth0|alice"})
+	assert.ErrorIs(t, err, user.ErrLastAuth)
+}
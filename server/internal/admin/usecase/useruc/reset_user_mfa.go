@@ -0,0 +1,45 @@
+package useruc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// ResetUserMFAUseCase removes the second factor of a user who lost both their
+// authenticator and recovery code, so that they can sign in with their
+// password and enroll again. It covers the second factor of the built-in
+// provider; the one of Auth0 is reset in Auth0.
+type ResetUserMFAUseCase struct {
+	action userAction
+}
+
+// NewResetUserMFAUseCase is a Wire provider for ResetUserMFAUseCase.
+func NewResetUserMFAUseCase(userRepo user.Repo, auditLogRepo auditlog.Repo, transaction usecasex.Transaction) *ResetUserMFAUseCase {
+	return &ResetUserMFAUseCase{action: userAction{userRepo: userRepo, auditLogRepo: auditLogRepo, transaction: transaction}}
+}
+
+// Execute removes the second factor, including a pending enrollment, and
+// records it in the audit log.
+func (uc *ResetUserMFAUseCase) Execute(ctx context.Context, in UserActionInput) (*UserActionOutput, error) {
+	out, err := uc.action.run(ctx, in, auditlog.ActionUserResetMFA, func(_ context.Context, u *user.User) (map[string]string, error) {
+		if u.MFA() == nil {
+			return nil, ErrMFANotEnabled
+		}
+		enabled := u.MFA().IsEnabled()
+		u.SetMFA(nil)
+		if !enabled {
+			return map[string]string{"pending": "true"}, nil
+		}
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] MFA of user %s reset by %s", in.User, in.Operator)
+	return out, nil
+}
@@ -0,0 +1,43 @@
+package useruc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetUserMFA(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	enrolled := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	enrolled.SetMFA(user.MFAFrom("secret", true, nil, 0, 0, nil))
+	pending := user.New().NewID().Name("bob").Email("bob@example.com").MustBuild()
+	pending.SetMFA(user.MFAFrom("secret", false, nil, 0, 0, nil))
+	none := user.New().NewID().Name("carol").Email("carol@example.com").MustBuild()
+	for _, u := range []*user.User{enrolled, pending, none} {
+		require.NoError(t, r.User.Save(ctx, u))
+	}
+	uc := NewResetUserMFAUseCase(r.User, r.AuditLog, r.Transaction)
+	op := adminuser.NewID()
+
+	out, err := uc.Execute(ctx, UserActionInput{Operator: op, User: enrolled.ID()})
+	require.NoError(t, err)
+	assert.Nil(t, out.User.MFA())
+	assert.Equal(t, auditlog.ActionUserResetMFA, out.AuditLog.Action())
+	got, err := r.User.FindByID(ctx, enrolled.ID())
+	require.NoError(t, err)
+	assert.Nil(t, got.MFA())
+
+	out, err = uc.Execute(ctx, UserActionInput{Operator: op, User: pending.ID()})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pending": "true"}, out.AuditLog.Detail())
+
+	_, err = uc.Execute(ctx, UserActionInput{Operator: op, User: none.ID()})
+	assert.ErrorIs(t, err, ErrMFANotEnabled)
+}
@@ -0,0 +1,75 @@
+package useruc
+
+import (
+	"context"
+	"fmt"
+	"html"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// HostWeb is the base URL of the sign-in UI of the main service, which the
+// password reset link points at.
+type HostWeb string
+
+// ResetUserPasswordUseCase starts a password reset on behalf of a user: the
+// user is mailed the same link the "forgot password" flow sends, and the
+// current password keeps working until they follow it.
+type ResetUserPasswordUseCase struct {
+	action  userAction
+	mailer  mailer.Mailer
+	hostWeb HostWeb
+}
+
+// NewResetUserPasswordUseCase is a Wire provider for ResetUserPasswordUseCase.
+func NewResetUserPasswordUseCase(
+	userRepo user.Repo,
+	auditLogRepo auditlog.Repo,
+	transaction usecasex.Transaction,
+	m mailer.Mailer,
+	hostWeb HostWeb,
+) *ResetUserPasswordUseCase {
+	return &ResetUserPasswordUseCase{
+		action:  userAction{userRepo: userRepo, auditLogRepo: auditLogRepo, transaction: transaction},
+		mailer:  m,
+		hostWeb: hostWeb,
+	}
+}
+
+// Execute issues a password reset token, mails the reset link to the user and
+// records it in the audit log. Only users who sign in with a password can
+// reset it.
+func (uc *ResetUserPasswordUseCase) Execute(ctx context.Context, in UserActionInput) (*UserActionOutput, error) {
+	var link string
+	out, err := uc.action.run(ctx, in, auditlog.ActionUserResetPassword, func(_ context.Context, u *user.User) (map[string]string, error) {
+		if u.IsDeleted() {
+			return nil, ErrUserDeactivated
+		}
+		if a := u.Auths().GetByProvider(user.ProviderReearth); a == nil || a.Sub == "" {
+			return nil, ErrNoPasswordAuth
+		}
+		pr := user.NewPasswordReset()
+		u.SetPasswordReset(pr)
+		link = string(uc.hostWeb) + "/?pwd-reset-token=" + pr.Token
+		return map[string]string{"email": u.Email()}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	u := out.User
+	text := fmt.Sprintf("Hi %s,\n\nAn administrator of Re:Earth has started a password reset for your account. Please open the link below to choose a new password:\n\n%s\n\nUntil then, your current password keeps working.\n", u.Name(), link)
+	htmlContent := fmt.Sprintf(`<p>Hi %s,</p><p>An administrator of Re:Earth has started a password reset for your account. Please open the link below to choose a new password:</p><p><a href="%s">Reset your password</a></p><p>Until then, your current password keeps working.</p>`,
+		html.EscapeString(u.Name()), html.EscapeString(link))
+	if err := uc.mailer.SendMail(ctx, []mailer.Contact{{Email: u.Email(), Name: u.Name()}},
+		"Password reset", text, htmlContent); err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] password reset of user %s started by %s", in.User, in.Operator)
+	return out, nil
+}
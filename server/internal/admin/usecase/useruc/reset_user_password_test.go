@@ -0,0 +1,57 @@
+package useruc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResetUserPassword(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().NewID().Name("alice").Email("alice@example.com").
+		Auths([]user.Auth{user.NewReearthAuth("alice")}).MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	m := mailer.NewMock()
+	uc := NewResetUserPasswordUseCase(r.User, r.AuditLog, r.Transaction, m, "https://reearth.example.com")
+
+	out, err := uc.Execute(ctx, UserActionInput{Operator: adminuser.NewID(), User: u.ID()})
+	require.NoError(t, err)
+	assert.Equal(t, auditlog.ActionUserResetPassword, out.AuditLog.Action())
+
+	got, err := r.User.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	require.NotNil(t, got.PasswordReset())
+	mails := m.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, []mailer.Contact{{Email: "alice@example.com", Name: "alice"}}, mails[0].To)
+	assert.Contains(t, mails[0].PlainContent, "https://reearth.example.com/?pwd-reset-token="+got.PasswordReset().Token)
+}
+
+func TestResetUserPassword_Errors(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	noPassword := user.New().NewID().Name("bob").Email("bob@example.com").
+		Auths([]user.Auth{user.AuthFrom("google-oauth2|bob")}).MustBuild()
+	deactivated := user.New().NewID().Name("carol").Email("carol@example.com").
+		Auths([]user.Auth{user.NewReearthAuth("carol")}).MustBuild()
+	deactivated.Deactivate()
+	require.NoError(t, r.User.Save(ctx, noPassword))
+	require.NoError(t, r.User.Save(ctx, deactivated))
+	m := mailer.NewMock()
+	uc := NewResetUserPasswordUseCase(r.User, r.AuditLog, r.Transaction, m, "https://reearth.example.com")
+	op := adminuser.NewID()
+
+	_, err := uc.Execute(ctx, UserActionInput{Operator: op, User: noPassword.ID()})
+	assert.ErrorIs(t, err, ErrNoPasswordAuth)
+	_, err = uc.Execute(ctx, UserActionInput{Operator: op, User: deactivated.ID()})
+	assert.ErrorIs(t, err, ErrUserDeactivated)
+	assert.Empty(t, m.Mails())
+}
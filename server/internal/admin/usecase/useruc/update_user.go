@@ -0,0 +1,138 @@
+package useruc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// UpdateUserUseCase edits the profile of a user on their behalf.
+type UpdateUserUseCase struct {
+	action        userAction
+	workspaceRepo workspace.Repo
+}
+
+// NewUpdateUserUseCase is a Wire provider for UpdateUserUseCase.
+func NewUpdateUserUseCase(
+	userRepo user.Repo,
+	workspaceRepo workspace.Repo,
+	auditLogRepo auditlog.Repo,
+	transaction usecasex.Transaction,
+) *UpdateUserUseCase {
+	return &UpdateUserUseCase{
+		action:        userAction{userRepo: userRepo, auditLogRepo: auditLogRepo, transaction: transaction},
+		workspaceRepo: workspaceRepo,
+	}
+}
+
+// UpdateUserInput is the input for UpdateUserUseCase.Execute. Nil fields are
+// left as they are.
+type UpdateUserInput struct {
+	UserActionInput
+	Name  *string
+	Alias *string
+	Email *string
+}
+
+// Execute applies the changed fields and records their old and new values in
+// the audit log. Like updateMe, the alias and, unless it was renamed, the name
+// of the personal workspace follow the user. The email is not pushed to
+// external identity providers.
+func (uc *UpdateUserUseCase) Execute(ctx context.Context, in UpdateUserInput) (*UserActionOutput, error) {
+	if in.Name == nil && in.Alias == nil && in.Email == nil {
+		return nil, ErrNothingToUpdate
+	}
+	if in.Name != nil && strings.TrimSpace(*in.Name) == "" {
+		return nil, ErrEmptyName
+	}
+
+	var changed []string
+	out, err := uc.action.run(ctx, in.UserActionInput, auditlog.ActionUserUpdate, func(ctx context.Context, u *user.User) (map[string]string, error) {
+		detail := map[string]string{}
+		set := func(field, from, to string) bool {
+			if from == to {
+				return false
+			}
+			detail["old"+field] = from
+			detail["new"+field] = to
+			changed = append(changed, strings.ToLower(field))
+			return true
+		}
+
+		oldName := u.Name()
+		if in.Email != nil && set("Email", u.Email(), *in.Email) {
+			if err := uc.checkUnique(ctx, u, uc.action.userRepo.FindByEmail, *in.Email, ErrEmailTaken); err != nil {
+				return nil, err
+			}
+			if err := u.UpdateEmail(*in.Email); err != nil {
+				return nil, err
+			}
+		}
+		aliasChanged := in.Alias != nil && set("Alias", u.Alias(), *in.Alias)
+		if aliasChanged {
+			if err := uc.checkUnique(ctx, u, uc.action.userRepo.FindByAlias, *in.Alias, ErrAliasTaken); err != nil {
+				return nil, err
+			}
+			u.UpdateAlias(*in.Alias)
+		}
+		nameChanged := in.Name != nil && set("Name", u.Name(), *in.Name)
+		if nameChanged {
+			u.UpdateName(*in.Name)
+		}
+		if len(changed) == 0 {
+			return nil, ErrNothingToUpdate
+		}
+
+		if aliasChanged || nameChanged {
+			if err := uc.syncWorkspace(ctx, u, oldName, aliasChanged, nameChanged); err != nil {
+				return nil, err
+			}
+		}
+		return detail, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] user %s updated by %s: fields=%v", in.User, in.Operator, changed)
+	return out, nil
+}
+
+// checkUnique fails with taken when find returns a user other than u.
+func (uc *UpdateUserUseCase) checkUnique(ctx context.Context, u *user.User, find func(context.Context, string) (*user.User, error), value string, taken error) error {
+	other, err := find(ctx, value)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return err
+	}
+	if other != nil && other.ID() != u.ID() {
+		return taken
+	}
+	return nil
+}
+
+func (uc *UpdateUserUseCase) syncWorkspace(ctx context.Context, u *user.User, oldName string, aliasChanged, nameChanged bool) error {
+	ws, err := uc.workspaceRepo.FindByID(ctx, u.Workspace())
+	if errors.Is(err, rerror.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !ws.IsPersonal() {
+		return nil
+	}
+	if aliasChanged {
+		ws.UpdateAlias(u.Alias())
+	}
+	if nameChanged && (ws.Name() == "" || ws.Name() == oldName) {
+		ws.Rename(u.Name())
+	}
+	return uc.workspaceRepo.Save(ctx, ws)
+}
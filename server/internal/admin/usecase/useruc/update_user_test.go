@@ -0,0 +1,87 @@
+package useruc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateUser(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	op := adminuser.NewID()
+	uid := user.NewID()
+	ws := workspace.New().NewID().Name("alice").Alias("alice").Personal(true).Members(map[workspace.UserID]workspace.Member{
+		uid: {Role: role.RoleOwner},
+	}).MustBuild()
+	u := user.New().ID(uid).Name("alice").Alias("alice").Email("alice@example.com").Workspace(ws.ID()).MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	require.NoError(t, r.Workspace.Save(ctx, ws))
+	uc := NewUpdateUserUseCase(r.User, r.Workspace, r.AuditLog, r.Transaction)
+
+	out, err := uc.Execute(ctx, UpdateUserInput{
+		UserActionInput: UserActionInput{Operator: op, User: uid},
+		Name:            lo.ToPtr("Alice Liddell"),
+		Alias:           lo.ToPtr("liddell"),
+		// unchanged fields are not recorded
+		Email: lo.ToPtr("alice@example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Liddell", out.User.Name())
+	assert.Equal(t, "liddell", out.User.Alias())
+	assert.Equal(t, auditlog.ActionUserUpdate, out.AuditLog.Action())
+	assert.Equal(t, map[string]string{
+		"oldName":  "alice",
+		"newName":  "Alice Liddell",
+		"oldAlias": "alice",
+		"newAlias": "liddell",
+	}, out.AuditLog.Detail())
+
+	got, err := r.Workspace.FindByID(ctx, ws.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "Alice Liddell", got.Name())
+	assert.Equal(t, "liddell", got.Alias())
+}
+
+func TestUpdateUser_Errors(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().NewID().Name("alice").Alias("alice").Email("alice@example.com").MustBuild()
+	other := user.New().NewID().Name("bob").Alias("bob").Email("bob@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	require.NoError(t, r.User.Save(ctx, other))
+	uc := NewUpdateUserUseCase(r.User, r.Workspace, r.AuditLog, r.Transaction)
+	in := UserActionInput{Operator: adminuser.NewID(), User: u.ID()}
+
+	tests := []struct {
+		name    string
+		input   UpdateUserInput
+		wantErr error
+	}{
+		{name: "nothing", input: UpdateUserInput{UserActionInput: in}, wantErr: ErrNothingToUpdate},
+		{name: "unchanged", input: UpdateUserInput{UserActionInput: in, Name: lo.ToPtr("alice")}, wantErr: ErrNothingToUpdate},
+		{name: "empty name", input: UpdateUserInput{UserActionInput: in, Name: lo.ToPtr(" ")}, wantErr: ErrEmptyName},
+		{name: "alias taken", input: UpdateUserInput{UserActionInput: in, Alias: lo.ToPtr("bob")}, wantErr: ErrAliasTaken},
+		{name: "email taken", input: UpdateUserInput{UserActionInput: in, Email: lo.ToPtr("bob@example.com")}, wantErr: ErrEmailTaken},
+		{name: "invalid email", input: UpdateUserInput{UserActionInput: in, Email: lo.ToPtr("alice")}, wantErr: user.ErrInvalidEmail},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(ctx, tt.input)
+			assert.ErrorIs(t, err, tt.wantErr)
+		})
+	}
+
+	entries, err := r.AuditLog.FindByTarget(ctx, u.ID().String())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
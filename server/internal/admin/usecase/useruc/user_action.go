@@ -0,0 +1,83 @@
+package useruc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/reearth/reearthx/util"
+)
+
+// UserActionInput is the input of the use cases that act on a single user.
+type UserActionInput struct {
+	Operator adminuser.ID
+	User     user.ID
+}
+
+// UserActionOutput is the user after an action and its audit log entry.
+type UserActionOutput struct {
+	User     *user.User
+	AuditLog *auditlog.Entry
+}
+
+// userAction changes a user and records the change in the audit log.
+type userAction struct {
+	userRepo     user.Repo
+	auditLogRepo auditlog.Repo
+	transaction  usecasex.Transaction
+}
+
+// run loads the user, lets apply change it and saves it together with an audit
+// log entry of the action, in one transaction. apply returns the detail of the
+// entry.
+func (a userAction) run(
+	ctx context.Context,
+	in UserActionInput,
+	action auditlog.Action,
+	apply func(ctx context.Context, u *user.User) (map[string]string, error),
+) (*UserActionOutput, error) {
+	var out *UserActionOutput
+	err := usecasex.DoTransaction(ctx, a.transaction, 0, func(ctx context.Context) error {
+		u, err := a.userRepo.FindByID(ctx, in.User)
+		if err != nil {
+			return err
+		}
+		detail, err := apply(ctx, u)
+		if err != nil {
+			return err
+		}
+		if err := a.userRepo.Save(ctx, u); err != nil {
+			return err
+		}
+		entry, err := a.record(ctx, in, action, detail)
+		if err != nil {
+			return err
+		}
+		out = &UserActionOutput{User: u, AuditLog: entry}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (a userAction) record(ctx context.Context, in UserActionInput, action auditlog.Action, detail map[string]string) (*auditlog.Entry, error) {
+	entry, err := auditlog.New().
+		NewID().
+		Actor(in.Operator).
+		Action(action).
+		Target(in.User.String()).
+		Detail(detail).
+		CreatedAt(util.Now()).
+		Build()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.auditLogRepo.Save(ctx, entry); err != nil {
+		return nil, err
+	}
+	return entry, nil
+}
@@ -0,0 +1,43 @@
+package useruc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// VerifyUserEmailUseCase marks the email of a user as verified, for users who
+// can't receive or follow the verification mail.
+type VerifyUserEmailUseCase struct {
+	action userAction
+}
+
+// NewVerifyUserEmailUseCase is a Wire provider for VerifyUserEmailUseCase.
+func NewVerifyUserEmailUseCase(userRepo user.Repo, auditLogRepo auditlog.Repo, transaction usecasex.Transaction) *VerifyUserEmailUseCase {
+	return &VerifyUserEmailUseCase{action: userAction{userRepo: userRepo, auditLogRepo: auditLogRepo, transaction: transaction}}
+}
+
+// Execute verifies the email of the user and records it in the audit log.
+func (uc *VerifyUserEmailUseCase) Execute(ctx context.Context, in UserActionInput) (*UserActionOutput, error) {
+	out, err := uc.action.run(ctx, in, auditlog.ActionUserVerifyEmail, func(_ context.Context, u *user.User) (map[string]string, error) {
+		if u.Verification().IsVerified() {
+			return nil, ErrEmailAlreadyVerified
+		}
+		v := u.Verification()
+		if v == nil {
+			v = user.NewVerification()
+			u.SetVerification(v)
+		}
+		v.SetVerified(true)
+		return map[string]string{"email": u.Email()}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] email of user %s verified by %s", in.User, in.Operator)
+	return out, nil
+}
@@ -0,0 +1,39 @@
+package useruc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVerifyUserEmail(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	pending := user.New().NewID().Name("alice").Email("alice@example.com").Verification(user.NewVerification()).MustBuild()
+	// users created before verifications were recorded have none
+	none := user.New().NewID().Name("bob").Email("bob@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, pending))
+	require.NoError(t, r.User.Save(ctx, none))
+	uc := NewVerifyUserEmailUseCase(r.User, r.AuditLog, r.Transaction)
+	op := adminuser.NewID()
+
+	for _, u := range []*user.User{pending, none} {
+		out, err := uc.Execute(ctx, UserActionInput{Operator: op, User: u.ID()})
+		require.NoError(t, err)
+		assert.Equal(t, auditlog.ActionUserVerifyEmail, out.AuditLog.Action())
+		assert.Equal(t, map[string]string{"email": u.Email()}, out.AuditLog.Detail())
+
+		got, err := r.User.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.True(t, got.Verification().IsVerified())
+	}
+
+	_, err := uc.Execute(ctx, UserActionInput{Operator: op, User: pending.ID()})
+	assert.ErrorIs(t, err, ErrEmailAlreadyVerified)
+}
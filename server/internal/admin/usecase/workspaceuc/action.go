@@ -0,0 +1,83 @@
+package workspaceuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/usecasex"
+	"github.com/reearth/reearthx/util"
+)
+
+// WorkspaceActionInput is the input of the use cases that act on a single
+// workspace.
+type WorkspaceActionInput struct {
+	Operator  adminuser.ID
+	Workspace workspace.ID
+}
+
+// WorkspaceActionOutput is the workspace after an action and its audit log
+// entry.
+type WorkspaceActionOutput struct {
+	Workspace *workspace.Workspace
+	AuditLog  *auditlog.Entry
+}
+
+// workspaceAction changes a workspace and records the change in the audit
+// log.
+type workspaceAction struct {
+	workspaceRepo workspace.Repo
+	auditLogRepo  auditlog.Repo
+	transaction   usecasex.Transaction
+}
+
+// run loads the workspace, lets apply change it and saves it together with an
+// audit log entry of the action, in one transaction. apply returns the detail
+// of the entry. Personal workspaces follow their user and are not changed
+// here.
+func (a workspaceAction) run(
+	ctx context.Context,
+	in WorkspaceActionInput,
+	action auditlog.Action,
+	apply func(ctx context.Context, ws *workspace.Workspace) (map[string]string, error),
+) (*WorkspaceActionOutput, error) {
+	var out *WorkspaceActionOutput
+	err := usecasex.DoTransaction(ctx, a.transaction, 0, func(ctx context.Context) error {
+		ws, err := a.workspaceRepo.FindByID(ctx, in.Workspace)
+		if err != nil {
+			return err
+		}
+		if ws.IsPersonal() {
+			return workspace.ErrCannotModifyPersonalWorkspace
+		}
+		detail, err := apply(ctx, ws)
+		if err != nil {
+			return err
+		}
+		if err := a.workspaceRepo.Save(ctx, ws); err != nil {
+			return err
+		}
+
+		entry, err := auditlog.New().
+			NewID().
+			Actor(in.Operator).
+			Action(action).
+			Target(in.Workspace.String()).
+			Detail(detail).
+			CreatedAt(util.Now()).
+			Build()
+		if err != nil {
+			return err
+		}
+		if err := a.auditLogRepo.Save(ctx, entry); err != nil {
+			return err
+		}
+		out = &WorkspaceActionOutput{Workspace: ws, AuditLog: entry}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
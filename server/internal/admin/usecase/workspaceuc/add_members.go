@@ -0,0 +1,93 @@
+package workspaceuc
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// AddWorkspaceMembersUseCase adds users to a workspace, e.g. a new owner of a
+// workspace whose owner left.
+type AddWorkspaceMembersUseCase struct {
+	action   workspaceAction
+	userRepo user.Repo
+	roles    memberRoles
+}
+
+// NewAddWorkspaceMembersUseCase is a Wire provider for AddWorkspaceMembersUseCase.
+func NewAddWorkspaceMembersUseCase(
+	workspaceRepo workspace.Repo,
+	userRepo user.Repo,
+	roleRepo role.Repo,
+	permittableRepo permittable.Repo,
+	auditLogRepo auditlog.Repo,
+	transaction usecasex.Transaction,
+) *AddWorkspaceMembersUseCase {
+	return &AddWorkspaceMembersUseCase{
+		action:   workspaceAction{workspaceRepo: workspaceRepo, auditLogRepo: auditLogRepo, transaction: transaction},
+		userRepo: userRepo,
+		roles:    memberRoles{roleRepo: roleRepo, permittableRepo: permittableRepo},
+	}
+}
+
+// AddWorkspaceMembersInput is the input for AddWorkspaceMembersUseCase.Execute.
+type AddWorkspaceMembersInput struct {
+	WorkspaceActionInput
+	Members map[user.ID]role.RoleType
+}
+
+// Execute adds the users with their roles, binds their permittables to the
+// workspace and records it in the audit log. Any role, including owner, can
+// be given. The members are recorded as invited by themselves, as there is no
+// inviting user.
+func (uc *AddWorkspaceMembersUseCase) Execute(ctx context.Context, in AddWorkspaceMembersInput) (*WorkspaceActionOutput, error) {
+	if len(in.Members) == 0 {
+		return nil, ErrNoMembers
+	}
+	uids := make(user.IDList, 0, len(in.Members))
+	for uid, r := range in.Members {
+		if !r.Valid() || r == role.RoleSelf {
+			return nil, role.ErrInvalidRole
+		}
+		uids = append(uids, uid)
+	}
+
+	out, err := uc.action.run(ctx, in.WorkspaceActionInput, auditlog.ActionWorkspaceAddMembers, func(ctx context.Context, ws *workspace.Workspace) (map[string]string, error) {
+		users, err := uc.userRepo.FindByIDs(ctx, uids)
+		if err != nil {
+			return nil, err
+		}
+		if len(users) != len(uids) {
+			return nil, rerror.ErrNotFound
+		}
+
+		added := make([]string, 0, len(users))
+		for _, u := range users {
+			r := in.Members[u.ID()]
+			if err := ws.Members().Join(u, r, u.ID()); err != nil {
+				return nil, err
+			}
+			added = append(added, u.ID().String()+":"+r.String())
+		}
+		if err := uc.roles.set(ctx, ws.ID(), in.Members); err != nil {
+			return nil, err
+		}
+		sort.Strings(added)
+		return map[string]string{"members": strings.Join(added, ",")}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] %d members added to workspace %s by %s", len(in.Members), in.Workspace, in.Operator)
+	return out, nil
+}
@@ -0,0 +1,112 @@
+package workspaceuc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// seedRoles saves the member roles and returns them by name.
+func seedRoles(t *testing.T, r *repo.Container) map[role.RoleType]*role.Role {
+	t.Helper()
+	roles := map[role.RoleType]*role.Role{}
+	for _, rt := range []role.RoleType{role.RoleOwner, role.RoleMaintainer, role.RoleWriter, role.RoleReader} {
+		rl := role.New().NewID().Name(rt.String()).MustBuild()
+		require.NoError(t, r.Role.Save(context.Background(), *rl))
+		roles[rt] = rl
+	}
+	return roles
+}
+
+// teamWs builds a workspace with the members.
+func teamWs(members map[user.ID]role.RoleType) *workspace.Workspace {
+	m := make(map[workspace.UserID]workspace.Member, len(members))
+	for uid, rt := range members {
+		m[uid] = workspace.Member{Role: rt, InvitedBy: uid}
+	}
+	return workspace.New().NewID().Name("Team").Alias("team").Members(m).MustBuild()
+}
+
+// workspaceRole returns the role the permittable of the user has in the
+// workspace, or nil.
+func workspaceRole(t *testing.T, r *repo.Container, uid user.ID, wid workspace.ID) *role.ID {
+	t.Helper()
+	p, err := r.Permittable.FindByUserID(context.Background(), uid)
+	if err != nil {
+		return nil
+	}
+	for _, wr := range p.WorkspaceRoles() {
+		if wr.ID() == wid {
+			rid := wr.RoleID()
+			return &rid
+		}
+	}
+	return nil
+}
+
+func TestAddWorkspaceMembers(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	roles := seedRoles(t, r)
+	owner := user.New().NewID().Name("owner").Email("owner@example.com").MustBuild()
+	alice := user.New().NewID().Name("alice").Email("alice@example.com").MustBuild()
+	bob := user.New().NewID().Name("bob").Email("bob@example.com").MustBuild()
+	for _, u := range []*user.User{owner, alice, bob} {
+		require.NoError(t, r.User.Save(ctx, u))
+	}
+	w := teamWs(map[user.ID]role.RoleType{owner.ID(): role.RoleOwner})
+	require.NoError(t, r.Workspace.Save(ctx, w))
+	uc := NewAddWorkspaceMembersUseCase(r.Workspace, r.User, r.Role, r.Permittable, r.AuditLog, r.Transaction)
+
+	out, err := uc.Execute(ctx, AddWorkspaceMembersInput{
+		WorkspaceActionInput: WorkspaceActionInput{Operator: adminuser.NewID(), Workspace: w.ID()},
+		Members:              map[user.ID]role.RoleType{alice.ID(): role.RoleOwner, bob.ID(): role.RoleWriter},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, role.RoleOwner, out.Workspace.Members().UserRole(alice.ID()))
+	assert.Equal(t, role.RoleWriter, out.Workspace.Members().UserRole(bob.ID()))
+	assert.Equal(t, auditlog.ActionWorkspaceAddMembers, out.AuditLog.Action())
+	assert.Contains(t, out.AuditLog.Detail()["members"], alice.ID().String()+":owner")
+
+	assert.Equal(t, roles[role.RoleOwner].ID(), *workspaceRole(t, r, alice.ID(), w.ID()))
+	assert.Equal(t, roles[role.RoleWriter].ID(), *workspaceRole(t, r, bob.ID(), w.ID()))
+}
+
+func TestAddWorkspaceMembers_Errors(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	seedRoles(t, r)
+	owner := user.New().NewID().Name("owner").Email("owner@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, owner))
+	w := teamWs(map[user.ID]role.RoleType{owner.ID(): role.RoleOwner})
+	require.NoError(t, r.Workspace.Save(ctx, w))
+	uc := NewAddWorkspaceMembersUseCase(r.Workspace, r.User, r.Role, r.Permittable, r.AuditLog, r.Transaction)
+	in := WorkspaceActionInput{Operator: adminuser.NewID(), Workspace: w.ID()}
+
+	tests := []struct {
+		name    string
+		members map[user.ID]role.RoleType
+		want    error
+	}{
+		{name: "empty", want: ErrNoMembers},
+		{name: "invalid role", members: map[user.ID]role.RoleType{owner.ID(): role.RoleSelf}, want: role.ErrInvalidRole},
+		{name: "already joined", members: map[user.ID]role.RoleType{owner.ID(): role.RoleReader}, want: workspace.ErrUserAlreadyJoined},
+		{name: "unknown user", members: map[user.ID]role.RoleType{user.NewID(): role.RoleReader}, want: rerror.ErrNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(ctx, AddWorkspaceMembersInput{WorkspaceActionInput: in, Members: tt.members})
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
@@ -0,0 +1,66 @@
+package workspaceuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// DeactivateWorkspaceUseCase soft-deletes a workspace. Its members and data
+// are kept, and it is purged once the retention window of the main service
+// has passed unless it is restored first.
+type DeactivateWorkspaceUseCase struct {
+	action workspaceAction
+}
+
+// NewDeactivateWorkspaceUseCase is a Wire provider for DeactivateWorkspaceUseCase.
+func NewDeactivateWorkspaceUseCase(workspaceRepo workspace.Repo, auditLogRepo auditlog.Repo, transaction usecasex.Transaction) *DeactivateWorkspaceUseCase {
+	return &DeactivateWorkspaceUseCase{action: workspaceAction{workspaceRepo: workspaceRepo, auditLogRepo: auditLogRepo, transaction: transaction}}
+}
+
+// Execute deactivates the workspace and records it in the audit log.
+func (uc *DeactivateWorkspaceUseCase) Execute(ctx context.Context, in WorkspaceActionInput) (*WorkspaceActionOutput, error) {
+	out, err := uc.action.run(ctx, in, auditlog.ActionWorkspaceDeactivate, func(_ context.Context, ws *workspace.Workspace) (map[string]string, error) {
+		if ws.IsDeleted() {
+			return nil, ErrWorkspaceDeactivated
+		}
+		ws.Delete()
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] workspace %s deactivated by %s", in.Workspace, in.Operator)
+	return out, nil
+}
+
+// RestoreWorkspaceUseCase reactivates a deactivated workspace.
+type RestoreWorkspaceUseCase struct {
+	action workspaceAction
+}
+
+// NewRestoreWorkspaceUseCase is a Wire provider for RestoreWorkspaceUseCase.
+func NewRestoreWorkspaceUseCase(workspaceRepo workspace.Repo, auditLogRepo auditlog.Repo, transaction usecasex.Transaction) *RestoreWorkspaceUseCase {
+	return &RestoreWorkspaceUseCase{action: workspaceAction{workspaceRepo: workspaceRepo, auditLogRepo: auditLogRepo, transaction: transaction}}
+}
+
+// Execute reactivates the workspace and records it in the audit log.
+func (uc *RestoreWorkspaceUseCase) Execute(ctx context.Context, in WorkspaceActionInput) (*WorkspaceActionOutput, error) {
+	out, err := uc.action.run(ctx, in, auditlog.ActionWorkspaceRestore, func(_ context.Context, ws *workspace.Workspace) (map[string]string, error) {
+		if !ws.IsDeleted() {
+			return nil, ErrWorkspaceNotDeactivated
+		}
+		ws.Restore()
+		return nil, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] workspace %s restored by %s", in.Workspace, in.Operator)
+	return out, nil
+}
@@ -0,0 +1,41 @@
+package workspaceuc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDeactivateAndRestoreWorkspace(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	op := adminuser.NewID()
+	w := ws("Alpha", "alpha")
+	require.NoError(t, r.Workspace.Save(ctx, w))
+	in := WorkspaceActionInput{Operator: op, Workspace: w.ID()}
+
+	_, err := NewRestoreWorkspaceUseCase(r.Workspace, r.AuditLog, r.Transaction).Execute(ctx, in)
+	assert.ErrorIs(t, err, ErrWorkspaceNotDeactivated)
+
+	out, err := NewDeactivateWorkspaceUseCase(r.Workspace, r.AuditLog, r.Transaction).Execute(ctx, in)
+	require.NoError(t, err)
+	assert.True(t, out.Workspace.IsDeleted())
+	assert.Equal(t, auditlog.ActionWorkspaceDeactivate, out.AuditLog.Action())
+	assert.Equal(t, op, out.AuditLog.Actor())
+
+	_, err = NewDeactivateWorkspaceUseCase(r.Workspace, r.AuditLog, r.Transaction).Execute(ctx, in)
+	assert.ErrorIs(t, err, ErrWorkspaceDeactivated)
+
+	out, err = NewRestoreWorkspaceUseCase(r.Workspace, r.AuditLog, r.Transaction).Execute(ctx, in)
+	require.NoError(t, err)
+	assert.False(t, out.Workspace.IsDeleted())
+
+	entries, err := r.AuditLog.FindByTarget(ctx, w.ID().String())
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+}
@@ -0,0 +1,26 @@
+package workspaceuc
+
+import (
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var (
+	// ErrWorkspaceDeactivated is returned when deactivating a workspace that
+	// is already deactivated.
+	ErrWorkspaceDeactivated = rerror.NewE(i18n.T("workspace is deactivated"))
+	// ErrWorkspaceNotDeactivated is returned when restoring an active
+	// workspace.
+	ErrWorkspaceNotDeactivated = rerror.NewE(i18n.T("workspace is not deactivated"))
+	// ErrNothingToUpdate is returned when an update changes no field.
+	ErrNothingToUpdate = rerror.NewE(i18n.T("nothing to update"))
+	// ErrEmptyName is returned when a workspace is renamed to an empty name.
+	ErrEmptyName = rerror.NewE(i18n.T("name can't be empty"))
+	// ErrAliasTaken is returned when another workspace already has the alias.
+	ErrAliasTaken = rerror.NewE(i18n.T("alias is already taken"))
+	// ErrLastOwner is returned when removing or demoting the only owner of a
+	// workspace. Another member has to be made an owner first.
+	ErrLastOwner = rerror.NewE(i18n.T("cannot remove the last owner of a workspace"))
+	// ErrNoMembers is returned when adding an empty list of members.
+	ErrNoMembers = rerror.NewE(i18n.T("no members to add"))
+)
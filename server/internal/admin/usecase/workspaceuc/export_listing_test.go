@@ -0,0 +1,60 @@
+package workspaceuc
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportWorkspaceListing(t *testing.T) {
+	ctx := context.Background()
+
+	// More workspaces than a page, so the export has to walk several pages.
+	list := make([]*workspace.Workspace, 0, 120)
+	for i := range 120 {
+		list = append(list, ws(fmt.Sprintf("Team %d", i), fmt.Sprintf("team%d", i)))
+	}
+	list = append(list, personalWs("Alice", "alice"))
+	repo := memory.NewWorkspaceWith(list...)
+	auditLogRepo := memory.NewAuditLog()
+	uc := NewExportWorkspaceListingUseCase(repo, auditLogRepo)
+
+	collect := func(in ExportWorkspaceListingInput) workspace.List {
+		t.Helper()
+		var got workspace.List
+		require.NoError(t, uc.Execute(ctx, in, func(w *workspace.Workspace) error {
+			got = append(got, w)
+			return nil
+		}))
+		return got
+	}
+
+	got := collect(ExportWorkspaceListingInput{Operator: adminuser.NewID()})
+	assert.Len(t, got, 121)
+	seen := map[workspace.ID]struct{}{}
+	for _, w := range got {
+		seen[w.ID()] = struct{}{}
+	}
+	assert.Len(t, seen, 121)
+
+	entries, err := auditLogRepo.FindByTarget(ctx, "")
+	require.NoError(t, err)
+	assert.Equal(t, auditlog.ActionWorkspaceListingExport, entries[len(entries)-1].Action())
+	assert.Equal(t, "121", entries[len(entries)-1].Detail()["workspaces"])
+
+	personal := true
+	got = collect(ExportWorkspaceListingInput{Operator: adminuser.NewID(), Personal: &personal})
+	require.Len(t, got, 1)
+	assert.Equal(t, "alice", got[0].Alias())
+
+	kw := "team11"
+	got = collect(ExportWorkspaceListingInput{Operator: adminuser.NewID(), Keyword: &kw})
+	assert.Len(t, got, 11) // team11, team110..team119
+}
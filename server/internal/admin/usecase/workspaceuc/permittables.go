@@ -0,0 +1,77 @@
+package workspaceuc
+
+import (
+	"context"
+	"errors"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// memberRoles keeps the workspace roles of the permittables of users in sync
+// with their memberships, like the workspace interactor of the main service
+// does.
+type memberRoles struct {
+	roleRepo        role.Repo
+	permittableRepo permittable.Repo
+}
+
+// set binds each user to their role in the workspace.
+func (m memberRoles) set(ctx context.Context, wid workspace.ID, roles map[user.ID]role.RoleType) error {
+	if len(roles) == 0 {
+		return nil
+	}
+
+	roleIDs := make(map[role.RoleType]id.RoleID, len(roles))
+	uids := make(user.IDList, 0, len(roles))
+	for uid, rt := range roles {
+		uids = append(uids, uid)
+		if _, ok := roleIDs[rt]; ok {
+			continue
+		}
+		r, err := m.roleRepo.FindByName(ctx, rt.String())
+		if err != nil {
+			return err
+		}
+		roleIDs[rt] = r.ID()
+	}
+
+	existing, err := m.permittableRepo.FindByUserIDs(ctx, uids)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return err
+	}
+	byUser := make(map[user.ID]*permittable.Permittable, len(existing))
+	for _, p := range existing {
+		byUser[p.UserID()] = p
+	}
+
+	toSave := make(permittable.List, 0, len(roles))
+	for uid, rt := range roles {
+		p, ok := byUser[uid]
+		if !ok {
+			if p, err = permittable.New().NewID().UserID(uid).Build(); err != nil {
+				return err
+			}
+		}
+		p.UpdateWorkspaceRole(wid, roleIDs[rt])
+		toSave = append(toSave, p)
+	}
+	return m.permittableRepo.SaveMany(ctx, toSave)
+}
+
+// remove unbinds the user from the workspace.
+func (m memberRoles) remove(ctx context.Context, wid workspace.ID, uid user.ID) error {
+	p, err := m.permittableRepo.FindByUserID(ctx, uid)
+	if errors.Is(err, rerror.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	p.RemoveWorkspaceRole(wid)
+	return m.permittableRepo.Save(ctx, *p)
+}
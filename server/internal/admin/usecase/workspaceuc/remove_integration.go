@@ -0,0 +1,48 @@
+package workspaceuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// RemoveWorkspaceIntegrationUseCase removes an integration from a workspace,
+// e.g. one that leaks its token or whose owner left.
+type RemoveWorkspaceIntegrationUseCase struct {
+	action workspaceAction
+}
+
+// NewRemoveWorkspaceIntegrationUseCase is a Wire provider for RemoveWorkspaceIntegrationUseCase.
+func NewRemoveWorkspaceIntegrationUseCase(workspaceRepo workspace.Repo, auditLogRepo auditlog.Repo, transaction usecasex.Transaction) *RemoveWorkspaceIntegrationUseCase {
+	return &RemoveWorkspaceIntegrationUseCase{action: workspaceAction{workspaceRepo: workspaceRepo, auditLogRepo: auditLogRepo, transaction: transaction}}
+}
+
+// RemoveWorkspaceIntegrationInput is the input for RemoveWorkspaceIntegrationUseCase.Execute.
+type RemoveWorkspaceIntegrationInput struct {
+	WorkspaceActionInput
+	Integration workspace.IntegrationID
+}
+
+// Execute removes the integration and records its role in the audit log.
+func (uc *RemoveWorkspaceIntegrationUseCase) Execute(ctx context.Context, in RemoveWorkspaceIntegrationInput) (*WorkspaceActionOutput, error) {
+	out, err := uc.action.run(ctx, in.WorkspaceActionInput, auditlog.ActionWorkspaceRemoveIntegration, func(_ context.Context, ws *workspace.Workspace) (map[string]string, error) {
+		m := ws.Members()
+		if !m.HasIntegration(in.Integration) {
+			return nil, workspace.ErrTargetUserNotInTheWorkspace
+		}
+		r := m.IntegrationRole(in.Integration)
+		if err := m.DeleteIntegration(in.Integration); err != nil {
+			return nil, err
+		}
+		return map[string]string{"integration": in.Integration.String(), "role": r.String()}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] integration %s removed from workspace %s by %s", in.Integration, in.Workspace, in.Operator)
+	return out, nil
+}
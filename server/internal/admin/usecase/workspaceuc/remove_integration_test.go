@@ -0,0 +1,39 @@
+package workspaceuc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveWorkspaceIntegration(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	owner := user.NewID()
+	iid := workspace.NewIntegrationID()
+	w := teamWs(map[user.ID]role.RoleType{owner: role.RoleOwner})
+	require.NoError(t, w.Members().AddIntegration(iid, role.RoleWriter, owner))
+	require.NoError(t, r.Workspace.Save(ctx, w))
+	uc := NewRemoveWorkspaceIntegrationUseCase(r.Workspace, r.AuditLog, r.Transaction)
+	in := RemoveWorkspaceIntegrationInput{
+		WorkspaceActionInput: WorkspaceActionInput{Operator: adminuser.NewID(), Workspace: w.ID()},
+		Integration:          iid,
+	}
+
+	out, err := uc.Execute(ctx, in)
+	require.NoError(t, err)
+	assert.False(t, out.Workspace.Members().HasIntegration(iid))
+	assert.Equal(t, auditlog.ActionWorkspaceRemoveIntegration, out.AuditLog.Action())
+	assert.Equal(t, map[string]string{"integration": iid.String(), "role": "writer"}, out.AuditLog.Detail())
+
+	_, err = uc.Execute(ctx, in)
+	assert.ErrorIs(t, err, workspace.ErrTargetUserNotInTheWorkspace)
+}
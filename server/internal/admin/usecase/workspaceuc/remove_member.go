@@ -0,0 +1,67 @@
+package workspaceuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// RemoveWorkspaceMemberUseCase removes a user from a workspace.
+type RemoveWorkspaceMemberUseCase struct {
+	action workspaceAction
+	roles  memberRoles
+}
+
+// NewRemoveWorkspaceMemberUseCase is a Wire provider for RemoveWorkspaceMemberUseCase.
+func NewRemoveWorkspaceMemberUseCase(
+	workspaceRepo workspace.Repo,
+	roleRepo role.Repo,
+	permittableRepo permittable.Repo,
+	auditLogRepo auditlog.Repo,
+	transaction usecasex.Transaction,
+) *RemoveWorkspaceMemberUseCase {
+	return &RemoveWorkspaceMemberUseCase{
+		action: workspaceAction{workspaceRepo: workspaceRepo, auditLogRepo: auditLogRepo, transaction: transaction},
+		roles:  memberRoles{roleRepo: roleRepo, permittableRepo: permittableRepo},
+	}
+}
+
+// RemoveWorkspaceMemberInput is the input for RemoveWorkspaceMemberUseCase.Execute.
+type RemoveWorkspaceMemberInput struct {
+	WorkspaceActionInput
+	User user.ID
+}
+
+// Execute removes the member, unbinds their permittable from the workspace and
+// records their role in the audit log. The only owner can't be removed.
+func (uc *RemoveWorkspaceMemberUseCase) Execute(ctx context.Context, in RemoveWorkspaceMemberInput) (*WorkspaceActionOutput, error) {
+	out, err := uc.action.run(ctx, in.WorkspaceActionInput, auditlog.ActionWorkspaceRemoveMember, func(ctx context.Context, ws *workspace.Workspace) (map[string]string, error) {
+		m := ws.Members()
+		if !m.HasUser(in.User) {
+			return nil, workspace.ErrTargetUserNotInTheWorkspace
+		}
+		if m.IsOnlyOwner(in.User) {
+			return nil, ErrLastOwner
+		}
+		r := m.UserRole(in.User)
+		if err := m.Leave(in.User); err != nil {
+			return nil, err
+		}
+		if err := uc.roles.remove(ctx, ws.ID(), in.User); err != nil {
+			return nil, err
+		}
+		return map[string]string{"user": in.User.String(), "role": r.String()}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] user %s removed from workspace %s by %s", in.User, in.Workspace, in.Operator)
+	return out, nil
+}
@@ -0,0 +1,42 @@
+package workspaceuc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRemoveWorkspaceMember(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	roles := seedRoles(t, r)
+	owner, alice := user.NewID(), user.NewID()
+	w := teamWs(map[user.ID]role.RoleType{owner: role.RoleOwner, alice: role.RoleWriter})
+	require.NoError(t, r.Workspace.Save(ctx, w))
+	p := permittable.New().NewID().UserID(alice).MustBuild()
+	p.UpdateWorkspaceRole(w.ID(), roles[role.RoleWriter].ID())
+	require.NoError(t, r.Permittable.Save(ctx, *p))
+	uc := NewRemoveWorkspaceMemberUseCase(r.Workspace, r.Role, r.Permittable, r.AuditLog, r.Transaction)
+	in := WorkspaceActionInput{Operator: adminuser.NewID(), Workspace: w.ID()}
+
+	_, err := uc.Execute(ctx, RemoveWorkspaceMemberInput{WorkspaceActionInput: in, User: owner})
+	assert.ErrorIs(t, err, ErrLastOwner)
+	_, err = uc.Execute(ctx, RemoveWorkspaceMemberInput{WorkspaceActionInput: in, User: user.NewID()})
+	assert.ErrorIs(t, err, workspace.ErrTargetUserNotInTheWorkspace)
+
+	out, err := uc.Execute(ctx, RemoveWorkspaceMemberInput{WorkspaceActionInput: in, User: alice})
+	require.NoError(t, err)
+	assert.False(t, out.Workspace.Members().HasUser(alice))
+	assert.Equal(t, auditlog.ActionWorkspaceRemoveMember, out.AuditLog.Action())
+	assert.Equal(t, map[string]string{"user": alice.String(), "role": "writer"}, out.AuditLog.Detail())
+	assert.Nil(t, workspaceRole(t, r, alice, w.ID()))
+}
@@ -0,0 +1,83 @@
+package workspaceuc
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// UpdateWorkspaceUseCase renames or re-aliases a workspace.
+type UpdateWorkspaceUseCase struct {
+	action workspaceAction
+}
+
+// NewUpdateWorkspaceUseCase is a Wire provider for UpdateWorkspaceUseCase.
+func NewUpdateWorkspaceUseCase(workspaceRepo workspace.Repo, auditLogRepo auditlog.Repo, transaction usecasex.Transaction) *UpdateWorkspaceUseCase {
+	return &UpdateWorkspaceUseCase{action: workspaceAction{workspaceRepo: workspaceRepo, auditLogRepo: auditLogRepo, transaction: transaction}}
+}
+
+// UpdateWorkspaceInput is the input for UpdateWorkspaceUseCase.Execute. Nil
+// fields are left as they are.
+type UpdateWorkspaceInput struct {
+	WorkspaceActionInput
+	Name  *string
+	Alias *string
+}
+
+// Execute applies the changed fields and records their old and new values in
+// the audit log. Like updateWorkspace, an empty alias falls back to one
+// derived from the ID.
+func (uc *UpdateWorkspaceUseCase) Execute(ctx context.Context, in UpdateWorkspaceInput) (*WorkspaceActionOutput, error) {
+	if in.Name == nil && in.Alias == nil {
+		return nil, ErrNothingToUpdate
+	}
+	var name, alias string
+	if in.Name != nil {
+		if name = strings.TrimSpace(*in.Name); name == "" {
+			return nil, ErrEmptyName
+		}
+	}
+	if in.Alias != nil {
+		if alias = strings.TrimSpace(*in.Alias); alias == "" {
+			alias = "w-" + in.Workspace.String()
+		}
+	}
+
+	var changed []string
+	out, err := uc.action.run(ctx, in.WorkspaceActionInput, auditlog.ActionWorkspaceUpdate, func(ctx context.Context, ws *workspace.Workspace) (map[string]string, error) {
+		detail := map[string]string{}
+		if in.Name != nil && name != ws.Name() {
+			detail["oldName"], detail["newName"] = ws.Name(), name
+			changed = append(changed, "name")
+			ws.Rename(name)
+		}
+		if in.Alias != nil && alias != ws.Alias() {
+			other, err := uc.action.workspaceRepo.FindByAlias(ctx, alias)
+			if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+				return nil, err
+			}
+			if other != nil && other.ID() != ws.ID() {
+				return nil, ErrAliasTaken
+			}
+			detail["oldAlias"], detail["newAlias"] = ws.Alias(), alias
+			changed = append(changed, "alias")
+			ws.UpdateAlias(alias)
+		}
+		if len(changed) == 0 {
+			return nil, ErrNothingToUpdate
+		}
+		return detail, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] workspace %s updated by %s: fields=%v", in.Workspace, in.Operator, changed)
+	return out, nil
+}
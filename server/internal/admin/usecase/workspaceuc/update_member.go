@@ -0,0 +1,77 @@
+package workspaceuc
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// UpdateWorkspaceMemberUseCase changes the role of a member. Unlike the main
+// service, it can make a member an owner, which is how a workspace whose
+// owner left gets a new one.
+type UpdateWorkspaceMemberUseCase struct {
+	action workspaceAction
+	roles  memberRoles
+}
+
+// NewUpdateWorkspaceMemberUseCase is a Wire provider for UpdateWorkspaceMemberUseCase.
+func NewUpdateWorkspaceMemberUseCase(
+	workspaceRepo workspace.Repo,
+	roleRepo role.Repo,
+	permittableRepo permittable.Repo,
+	auditLogRepo auditlog.Repo,
+	transaction usecasex.Transaction,
+) *UpdateWorkspaceMemberUseCase {
+	return &UpdateWorkspaceMemberUseCase{
+		action: workspaceAction{workspaceRepo: workspaceRepo, auditLogRepo: auditLogRepo, transaction: transaction},
+		roles:  memberRoles{roleRepo: roleRepo, permittableRepo: permittableRepo},
+	}
+}
+
+// UpdateWorkspaceMemberInput is the input for UpdateWorkspaceMemberUseCase.Execute.
+type UpdateWorkspaceMemberInput struct {
+	WorkspaceActionInput
+	User user.ID
+	Role role.RoleType
+}
+
+// Execute changes the role, updates the permittable of the member and records
+// the old and new roles in the audit log. The only owner can't be demoted.
+func (uc *UpdateWorkspaceMemberUseCase) Execute(ctx context.Context, in UpdateWorkspaceMemberInput) (*WorkspaceActionOutput, error) {
+	if !in.Role.Valid() || in.Role == role.RoleSelf {
+		return nil, role.ErrInvalidRole
+	}
+
+	out, err := uc.action.run(ctx, in.WorkspaceActionInput, auditlog.ActionWorkspaceUpdateMember, func(ctx context.Context, ws *workspace.Workspace) (map[string]string, error) {
+		m := ws.Members()
+		if !m.HasUser(in.User) {
+			return nil, workspace.ErrTargetUserNotInTheWorkspace
+		}
+		from := m.UserRole(in.User)
+		if from == in.Role {
+			return nil, ErrNothingToUpdate
+		}
+		if from == role.RoleOwner && m.IsOnlyOwner(in.User) {
+			return nil, ErrLastOwner
+		}
+		if err := m.UpdateUserRole(in.User, in.Role); err != nil {
+			return nil, err
+		}
+		if err := uc.roles.set(ctx, ws.ID(), map[user.ID]role.RoleType{in.User: in.Role}); err != nil {
+			return nil, err
+		}
+		return map[string]string{"user": in.User.String(), "oldRole": from.String(), "newRole": in.Role.String()}, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infofc(ctx, "[admin] role of user %s in workspace %s changed to %s by %s", in.User, in.Workspace, in.Role, in.Operator)
+	return out, nil
+}
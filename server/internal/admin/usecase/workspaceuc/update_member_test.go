@@ -0,0 +1,68 @@
+package workspaceuc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateWorkspaceMember_ReassignOwner(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	roles := seedRoles(t, r)
+	owner, alice := user.NewID(), user.NewID()
+	w := teamWs(map[user.ID]role.RoleType{owner: role.RoleOwner, alice: role.RoleReader})
+	require.NoError(t, r.Workspace.Save(ctx, w))
+	uc := NewUpdateWorkspaceMemberUseCase(r.Workspace, r.Role, r.Permittable, r.AuditLog, r.Transaction)
+	in := WorkspaceActionInput{Operator: adminuser.NewID(), Workspace: w.ID()}
+
+	_, err := uc.Execute(ctx, UpdateWorkspaceMemberInput{WorkspaceActionInput: in, User: owner, Role: role.RoleWriter})
+	assert.ErrorIs(t, err, ErrLastOwner)
+
+	out, err := uc.Execute(ctx, UpdateWorkspaceMemberInput{WorkspaceActionInput: in, User: alice, Role: role.RoleOwner})
+	require.NoError(t, err)
+	assert.Equal(t, role.RoleOwner, out.Workspace.Members().UserRole(alice))
+	assert.Equal(t, auditlog.ActionWorkspaceUpdateMember, out.AuditLog.Action())
+	assert.Equal(t, map[string]string{"user": alice.String(), "oldRole": "reader", "newRole": "owner"}, out.AuditLog.Detail())
+	assert.Equal(t, roles[role.RoleOwner].ID(), *workspaceRole(t, r, alice, w.ID()))
+
+	_, err = uc.Execute(ctx, UpdateWorkspaceMemberInput{WorkspaceActionInput: in, User: owner, Role: role.RoleWriter})
+	require.NoError(t, err)
+	assert.Equal(t, roles[role.RoleWriter].ID(), *workspaceRole(t, r, owner, w.ID()))
+}
+
+func TestUpdateWorkspaceMember_Errors(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	seedRoles(t, r)
+	owner := user.NewID()
+	w := teamWs(map[user.ID]role.RoleType{owner: role.RoleOwner})
+	require.NoError(t, r.Workspace.Save(ctx, w))
+	uc := NewUpdateWorkspaceMemberUseCase(r.Workspace, r.Role, r.Permittable, r.AuditLog, r.Transaction)
+	in := WorkspaceActionInput{Operator: adminuser.NewID(), Workspace: w.ID()}
+
+	tests := []struct {
+		name string
+		user user.ID
+		role role.RoleType
+		want error
+	}{
+		{name: "invalid role", user: owner, role: role.RoleType("admin"), want: role.ErrInvalidRole},
+		{name: "unchanged", user: owner, role: role.RoleOwner, want: ErrNothingToUpdate},
+		{name: "not a member", user: user.NewID(), role: role.RoleReader, want: workspace.ErrTargetUserNotInTheWorkspace},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(ctx, UpdateWorkspaceMemberInput{WorkspaceActionInput: in, User: tt.user, Role: tt.role})
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+}
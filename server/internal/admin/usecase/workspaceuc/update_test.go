@@ -0,0 +1,80 @@
+package workspaceuc
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpdateWorkspace(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	w := ws("Alpha", "alpha")
+	require.NoError(t, r.Workspace.Save(ctx, w))
+	uc := NewUpdateWorkspaceUseCase(r.Workspace, r.AuditLog, r.Transaction)
+
+	out, err := uc.Execute(ctx, UpdateWorkspaceInput{
+		WorkspaceActionInput: WorkspaceActionInput{Operator: adminuser.NewID(), Workspace: w.ID()},
+		Name:                 lo.ToPtr("Beta"),
+		Alias:                lo.ToPtr("beta"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Beta", out.Workspace.Name())
+	assert.Equal(t, "beta", out.Workspace.Alias())
+	assert.Equal(t, auditlog.ActionWorkspaceUpdate, out.AuditLog.Action())
+	assert.Equal(t, map[string]string{
+		"oldName": "Alpha", "newName": "Beta",
+		"oldAlias": "alpha", "newAlias": "beta",
+	}, out.AuditLog.Detail())
+
+	got, err := r.Workspace.FindByID(ctx, w.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "beta", got.Alias())
+}
+
+func TestUpdateWorkspace_Errors(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	w := ws("Alpha", "alpha")
+	other := ws("Beta", "beta")
+	personal := personalWs("Alice", "alice")
+	require.NoError(t, r.Workspace.SaveAll(ctx, workspace.List{w, other, personal}))
+	uc := NewUpdateWorkspaceUseCase(r.Workspace, r.AuditLog, r.Transaction)
+	in := WorkspaceActionInput{Operator: adminuser.NewID(), Workspace: w.ID()}
+
+	tests := []struct {
+		name string
+		in   UpdateWorkspaceInput
+		want error
+	}{
+		{name: "nothing", in: UpdateWorkspaceInput{WorkspaceActionInput: in}, want: ErrNothingToUpdate},
+		{name: "unchanged", in: UpdateWorkspaceInput{WorkspaceActionInput: in, Name: lo.ToPtr("Alpha")}, want: ErrNothingToUpdate},
+		{name: "empty name", in: UpdateWorkspaceInput{WorkspaceActionInput: in, Name: lo.ToPtr(" ")}, want: ErrEmptyName},
+		{name: "alias taken", in: UpdateWorkspaceInput{WorkspaceActionInput: in, Alias: lo.ToPtr("beta")}, want: ErrAliasTaken},
+		{
+			name: "personal",
+			in: UpdateWorkspaceInput{
+				WorkspaceActionInput: WorkspaceActionInput{Operator: adminuser.NewID(), Workspace: personal.ID()},
+				Name:                 lo.ToPtr("Bob"),
+			},
+			want: workspace.ErrCannotModifyPersonalWorkspace,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := uc.Execute(ctx, tt.in)
+			assert.ErrorIs(t, err, tt.want)
+		})
+	}
+
+	entries, err := r.AuditLog.FindByTarget(ctx, w.ID().String())
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
@@ -120,10 +120,15 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 			log.Panicc(ctx, err)
 		}
 		middlewares = append(middlewares, echo.WrapMiddleware(jwt))
+		if len(cfg.Config.OIDCIdPs) > 0 {
+			middlewares = append(middlewares, echo.WrapMiddleware(oidcIdPMiddleware(cfg)))
+		}
 	}
 
-	// Always apply the app's auth middleware (handles both mock and real auth)
+	// Always apply the app's auth middleware (handles both mock and real auth).
+	// An impersonation token, when present, takes its place.
 	middlewares = append(middlewares,
+		echo.WrapMiddleware(impersonationMiddleware(cfg)),
 		echo.WrapMiddleware(authMiddleware(cfg)),
 		cacheControl,
 	)
@@ -140,16 +145,29 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 		if err != nil {
 			log.Panicc(ctx, err)
 		}
-		restJWT = echo.WrapMiddleware(jwt)
+		if len(cfg.Config.OIDCIdPs) > 0 {
+			claims := oidcIdPMiddleware(cfg)
+			restJWT = echo.WrapMiddleware(func(next http.Handler) http.Handler {
+				return jwt(claims(next))
+			})
+		} else {
+			restJWT = echo.WrapMiddleware(jwt)
+		}
 	}
+	initOIDCProvider(ctx, e, cfg)
+	initSCIM(e, cfg)
+
 	adapterhttp.RegisterRESTRouter(e, adapterhttp.RouterConfig{
 		AuthResolver:       restAuthResolver(cfg),
+		Impersonation:      echo.WrapMiddleware(impersonationMiddleware(cfg)),
 		CacheControl:       cacheControl,
 		UsecaseMiddleware:  usecaseMiddleware,
 		JWTMiddleware:      restJWT,
 		AuthConfigProvider: cfg.Config,
 		APIKey:             cfg.Config.RestAPIKey,
 		SyncSSOAPIKey:      cfg.Config.SyncSSOAPIKey,
+		MagicLink:          cfg.Config.OIDC.Issuer != "",
+		Storage:            cfg.Gateways.Storage,
 		SwaggerUser:        cfg.Config.SwaggerBasicUser,
 		SwaggerPass:        cfg.Config.SwaggerBasicPass,
 		Debug:              cfg.Debug || cfg.Config.Dev,
@@ -162,10 +180,17 @@ func initEcho(ctx context.Context, cfg *ServerConfig) *echo.Echo {
 // FindBySub for real auth) plus generateUserOperator to turn an AuthInfo into a
 // domain user + operator for the REST middleware. It returns (nil, nil, nil) for an
 // unauthenticated request (no token, or the resolved subject has no user) so that
-// RequiredAuth can answer 401 and OptionalAuth can proceed anonymously.
+// RequiredAuth can answer 401 and OptionalAuth can proceed anonymously. An
+// impersonated request keeps the user and operator impersonationMiddleware
+// attached.
 func restAuthResolver(cfg *ServerConfig) adapterhttp.AuthResolver {
+	users := interactor.NewUser(cfg.Repos, cfg.Gateways, nil, cfg.Config.SignupSecret, cfg.Config.HostWeb)
 	return func(c echo.Context, ai *appx.AuthInfo) (*user.User, *workspace.Operator, error) {
 		ctx := c.Request().Context()
+		if adapter.Impersonation(ctx) != nil {
+			// resolved by impersonationMiddleware
+			return adapter.User(ctx), adapter.Operator(ctx), nil
+		}
 		var u *user.User
 		var err error
 		if cfg.Config.Mock_Auth {
@@ -182,6 +207,9 @@ func restAuthResolver(cfg *ServerConfig) adapterhttp.AuthResolver {
 			}
 			return nil, nil, err
 		}
+		if ai != nil {
+			applyRoleMappings(ctx, users, u, *ai)
+		}
 		op, err := generateUserOperator(ctx, cfg, u)
 		if err != nil {
 			return nil, nil, err
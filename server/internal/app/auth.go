@@ -11,6 +11,8 @@ import (
 	"strings"
 
 	"github.com/reearth/reearth-accounts/server/internal/adapter"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interactor"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearth-accounts/server/pkg/role"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
@@ -175,6 +177,12 @@ func mockAuthMiddleware(cfg *ServerConfig) func(http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			ctx := req.Context()
 
+			// already authenticated by impersonationMiddleware
+			if adapter.Impersonation(ctx) != nil {
+				next.ServeHTTP(w, req)
+				return
+			}
+
 			log.Debugfc(ctx, "[mockAuthMiddleware] Using mock authentication")
 
 			// bypass some queries & mutations
@@ -229,10 +237,17 @@ func mockAuthMiddleware(cfg *ServerConfig) func(http.Handler) http.Handler {
 }
 
 func identityProviderAuthMiddleware(cfg *ServerConfig) func(http.Handler) http.Handler {
+	users := interactor.NewUser(cfg.Repos, cfg.Gateways, nil, cfg.Config.SignupSecret, cfg.Config.HostWeb)
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
 			ctx := req.Context()
 
+			// already authenticated by impersonationMiddleware
+			if adapter.Impersonation(ctx) != nil {
+				next.ServeHTTP(w, req)
+				return
+			}
+
 			// bypass some queries & mutations
 			if isBypassed(req) {
 				log.Debugfc(ctx, "[authMiddleware] Skipping auth for signup mutation")
@@ -285,6 +300,8 @@ func identityProviderAuthMiddleware(cfg *ServerConfig) func(http.Handler) http.H
 
 				usr = existingUsr
 				log.Debugfc(ctx, "[authMiddleware] User loaded by sub: %s (%s)", usr.Name(), usr.ID())
+
+				applyRoleMappings(ctx, users, usr, ai)
 			}
 
 			if usr != nil {
@@ -366,6 +383,30 @@ func generateUserOperator(ctx context.Context, cfg *ServerConfig, u *user.User)
 	}, nil
 }
 
+// applyRoleMappings updates the workspace memberships of u from the claims of
+// the token it is signed in with, so that the operator built afterwards
+// reflects them. Tokens whose payload can't be read carry no claims to map.
+// The mappings only refine the memberships, so a failure is logged and the
+// request goes on with the memberships the user already has.
+func applyRoleMappings(ctx context.Context, users interfaces.User, u *user.User, ai appx.AuthInfo) {
+	if ai.Token == "" || ai.Iss == "" {
+		return
+	}
+	claims, err := adapter.TokenClaims(ai.Token)
+	if err != nil {
+		log.Debugfc(ctx, "[authMiddleware] Skipping role mappings: %v", err)
+		return
+	}
+	if err := users.ApplyRoleMappings(ctx, interfaces.ApplyRoleMappingsParam{
+		User:   u,
+		Issuer: ai.Iss,
+		Sub:    ai.Sub,
+		Claims: claims,
+	}); err != nil {
+		log.Errorfc(ctx, "[authMiddleware] Failed to apply role mappings for user %s: %v", u.ID(), err)
+	}
+}
+
 func injectDebugAuthInfo(ctx context.Context, req *http.Request) (context.Context, *appx.AuthInfo) {
 	sub := req.Header.Get(debugAuthSubHeader)
 	if sub == "" {
@@ -380,3 +421,38 @@ func injectDebugAuthInfo(ctx context.Context, req *http.Request) (context.Contex
 	}
 	return context.WithValue(ctx, adapter.AuthInfoKey, *ai), ai
 }
+
+// oidcIdPMiddleware replaces the identity of tokens issued by a generic OIDC
+// provider with the one mapped from its configured claims, so that the sub
+// carries the provider prefix under which its users are stored. It runs after
+// the JWT middleware, which has already verified the token.
+func oidcIdPMiddleware(cfg *ServerConfig) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			ctx := req.Context()
+			ai, ok := ctx.Value(adapter.AuthInfoKey).(appx.AuthInfo)
+			if !ok || ai.Token == "" {
+				next.ServeHTTP(w, req)
+				return
+			}
+			r := cfg.Gateways.IdentityResolverFor(ai.Iss)
+			if r == nil {
+				next.ServeHTTP(w, req)
+				return
+			}
+
+			u, err := r.ResolveIdentity(ctx, ai.Token)
+			if err != nil {
+				log.Warnfc(ctx, "[oidcIdPMiddleware] Rejecting token of iss=%s: %v", ai.Iss, err)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+			ai.Sub = u.ID
+			ai.Email = u.Email
+			ai.Name = u.Name
+			ai.EmailVerified = &u.EmailVerified
+			ctx = context.WithValue(ctx, adapter.AuthInfoKey, ai)
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
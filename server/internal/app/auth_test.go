@@ -2,6 +2,7 @@ package app
 
 import (
 	"context"
+	"encoding/base64"
 	"errors"
 	"net/http"
 	"net/http/httptest"
@@ -10,13 +11,17 @@ import (
 
 	"github.com/reearth/reearth-accounts/server/internal/adapter"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/oidcidp"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/appx"
 	"github.com/reearth/reearthx/rerror"
 	"github.com/stretchr/testify/assert"
+	"go.uber.org/mock/gomock"
 )
 
 func TestAuthMiddleware(t *testing.T) {
@@ -1016,3 +1021,148 @@ type mockWorkspaceRepoWithError struct {
 func (m *mockWorkspaceRepoWithError) FindByUser(ctx context.Context, uid id.UserID) (workspace.List, error) {
 	return nil, m.err
 }
+
+func TestOIDCIdPMiddleware(t *testing.T) {
+	idp := oidcidp.New(oidcidp.Config{
+		Name:                 "keycloak",
+		Issuer:               "https://idp.example.com",
+		ClientID:             "reearth",
+		EmailClaim:           "upn",
+		RequireEmailVerified: true,
+	})
+	cfg := &ServerConfig{
+		Config: &Config{},
+		Gateways: &gateway.Container{
+			Authenticators: map[gateway.Provider]gateway.Authenticator{"keycloak": idp},
+		},
+	}
+	token := func(claims string) string {
+		return "e30." + base64.RawURLEncoding.EncodeToString([]byte(claims)) + ".sig"
+	}
+	serve := func(ai *appx.AuthInfo) (*httptest.ResponseRecorder, appx.AuthInfo) {
+		var got appx.AuthInfo
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			got, _ = r.Context().Value(adapter.AuthInfoKey).(appx.AuthInfo)
+			w.WriteHeader(http.StatusOK)
+		})
+		req := httptest.NewRequest(http.MethodPost, "/", nil)
+		if ai != nil {
+			req = req.WithContext(context.WithValue(req.Context(), adapter.AuthInfoKey, *ai))
+		}
+		rr := httptest.NewRecorder()
+		oidcIdPMiddleware(cfg)(next).ServeHTTP(rr, req)
+		return rr, got
+	}
+
+	t.Run("maps claims of the provider's tokens", func(t *testing.T) {
+		rr, got := serve(&appx.AuthInfo{
+			Iss:   "https://idp.example.com",
+			Sub:   "abc",
+			Token: token(`{"sub":"abc","upn":"a@example.com","name":"Alice","email_verified":true}`),
+		})
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "keycloak|abc", got.Sub)
+		assert.Equal(t, "a@example.com", got.Email)
+		assert.Equal(t, "Alice", got.Name)
+		assert.True(t, *got.EmailVerified)
+	})
+
+	t.Run("rejects unverified emails", func(t *testing.T) {
+		rr, _ := serve(&appx.AuthInfo{
+			Iss:   "https://idp.example.com",
+			Token: token(`{"sub":"abc","upn":"a@example.com"}`),
+		})
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("leaves other issuers untouched", func(t *testing.T) {
+		ai := appx.AuthInfo{Iss: "https://example.auth0.com/", Sub: "auth0|abc", Token: "t"}
+		rr, got := serve(&ai)
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, ai, got)
+	})
+
+	t.Run("passes requests without a token", func(t *testing.T) {
+		rr, _ := serve(nil)
+		assert.Equal(t, http.StatusOK, rr.Code)
+	})
+}
+
+func TestAuthMiddleware_RoleMappings(t *testing.T) {
+	const (
+		iss = "https://example.auth0.com/"
+		sub = "samlp|org_1|alice@example.com"
+	)
+	ctx := context.Background()
+	u := user.New().NewID().Name("alice").Email("alice@example.com").
+		Auths([]user.Auth{user.AuthFrom(sub)}).MustBuild()
+	w := workspace.New().NewID().Name("gis").Members(map[id.UserID]workspace.Member{
+		user.NewID(): {Role: role.RoleOwner},
+	}).MustBuild()
+
+	repos := memory.New()
+	repos.User = memory.NewUserWith(u)
+	repos.Workspace = memory.NewWorkspaceWith(w)
+	assert.NoError(t, repos.Role.Save(ctx, *role.New().NewID().Name(role.RoleWriter.String()).MustBuild()))
+	assert.NoError(t, repos.RoleMapping.Save(ctx, rolemapping.New().NewID().Workspace(w.ID()).
+		Issuer(iss).SubPrefix("samlp|org_1").
+		Rules([]rolemapping.Rule{{Claim: "groups", Value: "gis-editors", Role: role.RoleWriter}}).MustBuild()))
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"` + iss + `","sub":"` + sub + `","groups":["gis-editors"]}`))
+	ai := appx.AuthInfo{Token: "e30." + payload + ".sig", Iss: iss, Sub: sub}
+
+	cfg := &ServerConfig{Config: &Config{}, Repos: repos}
+	var op *workspace.Operator
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op = adapter.Operator(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(context.WithValue(ctx, adapter.AuthInfoKey, ai))
+	rr := httptest.NewRecorder()
+	identityProviderAuthMiddleware(cfg)(next).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	got, err := repos.Workspace.FindByID(ctx, w.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, role.RoleWriter, got.Members().UserRole(u.ID()))
+	if assert.NotNil(t, op) {
+		assert.True(t, op.IsWritableWorkspace(w.ID()))
+	}
+}
+
+func TestAuthMiddleware_RoleMappingsFailure(t *testing.T) {
+	const (
+		iss = "https://example.auth0.com/"
+		sub = "samlp|org_1|alice@example.com"
+	)
+	u := user.New().NewID().Name("alice").Email("alice@example.com").
+		Auths([]user.Auth{user.AuthFrom(sub)}).MustBuild()
+	w := workspace.New().NewID().Name("alice").Members(map[id.UserID]workspace.Member{
+		u.ID(): {Role: role.RoleOwner},
+	}).MustBuild()
+	mappings := rolemapping.NewMockRepo(gomock.NewController(t))
+	mappings.EXPECT().FindByIssuer(gomock.Any(), iss).Return(nil, errors.New("db down"))
+
+	repos := memory.New()
+	repos.User = memory.NewUserWith(u)
+	repos.Workspace = memory.NewWorkspaceWith(w)
+	repos.RoleMapping = mappings
+
+	payload := base64.RawURLEncoding.EncodeToString([]byte(`{"iss":"` + iss + `","sub":"` + sub + `"}`))
+	ai := appx.AuthInfo{Token: "e30." + payload + ".sig", Iss: iss, Sub: sub}
+
+	cfg := &ServerConfig{Config: &Config{}, Repos: repos}
+	var op *workspace.Operator
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		op = adapter.Operator(r.Context())
+		w.WriteHeader(http.StatusOK)
+	})
+	req := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(context.WithValue(context.Background(), adapter.AuthInfoKey, ai))
+	rr := httptest.NewRecorder()
+	identityProviderAuthMiddleware(cfg)(next).ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	if assert.NotNil(t, op) {
+		assert.True(t, op.IsOwningWorkspace(w.ID()), "the memberships the user already has are kept")
+	}
+}
@@ -10,6 +10,9 @@ import (
 
 	"github.com/joho/godotenv"
 	"github.com/kelseyhightower/envconfig"
+	"github.com/reearth/reearth-accounts/server/internal/adapter"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/ldap"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/oidcidp"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/appx"
 	"github.com/reearth/reearthx/log"
@@ -41,8 +44,15 @@ type Config struct {
 	Auth_TTL *int        `pp:",omitempty"`
 	Auth0    Auth0Config `pp:",omitempty"`
 
-	CIP          CIPConfig `pp:",omitempty"`
-	AuthProvider string    `default:"auth0" envconfig:"REEARTH_ACCOUNTS_AUTH_PROVIDER" pp:",omitempty"`
+	CIP      CIPConfig          `pp:",omitempty"`
+	WebAuthn WebAuthnConfig     `pp:",omitempty"`
+	OIDC     OIDCProviderConfig `pp:",omitempty"`
+	// OIDCIdPs lists generic OIDC identity providers (e.g. Keycloak, Azure AD)
+	// as a JSON array of oidcidp.Config.
+	OIDCIdPs     OIDCIdPConfigs `envconfig:"REEARTH_ACCOUNTS_OIDC_IDPS" pp:",omitempty"`
+	AuthProvider string         `default:"auth0" envconfig:"REEARTH_ACCOUNTS_AUTH_PROVIDER" pp:",omitempty"`
+	LDAP         LDAPConfig     `pp:",omitempty"`
+	Deletion     DeletionConfig `pp:",omitempty"`
 
 	GraphQL GraphQLConfig
 
@@ -53,6 +63,11 @@ type Config struct {
 	Reearth_GCS    string
 	Published_Host string
 
+	// ImpersonationSecret verifies the tokens with which the admin API lets an
+	// admin view the service as a user. It must match the admin API's; when
+	// empty, impersonation is disabled.
+	ImpersonationSecret string `envconfig:"REEARTH_ACCOUNTS_IMPERSONATION_SECRET"`
+
 	// cerbos
 	CerbosUseSSL bool   `envconfig:"REEARTH_ACCOUNTS_CERBOS_USE_SSL" default:"true"`
 	CerbosHost   string `envconfig:"CERBOS_HOST"`
@@ -148,6 +163,102 @@ func (c CIPConfig) AuthConfig() *AuthConfig {
 	}
 }
 
+type WebAuthnConfig struct {
+	// RPID is the WebAuthn relying party ID (e.g. "reearth.io"). Passkeys are
+	// disabled when it is empty.
+	RPID          string   `envconfig:"REEARTH_ACCOUNTS_WEBAUTHN_RP_ID"`
+	RPDisplayName string   `envconfig:"REEARTH_ACCOUNTS_WEBAUTHN_RP_DISPLAY_NAME" default:"Re:Earth"`
+	RPOrigins     []string `envconfig:"REEARTH_ACCOUNTS_WEBAUTHN_RP_ORIGINS"`
+}
+
+// DeletionConfig configures the purge of deactivated users and workspaces.
+type DeletionConfig struct {
+	// Retention is how long deactivated users and workspaces are kept before
+	// they are permanently deleted. They are kept for good when it is 0, and
+	// only the users who requested their deletion are purged.
+	Retention time.Duration `envconfig:"REEARTH_ACCOUNTS_DELETION_RETENTION" default:"0"`
+	// NoticePeriod is how long before the deletion users are mailed about it.
+	NoticePeriod  time.Duration `envconfig:"REEARTH_ACCOUNTS_DELETION_NOTICE_PERIOD" default:"168h"`
+	PurgeInterval time.Duration `envconfig:"REEARTH_ACCOUNTS_DELETION_PURGE_INTERVAL" default:"1h"`
+}
+
+// LDAPConfig configures the directory sync of on-prem deployments. See
+// ldap.Config for the attributes.
+type LDAPConfig struct {
+	// URL is the address of the LDAP server. The sync is disabled when it is
+	// empty.
+	URL                  string        `envconfig:"REEARTH_ACCOUNTS_LDAP_URL"`
+	StartTLS             bool          `envconfig:"REEARTH_ACCOUNTS_LDAP_START_TLS"`
+	BindDN               string        `envconfig:"REEARTH_ACCOUNTS_LDAP_BIND_DN"`
+	BindPassword         string        `envconfig:"REEARTH_ACCOUNTS_LDAP_BIND_PASSWORD"`
+	BaseDN               string        `envconfig:"REEARTH_ACCOUNTS_LDAP_BASE_DN"`
+	UserFilter           string        `envconfig:"REEARTH_ACCOUNTS_LDAP_USER_FILTER"`
+	SubAttribute         string        `envconfig:"REEARTH_ACCOUNTS_LDAP_SUB_ATTRIBUTE"`
+	SubPrefix            string        `envconfig:"REEARTH_ACCOUNTS_LDAP_SUB_PREFIX"`
+	EmailAttribute       string        `envconfig:"REEARTH_ACCOUNTS_LDAP_EMAIL_ATTRIBUTE"`
+	NameAttribute        string        `envconfig:"REEARTH_ACCOUNTS_LDAP_NAME_ATTRIBUTE"`
+	GroupAttribute       string        `envconfig:"REEARTH_ACCOUNTS_LDAP_GROUP_ATTRIBUTE"`
+	GroupBaseDN          string        `envconfig:"REEARTH_ACCOUNTS_LDAP_GROUP_BASE_DN"`
+	GroupFilter          string        `envconfig:"REEARTH_ACCOUNTS_LDAP_GROUP_FILTER"`
+	GroupMemberAttribute string        `envconfig:"REEARTH_ACCOUNTS_LDAP_GROUP_MEMBER_ATTRIBUTE"`
+	SyncInterval         time.Duration `envconfig:"REEARTH_ACCOUNTS_LDAP_SYNC_INTERVAL" default:"1h"`
+	// MaxRemovals and MaxRemovalRatio fail a sync that would deactivate more
+	// users than the count or the fraction of the synced users. 0 disables
+	// either limit.
+	MaxRemovals     int     `envconfig:"REEARTH_ACCOUNTS_LDAP_MAX_REMOVALS" default:"100"`
+	MaxRemovalRatio float64 `envconfig:"REEARTH_ACCOUNTS_LDAP_MAX_REMOVAL_RATIO" default:"0.5"`
+}
+
+func (c LDAPConfig) Directory() ldap.Config {
+	return ldap.Config{
+		URL:                  c.URL,
+		StartTLS:             c.StartTLS,
+		BindDN:               c.BindDN,
+		BindPassword:         c.BindPassword,
+		BaseDN:               c.BaseDN,
+		UserFilter:           c.UserFilter,
+		SubAttribute:         c.SubAttribute,
+		SubPrefix:            c.SubPrefix,
+		EmailAttribute:       c.EmailAttribute,
+		NameAttribute:        c.NameAttribute,
+		GroupAttribute:       c.GroupAttribute,
+		GroupBaseDN:          c.GroupBaseDN,
+		GroupFilter:          c.GroupFilter,
+		GroupMemberAttribute: c.GroupMemberAttribute,
+	}
+}
+
+type OIDCProviderConfig struct {
+	// Issuer is the public base URL of this service. The built-in OIDC provider
+	// is disabled when it is empty.
+	Issuer   string `envconfig:"REEARTH_ACCOUNTS_OIDC_ISSUER"`
+	ClientID string `envconfig:"REEARTH_ACCOUNTS_OIDC_CLIENT_ID" default:"reearth"`
+	// RedirectURIs lists the exact redirect URIs accepted for ClientID.
+	// Defaults to HostWeb.
+	RedirectURIs   []string      `envconfig:"REEARTH_ACCOUNTS_OIDC_REDIRECT_URIS"`
+	AccessTokenTTL time.Duration `envconfig:"REEARTH_ACCOUNTS_OIDC_ACCESS_TOKEN_TTL" default:"1h"`
+	// RefreshTokenTTL is how long a refresh token stays valid unused, and
+	// RefreshTokenMaxLifetime how long a sign-in can be kept alive by
+	// refreshing it.
+	RefreshTokenTTL         time.Duration `envconfig:"REEARTH_ACCOUNTS_OIDC_REFRESH_TOKEN_TTL" default:"720h"`
+	RefreshTokenMaxLifetime time.Duration `envconfig:"REEARTH_ACCOUNTS_OIDC_REFRESH_TOKEN_MAX_LIFETIME" default:"2160h"`
+	// KeyRefreshInterval is how often signing key rotations made by another
+	// instance or the admin API are picked up.
+	KeyRefreshInterval time.Duration `envconfig:"REEARTH_ACCOUNTS_OIDC_KEY_REFRESH_INTERVAL" default:"5m"`
+}
+
+// AuthConfig builds the JWT validation parameters for tokens issued by the
+// built-in OIDC provider. Returns nil when the provider is disabled.
+func (c OIDCProviderConfig) AuthConfig() *AuthConfig {
+	if c.Issuer == "" {
+		return nil
+	}
+	return &AuthConfig{
+		ISS: c.Issuer,
+		AUD: []string{c.ClientID},
+	}
+}
+
 type CertConfig struct {
 	IP                net.IP
 	PubSubTopicIssue  string
@@ -185,9 +296,50 @@ func (c Config) Auths() (res []appx.JWTProvider) {
 			TTL: ac.TTL,
 		})
 	}
+	if ac := c.OIDC.AuthConfig(); ac != nil {
+		res = append(res, appx.JWTProvider{
+			ISS: ac.ISS,
+			AUD: ac.AUD,
+		})
+	}
+	for _, p := range c.OIDCIdPs {
+		res = append(res, appx.JWTProvider{
+			ISS:     p.Issuer,
+			AUD:     []string{p.ClientID},
+			JWKSURI: p.JWKSURI,
+			ALG:     p.ALG,
+		})
+	}
 	return append(res, c.Auth...)
 }
 
+type OIDCIdPConfigs []oidcidp.Config
+
+// Decode is a custom decoder for OIDCIdPConfigs. It rejects invalid entries and
+// duplicate names, as the name identifies the provider of stored auths.
+func (c *OIDCIdPConfigs) Decode(value string) error {
+	if strings.TrimSpace(value) == "" {
+		*c = nil
+		return nil
+	}
+	var providers []oidcidp.Config
+	if err := json.Unmarshal([]byte(value), &providers); err != nil {
+		return fmt.Errorf("invalid oidc identity providers json: %w", err)
+	}
+	names := map[string]struct{}{}
+	for _, p := range providers {
+		if err := p.Validate(); err != nil {
+			return err
+		}
+		if _, ok := names[p.Name]; ok {
+			return fmt.Errorf("duplicate oidc provider name %q", p.Name)
+		}
+		names[p.Name] = struct{}{}
+	}
+	*c = providers
+	return nil
+}
+
 type AuthConfigs []appx.JWTProvider
 
 // Decode is a custom decoder for AuthConfigs
@@ -267,7 +419,8 @@ func (c Config) Print() string {
 	// struct, so every secret field must be listed here explicitly.
 	for _, secret := range []string{
 		c.DB, c.RestAPIKey, c.SwaggerBasicPass,
-		c.Auth0.ClientSecret, c.SignupSecret, c.SyncSSOAPIKey,
+		c.Auth0.ClientSecret, c.SignupSecret, c.SyncSSOAPIKey, c.LDAP.BindPassword,
+		c.ImpersonationSecret,
 	} {
 		if secret == "" {
 			continue
@@ -303,3 +456,12 @@ func (c Config) GetCIPProjectID() string  { return c.CIP.ProjectID }
 func (c Config) GetCIPTenantID() string   { return c.CIP.TenantID }
 func (c Config) GetCIPAPIKey() string     { return c.CIP.APIKey }
 func (c Config) GetCIPAuthDomain() string { return c.CIP.AuthDomain }
+
+// GetOIDCProviders returns the public settings of the generic OIDC providers.
+func (c Config) GetOIDCProviders() []adapter.OIDCProviderData {
+	res := make([]adapter.OIDCProviderData, 0, len(c.OIDCIdPs))
+	for _, p := range c.OIDCIdPs {
+		res = append(res, adapter.OIDCProviderData{Name: p.Name, Issuer: p.Issuer, ClientID: p.ClientID})
+	}
+	return res
+}
@@ -60,6 +60,8 @@ func GraphqlAPI(conf *Config, dev bool) echo.HandlerFunc {
 	// tracing with detailed operation tracking
 	srv.AroundOperations(detailedOperationTracer())
 
+	srv.AroundFields(denyImpersonatedMutations)
+
 	if conf.GraphQL.ComplexityLimit > 0 {
 		srv.Use(extension.FixedComplexityLimit(conf.GraphQL.ComplexityLimit))
 	}
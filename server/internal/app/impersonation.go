@@ -0,0 +1,139 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/adapter"
+	"github.com/reearth/reearth-accounts/server/internal/admin/usecase/adminaudituc"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/impersonation"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+// impersonationMiddleware authenticates requests carrying an impersonation
+// token issued by the admin API. It attaches the target user, the operator
+// built for them and the grant, so the auth middlewares that follow leave
+// the request alone. A token only holds while the admin session it was issued
+// in is signed in, so signing the admin out ends the impersonation too.
+//
+// Every impersonated request is appended to the admin audit trail before it
+// is served, with both the admin and the user; when that fails the request is
+// refused. A token that can't be verified is rejected rather than ignored, so
+// the admin never silently acts as someone else.
+func impersonationMiddleware(cfg *ServerConfig) func(http.Handler) http.Handler {
+	signer := impersonation.NewSigner(cfg.Config.ImpersonationSecret)
+	audit := adminaudituc.NewRecordUseCase(cfg.Repos.AdminAudit)
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+			token := req.Header.Get(impersonation.Header)
+			if token == "" {
+				next.ServeHTTP(w, req)
+				return
+			}
+			ctx := req.Context()
+
+			g, err := signer.Parse(token)
+			if err != nil {
+				log.Warnfc(ctx, "[impersonation] Rejecting request: %v", err)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			s, err := cfg.Repos.AdminSession.FindByID(ctx, g.Session)
+			if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+				log.Errorfc(ctx, "[impersonation] Database error finding admin session %s: %v", g.Session, err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			if s == nil || s.IsExpired(util.Now()) || s.AdminUser() != g.Admin {
+				log.Warnfc(ctx, "[impersonation] Rejecting request of admin=%s: session %s is not signed in", g.Admin, g.Session)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			usr, err := cfg.Repos.User.FindByID(ctx, g.User)
+			if err != nil {
+				if errors.Is(err, rerror.ErrNotFound) {
+					log.Warnfc(ctx, "[impersonation] Rejecting request of admin=%s: user %s not found", g.Admin, g.User)
+					http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+					return
+				}
+				log.Errorfc(ctx, "[impersonation] Database error finding user %s: %v", g.User, err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+			if usr.IsDeleted() {
+				log.Warnfc(ctx, "[impersonation] Rejecting request of admin=%s: user %s is deactivated", g.Admin, g.User)
+				http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+				return
+			}
+
+			op, err := generateUserOperator(ctx, cfg, usr)
+			if err != nil {
+				log.Errorfc(ctx, "[impersonation] Failed to generate operator for user %s: %v", usr.ID(), err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			log.Infofc(ctx, "[impersonation] admin=%s user=%s token=%s %s %s", g.Admin, g.User, g.TokenID, req.Method, req.URL.Path)
+			if _, err := audit.Execute(ctx, adminaudituc.RecordInput{
+				Actor:     g.Admin,
+				Action:    "IMPERSONATE " + req.Method + " " + req.URL.Path,
+				Target:    g.User.String(),
+				RequestID: req.Header.Get(echo.HeaderXRequestID),
+				IP:        clientIP(req),
+				Diff: map[string]string{
+					"session":  g.Session.String(),
+					"token_id": g.TokenID,
+				},
+			}); err != nil {
+				log.Errorfc(ctx, "[impersonation] Failed to record request of admin=%s user=%s: %v", g.Admin, g.User, err)
+				http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				return
+			}
+
+			ctx = adapter.AttachUser(ctx, usr)
+			ctx = adapter.AttachOperator(ctx, op)
+			ctx = adapter.AttachImpersonation(ctx, g)
+			next.ServeHTTP(w, req.WithContext(ctx))
+		})
+	}
+}
+
+// clientIP is the address of the client: the first hop of X-Forwarded-For
+// when a proxy set it, or the peer address otherwise.
+func clientIP(req *http.Request) string {
+	if xff := req.Header.Get(echo.HeaderXForwardedFor); xff != "" {
+		ip, _, _ := strings.Cut(xff, ",")
+		return strings.TrimSpace(ip)
+	}
+	if host, _, err := net.SplitHostPort(req.RemoteAddr); err == nil {
+		return host
+	}
+	return req.RemoteAddr
+}
+
+// denyImpersonatedMutations is a gqlgen field middleware that rejects every
+// mutation of an impersonated request: an admin may only read on the user's
+// behalf. Denying all of them rather than a list means a mutation added later
+// is denied too. adapterhttp.ImpersonationReadOnly does the same for REST.
+func denyImpersonatedMutations(ctx context.Context, next graphql.Resolver) (any, error) {
+	fc := graphql.GetFieldContext(ctx)
+	if fc == nil || fc.Object != "Mutation" {
+		return next(ctx)
+	}
+	g := adapter.Impersonation(ctx)
+	if g == nil {
+		return next(ctx)
+	}
+	log.Warnfc(ctx, "[impersonation] Denied mutation %s: admin=%s user=%s", fc.Field.Name, g.Admin, g.User)
+	return nil, interfaces.ErrImpersonationDenied
+}
@@ -0,0 +1,176 @@
+package app
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/reearth/reearth-accounts/server/internal/adapter"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/impersonation"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+const testImpersonationSecret = "impersonation-secret-impersonation"
+
+func TestImpersonationMiddleware(t *testing.T) {
+	uid := user.NewID()
+	u := user.New().ID(uid).Name("target").Email("target@example.com").MustBuild()
+	wid := workspace.NewID()
+	w := workspace.New().ID(wid).Name("ws").
+		Members(map[id.UserID]workspace.Member{uid: {Role: role.RoleOwner, InvitedBy: uid}}).
+		MustBuild()
+	gone := user.New().NewID().Name("gone").Email("gone@example.com").MustBuild()
+	gone.Deactivate()
+
+	repos := memory.New()
+	repos.User = memory.NewUserWith(u, gone)
+	repos.Workspace = memory.NewWorkspaceWith(w)
+	cfg := &ServerConfig{
+		Config: &Config{ImpersonationSecret: testImpersonationSecret},
+		Repos:  repos,
+	}
+	signer := impersonation.NewSigner(testImpersonationSecret)
+	admin := adminuser.NewID()
+	now := time.Now()
+	session := adminsession.New().NewID().AdminUser(admin).CreatedAt(now).ExpiresAt(now.Add(time.Hour)).MustBuild()
+	expiredSession := adminsession.New().NewID().AdminUser(admin).CreatedAt(now.Add(-2 * time.Hour)).ExpiresAt(now.Add(-time.Hour)).MustBuild()
+	otherSession := adminsession.New().NewID().AdminUser(adminuser.NewID()).CreatedAt(now).ExpiresAt(now.Add(time.Hour)).MustBuild()
+	ctx := context.Background()
+	require.NoError(t, repos.AdminSession.Save(ctx, session))
+	require.NoError(t, repos.AdminSession.Save(ctx, expiredSession))
+	require.NoError(t, repos.AdminSession.Save(ctx, otherSession))
+
+	serve := func(token string) (*httptest.ResponseRecorder, context.Context) {
+		var captured context.Context
+		next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			captured = r.Context()
+			w.WriteHeader(http.StatusOK)
+		})
+		req := httptest.NewRequest(http.MethodPost, "/api/graphql", nil)
+		if token != "" {
+			req.Header.Set(impersonation.Header, token)
+		}
+		rr := httptest.NewRecorder()
+		impersonationMiddleware(cfg)(authMiddleware(cfg)(next)).ServeHTTP(rr, req)
+		return rr, captured
+	}
+
+	t.Run("should attach the target user, operator and grant", func(t *testing.T) {
+		token, g, err := signer.Issue(admin, session.ID(), uid, time.Now(), time.Minute)
+		require.NoError(t, err)
+
+		rr, ctx := serve(token)
+		require.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, uid, adapter.User(ctx).ID())
+		op := adapter.Operator(ctx)
+		require.NotNil(t, op)
+		assert.Equal(t, uid, *op.User)
+		assert.True(t, op.IsOwningWorkspace(wid))
+		assert.Equal(t, g.TokenID, adapter.Impersonation(ctx).TokenID)
+		assert.Equal(t, admin, adapter.Impersonation(ctx).Admin)
+	})
+
+	t.Run("should record the request in the admin audit trail", func(t *testing.T) {
+		token, g, err := signer.Issue(admin, session.ID(), uid, time.Now(), time.Minute)
+		require.NoError(t, err)
+
+		rr, _ := serve(token)
+		require.Equal(t, http.StatusOK, rr.Code)
+		last, err := repos.AdminAudit.FindLast(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, admin, last.Actor())
+		assert.Equal(t, "IMPERSONATE POST /api/graphql", last.Action())
+		assert.Equal(t, uid.String(), last.Target())
+		assert.Equal(t, g.TokenID, last.Diff()["token_id"])
+		assert.Equal(t, session.ID().String(), last.Diff()["session"])
+	})
+
+	t.Run("should leave requests without a token to the auth middleware", func(t *testing.T) {
+		rr, _ := serve("")
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+
+	t.Run("should reject bad tokens", func(t *testing.T) {
+		expired, _, err := signer.Issue(admin, session.ID(), uid, time.Now().Add(-time.Hour), time.Minute)
+		require.NoError(t, err)
+		missing, _, err := signer.Issue(admin, session.ID(), user.NewID(), time.Now(), time.Minute)
+		require.NoError(t, err)
+		deactivated, _, err := signer.Issue(admin, session.ID(), gone.ID(), time.Now(), time.Minute)
+		require.NoError(t, err)
+		signedOut, _, err := signer.Issue(admin, adminsession.NewID(), uid, time.Now(), time.Minute)
+		require.NoError(t, err)
+		sessionExpired, _, err := signer.Issue(admin, expiredSession.ID(), uid, time.Now(), time.Minute)
+		require.NoError(t, err)
+		othersSession, _, err := signer.Issue(admin, otherSession.ID(), uid, time.Now(), time.Minute)
+		require.NoError(t, err)
+
+		for name, token := range map[string]string{
+			"garbage":         "garbage",
+			"expired":         expired,
+			"missing":         missing,
+			"deactivated":     deactivated,
+			"signed out":      signedOut,
+			"session expired": sessionExpired,
+			"other's session": othersSession,
+		} {
+			rr, ctx := serve(token)
+			assert.Equal(t, http.StatusUnauthorized, rr.Code, name)
+			assert.Nil(t, ctx, name)
+		}
+	})
+
+	t.Run("should reject tokens when impersonation is disabled", func(t *testing.T) {
+		token, _, err := signer.Issue(admin, session.ID(), uid, time.Now(), time.Minute)
+		require.NoError(t, err)
+
+		disabled := &ServerConfig{Config: &Config{}, Repos: repos}
+		req := httptest.NewRequest(http.MethodPost, "/api/graphql", nil)
+		req.Header.Set(impersonation.Header, token)
+		rr := httptest.NewRecorder()
+		impersonationMiddleware(disabled)(http.NotFoundHandler()).ServeHTTP(rr, req)
+		assert.Equal(t, http.StatusUnauthorized, rr.Code)
+	})
+}
+
+func TestDenyImpersonatedMutations(t *testing.T) {
+	resolved := func(ctx context.Context) (any, error) { return "ok", nil }
+	field := func(ctx context.Context, object, name string) context.Context {
+		return graphql.WithFieldContext(ctx, &graphql.FieldContext{
+			Object: object,
+			Field:  graphql.CollectedField{Field: &ast.Field{Name: name}},
+		})
+	}
+	impersonated := adapter.AttachImpersonation(context.Background(), &impersonation.Grant{
+		Admin: adminuser.NewID(),
+		User:  user.NewID(),
+	})
+
+	_, err := denyImpersonatedMutations(field(impersonated, "Mutation", "deleteMe"), resolved)
+	assert.ErrorIs(t, err, interfaces.ErrImpersonationDenied)
+	_, err = denyImpersonatedMutations(field(impersonated, "Mutation", "transferWorkspaceOwnership"), resolved)
+	assert.ErrorIs(t, err, interfaces.ErrImpersonationDenied)
+	_, err = denyImpersonatedMutations(field(impersonated, "Mutation", "createWorkspace"), resolved)
+	assert.ErrorIs(t, err, interfaces.ErrImpersonationDenied)
+	_, err = denyImpersonatedMutations(field(impersonated, "Mutation", "uploadMyPhoto"), resolved)
+	assert.ErrorIs(t, err, interfaces.ErrImpersonationDenied)
+
+	res, err := denyImpersonatedMutations(field(impersonated, "Query", "me"), resolved)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", res)
+	res, err = denyImpersonatedMutations(field(context.Background(), "Mutation", "deleteMe"), resolved)
+	assert.NoError(t, err)
+	assert.Equal(t, "ok", res)
+}
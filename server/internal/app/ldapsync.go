@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearthx/log"
+)
+
+// runLDAPSync syncs the LDAP directory at startup and then every interval
+// until ctx is done. Only one instance syncs at a time; the others skip the
+// run.
+func runLDAPSync(ctx context.Context, uc interfaces.LDAPSync, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	sync := func() {
+		if _, err := uc.Sync(ctx); err != nil {
+			if errors.Is(err, interfaces.ErrLDAPSyncRunning) {
+				log.Infofc(ctx, "[ldapSync] skipped: another instance is syncing")
+				return
+			}
+			log.Errorfc(ctx, "[ldapSync] failed: %v", err)
+		}
+	}
+
+	sync()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			sync()
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package app
+
+import (
+	"context"
+
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/adapter/oidc"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interactor"
+	"github.com/reearth/reearthx/log"
+)
+
+// initOIDCProvider mounts the built-in OIDC provider when an issuer is
+// configured. Its tokens are validated like any other issuer's, through the
+// provider entry that Config.Auths() adds for it.
+func initOIDCProvider(ctx context.Context, e *echo.Echo, cfg *ServerConfig) {
+	c := cfg.Config.OIDC
+	if c.Issuer == "" {
+		return
+	}
+
+	keys, err := oidc.LoadKeys(ctx, cfg.Repos.Config)
+	if err != nil {
+		log.Fatalf("oidc: failed to load signing keys: %+v\n", err)
+	}
+	go keys.RefreshEvery(context.WithoutCancel(ctx), c.KeyRefreshInterval)
+
+	redirectURIs := c.RedirectURIs
+	if len(redirectURIs) == 0 && cfg.Config.HostWeb != "" {
+		redirectURIs = []string{cfg.Config.HostWeb}
+	}
+
+	users := interactor.NewUser(cfg.Repos, cfg.Gateways, nil, cfg.Config.SignupSecret, cfg.Config.HostWeb).(*interactor.User)
+	oidc.New(oidc.Config{
+		Issuer:          c.Issuer,
+		ClientID:        c.ClientID,
+		RedirectURIs:    redirectURIs,
+		LoginURL:        cfg.Config.HostWeb + "/login",
+		AccessTokenTTL:  c.AccessTokenTTL,
+		RefreshTokenTTL: c.RefreshTokenTTL,
+
+		RefreshTokenMaxLifetime: c.RefreshTokenMaxLifetime,
+	}, keys, users).Register(e)
+
+	log.Infofc(ctx, "oidc: built-in provider enabled: issuer=%s", c.Issuer)
+}
@@ -0,0 +1,40 @@
+package app
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearthx/log"
+)
+
+// runPurge purges the users and workspaces whose retention window has passed
+// at startup and then every interval until ctx is done. Only one instance
+// purges at a time; the others skip the run.
+func runPurge(ctx context.Context, uc interfaces.Purge, interval time.Duration) {
+	if interval <= 0 {
+		interval = time.Hour
+	}
+	purge := func() {
+		if _, err := uc.Purge(ctx); err != nil {
+			if errors.Is(err, interfaces.ErrPurgeRunning) {
+				log.Infofc(ctx, "[purge] skipped: another instance is purging")
+				return
+			}
+			log.Errorfc(ctx, "[purge] failed: %v", err)
+		}
+	}
+
+	purge()
+	t := time.NewTicker(interval)
+	defer t.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-t.C:
+			purge()
+		}
+	}
+}
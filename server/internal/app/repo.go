@@ -6,9 +6,14 @@ import (
 	"github.com/jackc/pgx/v5/pgxpool"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/auth0"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/cip"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/ldap"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/local"
 	mongorepo "github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/oidcidp"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/passkey"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/storage"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/tokenverifier"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
@@ -18,7 +23,7 @@ import (
 	"go.mongodb.org/mongo-driver/mongo"
 )
 
-func initGateways(ctx context.Context, conf *Config) *gateway.Container {
+func initGateways(ctx context.Context, conf *Config, users user.Repo) *gateway.Container {
 	str, err := storage.NewGCPStorage(&storage.Config{
 		IsLocal:          conf.StorageIsLocal,
 		BucketName:       conf.StorageBucketName,
@@ -35,7 +40,10 @@ func initGateways(ctx context.Context, conf *Config) *gateway.Container {
 	// ResendVerificationEmail) are routed by each user's auth record provider
 	// rather than swapping a single authenticator globally. This keeps Auth0
 	// subs going to Auth0 and CIP subs going to Firebase when both coexist.
-	authenticators := map[gateway.Provider]gateway.Authenticator{}
+	authenticators := map[gateway.Provider]gateway.Authenticator{
+		// Native TOTP MFA for the built-in password provider is always available.
+		gateway.ProviderReearth: local.New(users),
+	}
 	if conf.Auth0.Domain != "" {
 		authenticators[gateway.ProviderAuth0] = auth0.New(conf.Auth0.Domain, conf.Auth0.ClientID, conf.Auth0.ClientSecret, conf.Auth0.HTTPTimeout)
 	}
@@ -53,11 +61,44 @@ func initGateways(ctx context.Context, conf *Config) *gateway.Container {
 		}
 		authenticators[gateway.ProviderCIP] = cipAuth
 	}
+	for _, p := range conf.OIDCIdPs {
+		authenticators[gateway.Provider(p.Name)] = oidcidp.New(p)
+	}
+
+	var passkeyGateway gateway.Passkey
+	if conf.WebAuthn.RPID != "" {
+		pk, pkErr := passkey.New(passkey.Config{
+			RPID:          conf.WebAuthn.RPID,
+			RPDisplayName: conf.WebAuthn.RPDisplayName,
+			RPOrigins:     conf.WebAuthn.RPOrigins,
+		})
+		if pkErr != nil {
+			log.Fatalf("Failed to init WebAuthn: %+v\n", pkErr)
+		}
+		passkeyGateway = pk
+	}
+
+	var tokenVerifier gateway.TokenVerifier
+	if auths := conf.Auths(); !conf.Mock_Auth && len(auths) > 0 {
+		tv, tvErr := tokenverifier.New(auths)
+		if tvErr != nil {
+			log.Fatalf("Failed to init token verifier: %+v\n", tvErr)
+		}
+		tokenVerifier = tv
+	}
+
+	var directory gateway.Directory
+	if conf.LDAP.URL != "" {
+		directory = ldap.New(conf.LDAP.Directory())
+	}
 
 	return &gateway.Container{
 		Mailer:         mailerInstance,
 		Authenticators: authenticators,
+		Directory:      directory,
+		Passkey:        passkeyGateway,
 		Storage:        str,
+		TokenVerifier:  tokenVerifier,
 	}
 }
 
@@ -66,7 +107,7 @@ func initPostgresReposAndGateways(ctx context.Context, pool *pgxpool.Pool, conf
 	if err != nil {
 		log.Fatalf("Failed to init postgres: %+v\n", err)
 	}
-	return repos, initGateways(ctx, conf)
+	return repos, initGateways(ctx, conf, repos.User)
 }
 
 func initReposAndGateways(ctx context.Context, client *mongo.Client, conf *Config) (*repo.Container, *gateway.Container) {
@@ -77,5 +118,5 @@ func initReposAndGateways(ctx context.Context, client *mongo.Client, conf *Confi
 		log.Fatalf("Failed to init mongo: %+v\n", err)
 	}
 
-	return repos, initGateways(ctx, conf)
+	return repos, initGateways(ctx, conf, repos.User)
 }
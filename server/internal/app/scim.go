@@ -0,0 +1,14 @@
+package app
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/reearth/reearth-accounts/server/internal/adapter/scim"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interactor"
+)
+
+// initSCIM mounts the SCIM provisioning API. It authenticates with the bearer
+// tokens of the SCIM tenants registered from the admin API, so it is inert
+// until a tenant is created.
+func initSCIM(e *echo.Echo, cfg *ServerConfig) {
+	scim.New(interactor.NewSCIM(cfg.Repos)).Register(e)
+}
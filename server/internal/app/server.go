@@ -19,6 +19,7 @@ import (
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo/migration"
 	pgmigration "github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/migration"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interactor"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
 
 	otelapp "github.com/reearth/reearth-accounts/server/internal/app/otel"
@@ -160,6 +161,11 @@ func Start(debug bool) {
 	}
 	cerbosAdapter := infraCerbos.NewCerbosAdapter(cerbosClient)
 
+	if gateways.Directory != nil {
+		go runLDAPSync(ctx, interactor.NewLDAPSync(repos, gateways.Directory, conf.LDAP.MaxRemovals, conf.LDAP.MaxRemovalRatio), conf.LDAP.SyncInterval)
+	}
+	go runPurge(ctx, interactor.NewPurge(repos, gateways, conf.Deletion.Retention, conf.Deletion.NoticePeriod, conf.HostWeb), conf.Deletion.PurgeInterval)
+
 	// Start web server
 	NewServer(ctx, &ServerConfig{
 		Config:        conf,
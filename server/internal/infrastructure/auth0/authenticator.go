@@ -310,9 +310,11 @@ func (a *Auth0) invalidateMFAStatusCache(sub string) {
 	delete(a.mfaStatusCache, sub)
 }
 
-func (a *Auth0) RegenerateMFARecoveryCode(ctx context.Context, sub string) (string, error) {
+// RegenerateMFARecoveryCode returns the single recovery code Auth0 keeps per
+// user.
+func (a *Auth0) RegenerateMFARecoveryCode(ctx context.Context, sub string) ([]string, error) {
 	if err := a.updateToken(ctx); err != nil {
-		return "", err
+		return nil, err
 	}
 
 	var r struct {
@@ -322,10 +324,10 @@ func (a *Auth0) RegenerateMFARecoveryCode(ctx context.Context, sub string) (stri
 		if !a.disableLogging {
 			log.Errorf("auth0: regenerate mfa recovery code: %+v", err)
 		}
-		return "", rerror.NewE(i18n.T("failed to regenerate mfa recovery code"))
+		return nil, rerror.NewE(i18n.T("failed to regenerate mfa recovery code"))
 	}
 
-	return r.RecoveryCode, nil
+	return []string{r.RecoveryCode}, nil
 }
 
 func (a *Auth0) needsFetchToken() bool {
@@ -78,7 +78,7 @@ func TestAuth0_RegenerateMFARecoveryCode(t *testing.T) {
 
 	got, err := a.RegenerateMFARecoveryCode(context.Background(), userID)
 	assert.NoError(t, err)
-	assert.Equal(t, recoveryCode, got)
+	assert.Equal(t, []string{recoveryCode}, got)
 }
 
 func TestAuth0_RegenerateMFARecoveryCode_NoEnrollment(t *testing.T) {
@@ -157,8 +157,8 @@ func (a *Authenticator) GetMFAStatus(_ context.Context, _ string) (gateway.MFASt
 	return gateway.MFAStatus{}, nil
 }
 
-func (a *Authenticator) RegenerateMFARecoveryCode(_ context.Context, _ string) (string, error) {
-	return "", nil
+func (a *Authenticator) RegenerateMFARecoveryCode(_ context.Context, _ string) ([]string, error) {
+	return nil, nil
 }
 
 func toAuthenticatorUser(rec *fbauth.UserRecord) gateway.AuthenticatorUser {
@@ -7,10 +7,20 @@ import (
 	"time"
 
 	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminstats"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
 	"github.com/reearth/reearth-accounts/server/pkg/config"
 	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearth-accounts/server/pkg/passkeylogin"
 	"github.com/reearth/reearth-accounts/server/pkg/permittable"
 	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/rerror"
@@ -49,6 +59,7 @@ func Run(t *testing.T, nc Factory) {
 	t.Run("User_FindByIDs_Ordering", func(t *testing.T) { testUserFindByIDsOrdering(t, nc) })
 	t.Run("User_SearchByKeyword", func(t *testing.T) { testUserSearch(t, nc) })
 	t.Run("User_Pagination", func(t *testing.T) { testUserPagination(t, nc) })
+	t.Run("User_FindDeletedBefore", func(t *testing.T) { testUserFindDeletedBefore(t, nc) })
 	t.Run("Workspace_CRUD_Members", func(t *testing.T) { testWorkspaceCRUD(t, nc) })
 	t.Run("Workspace_SaveUpdate", func(t *testing.T) { testWorkspaceSaveUpdate(t, nc) })
 	t.Run("Workspace_FindByName_Alias", func(t *testing.T) { testWorkspaceFindByNameAlias(t, nc) })
@@ -59,14 +70,26 @@ func Run(t *testing.T, nc Factory) {
 	t.Run("Workspace_SaveAll_RemoveAll", func(t *testing.T) { testWorkspaceSaveAllRemoveAll(t, nc) })
 	t.Run("Workspace_Remove", func(t *testing.T) { testWorkspaceRemove(t, nc) })
 	t.Run("Workspace_Filtered", func(t *testing.T) { testWorkspaceFiltered(t, nc) })
+	t.Run("Workspace_FindDeletedBefore", func(t *testing.T) { testWorkspaceFindDeletedBefore(t, nc) })
 	t.Run("Role_CRUD", func(t *testing.T) { testRoleCRUD(t, nc) })
 	t.Run("Role_FindAll_FindByIDs", func(t *testing.T) { testRoleFindAllAndByIDs(t, nc) })
 	t.Run("Permittable_RoleQueries", func(t *testing.T) { testPermittable(t, nc) })
 	t.Run("Permittable_WorkspaceRoles", func(t *testing.T) { testPermittableWorkspaceRoles(t, nc) })
 	t.Run("Permittable_FindByUserIDs_SaveMany", func(t *testing.T) { testPermittableFindByUserIDsAndSaveMany(t, nc) })
 	t.Run("Permittable_NotFound", func(t *testing.T) { testPermittableNotFound(t, nc) })
+	t.Run("Permittable_RemoveByUserID", func(t *testing.T) { testPermittableRemoveByUserID(t, nc) })
 	t.Run("Config_LockLoadSave", func(t *testing.T) { testConfig(t, nc) })
 	t.Run("Config_SaveAuth", func(t *testing.T) { testConfigSaveAuth(t, nc) })
+	t.Run("Config_Keys", func(t *testing.T) { testConfigKeys(t, nc) })
+	t.Run("SCIMTenant_CRUD", func(t *testing.T) { testSCIMTenant(t, nc) })
+	t.Run("AuditLog_SaveFind", func(t *testing.T) { testAuditLog(t, nc) })
+	t.Run("RoleMapping_CRUD", func(t *testing.T) { testRoleMapping(t, nc) })
+	t.Run("LDAPSync_SaveFind", func(t *testing.T) { testLDAPSync(t, nc) })
+	t.Run("AdminAudit_AppendFind", func(t *testing.T) { testAdminAudit(t, nc) })
+	t.Run("AdminSession_CRUD", func(t *testing.T) { testAdminSession(t, nc) })
+	t.Run("AdminApprovalRule_CRUD", func(t *testing.T) { testAdminApprovalRule(t, nc) })
+	t.Run("AdminStats_Compute", func(t *testing.T) { testAdminStats(t, nc) })
+	t.Run("PasskeyLogin_SaveTake", func(t *testing.T) { testPasskeyLogin(t, nc) })
 	t.Run("Transaction_CommitRollback", func(t *testing.T) { testTransaction(t, nc) })
 }
 
@@ -340,6 +363,29 @@ func testUserSearch(t *testing.T, nc Factory) {
 	assert.Equal(t, u.ID(), res[0].ID())
 }
 
+func testUserFindDeletedBefore(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	old, recent := timeFixed(), timeFixed().Add(time.Hour)
+	a, err := user.New().NewID().Name("deleted-old").Email("old@example.com").Workspace(id.NewWorkspaceID()).
+		DeletedAt(&old).DeletionRequestedAt(&old).PurgeNotifiedAt(&recent).Build()
+	require.NoError(t, err)
+	b, err := user.New().NewID().Name("deleted-recent").Email("recent@example.com").Workspace(id.NewWorkspaceID()).
+		DeletedAt(&recent).Build()
+	require.NoError(t, err)
+	require.NoError(t, c.User.Save(ctx, a))
+	require.NoError(t, c.User.Save(ctx, b))
+	require.NoError(t, c.User.Save(ctx, newUser(t, "active", "active@example.com")))
+
+	got, err := c.User.FindDeletedBefore(ctx, recent)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, a.ID(), got[0].ID())
+	assert.True(t, old.Equal(*got[0].DeletionRequestedAt()))
+	assert.True(t, recent.Equal(*got[0].PurgeNotifiedAt()))
+}
+
 func testUserPagination(t *testing.T, nc Factory) {
 	c, caps, done := nc(t)
 	defer done()
@@ -513,6 +559,25 @@ func testWorkspaceRemove(t *testing.T, nc Factory) {
 	assert.ErrorIs(t, err, rerror.ErrNotFound)
 }
 
+func testWorkspaceFindDeletedBefore(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	uid := id.NewUserID()
+	members := map[id.UserID]workspace.Member{uid: {Role: role.RoleOwner, InvitedBy: uid}}
+	old, recent := timeFixed(), timeFixed().Add(time.Hour)
+	a, err := workspace.New().NewID().Name("deleted-old").Members(members).DeletedAt(&old).Build()
+	require.NoError(t, err)
+	b, err := workspace.New().NewID().Name("deleted-recent").Members(members).DeletedAt(&recent).Build()
+	require.NoError(t, err)
+	require.NoError(t, c.Workspace.SaveAll(ctx, workspace.List{a, b, newWorkspace(t, "active", uid)}))
+
+	got, err := c.Workspace.FindDeletedBefore(ctx, recent)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, a.ID(), got[0].ID())
+}
+
 func testWorkspaceFiltered(t *testing.T, nc Factory) {
 	c, caps, done := nc(t)
 	defer done()
@@ -655,6 +720,27 @@ func testPermittableNotFound(t *testing.T, nc Factory) {
 	assert.ErrorIs(t, err, rerror.ErrNotFound)
 }
 
+func testPermittableRemoveByUserID(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	u1, u2 := id.NewUserID(), id.NewUserID()
+	rid := id.NewRoleID()
+	p1, err := permittable.New().NewID().UserID(u1).RoleIDs([]id.RoleID{rid}).
+		WorkspaceRoles([]permittable.WorkspaceRole{permittable.NewWorkspaceRole(id.NewWorkspaceID(), rid)}).Build()
+	require.NoError(t, err)
+	p2 := newPermittable(t, u2, rid)
+	require.NoError(t, c.Permittable.SaveMany(ctx, permittable.List{p1, &p2}))
+
+	require.NoError(t, c.Permittable.RemoveByUserID(ctx, u1))
+	_, err = c.Permittable.FindByUserID(ctx, u1)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	got, err := c.Permittable.FindByRoleID(ctx, rid)
+	require.NoError(t, err)
+	require.Len(t, got, 1)
+	assert.Equal(t, u2, got[0].UserID())
+}
+
 func testConfig(t *testing.T, nc Factory) {
 	c, _, done := nc(t)
 	defer done()
@@ -665,12 +751,22 @@ func testConfig(t *testing.T, nc Factory) {
 		cfg = &config.Config{}
 	}
 	cfg.Migration = 7
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg.PurgeSince = &since
 	require.NoError(t, c.Config.SaveAndUnlock(ctx, cfg))
 
 	reloaded, err := c.Config.LockAndLoad(ctx)
 	require.NoError(t, err)
 	require.NotNil(t, reloaded)
 	assert.Equal(t, int64(7), reloaded.Migration)
+	require.NotNil(t, reloaded.PurgeSince)
+	assert.True(t, since.Equal(*reloaded.PurgeSince))
+
+	// Load does not wait for the lock held above
+	loaded, err := c.Config.Load(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, loaded)
+	assert.Equal(t, int64(7), loaded.Migration)
 	require.NoError(t, c.Config.Unlock(ctx))
 }
 
@@ -689,6 +785,421 @@ func testConfigSaveAuth(t *testing.T, nc Factory) {
 	require.NoError(t, c.Config.Unlock(ctx))
 }
 
+func testConfigKeys(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	activated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	retired := activated.Add(48 * time.Hour)
+	keys := []config.Auth{
+		{Cert: "cert-1", Key: "key-1", KID: "kid-1", ActivatedAt: activated, RetiredAt: &retired},
+		{Cert: "cert-2", Key: "key-2", KID: "kid-2", ActivatedAt: activated.Add(24 * time.Hour)},
+	}
+
+	cfg, err := c.Config.LockAndLoad(ctx)
+	require.NoError(t, err)
+	if cfg == nil {
+		cfg = &config.Config{}
+	}
+	cfg.Keys = keys
+	require.NoError(t, c.Config.SaveAndUnlock(ctx, cfg))
+
+	reloaded, err := c.Config.LockAndLoad(ctx)
+	require.NoError(t, err)
+	require.NotNil(t, reloaded)
+	require.Len(t, reloaded.Keys, 2)
+	for i, k := range reloaded.Keys {
+		assert.Equal(t, keys[i].KID, k.KID)
+		assert.Equal(t, keys[i].Cert, k.Cert)
+		assert.Equal(t, keys[i].Key, k.Key)
+		assert.True(t, keys[i].ActivatedAt.Equal(k.ActivatedAt))
+	}
+	require.NotNil(t, reloaded.Keys[0].RetiredAt)
+	assert.True(t, retired.Equal(*reloaded.Keys[0].RetiredAt))
+	assert.Nil(t, reloaded.Keys[1].RetiredAt)
+	require.NoError(t, c.Config.Unlock(ctx))
+}
+
+func testSCIMTenant(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	uid := id.NewUserID()
+	wid := id.NewWorkspaceID()
+	tn := scimtenant.New().NewID().Name("okta").SubPrefix("samlp|okta").
+		Users(id.UserIDList{uid}).
+		Groups([]scimtenant.Group{{Workspace: wid, Role: role.RoleWriter}}).
+		MustBuild()
+	token, err := tn.RotateToken()
+	require.NoError(t, err)
+	require.NoError(t, c.SCIMTenant.Save(ctx, tn))
+
+	got, err := c.SCIMTenant.FindByTokenHash(ctx, scimtenant.HashToken(token))
+	require.NoError(t, err)
+	assert.Equal(t, tn.ID(), got.ID())
+	assert.Equal(t, "samlp|okta", got.SubPrefix())
+	assert.Equal(t, id.UserIDList{uid}, got.Users())
+	assert.Equal(t, []scimtenant.Group{{Workspace: wid, Role: role.RoleWriter}}, got.Groups())
+	_, err = c.SCIMTenant.FindByTokenHash(ctx, "")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	got.RemoveUser(uid)
+	require.NoError(t, c.SCIMTenant.Save(ctx, got))
+	byID, err := c.SCIMTenant.FindByID(ctx, tn.ID())
+	require.NoError(t, err)
+	assert.Empty(t, byID.Users())
+	assert.Equal(t, id.UserIDList{uid}, byID.Deprovisioned())
+
+	all, err := c.SCIMTenant.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Contains(t, all.IDs(), tn.ID())
+
+	require.NoError(t, c.SCIMTenant.Remove(ctx, tn.ID()))
+	_, err = c.SCIMTenant.FindByID(ctx, tn.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func testAuditLog(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	actor := id.NewAdminUserID()
+	target := id.NewUserID().String()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	second := auditlog.New().NewID().Actor(actor).Action(auditlog.ActionUserMerge).Target(target).
+		CreatedAt(now.Add(time.Second)).MustBuild()
+	first := auditlog.New().NewID().Actor(actor).Action(auditlog.ActionUserMerge).Target(target).
+		Detail(map[string]string{"source": "s"}).CreatedAt(now).MustBuild()
+	other := auditlog.New().NewID().Actor(actor).Action(auditlog.ActionUserMerge).Target("other").
+		CreatedAt(now).MustBuild()
+	for _, e := range []*auditlog.Entry{second, first, other} {
+		require.NoError(t, c.AuditLog.Save(ctx, e))
+	}
+
+	got, err := c.AuditLog.FindByTarget(ctx, target)
+	require.NoError(t, err)
+	assert.Equal(t, auditlog.IDList{first.ID(), second.ID()}, got.IDs())
+	assert.Equal(t, actor, got[0].Actor())
+	assert.Equal(t, auditlog.ActionUserMerge, got[0].Action())
+	assert.Equal(t, map[string]string{"source": "s"}, got[0].Detail())
+	assert.True(t, now.Equal(got[0].CreatedAt()))
+}
+
+func testRoleMapping(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	wid := id.NewWorkspaceID()
+	iss := "https://example.auth0.com/"
+	rules := []rolemapping.Rule{{Claim: "groups", Value: "gis-editors", Role: role.RoleWriter}}
+	m := rolemapping.New().NewID().Workspace(wid).Issuer(iss).SubPrefix("samlp|org_1").Rules(rules).MustBuild()
+	other := rolemapping.New().NewID().Workspace(id.NewWorkspaceID()).Issuer("https://other.example.com/").MustBuild()
+	require.NoError(t, c.RoleMapping.Save(ctx, m))
+	require.NoError(t, c.RoleMapping.Save(ctx, other))
+
+	got, err := c.RoleMapping.FindByWorkspace(ctx, wid)
+	require.NoError(t, err)
+	assert.Equal(t, m.ID(), got.ID())
+	assert.Equal(t, "samlp|org_1", got.SubPrefix())
+	assert.Equal(t, rules, got.Rules())
+
+	byIssuer, err := c.RoleMapping.FindByIssuer(ctx, iss)
+	require.NoError(t, err)
+	assert.Equal(t, rolemapping.IDList{m.ID()}, byIssuer.IDs())
+
+	// A new mapping of the same workspace replaces the previous one.
+	replaced := rolemapping.New().NewID().Workspace(wid).Issuer(iss).MustBuild()
+	require.NoError(t, c.RoleMapping.Save(ctx, replaced))
+	all, err := c.RoleMapping.FindAll(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, rolemapping.IDList{replaced.ID(), other.ID()}, all.IDs())
+
+	require.NoError(t, c.RoleMapping.RemoveByWorkspace(ctx, wid))
+	_, err = c.RoleMapping.FindByWorkspace(ctx, wid)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func testLDAPSync(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	uid := id.NewUserID()
+
+	_, err := c.LDAPSync.FindLastSucceeded(ctx)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	first := ldapsync.NewRun(now.Add(-2 * time.Hour))
+	first.AddUser(uid)
+	first.AddCreated(ldapsync.UserChange{User: uid, Sub: "ldap|alice", Email: "alice@example.com"})
+	first.Succeed(now.Add(-2 * time.Hour).Add(time.Second))
+	failed := ldapsync.NewRun(now.Add(-time.Hour))
+	failed.Fail(now.Add(-time.Hour).Add(time.Second), fmt.Errorf("connection refused"))
+	running := ldapsync.NewRun(now)
+	for _, r := range []*ldapsync.Run{first, failed, running} {
+		require.NoError(t, c.LDAPSync.Save(ctx, r))
+	}
+
+	got, err := c.LDAPSync.FindByID(ctx, first.ID())
+	require.NoError(t, err)
+	assert.Equal(t, ldapsync.StatusSucceeded, got.Status())
+	assert.Equal(t, id.UserIDList{uid}, got.Users())
+	assert.Equal(t, first.Created(), got.Created())
+
+	last, err := c.LDAPSync.FindLastSucceeded(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID(), last.ID())
+
+	recent, err := c.LDAPSync.FindRecent(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, ldapsync.IDList{running.ID(), failed.ID()}, recent.IDs())
+
+	// Saving a run again replaces it.
+	running.Succeed(now.Add(time.Second))
+	require.NoError(t, c.LDAPSync.Save(ctx, running))
+	last, err = c.LDAPSync.FindLastSucceeded(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, running.ID(), last.ID())
+
+	_, err = c.LDAPSync.FindByID(ctx, ldapsync.NewID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func testAdminAudit(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	alice, bob := id.NewAdminUserID(), id.NewAdminUserID()
+
+	_, err := c.AdminAudit.FindLast(ctx)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	var chain adminaudit.List
+	var prev *adminaudit.Record
+	for i, actor := range []id.AdminUserID{alice, bob, alice} {
+		r := adminaudit.New().NewID().Chain(prev).Actor(actor).Action("PATCH /api/v1/users/:id").
+			Target(fmt.Sprintf("u%d", i)).Diff(map[string]string{"name": `"n"`}).Status(200).
+			CreatedAt(now.Add(time.Duration(i) * time.Second)).MustBuild()
+		require.NoError(t, c.AdminAudit.Append(ctx, r))
+		chain = append(chain, r)
+		prev = r
+	}
+
+	// Another record at a taken position is refused.
+	dup := adminaudit.New().NewID().Chain(chain[1]).Actor(bob).Action("DELETE /api/v1/users/:id").MustBuild()
+	assert.ErrorIs(t, c.AdminAudit.Append(ctx, dup), adminaudit.ErrSeqTaken)
+
+	last, err := c.AdminAudit.FindLast(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, chain[2].ID(), last.ID())
+	assert.Equal(t, chain[2].Hash(), last.Hash())
+
+	after, err := c.AdminAudit.FindAfter(ctx, 1, 10)
+	require.NoError(t, err)
+	assert.Equal(t, adminaudit.IDList{chain[1].ID(), chain[2].ID()}, after.IDs())
+	all, err := c.AdminAudit.FindAfter(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.NoError(t, adminaudit.Verify(nil, all))
+
+	got, pi, err := c.AdminAudit.List(ctx, adminaudit.ListFilter{Actor: &alice})
+	require.NoError(t, err)
+	assert.Equal(t, adminaudit.IDList{chain[2].ID(), chain[0].ID()}, got.IDs())
+	assert.Equal(t, int64(2), pi.TotalCount)
+	assert.Equal(t, map[string]string{"name": `"n"`}, got[1].Diff())
+
+	since := now.Add(time.Second)
+	got, _, err = c.AdminAudit.List(ctx, adminaudit.ListFilter{Since: &since, Target: "u1"})
+	require.NoError(t, err)
+	assert.Equal(t, adminaudit.IDList{chain[1].ID()}, got.IDs())
+
+	got, pi, err = c.AdminAudit.List(ctx, adminaudit.ListFilter{
+		Pagination: usecasex.OffsetPagination{Offset: 1, Limit: 1}.Wrap(),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, adminaudit.IDList{chain[1].ID()}, got.IDs())
+	assert.True(t, pi.HasNextPage)
+	assert.True(t, pi.HasPreviousPage)
+}
+
+func testAdminSession(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	alice, bob := id.NewAdminUserID(), id.NewAdminUserID()
+
+	older := adminsession.New().NewID().AdminUser(alice).UserAgent("ua").IP("203.0.113.1").
+		CreatedAt(now.Add(-time.Hour)).ExpiresAt(now.Add(-time.Minute)).MustBuild()
+	newer := adminsession.New().NewID().AdminUser(alice).
+		CreatedAt(now).ExpiresAt(now.Add(time.Hour)).MustBuild()
+	other := adminsession.New().NewID().AdminUser(bob).
+		CreatedAt(now).ExpiresAt(now.Add(time.Hour)).MustBuild()
+	for _, s := range []*adminsession.Session{older, newer, other} {
+		require.NoError(t, c.AdminSession.Save(ctx, s))
+	}
+
+	got, err := c.AdminSession.FindByID(ctx, older.ID())
+	require.NoError(t, err)
+	assert.Equal(t, alice, got.AdminUser())
+	assert.Equal(t, "ua", got.UserAgent())
+	assert.Equal(t, "203.0.113.1", got.IP())
+	assert.True(t, older.CreatedAt().Equal(got.CreatedAt()))
+	assert.True(t, older.ExpiresAt().Equal(got.ExpiresAt()))
+
+	list, err := c.AdminSession.FindByAdminUser(ctx, alice)
+	require.NoError(t, err)
+	assert.Equal(t, adminsession.IDList{newer.ID(), older.ID()}, list.IDs())
+
+	require.NoError(t, c.AdminSession.RemoveExpired(ctx, now))
+	_, err = c.AdminSession.FindByID(ctx, older.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	require.NoError(t, c.AdminSession.Remove(ctx, newer.ID()))
+	require.NoError(t, c.AdminSession.Remove(ctx, newer.ID()))
+	list, err = c.AdminSession.FindByAdminUser(ctx, alice)
+	require.NoError(t, err)
+	assert.Empty(t, list)
+
+	require.NoError(t, c.AdminSession.RemoveByAdminUser(ctx, bob))
+	_, err = c.AdminSession.FindByID(ctx, other.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func testPasskeyLogin(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Millisecond)
+	uid := id.NewUserID()
+
+	expired := passkeylogin.From("expired", uid, "c1", now.Add(-time.Minute))
+	pending := passkeylogin.From("pending", uid, "c2", now.Add(time.Minute))
+	for _, s := range []*passkeylogin.Session{expired, pending} {
+		require.NoError(t, c.PasskeyLogin.Save(ctx, s))
+	}
+
+	require.NoError(t, c.PasskeyLogin.RemoveExpired(ctx, now))
+	_, err := c.PasskeyLogin.Take(ctx, "expired")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	got, err := c.PasskeyLogin.Take(ctx, "pending")
+	require.NoError(t, err)
+	assert.Equal(t, uid, got.User())
+	assert.Equal(t, "c2", got.Challenge())
+	assert.True(t, pending.ExpiresAt().Equal(got.ExpiresAt()))
+
+	// a session is taken only once
+	_, err = c.PasskeyLogin.Take(ctx, "pending")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func testAdminStats(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	w := adminstats.NewWindow(7, timeFixed())
+	day1, day2 := timeFixed().Add(-24*time.Hour), timeFixed()
+	before := w.Since.Add(-time.Hour)
+
+	build := func(b *user.Builder) *user.User {
+		u, err := b.NewID().Workspace(id.NewWorkspaceID()).Build()
+		require.NoError(t, err)
+		require.NoError(t, c.User.Save(ctx, u))
+		return u
+	}
+	verified := user.VerificationFrom("", time.Time{}, true)
+	alice := build(user.New().Name("alice").Email("alice@example.com").CreatedAt(&day1).Verification(verified).
+		Auths([]user.Auth{user.NewReearthAuth("a"), user.NewAuth("google-oauth2", "a"), user.NewAuth("google-oauth2", "b")}))
+	build(user.New().Name("bob").Email("bob@example.com").CreatedAt(&before).Auths([]user.Auth{user.NewAuth("auth0", "b")}))
+	build(user.New().Name("carol").Email("carol@example.com").CreatedAt(&day2).DeletedAt(&day2).Verification(verified).
+		Auths([]user.Auth{user.NewAuth("auth0", "c")}))
+	// no creation time, unverified
+	build(user.New().Name("dave").Email("dave@example.com"))
+	// merged users are left out
+	build(user.New().Name("erin").Email("erin@example.com").CreatedAt(&day1).DeletedAt(&day2).MergedInto(alice.ID().Ref()))
+
+	owner, writer := id.NewUserID(), id.NewUserID()
+	personal, err := workspace.New().NewID().Name("alice").Personal(true).
+		Members(map[id.UserID]workspace.Member{alice.ID(): {Role: role.RoleOwner, InvitedBy: alice.ID()}}).Build()
+	require.NoError(t, err)
+	team, err := workspace.New().NewID().Name("team").Members(map[id.UserID]workspace.Member{
+		owner:      {Role: role.RoleOwner, InvitedBy: owner},
+		writer:     {Role: role.RoleWriter, InvitedBy: owner},
+		alice.ID(): {Role: role.RoleWriter, InvitedBy: owner},
+	}).Build()
+	require.NoError(t, err)
+	gone, err := workspace.New().NewID().Name("gone").DeletedAt(&day2).
+		Members(map[id.UserID]workspace.Member{owner: {Role: role.RoleOwner, InvitedBy: owner}}).Build()
+	require.NoError(t, err)
+	for _, ws := range []*workspace.Workspace{personal, team, gone} {
+		require.NoError(t, c.Workspace.Save(ctx, ws))
+	}
+
+	s, err := c.AdminStats.Compute(ctx, w)
+	require.NoError(t, err)
+	assert.Equal(t, adminstats.Users{Active: 3, Deactivated: 1, Unverified: 2}, s.Users)
+	assert.Equal(t, adminstats.Workspaces{Personal: 1, Team: 1, Deactivated: 1}, s.Workspaces)
+	assert.Equal(t, map[role.RoleType]int64{role.RoleOwner: 1, role.RoleWriter: 2}, s.MembersByRole)
+	assert.Equal(t, map[string]int64{user.ProviderReearth: 1, "google-oauth2": 1, user.ProviderAuth0: 1}, s.UsersByAuthProvider)
+
+	days := w.Fill(s.Days)
+	require.Len(t, days, 7)
+	assert.Equal(t, adminstats.Day{Date: adminstats.DayOf(day1), Signups: 1}, days[5])
+	assert.Equal(t, adminstats.Day{Date: adminstats.DayOf(day2), Signups: 1, Deactivations: 1}, days[6])
+	for _, d := range days[:5] {
+		assert.Zero(t, d.Signups+d.Deactivations, d.Date)
+	}
+}
+
+func testAdminApprovalRule(t *testing.T, nc Factory) {
+	c, _, done := nc(t)
+	defer done()
+	ctx := context.Background()
+	by := id.NewAdminUserID()
+
+	domain := adminapprovalrule.New().NewID().Kind(adminapprovalrule.KindEmailDomain).Value("example.com").
+		Role(adminuser.RoleViewer).CreatedBy(by).MustBuild()
+	group := adminapprovalrule.New().NewID().Kind(adminapprovalrule.KindGroup).Value("admins").
+		Provider(adminapprovalrule.ProviderOIDC).Role(adminuser.RoleSystemAdmin).MustBuild()
+	// saved out of order; FindAll still returns the oldest first
+	require.NoError(t, c.AdminApprovalRule.Save(ctx, group))
+	require.NoError(t, c.AdminApprovalRule.Save(ctx, domain))
+
+	got, err := c.AdminApprovalRule.FindByID(ctx, group.ID())
+	require.NoError(t, err)
+	assert.Equal(t, adminapprovalrule.KindGroup, got.Kind())
+	assert.Equal(t, "admins", got.Value())
+	assert.Equal(t, adminapprovalrule.ProviderOIDC, got.Provider())
+	assert.Equal(t, adminuser.RoleSystemAdmin, got.Role())
+	assert.True(t, got.CreatedBy().IsEmpty())
+
+	all, err := c.AdminApprovalRule.FindAll(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, adminapprovalrule.IDList{domain.ID(), group.ID()}, all.IDs())
+	assert.Equal(t, by, all[0].CreatedBy())
+
+	require.NoError(t, domain.SetRole(adminuser.RoleSystemAdmin))
+	require.NoError(t, c.AdminApprovalRule.Save(ctx, domain))
+	got, err = c.AdminApprovalRule.FindByID(ctx, domain.ID())
+	require.NoError(t, err)
+	assert.Equal(t, adminuser.RoleSystemAdmin, got.Role())
+
+	// the admin user records the rule that approved it
+	u := adminuser.New().NewID().Name("Carol").Email("carol-rule@example.com").MustBuild()
+	u.ApproveByRule(domain.ID())
+	require.NoError(t, c.AdminUser.Save(ctx, u))
+	gotUser, err := c.AdminUser.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	assert.True(t, gotUser.IsApproved())
+	assert.Equal(t, domain.ID(), gotUser.ApprovedByRule())
+
+	require.NoError(t, c.AdminApprovalRule.Remove(ctx, domain.ID()))
+	require.NoError(t, c.AdminApprovalRule.Remove(ctx, domain.ID()))
+	_, err = c.AdminApprovalRule.FindByID(ctx, domain.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
 func testTransaction(t *testing.T, nc Factory) {
 	c, caps, done := nc(t)
 	defer done()
@@ -38,6 +38,12 @@ func TestMongoConformance(t *testing.T) {
 			Options: options.Index().SetUnique(true).SetCollation(&options.Collation{Locale: "en", Strength: 2}),
 		})
 		require.NoError(t, err)
+		// unique chain position mirrors the production mongo migration
+		_, err = db.Collection("adminauditrecord").Indexes().CreateOne(ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "seq", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		})
+		require.NoError(t, err)
 
 		repos, err := mongorepo.New(ctx, db, false, false, nil)
 		require.NoError(t, err)
@@ -17,7 +17,8 @@ import (
 )
 
 const pgTruncate = `TRUNCATE users, workspaces, workspace_members, workspace_integrations,
-	roles, permittables, permittable_workspace_roles, config RESTART IDENTITY CASCADE`
+	roles, permittables, permittable_workspace_roles, config, scim_tenants, audit_logs, role_mappings,
+	ldap_sync_runs, admin_audit_records, admin_sessions, admin_approval_rules, passkey_logins RESTART IDENTITY CASCADE`
 
 func TestPostgresConformance(t *testing.T) {
 	ctx := context.Background()
@@ -0,0 +1,261 @@
+// Package ldap implements gateway.Directory on top of an LDAP server such as
+// OpenLDAP or Active Directory.
+package ldap
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+)
+
+const pageSize = 500
+
+// adAccountDisable is the ACCOUNTDISABLE flag of Active Directory's
+// userAccountControl attribute.
+const adAccountDisable = 0x2
+
+type Config struct {
+	// URL is the address of the server, e.g. "ldap://ldap.example.com:389" or
+	// "ldaps://ldap.example.com:636". The sync is disabled when it is empty.
+	URL          string `json:"url"`
+	StartTLS     bool   `json:"startTls"`
+	BindDN       string `json:"bindDn"`
+	BindPassword string `json:"bindPassword"`
+	// BaseDN and UserFilter select the user entries to sync.
+	BaseDN     string `json:"baseDn"`
+	UserFilter string `json:"userFilter"`
+	// SubAttribute is the attribute identifying a user. Users are provisioned
+	// with the sub "<SubPrefix>|<value>", so setting SubPrefix to the name of
+	// the OIDC provider the directory signs in through links both.
+	SubAttribute   string `json:"subAttribute"`
+	SubPrefix      string `json:"subPrefix"`
+	EmailAttribute string `json:"emailAttribute"`
+	NameAttribute  string `json:"nameAttribute"`
+	// GroupAttribute lists the group DNs on a user entry, e.g. memberOf.
+	GroupAttribute string `json:"groupAttribute"`
+	// GroupBaseDN enables reading groups from group entries instead, for
+	// servers without the memberOf overlay. GroupMemberAttribute holds the DNs
+	// or SubAttribute values of the members of a group.
+	GroupBaseDN          string `json:"groupBaseDn"`
+	GroupFilter          string `json:"groupFilter"`
+	GroupMemberAttribute string `json:"groupMemberAttribute"`
+}
+
+func (c Config) withDefaults() Config {
+	if c.UserFilter == "" {
+		c.UserFilter = "(objectClass=person)"
+	}
+	if c.SubAttribute == "" {
+		c.SubAttribute = "uid"
+	}
+	if c.SubPrefix == "" {
+		c.SubPrefix = "ldap"
+	}
+	if c.EmailAttribute == "" {
+		c.EmailAttribute = "mail"
+	}
+	if c.NameAttribute == "" {
+		c.NameAttribute = "cn"
+	}
+	if c.GroupAttribute == "" {
+		c.GroupAttribute = "memberOf"
+	}
+	if c.GroupFilter == "" {
+		c.GroupFilter = "(|(objectClass=groupOfNames)(objectClass=groupOfUniqueNames)(objectClass=posixGroup))"
+	}
+	if c.GroupMemberAttribute == "" {
+		c.GroupMemberAttribute = "member"
+	}
+	return c
+}
+
+type Directory struct {
+	c Config
+}
+
+var _ gateway.Directory = (*Directory)(nil)
+
+func New(c Config) *Directory {
+	return &Directory{c: c.withDefaults()}
+}
+
+func (d *Directory) Issuer() string {
+	return d.c.URL
+}
+
+func (d *Directory) Users(ctx context.Context) ([]gateway.DirectoryUser, error) {
+	conn, err := d.dial(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = conn.Close() }()
+
+	var groups groupIndex
+	if d.c.GroupBaseDN != "" {
+		res, err := conn.SearchWithPaging(goldap.NewSearchRequest(
+			d.c.GroupBaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+			d.c.GroupFilter, []string{"cn", d.c.GroupMemberAttribute}, nil,
+		), pageSize)
+		if err != nil {
+			return nil, fmt.Errorf("ldap: search groups: %w", err)
+		}
+		groups = d.indexGroups(res.Entries)
+	}
+
+	res, err := conn.SearchWithPaging(goldap.NewSearchRequest(
+		d.c.BaseDN, goldap.ScopeWholeSubtree, goldap.NeverDerefAliases, 0, 0, false,
+		d.c.UserFilter, d.userAttributes(), nil,
+	), pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: search users: %w", err)
+	}
+
+	users := make([]gateway.DirectoryUser, 0, len(res.Entries))
+	for _, e := range res.Entries {
+		users = append(users, d.user(e, groups))
+	}
+	return users, nil
+}
+
+func (d *Directory) dial(ctx context.Context) (*goldap.Conn, error) {
+	conn, err := goldap.DialURL(d.c.URL)
+	if err != nil {
+		return nil, fmt.Errorf("ldap: dial: %w", err)
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		conn.SetTimeout(time.Until(deadline))
+	}
+	if d.c.StartTLS {
+		host := d.c.URL
+		if i := strings.Index(host, "://"); i >= 0 {
+			host = host[i+3:]
+		}
+		host, _, _ = strings.Cut(host, ":")
+		if err := conn.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("ldap: start tls: %w", err)
+		}
+	}
+	if d.c.BindDN != "" {
+		if err := conn.Bind(d.c.BindDN, d.c.BindPassword); err != nil {
+			_ = conn.Close()
+			return nil, fmt.Errorf("ldap: bind: %w", err)
+		}
+	}
+	return conn, nil
+}
+
+func (d *Directory) userAttributes() []string {
+	attrs := []string{
+		d.c.SubAttribute, d.c.EmailAttribute, d.c.NameAttribute,
+		"userAccountControl", "pwdAccountLockedTime",
+	}
+	if d.c.GroupBaseDN == "" {
+		attrs = append(attrs, d.c.GroupAttribute)
+	}
+	return attrs
+}
+
+func (d *Directory) user(e *goldap.Entry, groups groupIndex) gateway.DirectoryUser {
+	u := gateway.DirectoryUser{
+		DN:       e.DN,
+		Email:    e.GetEqualFoldAttributeValue(d.c.EmailAttribute),
+		Name:     e.GetEqualFoldAttributeValue(d.c.NameAttribute),
+		Disabled: disabled(e),
+	}
+	id := e.GetEqualFoldAttributeValue(d.c.SubAttribute)
+	if id != "" {
+		u.Sub = d.c.SubPrefix + "|" + id
+		if u.Name == "" {
+			u.Name = id
+		}
+	}
+
+	var dns []string
+	if groups != nil {
+		dns = groups.of(e.DN, id)
+	} else {
+		dns = e.GetEqualFoldAttributeValues(d.c.GroupAttribute)
+	}
+	for _, dn := range dns {
+		u.GroupDNs = append(u.GroupDNs, dn)
+		if cn := commonName(dn); cn != "" {
+			u.Groups = append(u.Groups, cn)
+		}
+	}
+	return u
+}
+
+func disabled(e *goldap.Entry) bool {
+	if e.GetEqualFoldAttributeValue("pwdAccountLockedTime") != "" {
+		return true
+	}
+	if v := e.GetEqualFoldAttributeValue("userAccountControl"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil && n&adAccountDisable != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// groupIndex maps a normalized member DN or sub attribute value to the DNs of
+// its groups.
+type groupIndex map[string][]string
+
+func (d *Directory) indexGroups(entries []*goldap.Entry) groupIndex {
+	idx := groupIndex{}
+	for _, g := range entries {
+		for _, m := range g.GetEqualFoldAttributeValues(d.c.GroupMemberAttribute) {
+			k := normalizeDN(m)
+			idx[k] = append(idx[k], g.DN)
+		}
+	}
+	return idx
+}
+
+func (idx groupIndex) of(dn, id string) []string {
+	if id == "" {
+		return idx[normalizeDN(dn)]
+	}
+	return slices.Concat(idx[normalizeDN(dn)], idx[strings.ToLower(id)])
+}
+
+// normalizeDN lower-cases a DN and drops the spaces around its separators so
+// that equal DNs compare equal. Values that are not DNs, like posixGroup
+// memberUid, are only lower-cased.
+func normalizeDN(dn string) string {
+	parsed, err := goldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) == 0 {
+		return strings.ToLower(dn)
+	}
+	rdns := make([]string, 0, len(parsed.RDNs))
+	for _, rdn := range parsed.RDNs {
+		attrs := make([]string, 0, len(rdn.Attributes))
+		for _, a := range rdn.Attributes {
+			attrs = append(attrs, strings.ToLower(a.Type)+"="+strings.ToLower(a.Value))
+		}
+		rdns = append(rdns, strings.Join(attrs, "+"))
+	}
+	return strings.Join(rdns, ",")
+}
+
+// commonName returns the value of the first cn of a DN.
+func commonName(dn string) string {
+	parsed, err := goldap.ParseDN(dn)
+	if err != nil || len(parsed.RDNs) == 0 {
+		return ""
+	}
+	for _, a := range parsed.RDNs[0].Attributes {
+		if strings.EqualFold(a.Type, "cn") {
+			return a.Value
+		}
+	}
+	return ""
+}
@@ -0,0 +1,78 @@
+//go:build integration
+
+package ldap
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	tcopenldap "github.com/testcontainers/testcontainers-go/modules/openldap"
+)
+
+const testLdif = `dn: ou=people,dc=example,dc=org
+objectClass: organizationalUnit
+ou: people
+
+dn: ou=groups,dc=example,dc=org
+objectClass: organizationalUnit
+ou: groups
+
+dn: uid=alice,ou=people,dc=example,dc=org
+objectClass: inetOrgPerson
+uid: alice
+cn: Alice
+sn: Liddell
+mail: alice@example.org
+
+dn: uid=bob,ou=people,dc=example,dc=org
+objectClass: inetOrgPerson
+uid: bob
+cn: Bob
+sn: Builder
+
+dn: cn=gis-editors,ou=groups,dc=example,dc=org
+objectClass: groupOfNames
+cn: gis-editors
+member: uid=alice,ou=people,dc=example,dc=org
+`
+
+func TestDirectory_OpenLDAP(t *testing.T) {
+	ctx := context.Background()
+
+	c, err := tcopenldap.Run(ctx, "bitnamilegacy/openldap:2.6.6")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = c.Terminate(ctx) })
+	require.NoError(t, c.LoadLdif(ctx, []byte(testLdif)))
+
+	url, err := c.ConnectionString(ctx)
+	require.NoError(t, err)
+
+	d := New(Config{
+		URL:          url,
+		BindDN:       "cn=admin,dc=example,dc=org",
+		BindPassword: "adminpassword",
+		BaseDN:       "ou=people,dc=example,dc=org",
+		UserFilter:   "(objectClass=inetOrgPerson)",
+		GroupBaseDN:  "ou=groups,dc=example,dc=org",
+	})
+	users, err := d.Users(ctx)
+	require.NoError(t, err)
+	require.Len(t, users, 2)
+
+	byDN := map[string]int{}
+	for i, u := range users {
+		byDN[u.DN] = i
+	}
+	alice := users[byDN["uid=alice,ou=people,dc=example,dc=org"]]
+	assert.Equal(t, "ldap|alice", alice.Sub)
+	assert.Equal(t, "alice@example.org", alice.Email)
+	assert.Equal(t, "Alice", alice.Name)
+	assert.Equal(t, []string{"gis-editors"}, alice.Groups)
+
+	bob := users[byDN["uid=bob,ou=people,dc=example,dc=org"]]
+	assert.Equal(t, "ldap|bob", bob.Sub)
+	assert.Empty(t, bob.Email)
+	assert.Empty(t, bob.Groups)
+}
@@ -0,0 +1,76 @@
+package ldap
+
+import (
+	"testing"
+
+	goldap "github.com/go-ldap/ldap/v3"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDirectory_User(t *testing.T) {
+	d := New(Config{URL: "ldap://ldap.example.org", SubPrefix: "keycloak"})
+	e := goldap.NewEntry("uid=alice,ou=people,dc=example,dc=org", map[string][]string{
+		"uid":      {"alice"},
+		"mail":     {"alice@example.org"},
+		"cn":       {"Alice"},
+		"memberOf": {"cn=gis-editors,ou=groups,dc=example,dc=org", "cn=sales,ou=groups,dc=example,dc=org"},
+	})
+
+	assert.Equal(t, "ldap://ldap.example.org", d.Issuer())
+	assert.Equal(t, gateway.DirectoryUser{
+		DN:       "uid=alice,ou=people,dc=example,dc=org",
+		Sub:      "keycloak|alice",
+		Email:    "alice@example.org",
+		Name:     "Alice",
+		Groups:   []string{"gis-editors", "sales"},
+		GroupDNs: []string{"cn=gis-editors,ou=groups,dc=example,dc=org", "cn=sales,ou=groups,dc=example,dc=org"},
+	}, d.user(e, nil))
+
+	t.Run("without sub attribute", func(t *testing.T) {
+		got := d.user(goldap.NewEntry("cn=printer,dc=example,dc=org", nil), nil)
+		assert.Empty(t, got.Sub)
+	})
+}
+
+func TestDirectory_User_Disabled(t *testing.T) {
+	d := New(Config{SubAttribute: "sAMAccountName"})
+	tests := []struct {
+		name  string
+		attrs map[string][]string
+		want  bool
+	}{
+		{name: "enabled", attrs: map[string][]string{"userAccountControl": {"512"}}, want: false},
+		{name: "disabled in active directory", attrs: map[string][]string{"userAccountControl": {"514"}}, want: true},
+		{name: "locked by ppolicy", attrs: map[string][]string{"pwdAccountLockedTime": {"000001010000Z"}}, want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.attrs["sAMAccountName"] = []string{"bob"}
+			got := d.user(goldap.NewEntry("cn=bob,dc=example,dc=org", tt.attrs), nil)
+			assert.Equal(t, "ldap|bob", got.Sub)
+			assert.Equal(t, tt.want, got.Disabled)
+		})
+	}
+}
+
+func TestDirectory_IndexGroups(t *testing.T) {
+	d := New(Config{GroupBaseDN: "ou=groups,dc=example,dc=org"})
+	groups := d.indexGroups([]*goldap.Entry{
+		goldap.NewEntry("cn=gis-editors,ou=groups,dc=example,dc=org", map[string][]string{
+			"member": {"UID=Alice, OU=People, DC=example, DC=org"},
+		}),
+		goldap.NewEntry("cn=posix,ou=groups,dc=example,dc=org", map[string][]string{
+			"member": {"alice"},
+		}),
+		goldap.NewEntry("cn=sales,ou=groups,dc=example,dc=org", map[string][]string{
+			"member": {"uid=bob,ou=people,dc=example,dc=org"},
+		}),
+	})
+
+	got := d.user(goldap.NewEntry("uid=alice,ou=people,dc=example,dc=org", map[string][]string{
+		"uid": {"alice"},
+	}), groups)
+	assert.Equal(t, []string{"gis-editors", "posix"}, got.Groups)
+	assert.Equal(t, []string{"cn=gis-editors,ou=groups,dc=example,dc=org", "cn=posix,ou=groups,dc=example,dc=org"}, got.GroupDNs)
+}
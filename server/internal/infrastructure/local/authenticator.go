@@ -0,0 +1,116 @@
+// Package local implements gateway.Authenticator for the built-in "reearth"
+// password provider, whose users exist only in the accounts DB.
+package local
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/util"
+)
+
+// Issuer is the label authenticator apps show next to the TOTP entry.
+const Issuer = "Re:Earth"
+
+// Authenticator manages native TOTP MFA on the user record. Profile updates and
+// verification emails are handled by the interactor directly, so those calls
+// are no-ops here.
+type Authenticator struct {
+	users user.Repo
+}
+
+var (
+	_ gateway.Authenticator = (*Authenticator)(nil)
+	_ gateway.MFAConfirmer  = (*Authenticator)(nil)
+)
+
+func New(users user.Repo) *Authenticator {
+	return &Authenticator{users: users}
+}
+
+func (a *Authenticator) UpdateUser(_ context.Context, p gateway.AuthenticatorUpdateUserParam) (gateway.AuthenticatorUser, error) {
+	return gateway.AuthenticatorUser{ID: p.ID}, nil
+}
+
+func (a *Authenticator) ResendVerificationEmail(_ context.Context, _ string) error {
+	return nil
+}
+
+// EnableMFA starts (or restarts) enrollment and returns the otpauth:// URI for
+// the new secret. MFA stays disabled until ConfirmMFA succeeds.
+func (a *Authenticator) EnableMFA(ctx context.Context, sub string) (string, error) {
+	u, err := a.users.FindBySub(ctx, sub)
+	if err != nil {
+		return "", err
+	}
+	if u.MFA().IsEnabled() {
+		return "", user.ErrMFAAlreadyEnabled
+	}
+	m, err := user.NewMFA()
+	if err != nil {
+		return "", err
+	}
+	u.SetMFA(m)
+	if err := a.users.Save(ctx, u); err != nil {
+		return "", err
+	}
+	return m.URI(Issuer, u.Email()), nil
+}
+
+func (a *Authenticator) ConfirmMFA(ctx context.Context, sub, code string) ([]string, error) {
+	u, err := a.users.FindBySub(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	m := u.MFA().Clone()
+	if err := m.Confirm(code, util.Now()); err != nil {
+		return nil, err
+	}
+	recoveryCodes, err := m.RegenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	u.SetMFA(m)
+	if err := a.users.Save(ctx, u); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
+
+func (a *Authenticator) DisableMFA(ctx context.Context, sub string) error {
+	u, err := a.users.FindBySub(ctx, sub)
+	if err != nil {
+		return err
+	}
+	if u.MFA() == nil {
+		return nil
+	}
+	u.SetMFA(nil)
+	return a.users.Save(ctx, u)
+}
+
+func (a *Authenticator) GetMFAStatus(ctx context.Context, sub string) (gateway.MFAStatus, error) {
+	u, err := a.users.FindBySub(ctx, sub)
+	if err != nil {
+		return gateway.MFAStatus{}, err
+	}
+	return gateway.MFAStatus{Enrolled: u.MFA().IsEnabled()}, nil
+}
+
+func (a *Authenticator) RegenerateMFARecoveryCode(ctx context.Context, sub string) ([]string, error) {
+	u, err := a.users.FindBySub(ctx, sub)
+	if err != nil {
+		return nil, err
+	}
+	m := u.MFA().Clone()
+	recoveryCodes, err := m.RegenerateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	u.SetMFA(m)
+	if err := a.users.Save(ctx, u); err != nil {
+		return nil, err
+	}
+	return recoveryCodes, nil
+}
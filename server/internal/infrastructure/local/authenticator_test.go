@@ -0,0 +1,70 @@
+package local
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newUser(t *testing.T) (*user.User, string) {
+	t.Helper()
+	uid := id.NewUserID()
+	a := user.ReearthSub(uid.String())
+	u := user.New().
+		ID(uid).
+		Workspace(id.NewWorkspaceID()).
+		Name("alice").
+		Email("alice@example.com").
+		Auths([]user.Auth{*a}).
+		MustBuild()
+	return u, a.Sub
+}
+
+func TestAuthenticator_EnableMFA(t *testing.T) {
+	ctx := context.Background()
+	u, sub := newUser(t)
+	repo := memory.NewUserWith(u)
+	a := New(repo)
+
+	uri, err := a.EnableMFA(ctx, sub)
+	require.NoError(t, err)
+	assert.Contains(t, uri, "otpauth://totp/Re:Earth:alice@example.com?")
+
+	got, err := repo.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	require.NotNil(t, got.MFA())
+	assert.False(t, got.MFA().IsEnabled())
+	assert.Contains(t, uri, "secret="+got.MFA().Secret())
+
+	// Restarting a pending enrollment rotates the secret.
+	uri2, err := a.EnableMFA(ctx, sub)
+	require.NoError(t, err)
+	assert.NotEqual(t, uri, uri2)
+
+	_, err = a.EnableMFA(ctx, "auth0|unknown")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
+
+func TestAuthenticator_NotEnrolled(t *testing.T) {
+	ctx := context.Background()
+	u, sub := newUser(t)
+	a := New(memory.NewUserWith(u))
+
+	status, err := a.GetMFAStatus(ctx, sub)
+	require.NoError(t, err)
+	assert.False(t, status.Enrolled)
+
+	_, err = a.ConfirmMFA(ctx, sub, "123456")
+	assert.ErrorIs(t, err, user.ErrMFANotEnrolled)
+
+	_, err = a.RegenerateMFARecoveryCode(ctx, sub)
+	assert.ErrorIs(t, err, user.ErrMFANotEnrolled)
+
+	assert.NoError(t, a.DisableMFA(ctx, sub))
+}
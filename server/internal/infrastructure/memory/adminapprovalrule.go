@@ -0,0 +1,67 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type AdminApprovalRule struct {
+	lock sync.Mutex
+	data map[adminapprovalrule.ID]*adminapprovalrule.Rule
+}
+
+func NewAdminApprovalRule() *AdminApprovalRule {
+	return &AdminApprovalRule{data: map[adminapprovalrule.ID]*adminapprovalrule.Rule{}}
+}
+
+func NewAdminApprovalRuleWith(items ...*adminapprovalrule.Rule) *AdminApprovalRule {
+	r := NewAdminApprovalRule()
+	for _, rule := range items {
+		r.data[rule.ID()] = rule
+	}
+	return r
+}
+
+func (r *AdminApprovalRule) FindAll(ctx context.Context) (adminapprovalrule.List, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res := make(adminapprovalrule.List, 0, len(r.data))
+	for _, rule := range r.data {
+		res = append(res, rule)
+	}
+	slices.SortFunc(res, func(a, b *adminapprovalrule.Rule) int {
+		return a.ID().Compare(b.ID())
+	})
+	return res, nil
+}
+
+func (r *AdminApprovalRule) FindByID(ctx context.Context, id adminapprovalrule.ID) (*adminapprovalrule.Rule, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if rule, ok := r.data[id]; ok {
+		return rule, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *AdminApprovalRule) Save(ctx context.Context, rule *adminapprovalrule.Rule) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[rule.ID()] = rule
+	return nil
+}
+
+func (r *AdminApprovalRule) Remove(ctx context.Context, id adminapprovalrule.ID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.data, id)
+	return nil
+}
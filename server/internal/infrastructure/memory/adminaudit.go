@@ -0,0 +1,108 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+// AdminAudit keeps the chain in sequence order; Append only ever adds to the
+// end of the slice.
+type AdminAudit struct {
+	lock sync.Mutex
+	data []*adminaudit.Record
+}
+
+func NewAdminAudit() *AdminAudit {
+	return &AdminAudit{}
+}
+
+func (r *AdminAudit) FindLast(ctx context.Context) (*adminaudit.Record, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if len(r.data) == 0 {
+		return nil, rerror.ErrNotFound
+	}
+	return r.data[len(r.data)-1], nil
+}
+
+func (r *AdminAudit) FindAfter(ctx context.Context, seq int64, limit int) (adminaudit.List, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res := adminaudit.List{}
+	for _, v := range r.data {
+		if v.Seq() <= seq {
+			continue
+		}
+		if limit > 0 && len(res) >= limit {
+			break
+		}
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func (r *AdminAudit) List(ctx context.Context, f adminaudit.ListFilter) (adminaudit.List, *usecasex.PageInfo, error) {
+	if f.Pagination != nil && f.Pagination.Cursor != nil {
+		return nil, nil, adminaudit.ErrCursorPaginationUnsupported
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	all := adminaudit.List{}
+	for i := len(r.data) - 1; i >= 0; i-- {
+		v := r.data[i]
+		if f.Actor != nil && v.Actor() != *f.Actor {
+			continue
+		}
+		if f.Action != "" && v.Action() != f.Action {
+			continue
+		}
+		if f.Target != "" && v.Target() != f.Target {
+			continue
+		}
+		if f.Since != nil && v.CreatedAt().Before(*f.Since) {
+			continue
+		}
+		if f.Until != nil && !v.CreatedAt().Before(*f.Until) {
+			continue
+		}
+		all = append(all, v)
+	}
+
+	total := int64(len(all))
+
+	if f.Pagination == nil || f.Pagination.Offset == nil {
+		return all, usecasex.NewPageInfo(total, nil, nil, false, false), nil
+	}
+
+	offset := f.Pagination.Offset.Offset
+	limit := f.Pagination.Offset.Limit
+	if offset > total {
+		offset = total
+	}
+	end := offset + limit
+	if end > total {
+		end = total
+	}
+	return all[offset:end], usecasex.NewPageInfo(total, nil, nil, end < total, offset > 0), nil
+}
+
+func (r *AdminAudit) Append(ctx context.Context, rec *adminaudit.Record) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, v := range r.data {
+		if v.Seq() == rec.Seq() {
+			return adminaudit.ErrSeqTaken
+		}
+	}
+	r.data = append(r.data, rec)
+	return nil
+}
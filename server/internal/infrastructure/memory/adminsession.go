@@ -0,0 +1,95 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type AdminSession struct {
+	lock sync.Mutex
+	data map[adminsession.ID]*adminsession.Session
+}
+
+func NewAdminSession() *AdminSession {
+	return &AdminSession{data: map[adminsession.ID]*adminsession.Session{}}
+}
+
+func NewAdminSessionWith(items ...*adminsession.Session) *AdminSession {
+	r := NewAdminSession()
+	for _, s := range items {
+		r.data[s.ID()] = s
+	}
+	return r
+}
+
+func (r *AdminSession) FindByID(ctx context.Context, id adminsession.ID) (*adminsession.Session, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if s, ok := r.data[id]; ok {
+		return s, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *AdminSession) FindByAdminUser(ctx context.Context, u adminuser.ID) (adminsession.List, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res := adminsession.List{}
+	for _, s := range r.data {
+		if s.AdminUser() == u {
+			res = append(res, s)
+		}
+	}
+	slices.SortFunc(res, func(a, b *adminsession.Session) int {
+		return b.CreatedAt().Compare(a.CreatedAt())
+	})
+	return res, nil
+}
+
+func (r *AdminSession) Save(ctx context.Context, s *adminsession.Session) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[s.ID()] = s
+	return nil
+}
+
+func (r *AdminSession) Remove(ctx context.Context, id adminsession.ID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.data, id)
+	return nil
+}
+
+func (r *AdminSession) RemoveByAdminUser(ctx context.Context, u adminuser.ID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id, s := range r.data {
+		if s.AdminUser() == u {
+			delete(r.data, id)
+		}
+	}
+	return nil
+}
+
+func (r *AdminSession) RemoveExpired(ctx context.Context, t time.Time) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id, s := range r.data {
+		if s.ExpiresAt().Before(t) {
+			delete(r.data, id)
+		}
+	}
+	return nil
+}
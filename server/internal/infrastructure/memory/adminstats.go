@@ -0,0 +1,87 @@
+package memory
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminstats"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+)
+
+// AdminStats computes the dashboard figures from the memory user and
+// workspace repos.
+type AdminStats struct {
+	users      *User
+	workspaces *Workspace
+}
+
+func NewAdminStats(users *User, workspaces *Workspace) *AdminStats {
+	return &AdminStats{users: users, workspaces: workspaces}
+}
+
+func (r *AdminStats) Compute(_ context.Context, w adminstats.Window) (*adminstats.Stats, error) {
+	s := &adminstats.Stats{
+		MembersByRole:       map[role.RoleType]int64{},
+		UsersByAuthProvider: map[string]int64{},
+	}
+	days := map[time.Time]*adminstats.Day{}
+	day := func(t time.Time) *adminstats.Day {
+		d := adminstats.DayOf(t)
+		if days[d] == nil {
+			days[d] = &adminstats.Day{Date: d}
+		}
+		return days[d]
+	}
+
+	r.users.data.Range(func(_ user.ID, u *user.User) bool {
+		if u.MergedInto() != nil {
+			return true
+		}
+		if at := u.CreatedAt(); at != nil && w.Contains(*at) {
+			day(*at).Signups++
+		}
+		if at := u.DeletedAt(); at != nil {
+			s.Users.Deactivated++
+			if w.Contains(*at) {
+				day(*at).Deactivations++
+			}
+			return true
+		}
+		s.Users.Active++
+		if !u.Verification().IsVerified() {
+			s.Users.Unverified++
+		}
+		providers := map[string]struct{}{}
+		for _, a := range u.Auths() {
+			if a.Provider != "" {
+				providers[a.Provider] = struct{}{}
+			}
+		}
+		for p := range providers {
+			s.UsersByAuthProvider[p]++
+		}
+		return true
+	})
+
+	r.workspaces.data.Range(func(_ workspace.ID, ws *workspace.Workspace) bool {
+		switch {
+		case ws.IsDeleted():
+			s.Workspaces.Deactivated++
+		case ws.IsPersonal():
+			s.Workspaces.Personal++
+		default:
+			s.Workspaces.Team++
+			for _, m := range ws.Members().Users() {
+				s.MembersByRole[m.Role]++
+			}
+		}
+		return true
+	})
+
+	for _, d := range days {
+		s.Days = append(s.Days, *d)
+	}
+	return s, nil
+}
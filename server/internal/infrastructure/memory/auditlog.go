@@ -0,0 +1,42 @@
+package memory
+
+import (
+	"context"
+	"slices"
+	"sync"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+)
+
+type AuditLog struct {
+	lock sync.Mutex
+	data []*auditlog.Entry
+}
+
+func NewAuditLog() *AuditLog {
+	return &AuditLog{}
+}
+
+func (r *AuditLog) FindByTarget(ctx context.Context, target string) (auditlog.List, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res := auditlog.List{}
+	for _, e := range r.data {
+		if e.Target() == target {
+			res = append(res, e)
+		}
+	}
+	slices.SortStableFunc(res, func(a, b *auditlog.Entry) int {
+		return a.CreatedAt().Compare(b.CreatedAt())
+	})
+	return res, nil
+}
+
+func (r *AuditLog) Save(ctx context.Context, e *auditlog.Entry) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data = append(r.data, e)
+	return nil
+}
@@ -23,6 +23,10 @@ func (r *Config) LockAndLoad(ctx context.Context) (*config.Config, error) {
 	return r.data, nil
 }
 
+func (r *Config) Load(ctx context.Context) (*config.Config, error) {
+	return r.data, nil
+}
+
 func (r *Config) Save(ctx context.Context, c *config.Config) error {
 	if c != nil {
 		r.data = c
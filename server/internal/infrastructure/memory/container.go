@@ -6,13 +6,24 @@ import (
 )
 
 func New() *repo.Container {
+	users, workspaces := NewUser(), NewWorkspace()
 	return &repo.Container{
-		AdminUser:   NewAdminUser(),
-		User:        NewUser(),
-		Workspace:   NewWorkspace(),
-		Role:        NewRole(),
-		Permittable: NewPermittable(),
-		Transaction: &usecasex.NopTransaction{},
-		Config:      NewConfig(),
+		AdminUser:         NewAdminUser(),
+		User:              users,
+		Workspace:         workspaces,
+		Role:              NewRole(),
+		Permittable:       NewPermittable(),
+		Transaction:       &usecasex.NopTransaction{},
+		Config:            NewConfig(),
+		SCIMTenant:        NewSCIMTenant(),
+		AuditLog:          NewAuditLog(),
+		RoleMapping:       NewRoleMapping(),
+		LDAPSync:          NewLDAPSync(),
+		AdminAudit:        NewAdminAudit(),
+		AdminSession:      NewAdminSession(),
+		AdminApprovalRule: NewAdminApprovalRule(),
+		AdminStats:        NewAdminStats(users, workspaces),
+		PasskeyLogin:      NewPasskeyLogin(),
+		Lock:              NewLock(),
 	}
 }
@@ -0,0 +1,86 @@
+package memory
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type LDAPSync struct {
+	lock sync.Mutex
+	data map[ldapsync.ID]*ldapsync.Run
+}
+
+func NewLDAPSync() *LDAPSync {
+	return &LDAPSync{
+		data: map[ldapsync.ID]*ldapsync.Run{},
+	}
+}
+
+func NewLDAPSyncWith(items ...*ldapsync.Run) *LDAPSync {
+	r := NewLDAPSync()
+	ctx := context.Background()
+	for _, i := range items {
+		_ = r.Save(ctx, i)
+	}
+	return r
+}
+
+func (r *LDAPSync) FindByID(ctx context.Context, id ldapsync.ID) (*ldapsync.Run, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if v, ok := r.data[id]; ok {
+		return v, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *LDAPSync) FindRecent(ctx context.Context, limit int) (ldapsync.List, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res := r.sorted()
+	if limit > 0 && len(res) > limit {
+		res = res[:limit]
+	}
+	return res, nil
+}
+
+func (r *LDAPSync) FindLastSucceeded(ctx context.Context) (*ldapsync.Run, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, v := range r.sorted() {
+		if v.Status() == ldapsync.StatusSucceeded {
+			return v, nil
+		}
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *LDAPSync) Save(ctx context.Context, run *ldapsync.Run) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[run.ID()] = run
+	return nil
+}
+
+// sorted returns the runs newest first.
+func (r *LDAPSync) sorted() ldapsync.List {
+	res := make(ldapsync.List, 0, len(r.data))
+	for _, v := range r.data {
+		res = append(res, v)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		if !res[i].StartedAt().Equal(res[j].StartedAt()) {
+			return res[i].StartedAt().After(res[j].StartedAt())
+		}
+		return res[i].ID().Compare(res[j].ID()) > 0
+	})
+	return res
+}
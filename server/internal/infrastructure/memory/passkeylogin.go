@@ -0,0 +1,51 @@
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/passkeylogin"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type PasskeyLogin struct {
+	lock sync.Mutex
+	data map[string]*passkeylogin.Session
+}
+
+func NewPasskeyLogin() *PasskeyLogin {
+	return &PasskeyLogin{data: map[string]*passkeylogin.Session{}}
+}
+
+func (r *PasskeyLogin) Save(ctx context.Context, s *passkeylogin.Session) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[s.ID()] = s
+	return nil
+}
+
+func (r *PasskeyLogin) Take(ctx context.Context, id string) (*passkeylogin.Session, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	s, ok := r.data[id]
+	if !ok {
+		return nil, rerror.ErrNotFound
+	}
+	delete(r.data, id)
+	return s, nil
+}
+
+func (r *PasskeyLogin) RemoveExpired(ctx context.Context, t time.Time) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id, s := range r.data {
+		if s.ExpiresAt().Before(t) {
+			delete(r.data, id)
+		}
+	}
+	return nil
+}
@@ -90,6 +90,18 @@ func (r *Permittable) Save(ctx context.Context, p permittable.Permittable) error
 	return nil
 }
 
+func (r *Permittable) RemoveByUserID(ctx context.Context, userID user.ID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for id, p := range r.data {
+		if p.UserID() == userID {
+			delete(r.data, id)
+		}
+	}
+	return nil
+}
+
 func (r *Permittable) SaveMany(ctx context.Context, ps permittable.List) error {
 	r.lock.Lock()
 	defer r.lock.Unlock()
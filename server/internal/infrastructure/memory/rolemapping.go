@@ -0,0 +1,93 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type RoleMapping struct {
+	lock sync.Mutex
+	data map[rolemapping.ID]*rolemapping.RoleMapping
+}
+
+func NewRoleMapping() *RoleMapping {
+	return &RoleMapping{
+		data: map[rolemapping.ID]*rolemapping.RoleMapping{},
+	}
+}
+
+func NewRoleMappingWith(items ...*rolemapping.RoleMapping) *RoleMapping {
+	r := NewRoleMapping()
+	ctx := context.Background()
+	for _, i := range items {
+		_ = r.Save(ctx, i)
+	}
+	return r
+}
+
+func (r *RoleMapping) FindAll(ctx context.Context) (rolemapping.List, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res := make(rolemapping.List, 0, len(r.data))
+	for _, v := range r.data {
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func (r *RoleMapping) FindByWorkspace(ctx context.Context, ws workspace.ID) (*rolemapping.RoleMapping, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for _, v := range r.data {
+		if v.Workspace() == ws {
+			return v, nil
+		}
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *RoleMapping) FindByIssuer(ctx context.Context, issuer string) (rolemapping.List, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res := rolemapping.List{}
+	for _, v := range r.data {
+		if v.Issuer() == issuer {
+			res = append(res, v)
+		}
+	}
+	return res, nil
+}
+
+// Save replaces the mapping of the same workspace, as a workspace has at most
+// one mapping.
+func (r *RoleMapping) Save(ctx context.Context, m *rolemapping.RoleMapping) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for k, v := range r.data {
+		if v.Workspace() == m.Workspace() && k != m.ID() {
+			delete(r.data, k)
+		}
+	}
+	r.data[m.ID()] = m
+	return nil
+}
+
+func (r *RoleMapping) RemoveByWorkspace(ctx context.Context, ws workspace.ID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	for k, v := range r.data {
+		if v.Workspace() == ws {
+			delete(r.data, k)
+		}
+	}
+	return nil
+}
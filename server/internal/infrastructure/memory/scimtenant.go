@@ -0,0 +1,81 @@
+package memory
+
+import (
+	"context"
+	"sync"
+
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type SCIMTenant struct {
+	lock sync.Mutex
+	data map[scimtenant.ID]*scimtenant.Tenant
+}
+
+func NewSCIMTenant() *SCIMTenant {
+	return &SCIMTenant{
+		data: map[scimtenant.ID]*scimtenant.Tenant{},
+	}
+}
+
+func NewSCIMTenantWith(items ...*scimtenant.Tenant) *SCIMTenant {
+	r := NewSCIMTenant()
+	ctx := context.Background()
+	for _, i := range items {
+		_ = r.Save(ctx, i)
+	}
+	return r
+}
+
+func (r *SCIMTenant) FindAll(ctx context.Context) (scimtenant.List, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	res := make(scimtenant.List, 0, len(r.data))
+	for _, v := range r.data {
+		res = append(res, v)
+	}
+	return res, nil
+}
+
+func (r *SCIMTenant) FindByID(ctx context.Context, id scimtenant.ID) (*scimtenant.Tenant, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if res, ok := r.data[id]; ok {
+		return res, nil
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *SCIMTenant) FindByTokenHash(ctx context.Context, hash string) (*scimtenant.Tenant, error) {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	if hash == "" {
+		return nil, rerror.ErrNotFound
+	}
+	for _, v := range r.data {
+		if v.TokenHash() == hash {
+			return v, nil
+		}
+	}
+	return nil, rerror.ErrNotFound
+}
+
+func (r *SCIMTenant) Save(ctx context.Context, t *scimtenant.Tenant) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	r.data[t.ID()] = t
+	return nil
+}
+
+func (r *SCIMTenant) Remove(ctx context.Context, id scimtenant.ID) error {
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	delete(r.data, id)
+	return nil
+}
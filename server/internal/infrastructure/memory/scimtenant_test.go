@@ -0,0 +1,39 @@
+package memory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSCIMTenant(t *testing.T) {
+	ctx := context.Background()
+	tn := scimtenant.New().NewID().Name("okta").MustBuild()
+	token, err := tn.RotateToken()
+	require.NoError(t, err)
+
+	repo := NewSCIMTenantWith(tn)
+
+	got, err := repo.FindByID(ctx, tn.ID())
+	assert.NoError(t, err)
+	assert.Equal(t, tn, got)
+
+	got, err = repo.FindByTokenHash(ctx, scimtenant.HashToken(token))
+	assert.NoError(t, err)
+	assert.Equal(t, tn, got)
+
+	_, err = repo.FindByTokenHash(ctx, "")
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	all, err := repo.FindAll(ctx)
+	assert.NoError(t, err)
+	assert.Equal(t, scimtenant.List{tn}, all)
+
+	assert.NoError(t, repo.Remove(ctx, tn.ID()))
+	_, err = repo.FindByID(ctx, tn.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
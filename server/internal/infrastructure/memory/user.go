@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearthx/rerror"
@@ -157,7 +158,7 @@ func (r *User) FindBySub(_ context.Context, auth0sub string) (*user.User, error)
 	}
 
 	return rerror.ErrIfNil(r.data.Find(func(key user.ID, value *user.User) bool {
-		return value.ContainAuth(user.AuthFrom(auth0sub))
+		return value.Auths().Has(auth0sub)
 	}), rerror.ErrNotFound)
 }
 
@@ -302,6 +303,18 @@ func (r *User) FindBySubOrCreate(_ context.Context, u *user.User, sub string) (*
 	return u2, nil
 }
 
+func (r *User) FindDeletedBefore(_ context.Context, t time.Time) (user.List, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	res := r.data.FindAll(func(_ user.ID, v *user.User) bool {
+		return v.DeletedAt() != nil && v.DeletedAt().Before(t)
+	})
+	sort.SliceStable(res, func(i, j int) bool { return res[i].ID().Compare(res[j].ID()) < 0 })
+	return res, nil
+}
+
 func (r *User) Create(_ context.Context, u *user.User) error {
 	if r.err != nil {
 		return r.err
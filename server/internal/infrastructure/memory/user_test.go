@@ -76,6 +76,11 @@ func TestUser_FindBySub(t *testing.T) {
 			auth0sub: "xxx",
 			want:     u,
 		},
+		{
+			name:     "must match the sub exactly",
+			auth0sub: "zzz",
+			wantErr:  rerror.ErrNotFound,
+		},
 		{
 			name:     "must return ErrInvalidParams",
 			auth0sub: "",
@@ -5,6 +5,7 @@ import (
 	"slices"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
 	"github.com/reearth/reearth-accounts/server/pkg/id"
@@ -163,6 +164,20 @@ func (r *Workspace) FindByIntegrations(_ context.Context, ids workspace.Integrat
 	return res, nil
 }
 
+func (r *Workspace) FindDeletedBefore(_ context.Context, t time.Time) (workspace.List, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	res := r.data.FindAll(func(key workspace.ID, value *workspace.Workspace) bool {
+		return value.DeletedAt() != nil && value.DeletedAt().Before(t) && r.f.CanRead(key)
+	})
+
+	slices.SortFunc(res, func(a, b *workspace.Workspace) int { return a.ID().Compare(b.ID()) })
+
+	return res, nil
+}
+
 func (r *Workspace) FindByIDs(_ context.Context, ids workspace.IDList) (workspace.List, error) {
 	if r.err != nil {
 		return nil, r.err
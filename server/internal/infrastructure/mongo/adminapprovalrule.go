@@ -0,0 +1,47 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearthx/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AdminApprovalRule struct {
+	client *mongox.Collection
+}
+
+func NewAdminApprovalRule(client *mongox.Client) *AdminApprovalRule {
+	return &AdminApprovalRule{
+		client: client.WithCollection("adminapprovalrule"),
+	}
+}
+
+func (r *AdminApprovalRule) FindAll(ctx context.Context) (adminapprovalrule.List, error) {
+	c := mongodoc.NewAdminApprovalRuleConsumer()
+	opts := options.Find().SetSort(bson.D{{Key: "id", Value: 1}})
+	if err := r.client.Find(ctx, bson.M{}, c, opts); err != nil {
+		return nil, err
+	}
+	return c.Result, nil
+}
+
+func (r *AdminApprovalRule) FindByID(ctx context.Context, id adminapprovalrule.ID) (*adminapprovalrule.Rule, error) {
+	c := mongodoc.NewAdminApprovalRuleConsumer()
+	if err := r.client.FindOne(ctx, bson.M{"id": id.String()}, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *AdminApprovalRule) Save(ctx context.Context, rule *adminapprovalrule.Rule) error {
+	doc, rid := mongodoc.NewAdminApprovalRule(rule)
+	return r.client.SaveOne(ctx, rid, doc)
+}
+
+func (r *AdminApprovalRule) Remove(ctx context.Context, id adminapprovalrule.ID) error {
+	return r.client.RemoveOne(ctx, bson.M{"id": id.String()})
+}
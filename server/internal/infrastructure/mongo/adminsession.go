@@ -0,0 +1,57 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AdminSession struct {
+	client *mongox.Collection
+}
+
+func NewAdminSession(client *mongox.Client) *AdminSession {
+	return &AdminSession{
+		client: client.WithCollection("adminsession"),
+	}
+}
+
+func (r *AdminSession) FindByID(ctx context.Context, id adminsession.ID) (*adminsession.Session, error) {
+	c := mongodoc.NewAdminSessionConsumer()
+	if err := r.client.FindOne(ctx, bson.M{"id": id.String()}, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *AdminSession) FindByAdminUser(ctx context.Context, u adminuser.ID) (adminsession.List, error) {
+	c := mongodoc.NewAdminSessionConsumer()
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: -1}, {Key: "id", Value: -1}})
+	if err := r.client.Find(ctx, bson.M{"adminuser": u.String()}, c, opts); err != nil {
+		return nil, err
+	}
+	return c.Result, nil
+}
+
+func (r *AdminSession) Save(ctx context.Context, s *adminsession.Session) error {
+	doc, sid := mongodoc.NewAdminSession(s)
+	return r.client.SaveOne(ctx, sid, doc)
+}
+
+func (r *AdminSession) Remove(ctx context.Context, id adminsession.ID) error {
+	return r.client.RemoveOne(ctx, bson.M{"id": id.String()})
+}
+
+func (r *AdminSession) RemoveByAdminUser(ctx context.Context, u adminuser.ID) error {
+	return r.client.RemoveAll(ctx, bson.M{"adminuser": u.String()})
+}
+
+func (r *AdminSession) RemoveExpired(ctx context.Context, t time.Time) error {
+	return r.client.RemoveAll(ctx, bson.M{"expiresat": bson.M{"$lt": t}})
+}
@@ -0,0 +1,222 @@
+package mongo
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminstats"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// AdminStats computes the dashboard figures with aggregation pipelines over
+// the user and workspace collections.
+type AdminStats struct {
+	users      *mongox.Collection
+	workspaces *mongox.Collection
+}
+
+func NewAdminStats(client *mongox.Client) *AdminStats {
+	return &AdminStats{
+		users:      client.WithCollection("user"),
+		workspaces: client.WithCollection("workspace"),
+	}
+}
+
+// NewAdminStatsCompat reads the workspaces from the legacy team collection,
+// like NewWorkspaceCompat.
+func NewAdminStatsCompat(client *mongox.Client) *AdminStats {
+	return &AdminStats{
+		users:      client.WithCollection("user"),
+		workspaces: client.WithCollection("team"),
+	}
+}
+
+type statsCount struct {
+	ID    string `bson:"_id"`
+	Count int64  `bson:"count"`
+}
+
+type userStatsResult struct {
+	Totals []struct {
+		Active      int64 `bson:"active"`
+		Deactivated int64 `bson:"deactivated"`
+		Unverified  int64 `bson:"unverified"`
+	} `bson:"totals"`
+	Signups       []statsCount `bson:"signups"`
+	Deactivations []statsCount `bson:"deactivations"`
+	Providers     []statsCount `bson:"providers"`
+}
+
+type workspaceStatsResult struct {
+	Totals []struct {
+		Personal    int64 `bson:"personal"`
+		Team        int64 `bson:"team"`
+		Deactivated int64 `bson:"deactivated"`
+	} `bson:"totals"`
+	Roles []statsCount `bson:"roles"`
+}
+
+const statsDayFormat = "2006-01-02"
+
+func (r *AdminStats) Compute(ctx context.Context, w adminstats.Window) (*adminstats.Stats, error) {
+	var ur userStatsResult
+	if err := r.aggregateOne(ctx, r.users, userStatsPipeline(w), &ur); err != nil {
+		return nil, err
+	}
+	var wr workspaceStatsResult
+	if err := r.aggregateOne(ctx, r.workspaces, workspaceStatsPipeline(), &wr); err != nil {
+		return nil, err
+	}
+
+	s := &adminstats.Stats{
+		MembersByRole:       make(map[role.RoleType]int64, len(wr.Roles)),
+		UsersByAuthProvider: make(map[string]int64, len(ur.Providers)),
+	}
+	if len(ur.Totals) > 0 {
+		s.Users = adminstats.Users(ur.Totals[0])
+	}
+	if len(wr.Totals) > 0 {
+		s.Workspaces = adminstats.Workspaces(wr.Totals[0])
+	}
+	days := map[string]*adminstats.Day{}
+	day := func(key string) (*adminstats.Day, error) {
+		if d := days[key]; d != nil {
+			return d, nil
+		}
+		t, err := time.Parse(statsDayFormat, key)
+		if err != nil {
+			return nil, rerror.ErrInternalByWithContext(ctx, err)
+		}
+		days[key] = &adminstats.Day{Date: t}
+		return days[key], nil
+	}
+	for _, c := range ur.Signups {
+		d, err := day(c.ID)
+		if err != nil {
+			return nil, err
+		}
+		d.Signups = c.Count
+	}
+	for _, c := range ur.Deactivations {
+		d, err := day(c.ID)
+		if err != nil {
+			return nil, err
+		}
+		d.Deactivations = c.Count
+	}
+	for _, d := range days {
+		s.Days = append(s.Days, *d)
+	}
+	for _, c := range ur.Providers {
+		s.UsersByAuthProvider[c.ID] = c.Count
+	}
+	for _, c := range wr.Roles {
+		s.MembersByRole[role.RoleType(c.ID)] = c.Count
+	}
+	return s, nil
+}
+
+func (r *AdminStats) aggregateOne(ctx context.Context, col *mongox.Collection, pipeline []any, res any) error {
+	cur, err := col.Client().Aggregate(ctx, pipeline)
+	if err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	defer func() { _ = cur.Close(ctx) }()
+	if !cur.Next(ctx) {
+		if err := cur.Err(); err != nil {
+			return rerror.ErrInternalByWithContext(ctx, err)
+		}
+		return nil
+	}
+	if err := cur.Decode(res); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
+
+// isSet is true when the field holds a value; missing fields and nulls are
+// both unset.
+func isSet(field string) bson.M {
+	return bson.M{"$ne": bson.A{bson.M{"$ifNull": bson.A{field, nil}}, nil}}
+}
+
+func countIf(cond any) bson.M {
+	return bson.M{"$sum": bson.M{"$cond": bson.A{cond, 1, 0}}}
+}
+
+// countPerDay counts the documents whose date field falls within the window
+// by UTC day.
+func countPerDay(field string, w adminstats.Window) bson.A {
+	return bson.A{
+		bson.M{"$match": bson.M{field: bson.M{"$gte": w.Since, "$lt": w.Until}}},
+		bson.M{"$group": bson.M{
+			"_id":   bson.M{"$dateToString": bson.M{"format": "%Y-%m-%d", "date": "$" + field}},
+			"count": bson.M{"$sum": 1},
+		}},
+	}
+}
+
+func userStatsPipeline(w adminstats.Window) []any {
+	active := bson.M{"$not": bson.A{isSet("$deletedat")}}
+	return []any{
+		// mergedinto is omitted on users never merged.
+		bson.M{"$match": bson.M{"mergedinto": nil}},
+		bson.M{"$facet": bson.M{
+			"totals": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":         nil,
+					"active":      countIf(active),
+					"deactivated": countIf(isSet("$deletedat")),
+					"unverified": countIf(bson.M{"$and": bson.A{
+						active,
+						bson.M{"$ne": bson.A{bson.M{"$ifNull": bson.A{"$verification.verified", false}}, true}},
+					}}),
+				}},
+			},
+			"signups":       countPerDay("createdat", w),
+			"deactivations": countPerDay("deletedat", w),
+			// The provider of a sub is its part before "|"; a user counts
+			// once for each distinct provider.
+			"providers": bson.A{
+				bson.M{"$match": bson.M{"deletedat": nil}},
+				bson.M{"$project": bson.M{"providers": bson.M{"$setUnion": bson.A{
+					bson.M{"$map": bson.M{
+						"input": bson.M{"$filter": bson.M{
+							"input": bson.M{"$ifNull": bson.A{"$subs", bson.A{}}},
+							"cond":  bson.M{"$gt": bson.A{bson.M{"$indexOfBytes": bson.A{"$$this", "|"}}, 0}},
+						}},
+						"in": bson.M{"$arrayElemAt": bson.A{bson.M{"$split": bson.A{"$$this", "|"}}, 0}},
+					}},
+					bson.A{},
+				}}}},
+				bson.M{"$unwind": "$providers"},
+				bson.M{"$group": bson.M{"_id": "$providers", "count": bson.M{"$sum": 1}}},
+			},
+		}},
+	}
+}
+
+func workspaceStatsPipeline() []any {
+	active := bson.M{"$not": bson.A{isSet("$deletedat")}}
+	return []any{
+		bson.M{"$facet": bson.M{
+			"totals": bson.A{
+				bson.M{"$group": bson.M{
+					"_id":         nil,
+					"personal":    countIf(bson.M{"$and": bson.A{active, bson.M{"$eq": bson.A{"$personal", true}}}}),
+					"team":        countIf(bson.M{"$and": bson.A{active, bson.M{"$ne": bson.A{"$personal", true}}}}),
+					"deactivated": countIf(isSet("$deletedat")),
+				}},
+			},
+			"roles": bson.A{
+				bson.M{"$match": bson.M{"deletedat": nil, "personal": bson.M{"$ne": true}}},
+				bson.M{"$project": bson.M{"members": bson.M{"$objectToArray": bson.M{"$ifNull": bson.A{"$members", bson.M{}}}}}},
+				bson.M{"$unwind": "$members"},
+				bson.M{"$group": bson.M{"_id": "$members.v.role", "count": bson.M{"$sum": 1}}},
+			},
+		}},
+	}
+}
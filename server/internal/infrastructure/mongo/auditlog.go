@@ -0,0 +1,35 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearthx/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditLog struct {
+	client *mongox.Collection
+}
+
+func NewAuditLog(client *mongox.Client) *AuditLog {
+	return &AuditLog{
+		client: client.WithCollection("auditlog"),
+	}
+}
+
+func (r *AuditLog) FindByTarget(ctx context.Context, target string) (auditlog.List, error) {
+	c := mongodoc.NewAuditLogConsumer()
+	opts := options.Find().SetSort(bson.D{{Key: "createdat", Value: 1}, {Key: "id", Value: 1}})
+	if err := r.client.Find(ctx, bson.M{"target": target}, c, opts); err != nil {
+		return nil, err
+	}
+	return c.Result, nil
+}
+
+func (r *AuditLog) Save(ctx context.Context, e *auditlog.Entry) error {
+	doc, eid := mongodoc.NewAuditLog(e)
+	return r.client.SaveOne(ctx, eid, doc)
+}
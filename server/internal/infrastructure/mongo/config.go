@@ -42,6 +42,16 @@ func (r *Config) LockAndLoad(ctx context.Context) (cfg *config.Config, err error
 	return cfgd.Model(), nil
 }
 
+func (r *Config) Load(ctx context.Context) (*config.Config, error) {
+	cfgd := &mongodoc.ConfigDocument{}
+	if err := r.client.FindOne(ctx, bson.M{}).Decode(cfgd); err != nil {
+		if !errors.Is(err, mongo.ErrNilDocument) && !errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, rerror.ErrInternalByWithContext(ctx, err)
+		}
+	}
+	return cfgd.Model(), nil
+}
+
 func (r *Config) Save(ctx context.Context, cfg *config.Config) error {
 	if cfg == nil {
 		return nil
@@ -17,10 +17,13 @@ func New(ctx context.Context, db *mongo.Database, useTransaction, needCompat boo
 	}
 
 	var ws workspace.Repo
+	var stats *AdminStats
 	if needCompat {
 		ws = NewWorkspaceCompat(client)
+		stats = NewAdminStatsCompat(client)
 	} else {
 		ws = NewWorkspace(client)
+		stats = NewAdminStats(client)
 	}
 
 	lock, err := NewLock(db.Collection("locks"))
@@ -29,14 +32,24 @@ func New(ctx context.Context, db *mongo.Database, useTransaction, needCompat boo
 	}
 
 	c := &repo.Container{
-		AdminUser:   NewAdminUser(client),
-		User:        NewUser(client),
-		Workspace:   ws,
-		Role:        NewRole(client),
-		Permittable: NewPermittable(client),
-		Transaction: client.Transaction(),
-		Users:       users,
-		Config:      NewConfig(db.Collection("config"), lock),
+		AdminUser:         NewAdminUser(client),
+		User:              NewUser(client),
+		Workspace:         ws,
+		Role:              NewRole(client),
+		Permittable:       NewPermittable(client),
+		Transaction:       client.Transaction(),
+		Users:             users,
+		Config:            NewConfig(db.Collection("config"), lock),
+		SCIMTenant:        NewSCIMTenant(client),
+		AuditLog:          NewAuditLog(client),
+		RoleMapping:       NewRoleMapping(client),
+		LDAPSync:          NewLDAPSync(client),
+		AdminAudit:        NewAdminAudit(client),
+		AdminSession:      NewAdminSession(client),
+		AdminApprovalRule: NewAdminApprovalRule(client),
+		AdminStats:        stats,
+		PasskeyLogin:      NewPasskeyLogin(client),
+		Lock:              lock,
 	}
 
 	return c, nil
@@ -0,0 +1,54 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearthx/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type LDAPSync struct {
+	client *mongox.Collection
+}
+
+func NewLDAPSync(client *mongox.Client) *LDAPSync {
+	return &LDAPSync{
+		client: client.WithCollection("ldapsyncrun"),
+	}
+}
+
+func (r *LDAPSync) FindByID(ctx context.Context, id ldapsync.ID) (*ldapsync.Run, error) {
+	c := mongodoc.NewLDAPSyncRunConsumer()
+	if err := r.client.FindOne(ctx, bson.M{"id": id.String()}, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *LDAPSync) FindRecent(ctx context.Context, limit int) (ldapsync.List, error) {
+	c := mongodoc.NewLDAPSyncRunConsumer()
+	opts := options.Find().SetSort(newestFirst).SetLimit(int64(limit))
+	if err := r.client.Find(ctx, bson.M{}, c, opts); err != nil {
+		return nil, err
+	}
+	return c.Result, nil
+}
+
+func (r *LDAPSync) FindLastSucceeded(ctx context.Context) (*ldapsync.Run, error) {
+	c := mongodoc.NewLDAPSyncRunConsumer()
+	opts := options.FindOne().SetSort(newestFirst)
+	if err := r.client.FindOne(ctx, bson.M{"status": string(ldapsync.StatusSucceeded)}, c, opts); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
+
+func (r *LDAPSync) Save(ctx context.Context, run *ldapsync.Run) error {
+	doc, rid := mongodoc.NewLDAPSyncRun(run)
+	return r.client.SaveOne(ctx, rid, doc)
+}
+
+var newestFirst = bson.D{{Key: "startedat", Value: -1}, {Key: "id", Value: -1}}
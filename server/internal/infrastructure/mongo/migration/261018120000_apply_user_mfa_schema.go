@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyUserMFASchema re-applies the user JSON schema validator, which gained the
+// optional mfa sub-document for native TOTP enrollment.
+func ApplyUserMFASchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"user"}, c)
+}
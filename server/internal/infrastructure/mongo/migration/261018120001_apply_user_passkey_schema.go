@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyUserPasskeySchema re-applies the user JSON schema validator, which gained
+// the optional passkeys array and pending passkey challenge.
+func ApplyUserPasskeySchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"user"}, c)
+}
@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyUserMagicLinkSchema re-applies the user JSON schema validator, which
+// gained the optional magiclink sub-document for passwordless sign-in.
+func ApplyUserMagicLinkSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"user"}, c)
+}
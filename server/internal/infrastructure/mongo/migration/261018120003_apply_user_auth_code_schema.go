@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyUserAuthCodeSchema re-applies the user JSON schema validator, which
+// gained the optional authcode sub-document for the built-in OIDC provider.
+func ApplyUserAuthCodeSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"user"}, c)
+}
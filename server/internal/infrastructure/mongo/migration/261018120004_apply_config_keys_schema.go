@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyConfigKeysSchema re-applies the config JSON schema validator, which
+// gained the versioned signing keys.
+func ApplyConfigKeysSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"config"}, c)
+}
@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplySCIMTenantSchema creates the scimtenant collection with its JSON schema
+// validator (or updates the validator if the collection already exists).
+func ApplySCIMTenantSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"scimtenant"}, c)
+}
@@ -0,0 +1,31 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddSCIMTenantTokenHashIndex creates a unique index on scimtenant.tokenhash,
+// which every SCIM request is authenticated by. Tenants without a token are
+// left out of the index.
+func AddSCIMTenantTokenHashIndex(ctx context.Context, c DBClient) error {
+	col := c.Database().Collection("scimtenant")
+
+	indexModel := mongo.IndexModel{
+		Keys: bson.D{{Key: "tokenhash", Value: 1}},
+		Options: options.Index().
+			SetUnique(true).
+			SetPartialFilterExpression(bson.M{"tokenhash": bson.M{"$gt": ""}}).
+			SetName("scimtenant_tokenhash_unique"),
+	}
+
+	if _, err := col.Indexes().CreateOne(ctx, indexModel); err != nil {
+		return fmt.Errorf("failed to create unique index on scimtenant.tokenhash: %w", err)
+	}
+	fmt.Println("Created unique index on scimtenant.tokenhash")
+	return nil
+}
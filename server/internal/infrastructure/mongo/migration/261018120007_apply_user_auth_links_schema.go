@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyUserAuthLinksSchema re-applies the user JSON schema validator, which
+// gained the optional authlinks array recording linked identities.
+func ApplyUserAuthLinksSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"user"}, c)
+}
@@ -0,0 +1,10 @@
+package migration
+
+import "context"
+
+// ApplyUserMergeSchemas re-applies the user JSON schema validator, which gained
+// the optional mergedinto field, and creates the auditlog collection with its
+// validator.
+func ApplyUserMergeSchemas(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"user", "auditlog"}, c)
+}
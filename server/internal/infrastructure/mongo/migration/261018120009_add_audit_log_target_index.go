@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddAuditLogTargetIndex creates an index on auditlog.target, by which the
+// history of a resource is looked up.
+func AddAuditLogTargetIndex(ctx context.Context, c DBClient) error {
+	col := c.Database().Collection("auditlog")
+
+	indexModel := mongo.IndexModel{
+		Keys:    bson.D{{Key: "target", Value: 1}, {Key: "createdat", Value: 1}},
+		Options: options.Index().SetName("auditlog_target_createdat"),
+	}
+
+	if _, err := col.Indexes().CreateOne(ctx, indexModel); err != nil {
+		return fmt.Errorf("failed to create index on auditlog.target: %w", err)
+	}
+	fmt.Println("Created index on auditlog.target")
+	return nil
+}
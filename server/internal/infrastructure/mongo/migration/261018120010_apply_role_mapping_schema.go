@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyRoleMappingSchema creates the rolemapping collection with its JSON
+// schema validator.
+func ApplyRoleMappingSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"rolemapping"}, c)
+}
@@ -0,0 +1,34 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddRoleMappingIndexes makes the workspace of a role mapping unique and
+// indexes its issuer, by which the mappings of a token are looked up on every
+// authenticated request.
+func AddRoleMappingIndexes(ctx context.Context, c DBClient) error {
+	col := c.Database().Collection("rolemapping")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "workspace", Value: 1}},
+			Options: options.Index().SetName("rolemapping_workspace_unique").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "issuer", Value: 1}},
+			Options: options.Index().SetName("rolemapping_issuer"),
+		},
+	}
+
+	if _, err := col.Indexes().CreateMany(ctx, indexModels); err != nil {
+		return fmt.Errorf("failed to create indexes on rolemapping: %w", err)
+	}
+	fmt.Println("Created indexes on rolemapping.workspace and rolemapping.issuer")
+	return nil
+}
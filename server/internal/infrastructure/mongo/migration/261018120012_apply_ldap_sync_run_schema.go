@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyLDAPSyncRunSchema creates the ldapsyncrun collection with its JSON
+// schema validator.
+func ApplyLDAPSyncRunSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"ldapsyncrun"}, c)
+}
@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddLDAPSyncRunIndexes indexes the start time of LDAP sync runs, by which the
+// recent runs and the last succeeded one are looked up.
+func AddLDAPSyncRunIndexes(ctx context.Context, c DBClient) error {
+	col := c.Database().Collection("ldapsyncrun")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "startedat", Value: -1}, {Key: "id", Value: -1}},
+			Options: options.Index().SetName("ldapsyncrun_startedat"),
+		},
+		{
+			Keys:    bson.D{{Key: "status", Value: 1}, {Key: "startedat", Value: -1}},
+			Options: options.Index().SetName("ldapsyncrun_status_startedat"),
+		},
+	}
+
+	if _, err := col.Indexes().CreateMany(ctx, indexModels); err != nil {
+		return fmt.Errorf("failed to create indexes on ldapsyncrun: %w", err)
+	}
+	fmt.Println("Created indexes on ldapsyncrun.startedat and ldapsyncrun.status")
+	return nil
+}
@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyUserDeletionSchema re-applies the user JSON schema validator, which
+// gained the optional deletionrequestedat and purgenotifiedat fields.
+func ApplyUserDeletionSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"user"}, c)
+}
@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyAdminAuditRecordSchema creates the adminauditrecord collection with its
+// JSON schema validator.
+func ApplyAdminAuditRecordSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"adminauditrecord"}, c)
+}
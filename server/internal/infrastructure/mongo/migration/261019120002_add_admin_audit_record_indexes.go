@@ -0,0 +1,42 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddAdminAuditRecordIndexes makes the chain position of admin audit records
+// unique, so two concurrent appends cannot both extend the same record, and
+// indexes the fields the admin audit listing filters on.
+func AddAdminAuditRecordIndexes(ctx context.Context, c DBClient) error {
+	col := c.Database().Collection("adminauditrecord")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "seq", Value: 1}},
+			Options: options.Index().SetName("adminauditrecord_seq").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "createdat", Value: -1}},
+			Options: options.Index().SetName("adminauditrecord_createdat"),
+		},
+		{
+			Keys:    bson.D{{Key: "actor", Value: 1}, {Key: "seq", Value: -1}},
+			Options: options.Index().SetName("adminauditrecord_actor_seq"),
+		},
+		{
+			Keys:    bson.D{{Key: "target", Value: 1}, {Key: "seq", Value: -1}},
+			Options: options.Index().SetName("adminauditrecord_target_seq"),
+		},
+	}
+
+	if _, err := col.Indexes().CreateMany(ctx, indexModels); err != nil {
+		return fmt.Errorf("failed to create indexes on adminauditrecord: %w", err)
+	}
+	fmt.Println("Created indexes on adminauditrecord.seq, createdat, actor and target")
+	return nil
+}
@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyAdminSessionSchema creates the adminsession collection with its JSON
+// schema validator.
+func ApplyAdminSessionSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"adminsession"}, c)
+}
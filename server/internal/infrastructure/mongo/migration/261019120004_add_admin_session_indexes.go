@@ -0,0 +1,38 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddAdminSessionIndexes indexes admin sessions by ID, which every admin
+// request looks up, by admin user for listing and revoking them, and by
+// expiry for removing the expired ones.
+func AddAdminSessionIndexes(ctx context.Context, c DBClient) error {
+	col := c.Database().Collection("adminsession")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetName("adminsession_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "adminuser", Value: 1}, {Key: "createdat", Value: -1}},
+			Options: options.Index().SetName("adminsession_adminuser_createdat"),
+		},
+		{
+			Keys:    bson.D{{Key: "expiresat", Value: 1}},
+			Options: options.Index().SetName("adminsession_expiresat"),
+		},
+	}
+
+	if _, err := col.Indexes().CreateMany(ctx, indexModels); err != nil {
+		return fmt.Errorf("failed to create indexes on adminsession: %w", err)
+	}
+	fmt.Println("Created indexes on adminsession.id, adminuser and expiresat")
+	return nil
+}
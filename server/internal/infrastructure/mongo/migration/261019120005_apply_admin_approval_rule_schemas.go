@@ -0,0 +1,10 @@
+package migration
+
+import "context"
+
+// ApplyAdminApprovalRuleSchemas creates the adminapprovalrule collection with
+// its JSON schema validator and re-applies the adminuser one, which gained
+// the optional approvedbyrule field.
+func ApplyAdminApprovalRuleSchemas(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"adminapprovalrule", "adminuser"}, c)
+}
@@ -0,0 +1,29 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddAdminApprovalRuleIndexes indexes admin approval rules by ID. Every
+// admin sign-in reads all of them, and there are only a few.
+func AddAdminApprovalRuleIndexes(ctx context.Context, c DBClient) error {
+	col := c.Database().Collection("adminapprovalrule")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetName("adminapprovalrule_id").SetUnique(true),
+		},
+	}
+
+	if _, err := col.Indexes().CreateMany(ctx, indexModels); err != nil {
+		return fmt.Errorf("failed to create indexes on adminapprovalrule: %w", err)
+	}
+	fmt.Println("Created indexes on adminapprovalrule.id")
+	return nil
+}
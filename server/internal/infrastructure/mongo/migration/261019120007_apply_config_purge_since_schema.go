@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyConfigPurgeSinceSchema re-applies the config JSON schema validator,
+// which gained the time the purge first ran.
+func ApplyConfigPurgeSinceSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"config"}, c)
+}
@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyUserRefreshTokenSchema re-applies the user JSON schema validator,
+// which gained the refresh token families of the built-in OIDC provider.
+func ApplyUserRefreshTokenSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"user"}, c)
+}
@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyPasskeyLoginSchema creates the passkeylogin collection with its JSON
+// schema validator.
+func ApplyPasskeyLoginSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"passkeylogin"}, c)
+}
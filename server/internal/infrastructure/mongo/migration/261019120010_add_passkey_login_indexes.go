@@ -0,0 +1,33 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// AddPasskeyLoginIndexes indexes passkey login ceremonies by ID, which
+// finishing a login looks up, and by expiry for removing the abandoned ones.
+func AddPasskeyLoginIndexes(ctx context.Context, c DBClient) error {
+	col := c.Database().Collection("passkeylogin")
+
+	indexModels := []mongo.IndexModel{
+		{
+			Keys:    bson.D{{Key: "id", Value: 1}},
+			Options: options.Index().SetName("passkeylogin_id").SetUnique(true),
+		},
+		{
+			Keys:    bson.D{{Key: "expiresat", Value: 1}},
+			Options: options.Index().SetName("passkeylogin_expiresat"),
+		},
+	}
+
+	if _, err := col.Indexes().CreateMany(ctx, indexModels); err != nil {
+		return fmt.Errorf("failed to create indexes on passkeylogin: %w", err)
+	}
+	fmt.Println("Created indexes on passkeylogin.id and expiresat")
+	return nil
+}
@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplySCIMTenantDeprovisionedSchema re-applies the scimtenant JSON schema
+// validator, which gained the users the tenant deleted.
+func ApplySCIMTenantDeprovisionedSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"scimtenant"}, c)
+}
@@ -0,0 +1,9 @@
+package migration
+
+import "context"
+
+// ApplyUserMFALockoutSchema re-applies the user JSON schema validator, whose
+// MFA gained the count of wrong codes and the end of the lockout.
+func ApplyUserMFALockoutSchema(ctx context.Context, c DBClient) error {
+	return ApplyCollectionSchemas(ctx, []string{"user"}, c)
+}
@@ -0,0 +1,27 @@
+package migration
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+// MigrateUserAuthCodes drops the single pending authorization code users had,
+// which is short-lived and has no expiry of its own, and re-applies the user
+// JSON schema validator, which now keeps several codes in authcodes and the
+// end of each refresh token family.
+func MigrateUserAuthCodes(ctx context.Context, c DBClient) error {
+	col := c.Database().Collection("user")
+
+	res, err := col.UpdateMany(ctx,
+		bson.M{"authcode": bson.M{"$exists": true}},
+		bson.M{"$unset": bson.M{"authcode": ""}},
+	)
+	if err != nil {
+		return fmt.Errorf("failed to unset user.authcode: %w", err)
+	}
+	fmt.Printf("Unset user.authcode for %d users\n", res.ModifiedCount)
+
+	return ApplyCollectionSchemas(ctx, []string{"user"}, c)
+}
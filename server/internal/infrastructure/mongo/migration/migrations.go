@@ -45,4 +45,32 @@ var migrations = migration.Migrations[DBClient]{
 	260708123739: BackfillAdminUserRole,
 	260803120000: AddWorkspaceMembersWildcardIndex,
 	260819120000: ApplyUserAndWorkspaceSchemas,
+	261018120000: ApplyUserMFASchema,
+	261018120001: ApplyUserPasskeySchema,
+	261018120002: ApplyUserMagicLinkSchema,
+	261018120003: ApplyUserAuthCodeSchema,
+	261018120004: ApplyConfigKeysSchema,
+	261018120005: ApplySCIMTenantSchema,
+	261018120006: AddSCIMTenantTokenHashIndex,
+	261018120007: ApplyUserAuthLinksSchema,
+	261018120008: ApplyUserMergeSchemas,
+	261018120009: AddAuditLogTargetIndex,
+	261018120010: ApplyRoleMappingSchema,
+	261018120011: AddRoleMappingIndexes,
+	261018120012: ApplyLDAPSyncRunSchema,
+	261018120013: AddLDAPSyncRunIndexes,
+	261019120000: ApplyUserDeletionSchema,
+	261019120001: ApplyAdminAuditRecordSchema,
+	261019120002: AddAdminAuditRecordIndexes,
+	261019120003: ApplyAdminSessionSchema,
+	261019120004: AddAdminSessionIndexes,
+	261019120005: ApplyAdminApprovalRuleSchemas,
+	261019120006: AddAdminApprovalRuleIndexes,
+	261019120007: ApplyConfigPurgeSinceSchema,
+	261019120008: ApplyUserRefreshTokenSchema,
+	261019120009: ApplyPasskeyLoginSchema,
+	261019120010: AddPasskeyLoginIndexes,
+	261019120011: ApplySCIMTenantDeprovisionedSchema,
+	261019120012: ApplyUserMFALockoutSchema,
+	261019120013: MigrateUserAuthCodes,
 }
@@ -0,0 +1,82 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+type AdminApprovalRuleDocument struct {
+	ID        string    `json:"id" bson:"id" jsonschema:"required,description=Admin approval rule ID (ULID format)"`
+	Kind      string    `json:"kind" bson:"kind" jsonschema:"required,description=What the rule matches: email_domain or group"`
+	Value     string    `json:"value" bson:"value" jsonschema:"required,description=Email domain (lowercase) or group name the rule matches"`
+	Provider  string    `json:"provider" bson:"provider" jsonschema:"description=Identity provider the rule is limited to: google or oidc. Default: \"\" (any)"`
+	Role      string    `json:"role" bson:"role" jsonschema:"required,description=Role granted to the admins the rule approves: system_admin or viewer"`
+	CreatedBy string    `json:"createdby" bson:"createdby" jsonschema:"foreignkey=adminuser,description=ID of the admin who created the rule. Default: \"\""`
+	UpdatedAt time.Time `json:"updatedat" bson:"updatedat" jsonschema:"required,description=Last update timestamp"`
+}
+
+type AdminApprovalRuleConsumer = Consumer[*AdminApprovalRuleDocument, *adminapprovalrule.Rule]
+
+func NewAdminApprovalRuleConsumer() *AdminApprovalRuleConsumer {
+	return NewConsumer[*AdminApprovalRuleDocument, *adminapprovalrule.Rule](func(a *adminapprovalrule.Rule) bool {
+		return true
+	})
+}
+
+func NewAdminApprovalRule(r *adminapprovalrule.Rule) (*AdminApprovalRuleDocument, string) {
+	rid := r.ID().String()
+	createdBy := ""
+	if by := r.CreatedBy(); !by.IsEmpty() {
+		createdBy = by.String()
+	}
+	return &AdminApprovalRuleDocument{
+		ID:        rid,
+		Kind:      r.Kind().String(),
+		Value:     r.Value(),
+		Provider:  r.Provider().String(),
+		Role:      r.Role().String(),
+		CreatedBy: createdBy,
+		UpdatedAt: r.UpdatedAt(),
+	}, rid
+}
+
+func (d *AdminApprovalRuleDocument) Model() (*adminapprovalrule.Rule, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	rid, err := adminapprovalrule.IDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := adminapprovalrule.KindFrom(d.Kind)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := adminapprovalrule.ProviderFrom(d.Provider)
+	if err != nil {
+		return nil, err
+	}
+	role, err := adminuser.RoleFrom(d.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	b := adminapprovalrule.New().
+		ID(rid).
+		Kind(kind).
+		Value(d.Value).
+		Provider(provider).
+		Role(role).
+		UpdatedAt(d.UpdatedAt)
+	if d.CreatedBy != "" {
+		by, err := adminuser.IDFrom(d.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		b = b.CreatedBy(by)
+	}
+	return b.Build()
+}
@@ -0,0 +1,85 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+type AdminAuditRecordDocument struct {
+	ID        string            `json:"id" bson:"id" jsonschema:"required,description=Admin audit record ID (ULID format)"`
+	Seq       int64             `json:"seq" bson:"seq" jsonschema:"required,description=Position in the hash chain, starting at 1"`
+	Actor     string            `json:"actor" bson:"actor" jsonschema:"required,foreignkey=adminuser,description=ID of the admin user who made the request"`
+	Action    string            `json:"action" bson:"action" jsonschema:"required,description=HTTP method and route of the request (e.g. PATCH /api/v1/users/:id)"`
+	Target    string            `json:"target" bson:"target" jsonschema:"description=ID of the resource in the route. Default: \"\""`
+	RequestID string            `json:"requestid" bson:"requestid" jsonschema:"description=Request ID of the request. Default: \"\""`
+	IP        string            `json:"ip" bson:"ip" jsonschema:"description=Client IP address. Default: \"\""`
+	Status    int               `json:"status" bson:"status" jsonschema:"description=HTTP status of the response"`
+	Diff      map[string]string `json:"diff" bson:"diff" jsonschema:"description=Path parameters and request body fields, secrets redacted. Default: {}"`
+	CreatedAt time.Time         `json:"createdat" bson:"createdat" jsonschema:"required,description=When the request was made"`
+	PrevHash  string            `json:"prevhash" bson:"prevhash" jsonschema:"description=Hash of the previous record, empty for the first one"`
+	Hash      string            `json:"hash" bson:"hash" jsonschema:"required,description=SHA-256 over the record and the previous hash (hex)"`
+}
+
+type AdminAuditRecordConsumer = Consumer[*AdminAuditRecordDocument, *adminaudit.Record]
+
+func NewAdminAuditRecordConsumer() *AdminAuditRecordConsumer {
+	return NewConsumer[*AdminAuditRecordDocument, *adminaudit.Record](func(a *adminaudit.Record) bool {
+		return true
+	})
+}
+
+func NewAdminAuditRecord(r *adminaudit.Record) (*AdminAuditRecordDocument, string) {
+	rid := r.ID().String()
+
+	diff := r.Diff()
+	if diff == nil {
+		diff = map[string]string{}
+	}
+
+	return &AdminAuditRecordDocument{
+		ID:        rid,
+		Seq:       r.Seq(),
+		Actor:     r.Actor().String(),
+		Action:    r.Action(),
+		Target:    r.Target(),
+		RequestID: r.RequestID(),
+		IP:        r.IP(),
+		Status:    r.Status(),
+		Diff:      diff,
+		CreatedAt: r.CreatedAt(),
+		PrevHash:  r.PrevHash(),
+		Hash:      r.Hash(),
+	}, rid
+}
+
+func (d *AdminAuditRecordDocument) Model() (*adminaudit.Record, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	rid, err := adminaudit.IDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := adminuser.IDFrom(d.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return adminaudit.New().
+		ID(rid).
+		Seq(d.Seq).
+		Actor(actor).
+		Action(d.Action).
+		Target(d.Target).
+		RequestID(d.RequestID).
+		IP(d.IP).
+		Status(d.Status).
+		Diff(d.Diff).
+		CreatedAt(d.CreatedAt).
+		PrevHash(d.PrevHash).
+		Hash(d.Hash).
+		Build()
+}
@@ -0,0 +1,61 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+type AdminSessionDocument struct {
+	ID        string    `json:"id" bson:"id" jsonschema:"required,description=Admin session ID (ULID format), carried by the session token"`
+	AdminUser string    `json:"adminuser" bson:"adminuser" jsonschema:"required,foreignkey=adminuser,description=ID of the signed-in admin user"`
+	UserAgent string    `json:"useragent" bson:"useragent" jsonschema:"description=User agent of the sign-in request. Default: \"\""`
+	IP        string    `json:"ip" bson:"ip" jsonschema:"description=Client IP address of the sign-in request. Default: \"\""`
+	CreatedAt time.Time `json:"createdat" bson:"createdat" jsonschema:"required,description=When the admin signed in"`
+	ExpiresAt time.Time `json:"expiresat" bson:"expiresat" jsonschema:"required,description=When the session ends"`
+}
+
+type AdminSessionConsumer = Consumer[*AdminSessionDocument, *adminsession.Session]
+
+func NewAdminSessionConsumer() *AdminSessionConsumer {
+	return NewConsumer[*AdminSessionDocument, *adminsession.Session](func(a *adminsession.Session) bool {
+		return true
+	})
+}
+
+func NewAdminSession(s *adminsession.Session) (*AdminSessionDocument, string) {
+	sid := s.ID().String()
+	return &AdminSessionDocument{
+		ID:        sid,
+		AdminUser: s.AdminUser().String(),
+		UserAgent: s.UserAgent(),
+		IP:        s.IP(),
+		CreatedAt: s.CreatedAt(),
+		ExpiresAt: s.ExpiresAt(),
+	}, sid
+}
+
+func (d *AdminSessionDocument) Model() (*adminsession.Session, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	sid, err := adminsession.IDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	u, err := adminuser.IDFrom(d.AdminUser)
+	if err != nil {
+		return nil, err
+	}
+
+	return adminsession.New().
+		ID(sid).
+		AdminUser(u).
+		UserAgent(d.UserAgent).
+		IP(d.IP).
+		CreatedAt(d.CreatedAt).
+		ExpiresAt(d.ExpiresAt).
+		Build()
+}
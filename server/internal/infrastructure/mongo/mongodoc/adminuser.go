@@ -8,16 +8,17 @@ import (
 )
 
 type AdminUserDocument struct {
-	ID         string    `json:"id" bson:"id" jsonschema:"required,description=Admin user ID (ULID format)"`
-	Email      string    `json:"email" bson:"email" jsonschema:"required,description=Admin user email address (lowercase, unique)"`
-	Name       string    `json:"name" bson:"name" jsonschema:"required,description=Admin user display name"`
-	PictureURL string    `json:"pictureurl" bson:"pictureurl" jsonschema:"description=Admin user picture URL from Google profile. Default: \"\""`
-	Role       string    `json:"role" bson:"role" jsonschema:"description=Admin user role: system_admin or viewer. Default: \"\""`
-	Status     string    `json:"status" bson:"status" jsonschema:"required,description=Admin user status: pending, approved or rejected"`
-	ApprovedBy string    `json:"approvedby" bson:"approvedby" jsonschema:"description=ID of the admin who approved this user (ULID format). Default: \"\""`
-	ApprovedAt time.Time `json:"approvedat" bson:"approvedat" jsonschema:"description=Approval timestamp"`
-	CreatedAt  time.Time `json:"createdat" bson:"createdat" jsonschema:"required,description=Creation timestamp"`
-	UpdatedAt  time.Time `json:"updatedat" bson:"updatedat" jsonschema:"required,description=Last update timestamp"`
+	ID             string    `json:"id" bson:"id" jsonschema:"required,description=Admin user ID (ULID format)"`
+	Email          string    `json:"email" bson:"email" jsonschema:"required,description=Admin user email address (lowercase, unique)"`
+	Name           string    `json:"name" bson:"name" jsonschema:"required,description=Admin user display name"`
+	PictureURL     string    `json:"pictureurl" bson:"pictureurl" jsonschema:"description=Admin user picture URL from Google profile. Default: \"\""`
+	Role           string    `json:"role" bson:"role" jsonschema:"description=Admin user role: system_admin or viewer. Default: \"\""`
+	Status         string    `json:"status" bson:"status" jsonschema:"required,description=Admin user status: pending, approved or rejected"`
+	ApprovedBy     string    `json:"approvedby" bson:"approvedby" jsonschema:"description=ID of the admin who approved this user (ULID format). Default: \"\""`
+	ApprovedAt     time.Time `json:"approvedat" bson:"approvedat" jsonschema:"description=Approval timestamp"`
+	ApprovedByRule string    `json:"approvedbyrule" bson:"approvedbyrule" jsonschema:"foreignkey=adminapprovalrule,description=ID of the approval rule that approved this user automatically (ULID format). Default: \"\""`
+	CreatedAt      time.Time `json:"createdat" bson:"createdat" jsonschema:"required,description=Creation timestamp"`
+	UpdatedAt      time.Time `json:"updatedat" bson:"updatedat" jsonschema:"required,description=Last update timestamp"`
 }
 
 type AdminUserConsumer = Consumer[*AdminUserDocument, *adminuser.AdminUser]
@@ -41,17 +42,23 @@ func NewAdminUser(u adminuser.AdminUser) (*AdminUserDocument, string) {
 		approvedBy = by.String()
 	}
 
+	approvedByRule := ""
+	if rule := u.ApprovedByRule(); !rule.IsEmpty() {
+		approvedByRule = rule.String()
+	}
+
 	return &AdminUserDocument{
-		ID:         uid,
-		Email:      u.Email(),
-		Name:       u.Name(),
-		PictureURL: u.PictureURL(),
-		Role:       u.Role().String(),
-		Status:     u.Status().String(),
-		ApprovedBy: approvedBy,
-		ApprovedAt: u.ApprovedAt(),
-		CreatedAt:  u.CreatedAt(),
-		UpdatedAt:  updatedAt,
+		ID:             uid,
+		Email:          u.Email(),
+		Name:           u.Name(),
+		PictureURL:     u.PictureURL(),
+		Role:           u.Role().String(),
+		Status:         u.Status().String(),
+		ApprovedBy:     approvedBy,
+		ApprovedByRule: approvedByRule,
+		ApprovedAt:     u.ApprovedAt(),
+		CreatedAt:      u.CreatedAt(),
+		UpdatedAt:      updatedAt,
 	}, uid
 }
 
@@ -97,5 +104,13 @@ func (d *AdminUserDocument) Model() (*adminuser.AdminUser, error) {
 		b = b.ApprovedBy(by)
 	}
 
+	if d.ApprovedByRule != "" {
+		rule, err := id.AdminApprovalRuleIDFrom(d.ApprovedByRule)
+		if err != nil {
+			return nil, err
+		}
+		b = b.ApprovedByRule(rule)
+	}
+
 	return b.Build()
 }
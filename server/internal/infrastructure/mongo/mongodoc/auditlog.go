@@ -0,0 +1,67 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+)
+
+type AuditLogDocument struct {
+	ID        string            `json:"id" bson:"id" jsonschema:"required,description=Audit log entry ID (ULID format)"`
+	Actor     string            `json:"actor" bson:"actor" jsonschema:"required,foreignkey=adminuser,description=ID of the admin user who took the action"`
+	Action    string            `json:"action" bson:"action" jsonschema:"required,description=Action taken, as <resource>.<verb> (e.g. user.merge)"`
+	Target    string            `json:"target" bson:"target" jsonschema:"description=ID of the resource the action was taken on. Default: \"\""`
+	Detail    map[string]string `json:"detail" bson:"detail" jsonschema:"description=Action specific values. Default: {}"`
+	CreatedAt time.Time         `json:"createdat" bson:"createdat" jsonschema:"required,description=When the action was taken"`
+}
+
+type AuditLogConsumer = Consumer[*AuditLogDocument, *auditlog.Entry]
+
+func NewAuditLogConsumer() *AuditLogConsumer {
+	return NewConsumer[*AuditLogDocument, *auditlog.Entry](func(a *auditlog.Entry) bool {
+		return true
+	})
+}
+
+func NewAuditLog(e *auditlog.Entry) (*AuditLogDocument, string) {
+	eid := e.ID().String()
+
+	detail := e.Detail()
+	if detail == nil {
+		detail = map[string]string{}
+	}
+
+	return &AuditLogDocument{
+		ID:        eid,
+		Actor:     e.Actor().String(),
+		Action:    e.Action().String(),
+		Target:    e.Target(),
+		Detail:    detail,
+		CreatedAt: e.CreatedAt(),
+	}, eid
+}
+
+func (d *AuditLogDocument) Model() (*auditlog.Entry, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	eid, err := auditlog.IDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := adminuser.IDFrom(d.Actor)
+	if err != nil {
+		return nil, err
+	}
+
+	return auditlog.New().
+		ID(eid).
+		Actor(actor).
+		Action(auditlog.Action(d.Action)).
+		Target(d.Target).
+		Detail(d.Detail).
+		CreatedAt(d.CreatedAt).
+		Build()
+}
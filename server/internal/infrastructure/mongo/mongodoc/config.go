@@ -1,6 +1,8 @@
 package mongodoc
 
 import (
+	"time"
+
 	"github.com/reearth/reearth-accounts/server/pkg/config"
 	"github.com/reearth/reearth-accounts/server/pkg/policy"
 )
@@ -8,19 +10,26 @@ import (
 type ConfigDocument struct {
 	Migration     int64      `json:"migration" jsonschema:"description=Current migration version number. Default: 0"`
 	Auth          *Auth      `json:"auth" jsonschema:"description=Authentication certificates configuration. Default: null"`
+	Keys          []Auth     `json:"keys" jsonschema:"description=Versions of the token signing key pair. Default: []"`
 	DefaultPolicy *policy.ID `json:"defaultpolicy" jsonschema:"description=Default policy ID. Default: null"`
+	PurgeSince    *time.Time `json:"purgesince" jsonschema:"description=Time the purge of deactivated users and workspaces first ran. Default: null"`
 }
 
 type Auth struct {
-	Cert string `json:"cert" jsonschema:"description=Auth certificate (PEM format). Default: \"\""`
-	Key  string `json:"key" jsonschema:"description=Auth private key (PEM format). Default: \"\""`
+	Cert        string     `json:"cert" jsonschema:"description=Auth certificate (PEM format). Default: \"\""`
+	Key         string     `json:"key" jsonschema:"description=Auth private key (PEM format). Default: \"\""`
+	KID         string     `json:"kid" jsonschema:"description=Key ID published in the JWS kid header. Default: \"\""`
+	ActivatedAt time.Time  `json:"activatedat" jsonschema:"description=Time the key starts signing tokens. Default: null"`
+	RetiredAt   *time.Time `json:"retiredat" jsonschema:"description=Time the key is no longer published. Default: null"`
 }
 
 func NewConfig(c config.Config) ConfigDocument {
 	return ConfigDocument{
 		Migration:     c.Migration,
 		Auth:          NewConfigAuth(c.Auth),
+		Keys:          newConfigAuthKeys(c.Keys),
 		DefaultPolicy: c.DefaultPolicy,
+		PurgeSince:    c.PurgeSince,
 	}
 }
 
@@ -29,8 +38,29 @@ func NewConfigAuth(c *config.Auth) *Auth {
 		return nil
 	}
 	return &Auth{
-		Cert: c.Cert,
-		Key:  c.Key,
+		Cert:        c.Cert,
+		Key:         c.Key,
+		KID:         c.KID,
+		ActivatedAt: c.ActivatedAt,
+		RetiredAt:   c.RetiredAt,
+	}
+}
+
+func newConfigAuthKeys(keys []config.Auth) []Auth {
+	res := make([]Auth, 0, len(keys))
+	for _, k := range keys {
+		res = append(res, *NewConfigAuth(&k))
+	}
+	return res
+}
+
+func (a Auth) model() config.Auth {
+	return config.Auth{
+		Cert:        a.Cert,
+		Key:         a.Key,
+		KID:         a.KID,
+		ActivatedAt: a.ActivatedAt,
+		RetiredAt:   a.RetiredAt,
 	}
 }
 
@@ -42,13 +72,15 @@ func (c *ConfigDocument) Model() *config.Config {
 	cfg := &config.Config{
 		Migration:     c.Migration,
 		DefaultPolicy: c.DefaultPolicy,
+		PurgeSince:    c.PurgeSince,
 	}
 
 	if c.Auth != nil {
-		cfg.Auth = &config.Auth{
-			Cert: c.Auth.Cert,
-			Key:  c.Auth.Key,
-		}
+		a := c.Auth.model()
+		cfg.Auth = &a
+	}
+	for _, k := range c.Keys {
+		cfg.Keys = append(cfg.Keys, k.model())
 	}
 
 	return cfg
@@ -0,0 +1,176 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+)
+
+type LDAPSyncUserChangeDocument struct {
+	User  string `json:"user" bson:"user" jsonschema:"required,foreignkey=user,description=Changed user (ULID format)"`
+	Sub   string `json:"sub" bson:"sub" jsonschema:"description=Auth sub of the user in the directory"`
+	Email string `json:"email" bson:"email" jsonschema:"description=Email of the user in the directory"`
+}
+
+type LDAPSyncMembershipChangeDocument struct {
+	Workspace string `json:"workspace" bson:"workspace" jsonschema:"required,foreignkey=workspace,description=Workspace whose membership changed (ULID format)"`
+	User      string `json:"user" bson:"user" jsonschema:"required,foreignkey=user,description=Member (ULID format)"`
+	From      string `json:"from" bson:"from" jsonschema:"description=Previous role, empty when the user joined"`
+	To        string `json:"to" bson:"to" jsonschema:"description=New role, empty when the user left"`
+}
+
+type LDAPSyncSkipDocument struct {
+	DN     string `json:"dn" bson:"dn" jsonschema:"required,description=Distinguished name of the skipped entry"`
+	Reason string `json:"reason" bson:"reason" jsonschema:"required,description=Why the entry was skipped"`
+}
+
+type LDAPSyncRunDocument struct {
+	ID          string                             `json:"id" bson:"id" jsonschema:"required,description=LDAP sync run ID (ULID format)"`
+	Status      string                             `json:"status" bson:"status" jsonschema:"required,description=running, succeeded or failed"`
+	Err         string                             `json:"err" bson:"err" jsonschema:"description=Why a failed run stopped. Default: \"\""`
+	StartedAt   time.Time                          `json:"startedat" bson:"startedat" jsonschema:"required,description=Start timestamp"`
+	FinishedAt  *time.Time                         `json:"finishedat,omitempty" bson:"finishedat,omitempty" jsonschema:"description=Finish timestamp. Default: null"`
+	Users       []string                           `json:"users" bson:"users" jsonschema:"description=Active users of the directory as of the run (ULID format). Default: []"`
+	Created     []LDAPSyncUserChangeDocument       `json:"created" bson:"created" jsonschema:"description=Users created. Default: []"`
+	Updated     []LDAPSyncUserChangeDocument       `json:"updated" bson:"updated" jsonschema:"description=Users whose name or email changed. Default: []"`
+	Reactivated []LDAPSyncUserChangeDocument       `json:"reactivated" bson:"reactivated" jsonschema:"description=Users reactivated. Default: []"`
+	Deactivated []LDAPSyncUserChangeDocument       `json:"deactivated" bson:"deactivated" jsonschema:"description=Users deactivated. Default: []"`
+	Memberships []LDAPSyncMembershipChangeDocument `json:"memberships" bson:"memberships" jsonschema:"description=Workspace memberships changed by role mappings. Default: []"`
+	Skipped     []LDAPSyncSkipDocument             `json:"skipped" bson:"skipped" jsonschema:"description=Directory entries that could not be synced. Default: []"`
+}
+
+type LDAPSyncRunConsumer = Consumer[*LDAPSyncRunDocument, *ldapsync.Run]
+
+func NewLDAPSyncRunConsumer() *LDAPSyncRunConsumer {
+	return NewConsumer[*LDAPSyncRunDocument, *ldapsync.Run](func(a *ldapsync.Run) bool {
+		return true
+	})
+}
+
+func NewLDAPSyncRun(r *ldapsync.Run) (*LDAPSyncRunDocument, string) {
+	rid := r.ID().String()
+	return &LDAPSyncRunDocument{
+		ID:          rid,
+		Status:      string(r.Status()),
+		Err:         r.Err(),
+		StartedAt:   r.StartedAt(),
+		FinishedAt:  r.FinishedAt(),
+		Users:       r.Users().Strings(),
+		Created:     newLDAPSyncUserChanges(r.Created()),
+		Updated:     newLDAPSyncUserChanges(r.Updated()),
+		Reactivated: newLDAPSyncUserChanges(r.Reactivated()),
+		Deactivated: newLDAPSyncUserChanges(r.Deactivated()),
+		Memberships: newLDAPSyncMembershipChanges(r.Memberships()),
+		Skipped:     newLDAPSyncSkips(r.Skipped()),
+	}, rid
+}
+
+func newLDAPSyncUserChanges(l []ldapsync.UserChange) []LDAPSyncUserChangeDocument {
+	res := make([]LDAPSyncUserChangeDocument, 0, len(l))
+	for _, c := range l {
+		res = append(res, LDAPSyncUserChangeDocument{User: c.User.String(), Sub: c.Sub, Email: c.Email})
+	}
+	return res
+}
+
+func newLDAPSyncMembershipChanges(l []ldapsync.MembershipChange) []LDAPSyncMembershipChangeDocument {
+	res := make([]LDAPSyncMembershipChangeDocument, 0, len(l))
+	for _, c := range l {
+		res = append(res, LDAPSyncMembershipChangeDocument{
+			Workspace: c.Workspace.String(),
+			User:      c.User.String(),
+			From:      c.From.String(),
+			To:        c.To.String(),
+		})
+	}
+	return res
+}
+
+func newLDAPSyncSkips(l []ldapsync.Skip) []LDAPSyncSkipDocument {
+	res := make([]LDAPSyncSkipDocument, 0, len(l))
+	for _, s := range l {
+		res = append(res, LDAPSyncSkipDocument{DN: s.DN, Reason: s.Reason})
+	}
+	return res
+}
+
+func (d *LDAPSyncRunDocument) Model() (*ldapsync.Run, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	rid, err := ldapsync.IDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := id.UserIDListFrom(d.Users)
+	if err != nil {
+		return nil, err
+	}
+	created, err := d.userChanges(d.Created)
+	if err != nil {
+		return nil, err
+	}
+	updated, err := d.userChanges(d.Updated)
+	if err != nil {
+		return nil, err
+	}
+	reactivated, err := d.userChanges(d.Reactivated)
+	if err != nil {
+		return nil, err
+	}
+	deactivated, err := d.userChanges(d.Deactivated)
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]ldapsync.MembershipChange, 0, len(d.Memberships))
+	for _, c := range d.Memberships {
+		wid, err := id.WorkspaceIDFrom(c.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		uid, err := id.UserIDFrom(c.User)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, ldapsync.MembershipChange{
+			Workspace: wid,
+			User:      uid,
+			From:      role.RoleType(c.From),
+			To:        role.RoleType(c.To),
+		})
+	}
+	skipped := make([]ldapsync.Skip, 0, len(d.Skipped))
+	for _, s := range d.Skipped {
+		skipped = append(skipped, ldapsync.Skip{DN: s.DN, Reason: s.Reason})
+	}
+
+	return ldapsync.New().
+		ID(rid).
+		Status(ldapsync.Status(d.Status)).
+		Err(d.Err).
+		StartedAt(d.StartedAt).
+		FinishedAt(d.FinishedAt).
+		Users(users).
+		Created(created).
+		Updated(updated).
+		Reactivated(reactivated).
+		Deactivated(deactivated).
+		Memberships(memberships).
+		Skipped(skipped).
+		Build()
+}
+
+func (d *LDAPSyncRunDocument) userChanges(l []LDAPSyncUserChangeDocument) ([]ldapsync.UserChange, error) {
+	res := make([]ldapsync.UserChange, 0, len(l))
+	for _, c := range l {
+		uid, err := id.UserIDFrom(c.User)
+		if err != nil {
+			return nil, err
+		}
+		res = append(res, ldapsync.UserChange{User: uid, Sub: c.Sub, Email: c.Email})
+	}
+	return res, nil
+}
@@ -0,0 +1,35 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/passkeylogin"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+)
+
+type PasskeyLoginDocument struct {
+	ID        string    `json:"id" bson:"id" jsonschema:"required,description=Random ID of the login ceremony, handed to the client"`
+	User      string    `json:"user" bson:"user" jsonschema:"required,foreignkey=user,description=ID of the user signing in"`
+	Challenge string    `json:"challenge" bson:"challenge" jsonschema:"required,description=WebAuthn challenge the assertion must sign"`
+	ExpiresAt time.Time `json:"expiresat" bson:"expiresat" jsonschema:"required,description=When the ceremony can no longer be finished"`
+}
+
+func NewPasskeyLogin(s *passkeylogin.Session) (*PasskeyLoginDocument, string) {
+	return &PasskeyLoginDocument{
+		ID:        s.ID(),
+		User:      s.User().String(),
+		Challenge: s.Challenge(),
+		ExpiresAt: s.ExpiresAt(),
+	}, s.ID()
+}
+
+func (d *PasskeyLoginDocument) Model() (*passkeylogin.Session, error) {
+	if d == nil {
+		return nil, nil
+	}
+	u, err := user.IDFrom(d.User)
+	if err != nil {
+		return nil, err
+	}
+	return passkeylogin.From(d.ID, u, d.Challenge, d.ExpiresAt), nil
+}
@@ -0,0 +1,83 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+)
+
+type RoleMappingRuleDocument struct {
+	Claim string `json:"claim" bson:"claim" jsonschema:"required,description=Name of the token claim, with dots for nested claims"`
+	Value string `json:"value" bson:"value" jsonschema:"required,description=Value the claim must contain"`
+	Role  string `json:"role" bson:"role" jsonschema:"required,description=Role granted in the workspace: owner, maintainer, writer or reader"`
+}
+
+type RoleMappingDocument struct {
+	ID        string                    `json:"id" bson:"id" jsonschema:"required,description=Role mapping ID (ULID format)"`
+	Workspace string                    `json:"workspace" bson:"workspace" jsonschema:"required,foreignkey=workspace,description=Workspace whose membership is mapped (ULID format)"`
+	Issuer    string                    `json:"issuer" bson:"issuer" jsonschema:"required,description=Issuer of the tokens of the identity provider"`
+	SubPrefix string                    `json:"subprefix" bson:"subprefix" jsonschema:"description=Prefix of the auth subs of the identity provider. Default: \"\""`
+	Rules     []RoleMappingRuleDocument `json:"rules" bson:"rules" jsonschema:"description=Rules mapping claims to roles. Default: []"`
+	UpdatedAt time.Time                 `json:"updatedat" bson:"updatedat" jsonschema:"description=Last update timestamp"`
+}
+
+type RoleMappingConsumer = Consumer[*RoleMappingDocument, *rolemapping.RoleMapping]
+
+func NewRoleMappingConsumer() *RoleMappingConsumer {
+	return NewConsumer[*RoleMappingDocument, *rolemapping.RoleMapping](func(a *rolemapping.RoleMapping) bool {
+		return true
+	})
+}
+
+func NewRoleMapping(m *rolemapping.RoleMapping) (*RoleMappingDocument, string) {
+	mid := m.ID().String()
+
+	updatedAt := m.UpdatedAt()
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+
+	rules := make([]RoleMappingRuleDocument, 0, len(m.Rules()))
+	for _, r := range m.Rules() {
+		rules = append(rules, RoleMappingRuleDocument{Claim: r.Claim, Value: r.Value, Role: r.Role.String()})
+	}
+
+	return &RoleMappingDocument{
+		ID:        mid,
+		Workspace: m.Workspace().String(),
+		Issuer:    m.Issuer(),
+		SubPrefix: m.SubPrefix(),
+		Rules:     rules,
+		UpdatedAt: updatedAt,
+	}, mid
+}
+
+func (d *RoleMappingDocument) Model() (*rolemapping.RoleMapping, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	mid, err := rolemapping.IDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := id.WorkspaceIDFrom(d.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	rules := make([]rolemapping.Rule, 0, len(d.Rules))
+	for _, r := range d.Rules {
+		rules = append(rules, rolemapping.Rule{Claim: r.Claim, Value: r.Value, Role: role.RoleType(r.Role)})
+	}
+
+	return rolemapping.New().
+		ID(mid).
+		Workspace(wid).
+		Issuer(d.Issuer).
+		SubPrefix(d.SubPrefix).
+		Rules(rules).
+		UpdatedAt(d.UpdatedAt).
+		Build()
+}
@@ -0,0 +1,106 @@
+package mongodoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+)
+
+type SCIMGroupDocument struct {
+	Workspace string `json:"workspace" bson:"workspace" jsonschema:"required,foreignkey=workspace,description=Workspace ID provisioned as the group (ULID format)"`
+	Role      string `json:"role" bson:"role" jsonschema:"required,description=Role of the members of the workspace: maintainer, writer or reader"`
+}
+
+type SCIMTenantDocument struct {
+	ID            string              `json:"id" bson:"id" jsonschema:"required,description=SCIM tenant ID (ULID format)"`
+	Name          string              `json:"name" bson:"name" jsonschema:"required,description=SCIM tenant display name"`
+	SubPrefix     string              `json:"subprefix" bson:"subprefix" jsonschema:"required,description=Prefix of the auth sub of provisioned users"`
+	TokenHash     string              `json:"tokenhash" bson:"tokenhash" jsonschema:"description=Hex SHA-256 of the bearer token. Default: \"\""`
+	Users         []string            `json:"users" bson:"users" jsonschema:"foreignkey=user,description=IDs of the users provisioned by the tenant. Default: []"`
+	Groups        []SCIMGroupDocument `json:"groups" bson:"groups" jsonschema:"description=Workspaces provisioned by the tenant. Default: []"`
+	UpdatedAt     time.Time           `json:"updatedat" bson:"updatedat" jsonschema:"description=Last update timestamp"`
+	Deprovisioned []string            `json:"deprovisioned" bson:"deprovisioned" jsonschema:"foreignkey=user,description=IDs of the users the tenant provisioned and then deleted. Default: []"`
+}
+
+type SCIMTenantConsumer = Consumer[*SCIMTenantDocument, *scimtenant.Tenant]
+
+func NewSCIMTenantConsumer() *SCIMTenantConsumer {
+	return NewConsumer[*SCIMTenantDocument, *scimtenant.Tenant](func(a *scimtenant.Tenant) bool {
+		return true
+	})
+}
+
+func NewSCIMTenant(t *scimtenant.Tenant) (*SCIMTenantDocument, string) {
+	tid := t.ID().String()
+
+	updatedAt := t.UpdatedAt()
+	if updatedAt.IsZero() {
+		updatedAt = time.Now()
+	}
+
+	groups := make([]SCIMGroupDocument, 0, len(t.Groups()))
+	for _, g := range t.Groups() {
+		groups = append(groups, SCIMGroupDocument{Workspace: g.Workspace.String(), Role: g.Role.String()})
+	}
+
+	users := t.Users().Strings()
+	if users == nil {
+		users = []string{}
+	}
+
+	deprovisioned := t.Deprovisioned().Strings()
+	if deprovisioned == nil {
+		deprovisioned = []string{}
+	}
+
+	return &SCIMTenantDocument{
+		ID:            tid,
+		Name:          t.Name(),
+		SubPrefix:     t.SubPrefix(),
+		TokenHash:     t.TokenHash(),
+		Users:         users,
+		Groups:        groups,
+		UpdatedAt:     updatedAt,
+		Deprovisioned: deprovisioned,
+	}, tid
+}
+
+func (d *SCIMTenantDocument) Model() (*scimtenant.Tenant, error) {
+	if d == nil {
+		return nil, nil
+	}
+
+	tid, err := scimtenant.IDFrom(d.ID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := id.UserIDListFrom(d.Users)
+	if err != nil {
+		return nil, err
+	}
+	deprovisioned, err := id.UserIDListFrom(d.Deprovisioned)
+	if err != nil {
+		return nil, err
+	}
+	groups := make([]scimtenant.Group, 0, len(d.Groups))
+	for _, g := range d.Groups {
+		wid, err := id.WorkspaceIDFrom(g.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, scimtenant.Group{Workspace: wid, Role: role.RoleType(g.Role)})
+	}
+
+	return scimtenant.New().
+		ID(tid).
+		Name(d.Name).
+		SubPrefix(d.SubPrefix).
+		TokenHash(d.TokenHash).
+		Users(users).
+		Deprovisioned(deprovisioned).
+		Groups(groups).
+		UpdatedAt(d.UpdatedAt).
+		Build()
+}
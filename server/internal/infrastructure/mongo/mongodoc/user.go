@@ -14,24 +14,67 @@ type PasswordResetDocument struct {
 	CreatedAt time.Time `json:"createdat" jsonschema:"description=Token creation timestamp"`
 }
 
+type MagicLinkDocument struct {
+	TokenHash   string      `json:"tokenhash" jsonschema:"description=SHA-256 hash of the pending sign-in token. Default: \"\" (none pending)"`
+	CreatedAt   time.Time   `json:"createdat" jsonschema:"description=Token creation timestamp"`
+	RequestedAt []time.Time `json:"requestedat" jsonschema:"description=Issue times within the rate-limit window. Default: []"`
+}
+
+type AuthCodeDocument struct {
+	CodeHash      string    `json:"codehash" jsonschema:"description=SHA-256 hash of the pending authorization code"`
+	ClientID      string    `json:"clientid" jsonschema:"description=OIDC client the code was issued to"`
+	RedirectURI   string    `json:"redirecturi" jsonschema:"description=Redirect URI the code must be redeemed with"`
+	Scopes        []string  `json:"scopes" jsonschema:"description=Granted scopes. Default: []"`
+	Nonce         string    `json:"nonce" jsonschema:"description=Nonce echoed in the ID token. Default: \"\""`
+	CodeChallenge string    `json:"codechallenge" jsonschema:"description=PKCE S256 code challenge"`
+	AuthTime      time.Time `json:"authtime" jsonschema:"description=Time the user authenticated"`
+	CreatedAt     time.Time `json:"createdat" jsonschema:"description=Code creation timestamp"`
+	ExpiresAt     time.Time `json:"expiresat" jsonschema:"description=Code expiry"`
+}
+
 type UserDocument struct {
-	ID             string                 `json:"id" bson:"id" jsonschema:"required,description=User ID (ULID format)"`
-	Name           string                 `json:"name" bson:"name" jsonschema:"required,description=User display name"`
-	Alias          string                 `json:"alias" bson:"alias" jsonschema:"required,description=Unique user handle/alias. Default: \"\""`
-	Email          string                 `json:"email" bson:"email" jsonschema:"required,description=User email address"`
-	LatestLogoutAt time.Time              `json:"latestlogoutat" bson:"latestlogoutat" jsonschema:"description=Timestamp (datetime) of user's latest logout in UTC. Default: zero value"`
-	Subs           []string               `json:"subs" bson:"subs" jsonschema:"required,description=OAuth subject identifiers for authentication providers. Default: []"`
-	Workspace      string                 `json:"workspace" bson:"workspace" jsonschema:"required,foreignkey=workspace,description=Personal workspace ID (ULID format)"`
-	Team           string                 `json:"team" bson:",omitempty" jsonschema:"description=Legacy team field (deprecated, use workspace)"`
-	Lang           string                 `json:"lang" bson:"lang" jsonschema:"description=User language preference. Default: \"\" (deprecated, move to metadata)"`
-	Theme          string                 `json:"theme" bson:"theme" jsonschema:"description=User UI theme preference. Default: \"\" (deprecated, move to metadata)"`
-	Password       []byte                 `json:"password" bson:"password,omitempty" jsonschema:"description=Hashed password (bcrypt). Null for OIDC-only users"`
-	PasswordReset  *PasswordResetDocument `json:"passwordreset" bson:"passwordreset" jsonschema:"description=Password reset token information"`
-	Verification   *UserVerificationDoc   `json:"verification" bson:"verification" jsonschema:"description=Email verification state. Default: null"`
-	Metadata       UserMetadataDoc        `json:"metadata" bson:"metadata" jsonschema:"required,description=Extended user metadata. Default: {}"`
-	UpdatedAt      time.Time              `json:"updatedat" bson:"updatedat" jsonschema:"description=Last update timestamp"`
-	DeletedAt      *time.Time             `json:"deletedat" bson:"deletedat,omitempty" jsonschema:"description=Soft delete timestamp. Null = active, non-null = deactivated"`
-	CreatedAt      *time.Time             `json:"createdat" bson:"createdat,omitempty" jsonschema:"description=User creation timestamp. Null for users created before this field existed"`
+	ID                  string                   `json:"id" bson:"id" jsonschema:"required,description=User ID (ULID format)"`
+	Name                string                   `json:"name" bson:"name" jsonschema:"required,description=User display name"`
+	Alias               string                   `json:"alias" bson:"alias" jsonschema:"required,description=Unique user handle/alias. Default: \"\""`
+	Email               string                   `json:"email" bson:"email" jsonschema:"required,description=User email address"`
+	LatestLogoutAt      time.Time                `json:"latestlogoutat" bson:"latestlogoutat" jsonschema:"description=Timestamp (datetime) of user's latest logout in UTC. Default: zero value"`
+	Subs                []string                 `json:"subs" bson:"subs" jsonschema:"required,description=OAuth subject identifiers for authentication providers. Default: []"`
+	AuthLinks           []UserAuthLinkDoc        `json:"authlinks" bson:"authlinks,omitempty" jsonschema:"description=How subs were linked to the existing user. Default: [] (subs the user signed up with have none)"`
+	Workspace           string                   `json:"workspace" bson:"workspace" jsonschema:"required,foreignkey=workspace,description=Personal workspace ID (ULID format)"`
+	Team                string                   `json:"team" bson:",omitempty" jsonschema:"description=Legacy team field (deprecated, use workspace)"`
+	Lang                string                   `json:"lang" bson:"lang" jsonschema:"description=User language preference. Default: \"\" (deprecated, move to metadata)"`
+	Theme               string                   `json:"theme" bson:"theme" jsonschema:"description=User UI theme preference. Default: \"\" (deprecated, move to metadata)"`
+	Password            []byte                   `json:"password" bson:"password,omitempty" jsonschema:"description=Hashed password (bcrypt). Null for OIDC-only users"`
+	PasswordReset       *PasswordResetDocument   `json:"passwordreset" bson:"passwordreset" jsonschema:"description=Password reset token information"`
+	MagicLink           *MagicLinkDocument       `json:"magiclink" bson:"magiclink,omitempty" jsonschema:"description=Passwordless sign-in link state. Null = never requested"`
+	AuthCodes           []AuthCodeDocument       `json:"authcodes" bson:"authcodes,omitempty" jsonschema:"description=Pending authorization codes of the built-in OIDC provider. Default: []"`
+	RefreshTokens       []RefreshTokenFamilyDoc  `json:"refreshtokens" bson:"refreshtokens,omitempty" jsonschema:"description=Sign-ins the built-in OIDC provider issued rotating refresh tokens for. Default: []"`
+	Verification        *UserVerificationDoc     `json:"verification" bson:"verification" jsonschema:"description=Email verification state. Default: null"`
+	MFA                 *UserMFADoc              `json:"mfa" bson:"mfa,omitempty" jsonschema:"description=TOTP second factor for the reearth password provider. Null = not enrolled"`
+	Passkeys            []UserPasskeyDoc         `json:"passkeys" bson:"passkeys,omitempty" jsonschema:"description=Registered WebAuthn passkeys. Default: []"`
+	PasskeyChallenge    *UserPasskeyChallengeDoc `json:"passkeychallenge" bson:"passkeychallenge,omitempty" jsonschema:"description=Pending WebAuthn ceremony challenge. Null = none in flight"`
+	Metadata            UserMetadataDoc          `json:"metadata" bson:"metadata" jsonschema:"required,description=Extended user metadata. Default: {}"`
+	UpdatedAt           time.Time                `json:"updatedat" bson:"updatedat" jsonschema:"description=Last update timestamp"`
+	DeletedAt           *time.Time               `json:"deletedat" bson:"deletedat,omitempty" jsonschema:"description=Soft delete timestamp. Null = active, non-null = deactivated"`
+	CreatedAt           *time.Time               `json:"createdat" bson:"createdat,omitempty" jsonschema:"description=User creation timestamp. Null for users created before this field existed"`
+	MergedInto          *string                  `json:"mergedinto" bson:"mergedinto,omitempty" jsonschema:"foreignkey=user,description=ID of the user this deleted user was merged into. Null = not merged"`
+	DeletionRequestedAt *time.Time               `json:"deletionrequestedat" bson:"deletionrequestedat,omitempty" jsonschema:"description=When the user requested the deletion of their account. Null = not requested"`
+	PurgeNotifiedAt     *time.Time               `json:"purgenotifiedat" bson:"purgenotifiedat,omitempty" jsonschema:"description=When the user was notified of the upcoming purge of their account. Null = not notified"`
+}
+
+type UserAuthLinkDoc struct {
+	Sub      string    `json:"sub" jsonschema:"description=Linked subject identifier, one of subs"`
+	Issuer   string    `json:"issuer" jsonschema:"description=Issuer of the token that proved the identity. Default: \"\""`
+	Email    string    `json:"email" jsonschema:"description=Email of the identity at link time. Default: \"\""`
+	LinkedAt time.Time `json:"linkedat" jsonschema:"description=Link timestamp"`
+}
+
+type RefreshTokenFamilyDoc struct {
+	ID         string    `json:"id" jsonschema:"description=Family ID carried by its refresh tokens"`
+	Generation int64     `json:"generation" jsonschema:"description=Generation of the only refresh token of the family still accepted"`
+	ExpiresAt  time.Time `json:"expiresat" jsonschema:"description=Expiry of that refresh token"`
+	// MaxExpiresAt is zero for families started before sign-ins had an end.
+	MaxExpiresAt time.Time `json:"maxexpiresat" bson:"maxexpiresat,omitempty" jsonschema:"description=End of the sign-in, which refreshing does not extend. Default: zero value"`
 }
 
 type UserVerificationDoc struct {
@@ -40,6 +83,34 @@ type UserVerificationDoc struct {
 	Verified   bool      `json:"verified" jsonschema:"description=Whether the email has been verified. Default: false"`
 }
 
+type UserMFADoc struct {
+	Secret        string     `json:"secret" jsonschema:"description=Base32-encoded TOTP secret"`
+	Enabled       bool       `json:"enabled" jsonschema:"description=Whether enrollment has been confirmed. Default: false"`
+	RecoveryCodes []string   `json:"recoverycodes" jsonschema:"description=SHA-256 hashes of unused recovery codes. Default: []"`
+	LastUsedStep  int64      `json:"lastusedstep" jsonschema:"description=Last accepted TOTP time step, used to reject replays. Default: 0"`
+	Failures      int        `json:"failures" jsonschema:"description=Wrong codes entered since the last accepted one or the last lockout. Default: 0"`
+	LockedUntil   *time.Time `json:"lockeduntil" bson:"lockeduntil,omitempty" jsonschema:"description=When the lockout after too many wrong codes ends. Null = never locked"`
+}
+
+type UserPasskeyDoc struct {
+	ID              []byte     `json:"id" jsonschema:"description=WebAuthn credential ID"`
+	PublicKey       []byte     `json:"publickey" jsonschema:"description=COSE-encoded credential public key"`
+	AttestationType string     `json:"attestationtype" jsonschema:"description=Attestation format reported at registration. Default: \"\""`
+	Transports      []string   `json:"transports" jsonschema:"description=Authenticator transports hinted to clients. Default: []"`
+	AAGUID          []byte     `json:"aaguid" bson:"aaguid,omitempty" jsonschema:"description=Authenticator model identifier"`
+	SignCount       int64      `json:"signcount" jsonschema:"description=Last seen signature counter, used to detect cloned authenticators. Default: 0"`
+	BackupEligible  bool       `json:"backupeligible" jsonschema:"description=Whether the credential may be synced across devices. Default: false"`
+	BackupState     bool       `json:"backupstate" jsonschema:"description=Whether the credential is currently backed up. Default: false"`
+	Nickname        string     `json:"nickname" jsonschema:"description=User-provided label. Default: \"\""`
+	CreatedAt       time.Time  `json:"createdat" jsonschema:"description=Registration timestamp"`
+	LastUsedAt      *time.Time `json:"lastusedat" bson:"lastusedat,omitempty" jsonschema:"description=Last successful login with this passkey. Null = never used"`
+}
+
+type UserPasskeyChallengeDoc struct {
+	Challenge string    `json:"challenge" jsonschema:"description=Base64url WebAuthn challenge"`
+	CreatedAt time.Time `json:"createdat" jsonschema:"description=Challenge creation timestamp"`
+}
+
 type UserMetadataDoc struct {
 	Description string `json:"description" jsonschema:"description=User bio/description. Default: \"\""`
 	Website     string `json:"website" jsonschema:"description=User website URL. Default: \"\""`
@@ -55,6 +126,15 @@ func NewUser(user *user.User) (*UserDocument, string) {
 	for _, a := range auths {
 		authsdoc = append(authsdoc, a.Sub)
 	}
+	var refreshTokensDoc []RefreshTokenFamilyDoc
+	for _, f := range user.RefreshTokenFamilies() {
+		refreshTokensDoc = append(refreshTokensDoc, RefreshTokenFamilyDoc{ID: f.ID, Generation: int64(f.Generation), ExpiresAt: f.ExpiresAt, MaxExpiresAt: f.MaxExpiresAt})
+	}
+
+	var authLinksDoc []UserAuthLinkDoc
+	for _, l := range user.AuthLinks() {
+		authLinksDoc = append(authLinksDoc, UserAuthLinkDoc{Sub: l.Sub, Issuer: l.Issuer, Email: l.Email, LinkedAt: l.LinkedAt})
+	}
 	var v *UserVerificationDoc
 	if user.Verification() != nil {
 		v = &UserVerificationDoc{
@@ -73,6 +153,67 @@ func NewUser(user *user.User) (*UserDocument, string) {
 		}
 	}
 
+	var magicLinkDoc *MagicLinkDocument
+	if m := user.MagicLink(); m != nil {
+		magicLinkDoc = &MagicLinkDocument{
+			TokenHash:   m.TokenHash,
+			CreatedAt:   m.CreatedAt,
+			RequestedAt: append([]time.Time{}, m.RequestedAt...), // never null: the schema expects an array
+		}
+	}
+
+	var authCodesDoc []AuthCodeDocument
+	for _, c := range user.AuthCodes() {
+		authCodesDoc = append(authCodesDoc, AuthCodeDocument{
+			CodeHash:      c.CodeHash,
+			ClientID:      c.ClientID,
+			RedirectURI:   c.RedirectURI,
+			Scopes:        append([]string{}, c.Scopes...), // never null: the schema expects an array
+			Nonce:         c.Nonce,
+			CodeChallenge: c.CodeChallenge,
+			AuthTime:      c.AuthTime,
+			CreatedAt:     c.CreatedAt,
+			ExpiresAt:     c.ExpiresAt,
+		})
+	}
+
+	var mfaDoc *UserMFADoc
+	if m := user.MFA(); m != nil {
+		mfaDoc = &UserMFADoc{
+			Secret:        m.Secret(),
+			Enabled:       m.IsEnabled(),
+			RecoveryCodes: append([]string{}, m.RecoveryCodes()...), // never null: the schema expects an array
+			LastUsedStep:  m.LastUsedStep(),
+			Failures:      m.Failures(),
+			LockedUntil:   m.LockedUntil(),
+		}
+	}
+
+	var passkeysDoc []UserPasskeyDoc
+	for _, p := range user.Passkeys() {
+		passkeysDoc = append(passkeysDoc, UserPasskeyDoc{
+			ID:              p.ID,
+			PublicKey:       p.PublicKey,
+			AttestationType: p.AttestationType,
+			Transports:      append([]string{}, p.Transports...), // never null: the schema expects an array
+			AAGUID:          p.AAGUID,
+			SignCount:       int64(p.SignCount),
+			BackupEligible:  p.BackupEligible,
+			BackupState:     p.BackupState,
+			Nickname:        p.Nickname,
+			CreatedAt:       p.CreatedAt,
+			LastUsedAt:      p.LastUsedAt,
+		})
+	}
+
+	var passkeyChallengeDoc *UserPasskeyChallengeDoc
+	if c := user.PasskeyChallenge(); c != nil {
+		passkeyChallengeDoc = &UserPasskeyChallengeDoc{
+			Challenge: c.Challenge,
+			CreatedAt: c.CreatedAt,
+		}
+	}
+
 	metadataDoc := UserMetadataDoc{
 		Description: user.Metadata().Description(),
 		Website:     user.Metadata().Website(),
@@ -87,20 +228,30 @@ func NewUser(user *user.User) (*UserDocument, string) {
 	}
 
 	return &UserDocument{
-		ID:             id,
-		Name:           user.Name(),
-		Alias:          user.Alias(),
-		Email:          user.Email(),
-		LatestLogoutAt: user.LatestLogoutAt(),
-		Subs:           authsdoc,
-		Workspace:      user.Workspace().String(),
-		Verification:   v,
-		Password:       user.Password(),
-		PasswordReset:  pwdResetDoc,
-		Metadata:       metadataDoc,
-		UpdatedAt:      updatedAt,
-		DeletedAt:      user.DeletedAt(),
-		CreatedAt:      user.CreatedAt(),
+		ID:                  id,
+		Name:                user.Name(),
+		Alias:               user.Alias(),
+		Email:               user.Email(),
+		LatestLogoutAt:      user.LatestLogoutAt(),
+		Subs:                authsdoc,
+		AuthLinks:           authLinksDoc,
+		Workspace:           user.Workspace().String(),
+		Verification:        v,
+		MFA:                 mfaDoc,
+		Passkeys:            passkeysDoc,
+		PasskeyChallenge:    passkeyChallengeDoc,
+		Password:            user.Password(),
+		PasswordReset:       pwdResetDoc,
+		MagicLink:           magicLinkDoc,
+		AuthCodes:           authCodesDoc,
+		RefreshTokens:       refreshTokensDoc,
+		Metadata:            metadataDoc,
+		UpdatedAt:           updatedAt,
+		DeletedAt:           user.DeletedAt(),
+		CreatedAt:           user.CreatedAt(),
+		MergedInto:          user.MergedInto().StringRef(),
+		DeletionRequestedAt: user.DeletionRequestedAt(),
+		PurgeNotifiedAt:     user.PurgeNotifiedAt(),
 	}, id
 }
 
@@ -135,6 +286,35 @@ func (d *UserDocument) Model() (*user.User, error) {
 		v = user.VerificationFrom(d.Verification.Code, d.Verification.Expiration, d.Verification.Verified)
 	}
 
+	var authLinks []user.AuthLink
+	for _, l := range d.AuthLinks {
+		authLinks = append(authLinks, user.AuthLink{Sub: l.Sub, Issuer: l.Issuer, Email: l.Email, LinkedAt: l.LinkedAt})
+	}
+
+	var refreshTokens []user.RefreshTokenFamily
+	for _, f := range d.RefreshTokens {
+		refreshTokens = append(refreshTokens, user.RefreshTokenFamily{ID: f.ID, Generation: int(f.Generation), ExpiresAt: f.ExpiresAt, MaxExpiresAt: f.MaxExpiresAt})
+	}
+
+	var authCodes []user.AuthCode
+	for _, c := range d.AuthCodes {
+		authCodes = append(authCodes, c.Model())
+	}
+
+	var passkeys []user.Passkey
+	for _, p := range d.Passkeys {
+		passkeys = append(passkeys, p.Model())
+	}
+
+	var mergedInto *id.UserID
+	if d.MergedInto != nil {
+		mid, err := id.UserIDFrom(*d.MergedInto)
+		if err != nil {
+			return nil, err
+		}
+		mergedInto = &mid
+	}
+
 	metadata := user.NewMetadata()
 	metadata.SetDescription(d.Metadata.Description)
 	metadata.SetWebsite(d.Metadata.Website)
@@ -150,13 +330,23 @@ func (d *UserDocument) Model() (*user.User, error) {
 		Metadata(metadata).
 		Alias(d.Alias).
 		Auths(auths).
+		AuthLinks(authLinks).
 		Workspace(tid).
 		Verification(v).
+		MFA(d.MFA.Model()).
+		Passkeys(passkeys).
+		PasskeyChallenge(d.PasskeyChallenge.Model()).
 		EncodedPassword(d.Password).
 		PasswordReset(d.PasswordReset.Model()).
+		MagicLink(d.MagicLink.Model()).
+		AuthCodes(authCodes).
+		RefreshTokenFamilies(refreshTokens).
 		UpdatedAt(d.UpdatedAt).
 		DeletedAt(d.DeletedAt).
 		CreatedAt(d.CreatedAt).
+		MergedInto(mergedInto).
+		DeletionRequestedAt(d.DeletionRequestedAt).
+		PurgeNotifiedAt(d.PurgeNotifiedAt).
 		Build()
 
 	if err != nil {
@@ -175,6 +365,64 @@ func (d *PasswordResetDocument) Model() *user.PasswordReset {
 	}
 }
 
+func (d *MagicLinkDocument) Model() *user.MagicLink {
+	if d == nil {
+		return nil
+	}
+	return &user.MagicLink{
+		TokenHash:   d.TokenHash,
+		CreatedAt:   d.CreatedAt,
+		RequestedAt: d.RequestedAt,
+	}
+}
+
+func (d AuthCodeDocument) Model() user.AuthCode {
+	return user.AuthCode{
+		CodeHash:      d.CodeHash,
+		ClientID:      d.ClientID,
+		RedirectURI:   d.RedirectURI,
+		Scopes:        d.Scopes,
+		Nonce:         d.Nonce,
+		CodeChallenge: d.CodeChallenge,
+		AuthTime:      d.AuthTime,
+		CreatedAt:     d.CreatedAt,
+		ExpiresAt:     d.ExpiresAt,
+	}
+}
+
+func (d *UserMFADoc) Model() *user.MFA {
+	if d == nil {
+		return nil
+	}
+	return user.MFAFrom(d.Secret, d.Enabled, d.RecoveryCodes, d.LastUsedStep, d.Failures, d.LockedUntil)
+}
+
+func (d UserPasskeyDoc) Model() user.Passkey {
+	return user.Passkey{
+		ID:              d.ID,
+		PublicKey:       d.PublicKey,
+		AttestationType: d.AttestationType,
+		Transports:      d.Transports,
+		AAGUID:          d.AAGUID,
+		SignCount:       uint32(d.SignCount),
+		BackupEligible:  d.BackupEligible,
+		BackupState:     d.BackupState,
+		Nickname:        d.Nickname,
+		CreatedAt:       d.CreatedAt,
+		LastUsedAt:      d.LastUsedAt,
+	}
+}
+
+func (d *UserPasskeyChallengeDoc) Model() *user.PasskeyChallenge {
+	if d == nil {
+		return nil
+	}
+	return &user.PasskeyChallenge{
+		Challenge: d.Challenge,
+		CreatedAt: d.CreatedAt,
+	}
+}
+
 type UserConsumer = mongox.SliceFuncConsumer[*UserDocument, *user.User]
 
 func NewUserConsumer(host string) *UserConsumer {
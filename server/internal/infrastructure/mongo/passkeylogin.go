@@ -0,0 +1,44 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth-accounts/server/pkg/passkeylogin"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PasskeyLogin struct {
+	client *mongox.Collection
+}
+
+func NewPasskeyLogin(client *mongox.Client) *PasskeyLogin {
+	return &PasskeyLogin{
+		client: client.WithCollection("passkeylogin"),
+	}
+}
+
+func (r *PasskeyLogin) Save(ctx context.Context, s *passkeylogin.Session) error {
+	doc, id := mongodoc.NewPasskeyLogin(s)
+	return r.client.SaveOne(ctx, id, doc)
+}
+
+func (r *PasskeyLogin) Take(ctx context.Context, id string) (*passkeylogin.Session, error) {
+	doc := mongodoc.PasskeyLoginDocument{}
+	if err := r.client.Client().FindOneAndDelete(ctx, bson.M{"id": id}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, rerror.ErrNotFound
+		}
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return doc.Model()
+}
+
+func (r *PasskeyLogin) RemoveExpired(ctx context.Context, t time.Time) error {
+	return r.client.RemoveAll(ctx, bson.M{"expiresat": bson.M{"$lt": t}})
+}
@@ -64,6 +64,10 @@ func (r *Permittable) SaveMany(ctx context.Context, ps permittable.List) error {
 	return r.client.SaveAll(ctx, ids, docs)
 }
 
+func (r *Permittable) RemoveByUserID(ctx context.Context, id user.ID) error {
+	return r.client.RemoveAll(ctx, bson.M{"userid": id.String()})
+}
+
 func (r *Permittable) find(ctx context.Context, filter any) (permittable.List, error) {
 	c := mongodoc.NewPermittableConsumer()
 	if err := r.client.Find(ctx, filter, c); err != nil {
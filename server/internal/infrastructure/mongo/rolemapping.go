@@ -0,0 +1,66 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/mongox"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type RoleMapping struct {
+	client *mongox.Collection
+}
+
+func NewRoleMapping(client *mongox.Client) *RoleMapping {
+	return &RoleMapping{
+		client: client.WithCollection("rolemapping"),
+	}
+}
+
+func (r *RoleMapping) FindAll(ctx context.Context) (rolemapping.List, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *RoleMapping) FindByWorkspace(ctx context.Context, ws workspace.ID) (*rolemapping.RoleMapping, error) {
+	return r.findOne(ctx, bson.M{"workspace": ws.String()})
+}
+
+func (r *RoleMapping) FindByIssuer(ctx context.Context, issuer string) (rolemapping.List, error) {
+	return r.find(ctx, bson.M{"issuer": issuer})
+}
+
+// Save replaces the mapping of the same workspace, as a workspace has at most
+// one mapping.
+func (r *RoleMapping) Save(ctx context.Context, m *rolemapping.RoleMapping) error {
+	if err := r.client.RemoveAll(ctx, bson.M{
+		"workspace": m.Workspace().String(),
+		"id":        bson.M{"$ne": m.ID().String()},
+	}); err != nil {
+		return err
+	}
+	doc, mid := mongodoc.NewRoleMapping(m)
+	return r.client.SaveOne(ctx, mid, doc)
+}
+
+func (r *RoleMapping) RemoveByWorkspace(ctx context.Context, ws workspace.ID) error {
+	return r.client.RemoveAll(ctx, bson.M{"workspace": ws.String()})
+}
+
+func (r *RoleMapping) find(ctx context.Context, filter any) (rolemapping.List, error) {
+	c := mongodoc.NewRoleMappingConsumer()
+	if err := r.client.Find(ctx, filter, c); err != nil {
+		return nil, err
+	}
+	return c.Result, nil
+}
+
+func (r *RoleMapping) findOne(ctx context.Context, filter any) (*rolemapping.RoleMapping, error) {
+	c := mongodoc.NewRoleMappingConsumer()
+	if err := r.client.FindOne(ctx, filter, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
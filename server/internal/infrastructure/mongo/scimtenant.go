@@ -0,0 +1,61 @@
+package mongo
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo/mongodoc"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearthx/mongox"
+	"github.com/reearth/reearthx/rerror"
+	"go.mongodb.org/mongo-driver/bson"
+)
+
+type SCIMTenant struct {
+	client *mongox.Collection
+}
+
+func NewSCIMTenant(client *mongox.Client) *SCIMTenant {
+	return &SCIMTenant{
+		client: client.WithCollection("scimtenant"),
+	}
+}
+
+func (r *SCIMTenant) FindAll(ctx context.Context) (scimtenant.List, error) {
+	return r.find(ctx, bson.M{})
+}
+
+func (r *SCIMTenant) FindByID(ctx context.Context, id scimtenant.ID) (*scimtenant.Tenant, error) {
+	return r.findOne(ctx, bson.M{"id": id.String()})
+}
+
+func (r *SCIMTenant) FindByTokenHash(ctx context.Context, hash string) (*scimtenant.Tenant, error) {
+	if hash == "" {
+		return nil, rerror.ErrNotFound
+	}
+	return r.findOne(ctx, bson.M{"tokenhash": hash})
+}
+
+func (r *SCIMTenant) Save(ctx context.Context, t *scimtenant.Tenant) error {
+	doc, tid := mongodoc.NewSCIMTenant(t)
+	return r.client.SaveOne(ctx, tid, doc)
+}
+
+func (r *SCIMTenant) Remove(ctx context.Context, id scimtenant.ID) error {
+	return r.client.RemoveOne(ctx, bson.M{"id": id.String()})
+}
+
+func (r *SCIMTenant) find(ctx context.Context, filter any) (scimtenant.List, error) {
+	c := mongodoc.NewSCIMTenantConsumer()
+	if err := r.client.Find(ctx, filter, c); err != nil {
+		return nil, err
+	}
+	return c.Result, nil
+}
+
+func (r *SCIMTenant) findOne(ctx context.Context, filter any) (*scimtenant.Tenant, error) {
+	c := mongodoc.NewSCIMTenantConsumer()
+	if err := r.client.FindOne(ctx, filter, c); err != nil {
+		return nil, err
+	}
+	return c.Result[0], nil
+}
@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo/mongodoc"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
@@ -204,6 +205,10 @@ func (r *User) Save(ctx context.Context, u *user.User) error {
 	return r.client.SaveOne(ctx, id, doc)
 }
 
+func (r *User) FindDeletedBefore(ctx context.Context, t time.Time) (user.List, error) {
+	return r.find(ctx, bson.M{"deletedat": bson.M{"$lt": t}})
+}
+
 func (r *User) Remove(ctx context.Context, id user.ID) error {
 	return r.client.RemoveOne(ctx, bson.M{"id": id.String()})
 }
@@ -5,6 +5,7 @@ import (
 	"log"
 	"regexp"
 	"strings"
+	"time"
 
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/mongo/mongodoc"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
@@ -267,6 +268,10 @@ func (r *Workspace) RemoveAll(ctx context.Context, ids id.WorkspaceIDList) error
 	})
 }
 
+func (r *Workspace) FindDeletedBefore(ctx context.Context, t time.Time) (workspace.List, error) {
+	return r.find(ctx, r.f.Filter(bson.M{"deletedat": bson.M{"$lt": t}}))
+}
+
 func (r *Workspace) find(ctx context.Context, filter any) (workspace.List, error) {
 	c := mongodoc.NewWorkspaceConsumer()
 	if err := r.client.Find(ctx, filter, c); err != nil {
@@ -0,0 +1,209 @@
+// Package oidcidp implements gateway.Authenticator for generic OpenID Connect
+// identity providers such as Keycloak or Azure AD. Tokens are validated by the
+// JWT middleware; this package maps their claims onto the user identity.
+package oidcidp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+const (
+	defaultSubClaim           = "sub"
+	defaultEmailClaim         = "email"
+	defaultNameClaim          = "name"
+	defaultEmailVerifiedClaim = "email_verified"
+)
+
+var (
+	ErrInvalidToken     = rerror.NewE(i18n.T("invalid token"))
+	ErrMissingClaim     = rerror.NewE(i18n.T("required claim is missing from the token"))
+	ErrEmailNotVerified = rerror.NewE(i18n.T("email is not verified"))
+
+	namePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+	// reservedNames are auth sub prefixes already used by other providers.
+	reservedNames = []string{
+		user.ProviderReearth, user.ProviderAuth0,
+		string(gateway.ProviderCIP), "samlp", "scim",
+	}
+)
+
+// Config describes a generic OIDC provider. Name is the provider key and the
+// prefix of the auth subs of its users, so it must not change once users have
+// signed in.
+type Config struct {
+	Name     string  `json:"name"`
+	Issuer   string  `json:"issuer"`
+	ClientID string  `json:"clientId"`
+	JWKSURI  *string `json:"jwksUri,omitempty"`
+	ALG      *string `json:"alg,omitempty"`
+	// Claims default to sub, email, name and email_verified.
+	SubClaim           string `json:"subClaim,omitempty"`
+	EmailClaim         string `json:"emailClaim,omitempty"`
+	NameClaim          string `json:"nameClaim,omitempty"`
+	EmailVerifiedClaim string `json:"emailVerifiedClaim,omitempty"`
+	// RequireEmailVerified rejects tokens whose email is not verified.
+	RequireEmailVerified bool `json:"requireEmailVerified,omitempty"`
+}
+
+func (c Config) Validate() error {
+	if !namePattern.MatchString(c.Name) {
+		return fmt.Errorf("oidc provider name %q must consist of lower case letters, digits and hyphens", c.Name)
+	}
+	if slices.Contains(reservedNames, c.Name) {
+		return fmt.Errorf("oidc provider name %q is reserved", c.Name)
+	}
+	u, err := url.Parse(c.Issuer)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("oidc provider %q: issuer must be an http(s) URL", c.Name)
+	}
+	if c.ClientID == "" {
+		return fmt.Errorf("oidc provider %q: client id is required", c.Name)
+	}
+	return nil
+}
+
+func (c Config) claim(name, def string) string {
+	if name == "" {
+		return def
+	}
+	return name
+}
+
+// Authenticator resolves users of a generic OIDC provider. The provider's
+// accounts are managed by the customer, so profile updates, verification
+// emails and MFA are no-ops.
+type Authenticator struct {
+	conf Config
+}
+
+var (
+	_ gateway.Authenticator    = (*Authenticator)(nil)
+	_ gateway.IdentityResolver = (*Authenticator)(nil)
+)
+
+func New(conf Config) *Authenticator {
+	return &Authenticator{conf: conf}
+}
+
+func (a *Authenticator) Issuer() string {
+	return a.conf.Issuer
+}
+
+// ResolveIdentity maps the claims of a token that the JWT middleware has
+// already verified. The signature is not checked again here.
+func (a *Authenticator) ResolveIdentity(_ context.Context, token string) (gateway.AuthenticatorUser, error) {
+	claims, err := decodeClaims(token)
+	if err != nil {
+		return gateway.AuthenticatorUser{}, err
+	}
+
+	sub := stringClaim(claims, a.conf.claim(a.conf.SubClaim, defaultSubClaim))
+	email := stringClaim(claims, a.conf.claim(a.conf.EmailClaim, defaultEmailClaim))
+	if sub == "" || email == "" {
+		return gateway.AuthenticatorUser{}, ErrMissingClaim
+	}
+	verified := boolClaim(claims, a.conf.claim(a.conf.EmailVerifiedClaim, defaultEmailVerifiedClaim))
+	if a.conf.RequireEmailVerified && !verified {
+		return gateway.AuthenticatorUser{}, ErrEmailNotVerified
+	}
+
+	return gateway.AuthenticatorUser{
+		ID:            user.NewAuth(a.conf.Name, sub).Sub,
+		Name:          stringClaim(claims, a.conf.claim(a.conf.NameClaim, defaultNameClaim)),
+		Email:         email,
+		EmailVerified: verified,
+	}, nil
+}
+
+func (a *Authenticator) UpdateUser(_ context.Context, p gateway.AuthenticatorUpdateUserParam) (gateway.AuthenticatorUser, error) {
+	return gateway.AuthenticatorUser{ID: p.ID}, nil
+}
+
+func (a *Authenticator) ResendVerificationEmail(_ context.Context, _ string) error {
+	return nil
+}
+
+func (a *Authenticator) DisableMFA(_ context.Context, _ string) error {
+	return nil
+}
+
+func (a *Authenticator) EnableMFA(_ context.Context, _ string) (string, error) {
+	return "", nil
+}
+
+func (a *Authenticator) GetMFAStatus(_ context.Context, _ string) (gateway.MFAStatus, error) {
+	return gateway.MFAStatus{}, nil
+}
+
+func (a *Authenticator) RegenerateMFARecoveryCode(_ context.Context, _ string) ([]string, error) {
+	return nil, nil
+}
+
+func decodeClaims(token string) (map[string]any, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return nil, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	var claims map[string]any
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return nil, ErrInvalidToken
+	}
+	return claims, nil
+}
+
+// stringClaim reads a claim, following dots into nested objects so that
+// claims such as "profile.email" can be mapped.
+func stringClaim(claims map[string]any, name string) string {
+	v, ok := lookup(claims, name)
+	if !ok {
+		return ""
+	}
+	s, _ := v.(string)
+	return s
+}
+
+// boolClaim also accepts "true", which some providers send as a string.
+func boolClaim(claims map[string]any, name string) bool {
+	v, ok := lookup(claims, name)
+	if !ok {
+		return false
+	}
+	switch v := v.(type) {
+	case bool:
+		return v
+	case string:
+		return strings.EqualFold(v, "true")
+	}
+	return false
+}
+
+func lookup(claims map[string]any, name string) (any, bool) {
+	if v, ok := claims[name]; ok {
+		return v, true
+	}
+	head, rest, found := strings.Cut(name, ".")
+	if !found {
+		return nil, false
+	}
+	nested, ok := claims[head].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return lookup(nested, rest)
+}
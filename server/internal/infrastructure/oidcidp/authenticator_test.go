@@ -0,0 +1,85 @@
+package oidcidp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func token(t *testing.T, claims map[string]any) string {
+	t.Helper()
+	b, err := json.Marshal(claims)
+	require.NoError(t, err)
+	return "eyJhbGciOiJSUzI1NiJ9." + base64.RawURLEncoding.EncodeToString(b) + ".sig"
+}
+
+func TestConfig_Validate(t *testing.T) {
+	valid := Config{Name: "keycloak", Issuer: "https://idp.example.com/realms/r", ClientID: "reearth"}
+	assert.NoError(t, valid.Validate())
+
+	for name, c := range map[string]Config{
+		"invalid name":  {Name: "Key Cloak", Issuer: valid.Issuer, ClientID: "reearth"},
+		"reserved name": {Name: "auth0", Issuer: valid.Issuer, ClientID: "reearth"},
+		"no issuer":     {Name: "keycloak", ClientID: "reearth"},
+		"bad issuer":    {Name: "keycloak", Issuer: "idp.example.com", ClientID: "reearth"},
+		"no client id":  {Name: "keycloak", Issuer: valid.Issuer},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Error(t, c.Validate())
+		})
+	}
+}
+
+func TestAuthenticator_ResolveIdentity(t *testing.T) {
+	ctx := context.Background()
+	conf := Config{Name: "keycloak", Issuer: "https://idp.example.com", ClientID: "reearth"}
+
+	t.Run("default claims", func(t *testing.T) {
+		u, err := New(conf).ResolveIdentity(ctx, token(t, map[string]any{
+			"sub": "abc", "email": "a@example.com", "name": "Alice", "email_verified": true,
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "keycloak|abc", u.ID)
+		assert.Equal(t, "a@example.com", u.Email)
+		assert.Equal(t, "Alice", u.Name)
+		assert.True(t, u.EmailVerified)
+	})
+
+	t.Run("mapped claims", func(t *testing.T) {
+		c := conf
+		c.SubClaim = "oid"
+		c.EmailClaim = "preferred_username"
+		c.NameClaim = "profile.display_name"
+		c.EmailVerifiedClaim = "verified"
+		c.RequireEmailVerified = true
+		u, err := New(c).ResolveIdentity(ctx, token(t, map[string]any{
+			"sub": "ignored", "oid": "o-1", "preferred_username": "b@example.com",
+			"profile": map[string]any{"display_name": "Bob"}, "verified": "true",
+		}))
+		require.NoError(t, err)
+		assert.Equal(t, "keycloak|o-1", u.ID)
+		assert.Equal(t, "b@example.com", u.Email)
+		assert.Equal(t, "Bob", u.Name)
+	})
+
+	t.Run("email not verified", func(t *testing.T) {
+		c := conf
+		c.RequireEmailVerified = true
+		_, err := New(c).ResolveIdentity(ctx, token(t, map[string]any{"sub": "abc", "email": "a@example.com"}))
+		assert.ErrorIs(t, err, ErrEmailNotVerified)
+	})
+
+	t.Run("missing claim", func(t *testing.T) {
+		_, err := New(conf).ResolveIdentity(ctx, token(t, map[string]any{"sub": "abc"}))
+		assert.ErrorIs(t, err, ErrMissingClaim)
+	})
+
+	t.Run("malformed token", func(t *testing.T) {
+		_, err := New(conf).ResolveIdentity(ctx, "not-a-jwt")
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
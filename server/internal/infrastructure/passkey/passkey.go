@@ -0,0 +1,185 @@
+// Package passkey implements gateway.Passkey on top of go-webauthn.
+package passkey
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+
+	"github.com/go-webauthn/webauthn/protocol"
+	"github.com/go-webauthn/webauthn/webauthn"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/util"
+)
+
+// ErrClonedAuthenticator is returned when an assertion's sign count does not
+// advance past the stored one, which suggests the credential was copied.
+var ErrClonedAuthenticator = errors.New("passkey sign count regressed")
+
+type Config struct {
+	// RPID is the relying party ID, usually the registrable domain of the web app.
+	RPID string
+	// RPDisplayName is shown by the browser during the ceremony.
+	RPDisplayName string
+	// RPOrigins lists the fully qualified origins allowed to run ceremonies.
+	RPOrigins []string
+}
+
+type Passkey struct {
+	w *webauthn.WebAuthn
+}
+
+var _ gateway.Passkey = (*Passkey)(nil)
+
+func New(c Config) (*Passkey, error) {
+	w, err := webauthn.New(&webauthn.Config{
+		RPID:          c.RPID,
+		RPDisplayName: c.RPDisplayName,
+		RPOrigins:     c.RPOrigins,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Passkey{w: w}, nil
+}
+
+func (p *Passkey) BeginRegistration(_ context.Context, u *user.User) (json.RawMessage, string, error) {
+	wu := webauthnUser{u: u}
+	creation, session, err := p.w.BeginRegistration(
+		wu,
+		webauthn.WithExclusions(webauthn.Credentials(wu.WebAuthnCredentials()).CredentialDescriptors()),
+		webauthn.WithResidentKeyRequirement(protocol.ResidentKeyRequirementPreferred),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	options, err := json.Marshal(creation)
+	if err != nil {
+		return nil, "", err
+	}
+	return options, session.Challenge, nil
+}
+
+func (p *Passkey) FinishRegistration(_ context.Context, u *user.User, challenge string, response []byte) (*user.Passkey, error) {
+	parsed, err := protocol.ParseCredentialCreationResponseBytes(response)
+	if err != nil {
+		return nil, err
+	}
+	wu := webauthnUser{u: u}
+	cred, err := p.w.CreateCredential(wu, sessionData(wu, challenge, protocol.VerificationPreferred), parsed)
+	if err != nil {
+		return nil, err
+	}
+	transports := make([]string, 0, len(cred.Transport))
+	for _, t := range cred.Transport {
+		transports = append(transports, string(t))
+	}
+	return &user.Passkey{
+		ID:              cred.ID,
+		PublicKey:       cred.PublicKey,
+		AttestationType: cred.AttestationType,
+		Transports:      transports,
+		AAGUID:          cred.Authenticator.AAGUID,
+		SignCount:       cred.Authenticator.SignCount,
+		BackupEligible:  cred.Flags.BackupEligible,
+		BackupState:     cred.Flags.BackupState,
+	}, nil
+}
+
+// BeginLogin requires user verification, i.e. a PIN or biometric check on the
+// authenticator, so that a passkey alone is a second factor for users who
+// enabled MFA.
+func (p *Passkey) BeginLogin(_ context.Context, u *user.User) (json.RawMessage, string, error) {
+	assertion, session, err := p.w.BeginLogin(webauthnUser{u: u}, webauthn.WithUserVerification(protocol.VerificationRequired))
+	if err != nil {
+		return nil, "", err
+	}
+	options, err := json.Marshal(assertion)
+	if err != nil {
+		return nil, "", err
+	}
+	return options, session.Challenge, nil
+}
+
+func (p *Passkey) FinishLogin(_ context.Context, u *user.User, challenge string, response []byte) (*user.Passkey, error) {
+	parsed, err := protocol.ParseCredentialRequestResponseBytes(response)
+	if err != nil {
+		return nil, err
+	}
+	wu := webauthnUser{u: u}
+	cred, err := p.w.ValidateLogin(wu, sessionData(wu, challenge, protocol.VerificationRequired), parsed)
+	if err != nil {
+		return nil, err
+	}
+	if cred.Authenticator.CloneWarning {
+		return nil, ErrClonedAuthenticator
+	}
+	pk := u.Passkey(cred.ID)
+	if pk == nil {
+		return nil, user.ErrPasskeyNotFound
+	}
+	pk.SignCount = cred.Authenticator.SignCount
+	pk.BackupState = cred.Flags.BackupState
+	now := util.Now()
+	pk.LastUsedAt = &now
+	return pk, nil
+}
+
+// sessionData rebuilds the go-webauthn session from the stored challenge.
+// Expiry is enforced by the caller, so it is left unset here.
+func sessionData(wu webauthnUser, challenge string, uv protocol.UserVerificationRequirement) webauthn.SessionData {
+	return webauthn.SessionData{
+		Challenge:        challenge,
+		UserID:           wu.WebAuthnID(),
+		UserVerification: uv,
+		CredParams:       webauthn.CredentialParametersDefault(),
+	}
+}
+
+// webauthnUser adapts user.User to webauthn.User. The user handle is the user
+// ID, which is opaque and stable across email changes.
+type webauthnUser struct {
+	u *user.User
+}
+
+func (w webauthnUser) WebAuthnID() []byte {
+	return []byte(w.u.ID().String())
+}
+
+func (w webauthnUser) WebAuthnName() string {
+	return w.u.Email()
+}
+
+func (w webauthnUser) WebAuthnDisplayName() string {
+	if n := w.u.Name(); n != "" {
+		return n
+	}
+	return w.u.Email()
+}
+
+func (w webauthnUser) WebAuthnCredentials() []webauthn.Credential {
+	passkeys := w.u.Passkeys()
+	res := make([]webauthn.Credential, 0, len(passkeys))
+	for _, p := range passkeys {
+		transports := make([]protocol.AuthenticatorTransport, 0, len(p.Transports))
+		for _, t := range p.Transports {
+			transports = append(transports, protocol.AuthenticatorTransport(t))
+		}
+		res = append(res, webauthn.Credential{
+			ID:              p.ID,
+			PublicKey:       p.PublicKey,
+			AttestationType: p.AttestationType,
+			Transport:       transports,
+			Flags: webauthn.CredentialFlags{
+				BackupEligible: p.BackupEligible,
+				BackupState:    p.BackupState,
+			},
+			Authenticator: webauthn.Authenticator{
+				AAGUID:    p.AAGUID,
+				SignCount: p.SignCount,
+			},
+		})
+	}
+	return res
+}
@@ -0,0 +1,87 @@
+package passkey
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	_, err := New(Config{RPDisplayName: "Re:Earth"})
+	assert.Error(t, err)
+
+	_, err = New(Config{RPID: "example.com", RPDisplayName: "Re:Earth", RPOrigins: []string{"https://example.com"}})
+	assert.NoError(t, err)
+}
+
+func TestPasskey_BeginRegistration(t *testing.T) {
+	p, err := New(Config{RPID: "example.com", RPDisplayName: "Re:Earth", RPOrigins: []string{"https://example.com"}})
+	require.NoError(t, err)
+
+	uid := id.NewUserID()
+	u := user.New().ID(uid).Workspace(id.NewWorkspaceID()).Name("alice").Email("alice@example.com").
+		Passkeys([]user.Passkey{{ID: []byte("existing"), Transports: []string{"usb"}}}).
+		MustBuild()
+
+	options, challenge, err := p.BeginRegistration(context.Background(), u)
+	require.NoError(t, err)
+	assert.NotEmpty(t, challenge)
+
+	var got struct {
+		PublicKey struct {
+			Challenge string `json:"challenge"`
+			RP        struct {
+				ID string `json:"id"`
+			} `json:"rp"`
+			User struct {
+				Name        string `json:"name"`
+				DisplayName string `json:"displayName"`
+			} `json:"user"`
+			ExcludeCredentials []struct {
+				ID string `json:"id"`
+			} `json:"excludeCredentials"`
+		} `json:"publicKey"`
+	}
+	require.NoError(t, json.Unmarshal(options, &got))
+	assert.Equal(t, challenge, got.PublicKey.Challenge)
+	assert.Equal(t, "example.com", got.PublicKey.RP.ID)
+	assert.Equal(t, "alice@example.com", got.PublicKey.User.Name)
+	assert.Equal(t, "alice", got.PublicKey.User.DisplayName)
+	require.Len(t, got.PublicKey.ExcludeCredentials, 1)
+	assert.Equal(t, (user.Passkey{ID: []byte("existing")}).IDString(), got.PublicKey.ExcludeCredentials[0].ID)
+}
+
+func TestPasskey_FinishRegistration_InvalidResponse(t *testing.T) {
+	p, err := New(Config{RPID: "example.com", RPDisplayName: "Re:Earth", RPOrigins: []string{"https://example.com"}})
+	require.NoError(t, err)
+	u := user.New().NewID().Workspace(id.NewWorkspaceID()).Email("alice@example.com").MustBuild()
+
+	_, err = p.FinishRegistration(context.Background(), u, "challenge", []byte(`{}`))
+	assert.Error(t, err)
+}
+
+func TestPasskey_BeginLogin(t *testing.T) {
+	p, err := New(Config{RPID: "example.com", RPDisplayName: "Re:Earth", RPOrigins: []string{"https://example.com"}})
+	require.NoError(t, err)
+	u := user.New().NewID().Workspace(id.NewWorkspaceID()).Email("alice@example.com").
+		Passkeys([]user.Passkey{{ID: []byte("existing")}}).
+		MustBuild()
+
+	options, challenge, err := p.BeginLogin(context.Background(), u)
+	require.NoError(t, err)
+
+	var got struct {
+		PublicKey struct {
+			Challenge        string `json:"challenge"`
+			UserVerification string `json:"userVerification"`
+		} `json:"publicKey"`
+	}
+	require.NoError(t, json.Unmarshal(options, &got))
+	assert.Equal(t, challenge, got.PublicKey.Challenge)
+	assert.Equal(t, "required", got.PublicKey.UserVerification, "a passkey without user verification is a single factor")
+}
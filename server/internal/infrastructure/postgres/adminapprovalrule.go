@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/pgdoc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/sqlc/gen"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type AdminApprovalRule struct {
+	c *Client
+}
+
+func NewAdminApprovalRule(c *Client) adminapprovalrule.Repo { return &AdminApprovalRule{c: c} }
+
+func adminApprovalRuleModel(r gen.AdminApprovalRule) (*adminapprovalrule.Rule, error) {
+	return pgdoc.AdminApprovalRuleRow{
+		ID:        r.ID,
+		Kind:      r.Kind,
+		Value:     r.Value,
+		Provider:  r.Provider,
+		Role:      r.Role,
+		CreatedBy: r.CreatedBy,
+		UpdatedAt: r.UpdatedAt,
+	}.Model()
+}
+
+func (r *AdminApprovalRule) FindAll(ctx context.Context) (adminapprovalrule.List, error) {
+	rows, err := r.c.queries(ctx).AdminApprovalRuleFindAll(ctx)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	out := make(adminapprovalrule.List, 0, len(rows))
+	for _, row := range rows {
+		m, err := adminApprovalRuleModel(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (r *AdminApprovalRule) FindByID(ctx context.Context, id adminapprovalrule.ID) (*adminapprovalrule.Rule, error) {
+	row, err := r.c.queries(ctx).AdminApprovalRuleFindByID(ctx, id.String())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, rerror.ErrNotFound
+	}
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return adminApprovalRuleModel(row)
+}
+
+func (r *AdminApprovalRule) Save(ctx context.Context, rule *adminapprovalrule.Rule) error {
+	row := pgdoc.NewAdminApprovalRuleRow(rule)
+	if err := r.c.queries(ctx).AdminApprovalRuleUpsert(ctx, gen.AdminApprovalRuleUpsertParams{
+		ID:        row.ID,
+		Kind:      row.Kind,
+		Value:     row.Value,
+		Provider:  row.Provider,
+		Role:      row.Role,
+		CreatedBy: row.CreatedBy,
+		UpdatedAt: row.UpdatedAt,
+	}); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
+
+func (r *AdminApprovalRule) Remove(ctx context.Context, id adminapprovalrule.ID) error {
+	if err := r.c.queries(ctx).AdminApprovalRuleDelete(ctx, id.String()); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
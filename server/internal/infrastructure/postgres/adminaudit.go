@@ -0,0 +1,166 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/pgdoc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/sqlc/gen"
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+const adminAuditRecordColumns = "id, seq, actor, action, target, request_id, ip, status, diff, created_at, prev_hash, hash"
+
+type AdminAudit struct {
+	c *Client
+}
+
+func NewAdminAudit(c *Client) adminaudit.Repo { return &AdminAudit{c: c} }
+
+func adminAuditRecordModel(r gen.AdminAuditRecord) (*adminaudit.Record, error) {
+	return pgdoc.AdminAuditRecordRow{
+		ID:        r.ID,
+		Seq:       r.Seq,
+		Actor:     r.Actor,
+		Action:    r.Action,
+		Target:    r.Target,
+		RequestID: r.RequestID,
+		IP:        r.Ip,
+		Status:    r.Status,
+		Diff:      r.Diff,
+		CreatedAt: r.CreatedAt,
+		PrevHash:  r.PrevHash,
+		Hash:      r.Hash,
+	}.Model()
+}
+
+func (r *AdminAudit) FindLast(ctx context.Context) (*adminaudit.Record, error) {
+	row, err := r.c.queries(ctx).AdminAuditRecordFindLast(ctx)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, rerror.ErrNotFound
+	}
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return adminAuditRecordModel(row)
+}
+
+func (r *AdminAudit) FindAfter(ctx context.Context, seq int64, limit int) (adminaudit.List, error) {
+	rows, err := r.c.queries(ctx).AdminAuditRecordFindAfter(ctx, gen.AdminAuditRecordFindAfterParams{
+		Seq:   seq,
+		Limit: int32(limit),
+	})
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	out := make(adminaudit.List, 0, len(rows))
+	for _, row := range rows {
+		m, err := adminAuditRecordModel(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (r *AdminAudit) List(ctx context.Context, f adminaudit.ListFilter) (adminaudit.List, *usecasex.PageInfo, error) {
+	if f.Pagination != nil && f.Pagination.Cursor != nil {
+		return nil, nil, adminaudit.ErrCursorPaginationUnsupported
+	}
+
+	var where []string
+	var args []any
+	if f.Actor != nil {
+		args = append(args, f.Actor.String())
+		where = append(where, "actor = $"+itoa(len(args)))
+	}
+	if f.Action != "" {
+		args = append(args, f.Action)
+		where = append(where, "action = $"+itoa(len(args)))
+	}
+	if f.Target != "" {
+		args = append(args, f.Target)
+		where = append(where, "target = $"+itoa(len(args)))
+	}
+	if f.Since != nil {
+		args = append(args, *f.Since)
+		where = append(where, "created_at >= $"+itoa(len(args)))
+	}
+	if f.Until != nil {
+		args = append(args, *f.Until)
+		where = append(where, "created_at < $"+itoa(len(args)))
+	}
+	base := "FROM admin_audit_records"
+	if len(where) > 0 {
+		base += " WHERE " + strings.Join(where, " AND ")
+	}
+
+	var total int64
+	if err := r.c.db(ctx).QueryRow(ctx, "SELECT count(*) "+base, args...).Scan(&total); err != nil {
+		return nil, nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+
+	q := "SELECT " + adminAuditRecordColumns + " " + base + " ORDER BY seq DESC"
+	var hasNext, hasPrev bool
+	if f.Pagination != nil && f.Pagination.Offset != nil {
+		off := f.Pagination.Offset
+		q += " LIMIT $" + itoa(len(args)+1) + " OFFSET $" + itoa(len(args)+2)
+		args = append(args, off.Limit, off.Offset)
+		hasPrev = off.Offset > 0
+		hasNext = off.Offset+off.Limit < total
+	}
+
+	rows, err := r.c.db(ctx).Query(ctx, q, args...)
+	if err != nil {
+		return nil, nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	defer rows.Close()
+	var out adminaudit.List
+	for rows.Next() {
+		var d pgdoc.AdminAuditRecordRow
+		if err := rows.Scan(
+			&d.ID, &d.Seq, &d.Actor, &d.Action, &d.Target, &d.RequestID,
+			&d.IP, &d.Status, &d.Diff, &d.CreatedAt, &d.PrevHash, &d.Hash,
+		); err != nil {
+			return nil, nil, rerror.ErrInternalByWithContext(ctx, err)
+		}
+		m, err := d.Model()
+		if err != nil {
+			return nil, nil, err
+		}
+		out = append(out, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return out, usecasex.NewPageInfo(total, nil, nil, hasNext, hasPrev), nil
+}
+
+func (r *AdminAudit) Append(ctx context.Context, rec *adminaudit.Record) error {
+	row := pgdoc.NewAdminAuditRecordRow(rec)
+	if err := r.c.queries(ctx).AdminAuditRecordAppend(ctx, gen.AdminAuditRecordAppendParams{
+		ID:        row.ID,
+		Seq:       row.Seq,
+		Actor:     row.Actor,
+		Action:    row.Action,
+		Target:    row.Target,
+		RequestID: row.RequestID,
+		Ip:        row.IP,
+		Status:    row.Status,
+		Diff:      row.Diff,
+		CreatedAt: row.CreatedAt,
+		PrevHash:  row.PrevHash,
+		Hash:      row.Hash,
+	}); err != nil {
+		if isUniqueViolation(err) {
+			return adminaudit.ErrSeqTaken
+		}
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
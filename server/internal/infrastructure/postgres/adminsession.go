@@ -0,0 +1,94 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/pgdoc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/sqlc/gen"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type AdminSession struct {
+	c *Client
+}
+
+func NewAdminSession(c *Client) adminsession.Repo { return &AdminSession{c: c} }
+
+func adminSessionModel(s gen.AdminSession) (*adminsession.Session, error) {
+	return pgdoc.AdminSessionRow{
+		ID:        s.ID,
+		AdminUser: s.AdminUser,
+		UserAgent: s.UserAgent,
+		IP:        s.Ip,
+		CreatedAt: s.CreatedAt,
+		ExpiresAt: s.ExpiresAt,
+	}.Model()
+}
+
+func (r *AdminSession) FindByID(ctx context.Context, id adminsession.ID) (*adminsession.Session, error) {
+	row, err := r.c.queries(ctx).AdminSessionFindByID(ctx, id.String())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, rerror.ErrNotFound
+	}
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return adminSessionModel(row)
+}
+
+func (r *AdminSession) FindByAdminUser(ctx context.Context, u adminuser.ID) (adminsession.List, error) {
+	rows, err := r.c.queries(ctx).AdminSessionFindByAdminUser(ctx, u.String())
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	out := make(adminsession.List, 0, len(rows))
+	for _, row := range rows {
+		m, err := adminSessionModel(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (r *AdminSession) Save(ctx context.Context, s *adminsession.Session) error {
+	row := pgdoc.NewAdminSessionRow(s)
+	if err := r.c.queries(ctx).AdminSessionUpsert(ctx, gen.AdminSessionUpsertParams{
+		ID:        row.ID,
+		AdminUser: row.AdminUser,
+		UserAgent: row.UserAgent,
+		Ip:        row.IP,
+		CreatedAt: row.CreatedAt,
+		ExpiresAt: row.ExpiresAt,
+	}); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
+
+func (r *AdminSession) Remove(ctx context.Context, id adminsession.ID) error {
+	if err := r.c.queries(ctx).AdminSessionDelete(ctx, id.String()); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
+
+func (r *AdminSession) RemoveByAdminUser(ctx context.Context, u adminuser.ID) error {
+	if err := r.c.queries(ctx).AdminSessionDeleteByAdminUser(ctx, u.String()); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
+
+func (r *AdminSession) RemoveExpired(ctx context.Context, t time.Time) error {
+	if err := r.c.queries(ctx).AdminSessionDeleteExpired(ctx, t); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
@@ -0,0 +1,100 @@
+package postgres
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/sqlc/gen"
+	"github.com/reearth/reearth-accounts/server/pkg/adminstats"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type AdminStats struct {
+	c *Client
+}
+
+func NewAdminStats(c *Client) adminstats.Repo { return &AdminStats{c: c} }
+
+// statsDayFormat is the layout of the days the per-day queries group by.
+const statsDayFormat = "2006-01-02"
+
+func (r *AdminStats) Compute(ctx context.Context, w adminstats.Window) (*adminstats.Stats, error) {
+	q := r.c.queries(ctx)
+	users, err := q.AdminStatsUsers(ctx)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	workspaces, err := q.AdminStatsWorkspaces(ctx)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	signups, err := q.AdminStatsSignupsPerDay(ctx, gen.AdminStatsSignupsPerDayParams{Since: w.Since, Until: w.Until})
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	deactivations, err := q.AdminStatsDeactivationsPerDay(ctx, gen.AdminStatsDeactivationsPerDayParams{Since: w.Since, Until: w.Until})
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	providers, err := q.AdminStatsUsersByAuthProvider(ctx)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	roles, err := q.AdminStatsMembersByRole(ctx)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+
+	s := &adminstats.Stats{
+		Users: adminstats.Users{
+			Active:      users.Active,
+			Deactivated: users.Deactivated,
+			Unverified:  users.Unverified,
+		},
+		Workspaces: adminstats.Workspaces{
+			Personal:    workspaces.Personal,
+			Team:        workspaces.Team,
+			Deactivated: workspaces.Deactivated,
+		},
+		MembersByRole:       make(map[role.RoleType]int64, len(roles)),
+		UsersByAuthProvider: make(map[string]int64, len(providers)),
+	}
+
+	days := map[string]*adminstats.Day{}
+	day := func(key string) (*adminstats.Day, error) {
+		if d := days[key]; d != nil {
+			return d, nil
+		}
+		t, err := time.Parse(statsDayFormat, key)
+		if err != nil {
+			return nil, rerror.ErrInternalByWithContext(ctx, err)
+		}
+		days[key] = &adminstats.Day{Date: t}
+		return days[key], nil
+	}
+	for _, row := range signups {
+		d, err := day(row.Day)
+		if err != nil {
+			return nil, err
+		}
+		d.Signups = row.Count
+	}
+	for _, row := range deactivations {
+		d, err := day(row.Day)
+		if err != nil {
+			return nil, err
+		}
+		d.Deactivations = row.Count
+	}
+	for _, d := range days {
+		s.Days = append(s.Days, *d)
+	}
+	for _, row := range providers {
+		s.UsersByAuthProvider[row.Provider] = row.Count
+	}
+	for _, row := range roles {
+		s.MembersByRole[role.RoleType(row.Role)] = row.Count
+	}
+	return s, nil
+}
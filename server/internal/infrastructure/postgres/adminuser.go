@@ -13,7 +13,7 @@ import (
 	"github.com/reearth/reearthx/usecasex"
 )
 
-const adminUserColumns = "id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at"
+const adminUserColumns = "id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at, approved_by_rule"
 
 // adminUserSystemAdminGuardLockKey is a pg_advisory_xact_lock key that
 // serializes SaveGuardingLastSystemAdmin's guarded saves, so the "at least one
@@ -30,16 +30,17 @@ func NewAdminUser(c *Client) adminuser.Repo { return &AdminUser{c: c} }
 
 func adminUserModel(a gen.AdminUser) (*adminuser.AdminUser, error) {
 	return pgdoc.AdminUserRow{
-		ID:         a.ID,
-		Email:      a.Email,
-		Name:       a.Name,
-		PictureURL: a.PictureUrl,
-		Role:       a.Role,
-		Status:     a.Status,
-		ApprovedBy: a.ApprovedBy,
-		ApprovedAt: a.ApprovedAt,
-		CreatedAt:  a.CreatedAt,
-		UpdatedAt:  a.UpdatedAt,
+		ID:             a.ID,
+		Email:          a.Email,
+		Name:           a.Name,
+		PictureURL:     a.PictureUrl,
+		Role:           a.Role,
+		Status:         a.Status,
+		ApprovedBy:     a.ApprovedBy,
+		ApprovedAt:     a.ApprovedAt,
+		CreatedAt:      a.CreatedAt,
+		UpdatedAt:      a.UpdatedAt,
+		ApprovedByRule: a.ApprovedByRule,
 	}.Model()
 }
 
@@ -173,16 +174,17 @@ func (r *AdminUser) Save(ctx context.Context, u *adminuser.AdminUser) error {
 	}
 	row := pgdoc.NewAdminUserRow(*u)
 	if err := r.c.queries(ctx).AdminUserUpsert(ctx, gen.AdminUserUpsertParams{
-		ID:         row.ID,
-		Email:      row.Email,
-		Name:       row.Name,
-		PictureUrl: row.PictureURL,
-		Role:       row.Role,
-		Status:     row.Status,
-		ApprovedBy: row.ApprovedBy,
-		ApprovedAt: row.ApprovedAt,
-		CreatedAt:  row.CreatedAt,
-		UpdatedAt:  row.UpdatedAt,
+		ID:             row.ID,
+		Email:          row.Email,
+		Name:           row.Name,
+		PictureUrl:     row.PictureURL,
+		Role:           row.Role,
+		Status:         row.Status,
+		ApprovedBy:     row.ApprovedBy,
+		ApprovedAt:     row.ApprovedAt,
+		CreatedAt:      row.CreatedAt,
+		UpdatedAt:      row.UpdatedAt,
+		ApprovedByRule: row.ApprovedByRule,
 	}); err != nil {
 		if isUniqueViolation(err) {
 			return adminuser.ErrDuplicatedAdminUser
@@ -228,7 +230,7 @@ func scanAdminUsers(rows pgx.Rows) (adminuser.List, error) {
 		var d pgdoc.AdminUserRow
 		if err := rows.Scan(
 			&d.ID, &d.Email, &d.Name, &d.PictureURL, &d.Role, &d.Status,
-			&d.ApprovedBy, &d.ApprovedAt, &d.CreatedAt, &d.UpdatedAt,
+			&d.ApprovedBy, &d.ApprovedAt, &d.CreatedAt, &d.UpdatedAt, &d.ApprovedByRule,
 		); err != nil {
 			return nil, err
 		}
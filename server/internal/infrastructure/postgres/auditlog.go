@@ -0,0 +1,54 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/pgdoc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/sqlc/gen"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type AuditLog struct {
+	c *Client
+}
+
+func NewAuditLog(c *Client) auditlog.Repo { return &AuditLog{c: c} }
+
+func (r *AuditLog) FindByTarget(ctx context.Context, target string) (auditlog.List, error) {
+	rows, err := r.c.queries(ctx).AuditLogFindByTarget(ctx, target)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	out := make(auditlog.List, 0, len(rows))
+	for _, row := range rows {
+		m, err := pgdoc.AuditLogRow{
+			ID:        row.ID,
+			Actor:     row.Actor,
+			Action:    row.Action,
+			Target:    row.Target,
+			Detail:    row.Detail,
+			CreatedAt: row.CreatedAt,
+		}.Model()
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (r *AuditLog) Save(ctx context.Context, e *auditlog.Entry) error {
+	row := pgdoc.NewAuditLogRow(e)
+	if err := r.c.queries(ctx).AuditLogInsert(ctx, gen.AuditLogInsertParams{
+		ID:        row.ID,
+		Actor:     row.Actor,
+		Action:    row.Action,
+		Target:    row.Target,
+		Detail:    row.Detail,
+		CreatedAt: row.CreatedAt,
+	}); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
@@ -46,12 +46,7 @@ func (r *Config) LockAndLoad(ctx context.Context) (*config.Config, error) {
 	}
 	r.conn = conn
 
-	row := pgdoc.ConfigRow{}
-	err = conn.QueryRow(ctx, `SELECT migration, auth_cert, auth_key, default_policy FROM config WHERE id = 1`).
-		Scan(&row.Migration, &row.AuthCert, &row.AuthKey, &row.DefaultPolicy)
-	if errors.Is(err, pgx.ErrNoRows) {
-		return &config.Config{}, nil
-	}
+	cfg, err := loadConfig(ctx, conn)
 	if err != nil {
 		// release lock+conn on load failure to avoid leaking either
 		_, _ = conn.Exec(ctx, `SELECT pg_advisory_unlock($1)`, configAdvisoryLockKey)
@@ -59,7 +54,28 @@ func (r *Config) LockAndLoad(ctx context.Context) (*config.Config, error) {
 		r.conn = nil
 		return nil, rerror.ErrInternalByWithContext(ctx, err)
 	}
-	return row.Model(), nil
+	return cfg, nil
+}
+
+func (r *Config) Load(ctx context.Context) (*config.Config, error) {
+	cfg, err := loadConfig(ctx, r.pool)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return cfg, nil
+}
+
+func loadConfig(ctx context.Context, q DBTX) (*config.Config, error) {
+	row := pgdoc.ConfigRow{}
+	err := q.QueryRow(ctx, `SELECT migration, auth_cert, auth_key, auth_keys, default_policy, purge_since FROM config WHERE id = 1`).
+		Scan(&row.Migration, &row.AuthCert, &row.AuthKey, &row.AuthKeys, &row.DefaultPolicy, &row.PurgeSince)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return &config.Config{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return row.Model()
 }
 
 // exec holds r.mu across the locked-conn Exec because pgxpool.Conn isn't safe
@@ -82,9 +98,9 @@ func (r *Config) Save(ctx context.Context, cfg *config.Config) error {
 	}
 	row := pgdoc.NewConfigRow(*cfg)
 	if err := r.exec(ctx,
-		`INSERT INTO config (id, migration, auth_cert, auth_key, default_policy) VALUES (1,$1,$2,$3,$4)
-		 ON CONFLICT (id) DO UPDATE SET migration=EXCLUDED.migration, auth_cert=EXCLUDED.auth_cert, auth_key=EXCLUDED.auth_key, default_policy=EXCLUDED.default_policy`,
-		row.Migration, row.AuthCert, row.AuthKey, row.DefaultPolicy); err != nil {
+		`INSERT INTO config (id, migration, auth_cert, auth_key, auth_keys, default_policy, purge_since) VALUES (1,$1,$2,$3,$4,$5,$6)
+		 ON CONFLICT (id) DO UPDATE SET migration=EXCLUDED.migration, auth_cert=EXCLUDED.auth_cert, auth_key=EXCLUDED.auth_key, auth_keys=EXCLUDED.auth_keys, default_policy=EXCLUDED.default_policy, purge_since=EXCLUDED.purge_since`,
+		row.Migration, row.AuthCert, row.AuthKey, row.AuthKeys, row.DefaultPolicy, row.PurgeSince); err != nil {
 		return rerror.ErrInternalByWithContext(ctx, err)
 	}
 	return nil
@@ -11,13 +11,23 @@ import (
 func New(_ context.Context, pool *pgxpool.Pool, users []user.Repo) (*repo.Container, error) {
 	c := NewClient(pool)
 	return &repo.Container{
-		AdminUser:   NewAdminUser(c),
-		User:        NewUser(c),
-		Workspace:   NewWorkspace(c),
-		Role:        NewRole(c),
-		Permittable: NewPermittable(c),
-		Transaction: NewTransaction(pool),
-		Users:       users,
-		Config:      NewConfig(pool),
+		AdminUser:         NewAdminUser(c),
+		User:              NewUser(c),
+		Workspace:         NewWorkspace(c),
+		Role:              NewRole(c),
+		Permittable:       NewPermittable(c),
+		Transaction:       NewTransaction(pool),
+		Users:             users,
+		Config:            NewConfig(pool),
+		SCIMTenant:        NewSCIMTenant(c),
+		AuditLog:          NewAuditLog(c),
+		RoleMapping:       NewRoleMapping(c),
+		LDAPSync:          NewLDAPSync(c),
+		AdminAudit:        NewAdminAudit(c),
+		AdminSession:      NewAdminSession(c),
+		AdminApprovalRule: NewAdminApprovalRule(c),
+		AdminStats:        NewAdminStats(c),
+		PasskeyLogin:      NewPasskeyLogin(c),
+		Lock:              NewLock(pool),
 	}, nil
 }
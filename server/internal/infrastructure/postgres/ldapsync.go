@@ -0,0 +1,80 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/pgdoc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/sqlc/gen"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type LDAPSync struct {
+	c *Client
+}
+
+func NewLDAPSync(c *Client) ldapsync.Repo { return &LDAPSync{c: c} }
+
+func ldapSyncRunModel(r gen.LdapSyncRun) (*ldapsync.Run, error) {
+	return pgdoc.LDAPSyncRunRow{
+		ID:         r.ID,
+		Status:     r.Status,
+		Err:        r.Err,
+		StartedAt:  r.StartedAt,
+		FinishedAt: r.FinishedAt,
+		Summary:    r.Summary,
+	}.Model()
+}
+
+func (r *LDAPSync) FindByID(ctx context.Context, id ldapsync.ID) (*ldapsync.Run, error) {
+	row, err := r.c.queries(ctx).LdapSyncRunFindByID(ctx, id.String())
+	return r.one(ctx, row, err)
+}
+
+func (r *LDAPSync) FindRecent(ctx context.Context, limit int) (ldapsync.List, error) {
+	rows, err := r.c.queries(ctx).LdapSyncRunFindRecent(ctx, int32(limit))
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	out := make(ldapsync.List, 0, len(rows))
+	for _, row := range rows {
+		m, err := ldapSyncRunModel(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (r *LDAPSync) FindLastSucceeded(ctx context.Context) (*ldapsync.Run, error) {
+	row, err := r.c.queries(ctx).LdapSyncRunFindLastSucceeded(ctx)
+	return r.one(ctx, row, err)
+}
+
+func (r *LDAPSync) Save(ctx context.Context, run *ldapsync.Run) error {
+	row := pgdoc.NewLDAPSyncRunRow(run)
+	if err := r.c.queries(ctx).LdapSyncRunUpsert(ctx, gen.LdapSyncRunUpsertParams{
+		ID:         row.ID,
+		Status:     row.Status,
+		Err:        row.Err,
+		StartedAt:  row.StartedAt,
+		FinishedAt: row.FinishedAt,
+		Summary:    row.Summary,
+	}); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
+
+func (r *LDAPSync) one(ctx context.Context, row gen.LdapSyncRun, err error) (*ldapsync.Run, error) {
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, rerror.ErrNotFound
+	}
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return ldapSyncRunModel(row)
+}
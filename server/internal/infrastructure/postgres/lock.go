@@ -0,0 +1,63 @@
+package postgres
+
+import (
+	"context"
+	"sync"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// Lock is a named session-level advisory lock. Each held lock keeps its own
+// connection, since advisory locks belong to the session that took them.
+type Lock struct {
+	pool  *pgxpool.Pool
+	mu    sync.Mutex
+	conns map[string]*pgxpool.Conn
+}
+
+func NewLock(pool *pgxpool.Pool) repo.Lock {
+	return &Lock{pool: pool, conns: map[string]*pgxpool.Conn{}}
+}
+
+func (r *Lock) Lock(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.conns[name]; ok {
+		return repo.ErrAlreadyLocked
+	}
+
+	conn, err := r.pool.Acquire(ctx)
+	if err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	var ok bool
+	if err := conn.QueryRow(ctx, `SELECT pg_try_advisory_lock(hashtext($1))`, name).Scan(&ok); err != nil {
+		conn.Release()
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	if !ok {
+		conn.Release()
+		return repo.ErrFailedToLock
+	}
+	r.conns[name] = conn
+	return nil
+}
+
+func (r *Lock) Unlock(ctx context.Context, name string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	conn, ok := r.conns[name]
+	if !ok {
+		return repo.ErrNotLocked
+	}
+	delete(r.conns, name)
+	defer conn.Release()
+	if _, err := conn.Exec(ctx, `SELECT pg_advisory_unlock(hashtext($1))`, name); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
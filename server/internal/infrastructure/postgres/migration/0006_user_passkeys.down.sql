@@ -0,0 +1,2 @@
+ALTER TABLE users DROP COLUMN IF EXISTS passkey_challenge;
+ALTER TABLE users DROP COLUMN IF EXISTS passkeys;
@@ -0,0 +1,2 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS passkeys jsonb;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS passkey_challenge jsonb;
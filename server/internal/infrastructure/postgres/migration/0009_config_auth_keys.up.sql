@@ -0,0 +1 @@
+ALTER TABLE config ADD COLUMN IF NOT EXISTS auth_keys jsonb NOT NULL DEFAULT '[]';
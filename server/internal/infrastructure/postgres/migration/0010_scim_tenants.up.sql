@@ -0,0 +1,13 @@
+-- scim_tenants
+CREATE TABLE scim_tenants (
+    id         text PRIMARY KEY,
+    name       text NOT NULL,
+    sub_prefix text NOT NULL,
+    token_hash text NOT NULL DEFAULT '',
+    users      text[] NOT NULL DEFAULT '{}',
+    groups     jsonb NOT NULL DEFAULT '[]',
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- every SCIM request is authenticated by the hash of its bearer token
+CREATE UNIQUE INDEX scim_tenants_token_hash_uniq ON scim_tenants (token_hash) WHERE token_hash <> '';
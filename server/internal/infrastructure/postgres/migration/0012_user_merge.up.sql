@@ -0,0 +1,13 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS merged_into text;
+
+-- audit_logs
+CREATE TABLE audit_logs (
+    id         text PRIMARY KEY,
+    actor      text NOT NULL,
+    action     text NOT NULL,
+    target     text NOT NULL DEFAULT '',
+    detail     jsonb NOT NULL DEFAULT '{}',
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_logs_target_idx ON audit_logs (target, created_at);
@@ -0,0 +1,14 @@
+-- role_mappings
+CREATE TABLE role_mappings (
+    id         text PRIMARY KEY,
+    workspace  text NOT NULL,
+    issuer     text NOT NULL,
+    sub_prefix text NOT NULL DEFAULT '',
+    rules      jsonb NOT NULL DEFAULT '[]',
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+-- a workspace has at most one mapping
+CREATE UNIQUE INDEX role_mappings_workspace_uniq ON role_mappings (workspace);
+-- the mappings of a token are looked up by its issuer on every request
+CREATE INDEX role_mappings_issuer_idx ON role_mappings (issuer);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS ldap_sync_runs;
@@ -0,0 +1,13 @@
+-- ldap_sync_runs
+CREATE TABLE ldap_sync_runs (
+    id          text PRIMARY KEY,
+    status      text NOT NULL,
+    err         text NOT NULL DEFAULT '',
+    started_at  timestamptz NOT NULL,
+    finished_at timestamptz,
+    summary     jsonb NOT NULL DEFAULT '{}'
+);
+
+-- recent runs and the last succeeded one are looked up by start time
+CREATE INDEX ldap_sync_runs_started_at_idx ON ldap_sync_runs (started_at DESC, id DESC);
+CREATE INDEX ldap_sync_runs_status_started_at_idx ON ldap_sync_runs (status, started_at DESC);
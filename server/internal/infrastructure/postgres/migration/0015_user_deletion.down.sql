@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS workspaces_deleted_at_idx;
+DROP INDEX IF EXISTS users_deleted_at_idx;
+ALTER TABLE users DROP COLUMN IF EXISTS purge_notified_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deletion_requested_at;
@@ -0,0 +1,5 @@
+ALTER TABLE users ADD COLUMN IF NOT EXISTS deletion_requested_at timestamptz;
+ALTER TABLE users ADD COLUMN IF NOT EXISTS purge_notified_at timestamptz;
+
+CREATE INDEX IF NOT EXISTS users_deleted_at_idx ON users (deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX IF NOT EXISTS workspaces_deleted_at_idx ON workspaces (deleted_at) WHERE deleted_at IS NOT NULL;
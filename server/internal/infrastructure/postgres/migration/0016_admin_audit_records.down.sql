@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS admin_audit_records;
+DROP FUNCTION IF EXISTS admin_audit_records_append_only();
@@ -0,0 +1,30 @@
+-- admin_audit_records is an append-only hash chain of admin API writes
+CREATE TABLE admin_audit_records (
+    id         text PRIMARY KEY,
+    seq        bigint NOT NULL UNIQUE,
+    actor      text NOT NULL,
+    action     text NOT NULL,
+    target     text NOT NULL DEFAULT '',
+    request_id text NOT NULL DEFAULT '',
+    ip         text NOT NULL DEFAULT '',
+    status     integer NOT NULL DEFAULT 0,
+    diff       jsonb NOT NULL DEFAULT '{}',
+    created_at timestamptz NOT NULL,
+    prev_hash  text NOT NULL DEFAULT '',
+    hash       text NOT NULL
+);
+
+CREATE INDEX admin_audit_records_created_at_idx ON admin_audit_records (created_at DESC);
+CREATE INDEX admin_audit_records_actor_seq_idx ON admin_audit_records (actor, seq DESC);
+CREATE INDEX admin_audit_records_target_seq_idx ON admin_audit_records (target, seq DESC);
+
+-- records are never changed once written; refuse it at the database too
+CREATE FUNCTION admin_audit_records_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'admin_audit_records is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER admin_audit_records_append_only
+    BEFORE UPDATE OR DELETE ON admin_audit_records
+    FOR EACH ROW EXECUTE FUNCTION admin_audit_records_append_only();
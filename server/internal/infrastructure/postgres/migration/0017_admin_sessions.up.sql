@@ -0,0 +1,13 @@
+-- admin_sessions holds the signed-in sessions of the admin console; a session
+-- token is only accepted while its row exists and is unexpired
+CREATE TABLE admin_sessions (
+    id         text PRIMARY KEY,
+    admin_user text NOT NULL,
+    user_agent text NOT NULL DEFAULT '',
+    ip         text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
+
+CREATE INDEX admin_sessions_admin_user_created_at_idx ON admin_sessions (admin_user, created_at DESC);
+CREATE INDEX admin_sessions_expires_at_idx ON admin_sessions (expires_at);
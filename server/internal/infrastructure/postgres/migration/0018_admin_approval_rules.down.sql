@@ -0,0 +1,2 @@
+ALTER TABLE admin_users DROP COLUMN IF EXISTS approved_by_rule;
+DROP TABLE IF EXISTS admin_approval_rules;
@@ -0,0 +1,14 @@
+-- admin_approval_rules approve new admin sign-ins automatically by email
+-- domain or group, with the given role
+CREATE TABLE admin_approval_rules (
+    id         text PRIMARY KEY,
+    kind       text NOT NULL,
+    value      text NOT NULL,
+    provider   text NOT NULL DEFAULT '',
+    role       text NOT NULL,
+    created_by text NOT NULL DEFAULT '',
+    updated_at timestamptz NOT NULL
+);
+
+-- admin_users.approved_by_rule records the rule that approved an admin
+ALTER TABLE admin_users ADD COLUMN approved_by_rule text NOT NULL DEFAULT '';
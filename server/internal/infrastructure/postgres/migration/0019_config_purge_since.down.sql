@@ -0,0 +1 @@
+ALTER TABLE config DROP COLUMN IF EXISTS purge_since;
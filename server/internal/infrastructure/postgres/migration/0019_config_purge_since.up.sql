@@ -0,0 +1 @@
+ALTER TABLE config ADD COLUMN IF NOT EXISTS purge_since timestamptz;
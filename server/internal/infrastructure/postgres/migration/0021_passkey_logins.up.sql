@@ -0,0 +1,10 @@
+-- passkey_logins holds the passkey login ceremonies in flight; a row is taken
+-- when the assertion is posted, so each challenge is accepted once
+CREATE TABLE passkey_logins (
+    id         text PRIMARY KEY,
+    user_id    text NOT NULL,
+    challenge  text NOT NULL,
+    expires_at timestamptz NOT NULL
+);
+
+CREATE INDEX passkey_logins_expires_at_idx ON passkey_logins (expires_at);
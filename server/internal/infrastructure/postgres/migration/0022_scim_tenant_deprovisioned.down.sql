@@ -0,0 +1 @@
+ALTER TABLE scim_tenants DROP COLUMN IF EXISTS deprovisioned;
@@ -0,0 +1,2 @@
+-- deprovisioned lists the users a tenant deleted, which it may provision again
+ALTER TABLE scim_tenants ADD COLUMN IF NOT EXISTS deprovisioned text[] NOT NULL DEFAULT '{}';
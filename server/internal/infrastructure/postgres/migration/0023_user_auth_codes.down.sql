@@ -0,0 +1 @@
+UPDATE users SET auth_code = NULL WHERE jsonb_typeof(auth_code) = 'array';
@@ -0,0 +1,3 @@
+-- auth_code now holds a JSON array of the pending authorization codes; the
+-- single codes stored before are short-lived and dropped
+UPDATE users SET auth_code = NULL WHERE jsonb_typeof(auth_code) <> 'array';
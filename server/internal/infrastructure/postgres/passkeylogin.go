@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/pgdoc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/sqlc/gen"
+	"github.com/reearth/reearth-accounts/server/pkg/passkeylogin"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type PasskeyLogin struct {
+	c *Client
+}
+
+func NewPasskeyLogin(c *Client) passkeylogin.Repo { return &PasskeyLogin{c: c} }
+
+func (r *PasskeyLogin) Save(ctx context.Context, s *passkeylogin.Session) error {
+	row := pgdoc.NewPasskeyLoginRow(s)
+	if err := r.c.queries(ctx).PasskeyLoginInsert(ctx, gen.PasskeyLoginInsertParams{
+		ID:        row.ID,
+		UserID:    row.User,
+		Challenge: row.Challenge,
+		ExpiresAt: row.ExpiresAt,
+	}); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
+
+func (r *PasskeyLogin) Take(ctx context.Context, id string) (*passkeylogin.Session, error) {
+	row, err := r.c.queries(ctx).PasskeyLoginTake(ctx, id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, rerror.ErrNotFound
+	}
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return pgdoc.PasskeyLoginRow{
+		ID:        row.ID,
+		User:      row.UserID,
+		Challenge: row.Challenge,
+		ExpiresAt: row.ExpiresAt,
+	}.Model()
+}
+
+func (r *PasskeyLogin) RemoveExpired(ctx context.Context, t time.Time) error {
+	if err := r.c.queries(ctx).PasskeyLoginDeleteExpired(ctx, t); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
@@ -137,3 +137,10 @@ func (r *Permittable) SaveMany(ctx context.Context, list permittable.List) error
 		return nil
 	})
 }
+
+func (r *Permittable) RemoveByUserID(ctx context.Context, uid user.ID) error {
+	if err := r.c.queries(ctx).PermittableDeleteByUserID(ctx, uid.String()); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
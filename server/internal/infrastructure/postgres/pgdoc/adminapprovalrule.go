@@ -0,0 +1,69 @@
+package pgdoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+type AdminApprovalRuleRow struct {
+	ID        string
+	Kind      string
+	Value     string
+	Provider  string
+	Role      string
+	CreatedBy string
+	UpdatedAt time.Time
+}
+
+func NewAdminApprovalRuleRow(r *adminapprovalrule.Rule) AdminApprovalRuleRow {
+	createdBy := ""
+	if by := r.CreatedBy(); !by.IsEmpty() {
+		createdBy = by.String()
+	}
+	return AdminApprovalRuleRow{
+		ID:        r.ID().String(),
+		Kind:      r.Kind().String(),
+		Value:     r.Value(),
+		Provider:  r.Provider().String(),
+		Role:      r.Role().String(),
+		CreatedBy: createdBy,
+		UpdatedAt: r.UpdatedAt(),
+	}
+}
+
+func (r AdminApprovalRuleRow) Model() (*adminapprovalrule.Rule, error) {
+	rid, err := adminapprovalrule.IDFrom(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	kind, err := adminapprovalrule.KindFrom(r.Kind)
+	if err != nil {
+		return nil, err
+	}
+	provider, err := adminapprovalrule.ProviderFrom(r.Provider)
+	if err != nil {
+		return nil, err
+	}
+	role, err := adminuser.RoleFrom(r.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	b := adminapprovalrule.New().
+		ID(rid).
+		Kind(kind).
+		Value(r.Value).
+		Provider(provider).
+		Role(role).
+		UpdatedAt(r.UpdatedAt)
+	if r.CreatedBy != "" {
+		by, err := adminuser.IDFrom(r.CreatedBy)
+		if err != nil {
+			return nil, err
+		}
+		b = b.CreatedBy(by)
+	}
+	return b.Build()
+}
@@ -0,0 +1,77 @@
+package pgdoc
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+type AdminAuditRecordRow struct {
+	ID        string
+	Seq       int64
+	Actor     string
+	Action    string
+	Target    string
+	RequestID string
+	IP        string
+	Status    int32
+	Diff      []byte // jsonb
+	CreatedAt time.Time
+	PrevHash  string
+	Hash      string
+}
+
+func NewAdminAuditRecordRow(r *adminaudit.Record) AdminAuditRecordRow {
+	diff := r.Diff()
+	if diff == nil {
+		diff = map[string]string{}
+	}
+	row := AdminAuditRecordRow{
+		ID:        r.ID().String(),
+		Seq:       r.Seq(),
+		Actor:     r.Actor().String(),
+		Action:    r.Action(),
+		Target:    r.Target(),
+		RequestID: r.RequestID(),
+		IP:        r.IP(),
+		Status:    int32(r.Status()),
+		CreatedAt: r.CreatedAt(),
+		PrevHash:  r.PrevHash(),
+		Hash:      r.Hash(),
+	}
+	row.Diff, _ = json.Marshal(diff)
+	return row
+}
+
+func (r AdminAuditRecordRow) Model() (*adminaudit.Record, error) {
+	rid, err := adminaudit.IDFrom(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := adminuser.IDFrom(r.Actor)
+	if err != nil {
+		return nil, err
+	}
+	var diff map[string]string
+	if len(r.Diff) > 0 {
+		if err := json.Unmarshal(r.Diff, &diff); err != nil {
+			return nil, err
+		}
+	}
+	return adminaudit.New().
+		ID(rid).
+		Seq(r.Seq).
+		Actor(actor).
+		Action(r.Action).
+		Target(r.Target).
+		RequestID(r.RequestID).
+		IP(r.IP).
+		Status(int(r.Status)).
+		Diff(diff).
+		CreatedAt(r.CreatedAt).
+		PrevHash(r.PrevHash).
+		Hash(r.Hash).
+		Build()
+}
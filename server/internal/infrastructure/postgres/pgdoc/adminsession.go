@@ -0,0 +1,47 @@
+package pgdoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+type AdminSessionRow struct {
+	ID        string
+	AdminUser string
+	UserAgent string
+	IP        string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
+func NewAdminSessionRow(s *adminsession.Session) AdminSessionRow {
+	return AdminSessionRow{
+		ID:        s.ID().String(),
+		AdminUser: s.AdminUser().String(),
+		UserAgent: s.UserAgent(),
+		IP:        s.IP(),
+		CreatedAt: s.CreatedAt(),
+		ExpiresAt: s.ExpiresAt(),
+	}
+}
+
+func (r AdminSessionRow) Model() (*adminsession.Session, error) {
+	sid, err := adminsession.IDFrom(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	u, err := adminuser.IDFrom(r.AdminUser)
+	if err != nil {
+		return nil, err
+	}
+	return adminsession.New().
+		ID(sid).
+		AdminUser(u).
+		UserAgent(r.UserAgent).
+		IP(r.IP).
+		CreatedAt(r.CreatedAt).
+		ExpiresAt(r.ExpiresAt).
+		Build()
+}
@@ -18,6 +18,8 @@ type AdminUserRow struct {
 	ApprovedAt *time.Time
 	CreatedAt  time.Time
 	UpdatedAt  time.Time
+	// ApprovedByRule is "" unless an approval rule approved the user.
+	ApprovedByRule string
 }
 
 func NewAdminUserRow(u adminuser.AdminUser) AdminUserRow {
@@ -29,17 +31,22 @@ func NewAdminUserRow(u adminuser.AdminUser) AdminUserRow {
 	if by := u.ApprovedBy(); !by.IsEmpty() {
 		approvedBy = by.String()
 	}
+	approvedByRule := ""
+	if rule := u.ApprovedByRule(); !rule.IsEmpty() {
+		approvedByRule = rule.String()
+	}
 	return AdminUserRow{
-		ID:         u.ID().String(),
-		Email:      u.Email(),
-		Name:       u.Name(),
-		PictureURL: u.PictureURL(),
-		Role:       u.Role().String(),
-		Status:     u.Status().String(),
-		ApprovedBy: approvedBy,
-		ApprovedAt: approvedAt,
-		CreatedAt:  u.CreatedAt(),
-		UpdatedAt:  u.UpdatedAt(),
+		ID:             u.ID().String(),
+		Email:          u.Email(),
+		Name:           u.Name(),
+		PictureURL:     u.PictureURL(),
+		Role:           u.Role().String(),
+		Status:         u.Status().String(),
+		ApprovedBy:     approvedBy,
+		ApprovedAt:     approvedAt,
+		CreatedAt:      u.CreatedAt(),
+		UpdatedAt:      u.UpdatedAt(),
+		ApprovedByRule: approvedByRule,
 	}
 }
 
@@ -82,6 +89,13 @@ func (r AdminUserRow) Model() (*adminuser.AdminUser, error) {
 		}
 		b = b.ApprovedBy(by)
 	}
+	if r.ApprovedByRule != "" {
+		rule, err := id.AdminApprovalRuleIDFrom(r.ApprovedByRule)
+		if err != nil {
+			return nil, err
+		}
+		b = b.ApprovedByRule(rule)
+	}
 
 	return b.Build()
 }
@@ -0,0 +1,59 @@
+package pgdoc
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+)
+
+type AuditLogRow struct {
+	ID        string
+	Actor     string
+	Action    string
+	Target    string
+	Detail    []byte // jsonb
+	CreatedAt time.Time
+}
+
+func NewAuditLogRow(e *auditlog.Entry) AuditLogRow {
+	detail := e.Detail()
+	if detail == nil {
+		detail = map[string]string{}
+	}
+	row := AuditLogRow{
+		ID:        e.ID().String(),
+		Actor:     e.Actor().String(),
+		Action:    e.Action().String(),
+		Target:    e.Target(),
+		CreatedAt: e.CreatedAt(),
+	}
+	row.Detail, _ = json.Marshal(detail)
+	return row
+}
+
+func (r AuditLogRow) Model() (*auditlog.Entry, error) {
+	eid, err := auditlog.IDFrom(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	actor, err := adminuser.IDFrom(r.Actor)
+	if err != nil {
+		return nil, err
+	}
+	var detail map[string]string
+	if len(r.Detail) > 0 {
+		if err := json.Unmarshal(r.Detail, &detail); err != nil {
+			return nil, err
+		}
+	}
+	return auditlog.New().
+		ID(eid).
+		Actor(actor).
+		Action(auditlog.Action(r.Action)).
+		Target(r.Target).
+		Detail(detail).
+		CreatedAt(r.CreatedAt).
+		Build()
+}
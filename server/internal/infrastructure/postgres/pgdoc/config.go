@@ -1,6 +1,9 @@
 package pgdoc
 
 import (
+	"encoding/json"
+	"time"
+
 	"github.com/reearth/reearth-accounts/server/pkg/config"
 	"github.com/reearth/reearth-accounts/server/pkg/policy"
 )
@@ -9,15 +12,32 @@ type ConfigRow struct {
 	Migration     int64
 	AuthCert      string
 	AuthKey       string
+	AuthKeys      []byte // jsonb
 	DefaultPolicy *string
+	PurgeSince    *time.Time
+}
+
+type ConfigAuthKeyJSON struct {
+	Cert        string     `json:"cert"`
+	Key         string     `json:"key"`
+	KID         string     `json:"kid"`
+	ActivatedAt time.Time  `json:"activatedat"`
+	RetiredAt   *time.Time `json:"retiredat"`
 }
 
 func NewConfigRow(c config.Config) ConfigRow {
-	row := ConfigRow{Migration: c.Migration}
+	row := ConfigRow{Migration: c.Migration, PurgeSince: c.PurgeSince}
 	if c.Auth != nil {
 		row.AuthCert = c.Auth.Cert
 		row.AuthKey = c.Auth.Key
 	}
+	keys := make([]ConfigAuthKeyJSON, 0, len(c.Keys))
+	for _, k := range c.Keys {
+		keys = append(keys, ConfigAuthKeyJSON{
+			Cert: k.Cert, Key: k.Key, KID: k.KID, ActivatedAt: k.ActivatedAt, RetiredAt: k.RetiredAt,
+		})
+	}
+	row.AuthKeys, _ = json.Marshal(keys)
 	if c.DefaultPolicy != nil {
 		s := c.DefaultPolicy.String()
 		row.DefaultPolicy = &s
@@ -25,14 +45,25 @@ func NewConfigRow(c config.Config) ConfigRow {
 	return row
 }
 
-func (r ConfigRow) Model() *config.Config {
-	cfg := &config.Config{Migration: r.Migration}
+func (r ConfigRow) Model() (*config.Config, error) {
+	cfg := &config.Config{Migration: r.Migration, PurgeSince: r.PurgeSince}
 	if r.AuthCert != "" || r.AuthKey != "" {
 		cfg.Auth = &config.Auth{Cert: r.AuthCert, Key: r.AuthKey}
 	}
+	if len(r.AuthKeys) > 0 {
+		var keys []ConfigAuthKeyJSON
+		if err := json.Unmarshal(r.AuthKeys, &keys); err != nil {
+			return nil, err
+		}
+		for _, k := range keys {
+			cfg.Keys = append(cfg.Keys, config.Auth{
+				Cert: k.Cert, Key: k.Key, KID: k.KID, ActivatedAt: k.ActivatedAt, RetiredAt: k.RetiredAt,
+			})
+		}
+	}
 	if r.DefaultPolicy != nil && *r.DefaultPolicy != "" {
 		p := policy.ID(*r.DefaultPolicy)
 		cfg.DefaultPolicy = &p
 	}
-	return cfg
+	return cfg, nil
 }
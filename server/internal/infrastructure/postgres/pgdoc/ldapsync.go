@@ -0,0 +1,154 @@
+package pgdoc
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+)
+
+type LDAPSyncRunRow struct {
+	ID         string
+	Status     string
+	Err        string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	Summary    []byte // jsonb
+}
+
+// LDAPSyncSummaryJSON holds the users and changes of a run, which are only
+// read together with it.
+type LDAPSyncSummaryJSON struct {
+	Users       []string                       `json:"users"`
+	Created     []LDAPSyncUserChangeJSON       `json:"created"`
+	Updated     []LDAPSyncUserChangeJSON       `json:"updated"`
+	Reactivated []LDAPSyncUserChangeJSON       `json:"reactivated"`
+	Deactivated []LDAPSyncUserChangeJSON       `json:"deactivated"`
+	Memberships []LDAPSyncMembershipChangeJSON `json:"memberships"`
+	Skipped     []LDAPSyncSkipJSON             `json:"skipped"`
+}
+
+type LDAPSyncUserChangeJSON struct {
+	User  string `json:"user"`
+	Sub   string `json:"sub"`
+	Email string `json:"email"`
+}
+
+type LDAPSyncMembershipChangeJSON struct {
+	Workspace string `json:"workspace"`
+	User      string `json:"user"`
+	From      string `json:"from"`
+	To        string `json:"to"`
+}
+
+type LDAPSyncSkipJSON struct {
+	DN     string `json:"dn"`
+	Reason string `json:"reason"`
+}
+
+func NewLDAPSyncRunRow(r *ldapsync.Run) LDAPSyncRunRow {
+	summary := LDAPSyncSummaryJSON{
+		Users:       r.Users().Strings(),
+		Created:     newLDAPSyncUserChangesJSON(r.Created()),
+		Updated:     newLDAPSyncUserChangesJSON(r.Updated()),
+		Reactivated: newLDAPSyncUserChangesJSON(r.Reactivated()),
+		Deactivated: newLDAPSyncUserChangesJSON(r.Deactivated()),
+		Memberships: make([]LDAPSyncMembershipChangeJSON, 0, len(r.Memberships())),
+		Skipped:     make([]LDAPSyncSkipJSON, 0, len(r.Skipped())),
+	}
+	for _, c := range r.Memberships() {
+		summary.Memberships = append(summary.Memberships, LDAPSyncMembershipChangeJSON{
+			Workspace: c.Workspace.String(),
+			User:      c.User.String(),
+			From:      c.From.String(),
+			To:        c.To.String(),
+		})
+	}
+	for _, s := range r.Skipped() {
+		summary.Skipped = append(summary.Skipped, LDAPSyncSkipJSON{DN: s.DN, Reason: s.Reason})
+	}
+
+	row := LDAPSyncRunRow{
+		ID:         r.ID().String(),
+		Status:     string(r.Status()),
+		Err:        r.Err(),
+		StartedAt:  r.StartedAt(),
+		FinishedAt: r.FinishedAt(),
+	}
+	row.Summary, _ = json.Marshal(summary)
+	return row
+}
+
+func newLDAPSyncUserChangesJSON(l []ldapsync.UserChange) []LDAPSyncUserChangeJSON {
+	res := make([]LDAPSyncUserChangeJSON, 0, len(l))
+	for _, c := range l {
+		res = append(res, LDAPSyncUserChangeJSON{User: c.User.String(), Sub: c.Sub, Email: c.Email})
+	}
+	return res
+}
+
+func (r LDAPSyncRunRow) Model() (*ldapsync.Run, error) {
+	rid, err := ldapsync.IDFrom(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	var summary LDAPSyncSummaryJSON
+	if len(r.Summary) > 0 {
+		if err := json.Unmarshal(r.Summary, &summary); err != nil {
+			return nil, err
+		}
+	}
+	users, err := id.UserIDListFrom(summary.Users)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([][]ldapsync.UserChange, 4)
+	for i, l := range [][]LDAPSyncUserChangeJSON{summary.Created, summary.Updated, summary.Reactivated, summary.Deactivated} {
+		changes[i] = make([]ldapsync.UserChange, 0, len(l))
+		for _, c := range l {
+			uid, err := id.UserIDFrom(c.User)
+			if err != nil {
+				return nil, err
+			}
+			changes[i] = append(changes[i], ldapsync.UserChange{User: uid, Sub: c.Sub, Email: c.Email})
+		}
+	}
+	memberships := make([]ldapsync.MembershipChange, 0, len(summary.Memberships))
+	for _, c := range summary.Memberships {
+		wid, err := id.WorkspaceIDFrom(c.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		uid, err := id.UserIDFrom(c.User)
+		if err != nil {
+			return nil, err
+		}
+		memberships = append(memberships, ldapsync.MembershipChange{
+			Workspace: wid,
+			User:      uid,
+			From:      role.RoleType(c.From),
+			To:        role.RoleType(c.To),
+		})
+	}
+	skipped := make([]ldapsync.Skip, 0, len(summary.Skipped))
+	for _, s := range summary.Skipped {
+		skipped = append(skipped, ldapsync.Skip{DN: s.DN, Reason: s.Reason})
+	}
+
+	return ldapsync.New().
+		ID(rid).
+		Status(ldapsync.Status(r.Status)).
+		Err(r.Err).
+		StartedAt(r.StartedAt).
+		FinishedAt(r.FinishedAt).
+		Users(users).
+		Created(changes[0]).
+		Updated(changes[1]).
+		Reactivated(changes[2]).
+		Deactivated(changes[3]).
+		Memberships(memberships).
+		Skipped(skipped).
+		Build()
+}
@@ -0,0 +1,32 @@
+package pgdoc
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/passkeylogin"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+)
+
+type PasskeyLoginRow struct {
+	ID        string
+	User      string
+	Challenge string
+	ExpiresAt time.Time
+}
+
+func NewPasskeyLoginRow(s *passkeylogin.Session) PasskeyLoginRow {
+	return PasskeyLoginRow{
+		ID:        s.ID(),
+		User:      s.User().String(),
+		Challenge: s.Challenge(),
+		ExpiresAt: s.ExpiresAt(),
+	}
+}
+
+func (r PasskeyLoginRow) Model() (*passkeylogin.Session, error) {
+	u, err := user.IDFrom(r.User)
+	if err != nil {
+		return nil, err
+	}
+	return passkeylogin.From(r.ID, u, r.Challenge, r.ExpiresAt), nil
+}
@@ -2,13 +2,19 @@ package pgdoc_test
 
 import (
 	"testing"
+	"time"
 
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/pgdoc"
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
 	"github.com/reearth/reearth-accounts/server/pkg/config"
 	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
 	"github.com/reearth/reearth-accounts/server/pkg/permittable"
 	"github.com/reearth/reearth-accounts/server/pkg/policy"
 	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/stretchr/testify/assert"
@@ -38,6 +44,110 @@ func TestUserRoundTrip(t *testing.T) {
 	assert.Equal(t, "alice", got.Alias())
 	assert.Equal(t, wid, got.Workspace())
 	assert.Equal(t, []string{"sub-1"}, subsOf(got))
+	assert.Nil(t, got.MFA())
+}
+
+func TestUserRoundTrip_MFA(t *testing.T) {
+	u, err := user.New().NewID().Name("alice").Email("a@example.com").
+		Workspace(id.NewWorkspaceID()).MFA(user.MFAFrom("SECRET", true, []string{"h1"}, 42, 0, nil)).Build()
+	require.NoError(t, err)
+
+	got, err := pgdoc.NewUserRow(u).Model()
+	require.NoError(t, err)
+	require.NotNil(t, got.MFA())
+	assert.Equal(t, "SECRET", got.MFA().Secret())
+	assert.True(t, got.MFA().IsEnabled())
+	assert.Equal(t, []string{"h1"}, got.MFA().RecoveryCodes())
+	assert.Equal(t, int64(42), got.MFA().LastUsedStep())
+}
+
+func TestUserRoundTrip_Passkeys(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	pk := user.Passkey{
+		ID: []byte("cred"), PublicKey: []byte("key"), AttestationType: "none",
+		Transports: []string{"internal"}, AAGUID: make([]byte, 16), SignCount: 3,
+		BackupEligible: true, Nickname: "laptop", CreatedAt: now, LastUsedAt: &now,
+	}
+	u, err := user.New().NewID().Name("alice").Email("a@example.com").
+		Workspace(id.NewWorkspaceID()).Passkeys([]user.Passkey{pk}).
+		PasskeyChallenge(&user.PasskeyChallenge{Challenge: "c", CreatedAt: now}).Build()
+	require.NoError(t, err)
+
+	got, err := pgdoc.NewUserRow(u).Model()
+	require.NoError(t, err)
+	assert.Equal(t, []user.Passkey{pk}, got.Passkeys())
+	assert.Equal(t, &user.PasskeyChallenge{Challenge: "c", CreatedAt: now}, got.PasskeyChallenge())
+}
+
+func TestUserRoundTrip_MagicLink(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	ml := &user.MagicLink{TokenHash: "hash", CreatedAt: now, RequestedAt: []time.Time{now}}
+	u, err := user.New().NewID().Name("alice").Email("a@example.com").
+		Workspace(id.NewWorkspaceID()).MagicLink(ml).Build()
+	require.NoError(t, err)
+
+	got, err := pgdoc.NewUserRow(u).Model()
+	require.NoError(t, err)
+	assert.Equal(t, ml, got.MagicLink())
+}
+
+func TestUserRoundTrip_AuthCodes(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	c := []user.AuthCode{
+		{
+			CodeHash: "hash", ClientID: "client", RedirectURI: "https://app.example.com/callback",
+			Scopes: []string{"openid", "email"}, Nonce: "nonce", CodeChallenge: "challenge",
+			AuthTime: now, CreatedAt: now, ExpiresAt: now.Add(time.Minute),
+		},
+		{
+			CodeHash: "hash2", ClientID: "client", RedirectURI: "https://app.example.com/callback",
+			Scopes: []string{"openid"}, CodeChallenge: "challenge2",
+			AuthTime: now, CreatedAt: now, ExpiresAt: now.Add(time.Minute),
+		},
+	}
+	u, err := user.New().NewID().Name("alice").Email("a@example.com").
+		Workspace(id.NewWorkspaceID()).AuthCodes(c).Build()
+	require.NoError(t, err)
+
+	got, err := pgdoc.NewUserRow(u).Model()
+	require.NoError(t, err)
+	assert.Equal(t, c, got.AuthCodes())
+}
+
+func TestUserRoundTrip_RefreshTokenFamilies(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	f := []user.RefreshTokenFamily{{ID: "family", Generation: 3, ExpiresAt: now, MaxExpiresAt: now.Add(time.Hour)}}
+	u, err := user.New().NewID().Name("alice").Email("a@example.com").
+		Workspace(id.NewWorkspaceID()).RefreshTokenFamilies(f).Build()
+	require.NoError(t, err)
+
+	got, err := pgdoc.NewUserRow(u).Model()
+	require.NoError(t, err)
+	assert.Equal(t, f, got.RefreshTokenFamilies())
+}
+
+func TestUserRoundTrip_AuthLinks(t *testing.T) {
+	now := time.Now().UTC().Truncate(time.Second)
+	u, err := user.New().NewID().Name("alice").Email("a@example.com").
+		Workspace(id.NewWorkspaceID()).Auths([]user.Auth{user.AuthFrom("auth0|a")}).Build()
+	require.NoError(t, err)
+	require.NoError(t, u.LinkAuth(user.AuthFrom("keycloak|b"), user.AuthLink{Issuer: "https://idp.example.com", Email: "a@example.com", LinkedAt: now}))
+
+	got, err := pgdoc.NewUserRow(u).Model()
+	require.NoError(t, err)
+	assert.Equal(t, u.Auths(), got.Auths())
+	assert.Equal(t, u.AuthLinks(), got.AuthLinks())
+}
+
+func TestUserRoundTrip_MergedInto(t *testing.T) {
+	target := id.NewUserID()
+	u, err := user.New().NewID().Name("alice").Email("a@example.com").
+		Workspace(id.NewWorkspaceID()).MergedInto(&target).Build()
+	require.NoError(t, err)
+
+	got, err := pgdoc.NewUserRow(u).Model()
+	require.NoError(t, err)
+	assert.Equal(t, &target, got.MergedInto())
 }
 
 func TestWorkspaceRoundTrip(t *testing.T) {
@@ -69,6 +179,80 @@ func TestRoleRoundTrip(t *testing.T) {
 	assert.Equal(t, "admin", got.Name())
 }
 
+func TestSCIMTenantRoundTrip(t *testing.T) {
+	uid := id.NewUserID()
+	wid := id.NewWorkspaceID()
+	tn := scimtenant.New().NewID().Name("okta").SubPrefix("samlp|okta").
+		TokenHash("hash").
+		Users(id.UserIDList{uid}).
+		Groups([]scimtenant.Group{{Workspace: wid, Role: role.RoleWriter}}).
+		MustBuild()
+	got, err := pgdoc.NewSCIMTenantRow(tn).Model()
+	require.NoError(t, err)
+	assert.Equal(t, tn.ID(), got.ID())
+	assert.Equal(t, "samlp|okta", got.SubPrefix())
+	assert.Equal(t, "hash", got.TokenHash())
+	assert.Equal(t, id.UserIDList{uid}, got.Users())
+	assert.Equal(t, []scimtenant.Group{{Workspace: wid, Role: role.RoleWriter}}, got.Groups())
+}
+
+func TestRoleMappingRoundTrip(t *testing.T) {
+	wid := id.NewWorkspaceID()
+	rules := []rolemapping.Rule{{Claim: "groups", Value: "gis-editors", Role: role.RoleWriter}}
+	m := rolemapping.New().NewID().Workspace(wid).Issuer("https://example.auth0.com/").
+		SubPrefix("samlp|org_1").Rules(rules).MustBuild()
+	got, err := pgdoc.NewRoleMappingRow(m).Model()
+	require.NoError(t, err)
+	assert.Equal(t, m.ID(), got.ID())
+	assert.Equal(t, wid, got.Workspace())
+	assert.Equal(t, "https://example.auth0.com/", got.Issuer())
+	assert.Equal(t, "samlp|org_1", got.SubPrefix())
+	assert.Equal(t, rules, got.Rules())
+}
+
+func TestAdminApprovalRuleRoundTrip(t *testing.T) {
+	by := adminuser.NewID()
+	r := adminapprovalrule.New().NewID().Kind(adminapprovalrule.KindGroup).Value("admins@example.com").
+		Provider(adminapprovalrule.ProviderGoogle).Role(adminuser.RoleViewer).CreatedBy(by).MustBuild()
+	got, err := pgdoc.NewAdminApprovalRuleRow(r).Model()
+	require.NoError(t, err)
+	assert.Equal(t, r.ID(), got.ID())
+	assert.Equal(t, adminapprovalrule.KindGroup, got.Kind())
+	assert.Equal(t, "admins@example.com", got.Value())
+	assert.Equal(t, adminapprovalrule.ProviderGoogle, got.Provider())
+	assert.Equal(t, adminuser.RoleViewer, got.Role())
+	assert.Equal(t, by, got.CreatedBy())
+
+	u := adminuser.New().NewID().Name("A").Email("a@example.com").MustBuild()
+	u.ApproveByRule(r.ID())
+	gotUser, err := pgdoc.NewAdminUserRow(*u).Model()
+	require.NoError(t, err)
+	assert.Equal(t, r.ID(), gotUser.ApprovedByRule())
+}
+
+func TestLDAPSyncRunRoundTrip(t *testing.T) {
+	now := time.Now().Truncate(time.Millisecond)
+	uid := id.NewUserID()
+	wid := id.NewWorkspaceID()
+	r := ldapsync.NewRun(now)
+	r.AddUser(uid)
+	r.AddCreated(ldapsync.UserChange{User: uid, Sub: "ldap|alice", Email: "alice@example.com"})
+	r.AddMemberships(ldapsync.MembershipChange{Workspace: wid, User: uid, To: role.RoleWriter})
+	r.AddSkipped(ldapsync.Skip{DN: "uid=bob,dc=example,dc=org", Reason: "missing email"})
+	r.Succeed(now.Add(time.Second))
+
+	got, err := pgdoc.NewLDAPSyncRunRow(r).Model()
+	require.NoError(t, err)
+	assert.Equal(t, r.ID(), got.ID())
+	assert.Equal(t, ldapsync.StatusSucceeded, got.Status())
+	assert.Equal(t, r.FinishedAt(), got.FinishedAt())
+	assert.Equal(t, id.UserIDList{uid}, got.Users())
+	assert.Equal(t, r.Created(), got.Created())
+	assert.Empty(t, got.Updated())
+	assert.Equal(t, r.Memberships(), got.Memberships())
+	assert.Equal(t, r.Skipped(), got.Skipped())
+}
+
 func TestPermittableRoundTrip(t *testing.T) {
 	uid := id.NewUserID()
 	rid := id.NewRoleID()
@@ -93,12 +277,31 @@ func TestPermittableRoundTrip(t *testing.T) {
 
 func TestConfigRoundTrip(t *testing.T) {
 	pid := policy.ID("policy-1")
-	cfg := config.Config{Migration: 5, Auth: &config.Auth{Cert: "cert", Key: "key"}, DefaultPolicy: &pid}
-	got := pgdoc.NewConfigRow(cfg).Model()
+	since := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	cfg := config.Config{Migration: 5, Auth: &config.Auth{Cert: "cert", Key: "key"}, DefaultPolicy: &pid, PurgeSince: &since}
+	got, err := pgdoc.NewConfigRow(cfg).Model()
+	require.NoError(t, err)
 	assert.Equal(t, int64(5), got.Migration)
 	require.NotNil(t, got.Auth)
 	assert.Equal(t, "cert", got.Auth.Cert)
 	assert.Equal(t, "key", got.Auth.Key)
 	require.NotNil(t, got.DefaultPolicy)
 	assert.Equal(t, pid, *got.DefaultPolicy)
+	assert.Equal(t, &since, got.PurgeSince)
+}
+
+func TestConfigRoundTrip_Keys(t *testing.T) {
+	activated := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	retired := activated.Add(48 * time.Hour)
+	keys := []config.Auth{
+		{Cert: "c1", Key: "k1", KID: "1", ActivatedAt: activated, RetiredAt: &retired},
+		{Cert: "c2", Key: "k2", KID: "2", ActivatedAt: activated.Add(24 * time.Hour)},
+	}
+	got, err := pgdoc.NewConfigRow(config.Config{Keys: keys}).Model()
+	require.NoError(t, err)
+	assert.Nil(t, got.Auth)
+	assert.Equal(t, keys, got.Keys)
+
+	_, err = pgdoc.ConfigRow{AuthKeys: []byte("{")}.Model()
+	assert.Error(t, err)
 }
@@ -0,0 +1,70 @@
+package pgdoc
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+)
+
+type RoleMappingRow struct {
+	ID        string
+	Workspace string
+	Issuer    string
+	SubPrefix string
+	Rules     []byte // jsonb
+	UpdatedAt time.Time
+}
+
+type RoleMappingRuleJSON struct {
+	Claim string `json:"claim"`
+	Value string `json:"value"`
+	Role  string `json:"role"`
+}
+
+func NewRoleMappingRow(m *rolemapping.RoleMapping) RoleMappingRow {
+	rules := make([]RoleMappingRuleJSON, 0, len(m.Rules()))
+	for _, r := range m.Rules() {
+		rules = append(rules, RoleMappingRuleJSON{Claim: r.Claim, Value: r.Value, Role: r.Role.String()})
+	}
+	row := RoleMappingRow{
+		ID:        m.ID().String(),
+		Workspace: m.Workspace().String(),
+		Issuer:    m.Issuer(),
+		SubPrefix: m.SubPrefix(),
+		UpdatedAt: m.UpdatedAt(),
+	}
+	row.Rules, _ = json.Marshal(rules)
+	return row
+}
+
+func (r RoleMappingRow) Model() (*rolemapping.RoleMapping, error) {
+	mid, err := rolemapping.IDFrom(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	wid, err := id.WorkspaceIDFrom(r.Workspace)
+	if err != nil {
+		return nil, err
+	}
+	var rulesJSON []RoleMappingRuleJSON
+	if len(r.Rules) > 0 {
+		if err := json.Unmarshal(r.Rules, &rulesJSON); err != nil {
+			return nil, err
+		}
+	}
+	rules := make([]rolemapping.Rule, 0, len(rulesJSON))
+	for _, rj := range rulesJSON {
+		rules = append(rules, rolemapping.Rule{Claim: rj.Claim, Value: rj.Value, Role: role.RoleType(rj.Role)})
+	}
+	return rolemapping.New().
+		ID(mid).
+		Workspace(wid).
+		Issuer(r.Issuer).
+		SubPrefix(r.SubPrefix).
+		Rules(rules).
+		UpdatedAt(r.UpdatedAt).
+		Build()
+}
@@ -0,0 +1,91 @@
+package pgdoc
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+)
+
+type SCIMTenantRow struct {
+	ID            string
+	Name          string
+	SubPrefix     string
+	TokenHash     string
+	Users         []string
+	Groups        []byte // jsonb
+	UpdatedAt     time.Time
+	Deprovisioned []string // users the tenant deleted
+}
+
+type SCIMGroupJSON struct {
+	Workspace string `json:"workspace"`
+	Role      string `json:"role"`
+}
+
+func NewSCIMTenantRow(t *scimtenant.Tenant) SCIMTenantRow {
+	groups := make([]SCIMGroupJSON, 0, len(t.Groups()))
+	for _, g := range t.Groups() {
+		groups = append(groups, SCIMGroupJSON{Workspace: g.Workspace.String(), Role: g.Role.String()})
+	}
+	users := t.Users().Strings()
+	if users == nil {
+		users = []string{}
+	}
+	deprovisioned := t.Deprovisioned().Strings()
+	if deprovisioned == nil {
+		deprovisioned = []string{}
+	}
+	row := SCIMTenantRow{
+		ID:            t.ID().String(),
+		Name:          t.Name(),
+		SubPrefix:     t.SubPrefix(),
+		TokenHash:     t.TokenHash(),
+		Users:         users,
+		UpdatedAt:     t.UpdatedAt(),
+		Deprovisioned: deprovisioned,
+	}
+	row.Groups, _ = json.Marshal(groups)
+	return row
+}
+
+func (r SCIMTenantRow) Model() (*scimtenant.Tenant, error) {
+	tid, err := scimtenant.IDFrom(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	users, err := id.UserIDListFrom(r.Users)
+	if err != nil {
+		return nil, err
+	}
+	deprovisioned, err := id.UserIDListFrom(r.Deprovisioned)
+	if err != nil {
+		return nil, err
+	}
+	var groupsJSON []SCIMGroupJSON
+	if len(r.Groups) > 0 {
+		if err := json.Unmarshal(r.Groups, &groupsJSON); err != nil {
+			return nil, err
+		}
+	}
+	groups := make([]scimtenant.Group, 0, len(groupsJSON))
+	for _, g := range groupsJSON {
+		wid, err := id.WorkspaceIDFrom(g.Workspace)
+		if err != nil {
+			return nil, err
+		}
+		groups = append(groups, scimtenant.Group{Workspace: wid, Role: role.RoleType(g.Role)})
+	}
+	return scimtenant.New().
+		ID(tid).
+		Name(r.Name).
+		SubPrefix(r.SubPrefix).
+		TokenHash(r.TokenHash).
+		Users(users).
+		Deprovisioned(deprovisioned).
+		Groups(groups).
+		UpdatedAt(r.UpdatedAt).
+		Build()
+}
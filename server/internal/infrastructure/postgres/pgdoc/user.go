@@ -27,24 +27,97 @@ type UserPasswordResetJSON struct {
 	CreatedAt time.Time `json:"createdat"`
 }
 
+type UserMagicLinkJSON struct {
+	TokenHash   string      `json:"tokenhash"`
+	CreatedAt   time.Time   `json:"createdat"`
+	RequestedAt []time.Time `json:"requestedat"`
+}
+
+type UserAuthLinkJSON struct {
+	Sub      string    `json:"sub"`
+	Issuer   string    `json:"issuer"`
+	Email    string    `json:"email"`
+	LinkedAt time.Time `json:"linkedat"`
+}
+
+type UserRefreshTokenFamilyJSON struct {
+	ID         string    `json:"id"`
+	Generation int       `json:"generation"`
+	ExpiresAt  time.Time `json:"expiresat"`
+	// MaxExpiresAt is zero for families started before sign-ins had an end.
+	MaxExpiresAt time.Time `json:"maxexpiresat"`
+}
+
+// UserAuthCodeJSON is an element of the auth_code column, a JSON array of the
+// pending authorization codes.
+type UserAuthCodeJSON struct {
+	CodeHash      string    `json:"codehash"`
+	ClientID      string    `json:"clientid"`
+	RedirectURI   string    `json:"redirecturi"`
+	Scopes        []string  `json:"scopes"`
+	Nonce         string    `json:"nonce"`
+	CodeChallenge string    `json:"codechallenge"`
+	AuthTime      time.Time `json:"authtime"`
+	CreatedAt     time.Time `json:"createdat"`
+	ExpiresAt     time.Time `json:"expiresat"`
+}
+
+type UserMFAJSON struct {
+	Secret        string     `json:"secret"`
+	Enabled       bool       `json:"enabled"`
+	RecoveryCodes []string   `json:"recoverycodes"`
+	LastUsedStep  int64      `json:"lastusedstep"`
+	Failures      int        `json:"failures"`
+	LockedUntil   *time.Time `json:"lockeduntil,omitempty"`
+}
+
+type UserPasskeyJSON struct {
+	ID              []byte     `json:"id"`
+	PublicKey       []byte     `json:"publickey"`
+	AttestationType string     `json:"attestationtype"`
+	Transports      []string   `json:"transports"`
+	AAGUID          []byte     `json:"aaguid"`
+	SignCount       uint32     `json:"signcount"`
+	BackupEligible  bool       `json:"backupeligible"`
+	BackupState     bool       `json:"backupstate"`
+	Nickname        string     `json:"nickname"`
+	CreatedAt       time.Time  `json:"createdat"`
+	LastUsedAt      *time.Time `json:"lastusedat,omitempty"`
+}
+
+type UserPasskeyChallengeJSON struct {
+	Challenge string    `json:"challenge"`
+	CreatedAt time.Time `json:"createdat"`
+}
+
 type UserRow struct {
-	ID             string
-	Name           string
-	Alias          string
-	Email          string
-	Workspace      string
-	Password       []byte
-	Subs           []string
-	LatestLogoutAt *time.Time
-	Metadata       []byte // jsonb
-	Verification   []byte // jsonb (nullable)
-	PasswordReset  []byte // jsonb (nullable)
-	Team           *string
-	Lang           *string
-	Theme          *string
-	UpdatedAt      time.Time
-	DeletedAt      *time.Time
-	CreatedAt      *time.Time
+	ID                  string
+	Name                string
+	Alias               string
+	Email               string
+	Workspace           string
+	Password            []byte
+	Subs                []string
+	LatestLogoutAt      *time.Time
+	Metadata            []byte // jsonb
+	Verification        []byte // jsonb (nullable)
+	PasswordReset       []byte // jsonb (nullable)
+	MagicLink           []byte // jsonb (nullable)
+	AuthCode            []byte // jsonb (nullable)
+	AuthLinks           []byte // jsonb (nullable)
+	RefreshTokens       []byte // jsonb (nullable)
+	MFA                 []byte // jsonb (nullable)
+	Passkeys            []byte // jsonb (nullable)
+	PasskeyChallenge    []byte // jsonb (nullable)
+	Team                *string
+	Lang                *string
+	Theme               *string
+	UpdatedAt           time.Time
+	DeletedAt           *time.Time
+	CreatedAt           *time.Time
+	MergedInto          *string
+	DeletionRequestedAt *time.Time
+	PurgeNotifiedAt     *time.Time
 }
 
 func NewUserRow(u *user.User) *UserRow {
@@ -81,6 +154,80 @@ func NewUserRow(u *user.User) *UserRow {
 		pwReset, _ = json.Marshal(UserPasswordResetJSON{Token: pr.Token, CreatedAt: pr.CreatedAt})
 	}
 
+	var magicLink []byte
+	if m := u.MagicLink(); m != nil {
+		magicLink, _ = json.Marshal(UserMagicLinkJSON{TokenHash: m.TokenHash, CreatedAt: m.CreatedAt, RequestedAt: m.RequestedAt})
+	}
+
+	var authLinks []byte
+	if ls := u.AuthLinks(); len(ls) > 0 {
+		lj := make([]UserAuthLinkJSON, 0, len(ls))
+		for _, l := range ls {
+			lj = append(lj, UserAuthLinkJSON{Sub: l.Sub, Issuer: l.Issuer, Email: l.Email, LinkedAt: l.LinkedAt})
+		}
+		authLinks, _ = json.Marshal(lj)
+	}
+
+	var refreshTokens []byte
+	if fs := u.RefreshTokenFamilies(); len(fs) > 0 {
+		fj := make([]UserRefreshTokenFamilyJSON, 0, len(fs))
+		for _, f := range fs {
+			fj = append(fj, UserRefreshTokenFamilyJSON{ID: f.ID, Generation: f.Generation, ExpiresAt: f.ExpiresAt, MaxExpiresAt: f.MaxExpiresAt})
+		}
+		refreshTokens, _ = json.Marshal(fj)
+	}
+
+	var authCode []byte
+	if cs := u.AuthCodes(); len(cs) > 0 {
+		cj := make([]UserAuthCodeJSON, 0, len(cs))
+		for _, c := range cs {
+			cj = append(cj, UserAuthCodeJSON{
+				CodeHash: c.CodeHash, ClientID: c.ClientID, RedirectURI: c.RedirectURI, Scopes: c.Scopes,
+				Nonce: c.Nonce, CodeChallenge: c.CodeChallenge, AuthTime: c.AuthTime, CreatedAt: c.CreatedAt,
+				ExpiresAt: c.ExpiresAt,
+			})
+		}
+		authCode, _ = json.Marshal(cj)
+	}
+
+	var mfa []byte
+	if m := u.MFA(); m != nil {
+		mfa, _ = json.Marshal(UserMFAJSON{
+			Secret:        m.Secret(),
+			Enabled:       m.IsEnabled(),
+			RecoveryCodes: m.RecoveryCodes(),
+			LastUsedStep:  m.LastUsedStep(),
+			Failures:      m.Failures(),
+			LockedUntil:   m.LockedUntil(),
+		})
+	}
+
+	var passkeys []byte
+	if ps := u.Passkeys(); len(ps) > 0 {
+		pj := make([]UserPasskeyJSON, 0, len(ps))
+		for _, p := range ps {
+			pj = append(pj, UserPasskeyJSON{
+				ID:              p.ID,
+				PublicKey:       p.PublicKey,
+				AttestationType: p.AttestationType,
+				Transports:      p.Transports,
+				AAGUID:          p.AAGUID,
+				SignCount:       p.SignCount,
+				BackupEligible:  p.BackupEligible,
+				BackupState:     p.BackupState,
+				Nickname:        p.Nickname,
+				CreatedAt:       p.CreatedAt,
+				LastUsedAt:      p.LastUsedAt,
+			})
+		}
+		passkeys, _ = json.Marshal(pj)
+	}
+
+	var passkeyChallenge []byte
+	if c := u.PasskeyChallenge(); c != nil {
+		passkeyChallenge, _ = json.Marshal(UserPasskeyChallengeJSON{Challenge: c.Challenge, CreatedAt: c.CreatedAt})
+	}
+
 	var llat *time.Time
 	if t := u.LatestLogoutAt(); !t.IsZero() {
 		tt := t
@@ -93,20 +240,30 @@ func NewUserRow(u *user.User) *UserRow {
 	}
 
 	return &UserRow{
-		ID:             u.ID().String(),
-		Name:           u.Name(),
-		Alias:          u.Alias(),
-		Email:          u.Email(),
-		Workspace:      u.Workspace().String(),
-		Password:       u.Password(),
-		Subs:           subs,
-		LatestLogoutAt: llat,
-		Metadata:       meta,
-		Verification:   verification,
-		PasswordReset:  pwReset,
-		UpdatedAt:      updatedAt,
-		DeletedAt:      u.DeletedAt(),
-		CreatedAt:      u.CreatedAt(),
+		ID:                  u.ID().String(),
+		Name:                u.Name(),
+		Alias:               u.Alias(),
+		Email:               u.Email(),
+		Workspace:           u.Workspace().String(),
+		Password:            u.Password(),
+		Subs:                subs,
+		LatestLogoutAt:      llat,
+		Metadata:            meta,
+		Verification:        verification,
+		PasswordReset:       pwReset,
+		MagicLink:           magicLink,
+		AuthCode:            authCode,
+		AuthLinks:           authLinks,
+		RefreshTokens:       refreshTokens,
+		MFA:                 mfa,
+		Passkeys:            passkeys,
+		PasskeyChallenge:    passkeyChallenge,
+		UpdatedAt:           updatedAt,
+		DeletedAt:           u.DeletedAt(),
+		CreatedAt:           u.CreatedAt(),
+		MergedInto:          u.MergedInto().StringRef(),
+		DeletionRequestedAt: u.DeletionRequestedAt(),
+		PurgeNotifiedAt:     u.PurgeNotifiedAt(),
 	}
 }
 
@@ -147,6 +304,93 @@ func (r *UserRow) Model() (*user.User, error) {
 		pwReset = &user.PasswordReset{Token: pj.Token, CreatedAt: pj.CreatedAt}
 	}
 
+	var magicLink *user.MagicLink
+	if len(r.MagicLink) > 0 {
+		var mj UserMagicLinkJSON
+		if err := json.Unmarshal(r.MagicLink, &mj); err != nil {
+			return nil, err
+		}
+		magicLink = &user.MagicLink{TokenHash: mj.TokenHash, CreatedAt: mj.CreatedAt, RequestedAt: mj.RequestedAt}
+	}
+
+	var authLinks []user.AuthLink
+	if len(r.AuthLinks) > 0 {
+		var lj []UserAuthLinkJSON
+		if err := json.Unmarshal(r.AuthLinks, &lj); err != nil {
+			return nil, err
+		}
+		for _, l := range lj {
+			authLinks = append(authLinks, user.AuthLink{Sub: l.Sub, Issuer: l.Issuer, Email: l.Email, LinkedAt: l.LinkedAt})
+		}
+	}
+
+	var refreshTokens []user.RefreshTokenFamily
+	if len(r.RefreshTokens) > 0 {
+		var fj []UserRefreshTokenFamilyJSON
+		if err := json.Unmarshal(r.RefreshTokens, &fj); err != nil {
+			return nil, err
+		}
+		for _, f := range fj {
+			refreshTokens = append(refreshTokens, user.RefreshTokenFamily{ID: f.ID, Generation: f.Generation, ExpiresAt: f.ExpiresAt, MaxExpiresAt: f.MaxExpiresAt})
+		}
+	}
+
+	var authCodes []user.AuthCode
+	if len(r.AuthCode) > 0 {
+		var cj []UserAuthCodeJSON
+		if err := json.Unmarshal(r.AuthCode, &cj); err != nil {
+			return nil, err
+		}
+		for _, c := range cj {
+			authCodes = append(authCodes, user.AuthCode{
+				CodeHash: c.CodeHash, ClientID: c.ClientID, RedirectURI: c.RedirectURI, Scopes: c.Scopes,
+				Nonce: c.Nonce, CodeChallenge: c.CodeChallenge, AuthTime: c.AuthTime, CreatedAt: c.CreatedAt,
+				ExpiresAt: c.ExpiresAt,
+			})
+		}
+	}
+
+	var mfa *user.MFA
+	if len(r.MFA) > 0 {
+		var mj UserMFAJSON
+		if err := json.Unmarshal(r.MFA, &mj); err != nil {
+			return nil, err
+		}
+		mfa = user.MFAFrom(mj.Secret, mj.Enabled, mj.RecoveryCodes, mj.LastUsedStep, mj.Failures, mj.LockedUntil)
+	}
+
+	var passkeys []user.Passkey
+	if len(r.Passkeys) > 0 {
+		var pj []UserPasskeyJSON
+		if err := json.Unmarshal(r.Passkeys, &pj); err != nil {
+			return nil, err
+		}
+		for _, p := range pj {
+			passkeys = append(passkeys, user.Passkey{
+				ID:              p.ID,
+				PublicKey:       p.PublicKey,
+				AttestationType: p.AttestationType,
+				Transports:      p.Transports,
+				AAGUID:          p.AAGUID,
+				SignCount:       p.SignCount,
+				BackupEligible:  p.BackupEligible,
+				BackupState:     p.BackupState,
+				Nickname:        p.Nickname,
+				CreatedAt:       p.CreatedAt,
+				LastUsedAt:      p.LastUsedAt,
+			})
+		}
+	}
+
+	var passkeyChallenge *user.PasskeyChallenge
+	if len(r.PasskeyChallenge) > 0 {
+		var cj UserPasskeyChallengeJSON
+		if err := json.Unmarshal(r.PasskeyChallenge, &cj); err != nil {
+			return nil, err
+		}
+		passkeyChallenge = &user.PasskeyChallenge{Challenge: cj.Challenge, CreatedAt: cj.CreatedAt}
+	}
+
 	var mj UserMetadataJSON
 	if len(r.Metadata) > 0 {
 		if err := json.Unmarshal(r.Metadata, &mj); err != nil {
@@ -165,6 +409,15 @@ func (r *UserRow) Model() (*user.User, error) {
 		llat = *r.LatestLogoutAt
 	}
 
+	var mergedInto *id.UserID
+	if r.MergedInto != nil {
+		mid, err := id.UserIDFrom(*r.MergedInto)
+		if err != nil {
+			return nil, err
+		}
+		mergedInto = &mid
+	}
+
 	return user.New().
 		ID(uid).
 		Name(r.Name).
@@ -173,12 +426,22 @@ func (r *UserRow) Model() (*user.User, error) {
 		Metadata(metadata).
 		Alias(r.Alias).
 		Auths(auths).
+		AuthLinks(authLinks).
 		Workspace(tid).
 		Verification(v).
 		EncodedPassword(r.Password).
 		PasswordReset(pwReset).
+		MagicLink(magicLink).
+		AuthCodes(authCodes).
+		RefreshTokenFamilies(refreshTokens).
+		MFA(mfa).
+		Passkeys(passkeys).
+		PasskeyChallenge(passkeyChallenge).
 		UpdatedAt(r.UpdatedAt).
 		DeletedAt(r.DeletedAt).
 		CreatedAt(r.CreatedAt).
+		MergedInto(mergedInto).
+		DeletionRequestedAt(r.DeletionRequestedAt).
+		PurgeNotifiedAt(r.PurgeNotifiedAt).
 		Build()
 }
@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/pgdoc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/sqlc/gen"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type RoleMapping struct {
+	c *Client
+}
+
+func NewRoleMapping(c *Client) rolemapping.Repo { return &RoleMapping{c: c} }
+
+func roleMappingModel(m gen.RoleMapping) (*rolemapping.RoleMapping, error) {
+	return pgdoc.RoleMappingRow{
+		ID:        m.ID,
+		Workspace: m.Workspace,
+		Issuer:    m.Issuer,
+		SubPrefix: m.SubPrefix,
+		Rules:     m.Rules,
+		UpdatedAt: m.UpdatedAt,
+	}.Model()
+}
+
+func roleMappingModels(rows []gen.RoleMapping) (rolemapping.List, error) {
+	out := make(rolemapping.List, 0, len(rows))
+	for _, row := range rows {
+		m, err := roleMappingModel(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (r *RoleMapping) FindAll(ctx context.Context) (rolemapping.List, error) {
+	rows, err := r.c.queries(ctx).RoleMappingFindAll(ctx)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return roleMappingModels(rows)
+}
+
+func (r *RoleMapping) FindByWorkspace(ctx context.Context, ws workspace.ID) (*rolemapping.RoleMapping, error) {
+	row, err := r.c.queries(ctx).RoleMappingFindByWorkspace(ctx, ws.String())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, rerror.ErrNotFound
+	}
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return roleMappingModel(row)
+}
+
+func (r *RoleMapping) FindByIssuer(ctx context.Context, issuer string) (rolemapping.List, error) {
+	rows, err := r.c.queries(ctx).RoleMappingFindByIssuer(ctx, issuer)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return roleMappingModels(rows)
+}
+
+// Save replaces the mapping of the same workspace, as a workspace has at most
+// one mapping.
+func (r *RoleMapping) Save(ctx context.Context, m *rolemapping.RoleMapping) error {
+	row := pgdoc.NewRoleMappingRow(m)
+	if err := r.c.queries(ctx).RoleMappingUpsert(ctx, gen.RoleMappingUpsertParams{
+		ID:        row.ID,
+		Workspace: row.Workspace,
+		Issuer:    row.Issuer,
+		SubPrefix: row.SubPrefix,
+		Rules:     row.Rules,
+		UpdatedAt: row.UpdatedAt,
+	}); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
+
+func (r *RoleMapping) RemoveByWorkspace(ctx context.Context, ws workspace.ID) error {
+	if err := r.c.queries(ctx).RoleMappingDeleteByWorkspace(ctx, ws.String()); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
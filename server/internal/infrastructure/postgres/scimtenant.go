@@ -0,0 +1,93 @@
+package postgres
+
+import (
+	"context"
+	"errors"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/pgdoc"
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/sqlc/gen"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearthx/rerror"
+)
+
+type SCIMTenant struct {
+	c *Client
+}
+
+func NewSCIMTenant(c *Client) scimtenant.Repo { return &SCIMTenant{c: c} }
+
+func scimTenantModel(t gen.ScimTenant) (*scimtenant.Tenant, error) {
+	return pgdoc.SCIMTenantRow{
+		ID:            t.ID,
+		Name:          t.Name,
+		SubPrefix:     t.SubPrefix,
+		TokenHash:     t.TokenHash,
+		Users:         t.Users,
+		Groups:        t.Groups,
+		UpdatedAt:     t.UpdatedAt,
+		Deprovisioned: t.Deprovisioned,
+	}.Model()
+}
+
+func (r *SCIMTenant) FindAll(ctx context.Context) (scimtenant.List, error) {
+	rows, err := r.c.queries(ctx).SCIMTenantFindAll(ctx)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	out := make(scimtenant.List, 0, len(rows))
+	for _, row := range rows {
+		m, err := scimTenantModel(row)
+		if err != nil {
+			return nil, err
+		}
+		out = append(out, m)
+	}
+	return out, nil
+}
+
+func (r *SCIMTenant) FindByID(ctx context.Context, tid scimtenant.ID) (*scimtenant.Tenant, error) {
+	row, err := r.c.queries(ctx).SCIMTenantFindByID(ctx, tid.String())
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, rerror.ErrNotFound
+	}
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return scimTenantModel(row)
+}
+
+func (r *SCIMTenant) FindByTokenHash(ctx context.Context, hash string) (*scimtenant.Tenant, error) {
+	row, err := r.c.queries(ctx).SCIMTenantFindByTokenHash(ctx, hash)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, rerror.ErrNotFound
+	}
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return scimTenantModel(row)
+}
+
+func (r *SCIMTenant) Save(ctx context.Context, t *scimtenant.Tenant) error {
+	row := pgdoc.NewSCIMTenantRow(t)
+	if err := r.c.queries(ctx).SCIMTenantUpsert(ctx, gen.SCIMTenantUpsertParams{
+		ID:            row.ID,
+		Name:          row.Name,
+		SubPrefix:     row.SubPrefix,
+		TokenHash:     row.TokenHash,
+		Users:         row.Users,
+		Groups:        row.Groups,
+		UpdatedAt:     row.UpdatedAt,
+		Deprovisioned: row.Deprovisioned,
+	}); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
+
+func (r *SCIMTenant) Remove(ctx context.Context, tid scimtenant.ID) error {
+	if err := r.c.queries(ctx).SCIMTenantDelete(ctx, tid.String()); err != nil {
+		return rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return nil
+}
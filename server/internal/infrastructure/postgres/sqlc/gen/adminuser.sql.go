@@ -11,7 +11,7 @@ import (
 )
 
 const adminUserFindByEmail = `-- name: AdminUserFindByEmail :one
-SELECT id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at FROM admin_users WHERE lower(email) = lower($1) LIMIT 1
+SELECT id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at, approved_by_rule FROM admin_users WHERE lower(email) = lower($1) LIMIT 1
 `
 
 func (q *Queries) AdminUserFindByEmail(ctx context.Context, lower string) (AdminUser, error) {
@@ -28,12 +28,13 @@ func (q *Queries) AdminUserFindByEmail(ctx context.Context, lower string) (Admin
 		&i.ApprovedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ApprovedByRule,
 	)
 	return i, err
 }
 
 const adminUserFindByID = `-- name: AdminUserFindByID :one
-SELECT id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at FROM admin_users WHERE id = $1
+SELECT id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at, approved_by_rule FROM admin_users WHERE id = $1
 `
 
 func (q *Queries) AdminUserFindByID(ctx context.Context, id string) (AdminUser, error) {
@@ -50,12 +51,13 @@ func (q *Queries) AdminUserFindByID(ctx context.Context, id string) (AdminUser,
 		&i.ApprovedAt,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.ApprovedByRule,
 	)
 	return i, err
 }
 
 const adminUserFindByIDs = `-- name: AdminUserFindByIDs :many
-SELECT id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at FROM admin_users WHERE id = ANY($1::text[]) ORDER BY id
+SELECT id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at, approved_by_rule FROM admin_users WHERE id = ANY($1::text[]) ORDER BY id
 `
 
 func (q *Queries) AdminUserFindByIDs(ctx context.Context, dollar_1 []string) ([]AdminUser, error) {
@@ -78,6 +80,7 @@ func (q *Queries) AdminUserFindByIDs(ctx context.Context, dollar_1 []string) ([]
 			&i.ApprovedAt,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.ApprovedByRule,
 		); err != nil {
 			return nil, err
 		}
@@ -90,8 +93,8 @@ func (q *Queries) AdminUserFindByIDs(ctx context.Context, dollar_1 []string) ([]
 }
 
 const adminUserUpsert = `-- name: AdminUserUpsert :exec
-INSERT INTO admin_users (id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at)
-VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+INSERT INTO admin_users (id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at, approved_by_rule)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
 ON CONFLICT (id) DO UPDATE SET
     email=EXCLUDED.email,
     name=EXCLUDED.name,
@@ -100,20 +103,22 @@ ON CONFLICT (id) DO UPDATE SET
     status=EXCLUDED.status,
     approved_by=EXCLUDED.approved_by,
     approved_at=EXCLUDED.approved_at,
-    updated_at=EXCLUDED.updated_at
+    updated_at=EXCLUDED.updated_at,
+    approved_by_rule=EXCLUDED.approved_by_rule
 `
 
 type AdminUserUpsertParams struct {
-	ID         string
-	Email      string
-	Name       string
-	PictureUrl string
-	Role       string
-	Status     string
-	ApprovedBy string
-	ApprovedAt *time.Time
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID             string
+	Email          string
+	Name           string
+	PictureUrl     string
+	Role           string
+	Status         string
+	ApprovedBy     string
+	ApprovedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ApprovedByRule string
 }
 
 func (q *Queries) AdminUserUpsert(ctx context.Context, arg AdminUserUpsertParams) error {
@@ -128,6 +133,7 @@ func (q *Queries) AdminUserUpsert(ctx context.Context, arg AdminUserUpsertParams
 		arg.ApprovedAt,
 		arg.CreatedAt,
 		arg.UpdatedAt,
+		arg.ApprovedByRule,
 	)
 	return err
 }
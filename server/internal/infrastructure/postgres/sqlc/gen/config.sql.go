@@ -7,17 +7,20 @@ package gen
 
 import (
 	"context"
+	"time"
 )
 
 const configLoad = `-- name: ConfigLoad :one
-SELECT migration, auth_cert, auth_key, default_policy FROM config WHERE id = 1
+SELECT migration, auth_cert, auth_key, auth_keys, default_policy, purge_since FROM config WHERE id = 1
 `
 
 type ConfigLoadRow struct {
 	Migration     int64
 	AuthCert      string
 	AuthKey       string
+	AuthKeys      []byte
 	DefaultPolicy *string
+	PurgeSince    *time.Time
 }
 
 func (q *Queries) ConfigLoad(ctx context.Context) (ConfigLoadRow, error) {
@@ -27,23 +30,27 @@ func (q *Queries) ConfigLoad(ctx context.Context) (ConfigLoadRow, error) {
 		&i.Migration,
 		&i.AuthCert,
 		&i.AuthKey,
+		&i.AuthKeys,
 		&i.DefaultPolicy,
+		&i.PurgeSince,
 	)
 	return i, err
 }
 
 const configUpsert = `-- name: ConfigUpsert :exec
-INSERT INTO config (id, migration, auth_cert, auth_key, default_policy)
-VALUES (1, $1, $2, $3, $4)
+INSERT INTO config (id, migration, auth_cert, auth_key, auth_keys, default_policy, purge_since)
+VALUES (1, $1, $2, $3, $4, $5, $6)
 ON CONFLICT (id) DO UPDATE SET
-  migration=EXCLUDED.migration, auth_cert=EXCLUDED.auth_cert, auth_key=EXCLUDED.auth_key, default_policy=EXCLUDED.default_policy
+  migration=EXCLUDED.migration, auth_cert=EXCLUDED.auth_cert, auth_key=EXCLUDED.auth_key, auth_keys=EXCLUDED.auth_keys, default_policy=EXCLUDED.default_policy, purge_since=EXCLUDED.purge_since
 `
 
 type ConfigUpsertParams struct {
 	Migration     int64
 	AuthCert      string
 	AuthKey       string
+	AuthKeys      []byte
 	DefaultPolicy *string
+	PurgeSince    *time.Time
 }
 
 func (q *Queries) ConfigUpsert(ctx context.Context, arg ConfigUpsertParams) error {
@@ -51,7 +58,9 @@ func (q *Queries) ConfigUpsert(ctx context.Context, arg ConfigUpsertParams) erro
 		arg.Migration,
 		arg.AuthCert,
 		arg.AuthKey,
+		arg.AuthKeys,
 		arg.DefaultPolicy,
+		arg.PurgeSince,
 	)
 	return err
 }
@@ -8,17 +8,61 @@ import (
 	"time"
 )
 
+type AdminApprovalRule struct {
+	ID        string
+	Kind      string
+	Value     string
+	Provider  string
+	Role      string
+	CreatedBy string
+	UpdatedAt time.Time
+}
+
+type AdminAuditRecord struct {
+	ID        string
+	Seq       int64
+	Actor     string
+	Action    string
+	Target    string
+	RequestID string
+	Ip        string
+	Status    int32
+	Diff      []byte
+	CreatedAt time.Time
+	PrevHash  string
+	Hash      string
+}
+
+type AdminSession struct {
+	ID        string
+	AdminUser string
+	UserAgent string
+	Ip        string
+	CreatedAt time.Time
+	ExpiresAt time.Time
+}
+
 type AdminUser struct {
-	ID         string
-	Email      string
-	Name       string
-	PictureUrl string
-	Role       string
-	Status     string
-	ApprovedBy string
-	ApprovedAt *time.Time
-	CreatedAt  time.Time
-	UpdatedAt  time.Time
+	ID             string
+	Email          string
+	Name           string
+	PictureUrl     string
+	Role           string
+	Status         string
+	ApprovedBy     string
+	ApprovedAt     *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	ApprovedByRule string
+}
+
+type AuditLog struct {
+	ID        string
+	Actor     string
+	Action    string
+	Target    string
+	Detail    []byte
+	CreatedAt time.Time
 }
 
 type Config struct {
@@ -26,7 +70,25 @@ type Config struct {
 	Migration     int64
 	AuthCert      string
 	AuthKey       string
+	AuthKeys      []byte
 	DefaultPolicy *string
+	PurgeSince    *time.Time
+}
+
+type LdapSyncRun struct {
+	ID         string
+	Status     string
+	Err        string
+	StartedAt  time.Time
+	FinishedAt *time.Time
+	Summary    []byte
+}
+
+type PasskeyLogin struct {
+	ID        string
+	UserID    string
+	Challenge string
+	ExpiresAt time.Time
 }
 
 type Permittable struct {
@@ -47,24 +109,54 @@ type Role struct {
 	Name string
 }
 
+type RoleMapping struct {
+	ID        string
+	Workspace string
+	Issuer    string
+	SubPrefix string
+	Rules     []byte
+	UpdatedAt time.Time
+}
+
+type ScimTenant struct {
+	ID            string
+	Name          string
+	SubPrefix     string
+	TokenHash     string
+	Users         []string
+	Groups        []byte
+	UpdatedAt     time.Time
+	Deprovisioned []string
+}
+
 type User struct {
-	ID             string
-	Name           string
-	Alias          string
-	Email          string
-	Workspace      string
-	Password       []byte
-	Subs           []string
-	LatestLogoutAt *time.Time
-	Metadata       []byte
-	Verification   []byte
-	PasswordReset  []byte
-	Team           *string
-	Lang           *string
-	Theme          *string
-	UpdatedAt      time.Time
-	DeletedAt      *time.Time
-	CreatedAt      *time.Time
+	ID                  string
+	Name                string
+	Alias               string
+	Email               string
+	Workspace           string
+	Password            []byte
+	Subs                []string
+	LatestLogoutAt      *time.Time
+	Metadata            []byte
+	Verification        []byte
+	PasswordReset       []byte
+	Team                *string
+	Lang                *string
+	Theme               *string
+	UpdatedAt           time.Time
+	DeletedAt           *time.Time
+	CreatedAt           *time.Time
+	Mfa                 []byte
+	Passkeys            []byte
+	PasskeyChallenge    []byte
+	MagicLink           []byte
+	AuthCode            []byte
+	AuthLinks           []byte
+	MergedInto          *string
+	DeletionRequestedAt *time.Time
+	PurgeNotifiedAt     *time.Time
+	RefreshTokens       []byte
 }
 
 type Workspace struct {
@@ -10,6 +10,15 @@ import (
 	"time"
 )
 
+const permittableDeleteByUserID = `-- name: PermittableDeleteByUserID :exec
+DELETE FROM permittables WHERE user_id = $1
+`
+
+func (q *Queries) PermittableDeleteByUserID(ctx context.Context, userID string) error {
+	_, err := q.db.Exec(ctx, permittableDeleteByUserID, userID)
+	return err
+}
+
 const permittableFindByRoleID = `-- name: PermittableFindByRoleID :many
 SELECT id, user_id, role_ids, updated_at FROM permittables WHERE role_ids @> ARRAY[$1::text] ORDER BY id
 `
@@ -6,16 +6,43 @@ package gen
 
 import (
 	"context"
+	"time"
 )
 
 type Querier interface {
+	AdminApprovalRuleDelete(ctx context.Context, id string) error
+	AdminApprovalRuleFindAll(ctx context.Context) ([]AdminApprovalRule, error)
+	AdminApprovalRuleFindByID(ctx context.Context, id string) (AdminApprovalRule, error)
+	AdminApprovalRuleUpsert(ctx context.Context, arg AdminApprovalRuleUpsertParams) error
+	AdminAuditRecordAppend(ctx context.Context, arg AdminAuditRecordAppendParams) error
+	AdminAuditRecordFindAfter(ctx context.Context, arg AdminAuditRecordFindAfterParams) ([]AdminAuditRecord, error)
+	AdminAuditRecordFindLast(ctx context.Context) (AdminAuditRecord, error)
+	AdminSessionDelete(ctx context.Context, id string) error
+	AdminSessionDeleteByAdminUser(ctx context.Context, adminUser string) error
+	AdminSessionDeleteExpired(ctx context.Context, expiresAt time.Time) error
+	AdminSessionFindByAdminUser(ctx context.Context, adminUser string) ([]AdminSession, error)
+	AdminSessionFindByID(ctx context.Context, id string) (AdminSession, error)
+	AdminSessionUpsert(ctx context.Context, arg AdminSessionUpsertParams) error
+	AdminStatsDeactivationsPerDay(ctx context.Context, arg AdminStatsDeactivationsPerDayParams) ([]AdminStatsDeactivationsPerDayRow, error)
+	AdminStatsMembersByRole(ctx context.Context) ([]AdminStatsMembersByRoleRow, error)
+	AdminStatsSignupsPerDay(ctx context.Context, arg AdminStatsSignupsPerDayParams) ([]AdminStatsSignupsPerDayRow, error)
+	AdminStatsUsers(ctx context.Context) (AdminStatsUsersRow, error)
+	AdminStatsUsersByAuthProvider(ctx context.Context) ([]AdminStatsUsersByAuthProviderRow, error)
+	AdminStatsWorkspaces(ctx context.Context) (AdminStatsWorkspacesRow, error)
 	AdminUserFindByEmail(ctx context.Context, lower string) (AdminUser, error)
 	AdminUserFindByID(ctx context.Context, id string) (AdminUser, error)
 	AdminUserFindByIDs(ctx context.Context, dollar_1 []string) ([]AdminUser, error)
 	AdminUserUpsert(ctx context.Context, arg AdminUserUpsertParams) error
+	AuditLogFindByTarget(ctx context.Context, target string) ([]AuditLog, error)
+	AuditLogInsert(ctx context.Context, arg AuditLogInsertParams) error
 	ConfigLoad(ctx context.Context) (ConfigLoadRow, error)
 	ConfigUpsert(ctx context.Context, arg ConfigUpsertParams) error
 	ConfigUpsertAuth(ctx context.Context, arg ConfigUpsertAuthParams) error
+	LdapSyncRunFindByID(ctx context.Context, id string) (LdapSyncRun, error)
+	LdapSyncRunFindLastSucceeded(ctx context.Context) (LdapSyncRun, error)
+	LdapSyncRunFindRecent(ctx context.Context, limit int32) ([]LdapSyncRun, error)
+	LdapSyncRunUpsert(ctx context.Context, arg LdapSyncRunUpsertParams) error
+	PermittableDeleteByUserID(ctx context.Context, userID string) error
 	PermittableFindByRoleID(ctx context.Context, dollar_1 string) ([]Permittable, error)
 	PermittableFindByUserID(ctx context.Context, userID string) (Permittable, error)
 	PermittableFindByUserIDs(ctx context.Context, dollar_1 []string) ([]Permittable, error)
@@ -28,7 +55,17 @@ type Querier interface {
 	RoleFindByID(ctx context.Context, id string) (Role, error)
 	RoleFindByIDs(ctx context.Context, dollar_1 []string) ([]Role, error)
 	RoleFindByName(ctx context.Context, name string) (Role, error)
+	RoleMappingDeleteByWorkspace(ctx context.Context, workspace string) error
+	RoleMappingFindAll(ctx context.Context) ([]RoleMapping, error)
+	RoleMappingFindByIssuer(ctx context.Context, issuer string) ([]RoleMapping, error)
+	RoleMappingFindByWorkspace(ctx context.Context, workspace string) (RoleMapping, error)
+	RoleMappingUpsert(ctx context.Context, arg RoleMappingUpsertParams) error
 	RoleUpsert(ctx context.Context, arg RoleUpsertParams) error
+	SCIMTenantDelete(ctx context.Context, id string) error
+	SCIMTenantFindAll(ctx context.Context) ([]ScimTenant, error)
+	SCIMTenantFindByID(ctx context.Context, id string) (ScimTenant, error)
+	SCIMTenantFindByTokenHash(ctx context.Context, tokenHash string) (ScimTenant, error)
+	SCIMTenantUpsert(ctx context.Context, arg SCIMTenantUpsertParams) error
 	UserDelete(ctx context.Context, id string) error
 	UserFindAll(ctx context.Context) ([]User, error)
 	// Case-insensitive, matching the partial unique index on lower(alias).
@@ -43,6 +80,7 @@ type Querier interface {
 	UserFindByPasswordResetRequest(ctx context.Context, dollar_1 string) (User, error)
 	UserFindBySub(ctx context.Context, dollar_1 string) (User, error)
 	UserFindByVerification(ctx context.Context, dollar_1 string) (User, error)
+	UserFindDeletedBefore(ctx context.Context, before time.Time) ([]User, error)
 	UserInsert(ctx context.Context, arg UserInsertParams) error
 	// created_at is intentionally excluded from the ON CONFLICT SET clause: it is
 	// set once on the first insert and must never be overwritten afterward.
@@ -61,6 +99,7 @@ type Querier interface {
 	WorkspaceIDsByIntegration(ctx context.Context, integrationID string) ([]string, error)
 	WorkspaceIDsByIntegrations(ctx context.Context, dollar_1 []string) ([]string, error)
 	WorkspaceIDsByUser(ctx context.Context, userID string) ([]string, error)
+	WorkspaceIDsDeletedBefore(ctx context.Context, before time.Time) ([]string, error)
 	WorkspaceIntegrationInsert(ctx context.Context, arg WorkspaceIntegrationInsertParams) error
 	WorkspaceIntegrationsByWorkspaceIDs(ctx context.Context, dollar_1 []string) ([]WorkspaceIntegration, error)
 	WorkspaceIntegrationsDeleteByWorkspace(ctx context.Context, workspaceID string) error
@@ -20,7 +20,7 @@ func (q *Queries) UserDelete(ctx context.Context, id string) error {
 }
 
 const userFindAll = `-- name: UserFindAll :many
-SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at FROM users ORDER BY id
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users ORDER BY id
 `
 
 func (q *Queries) UserFindAll(ctx context.Context) ([]User, error) {
@@ -50,6 +50,16 @@ func (q *Queries) UserFindAll(ctx context.Context) ([]User, error) {
 			&i.UpdatedAt,
 			&i.DeletedAt,
 			&i.CreatedAt,
+			&i.Mfa,
+			&i.Passkeys,
+			&i.PasskeyChallenge,
+			&i.MagicLink,
+			&i.AuthCode,
+			&i.AuthLinks,
+			&i.MergedInto,
+			&i.DeletionRequestedAt,
+			&i.PurgeNotifiedAt,
+			&i.RefreshTokens,
 		); err != nil {
 			return nil, err
 		}
@@ -62,7 +72,7 @@ func (q *Queries) UserFindAll(ctx context.Context) ([]User, error) {
 }
 
 const userFindByAlias = `-- name: UserFindByAlias :one
-SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at FROM users WHERE lower(alias) = lower($1) AND alias <> ''
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users WHERE lower(alias) = lower($1) AND alias <> ''
 `
 
 // Case-insensitive, matching the partial unique index on lower(alias).
@@ -87,12 +97,22 @@ func (q *Queries) UserFindByAlias(ctx context.Context, lower string) (User, erro
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.Mfa,
+		&i.Passkeys,
+		&i.PasskeyChallenge,
+		&i.MagicLink,
+		&i.AuthCode,
+		&i.AuthLinks,
+		&i.MergedInto,
+		&i.DeletionRequestedAt,
+		&i.PurgeNotifiedAt,
+		&i.RefreshTokens,
 	)
 	return i, err
 }
 
 const userFindByEmail = `-- name: UserFindByEmail :one
-SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at FROM users WHERE lower(email) = lower($1)
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users WHERE lower(email) = lower($1)
 `
 
 // Case-insensitive, matching the case-insensitive unique index on lower(email).
@@ -117,12 +137,22 @@ func (q *Queries) UserFindByEmail(ctx context.Context, lower string) (User, erro
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.Mfa,
+		&i.Passkeys,
+		&i.PasskeyChallenge,
+		&i.MagicLink,
+		&i.AuthCode,
+		&i.AuthLinks,
+		&i.MergedInto,
+		&i.DeletionRequestedAt,
+		&i.PurgeNotifiedAt,
+		&i.RefreshTokens,
 	)
 	return i, err
 }
 
 const userFindByID = `-- name: UserFindByID :one
-SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at FROM users WHERE id = $1
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users WHERE id = $1
 `
 
 func (q *Queries) UserFindByID(ctx context.Context, id string) (User, error) {
@@ -146,12 +176,22 @@ func (q *Queries) UserFindByID(ctx context.Context, id string) (User, error) {
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.Mfa,
+		&i.Passkeys,
+		&i.PasskeyChallenge,
+		&i.MagicLink,
+		&i.AuthCode,
+		&i.AuthLinks,
+		&i.MergedInto,
+		&i.DeletionRequestedAt,
+		&i.PurgeNotifiedAt,
+		&i.RefreshTokens,
 	)
 	return i, err
 }
 
 const userFindByIDs = `-- name: UserFindByIDs :many
-SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at FROM users WHERE id = ANY($1::text[])
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users WHERE id = ANY($1::text[])
 `
 
 func (q *Queries) UserFindByIDs(ctx context.Context, dollar_1 []string) ([]User, error) {
@@ -181,6 +221,16 @@ func (q *Queries) UserFindByIDs(ctx context.Context, dollar_1 []string) ([]User,
 			&i.UpdatedAt,
 			&i.DeletedAt,
 			&i.CreatedAt,
+			&i.Mfa,
+			&i.Passkeys,
+			&i.PasskeyChallenge,
+			&i.MagicLink,
+			&i.AuthCode,
+			&i.AuthLinks,
+			&i.MergedInto,
+			&i.DeletionRequestedAt,
+			&i.PurgeNotifiedAt,
+			&i.RefreshTokens,
 		); err != nil {
 			return nil, err
 		}
@@ -193,7 +243,7 @@ func (q *Queries) UserFindByIDs(ctx context.Context, dollar_1 []string) ([]User,
 }
 
 const userFindByName = `-- name: UserFindByName :one
-SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at FROM users WHERE name = $1
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users WHERE name = $1
 `
 
 func (q *Queries) UserFindByName(ctx context.Context, name string) (User, error) {
@@ -217,12 +267,22 @@ func (q *Queries) UserFindByName(ctx context.Context, name string) (User, error)
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.Mfa,
+		&i.Passkeys,
+		&i.PasskeyChallenge,
+		&i.MagicLink,
+		&i.AuthCode,
+		&i.AuthLinks,
+		&i.MergedInto,
+		&i.DeletionRequestedAt,
+		&i.PurgeNotifiedAt,
+		&i.RefreshTokens,
 	)
 	return i, err
 }
 
 const userFindByNameOrEmail = `-- name: UserFindByNameOrEmail :one
-SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at FROM users WHERE name = $1 OR lower(email) = lower($1) LIMIT 1
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users WHERE name = $1 OR lower(email) = lower($1) LIMIT 1
 `
 
 // Exact name OR case-insensitive email (email is case-insensitively unique).
@@ -247,12 +307,22 @@ func (q *Queries) UserFindByNameOrEmail(ctx context.Context, name string) (User,
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.Mfa,
+		&i.Passkeys,
+		&i.PasskeyChallenge,
+		&i.MagicLink,
+		&i.AuthCode,
+		&i.AuthLinks,
+		&i.MergedInto,
+		&i.DeletionRequestedAt,
+		&i.PurgeNotifiedAt,
+		&i.RefreshTokens,
 	)
 	return i, err
 }
 
 const userFindByPasswordResetRequest = `-- name: UserFindByPasswordResetRequest :one
-SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at FROM users WHERE password_reset ->> 'token' = $1::text LIMIT 1
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users WHERE password_reset ->> 'token' = $1::text LIMIT 1
 `
 
 func (q *Queries) UserFindByPasswordResetRequest(ctx context.Context, dollar_1 string) (User, error) {
@@ -276,12 +346,22 @@ func (q *Queries) UserFindByPasswordResetRequest(ctx context.Context, dollar_1 s
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.Mfa,
+		&i.Passkeys,
+		&i.PasskeyChallenge,
+		&i.MagicLink,
+		&i.AuthCode,
+		&i.AuthLinks,
+		&i.MergedInto,
+		&i.DeletionRequestedAt,
+		&i.PurgeNotifiedAt,
+		&i.RefreshTokens,
 	)
 	return i, err
 }
 
 const userFindBySub = `-- name: UserFindBySub :one
-SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at FROM users WHERE subs @> ARRAY[$1::text] LIMIT 1
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users WHERE subs @> ARRAY[$1::text] LIMIT 1
 `
 
 func (q *Queries) UserFindBySub(ctx context.Context, dollar_1 string) (User, error) {
@@ -305,12 +385,22 @@ func (q *Queries) UserFindBySub(ctx context.Context, dollar_1 string) (User, err
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.Mfa,
+		&i.Passkeys,
+		&i.PasskeyChallenge,
+		&i.MagicLink,
+		&i.AuthCode,
+		&i.AuthLinks,
+		&i.MergedInto,
+		&i.DeletionRequestedAt,
+		&i.PurgeNotifiedAt,
+		&i.RefreshTokens,
 	)
 	return i, err
 }
 
 const userFindByVerification = `-- name: UserFindByVerification :one
-SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at FROM users WHERE verification ->> 'code' = $1::text LIMIT 1
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users WHERE verification ->> 'code' = $1::text LIMIT 1
 `
 
 func (q *Queries) UserFindByVerification(ctx context.Context, dollar_1 string) (User, error) {
@@ -334,30 +424,102 @@ func (q *Queries) UserFindByVerification(ctx context.Context, dollar_1 string) (
 		&i.UpdatedAt,
 		&i.DeletedAt,
 		&i.CreatedAt,
+		&i.Mfa,
+		&i.Passkeys,
+		&i.PasskeyChallenge,
+		&i.MagicLink,
+		&i.AuthCode,
+		&i.AuthLinks,
+		&i.MergedInto,
+		&i.DeletionRequestedAt,
+		&i.PurgeNotifiedAt,
+		&i.RefreshTokens,
 	)
 	return i, err
 }
 
+const userFindDeletedBefore = `-- name: UserFindDeletedBefore :many
+SELECT id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens FROM users WHERE deleted_at < $1::timestamptz ORDER BY id
+`
+
+func (q *Queries) UserFindDeletedBefore(ctx context.Context, before time.Time) ([]User, error) {
+	rows, err := q.db.Query(ctx, userFindDeletedBefore, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []User
+	for rows.Next() {
+		var i User
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Alias,
+			&i.Email,
+			&i.Workspace,
+			&i.Password,
+			&i.Subs,
+			&i.LatestLogoutAt,
+			&i.Metadata,
+			&i.Verification,
+			&i.PasswordReset,
+			&i.Team,
+			&i.Lang,
+			&i.Theme,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.CreatedAt,
+			&i.Mfa,
+			&i.Passkeys,
+			&i.PasskeyChallenge,
+			&i.MagicLink,
+			&i.AuthCode,
+			&i.AuthLinks,
+			&i.MergedInto,
+			&i.DeletionRequestedAt,
+			&i.PurgeNotifiedAt,
+			&i.RefreshTokens,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const userInsert = `-- name: UserInsert :exec
-INSERT INTO users (id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, created_at, updated_at, deleted_at)
-VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)
+INSERT INTO users (id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, created_at, updated_at, deleted_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24)
 `
 
 type UserInsertParams struct {
-	ID             string
-	Name           string
-	Alias          string
-	Email          string
-	Workspace      string
-	Password       []byte
-	Subs           []string
-	LatestLogoutAt *time.Time
-	Metadata       []byte
-	Verification   []byte
-	PasswordReset  []byte
-	CreatedAt      *time.Time
-	UpdatedAt      time.Time
-	DeletedAt      *time.Time
+	ID                  string
+	Name                string
+	Alias               string
+	Email               string
+	Workspace           string
+	Password            []byte
+	Subs                []string
+	LatestLogoutAt      *time.Time
+	Metadata            []byte
+	Verification        []byte
+	PasswordReset       []byte
+	CreatedAt           *time.Time
+	UpdatedAt           time.Time
+	DeletedAt           *time.Time
+	Mfa                 []byte
+	Passkeys            []byte
+	PasskeyChallenge    []byte
+	MagicLink           []byte
+	AuthCode            []byte
+	AuthLinks           []byte
+	MergedInto          *string
+	DeletionRequestedAt *time.Time
+	PurgeNotifiedAt     *time.Time
+	RefreshTokens       []byte
 }
 
 func (q *Queries) UserInsert(ctx context.Context, arg UserInsertParams) error {
@@ -376,35 +538,59 @@ func (q *Queries) UserInsert(ctx context.Context, arg UserInsertParams) error {
 		arg.CreatedAt,
 		arg.UpdatedAt,
 		arg.DeletedAt,
+		arg.Mfa,
+		arg.Passkeys,
+		arg.PasskeyChallenge,
+		arg.MagicLink,
+		arg.AuthCode,
+		arg.AuthLinks,
+		arg.MergedInto,
+		arg.DeletionRequestedAt,
+		arg.PurgeNotifiedAt,
+		arg.RefreshTokens,
 	)
 	return err
 }
 
 const userUpsert = `-- name: UserUpsert :exec
-INSERT INTO users (id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, created_at, updated_at, deleted_at)
-VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)
+INSERT INTO users (id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, created_at, updated_at, deleted_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24)
 ON CONFLICT (id) DO UPDATE SET
   name=EXCLUDED.name, alias=EXCLUDED.alias, email=EXCLUDED.email, workspace=EXCLUDED.workspace,
   password=EXCLUDED.password, subs=EXCLUDED.subs, latest_logout_at=EXCLUDED.latest_logout_at,
   metadata=EXCLUDED.metadata, verification=EXCLUDED.verification, password_reset=EXCLUDED.password_reset,
-  updated_at=EXCLUDED.updated_at, deleted_at=EXCLUDED.deleted_at
+  updated_at=EXCLUDED.updated_at, deleted_at=EXCLUDED.deleted_at, mfa=EXCLUDED.mfa,
+  passkeys=EXCLUDED.passkeys, passkey_challenge=EXCLUDED.passkey_challenge,
+  magic_link=EXCLUDED.magic_link, auth_code=EXCLUDED.auth_code, auth_links=EXCLUDED.auth_links,
+  merged_into=EXCLUDED.merged_into, deletion_requested_at=EXCLUDED.deletion_requested_at,
+  purge_notified_at=EXCLUDED.purge_notified_at, refresh_tokens=EXCLUDED.refresh_tokens
 `
 
 type UserUpsertParams struct {
-	ID             string
-	Name           string
-	Alias          string
-	Email          string
-	Workspace      string
-	Password       []byte
-	Subs           []string
-	LatestLogoutAt *time.Time
-	Metadata       []byte
-	Verification   []byte
-	PasswordReset  []byte
-	CreatedAt      *time.Time
-	UpdatedAt      time.Time
-	DeletedAt      *time.Time
+	ID                  string
+	Name                string
+	Alias               string
+	Email               string
+	Workspace           string
+	Password            []byte
+	Subs                []string
+	LatestLogoutAt      *time.Time
+	Metadata            []byte
+	Verification        []byte
+	PasswordReset       []byte
+	CreatedAt           *time.Time
+	UpdatedAt           time.Time
+	DeletedAt           *time.Time
+	Mfa                 []byte
+	Passkeys            []byte
+	PasskeyChallenge    []byte
+	MagicLink           []byte
+	AuthCode            []byte
+	AuthLinks           []byte
+	MergedInto          *string
+	DeletionRequestedAt *time.Time
+	PurgeNotifiedAt     *time.Time
+	RefreshTokens       []byte
 }
 
 // created_at is intentionally excluded from the ON CONFLICT SET clause: it is
@@ -425,6 +611,16 @@ func (q *Queries) UserUpsert(ctx context.Context, arg UserUpsertParams) error {
 		arg.CreatedAt,
 		arg.UpdatedAt,
 		arg.DeletedAt,
+		arg.Mfa,
+		arg.Passkeys,
+		arg.PasskeyChallenge,
+		arg.MagicLink,
+		arg.AuthCode,
+		arg.AuthLinks,
+		arg.MergedInto,
+		arg.DeletionRequestedAt,
+		arg.PurgeNotifiedAt,
+		arg.RefreshTokens,
 	)
 	return err
 }
@@ -275,6 +275,30 @@ func (q *Queries) WorkspaceIDsByUser(ctx context.Context, userID string) ([]stri
 	return items, nil
 }
 
+const workspaceIDsDeletedBefore = `-- name: WorkspaceIDsDeletedBefore :many
+SELECT id FROM workspaces WHERE deleted_at < $1::timestamptz ORDER BY id
+`
+
+func (q *Queries) WorkspaceIDsDeletedBefore(ctx context.Context, before time.Time) ([]string, error) {
+	rows, err := q.db.Query(ctx, workspaceIDsDeletedBefore, before)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []string
+	for rows.Next() {
+		var id string
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const workspaceIntegrationInsert = `-- name: WorkspaceIntegrationInsert :exec
 INSERT INTO workspace_integrations (workspace_id, integration_id, role, invited_by, disabled) VALUES ($1,$2,$3,$4,$5)
 `
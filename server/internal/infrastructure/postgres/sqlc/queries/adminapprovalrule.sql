@@ -0,0 +1,15 @@
+-- name: AdminApprovalRuleUpsert :exec
+INSERT INTO admin_approval_rules (id, kind, value, provider, role, created_by, updated_at)
+VALUES ($1,$2,$3,$4,$5,$6,$7)
+ON CONFLICT (id) DO UPDATE SET
+  kind=EXCLUDED.kind, value=EXCLUDED.value, provider=EXCLUDED.provider,
+  role=EXCLUDED.role, created_by=EXCLUDED.created_by, updated_at=EXCLUDED.updated_at;
+
+-- name: AdminApprovalRuleFindAll :many
+SELECT * FROM admin_approval_rules ORDER BY id;
+
+-- name: AdminApprovalRuleFindByID :one
+SELECT * FROM admin_approval_rules WHERE id = $1;
+
+-- name: AdminApprovalRuleDelete :exec
+DELETE FROM admin_approval_rules WHERE id = $1;
@@ -0,0 +1,9 @@
+-- name: AdminAuditRecordAppend :exec
+INSERT INTO admin_audit_records (id, seq, actor, action, target, request_id, ip, status, diff, created_at, prev_hash, hash)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12);
+
+-- name: AdminAuditRecordFindLast :one
+SELECT * FROM admin_audit_records ORDER BY seq DESC LIMIT 1;
+
+-- name: AdminAuditRecordFindAfter :many
+SELECT * FROM admin_audit_records WHERE seq > $1 ORDER BY seq LIMIT $2;
@@ -0,0 +1,21 @@
+-- name: AdminSessionUpsert :exec
+INSERT INTO admin_sessions (id, admin_user, user_agent, ip, created_at, expires_at)
+VALUES ($1,$2,$3,$4,$5,$6)
+ON CONFLICT (id) DO UPDATE SET
+  admin_user=EXCLUDED.admin_user, user_agent=EXCLUDED.user_agent, ip=EXCLUDED.ip,
+  created_at=EXCLUDED.created_at, expires_at=EXCLUDED.expires_at;
+
+-- name: AdminSessionFindByID :one
+SELECT * FROM admin_sessions WHERE id = $1;
+
+-- name: AdminSessionFindByAdminUser :many
+SELECT * FROM admin_sessions WHERE admin_user = $1 ORDER BY created_at DESC, id DESC;
+
+-- name: AdminSessionDelete :exec
+DELETE FROM admin_sessions WHERE id = $1;
+
+-- name: AdminSessionDeleteByAdminUser :exec
+DELETE FROM admin_sessions WHERE admin_user = $1;
+
+-- name: AdminSessionDeleteExpired :exec
+DELETE FROM admin_sessions WHERE expires_at < $1;
@@ -0,0 +1,39 @@
+-- name: AdminStatsUsers :one
+SELECT
+  count(*) FILTER (WHERE deleted_at IS NULL) AS active,
+  count(*) FILTER (WHERE deleted_at IS NOT NULL) AS deactivated,
+  count(*) FILTER (WHERE deleted_at IS NULL AND NOT coalesce((verification->>'verified')::boolean, false)) AS unverified
+FROM users
+WHERE merged_into IS NULL;
+
+-- name: AdminStatsSignupsPerDay :many
+SELECT to_char(created_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day, count(*) AS count
+FROM users
+WHERE merged_into IS NULL AND created_at >= @since::timestamptz AND created_at < @until::timestamptz
+GROUP BY day;
+
+-- name: AdminStatsDeactivationsPerDay :many
+SELECT to_char(deleted_at AT TIME ZONE 'UTC', 'YYYY-MM-DD') AS day, count(*) AS count
+FROM users
+WHERE merged_into IS NULL AND deleted_at >= @since::timestamptz AND deleted_at < @until::timestamptz
+GROUP BY day;
+
+-- name: AdminStatsUsersByAuthProvider :many
+SELECT split_part(s.sub, '|', 1) AS provider, count(DISTINCT u.id) AS count
+FROM users u, unnest(u.subs) AS s(sub)
+WHERE u.merged_into IS NULL AND u.deleted_at IS NULL AND position('|' IN s.sub) > 1
+GROUP BY provider;
+
+-- name: AdminStatsWorkspaces :one
+SELECT
+  count(*) FILTER (WHERE deleted_at IS NULL AND personal) AS personal,
+  count(*) FILTER (WHERE deleted_at IS NULL AND NOT personal) AS team,
+  count(*) FILTER (WHERE deleted_at IS NOT NULL) AS deactivated
+FROM workspaces;
+
+-- name: AdminStatsMembersByRole :many
+SELECT m.role, count(*) AS count
+FROM workspace_members m
+JOIN workspaces w ON w.id = m.workspace_id
+WHERE w.deleted_at IS NULL AND NOT w.personal
+GROUP BY m.role;
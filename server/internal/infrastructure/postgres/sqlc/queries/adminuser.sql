@@ -1,6 +1,6 @@
 -- name: AdminUserUpsert :exec
-INSERT INTO admin_users (id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at)
-VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10)
+INSERT INTO admin_users (id, email, name, picture_url, role, status, approved_by, approved_at, created_at, updated_at, approved_by_rule)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11)
 ON CONFLICT (id) DO UPDATE SET
     email=EXCLUDED.email,
     name=EXCLUDED.name,
@@ -9,7 +9,8 @@ ON CONFLICT (id) DO UPDATE SET
     status=EXCLUDED.status,
     approved_by=EXCLUDED.approved_by,
     approved_at=EXCLUDED.approved_at,
-    updated_at=EXCLUDED.updated_at;
+    updated_at=EXCLUDED.updated_at,
+    approved_by_rule=EXCLUDED.approved_by_rule;
 
 -- name: AdminUserFindByID :one
 SELECT * FROM admin_users WHERE id = $1;
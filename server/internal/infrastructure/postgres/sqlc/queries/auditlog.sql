@@ -0,0 +1,6 @@
+-- name: AuditLogInsert :exec
+INSERT INTO audit_logs (id, actor, action, target, detail, created_at)
+VALUES ($1,$2,$3,$4,$5,$6);
+
+-- name: AuditLogFindByTarget :many
+SELECT * FROM audit_logs WHERE target = $1 ORDER BY created_at, id;
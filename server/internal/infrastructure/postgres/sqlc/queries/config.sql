@@ -1,11 +1,11 @@
 -- name: ConfigLoad :one
-SELECT migration, auth_cert, auth_key, default_policy FROM config WHERE id = 1;
+SELECT migration, auth_cert, auth_key, auth_keys, default_policy, purge_since FROM config WHERE id = 1;
 
 -- name: ConfigUpsert :exec
-INSERT INTO config (id, migration, auth_cert, auth_key, default_policy)
-VALUES (1, $1, $2, $3, $4)
+INSERT INTO config (id, migration, auth_cert, auth_key, auth_keys, default_policy, purge_since)
+VALUES (1, $1, $2, $3, $4, $5, $6)
 ON CONFLICT (id) DO UPDATE SET
-  migration=EXCLUDED.migration, auth_cert=EXCLUDED.auth_cert, auth_key=EXCLUDED.auth_key, default_policy=EXCLUDED.default_policy;
+  migration=EXCLUDED.migration, auth_cert=EXCLUDED.auth_cert, auth_key=EXCLUDED.auth_key, auth_keys=EXCLUDED.auth_keys, default_policy=EXCLUDED.default_policy, purge_since=EXCLUDED.purge_since;
 
 -- name: ConfigUpsertAuth :exec
 INSERT INTO config (id, auth_cert, auth_key)
@@ -0,0 +1,15 @@
+-- name: LdapSyncRunUpsert :exec
+INSERT INTO ldap_sync_runs (id, status, err, started_at, finished_at, summary)
+VALUES ($1,$2,$3,$4,$5,$6)
+ON CONFLICT (id) DO UPDATE SET
+  status=EXCLUDED.status, err=EXCLUDED.err, started_at=EXCLUDED.started_at,
+  finished_at=EXCLUDED.finished_at, summary=EXCLUDED.summary;
+
+-- name: LdapSyncRunFindByID :one
+SELECT * FROM ldap_sync_runs WHERE id = $1;
+
+-- name: LdapSyncRunFindRecent :many
+SELECT * FROM ldap_sync_runs ORDER BY started_at DESC, id DESC LIMIT $1;
+
+-- name: LdapSyncRunFindLastSucceeded :one
+SELECT * FROM ldap_sync_runs WHERE status = 'succeeded' ORDER BY started_at DESC, id DESC LIMIT 1;
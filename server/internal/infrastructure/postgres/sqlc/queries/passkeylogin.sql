@@ -0,0 +1,10 @@
+-- name: PasskeyLoginInsert :exec
+INSERT INTO passkey_logins (id, user_id, challenge, expires_at)
+VALUES ($1,$2,$3,$4);
+
+-- name: PasskeyLoginTake :one
+DELETE FROM passkey_logins WHERE id = $1
+RETURNING *;
+
+-- name: PasskeyLoginDeleteExpired :exec
+DELETE FROM passkey_logins WHERE expires_at < $1;
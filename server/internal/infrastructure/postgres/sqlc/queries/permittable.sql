@@ -21,3 +21,6 @@ INSERT INTO permittable_workspace_roles (permittable_id, workspace_id, role_id)
 
 -- name: PermittableWorkspaceRolesByPermittableIDs :many
 SELECT * FROM permittable_workspace_roles WHERE permittable_id = ANY($1::text[]);
+
+-- name: PermittableDeleteByUserID :exec
+DELETE FROM permittables WHERE user_id = $1;
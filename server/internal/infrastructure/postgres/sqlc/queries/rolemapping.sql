@@ -0,0 +1,18 @@
+-- name: RoleMappingUpsert :exec
+INSERT INTO role_mappings (id, workspace, issuer, sub_prefix, rules, updated_at)
+VALUES ($1,$2,$3,$4,$5,$6)
+ON CONFLICT (workspace) DO UPDATE SET
+  id=EXCLUDED.id, issuer=EXCLUDED.issuer, sub_prefix=EXCLUDED.sub_prefix,
+  rules=EXCLUDED.rules, updated_at=EXCLUDED.updated_at;
+
+-- name: RoleMappingFindByWorkspace :one
+SELECT * FROM role_mappings WHERE workspace = $1;
+
+-- name: RoleMappingFindByIssuer :many
+SELECT * FROM role_mappings WHERE issuer = $1 ORDER BY id;
+
+-- name: RoleMappingFindAll :many
+SELECT * FROM role_mappings ORDER BY id;
+
+-- name: RoleMappingDeleteByWorkspace :exec
+DELETE FROM role_mappings WHERE workspace = $1;
@@ -0,0 +1,19 @@
+-- name: SCIMTenantUpsert :exec
+INSERT INTO scim_tenants (id, name, sub_prefix, token_hash, users, groups, updated_at, deprovisioned)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8)
+ON CONFLICT (id) DO UPDATE SET
+  name=EXCLUDED.name, sub_prefix=EXCLUDED.sub_prefix, token_hash=EXCLUDED.token_hash,
+  users=EXCLUDED.users, groups=EXCLUDED.groups, updated_at=EXCLUDED.updated_at,
+  deprovisioned=EXCLUDED.deprovisioned;
+
+-- name: SCIMTenantFindByID :one
+SELECT * FROM scim_tenants WHERE id = $1;
+
+-- name: SCIMTenantFindByTokenHash :one
+SELECT * FROM scim_tenants WHERE token_hash = $1 AND token_hash <> '';
+
+-- name: SCIMTenantFindAll :many
+SELECT * FROM scim_tenants ORDER BY id;
+
+-- name: SCIMTenantDelete :exec
+DELETE FROM scim_tenants WHERE id = $1;
@@ -1,17 +1,21 @@
 -- name: UserInsert :exec
-INSERT INTO users (id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, created_at, updated_at, deleted_at)
-VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14);
+INSERT INTO users (id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, created_at, updated_at, deleted_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24);
 
 -- name: UserUpsert :exec
 -- created_at is intentionally excluded from the ON CONFLICT SET clause: it is
 -- set once on the first insert and must never be overwritten afterward.
-INSERT INTO users (id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, created_at, updated_at, deleted_at)
-VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14)
+INSERT INTO users (id, name, alias, email, workspace, password, subs, latest_logout_at, metadata, verification, password_reset, created_at, updated_at, deleted_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens)
+VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17,$18,$19,$20,$21,$22,$23,$24)
 ON CONFLICT (id) DO UPDATE SET
   name=EXCLUDED.name, alias=EXCLUDED.alias, email=EXCLUDED.email, workspace=EXCLUDED.workspace,
   password=EXCLUDED.password, subs=EXCLUDED.subs, latest_logout_at=EXCLUDED.latest_logout_at,
   metadata=EXCLUDED.metadata, verification=EXCLUDED.verification, password_reset=EXCLUDED.password_reset,
-  updated_at=EXCLUDED.updated_at, deleted_at=EXCLUDED.deleted_at;
+  updated_at=EXCLUDED.updated_at, deleted_at=EXCLUDED.deleted_at, mfa=EXCLUDED.mfa,
+  passkeys=EXCLUDED.passkeys, passkey_challenge=EXCLUDED.passkey_challenge,
+  magic_link=EXCLUDED.magic_link, auth_code=EXCLUDED.auth_code, auth_links=EXCLUDED.auth_links,
+  merged_into=EXCLUDED.merged_into, deletion_requested_at=EXCLUDED.deletion_requested_at,
+  purge_notified_at=EXCLUDED.purge_notified_at, refresh_tokens=EXCLUDED.refresh_tokens;
 
 -- name: UserFindByID :one
 SELECT * FROM users WHERE id = $1;
@@ -48,3 +52,6 @@ SELECT * FROM users WHERE password_reset ->> 'token' = $1::text LIMIT 1;
 
 -- name: UserDelete :exec
 DELETE FROM users WHERE id = $1;
+
+-- name: UserFindDeletedBefore :many
+SELECT * FROM users WHERE deleted_at < sqlc.arg(before)::timestamptz ORDER BY id;
@@ -61,3 +61,6 @@ SELECT DISTINCT workspace_id FROM workspace_integrations WHERE integration_id =
 
 -- name: WorkspaceIDsByIntegrations :many
 SELECT DISTINCT workspace_id FROM workspace_integrations WHERE integration_id = ANY($1::text[]);
+
+-- name: WorkspaceIDsDeletedBefore :many
+SELECT id FROM workspaces WHERE deleted_at < sqlc.arg(before)::timestamptz ORDER BY id;
@@ -19,7 +19,17 @@ CREATE TABLE users (
     theme            text,
     updated_at       timestamptz NOT NULL DEFAULT now(),
     deleted_at       timestamptz,
-    created_at       timestamptz
+    created_at       timestamptz,
+    mfa              jsonb,
+    passkeys         jsonb,
+    passkey_challenge jsonb,
+    magic_link       jsonb,
+    auth_code        jsonb,
+    auth_links       jsonb,
+    merged_into      text,
+    deletion_requested_at timestamptz,
+    purge_notified_at     timestamptz,
+    refresh_tokens        jsonb
 );
 
 CREATE TABLE workspaces (
@@ -65,7 +75,8 @@ CREATE TABLE admin_users (
     approved_by text NOT NULL DEFAULT '',
     approved_at timestamptz,
     created_at  timestamptz NOT NULL DEFAULT now(),
-    updated_at  timestamptz NOT NULL DEFAULT now()
+    updated_at  timestamptz NOT NULL DEFAULT now(),
+    approved_by_rule text NOT NULL DEFAULT ''
 );
 
 CREATE TABLE roles (
@@ -92,5 +103,86 @@ CREATE TABLE config (
     migration      bigint NOT NULL DEFAULT 0,
     auth_cert      text NOT NULL DEFAULT '',
     auth_key       text NOT NULL DEFAULT '',
-    default_policy text
+    auth_keys      jsonb NOT NULL DEFAULT '[]',
+    default_policy text,
+    purge_since    timestamptz
+);
+
+CREATE TABLE scim_tenants (
+    id         text PRIMARY KEY,
+    name       text NOT NULL,
+    sub_prefix text NOT NULL,
+    token_hash text NOT NULL DEFAULT '',
+    users      text[] NOT NULL DEFAULT '{}',
+    groups     jsonb NOT NULL DEFAULT '[]',
+    updated_at timestamptz NOT NULL DEFAULT now(),
+    deprovisioned text[] NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE audit_logs (
+    id         text PRIMARY KEY,
+    actor      text NOT NULL,
+    action     text NOT NULL,
+    target     text NOT NULL DEFAULT '',
+    detail     jsonb NOT NULL DEFAULT '{}',
+    created_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE role_mappings (
+    id         text PRIMARY KEY,
+    workspace  text NOT NULL,
+    issuer     text NOT NULL,
+    sub_prefix text NOT NULL DEFAULT '',
+    rules      jsonb NOT NULL DEFAULT '[]',
+    updated_at timestamptz NOT NULL DEFAULT now()
+);
+
+CREATE TABLE ldap_sync_runs (
+    id          text PRIMARY KEY,
+    status      text NOT NULL,
+    err         text NOT NULL DEFAULT '',
+    started_at  timestamptz NOT NULL,
+    finished_at timestamptz,
+    summary     jsonb NOT NULL DEFAULT '{}'
+);
+
+CREATE TABLE admin_audit_records (
+    id         text PRIMARY KEY,
+    seq        bigint NOT NULL UNIQUE,
+    actor      text NOT NULL,
+    action     text NOT NULL,
+    target     text NOT NULL DEFAULT '',
+    request_id text NOT NULL DEFAULT '',
+    ip         text NOT NULL DEFAULT '',
+    status     integer NOT NULL DEFAULT 0,
+    diff       jsonb NOT NULL DEFAULT '{}',
+    created_at timestamptz NOT NULL,
+    prev_hash  text NOT NULL DEFAULT '',
+    hash       text NOT NULL
+);
+
+CREATE TABLE admin_sessions (
+    id         text PRIMARY KEY,
+    admin_user text NOT NULL,
+    user_agent text NOT NULL DEFAULT '',
+    ip         text NOT NULL DEFAULT '',
+    created_at timestamptz NOT NULL,
+    expires_at timestamptz NOT NULL
+);
+
+CREATE TABLE admin_approval_rules (
+    id         text PRIMARY KEY,
+    kind       text NOT NULL,
+    value      text NOT NULL,
+    provider   text NOT NULL DEFAULT '',
+    role       text NOT NULL,
+    created_by text NOT NULL DEFAULT '',
+    updated_at timestamptz NOT NULL
+);
+
+CREATE TABLE passkey_logins (
+    id         text PRIMARY KEY,
+    user_id    text NOT NULL,
+    challenge  text NOT NULL,
+    expires_at timestamptz NOT NULL
 );
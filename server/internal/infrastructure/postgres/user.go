@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -27,7 +28,10 @@ func rowToUserRow(r gen.User) *pgdoc.UserRow {
 		Password: r.Password, Subs: r.Subs, LatestLogoutAt: r.LatestLogoutAt,
 		Metadata: r.Metadata, Verification: r.Verification, PasswordReset: r.PasswordReset,
 		Team: r.Team, Lang: r.Lang, Theme: r.Theme, UpdatedAt: r.UpdatedAt,
-		DeletedAt: r.DeletedAt, CreatedAt: r.CreatedAt,
+		DeletedAt: r.DeletedAt, CreatedAt: r.CreatedAt, MFA: r.Mfa,
+		Passkeys: r.Passkeys, PasskeyChallenge: r.PasskeyChallenge, MagicLink: r.MagicLink,
+		AuthCode: r.AuthCode, AuthLinks: r.AuthLinks, MergedInto: r.MergedInto,
+		DeletionRequestedAt: r.DeletionRequestedAt, PurgeNotifiedAt: r.PurgeNotifiedAt, RefreshTokens: r.RefreshTokens,
 	}
 }
 
@@ -56,7 +60,10 @@ func (r *User) insertParams(u *user.User) gen.UserUpsertParams {
 		ID: d.ID, Name: d.Name, Alias: d.Alias, Email: d.Email, Workspace: d.Workspace,
 		Password: d.Password, Subs: d.Subs, LatestLogoutAt: d.LatestLogoutAt,
 		Metadata: d.Metadata, Verification: d.Verification, PasswordReset: d.PasswordReset,
-		CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt, DeletedAt: d.DeletedAt,
+		CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt, DeletedAt: d.DeletedAt, Mfa: d.MFA,
+		Passkeys: d.Passkeys, PasskeyChallenge: d.PasskeyChallenge, MagicLink: d.MagicLink,
+		AuthCode: d.AuthCode, AuthLinks: d.AuthLinks, MergedInto: d.MergedInto,
+		DeletionRequestedAt: d.DeletionRequestedAt, PurgeNotifiedAt: d.PurgeNotifiedAt, RefreshTokens: d.RefreshTokens,
 	}
 }
 
@@ -66,7 +73,10 @@ func (r *User) Create(ctx context.Context, u *user.User) error {
 		ID: d.ID, Name: d.Name, Alias: d.Alias, Email: d.Email, Workspace: d.Workspace,
 		Password: d.Password, Subs: d.Subs, LatestLogoutAt: d.LatestLogoutAt,
 		Metadata: d.Metadata, Verification: d.Verification, PasswordReset: d.PasswordReset,
-		CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt, DeletedAt: d.DeletedAt,
+		CreatedAt: d.CreatedAt, UpdatedAt: d.UpdatedAt, DeletedAt: d.DeletedAt, Mfa: d.MFA,
+		Passkeys: d.Passkeys, PasskeyChallenge: d.PasskeyChallenge, MagicLink: d.MagicLink,
+		AuthCode: d.AuthCode, AuthLinks: d.AuthLinks, MergedInto: d.MergedInto,
+		DeletionRequestedAt: d.DeletionRequestedAt, PurgeNotifiedAt: d.PurgeNotifiedAt, RefreshTokens: d.RefreshTokens,
 	})
 	if isUniqueViolation(err) {
 		return user.ErrDuplicatedUser
@@ -141,6 +151,14 @@ func (r *User) FindAll(ctx context.Context) (user.List, error) {
 	return userModels(rows)
 }
 
+func (r *User) FindDeletedBefore(ctx context.Context, t time.Time) (user.List, error) {
+	rows, err := r.c.queries(ctx).UserFindDeletedBefore(ctx, t)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return userModels(rows)
+}
+
 func (r *User) FindAllWithPagination(ctx context.Context, keyword *string, status user.StatusFilter, p *usecasex.Pagination) (user.List, *usecasex.PageInfo, error) {
 	if p != nil && p.Cursor != nil {
 		return nil, nil, user.ErrCursorPaginationUnsupported
@@ -280,7 +298,7 @@ func likeContains(s string) string {
 
 // userColumns matches scanUsers/gen.User scan order; avoid SELECT * to keep scanning stable.
 const userColumns = "id, name, alias, email, workspace, password, subs, " +
-	"latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at"
+	"latest_logout_at, metadata, verification, password_reset, team, lang, theme, updated_at, deleted_at, created_at, mfa, passkeys, passkey_challenge, magic_link, auth_code, auth_links, merged_into, deletion_requested_at, purge_notified_at, refresh_tokens"
 
 func scanUsers(rows pgx.Rows) (user.List, error) {
 	defer rows.Close()
@@ -290,7 +308,9 @@ func scanUsers(rows pgx.Rows) (user.List, error) {
 		if err := rows.Scan(
 			&g.ID, &g.Name, &g.Alias, &g.Email, &g.Workspace, &g.Password, &g.Subs,
 			&g.LatestLogoutAt, &g.Metadata, &g.Verification, &g.PasswordReset,
-			&g.Team, &g.Lang, &g.Theme, &g.UpdatedAt, &g.DeletedAt, &g.CreatedAt,
+			&g.Team, &g.Lang, &g.Theme, &g.UpdatedAt, &g.DeletedAt, &g.CreatedAt, &g.Mfa,
+			&g.Passkeys, &g.PasskeyChallenge, &g.MagicLink, &g.AuthCode, &g.AuthLinks, &g.MergedInto,
+			&g.DeletionRequestedAt, &g.PurgeNotifiedAt, &g.RefreshTokens,
 		); err != nil {
 			return nil, err
 		}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"sort"
 	"strings"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/postgres/pgdoc"
@@ -202,6 +203,14 @@ func (r *Workspace) FindByIntegrations(ctx context.Context, iids id.IntegrationI
 	return r.findByIDStrings(ctx, wsIDs)
 }
 
+func (r *Workspace) FindDeletedBefore(ctx context.Context, t time.Time) (workspace.List, error) {
+	wsIDs, err := r.c.queries(ctx).WorkspaceIDsDeletedBefore(ctx, t)
+	if err != nil {
+		return nil, rerror.ErrInternalByWithContext(ctx, err)
+	}
+	return r.findByIDStrings(ctx, wsIDs)
+}
+
 func (r *Workspace) findByIDStrings(ctx context.Context, ids []string) (workspace.List, error) {
 	if len(ids) == 0 {
 		return workspace.List{}, nil
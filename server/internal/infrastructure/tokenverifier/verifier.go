@@ -0,0 +1,89 @@
+// Package tokenverifier implements gateway.TokenVerifier with the same JWT
+// validation the request authentication uses.
+package tokenverifier
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearthx/appx"
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var ErrInvalidToken = rerror.NewE(i18n.T("invalid token"))
+
+type validator interface {
+	ValidateToken(ctx context.Context, token string) (any, error)
+}
+
+type Verifier struct {
+	v validator
+}
+
+var _ gateway.TokenVerifier = (*Verifier)(nil)
+
+func New(providers []appx.JWTProvider) (*Verifier, error) {
+	v, err := appx.NewJWTMultipleValidator(providers)
+	if err != nil {
+		return nil, err
+	}
+	return &Verifier{v: v}, nil
+}
+
+type claims struct {
+	Iss      string `json:"iss"`
+	Sub      string `json:"sub"`
+	Iat      int64  `json:"iat"`
+	Name     string `json:"name"`
+	Nickname string `json:"nickname"`
+	Email    string `json:"email"`
+}
+
+// VerifyToken validates the token against every configured provider, then
+// reads its claims. The nickname takes precedence over the name as in the
+// request authentication.
+func (v *Verifier) VerifyToken(ctx context.Context, token string) (gateway.VerifiedToken, error) {
+	if _, err := v.v.ValidateToken(ctx, token); err != nil {
+		return gateway.VerifiedToken{}, ErrInvalidToken
+	}
+	c, err := decode(token)
+	if err != nil {
+		return gateway.VerifiedToken{}, err
+	}
+	name := c.Nickname
+	if name == "" {
+		name = c.Name
+	}
+	var iat time.Time
+	if c.Iat > 0 {
+		iat = time.Unix(c.Iat, 0)
+	}
+	return gateway.VerifiedToken{
+		Issuer:   c.Iss,
+		Sub:      c.Sub,
+		Name:     name,
+		Email:    c.Email,
+		IssuedAt: iat,
+	}, nil
+}
+
+func decode(token string) (claims, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return claims{}, ErrInvalidToken
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return claims{}, ErrInvalidToken
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil {
+		return claims{}, ErrInvalidToken
+	}
+	return c, nil
+}
@@ -0,0 +1,45 @@
+package tokenverifier
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeValidator struct {
+	err error
+}
+
+func (f fakeValidator) ValidateToken(_ context.Context, _ string) (any, error) {
+	return nil, f.err
+}
+
+func token(payload string) string {
+	return "e30." + base64.RawURLEncoding.EncodeToString([]byte(payload)) + ".sig"
+}
+
+func TestVerifier_VerifyToken(t *testing.T) {
+	ctx := context.Background()
+
+	v := &Verifier{v: fakeValidator{}}
+	got, err := v.VerifyToken(ctx, token(`{"iss":"https://idp.example.com","sub":"abc","iat":1700000000,"name":"Alice","email":"a@example.com"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "https://idp.example.com", got.Issuer)
+	assert.Equal(t, "abc", got.Sub)
+	assert.Equal(t, "Alice", got.Name)
+	assert.Equal(t, "a@example.com", got.Email)
+	assert.Equal(t, time.Unix(1700000000, 0), got.IssuedAt)
+
+	got, err = v.VerifyToken(ctx, token(`{"sub":"abc","name":"Alice","nickname":"ali"}`))
+	require.NoError(t, err)
+	assert.Equal(t, "ali", got.Name)
+	assert.True(t, got.IssuedAt.IsZero())
+
+	_, err = (&Verifier{v: fakeValidator{err: errors.New("expired")}}).VerifyToken(ctx, token(`{"sub":"abc"}`))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+}
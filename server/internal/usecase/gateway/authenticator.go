@@ -1,6 +1,9 @@
 package gateway
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type AuthenticatorUpdateUserParam struct {
 	ID       string
@@ -24,7 +27,43 @@ type Authenticator interface {
 	DisableMFA(ctx context.Context, sub string) error
 	EnableMFA(ctx context.Context, sub string) (enrollmentURL string, err error)
 	GetMFAStatus(ctx context.Context, sub string) (MFAStatus, error)
-	RegenerateMFARecoveryCode(ctx context.Context, sub string) (recoveryCode string, err error)
+	RegenerateMFARecoveryCode(ctx context.Context, sub string) (recoveryCodes []string, err error)
 	ResendVerificationEmail(ctx context.Context, userID string) error
 	UpdateUser(context.Context, AuthenticatorUpdateUserParam) (AuthenticatorUser, error)
 }
+
+// MFAConfirmer is implemented by authenticators whose MFA enrollment is
+// completed in-app by submitting a first code, rather than through an external
+// enrollment ticket. It returns the initial recovery codes.
+type MFAConfirmer interface {
+	ConfirmMFA(ctx context.Context, sub, code string) (recoveryCodes []string, err error)
+}
+
+// IdentityResolver is implemented by authenticators of generic OIDC providers,
+// whose tokens carry the user identity in configurable claims. It replaces the
+// userinfo lookup used for other issuers.
+type IdentityResolver interface {
+	// Issuer is the iss of the tokens the provider issues.
+	Issuer() string
+	// ResolveIdentity reads the identity from a token that has already been
+	// validated against the provider. The returned ID is the auth sub under
+	// which the user is stored.
+	ResolveIdentity(ctx context.Context, token string) (AuthenticatorUser, error)
+}
+
+// VerifiedToken is the identity asserted by a token whose signature, issuer,
+// audience and expiry have been checked.
+type VerifiedToken struct {
+	Issuer   string
+	Sub      string
+	Name     string
+	Email    string
+	IssuedAt time.Time
+}
+
+// TokenVerifier verifies tokens of any configured identity provider outside
+// of the request authentication, e.g. to prove a second identity when linking
+// it to the signed-in user.
+type TokenVerifier interface {
+	VerifyToken(ctx context.Context, token string) (VerifiedToken, error)
+}
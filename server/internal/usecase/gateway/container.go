@@ -1,6 +1,10 @@
 package gateway
 
-import "github.com/reearth/reearthx/mailer"
+import (
+	"strings"
+
+	"github.com/reearth/reearthx/mailer"
+)
 
 // Provider is a typed identifier for an external authentication provider used
 // to key per-provider authenticators on Container.
@@ -13,14 +17,24 @@ const (
 	// (Firebase) authenticator. CIP/Firebase subs are stored unprefixed, so
 	// an empty provider string on an auth record is treated as CIP.
 	ProviderCIP Provider = "cip"
+	// ProviderReearth routes MFA calls for the built-in password provider to the
+	// local authenticator. The accounts DB is its only store, so there is no
+	// external IdP to sync profile changes or verification emails to.
+	ProviderReearth Provider = "reearth"
 )
 
 type Container struct {
 	// Authenticators holds the external IdP authenticators keyed by auth provider.
 	// Management calls are routed by each auth record's provider.
 	Authenticators map[Provider]Authenticator
-	Mailer         mailer.Mailer
-	Storage        Storage
+	// Directory is nil when no LDAP directory is configured.
+	Directory Directory
+	Mailer    mailer.Mailer
+	// Passkey is nil when no WebAuthn relying party is configured.
+	Passkey Passkey
+	Storage Storage
+	// TokenVerifier is nil when no JWT provider is configured, e.g. with mock auth.
+	TokenVerifier TokenVerifier
 }
 
 // AuthenticatorFor returns the authenticator for an auth record's provider, or nil
@@ -36,3 +50,17 @@ func (c *Container) AuthenticatorFor(provider string) Authenticator {
 	}
 	return c.Authenticators[p]
 }
+
+// IdentityResolverFor returns the resolver of the generic OIDC provider that
+// issues tokens with iss, or nil if the issuer is not one.
+func (c *Container) IdentityResolverFor(iss string) IdentityResolver {
+	if c == nil || iss == "" {
+		return nil
+	}
+	for _, a := range c.Authenticators {
+		if r, ok := a.(IdentityResolver); ok && strings.TrimSuffix(r.Issuer(), "/") == strings.TrimSuffix(iss, "/") {
+			return r
+		}
+	}
+	return nil
+}
@@ -0,0 +1,29 @@
+package gateway
+
+import "context"
+
+// Directory reads the users of an external directory such as LDAP, to be
+// provisioned by the directory sync.
+type Directory interface {
+	// Issuer identifies the directory. Role mappings of this issuer map the
+	// groups of its users onto workspace membership.
+	Issuer() string
+	// Users returns every user entry matched by the directory's filter.
+	Users(ctx context.Context) ([]DirectoryUser, error)
+}
+
+type DirectoryUser struct {
+	// DN is the distinguished name of the entry.
+	DN string
+	// Sub is the auth sub the user is provisioned with, e.g. "ldap|alice".
+	Sub   string
+	Email string
+	Name  string
+	// Groups holds the common names of the user's groups and GroupDNs their
+	// distinguished names.
+	Groups   []string
+	GroupDNs []string
+	// Disabled is set for entries locked in the directory, which are
+	// deactivated like removed ones.
+	Disabled bool
+}
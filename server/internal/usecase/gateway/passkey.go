@@ -0,0 +1,28 @@
+package gateway
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+)
+
+// Passkey runs the WebAuthn relying-party ceremonies. It is stateless: the
+// challenge returned by a Begin call is stored by the caller and passed back
+// to the matching Finish call.
+type Passkey interface {
+	// BeginRegistration returns the PublicKeyCredentialCreationOptions to pass
+	// to navigator.credentials.create, excluding the user's existing passkeys.
+	BeginRegistration(ctx context.Context, u *user.User) (options json.RawMessage, challenge string, err error)
+	// FinishRegistration verifies the attestation response and returns the new
+	// credential. The nickname and timestamps are left for the caller to set.
+	FinishRegistration(ctx context.Context, u *user.User, challenge string, response []byte) (*user.Passkey, error)
+	// BeginLogin returns the PublicKeyCredentialRequestOptions to pass to
+	// navigator.credentials.get, allowing only the user's passkeys and
+	// requiring user verification.
+	BeginLogin(ctx context.Context, u *user.User) (options json.RawMessage, challenge string, err error)
+	// FinishLogin verifies the assertion response, which must be user
+	// verified, and returns the used credential with its updated sign count
+	// and backup state.
+	FinishLogin(ctx context.Context, u *user.User, challenge string, response []byte) (*user.Passkey, error)
+}
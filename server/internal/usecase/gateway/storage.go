@@ -3,6 +3,7 @@ package gateway
 
 import (
 	"context"
+	"strings"
 
 	"github.com/reearth/reearthx/asset/domain/file"
 )
@@ -18,3 +19,16 @@ type Storage interface {
 	Upload(ctx context.Context, name string, data *file.File) error
 	GetSignedURL(ctx context.Context, name string) (string, error)
 }
+
+// SignedPhotoURL resolves a stored photo to a URL that can be rendered. Uploaded
+// photos are stored as storage keys and are signed; absolute http(s) URLs set
+// directly on a profile are returned as is.
+func SignedPhotoURL(ctx context.Context, s Storage, photoURL string) (string, error) {
+	if photoURL == "" || s == nil {
+		return photoURL, nil
+	}
+	if l := strings.ToLower(photoURL); strings.HasPrefix(l, "http://") || strings.HasPrefix(l, "https://") {
+		return photoURL, nil
+	}
+	return s.GetSignedURL(ctx, photoURL)
+}
@@ -27,8 +27,9 @@ func NewContainer(
 		Cerbos:      cerbos,
 		Permittable: NewPermittable(r),
 		User:        NewUser(r, acg, cerbos, config.SignupSecret, config.AuthSrvUIDomain, config.AllowedISS...),
-		Workspace:   NewWorkspace(r, enforcer, cerbos),
+		Workspace:   NewWorkspace(r, enforcer, cerbos, acg.Storage),
 		Role:        r.Role,
+		SCIM:        NewSCIM(r),
 	}
 }
 
@@ -0,0 +1,319 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+// ldapSyncLockName serializes runs across the instances of the server.
+const ldapSyncLockName = "ldap-sync"
+
+// LDAPSync provisions the users of an LDAP directory with the semantics of
+// SyncSSOUser, so that a synced user is the same one who signs in through the
+// identity provider backed by the directory. Groups are mapped onto workspace
+// membership by the role mappings of the directory's issuer, with the claims
+// "groups" (common names) and "memberOf" (DNs).
+//
+// A run that would deactivate more than maxRemovals users, or more than
+// maxRemovalRatio of them, fails without deactivating anyone, so that a
+// misconfigured filter or a directory returning part of its users doesn't lock
+// everyone out. A limit of 0 is not enforced.
+type LDAPSync struct {
+	repos           *repo.Container
+	directory       gateway.Directory
+	user            *User
+	maxRemovals     int
+	maxRemovalRatio float64
+}
+
+func NewLDAPSync(r *repo.Container, d gateway.Directory, maxRemovals int, maxRemovalRatio float64) interfaces.LDAPSync {
+	return &LDAPSync{
+		repos:           r,
+		directory:       d,
+		user:            &User{repos: r},
+		maxRemovals:     maxRemovals,
+		maxRemovalRatio: maxRemovalRatio,
+	}
+}
+
+func (i *LDAPSync) Sync(ctx context.Context) (*ldapsync.Run, error) {
+	if i.directory == nil {
+		return nil, interfaces.ErrLDAPSyncNotConfigured
+	}
+	if err := i.repos.Lock.Lock(ctx, ldapSyncLockName); err != nil {
+		if errors.Is(err, repo.ErrFailedToLock) || errors.Is(err, repo.ErrAlreadyLocked) {
+			return nil, interfaces.ErrLDAPSyncRunning
+		}
+		return nil, err
+	}
+	defer func() {
+		if err := i.repos.Lock.Unlock(ctx, ldapSyncLockName); err != nil {
+			log.Warnfc(ctx, "[ldapSync] failed to unlock: %v", err)
+		}
+	}()
+
+	run := ldapsync.NewRun(util.Now())
+	err := i.sync(ctx, run)
+	if err != nil {
+		run.Fail(util.Now(), err)
+	} else {
+		run.Succeed(util.Now())
+	}
+	if serr := i.repos.LDAPSync.Save(ctx, run); serr != nil {
+		return nil, serr
+	}
+
+	log.Infofc(ctx, "[ldapSync] run %s %s: created=%d updated=%d reactivated=%d deactivated=%d memberships=%d skipped=%d",
+		run.ID(), run.Status(), len(run.Created()), len(run.Updated()), len(run.Reactivated()),
+		len(run.Deactivated()), len(run.Memberships()), len(run.Skipped()))
+	return run, err
+}
+
+func (i *LDAPSync) sync(ctx context.Context, run *ldapsync.Run) error {
+	entries, err := i.directory.Users(ctx)
+	if err != nil {
+		return err
+	}
+	// an empty directory is far more likely a broken search than one whose
+	// users have all left
+	if len(entries) == 0 {
+		return interfaces.ErrLDAPSyncNoUsers
+	}
+	iss := i.directory.Issuer()
+
+	var disabled []string
+	for _, e := range entries {
+		if e.Sub == "" {
+			run.AddSkipped(ldapsync.Skip{DN: e.DN, Reason: "missing sub attribute"})
+			continue
+		}
+		if e.Disabled {
+			disabled = append(disabled, e.Sub)
+			continue
+		}
+
+		reason := ""
+		if strings.TrimSpace(e.Email) == "" {
+			reason = "missing email"
+		} else {
+			err := Run0(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) error {
+				return i.syncUser(ctx, run, iss, e)
+			})
+			switch {
+			case errors.Is(err, interfaces.ErrUserAlreadyExists):
+				reason = "email belongs to another user"
+			case errors.Is(err, user.ErrInvalidEmail):
+				reason = "invalid email"
+			case err != nil:
+				return err
+			}
+		}
+		if reason == "" {
+			continue
+		}
+
+		run.AddSkipped(ldapsync.Skip{DN: e.DN, Reason: reason})
+		// A skipped entry is still in the directory, so its user is kept.
+		u, err := i.repos.User.FindBySub(ctx, e.Sub)
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return err
+		}
+		if u != nil {
+			run.AddUser(u.ID())
+		}
+	}
+
+	return i.deactivate(ctx, run, iss, disabled)
+}
+
+// syncUser creates or updates the user of an entry and applies the role
+// mappings of the directory to it. It must be called in a transaction.
+func (i *LDAPSync) syncUser(ctx context.Context, run *ldapsync.Run, iss string, e gateway.DirectoryUser) error {
+	email := strings.TrimSpace(e.Email)
+	existing, err := i.repos.User.FindBySub(ctx, e.Sub)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return err
+	}
+
+	u, err := i.user.findOrCreateSSOUser(ctx, interfaces.SyncSSOUserParam{
+		Email: email,
+		Name:  e.Name,
+		Sub:   e.Sub,
+	})
+	if err != nil {
+		return err
+	}
+
+	if existing == nil {
+		run.AddCreated(ldapsync.UserChange{User: u.ID(), Sub: e.Sub, Email: u.Email()})
+	} else {
+		updated := false
+		if e.Name != "" && e.Name != u.Name() {
+			u.UpdateName(e.Name)
+			updated = true
+		}
+		if !strings.EqualFold(email, u.Email()) {
+			other, err := i.repos.User.FindByEmail(ctx, email)
+			if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+				return err
+			}
+			if other != nil && other.ID() != u.ID() {
+				return interfaces.ErrUserAlreadyExists
+			}
+			if err := u.UpdateEmail(email); err != nil {
+				return err
+			}
+			updated = true
+		}
+		reactivated := u.IsDeleted()
+		if reactivated {
+			u.Reactivate()
+		}
+
+		if updated || reactivated {
+			if err := i.repos.User.Save(ctx, u); err != nil {
+				return err
+			}
+		}
+		c := ldapsync.UserChange{User: u.ID(), Sub: e.Sub, Email: u.Email()}
+		if reactivated {
+			run.AddReactivated(c)
+		} else if updated {
+			run.AddUpdated(c)
+		}
+	}
+	run.AddUser(u.ID())
+
+	mappings, err := i.user.roleMappings(ctx, iss, e.Sub)
+	if err != nil {
+		return err
+	}
+	changes, err := i.user.applyRoleMappings(ctx, u, mappings, map[string]any{
+		"groups":   stringsToAny(e.Groups),
+		"memberOf": stringsToAny(e.GroupDNs),
+	})
+	if err != nil {
+		return err
+	}
+	run.AddMemberships(changes...)
+	return nil
+}
+
+// deactivate deactivates the users of the last succeeded run that are gone
+// from the directory and the users of disabled entries, and removes them from
+// the workspaces mapped from the directory's groups.
+func (i *LDAPSync) deactivate(ctx context.Context, run *ldapsync.Run, iss string, disabled []string) error {
+	var uids user.IDList
+	last, err := i.repos.LDAPSync.FindLastSucceeded(ctx)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return err
+	}
+	if last != nil {
+		present := run.Users()
+		for _, uid := range last.Users() {
+			if !present.Has(uid) {
+				uids = append(uids, uid)
+			}
+		}
+	}
+	subs := map[user.ID]string{}
+	for _, sub := range disabled {
+		u, err := i.repos.User.FindBySub(ctx, sub)
+		if err != nil {
+			if errors.Is(err, rerror.ErrNotFound) {
+				continue
+			}
+			return err
+		}
+		subs[u.ID()] = sub
+		if !uids.Has(u.ID()) {
+			uids = append(uids, u.ID())
+		}
+	}
+	if len(uids) == 0 {
+		return nil
+	}
+	if err := i.checkRemovals(ctx, run, uids); err != nil {
+		return err
+	}
+
+	// Removed users are no longer in any group, so every mapping of the
+	// directory removes them, whatever its sub prefix.
+	mappings, err := i.repos.RoleMapping.FindByIssuer(ctx, iss)
+	if err != nil {
+		return err
+	}
+
+	for _, uid := range uids {
+		err := Run0(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) error {
+			u, err := i.repos.User.FindByID(ctx, uid)
+			if err != nil {
+				if errors.Is(err, rerror.ErrNotFound) {
+					return nil
+				}
+				return err
+			}
+			if !u.IsDeleted() {
+				u.Deactivate()
+				if err := i.repos.User.Save(ctx, u); err != nil {
+					return err
+				}
+				run.AddDeactivated(ldapsync.UserChange{User: uid, Sub: subs[uid], Email: u.Email()})
+			}
+			changes, err := i.user.applyRoleMappings(ctx, u, mappings, nil)
+			if err != nil {
+				return err
+			}
+			run.AddMemberships(changes...)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRemovals returns ErrLDAPSyncTooManyRemovals when deactivating the
+// users exceeds the limits. The users already deactivated are not counted.
+func (i *LDAPSync) checkRemovals(ctx context.Context, run *ldapsync.Run, uids user.IDList) error {
+	if i.maxRemovals <= 0 && i.maxRemovalRatio <= 0 {
+		return nil
+	}
+	users, err := i.repos.User.FindByIDs(ctx, uids)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return err
+	}
+	removals := 0
+	for _, u := range users {
+		if u != nil && !u.IsDeleted() {
+			removals++
+		}
+	}
+
+	total := len(run.Users()) + removals
+	if (i.maxRemovals > 0 && removals > i.maxRemovals) ||
+		(i.maxRemovalRatio > 0 && float64(removals) > i.maxRemovalRatio*float64(total)) {
+		return fmt.Errorf("%w: %d of %d users", interfaces.ErrLDAPSyncTooManyRemovals, removals, total)
+	}
+	return nil
+}
+
+func stringsToAny(l []string) []any {
+	res := make([]any, 0, len(l))
+	for _, s := range l {
+		res = append(res, s)
+	}
+	return res
+}
@@ -0,0 +1,175 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testLDAPIssuer = "ldap://ldap.example.org"
+
+type fakeDirectory struct {
+	users []gateway.DirectoryUser
+	err   error
+}
+
+func (d *fakeDirectory) Issuer() string { return testLDAPIssuer }
+
+func (d *fakeDirectory) Users(context.Context) ([]gateway.DirectoryUser, error) {
+	return d.users, d.err
+}
+
+func TestLDAPSync_Sync(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	for _, name := range []string{interfaces.RoleSelf, role.RoleOwner.String(), role.RoleWriter.String(), role.RoleReader.String()} {
+		require.NoError(t, r.Role.Save(ctx, *role.New().NewID().Name(name).MustBuild()))
+	}
+	ws := workspace.New().NewID().Name("gis").Members(map[workspace.UserID]workspace.Member{
+		user.NewID(): {Role: role.RoleOwner},
+	}).MustBuild()
+	require.NoError(t, r.Workspace.Save(ctx, ws))
+	require.NoError(t, r.RoleMapping.Save(ctx, rolemapping.New().NewID().Workspace(ws.ID()).
+		Issuer(testLDAPIssuer).SubPrefix("ldap").
+		Rules([]rolemapping.Rule{{Claim: "groups", Value: "gis-editors", Role: role.RoleWriter}}).MustBuild()))
+
+	taken := user.New().NewID().Workspace(workspace.NewID()).Name("carol").Email("carol@example.org").MustBuild()
+	require.NoError(t, r.User.Save(ctx, taken))
+
+	alice := gateway.DirectoryUser{
+		DN: "uid=alice,dc=example,dc=org", Sub: "ldap|alice", Email: "alice@example.org", Name: "Alice",
+		Groups: []string{"gis-editors"},
+	}
+	bob := gateway.DirectoryUser{DN: "uid=bob,dc=example,dc=org", Sub: "ldap|bob", Email: "bob@example.org", Name: "Bob"}
+	dir := &fakeDirectory{users: []gateway.DirectoryUser{
+		alice,
+		bob,
+		{DN: "uid=carol,dc=example,dc=org", Sub: "ldap|carol", Email: "carol@example.org"},
+		{DN: "uid=dave,dc=example,dc=org", Sub: "ldap|dave"},
+		{DN: "cn=printer,dc=example,dc=org"},
+	}}
+	uc := NewLDAPSync(r, dir, 100, 0.5)
+
+	// The first run creates the users and maps their groups.
+	run, err := uc.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ldapsync.StatusSucceeded, run.Status())
+	require.Len(t, run.Created(), 2)
+	assert.Equal(t, "ldap|alice", run.Created()[0].Sub)
+	assert.Equal(t, []ldapsync.Skip{
+		{DN: "uid=carol,dc=example,dc=org", Reason: "email belongs to another user"},
+		{DN: "uid=dave,dc=example,dc=org", Reason: "missing email"},
+		{DN: "cn=printer,dc=example,dc=org", Reason: "missing sub attribute"},
+	}, run.Skipped())
+
+	au, err := r.User.FindBySub(ctx, "ldap|alice")
+	require.NoError(t, err)
+	bu, err := r.User.FindBySub(ctx, "ldap|bob")
+	require.NoError(t, err)
+	assert.Equal(t, []ldapsync.MembershipChange{{Workspace: ws.ID(), User: au.ID(), To: role.RoleWriter}}, run.Memberships())
+	got, err := r.Workspace.FindByID(ctx, ws.ID())
+	require.NoError(t, err)
+	assert.Equal(t, role.RoleWriter, got.Members().UserRole(au.ID()))
+
+	// An unchanged directory changes nothing.
+	run, err = uc.Sync(ctx)
+	require.NoError(t, err)
+	assert.False(t, run.HasChanges())
+
+	// Alice leaves the group and is renamed, and Bob is removed.
+	alice.Groups = nil
+	alice.Name = "Alice Liddell"
+	dir.users = []gateway.DirectoryUser{alice}
+	run, err = uc.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ldapsync.UserChange{{User: au.ID(), Sub: "ldap|alice", Email: "alice@example.org"}}, run.Updated())
+	assert.Equal(t, []ldapsync.UserChange{{User: bu.ID(), Email: "bob@example.org"}}, run.Deactivated())
+	assert.Equal(t, []ldapsync.MembershipChange{{Workspace: ws.ID(), User: au.ID(), From: role.RoleWriter}}, run.Memberships())
+	bu, err = r.User.FindByID(ctx, bu.ID())
+	require.NoError(t, err)
+	assert.True(t, bu.IsDeleted())
+
+	// Bob comes back, and Alice is disabled in the directory.
+	alice.Disabled = true
+	dir.users = []gateway.DirectoryUser{alice, bob}
+	run, err = uc.Sync(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []ldapsync.UserChange{{User: bu.ID(), Sub: "ldap|bob", Email: "bob@example.org"}}, run.Reactivated())
+	assert.Equal(t, []ldapsync.UserChange{{User: au.ID(), Sub: "ldap|alice", Email: "alice@example.org"}}, run.Deactivated())
+
+	// A failed run is recorded and does not deactivate anyone.
+	dir.err = errors.New("connection refused")
+	run, err = uc.Sync(ctx)
+	assert.EqualError(t, err, "connection refused")
+	assert.Equal(t, ldapsync.StatusFailed, run.Status())
+	assert.Equal(t, "connection refused", run.Err())
+	runs, err := r.LDAPSync.FindRecent(ctx, 10)
+	require.NoError(t, err)
+	assert.Len(t, runs, 5)
+	last, err := r.LDAPSync.FindLastSucceeded(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, runs[1].ID(), last.ID())
+}
+
+func TestLDAPSync_NotConfigured(t *testing.T) {
+	_, err := NewLDAPSync(memory.New(), nil, 0, 0).Sync(context.Background())
+	assert.ErrorIs(t, err, interfaces.ErrLDAPSyncNotConfigured)
+}
+
+func TestLDAPSync_Limits(t *testing.T) {
+	ctx := context.Background()
+	var users []gateway.DirectoryUser
+	for _, name := range []string{"alice", "bob", "carol", "dave"} {
+		users = append(users, gateway.DirectoryUser{
+			DN: "uid=" + name + ",dc=example,dc=org", Sub: "ldap|" + name, Email: name + "@example.org",
+		})
+	}
+
+	for name, tc := range map[string]struct {
+		maxRemovals     int
+		maxRemovalRatio float64
+		users           []gateway.DirectoryUser
+		want            error
+	}{
+		"no users":          {users: nil, want: interfaces.ErrLDAPSyncNoUsers},
+		"count":             {maxRemovals: 2, users: users[:1], want: interfaces.ErrLDAPSyncTooManyRemovals},
+		"ratio":             {maxRemovalRatio: 0.5, users: users[:1], want: interfaces.ErrLDAPSyncTooManyRemovals},
+		"within the limits": {maxRemovals: 2, maxRemovalRatio: 0.5, users: users[:2]},
+	} {
+		t.Run(name, func(t *testing.T) {
+			r := memory.New()
+			require.NoError(t, r.Role.Save(ctx, *role.New().NewID().Name(interfaces.RoleSelf).MustBuild()))
+			require.NoError(t, r.Role.Save(ctx, *role.New().NewID().Name(role.RoleOwner.String()).MustBuild()))
+			dir := &fakeDirectory{users: users}
+			uc := NewLDAPSync(r, dir, tc.maxRemovals, tc.maxRemovalRatio)
+			_, err := uc.Sync(ctx)
+			require.NoError(t, err)
+
+			dir.users = tc.users
+			run, err := uc.Sync(ctx)
+			if tc.want == nil {
+				require.NoError(t, err)
+				assert.Len(t, run.Deactivated(), 2)
+				return
+			}
+			assert.ErrorIs(t, err, tc.want)
+			assert.Equal(t, ldapsync.StatusFailed, run.Status())
+			for _, u := range users {
+				got, err := r.User.FindBySub(ctx, u.Sub)
+				require.NoError(t, err)
+				assert.False(t, got.IsDeleted())
+			}
+		})
+	}
+}
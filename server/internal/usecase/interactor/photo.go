@@ -0,0 +1,144 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"path"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/photo"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/asset/domain/file"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+)
+
+// UploadMyPhoto stores the thumbnails of the photo under the directory of the
+// signed-in user and sets the largest one as the photo of the user and their
+// personal workspace, like UpdateMe does with photoURL. The previous photos
+// are deleted once the new one is saved.
+func (i *User) UploadMyPhoto(ctx context.Context, f *file.File, operator *workspace.Operator) (*user.User, error) {
+	if operator.User == nil {
+		return nil, interfaces.ErrInvalidOperator
+	}
+	p, err := decodePhoto(f)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := path.Join(gateway.GcsUserBasePath, operator.User.String())
+	var name string
+	var previous []string
+	u, err := Run1(ctx, operator, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
+		u, err := i.repos.User.FindByID(ctx, *operator.User)
+		if err != nil {
+			return nil, err
+		}
+		ws, err := i.repos.Workspace.FindByID(ctx, u.Workspace())
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return nil, err
+		}
+
+		if name, err = p.Upload(ctx, i.gateways.Storage, photo.NewDir(prefix)); err != nil {
+			return nil, err
+		}
+
+		previous = append(previous, photo.Objects(u.Metadata().PhotoURL(), prefix)...)
+		u.Metadata().SetPhotoURL(name)
+		if ws != nil && ws.IsPersonal() {
+			metadata := ws.Metadata()
+			previous = append(previous, photo.Objects(metadata.PhotoURL(), path.Join(gateway.GcsWorkspaceBasePath, ws.ID().String()))...)
+			metadata.SetPhotoURL(name)
+			ws.SetMetadata(*metadata)
+			if err := i.repos.Workspace.Save(ctx, ws); err != nil {
+				return nil, err
+			}
+		}
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return nil, err
+		}
+		return u, nil
+	})
+	if err != nil {
+		deletePhotos(ctx, i.gateways.Storage, photo.Objects(name, prefix))
+		return nil, err
+	}
+
+	deletePhotos(ctx, i.gateways.Storage, previous)
+	return u, nil
+}
+
+// UploadPhoto stores the thumbnails of the photo under the directory of the
+// workspace and sets the largest one as its photo. The previous photo is
+// deleted once the new one is saved.
+func (i *Workspace) UploadPhoto(ctx context.Context, id workspace.ID, f *file.File, operator *workspace.Operator) (*workspace.Workspace, error) {
+	if operator.User == nil {
+		return nil, interfaces.ErrInvalidOperator
+	}
+	p, err := decodePhoto(f)
+	if err != nil {
+		return nil, err
+	}
+
+	prefix := path.Join(gateway.GcsWorkspaceBasePath, id.String())
+	var name string
+	var previous []string
+	ws, err := Run1(ctx, operator, i.repos, Usecase().Transaction(), func(ctx context.Context) (*workspace.Workspace, error) {
+		ws, err := i.repos.Workspace.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if ws.IsPersonal() {
+			return nil, workspace.ErrCannotModifyPersonalWorkspace
+		}
+		if err := i.checkEditPermission(ctx, ws, operator); err != nil {
+			return nil, err
+		}
+
+		if name, err = p.Upload(ctx, i.storage, photo.NewDir(prefix)); err != nil {
+			return nil, err
+		}
+
+		metadata := ws.Metadata()
+		previous = photo.Objects(metadata.PhotoURL(), prefix)
+		metadata.SetPhotoURL(name)
+		ws.SetMetadata(*metadata)
+		if err := i.repos.Workspace.Save(ctx, ws); err != nil {
+			return nil, err
+		}
+
+		i.applyDefaultPolicy(ws, operator)
+		return ws, nil
+	})
+	if err != nil {
+		deletePhotos(ctx, i.storage, photo.Objects(name, prefix))
+		return nil, err
+	}
+
+	deletePhotos(ctx, i.storage, previous)
+	return ws, nil
+}
+
+func decodePhoto(f *file.File) (*photo.Photo, error) {
+	if f == nil || f.Content == nil {
+		return nil, photo.ErrInvalid
+	}
+	defer func() { _ = f.Content.Close() }()
+	if f.Size > photo.MaxSize {
+		return nil, photo.ErrTooLarge
+	}
+	return photo.Decode(f.Content)
+}
+
+// deletePhotos deletes replaced photos. Failures only leave orphaned objects
+// behind, so they are logged rather than returned.
+func deletePhotos(ctx context.Context, s gateway.Storage, names []string) {
+	for _, n := range lo.Uniq(names) {
+		if err := s.Delete(ctx, n); err != nil {
+			log.Warnfc(ctx, "photo: failed to delete %s: %v", n, err)
+		}
+	}
+}
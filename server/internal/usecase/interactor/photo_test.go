@@ -0,0 +1,123 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway/mock"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/photo"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/asset/domain/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func testPhoto(t *testing.T) *file.File {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, image.NewRGBA(image.Rect(0, 0, 32, 32))))
+	return &file.File{Content: io.NopCloser(&buf), Name: "photo.png", Size: int64(buf.Len())}
+}
+
+// photoStorage expects the thumbnails of one photo to be uploaded under
+// prefix and the objects in deleted to be deleted.
+func photoStorage(t *testing.T, prefix string, deleted ...string) *mock.MockStorage {
+	storage := mock.NewMockStorage(gomock.NewController(t))
+	storage.EXPECT().Upload(gomock.Any(), gomock.Any(), gomock.Any()).Times(len(photo.Sizes)).
+		DoAndReturn(func(_ context.Context, name string, _ *file.File) error {
+			assert.True(t, strings.HasPrefix(name, prefix+"/photos/"), name)
+			return nil
+		})
+	for _, d := range deleted {
+		storage.EXPECT().Delete(gomock.Any(), d).Return(nil)
+	}
+	return storage
+}
+
+func TestUser_UploadMyPhoto(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	uid := user.NewID()
+	ws := workspace.New().NewID().Name("alice").Personal(true).Members(map[workspace.UserID]workspace.Member{
+		uid: {Role: role.RoleOwner},
+	}).MustBuild()
+	ws.Metadata().SetPhotoURL("workspaces/" + ws.ID().String() + "/old.png")
+	u := user.New().ID(uid).Workspace(ws.ID()).Name("alice").Email("alice@example.com").MustBuild()
+	old := "users/" + uid.String() + "/photos/p1/512.png"
+	u.Metadata().SetPhotoURL(old)
+	require.NoError(t, r.User.Save(ctx, u))
+	require.NoError(t, r.Workspace.Save(ctx, ws))
+
+	prefix := "users/" + uid.String()
+	storage := photoStorage(t, prefix,
+		old, prefix+"/photos/p1/256.png", prefix+"/photos/p1/64.png",
+		"workspaces/"+ws.ID().String()+"/old.png")
+	uc := NewUser(r, &gateway.Container{Storage: storage}, nil, "", "")
+
+	got, err := uc.UploadMyPhoto(ctx, testPhoto(t), &workspace.Operator{User: &uid})
+	require.NoError(t, err)
+	assert.Regexp(t, "^"+prefix+"/photos/[^/]+/512.png$", got.Metadata().PhotoURL())
+
+	ws2, err := r.Workspace.FindByID(ctx, ws.ID())
+	require.NoError(t, err)
+	assert.Equal(t, got.Metadata().PhotoURL(), ws2.Metadata().PhotoURL())
+}
+
+func TestUser_UploadMyPhoto_Invalid(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().NewID().Workspace(workspace.NewID()).Name("alice").Email("alice@example.com").MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	uc := NewUser(r, &gateway.Container{Storage: mock.NewMockStorage(gomock.NewController(t))}, nil, "", "")
+	op := &workspace.Operator{User: u.ID().Ref()}
+
+	_, err := uc.UploadMyPhoto(ctx, testPhoto(t), &workspace.Operator{})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidOperator)
+
+	_, err = uc.UploadMyPhoto(ctx, &file.File{Content: io.NopCloser(strings.NewReader("hello")), Size: 5}, op)
+	assert.ErrorIs(t, err, photo.ErrUnsupportedType)
+
+	f := testPhoto(t)
+	f.Size = photo.MaxSize + 1
+	_, err = uc.UploadMyPhoto(ctx, f, op)
+	assert.ErrorIs(t, err, photo.ErrTooLarge)
+}
+
+func TestWorkspace_UploadPhoto(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	owner, writer := user.NewID(), user.NewID()
+	ws := workspace.New().NewID().Name("team").Members(map[workspace.UserID]workspace.Member{
+		owner:  {Role: role.RoleOwner},
+		writer: {Role: role.RoleWriter},
+	}).MustBuild()
+	// photos shared with others are not deleted
+	ws.Metadata().SetPhotoURL("assets/team.png")
+	personal := workspace.New().NewID().Name("owner").Personal(true).Members(map[workspace.UserID]workspace.Member{
+		owner: {Role: role.RoleOwner},
+	}).MustBuild()
+	require.NoError(t, r.Workspace.SaveAll(ctx, workspace.List{ws, personal}))
+
+	prefix := "workspaces/" + ws.ID().String()
+	uc := NewWorkspace(r, nil, nil, photoStorage(t, prefix))
+	got, err := uc.UploadPhoto(ctx, ws.ID(), testPhoto(t), &workspace.Operator{User: &owner})
+	require.NoError(t, err)
+	assert.Regexp(t, "^"+prefix+"/photos/[^/]+/512.png$", got.Metadata().PhotoURL())
+
+	uc = NewWorkspace(r, nil, nil, mock.NewMockStorage(gomock.NewController(t)))
+	_, err = uc.UploadPhoto(ctx, ws.ID(), testPhoto(t), &workspace.Operator{User: &writer})
+	assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
+	_, err = uc.UploadPhoto(ctx, personal.ID(), testPhoto(t), &workspace.Operator{User: &owner})
+	assert.ErrorIs(t, err, workspace.ErrCannotModifyPersonalWorkspace)
+}
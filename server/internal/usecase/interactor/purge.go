@@ -0,0 +1,268 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	htmlTmpl "html/template"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/config"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+// purgeLockName serializes runs across the instances of the server.
+const purgeLockName = "purge"
+
+var (
+	purgeRequestedMailContent = mailContent{
+		Message:     "As you requested, your Re:Earth account and the workspaces only you own will be permanently deleted on %s. Until then, you can sign in and cancel the deletion.",
+		Suffix:      "If you still want your account deleted, you don't need to do anything.",
+		ActionLabel: "cancel the deletion",
+	}
+	purgeDeactivatedMailContent = mailContent{
+		Message:     "Your Re:Earth account was deactivated and will be permanently deleted, together with the workspaces only you own, on %s.",
+		Suffix:      "If you think this is a mistake, please contact your administrator before then.",
+		ActionLabel: "visit Re:Earth",
+	}
+)
+
+// Purge permanently removes deactivated users and workspaces once the
+// retention window has passed. Users are removed the way DeleteMe used to
+// remove them: they leave the team workspaces that have another owner, and the
+// other workspaces they are a member of are removed with them.
+//
+// The retention of the records deactivated before the purge first ran counts
+// from that run, so enabling it doesn't remove them without notice. When the
+// retention is 0 only the users who requested their deletion are purged, once
+// the notice period has passed.
+type Purge struct {
+	repos     *repo.Container
+	gateways  *gateway.Container
+	retention time.Duration
+	notice    time.Duration
+	webURL    string
+}
+
+func NewPurge(r *repo.Container, g *gateway.Container, retention, notice time.Duration, webURL string) interfaces.Purge {
+	return &Purge{
+		repos:     r,
+		gateways:  g,
+		retention: retention,
+		notice:    notice,
+		webURL:    webURL,
+	}
+}
+
+func (i *Purge) Purge(ctx context.Context) (*interfaces.PurgeResult, error) {
+	if err := i.repos.Lock.Lock(ctx, purgeLockName); err != nil {
+		if errors.Is(err, repo.ErrFailedToLock) || errors.Is(err, repo.ErrAlreadyLocked) {
+			return nil, interfaces.ErrPurgeRunning
+		}
+		return nil, err
+	}
+	defer func() {
+		if err := i.repos.Lock.Unlock(ctx, purgeLockName); err != nil {
+			log.Warnfc(ctx, "[purge] failed to unlock: %v", err)
+		}
+	}()
+
+	now := util.Now()
+	res := &interfaces.PurgeResult{}
+
+	var since time.Time
+	if i.retention > 0 {
+		var err error
+		if since, err = i.since(ctx, now); err != nil {
+			return nil, err
+		}
+	}
+
+	// Users are looked up a notice period early so that they are notified
+	// before they are due.
+	users, err := i.repos.User.FindDeletedBefore(ctx, now.Add(i.notice-i.retention))
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if i.retention == 0 && u.DeletionRequestedAt() == nil {
+			continue
+		}
+		due := i.dueAt(u, since)
+		if u.PurgeNotifiedAt() == nil {
+			if due.After(now.Add(i.notice)) {
+				continue
+			}
+			if err := i.notify(ctx, u, now, due); err != nil {
+				// keep going so that one bad address doesn't hold back the others;
+				// the user is notified on a later run
+				log.Errorfc(ctx, "[purge] failed to notify user %s: %v", u.ID(), err)
+				continue
+			}
+			res.Notified = append(res.Notified, u.ID())
+			continue
+		}
+		if now.Before(due) || now.Before(u.PurgeNotifiedAt().Add(i.notice)) {
+			continue
+		}
+		if err := i.purgeUser(ctx, u); err != nil {
+			return res, err
+		}
+		res.Users = append(res.Users, u.ID())
+	}
+
+	// workspaces are not mailed about, so none is due before the retention
+	// has passed since the first run
+	if cutoff := now.Add(-i.retention); i.retention > 0 && !cutoff.Before(since) {
+		workspaces, err := i.repos.Workspace.FindDeletedBefore(ctx, cutoff)
+		if err != nil {
+			return res, err
+		}
+		if err := Run0(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) error {
+			return i.removeWorkspaces(ctx, workspaces)
+		}); err != nil {
+			return res, err
+		}
+		res.Workspaces = workspaces.IDs()
+	}
+
+	log.Infofc(ctx, "[purge] notified=%d users=%d workspaces=%d", len(res.Notified), len(res.Users), len(res.Workspaces))
+	return res, nil
+}
+
+// since returns when the purge first ran, recording now on the first run.
+func (i *Purge) since(ctx context.Context, now time.Time) (time.Time, error) {
+	c, err := i.repos.Config.LockAndLoad(ctx)
+	if err != nil {
+		return time.Time{}, err
+	}
+	if c != nil && c.PurgeSince != nil {
+		return *c.PurgeSince, i.repos.Config.Unlock(ctx)
+	}
+	if c == nil {
+		c = &config.Config{}
+	}
+	c.PurgeSince = &now
+	return now, i.repos.Config.SaveAndUnlock(ctx, c)
+}
+
+// dueAt returns when the user is due to be purged, which is no earlier than
+// the retention after the first run.
+func (i *Purge) dueAt(u *user.User, since time.Time) time.Time {
+	due := *u.PurgeAt(i.retention)
+	if earliest := since.Add(i.retention); due.Before(earliest) {
+		return earliest
+	}
+	return due
+}
+
+// notify mails the user the date they are purged on and records it. Merged
+// users, whose account lives on in the user they were merged into, and users
+// without an email are not mailed.
+func (i *Purge) notify(ctx context.Context, u *user.User, now, due time.Time) error {
+	if u.MergedInto() == nil && u.Email() != "" {
+		on := due
+		if earliest := now.Add(i.notice); on.Before(earliest) {
+			on = earliest
+		}
+
+		c := purgeDeactivatedMailContent
+		if u.DeletionRequestedAt() != nil {
+			c = purgeRequestedMailContent
+		}
+		var textOut, htmlOut bytes.Buffer
+		content := mailContent{
+			UserName:    u.Name(),
+			ActionURL:   htmlTmpl.URL(i.webURL),
+			Message:     fmt.Sprintf(c.Message, on.UTC().Format("January 2, 2006")),
+			Suffix:      c.Suffix,
+			ActionLabel: c.ActionLabel,
+		}
+		if err := authTextTMPL.Execute(&textOut, content); err != nil {
+			return err
+		}
+		if err := authHTMLTMPL.Execute(&htmlOut, content); err != nil {
+			return err
+		}
+		if err := i.gateways.Mailer.SendMail(ctx, []mailer.Contact{{Email: u.Email(), Name: u.Name()}},
+			"Your Re:Earth account will be deleted", textOut.String(), htmlOut.String()); err != nil {
+			return err
+		}
+	}
+
+	u.SetPurgeNotifiedAt(now)
+	return i.repos.User.Save(ctx, u)
+}
+
+func (i *Purge) purgeUser(ctx context.Context, u *user.User) error {
+	return Run0(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) error {
+		workspaces, err := i.repos.Workspace.FindByUser(ctx, u.ID())
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return err
+		}
+
+		var left, removed workspace.List
+		for _, ws := range workspaces {
+			if !ws.IsPersonal() && !ws.Members().IsOnlyOwner(u.ID()) {
+				if err := ws.Members().Leave(u.ID()); err != nil {
+					if errors.Is(err, workspace.ErrTargetUserNotInTheWorkspace) {
+						continue
+					}
+					return err
+				}
+				left = append(left, ws)
+				continue
+			}
+			removed = append(removed, ws)
+		}
+
+		if err := i.repos.Workspace.SaveAll(ctx, left); err != nil {
+			return err
+		}
+		if err := i.removeWorkspaces(ctx, removed); err != nil {
+			return err
+		}
+		if err := i.repos.Permittable.RemoveByUserID(ctx, u.ID()); err != nil {
+			return err
+		}
+		return i.repos.User.Remove(ctx, u.ID())
+	})
+}
+
+// removeWorkspaces removes the workspaces and the roles their members were
+// bound to in them.
+func (i *Purge) removeWorkspaces(ctx context.Context, workspaces workspace.List) error {
+	if len(workspaces) == 0 {
+		return nil
+	}
+
+	var members user.IDList
+	for _, ws := range workspaces {
+		members = append(members, ws.Members().UserIDs()...)
+	}
+	if len(members) > 0 {
+		ps, err := i.repos.Permittable.FindByUserIDs(ctx, members)
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return err
+		}
+		for _, p := range ps {
+			for _, ws := range workspaces {
+				p.RemoveWorkspaceRole(ws.ID())
+			}
+		}
+		if err := i.repos.Permittable.SaveMany(ctx, ps); err != nil {
+			return err
+		}
+	}
+
+	return i.repos.Workspace.RemoveAll(ctx, workspaces.IDs())
+}
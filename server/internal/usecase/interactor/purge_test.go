@@ -0,0 +1,277 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/config"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testRetention = 30 * 24 * time.Hour
+	testNotice    = 7 * 24 * time.Hour
+)
+
+// newPurgedRepos returns repos where the purge has been running for a year.
+func newPurgedRepos(t *testing.T, now time.Time) *repo.Container {
+	t.Helper()
+	r := memory.New()
+	since := now.AddDate(-1, 0, 0)
+	require.NoError(t, r.Config.Save(context.Background(), &config.Config{PurgeSince: &since}))
+	return r
+}
+
+func TestPurge_NotifiesThenPurgesUser(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	r := newPurgedRepos(t, now)
+	m := mailer.NewMock()
+	uc := NewPurge(r, &gateway.Container{Mailer: m}, testRetention, testNotice, "https://reearth.example.com")
+
+	uid, other := user.NewID(), user.NewID()
+	personalID, soleID, sharedID := workspace.NewID(), workspace.NewID(), workspace.NewID()
+	deletedAt := now.Add(-25 * 24 * time.Hour)
+	u := user.New().ID(uid).Workspace(personalID).Name("alice").Email("alice@example.com").
+		DeletedAt(&deletedAt).DeletionRequestedAt(&deletedAt).MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	require.NoError(t, r.Workspace.SaveAll(ctx, workspace.List{
+		workspace.New().ID(personalID).Name("alice").Personal(true).Members(map[workspace.UserID]workspace.Member{
+			uid: {Role: role.RoleOwner},
+		}).MustBuild(),
+		// alice is the only owner, so it goes with her
+		workspace.New().ID(soleID).Name("sole").Members(map[workspace.UserID]workspace.Member{
+			uid:   {Role: role.RoleOwner},
+			other: {Role: role.RoleWriter},
+		}).MustBuild(),
+		workspace.New().ID(sharedID).Name("shared").Members(map[workspace.UserID]workspace.Member{
+			uid:   {Role: role.RoleWriter},
+			other: {Role: role.RoleOwner},
+		}).MustBuild(),
+	}))
+	rid := id.NewRoleID()
+	p1 := permittable.New().NewID().UserID(uid).RoleIDs([]id.RoleID{rid}).MustBuild()
+	p2 := permittable.New().NewID().UserID(other).RoleIDs([]id.RoleID{rid}).WorkspaceRoles([]permittable.WorkspaceRole{
+		permittable.NewWorkspaceRole(soleID, rid),
+		permittable.NewWorkspaceRole(sharedID, rid),
+	}).MustBuild()
+	require.NoError(t, r.Permittable.SaveMany(ctx, permittable.List{p1, p2}))
+
+	// notified 5 days before the retention window ends
+	reset := util.MockNow(now)
+	res, err := uc.Purge(ctx)
+	reset()
+	require.NoError(t, err)
+	assert.Equal(t, user.IDList{uid}, res.Notified)
+	assert.Empty(t, res.Users)
+	mails := m.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, []mailer.Contact{{Email: "alice@example.com", Name: "alice"}}, mails[0].To)
+	// the notice period is honored even though the retention window ends earlier
+	assert.Contains(t, mails[0].PlainContent, "October 8, 2026")
+	assert.Contains(t, mails[0].PlainContent, "cancel the deletion")
+
+	// the retention window has ended, but not the notice period
+	reset = util.MockNow(now.Add(6 * 24 * time.Hour))
+	res, err = uc.Purge(ctx)
+	reset()
+	require.NoError(t, err)
+	assert.Empty(t, res.Notified)
+	assert.Empty(t, res.Users)
+	assert.Len(t, m.Mails(), 1)
+
+	reset = util.MockNow(now.Add(testNotice))
+	res, err = uc.Purge(ctx)
+	reset()
+	require.NoError(t, err)
+	assert.Equal(t, user.IDList{uid}, res.Users)
+
+	_, err = r.User.FindByID(ctx, uid)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	_, err = r.Workspace.FindByID(ctx, personalID)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	_, err = r.Workspace.FindByID(ctx, soleID)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	shared, err := r.Workspace.FindByID(ctx, sharedID)
+	require.NoError(t, err)
+	assert.False(t, shared.Members().HasUser(uid))
+	assert.True(t, shared.Members().HasUser(other))
+
+	_, err = r.Permittable.FindByUserID(ctx, uid)
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	p, err := r.Permittable.FindByUserID(ctx, other)
+	require.NoError(t, err)
+	assert.Equal(t, []permittable.WorkspaceRole{permittable.NewWorkspaceRole(sharedID, rid)}, p.WorkspaceRoles())
+}
+
+func TestPurge_DeactivatedUsers(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+	ctx := context.Background()
+	r := newPurgedRepos(t, now)
+	m := mailer.NewMock()
+	uc := NewPurge(r, &gateway.Container{Mailer: m}, testRetention, testNotice, "https://reearth.example.com")
+
+	longAgo := now.Add(-testRetention - testNotice)
+	recent := now.Add(-24 * time.Hour)
+	target := user.NewID()
+	deactivated := user.New().NewID().Workspace(workspace.NewID()).Name("bob").Email("bob@example.com").
+		DeletedAt(&longAgo).MustBuild()
+	merged := user.New().NewID().Workspace(workspace.NewID()).Name("carol").Email("carol@example.com").
+		DeletedAt(&longAgo).MergedInto(&target).MustBuild()
+	notified := user.New().NewID().Workspace(workspace.NewID()).Name("dave").Email("dave@example.com").
+		DeletedAt(&longAgo).PurgeNotifiedAt(&longAgo).MustBuild()
+	fresh := user.New().NewID().Workspace(workspace.NewID()).Name("erin").Email("erin@example.com").
+		DeletedAt(&recent).MustBuild()
+	active := user.New().NewID().Workspace(workspace.NewID()).Name("frank").Email("frank@example.com").MustBuild()
+	for _, u := range []*user.User{deactivated, merged, notified, fresh, active} {
+		require.NoError(t, r.User.Save(ctx, u))
+	}
+
+	res, err := uc.Purge(ctx)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, user.IDList{deactivated.ID(), merged.ID()}, res.Notified)
+	assert.Equal(t, user.IDList{notified.ID()}, res.Users)
+
+	// merged users are not mailed, and deactivated users cannot cancel
+	mails := m.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, "bob@example.com", mails[0].To[0].Email)
+	assert.Contains(t, mails[0].PlainContent, "contact your administrator")
+
+	got, err := r.User.FindByID(ctx, merged.ID())
+	require.NoError(t, err)
+	assert.Equal(t, now, *got.PurgeNotifiedAt())
+	for _, u := range []*user.User{fresh, active} {
+		got, err := r.User.FindByID(ctx, u.ID())
+		require.NoError(t, err)
+		assert.Nil(t, got.PurgeNotifiedAt())
+	}
+}
+
+func TestPurge_Workspaces(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+	ctx := context.Background()
+	r := newPurgedRepos(t, now)
+	uc := NewPurge(r, &gateway.Container{Mailer: mailer.NewMock()}, testRetention, testNotice, "")
+
+	uid := user.NewID()
+	members := map[workspace.UserID]workspace.Member{uid: {Role: role.RoleOwner}}
+	longAgo := now.Add(-testRetention - time.Hour)
+	recent := now.Add(-time.Hour)
+	old := workspace.New().NewID().Name("old").Members(members).DeletedAt(&longAgo).MustBuild()
+	fresh := workspace.New().NewID().Name("fresh").Members(members).DeletedAt(&recent).MustBuild()
+	require.NoError(t, r.Workspace.SaveAll(ctx, workspace.List{old, fresh}))
+	rid := id.NewRoleID()
+	p := permittable.New().NewID().UserID(uid).RoleIDs([]id.RoleID{rid}).WorkspaceRoles([]permittable.WorkspaceRole{
+		permittable.NewWorkspaceRole(old.ID(), rid),
+		permittable.NewWorkspaceRole(fresh.ID(), rid),
+	}).MustBuild()
+	require.NoError(t, r.Permittable.Save(ctx, *p))
+
+	res, err := uc.Purge(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, workspace.IDList{old.ID()}, res.Workspaces)
+
+	_, err = r.Workspace.FindByID(ctx, old.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	_, err = r.Workspace.FindByID(ctx, fresh.ID())
+	assert.NoError(t, err)
+	got, err := r.Permittable.FindByUserID(ctx, uid)
+	require.NoError(t, err)
+	assert.Equal(t, []permittable.WorkspaceRole{permittable.NewWorkspaceRole(fresh.ID(), rid)}, got.WorkspaceRoles())
+}
+
+func TestPurge_FirstRun(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	ctx := context.Background()
+	r := memory.New()
+	m := mailer.NewMock()
+	uc := NewPurge(r, &gateway.Container{Mailer: m}, testRetention, testNotice, "https://reearth.example.com")
+
+	longAgo := now.AddDate(-1, 0, 0)
+	u := user.New().NewID().Workspace(workspace.NewID()).Name("bob").Email("bob@example.com").
+		DeletedAt(&longAgo).MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	ws := workspace.New().NewID().Name("old").DeletedAt(&longAgo).MustBuild()
+	require.NoError(t, r.Workspace.Save(ctx, ws))
+
+	// the records deactivated before the first run are kept for the retention
+	reset := util.MockNow(now)
+	res, err := uc.Purge(ctx)
+	reset()
+	require.NoError(t, err)
+	assert.Empty(t, res.Notified)
+	assert.Empty(t, res.Users)
+	assert.Empty(t, res.Workspaces)
+	c, err := r.Config.LockAndLoad(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.Config.Unlock(ctx))
+	assert.Equal(t, now, *c.PurgeSince)
+
+	reset = util.MockNow(now.Add(testRetention - testNotice))
+	res, err = uc.Purge(ctx)
+	reset()
+	require.NoError(t, err)
+	assert.Equal(t, user.IDList{u.ID()}, res.Notified)
+	assert.Empty(t, res.Workspaces)
+	mails := m.Mails()
+	require.Len(t, mails, 1)
+	assert.Contains(t, mails[0].PlainContent, "October 31, 2026")
+
+	reset = util.MockNow(now.Add(testRetention))
+	res, err = uc.Purge(ctx)
+	reset()
+	require.NoError(t, err)
+	assert.Equal(t, user.IDList{u.ID()}, res.Users)
+	assert.Equal(t, workspace.IDList{ws.ID()}, res.Workspaces)
+}
+
+func TestPurge_NoRetention(t *testing.T) {
+	now := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+	ctx := context.Background()
+	r := memory.New()
+	m := mailer.NewMock()
+	uc := NewPurge(r, &gateway.Container{Mailer: m}, 0, testNotice, "https://reearth.example.com")
+
+	longAgo := now.AddDate(-1, 0, 0)
+	requested := user.New().NewID().Workspace(workspace.NewID()).Name("alice").Email("alice@example.com").
+		DeletedAt(&longAgo).DeletionRequestedAt(&longAgo).PurgeNotifiedAt(&longAgo).MustBuild()
+	deactivated := user.New().NewID().Workspace(workspace.NewID()).Name("bob").Email("bob@example.com").
+		DeletedAt(&longAgo).MustBuild()
+	require.NoError(t, r.User.Save(ctx, requested))
+	require.NoError(t, r.User.Save(ctx, deactivated))
+	ws := workspace.New().NewID().Name("old").DeletedAt(&longAgo).MustBuild()
+	require.NoError(t, r.Workspace.Save(ctx, ws))
+
+	res, err := uc.Purge(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, res.Notified)
+	assert.Equal(t, user.IDList{requested.ID()}, res.Users)
+	assert.Empty(t, res.Workspaces)
+	assert.Empty(t, m.Mails())
+
+	_, err = r.User.FindByID(ctx, deactivated.ID())
+	assert.NoError(t, err)
+	_, err = r.Workspace.FindByID(ctx, ws.ID())
+	assert.NoError(t, err)
+	c, err := r.Config.LockAndLoad(ctx)
+	require.NoError(t, err)
+	require.NoError(t, r.Config.Unlock(ctx))
+	assert.Nil(t, c)
+}
@@ -0,0 +1,585 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+)
+
+// SCIM maps SCIM Users onto users with the semantics of SyncSSOUser, so that
+// a provisioned user is the same one who later signs in through the tenant's
+// SSO connection, and SCIM Groups onto team workspaces whose members all hold
+// the role of the group.
+type SCIM struct {
+	repos     *repo.Container
+	user      *User
+	workspace *Workspace
+}
+
+func NewSCIM(r *repo.Container) interfaces.SCIM {
+	return &SCIM{
+		repos: r,
+		user:  &User{repos: r},
+		workspace: &Workspace{
+			repos:           r,
+			permittableRepo: r.Permittable,
+			roleRepo:        r.Role,
+		},
+	}
+}
+
+func (i *SCIM) Authenticate(ctx context.Context, token string) (*scimtenant.Tenant, error) {
+	if token == "" {
+		return nil, interfaces.ErrInvalidSCIMToken
+	}
+	t, err := i.repos.SCIMTenant.FindByTokenHash(ctx, scimtenant.HashToken(token))
+	if err != nil {
+		if errors.Is(err, rerror.ErrNotFound) {
+			return nil, interfaces.ErrInvalidSCIMToken
+		}
+		return nil, err
+	}
+	if !t.Authenticate(token) {
+		return nil, interfaces.ErrInvalidSCIMToken
+	}
+	return t, nil
+}
+
+func (i *SCIM) FindUsers(ctx context.Context, tid scimtenant.ID, filter interfaces.SCIMUserFilter) (user.List, int, error) {
+	t, err := i.repos.SCIMTenant.FindByID(ctx, tid)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if filter.UserName == nil && filter.Email == nil {
+		ids := t.Users()
+		page := paginate(ids, filter.Offset, filter.Limit)
+		if len(page) == 0 {
+			return user.List{}, len(ids), nil
+		}
+		found, err := i.repos.User.FindByIDs(ctx, page)
+		if err != nil {
+			return nil, 0, err
+		}
+		byID := make(map[user.ID]*user.User, len(found))
+		for _, u := range found {
+			if u != nil {
+				byID[u.ID()] = u
+			}
+		}
+		res := make(user.List, 0, len(page))
+		for _, id := range page {
+			if u, ok := byID[id]; ok {
+				res = append(res, u)
+			}
+		}
+		return res, len(ids), nil
+	}
+
+	var u *user.User
+	if filter.UserName != nil {
+		u, err = i.repos.User.FindBySub(ctx, t.Sub(*filter.UserName))
+	} else {
+		u, err = i.repos.User.FindByEmail(ctx, *filter.Email)
+	}
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, 0, err
+	}
+	if u == nil || !t.HasUser(u.ID()) {
+		return user.List{}, 0, nil
+	}
+	return paginate(user.List{u}, filter.Offset, filter.Limit), 1, nil
+}
+
+func (i *SCIM) FindUser(ctx context.Context, tid scimtenant.ID, id user.ID) (*user.User, error) {
+	t, err := i.repos.SCIMTenant.FindByID(ctx, tid)
+	if err != nil {
+		return nil, err
+	}
+	if !t.HasUser(id) {
+		return nil, rerror.ErrNotFound
+	}
+	return i.repos.User.FindByID(ctx, id)
+}
+
+func (i *SCIM) CreateUser(ctx context.Context, tid scimtenant.ID, param interfaces.SCIMUserParam) (*user.User, error) {
+	userName := strings.TrimSpace(lo.FromPtr(param.UserName))
+	if userName == "" {
+		return nil, interfaces.ErrSCIMInvalidUserName
+	}
+	email := strings.TrimSpace(lo.FromPtr(param.Email))
+	if email == "" {
+		return nil, interfaces.ErrInvalidUserEmail
+	}
+	name := strings.TrimSpace(lo.FromPtr(param.Name))
+	if name == "" {
+		name = userName
+	}
+
+	return Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
+		t, err := i.repos.SCIMTenant.FindByID(ctx, tid)
+		if err != nil {
+			return nil, err
+		}
+
+		// Only a user the tenant provisioned and then deleted is taken back.
+		// Any other user with the sub, e.g. one who signed in via SSO before,
+		// is not the tenant's to manage.
+		existing, err := i.repos.User.FindBySub(ctx, t.Sub(userName))
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return nil, err
+		}
+		if existing != nil && (t.HasUser(existing.ID()) || !t.Provisioned(existing.ID())) {
+			return nil, interfaces.ErrSCIMUserNameTaken
+		}
+
+		u, err := i.user.syncSSOUser(ctx, interfaces.SyncSSOUserParam{
+			Email: email,
+			Name:  name,
+			Sub:   t.Sub(userName),
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		param.Name = &name
+		param.Email = &email
+		if param.Active == nil {
+			param.Active = lo.ToPtr(true)
+		}
+		if err := i.updateUser(ctx, t, u, param); err != nil {
+			return nil, err
+		}
+
+		t.AddUser(u.ID())
+		if err := i.repos.SCIMTenant.Save(ctx, t); err != nil {
+			return nil, err
+		}
+		return u, nil
+	})
+}
+
+func (i *SCIM) UpdateUser(ctx context.Context, tid scimtenant.ID, id user.ID, param interfaces.SCIMUserParam) (*user.User, error) {
+	return Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
+		t, err := i.repos.SCIMTenant.FindByID(ctx, tid)
+		if err != nil {
+			return nil, err
+		}
+		if !t.HasUser(id) {
+			return nil, rerror.ErrNotFound
+		}
+		u, err := i.repos.User.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if err := i.updateUser(ctx, t, u, param); err != nil {
+			return nil, err
+		}
+		return u, nil
+	})
+}
+
+// DeleteUser deprovisions the user: it is deactivated, removed from the groups
+// of the tenant and no longer visible to the tenant. Its data is kept, and
+// provisioning the same userName again reactivates it.
+func (i *SCIM) DeleteUser(ctx context.Context, tid scimtenant.ID, id user.ID) error {
+	return Run0(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) error {
+		t, err := i.repos.SCIMTenant.FindByID(ctx, tid)
+		if err != nil {
+			return err
+		}
+		if !t.HasUser(id) {
+			return rerror.ErrNotFound
+		}
+		u, err := i.repos.User.FindByID(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		if !u.IsDeleted() {
+			u.Deactivate()
+			if err := i.repos.User.Save(ctx, u); err != nil {
+				return err
+			}
+		}
+
+		for _, g := range t.Groups() {
+			ws, err := i.repos.Workspace.FindByID(ctx, g.Workspace)
+			if err != nil {
+				if errors.Is(err, rerror.ErrNotFound) {
+					continue
+				}
+				return err
+			}
+			if !ws.Members().HasUser(id) {
+				continue
+			}
+			if err := ws.Members().Leave(id); err != nil {
+				return err
+			}
+			if err := i.workspace.bulkRemovePermittable(ctx, ws.ID(), user.IDList{id}); err != nil {
+				return err
+			}
+			if err := i.repos.Workspace.Save(ctx, ws); err != nil {
+				return err
+			}
+		}
+
+		t.RemoveUser(id)
+		return i.repos.SCIMTenant.Save(ctx, t)
+	})
+}
+
+// updateUser applies the set attributes of param to u and saves it. Setting
+// Active to false deactivates the user.
+func (i *SCIM) updateUser(ctx context.Context, t *scimtenant.Tenant, u *user.User, param interfaces.SCIMUserParam) error {
+	if param.UserName != nil {
+		userName := strings.TrimSpace(*param.UserName)
+		if userName == "" {
+			return interfaces.ErrSCIMInvalidUserName
+		}
+		if current := t.UserName(u.Auths()); current != userName {
+			sub := t.Sub(userName)
+			other, err := i.repos.User.FindBySub(ctx, sub)
+			if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+				return err
+			}
+			if other != nil && other.ID() != u.ID() {
+				return interfaces.ErrSCIMUserNameTaken
+			}
+			if current != "" {
+				u.RemoveAuth(user.AuthFrom(t.Sub(current)))
+			}
+			u.AddAuth(user.AuthFrom(sub))
+		}
+	}
+
+	if param.Name != nil {
+		if name := strings.TrimSpace(*param.Name); name != "" && name != u.Name() {
+			u.UpdateName(name)
+		}
+	}
+
+	if param.Email != nil {
+		email := strings.TrimSpace(*param.Email)
+		if !strings.EqualFold(email, u.Email()) {
+			other, err := i.repos.User.FindByEmail(ctx, email)
+			if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+				return err
+			}
+			if other != nil && other.ID() != u.ID() {
+				return interfaces.ErrUserAlreadyExists
+			}
+			if err := u.UpdateEmail(email); err != nil {
+				return err
+			}
+		}
+	}
+
+	if param.Active != nil {
+		if *param.Active && u.IsDeleted() {
+			u.Reactivate()
+		} else if !*param.Active && !u.IsDeleted() {
+			u.Deactivate()
+		}
+	}
+
+	return i.repos.User.Save(ctx, u)
+}
+
+func (i *SCIM) FindGroups(ctx context.Context, tid scimtenant.ID, filter interfaces.SCIMGroupFilter) ([]interfaces.SCIMGroup, int, error) {
+	t, err := i.repos.SCIMTenant.FindByID(ctx, tid)
+	if err != nil {
+		return nil, 0, err
+	}
+	groups, err := i.groups(ctx, t)
+	if err != nil {
+		return nil, 0, err
+	}
+	if filter.Name != nil {
+		groups = lo.Filter(groups, func(g interfaces.SCIMGroup, _ int) bool {
+			return g.Workspace.Name() == *filter.Name
+		})
+	}
+	return paginate(groups, filter.Offset, filter.Limit), len(groups), nil
+}
+
+func (i *SCIM) FindGroup(ctx context.Context, tid scimtenant.ID, id workspace.ID) (*interfaces.SCIMGroup, error) {
+	t, err := i.repos.SCIMTenant.FindByID(ctx, tid)
+	if err != nil {
+		return nil, err
+	}
+	g := t.Group(id)
+	if g == nil {
+		return nil, rerror.ErrNotFound
+	}
+	ws, err := i.repos.Workspace.FindByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return &interfaces.SCIMGroup{Workspace: ws, Role: g.Role}, nil
+}
+
+func (i *SCIM) CreateGroup(ctx context.Context, tid scimtenant.ID, param interfaces.SCIMGroupParam) (*interfaces.SCIMGroup, error) {
+	name := strings.TrimSpace(lo.FromPtr(param.Name))
+	if name == "" {
+		return nil, interfaces.ErrSCIMInvalidGroupName
+	}
+
+	return Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*interfaces.SCIMGroup, error) {
+		t, err := i.repos.SCIMTenant.FindByID(ctx, tid)
+		if err != nil {
+			return nil, err
+		}
+		groups, err := i.groups(ctx, t)
+		if err != nil {
+			return nil, err
+		}
+		if lo.ContainsBy(groups, func(g interfaces.SCIMGroup) bool { return g.Workspace.Name() == name }) {
+			return nil, interfaces.ErrSCIMGroupNameConflict
+		}
+
+		wid := workspace.NewID()
+		if err := t.SetGroup(wid, lo.FromPtr(param.Role)); err != nil {
+			return nil, err
+		}
+		g := t.Group(wid)
+
+		ws, err := workspace.New().
+			ID(wid).
+			Alias("w-" + wid.String()).
+			Name(name).
+			Metadata(workspace.NewMetadata()).
+			CreatedAt(lo.ToPtr(time.Now())).
+			Build()
+		if err != nil {
+			return nil, err
+		}
+
+		members := slices.Concat(lo.FromPtr(param.Members), param.AddMembers)
+		joined, err := i.joinGroup(ctx, t, ws, g.Role, members)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := i.repos.Workspace.Create(ctx, ws); err != nil {
+			if errors.Is(err, workspace.ErrDuplicateWorkspaceAlias) {
+				return nil, interfaces.ErrWorkspaceAliasAlreadyExists
+			}
+			return nil, err
+		}
+		if err := i.workspace.bulkUpdatePermittable(ctx, wid, joined); err != nil {
+			return nil, err
+		}
+		if err := i.repos.SCIMTenant.Save(ctx, t); err != nil {
+			return nil, err
+		}
+		return &interfaces.SCIMGroup{Workspace: ws, Role: g.Role}, nil
+	})
+}
+
+func (i *SCIM) UpdateGroup(ctx context.Context, tid scimtenant.ID, id workspace.ID, param interfaces.SCIMGroupParam) (*interfaces.SCIMGroup, error) {
+	return Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*interfaces.SCIMGroup, error) {
+		t, err := i.repos.SCIMTenant.FindByID(ctx, tid)
+		if err != nil {
+			return nil, err
+		}
+		g := t.Group(id)
+		if g == nil {
+			return nil, rerror.ErrNotFound
+		}
+		ws, err := i.repos.Workspace.FindByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+
+		if param.Name != nil {
+			name := strings.TrimSpace(*param.Name)
+			if name == "" {
+				return nil, interfaces.ErrSCIMInvalidGroupName
+			}
+			ws.Rename(name)
+		}
+
+		// Members leave before the role changes, so that only the remaining
+		// members get their role updated.
+		var remove user.IDList
+		if param.Members != nil {
+			for _, uid := range ws.Members().UserIDs() {
+				if !param.Members.Has(uid) {
+					remove = append(remove, uid)
+				}
+			}
+		}
+		for _, uid := range param.RemoveMembers {
+			if ws.Members().HasUser(uid) && !remove.Has(uid) {
+				remove = append(remove, uid)
+			}
+		}
+		for _, uid := range remove {
+			if err := ws.Members().Leave(uid); err != nil {
+				return nil, err
+			}
+		}
+
+		updated := map[user.ID]role.RoleType{}
+		if param.Role != nil && *param.Role != g.Role {
+			if err := t.SetGroup(id, *param.Role); err != nil {
+				return nil, err
+			}
+			g = t.Group(id)
+			for _, uid := range ws.Members().UserIDs() {
+				if err := ws.Members().UpdateUserRole(uid, g.Role); err != nil {
+					return nil, err
+				}
+				updated[uid] = g.Role
+			}
+		}
+
+		var add user.IDList
+		for _, uid := range slices.Concat(lo.FromPtr(param.Members), param.AddMembers) {
+			if !ws.Members().HasUser(uid) && !add.Has(uid) && !param.RemoveMembers.Has(uid) {
+				add = append(add, uid)
+			}
+		}
+		joined, err := i.joinGroup(ctx, t, ws, g.Role, add)
+		if err != nil {
+			return nil, err
+		}
+		for uid, r := range joined {
+			updated[uid] = r
+		}
+
+		if err := i.workspace.bulkRemovePermittable(ctx, id, remove); err != nil {
+			return nil, err
+		}
+		if err := i.workspace.bulkUpdatePermittable(ctx, id, updated); err != nil {
+			return nil, err
+		}
+		if err := i.repos.Workspace.Save(ctx, ws); err != nil {
+			return nil, err
+		}
+		if err := i.repos.SCIMTenant.Save(ctx, t); err != nil {
+			return nil, err
+		}
+		return &interfaces.SCIMGroup{Workspace: ws, Role: g.Role}, nil
+	})
+}
+
+// DeleteGroup removes every member from the workspace and deactivates it. The
+// workspace itself and its data are kept so that an administrator can restore
+// it.
+func (i *SCIM) DeleteGroup(ctx context.Context, tid scimtenant.ID, id workspace.ID) error {
+	return Run0(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) error {
+		t, err := i.repos.SCIMTenant.FindByID(ctx, tid)
+		if err != nil {
+			return err
+		}
+		if t.Group(id) == nil {
+			return rerror.ErrNotFound
+		}
+		ws, err := i.repos.Workspace.FindByID(ctx, id)
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return err
+		}
+
+		if ws != nil {
+			members := user.IDList(ws.Members().UserIDs())
+			for _, uid := range members {
+				if err := ws.Members().Leave(uid); err != nil {
+					return err
+				}
+			}
+			if err := i.workspace.bulkRemovePermittable(ctx, id, members); err != nil {
+				return err
+			}
+			ws.Delete()
+			if err := i.repos.Workspace.Save(ctx, ws); err != nil {
+				return err
+			}
+		}
+
+		t.RemoveGroup(id)
+		return i.repos.SCIMTenant.Save(ctx, t)
+	})
+}
+
+// groups returns the workspaces provisioned by the tenant in the order they
+// were created. Workspaces removed outside of SCIM are skipped.
+func (i *SCIM) groups(ctx context.Context, t *scimtenant.Tenant) ([]interfaces.SCIMGroup, error) {
+	groups := t.Groups()
+	if len(groups) == 0 {
+		return nil, nil
+	}
+	ids := lo.Map(groups, func(g scimtenant.Group, _ int) workspace.ID { return g.Workspace })
+	wss, err := i.repos.Workspace.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[workspace.ID]*workspace.Workspace, len(wss))
+	for _, ws := range wss {
+		if ws != nil {
+			byID[ws.ID()] = ws
+		}
+	}
+	res := make([]interfaces.SCIMGroup, 0, len(groups))
+	for _, g := range groups {
+		if ws, ok := byID[g.Workspace]; ok {
+			res = append(res, interfaces.SCIMGroup{Workspace: ws, Role: g.Role})
+		}
+	}
+	return res, nil
+}
+
+// joinGroup adds users provisioned by the tenant to ws with the role of the
+// group, and returns the roles to grant them.
+func (i *SCIM) joinGroup(ctx context.Context, t *scimtenant.Tenant, ws *workspace.Workspace, r role.RoleType, ids user.IDList) (map[user.ID]role.RoleType, error) {
+	joined := map[user.ID]role.RoleType{}
+	if len(ids) == 0 {
+		return joined, nil
+	}
+	for _, uid := range ids {
+		if !t.HasUser(uid) {
+			return nil, interfaces.ErrSCIMMemberNotFound
+		}
+	}
+	users, err := i.repos.User.FindByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	for _, u := range users {
+		if u == nil || ws.Members().HasUser(u.ID()) {
+			continue
+		}
+		// Provisioned members are not invited by anyone, so they are recorded
+		// as having joined by themselves.
+		if err := ws.Members().Join(u, r, u.ID()); err != nil {
+			return nil, err
+		}
+		joined[u.ID()] = r
+	}
+	return joined, nil
+}
+
+// paginate returns the window of l starting at offset with at most limit
+// elements.
+func paginate[T any](l []T, offset, limit int) []T {
+	if offset < 0 {
+		offset = 0
+	}
+	if offset >= len(l) || limit <= 0 {
+		return []T{}
+	}
+	return l[offset:min(offset+limit, len(l))]
+}
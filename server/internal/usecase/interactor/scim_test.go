@@ -0,0 +1,242 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/samber/lo"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newSCIMTest(t *testing.T) (context.Context, *repo.Container, *scimtenant.Tenant, string) {
+	t.Helper()
+	ctx := context.Background()
+	r := memory.New()
+	for _, name := range []string{interfaces.RoleSelf, role.RoleOwner.String(), role.RoleMaintainer.String(), role.RoleWriter.String(), role.RoleReader.String()} {
+		require.NoError(t, r.Role.Save(ctx, *role.New().NewID().Name(name).MustBuild()))
+	}
+	tn := scimtenant.New().NewID().Name("okta").SubPrefix("samlp|okta").MustBuild()
+	token, err := tn.RotateToken()
+	require.NoError(t, err)
+	require.NoError(t, r.SCIMTenant.Save(ctx, tn))
+	return ctx, r, tn, token
+}
+
+func TestSCIM_Authenticate(t *testing.T) {
+	ctx, r, tn, token := newSCIMTest(t)
+	uc := NewSCIM(r)
+
+	got, err := uc.Authenticate(ctx, token)
+	require.NoError(t, err)
+	assert.Equal(t, tn.ID(), got.ID())
+
+	_, err = uc.Authenticate(ctx, "wrong")
+	assert.ErrorIs(t, err, interfaces.ErrInvalidSCIMToken)
+	_, err = uc.Authenticate(ctx, "")
+	assert.ErrorIs(t, err, interfaces.ErrInvalidSCIMToken)
+}
+
+func TestSCIM_Users(t *testing.T) {
+	ctx, r, tn, _ := newSCIMTest(t)
+	uc := NewSCIM(r)
+
+	u, err := uc.CreateUser(ctx, tn.ID(), interfaces.SCIMUserParam{
+		UserName: lo.ToPtr("alice@example.com"),
+		Name:     lo.ToPtr("Alice"),
+		Email:    lo.ToPtr("alice@example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Alice", u.Name())
+	assert.True(t, u.Auths().Has("samlp|okta|alice@example.com"))
+
+	// the user signs in via SSO as the provisioned user
+	bySub, err := r.User.FindBySub(ctx, "samlp|okta|alice@example.com")
+	require.NoError(t, err)
+	assert.Equal(t, u.ID(), bySub.ID())
+	p, err := r.Permittable.FindByUserID(ctx, u.ID())
+	require.NoError(t, err)
+	assert.Len(t, p.WorkspaceRoles(), 1, "a personal workspace is created like SyncSSOUser")
+
+	_, err = uc.CreateUser(ctx, tn.ID(), interfaces.SCIMUserParam{
+		UserName: lo.ToPtr("alice@example.com"),
+		Email:    lo.ToPtr("alice@example.com"),
+	})
+	assert.ErrorIs(t, err, interfaces.ErrSCIMUserNameTaken)
+
+	res, total, err := uc.FindUsers(ctx, tn.ID(), interfaces.SCIMUserFilter{UserName: lo.ToPtr("alice@example.com"), Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, user.List{u}, res)
+
+	// users of other tenants or signed up otherwise are not visible
+	other := user.New().NewID().Name("Bob").Email("bob@example.com").Workspace(u.Workspace()).MustBuild()
+	require.NoError(t, r.User.Save(ctx, other))
+	res, total, err = uc.FindUsers(ctx, tn.ID(), interfaces.SCIMUserFilter{Email: lo.ToPtr("bob@example.com"), Limit: 10})
+	require.NoError(t, err)
+	assert.Zero(t, total)
+	assert.Empty(t, res)
+	_, err = uc.FindUser(ctx, tn.ID(), other.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	u, err = uc.UpdateUser(ctx, tn.ID(), u.ID(), interfaces.SCIMUserParam{
+		UserName: lo.ToPtr("alice2@example.com"),
+		Active:   lo.ToPtr(false),
+	})
+	require.NoError(t, err)
+	assert.True(t, u.IsDeleted())
+	assert.False(t, u.Auths().Has("samlp|okta|alice@example.com"))
+	assert.True(t, u.Auths().Has("samlp|okta|alice2@example.com"))
+
+	_, err = uc.UpdateUser(ctx, tn.ID(), u.ID(), interfaces.SCIMUserParam{Email: lo.ToPtr("bob@example.com")})
+	assert.ErrorIs(t, err, interfaces.ErrUserAlreadyExists)
+
+	res, total, err = uc.FindUsers(ctx, tn.ID(), interfaces.SCIMUserFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Len(t, res, 1)
+}
+
+func TestSCIM_DeleteUser(t *testing.T) {
+	ctx, r, tn, _ := newSCIMTest(t)
+	uc := NewSCIM(r)
+
+	u, err := uc.CreateUser(ctx, tn.ID(), interfaces.SCIMUserParam{
+		UserName: lo.ToPtr("alice"),
+		Email:    lo.ToPtr("alice@example.com"),
+	})
+	require.NoError(t, err)
+	g, err := uc.CreateGroup(ctx, tn.ID(), interfaces.SCIMGroupParam{
+		Name:    lo.ToPtr("Team"),
+		Members: &user.IDList{u.ID()},
+	})
+	require.NoError(t, err)
+
+	require.NoError(t, uc.DeleteUser(ctx, tn.ID(), u.ID()))
+
+	got, err := r.User.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	assert.True(t, got.IsDeleted())
+	ws, err := r.Workspace.FindByID(ctx, g.Workspace.ID())
+	require.NoError(t, err)
+	assert.False(t, ws.Members().HasUser(u.ID()))
+	_, err = uc.FindUser(ctx, tn.ID(), u.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+
+	// provisioning the user again reactivates it
+	again, err := uc.CreateUser(ctx, tn.ID(), interfaces.SCIMUserParam{
+		UserName: lo.ToPtr("alice"),
+		Email:    lo.ToPtr("alice@example.com"),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, u.ID(), again.ID())
+	assert.False(t, again.IsDeleted())
+}
+
+func TestSCIM_CreateUser_NotProvisioned(t *testing.T) {
+	ctx, r, tn, _ := newSCIMTest(t)
+	uc := NewSCIM(r)
+
+	// a user who signed in with the sub of the tenant before it provisioned it
+	carol := user.New().NewID().Name("Carol").Email("carol@example.com").
+		Auths([]user.Auth{user.AuthFrom("samlp|okta|carol")}).MustBuild()
+	require.NoError(t, r.User.Save(ctx, carol))
+
+	_, err := uc.CreateUser(ctx, tn.ID(), interfaces.SCIMUserParam{
+		UserName: lo.ToPtr("carol"),
+		Email:    lo.ToPtr("mallory@example.com"),
+	})
+	assert.ErrorIs(t, err, interfaces.ErrSCIMUserNameTaken)
+
+	got, err := r.User.FindByID(ctx, carol.ID())
+	require.NoError(t, err)
+	assert.Equal(t, "carol@example.com", got.Email())
+	tn, err = r.SCIMTenant.FindByID(ctx, tn.ID())
+	require.NoError(t, err)
+	assert.False(t, tn.Provisioned(carol.ID()))
+}
+
+func TestSCIM_Groups(t *testing.T) {
+	ctx, r, tn, _ := newSCIMTest(t)
+	uc := NewSCIM(r)
+
+	var ids user.IDList
+	for _, name := range []string{"alice", "bob", "carol"} {
+		u, err := uc.CreateUser(ctx, tn.ID(), interfaces.SCIMUserParam{
+			UserName: lo.ToPtr(name),
+			Email:    lo.ToPtr(name + "@example.com"),
+		})
+		require.NoError(t, err)
+		ids = append(ids, u.ID())
+	}
+	stranger := user.New().NewID().Name("dave").Email("dave@example.com").Workspace(id.NewWorkspaceID()).MustBuild()
+	require.NoError(t, r.User.Save(ctx, stranger))
+	_, err := uc.CreateGroup(ctx, tn.ID(), interfaces.SCIMGroupParam{
+		Name:    lo.ToPtr("Team"),
+		Members: &user.IDList{stranger.ID()},
+	})
+	assert.ErrorIs(t, err, interfaces.ErrSCIMMemberNotFound)
+
+	g, err := uc.CreateGroup(ctx, tn.ID(), interfaces.SCIMGroupParam{
+		Name:    lo.ToPtr("Team"),
+		Role:    lo.ToPtr(role.RoleWriter),
+		Members: &user.IDList{ids[0], ids[1]},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, role.RoleWriter, g.Role)
+	assert.False(t, g.Workspace.IsPersonal())
+	assert.Equal(t, role.RoleWriter, g.Workspace.Members().UserRole(ids[0]))
+
+	p, err := r.Permittable.FindByUserID(ctx, ids[0])
+	require.NoError(t, err)
+	writer, err := r.Role.FindByName(ctx, role.RoleWriter.String())
+	require.NoError(t, err)
+	assert.Contains(t, p.WorkspaceRoles(), permittable.NewWorkspaceRole(g.Workspace.ID(), writer.ID()))
+
+	_, err = uc.CreateGroup(ctx, tn.ID(), interfaces.SCIMGroupParam{Name: lo.ToPtr("Team")})
+	assert.ErrorIs(t, err, interfaces.ErrSCIMGroupNameConflict)
+
+	g, err = uc.UpdateGroup(ctx, tn.ID(), g.Workspace.ID(), interfaces.SCIMGroupParam{
+		Name:          lo.ToPtr("Renamed"),
+		Role:          lo.ToPtr(role.RoleMaintainer),
+		AddMembers:    user.IDList{ids[2]},
+		RemoveMembers: user.IDList{ids[0]},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "Renamed", g.Workspace.Name())
+	assert.False(t, g.Workspace.Members().HasUser(ids[0]))
+	assert.Equal(t, role.RoleMaintainer, g.Workspace.Members().UserRole(ids[1]))
+	assert.Equal(t, role.RoleMaintainer, g.Workspace.Members().UserRole(ids[2]))
+
+	p, err = r.Permittable.FindByUserID(ctx, ids[0])
+	require.NoError(t, err)
+	for _, wr := range p.WorkspaceRoles() {
+		assert.NotEqual(t, g.Workspace.ID(), wr.ID())
+	}
+
+	g, err = uc.UpdateGroup(ctx, tn.ID(), g.Workspace.ID(), interfaces.SCIMGroupParam{Members: &user.IDList{ids[0]}})
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []user.ID{ids[0]}, g.Workspace.Members().UserIDs())
+
+	groups, total, err := uc.FindGroups(ctx, tn.ID(), interfaces.SCIMGroupFilter{Name: lo.ToPtr("Renamed"), Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, 1, total)
+	assert.Equal(t, g.Workspace.ID(), groups[0].Workspace.ID())
+
+	require.NoError(t, uc.DeleteGroup(ctx, tn.ID(), g.Workspace.ID()))
+	ws, err := r.Workspace.FindByID(ctx, g.Workspace.ID())
+	require.NoError(t, err)
+	assert.True(t, ws.IsDeleted())
+	assert.Zero(t, ws.Members().Count())
+	_, err = uc.FindGroup(ctx, tn.ID(), g.Workspace.ID())
+	assert.ErrorIs(t, err, rerror.ErrNotFound)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/reearth/reearthx/log"
 	"github.com/reearth/reearthx/mailer"
 	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
 )
 
 type User struct {
@@ -115,7 +116,8 @@ func (i *User) FetchByNameOrAlias(ctx context.Context, nameOrAlias string) (user
 }
 
 func (i *User) GetUserByCredentials(ctx context.Context, inp interfaces.GetUserByCredentials) (u *user.User, err error) {
-	return Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
+	var mfaErr error
+	u, err = Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
 		u, err = i.repos.User.FindByNameOrEmail(ctx, inp.Email)
 		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
 			return nil, err
@@ -126,14 +128,52 @@ func (i *User) GetUserByCredentials(ctx context.Context, inp interfaces.GetUserB
 		if err != nil {
 			return nil, err
 		}
-		if !matched {
+		if !matched || u.IsDeleted() {
 			return nil, interfaces.ErrInvalidEmailOrPassword
 		}
 		if u.Verification() == nil || !u.Verification().IsVerified() {
 			return nil, interfaces.ErrNotVerifiedUser
 		}
+		if u.MFA().IsEnabled() {
+			if mfaErr = verifyMFA(u, inp.MFACode); mfaErr != nil && !errors.Is(mfaErr, user.ErrInvalidMFACode) {
+				return nil, mfaErr
+			}
+			// Persist the consumed TOTP step / recovery code so neither can be
+			// replayed, or the failed attempt so it counts towards the lockout.
+			if err := i.repos.User.Save(ctx, u); err != nil {
+				return nil, err
+			}
+			if mfaErr != nil {
+				// returned below, so the failure is committed
+				return nil, nil
+			}
+		}
 		return u, nil
 	})
+	if err == nil && mfaErr != nil {
+		return nil, mfaErr
+	}
+	return u, err
+}
+
+// verifyMFA checks the second factor of a user with MFA enabled and records on
+// u the consumed TOTP step or recovery code, or the failed attempt. The caller
+// must save u, also when it returns user.ErrInvalidMFACode, and commit that
+// save so that wrong codes add up to a lockout.
+func verifyMFA(u *user.User, code string) error {
+	m := u.MFA().Clone()
+	if !m.IsEnabled() {
+		return nil
+	}
+	if code == "" {
+		return interfaces.ErrMFARequired
+	}
+	err := m.Verify(code, util.Now())
+	if err != nil && !errors.Is(err, user.ErrInvalidMFACode) {
+		return err
+	}
+	u.SetMFA(m)
+	return err
 }
 
 func (i *User) GetUserBySubject(ctx context.Context, sub string) (u *user.User, err error) {
@@ -257,9 +297,11 @@ func (i *User) UpdateMe(ctx context.Context, p interfaces.UpdateMeParam, operato
 		// Auth0 subs go to Auth0. CIP (Cloud Identity Platform, used by Veda) is
 		// deliberately skipped: the accounts DB record is the source of truth for
 		// display name there, and Veda manages its own IdP state independently.
+		// The built-in reearth provider has no external IdP, so it is skipped too.
 		if p.Name != nil || p.Email != nil || p.Password != nil {
 			for _, a := range u.Auths() {
-				if gateway.Provider(a.Provider) == gateway.ProviderCIP || a.Provider == "" {
+				if gateway.Provider(a.Provider) == gateway.ProviderCIP || a.Provider == "" ||
+					gateway.Provider(a.Provider) == gateway.ProviderReearth {
 					continue
 				}
 				authenticator := i.gateways.AuthenticatorFor(a.Provider)
@@ -321,6 +363,10 @@ func (i *User) RemoveMyAuth(ctx context.Context, authProvider string, operator *
 			return nil, err
 		}
 
+		// the user must keep a way to sign in
+		if u.HasAuthProvider(authProvider) && len(u.Auths()) == 1 {
+			return nil, user.ErrLastAuth
+		}
 		u.RemoveAuthByProvider(authProvider)
 
 		err = i.repos.User.Save(ctx, u)
@@ -346,7 +392,7 @@ func (i *User) DisableMFA(ctx context.Context, operator *workspace.Operator) err
 		if err != nil {
 			return err
 		}
-		a := u.Auths().GetByProvider(user.ProviderAuth0)
+		a := mfaAuth(u)
 		if a == nil {
 			return rerror.NewE(i18n.T("no authenticator found"))
 		}
@@ -367,7 +413,7 @@ func (i *User) EnableMFA(ctx context.Context, operator *workspace.Operator) (str
 		if err != nil {
 			return "", err
 		}
-		a := u.Auths().GetByProvider(user.ProviderAuth0)
+		a := mfaAuth(u)
 		if a == nil {
 			return "", rerror.NewE(i18n.T("no authenticator found"))
 		}
@@ -379,6 +425,30 @@ func (i *User) EnableMFA(ctx context.Context, operator *workspace.Operator) (str
 	})
 }
 
+// ConfirmMFA completes an in-app enrollment started by EnableMFA and returns the
+// initial recovery codes. Providers enrolled through an external ticket flow
+// (Auth0) do not support it.
+func (i *User) ConfirmMFA(ctx context.Context, code string, operator *workspace.Operator) ([]string, error) {
+	if operator == nil || operator.User == nil {
+		return nil, interfaces.ErrInvalidOperator
+	}
+	return Run1(ctx, operator, i.repos, Usecase(), func(ctx context.Context) ([]string, error) {
+		u, err := i.repos.User.FindByID(ctx, *operator.User)
+		if err != nil {
+			return nil, err
+		}
+		a := mfaAuth(u)
+		if a == nil {
+			return nil, rerror.NewE(i18n.T("no authenticator found"))
+		}
+		confirmer, ok := i.gateways.AuthenticatorFor(a.Provider).(gateway.MFAConfirmer)
+		if !ok {
+			return nil, interfaces.ErrMFAConfirmationNotSupported
+		}
+		return confirmer.ConfirmMFA(ctx, a.Sub, code)
+	})
+}
+
 func (i *User) GetMFAStatus(ctx context.Context, operator *workspace.Operator) (gateway.MFAStatus, error) {
 	if operator == nil || operator.User == nil {
 		return gateway.MFAStatus{}, interfaces.ErrInvalidOperator
@@ -388,7 +458,7 @@ func (i *User) GetMFAStatus(ctx context.Context, operator *workspace.Operator) (
 		if err != nil {
 			return gateway.MFAStatus{}, err
 		}
-		a := u.Auths().GetByProvider(user.ProviderAuth0)
+		a := mfaAuth(u)
 		if a == nil {
 			return gateway.MFAStatus{Enrolled: false}, nil
 		}
@@ -412,27 +482,41 @@ func (i *User) GetMFAStatus(ctx context.Context, operator *workspace.Operator) (
 // present on most accounts, so it wouldn't meaningfully close this gap going
 // forward. The real fix needs a step-up mechanism through the identity
 // provider (Auth0), which is planned separately and not covered here.
-func (i *User) RegenerateMFARecoveryCode(ctx context.Context, operator *workspace.Operator) (string, error) {
+func (i *User) RegenerateMFARecoveryCode(ctx context.Context, operator *workspace.Operator) ([]string, error) {
 	if operator == nil || operator.User == nil {
-		return "", interfaces.ErrInvalidOperator
+		return nil, interfaces.ErrInvalidOperator
 	}
-	return Run1(ctx, operator, i.repos, Usecase(), func(ctx context.Context) (string, error) {
+	return Run1(ctx, operator, i.repos, Usecase(), func(ctx context.Context) ([]string, error) {
 		u, err := i.repos.User.FindByID(ctx, *operator.User)
 		if err != nil {
-			return "", err
+			return nil, err
 		}
-		a := u.Auths().GetByProvider(user.ProviderAuth0)
+		a := mfaAuth(u)
 		if a == nil {
-			return "", rerror.NewE(i18n.T("no authenticator found"))
+			return nil, rerror.NewE(i18n.T("no authenticator found"))
 		}
 		authenticator := i.gateways.AuthenticatorFor(a.Provider)
 		if authenticator == nil {
-			return "", rerror.NewE(i18n.T("no authenticator found"))
+			return nil, rerror.NewE(i18n.T("no authenticator found"))
 		}
 		return authenticator.RegenerateMFARecoveryCode(ctx, a.Sub)
 	})
 }
 
+// mfaAuth picks the auth record whose provider manages the user's second
+// factor: Auth0 when linked, otherwise the built-in reearth provider. CIP users
+// have no MFA support.
+func mfaAuth(u *user.User) *user.Auth {
+	if a := u.Auths().GetByProvider(user.ProviderAuth0); a != nil {
+		return a
+	}
+	return u.Auths().GetByProvider(user.ProviderReearth)
+}
+
+// DeleteMe schedules the deletion of the signed-in user. The user is
+// deactivated right away and purged, together with the workspaces they are the
+// only owner of, once the retention window has passed, unless they call
+// CancelMyDeletion before then.
 func (i *User) DeleteMe(ctx context.Context, userID user.ID, operator *workspace.Operator) (err error) {
 	if operator.User == nil {
 		return interfaces.ErrInvalidOperator
@@ -446,52 +530,34 @@ func (i *User) DeleteMe(ctx context.Context, userID user.ID, operator *workspace
 		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
 			return err
 		}
-		if u == nil {
+		if u == nil || u.DeletionRequestedAt() != nil {
 			return nil
 		}
 
-		workspaces, err := i.repos.Workspace.FindByUser(ctx, u.ID())
-		if err != nil {
-			return err
-		}
-
-		updatedWorkspaces := make([]*workspace.Workspace, 0, len(workspaces))
-		deletedWorkspaces := []user.WorkspaceID{}
-
-		for _, ws := range workspaces {
-			if !ws.IsPersonal() && !ws.Members().IsOnlyOwner(u.ID()) {
-				if err := ws.Members().Leave(u.ID()); err != nil {
-					if errors.Is(err, workspace.ErrTargetUserNotInTheWorkspace) {
-						// User already removed by a concurrent change; nothing to save
-						continue
-					}
-					return err
-				}
-				updatedWorkspaces = append(updatedWorkspaces, ws)
-				continue
-			}
-
-			deletedWorkspaces = append(deletedWorkspaces, ws.ID())
-		}
+		u.RequestDeletion()
+		return i.repos.User.Save(ctx, u)
+	})
+}
 
-		// Save workspaces
-		if err := i.repos.Workspace.SaveAll(ctx, updatedWorkspaces); err != nil {
-			return err
+// CancelMyDeletion reactivates the signed-in user if they scheduled the
+// deletion of their account with DeleteMe and it has not been purged yet.
+func (i *User) CancelMyDeletion(ctx context.Context, operator *workspace.Operator) (*user.User, error) {
+	if operator == nil || operator.User == nil {
+		return nil, interfaces.ErrInvalidOperator
+	}
+	return Run1(ctx, operator, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
+		u, err := i.repos.User.FindByID(ctx, *operator.User)
+		if err != nil {
+			return nil, err
 		}
-
-		// Delete workspaces
-		if err := i.repos.Workspace.RemoveAll(ctx, deletedWorkspaces); err != nil {
-			return err
+		if err := u.CancelDeletion(); err != nil {
+			return nil, err
 		}
-
-		// Delete user
-		if err := i.repos.User.Remove(ctx, u.ID()); err != nil {
-			return err
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return nil, err
 		}
-
-		return nil
+		return u, nil
 	})
-
 }
 
 // Deactivate soft-deletes a user (sets deleted_at). Same permission model as
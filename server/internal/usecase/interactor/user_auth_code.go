@@ -0,0 +1,74 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// IssueAuthCode stores a fresh authorization code of the built-in OIDC provider
+// on an authenticated user, next to the codes still pending. The returned code is
+// prefixed with the user ID so RedeemAuthCode can find the user without a
+// separate lookup table.
+func (i *User) IssueAuthCode(ctx context.Context, uid user.ID, req user.AuthCode) (string, error) {
+	return Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (string, error) {
+		u, err := i.repos.User.FindByID(ctx, uid)
+		if err != nil {
+			return "", err
+		}
+		if u.IsDeleted() {
+			return "", rerror.ErrNotFound
+		}
+		c, code, err := user.IssueAuthCode(req)
+		if err != nil {
+			return "", err
+		}
+		u.AddAuthCode(c)
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return "", err
+		}
+		return uid.String() + "." + code, nil
+	})
+}
+
+// RedeemAuthCode exchanges a code issued by IssueAuthCode for its user and
+// authorization request. A code can be tried only once: it is removed even when
+// the client, redirect URI or verifier does not match.
+func (i *User) RedeemAuthCode(ctx context.Context, inp interfaces.RedeemAuthCodeParam) (*user.User, *user.AuthCode, error) {
+	u, c, err := Run2(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, *user.AuthCode, error) {
+		uidStr, code, ok := strings.Cut(inp.Code, ".")
+		if !ok {
+			return nil, nil, interfaces.ErrInvalidAuthCode
+		}
+		uid, err := user.IDFrom(uidStr)
+		if err != nil {
+			return nil, nil, interfaces.ErrInvalidAuthCode
+		}
+		u, err := i.repos.User.FindByID(ctx, uid)
+		if errors.Is(err, rerror.ErrNotFound) {
+			return nil, nil, interfaces.ErrInvalidAuthCode
+		} else if err != nil {
+			return nil, nil, err
+		}
+		if u.IsDeleted() {
+			return nil, nil, interfaces.ErrInvalidAuthCode
+		}
+		c := u.RedeemAuthCode(code, inp.ClientID, inp.RedirectURI, inp.CodeVerifier)
+		// the removal of a rejected code is saved as well
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return nil, nil, err
+		}
+		return u, c, nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	if c == nil {
+		return nil, nil, interfaces.ErrInvalidAuthCode
+	}
+	return u, c, nil
+}
@@ -0,0 +1,61 @@
+package interactor
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_RedeemAuthCode(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().
+		NewID().
+		Workspace(id.NewWorkspaceID()).
+		Name("Test User").
+		Email("test@example.com").
+		MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	uc := NewUser(r, &gateway.Container{}, nil, "", "").(*User)
+
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	sum := sha256.Sum256([]byte(verifier))
+	req := user.AuthCode{
+		ClientID:      "client",
+		RedirectURI:   "https://app.example.com/callback",
+		CodeChallenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}
+	code1, err := uc.IssueAuthCode(ctx, u.ID(), req)
+	require.NoError(t, err)
+	code2, err := uc.IssueAuthCode(ctx, u.ID(), req)
+	require.NoError(t, err)
+
+	param := func(code, verifier string) interfaces.RedeemAuthCodeParam {
+		return interfaces.RedeemAuthCodeParam{Code: code, ClientID: "client", RedirectURI: "https://app.example.com/callback", CodeVerifier: verifier}
+	}
+
+	got, c, err := uc.RedeemAuthCode(ctx, param(code1, verifier))
+	require.NoError(t, err)
+	assert.Equal(t, u.ID(), got.ID())
+	assert.Equal(t, "client", c.ClientID)
+	_, _, err = uc.RedeemAuthCode(ctx, param(code1, verifier))
+	assert.ErrorIs(t, err, interfaces.ErrInvalidAuthCode)
+
+	// the rejected code stays removed
+	_, _, err = uc.RedeemAuthCode(ctx, param(code2, "wrong-verifier"))
+	assert.ErrorIs(t, err, interfaces.ErrInvalidAuthCode)
+	_, _, err = uc.RedeemAuthCode(ctx, param(code2, verifier))
+	assert.ErrorIs(t, err, interfaces.ErrInvalidAuthCode)
+	saved, err := r.User.FindByID(ctx, u.ID())
+	require.NoError(t, err)
+	assert.Empty(t, saved.AuthCodes())
+}
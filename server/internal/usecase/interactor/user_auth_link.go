@@ -0,0 +1,82 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+// linkTokenMaxAge bounds how long ago the token proving the new identity may
+// have been issued, so that only an identity the user has just signed in with
+// can be linked.
+const linkTokenMaxAge = 10 * time.Minute
+
+// LinkMyAuth links a second identity to the signed-in user. The operator
+// proves the current identity and token, which must be issued by a configured
+// provider within linkTokenMaxAge, proves the new one.
+func (i *User) LinkMyAuth(ctx context.Context, token string, operator *workspace.Operator) (*user.User, error) {
+	if operator == nil || operator.User == nil {
+		return nil, interfaces.ErrInvalidOperator
+	}
+	if i.gateways == nil || i.gateways.TokenVerifier == nil {
+		return nil, interfaces.ErrAuthLinkNotConfigured
+	}
+
+	vt, err := i.gateways.TokenVerifier.VerifyToken(ctx, token)
+	if err != nil {
+		log.Debugfc(ctx, "[LinkMyAuth] invalid token: %v", err)
+		return nil, interfaces.ErrInvalidLinkToken
+	}
+	if vt.IssuedAt.IsZero() || util.Now().Sub(vt.IssuedAt) > linkTokenMaxAge {
+		return nil, interfaces.ErrInvalidLinkToken
+	}
+
+	sub, email := vt.Sub, vt.Email
+	if r := i.gateways.IdentityResolverFor(vt.Issuer); r != nil {
+		au, err := r.ResolveIdentity(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		sub, email = au.ID, au.Email
+	}
+	if sub == "" {
+		return nil, interfaces.ErrInvalidLinkToken
+	}
+
+	return Run1(ctx, operator, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
+		u, err := i.repos.User.FindByID(ctx, *operator.User)
+		if err != nil {
+			return nil, err
+		}
+		if u.Auths().Has(sub) {
+			return nil, interfaces.ErrAuthAlreadyLinked
+		}
+
+		other, err := i.repos.User.FindBySub(ctx, sub)
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return nil, err
+		}
+		if other != nil {
+			return nil, interfaces.ErrAuthLinkedToAnotherUser
+		}
+
+		if err := u.LinkAuth(user.AuthFrom(sub), user.AuthLink{
+			Issuer:   vt.Issuer,
+			Email:    email,
+			LinkedAt: util.Now(),
+		}); err != nil {
+			return nil, err
+		}
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return nil, err
+		}
+		return u, nil
+	})
+}
@@ -0,0 +1,141 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTokenVerifier struct {
+	token gateway.VerifiedToken
+	err   error
+}
+
+func (f *fakeTokenVerifier) VerifyToken(_ context.Context, _ string) (gateway.VerifiedToken, error) {
+	return f.token, f.err
+}
+
+func TestUser_LinkMyAuth(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	freshToken := gateway.VerifiedToken{
+		Issuer:   "https://example.auth0.com/",
+		Sub:      "google-oauth2|123",
+		Email:    "alice@example.com",
+		IssuedAt: now.Add(-time.Minute),
+	}
+
+	tests := []struct {
+		name     string
+		verifier gateway.TokenVerifier
+		other    bool
+		auths    []user.Auth
+		wantErr  error
+	}{
+		{
+			name:     "links a fresh identity",
+			verifier: &fakeTokenVerifier{token: freshToken},
+		},
+		{
+			name:    "not configured",
+			wantErr: interfaces.ErrAuthLinkNotConfigured,
+		},
+		{
+			name:     "invalid token",
+			verifier: &fakeTokenVerifier{err: errors.New("bad signature")},
+			wantErr:  interfaces.ErrInvalidLinkToken,
+		},
+		{
+			name: "stale token",
+			verifier: &fakeTokenVerifier{token: gateway.VerifiedToken{
+				Issuer:   freshToken.Issuer,
+				Sub:      freshToken.Sub,
+				IssuedAt: now.Add(-linkTokenMaxAge - time.Second),
+			}},
+			wantErr: interfaces.ErrInvalidLinkToken,
+		},
+		{
+			name:     "already linked",
+			verifier: &fakeTokenVerifier{token: freshToken},
+			auths:    []user.Auth{user.AuthFrom("auth0|1"), user.AuthFrom(freshToken.Sub)},
+			wantErr:  interfaces.ErrAuthAlreadyLinked,
+		},
+		{
+			name:     "linked to another user",
+			verifier: &fakeTokenVerifier{token: freshToken},
+			other:    true,
+			wantErr:  interfaces.ErrAuthLinkedToAnotherUser,
+		},
+		{
+			name:     "provider already linked",
+			verifier: &fakeTokenVerifier{token: freshToken},
+			auths:    []user.Auth{user.AuthFrom("auth0|1"), user.AuthFrom("google-oauth2|999")},
+			wantErr:  user.ErrAuthProviderAlreadyLinked,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx := context.Background()
+			r := memory.New()
+			auths := tt.auths
+			if auths == nil {
+				auths = []user.Auth{user.AuthFrom("auth0|1")}
+			}
+			u := user.New().NewID().Workspace(id.NewWorkspaceID()).Name("alice").
+				Email("alice@example.com").Auths(auths).MustBuild()
+			require.NoError(t, r.User.Save(ctx, u))
+			if tt.other {
+				o := user.New().NewID().Workspace(id.NewWorkspaceID()).Name("bob").
+					Email("bob@example.com").Auths([]user.Auth{user.AuthFrom(freshToken.Sub)}).MustBuild()
+				require.NoError(t, r.User.Save(ctx, o))
+			}
+
+			uc := NewUser(r, &gateway.Container{TokenVerifier: tt.verifier}, nil, "", "")
+			uid := u.ID()
+			got, err := uc.LinkMyAuth(ctx, "token", &workspace.Operator{User: &uid})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.Nil(t, got)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, got.Auths().Has(freshToken.Sub))
+			assert.Equal(t, &user.AuthLink{
+				Sub:      freshToken.Sub,
+				Issuer:   freshToken.Issuer,
+				Email:    freshToken.Email,
+				LinkedAt: now,
+			}, got.AuthLink(freshToken.Sub))
+
+			saved, err := r.User.FindByID(ctx, uid)
+			require.NoError(t, err)
+			assert.Len(t, saved.Auths(), 2)
+		})
+	}
+}
+
+func TestUser_RemoveMyAuth_LastAuth(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().NewID().Workspace(id.NewWorkspaceID()).Name("alice").
+		Email("alice@example.com").Auths([]user.Auth{user.AuthFrom("google-oauth2|1")}).MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	uc := NewUser(r, nil, nil, "", "")
+	uid := u.ID()
+	_, err := uc.RemoveMyAuth(ctx, "google-oauth2", &workspace.Operator{User: &uid})
+	assert.ErrorIs(t, err, user.ErrLastAuth)
+}
@@ -0,0 +1,68 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	htmlTmpl "html/template"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/dataexport"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/reearth/reearthx/util"
+)
+
+var dataExportMailContent = mailContent{
+	Message:     "We've prepared a copy of the personal data Re:Earth holds about you: your profile, sign-in methods, workspace memberships, roles and the actions administrators took on your account. The link below downloads it as a ZIP archive and expires in 24 hours.",
+	Suffix:      "If you did not request this export, please contact us.",
+	ActionLabel: "Download your data",
+}
+
+// RequestMyDataExport gathers the personal data of the signed-in user into an
+// archive, uploads it to the storage and mails the user a signed link to
+// download it.
+func (i *User) RequestMyDataExport(ctx context.Context, operator *workspace.Operator) error {
+	if operator == nil || operator.User == nil {
+		return interfaces.ErrInvalidOperator
+	}
+
+	a, err := dataexport.Collect(ctx, dataexport.Repos{
+		User:        i.repos.User,
+		Workspace:   i.repos.Workspace,
+		Role:        i.repos.Role,
+		Permittable: i.repos.Permittable,
+		AuditLog:    i.repos.AuditLog,
+	}, *operator.User, util.Now())
+	if err != nil {
+		return err
+	}
+
+	link, err := a.Upload(ctx, i.gateways.Storage)
+	if err != nil {
+		return err
+	}
+
+	var textOut, htmlOut bytes.Buffer
+	content := mailContent{
+		UserName:    a.User.Name,
+		ActionURL:   htmlTmpl.URL(link),
+		Message:     dataExportMailContent.Message,
+		Suffix:      dataExportMailContent.Suffix,
+		ActionLabel: dataExportMailContent.ActionLabel,
+	}
+	if err := authTextTMPL.Execute(&textOut, content); err != nil {
+		return err
+	}
+	if err := authHTMLTMPL.Execute(&htmlOut, content); err != nil {
+		return err
+	}
+
+	if err := i.gateways.Mailer.SendMail(ctx, []mailer.Contact{{Email: a.User.Email, Name: a.User.Name}},
+		"Your Re:Earth data export", textOut.String(), htmlOut.String()); err != nil {
+		return err
+	}
+
+	log.Infofc(ctx, "user: data export of %s mailed: object=%s", a.User.ID, a.ObjectName())
+	return nil
+}
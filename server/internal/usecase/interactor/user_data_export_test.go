@@ -0,0 +1,53 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway/mock"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+)
+
+func TestUser_RequestMyDataExport(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().
+		NewID().
+		Workspace(id.NewWorkspaceID()).
+		Name("Test User").
+		Email("test@example.com").
+		MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	ctrl := gomock.NewController(t)
+	storage := mock.NewMockStorage(ctrl)
+	name := "users/" + u.ID().String() + "/data-exports/20260101T000000Z.zip"
+	storage.EXPECT().Upload(gomock.Any(), name, gomock.Any()).Return(nil)
+	storage.EXPECT().GetSignedURL(gomock.Any(), name).Return("https://storage.example.com/export.zip?sig=1", nil)
+
+	m := mailer.NewMock()
+	uc := NewUser(r, &gateway.Container{Mailer: m, Storage: storage}, nil, "", "")
+
+	assert.ErrorIs(t, uc.RequestMyDataExport(ctx, nil), interfaces.ErrInvalidOperator)
+	assert.Empty(t, m.Mails())
+
+	require.NoError(t, uc.RequestMyDataExport(ctx, &workspace.Operator{User: u.ID().Ref()}))
+	mails := m.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, []mailer.Contact{{Email: "test@example.com", Name: "Test User"}}, mails[0].To)
+	assert.Contains(t, mails[0].PlainContent, "https://storage.example.com/export.zip?sig=1")
+}
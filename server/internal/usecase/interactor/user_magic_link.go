@@ -0,0 +1,130 @@
+package interactor
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	htmlTmpl "html/template"
+	"net/url"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var magicLinkMailContent = mailContent{
+	Message:     "We've received a request to sign in to Re:Earth with this email address. Open the link below in the same browser you requested it from. It can be used once and expires in 15 minutes.",
+	Suffix:      "If you did not request this link, then you can ignore this email.",
+	ActionLabel: "Sign in to Re:Earth",
+}
+
+// StartMagicLink mails a single-use sign-in link to a verified user of the
+// built-in password provider. Unknown or ineligible emails, and emails that
+// asked for too many links, get no mail and no error, so the endpoint does not
+// reveal which addresses are registered.
+func (i *User) StartMagicLink(ctx context.Context, param interfaces.StartMagicLinkParam) error {
+	var contact mailer.Contact
+	var mailText, mailHTML string
+
+	if err := Run0(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) error {
+		u, err := i.repos.User.FindByEmail(ctx, param.Email)
+		if errors.Is(err, rerror.ErrNotFound) {
+			return nil
+		} else if err != nil {
+			return err
+		}
+
+		if u.Verification() == nil || !u.Verification().IsVerified() || u.IsDeleted() {
+			return nil
+		}
+		if !u.Auths().HasProvider(user.ProviderReearth) {
+			return nil
+		}
+
+		ml, token, err := user.IssueMagicLink(u.MagicLink())
+		if errors.Is(err, user.ErrMagicLinkRateLimited) {
+			log.Debugfc(ctx, "magic link: rate limited for user %s", u.ID())
+			return nil
+		} else if err != nil {
+			return err
+		}
+		u.SetMagicLink(ml)
+
+		if err = i.repos.User.Save(ctx, u); err != nil {
+			return err
+		}
+
+		q := url.Values{}
+		q.Set("magic-link-token", token)
+		q.Set("email", u.Email())
+		if param.RequestID != "" {
+			q.Set("id", param.RequestID)
+		}
+		link := i.authSrvUIDomain + "/?" + q.Encode()
+
+		var TextOut, HTMLOut bytes.Buffer
+		content := mailContent{
+			UserName:    u.Name(),
+			ActionURL:   htmlTmpl.URL(link),
+			Message:     magicLinkMailContent.Message,
+			Suffix:      magicLinkMailContent.Suffix,
+			ActionLabel: magicLinkMailContent.ActionLabel,
+		}
+		if err = authTextTMPL.Execute(&TextOut, content); err != nil {
+			return err
+		}
+		if err = authHTMLTMPL.Execute(&HTMLOut, content); err != nil {
+			return err
+		}
+
+		contact = mailer.Contact{Email: u.Email(), Name: u.Name()}
+		mailText = TextOut.String()
+		mailHTML = HTMLOut.String()
+
+		return nil
+	}); err != nil {
+		return err
+	}
+
+	if contact.Email == "" {
+		return nil
+	}
+	return i.gateways.Mailer.SendMail(ctx, []mailer.Contact{contact}, "Sign in to Re:Earth", mailText, mailHTML)
+}
+
+// GetUserByMagicLink redeems a link issued by StartMagicLink. It is the
+// passwordless counterpart of GetUserByCredentials and enforces MFA the same
+// way; the token is only consumed once every check has passed.
+func (i *User) GetUserByMagicLink(ctx context.Context, inp interfaces.GetUserByMagicLink) (*user.User, error) {
+	var mfaErr error
+	u, err := Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
+		u, err := i.repos.User.FindByEmail(ctx, inp.Email)
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return nil, err
+		} else if u == nil || u.IsDeleted() {
+			return nil, interfaces.ErrInvalidMagicLink
+		}
+		if !u.MagicLink().Validate(inp.Token) {
+			return nil, interfaces.ErrInvalidMagicLink
+		}
+		if mfaErr = verifyMFA(u, inp.MFACode); mfaErr != nil {
+			if !errors.Is(mfaErr, user.ErrInvalidMFACode) {
+				return nil, mfaErr
+			}
+			// keep the link, but commit the failed attempt so it counts
+			// towards the lockout
+			return nil, i.repos.User.Save(ctx, u)
+		}
+		u.SetMagicLink(u.MagicLink().Consume())
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return nil, err
+		}
+		return u, nil
+	})
+	if err == nil && mfaErr != nil {
+		return nil, mfaErr
+	}
+	return u, err
+}
@@ -0,0 +1,121 @@
+package interactor
+
+import (
+	"context"
+	"net/url"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var magicLinkTokenRe = regexp.MustCompile(`magic-link-token=([A-Za-z0-9_-]+)`)
+
+func TestUser_MagicLink(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	ctx := context.Background()
+	r := memory.New()
+	u := user.New().
+		NewID().
+		Workspace(id.NewWorkspaceID()).
+		Name("Test User").
+		Email("test@example.com").
+		Auths([]user.Auth{*user.ReearthSub("test")}).
+		Verification(user.VerificationFrom("code", now.Add(time.Hour), true)).
+		MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+	unverified := user.New().
+		NewID().
+		Workspace(id.NewWorkspaceID()).
+		Name("Unverified").
+		Email("unverified@example.com").
+		Auths([]user.Auth{*user.ReearthSub("unverified")}).
+		MustBuild()
+	require.NoError(t, r.User.Save(ctx, unverified))
+
+	m := mailer.NewMock()
+	uc := NewUser(r, &gateway.Container{Mailer: m}, nil, "https://auth.example.com", "")
+
+	// unknown and unverified emails are accepted silently without sending mail
+	require.NoError(t, uc.StartMagicLink(ctx, interfaces.StartMagicLinkParam{Email: "unknown@example.com"}))
+	require.NoError(t, uc.StartMagicLink(ctx, interfaces.StartMagicLinkParam{Email: "unverified@example.com"}))
+	assert.Empty(t, m.Mails())
+
+	require.NoError(t, uc.StartMagicLink(ctx, interfaces.StartMagicLinkParam{Email: "test@example.com", RequestID: "req-1"}))
+	mails := m.Mails()
+	require.Len(t, mails, 1)
+	assert.Equal(t, []mailer.Contact{{Email: "test@example.com", Name: "Test User"}}, mails[0].To)
+	assert.Contains(t, mails[0].PlainContent, "id=req-1")
+	match := magicLinkTokenRe.FindStringSubmatch(mails[0].PlainContent)
+	require.Len(t, match, 2)
+	token, err := url.QueryUnescape(match[1])
+	require.NoError(t, err)
+
+	saved, err := r.User.FindByEmail(ctx, "test@example.com")
+	require.NoError(t, err)
+	assert.NotEqual(t, token, saved.MagicLink().TokenHash)
+
+	_, err = uc.(*User).GetUserByMagicLink(ctx, interfaces.GetUserByMagicLink{Email: "test@example.com", Token: "wrong"})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidMagicLink)
+	_, err = uc.(*User).GetUserByMagicLink(ctx, interfaces.GetUserByMagicLink{Email: "unknown@example.com", Token: token})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidMagicLink)
+
+	got, err := uc.(*User).GetUserByMagicLink(ctx, interfaces.GetUserByMagicLink{Email: "test@example.com", Token: token})
+	require.NoError(t, err)
+	assert.Equal(t, u.ID(), got.ID())
+
+	// single use
+	_, err = uc.(*User).GetUserByMagicLink(ctx, interfaces.GetUserByMagicLink{Email: "test@example.com", Token: token})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidMagicLink)
+
+	// rate limited per email
+	for range 4 {
+		require.NoError(t, uc.StartMagicLink(ctx, interfaces.StartMagicLinkParam{Email: "test@example.com"}))
+	}
+	// the same response as on success, without a mail
+	require.NoError(t, uc.StartMagicLink(ctx, interfaces.StartMagicLinkParam{Email: "test@example.com"}))
+	assert.Len(t, m.Mails(), 5)
+}
+
+func TestUser_GetUserByMagicLink_MFA(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	ctx := context.Background()
+	r := memory.New()
+	ml, token, err := user.IssueMagicLink(nil)
+	require.NoError(t, err)
+	mfa := user.MFAFrom("JBSWY3DPEHPK3PXP", true, nil, 0, 0, nil)
+	u := user.New().
+		NewID().
+		Workspace(id.NewWorkspaceID()).
+		Name("Test User").
+		Email("test@example.com").
+		MFA(mfa).
+		MagicLink(ml).
+		MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	uc := NewUser(r, &gateway.Container{}, nil, "", "")
+
+	_, err = uc.(*User).GetUserByMagicLink(ctx, interfaces.GetUserByMagicLink{Email: "test@example.com", Token: token})
+	assert.ErrorIs(t, err, interfaces.ErrMFARequired)
+	_, err = uc.(*User).GetUserByMagicLink(ctx, interfaces.GetUserByMagicLink{Email: "test@example.com", Token: token, MFACode: "000000"})
+	assert.ErrorIs(t, err, user.ErrInvalidMFACode)
+
+	// a failed second factor must not burn the link
+	saved, err := r.User.FindByEmail(ctx, "test@example.com")
+	require.NoError(t, err)
+	assert.True(t, saved.MagicLink().Validate(token))
+}
@@ -0,0 +1,180 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/passkeylogin"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+// BeginPasskeyRegistration starts a WebAuthn registration ceremony for the
+// operator and returns the creation options for the browser.
+func (i *User) BeginPasskeyRegistration(ctx context.Context, operator *workspace.Operator) (json.RawMessage, error) {
+	if operator == nil || operator.User == nil {
+		return nil, interfaces.ErrInvalidOperator
+	}
+	if i.gateways == nil || i.gateways.Passkey == nil {
+		return nil, interfaces.ErrPasskeyNotConfigured
+	}
+	return Run1(ctx, operator, i.repos, Usecase().Transaction(), func(ctx context.Context) (json.RawMessage, error) {
+		u, err := i.repos.User.FindByID(ctx, *operator.User)
+		if err != nil {
+			return nil, err
+		}
+		options, challenge, err := i.gateways.Passkey.BeginRegistration(ctx, u)
+		if err != nil {
+			return nil, err
+		}
+		u.SetPasskeyChallenge(user.NewPasskeyChallenge(challenge))
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return nil, err
+		}
+		return options, nil
+	})
+}
+
+// FinishPasskeyRegistration verifies the browser's attestation against the
+// pending challenge and stores the new passkey.
+func (i *User) FinishPasskeyRegistration(ctx context.Context, p interfaces.FinishPasskeyRegistrationParam, operator *workspace.Operator) (*user.Passkey, error) {
+	if operator == nil || operator.User == nil {
+		return nil, interfaces.ErrInvalidOperator
+	}
+	if i.gateways == nil || i.gateways.Passkey == nil {
+		return nil, interfaces.ErrPasskeyNotConfigured
+	}
+	return Run1(ctx, operator, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.Passkey, error) {
+		u, err := i.repos.User.FindByID(ctx, *operator.User)
+		if err != nil {
+			return nil, err
+		}
+		c := u.PasskeyChallenge()
+		if !c.Validate() {
+			return nil, interfaces.ErrInvalidPasskeyChallenge
+		}
+		pk, err := i.gateways.Passkey.FinishRegistration(ctx, u, c.Challenge, p.Response)
+		if err != nil {
+			log.Debugfc(ctx, "passkey: registration failed for user %s: %v", u.ID(), err)
+			return nil, interfaces.ErrInvalidPasskey
+		}
+		pk.Nickname = strings.TrimSpace(p.Nickname)
+		pk.CreatedAt = util.Now()
+		if err := u.AddPasskey(*pk); err != nil {
+			return nil, err
+		}
+		u.SetPasskeyChallenge(nil)
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return nil, err
+		}
+		return pk, nil
+	})
+}
+
+func (i *User) RemoveMyPasskey(ctx context.Context, id []byte, operator *workspace.Operator) (*user.User, error) {
+	if operator == nil || operator.User == nil {
+		return nil, interfaces.ErrInvalidOperator
+	}
+	return Run1(ctx, operator, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
+		u, err := i.repos.User.FindByID(ctx, *operator.User)
+		if err != nil {
+			return nil, err
+		}
+		if err := u.RemovePasskey(id); err != nil {
+			return nil, err
+		}
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return nil, err
+		}
+		return u, nil
+	})
+}
+
+// BeginPasskeyLogin starts a WebAuthn login ceremony for the user with the
+// given email and returns the request options for the browser, with the ID of
+// the ceremony to post back with the assertion. It is the passkey counterpart
+// of the password check in GetUserByCredentials.
+//
+// Anyone can call it, so it fails with the same error for every account that
+// can't sign in with a passkey, and the ceremony is stored apart from the user.
+func (i *User) BeginPasskeyLogin(ctx context.Context, email string) (json.RawMessage, string, error) {
+	if i.gateways == nil || i.gateways.Passkey == nil {
+		return nil, "", interfaces.ErrPasskeyNotConfigured
+	}
+	return Run2(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (json.RawMessage, string, error) {
+		u, err := i.repos.User.FindByNameOrEmail(ctx, email)
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return nil, "", err
+		}
+		if !canLoginWithPasskey(u) || len(u.Passkeys()) == 0 {
+			return nil, "", interfaces.ErrInvalidPasskey
+		}
+		options, challenge, err := i.gateways.Passkey.BeginLogin(ctx, u)
+		if err != nil {
+			return nil, "", err
+		}
+		s, err := passkeylogin.New(u.ID(), challenge)
+		if err != nil {
+			return nil, "", err
+		}
+		if err := i.repos.PasskeyLogin.RemoveExpired(ctx, util.Now()); err != nil {
+			return nil, "", err
+		}
+		if err := i.repos.PasskeyLogin.Save(ctx, s); err != nil {
+			return nil, "", err
+		}
+		return options, s.ID(), nil
+	})
+}
+
+// GetUserByPasskey verifies a passkey assertion against the ceremony started by
+// BeginPasskeyLogin. User verification is required for the assertion, so the
+// passkey is itself multi-factor and no MFA code is asked.
+func (i *User) GetUserByPasskey(ctx context.Context, inp interfaces.GetUserByPasskey) (*user.User, error) {
+	if i.gateways == nil || i.gateways.Passkey == nil {
+		return nil, interfaces.ErrPasskeyNotConfigured
+	}
+	// The ceremony is taken outside the transaction so that it is used up even
+	// when the assertion is rejected.
+	s, err := i.repos.PasskeyLogin.Take(ctx, inp.Session)
+	if errors.Is(err, rerror.ErrNotFound) || (err == nil && s.IsExpired(util.Now())) {
+		return nil, interfaces.ErrInvalidPasskeyChallenge
+	} else if err != nil {
+		return nil, err
+	}
+	return Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
+		u, err := i.repos.User.FindByID(ctx, s.User())
+		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+			return nil, err
+		}
+		if !canLoginWithPasskey(u) {
+			return nil, interfaces.ErrInvalidPasskey
+		}
+		pk, err := i.gateways.Passkey.FinishLogin(ctx, u, s.Challenge(), inp.Response)
+		if err != nil {
+			log.Debugfc(ctx, "passkey: login failed for user %s: %v", u.ID(), err)
+			return nil, interfaces.ErrInvalidPasskey
+		}
+		// The new sign count must be kept so a cloned authenticator is
+		// detected on its next use.
+		if err := u.UpdatePasskey(*pk); err != nil {
+			return nil, err
+		}
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return nil, err
+		}
+		return u, nil
+	})
+}
+
+// canLoginWithPasskey reports whether u may sign in, on the same terms as the
+// password check.
+func canLoginWithPasskey(u *user.User) bool {
+	return u != nil && !u.IsDeleted() && u.Verification() != nil && u.Verification().IsVerified()
+}
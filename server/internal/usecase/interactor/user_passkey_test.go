@@ -0,0 +1,164 @@
+package interactor
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakePasskey accepts a response equal to the issued challenge, standing in for
+// a real authenticator signature.
+type fakePasskey struct{}
+
+var _ gateway.Passkey = fakePasskey{}
+
+func (fakePasskey) BeginRegistration(_ context.Context, _ *user.User) (json.RawMessage, string, error) {
+	return json.RawMessage(`{"publicKey":{}}`), "reg-challenge", nil
+}
+
+func (fakePasskey) FinishRegistration(_ context.Context, _ *user.User, challenge string, response []byte) (*user.Passkey, error) {
+	if string(response) != challenge {
+		return nil, errors.New("bad signature")
+	}
+	return &user.Passkey{ID: []byte("cred"), PublicKey: []byte("key"), SignCount: 1}, nil
+}
+
+func (fakePasskey) BeginLogin(_ context.Context, _ *user.User) (json.RawMessage, string, error) {
+	return json.RawMessage(`{"publicKey":{}}`), "login-challenge", nil
+}
+
+func (fakePasskey) FinishLogin(_ context.Context, u *user.User, challenge string, response []byte) (*user.Passkey, error) {
+	if string(response) != challenge {
+		return nil, errors.New("bad signature")
+	}
+	pk := u.Passkey([]byte("cred"))
+	pk.SignCount++
+	return pk, nil
+}
+
+func TestUser_Passkey(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	ctx := context.Background()
+	r := memory.New()
+	uid := id.NewUserID()
+	u := user.New().
+		ID(uid).
+		Workspace(id.NewWorkspaceID()).
+		Name("Test User").
+		Email("test@example.com").
+		Verification(user.VerificationFrom("code", now.Add(time.Hour), true)).
+		MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	uc := NewUser(r, &gateway.Container{Passkey: fakePasskey{}}, nil, "", "")
+	operator := &workspace.Operator{User: &uid}
+
+	_, err := uc.FinishPasskeyRegistration(ctx, interfaces.FinishPasskeyRegistrationParam{Response: []byte("reg-challenge")}, operator)
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPasskeyChallenge)
+
+	options, err := uc.BeginPasskeyRegistration(ctx, operator)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"publicKey":{}}`, string(options))
+
+	_, err = uc.FinishPasskeyRegistration(ctx, interfaces.FinishPasskeyRegistrationParam{Response: []byte("forged")}, operator)
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPasskey)
+
+	pk, err := uc.FinishPasskeyRegistration(ctx, interfaces.FinishPasskeyRegistrationParam{Nickname: " laptop ", Response: []byte("reg-challenge")}, operator)
+	require.NoError(t, err)
+	assert.Equal(t, "laptop", pk.Nickname)
+	assert.Equal(t, now, pk.CreatedAt)
+
+	got, err := r.User.FindByID(ctx, uid)
+	require.NoError(t, err)
+	assert.Len(t, got.Passkeys(), 1)
+	assert.Nil(t, got.PasskeyChallenge())
+
+	// login
+	login := uc.(*User)
+	_, _, err = login.BeginPasskeyLogin(ctx, "unknown@example.com")
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPasskey, "unknown emails can't be told apart")
+
+	// starting a login leaves a registration in flight intact
+	_, err = uc.BeginPasskeyRegistration(ctx, operator)
+	require.NoError(t, err)
+	_, session, err := login.BeginPasskeyLogin(ctx, "test@example.com")
+	require.NoError(t, err)
+	require.NotEmpty(t, session)
+	got, err = r.User.FindByID(ctx, uid)
+	require.NoError(t, err)
+	assert.Equal(t, "reg-challenge", got.PasskeyChallenge().Challenge)
+
+	_, err = login.GetUserByPasskey(ctx, interfaces.GetUserByPasskey{Session: session, Response: []byte("forged")})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPasskey)
+	// a rejected assertion uses the session up
+	_, err = login.GetUserByPasskey(ctx, interfaces.GetUserByPasskey{Session: session, Response: []byte("login-challenge")})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPasskeyChallenge)
+
+	_, session, err = login.BeginPasskeyLogin(ctx, "test@example.com")
+	require.NoError(t, err)
+	_, other, err := login.BeginPasskeyLogin(ctx, "test@example.com")
+	require.NoError(t, err)
+	loggedIn, err := login.GetUserByPasskey(ctx, interfaces.GetUserByPasskey{Session: session, Response: []byte("login-challenge")})
+	require.NoError(t, err)
+	assert.Equal(t, uid, loggedIn.ID())
+	assert.Equal(t, uint32(2), loggedIn.Passkey([]byte("cred")).SignCount)
+
+	// The session is single-use.
+	_, err = login.GetUserByPasskey(ctx, interfaces.GetUserByPasskey{Session: session, Response: []byte("login-challenge")})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPasskeyChallenge)
+
+	// An expired session is rejected.
+	util.MockNow(now.Add(10 * time.Minute))
+	_, err = login.GetUserByPasskey(ctx, interfaces.GetUserByPasskey{Session: other, Response: []byte("login-challenge")})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPasskeyChallenge)
+
+	// A deactivated user can't sign in.
+	_, session, err = login.BeginPasskeyLogin(ctx, "test@example.com")
+	require.NoError(t, err)
+	got, err = r.User.FindByID(ctx, uid)
+	require.NoError(t, err)
+	got.Deactivate()
+	require.NoError(t, r.User.Save(ctx, got))
+	_, err = login.GetUserByPasskey(ctx, interfaces.GetUserByPasskey{Session: session, Response: []byte("login-challenge")})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPasskey)
+	_, _, err = login.BeginPasskeyLogin(ctx, "test@example.com")
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPasskey)
+	got.Reactivate()
+	require.NoError(t, r.User.Save(ctx, got))
+
+	// removal
+	_, err = uc.RemoveMyPasskey(ctx, []byte("other"), operator)
+	assert.ErrorIs(t, err, user.ErrPasskeyNotFound)
+
+	removed, err := uc.RemoveMyPasskey(ctx, []byte("cred"), operator)
+	require.NoError(t, err)
+	assert.Empty(t, removed.Passkeys())
+
+	_, _, err = login.BeginPasskeyLogin(ctx, "test@example.com")
+	assert.ErrorIs(t, err, interfaces.ErrInvalidPasskey)
+}
+
+func TestUser_Passkey_NotConfigured(t *testing.T) {
+	uid := id.NewUserID()
+	uc := NewUser(memory.New(), &gateway.Container{}, nil, "", "")
+
+	_, err := uc.BeginPasskeyRegistration(context.Background(), &workspace.Operator{User: &uid})
+	assert.ErrorIs(t, err, interfaces.ErrPasskeyNotConfigured)
+
+	_, _, err = uc.(*User).BeginPasskeyLogin(context.Background(), "test@example.com")
+	assert.ErrorIs(t, err, interfaces.ErrPasskeyNotConfigured)
+}
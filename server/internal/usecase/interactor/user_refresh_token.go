@@ -0,0 +1,64 @@
+package interactor
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+// StartRefreshTokenFamily records a new sign-in of the built-in OIDC provider
+// whose refresh tokens are rotated by RotateRefreshToken until expiresAt, which
+// each rotation pushes forward up to maxExpiresAt.
+func (i *User) StartRefreshTokenFamily(ctx context.Context, uid user.ID, expiresAt, maxExpiresAt time.Time) (user.RefreshTokenFamily, error) {
+	return Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (user.RefreshTokenFamily, error) {
+		u, err := i.repos.User.FindByID(ctx, uid)
+		if err != nil {
+			return user.RefreshTokenFamily{}, err
+		}
+		if u.IsDeleted() {
+			return user.RefreshTokenFamily{}, rerror.ErrNotFound
+		}
+		f := u.StartRefreshTokenFamily(util.Now(), expiresAt, maxExpiresAt)
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return user.RefreshTokenFamily{}, err
+		}
+		return f, nil
+	})
+}
+
+// RotateRefreshToken accepts a refresh token of the given family and
+// generation and returns the family of the token that replaces it. Presenting
+// a token that was already rotated revokes the whole family, which stays
+// revoked even though ErrRefreshTokenReused is returned.
+func (i *User) RotateRefreshToken(ctx context.Context, uid user.ID, family string, generation int, expiresAt time.Time) (user.RefreshTokenFamily, error) {
+	f, reused, err := Run2(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (user.RefreshTokenFamily, bool, error) {
+		u, err := i.repos.User.FindByID(ctx, uid)
+		if err != nil {
+			return user.RefreshTokenFamily{}, false, err
+		}
+		if u.IsDeleted() {
+			return user.RefreshTokenFamily{}, false, user.ErrInvalidRefreshToken
+		}
+		f, err := u.RotateRefreshToken(family, generation, util.Now(), expiresAt)
+		reused := errors.Is(err, user.ErrRefreshTokenReused)
+		if err != nil && !reused {
+			return user.RefreshTokenFamily{}, false, err
+		}
+		// the revocation of a reused family is saved as well
+		if err := i.repos.User.Save(ctx, u); err != nil {
+			return user.RefreshTokenFamily{}, false, err
+		}
+		return f, reused, nil
+	})
+	if err != nil {
+		return user.RefreshTokenFamily{}, err
+	}
+	if reused {
+		return user.RefreshTokenFamily{}, user.ErrRefreshTokenReused
+	}
+	return f, nil
+}
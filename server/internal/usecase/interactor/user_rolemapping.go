@@ -0,0 +1,133 @@
+package interactor
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/log"
+)
+
+func (i *User) ApplyRoleMappings(ctx context.Context, param interfaces.ApplyRoleMappingsParam) error {
+	if param.User == nil {
+		return nil
+	}
+	// Most tokens are not issued by an identity provider with mappings, so
+	// look them up before opening a transaction.
+	mappings, err := i.roleMappings(ctx, param.Issuer, param.Sub)
+	if err != nil || len(mappings) == 0 {
+		return err
+	}
+	return Run0(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) error {
+		_, err := i.applyRoleMappings(ctx, param.User, mappings, param.Claims)
+		return err
+	})
+}
+
+// roleMappings returns the mappings of the workspaces whose identity provider
+// issued a token with iss and sub.
+func (i *User) roleMappings(ctx context.Context, iss, sub string) (rolemapping.List, error) {
+	if iss == "" || i.repos.RoleMapping == nil {
+		return nil, nil
+	}
+	l, err := i.repos.RoleMapping.FindByIssuer(ctx, iss)
+	if err != nil {
+		return nil, err
+	}
+	return l.Applying(iss, sub), nil
+}
+
+// applyRoleMappings makes u a member of each mapped workspace with the role
+// its claims map to, and removes it from the workspaces whose rules no longer
+// match. The mapping is authoritative for the users of the identity provider,
+// except that the only owner of a workspace is never demoted or removed, so
+// that the workspace is not left without one. It returns the memberships it
+// changed and must be called in a transaction.
+func (i *User) applyRoleMappings(ctx context.Context, u *user.User, mappings rolemapping.List, claims map[string]any) ([]ldapsync.MembershipChange, error) {
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+	wids := make(workspace.IDList, 0, len(mappings))
+	for _, m := range mappings {
+		wids = append(wids, m.Workspace())
+	}
+	wss, err := i.repos.Workspace.FindByIDs(ctx, wids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[workspace.ID]*workspace.Workspace, len(wss))
+	for _, ws := range wss {
+		if ws != nil && !ws.IsDeleted() && !ws.IsPersonal() {
+			byID[ws.ID()] = ws
+		}
+	}
+
+	wi := &Workspace{
+		repos:           i.repos,
+		permittableRepo: i.repos.Permittable,
+		roleRepo:        i.repos.Role,
+	}
+	uid := u.ID()
+	var changes []ldapsync.MembershipChange
+	for _, m := range mappings {
+		ws, ok := byID[m.Workspace()]
+		if !ok {
+			continue
+		}
+		members := ws.Members()
+		r, matched := m.Evaluate(claims)
+		current := members.User(uid)
+
+		switch {
+		case matched && current == nil:
+			if err := members.Join(u, r, uid); err != nil {
+				return nil, err
+			}
+		case matched && current.Role != r:
+			if members.IsOnlyOwner(uid) {
+				log.Warnfc(ctx, "[roleMapping] keeping the only owner %s of workspace %s", uid, ws.ID())
+				continue
+			}
+			if err := members.UpdateUserRole(uid, r); err != nil {
+				return nil, err
+			}
+		case !matched && current != nil:
+			if members.IsOnlyOwner(uid) {
+				log.Warnfc(ctx, "[roleMapping] keeping the only owner %s of workspace %s", uid, ws.ID())
+				continue
+			}
+			if err := members.Leave(uid); err != nil {
+				return nil, err
+			}
+		default:
+			continue
+		}
+
+		if err := i.repos.Workspace.Save(ctx, ws); err != nil {
+			return nil, err
+		}
+		if matched {
+			err = wi.bulkUpdatePermittable(ctx, ws.ID(), map[user.ID]role.RoleType{uid: r})
+		} else {
+			err = wi.bulkRemovePermittable(ctx, ws.ID(), user.IDList{uid})
+		}
+		if err != nil {
+			return nil, err
+		}
+		log.Infofc(ctx, "[roleMapping] user %s in workspace %s: role=%q", uid, ws.ID(), r)
+
+		c := ldapsync.MembershipChange{Workspace: ws.ID(), User: uid}
+		if current != nil {
+			c.From = current.Role
+		}
+		if matched {
+			c.To = r
+		}
+		changes = append(changes, c)
+	}
+	return changes, nil
+}
@@ -0,0 +1,190 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_ApplyRoleMappings(t *testing.T) {
+	const (
+		iss = "https://example.auth0.com/"
+		sub = "samlp|org_1|alice@example.com"
+	)
+
+	setup := func(t *testing.T) (*repo.Container, *user.User, *workspace.Workspace, map[role.RoleType]role.ID) {
+		t.Helper()
+		ctx := context.Background()
+		r := memory.New()
+		roles := map[role.RoleType]role.ID{}
+		for _, rt := range []role.RoleType{role.RoleOwner, role.RoleMaintainer, role.RoleWriter, role.RoleReader} {
+			ro := role.New().NewID().Name(rt.String()).MustBuild()
+			require.NoError(t, r.Role.Save(ctx, *ro))
+			roles[rt] = ro.ID()
+		}
+
+		u := user.New().NewID().Workspace(id.NewWorkspaceID()).Name("alice").Email("alice@example.com").
+			Auths([]user.Auth{user.AuthFrom(sub)}).MustBuild()
+		require.NoError(t, r.User.Save(ctx, u))
+
+		ws := workspace.New().NewID().Name("gis").Members(map[workspace.UserID]workspace.Member{
+			user.NewID(): {Role: role.RoleOwner},
+		}).MustBuild()
+		require.NoError(t, r.Workspace.Save(ctx, ws))
+
+		require.NoError(t, r.RoleMapping.Save(ctx, rolemapping.New().NewID().Workspace(ws.ID()).
+			Issuer(iss).SubPrefix("samlp|org_1").Rules([]rolemapping.Rule{
+			{Claim: "groups", Value: "gis-viewers", Role: role.RoleReader},
+			{Claim: "groups", Value: "gis-editors", Role: role.RoleWriter},
+		}).MustBuild()))
+		return r, u, ws, roles
+	}
+
+	workspaceRole := func(t *testing.T, r *repo.Container, uid user.ID, wid workspace.ID) *permittable.WorkspaceRole {
+		t.Helper()
+		p, err := r.Permittable.FindByUserID(context.Background(), uid)
+		if err != nil {
+			return nil
+		}
+		for _, wr := range p.WorkspaceRoles() {
+			if wr.ID() == wid {
+				return &wr
+			}
+		}
+		return nil
+	}
+
+	t.Run("joins the workspace", func(t *testing.T) {
+		ctx := context.Background()
+		r, u, ws, roles := setup(t)
+
+		err := NewUser(r, nil, nil, "", "").ApplyRoleMappings(ctx, interfaces.ApplyRoleMappingsParam{
+			User: u, Issuer: iss, Sub: sub,
+			Claims: map[string]any{"groups": []any{"gis-viewers", "gis-editors"}},
+		})
+		require.NoError(t, err)
+
+		got, err := r.Workspace.FindByID(ctx, ws.ID())
+		require.NoError(t, err)
+		assert.Equal(t, role.RoleWriter, got.Members().UserRole(u.ID()))
+		wr := workspaceRole(t, r, u.ID(), ws.ID())
+		require.NotNil(t, wr)
+		assert.Equal(t, roles[role.RoleWriter], wr.RoleID())
+	})
+
+	t.Run("changes the role", func(t *testing.T) {
+		ctx := context.Background()
+		r, u, ws, roles := setup(t)
+		require.NoError(t, ws.Members().Join(u, role.RoleWriter, u.ID()))
+		require.NoError(t, r.Workspace.Save(ctx, ws))
+
+		err := NewUser(r, nil, nil, "", "").ApplyRoleMappings(ctx, interfaces.ApplyRoleMappingsParam{
+			User: u, Issuer: iss, Sub: sub,
+			Claims: map[string]any{"groups": []any{"gis-viewers"}},
+		})
+		require.NoError(t, err)
+
+		got, err := r.Workspace.FindByID(ctx, ws.ID())
+		require.NoError(t, err)
+		assert.Equal(t, role.RoleReader, got.Members().UserRole(u.ID()))
+		wr := workspaceRole(t, r, u.ID(), ws.ID())
+		require.NotNil(t, wr)
+		assert.Equal(t, roles[role.RoleReader], wr.RoleID())
+	})
+
+	t.Run("leaves when no rule matches", func(t *testing.T) {
+		ctx := context.Background()
+		r, u, ws, _ := setup(t)
+		uc := NewUser(r, nil, nil, "", "")
+		require.NoError(t, uc.ApplyRoleMappings(ctx, interfaces.ApplyRoleMappingsParam{
+			User: u, Issuer: iss, Sub: sub,
+			Claims: map[string]any{"groups": []any{"gis-viewers"}},
+		}))
+
+		require.NoError(t, uc.ApplyRoleMappings(ctx, interfaces.ApplyRoleMappingsParam{
+			User: u, Issuer: iss, Sub: sub,
+			Claims: map[string]any{"groups": []any{"sales"}},
+		}))
+
+		got, err := r.Workspace.FindByID(ctx, ws.ID())
+		require.NoError(t, err)
+		assert.False(t, got.Members().HasUser(u.ID()))
+		assert.Nil(t, workspaceRole(t, r, u.ID(), ws.ID()))
+	})
+
+	t.Run("keeps the only owner", func(t *testing.T) {
+		ctx := context.Background()
+		r := memory.New()
+		u := user.New().NewID().Workspace(id.NewWorkspaceID()).Name("alice").Email("alice@example.com").MustBuild()
+		ws := workspace.New().NewID().Name("gis").Members(map[workspace.UserID]workspace.Member{
+			u.ID(): {Role: role.RoleOwner},
+		}).MustBuild()
+		require.NoError(t, r.Workspace.Save(ctx, ws))
+		require.NoError(t, r.RoleMapping.Save(ctx, rolemapping.New().NewID().Workspace(ws.ID()).Issuer(iss).
+			Rules([]rolemapping.Rule{{Claim: "groups", Value: "gis-viewers", Role: role.RoleReader}}).MustBuild()))
+
+		uc := NewUser(r, nil, nil, "", "")
+		for _, groups := range []any{[]any{"gis-viewers"}, []any{}} {
+			require.NoError(t, uc.ApplyRoleMappings(ctx, interfaces.ApplyRoleMappingsParam{
+				User: u, Issuer: iss, Sub: sub, Claims: map[string]any{"groups": groups},
+			}))
+			got, err := r.Workspace.FindByID(ctx, ws.ID())
+			require.NoError(t, err)
+			assert.Equal(t, role.RoleOwner, got.Members().UserRole(u.ID()))
+		}
+	})
+
+	t.Run("ignores other identity providers", func(t *testing.T) {
+		ctx := context.Background()
+		r, u, ws, _ := setup(t)
+
+		err := NewUser(r, nil, nil, "", "").ApplyRoleMappings(ctx, interfaces.ApplyRoleMappingsParam{
+			User: u, Issuer: iss, Sub: "samlp|org_2|alice@example.com",
+			Claims: map[string]any{"groups": []any{"gis-viewers"}},
+		})
+		require.NoError(t, err)
+
+		got, err := r.Workspace.FindByID(ctx, ws.ID())
+		require.NoError(t, err)
+		assert.False(t, got.Members().HasUser(u.ID()))
+	})
+}
+
+func TestUser_SyncSSOUser_RoleMappings(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	for _, name := range []string{interfaces.RoleSelf, role.RoleOwner.String(), role.RoleReader.String()} {
+		require.NoError(t, r.Role.Save(ctx, *role.New().NewID().Name(name).MustBuild()))
+	}
+	ws := workspace.New().NewID().Name("gis").Members(map[workspace.UserID]workspace.Member{
+		user.NewID(): {Role: role.RoleOwner},
+	}).MustBuild()
+	require.NoError(t, r.Workspace.Save(ctx, ws))
+	require.NoError(t, r.RoleMapping.Save(ctx, rolemapping.New().NewID().Workspace(ws.ID()).
+		Issuer("https://example.auth0.com/").SubPrefix("samlp|org_1").
+		Rules([]rolemapping.Rule{{Claim: "groups", Value: "gis-viewers", Role: role.RoleReader}}).MustBuild()))
+
+	u, err := NewUser(r, nil, nil, "", "").SyncSSOUser(ctx, interfaces.SyncSSOUserParam{
+		Email:  "sso@example.com",
+		Name:   "SSO User",
+		Sub:    "samlp|org_1|sso@example.com",
+		Issuer: "https://example.auth0.com/",
+		Claims: map[string]any{"groups": []any{"gis-viewers"}},
+	})
+	require.NoError(t, err)
+
+	got, err := r.Workspace.FindByID(ctx, ws.ID())
+	require.NoError(t, err)
+	assert.Equal(t, role.RoleReader, got.Members().UserRole(u.ID()))
+}
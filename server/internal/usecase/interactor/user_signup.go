@@ -16,6 +16,7 @@ import (
 	textTmpl "text/template"
 	"time"
 
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearth-accounts/server/pkg/permittable"
@@ -158,13 +159,18 @@ func (i *User) SignupOIDC(ctx context.Context, param interfaces.SignupOIDCParam)
 	sub := param.Sub
 	name := param.Name
 	email := param.Email
-	if sub == "" || email == "" {
+	// The identity of generic OIDC providers is always taken from the token, as
+	// the sub must carry the provider prefix and the email may need to be verified.
+	if sub == "" || email == "" || i.gateways.IdentityResolverFor(param.Issuer) != nil {
 		ui, err := i.getUserInfoFromISS(ctx, param.Issuer, param.AccessToken)
 		if err != nil {
 			return nil, err
 		}
 		sub = ui.Sub
 		email = ui.Email
+		if name == "" {
+			name = ui.Name
+		}
 	}
 
 	return Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
@@ -230,66 +236,89 @@ func (i *User) SignupOIDC(ctx context.Context, param interfaces.SignupOIDCParam)
 
 func (i *User) SyncSSOUser(ctx context.Context, param interfaces.SyncSSOUserParam) (*user.User, error) {
 	return Run1(ctx, nil, i.repos, Usecase().Transaction(), func(ctx context.Context) (*user.User, error) {
-		eu, err := i.repos.User.FindBySub(ctx, param.Sub)
-		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
-			return nil, err
-		}
-		if eu != nil {
-			return eu, nil
-		}
+		return i.syncSSOUser(ctx, param)
+	})
+}
 
-		eu, err = i.repos.User.FindByEmail(ctx, param.Email)
-		if err != nil && !errors.Is(err, rerror.ErrNotFound) {
-			return nil, err
-		}
-		if eu != nil {
-			return nil, interfaces.ErrUserAlreadyExists
-		}
+// syncSSOUser returns the user signed in with param.Sub, creating it together
+// with its personal workspace if it does not exist yet, and applies the role
+// mappings of the identity provider that issued its token. It must be called
+// in a transaction.
+func (i *User) syncSSOUser(ctx context.Context, param interfaces.SyncSSOUserParam) (*user.User, error) {
+	u, err := i.findOrCreateSSOUser(ctx, param)
+	if err != nil {
+		return nil, err
+	}
+	mappings, err := i.roleMappings(ctx, param.Issuer, param.Sub)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := i.applyRoleMappings(ctx, u, mappings, param.Claims); err != nil {
+		return nil, err
+	}
+	return u, nil
+}
 
-		u, ws, err := workspace.Init(workspace.InitParams{
-			Email:       param.Email,
-			Lang:        param.Lang,
-			Name:        param.Name,
-			Sub:         user.AuthFrom(param.Sub).Ref(),
-			Theme:       param.Theme,
-			UserID:      param.UserID,
-			WorkspaceID: param.WorkspaceID,
-		})
-		if err != nil {
-			return nil, err
-		}
+func (i *User) findOrCreateSSOUser(ctx context.Context, param interfaces.SyncSSOUserParam) (*user.User, error) {
+	eu, err := i.repos.User.FindBySub(ctx, param.Sub)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, err
+	}
+	if eu != nil {
+		return eu, nil
+	}
 
-		if err = i.repos.User.Create(ctx, u); err != nil {
-			if errors.Is(err, user.ErrDuplicatedUser) {
-				return nil, interfaces.ErrUserAlreadyExists
-			}
-			return nil, err
+	eu, err = i.repos.User.FindByEmail(ctx, param.Email)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, err
+	}
+	if eu != nil {
+		return nil, interfaces.ErrUserAlreadyExists
+	}
+
+	u, ws, err := workspace.Init(workspace.InitParams{
+		Email:       param.Email,
+		Lang:        param.Lang,
+		Name:        param.Name,
+		Sub:         user.AuthFrom(param.Sub).Ref(),
+		Theme:       param.Theme,
+		UserID:      param.UserID,
+		WorkspaceID: param.WorkspaceID,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err = i.repos.User.Create(ctx, u); err != nil {
+		if errors.Is(err, user.ErrDuplicatedUser) {
+			return nil, interfaces.ErrUserAlreadyExists
 		}
-		if err = i.repos.Workspace.Save(ctx, ws); err != nil {
-			if errors.Is(err, workspace.ErrDuplicateWorkspaceAlias) {
-				return nil, interfaces.ErrWorkspaceAliasAlreadyExists
-			}
-			return nil, err
+		return nil, err
+	}
+	if err = i.repos.Workspace.Save(ctx, ws); err != nil {
+		if errors.Is(err, workspace.ErrDuplicateWorkspaceAlias) {
+			return nil, interfaces.ErrWorkspaceAliasAlreadyExists
 		}
+		return nil, err
+	}
 
-		roleSelf, err := i.repos.Role.FindByName(ctx, interfaces.RoleSelf)
-		if err != nil {
-			return nil, err
-		}
+	roleSelf, err := i.repos.Role.FindByName(ctx, interfaces.RoleSelf)
+	if err != nil {
+		return nil, err
+	}
 
-		roleOwner, err := i.repos.Role.FindByName(ctx, role.RoleOwner.String())
-		if err != nil {
-			return nil, err
-		}
+	roleOwner, err := i.repos.Role.FindByName(ctx, role.RoleOwner.String())
+	if err != nil {
+		return nil, err
+	}
 
-		wsRole := permittable.NewWorkspaceRole(ws.ID(), roleOwner.ID())
-		perm := permittable.New().NewID().RoleIDs([]id.RoleID{roleSelf.ID()}).UserID(u.ID()).WorkspaceRoles([]permittable.WorkspaceRole{wsRole}).MustBuild()
-		if err = i.repos.Permittable.Save(ctx, lo.FromPtr(perm)); err != nil {
-			return nil, err
-		}
+	wsRole := permittable.NewWorkspaceRole(ws.ID(), roleOwner.ID())
+	perm := permittable.New().NewID().RoleIDs([]id.RoleID{roleSelf.ID()}).UserID(u.ID()).WorkspaceRoles([]permittable.WorkspaceRole{wsRole}).MustBuild()
+	if err = i.repos.Permittable.Save(ctx, lo.FromPtr(perm)); err != nil {
+		return nil, err
+	}
 
-		return u, nil
-	})
+	return u, nil
 }
 
 func (i *User) FindOrCreate(ctx context.Context, param interfaces.UserFindOrCreateParam) (u *user.User, err error) {
@@ -389,6 +418,14 @@ func (i *User) getUserInfoFromISS(ctx context.Context, iss, accessToken string)
 		}
 	}
 
+	if r := i.gateways.IdentityResolverFor(iss); r != nil {
+		au, err := r.ResolveIdentity(ctx, accessToken)
+		if err != nil {
+			return UserInfo{}, err
+		}
+		return UserInfo{Sub: au.ID, Name: au.Name, Email: au.Email}, nil
+	}
+
 	var u string
 	c, err := getOpenIDConfiguration(ctx, iss)
 	if err != nil {
@@ -564,7 +601,11 @@ func (i *User) CreateVerification(ctx context.Context, email string) error {
 		}
 
 		// Resend through the user's external IdP, routed by auth record provider.
+		// The reearth provider has no external IdP to resend through.
 		for _, a := range u.Auths() {
+			if gateway.Provider(a.Provider) == gateway.ProviderReearth {
+				continue
+			}
 			authenticator := i.gateways.AuthenticatorFor(a.Provider)
 			if authenticator == nil {
 				continue
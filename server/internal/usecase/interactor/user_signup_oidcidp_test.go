@@ -0,0 +1,89 @@
+package interactor
+
+import (
+	"context"
+	"testing"
+
+	accountmemory "github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/mailer"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+)
+
+type fakeIdentityResolver struct {
+	gateway.Authenticator
+	iss string
+	u   gateway.AuthenticatorUser
+	err error
+}
+
+func (f fakeIdentityResolver) Issuer() string { return f.iss }
+
+func (f fakeIdentityResolver) ResolveIdentity(_ context.Context, _ string) (gateway.AuthenticatorUser, error) {
+	return f.u, f.err
+}
+
+// TestUser_SignupOIDC_IdentityResolver proves that the identity of a generic
+// OIDC provider is taken from the token, ignoring the sub and email sent by
+// the client.
+func TestUser_SignupOIDC_IdentityResolver(t *testing.T) {
+	ctx := context.Background()
+	r := accountmemory.New()
+
+	selfRole := role.New().NewID().Name(interfaces.RoleSelf).MustBuild()
+	ownerRole := role.New().NewID().Name(role.RoleOwner.String()).MustBuild()
+	require.NoError(t, r.Role.Save(ctx, *selfRole))
+	require.NoError(t, r.Role.Save(ctx, *ownerRole))
+
+	g := &gateway.Container{
+		Mailer: mailer.NewMock(),
+		Authenticators: map[gateway.Provider]gateway.Authenticator{
+			"keycloak": fakeIdentityResolver{
+				iss: "https://idp.example.com/realms/r",
+				u:   gateway.AuthenticatorUser{ID: "keycloak|kc-1", Name: "KC User", Email: "kc@example.com", EmailVerified: true},
+			},
+		},
+	}
+	uc := NewUser(r, g, nil, "", "")
+
+	u, err := uc.SignupOIDC(ctx, interfaces.SignupOIDCParam{
+		AccessToken: "token",
+		Issuer:      "https://idp.example.com/realms/r/",
+		Sub:         "spoofed",
+		Email:       "spoofed@example.com",
+		User: interfaces.SignupUserParam{
+			Lang:  &language.English,
+			Theme: user.ThemeDefault.Ref(),
+		},
+	})
+	require.NoError(t, err)
+	assert.True(t, u.ContainAuth(user.AuthFrom("keycloak|kc-1")))
+	assert.Equal(t, "kc@example.com", u.Email())
+	assert.Equal(t, "KC User", u.Name())
+
+	got, err := r.User.FindBySub(ctx, "keycloak|kc-1")
+	require.NoError(t, err)
+	assert.Equal(t, u.ID(), got.ID())
+}
+
+func TestUser_SignupOIDC_IdentityResolverError(t *testing.T) {
+	g := &gateway.Container{
+		Authenticators: map[gateway.Provider]gateway.Authenticator{
+			"keycloak": fakeIdentityResolver{iss: "https://idp.example.com", err: assert.AnError},
+		},
+	}
+	uc := NewUser(accountmemory.New(), g, nil, "", "")
+
+	_, err := uc.SignupOIDC(context.Background(), interfaces.SignupOIDCParam{
+		AccessToken: "token",
+		Issuer:      "https://idp.example.com",
+		Sub:         "sub",
+		Email:       "a@example.com",
+	})
+	assert.ErrorIs(t, err, assert.AnError)
+}
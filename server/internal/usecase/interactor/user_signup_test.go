@@ -790,8 +790,8 @@ func (m *mockAuthenticator) GetMFAStatus(_ context.Context, _ string) (gateway.M
 	return gateway.MFAStatus{}, nil
 }
 
-func (m *mockAuthenticator) RegenerateMFARecoveryCode(_ context.Context, _ string) (string, error) {
-	return "", nil
+func (m *mockAuthenticator) RegenerateMFARecoveryCode(_ context.Context, _ string) ([]string, error) {
+	return nil, nil
 }
 
 func (m *mockAuthenticator) ResendVerificationEmail(ctx context.Context, userID string) error {
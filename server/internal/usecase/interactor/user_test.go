@@ -6,6 +6,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/local"
 	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
@@ -17,6 +18,7 @@ import (
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/mailer"
 	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
 	"github.com/samber/lo"
 	"golang.org/x/text/language"
 
@@ -1272,8 +1274,8 @@ func (m *mockAuthenticatorWithError) GetMFAStatus(_ context.Context, _ string) (
 	return gateway.MFAStatus{}, nil
 }
 
-func (m *mockAuthenticatorWithError) RegenerateMFARecoveryCode(_ context.Context, _ string) (string, error) {
-	return "new-recovery-code", nil
+func (m *mockAuthenticatorWithError) RegenerateMFARecoveryCode(_ context.Context, _ string) ([]string, error) {
+	return []string{"new-recovery-code"}, nil
 }
 
 func (m *mockAuthenticatorWithError) ResendVerificationEmail(_ context.Context, _ string) error {
@@ -1311,9 +1313,9 @@ func TestUser_RegenerateMFARecoveryCode(t *testing.T) {
 
 	t.Run("ok", func(t *testing.T) {
 		uc, operator := newUC()
-		code, err := uc.RegenerateMFARecoveryCode(ctx, operator)
+		codes, err := uc.RegenerateMFARecoveryCode(ctx, operator)
 		assert.NoError(t, err)
-		assert.Equal(t, "new-recovery-code", code)
+		assert.Equal(t, []string{"new-recovery-code"}, codes)
 	})
 
 	t.Run("nil operator", func(t *testing.T) {
@@ -1350,6 +1352,84 @@ func TestUser_RegenerateMFARecoveryCode(t *testing.T) {
 	})
 }
 
+func TestUser_MFA_ReearthProvider(t *testing.T) {
+	user.DefaultPasswordEncoder = &user.NoopPasswordEncoder{}
+	// RFC 6238 test key; the code for unix time 59 is 287082.
+	defer util.MockNow(time.Unix(59, 0))()
+	const secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+	ctx := context.Background()
+	r := memory.New()
+	uid := id.NewUserID()
+	u := user.New().
+		ID(uid).
+		Workspace(id.NewWorkspaceID()).
+		Name("Test User").
+		Email("test@example.com").
+		PasswordPlainText("PAss00!!").
+		Verification(user.VerificationFrom("code", time.Now().Add(time.Hour), true)).
+		Auths([]user.Auth{*user.ReearthSub(uid.String())}).
+		MFA(user.MFAFrom(secret, false, nil, 0, 0, nil)).
+		MustBuild()
+	require.NoError(t, r.User.Save(ctx, u))
+
+	g := &gateway.Container{Authenticators: map[gateway.Provider]gateway.Authenticator{
+		gateway.ProviderReearth: local.New(r.User),
+	}}
+	uc := NewUser(r, g, nil, "", "")
+	operator := &workspace.Operator{User: &uid}
+
+	status, err := uc.GetMFAStatus(ctx, operator)
+	require.NoError(t, err)
+	assert.False(t, status.Enrolled)
+
+	_, err = uc.ConfirmMFA(ctx, "000000", operator)
+	assert.ErrorIs(t, err, user.ErrInvalidMFACode)
+
+	recoveryCodes, err := uc.ConfirmMFA(ctx, "287082", operator)
+	require.NoError(t, err)
+	require.Len(t, recoveryCodes, user.RecoveryCodeCount)
+
+	status, err = uc.GetMFAStatus(ctx, operator)
+	require.NoError(t, err)
+	assert.True(t, status.Enrolled)
+
+	_, err = uc.EnableMFA(ctx, operator)
+	assert.ErrorIs(t, err, user.ErrMFAAlreadyEnabled)
+
+	credentials := uc.(*User)
+	_, err = credentials.GetUserByCredentials(ctx, interfaces.GetUserByCredentials{Email: "test@example.com", Password: "PAss00!!"})
+	assert.ErrorIs(t, err, interfaces.ErrMFARequired)
+
+	// The TOTP step consumed by the confirmation cannot be replayed.
+	_, err = credentials.GetUserByCredentials(ctx, interfaces.GetUserByCredentials{Email: "test@example.com", Password: "PAss00!!", MFACode: "287082"})
+	assert.ErrorIs(t, err, user.ErrInvalidMFACode)
+
+	got, err := credentials.GetUserByCredentials(ctx, interfaces.GetUserByCredentials{Email: "test@example.com", Password: "PAss00!!", MFACode: recoveryCodes[0]})
+	require.NoError(t, err)
+	assert.Equal(t, uid, got.ID())
+
+	_, err = credentials.GetUserByCredentials(ctx, interfaces.GetUserByCredentials{Email: "test@example.com", Password: "PAss00!!", MFACode: recoveryCodes[0]})
+	assert.ErrorIs(t, err, user.ErrInvalidMFACode)
+
+	// Wrong codes are saved, and enough of them lock out even a valid one.
+	for range 4 {
+		_, err = credentials.GetUserByCredentials(ctx, interfaces.GetUserByCredentials{Email: "test@example.com", Password: "PAss00!!", MFACode: "000000"})
+		assert.ErrorIs(t, err, user.ErrInvalidMFACode)
+	}
+	_, err = credentials.GetUserByCredentials(ctx, interfaces.GetUserByCredentials{Email: "test@example.com", Password: "PAss00!!", MFACode: recoveryCodes[1]})
+	assert.ErrorIs(t, err, user.ErrMFALocked)
+
+	require.NoError(t, uc.DisableMFA(ctx, operator))
+	status, err = uc.GetMFAStatus(ctx, operator)
+	require.NoError(t, err)
+	assert.False(t, status.Enrolled)
+
+	enrollmentURL, err := uc.EnableMFA(ctx, operator)
+	require.NoError(t, err)
+	assert.Contains(t, enrollmentURL, "otpauth://totp/")
+}
+
 func TestUser_UpdateMe_AuthenticatorUpdateUserError(t *testing.T) {
 	user.DefaultPasswordEncoder = &user.NoopPasswordEncoder{}
 
@@ -1598,7 +1678,7 @@ func TestUser_UpdateMe_WorkspaceMetadataFindByIDError(t *testing.T) {
 	assert.Nil(t, result)
 }
 
-func TestUser_DeleteMe_DeletesUserAndPersonalWorkspace(t *testing.T) {
+func TestUser_DeleteMe_SchedulesDeletion(t *testing.T) {
 	ctx := context.Background()
 	r := memory.New()
 	uc := NewUser(r, nil, nil, "", "")
@@ -1616,85 +1696,66 @@ func TestUser_DeleteMe_DeletesUserAndPersonalWorkspace(t *testing.T) {
 	op := &workspace.Operator{User: &uid}
 	assert.NoError(t, uc.DeleteMe(ctx, uid, op))
 
-	_, err := r.User.FindByID(ctx, uid)
-	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	// The user is deactivated but kept until the purge
+	got, err := r.User.FindByID(ctx, uid)
+	assert.NoError(t, err)
+	assert.True(t, got.IsDeleted())
+	assert.NotNil(t, got.DeletionRequestedAt())
 
 	_, err = r.Workspace.FindByID(ctx, wid)
-	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	assert.NoError(t, err)
+
+	// Deleting again keeps the original request
+	requestedAt := *got.DeletionRequestedAt()
+	assert.NoError(t, uc.DeleteMe(ctx, uid, op))
+	got, err = r.User.FindByID(ctx, uid)
+	assert.NoError(t, err)
+	assert.Equal(t, requestedAt, *got.DeletionRequestedAt())
 }
 
-func TestUser_DeleteMe_LeavesSharedWorkspaceAndDeletesUser(t *testing.T) {
+func TestUser_CancelMyDeletion(t *testing.T) {
 	ctx := context.Background()
 	r := memory.New()
 	uc := NewUser(r, nil, nil, "", "")
 
 	uid := id.NewUserID()
-	wid := id.NewWorkspaceID()
-	sharedWID := id.NewWorkspaceID()
-	ownerUID := id.NewUserID()
+	u := user.New().ID(uid).Workspace(id.NewWorkspaceID()).Name("Test").Email("test@example.com").MustBuild()
+	assert.NoError(t, r.User.Save(ctx, u))
+	op := &workspace.Operator{User: &uid}
 
-	u := user.New().ID(uid).Workspace(wid).Name("Test").Email("test@example.com").MustBuild()
-	personalWS := workspace.New().ID(wid).Name("Test").Personal(true).Members(map[workspace.UserID]workspace.Member{
-		uid: {Role: role.RoleOwner},
-	}).MustBuild()
-	sharedWS := workspace.New().ID(sharedWID).Name("Shared").Members(map[workspace.UserID]workspace.Member{
-		uid:      {Role: role.RoleWriter},
-		ownerUID: {Role: role.RoleOwner},
-	}).MustBuild()
+	_, err := uc.CancelMyDeletion(ctx, &workspace.Operator{})
+	assert.ErrorIs(t, err, interfaces.ErrInvalidOperator)
 
-	assert.NoError(t, r.User.Save(ctx, u))
-	assert.NoError(t, r.Workspace.Save(ctx, personalWS))
-	assert.NoError(t, r.Workspace.Save(ctx, sharedWS))
+	_, err = uc.CancelMyDeletion(ctx, op)
+	assert.ErrorIs(t, err, user.ErrDeletionNotRequested)
 
-	op := &workspace.Operator{User: &uid}
 	assert.NoError(t, uc.DeleteMe(ctx, uid, op))
+	got, err := uc.CancelMyDeletion(ctx, op)
+	assert.NoError(t, err)
+	assert.False(t, got.IsDeleted())
+	assert.Nil(t, got.DeletionRequestedAt())
 
-	// User and personal workspace deleted
-	_, err := r.User.FindByID(ctx, uid)
-	assert.ErrorIs(t, err, rerror.ErrNotFound)
-	_, err = r.Workspace.FindByID(ctx, wid)
-	assert.ErrorIs(t, err, rerror.ErrNotFound)
-
-	// Shared workspace persists without the deleted user
-	remaining, err := r.Workspace.FindByID(ctx, sharedWID)
+	stored, err := r.User.FindByID(ctx, uid)
 	assert.NoError(t, err)
-	assert.False(t, remaining.Members().HasUser(uid))
-	assert.True(t, remaining.Members().HasUser(ownerUID))
+	assert.False(t, stored.IsDeleted())
 }
 
-func TestUser_DeleteMe_SoleOwnerOfSharedWorkspaceDeleted(t *testing.T) {
+func TestUser_CancelMyDeletion_DeactivatedByAdmin(t *testing.T) {
 	ctx := context.Background()
 	r := memory.New()
 	uc := NewUser(r, nil, nil, "", "")
 
 	uid := id.NewUserID()
-	wid := id.NewWorkspaceID()
-	ownedWID := id.NewWorkspaceID()
-
-	u := user.New().ID(uid).Workspace(wid).Name("Test").Email("test@example.com").MustBuild()
-	personalWS := workspace.New().ID(wid).Name("Test").Personal(true).Members(map[workspace.UserID]workspace.Member{
-		uid: {Role: role.RoleOwner},
-	}).MustBuild()
-	// Non-personal workspace where the user is the sole owner
-	ownedWS := workspace.New().ID(ownedWID).Name("Owned").Members(map[workspace.UserID]workspace.Member{
-		uid: {Role: role.RoleOwner},
-	}).MustBuild()
-
+	u := user.New().ID(uid).Workspace(id.NewWorkspaceID()).Name("Test").Email("test@example.com").MustBuild()
+	u.Deactivate()
 	assert.NoError(t, r.User.Save(ctx, u))
-	assert.NoError(t, r.Workspace.Save(ctx, personalWS))
-	assert.NoError(t, r.Workspace.Save(ctx, ownedWS))
 
-	op := &workspace.Operator{User: &uid}
-	assert.NoError(t, uc.DeleteMe(ctx, uid, op))
-
-	_, err := r.User.FindByID(ctx, uid)
-	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	_, err := uc.CancelMyDeletion(ctx, &workspace.Operator{User: &uid})
+	assert.ErrorIs(t, err, user.ErrDeletionNotRequested)
 
-	// Both workspaces deleted since user was sole owner of both
-	_, err = r.Workspace.FindByID(ctx, wid)
-	assert.ErrorIs(t, err, rerror.ErrNotFound)
-	_, err = r.Workspace.FindByID(ctx, ownedWID)
-	assert.ErrorIs(t, err, rerror.ErrNotFound)
+	stored, err := r.User.FindByID(ctx, uid)
+	assert.NoError(t, err)
+	assert.True(t, stored.IsDeleted())
 }
 
 type failingMailer struct{ err error }
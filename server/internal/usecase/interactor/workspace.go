@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/reearth/reearth-accounts/server/internal/rbac"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/interfaces"
 	"github.com/reearth/reearth-accounts/server/internal/usecase/repo"
 	"github.com/reearth/reearth-accounts/server/pkg/applog"
@@ -35,12 +36,13 @@ type Workspace struct {
 	userquery          interfaces.UserQuery
 	permittableRepo    permittable.Repo
 	roleRepo           role.Repo
+	storage            gateway.Storage
 	// TODO: we need to generate policy for accounts
 	// after that we need to check permission on each function
 	cerbos interfaces.Cerbos
 }
 
-func NewWorkspace(r *repo.Container, enforceMemberCount WorkspaceMemberCountEnforcer, cerbos interfaces.Cerbos) interfaces.Workspace {
+func NewWorkspace(r *repo.Container, enforceMemberCount WorkspaceMemberCountEnforcer, cerbos interfaces.Cerbos, storage gateway.Storage) interfaces.Workspace {
 	return &Workspace{
 		repos:              r,
 		enforceMemberCount: enforceMemberCount,
@@ -48,6 +50,7 @@ func NewWorkspace(r *repo.Container, enforceMemberCount WorkspaceMemberCountEnfo
 		permittableRepo:    r.Permittable,
 		roleRepo:           r.Role,
 		cerbos:             cerbos,
+		storage:            storage,
 	}
 }
 
@@ -183,6 +186,33 @@ func (i *Workspace) Create(ctx context.Context, alias, name, description string,
 	})
 }
 
+// checkEditPermission checks that the operator may edit the workspace via
+// Cerbos, falling back to an owner-only check when Cerbos is not configured.
+func (i *Workspace) checkEditPermission(ctx context.Context, ws *workspace.Workspace, operator *workspace.Operator) error {
+	if i.cerbos != nil {
+		result, cErr := i.cerbos.CheckPermission(ctx, *operator.User, interfaces.CheckPermissionParam{
+			Service:        rbac.ServiceName,
+			Resource:       rbac.ResourceWorkspace,
+			Action:         rbac.ActionEdit,
+			WorkspaceAlias: ws.Alias(),
+		})
+		if cErr != nil {
+			return applog.ErrorWithCallerLogging(ctx, "failed to check permission", cErr)
+		}
+		if result != nil {
+			if !result.Allowed {
+				return interfaces.ErrPermissionDenied
+			}
+			return nil
+		}
+	}
+
+	if ws.Members().UserRole(*operator.User) != role.RoleOwner {
+		return interfaces.ErrOperationDenied
+	}
+	return nil
+}
+
 func (i *Workspace) Update(ctx context.Context, param interfaces.UpdateWorkspaceParam, operator *workspace.Operator) (_ *workspace.Workspace, err error) {
 	if operator.User == nil {
 		return nil, interfaces.ErrInvalidOperator
@@ -198,30 +228,8 @@ func (i *Workspace) Update(ctx context.Context, param interfaces.UpdateWorkspace
 			return nil, workspace.ErrCannotModifyPersonalWorkspace
 		}
 
-		// Check permission via Cerbos or fallback to role-based check
-		var cerbosChecked bool
-		if i.cerbos != nil {
-			result, cErr := i.cerbos.CheckPermission(ctx, *operator.User, interfaces.CheckPermissionParam{
-				Service:        rbac.ServiceName,
-				Resource:       rbac.ResourceWorkspace,
-				Action:         rbac.ActionEdit,
-				WorkspaceAlias: ws.Alias(),
-			})
-			if cErr != nil {
-				return nil, applog.ErrorWithCallerLogging(ctx, "failed to check permission", cErr)
-			}
-			if result != nil {
-				cerbosChecked = true
-				if !result.Allowed {
-					return nil, interfaces.ErrPermissionDenied
-				}
-			}
-		}
-
-		if !cerbosChecked {
-			if ws.Members().UserRole(*operator.User) != role.RoleOwner {
-				return nil, interfaces.ErrOperationDenied
-			}
+		if err := i.checkEditPermission(ctx, ws, operator); err != nil {
+			return nil, err
 		}
 
 		// Update name if provided
@@ -43,7 +43,7 @@ func TestWorkspace_Create(t *testing.T) {
 
 	u := user.New().NewID().Name("aaa").Email("aaa@bbb.com").Workspace(id.NewWorkspaceID()).MustBuild()
 	_ = db.User.Save(ctx, u)
-	workspaceUC := NewWorkspace(db, nil, nil)
+	workspaceUC := NewWorkspace(db, nil, nil, nil)
 	op := &workspace.Operator{User: lo.ToPtr(u.ID())}
 	ws, err := workspaceUC.Create(ctx, "alias", "name", "description", u.ID(), false, op)
 
@@ -80,7 +80,7 @@ func TestWorkspace_Create_SkipOwnerMembership(t *testing.T) {
 
 	u := user.New().NewID().Name("veda").Email("veda@bbb.com").Workspace(id.NewWorkspaceID()).MustBuild()
 	_ = db.User.Save(ctx, u)
-	workspaceUC := NewWorkspace(db, nil, nil)
+	workspaceUC := NewWorkspace(db, nil, nil, nil)
 	op := &workspace.Operator{User: lo.ToPtr(u.ID())}
 
 	ws, err := workspaceUC.Create(ctx, "no-owner", "no-owner", "", u.ID(), true, op)
@@ -130,7 +130,7 @@ func TestWorkspace_Update(t *testing.T) {
 				MustBuild()
 			assert.NoError(t, db.Workspace.Save(ctx, ws))
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
 				ID:          wsID,
 				Name:        lo.ToPtr("Updated Name"),
@@ -175,7 +175,7 @@ func TestWorkspace_Update(t *testing.T) {
 				MustBuild()
 			assert.NoError(t, db.Workspace.Save(ctx, ws))
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
 				ID:   wsID,
 				Name: lo.ToPtr("New Name"),
@@ -208,7 +208,7 @@ func TestWorkspace_Update(t *testing.T) {
 				MustBuild()
 			assert.NoError(t, db.Workspace.Save(ctx, ws))
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
 				ID:          wsID,
 				Description: lo.ToPtr("New description"),
@@ -245,7 +245,7 @@ func TestWorkspace_Update(t *testing.T) {
 				MustBuild()
 			assert.NoError(t, db.Workspace.Save(ctx, ws))
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
 				ID:    wsID,
 				Alias: lo.ToPtr("same-alias"),
@@ -280,7 +280,7 @@ func TestWorkspace_Update(t *testing.T) {
 				MustBuild()
 			assert.NoError(t, db.Workspace.Save(ctx, ws))
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
 				ID:   wsID,
 				Name: lo.ToPtr("New Name"),
@@ -309,7 +309,7 @@ func TestWorkspace_Update(t *testing.T) {
 				MustBuild()
 			assert.NoError(t, db.Workspace.Save(ctx, ws))
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
 				ID:   wsID,
 				Name: lo.ToPtr("New Name"),
@@ -341,7 +341,7 @@ func TestWorkspace_Update(t *testing.T) {
 				MustBuild()
 			assert.NoError(t, db.Workspace.Save(ctx, ws))
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
 				ID:   wsID,
 				Name: lo.ToPtr("New Name"),
@@ -371,7 +371,7 @@ func TestWorkspace_Update(t *testing.T) {
 				MustBuild()
 			assert.NoError(t, db.Workspace.Save(ctx, ws))
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
 				ID:   wsID,
 				Name: lo.ToPtr("   "),
@@ -415,7 +415,7 @@ func TestWorkspace_Update(t *testing.T) {
 				MustBuild()
 			assert.NoError(t, db.Workspace.Save(ctx, other))
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
 				ID:    wsID,
 				Alias: lo.ToPtr("existing-alias"),
@@ -435,7 +435,7 @@ func TestWorkspace_Update(t *testing.T) {
 			ownerID := id.NewUserID()
 			wsID := id.NewWorkspaceID()
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
 				ID:   wsID,
 				Name: lo.ToPtr("New Name"),
@@ -465,7 +465,7 @@ func TestWorkspace_Update(t *testing.T) {
 				MustBuild()
 			assert.NoError(t, db.Workspace.Save(ctx, ws))
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 			// Test https URL
 			result, err := workspaceUC.Update(ctx, interfaces.UpdateWorkspaceParam{
@@ -622,7 +622,7 @@ func TestWorkspace_Fetch(t *testing.T) {
 				err := db.Workspace.Save(ctx, p)
 				assert.NoError(t, err)
 			}
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 			got, err := workspaceUC.Fetch(ctx, tc.args.ids, tc.args.operator)
 			if tc.wantErr != nil {
@@ -638,7 +638,7 @@ func TestWorkspace_Fetch(t *testing.T) {
 func TestWorkspace_Fetch_TooManyIDs(t *testing.T) {
 	ctx := context.Background()
 	db := memory.New()
-	workspaceUC := NewWorkspace(db, nil, nil)
+	workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 	ids := make([]workspace.ID, maxFetchWorkspaceIDs+1)
 	for i := range ids {
@@ -734,7 +734,7 @@ func TestWorkspace_FindByUser(t *testing.T) {
 				err := db.Workspace.Save(ctx, p)
 				assert.NoError(t, err)
 			}
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 			got, err := workspaceUC.FindByUser(ctx, tc.args.userID, tc.args.operator)
 			if tc.wantErr != nil {
@@ -860,7 +860,7 @@ func TestWorkspace_Remove(t *testing.T) {
 				err := db.Workspace.Save(ctx, p)
 				assert.NoError(t, err)
 			}
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 			err := workspaceUC.Remove(ctx, tc.args.wId, tc.args.operator)
 			if tc.wantErr != nil {
 				assert.Equal(t, tc.wantErr, err)
@@ -1065,7 +1065,7 @@ func TestWorkspace_AddMember(t *testing.T) {
 				err := db.User.Save(ctx, p)
 				assert.NoError(t, err)
 			}
-			workspaceUC := NewWorkspace(db, tc.enforcer, nil)
+			workspaceUC := NewWorkspace(db, tc.enforcer, nil, nil)
 
 			got, err := workspaceUC.AddUserMember(ctx, tc.args.wId, tc.args.users, tc.args.operator)
 			if tc.wantErr != nil {
@@ -1169,7 +1169,7 @@ func TestWorkspace_AddIntegrationMember(t *testing.T) {
 				assert.NoError(t, err)
 			}
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 			got, err := workspaceUC.AddIntegrationMember(ctx, tc.args.wId, tc.args.integrationID, tc.args.role, tc.args.operator)
 			if tc.wantErr != nil {
@@ -1315,7 +1315,7 @@ func TestWorkspace_RemoveMember(t *testing.T) {
 				err := db.User.Save(ctx, p)
 				assert.NoError(t, err)
 			}
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 			got, err := workspaceUC.RemoveUserMember(ctx, tc.args.wId, tc.args.uId, tc.args.operator)
 			if tc.wantErr != nil {
@@ -1566,7 +1566,7 @@ func TestWorkspace_RemoveMultipleMembers(t *testing.T) {
 				err := db.User.Save(ctx, p)
 				assert.NoError(t, err)
 			}
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 			got, err := workspaceUC.RemoveMultipleUserMembers(ctx, tc.args.wId, tc.args.uIds, tc.args.operator)
 			if tc.wantErr != nil {
@@ -1811,7 +1811,7 @@ func TestWorkspace_UpdateMember(t *testing.T) {
 				err := db.User.Save(ctx, p)
 				assert.NoError(t, err)
 			}
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 			got, err := workspaceUC.UpdateUserMember(ctx, tc.args.wId, tc.args.uId, tc.args.role, tc.args.operator)
 			if tc.wantErr != nil {
@@ -1957,7 +1957,7 @@ func TestWorkspace_RemoveIntegrations(t *testing.T) {
 				assert.NoError(t, err)
 			}
 
-			workspaceUC := NewWorkspace(db, nil, nil)
+			workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 			got, err := workspaceUC.RemoveIntegrations(ctx, tc.args.wId, tc.args.iIds, tc.args.op)
 			if tc.wantErr != nil {
@@ -2015,7 +2015,7 @@ func TestWorkspace_AddUserMember_PermittableSync(t *testing.T) {
 			OwningWorkspaces: workspace.IDList{wsID},
 		}
 
-		_, err := NewWorkspace(db, nil, nil).AddUserMember(ctx, wsID, map[user.ID]role.RoleType{
+		_, err := NewWorkspace(db, nil, nil, nil).AddUserMember(ctx, wsID, map[user.ID]role.RoleType{
 			u1.ID(): role.RoleWriter,
 			u2.ID(): role.RoleReader,
 		}, op)
@@ -2071,7 +2071,7 @@ func TestWorkspace_AddUserMember_PermittableSync(t *testing.T) {
 			OwningWorkspaces: workspace.IDList{ws1ID},
 		}
 
-		_, err := NewWorkspace(db, nil, nil).AddUserMember(ctx, ws1ID, map[user.ID]role.RoleType{
+		_, err := NewWorkspace(db, nil, nil, nil).AddUserMember(ctx, ws1ID, map[user.ID]role.RoleType{
 			u.ID(): role.RoleWriter,
 		}, op)
 		assert.NoError(t, err)
@@ -2356,7 +2356,7 @@ func TestWorkspace_FindAll(t *testing.T) {
 	ctx := context.Background()
 	db := memory.New()
 	op := maintainerOperator(ctx, t, db)
-	workspaceUC := NewWorkspace(db, nil, nil)
+	workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 	wsA := workspace.New().NewID().Name("alpha").MustBuild()
 	wsB := workspace.New().NewID().Name("beta").MustBuild()
@@ -2438,7 +2438,7 @@ func TestWorkspace_DeactivateAndRestore(t *testing.T) {
 	t.Run("owner can deactivate then restore", func(t *testing.T) {
 		wid, ownerID, db := newOwnedWorkspace()
 		op := &workspace.Operator{User: lo.ToPtr(ownerID), OwningWorkspaces: []workspace.ID{wid}}
-		workspaceUC := NewWorkspace(db, nil, nil)
+		workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 		ws, err := workspaceUC.Deactivate(ctx, wid, op)
 		assert.NoError(t, err)
@@ -2460,7 +2460,7 @@ func TestWorkspace_DeactivateAndRestore(t *testing.T) {
 	t.Run("non-owner cannot deactivate", func(t *testing.T) {
 		wid, _, db := newOwnedWorkspace()
 		op := &workspace.Operator{User: lo.ToPtr(id.NewUserID())}
-		workspaceUC := NewWorkspace(db, nil, nil)
+		workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 		_, err := workspaceUC.Deactivate(ctx, wid, op)
 		assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
@@ -2475,7 +2475,7 @@ func TestWorkspace_DeactivateAndRestore(t *testing.T) {
 			Personal(true).MustBuild()
 		assert.NoError(t, db.Workspace.Save(ctx, ws))
 		op := &workspace.Operator{User: lo.ToPtr(ownerID), OwningWorkspaces: []workspace.ID{wid}}
-		workspaceUC := NewWorkspace(db, nil, nil)
+		workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 		_, err := workspaceUC.Deactivate(ctx, wid, op)
 		assert.ErrorIs(t, err, workspace.ErrCannotModifyPersonalWorkspace)
@@ -2487,7 +2487,7 @@ func TestWorkspace_DeactivateAndRestore(t *testing.T) {
 		ws := workspace.New().ID(wid).Name("no-owner").Alias("no-owner").Personal(false).MustBuild()
 		assert.NoError(t, db.Workspace.Save(ctx, ws))
 		op := &workspace.Operator{User: lo.ToPtr(id.NewUserID())}
-		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true})
+		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true}, nil)
 
 		got, err := workspaceUC.Deactivate(ctx, wid, op)
 		assert.NoError(t, err)
@@ -2499,10 +2499,10 @@ func TestWorkspace_DeactivateAndRestore(t *testing.T) {
 		op := &workspace.Operator{User: lo.ToPtr(ownerID), OwningWorkspaces: []workspace.ID{wid}}
 		// Deactivate first via the fallback path (no cerbos), then attempt to
 		// restore with cerbos configured and denying.
-		_, err := NewWorkspace(db, nil, nil).Deactivate(ctx, wid, op)
+		_, err := NewWorkspace(db, nil, nil, nil).Deactivate(ctx, wid, op)
 		assert.NoError(t, err)
 
-		_, err = NewWorkspace(db, nil, &fakeCerbos{allowed: false}).Restore(ctx, wid, op)
+		_, err = NewWorkspace(db, nil, &fakeCerbos{allowed: false}, nil).Restore(ctx, wid, op)
 		assert.ErrorIs(t, err, interfaces.ErrPermissionDenied)
 
 		stored, err := db.Workspace.FindByID(ctx, wid)
@@ -2543,7 +2543,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		assert.NoError(t, db.User.Save(ctx, newUser))
 
 		op := &workspace.Operator{User: lo.ToPtr(ownerID), WritableWorkspaces: []workspace.ID{wid}}
-		workspaceUC := NewWorkspace(db, nil, nil)
+		workspaceUC := NewWorkspace(db, nil, nil, nil)
 
 		got, err := workspaceUC.AddUserMember(ctx, wid, map[user.ID]role.RoleType{newUser.ID(): role.RoleReader}, op)
 		assert.NoError(t, err)
@@ -2555,7 +2555,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		newUser := user.New().NewID().Name("bbb").Email("bbb@bbb.com").MustBuild()
 		assert.NoError(t, db.User.Save(ctx, newUser))
 
-		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true})
+		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true}, nil)
 		got, err := workspaceUC.AddUserMember(ctx, wid, map[user.ID]role.RoleType{newUser.ID(): role.RoleReader}, op)
 		assert.NoError(t, err)
 		assert.Equal(t, role.RoleReader, got.Members().UserRole(newUser.ID()))
@@ -2566,7 +2566,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		newUser := user.New().NewID().Name("bbb").Email("bbb@bbb.com").MustBuild()
 		assert.NoError(t, db.User.Save(ctx, newUser))
 
-		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: false})
+		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: false}, nil)
 		_, err := workspaceUC.AddUserMember(ctx, wid, map[user.ID]role.RoleType{newUser.ID(): role.RoleReader}, op)
 		assert.ErrorIs(t, err, interfaces.ErrPermissionDenied)
 	})
@@ -2582,7 +2582,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		assert.NoError(t, db.Workspace.Save(ctx, ws))
 		op := &workspace.Operator{User: lo.ToPtr(id.NewUserID())} // not a member at all
 
-		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true})
+		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true}, nil)
 		got, err := workspaceUC.UpdateUserMember(ctx, wid, targetUser, role.RoleWriter, op)
 		assert.NoError(t, err)
 		assert.Equal(t, role.RoleWriter, got.Members().UserRole(targetUser))
@@ -2606,7 +2606,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		// A real maintainer already has edit_member, so nothing (Cerbos included)
 		// should let them grant themselves Owner through UpdateUserMember: the
 		// owner role can only be granted via TransferOwnership.
-		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true})
+		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true}, nil)
 		_, err := workspaceUC.UpdateUserMember(ctx, wid, operatorID, role.RoleOwner, op)
 		assert.ErrorIs(t, err, workspace.ErrCannotChangeRoleToOwner)
 	})
@@ -2625,7 +2625,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		// actually gates this call (see the Writer-denied test below).
 		op := &workspace.Operator{User: lo.ToPtr(operatorID), MaintainableWorkspaces: []workspace.ID{wid}}
 
-		workspaceUC := NewWorkspace(db, nil, nil)
+		workspaceUC := NewWorkspace(db, nil, nil, nil)
 		got, err := workspaceUC.UpdateUserMemberViaService(ctx, wid, operatorID, role.RoleMaintainer, op)
 		assert.NoError(t, err)
 		assert.Equal(t, role.RoleMaintainer, got.Members().UserRole(operatorID))
@@ -2644,7 +2644,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 
 		// Cerbos would even allow it (global role), but Owner is blocked
 		// unconditionally — TransferOwnership is the only path to Owner.
-		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true})
+		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true}, nil)
 		_, err := workspaceUC.UpdateUserMemberViaService(ctx, wid, operatorID, role.RoleOwner, op)
 		assert.ErrorIs(t, err, workspace.ErrCannotChangeRoleToOwner)
 	})
@@ -2662,7 +2662,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		// permission gate alone wouldn't have blocked this without the guard.
 		op := &workspace.Operator{User: lo.ToPtr(ownerID), OwningWorkspaces: []workspace.ID{wid}}
 
-		workspaceUC := NewWorkspace(db, nil, nil)
+		workspaceUC := NewWorkspace(db, nil, nil, nil)
 		_, err := workspaceUC.UpdateUserMemberViaService(ctx, wid, ownerID, role.RoleMaintainer, op)
 		assert.ErrorIs(t, err, interfaces.ErrCannotChangeOwnerRole)
 	})
@@ -2682,7 +2682,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		assert.NoError(t, db.Workspace.Save(ctx, ws))
 		op := &workspace.Operator{User: lo.ToPtr(operatorID), MaintainableWorkspaces: []workspace.ID{wid}}
 
-		workspaceUC := NewWorkspace(db, nil, nil)
+		workspaceUC := NewWorkspace(db, nil, nil, nil)
 		got, err := workspaceUC.UpdateUserMemberViaService(ctx, wid, targetUser, role.RoleMaintainer, op)
 		assert.NoError(t, err)
 		assert.Equal(t, role.RoleMaintainer, got.Members().UserRole(targetUser))
@@ -2700,7 +2700,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		// Writer counts as writable but not maintaining, so this must still be denied.
 		op := &workspace.Operator{User: lo.ToPtr(operatorID), WritableWorkspaces: []workspace.ID{wid}}
 
-		workspaceUC := NewWorkspace(db, nil, nil)
+		workspaceUC := NewWorkspace(db, nil, nil, nil)
 		_, err := workspaceUC.UpdateUserMemberViaService(ctx, wid, operatorID, role.RoleMaintainer, op)
 		assert.ErrorIs(t, err, interfaces.ErrOperationDenied)
 	})
@@ -2716,7 +2716,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		assert.NoError(t, db.Workspace.Save(ctx, ws))
 		op := &workspace.Operator{User: lo.ToPtr(id.NewUserID())} // not a member at all
 
-		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true})
+		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true}, nil)
 		got, err := workspaceUC.UpdateUserMemberViaService(ctx, wid, targetUser, role.RoleMaintainer, op)
 		assert.NoError(t, err)
 		assert.Equal(t, role.RoleMaintainer, got.Members().UserRole(targetUser))
@@ -2735,7 +2735,7 @@ func TestWorkspace_MemberManagement_CerbosFallback(t *testing.T) {
 		assert.NoError(t, db.Workspace.Save(ctx, ws))
 		op := &workspace.Operator{User: lo.ToPtr(id.NewUserID())} // not a member at all
 
-		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true})
+		workspaceUC := NewWorkspace(db, nil, &fakeCerbos{allowed: true}, nil)
 		got, err := workspaceUC.RemoveMultipleUserMembers(ctx, wid, workspace.UserIDList{targetUser}, op)
 		assert.NoError(t, err)
 		assert.False(t, got.Members().HasUser(targetUser))
@@ -10,6 +10,9 @@ var (
 	ErrOperationDenied error = rerror.NewE(i18n.T("operation denied"))
 	ErrInvalidOperator error = rerror.NewE(i18n.T("invalid operator"))
 	ErrInvalidPhotoURL error = rerror.NewE(i18n.T("invalid photo URL"))
+	// ErrImpersonationDenied is returned for operations an admin impersonating
+	// a user may not perform on their behalf.
+	ErrImpersonationDenied error = rerror.NewE(i18n.T("operation denied while impersonating a user"))
 )
 
 type Container struct {
@@ -18,4 +21,5 @@ type Container struct {
 	User        User
 	Workspace   Workspace
 	Role        role.Repo
+	SCIM        SCIM
 }
@@ -0,0 +1,22 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var (
+	ErrLDAPSyncNotConfigured   = rerror.NewE(i18n.T("ldap directory is not configured"))
+	ErrLDAPSyncRunning         = rerror.NewE(i18n.T("ldap sync is already running"))
+	ErrLDAPSyncNoUsers         = rerror.NewE(i18n.T("ldap directory returned no users"))
+	ErrLDAPSyncTooManyRemovals = rerror.NewE(i18n.T("ldap sync would deactivate too many users"))
+)
+
+type LDAPSync interface {
+	// Sync provisions the users of the directory and records the run. A run
+	// that fails is recorded too and returned with its error.
+	Sync(ctx context.Context) (*ldapsync.Run, error)
+}
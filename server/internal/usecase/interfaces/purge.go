@@ -0,0 +1,28 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var ErrPurgeRunning = rerror.NewE(i18n.T("purge is already running"))
+
+// PurgeResult lists what a purge run did.
+type PurgeResult struct {
+	// Notified are the users who were mailed that they are about to be purged.
+	Notified   user.IDList
+	Users      user.IDList
+	Workspaces workspace.IDList
+}
+
+type Purge interface {
+	// Purge permanently removes the users and workspaces that have been
+	// deactivated for longer than the retention window, together with their
+	// permittable bindings. A user is mailed a notice before being purged and
+	// is purged no earlier than the notice period after it was sent.
+	Purge(ctx context.Context) (*PurgeResult, error)
+}
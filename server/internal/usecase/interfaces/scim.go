@@ -0,0 +1,81 @@
+package interfaces
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var (
+	ErrInvalidSCIMToken      = rerror.NewE(i18n.T("invalid scim token"))
+	ErrSCIMUserNameTaken     = rerror.NewE(i18n.T("scim user name is already in use"))
+	ErrSCIMInvalidUserName   = rerror.NewE(i18n.T("invalid scim user name"))
+	ErrSCIMMemberNotFound    = rerror.NewE(i18n.T("scim group member is not provisioned by the tenant"))
+	ErrSCIMInvalidGroupName  = rerror.NewE(i18n.T("invalid scim group name"))
+	ErrSCIMGroupNameConflict = rerror.NewE(i18n.T("scim group name is already provisioned"))
+)
+
+// SCIMUserParam carries the attributes of a SCIM User resource. Nil fields are
+// left unchanged on update.
+type SCIMUserParam struct {
+	UserName *string
+	Name     *string
+	Email    *string
+	Active   *bool
+}
+
+// SCIMUserFilter narrows a user listing. UserName and Email are exact matches.
+// Offset is zero-based; a Limit of zero returns only the total.
+type SCIMUserFilter struct {
+	UserName *string
+	Email    *string
+	Offset   int
+	Limit    int
+}
+
+// SCIMGroupParam carries the attributes of a SCIM Group resource. On update,
+// Members replaces the membership when set, and AddMembers and RemoveMembers
+// are applied afterwards.
+type SCIMGroupParam struct {
+	Name          *string
+	Role          *role.RoleType
+	Members       *user.IDList
+	AddMembers    user.IDList
+	RemoveMembers user.IDList
+}
+
+// SCIMGroup is a workspace provisioned as a SCIM Group, together with the role
+// its members hold.
+type SCIMGroup struct {
+	Workspace *workspace.Workspace
+	Role      role.RoleType
+}
+
+type SCIMGroupFilter struct {
+	Name   *string
+	Offset int
+	Limit  int
+}
+
+// SCIM provisions users and workspaces on behalf of an identity provider
+// tenant. Every method is scoped to the resources the tenant has provisioned.
+type SCIM interface {
+	Authenticate(ctx context.Context, token string) (*scimtenant.Tenant, error)
+
+	FindUsers(ctx context.Context, tenant scimtenant.ID, filter SCIMUserFilter) (user.List, int, error)
+	FindUser(ctx context.Context, tenant scimtenant.ID, id user.ID) (*user.User, error)
+	CreateUser(ctx context.Context, tenant scimtenant.ID, param SCIMUserParam) (*user.User, error)
+	UpdateUser(ctx context.Context, tenant scimtenant.ID, id user.ID, param SCIMUserParam) (*user.User, error)
+	DeleteUser(ctx context.Context, tenant scimtenant.ID, id user.ID) error
+
+	FindGroups(ctx context.Context, tenant scimtenant.ID, filter SCIMGroupFilter) ([]SCIMGroup, int, error)
+	FindGroup(ctx context.Context, tenant scimtenant.ID, id workspace.ID) (*SCIMGroup, error)
+	CreateGroup(ctx context.Context, tenant scimtenant.ID, param SCIMGroupParam) (*SCIMGroup, error)
+	UpdateGroup(ctx context.Context, tenant scimtenant.ID, id workspace.ID, param SCIMGroupParam) (*SCIMGroup, error)
+	DeleteGroup(ctx context.Context, tenant scimtenant.ID, id workspace.ID) error
+}
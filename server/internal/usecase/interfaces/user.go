@@ -2,10 +2,12 @@ package interfaces
 
 import (
 	"context"
+	"encoding/json"
 
 	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/asset/domain/file"
 	"github.com/reearth/reearthx/i18n"
 	"github.com/reearth/reearthx/rerror"
 	"golang.org/x/text/language"
@@ -19,9 +21,20 @@ var (
 	ErrInvalidUserEmail                = rerror.NewE(i18n.T("invalid email"))
 	ErrNotVerifiedUser                 = rerror.NewE(i18n.T("not verified user"))
 	ErrInvalidEmailOrPassword          = rerror.NewE(i18n.T("invalid email or password"))
+	ErrMFARequired                     = rerror.NewE(i18n.T("mfa code required"))
+	ErrMFAConfirmationNotSupported     = rerror.NewE(i18n.T("mfa confirmation is not supported by this provider"))
+	ErrInvalidMagicLink                = rerror.NewE(i18n.T("invalid or expired sign-in link"))
+	ErrInvalidAuthCode                 = rerror.NewE(i18n.T("invalid authorization code"))
+	ErrPasskeyNotConfigured            = rerror.NewE(i18n.T("passkeys are not configured"))
+	ErrInvalidPasskeyChallenge         = rerror.NewE(i18n.T("passkey challenge is missing or expired"))
+	ErrInvalidPasskey                  = rerror.NewE(i18n.T("invalid passkey"))
 	ErrUserAlreadyExists               = rerror.NewE(i18n.T("user already exists"))
 	ErrUserAliasAlreadyExists          = rerror.NewE(i18n.T("user alias already exists"))
 	ErrWorkspaceAliasAlreadyExists     = rerror.NewE(i18n.T("workspace alias already exists"))
+	ErrAuthLinkNotConfigured           = rerror.NewE(i18n.T("linking identities is not configured"))
+	ErrInvalidLinkToken                = rerror.NewE(i18n.T("invalid or expired token of the identity to link"))
+	ErrAuthAlreadyLinked               = rerror.NewE(i18n.T("the identity is already linked to this user"))
+	ErrAuthLinkedToAnotherUser         = rerror.NewE(i18n.T("the identity belongs to another user"))
 )
 
 type SignupOIDCParam struct {
@@ -42,6 +55,19 @@ type SyncSSOUserParam struct {
 	Theme       *user.Theme
 	UserID      *user.ID
 	WorkspaceID *workspace.ID
+	// Issuer and Claims are those of the verified token the user signed in
+	// with. When set, the role mappings of the identity provider are applied
+	// to the user.
+	Issuer string
+	Claims map[string]any
+}
+
+// ApplyRoleMappingsParam identifies the token a user has been signed in with.
+type ApplyRoleMappingsParam struct {
+	User   *user.User
+	Issuer string
+	Sub    string
+	Claims map[string]any
 }
 
 type SignupUserParam struct {
@@ -72,6 +98,42 @@ type UserFindOrCreateParam struct {
 type GetUserByCredentials struct {
 	Email    string
 	Password string
+	// MFACode is a TOTP or recovery code, required when the user has MFA enabled.
+	MFACode string
+}
+
+type StartMagicLinkParam struct {
+	Email string
+	// RequestID is the pending sign-in request of the built-in OIDC provider,
+	// carried through the link so that the login page can redeem it.
+	RequestID string
+}
+
+type GetUserByMagicLink struct {
+	Email string
+	Token string
+	// MFACode is a TOTP or recovery code, required when the user has MFA enabled.
+	MFACode string
+}
+
+type RedeemAuthCodeParam struct {
+	Code         string
+	ClientID     string
+	RedirectURI  string
+	CodeVerifier string
+}
+
+type GetUserByPasskey struct {
+	// Session is the login ceremony returned by BeginPasskeyLogin.
+	Session string
+	// Response is the JSON-encoded PublicKeyCredential from navigator.credentials.get.
+	Response []byte
+}
+
+type FinishPasskeyRegistrationParam struct {
+	Nickname string
+	// Response is the JSON-encoded PublicKeyCredential from navigator.credentials.create.
+	Response []byte
 }
 
 type UpdateMeParam struct {
@@ -133,14 +195,30 @@ type User interface {
 	Signup(context.Context, SignupParam) (*user.User, error)
 	SignupOIDC(context.Context, SignupOIDCParam) (*user.User, error)
 	SyncSSOUser(context.Context, SyncSSOUserParam) (*user.User, error)
+	// ApplyRoleMappings updates the memberships of the user in the workspaces
+	// whose identity provider issued the token, according to their role
+	// mappings.
+	ApplyRoleMappings(context.Context, ApplyRoleMappingsParam) error
 
 	// session management
 	Logout(context.Context, *workspace.Operator) (*user.User, error)
 
 	// editing me
+	// DeleteMe deactivates the signed-in user and schedules their purge.
 	DeleteMe(context.Context, user.ID, *workspace.Operator) error
+	// CancelMyDeletion reactivates the signed-in user who called DeleteMe.
+	CancelMyDeletion(context.Context, *workspace.Operator) (*user.User, error)
 	RemoveMyAuth(context.Context, string, *workspace.Operator) (*user.User, error)
+	// LinkMyAuth adds the identity proven by a fresh token of another provider
+	// to the signed-in user.
+	LinkMyAuth(ctx context.Context, token string, operator *workspace.Operator) (*user.User, error)
 	UpdateMe(context.Context, UpdateMeParam, *workspace.Operator) (*user.User, error)
+	// UploadMyPhoto sets the thumbnails of the photo as the photo of the
+	// signed-in user and their personal workspace.
+	UploadMyPhoto(context.Context, *file.File, *workspace.Operator) (*user.User, error)
+	// RequestMyDataExport mails the signed-in user a link to download an
+	// archive of their personal data.
+	RequestMyDataExport(context.Context, *workspace.Operator) error
 
 	// admin: deactivate soft-deletes a user (sets deleted_at); restore reverses it.
 	// Same permission model as workspace's Deactivate/Restore (Cerbos, falling back
@@ -157,11 +235,18 @@ type User interface {
 	CreateVerification(context.Context, string) error
 	VerifyUser(context.Context, string) (*user.User, error)
 	StartPasswordReset(context.Context, string) error
+	StartMagicLink(context.Context, StartMagicLinkParam) error
 	PasswordReset(context.Context, string, string) error
 
 	// mfa
 	DisableMFA(context.Context, *workspace.Operator) error
 	EnableMFA(context.Context, *workspace.Operator) (enrollmentURL string, err error)
+	ConfirmMFA(ctx context.Context, code string, operator *workspace.Operator) (recoveryCodes []string, err error)
 	GetMFAStatus(context.Context, *workspace.Operator) (gateway.MFAStatus, error)
-	RegenerateMFARecoveryCode(context.Context, *workspace.Operator) (recoveryCode string, err error)
+	RegenerateMFARecoveryCode(context.Context, *workspace.Operator) (recoveryCodes []string, err error)
+
+	// passkeys
+	BeginPasskeyRegistration(context.Context, *workspace.Operator) (options json.RawMessage, err error)
+	FinishPasskeyRegistration(context.Context, FinishPasskeyRegistrationParam, *workspace.Operator) (*user.Passkey, error)
+	RemoveMyPasskey(ctx context.Context, id []byte, operator *workspace.Operator) (*user.User, error)
 }
@@ -4,11 +4,13 @@ package proxy
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 
 	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/asset/domain/file"
 	"github.com/reearth/reearthx/util"
 
 	_ "github.com/Khan/genqlient/generate"
@@ -89,6 +91,10 @@ func (u *User) SyncSSOUser(_ context.Context, _ interfaces.SyncSSOUserParam) (*u
 	panic("unsupported")
 }
 
+func (u *User) ApplyRoleMappings(_ context.Context, _ interfaces.ApplyRoleMappingsParam) error {
+	panic("unsupported")
+}
+
 func (u *User) FindOrCreate(ctx context.Context, param interfaces.UserFindOrCreateParam) (*user.User, error) {
 	input := FindOrCreateInput{
 		Sub:   param.Sub,
@@ -225,12 +231,48 @@ func (u *User) EnableMFA(_ context.Context, _ *workspace.Operator) (string, erro
 	return "", errors.New("EnableMFA is not supported in proxy mode")
 }
 
+func (u *User) ConfirmMFA(_ context.Context, _ string, _ *workspace.Operator) ([]string, error) {
+	return nil, errors.New("ConfirmMFA is not supported in proxy mode")
+}
+
 func (u *User) GetMFAStatus(_ context.Context, _ *workspace.Operator) (gateway.MFAStatus, error) {
 	return gateway.MFAStatus{}, errors.New("GetMFAStatus is not supported in proxy mode")
 }
 
-func (u *User) RegenerateMFARecoveryCode(_ context.Context, _ *workspace.Operator) (string, error) {
-	return "", errors.New("RegenerateMFARecoveryCode is not supported in proxy mode")
+func (u *User) RegenerateMFARecoveryCode(_ context.Context, _ *workspace.Operator) ([]string, error) {
+	return nil, errors.New("RegenerateMFARecoveryCode is not supported in proxy mode")
+}
+
+func (u *User) StartMagicLink(_ context.Context, _ interfaces.StartMagicLinkParam) error {
+	return errors.New("StartMagicLink is not supported in proxy mode")
+}
+
+func (u *User) BeginPasskeyRegistration(_ context.Context, _ *workspace.Operator) (json.RawMessage, error) {
+	return nil, errors.New("BeginPasskeyRegistration is not supported in proxy mode")
+}
+
+func (u *User) FinishPasskeyRegistration(_ context.Context, _ interfaces.FinishPasskeyRegistrationParam, _ *workspace.Operator) (*user.Passkey, error) {
+	return nil, errors.New("FinishPasskeyRegistration is not supported in proxy mode")
+}
+
+func (u *User) LinkMyAuth(_ context.Context, _ string, _ *workspace.Operator) (*user.User, error) {
+	return nil, errors.New("LinkMyAuth is not supported in proxy mode")
+}
+
+func (u *User) RequestMyDataExport(_ context.Context, _ *workspace.Operator) error {
+	return errors.New("RequestMyDataExport is not supported in proxy mode")
+}
+
+func (u *User) UploadMyPhoto(_ context.Context, _ *file.File, _ *workspace.Operator) (*user.User, error) {
+	return nil, errors.New("UploadMyPhoto is not supported in proxy mode")
+}
+
+func (u *User) CancelMyDeletion(_ context.Context, _ *workspace.Operator) (*user.User, error) {
+	return nil, errors.New("CancelMyDeletion is not supported in proxy mode")
+}
+
+func (u *User) RemoveMyPasskey(_ context.Context, _ []byte, _ *workspace.Operator) (*user.User, error) {
+	return nil, errors.New("RemoveMyPasskey is not supported in proxy mode")
 }
 
 func (u *User) UpdateUserBySub(_ context.Context, _ string, _ *string, _ *workspace.Operator) error {
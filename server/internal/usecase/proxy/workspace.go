@@ -11,6 +11,7 @@ import (
 	accountid "github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/reearth/reearth-accounts/server/pkg/role"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/asset/domain/file"
 	"github.com/samber/lo"
 )
 
@@ -211,6 +212,12 @@ func (w *Workspace) Restore(ctx context.Context, id workspace.ID, op *workspace.
 	return nil, workspace.ErrNotImplemented
 }
 
+// UploadPhoto is not supported via this GraphQL proxy: the client sends no
+// multipart requests.
+func (w *Workspace) UploadPhoto(ctx context.Context, id workspace.ID, photo *file.File, op *workspace.Operator) (*workspace.Workspace, error) {
+	return nil, workspace.ErrNotImplemented
+}
+
 func (w *Workspace) TransferOwnership(ctx context.Context, id workspace.ID, newOwnerID accountid.UserID, op *workspace.Operator) (*workspace.Workspace, error) {
 	res, err := TransferWorkspaceOwnership(ctx, w.gql, TransferWorkspaceOwnershipInput{WorkspaceId: id.String(), NewOwnerId: newOwnerID.String()})
 	if err != nil {
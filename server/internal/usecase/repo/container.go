@@ -1,10 +1,19 @@
 package repo
 
 import (
+	"github.com/reearth/reearth-accounts/server/pkg/adminapprovalrule"
+	"github.com/reearth/reearth-accounts/server/pkg/adminaudit"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminstats"
 	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
 	"github.com/reearth/reearth-accounts/server/pkg/config"
+	"github.com/reearth/reearth-accounts/server/pkg/ldapsync"
+	"github.com/reearth/reearth-accounts/server/pkg/passkeylogin"
 	"github.com/reearth/reearth-accounts/server/pkg/permittable"
 	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/rolemapping"
+	"github.com/reearth/reearth-accounts/server/pkg/scimtenant"
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearth-accounts/server/pkg/workspace"
 	"github.com/reearth/reearthx/i18n"
@@ -13,14 +22,24 @@ import (
 )
 
 type Container struct {
-	AdminUser   adminuser.Repo
-	User        user.Repo
-	Workspace   workspace.Repo
-	Role        role.Repo
-	Permittable permittable.Repo
-	Transaction usecasex.Transaction
-	Users       []user.Repo
-	Config      config.Repo
+	AdminUser         adminuser.Repo
+	User              user.Repo
+	Workspace         workspace.Repo
+	Role              role.Repo
+	Permittable       permittable.Repo
+	Transaction       usecasex.Transaction
+	Users             []user.Repo
+	Config            config.Repo
+	SCIMTenant        scimtenant.Repo
+	AuditLog          auditlog.Repo
+	RoleMapping       rolemapping.Repo
+	LDAPSync          ldapsync.Repo
+	AdminAudit        adminaudit.Repo
+	AdminSession      adminsession.Repo
+	AdminApprovalRule adminapprovalrule.Repo
+	AdminStats        adminstats.Repo
+	PasskeyLogin      passkeylogin.Repo
+	Lock              Lock
 }
 
 var (
@@ -32,13 +51,23 @@ func (c *Container) Filtered(f workspace.WorkspaceFilter) *Container {
 		return c
 	}
 	return &Container{
-		Workspace:   c.Workspace.Filtered(f),
-		AdminUser:   c.AdminUser,
-		User:        c.User,
-		Users:       c.Users,
-		Role:        c.Role,
-		Permittable: c.Permittable,
-		Transaction: c.Transaction,
+		Workspace:         c.Workspace.Filtered(f),
+		AdminUser:         c.AdminUser,
+		User:              c.User,
+		Users:             c.Users,
+		Role:              c.Role,
+		Permittable:       c.Permittable,
+		Transaction:       c.Transaction,
+		SCIMTenant:        c.SCIMTenant,
+		AuditLog:          c.AuditLog,
+		RoleMapping:       c.RoleMapping,
+		LDAPSync:          c.LDAPSync,
+		AdminAudit:        c.AdminAudit,
+		AdminSession:      c.AdminSession,
+		AdminApprovalRule: c.AdminApprovalRule,
+		AdminStats:        c.AdminStats,
+		PasskeyLogin:      c.PasskeyLogin,
+		Lock:              c.Lock,
 	}
 }
 
@@ -3,6 +3,7 @@ package repo
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearthx/rerror"
@@ -161,6 +162,18 @@ func (u MultiUser) FindBySubOrCreate(ctx context.Context, v *user.User, s string
 	})
 }
 
+func (u MultiUser) FindDeletedBefore(ctx context.Context, t time.Time) (user.List, error) {
+	res := user.List{}
+	for _, r := range u {
+		if r, err := r.FindDeletedBefore(ctx, t); err != nil {
+			return nil, err
+		} else {
+			res = append(res, r...)
+		}
+	}
+	return res, nil
+}
+
 func (u MultiUser) Create(ctx context.Context, usr *user.User) error {
 	return u.first(func(r user.Repo) error {
 		return r.Create(ctx, usr)
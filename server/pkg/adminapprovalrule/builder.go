@@ -0,0 +1,87 @@
+package adminapprovalrule
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+type Builder struct {
+	r *Rule
+}
+
+func New() *Builder {
+	return &Builder{r: &Rule{}}
+}
+
+func (b *Builder) Build() (*Rule, error) {
+	if b.r.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if !b.r.kind.Valid() {
+		return nil, ErrInvalidKind
+	}
+	v, err := normalizeValue(b.r.kind, b.r.value)
+	if err != nil {
+		return nil, err
+	}
+	b.r.value = v
+	if b.r.provider != "" && !b.r.provider.Valid() {
+		return nil, ErrInvalidProvider
+	}
+	if !b.r.role.Valid() {
+		return nil, adminuser.ErrInvalidRole
+	}
+	if b.r.updatedAt.IsZero() {
+		b.r.updatedAt = time.Now()
+	}
+	return b.r, nil
+}
+
+func (b *Builder) MustBuild() *Rule {
+	r, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.r.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.r.id = NewID()
+	return b
+}
+
+func (b *Builder) Kind(k Kind) *Builder {
+	b.r.kind = k
+	return b
+}
+
+func (b *Builder) Value(v string) *Builder {
+	b.r.value = v
+	return b
+}
+
+func (b *Builder) Provider(p Provider) *Builder {
+	b.r.provider = p
+	return b
+}
+
+func (b *Builder) Role(r adminuser.Role) *Builder {
+	b.r.role = r
+	return b
+}
+
+func (b *Builder) CreatedBy(u adminuser.ID) *Builder {
+	b.r.createdBy = u
+	return b
+}
+
+func (b *Builder) UpdatedAt(t time.Time) *Builder {
+	b.r.updatedAt = t
+	return b
+}
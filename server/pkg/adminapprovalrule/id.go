@@ -0,0 +1,18 @@
+package adminapprovalrule
+
+import (
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+type ID = id.AdminApprovalRuleID
+type IDList = id.AdminApprovalRuleIDList
+
+var NewID = id.NewAdminApprovalRuleID
+
+var MustID = id.MustAdminApprovalRuleID
+
+var IDFrom = id.AdminApprovalRuleIDFrom
+
+var IDFromRef = id.AdminApprovalRuleIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
@@ -0,0 +1,55 @@
+package adminapprovalrule
+
+import "strings"
+
+type List []*Rule
+
+// Match returns the rule that approves the sign-in, or nil. Group rules are
+// more specific than email domain rules and win over them; among rules of
+// the same kind the oldest one wins, so the list must be ordered by
+// creation as Repo.FindAll returns it.
+func (l List) Match(s Subject) *Rule {
+	for _, k := range []Kind{KindGroup, KindEmailDomain} {
+		for _, r := range l {
+			if r.Kind() == k && r.Matches(s) {
+				return r
+			}
+		}
+	}
+	return nil
+}
+
+// HasGroupRules reports whether a group rule applies to sign-ins through p,
+// i.e. whether the groups of the admin are worth resolving.
+func (l List) HasGroupRules(p Provider) bool {
+	for _, r := range l {
+		if r.Kind() == KindGroup && (r.Provider() == "" || r.Provider() == p) {
+			return true
+		}
+	}
+	return false
+}
+
+// Find returns the rule with the kind, normalized value and provider, or nil.
+// Values compare case-insensitively, as they match.
+func (l List) Find(kind Kind, value string, p Provider) *Rule {
+	for _, r := range l {
+		if r.Kind() == kind && r.Provider() == p && strings.EqualFold(r.Value(), value) {
+			return r
+		}
+	}
+	return nil
+}
+
+func (l List) IDs() IDList {
+	if l == nil {
+		return nil
+	}
+	ids := make(IDList, 0, len(l))
+	for _, r := range l {
+		if r != nil {
+			ids = append(ids, r.ID())
+		}
+	}
+	return ids
+}
@@ -0,0 +1,16 @@
+package adminapprovalrule
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=./repo.go -destination=./mock_adminapprovalrule.go -package adminapprovalrule
+type Repo interface {
+	// FindAll returns every rule, oldest first.
+	FindAll(context.Context) (List, error)
+	// FindByID returns the rule, or rerror.ErrNotFound.
+	FindByID(context.Context, ID) (*Rule, error)
+	Save(context.Context, *Rule) error
+	// Remove removes the rule. Removing a missing rule is not an error.
+	Remove(context.Context, ID) error
+}
@@ -0,0 +1,215 @@
+package adminapprovalrule
+
+import (
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+var (
+	// KindEmailDomain matches the domain of the admin's email address.
+	KindEmailDomain = Kind("email_domain")
+	// KindGroup matches a group the admin belongs to: a Google Workspace group
+	// (by its email address) or an entry of the OIDC groups claim.
+	KindGroup = Kind("group")
+
+	kinds = []Kind{
+		KindEmailDomain,
+		KindGroup,
+	}
+
+	ErrInvalidKind = errors.New("invalid approval rule kind")
+)
+
+var (
+	// ProviderGoogle scopes a rule to Google sign-ins.
+	ProviderGoogle = Provider("google")
+	// ProviderOIDC scopes a rule to sign-ins through the generic OIDC provider.
+	ProviderOIDC = Provider("oidc")
+
+	providers = []Provider{
+		ProviderGoogle,
+		ProviderOIDC,
+	}
+
+	ErrInvalidProvider = errors.New("invalid approval rule provider")
+)
+
+var (
+	ErrEmptyValue   = errors.New("approval rule value can't be empty")
+	ErrInvalidValue = errors.New("invalid approval rule value")
+)
+
+type Kind string
+
+func (k Kind) Valid() bool {
+	return slices.Contains(kinds, k)
+}
+
+func (k Kind) String() string {
+	return string(k)
+}
+
+func KindFrom(s string) (Kind, error) {
+	k := Kind(strings.ToLower(s))
+	if k.Valid() {
+		return k, nil
+	}
+	return k, ErrInvalidKind
+}
+
+// Provider is the identity provider an admin signed in with. The empty
+// provider on a rule means any provider.
+type Provider string
+
+func (p Provider) Valid() bool {
+	return slices.Contains(providers, p)
+}
+
+func (p Provider) String() string {
+	return string(p)
+}
+
+// ProviderFrom parses a provider; the empty string is the "any provider"
+// value of a rule.
+func ProviderFrom(s string) (Provider, error) {
+	p := Provider(strings.ToLower(s))
+	if p == "" || p.Valid() {
+		return p, nil
+	}
+	return p, ErrInvalidProvider
+}
+
+// Rule approves new admin users automatically: an admin whose sign-in
+// matches the rule is approved with the rule's role instead of waiting as
+// pending for a manual approval.
+type Rule struct {
+	id        ID
+	kind      Kind
+	value     string
+	provider  Provider
+	role      adminuser.Role
+	createdBy adminuser.ID
+	updatedAt time.Time
+}
+
+func (r *Rule) ID() ID {
+	if r == nil {
+		return ID{}
+	}
+	return r.id
+}
+
+func (r *Rule) Kind() Kind {
+	if r == nil {
+		return ""
+	}
+	return r.kind
+}
+
+// Value is the email domain (lowercase, without "@") or the group name the
+// rule matches.
+func (r *Rule) Value() string {
+	if r == nil {
+		return ""
+	}
+	return r.value
+}
+
+// Provider is the identity provider the rule is limited to, or empty for
+// any.
+func (r *Rule) Provider() Provider {
+	if r == nil {
+		return ""
+	}
+	return r.provider
+}
+
+// Role is the role granted to the admins the rule approves.
+func (r *Rule) Role() adminuser.Role {
+	if r == nil {
+		return ""
+	}
+	return r.role
+}
+
+func (r *Rule) CreatedBy() adminuser.ID {
+	if r == nil {
+		return adminuser.ID{}
+	}
+	return r.createdBy
+}
+
+// CreatedAt is derived from the ULID-based ID, which embeds its creation time.
+func (r *Rule) CreatedAt() time.Time {
+	if r == nil {
+		return time.Time{}
+	}
+	return r.id.Timestamp()
+}
+
+func (r *Rule) UpdatedAt() time.Time {
+	if r == nil {
+		return time.Time{}
+	}
+	return r.updatedAt
+}
+
+// SetRole changes the role the rule grants. Admins the rule already approved
+// keep their role.
+func (r *Rule) SetRole(role adminuser.Role) error {
+	if r == nil {
+		return nil
+	}
+	if !role.Valid() {
+		return adminuser.ErrInvalidRole
+	}
+	r.role = role
+	r.updatedAt = time.Now()
+	return nil
+}
+
+// Subject is the sign-in a rule is matched against.
+type Subject struct {
+	Provider Provider
+	// Email is the normalized email address of the admin.
+	Email  string
+	Groups []string
+}
+
+// Matches reports whether the rule approves the sign-in.
+func (r *Rule) Matches(s Subject) bool {
+	if r == nil || (r.provider != "" && r.provider != s.Provider) {
+		return false
+	}
+	switch r.kind {
+	case KindEmailDomain:
+		_, domain, ok := strings.Cut(s.Email, "@")
+		return ok && strings.EqualFold(domain, r.value)
+	case KindGroup:
+		return slices.ContainsFunc(s.Groups, func(g string) bool {
+			return strings.EqualFold(strings.TrimSpace(g), r.value)
+		})
+	}
+	return false
+}
+
+// normalizeValue trims the value and, for email domains, lowercases it and
+// drops a leading "@".
+func normalizeValue(k Kind, v string) (string, error) {
+	v = strings.TrimSpace(v)
+	if k == KindEmailDomain {
+		v = strings.ToLower(strings.TrimPrefix(v, "@"))
+	}
+	if v == "" {
+		return "", ErrEmptyValue
+	}
+	if k == KindEmailDomain && (strings.ContainsAny(v, "@ \t") || !strings.Contains(v, ".") ||
+		strings.HasPrefix(v, ".") || strings.HasSuffix(v, ".")) {
+		return "", ErrInvalidValue
+	}
+	return v, nil
+}
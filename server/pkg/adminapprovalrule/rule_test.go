@@ -0,0 +1,73 @@
+package adminapprovalrule
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	by := adminuser.NewID()
+	r, err := New().NewID().Kind(KindEmailDomain).Value(" @Example.COM ").Provider(ProviderOIDC).
+		Role(adminuser.RoleViewer).CreatedBy(by).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "example.com", r.Value())
+	assert.Equal(t, ProviderOIDC, r.Provider())
+	assert.Equal(t, adminuser.RoleViewer, r.Role())
+	assert.Equal(t, by, r.CreatedBy())
+	assert.False(t, r.UpdatedAt().IsZero())
+
+	g, err := New().NewID().Kind(KindGroup).Value(" Admins ").Role(adminuser.RoleSystemAdmin).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, "Admins", g.Value())
+
+	tests := []struct {
+		name string
+		b    *Builder
+		err  error
+	}{
+		{"no id", New().Kind(KindGroup).Value("g").Role(adminuser.RoleViewer), ErrInvalidID},
+		{"bad kind", New().NewID().Kind("user").Value("g").Role(adminuser.RoleViewer), ErrInvalidKind},
+		{"empty value", New().NewID().Kind(KindGroup).Value(" ").Role(adminuser.RoleViewer), ErrEmptyValue},
+		{"email as domain", New().NewID().Kind(KindEmailDomain).Value("a@example.com").Role(adminuser.RoleViewer), ErrInvalidValue},
+		{"bare label domain", New().NewID().Kind(KindEmailDomain).Value("localhost").Role(adminuser.RoleViewer), ErrInvalidValue},
+		{"bad provider", New().NewID().Kind(KindGroup).Value("g").Provider("ldap").Role(adminuser.RoleViewer), ErrInvalidProvider},
+		{"no role", New().NewID().Kind(KindGroup).Value("g"), adminuser.ErrInvalidRole},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := tt.b.Build()
+			assert.ErrorIs(t, err, tt.err)
+		})
+	}
+}
+
+func TestRule_Matches(t *testing.T) {
+	domain := New().NewID().Kind(KindEmailDomain).Value("example.com").Role(adminuser.RoleViewer).MustBuild()
+	group := New().NewID().Kind(KindGroup).Value("admins@example.com").Provider(ProviderGoogle).Role(adminuser.RoleSystemAdmin).MustBuild()
+
+	assert.True(t, domain.Matches(Subject{Provider: ProviderOIDC, Email: "a@example.com"}))
+	assert.False(t, domain.Matches(Subject{Provider: ProviderOIDC, Email: "a@sub.example.com"}))
+	assert.False(t, domain.Matches(Subject{Provider: ProviderOIDC, Email: "a@example.com.evil"}))
+
+	assert.True(t, group.Matches(Subject{Provider: ProviderGoogle, Email: "a@x.io", Groups: []string{"staff@example.com", "Admins@example.com"}}))
+	assert.False(t, group.Matches(Subject{Provider: ProviderOIDC, Email: "a@x.io", Groups: []string{"admins@example.com"}}))
+	assert.False(t, group.Matches(Subject{Provider: ProviderGoogle, Email: "a@x.io"}))
+}
+
+func TestList_Match(t *testing.T) {
+	domain := New().NewID().Kind(KindEmailDomain).Value("example.com").Role(adminuser.RoleViewer).MustBuild()
+	group := New().NewID().Kind(KindGroup).Value("admins").Role(adminuser.RoleSystemAdmin).MustBuild()
+	later := New().NewID().Kind(KindEmailDomain).Value("example.com").Provider(ProviderOIDC).Role(adminuser.RoleSystemAdmin).MustBuild()
+	l := List{domain, group, later}
+
+	assert.Equal(t, group, l.Match(Subject{Provider: ProviderOIDC, Email: "a@example.com", Groups: []string{"admins"}}))
+	assert.Equal(t, domain, l.Match(Subject{Provider: ProviderOIDC, Email: "a@example.com"}))
+	assert.Nil(t, l.Match(Subject{Provider: ProviderOIDC, Email: "a@other.com"}))
+
+	assert.True(t, l.HasGroupRules(ProviderGoogle))
+	assert.False(t, List{domain}.HasGroupRules(ProviderGoogle))
+	assert.Equal(t, later, l.Find(KindEmailDomain, "example.com", ProviderOIDC))
+	assert.Nil(t, l.Find(KindEmailDomain, "example.com", ProviderGoogle))
+}
@@ -0,0 +1,122 @@
+package adminaudit
+
+import (
+	"maps"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+type Builder struct {
+	r *Record
+}
+
+func New() *Builder {
+	return &Builder{r: &Record{}}
+}
+
+// Build validates the record and, unless a hash was given, seals it with its
+// hash. Records read back from a store keep their stored hash so that
+// Verify can tell whether they were edited.
+func (b *Builder) Build() (*Record, error) {
+	if b.r.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if b.r.actor.IsNil() {
+		return nil, ErrEmptyActor
+	}
+	if b.r.action == "" {
+		return nil, ErrEmptyAction
+	}
+	if b.r.seq < 1 {
+		return nil, ErrInvalidSeq
+	}
+	if b.r.createdAt.IsZero() {
+		b.r.createdAt = time.Now()
+	}
+	b.r.createdAt = b.r.createdAt.UTC().Truncate(time.Millisecond)
+	if b.r.hash == "" {
+		b.r.hash = b.r.computeHash()
+	}
+	return b.r, nil
+}
+
+func (b *Builder) MustBuild() *Record {
+	r, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.r.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.r.id = NewID()
+	return b
+}
+
+// Chain makes the record follow prev, which is nil when the chain is empty.
+func (b *Builder) Chain(prev *Record) *Builder {
+	b.r.seq = prev.Seq() + 1
+	b.r.prevHash = prev.Hash()
+	return b
+}
+
+func (b *Builder) Seq(seq int64) *Builder {
+	b.r.seq = seq
+	return b
+}
+
+func (b *Builder) Actor(actor adminuser.ID) *Builder {
+	b.r.actor = actor
+	return b
+}
+
+func (b *Builder) Action(action string) *Builder {
+	b.r.action = action
+	return b
+}
+
+func (b *Builder) Target(target string) *Builder {
+	b.r.target = target
+	return b
+}
+
+func (b *Builder) RequestID(requestID string) *Builder {
+	b.r.requestID = requestID
+	return b
+}
+
+func (b *Builder) IP(ip string) *Builder {
+	b.r.ip = ip
+	return b
+}
+
+func (b *Builder) Status(status int) *Builder {
+	b.r.status = status
+	return b
+}
+
+func (b *Builder) Diff(diff map[string]string) *Builder {
+	b.r.diff = maps.Clone(diff)
+	return b
+}
+
+func (b *Builder) CreatedAt(createdAt time.Time) *Builder {
+	b.r.createdAt = createdAt
+	return b
+}
+
+func (b *Builder) PrevHash(prevHash string) *Builder {
+	b.r.prevHash = prevHash
+	return b
+}
+
+func (b *Builder) Hash(hash string) *Builder {
+	b.r.hash = hash
+	return b
+}
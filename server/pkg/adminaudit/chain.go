@@ -0,0 +1,61 @@
+package adminaudit
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrChainBroken is wrapped by the ChainError Verify returns.
+var ErrChainBroken = errors.New("admin audit chain is broken")
+
+// Breach names how a chain of records is broken.
+type Breach string
+
+const (
+	// BreachGap is a record whose sequence number doesn't follow the one
+	// before, i.e. records were removed in between.
+	BreachGap Breach = "gap"
+	// BreachEdited is a record whose content no longer matches its hash.
+	BreachEdited Breach = "edited"
+	// BreachLink is a record that doesn't carry the hash of the one before,
+	// i.e. the one before was replaced.
+	BreachLink Breach = "link"
+)
+
+// ChainError is where a chain of records is broken.
+type ChainError struct {
+	Seq    int64
+	Breach Breach
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("%s at record %d (%s)", ErrChainBroken, e.Seq, e.Breach)
+}
+
+func (e *ChainError) Unwrap() error {
+	return ErrChainBroken
+}
+
+// Verify checks that l continues prev, which is nil when l starts the chain:
+// the records are numbered without gaps, each one matches its hash and
+// carries the hash of the one before. It returns a *ChainError at the first
+// breach.
+//
+// A chain that was cut at its end, or rewritten from some record on with new
+// hashes, still verifies; comparing the hash of the last record with one
+// noted earlier detects that.
+func Verify(prev *Record, l List) error {
+	for _, r := range l {
+		if r.seq != prev.Seq()+1 {
+			return &ChainError{Seq: prev.Seq() + 1, Breach: BreachGap}
+		}
+		if r.hash != r.computeHash() {
+			return &ChainError{Seq: r.seq, Breach: BreachEdited}
+		}
+		if r.prevHash != prev.Hash() {
+			return &ChainError{Seq: r.seq, Breach: BreachLink}
+		}
+		prev = r
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package adminaudit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+)
+
+// chain builds n records, each following the one before.
+func chain(n int) List {
+	actor := adminuser.NewID()
+	now := time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)
+	l := make(List, 0, n)
+	var prev *Record
+	for i := range n {
+		prev = New().NewID().Chain(prev).Actor(actor).Action("POST /api/v1/users/:id/deactivate").
+			Diff(map[string]string{"i": string(rune('a' + i))}).CreatedAt(now.Add(time.Duration(i) * time.Second)).MustBuild()
+		l = append(l, prev)
+	}
+	return l
+}
+
+// rebuild copies r with a changed diff and, unless rehash is false, a
+// recomputed hash.
+func rebuild(r *Record, diff map[string]string, rehash bool) *Record {
+	b := New().ID(r.ID()).Seq(r.Seq()).Actor(r.Actor()).Action(r.Action()).Diff(diff).
+		CreatedAt(r.CreatedAt()).PrevHash(r.PrevHash())
+	if !rehash {
+		b = b.Hash(r.Hash())
+	}
+	return b.MustBuild()
+}
+
+func TestVerify(t *testing.T) {
+	l := chain(4)
+
+	tests := []struct {
+		name string
+		prev *Record
+		list List
+		want *ChainError
+	}{
+		{name: "whole chain", list: l},
+		{name: "continued", prev: l[1], list: l[2:]},
+		{name: "empty", list: List{}},
+		{name: "first removed", list: l[1:], want: &ChainError{Seq: 1, Breach: BreachGap}},
+		{name: "record removed", list: List{l[0], l[2], l[3]}, want: &ChainError{Seq: 2, Breach: BreachGap}},
+		{name: "record edited", list: List{l[0], rebuild(l[1], map[string]string{"i": "x"}, false), l[2]}, want: &ChainError{Seq: 2, Breach: BreachEdited}},
+		{name: "record replaced", list: List{l[0], rebuild(l[1], map[string]string{"i": "x"}, true), l[2]}, want: &ChainError{Seq: 3, Breach: BreachLink}},
+		{name: "wrong prev", prev: l[0], list: List{New().NewID().Seq(2).Actor(adminuser.NewID()).Action("a").MustBuild()}, want: &ChainError{Seq: 2, Breach: BreachLink}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Verify(tt.prev, tt.list)
+			if tt.want == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrChainBroken)
+			assert.Equal(t, tt.want, err)
+		})
+	}
+}
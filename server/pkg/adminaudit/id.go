@@ -0,0 +1,18 @@
+package adminaudit
+
+import (
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+type ID = id.AdminAuditRecordID
+type IDList = id.AdminAuditRecordIDList
+
+var NewID = id.NewAdminAuditRecordID
+
+var MustID = id.MustAdminAuditRecordID
+
+var IDFrom = id.AdminAuditRecordIDFrom
+
+var IDFromRef = id.AdminAuditRecordIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
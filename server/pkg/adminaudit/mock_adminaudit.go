@@ -0,0 +1,102 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/adminaudit/repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/adminaudit/repo.go -destination=./pkg/adminaudit/mock_adminaudit.go -package adminaudit
+//
+
+// Package adminaudit is a generated GoMock package.
+package adminaudit
+
+import (
+	context "context"
+	reflect "reflect"
+
+	usecasex "github.com/reearth/reearthx/usecasex"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// Append mocks base method.
+func (m *MockRepo) Append(arg0 context.Context, arg1 *Record) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Append", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Append indicates an expected call of Append.
+func (mr *MockRepoMockRecorder) Append(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Append", reflect.TypeOf((*MockRepo)(nil).Append), arg0, arg1)
+}
+
+// FindAfter mocks base method.
+func (m *MockRepo) FindAfter(ctx context.Context, seq int64, limit int) (List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAfter", ctx, seq, limit)
+	ret0, _ := ret[0].(List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAfter indicates an expected call of FindAfter.
+func (mr *MockRepoMockRecorder) FindAfter(ctx, seq, limit any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAfter", reflect.TypeOf((*MockRepo)(nil).FindAfter), ctx, seq, limit)
+}
+
+// FindLast mocks base method.
+func (m *MockRepo) FindLast(arg0 context.Context) (*Record, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLast", arg0)
+	ret0, _ := ret[0].(*Record)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLast indicates an expected call of FindLast.
+func (mr *MockRepoMockRecorder) FindLast(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLast", reflect.TypeOf((*MockRepo)(nil).FindLast), arg0)
+}
+
+// List mocks base method.
+func (m *MockRepo) List(arg0 context.Context, arg1 ListFilter) (List, *usecasex.PageInfo, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "List", arg0, arg1)
+	ret0, _ := ret[0].(List)
+	ret1, _ := ret[1].(*usecasex.PageInfo)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// List indicates an expected call of List.
+func (mr *MockRepoMockRecorder) List(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockRepo)(nil).List), arg0, arg1)
+}
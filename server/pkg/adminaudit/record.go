@@ -0,0 +1,189 @@
+// Package adminaudit holds the tamper-evident trail of the mutating requests
+// made to the admin API. The audit trail middleware appends a record for each
+// one, whether or not it succeeded, and the records are hash-chained so that
+// an edit or a gap can be detected. The main API appends one too for each
+// request an admin makes while impersonating a user.
+//
+// A record says who asked for what; what the request changed, and the values
+// it replaced, is in the package auditlog entry its use case writes. The two
+// are separate because an entry must roll back with a failed change, while a
+// record must not. A request whose record cannot be appended fails, so every
+// admin write reported as done has a record.
+package adminaudit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"maps"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+var (
+	ErrEmptyActor  = errors.New("admin audit record actor can't be empty")
+	ErrEmptyAction = errors.New("admin audit record action can't be empty")
+	ErrInvalidSeq  = errors.New("admin audit record sequence number must be positive")
+)
+
+// Record is one mutating request an admin made to the admin API. Records form
+// a hash chain: each one is numbered one above the one before and includes
+// its hash, so a record that is edited, removed or inserted afterwards breaks
+// the chain. Records are appended once and never updated.
+type Record struct {
+	id        ID
+	seq       int64
+	actor     adminuser.ID
+	action    string
+	target    string
+	requestID string
+	ip        string
+	status    int
+	diff      map[string]string
+	createdAt time.Time
+	prevHash  string
+	hash      string
+}
+
+func (r *Record) ID() ID {
+	if r == nil {
+		return ID{}
+	}
+	return r.id
+}
+
+// Seq is the position of the record in the chain, starting at 1.
+func (r *Record) Seq() int64 {
+	if r == nil {
+		return 0
+	}
+	return r.seq
+}
+
+// Actor is the admin user who made the request.
+func (r *Record) Actor() adminuser.ID {
+	if r == nil {
+		return adminuser.ID{}
+	}
+	return r.actor
+}
+
+// Action is the method and route of the request, e.g.
+// "POST /api/v1/admin-users/:id/approve", prefixed with "IMPERSONATE " for a
+// request to the main API made as a user.
+func (r *Record) Action() string {
+	if r == nil {
+		return ""
+	}
+	return r.action
+}
+
+// Target is the ID of the resource the request was made on, or empty when
+// the route has none.
+func (r *Record) Target() string {
+	if r == nil {
+		return ""
+	}
+	return r.target
+}
+
+func (r *Record) RequestID() string {
+	if r == nil {
+		return ""
+	}
+	return r.requestID
+}
+
+// IP is the address of the client that made the request.
+func (r *Record) IP() string {
+	if r == nil {
+		return ""
+	}
+	return r.ip
+}
+
+// Status is the HTTP status of the response. Requests that were refused, e.g.
+// with 403, are recorded too. It is 0 for impersonated requests, which are
+// recorded before they are served.
+func (r *Record) Status() int {
+	if r == nil {
+		return 0
+	}
+	return r.status
+}
+
+// Diff is what the request asked to change: the other path parameters and
+// the top-level fields of the JSON body, with secrets redacted. The old values
+// are in the audit log entry of the action, if it writes one.
+func (r *Record) Diff() map[string]string {
+	if r == nil {
+		return nil
+	}
+	return maps.Clone(r.diff)
+}
+
+func (r *Record) CreatedAt() time.Time {
+	if r == nil {
+		return time.Time{}
+	}
+	return r.createdAt
+}
+
+// PrevHash is the hash of the record before, or empty for the first record.
+func (r *Record) PrevHash() string {
+	if r == nil {
+		return ""
+	}
+	return r.prevHash
+}
+
+// Hash is the hash of the record as it was appended.
+func (r *Record) Hash() string {
+	if r == nil {
+		return ""
+	}
+	return r.hash
+}
+
+// hashInput is the canonical form a record is hashed in. The diff is encoded
+// with sorted keys and the time in milliseconds, the precision every backend
+// keeps, so a record hashes the same after a round trip through any of them.
+type hashInput struct {
+	Seq       int64             `json:"seq"`
+	ID        string            `json:"id"`
+	Actor     string            `json:"actor"`
+	Action    string            `json:"action"`
+	Target    string            `json:"target"`
+	RequestID string            `json:"requestId"`
+	IP        string            `json:"ip"`
+	Status    int               `json:"status"`
+	Diff      map[string]string `json:"diff"`
+	CreatedAt int64             `json:"createdAt"`
+	PrevHash  string            `json:"prevHash"`
+}
+
+// computeHash returns the hex encoded SHA-256 of the content of the record and
+// the hash of the record before.
+func (r *Record) computeHash() string {
+	diff := r.diff
+	if diff == nil {
+		diff = map[string]string{}
+	}
+	b, _ := json.Marshal(hashInput{
+		Seq:       r.seq,
+		ID:        r.id.String(),
+		Actor:     r.actor.String(),
+		Action:    r.action,
+		Target:    r.target,
+		RequestID: r.requestID,
+		IP:        r.ip,
+		Status:    r.status,
+		Diff:      diff,
+		CreatedAt: r.createdAt.UnixMilli(),
+		PrevHash:  r.prevHash,
+	})
+	sum := sha256.Sum256(b)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,46 @@
+package adminaudit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	actor := adminuser.NewID()
+	now := time.Date(2026, 10, 19, 0, 0, 0, 123456789, time.UTC)
+	r, err := New().NewID().Chain(nil).Actor(actor).Action("POST /api/v1/admin-users/:id/approve").
+		Target("target").RequestID("req").IP("192.0.2.1").Status(200).
+		Diff(map[string]string{"role": `"viewer"`}).CreatedAt(now).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), r.Seq())
+	assert.Equal(t, actor, r.Actor())
+	assert.Equal(t, "target", r.Target())
+	assert.Equal(t, "req", r.RequestID())
+	assert.Equal(t, "192.0.2.1", r.IP())
+	assert.Equal(t, 200, r.Status())
+	assert.Equal(t, map[string]string{"role": `"viewer"`}, r.Diff())
+	assert.Equal(t, now.Truncate(time.Millisecond), r.CreatedAt())
+	assert.Empty(t, r.PrevHash())
+	assert.Len(t, r.Hash(), 64)
+
+	next := New().NewID().Chain(r).Actor(actor).Action("DELETE /api/v1/scim-tenants/:id").MustBuild()
+	assert.Equal(t, int64(2), next.Seq())
+	assert.Equal(t, r.Hash(), next.PrevHash())
+
+	_, err = New().Chain(nil).Actor(actor).Action("a").Build()
+	assert.ErrorIs(t, err, ErrInvalidID)
+	_, err = New().NewID().Chain(nil).Action("a").Build()
+	assert.ErrorIs(t, err, ErrEmptyActor)
+	_, err = New().NewID().Chain(nil).Actor(actor).Build()
+	assert.ErrorIs(t, err, ErrEmptyAction)
+	_, err = New().NewID().Actor(actor).Action("a").Build()
+	assert.ErrorIs(t, err, ErrInvalidSeq)
+}
+
+func TestBuilder_Build_KeepsGivenHash(t *testing.T) {
+	r := New().NewID().Seq(1).Actor(adminuser.NewID()).Action("a").Hash("stored").MustBuild()
+	assert.Equal(t, "stored", r.Hash())
+}
@@ -0,0 +1,46 @@
+package adminaudit
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/usecasex"
+)
+
+var (
+	// ErrSeqTaken is returned by Append when another record already has the
+	// sequence number, i.e. a concurrent append won the race.
+	ErrSeqTaken = errors.New("admin audit sequence number is taken")
+	// ErrCursorPaginationUnsupported is returned by List when cursor-based
+	// pagination is requested.
+	ErrCursorPaginationUnsupported = rerror.NewE(i18n.T("cursor pagination is not supported for admin audit records"))
+)
+
+// ListFilter narrows and paginates a List query. Since is inclusive and Until
+// exclusive.
+type ListFilter struct {
+	Actor      *adminuser.ID
+	Action     string
+	Target     string
+	Since      *time.Time
+	Until      *time.Time
+	Pagination *usecasex.Pagination
+}
+
+//go:generate mockgen -source=./repo.go -destination=./mock_adminaudit.go -package adminaudit
+type Repo interface {
+	// FindLast returns the record with the highest sequence number, or
+	// rerror.ErrNotFound when the chain is empty.
+	FindLast(context.Context) (*Record, error)
+	// FindAfter returns up to limit records numbered above seq, in order.
+	FindAfter(ctx context.Context, seq int64, limit int) (List, error)
+	// List returns the records matching the filter, newest first.
+	List(context.Context, ListFilter) (List, *usecasex.PageInfo, error)
+	// Append adds the record to the end of the chain, or fails with
+	// ErrSeqTaken. There is no way to update or remove records.
+	Append(context.Context, *Record) error
+}
@@ -0,0 +1,74 @@
+package adminsession
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+type Builder struct {
+	s *Session
+}
+
+func New() *Builder {
+	return &Builder{s: &Session{}}
+}
+
+func (b *Builder) Build() (*Session, error) {
+	if b.s.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if b.s.adminUser.IsNil() {
+		return nil, ErrEmptyAdminUser
+	}
+	if b.s.createdAt.IsZero() {
+		b.s.createdAt = time.Now()
+	}
+	if !b.s.expiresAt.After(b.s.createdAt) {
+		return nil, ErrInvalidExpiry
+	}
+	return b.s, nil
+}
+
+func (b *Builder) MustBuild() *Session {
+	s, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return s
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.s.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.s.id = NewID()
+	return b
+}
+
+func (b *Builder) AdminUser(u adminuser.ID) *Builder {
+	b.s.adminUser = u
+	return b
+}
+
+func (b *Builder) UserAgent(ua string) *Builder {
+	b.s.userAgent = ua
+	return b
+}
+
+func (b *Builder) IP(ip string) *Builder {
+	b.s.ip = ip
+	return b
+}
+
+func (b *Builder) CreatedAt(t time.Time) *Builder {
+	b.s.createdAt = t
+	return b
+}
+
+func (b *Builder) ExpiresAt(t time.Time) *Builder {
+	b.s.expiresAt = t
+	return b
+}
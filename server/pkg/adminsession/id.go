@@ -0,0 +1,18 @@
+package adminsession
+
+import (
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+type ID = id.AdminSessionID
+type IDList = id.AdminSessionIDList
+
+var NewID = id.NewAdminSessionID
+
+var MustID = id.MustAdminSessionID
+
+var IDFrom = id.AdminSessionIDFrom
+
+var IDFromRef = id.AdminSessionIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
@@ -0,0 +1,32 @@
+package adminsession
+
+import "time"
+
+type List []*Session
+
+// Active returns the sessions that have not expired at now.
+func (l List) Active(now time.Time) List {
+	if l == nil {
+		return nil
+	}
+	res := make(List, 0, len(l))
+	for _, s := range l {
+		if s != nil && !s.IsExpired(now) {
+			res = append(res, s)
+		}
+	}
+	return res
+}
+
+func (l List) IDs() IDList {
+	if l == nil {
+		return nil
+	}
+	ids := make(IDList, 0, len(l))
+	for _, s := range l {
+		if s != nil {
+			ids = append(ids, s.ID())
+		}
+	}
+	return ids
+}
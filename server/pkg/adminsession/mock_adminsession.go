@@ -0,0 +1,129 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/adminsession/repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/adminsession/repo.go -destination=./pkg/adminsession/mock_adminsession.go -package adminsession
+//
+
+// Package adminsession is a generated GoMock package.
+package adminsession
+
+import (
+	context "context"
+	reflect "reflect"
+	time "time"
+
+	adminuser "github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// FindByAdminUser mocks base method.
+func (m *MockRepo) FindByAdminUser(arg0 context.Context, arg1 adminuser.ID) (List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByAdminUser", arg0, arg1)
+	ret0, _ := ret[0].(List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByAdminUser indicates an expected call of FindByAdminUser.
+func (mr *MockRepoMockRecorder) FindByAdminUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByAdminUser", reflect.TypeOf((*MockRepo)(nil).FindByAdminUser), arg0, arg1)
+}
+
+// FindByID mocks base method.
+func (m *MockRepo) FindByID(arg0 context.Context, arg1 ID) (*Session, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*Session)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockRepoMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepo)(nil).FindByID), arg0, arg1)
+}
+
+// Remove mocks base method.
+func (m *MockRepo) Remove(arg0 context.Context, arg1 ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Remove", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Remove indicates an expected call of Remove.
+func (mr *MockRepoMockRecorder) Remove(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Remove", reflect.TypeOf((*MockRepo)(nil).Remove), arg0, arg1)
+}
+
+// RemoveByAdminUser mocks base method.
+func (m *MockRepo) RemoveByAdminUser(arg0 context.Context, arg1 adminuser.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveByAdminUser", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveByAdminUser indicates an expected call of RemoveByAdminUser.
+func (mr *MockRepoMockRecorder) RemoveByAdminUser(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveByAdminUser", reflect.TypeOf((*MockRepo)(nil).RemoveByAdminUser), arg0, arg1)
+}
+
+// RemoveExpired mocks base method.
+func (m *MockRepo) RemoveExpired(ctx context.Context, t time.Time) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveExpired", ctx, t)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveExpired indicates an expected call of RemoveExpired.
+func (mr *MockRepoMockRecorder) RemoveExpired(ctx, t any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveExpired", reflect.TypeOf((*MockRepo)(nil).RemoveExpired), ctx, t)
+}
+
+// Save mocks base method.
+func (m *MockRepo) Save(arg0 context.Context, arg1 *Session) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepoMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepo)(nil).Save), arg0, arg1)
+}
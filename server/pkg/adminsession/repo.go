@@ -0,0 +1,25 @@
+package adminsession
+
+import (
+	"context"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+//go:generate mockgen -source=./repo.go -destination=./mock_adminsession.go -package adminsession
+type Repo interface {
+	// FindByID returns the session, or rerror.ErrNotFound once it has been
+	// removed. Expired sessions may still be returned until RemoveExpired
+	// runs.
+	FindByID(context.Context, ID) (*Session, error)
+	// FindByAdminUser returns the sessions of the admin user, newest first.
+	FindByAdminUser(context.Context, adminuser.ID) (List, error)
+	Save(context.Context, *Session) error
+	// Remove removes the session. Removing a missing session is not an error.
+	Remove(context.Context, ID) error
+	// RemoveByAdminUser removes every session of the admin user.
+	RemoveByAdminUser(context.Context, adminuser.ID) error
+	// RemoveExpired removes the sessions that expired before t.
+	RemoveExpired(ctx context.Context, t time.Time) error
+}
@@ -0,0 +1,74 @@
+package adminsession
+
+import (
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+var (
+	ErrEmptyAdminUser = errors.New("admin session admin user can't be empty")
+	ErrInvalidExpiry  = errors.New("admin session must expire after it is created")
+)
+
+// Session is a sign-in of an admin user to the admin console. The session
+// token carries its ID, and the token is only accepted while the session is
+// stored and unexpired, so removing the session signs the admin out.
+type Session struct {
+	id        ID
+	adminUser adminuser.ID
+	userAgent string
+	ip        string
+	createdAt time.Time
+	expiresAt time.Time
+}
+
+func (s *Session) ID() ID {
+	if s == nil {
+		return ID{}
+	}
+	return s.id
+}
+
+func (s *Session) AdminUser() adminuser.ID {
+	if s == nil {
+		return adminuser.ID{}
+	}
+	return s.adminUser
+}
+
+// UserAgent and IP are those of the sign-in request, shown to tell sessions
+// apart.
+func (s *Session) UserAgent() string {
+	if s == nil {
+		return ""
+	}
+	return s.userAgent
+}
+
+func (s *Session) IP() string {
+	if s == nil {
+		return ""
+	}
+	return s.ip
+}
+
+func (s *Session) CreatedAt() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.createdAt
+}
+
+func (s *Session) ExpiresAt() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.expiresAt
+}
+
+// IsExpired reports whether the session has ended at now.
+func (s *Session) IsExpired(now time.Time) bool {
+	return s == nil || !now.Before(s.expiresAt)
+}
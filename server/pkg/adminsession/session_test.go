@@ -0,0 +1,41 @@
+package adminsession
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder(t *testing.T) {
+	now := time.Now()
+	u := adminuser.NewID()
+
+	s, err := New().NewID().AdminUser(u).UserAgent("ua").IP("127.0.0.1").
+		CreatedAt(now).ExpiresAt(now.Add(time.Hour)).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, u, s.AdminUser())
+	assert.Equal(t, "ua", s.UserAgent())
+	assert.Equal(t, "127.0.0.1", s.IP())
+	assert.Equal(t, now, s.CreatedAt())
+	assert.Equal(t, now.Add(time.Hour), s.ExpiresAt())
+
+	_, err = New().AdminUser(u).ExpiresAt(now.Add(time.Hour)).Build()
+	assert.ErrorIs(t, err, ErrInvalidID)
+	_, err = New().NewID().ExpiresAt(now.Add(time.Hour)).Build()
+	assert.ErrorIs(t, err, ErrEmptyAdminUser)
+	_, err = New().NewID().AdminUser(u).CreatedAt(now).ExpiresAt(now).Build()
+	assert.ErrorIs(t, err, ErrInvalidExpiry)
+}
+
+func TestSession_IsExpired(t *testing.T) {
+	now := time.Now()
+	s := New().NewID().AdminUser(adminuser.NewID()).CreatedAt(now).ExpiresAt(now.Add(time.Hour)).MustBuild()
+
+	assert.False(t, s.IsExpired(now))
+	assert.True(t, s.IsExpired(now.Add(time.Hour)))
+	assert.True(t, (*Session)(nil).IsExpired(now))
+	assert.Equal(t, List{s}, List{s, nil}.Active(now))
+	assert.Empty(t, List{s}.Active(now.Add(2*time.Hour)))
+}
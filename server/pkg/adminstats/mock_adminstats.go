@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/adminstats/repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/adminstats/repo.go -destination=./pkg/adminstats/mock_adminstats.go -package adminstats
+//
+
+// Package adminstats is a generated GoMock package.
+package adminstats
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// Compute mocks base method.
+func (m *MockRepo) Compute(arg0 context.Context, arg1 Window) (*Stats, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Compute", arg0, arg1)
+	ret0, _ := ret[0].(*Stats)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Compute indicates an expected call of Compute.
+func (mr *MockRepoMockRecorder) Compute(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Compute", reflect.TypeOf((*MockRepo)(nil).Compute), arg0, arg1)
+}
@@ -0,0 +1,12 @@
+package adminstats
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=./repo.go -destination=./mock_adminstats.go -package adminstats
+type Repo interface {
+	// Compute returns the current counts and the daily figures of the
+	// window.
+	Compute(context.Context, Window) (*Stats, error)
+}
@@ -0,0 +1,99 @@
+// Package adminstats holds the overview figures of the admin dashboard.
+//
+// Users merged into another user are left out of every figure: their auths
+// and workspaces moved to the user they were merged into.
+package adminstats
+
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+)
+
+// MaxDays is the longest window of daily figures.
+const MaxDays = 365
+
+// Users counts the users by state.
+type Users struct {
+	Active      int64
+	Deactivated int64
+	// Unverified counts the active users whose email is not verified.
+	Unverified int64
+}
+
+// Workspaces counts the workspaces by kind and state.
+type Workspaces struct {
+	// Personal and Team count the workspaces that are not deactivated.
+	Personal    int64
+	Team        int64
+	Deactivated int64
+}
+
+// Day holds the figures of one UTC day.
+type Day struct {
+	Date time.Time
+	// Signups counts the users created that day. Users created before their
+	// creation time was recorded are not counted on any day.
+	Signups int64
+	// Deactivations counts the users deactivated that day and not restored
+	// since.
+	Deactivations int64
+}
+
+// Stats is the overview of the users and workspaces.
+type Stats struct {
+	Users      Users
+	Workspaces Workspaces
+	// Days holds the figures by day of the window. Repos may leave out the
+	// days without any signup or deactivation and return the others in any
+	// order; Window.Fill completes and orders them.
+	Days []Day
+	// MembersByRole counts the user members of the team workspaces that are
+	// not deactivated, by role.
+	MembersByRole map[role.RoleType]int64
+	// UsersByAuthProvider counts the active users that can sign in with each
+	// provider. A user with auths of several providers counts for each.
+	UsersByAuthProvider map[string]int64
+}
+
+// Window is the span of the daily figures: Since is the start of its first
+// UTC day and Until the end of its last one.
+type Window struct {
+	Since time.Time
+	Until time.Time
+}
+
+// NewWindow returns the window of the given number of days ending with the
+// day of now, clamped to between 1 and MaxDays.
+func NewWindow(days int, now time.Time) Window {
+	days = max(1, min(days, MaxDays))
+	until := DayOf(now).AddDate(0, 0, 1)
+	return Window{Since: until.AddDate(0, 0, -days), Until: until}
+}
+
+// Contains reports whether t falls within the window.
+func (w Window) Contains(t time.Time) bool {
+	return !t.Before(w.Since) && t.Before(w.Until)
+}
+
+// Fill returns one Day for each day of the window, oldest first, with the
+// figures of the given days and zero for the others.
+func (w Window) Fill(days []Day) []Day {
+	byDate := make(map[time.Time]Day, len(days))
+	for _, d := range days {
+		byDate[DayOf(d.Date)] = d
+	}
+	res := []Day{}
+	for t := w.Since; t.Before(w.Until); t = t.AddDate(0, 0, 1) {
+		d := byDate[t]
+		d.Date = t
+		res = append(res, d)
+	}
+	return res
+}
+
+// DayOf returns the start of the UTC day of t.
+func DayOf(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,30 @@
+package adminstats
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWindow(t *testing.T) {
+	now := time.Date(2026, 10, 19, 15, 4, 5, 0, time.FixedZone("JST", 9*60*60))
+	w := NewWindow(3, now)
+	assert.Equal(t, time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), w.Since)
+	assert.Equal(t, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC), w.Until)
+	assert.True(t, w.Contains(time.Date(2026, 10, 19, 23, 59, 0, 0, time.UTC)))
+	assert.False(t, w.Contains(w.Until))
+
+	assert.Equal(t, w.Until.AddDate(0, 0, -1), NewWindow(0, now).Since)
+	assert.Equal(t, w.Until.AddDate(0, 0, -MaxDays), NewWindow(10000, now).Since)
+}
+
+func TestWindow_Fill(t *testing.T) {
+	w := Window{Since: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC), Until: time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)}
+	got := w.Fill([]Day{{Date: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Signups: 2, Deactivations: 1}})
+	assert.Equal(t, []Day{
+		{Date: time.Date(2026, 10, 17, 0, 0, 0, 0, time.UTC)},
+		{Date: time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC), Signups: 2, Deactivations: 1},
+		{Date: time.Date(2026, 10, 19, 0, 0, 0, 0, time.UTC)},
+	}, got)
+}
@@ -5,6 +5,8 @@ import (
 	"net/mail"
 	"strings"
 	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
 )
 
 var (
@@ -18,13 +20,16 @@ var (
 type AdminUser struct {
 	approvedAt time.Time // zero value if not yet approved
 	approvedBy ID        // zero value if not yet approved
-	email      string    // unique, lowercase
-	id         ID
-	name       string
-	pictureURL string
-	role       Role
-	status     Status
-	updatedAt  time.Time
+	// approvedByRule is the automatic approval rule that approved the user;
+	// zero value when an admin or bootstrap approved it.
+	approvedByRule id.AdminApprovalRuleID
+	email          string // unique, lowercase
+	id             ID
+	name           string
+	pictureURL     string
+	role           Role
+	status         Status
+	updatedAt      time.Time
 }
 
 func (u *AdminUser) ID() ID {
@@ -48,6 +53,15 @@ func (u *AdminUser) ApprovedBy() ID {
 	return u.approvedBy
 }
 
+// ApprovedByRule is the automatic approval rule that approved the user, or
+// empty when it was approved by an admin or bootstrapped.
+func (u *AdminUser) ApprovedByRule() id.AdminApprovalRuleID {
+	if u == nil {
+		return id.AdminApprovalRuleID{}
+	}
+	return u.approvedByRule
+}
+
 // CreatedAt is derived from the ULID-based ID, which embeds its creation time.
 func (u *AdminUser) CreatedAt() time.Time {
 	if u == nil {
@@ -131,10 +145,21 @@ func (u *AdminUser) Approve(by ID) {
 	now := time.Now()
 	u.status = StatusApproved
 	u.approvedBy = by
+	u.approvedByRule = id.AdminApprovalRuleID{}
 	u.approvedAt = now
 	u.updatedAt = now
 }
 
+// ApproveByRule approves the user on behalf of an automatic approval rule
+// and records the rule. Like Approve it keeps an existing approval.
+func (u *AdminUser) ApproveByRule(rule id.AdminApprovalRuleID) {
+	if u == nil || u.status == StatusApproved {
+		return
+	}
+	u.Approve(ID{})
+	u.approvedByRule = rule
+}
+
 // Reject marks the user as rejected. It is used both to reject a pending user
 // and to revoke an already-approved one. Approval history (approvedBy /
 // approvedAt) is intentionally retained.
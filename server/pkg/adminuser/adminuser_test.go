@@ -3,6 +3,7 @@ package adminuser
 import (
 	"testing"
 
+	"github.com/reearth/reearth-accounts/server/pkg/id"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -93,6 +94,24 @@ func TestAdminUser_Approve_AfterReject(t *testing.T) {
 	assert.Equal(t, second, u.ApprovedBy())
 }
 
+func TestAdminUser_ApproveByRule(t *testing.T) {
+	u := newTestAdminUser()
+	rule := id.NewAdminApprovalRuleID()
+
+	u.ApproveByRule(rule)
+	assert.True(t, u.IsApproved())
+	assert.Equal(t, rule, u.ApprovedByRule())
+	assert.True(t, u.ApprovedBy().IsEmpty())
+	assert.False(t, u.ApprovedAt().IsZero())
+
+	// a later manual re-approval replaces the rule as the approver
+	u.Reject()
+	approver := NewID()
+	u.Approve(approver)
+	assert.Equal(t, approver, u.ApprovedBy())
+	assert.True(t, u.ApprovedByRule().IsEmpty())
+}
+
 func TestAdminUser_Reject(t *testing.T) {
 	u := newTestAdminUser()
 	approver := NewID()
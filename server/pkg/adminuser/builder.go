@@ -1,6 +1,10 @@
 package adminuser
 
-import "time"
+import (
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
 
 type Builder struct {
 	u *AdminUser
@@ -55,6 +59,7 @@ func (b *Builder) Build() (*AdminUser, error) {
 		// metadata.
 		b.u.approvedAt = time.Time{}
 		b.u.approvedBy = ID{}
+		b.u.approvedByRule = id.AdminApprovalRuleID{}
 	}
 
 	return b.u, nil
@@ -88,6 +93,11 @@ func (b *Builder) ApprovedBy(approvedBy ID) *Builder {
 	return b
 }
 
+func (b *Builder) ApprovedByRule(rule id.AdminApprovalRuleID) *Builder {
+	b.u.approvedByRule = rule
+	return b
+}
+
 func (b *Builder) Email(email string) *Builder {
 	b.u.email = email
 	return b
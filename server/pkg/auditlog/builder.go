@@ -0,0 +1,75 @@
+package auditlog
+
+import (
+	"maps"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+)
+
+type Builder struct {
+	e *Entry
+}
+
+func New() *Builder {
+	return &Builder{e: &Entry{}}
+}
+
+func (b *Builder) Build() (*Entry, error) {
+	if b.e.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if b.e.actor.IsNil() {
+		return nil, ErrEmptyActor
+	}
+	if b.e.action == "" {
+		return nil, ErrEmptyAction
+	}
+	if b.e.createdAt.IsZero() {
+		b.e.createdAt = time.Now()
+	}
+	return b.e, nil
+}
+
+func (b *Builder) MustBuild() *Entry {
+	e, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return e
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.e.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.e.id = NewID()
+	return b
+}
+
+func (b *Builder) Actor(actor adminuser.ID) *Builder {
+	b.e.actor = actor
+	return b
+}
+
+func (b *Builder) Action(action Action) *Builder {
+	b.e.action = action
+	return b
+}
+
+func (b *Builder) Target(target string) *Builder {
+	b.e.target = target
+	return b
+}
+
+func (b *Builder) Detail(detail map[string]string) *Builder {
+	b.e.detail = maps.Clone(detail)
+	return b
+}
+
+func (b *Builder) CreatedAt(createdAt time.Time) *Builder {
+	b.e.createdAt = createdAt
+	return b
+}
@@ -0,0 +1,38 @@
+package auditlog
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	actor := adminuser.NewID()
+	now := time.Date(2026, 10, 18, 0, 0, 0, 0, time.UTC)
+
+	e, err := New().NewID().Actor(actor).Action(ActionUserMerge).Target("target").
+		Detail(map[string]string{"source": "source"}).CreatedAt(now).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, actor, e.Actor())
+	assert.Equal(t, ActionUserMerge, e.Action())
+	assert.Equal(t, "target", e.Target())
+	assert.Equal(t, map[string]string{"source": "source"}, e.Detail())
+	assert.Equal(t, now, e.CreatedAt())
+
+	_, err = New().Actor(actor).Action(ActionUserMerge).Build()
+	assert.ErrorIs(t, err, ErrInvalidID)
+	_, err = New().NewID().Action(ActionUserMerge).Build()
+	assert.ErrorIs(t, err, ErrEmptyActor)
+	_, err = New().NewID().Actor(actor).Build()
+	assert.ErrorIs(t, err, ErrEmptyAction)
+}
+
+func TestEntry_Detail_IsCopied(t *testing.T) {
+	d := map[string]string{"k": "v"}
+	e := New().NewID().Actor(adminuser.NewID()).Action(ActionUserMerge).Detail(d).MustBuild()
+	d["k"] = "changed"
+	e.Detail()["k"] = "changed"
+	assert.Equal(t, "v", e.Detail()["k"])
+}
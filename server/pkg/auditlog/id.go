@@ -0,0 +1,18 @@
+package auditlog
+
+import (
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+type ID = id.AuditLogID
+type IDList = id.AuditLogIDList
+
+var NewID = id.NewAuditLogID
+
+var MustID = id.MustAuditLogID
+
+var IDFrom = id.AuditLogIDFrom
+
+var IDFromRef = id.AuditLogIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
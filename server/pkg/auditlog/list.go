@@ -0,0 +1,16 @@
+package auditlog
+
+type List []*Entry
+
+func (l List) IDs() IDList {
+	if l == nil {
+		return nil
+	}
+	ids := make(IDList, 0, len(l))
+	for _, e := range l {
+		if e != nil {
+			ids = append(ids, e.ID())
+		}
+	}
+	return ids
+}
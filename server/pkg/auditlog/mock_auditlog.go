@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./pkg/auditlog/repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./pkg/auditlog/repo.go -destination=./pkg/auditlog/mock_auditlog.go -package auditlog
+//
+
+// Package auditlog is a generated GoMock package.
+package auditlog
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// FindByTarget mocks base method.
+func (m *MockRepo) FindByTarget(arg0 context.Context, arg1 string) (List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByTarget", arg0, arg1)
+	ret0, _ := ret[0].(List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByTarget indicates an expected call of FindByTarget.
+func (mr *MockRepoMockRecorder) FindByTarget(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByTarget", reflect.TypeOf((*MockRepo)(nil).FindByTarget), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockRepo) Save(arg0 context.Context, arg1 *Entry) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepoMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepo)(nil).Save), arg0, arg1)
+}
@@ -0,0 +1,12 @@
+package auditlog
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=./repo.go -destination=./mock_auditlog.go -package auditlog
+type Repo interface {
+	// FindByTarget returns the entries about the given resource, oldest first.
+	FindByTarget(context.Context, string) (List, error)
+	Save(context.Context, *Entry) error
+}
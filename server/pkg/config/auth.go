@@ -0,0 +1,176 @@
+package config
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"slices"
+	"time"
+
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var (
+	ErrRotationInProgress = rerror.NewE(i18n.T("a signing key rotation is already in progress"))
+	ErrInvalidOverlap     = rerror.NewE(i18n.T("overlap must not be negative"))
+)
+
+// Auth is one version of the token signing key pair, encoded as PEM.
+//
+// A version is published from the moment it is saved, signs tokens from
+// ActivatedAt until a newer version activates, and is withdrawn at RetiredAt.
+// Publishing a version before it signs and after it stops signing lets
+// verifiers that cache the public keys pick up a rotation without rejecting
+// any token.
+type Auth struct {
+	Cert string
+	Key  string
+	// KID identifies the version in the JWS kid header. It is empty for the
+	// pair saved before keys were versioned.
+	KID         string
+	ActivatedAt time.Time
+	RetiredAt   *time.Time
+}
+
+// GenerateAuth creates a 2048-bit RSA key and a self-signed certificate,
+// identified by the RFC 7638 thumbprint of its public key.
+func GenerateAuth(now time.Time) (*Auth, error) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate signing key: %w", err)
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(now.UnixNano()),
+		Subject:               pkix.Name{CommonName: "reearth-accounts"},
+		NotBefore:             now,
+		NotAfter:              now.AddDate(100, 0, 0),
+		KeyUsage:              x509.KeyUsageDigitalSignature,
+		BasicConstraintsValid: true,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, key.Public(), key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create signing certificate: %w", err)
+	}
+	return &Auth{
+		Key:         string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})),
+		Cert:        string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})),
+		KID:         Thumbprint(&key.PublicKey),
+		ActivatedAt: now,
+	}, nil
+}
+
+// Thumbprint returns the base64url-encoded RFC 7638 SHA-256 thumbprint of an
+// RSA public key.
+func Thumbprint(k *rsa.PublicKey) string {
+	e := base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes())
+	n := base64.RawURLEncoding.EncodeToString(k.N.Bytes())
+	sum := sha256.Sum256([]byte(`{"e":"` + e + `","kty":"RSA","n":"` + n + `"}`))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func (a Auth) IsPending(now time.Time) bool {
+	return a.ActivatedAt.After(now)
+}
+
+func (a Auth) IsRetired(now time.Time) bool {
+	return a.RetiredAt != nil && !a.RetiredAt.After(now)
+}
+
+// AuthKeys returns every stored key version. A pair saved by SaveAuth before
+// keys were versioned counts as a version active since the beginning, and is
+// identified by its thumbprint when it has no KID.
+func (c *Config) AuthKeys() []Auth {
+	if c == nil {
+		return nil
+	}
+	if len(c.Keys) == 0 && c.Auth != nil && c.Auth.Key != "" {
+		a := *c.Auth
+		if a.KID == "" {
+			a.KID = thumbprintOf(a.Key)
+		}
+		return []Auth{a}
+	}
+	return c.Keys
+}
+
+func thumbprintOf(key string) string {
+	b, _ := pem.Decode([]byte(key))
+	if b == nil {
+		return ""
+	}
+	if k, err := x509.ParsePKCS1PrivateKey(b.Bytes); err == nil {
+		return Thumbprint(&k.PublicKey)
+	}
+	if k, err := x509.ParsePKCS8PrivateKey(b.Bytes); err == nil {
+		if rk, ok := k.(*rsa.PrivateKey); ok {
+			return Thumbprint(&rk.PublicKey)
+		}
+	}
+	return ""
+}
+
+// PublishedAuthKeys returns the versions whose public keys must be available
+// to verifiers at now: every version that has not been retired yet.
+func (c *Config) PublishedAuthKeys(now time.Time) []Auth {
+	var res []Auth
+	for _, a := range c.AuthKeys() {
+		if !a.IsRetired(now) {
+			res = append(res, a)
+		}
+	}
+	return res
+}
+
+// SigningAuthKey returns the published version that signs tokens at now: the
+// one activated most recently.
+func (c *Config) SigningAuthKey(now time.Time) *Auth {
+	var res *Auth
+	for _, a := range c.PublishedAuthKeys(now) {
+		if a.IsPending(now) {
+			continue
+		}
+		if res == nil || a.ActivatedAt.After(res.ActivatedAt) {
+			res = &a
+		}
+	}
+	return res
+}
+
+// RotateAuthKey adds next as a new key version. It is published immediately
+// and starts signing after overlap, which gives verifiers time to refresh their
+// key cache. The versions in use are retired another overlap later, so tokens
+// they signed keep verifying until they expire. An overlap of zero swaps and
+// retires the keys at once, e.g. when a key has leaked. Versions that are
+// already retired are dropped.
+func (c *Config) RotateAuthKey(next Auth, now time.Time, overlap time.Duration) error {
+	if overlap < 0 {
+		return ErrInvalidOverlap
+	}
+
+	published := c.PublishedAuthKeys(now)
+	if slices.ContainsFunc(published, func(a Auth) bool { return a.IsPending(now) }) {
+		return ErrRotationInProgress
+	}
+
+	next.ActivatedAt = now.Add(overlap)
+	next.RetiredAt = nil
+	retireAt := next.ActivatedAt.Add(overlap)
+
+	keys := make([]Auth, 0, len(published)+1)
+	for _, a := range published {
+		if a.RetiredAt == nil || a.RetiredAt.After(retireAt) {
+			a.RetiredAt = &retireAt
+		}
+		keys = append(keys, a)
+	}
+	c.Keys = append(keys, next)
+	c.Auth = nil
+	return nil
+}
@@ -0,0 +1,78 @@
+package config
+
+import (
+	"crypto/x509"
+	"encoding/pem"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateAuth(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	a, err := GenerateAuth(now)
+	require.NoError(t, err)
+	assert.Equal(t, now, a.ActivatedAt)
+	assert.Nil(t, a.RetiredAt)
+
+	b, _ := pem.Decode([]byte(a.Key))
+	require.NotNil(t, b)
+	k, err := x509.ParsePKCS1PrivateKey(b.Bytes)
+	require.NoError(t, err)
+	assert.Equal(t, Thumbprint(&k.PublicKey), a.KID)
+	assert.Len(t, a.KID, 43)
+}
+
+func TestConfig_AuthKeys(t *testing.T) {
+	now := time.Now()
+
+	assert.Empty(t, (*Config)(nil).AuthKeys())
+	assert.Empty(t, (&Config{}).AuthKeys())
+	assert.Nil(t, (&Config{}).SigningAuthKey(now))
+
+	legacy := &Config{Auth: &Auth{Cert: "c", Key: "k"}}
+	assert.Equal(t, []Auth{{Cert: "c", Key: "k"}}, legacy.AuthKeys())
+	require.NotNil(t, legacy.SigningAuthKey(now))
+	assert.Equal(t, "k", legacy.SigningAuthKey(now).Key)
+
+	versioned := &Config{Auth: &Auth{Key: "legacy"}, Keys: []Auth{{Key: "k1", KID: "1"}}}
+	assert.Equal(t, []Auth{{Key: "k1", KID: "1"}}, versioned.AuthKeys())
+}
+
+func TestConfig_RotateAuthKey(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	overlap := 24 * time.Hour
+	c := &Config{Auth: &Auth{Key: "k0"}}
+
+	require.NoError(t, c.RotateAuthKey(Auth{Key: "k1", KID: "1"}, t0, overlap))
+	assert.Nil(t, c.Auth)
+	require.Len(t, c.Keys, 2)
+	assert.Equal(t, t0.Add(overlap), c.Keys[1].ActivatedAt)
+	require.NotNil(t, c.Keys[0].RetiredAt)
+	assert.Equal(t, t0.Add(2*overlap), *c.Keys[0].RetiredAt)
+
+	// the new key is published before it signs
+	assert.Len(t, c.PublishedAuthKeys(t0), 2)
+	assert.Equal(t, "k0", c.SigningAuthKey(t0).Key)
+	assert.ErrorIs(t, c.RotateAuthKey(Auth{Key: "k2"}, t0.Add(time.Hour), overlap), ErrRotationInProgress)
+
+	// the old key is still published after the switch
+	t1 := t0.Add(overlap)
+	assert.Equal(t, "k1", c.SigningAuthKey(t1).Key)
+	assert.Len(t, c.PublishedAuthKeys(t1), 2)
+
+	// and withdrawn once retired
+	t2 := t0.Add(2 * overlap)
+	assert.Equal(t, []Auth{c.Keys[1]}, c.PublishedAuthKeys(t2))
+
+	// retired versions are dropped by the next rotation
+	require.NoError(t, c.RotateAuthKey(Auth{Key: "k2", KID: "2"}, t2, 0))
+	require.Len(t, c.Keys, 2)
+	assert.Equal(t, "k1", c.Keys[0].Key)
+	assert.Equal(t, "k2", c.SigningAuthKey(t2).Key)
+	assert.Len(t, c.PublishedAuthKeys(t2), 1, "a zero overlap retires the previous key at once")
+
+	assert.ErrorIs(t, c.RotateAuthKey(Auth{Key: "k3"}, t2, -time.Second), ErrInvalidOverlap)
+}
@@ -2,19 +2,21 @@ package config
 
 import (
 	"sort"
+	"time"
 
 	"github.com/reearth/reearth-accounts/server/pkg/policy"
 )
 
 type Config struct {
-	Migration     int64
-	Auth          *Auth
+	Migration int64
+	// Auth is the single signing key pair saved before keys were versioned.
+	Auth *Auth
+	// Keys are the versions of the signing key. See AuthKeys.
+	Keys          []Auth
 	DefaultPolicy *policy.ID
-}
-
-type Auth struct {
-	Cert string
-	Key  string
+	// PurgeSince is when the purge of deactivated users and workspaces first
+	// ran. The retention of the records deactivated before it counts from it.
+	PurgeSince *time.Time
 }
 
 func (c *Config) NextMigrations(migrations []int64) []int64 {
@@ -6,6 +6,9 @@ import (
 
 type Repo interface {
 	LockAndLoad(context.Context) (*Config, error)
+	// Load reads the config without taking the lock, for callers that only
+	// read it.
+	Load(context.Context) (*Config, error)
 	Save(context.Context, *Config) error
 	SaveAuth(context.Context, *Auth) error
 	SaveAndUnlock(context.Context, *Config) error
@@ -0,0 +1,353 @@
+// Package dataexport assembles the personal data held about a user into an
+// archive, to answer data subject access requests.
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/asset/domain/file"
+	"github.com/reearth/reearthx/rerror"
+	"golang.org/x/text/language"
+)
+
+// Repos are the repositories the personal data of a user is read from.
+type Repos struct {
+	User        user.Repo
+	Workspace   workspace.Repo
+	Role        role.Repo
+	Permittable permittable.Repo
+	AuditLog    auditlog.Repo
+}
+
+// Archive is the personal data of a user. Secrets such as the password hash,
+// the MFA secret, recovery codes and pending tokens are left out.
+type Archive struct {
+	User       User         `json:"user"`
+	Workspaces []Workspace  `json:"workspaces"`
+	Roles      Roles        `json:"roles"`
+	Sessions   Sessions     `json:"sessions"`
+	AuditLog   []AuditEntry `json:"auditLog"`
+	CreatedAt  time.Time    `json:"createdAt"`
+}
+
+type User struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Alias      string     `json:"alias"`
+	Email      string     `json:"email"`
+	Verified   bool       `json:"verified"`
+	Metadata   Metadata   `json:"metadata"`
+	Auths      []Auth     `json:"auths"`
+	MFAEnabled bool       `json:"mfaEnabled"`
+	Passkeys   []Passkey  `json:"passkeys"`
+	CreatedAt  *time.Time `json:"createdAt,omitempty"`
+	UpdatedAt  time.Time  `json:"updatedAt"`
+	DeletedAt  *time.Time `json:"deletedAt,omitempty"`
+	MergedInto string     `json:"mergedInto,omitempty"`
+}
+
+type Metadata struct {
+	PhotoURL    string `json:"photoUrl,omitempty"`
+	Description string `json:"description,omitempty"`
+	Website     string `json:"website,omitempty"`
+	Lang        string `json:"lang,omitempty"`
+	Theme       string `json:"theme,omitempty"`
+}
+
+// Auth is an identity the user signs in with. Issuer, Email and LinkedAt are
+// only set for identities linked after signup.
+type Auth struct {
+	Provider string     `json:"provider"`
+	Sub      string     `json:"sub"`
+	Issuer   string     `json:"issuer,omitempty"`
+	Email    string     `json:"email,omitempty"`
+	LinkedAt *time.Time `json:"linkedAt,omitempty"`
+}
+
+type Passkey struct {
+	ID         string     `json:"id"`
+	Nickname   string     `json:"nickname"`
+	Transports []string   `json:"transports,omitempty"`
+	CreatedAt  time.Time  `json:"createdAt"`
+	LastUsedAt *time.Time `json:"lastUsedAt,omitempty"`
+}
+
+// Workspace is a membership of the user.
+type Workspace struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Alias     string     `json:"alias"`
+	Personal  bool       `json:"personal"`
+	Role      string     `json:"role"`
+	Disabled  bool       `json:"disabled,omitempty"`
+	InvitedBy string     `json:"invitedBy,omitempty"`
+	DeletedAt *time.Time `json:"deletedAt,omitempty"`
+}
+
+// Roles are the roles bound to the user in the permittable of the user.
+type Roles struct {
+	Platform   []string        `json:"platform"`
+	Workspaces []WorkspaceRole `json:"workspaces"`
+}
+
+type WorkspaceRole struct {
+	WorkspaceID string `json:"workspaceId"`
+	Role        string `json:"role"`
+}
+
+// Sessions are the traces the sign-ins of the user leave. Access and refresh
+// tokens are not stored, so only the times are known.
+type Sessions struct {
+	// LatestLogoutAt is when the user last signed out everywhere. Tokens
+	// issued before it are rejected.
+	LatestLogoutAt *time.Time `json:"latestLogoutAt,omitempty"`
+	// MagicLinksRequestedAt are the sign-in links mailed in the last hour.
+	MagicLinksRequestedAt []time.Time `json:"magicLinksRequestedAt,omitempty"`
+	// PasswordResetRequestedAt is when the pending password reset was asked.
+	PasswordResetRequestedAt *time.Time `json:"passwordResetRequestedAt,omitempty"`
+}
+
+// AuditEntry is an action an admin took on the user.
+type AuditEntry struct {
+	ID        string            `json:"id"`
+	Action    string            `json:"action"`
+	Actor     string            `json:"actor"`
+	Detail    map[string]string `json:"detail,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+}
+
+// Collect reads the personal data of the user with the given ID.
+func Collect(ctx context.Context, r Repos, uid user.ID, now time.Time) (*Archive, error) {
+	u, err := r.User.FindByID(ctx, uid)
+	if err != nil {
+		return nil, err
+	}
+
+	a := &Archive{
+		User:       newUser(u),
+		Workspaces: []Workspace{},
+		Roles:      Roles{Platform: []string{}, Workspaces: []WorkspaceRole{}},
+		Sessions:   newSessions(u),
+		AuditLog:   []AuditEntry{},
+		CreatedAt:  now,
+	}
+
+	wss, err := r.Workspace.FindByUser(ctx, uid)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, err
+	}
+	for _, ws := range wss {
+		m := ws.Members().User(uid)
+		if m == nil {
+			continue
+		}
+		w := Workspace{
+			ID:        ws.ID().String(),
+			Name:      ws.Name(),
+			Alias:     ws.Alias(),
+			Personal:  ws.IsPersonal(),
+			Role:      m.Role.String(),
+			Disabled:  m.Disabled,
+			DeletedAt: ws.DeletedAt(),
+		}
+		if !m.InvitedBy.IsEmpty() {
+			w.InvitedBy = m.InvitedBy.String()
+		}
+		a.Workspaces = append(a.Workspaces, w)
+	}
+	slices.SortFunc(a.Workspaces, func(x, y Workspace) int { return strings.Compare(x.ID, y.ID) })
+
+	if err := collectRoles(ctx, r, uid, &a.Roles); err != nil {
+		return nil, err
+	}
+
+	entries, err := r.AuditLog.FindByTarget(ctx, uid.String())
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return nil, err
+	}
+	for _, e := range entries {
+		a.AuditLog = append(a.AuditLog, AuditEntry{
+			ID:        e.ID().String(),
+			Action:    e.Action().String(),
+			Actor:     e.Actor().String(),
+			Detail:    e.Detail(),
+			CreatedAt: e.CreatedAt(),
+		})
+	}
+	return a, nil
+}
+
+func newUser(u *user.User) User {
+	md := u.Metadata()
+	res := User{
+		ID:       u.ID().String(),
+		Name:     u.Name(),
+		Alias:    u.Alias(),
+		Email:    u.Email(),
+		Verified: u.Verification().IsVerified(),
+		Metadata: Metadata{
+			PhotoURL:    md.PhotoURL(),
+			Description: md.Description(),
+			Website:     md.Website(),
+			Theme:       string(md.Theme()),
+		},
+		Auths:      []Auth{},
+		MFAEnabled: u.MFA().IsEnabled(),
+		Passkeys:   []Passkey{},
+		CreatedAt:  u.CreatedAt(),
+		UpdatedAt:  u.UpdatedAt(),
+		DeletedAt:  u.DeletedAt(),
+	}
+	if l := md.Lang(); l != language.Und {
+		res.Metadata.Lang = l.String()
+	}
+	if m := u.MergedInto(); m != nil {
+		res.MergedInto = m.String()
+	}
+	for _, a := range u.Auths() {
+		auth := Auth{Provider: a.Provider, Sub: a.Sub}
+		if l := u.AuthLink(a.Sub); l != nil {
+			auth.Issuer = l.Issuer
+			auth.Email = l.Email
+			auth.LinkedAt = &l.LinkedAt
+		}
+		res.Auths = append(res.Auths, auth)
+	}
+	for _, p := range u.Passkeys() {
+		res.Passkeys = append(res.Passkeys, Passkey{
+			ID:         p.IDString(),
+			Nickname:   p.Nickname,
+			Transports: p.Transports,
+			CreatedAt:  p.CreatedAt,
+			LastUsedAt: p.LastUsedAt,
+		})
+	}
+	return res
+}
+
+func newSessions(u *user.User) Sessions {
+	var s Sessions
+	if t := u.LatestLogoutAt(); !t.IsZero() {
+		s.LatestLogoutAt = &t
+	}
+	if ml := u.MagicLink(); ml != nil {
+		s.MagicLinksRequestedAt = slices.Clone(ml.RequestedAt)
+	}
+	if pr := u.PasswordReset(); pr != nil {
+		t := pr.CreatedAt
+		s.PasswordResetRequestedAt = &t
+	}
+	return s
+}
+
+func collectRoles(ctx context.Context, r Repos, uid user.ID, res *Roles) error {
+	p, err := r.Permittable.FindByUserID(ctx, uid)
+	if errors.Is(err, rerror.ErrNotFound) || (err == nil && p == nil) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	ids := slices.Clone(p.RoleIDs())
+	for _, wr := range p.WorkspaceRoles() {
+		ids = append(ids, wr.RoleID())
+	}
+	roles, err := r.Role.FindByIDs(ctx, ids)
+	if err != nil && !errors.Is(err, rerror.ErrNotFound) {
+		return err
+	}
+	names := make(map[id.RoleID]string, len(roles))
+	for _, rl := range roles {
+		names[rl.ID()] = rl.Name()
+	}
+	name := func(rid id.RoleID) string {
+		if n, ok := names[rid]; ok {
+			return n
+		}
+		return rid.String()
+	}
+
+	for _, rid := range p.RoleIDs() {
+		res.Platform = append(res.Platform, name(rid))
+	}
+	for _, wr := range p.WorkspaceRoles() {
+		res.Workspaces = append(res.Workspaces, WorkspaceRole{
+			WorkspaceID: wr.ID().String(),
+			Role:        name(wr.RoleID()),
+		})
+	}
+	return nil
+}
+
+// WriteZip writes the archive as a ZIP file with a JSON file per section.
+func (a *Archive) WriteZip(w io.Writer) error {
+	zw := zip.NewWriter(w)
+	files := []struct {
+		name string
+		v    any
+	}{
+		{"user.json", a.User},
+		{"workspaces.json", a.Workspaces},
+		{"roles.json", a.Roles},
+		{"sessions.json", a.Sessions},
+		{"audit_log.json", a.AuditLog},
+	}
+	for _, f := range files {
+		fw, err := zw.CreateHeader(&zip.FileHeader{Name: f.name, Method: zip.Deflate, Modified: a.CreatedAt})
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(fw)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(f.v); err != nil {
+			return fmt.Errorf("encode %s: %w", f.name, err)
+		}
+	}
+	return zw.Close()
+}
+
+// Storage is where archives are uploaded. gateway.Storage implements it.
+type Storage interface {
+	Upload(ctx context.Context, name string, data *file.File) error
+	GetSignedURL(ctx context.Context, name string) (string, error)
+}
+
+// Upload stores the archive as a ZIP file and returns a signed URL to
+// download it, which expires.
+func (a *Archive) Upload(ctx context.Context, s Storage) (string, error) {
+	var buf bytes.Buffer
+	if err := a.WriteZip(&buf); err != nil {
+		return "", err
+	}
+	name := a.ObjectName()
+	if err := s.Upload(ctx, name, &file.File{
+		Content:     io.NopCloser(&buf),
+		Name:        path.Base(name),
+		ContentType: "application/zip",
+		Size:        int64(buf.Len()),
+	}); err != nil {
+		return "", err
+	}
+	return s.GetSignedURL(ctx, name)
+}
+
+// ObjectName is the name the archive is stored under.
+func (a *Archive) ObjectName() string {
+	return fmt.Sprintf("users/%s/data-exports/%s.zip", a.User.ID, a.CreatedAt.UTC().Format("20060102T150405Z"))
+}
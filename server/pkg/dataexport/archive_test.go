@@ -0,0 +1,130 @@
+package dataexport
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/internal/infrastructure/memory"
+	"github.com/reearth/reearth-accounts/server/internal/usecase/gateway/mock"
+	"github.com/reearth/reearth-accounts/server/pkg/auditlog"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/permittable"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/reearth/reearthx/asset/domain/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/mock/gomock"
+	"golang.org/x/text/language"
+)
+
+func TestCollect(t *testing.T) {
+	ctx := context.Background()
+	r := memory.New()
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+
+	u := user.New().NewID().Name("Alice").Alias("alice").Email("alice@example.com").
+		Auths([]user.Auth{user.AuthFrom("auth0|1")}).
+		Metadata(user.MetadataFrom("", "hi", "", language.Japanese, user.ThemeDark)).
+		MustBuild()
+	u.SetPassword("Passw0rd!")
+	u.SetLatestLogoutAt(now.Add(-time.Hour))
+	require.NoError(t, r.User.Save(ctx, u))
+
+	team := workspace.New().NewID().Name("Team").Alias("team").
+		Members(map[workspace.UserID]workspace.Member{u.ID(): {Role: role.RoleWriter}}).
+		MustBuild()
+	other := workspace.New().NewID().Name("Other").Alias("other").MustBuild()
+	require.NoError(t, r.Workspace.SaveAll(ctx, workspace.List{team, other}))
+
+	self := role.New().NewID().Name("self").MustBuild()
+	writer := role.New().NewID().Name("writer").MustBuild()
+	require.NoError(t, r.Role.Save(ctx, *self))
+	require.NoError(t, r.Role.Save(ctx, *writer))
+	p := permittable.New().NewID().UserID(u.ID()).RoleIDs([]id.RoleID{self.ID()}).
+		WorkspaceRoles([]permittable.WorkspaceRole{permittable.NewWorkspaceRole(team.ID(), writer.ID())}).
+		MustBuild()
+	require.NoError(t, r.Permittable.Save(ctx, *p))
+
+	entry := auditlog.New().NewID().Actor(id.NewAdminUserID()).Action(auditlog.ActionUserMerge).
+		Target(u.ID().String()).CreatedAt(now).MustBuild()
+	require.NoError(t, r.AuditLog.Save(ctx, entry))
+
+	a, err := Collect(ctx, Repos{
+		User:        r.User,
+		Workspace:   r.Workspace,
+		Role:        r.Role,
+		Permittable: r.Permittable,
+		AuditLog:    r.AuditLog,
+	}, u.ID(), now)
+	require.NoError(t, err)
+
+	assert.Equal(t, "alice@example.com", a.User.Email)
+	assert.Equal(t, "ja", a.User.Metadata.Lang)
+	assert.Equal(t, "dark", a.User.Metadata.Theme)
+	assert.Equal(t, []Auth{{Provider: "auth0", Sub: "auth0|1"}}, a.User.Auths)
+	assert.Equal(t, []Workspace{{ID: team.ID().String(), Name: "Team", Alias: "team", Role: "writer"}}, a.Workspaces)
+	assert.Equal(t, []string{"self"}, a.Roles.Platform)
+	assert.Equal(t, []WorkspaceRole{{WorkspaceID: team.ID().String(), Role: "writer"}}, a.Roles.Workspaces)
+	require.NotNil(t, a.Sessions.LatestLogoutAt)
+	assert.Equal(t, now.Add(-time.Hour), *a.Sessions.LatestLogoutAt)
+	require.Len(t, a.AuditLog, 1)
+	assert.Equal(t, "user.merge", a.AuditLog[0].Action)
+
+	var buf bytes.Buffer
+	require.NoError(t, a.WriteZip(&buf))
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	assert.Equal(t, []string{"user.json", "workspaces.json", "roles.json", "sessions.json", "audit_log.json"}, names)
+
+	f, err := zr.File[0].Open()
+	require.NoError(t, err)
+	b, err := io.ReadAll(f)
+	require.NoError(t, err)
+	var raw map[string]any
+	require.NoError(t, json.Unmarshal(b, &raw))
+	assert.Equal(t, "Alice", raw["name"])
+	assert.NotContains(t, string(b), "password")
+}
+
+func TestCollect_NotFound(t *testing.T) {
+	r := memory.New()
+	_, err := Collect(context.Background(), Repos{
+		User:        r.User,
+		Workspace:   r.Workspace,
+		Role:        r.Role,
+		Permittable: r.Permittable,
+		AuditLog:    r.AuditLog,
+	}, user.NewID(), time.Now())
+	assert.Error(t, err)
+}
+
+func TestArchive_Upload(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	s := mock.NewMockStorage(ctrl)
+	a := &Archive{User: User{ID: "u1"}, CreatedAt: time.Date(2026, 10, 18, 12, 30, 5, 0, time.FixedZone("JST", 9*60*60))}
+
+	s.EXPECT().Upload(gomock.Any(), "users/u1/data-exports/20261018T033005Z.zip", gomock.Any()).
+		DoAndReturn(func(_ context.Context, _ string, f *file.File) error {
+			assert.Equal(t, "application/zip", f.ContentType)
+			b, err := io.ReadAll(f.Content)
+			require.NoError(t, err)
+			assert.Equal(t, int64(len(b)), f.Size)
+			return nil
+		})
+	s.EXPECT().GetSignedURL(gomock.Any(), "users/u1/data-exports/20261018T033005Z.zip").Return("https://example.com/signed", nil)
+
+	url, err := a.Upload(context.Background(), s)
+	require.NoError(t, err)
+	assert.Equal(t, "https://example.com/signed", url)
+}
@@ -8,13 +8,27 @@ type Workspace struct{}
 type Integration struct{}
 type Role struct{}
 type Permittable struct{}
+type SCIMTenant struct{}
+type AuditLog struct{}
+type RoleMapping struct{}
+type LDAPSyncRun struct{}
+type AdminAuditRecord struct{}
+type AdminSession struct{}
+type AdminApprovalRule struct{}
 
-func (AdminUser) Type() string   { return "adminuser" }
-func (User) Type() string        { return "user" }
-func (Workspace) Type() string   { return "workspace" }
-func (Integration) Type() string { return "integration" }
-func (Role) Type() string        { return "role" }
-func (Permittable) Type() string { return "permittable" }
+func (AdminUser) Type() string         { return "adminuser" }
+func (User) Type() string              { return "user" }
+func (Workspace) Type() string         { return "workspace" }
+func (Integration) Type() string       { return "integration" }
+func (Role) Type() string              { return "role" }
+func (Permittable) Type() string       { return "permittable" }
+func (SCIMTenant) Type() string        { return "scimtenant" }
+func (AuditLog) Type() string          { return "auditlog" }
+func (RoleMapping) Type() string       { return "rolemapping" }
+func (LDAPSyncRun) Type() string       { return "ldapsyncrun" }
+func (AdminAuditRecord) Type() string  { return "adminauditrecord" }
+func (AdminSession) Type() string      { return "adminsession" }
+func (AdminApprovalRule) Type() string { return "adminapprovalrule" }
 
 type AdminUserID = idx.ID[AdminUser]
 type UserID = idx.ID[User]
@@ -22,6 +36,13 @@ type WorkspaceID = idx.ID[Workspace]
 type IntegrationID = idx.ID[Integration]
 type RoleID = idx.ID[Role]
 type PermittableID = idx.ID[Permittable]
+type SCIMTenantID = idx.ID[SCIMTenant]
+type AuditLogID = idx.ID[AuditLog]
+type RoleMappingID = idx.ID[RoleMapping]
+type LDAPSyncRunID = idx.ID[LDAPSyncRun]
+type AdminAuditRecordID = idx.ID[AdminAuditRecord]
+type AdminSessionID = idx.ID[AdminSession]
+type AdminApprovalRuleID = idx.ID[AdminApprovalRule]
 
 var NewAdminUserID = idx.New[AdminUser]
 var NewUserID = idx.New[User]
@@ -29,6 +50,13 @@ var NewWorkspaceID = idx.New[Workspace]
 var NewIntegrationID = idx.New[Integration]
 var NewRoleID = idx.New[Role]
 var NewPermittableID = idx.New[Permittable]
+var NewSCIMTenantID = idx.New[SCIMTenant]
+var NewAuditLogID = idx.New[AuditLog]
+var NewRoleMappingID = idx.New[RoleMapping]
+var NewLDAPSyncRunID = idx.New[LDAPSyncRun]
+var NewAdminAuditRecordID = idx.New[AdminAuditRecord]
+var NewAdminSessionID = idx.New[AdminSession]
+var NewAdminApprovalRuleID = idx.New[AdminApprovalRule]
 
 var MustAdminUserID = idx.Must[AdminUser]
 var MustUserID = idx.Must[User]
@@ -36,6 +64,13 @@ var MustWorkspaceID = idx.Must[Workspace]
 var MustIntegrationID = idx.Must[Integration]
 var MustRoleID = idx.Must[Role]
 var MustPermittableID = idx.Must[Permittable]
+var MustSCIMTenantID = idx.Must[SCIMTenant]
+var MustAuditLogID = idx.Must[AuditLog]
+var MustRoleMappingID = idx.Must[RoleMapping]
+var MustLDAPSyncRunID = idx.Must[LDAPSyncRun]
+var MustAdminAuditRecordID = idx.Must[AdminAuditRecord]
+var MustAdminSessionID = idx.Must[AdminSession]
+var MustAdminApprovalRuleID = idx.Must[AdminApprovalRule]
 
 var AdminUserIDFrom = idx.From[AdminUser]
 var UserIDFrom = idx.From[User]
@@ -43,6 +78,13 @@ var WorkspaceIDFrom = idx.From[Workspace]
 var IntegrationIDFrom = idx.From[Integration]
 var RoleIDFrom = idx.From[Role]
 var PermittableIDFrom = idx.From[Permittable]
+var SCIMTenantIDFrom = idx.From[SCIMTenant]
+var AuditLogIDFrom = idx.From[AuditLog]
+var RoleMappingIDFrom = idx.From[RoleMapping]
+var LDAPSyncRunIDFrom = idx.From[LDAPSyncRun]
+var AdminAuditRecordIDFrom = idx.From[AdminAuditRecord]
+var AdminSessionIDFrom = idx.From[AdminSession]
+var AdminApprovalRuleIDFrom = idx.From[AdminApprovalRule]
 
 var AdminUserIDFromRef = idx.FromRef[AdminUser]
 var UserIDFromRef = idx.FromRef[User]
@@ -50,6 +92,13 @@ var WorkspaceIDFromRef = idx.FromRef[Workspace]
 var IntegrationIDFromRef = idx.FromRef[Integration]
 var RoleIDFromRef = idx.FromRef[Role]
 var PermittableIDFromRef = idx.FromRef[Permittable]
+var SCIMTenantIDFromRef = idx.FromRef[SCIMTenant]
+var AuditLogIDFromRef = idx.FromRef[AuditLog]
+var RoleMappingIDFromRef = idx.FromRef[RoleMapping]
+var LDAPSyncRunIDFromRef = idx.FromRef[LDAPSyncRun]
+var AdminAuditRecordIDFromRef = idx.FromRef[AdminAuditRecord]
+var AdminSessionIDFromRef = idx.FromRef[AdminSession]
+var AdminApprovalRuleIDFromRef = idx.FromRef[AdminApprovalRule]
 
 type AdminUserIDList = idx.List[AdminUser]
 type UserIDList = idx.List[User]
@@ -57,6 +106,13 @@ type WorkspaceIDList = idx.List[Workspace]
 type IntegrationIDList = idx.List[Integration]
 type RoleIDList = idx.List[Role]
 type PermittableIDList = idx.List[Permittable]
+type SCIMTenantIDList = idx.List[SCIMTenant]
+type AuditLogIDList = idx.List[AuditLog]
+type RoleMappingIDList = idx.List[RoleMapping]
+type LDAPSyncRunIDList = idx.List[LDAPSyncRun]
+type AdminAuditRecordIDList = idx.List[AdminAuditRecord]
+type AdminSessionIDList = idx.List[AdminSession]
+type AdminApprovalRuleIDList = idx.List[AdminApprovalRule]
 
 var AdminUserIDListFrom = idx.ListFrom[AdminUser]
 var RoleIDListFrom = idx.ListFrom[Role]
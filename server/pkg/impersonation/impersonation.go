@@ -0,0 +1,164 @@
+// Package impersonation issues and verifies the short-lived tokens with which
+// an admin views the main service as a user. The admin API signs them and the
+// main service accepts them, both with a shared HMAC secret.
+package impersonation
+
+import (
+	"errors"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+// Header is the request header carrying an impersonation token. It is
+// separate from Authorization so the token is never mistaken for one of an
+// identity provider.
+const Header = "X-Reearth-Impersonation-Token"
+
+// MaxTTL bounds the lifetime of a token whatever the admin API is configured
+// with.
+const MaxTTL = time.Hour
+
+const (
+	issuer   = "reearth-accounts-admin"
+	audience = "reearth-accounts-impersonation"
+)
+
+var (
+	// ErrInvalidToken is returned when a token is malformed, has a bad
+	// signature, is expired or carries unusable identities.
+	ErrInvalidToken = errors.New("invalid impersonation token")
+	// ErrEmptySecret is returned when no signing secret is configured, which
+	// disables impersonation.
+	ErrEmptySecret = errors.New("impersonation secret is empty")
+	// ErrInvalidTTL is returned by Issue for a lifetime that is not positive
+	// or exceeds MaxTTL.
+	ErrInvalidTTL = errors.New("impersonation token lifetime must be positive and at most an hour")
+)
+
+// Grant is what a token carries: the admin who impersonates, the admin
+// session they issued it from, the user they act as and when the grant ends.
+// TokenID tells the requests made with one token apart in the logs. The grant
+// only holds while Session does, so signing the admin out or revoking the
+// session ends it too.
+type Grant struct {
+	TokenID   string
+	Admin     adminuser.ID
+	Session   adminsession.ID
+	User      id.UserID
+	ExpiresAt time.Time
+}
+
+type actorClaim struct {
+	Sub string `json:"sub"`
+}
+
+type claims struct {
+	jwt.RegisteredClaims
+	// Act names the admin acting as the subject (RFC 8693).
+	Act actorClaim `json:"act"`
+	// Sid is the admin session the token was issued from.
+	Sid string `json:"sid"`
+}
+
+// Signer signs and parses impersonation tokens.
+type Signer struct {
+	secret []byte
+}
+
+// NewSigner builds a Signer. An empty secret makes every call fail with
+// ErrEmptySecret.
+func NewSigner(secret string) *Signer {
+	return &Signer{secret: []byte(secret)}
+}
+
+// Enabled reports whether a secret is configured.
+func (s *Signer) Enabled() bool {
+	return s != nil && len(s.secret) > 0
+}
+
+// Issue signs a token with which admin, signed in with session, acts as u
+// until now+ttl.
+func (s *Signer) Issue(admin adminuser.ID, session adminsession.ID, u id.UserID, now time.Time, ttl time.Duration) (string, *Grant, error) {
+	if !s.Enabled() {
+		return "", nil, ErrEmptySecret
+	}
+	if ttl <= 0 || ttl > MaxTTL {
+		return "", nil, ErrInvalidTTL
+	}
+
+	g := &Grant{
+		TokenID:   uuid.NewString(),
+		Admin:     admin,
+		Session:   session,
+		User:      u,
+		ExpiresAt: now.Add(ttl).Truncate(time.Second),
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        g.TokenID,
+			Subject:   u.String(),
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{audience},
+			IssuedAt:  jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(g.ExpiresAt),
+		},
+		Act: actorClaim{Sub: admin.String()},
+		Sid: session.String(),
+	})
+	signed, err := token.SignedString(s.secret)
+	if err != nil {
+		return "", nil, err
+	}
+	return signed, g, nil
+}
+
+// Parse validates a token and returns its grant.
+func (s *Signer) Parse(token string) (*Grant, error) {
+	if !s.Enabled() {
+		return nil, ErrEmptySecret
+	}
+
+	c := &claims{}
+	_, err := jwt.ParseWithClaims(token, c, func(t *jwt.Token) (any, error) {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, ErrInvalidToken
+		}
+		return s.secret, nil
+	},
+		jwt.WithValidMethods([]string{"HS256"}),
+		jwt.WithIssuer(issuer),
+		jwt.WithAudience(audience),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+
+	if c.ID == "" {
+		return nil, ErrInvalidToken
+	}
+	admin, err := adminuser.IDFrom(c.Act.Sub)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	session, err := adminsession.IDFrom(c.Sid)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	u, err := id.UserIDFrom(c.Subject)
+	if err != nil {
+		return nil, ErrInvalidToken
+	}
+	return &Grant{
+		TokenID:   c.ID,
+		Admin:     admin,
+		Session:   session,
+		User:      u,
+		ExpiresAt: c.ExpiresAt.Time,
+	}, nil
+}
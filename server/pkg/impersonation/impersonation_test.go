@@ -0,0 +1,93 @@
+package impersonation
+
+import (
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/reearth/reearth-accounts/server/pkg/adminsession"
+	"github.com/reearth/reearth-accounts/server/pkg/adminuser"
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "test-secret-that-is-long-enough-000"
+
+func TestSigner_IssueParse_RoundTrip(t *testing.T) {
+	s := NewSigner(testSecret)
+	admin := adminuser.NewID()
+	sid := adminsession.NewID()
+	u := id.NewUserID()
+	now := time.Now()
+
+	tok, g, err := s.Issue(admin, sid, u, now, 15*time.Minute)
+	require.NoError(t, err)
+	assert.NotEmpty(t, g.TokenID)
+	assert.Equal(t, now.Add(15*time.Minute).Truncate(time.Second), g.ExpiresAt)
+
+	got, err := s.Parse(tok)
+	require.NoError(t, err)
+	assert.Equal(t, g.TokenID, got.TokenID)
+	assert.Equal(t, admin, got.Admin)
+	assert.Equal(t, sid, got.Session)
+	assert.Equal(t, u, got.User)
+	assert.True(t, g.ExpiresAt.Equal(got.ExpiresAt))
+}
+
+func TestSigner_Issue_TTL(t *testing.T) {
+	s := NewSigner(testSecret)
+	for _, ttl := range []time.Duration{0, -time.Minute, MaxTTL + time.Second} {
+		_, _, err := s.Issue(adminuser.NewID(), adminsession.NewID(), id.NewUserID(), time.Now(), ttl)
+		assert.ErrorIs(t, err, ErrInvalidTTL, ttl)
+	}
+}
+
+func TestSigner_Parse_Rejects(t *testing.T) {
+	s := NewSigner(testSecret)
+
+	expired, _, err := s.Issue(adminuser.NewID(), adminsession.NewID(), id.NewUserID(), time.Now().Add(-2*time.Hour), time.Hour)
+	require.NoError(t, err)
+	other, _, err := NewSigner("secret-b-secret-b-secret-b-secret-b").Issue(adminuser.NewID(), adminsession.NewID(), id.NewUserID(), time.Now(), time.Hour)
+	require.NoError(t, err)
+	// a token for another audience signed with the same secret, such as an
+	// admin session, is not an impersonation
+	sess, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.RegisteredClaims{
+		Subject:   adminuser.NewID().String(),
+		Issuer:    issuer,
+		ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	// a token without the admin session it was issued from
+	sessionless, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "t",
+			Subject:   id.NewUserID().String(),
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Hour)),
+		},
+		Act: actorClaim{Sub: adminuser.NewID().String()},
+	}).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+
+	for name, tok := range map[string]string{
+		"expired":      expired,
+		"wrong secret": other,
+		"session":      sess,
+		"no session":   sessionless,
+		"garbage":      "not-a-jwt",
+	} {
+		_, err := s.Parse(tok)
+		assert.ErrorIs(t, err, ErrInvalidToken, name)
+	}
+}
+
+func TestSigner_EmptySecret(t *testing.T) {
+	s := NewSigner("")
+	assert.False(t, s.Enabled())
+	_, _, err := s.Issue(adminuser.NewID(), adminsession.NewID(), id.NewUserID(), time.Now(), time.Minute)
+	assert.ErrorIs(t, err, ErrEmptySecret)
+	_, err = s.Parse("x")
+	assert.ErrorIs(t, err, ErrEmptySecret)
+}
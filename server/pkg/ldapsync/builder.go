@@ -0,0 +1,105 @@
+package ldapsync
+
+import (
+	"slices"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+)
+
+type Builder struct {
+	r *Run
+}
+
+func New() *Builder {
+	return &Builder{r: &Run{}}
+}
+
+func (b *Builder) Build() (*Run, error) {
+	if b.r.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if !b.r.status.Valid() {
+		return nil, ErrInvalidStatus
+	}
+	if b.r.startedAt.IsZero() {
+		return nil, ErrInvalidStartedAt
+	}
+	return b.r, nil
+}
+
+func (b *Builder) MustBuild() *Run {
+	r, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return r
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.r.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.r.id = NewID()
+	return b
+}
+
+func (b *Builder) Status(status Status) *Builder {
+	b.r.status = status
+	return b
+}
+
+func (b *Builder) Err(err string) *Builder {
+	b.r.err = err
+	return b
+}
+
+func (b *Builder) StartedAt(t time.Time) *Builder {
+	b.r.startedAt = t
+	return b
+}
+
+func (b *Builder) FinishedAt(t *time.Time) *Builder {
+	if t != nil {
+		t2 := *t
+		b.r.finishedAt = &t2
+	}
+	return b
+}
+
+func (b *Builder) Users(users user.IDList) *Builder {
+	b.r.users = slices.Clone(users)
+	return b
+}
+
+func (b *Builder) Created(c []UserChange) *Builder {
+	b.r.created = slices.Clone(c)
+	return b
+}
+
+func (b *Builder) Updated(c []UserChange) *Builder {
+	b.r.updated = slices.Clone(c)
+	return b
+}
+
+func (b *Builder) Reactivated(c []UserChange) *Builder {
+	b.r.reactivated = slices.Clone(c)
+	return b
+}
+
+func (b *Builder) Deactivated(c []UserChange) *Builder {
+	b.r.deactivated = slices.Clone(c)
+	return b
+}
+
+func (b *Builder) Memberships(c []MembershipChange) *Builder {
+	b.r.memberships = slices.Clone(c)
+	return b
+}
+
+func (b *Builder) Skipped(s []Skip) *Builder {
+	b.r.skipped = slices.Clone(s)
+	return b
+}
@@ -0,0 +1,18 @@
+package ldapsync
+
+import (
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+type ID = id.LDAPSyncRunID
+type IDList = id.LDAPSyncRunIDList
+
+var NewID = id.NewLDAPSyncRunID
+
+var MustID = id.MustLDAPSyncRunID
+
+var IDFrom = id.LDAPSyncRunIDFrom
+
+var IDFromRef = id.LDAPSyncRunIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
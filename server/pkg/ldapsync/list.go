@@ -0,0 +1,16 @@
+package ldapsync
+
+type List []*Run
+
+func (l List) IDs() IDList {
+	if l == nil {
+		return nil
+	}
+	ids := make(IDList, 0, len(l))
+	for _, r := range l {
+		if r != nil {
+			ids = append(ids, r.ID())
+		}
+	}
+	return ids
+}
@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repo.go -destination=./mock_ldapsync.go -package ldapsync
+//
+
+// Package ldapsync is a generated GoMock package.
+package ldapsync
+
+import (
+	context "context"
+	reflect "reflect"
+
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// FindByID mocks base method.
+func (m *MockRepo) FindByID(arg0 context.Context, arg1 ID) (*Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByID", arg0, arg1)
+	ret0, _ := ret[0].(*Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByID indicates an expected call of FindByID.
+func (mr *MockRepoMockRecorder) FindByID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByID", reflect.TypeOf((*MockRepo)(nil).FindByID), arg0, arg1)
+}
+
+// FindLastSucceeded mocks base method.
+func (m *MockRepo) FindLastSucceeded(arg0 context.Context) (*Run, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindLastSucceeded", arg0)
+	ret0, _ := ret[0].(*Run)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindLastSucceeded indicates an expected call of FindLastSucceeded.
+func (mr *MockRepoMockRecorder) FindLastSucceeded(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindLastSucceeded", reflect.TypeOf((*MockRepo)(nil).FindLastSucceeded), arg0)
+}
+
+// FindRecent mocks base method.
+func (m *MockRepo) FindRecent(arg0 context.Context, arg1 int) (List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindRecent", arg0, arg1)
+	ret0, _ := ret[0].(List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindRecent indicates an expected call of FindRecent.
+func (mr *MockRepoMockRecorder) FindRecent(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindRecent", reflect.TypeOf((*MockRepo)(nil).FindRecent), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockRepo) Save(arg0 context.Context, arg1 *Run) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepoMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepo)(nil).Save), arg0, arg1)
+}
@@ -0,0 +1,16 @@
+package ldapsync
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=./repo.go -destination=./mock_ldapsync.go -package ldapsync
+type Repo interface {
+	FindByID(context.Context, ID) (*Run, error)
+	// FindRecent returns up to limit runs, newest first.
+	FindRecent(context.Context, int) (List, error)
+	// FindLastSucceeded returns the newest succeeded run, or
+	// rerror.ErrNotFound.
+	FindLastSucceeded(context.Context) (*Run, error)
+	Save(context.Context, *Run) error
+}
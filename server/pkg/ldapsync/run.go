@@ -0,0 +1,218 @@
+package ldapsync
+
+import (
+	"errors"
+	"slices"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+)
+
+var (
+	ErrInvalidStartedAt = errors.New("ldap sync run start time can't be empty")
+	ErrInvalidStatus    = errors.New("invalid ldap sync run status")
+)
+
+type Status string
+
+const (
+	StatusRunning   Status = "running"
+	StatusSucceeded Status = "succeeded"
+	StatusFailed    Status = "failed"
+)
+
+func (s Status) Valid() bool {
+	switch s {
+	case StatusRunning, StatusSucceeded, StatusFailed:
+		return true
+	}
+	return false
+}
+
+// Run is one synchronization of the users of an LDAP directory, with what it
+// changed. The users of the last succeeded run are the ones the next run
+// deactivates when they are gone from the directory.
+type Run struct {
+	id          ID
+	status      Status
+	err         string
+	startedAt   time.Time
+	finishedAt  *time.Time
+	users       user.IDList
+	created     []UserChange
+	updated     []UserChange
+	reactivated []UserChange
+	deactivated []UserChange
+	memberships []MembershipChange
+	skipped     []Skip
+}
+
+// UserChange is a user the run created, updated, reactivated or deactivated.
+type UserChange struct {
+	User  user.ID
+	Sub   string
+	Email string
+}
+
+// MembershipChange is a workspace membership the run changed through the role
+// mappings of the directory. From is empty when the user joined and To when
+// it left.
+type MembershipChange struct {
+	Workspace workspace.ID
+	User      user.ID
+	From      role.RoleType
+	To        role.RoleType
+}
+
+// Skip is a directory entry the run could not sync, e.g. because it has no
+// email or its email belongs to another user.
+type Skip struct {
+	DN     string
+	Reason string
+}
+
+// NewRun starts a run at startedAt.
+func NewRun(startedAt time.Time) *Run {
+	return &Run{id: NewID(), status: StatusRunning, startedAt: startedAt}
+}
+
+func (r *Run) ID() ID {
+	if r == nil {
+		return ID{}
+	}
+	return r.id
+}
+
+func (r *Run) Status() Status {
+	if r == nil {
+		return ""
+	}
+	return r.status
+}
+
+// Err is why a failed run stopped.
+func (r *Run) Err() string {
+	if r == nil {
+		return ""
+	}
+	return r.err
+}
+
+func (r *Run) StartedAt() time.Time {
+	if r == nil {
+		return time.Time{}
+	}
+	return r.startedAt
+}
+
+func (r *Run) FinishedAt() *time.Time {
+	if r == nil || r.finishedAt == nil {
+		return nil
+	}
+	t := *r.finishedAt
+	return &t
+}
+
+// Users are the active users of the directory as of the run.
+func (r *Run) Users() user.IDList {
+	if r == nil {
+		return nil
+	}
+	return slices.Clone(r.users)
+}
+
+func (r *Run) Created() []UserChange {
+	if r == nil {
+		return nil
+	}
+	return slices.Clone(r.created)
+}
+
+func (r *Run) Updated() []UserChange {
+	if r == nil {
+		return nil
+	}
+	return slices.Clone(r.updated)
+}
+
+func (r *Run) Reactivated() []UserChange {
+	if r == nil {
+		return nil
+	}
+	return slices.Clone(r.reactivated)
+}
+
+func (r *Run) Deactivated() []UserChange {
+	if r == nil {
+		return nil
+	}
+	return slices.Clone(r.deactivated)
+}
+
+func (r *Run) Memberships() []MembershipChange {
+	if r == nil {
+		return nil
+	}
+	return slices.Clone(r.memberships)
+}
+
+func (r *Run) Skipped() []Skip {
+	if r == nil {
+		return nil
+	}
+	return slices.Clone(r.skipped)
+}
+
+// HasChanges reports whether the run changed any user or membership.
+func (r *Run) HasChanges() bool {
+	if r == nil {
+		return false
+	}
+	return len(r.created)+len(r.updated)+len(r.reactivated)+len(r.deactivated)+len(r.memberships) > 0
+}
+
+func (r *Run) AddUser(u user.ID) {
+	r.users = append(r.users, u)
+}
+
+func (r *Run) AddCreated(c UserChange) {
+	r.created = append(r.created, c)
+}
+
+func (r *Run) AddUpdated(c UserChange) {
+	r.updated = append(r.updated, c)
+}
+
+func (r *Run) AddReactivated(c UserChange) {
+	r.reactivated = append(r.reactivated, c)
+}
+
+func (r *Run) AddDeactivated(c UserChange) {
+	r.deactivated = append(r.deactivated, c)
+}
+
+func (r *Run) AddMemberships(c ...MembershipChange) {
+	r.memberships = append(r.memberships, c...)
+}
+
+func (r *Run) AddSkipped(s Skip) {
+	r.skipped = append(r.skipped, s)
+}
+
+// Succeed finishes the run at t.
+func (r *Run) Succeed(t time.Time) {
+	r.status = StatusSucceeded
+	r.finishedAt = &t
+}
+
+// Fail finishes the run at t because of err. The changes made before err are
+// kept in the run, as they were already saved.
+func (r *Run) Fail(t time.Time, err error) {
+	r.status = StatusFailed
+	r.finishedAt = &t
+	if err != nil {
+		r.err = err.Error()
+	}
+}
@@ -0,0 +1,52 @@
+package ldapsync
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRun(t *testing.T) {
+	start := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	r := NewRun(start)
+	assert.Equal(t, StatusRunning, r.Status())
+	assert.False(t, r.HasChanges())
+	assert.Nil(t, r.FinishedAt())
+
+	uid := user.NewID()
+	r.AddUser(uid)
+	r.AddSkipped(Skip{DN: "uid=bob,ou=people,dc=example,dc=org", Reason: "no email"})
+	assert.False(t, r.HasChanges())
+
+	r.AddMemberships(MembershipChange{Workspace: workspace.NewID(), User: uid, To: role.RoleReader})
+	assert.True(t, r.HasChanges())
+
+	r.Succeed(start.Add(time.Minute))
+	assert.Equal(t, StatusSucceeded, r.Status())
+	assert.Equal(t, start.Add(time.Minute), *r.FinishedAt())
+	assert.Equal(t, user.IDList{uid}, r.Users())
+
+	f := NewRun(start)
+	f.Fail(start.Add(time.Second), errors.New("connection refused"))
+	assert.Equal(t, StatusFailed, f.Status())
+	assert.Equal(t, "connection refused", f.Err())
+}
+
+func TestBuilder_Build(t *testing.T) {
+	_, err := New().Status(StatusSucceeded).StartedAt(time.Now()).Build()
+	assert.ErrorIs(t, err, ErrInvalidID)
+	_, err = New().NewID().Status("done").StartedAt(time.Now()).Build()
+	assert.ErrorIs(t, err, ErrInvalidStatus)
+	_, err = New().NewID().Status(StatusSucceeded).Build()
+	assert.ErrorIs(t, err, ErrInvalidStartedAt)
+
+	finished := time.Now()
+	r, err := New().NewID().Status(StatusSucceeded).StartedAt(finished.Add(-time.Minute)).FinishedAt(&finished).Build()
+	assert.NoError(t, err)
+	assert.Equal(t, finished, *r.FinishedAt())
+}
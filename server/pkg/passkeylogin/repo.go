@@ -0,0 +1,17 @@
+package passkeylogin
+
+import (
+	"context"
+	"time"
+)
+
+//go:generate mockgen -source=./repo.go -destination=./mock_passkeylogin.go -package passkeylogin
+type Repo interface {
+	Save(context.Context, *Session) error
+	// Take removes the session and returns it, or returns rerror.ErrNotFound,
+	// so that each session is used once. Expired sessions may still be
+	// returned until RemoveExpired runs.
+	Take(context.Context, string) (*Session, error)
+	// RemoveExpired removes the sessions that expired before t.
+	RemoveExpired(ctx context.Context, t time.Time) error
+}
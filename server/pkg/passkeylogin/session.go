@@ -0,0 +1,84 @@
+// Package passkeylogin holds the pending WebAuthn login ceremonies of the
+// built-in OIDC provider. Anyone who knows an email can start one, so they are
+// kept apart from the user: starting a login never replaces the challenge of
+// another login or of a passkey registration in flight.
+package passkeylogin
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/util"
+)
+
+// ttl bounds how long a login ceremony may take between its begin and finish
+// calls.
+const ttl = 5 * time.Minute
+
+var ErrEmptyChallenge = errors.New("passkey login challenge can't be empty")
+
+// Session is a login ceremony in flight. Its random ID is handed to the client
+// and posted back with the assertion.
+type Session struct {
+	id        string
+	user      user.ID
+	challenge string
+	expiresAt time.Time
+}
+
+// New starts a login ceremony of the user with the given challenge.
+func New(u user.ID, challenge string) (*Session, error) {
+	if challenge == "" {
+		return nil, ErrEmptyChallenge
+	}
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return &Session{
+		id:        base64.RawURLEncoding.EncodeToString(b),
+		user:      u,
+		challenge: challenge,
+		expiresAt: util.Now().Add(ttl),
+	}, nil
+}
+
+// From restores a stored session.
+func From(id string, u user.ID, challenge string, expiresAt time.Time) *Session {
+	return &Session{id: id, user: u, challenge: challenge, expiresAt: expiresAt}
+}
+
+func (s *Session) ID() string {
+	if s == nil {
+		return ""
+	}
+	return s.id
+}
+
+func (s *Session) User() user.ID {
+	if s == nil {
+		return user.ID{}
+	}
+	return s.user
+}
+
+func (s *Session) Challenge() string {
+	if s == nil {
+		return ""
+	}
+	return s.challenge
+}
+
+func (s *Session) ExpiresAt() time.Time {
+	if s == nil {
+		return time.Time{}
+	}
+	return s.expiresAt
+}
+
+func (s *Session) IsExpired(now time.Time) bool {
+	return s == nil || !now.Before(s.expiresAt)
+}
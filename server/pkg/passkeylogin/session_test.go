@@ -0,0 +1,33 @@
+package passkeylogin
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNew(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+	uid := user.NewID()
+
+	s, err := New(uid, "challenge")
+	require.NoError(t, err)
+	assert.Len(t, s.ID(), 43)
+	assert.Equal(t, uid, s.User())
+	assert.Equal(t, "challenge", s.Challenge())
+	assert.False(t, s.IsExpired(now))
+	assert.True(t, s.IsExpired(now.Add(ttl)))
+
+	s2, err := New(uid, "challenge")
+	require.NoError(t, err)
+	assert.NotEqual(t, s.ID(), s2.ID())
+
+	_, err = New(uid, "")
+	assert.ErrorIs(t, err, ErrEmptyChallenge)
+	assert.True(t, (*Session)(nil).IsExpired(now))
+}
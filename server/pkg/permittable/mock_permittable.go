@@ -87,6 +87,20 @@ func (mr *MockRepoMockRecorder) FindByUserIDs(arg0, arg1 any) *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserIDs", reflect.TypeOf((*MockRepo)(nil).FindByUserIDs), arg0, arg1)
 }
 
+// RemoveByUserID mocks base method.
+func (m *MockRepo) RemoveByUserID(arg0 context.Context, arg1 user.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveByUserID", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveByUserID indicates an expected call of RemoveByUserID.
+func (mr *MockRepoMockRecorder) RemoveByUserID(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveByUserID", reflect.TypeOf((*MockRepo)(nil).RemoveByUserID), arg0, arg1)
+}
+
 // Save mocks base method.
 func (m *MockRepo) Save(arg0 context.Context, arg1 Permittable) error {
 	m.ctrl.T.Helper()
@@ -14,4 +14,5 @@ type Repo interface {
 	FindByRoleID(context.Context, id.RoleID) (List, error)
 	Save(context.Context, Permittable) error
 	SaveMany(context.Context, List) error
+	RemoveByUserID(context.Context, user.ID) error
 }
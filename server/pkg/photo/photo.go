@@ -0,0 +1,210 @@
+// Package photo validates the photos of users and workspaces and resizes them
+// to the standard thumbnails they are stored as.
+package photo
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/draw"
+	_ "image/gif" // registers the GIF decoder
+	"image/jpeg"
+	"image/png"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/reearth/reearthx/asset/domain/file"
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// MaxSize is the largest photo accepted, in bytes.
+const MaxSize = 5 << 20
+
+// maxPixels caps the decoded size of a photo so that a small, highly
+// compressed file cannot exhaust the memory.
+const maxPixels = 50_000_000
+
+// Sizes are the widths in pixels of the square thumbnails a photo is stored
+// as, largest first. The largest one is set as the photo.
+var Sizes = []int{512, 256, 64}
+
+var (
+	ErrTooLarge        = rerror.NewE(i18n.T("photo is too large"))
+	ErrUnsupportedType = rerror.NewE(i18n.T("unsupported photo type"))
+	ErrInvalid         = rerror.NewE(i18n.T("invalid photo"))
+)
+
+// Storage is where photos are uploaded. gateway.Storage implements it.
+type Storage interface {
+	Upload(ctx context.Context, name string, data *file.File) error
+	Delete(ctx context.Context, name string) error
+}
+
+// Photo is a decoded photo cropped to the square in its center.
+type Photo struct {
+	img *image.RGBA
+	// ext is the format the thumbnails are encoded in: JPEG photos stay JPEG
+	// and the others become PNG to keep their transparency.
+	ext string
+}
+
+// Decode reads a PNG, JPEG or GIF photo of at most MaxSize bytes. The type is
+// sniffed from the content rather than trusted from the client.
+func Decode(r io.Reader) (*Photo, error) {
+	data, err := io.ReadAll(io.LimitReader(r, MaxSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxSize {
+		return nil, ErrTooLarge
+	}
+
+	var ext string
+	switch http.DetectContentType(data) {
+	case "image/jpeg":
+		ext = "jpg"
+	case "image/png", "image/gif":
+		ext = "png"
+	default:
+		return nil, ErrUnsupportedType
+	}
+
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || cfg.Width <= 0 || cfg.Height <= 0 || cfg.Width*cfg.Height > maxPixels {
+		return nil, ErrInvalid
+	}
+	src, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return nil, ErrInvalid
+	}
+
+	b := src.Bounds()
+	side := min(b.Dx(), b.Dy())
+	img := image.NewRGBA(image.Rect(0, 0, side, side))
+	draw.Draw(img, img.Bounds(), src, image.Pt(b.Min.X+(b.Dx()-side)/2, b.Min.Y+(b.Dy()-side)/2), draw.Src)
+	return &Photo{img: img, ext: ext}, nil
+}
+
+// Thumbnails resizes the photo to Sizes. Photos smaller than a size are
+// scaled up so that every thumbnail has its nominal size.
+func (p *Photo) Thumbnails() []image.Image {
+	res := make([]image.Image, 0, len(Sizes))
+	src := p.img
+	for _, s := range Sizes {
+		// each thumbnail is resized from the previous one, which is cheaper
+		// than resizing every thumbnail from the photo and looks the same
+		src = resize(src, s)
+		res = append(res, src)
+	}
+	return res
+}
+
+// NewDir returns a directory to upload a new photo of the owner whose objects
+// are stored under prefix to. Every photo gets its own directory so that
+// clients caching the previous one never see it change.
+func NewDir(prefix string) string {
+	return path.Join(prefix, "photos", uuid.NewString())
+}
+
+// Upload stores the thumbnails of the photo in dir and returns the name of the
+// largest one. The thumbnails uploaded so far are deleted when one fails.
+func (p *Photo) Upload(ctx context.Context, s Storage, dir string) (string, error) {
+	contentType := "image/png"
+	if p.ext == "jpg" {
+		contentType = "image/jpeg"
+	}
+
+	var names []string
+	for i, t := range p.Thumbnails() {
+		var buf bytes.Buffer
+		var err error
+		if p.ext == "jpg" {
+			err = jpeg.Encode(&buf, t, &jpeg.Options{Quality: 90})
+		} else {
+			err = png.Encode(&buf, t)
+		}
+		if err == nil {
+			name := path.Join(dir, fmt.Sprintf("%d.%s", Sizes[i], p.ext))
+			err = s.Upload(ctx, name, &file.File{
+				Content:     io.NopCloser(&buf),
+				Name:        path.Base(name),
+				ContentType: contentType,
+				Size:        int64(buf.Len()),
+			})
+			if err == nil {
+				names = append(names, name)
+				continue
+			}
+		}
+		for _, n := range names {
+			_ = s.Delete(ctx, n)
+		}
+		return "", err
+	}
+	return names[0], nil
+}
+
+// Objects returns the objects to delete when the photo stored as name is
+// replaced: the thumbnails of an uploaded photo, or the object itself. Photos
+// that are not stored under prefix, the directory of the owner, may be shared
+// with others and are left alone.
+func Objects(name, prefix string) []string {
+	if name == "" || prefix == "" || !strings.HasPrefix(name, prefix+"/") {
+		return nil
+	}
+
+	dir, base := path.Split(name)
+	ext := path.Ext(base)
+	if path.Dir(path.Clean(dir)) != path.Join(prefix, "photos") || base != fmt.Sprintf("%d%s", Sizes[0], ext) {
+		return []string{name}
+	}
+	res := make([]string, 0, len(Sizes))
+	for _, s := range Sizes {
+		res = append(res, fmt.Sprintf("%s%d%s", dir, s, ext))
+	}
+	return res
+}
+
+// resize scales the square src to a square of the given size, averaging the
+// pixels each pixel of the result covers.
+func resize(src *image.RGBA, size int) *image.RGBA {
+	dst := image.NewRGBA(image.Rect(0, 0, size, size))
+	n := src.Bounds().Dx()
+	for y := 0; y < size; y++ {
+		y0, y1 := cover(y, size, n)
+		for x := 0; x < size; x++ {
+			x0, x1 := cover(x, size, n)
+			var sum [4]int
+			for sy := y0; sy < y1; sy++ {
+				off := src.PixOffset(x0, sy)
+				for sx := x0; sx < x1; sx++ {
+					for c := range sum {
+						sum[c] += int(src.Pix[off+c])
+					}
+					off += 4
+				}
+			}
+			count := (x1 - x0) * (y1 - y0)
+			off := dst.PixOffset(x, y)
+			for c := range sum {
+				dst.Pix[off+c] = uint8(sum[c] / count)
+			}
+		}
+	}
+	return dst
+}
+
+// cover returns the range of the n source pixels the i-th of size pixels
+// covers. It is a single pixel when scaling up.
+func cover(i, size, n int) (int, int) {
+	lo, hi := i*n/size, (i+1)*n/size
+	if hi <= lo {
+		hi = lo + 1
+	}
+	return lo, hi
+}
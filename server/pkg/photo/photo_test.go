@@ -0,0 +1,170 @@
+package photo
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/reearth/reearthx/asset/domain/file"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeStorage struct {
+	objects map[string]*file.File
+	fail    string
+}
+
+func (s *fakeStorage) Upload(_ context.Context, name string, data *file.File) error {
+	if name == s.fail {
+		return errors.New("boom")
+	}
+	s.objects[name] = data
+	return nil
+}
+
+func (s *fakeStorage) Delete(_ context.Context, name string) error {
+	delete(s.objects, name)
+	return nil
+}
+
+func testImage(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func encode(t *testing.T, enc func(io.Writer, image.Image) error, img image.Image) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	require.NoError(t, enc(&buf, img))
+	return buf.Bytes()
+}
+
+func TestDecode(t *testing.T) {
+	img := testImage(40, 20, color.RGBA{R: 255, A: 255})
+	pngData := encode(t, png.Encode, img)
+	jpegData := encode(t, func(w io.Writer, m image.Image) error { return jpeg.Encode(w, m, nil) }, img)
+	gifData := encode(t, func(w io.Writer, m image.Image) error { return gif.Encode(w, m, nil) }, img)
+
+	tests := []struct {
+		name    string
+		data    []byte
+		wantExt string
+		wantErr error
+	}{
+		{name: "png", data: pngData, wantExt: "png"},
+		{name: "jpeg", data: jpegData, wantExt: "jpg"},
+		{name: "gif", data: gifData, wantExt: "png"},
+		{name: "too large", data: append(pngData, make([]byte, MaxSize)...), wantErr: ErrTooLarge},
+		{name: "not an image", data: []byte("hello"), wantErr: ErrUnsupportedType},
+		{name: "broken", data: pngData[:len(pngData)/2], wantErr: ErrInvalid},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, err := Decode(bytes.NewReader(tt.data))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantExt, p.ext)
+			// cropped to the square in the center
+			assert.Equal(t, image.Rect(0, 0, 20, 20), p.img.Bounds())
+		})
+	}
+}
+
+func TestPhoto_Thumbnails(t *testing.T) {
+	// the left half is red and the right half blue, so the center square is
+	// split between them
+	img := testImage(1200, 1000, color.RGBA{R: 255, A: 255})
+	for y := 0; y < 1000; y++ {
+		for x := 600; x < 1200; x++ {
+			img.Set(x, y, color.RGBA{B: 255, A: 255})
+		}
+	}
+	p, err := Decode(bytes.NewReader(encode(t, png.Encode, img)))
+	require.NoError(t, err)
+
+	ts := p.Thumbnails()
+	require.Len(t, ts, len(Sizes))
+	for i, th := range ts {
+		assert.Equal(t, image.Rect(0, 0, Sizes[i], Sizes[i]), th.Bounds())
+		assert.Equal(t, color.RGBA{R: 255, A: 255}, th.At(0, 0))
+		assert.Equal(t, color.RGBA{B: 255, A: 255}, th.At(Sizes[i]-1, Sizes[i]-1))
+	}
+
+	// small photos are scaled up
+	p, err = Decode(bytes.NewReader(encode(t, png.Encode, testImage(10, 10, color.RGBA{G: 255, A: 255}))))
+	require.NoError(t, err)
+	th := p.Thumbnails()[0]
+	assert.Equal(t, image.Rect(0, 0, Sizes[0], Sizes[0]), th.Bounds())
+	assert.Equal(t, color.RGBA{G: 255, A: 255}, th.At(Sizes[0]-1, 0))
+}
+
+func TestPhoto_Upload(t *testing.T) {
+	ctx := context.Background()
+	p, err := Decode(bytes.NewReader(encode(t, png.Encode, testImage(10, 10, color.White))))
+	require.NoError(t, err)
+
+	s := &fakeStorage{objects: map[string]*file.File{}}
+	name, err := p.Upload(ctx, s, "users/u1/photos/p1")
+	require.NoError(t, err)
+	assert.Equal(t, "users/u1/photos/p1/512.png", name)
+	assert.Len(t, s.objects, len(Sizes))
+	for _, n := range Objects(name, "users/u1") {
+		require.Contains(t, s.objects, n)
+		assert.Equal(t, "image/png", s.objects[n].ContentType)
+		_, err := png.Decode(s.objects[n].Content)
+		assert.NoError(t, err)
+	}
+
+	s = &fakeStorage{objects: map[string]*file.File{}, fail: "users/u1/photos/p1/64.png"}
+	_, err = p.Upload(ctx, s, "users/u1/photos/p1")
+	assert.EqualError(t, err, "boom")
+	assert.Empty(t, s.objects)
+}
+
+func TestNewDir(t *testing.T) {
+	d := NewDir("workspaces/w1")
+	assert.True(t, strings.HasPrefix(d, "workspaces/w1/photos/"))
+	assert.NotEqual(t, d, NewDir("workspaces/w1"))
+}
+
+func TestObjects(t *testing.T) {
+	tests := []struct {
+		name   string
+		photo  string
+		prefix string
+		want   []string
+	}{
+		{
+			name:   "uploaded",
+			photo:  "users/u1/photos/p1/512.jpg",
+			prefix: "users/u1",
+			want:   []string{"users/u1/photos/p1/512.jpg", "users/u1/photos/p1/256.jpg", "users/u1/photos/p1/64.jpg"},
+		},
+		{name: "other object of the owner", photo: "users/u1/avatar.png", prefix: "users/u1", want: []string{"users/u1/avatar.png"}},
+		{name: "other owner", photo: "users/u10/photos/p1/512.png", prefix: "users/u1"},
+		{name: "shared", photo: "assets/avatar.png", prefix: "users/u1"},
+		{name: "empty", photo: "", prefix: "users/u1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Objects(tt.photo, tt.prefix))
+		})
+	}
+}
@@ -63,3 +63,12 @@ func (r RoleType) Includes(role RoleType) bool {
 	}
 	return false
 }
+
+// Higher returns the higher of both roles. An invalid role is never higher
+// than a valid one.
+func Higher(a, b RoleType) RoleType {
+	if !b.Valid() || a.Includes(b) {
+		return a
+	}
+	return b
+}
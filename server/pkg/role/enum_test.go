@@ -71,3 +71,11 @@ func TestRoleType_Includes(t *testing.T) {
 
 	assert.False(t, RoleType("").Includes(RoleReader))
 }
+
+func TestHigher(t *testing.T) {
+	assert.Equal(t, RoleOwner, Higher(RoleOwner, RoleWriter))
+	assert.Equal(t, RoleMaintainer, Higher(RoleReader, RoleMaintainer))
+	assert.Equal(t, RoleWriter, Higher(RoleWriter, RoleWriter))
+	assert.Equal(t, RoleReader, Higher(RoleType(""), RoleReader))
+	assert.Equal(t, RoleReader, Higher(RoleReader, RoleType("xxx")))
+}
@@ -0,0 +1,87 @@
+package rolemapping
+
+import (
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+)
+
+type Builder struct {
+	m *RoleMapping
+}
+
+func New() *Builder {
+	return &Builder{m: &RoleMapping{}}
+}
+
+func (b *Builder) Build() (*RoleMapping, error) {
+	if b.m.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if b.m.workspace.IsNil() {
+		return nil, ErrInvalidWorkspace
+	}
+	if strings.TrimSpace(b.m.issuer) == "" {
+		return nil, ErrEmptyIssuer
+	}
+	if strings.HasSuffix(b.m.subPrefix, "|") {
+		return nil, ErrInvalidSubPrefix
+	}
+	for _, r := range b.m.rules {
+		if r.Claim == "" || r.Value == "" {
+			return nil, ErrInvalidRule
+		}
+		if !r.Role.Valid() || r.Role == role.RoleSelf {
+			return nil, ErrInvalidRole
+		}
+	}
+	if b.m.updatedAt.IsZero() {
+		b.m.updatedAt = time.Now()
+	}
+	return b.m, nil
+}
+
+func (b *Builder) MustBuild() *RoleMapping {
+	m, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.m.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.m.id = NewID()
+	return b
+}
+
+func (b *Builder) Workspace(ws workspace.ID) *Builder {
+	b.m.workspace = ws
+	return b
+}
+
+func (b *Builder) Issuer(issuer string) *Builder {
+	b.m.issuer = issuer
+	return b
+}
+
+func (b *Builder) SubPrefix(subPrefix string) *Builder {
+	b.m.subPrefix = subPrefix
+	return b
+}
+
+func (b *Builder) Rules(rules []Rule) *Builder {
+	b.m.rules = append([]Rule(nil), rules...)
+	return b
+}
+
+func (b *Builder) UpdatedAt(updatedAt time.Time) *Builder {
+	b.m.updatedAt = updatedAt
+	return b
+}
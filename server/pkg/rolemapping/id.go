@@ -0,0 +1,18 @@
+package rolemapping
+
+import (
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+type ID = id.RoleMappingID
+type IDList = id.RoleMappingIDList
+
+var NewID = id.NewRoleMappingID
+
+var MustID = id.MustRoleMappingID
+
+var IDFrom = id.RoleMappingIDFrom
+
+var IDFromRef = id.RoleMappingIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
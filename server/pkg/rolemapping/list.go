@@ -0,0 +1,28 @@
+package rolemapping
+
+type List []*RoleMapping
+
+func (l List) IDs() IDList {
+	if l == nil {
+		return nil
+	}
+	ids := make(IDList, 0, len(l))
+	for _, m := range l {
+		if m != nil {
+			ids = append(ids, m.ID())
+		}
+	}
+	return ids
+}
+
+// Applying returns the mappings of the identity provider that issued a token
+// with iss and sub.
+func (l List) Applying(iss, sub string) List {
+	var res List
+	for _, m := range l {
+		if m.Applies(iss, sub) {
+			res = append(res, m)
+		}
+	}
+	return res
+}
@@ -0,0 +1,153 @@
+package rolemapping
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+)
+
+var (
+	ErrInvalidWorkspace = errors.New("role mapping workspace can't be empty")
+	ErrEmptyIssuer      = errors.New("role mapping issuer can't be empty")
+	ErrInvalidSubPrefix = errors.New("role mapping sub prefix can't end with '|'")
+	ErrInvalidRule      = errors.New("role mapping rule must have a claim and a value")
+	ErrInvalidRole      = errors.New("invalid role mapping role")
+)
+
+// RoleMapping decides the membership of a workspace from the claims of the
+// tokens of its identity provider. The user signed in with such a token is a
+// member of the workspace as long as one of the rules matches, with the
+// highest role of the matching rules.
+type RoleMapping struct {
+	id        ID
+	workspace workspace.ID
+	issuer    string
+	subPrefix string
+	rules     []Rule
+	updatedAt time.Time
+}
+
+// Rule grants Role to the users whose token has Value in the claim Claim,
+// e.g. "groups" containing "gis-editors".
+type Rule struct {
+	Claim string
+	Value string
+	Role  role.RoleType
+}
+
+func (m *RoleMapping) ID() ID {
+	if m == nil {
+		return ID{}
+	}
+	return m.id
+}
+
+func (m *RoleMapping) Workspace() workspace.ID {
+	if m == nil {
+		return workspace.ID{}
+	}
+	return m.workspace
+}
+
+// Issuer is the iss of the tokens of the identity provider.
+func (m *RoleMapping) Issuer() string {
+	if m == nil {
+		return ""
+	}
+	return m.issuer
+}
+
+// SubPrefix narrows the identity provider down to the connection whose auth
+// subs start with it, e.g. "samlp|org_123" when several SSO connections share
+// the issuer. Empty means every token of the issuer.
+func (m *RoleMapping) SubPrefix() string {
+	if m == nil {
+		return ""
+	}
+	return m.subPrefix
+}
+
+func (m *RoleMapping) Rules() []Rule {
+	if m == nil {
+		return nil
+	}
+	return slices.Clone(m.rules)
+}
+
+func (m *RoleMapping) UpdatedAt() time.Time {
+	if m == nil {
+		return time.Time{}
+	}
+	return m.updatedAt
+}
+
+// Applies reports whether a token with iss and sub was issued by the identity
+// provider of the workspace.
+func (m *RoleMapping) Applies(iss, sub string) bool {
+	if m == nil || iss == "" || iss != m.issuer {
+		return false
+	}
+	return m.subPrefix == "" || strings.HasPrefix(sub, m.subPrefix+"|")
+}
+
+// Evaluate returns the highest role of the rules matching claims, or false if
+// none of them matches.
+func (m *RoleMapping) Evaluate(claims map[string]any) (role.RoleType, bool) {
+	if m == nil {
+		return "", false
+	}
+	var res role.RoleType
+	for _, r := range m.rules {
+		if slices.Contains(claimValues(claims, r.Claim), r.Value) {
+			res = role.Higher(r.Role, res)
+		}
+	}
+	return res, res != ""
+}
+
+// claimValues reads a claim as a list of strings, following dots into nested
+// objects so that claims such as "realm_access.roles" can be mapped.
+func claimValues(claims map[string]any, name string) []string {
+	v, ok := lookup(claims, name)
+	if !ok {
+		return nil
+	}
+	switch v := v.(type) {
+	case []any:
+		res := make([]string, 0, len(v))
+		for _, e := range v {
+			res = append(res, claimString(e))
+		}
+		return res
+	case []string:
+		return v
+	}
+	return []string{claimString(v)}
+}
+
+func claimString(v any) string {
+	if s, ok := v.(string); ok {
+		return s
+	}
+	return fmt.Sprint(v)
+}
+
+func lookup(claims map[string]any, name string) (any, bool) {
+	if v, ok := claims[name]; ok {
+		return v, true
+	}
+	head, rest, found := strings.Cut(name, ".")
+	if !found {
+		return nil, false
+	}
+	nested, ok := claims[head].(map[string]any)
+	if !ok {
+		return nil, false
+	}
+	return lookup(nested, rest)
+}
@@ -0,0 +1,136 @@
+package rolemapping
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	wid := workspace.NewID()
+	tests := []struct {
+		name    string
+		b       *Builder
+		wantErr error
+	}{
+		{
+			name: "ok",
+			b: New().NewID().Workspace(wid).Issuer("https://idp.example.com/").
+				Rules([]Rule{{Claim: "groups", Value: "editors", Role: role.RoleWriter}}),
+		},
+		{
+			name:    "no id",
+			b:       New().Workspace(wid).Issuer("https://idp.example.com/"),
+			wantErr: ErrInvalidID,
+		},
+		{
+			name:    "no workspace",
+			b:       New().NewID().Issuer("https://idp.example.com/"),
+			wantErr: ErrInvalidWorkspace,
+		},
+		{
+			name:    "no issuer",
+			b:       New().NewID().Workspace(wid),
+			wantErr: ErrEmptyIssuer,
+		},
+		{
+			name:    "sub prefix ending with a separator",
+			b:       New().NewID().Workspace(wid).Issuer("https://idp.example.com/").SubPrefix("samlp|"),
+			wantErr: ErrInvalidSubPrefix,
+		},
+		{
+			name: "rule without value",
+			b: New().NewID().Workspace(wid).Issuer("https://idp.example.com/").
+				Rules([]Rule{{Claim: "groups", Role: role.RoleWriter}}),
+			wantErr: ErrInvalidRule,
+		},
+		{
+			name: "self role",
+			b: New().NewID().Workspace(wid).Issuer("https://idp.example.com/").
+				Rules([]Rule{{Claim: "groups", Value: "all", Role: role.RoleSelf}}),
+			wantErr: ErrInvalidRole,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := tt.b.Build()
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.False(t, m.UpdatedAt().IsZero())
+		})
+	}
+}
+
+func TestRoleMapping_Applies(t *testing.T) {
+	m := New().NewID().Workspace(workspace.NewID()).Issuer("https://example.auth0.com/").SubPrefix("samlp|org_1").MustBuild()
+
+	assert.True(t, m.Applies("https://example.auth0.com/", "samlp|org_1|alice@example.com"))
+	assert.False(t, m.Applies("https://example.auth0.com/", "samlp|org_10|alice@example.com"))
+	assert.False(t, m.Applies("https://example.auth0.com/", "auth0|1"))
+	assert.False(t, m.Applies("https://other.example.com/", "samlp|org_1|alice@example.com"))
+
+	all := New().NewID().Workspace(workspace.NewID()).Issuer("https://example.auth0.com/").MustBuild()
+	assert.True(t, all.Applies("https://example.auth0.com/", "auth0|1"))
+	assert.False(t, all.Applies("", "auth0|1"))
+}
+
+func TestRoleMapping_Evaluate(t *testing.T) {
+	m := New().NewID().Workspace(workspace.NewID()).Issuer("https://idp.example.com/").Rules([]Rule{
+		{Claim: "groups", Value: "gis-viewers", Role: role.RoleReader},
+		{Claim: "groups", Value: "gis-admins", Role: role.RoleMaintainer},
+		{Claim: "realm_access.roles", Value: "editor", Role: role.RoleWriter},
+		{Claim: "department", Value: "gis", Role: role.RoleReader},
+	}).MustBuild()
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+		want   role.RoleType
+		wantOK bool
+	}{
+		{
+			name:   "no claims",
+			claims: nil,
+		},
+		{
+			name:   "no matching group",
+			claims: map[string]any{"groups": []any{"sales"}},
+		},
+		{
+			name:   "single group",
+			claims: map[string]any{"groups": []any{"sales", "gis-viewers"}},
+			want:   role.RoleReader,
+			wantOK: true,
+		},
+		{
+			name:   "highest of several matches",
+			claims: map[string]any{"groups": []any{"gis-viewers", "gis-admins"}, "department": "gis"},
+			want:   role.RoleMaintainer,
+			wantOK: true,
+		},
+		{
+			name:   "nested claim",
+			claims: map[string]any{"realm_access": map[string]any{"roles": []any{"editor"}}},
+			want:   role.RoleWriter,
+			wantOK: true,
+		},
+		{
+			name:   "string claim",
+			claims: map[string]any{"department": "gis"},
+			want:   role.RoleReader,
+			wantOK: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := m.Evaluate(tt.claims)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: ./repo.go
+//
+// Generated by this command:
+//
+//	mockgen -source=./repo.go -destination=./mock_rolemapping.go -package rolemapping
+//
+
+// Package rolemapping is a generated GoMock package.
+package rolemapping
+
+import (
+	context "context"
+	reflect "reflect"
+
+	workspace "github.com/reearth/reearth-accounts/server/pkg/workspace"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRepo is a mock of Repo interface.
+type MockRepo struct {
+	ctrl     *gomock.Controller
+	recorder *MockRepoMockRecorder
+	isgomock struct{}
+}
+
+// MockRepoMockRecorder is the mock recorder for MockRepo.
+type MockRepoMockRecorder struct {
+	mock *MockRepo
+}
+
+// NewMockRepo creates a new mock instance.
+func NewMockRepo(ctrl *gomock.Controller) *MockRepo {
+	mock := &MockRepo{ctrl: ctrl}
+	mock.recorder = &MockRepoMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRepo) EXPECT() *MockRepoMockRecorder {
+	return m.recorder
+}
+
+// FindAll mocks base method.
+func (m *MockRepo) FindAll(arg0 context.Context) (List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindAll", arg0)
+	ret0, _ := ret[0].(List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindAll indicates an expected call of FindAll.
+func (mr *MockRepoMockRecorder) FindAll(arg0 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindAll", reflect.TypeOf((*MockRepo)(nil).FindAll), arg0)
+}
+
+// FindByIssuer mocks base method.
+func (m *MockRepo) FindByIssuer(arg0 context.Context, arg1 string) (List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByIssuer", arg0, arg1)
+	ret0, _ := ret[0].(List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByIssuer indicates an expected call of FindByIssuer.
+func (mr *MockRepoMockRecorder) FindByIssuer(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByIssuer", reflect.TypeOf((*MockRepo)(nil).FindByIssuer), arg0, arg1)
+}
+
+// FindByWorkspace mocks base method.
+func (m *MockRepo) FindByWorkspace(arg0 context.Context, arg1 workspace.ID) (*RoleMapping, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindByWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(*RoleMapping)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindByWorkspace indicates an expected call of FindByWorkspace.
+func (mr *MockRepoMockRecorder) FindByWorkspace(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByWorkspace", reflect.TypeOf((*MockRepo)(nil).FindByWorkspace), arg0, arg1)
+}
+
+// RemoveByWorkspace mocks base method.
+func (m *MockRepo) RemoveByWorkspace(arg0 context.Context, arg1 workspace.ID) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveByWorkspace", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveByWorkspace indicates an expected call of RemoveByWorkspace.
+func (mr *MockRepoMockRecorder) RemoveByWorkspace(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveByWorkspace", reflect.TypeOf((*MockRepo)(nil).RemoveByWorkspace), arg0, arg1)
+}
+
+// Save mocks base method.
+func (m *MockRepo) Save(arg0 context.Context, arg1 *RoleMapping) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Save indicates an expected call of Save.
+func (mr *MockRepoMockRecorder) Save(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockRepo)(nil).Save), arg0, arg1)
+}
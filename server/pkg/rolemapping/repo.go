@@ -0,0 +1,18 @@
+package rolemapping
+
+import (
+	"context"
+
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+)
+
+//go:generate mockgen -source=./repo.go -destination=./mock_rolemapping.go -package rolemapping
+type Repo interface {
+	FindAll(context.Context) (List, error)
+	// FindByWorkspace returns the mapping of the workspace, or
+	// rerror.ErrNotFound.
+	FindByWorkspace(context.Context, workspace.ID) (*RoleMapping, error)
+	FindByIssuer(context.Context, string) (List, error)
+	Save(context.Context, *RoleMapping) error
+	RemoveByWorkspace(context.Context, workspace.ID) error
+}
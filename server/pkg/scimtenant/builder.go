@@ -0,0 +1,95 @@
+package scimtenant
+
+import (
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+)
+
+type Builder struct {
+	t *Tenant
+}
+
+func New() *Builder {
+	return &Builder{t: &Tenant{}}
+}
+
+func (b *Builder) Build() (*Tenant, error) {
+	if b.t.id.IsNil() {
+		return nil, ErrInvalidID
+	}
+	if strings.TrimSpace(b.t.name) == "" {
+		return nil, ErrEmptyName
+	}
+	// Default to a prefix unique to the tenant, so that provisioned users can
+	// only be matched by users the tenant itself provisioned.
+	if b.t.subPrefix == "" {
+		b.t.subPrefix = "scim|" + b.t.id.String()
+	}
+	if strings.HasSuffix(b.t.subPrefix, "|") {
+		return nil, ErrInvalidSubPrefix
+	}
+	for _, g := range b.t.groups {
+		if !g.Role.Valid() {
+			return nil, ErrInvalidRole
+		}
+	}
+	if b.t.updatedAt.IsZero() {
+		b.t.updatedAt = time.Now()
+	}
+	return b.t, nil
+}
+
+func (b *Builder) MustBuild() *Tenant {
+	t, err := b.Build()
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func (b *Builder) ID(id ID) *Builder {
+	b.t.id = id
+	return b
+}
+
+func (b *Builder) NewID() *Builder {
+	b.t.id = NewID()
+	return b
+}
+
+func (b *Builder) Name(name string) *Builder {
+	b.t.name = name
+	return b
+}
+
+func (b *Builder) SubPrefix(subPrefix string) *Builder {
+	b.t.subPrefix = subPrefix
+	return b
+}
+
+func (b *Builder) TokenHash(tokenHash string) *Builder {
+	b.t.tokenHash = tokenHash
+	return b
+}
+
+func (b *Builder) Users(users user.IDList) *Builder {
+	b.t.users = users.Clone()
+	return b
+}
+
+func (b *Builder) Deprovisioned(users user.IDList) *Builder {
+	b.t.deprovisioned = users.Clone()
+	return b
+}
+
+func (b *Builder) Groups(groups []Group) *Builder {
+	b.t.groups = append([]Group(nil), groups...)
+	return b
+}
+
+func (b *Builder) UpdatedAt(updatedAt time.Time) *Builder {
+	b.t.updatedAt = updatedAt
+	return b
+}
@@ -0,0 +1,18 @@
+package scimtenant
+
+import (
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+)
+
+type ID = id.SCIMTenantID
+type IDList = id.SCIMTenantIDList
+
+var NewID = id.NewSCIMTenantID
+
+var MustID = id.MustSCIMTenantID
+
+var IDFrom = id.SCIMTenantIDFrom
+
+var IDFromRef = id.SCIMTenantIDFromRef
+
+var ErrInvalidID = id.ErrInvalidID
@@ -0,0 +1,16 @@
+package scimtenant
+
+type List []*Tenant
+
+func (l List) IDs() IDList {
+	if l == nil {
+		return nil
+	}
+	ids := make(IDList, 0, len(l))
+	for _, t := range l {
+		if t != nil {
+			ids = append(ids, t.ID())
+		}
+	}
+	return ids
+}
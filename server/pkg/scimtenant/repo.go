@@ -0,0 +1,16 @@
+package scimtenant
+
+import (
+	"context"
+)
+
+//go:generate mockgen -source=./repo.go -destination=./mock_scimtenant.go -package scimtenant
+type Repo interface {
+	FindAll(context.Context) (List, error)
+	FindByID(context.Context, ID) (*Tenant, error)
+	// FindByTokenHash returns the tenant whose bearer token hashes to the given
+	// value, or rerror.ErrNotFound.
+	FindByTokenHash(context.Context, string) (*Tenant, error)
+	Save(context.Context, *Tenant) error
+	Remove(context.Context, ID) error
+}
@@ -0,0 +1,256 @@
+package scimtenant
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/reearth/reearth-accounts/server/pkg/workspace"
+)
+
+var (
+	ErrEmptyName        = errors.New("scim tenant name can't be empty")
+	ErrInvalidSubPrefix = errors.New("scim tenant sub prefix can't be empty or end with '|'")
+	// ErrReservedSubPrefix is returned for a sub prefix that overlaps with the
+	// subs of another provider, whose users the tenant could then take over.
+	ErrReservedSubPrefix = errors.New("scim tenant sub prefix is reserved for another provider")
+	ErrInvalidRole       = errors.New("invalid scim group role")
+)
+
+// Tenant is an identity provider (e.g. Okta or Entra ID) that provisions users
+// and workspaces through the SCIM API. Each tenant authenticates with its own
+// bearer token and can only see the users and groups it has provisioned.
+type Tenant struct {
+	groups    []Group
+	id        ID
+	name      string
+	subPrefix string
+	tokenHash string // hex SHA-256 of the bearer token
+	updatedAt time.Time
+	users     user.IDList
+	// deprovisioned are the users the tenant provisioned and then deleted. The
+	// tenant no longer sees them, but provisioning the same userName again
+	// takes them back.
+	deprovisioned user.IDList
+}
+
+// Group is a workspace provisioned by the tenant. Every member of the
+// workspace holds Role.
+type Group struct {
+	Workspace workspace.ID
+	Role      role.RoleType
+}
+
+func (t *Tenant) ID() ID {
+	if t == nil {
+		return ID{}
+	}
+	return t.id
+}
+
+// CreatedAt is derived from the ULID-based ID, which embeds its creation time.
+func (t *Tenant) CreatedAt() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.id.Timestamp()
+}
+
+func (t *Tenant) Name() string {
+	if t == nil {
+		return ""
+	}
+	return t.name
+}
+
+// SubPrefix is prepended to the SCIM userName to form the auth sub of a
+// provisioned user, e.g. "samlp|okta" so that the user provisioned as
+// "alice@example.com" is the same user who later signs in via SSO as
+// "samlp|okta|alice@example.com".
+func (t *Tenant) SubPrefix() string {
+	if t == nil {
+		return ""
+	}
+	return t.subPrefix
+}
+
+func (t *Tenant) TokenHash() string {
+	if t == nil {
+		return ""
+	}
+	return t.tokenHash
+}
+
+func (t *Tenant) UpdatedAt() time.Time {
+	if t == nil {
+		return time.Time{}
+	}
+	return t.updatedAt
+}
+
+func (t *Tenant) Users() user.IDList {
+	if t == nil {
+		return nil
+	}
+	return t.users.Clone()
+}
+
+func (t *Tenant) Deprovisioned() user.IDList {
+	if t == nil {
+		return nil
+	}
+	return t.deprovisioned.Clone()
+}
+
+func (t *Tenant) Groups() []Group {
+	if t == nil {
+		return nil
+	}
+	return slices.Clone(t.groups)
+}
+
+// Sub returns the auth sub of the user provisioned with userName.
+func (t *Tenant) Sub(userName string) string {
+	return t.SubPrefix() + "|" + userName
+}
+
+// UserName returns the SCIM userName of a provisioned user from its auths, or
+// an empty string if none of them was issued by the tenant.
+func (t *Tenant) UserName(auths user.Auths) string {
+	p := t.SubPrefix() + "|"
+	for _, a := range auths {
+		if name, ok := strings.CutPrefix(a.Sub, p); ok {
+			return name
+		}
+	}
+	return ""
+}
+
+// Authenticate reports whether token is the bearer token of the tenant.
+func (t *Tenant) Authenticate(token string) bool {
+	if t == nil || t.tokenHash == "" || token == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(HashToken(token)), []byte(t.tokenHash)) == 1
+}
+
+// RotateToken replaces the bearer token and returns the new one. The previous
+// token stops working as soon as the tenant is saved.
+func (t *Tenant) RotateToken() (string, error) {
+	token, err := NewToken()
+	if err != nil {
+		return "", err
+	}
+	t.tokenHash = HashToken(token)
+	t.updatedAt = time.Now()
+	return token, nil
+}
+
+func (t *Tenant) HasUser(u user.ID) bool {
+	return t != nil && t.users.Has(u)
+}
+
+// Provisioned reports whether the user was provisioned by the tenant, even if
+// it has since been deleted. Other users must not be taken over by the tenant.
+func (t *Tenant) Provisioned(u user.ID) bool {
+	return t != nil && (t.users.Has(u) || t.deprovisioned.Has(u))
+}
+
+func (t *Tenant) AddUser(u user.ID) {
+	if t == nil || t.users.Has(u) {
+		return
+	}
+	t.users = append(t.users, u)
+	t.deprovisioned = t.deprovisioned.Delete(u)
+	t.updatedAt = time.Now()
+}
+
+// RemoveUser hides the user from the tenant, remembering that it provisioned
+// it.
+func (t *Tenant) RemoveUser(u user.ID) {
+	if t == nil || !t.users.Has(u) {
+		return
+	}
+	t.users = t.users.Delete(u)
+	if !t.deprovisioned.Has(u) {
+		t.deprovisioned = append(t.deprovisioned, u)
+	}
+	t.updatedAt = time.Now()
+}
+
+func (t *Tenant) Group(ws workspace.ID) *Group {
+	if t == nil {
+		return nil
+	}
+	for _, g := range t.groups {
+		if g.Workspace == ws {
+			return &g
+		}
+	}
+	return nil
+}
+
+// SetGroup adds the workspace to the groups of the tenant or updates its role.
+// An empty role means reader. Owner is refused, as a provisioned workspace has
+// no owner.
+func (t *Tenant) SetGroup(ws workspace.ID, r role.RoleType) error {
+	if t == nil {
+		return nil
+	}
+	if r == "" {
+		r = role.RoleReader
+	}
+	if !r.Valid() || r == role.RoleOwner || r == role.RoleSelf {
+		return ErrInvalidRole
+	}
+	t.updatedAt = time.Now()
+	for i, g := range t.groups {
+		if g.Workspace == ws {
+			t.groups[i].Role = r
+			return nil
+		}
+	}
+	t.groups = append(t.groups, Group{Workspace: ws, Role: r})
+	return nil
+}
+
+func (t *Tenant) RemoveGroup(ws workspace.ID) {
+	if t == nil {
+		return
+	}
+	n := slices.DeleteFunc(t.groups, func(g Group) bool { return g.Workspace == ws })
+	if len(n) != len(t.groups) {
+		t.updatedAt = time.Now()
+	}
+	t.groups = n
+}
+
+// SubPrefixesOverlap reports whether a sub could start with both prefixes, e.g.
+// "samlp|okta" and "samlp|okta|eu". A provider name is a prefix too.
+func SubPrefixesOverlap(a, b string) bool {
+	return a == b || strings.HasPrefix(a, b+"|") || strings.HasPrefix(b, a+"|")
+}
+
+// NewToken generates a random bearer token.
+func NewToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// HashToken returns the value stored for a bearer token. Tokens are random and
+// long, so a plain SHA-256 is enough and allows looking the tenant up by it.
+func HashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,102 @@
+package scimtenant
+
+import (
+	"testing"
+
+	"github.com/reearth/reearth-accounts/server/pkg/id"
+	"github.com/reearth/reearth-accounts/server/pkg/role"
+	"github.com/reearth/reearth-accounts/server/pkg/user"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuilder_Build(t *testing.T) {
+	tid := NewID()
+
+	tn, err := New().ID(tid).Name("okta").Build()
+	require.NoError(t, err)
+	assert.Equal(t, "scim|"+tid.String(), tn.SubPrefix())
+	assert.False(t, tn.UpdatedAt().IsZero())
+
+	_, err = New().Name("okta").Build()
+	assert.ErrorIs(t, err, ErrInvalidID)
+	_, err = New().NewID().Name(" ").Build()
+	assert.ErrorIs(t, err, ErrEmptyName)
+	_, err = New().NewID().Name("okta").SubPrefix("samlp|").Build()
+	assert.ErrorIs(t, err, ErrInvalidSubPrefix)
+	_, err = New().NewID().Name("okta").Groups([]Group{{Workspace: id.NewWorkspaceID(), Role: "x"}}).Build()
+	assert.ErrorIs(t, err, ErrInvalidRole)
+}
+
+func TestTenant_Token(t *testing.T) {
+	tn := New().NewID().Name("okta").MustBuild()
+	assert.False(t, tn.Authenticate(""))
+
+	token, err := tn.RotateToken()
+	require.NoError(t, err)
+	assert.Equal(t, HashToken(token), tn.TokenHash())
+	assert.True(t, tn.Authenticate(token))
+	assert.False(t, tn.Authenticate(token+"x"))
+
+	next, err := tn.RotateToken()
+	require.NoError(t, err)
+	assert.NotEqual(t, token, next)
+	assert.False(t, tn.Authenticate(token))
+	assert.True(t, tn.Authenticate(next))
+}
+
+func TestTenant_UserName(t *testing.T) {
+	tn := New().NewID().Name("okta").SubPrefix("samlp|okta").MustBuild()
+	assert.Equal(t, "samlp|okta|alice@example.com", tn.Sub("alice@example.com"))
+	assert.Equal(t, "alice@example.com", tn.UserName(user.Auths{
+		user.AuthFrom("auth0|123"),
+		user.AuthFrom("samlp|okta|alice@example.com"),
+	}))
+	assert.Empty(t, tn.UserName(user.Auths{user.AuthFrom("samlp|oktaa|alice@example.com")}))
+}
+
+func TestTenant_Users(t *testing.T) {
+	tn := New().NewID().Name("okta").MustBuild()
+	u1, u2 := id.NewUserID(), id.NewUserID()
+
+	tn.AddUser(u1)
+	tn.AddUser(u1)
+	tn.AddUser(u2)
+	assert.Equal(t, user.IDList{u1, u2}, tn.Users())
+
+	tn.RemoveUser(u1)
+	assert.False(t, tn.HasUser(u1))
+	assert.True(t, tn.HasUser(u2))
+	assert.True(t, tn.Provisioned(u1))
+	assert.Equal(t, user.IDList{u1}, tn.Deprovisioned())
+	assert.False(t, tn.Provisioned(id.NewUserID()))
+
+	tn.AddUser(u1)
+	assert.True(t, tn.HasUser(u1))
+	assert.Empty(t, tn.Deprovisioned())
+}
+
+func TestSubPrefixesOverlap(t *testing.T) {
+	assert.True(t, SubPrefixesOverlap("samlp|okta", "samlp|okta"))
+	assert.True(t, SubPrefixesOverlap("samlp|okta", "samlp|okta|eu"))
+	assert.True(t, SubPrefixesOverlap("auth0", "auth0|okta"))
+	assert.False(t, SubPrefixesOverlap("samlp|okta", "samlp|oktaa"))
+	assert.False(t, SubPrefixesOverlap("samlp|okta", "samlp|entra"))
+}
+
+func TestTenant_Groups(t *testing.T) {
+	tn := New().NewID().Name("okta").MustBuild()
+	ws := id.NewWorkspaceID()
+
+	require.NoError(t, tn.SetGroup(ws, ""))
+	assert.Equal(t, &Group{Workspace: ws, Role: role.RoleReader}, tn.Group(ws))
+
+	require.NoError(t, tn.SetGroup(ws, role.RoleWriter))
+	assert.Equal(t, []Group{{Workspace: ws, Role: role.RoleWriter}}, tn.Groups())
+
+	assert.ErrorIs(t, tn.SetGroup(ws, role.RoleOwner), ErrInvalidRole)
+
+	tn.RemoveGroup(ws)
+	assert.Nil(t, tn.Group(ws))
+	assert.Empty(t, tn.Groups())
+}
@@ -25,6 +25,15 @@ func AuthFrom(sub string) Auth {
 	return Auth{Provider: s[0], Sub: sub}
 }
 
+// NewAuth builds the auth of an external provider, whose sub is prefixed with
+// the provider name.
+func NewAuth(provider, sub string) Auth {
+	return Auth{
+		Provider: provider,
+		Sub:      provider + "|" + sub,
+	}
+}
+
 func NewReearthAuth(sub string) Auth {
 	return Auth{
 		Provider: ProviderReearth,
@@ -0,0 +1,132 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"slices"
+	"time"
+
+	"github.com/reearth/reearthx/util"
+)
+
+// authCodeTTL is kept short because the code is exchanged by the client right
+// after the redirect.
+const authCodeTTL = time.Minute
+
+// maxAuthCodes bounds the codes a user can have pending at once; issuing one
+// more drops the oldest.
+const maxAuthCodes = 10
+
+// AuthCode is a pending OAuth 2.0 authorization code issued by the built-in
+// OIDC provider. Only the SHA-256 hash of the code is stored, and PKCE with
+// S256 is mandatory, so CodeChallenge is always set.
+type AuthCode struct {
+	CodeHash      string
+	ClientID      string
+	RedirectURI   string
+	Scopes        []string
+	Nonce         string
+	CodeChallenge string
+	AuthTime      time.Time
+	CreatedAt     time.Time
+	ExpiresAt     time.Time
+}
+
+// IssueAuthCode binds a fresh code to the authorization request req and
+// returns it with the plain code to be sent to the client.
+func IssueAuthCode(req AuthCode) (*AuthCode, string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	code := base64.RawURLEncoding.EncodeToString(b)
+	c := req.Clone()
+	c.CodeHash = hashAuthCode(code)
+	c.CreatedAt = util.Now()
+	c.ExpiresAt = c.CreatedAt.Add(authCodeTTL)
+	return c, code, nil
+}
+
+// Redeem reports whether code was issued for this client and redirect URI, has
+// not expired, and matches the PKCE verifier.
+func (c *AuthCode) Redeem(code, clientID, redirectURI, verifier string) bool {
+	if c == nil || c.CodeHash == "" || code == "" || verifier == "" {
+		return false
+	}
+	if !c.ExpiresAt.After(util.Now()) {
+		return false
+	}
+	if c.ClientID != clientID || c.RedirectURI != redirectURI {
+		return false
+	}
+	if subtle.ConstantTimeCompare([]byte(c.CodeHash), []byte(hashAuthCode(code))) != 1 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	challenge := base64.RawURLEncoding.EncodeToString(sum[:])
+	return subtle.ConstantTimeCompare([]byte(c.CodeChallenge), []byte(challenge)) == 1
+}
+
+func (c *AuthCode) Clone() *AuthCode {
+	if c == nil {
+		return nil
+	}
+	c2 := *c
+	c2.Scopes = slices.Clone(c.Scopes)
+	return &c2
+}
+
+// AuthCodes returns the pending authorization codes.
+func (u *User) AuthCodes() []AuthCode {
+	if u == nil || len(u.authCodes) == 0 {
+		return nil
+	}
+	res := make([]AuthCode, 0, len(u.authCodes))
+	for _, c := range u.authCodes {
+		res = append(res, *c.Clone())
+	}
+	return res
+}
+
+// AddAuthCode keeps c pending next to the other codes of the user, so sign-ins
+// in several browsers or clients at once don't cancel each other. Expired
+// codes are dropped.
+func (u *User) AddAuthCode(c *AuthCode) {
+	u.pruneAuthCodes(util.Now())
+	if len(u.authCodes) >= maxAuthCodes {
+		u.authCodes = slices.Delete(u.authCodes, 0, len(u.authCodes)-maxAuthCodes+1)
+	}
+	u.authCodes = append(u.authCodes, *c.Clone())
+	u.updatedAt = time.Now()
+}
+
+// RedeemAuthCode looks up the pending code by its hash and removes it, so a
+// code can be tried only once. It returns the code if Redeem accepts it, or
+// nil.
+func (u *User) RedeemAuthCode(code, clientID, redirectURI, verifier string) *AuthCode {
+	u.pruneAuthCodes(util.Now())
+	h := hashAuthCode(code)
+	i := slices.IndexFunc(u.authCodes, func(c AuthCode) bool { return c.CodeHash == h })
+	if i < 0 {
+		return nil
+	}
+	c := u.authCodes[i].Clone()
+	u.authCodes = slices.Delete(u.authCodes, i, i+1)
+	u.updatedAt = time.Now()
+	if !c.Redeem(code, clientID, redirectURI, verifier) {
+		return nil
+	}
+	return c
+}
+
+func (u *User) pruneAuthCodes(now time.Time) {
+	u.authCodes = slices.DeleteFunc(u.authCodes, func(c AuthCode) bool {
+		return !c.ExpiresAt.After(now)
+	})
+}
+
+func hashAuthCode(code string) string {
+	return hashMagicLinkToken(code)
+}
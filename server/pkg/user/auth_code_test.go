@@ -0,0 +1,86 @@
+package user
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuthCode_Redeem(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	sum := sha256.Sum256([]byte(verifier))
+	c, code, err := IssueAuthCode(AuthCode{
+		ClientID:      "client",
+		RedirectURI:   "https://app.example.com/callback",
+		Scopes:        []string{"openid"},
+		CodeChallenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	})
+	require.NoError(t, err)
+	assert.NotEqual(t, code, c.CodeHash)
+	assert.Equal(t, now, c.CreatedAt)
+
+	assert.True(t, c.Redeem(code, "client", "https://app.example.com/callback", verifier))
+	assert.False(t, c.Redeem("wrong", "client", "https://app.example.com/callback", verifier))
+	assert.False(t, c.Redeem(code, "other", "https://app.example.com/callback", verifier))
+	assert.False(t, c.Redeem(code, "client", "https://evil.example.com/callback", verifier))
+	assert.False(t, c.Redeem(code, "client", "https://app.example.com/callback", "wrong-verifier"))
+	assert.False(t, (*AuthCode)(nil).Redeem(code, "client", "https://app.example.com/callback", verifier))
+
+	defer util.MockNow(now.Add(authCodeTTL))()
+	assert.False(t, c.Redeem(code, "client", "https://app.example.com/callback", verifier))
+}
+
+func TestUser_RedeemAuthCode(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	verifier := "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk"
+	sum := sha256.Sum256([]byte(verifier))
+	req := AuthCode{
+		ClientID:      "client",
+		RedirectURI:   "https://app.example.com/callback",
+		CodeChallenge: base64.RawURLEncoding.EncodeToString(sum[:]),
+	}
+	u := &User{}
+	c1, code1, err := IssueAuthCode(req)
+	require.NoError(t, err)
+	u.AddAuthCode(c1)
+	c2, code2, err := IssueAuthCode(req)
+	require.NoError(t, err)
+	u.AddAuthCode(c2)
+
+	// a second sign-in does not cancel the first
+	assert.Equal(t, c1, u.RedeemAuthCode(code1, "client", "https://app.example.com/callback", verifier))
+	assert.Nil(t, u.RedeemAuthCode(code1, "client", "https://app.example.com/callback", verifier))
+
+	// a code is removed by a failed attempt as well
+	assert.Nil(t, u.RedeemAuthCode(code2, "client", "https://app.example.com/callback", "wrong-verifier"))
+	assert.Nil(t, u.RedeemAuthCode(code2, "client", "https://app.example.com/callback", verifier))
+	assert.Empty(t, u.AuthCodes())
+
+	// expired codes are dropped and the oldest is dropped beyond the limit
+	expired, _, err := IssueAuthCode(req)
+	require.NoError(t, err)
+	u.AddAuthCode(expired)
+	defer util.MockNow(now.Add(authCodeTTL))()
+	var first string
+	for i := 0; i <= maxAuthCodes; i++ {
+		c, code, err := IssueAuthCode(req)
+		require.NoError(t, err)
+		u.AddAuthCode(c)
+		if i == 0 {
+			first = code
+		}
+	}
+	assert.Len(t, u.AuthCodes(), maxAuthCodes)
+	assert.NotContains(t, u.AuthCodes(), *expired)
+	assert.Nil(t, u.RedeemAuthCode(first, "client", "https://app.example.com/callback", verifier))
+}
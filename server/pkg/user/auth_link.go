@@ -0,0 +1,64 @@
+package user
+
+import (
+	"slices"
+	"time"
+
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+var (
+	ErrAuthProviderAlreadyLinked = rerror.NewE(i18n.T("an identity of this provider is already linked"))
+	ErrLastAuth                  = rerror.NewE(i18n.T("the last sign-in method can't be removed"))
+)
+
+// AuthLink records how an auth was linked to an existing user, so that users
+// can tell their sign-in methods apart. Auths the user signed up with have no
+// link.
+type AuthLink struct {
+	Sub      string
+	Issuer   string
+	Email    string
+	LinkedAt time.Time
+}
+
+func (u *User) AuthLinks() []AuthLink {
+	if u == nil || len(u.authLinks) == 0 {
+		return nil
+	}
+	return slices.Clone(u.authLinks)
+}
+
+func (u *User) AuthLink(sub string) *AuthLink {
+	if u == nil {
+		return nil
+	}
+	for _, l := range u.authLinks {
+		if l.Sub == sub {
+			l2 := l
+			return &l2
+		}
+	}
+	return nil
+}
+
+// LinkAuth adds an auth of another provider to the user. A user holds at most
+// one auth per provider.
+func (u *User) LinkAuth(a Auth, l AuthLink) error {
+	if u.HasAuthProvider(a.Provider) {
+		return ErrAuthProviderAlreadyLinked
+	}
+	l.Sub = a.Sub
+	u.auths = append(u.auths, a)
+	u.authLinks = append(u.authLinks, l)
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// pruneAuthLinks drops the links of auths the user no longer holds.
+func (u *User) pruneAuthLinks() {
+	u.authLinks = slices.DeleteFunc(u.authLinks, func(l AuthLink) bool {
+		return !Auths(u.auths).Has(l.Sub)
+	})
+}
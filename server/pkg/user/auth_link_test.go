@@ -0,0 +1,25 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_LinkAuth(t *testing.T) {
+	u := New().NewID().Name("alice").Email("a@example.com").Workspace(NewWorkspaceID()).
+		Auths([]Auth{AuthFrom("auth0|a")}).MustBuild()
+	now := time.Now().UTC().Truncate(time.Second)
+
+	require.NoError(t, u.LinkAuth(AuthFrom("keycloak|b"), AuthLink{Issuer: "https://idp.example.com", Email: "a@example.com", LinkedAt: now}))
+	assert.Equal(t, Auths{AuthFrom("auth0|a"), AuthFrom("keycloak|b")}, u.Auths())
+	assert.Equal(t, &AuthLink{Sub: "keycloak|b", Issuer: "https://idp.example.com", Email: "a@example.com", LinkedAt: now}, u.AuthLink("keycloak|b"))
+	assert.Nil(t, u.AuthLink("auth0|a"))
+
+	assert.ErrorIs(t, u.LinkAuth(AuthFrom("keycloak|c"), AuthLink{}), ErrAuthProviderAlreadyLinked)
+
+	assert.True(t, u.RemoveAuthByProvider("keycloak"))
+	assert.Empty(t, u.AuthLinks())
+}
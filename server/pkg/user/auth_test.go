@@ -24,6 +24,13 @@ func TestAuthFrom(t *testing.T) {
 	}, AuthFrom(""))
 }
 
+func TestNewAuth(t *testing.T) {
+	assert.Equal(t, Auth{
+		Provider: "keycloak",
+		Sub:      "keycloak|xx",
+	}, NewAuth("keycloak", "xx"))
+}
+
 func TestNewReearthAuth(t *testing.T) {
 	assert.Equal(t, Auth{
 		Provider: "reearth",
@@ -0,0 +1,95 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"slices"
+	"time"
+
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+const (
+	magicLinkTTL        = 15 * time.Minute
+	magicLinkRateWindow = time.Hour
+	magicLinkRateLimit  = 5
+)
+
+var ErrMagicLinkRateLimited = rerror.NewE(i18n.T("too many sign-in links requested"))
+
+// MagicLink is a passwordless sign-in token sent by email. Only the SHA-256
+// hash of the token is stored, and it is cleared once redeemed. RequestedAt
+// keeps the issue times inside the rate-limit window and survives redemption.
+type MagicLink struct {
+	TokenHash   string
+	CreatedAt   time.Time
+	RequestedAt []time.Time
+}
+
+// IssueMagicLink replaces any pending token of prev with a new one and returns
+// the plain token to be mailed. It fails when too many links were requested
+// within the rate-limit window.
+func IssueMagicLink(prev *MagicLink) (*MagicLink, string, error) {
+	now := util.Now()
+	var requested []time.Time
+	if prev != nil {
+		for _, t := range prev.RequestedAt {
+			if t.Add(magicLinkRateWindow).After(now) {
+				requested = append(requested, t)
+			}
+		}
+	}
+	if len(requested) >= magicLinkRateLimit {
+		return nil, "", ErrMagicLinkRateLimited
+	}
+
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return nil, "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(b)
+	return &MagicLink{
+		TokenHash:   hashMagicLinkToken(token),
+		CreatedAt:   now,
+		RequestedAt: append(requested, now),
+	}, token, nil
+}
+
+func (m *MagicLink) Validate(token string) bool {
+	if m == nil || m.TokenHash == "" || token == "" {
+		return false
+	}
+	if !m.CreatedAt.Add(magicLinkTTL).After(util.Now()) {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(m.TokenHash), []byte(hashMagicLinkToken(token))) == 1
+}
+
+// Consume returns a copy with the token removed so it cannot be redeemed again.
+func (m *MagicLink) Consume() *MagicLink {
+	if m == nil {
+		return nil
+	}
+	return &MagicLink{RequestedAt: slices.Clone(m.RequestedAt)}
+}
+
+func (m *MagicLink) Clone() *MagicLink {
+	if m == nil {
+		return nil
+	}
+	return &MagicLink{
+		TokenHash:   m.TokenHash,
+		CreatedAt:   m.CreatedAt,
+		RequestedAt: slices.Clone(m.RequestedAt),
+	}
+}
+
+func hashMagicLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,71 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIssueMagicLink(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	m, token, err := IssueMagicLink(nil)
+	require.NoError(t, err)
+	assert.NotEmpty(t, token)
+	assert.NotEqual(t, token, m.TokenHash)
+	assert.Equal(t, now, m.CreatedAt)
+	assert.Equal(t, []time.Time{now}, m.RequestedAt)
+
+	// A new link replaces the previous token.
+	m2, token2, err := IssueMagicLink(m)
+	require.NoError(t, err)
+	assert.False(t, m2.Validate(token))
+	assert.True(t, m2.Validate(token2))
+	assert.Len(t, m2.RequestedAt, 2)
+}
+
+func TestIssueMagicLink_RateLimit(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	var m *MagicLink
+	var err error
+	for range magicLinkRateLimit {
+		m, _, err = IssueMagicLink(m)
+		require.NoError(t, err)
+	}
+	_, _, err = IssueMagicLink(m)
+	assert.ErrorIs(t, err, ErrMagicLinkRateLimited)
+
+	// Redeeming does not reset the window.
+	_, _, err = IssueMagicLink(m.Consume())
+	assert.ErrorIs(t, err, ErrMagicLinkRateLimited)
+
+	util.MockNow(now.Add(magicLinkRateWindow))
+	m, _, err = IssueMagicLink(m)
+	require.NoError(t, err)
+	assert.Len(t, m.RequestedAt, 1)
+}
+
+func TestMagicLink_Validate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	m, token, err := IssueMagicLink(nil)
+	require.NoError(t, err)
+
+	assert.True(t, m.Validate(token))
+	assert.False(t, m.Validate("wrong"))
+	assert.False(t, m.Validate(""))
+	assert.False(t, m.Consume().Validate(token))
+
+	var nilLink *MagicLink
+	assert.False(t, nilLink.Validate(token))
+
+	util.MockNow(now.Add(magicLinkTTL))
+	assert.False(t, m.Validate(token))
+}
@@ -0,0 +1,263 @@
+package user
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+// TOTP parameters (RFC 6238). These are the defaults every authenticator app
+// understands, so they are not configurable.
+const (
+	totpPeriod    = 30
+	totpDigits    = 6
+	totpSkew      = 1 // accept one step either side for clock drift
+	totpSecretLen = 20
+)
+
+const (
+	// RecoveryCodeCount is how many recovery codes a user is given at once.
+	RecoveryCodeCount = 10
+	// mfaMaxFailures wrong codes in a row lock the second factor for
+	// mfaLockout, which bounds how fast a TOTP code can be guessed.
+	mfaMaxFailures = 5
+	mfaLockout     = 15 * time.Minute
+)
+
+var (
+	ErrMFAAlreadyEnabled = rerror.NewE(i18n.T("mfa is already enabled"))
+	ErrMFANotEnrolled    = rerror.NewE(i18n.T("mfa is not enrolled"))
+	ErrInvalidMFACode    = rerror.NewE(i18n.T("invalid mfa code"))
+	ErrMFALocked         = rerror.NewE(i18n.T("too many invalid mfa codes, try again later"))
+)
+
+var totpEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// MFA holds the TOTP second factor of a user authenticating with the built-in
+// reearth password provider. Enrollment is two-step: NewMFA creates a pending
+// secret, and Confirm enables it once the user proves possession with a valid
+// code. Recovery codes are stored only as SHA-256 hashes and are consumed on use.
+// Wrong codes are counted, and too many in a row lock it for a while.
+type MFA struct {
+	secret        string
+	enabled       bool
+	recoveryCodes []string
+	lastUsedStep  int64
+	failures      int
+	lockedUntil   *time.Time
+}
+
+// NewMFA returns a pending (not yet enabled) MFA with a freshly generated secret.
+func NewMFA() (*MFA, error) {
+	b := make([]byte, totpSecretLen)
+	if _, err := rand.Read(b); err != nil {
+		return nil, err
+	}
+	return &MFA{secret: totpEncoding.EncodeToString(b)}, nil
+}
+
+func MFAFrom(secret string, enabled bool, recoveryCodes []string, lastUsedStep int64, failures int, lockedUntil *time.Time) *MFA {
+	return &MFA{
+		secret:        secret,
+		enabled:       enabled,
+		recoveryCodes: slices.Clone(recoveryCodes),
+		lastUsedStep:  lastUsedStep,
+		failures:      failures,
+		lockedUntil:   util.CloneRef(lockedUntil),
+	}
+}
+
+func (m *MFA) Secret() string {
+	if m == nil {
+		return ""
+	}
+	return m.secret
+}
+
+func (m *MFA) IsEnabled() bool {
+	return m != nil && m.enabled
+}
+
+// RecoveryCodes returns the hashes of the unused recovery codes.
+func (m *MFA) RecoveryCodes() []string {
+	if m == nil {
+		return nil
+	}
+	return slices.Clone(m.recoveryCodes)
+}
+
+func (m *MFA) LastUsedStep() int64 {
+	if m == nil {
+		return 0
+	}
+	return m.lastUsedStep
+}
+
+// Failures is the number of wrong codes entered since the last accepted one
+// or the last lockout.
+func (m *MFA) Failures() int {
+	if m == nil {
+		return 0
+	}
+	return m.failures
+}
+
+// LockedUntil is when the last lockout ended or ends, or nil when there has
+// been none.
+func (m *MFA) LockedUntil() *time.Time {
+	if m == nil {
+		return nil
+	}
+	return util.CloneRef(m.lockedUntil)
+}
+
+func (m *MFA) IsLocked(now time.Time) bool {
+	return m != nil && m.lockedUntil != nil && now.Before(*m.lockedUntil)
+}
+
+// URI returns the otpauth:// key URI to be rendered as a QR code by the client.
+func (m *MFA) URI(issuer, account string) string {
+	label := url.PathEscape(issuer) + ":" + url.PathEscape(account)
+	q := url.Values{}
+	q.Set("secret", m.Secret())
+	q.Set("issuer", issuer)
+	q.Set("algorithm", "SHA1")
+	q.Set("digits", fmt.Sprint(totpDigits))
+	q.Set("period", fmt.Sprint(totpPeriod))
+	return "otpauth://totp/" + label + "?" + q.Encode()
+}
+
+// Confirm completes enrollment by validating the first code from the user's
+// authenticator app.
+func (m *MFA) Confirm(code string, now time.Time) error {
+	if m == nil {
+		return ErrMFANotEnrolled
+	}
+	if m.enabled {
+		return ErrMFAAlreadyEnabled
+	}
+	if !m.validateTOTP(code, now) {
+		return ErrInvalidMFACode
+	}
+	m.enabled = true
+	return nil
+}
+
+// Verify checks a TOTP code or, failing that, a recovery code. A TOTP step is
+// accepted only once so an observed code cannot be replayed, and a matching
+// recovery code is removed. A wrong code is counted, and the mfaMaxFailures-th
+// in a row locks the MFA for mfaLockout, during which every code is refused
+// with ErrMFALocked.
+func (m *MFA) Verify(code string, now time.Time) error {
+	if !m.IsEnabled() {
+		return ErrMFANotEnrolled
+	}
+	if m.IsLocked(now) {
+		return ErrMFALocked
+	}
+	if m.validateTOTP(code, now) || m.useRecoveryCode(code) {
+		m.failures = 0
+		return nil
+	}
+	m.failures++
+	if m.failures >= mfaMaxFailures {
+		m.failures = 0
+		until := now.Add(mfaLockout)
+		m.lockedUntil = &until
+	}
+	return ErrInvalidMFACode
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes with RecoveryCodeCount
+// new ones and returns them in plain text. Each can be used once, and only
+// their hashes are kept.
+func (m *MFA) RegenerateRecoveryCodes() ([]string, error) {
+	if !m.IsEnabled() {
+		return nil, ErrMFANotEnrolled
+	}
+	codes := make([]string, RecoveryCodeCount)
+	hashes := make([]string, RecoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 10)
+		if _, err := rand.Read(b); err != nil {
+			return nil, err
+		}
+		raw := totpEncoding.EncodeToString(b)
+		codes[i] = raw[0:4] + "-" + raw[4:8] + "-" + raw[8:12] + "-" + raw[12:16]
+		hashes[i] = hashRecoveryCode(codes[i])
+	}
+	m.recoveryCodes = hashes
+	return codes, nil
+}
+
+func (m *MFA) Clone() *MFA {
+	if m == nil {
+		return nil
+	}
+	return MFAFrom(m.secret, m.enabled, m.recoveryCodes, m.lastUsedStep, m.failures, m.lockedUntil)
+}
+
+func (m *MFA) validateTOTP(code string, now time.Time) bool {
+	code = strings.TrimSpace(code)
+	if len(code) != totpDigits {
+		return false
+	}
+	key, err := totpEncoding.DecodeString(strings.ToUpper(m.secret))
+	if err != nil {
+		return false
+	}
+	step := now.Unix() / totpPeriod
+	for i := -totpSkew; i <= totpSkew; i++ {
+		s := step + int64(i)
+		if s <= m.lastUsedStep {
+			continue
+		}
+		if subtle.ConstantTimeCompare([]byte(totpCode(key, s)), []byte(code)) == 1 {
+			m.lastUsedStep = s
+			return true
+		}
+	}
+	return false
+}
+
+func (m *MFA) useRecoveryCode(code string) bool {
+	h := hashRecoveryCode(code)
+	for i, c := range m.recoveryCodes {
+		if subtle.ConstantTimeCompare([]byte(c), []byte(h)) == 1 {
+			m.recoveryCodes = slices.Delete(m.recoveryCodes, i, i+1)
+			return true
+		}
+	}
+	return false
+}
+
+func totpCode(key []byte, step int64) string {
+	var msg [8]byte
+	binary.BigEndian.PutUint64(msg[:], uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(msg[:])
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	v := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+	return fmt.Sprintf("%0*d", totpDigits, v%1_000_000)
+}
+
+func hashRecoveryCode(code string) string {
+	normalized := strings.ToUpper(strings.ReplaceAll(strings.TrimSpace(code), "-", ""))
+	sum := sha256.Sum256([]byte(normalized))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,129 @@
+package user
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// rfc6238Secret is the SHA-1 test key from RFC 6238 Appendix B.
+var rfc6238Secret = totpEncoding.EncodeToString([]byte("12345678901234567890"))
+
+func TestTOTPCode_RFC6238(t *testing.T) {
+	key := []byte("12345678901234567890")
+	tests := []struct {
+		unix int64
+		want string
+	}{
+		{unix: 59, want: "287082"},
+		{unix: 1111111109, want: "081804"},
+		{unix: 1234567890, want: "005924"},
+		{unix: 2000000000, want: "279037"},
+	}
+	for _, tt := range tests {
+		assert.Equal(t, tt.want, totpCode(key, tt.unix/totpPeriod))
+	}
+}
+
+func TestNewMFA(t *testing.T) {
+	m, err := NewMFA()
+	require.NoError(t, err)
+	assert.False(t, m.IsEnabled())
+	assert.Len(t, m.Secret(), 32)
+	assert.Empty(t, m.RecoveryCodes())
+}
+
+func TestMFA_URI(t *testing.T) {
+	m := MFAFrom("ABCDEF", false, nil, 0, 0, nil)
+	uri := m.URI("Re:Earth", "alice@example.com")
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Re:Earth:alice@example.com?"))
+	assert.Contains(t, uri, "secret=ABCDEF")
+	assert.Contains(t, uri, "issuer=Re%3AEarth")
+	assert.Contains(t, uri, "digits=6")
+	assert.Contains(t, uri, "period=30")
+}
+
+func TestMFA_Confirm(t *testing.T) {
+	now := time.Unix(59, 0)
+
+	m := MFAFrom(rfc6238Secret, false, nil, 0, 0, nil)
+	assert.ErrorIs(t, m.Confirm("000000", now), ErrInvalidMFACode)
+	assert.False(t, m.IsEnabled())
+
+	require.NoError(t, m.Confirm("287082", now))
+	assert.True(t, m.IsEnabled())
+	assert.ErrorIs(t, m.Confirm("287082", now), ErrMFAAlreadyEnabled)
+
+	var nilMFA *MFA
+	assert.ErrorIs(t, nilMFA.Confirm("287082", now), ErrMFANotEnrolled)
+}
+
+func TestMFA_Verify(t *testing.T) {
+	now := time.Unix(1111111109, 0)
+
+	t.Run("not enabled", func(t *testing.T) {
+		m := MFAFrom(rfc6238Secret, false, nil, 0, 0, nil)
+		assert.ErrorIs(t, m.Verify("081804", now), ErrMFANotEnrolled)
+	})
+
+	t.Run("totp within skew and no replay", func(t *testing.T) {
+		m := MFAFrom(rfc6238Secret, true, nil, 0, 0, nil)
+		assert.NoError(t, m.Verify("081804", now.Add(totpPeriod*time.Second)))
+		assert.ErrorIs(t, m.Verify("081804", now), ErrInvalidMFACode)
+		assert.ErrorIs(t, m.Verify("081804", now.Add(3*totpPeriod*time.Second)), ErrInvalidMFACode)
+	})
+
+	t.Run("recovery codes are single use", func(t *testing.T) {
+		m := MFAFrom(rfc6238Secret, true, nil, 0, 0, nil)
+		codes, err := m.RegenerateRecoveryCodes()
+		require.NoError(t, err)
+		require.Len(t, codes, RecoveryCodeCount)
+		assert.Len(t, m.RecoveryCodes(), RecoveryCodeCount)
+		assert.NotContains(t, m.RecoveryCodes(), codes[0])
+
+		assert.NoError(t, m.Verify(strings.ToLower(codes[0]), now))
+		assert.ErrorIs(t, m.Verify(codes[0], now), ErrInvalidMFACode)
+		assert.NoError(t, m.Verify(codes[1], now))
+		assert.Len(t, m.RecoveryCodes(), RecoveryCodeCount-2)
+	})
+
+	t.Run("locks after too many wrong codes", func(t *testing.T) {
+		m := MFAFrom(rfc6238Secret, true, nil, 0, 0, nil)
+		for range mfaMaxFailures - 1 {
+			assert.ErrorIs(t, m.Verify("000000", now), ErrInvalidMFACode)
+		}
+		assert.Equal(t, mfaMaxFailures-1, m.Failures())
+		assert.False(t, m.IsLocked(now))
+
+		assert.ErrorIs(t, m.Verify("000000", now), ErrInvalidMFACode)
+		assert.True(t, m.IsLocked(now))
+		assert.Equal(t, now.Add(mfaLockout), *m.LockedUntil())
+		assert.ErrorIs(t, m.Verify("081804", now), ErrMFALocked)
+
+		later := now.Add(mfaLockout)
+		assert.False(t, m.IsLocked(later))
+		assert.NoError(t, m.Verify(totpCode(mustDecodeSecret(t), later.Unix()/totpPeriod), later))
+	})
+
+	t.Run("an accepted code resets the count", func(t *testing.T) {
+		m := MFAFrom(rfc6238Secret, true, nil, 0, mfaMaxFailures-1, nil)
+		assert.NoError(t, m.Verify("081804", now))
+		assert.Zero(t, m.Failures())
+	})
+}
+
+func mustDecodeSecret(t *testing.T) []byte {
+	t.Helper()
+	key, err := totpEncoding.DecodeString(rfc6238Secret)
+	require.NoError(t, err)
+	return key
+}
+
+func TestMFA_RegenerateRecoveryCodes_NotEnabled(t *testing.T) {
+	m := MFAFrom(rfc6238Secret, false, nil, 0, 0, nil)
+	_, err := m.RegenerateRecoveryCodes()
+	assert.ErrorIs(t, err, ErrMFANotEnrolled)
+}
@@ -12,6 +12,7 @@ package user
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	usecasex "github.com/reearth/reearthx/usecasex"
 	gomock "go.uber.org/mock/gomock"
@@ -267,6 +268,21 @@ func (mr *MockRepoMockRecorder) FindByVerification(arg0, arg1 any) *gomock.Call
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByVerification", reflect.TypeOf((*MockRepo)(nil).FindByVerification), arg0, arg1)
 }
 
+// FindDeletedBefore mocks base method.
+func (m *MockRepo) FindDeletedBefore(arg0 context.Context, arg1 time.Time) (List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedBefore", arg0, arg1)
+	ret0, _ := ret[0].(List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedBefore indicates an expected call of FindDeletedBefore.
+func (mr *MockRepoMockRecorder) FindDeletedBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedBefore", reflect.TypeOf((*MockRepo)(nil).FindDeletedBefore), arg0, arg1)
+}
+
 // Remove mocks base method.
 func (m *MockRepo) Remove(arg0 context.Context, arg1 ID) error {
 	m.ctrl.T.Helper()
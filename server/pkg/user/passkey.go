@@ -0,0 +1,151 @@
+package user
+
+import (
+	"bytes"
+	"encoding/base64"
+	"slices"
+	"time"
+
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+	"github.com/reearth/reearthx/util"
+)
+
+// passkeyChallengeTTL bounds how long a registration ceremony may take between
+// its begin and finish calls.
+const passkeyChallengeTTL = 5 * time.Minute
+
+var (
+	ErrPasskeyAlreadyRegistered = rerror.NewE(i18n.T("passkey is already registered"))
+	ErrPasskeyNotFound          = rerror.NewE(i18n.T("passkey not found"))
+)
+
+// Passkey is a WebAuthn public key credential registered by a user. The
+// backup flags are kept because relying parties must reject assertions whose
+// backup eligibility differs from what was seen at registration.
+type Passkey struct {
+	ID              []byte
+	PublicKey       []byte
+	AttestationType string
+	Transports      []string
+	AAGUID          []byte
+	SignCount       uint32
+	BackupEligible  bool
+	BackupState     bool
+	Nickname        string
+	CreatedAt       time.Time
+	LastUsedAt      *time.Time
+}
+
+// IDString returns the credential ID in the unpadded base64url form used by
+// WebAuthn clients.
+func (p Passkey) IDString() string {
+	return base64.RawURLEncoding.EncodeToString(p.ID)
+}
+
+func (p Passkey) Clone() Passkey {
+	p.ID = slices.Clone(p.ID)
+	p.PublicKey = slices.Clone(p.PublicKey)
+	p.Transports = slices.Clone(p.Transports)
+	p.AAGUID = slices.Clone(p.AAGUID)
+	p.LastUsedAt = util.CloneRef(p.LastUsedAt)
+	return p
+}
+
+// PasskeyIDFrom decodes a base64url credential ID as returned by IDString.
+func PasskeyIDFrom(s string) ([]byte, error) {
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+// PasskeyChallenge is the pending challenge of a passkey registration. Only
+// one registration per user can be in flight; starting another replaces it.
+// Login ceremonies are kept apart, see passkeylogin.Session.
+type PasskeyChallenge struct {
+	Challenge string
+	CreatedAt time.Time
+}
+
+func NewPasskeyChallenge(challenge string) *PasskeyChallenge {
+	return &PasskeyChallenge{
+		Challenge: challenge,
+		CreatedAt: util.Now(),
+	}
+}
+
+func (c *PasskeyChallenge) Validate() bool {
+	return c != nil && c.Challenge != "" && c.CreatedAt.Add(passkeyChallengeTTL).After(util.Now())
+}
+
+func (c *PasskeyChallenge) Clone() *PasskeyChallenge {
+	if c == nil {
+		return nil
+	}
+	c2 := *c
+	return &c2
+}
+
+func (u *User) Passkeys() []Passkey {
+	if u == nil || len(u.passkeys) == 0 {
+		return nil
+	}
+	res := make([]Passkey, 0, len(u.passkeys))
+	for _, p := range u.passkeys {
+		res = append(res, p.Clone())
+	}
+	return res
+}
+
+func (u *User) Passkey(id []byte) *Passkey {
+	if u == nil {
+		return nil
+	}
+	for _, p := range u.passkeys {
+		if bytes.Equal(p.ID, id) {
+			p2 := p.Clone()
+			return &p2
+		}
+	}
+	return nil
+}
+
+func (u *User) AddPasskey(p Passkey) error {
+	if u.Passkey(p.ID) != nil {
+		return ErrPasskeyAlreadyRegistered
+	}
+	u.passkeys = append(u.passkeys, p.Clone())
+	u.updatedAt = time.Now()
+	return nil
+}
+
+// UpdatePasskey replaces the stored credential with the same ID, e.g. to record
+// a new sign count after a successful assertion.
+func (u *User) UpdatePasskey(p Passkey) error {
+	for i, q := range u.passkeys {
+		if bytes.Equal(q.ID, p.ID) {
+			u.passkeys[i] = p.Clone()
+			u.updatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrPasskeyNotFound
+}
+
+func (u *User) RemovePasskey(id []byte) error {
+	for i, p := range u.passkeys {
+		if bytes.Equal(p.ID, id) {
+			u.passkeys = slices.Delete(u.passkeys, i, i+1)
+			u.updatedAt = time.Now()
+			return nil
+		}
+	}
+	return ErrPasskeyNotFound
+}
+
+func (u *User) PasskeyChallenge() *PasskeyChallenge {
+	return u.passkeyChallenge
+}
+
+func (u *User) SetPasskeyChallenge(c *PasskeyChallenge) {
+	u.passkeyChallenge = c.Clone()
+	u.updatedAt = time.Now()
+}
@@ -0,0 +1,57 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/reearth/reearthx/util"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPasskey_IDString(t *testing.T) {
+	p := Passkey{ID: []byte{0xfb, 0xff, 0x01}}
+	assert.Equal(t, "-_8B", p.IDString())
+
+	id, err := PasskeyIDFrom(p.IDString())
+	require.NoError(t, err)
+	assert.Equal(t, p.ID, id)
+}
+
+func TestPasskeyChallenge_Validate(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	defer util.MockNow(now)()
+
+	c := NewPasskeyChallenge("abc")
+	assert.True(t, c.Validate())
+	assert.False(t, (&PasskeyChallenge{Challenge: "abc", CreatedAt: now.Add(-6 * time.Minute)}).Validate())
+	assert.False(t, (&PasskeyChallenge{CreatedAt: now}).Validate())
+
+	var nilChallenge *PasskeyChallenge
+	assert.False(t, nilChallenge.Validate())
+}
+
+func TestUser_Passkeys(t *testing.T) {
+	u := New().NewID().Email("a@example.com").Workspace(NewWorkspaceID()).MustBuild()
+	assert.Nil(t, u.Passkeys())
+
+	p := Passkey{ID: []byte("cred"), PublicKey: []byte("key"), Nickname: "laptop"}
+	require.NoError(t, u.AddPasskey(p))
+	assert.ErrorIs(t, u.AddPasskey(p), ErrPasskeyAlreadyRegistered)
+	assert.Equal(t, []Passkey{p}, u.Passkeys())
+
+	// returned values do not alias the stored credential
+	u.Passkeys()[0].ID[0] = 'x'
+	require.NotNil(t, u.Passkey([]byte("cred")))
+
+	p.SignCount = 5
+	require.NoError(t, u.UpdatePasskey(p))
+	assert.Equal(t, uint32(5), u.Passkey([]byte("cred")).SignCount)
+	assert.ErrorIs(t, u.UpdatePasskey(Passkey{ID: []byte("other")}), ErrPasskeyNotFound)
+
+	assert.Equal(t, []Passkey{p}, u.Clone().Passkeys())
+
+	require.NoError(t, u.RemovePasskey([]byte("cred")))
+	assert.ErrorIs(t, u.RemovePasskey([]byte("cred")), ErrPasskeyNotFound)
+	assert.Nil(t, u.Passkeys())
+}
@@ -0,0 +1,100 @@
+package user
+
+import (
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/reearth/reearthx/i18n"
+	"github.com/reearth/reearthx/rerror"
+)
+
+// maxRefreshTokenFamilies bounds the sign-ins a user keeps refresh tokens for;
+// starting one more drops the one that expires first.
+const maxRefreshTokenFamilies = 20
+
+var (
+	ErrInvalidRefreshToken = rerror.NewE(i18n.T("invalid refresh token"))
+	ErrRefreshTokenReused  = rerror.NewE(i18n.T("refresh token reused"))
+)
+
+// RefreshTokenFamily tracks the refresh tokens the built-in OIDC provider
+// issued from one sign-in. Every refresh rotates the token to the next
+// generation and only the newest one is accepted, so presenting an older one
+// means it was copied; the family is then revoked.
+//
+// ExpiresAt is an idle timeout that every refresh pushes forward, but never
+// past MaxExpiresAt, so a sign-in ends at the latest a fixed time after it
+// started however often its tokens are refreshed.
+type RefreshTokenFamily struct {
+	ID           string
+	Generation   int
+	ExpiresAt    time.Time
+	MaxExpiresAt time.Time
+}
+
+func (u *User) RefreshTokenFamilies() []RefreshTokenFamily {
+	if u == nil || len(u.refreshTokenFamilies) == 0 {
+		return nil
+	}
+	return slices.Clone(u.refreshTokenFamilies)
+}
+
+// StartRefreshTokenFamily starts the refresh tokens of a new sign-in, whose
+// first token is of generation 1. expiresAt is the idle timeout and
+// maxExpiresAt the end of the sign-in.
+func (u *User) StartRefreshTokenFamily(now, expiresAt, maxExpiresAt time.Time) RefreshTokenFamily {
+	u.pruneRefreshTokenFamilies(now)
+	if len(u.refreshTokenFamilies) >= maxRefreshTokenFamilies {
+		first := 0
+		for i, f := range u.refreshTokenFamilies {
+			if f.ExpiresAt.Before(u.refreshTokenFamilies[first].ExpiresAt) {
+				first = i
+			}
+		}
+		u.refreshTokenFamilies = slices.Delete(u.refreshTokenFamilies, first, first+1)
+	}
+	f := RefreshTokenFamily{ID: uuid.NewString(), Generation: 1, ExpiresAt: expiresAt, MaxExpiresAt: maxExpiresAt}
+	f.ExpiresAt = f.capExpiresAt(expiresAt)
+	u.refreshTokenFamilies = append(u.refreshTokenFamilies, f)
+	u.updatedAt = time.Now()
+	return f
+}
+
+// RotateRefreshToken accepts the token of the given generation of a family and
+// moves the family on to the next one. A token of an earlier generation
+// revokes the family and returns ErrRefreshTokenReused.
+func (u *User) RotateRefreshToken(id string, generation int, now, expiresAt time.Time) (RefreshTokenFamily, error) {
+	u.pruneRefreshTokenFamilies(now)
+	i := slices.IndexFunc(u.refreshTokenFamilies, func(f RefreshTokenFamily) bool { return f.ID == id })
+	if i < 0 || generation > u.refreshTokenFamilies[i].Generation {
+		return RefreshTokenFamily{}, ErrInvalidRefreshToken
+	}
+	u.updatedAt = time.Now()
+	if generation < u.refreshTokenFamilies[i].Generation {
+		u.refreshTokenFamilies = slices.Delete(u.refreshTokenFamilies, i, i+1)
+		return RefreshTokenFamily{}, ErrRefreshTokenReused
+	}
+	f := &u.refreshTokenFamilies[i]
+	f.Generation++
+	f.ExpiresAt = f.capExpiresAt(expiresAt)
+	return *f, nil
+}
+
+func (f *RefreshTokenFamily) capExpiresAt(expiresAt time.Time) time.Time {
+	if f.MaxExpiresAt.IsZero() {
+		// started before families had an end: end it when it would have
+		// expired had it not been refreshed
+		f.MaxExpiresAt = f.ExpiresAt
+	}
+	if expiresAt.After(f.MaxExpiresAt) {
+		return f.MaxExpiresAt
+	}
+	return expiresAt
+}
+
+func (u *User) pruneRefreshTokenFamilies(now time.Time) {
+	u.refreshTokenFamilies = slices.DeleteFunc(u.refreshTokenFamilies, func(f RefreshTokenFamily) bool {
+		return !f.ExpiresAt.After(now)
+	})
+}
@@ -0,0 +1,79 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestUser_RotateRefreshToken(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := &User{}
+
+	f := u.StartRefreshTokenFamily(now, now.Add(time.Hour), now.Add(24*time.Hour))
+	assert.NotEmpty(t, f.ID)
+	assert.Equal(t, 1, f.Generation)
+	other := u.StartRefreshTokenFamily(now, now.Add(time.Hour), now.Add(24*time.Hour))
+
+	next, err := u.RotateRefreshToken(f.ID, 1, now, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, RefreshTokenFamily{ID: f.ID, Generation: 2, ExpiresAt: now.Add(2 * time.Hour), MaxExpiresAt: now.Add(24 * time.Hour)}, next)
+
+	_, err = u.RotateRefreshToken(f.ID, 3, now, now.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	_, err = u.RotateRefreshToken("unknown", 1, now, now.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	// a rotated token revokes its family, but not the other sign-ins
+	_, err = u.RotateRefreshToken(f.ID, 1, now, now.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrRefreshTokenReused)
+	_, err = u.RotateRefreshToken(f.ID, 2, now, now.Add(time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	assert.Equal(t, []RefreshTokenFamily{other}, u.RefreshTokenFamilies())
+
+	// expired families are dropped
+	_, err = u.RotateRefreshToken(other.ID, 1, now.Add(time.Hour), now.Add(2*time.Hour))
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+	assert.Empty(t, u.RefreshTokenFamilies())
+}
+
+func TestUser_StartRefreshTokenFamily_Limit(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := &User{}
+
+	first := u.StartRefreshTokenFamily(now, now.Add(time.Minute), now.Add(24*time.Hour))
+	for i := 1; i < maxRefreshTokenFamilies; i++ {
+		u.StartRefreshTokenFamily(now, now.Add(time.Hour), now.Add(24*time.Hour))
+	}
+	u.StartRefreshTokenFamily(now, now.Add(time.Hour), now.Add(24*time.Hour))
+
+	fams := u.RefreshTokenFamilies()
+	assert.Len(t, fams, maxRefreshTokenFamilies)
+	assert.NotContains(t, fams, first, "the family that expires first is dropped")
+}
+
+func TestUser_RotateRefreshToken_MaxLifetime(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	u := &User{}
+
+	f := u.StartRefreshTokenFamily(now, now.Add(time.Hour), now.Add(90*time.Minute))
+	assert.Equal(t, now.Add(time.Hour), f.ExpiresAt)
+
+	// refreshing pushes the idle timeout forward, but not past the end of the sign-in
+	f, err := u.RotateRefreshToken(f.ID, 1, now.Add(50*time.Minute), now.Add(110*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(90*time.Minute), f.ExpiresAt)
+
+	_, err = u.RotateRefreshToken(f.ID, 2, now.Add(90*time.Minute), now.Add(150*time.Minute))
+	assert.ErrorIs(t, err, ErrInvalidRefreshToken)
+
+	// a family stored before families had an end ends when it would have expired
+	legacy := RefreshTokenFamily{ID: "legacy", Generation: 1, ExpiresAt: now.Add(time.Hour)}
+	u = &User{refreshTokenFamilies: []RefreshTokenFamily{legacy}}
+	f, err = u.RotateRefreshToken("legacy", 1, now, now.Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(time.Hour), f.ExpiresAt)
+	assert.Equal(t, now.Add(time.Hour), f.MaxExpiresAt)
+}
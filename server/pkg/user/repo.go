@@ -3,6 +3,7 @@ package user
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/reearth/reearthx/i18n"
 	"github.com/reearth/reearthx/rerror"
@@ -35,6 +36,9 @@ type Repo interface {
 	FindByVerification(context.Context, string) (*User, error)
 	FindByPasswordResetRequest(context.Context, string) (*User, error)
 	FindBySubOrCreate(context.Context, *User, string) (*User, error)
+	// FindDeletedBefore returns the deactivated users whose deletedAt is before
+	// the given time.
+	FindDeletedBefore(context.Context, time.Time) (List, error)
 	Create(context.Context, *User) error
 	Save(context.Context, *User) error
 	Remove(context.Context, ID) error
@@ -11,24 +11,44 @@ import (
 
 var (
 	ErrInvalidEmail = errors.New("invalid email")
+	// ErrDeletionNotRequested is returned when cancelling the deletion of a user
+	// who did not request it.
+	ErrDeletionNotRequested = errors.New("deletion of the user is not requested")
 )
 
 type User struct {
-	id             ID
-	name           string
-	alias          string
-	email          string
-	latestLogoutAt time.Time
-	metadata       Metadata
-	password       EncodedPassword
-	workspace      WorkspaceID
-	auths          []Auth
-	verification   *Verification
-	passwordReset  *PasswordReset
-	host           string
-	updatedAt      time.Time
-	deletedAt      *time.Time
-	createdAt      *time.Time
+	id               ID
+	name             string
+	alias            string
+	email            string
+	latestLogoutAt   time.Time
+	metadata         Metadata
+	password         EncodedPassword
+	workspace        WorkspaceID
+	auths            []Auth
+	authLinks        []AuthLink
+	verification     *Verification
+	passwordReset    *PasswordReset
+	magicLink        *MagicLink
+	mfa              *MFA
+	passkeys         []Passkey
+	passkeyChallenge *PasskeyChallenge
+	host             string
+	updatedAt        time.Time
+	deletedAt        *time.Time
+	createdAt        *time.Time
+	mergedInto       *ID
+	// deletionRequestedAt is set when the user deleted their own account, which
+	// they can cancel until it is purged.
+	deletionRequestedAt *time.Time
+	purgeNotifiedAt     *time.Time
+
+	// refreshTokenFamilies are the sign-ins the built-in OIDC provider issued
+	// refresh tokens for.
+	refreshTokenFamilies []RefreshTokenFamily
+	// authCodes are the authorization codes the built-in OIDC provider issued
+	// and that were not redeemed yet.
+	authCodes []AuthCode
 }
 
 func (u *User) ID() ID {
@@ -137,6 +157,7 @@ func (u *User) RemoveAuth(a Auth) bool {
 	for i, b := range u.auths {
 		if a == b {
 			u.auths = append(u.auths[:i], u.auths[i+1:]...)
+			u.pruneAuthLinks()
 			u.updatedAt = time.Now()
 			return true
 		}
@@ -163,6 +184,7 @@ func (u *User) RemoveAuthByProvider(provider string) bool {
 	for i, b := range u.auths {
 		if provider == b.Provider {
 			u.auths = append(u.auths[:i], u.auths[i+1:]...)
+			u.pruneAuthLinks()
 			u.updatedAt = time.Now()
 			return true
 		}
@@ -172,6 +194,7 @@ func (u *User) RemoveAuthByProvider(provider string) bool {
 
 func (u *User) ClearAuths() {
 	u.auths = nil
+	u.authLinks = nil
 	u.updatedAt = time.Now()
 }
 
@@ -193,8 +216,66 @@ func (u *User) IsDeleted() bool {
 	return u.deletedAt != nil
 }
 
+// MergedInto returns the user this user was merged into, if any. Merged users
+// stay deleted.
+func (u *User) MergedInto() *ID {
+	return u.mergedInto.CloneRef()
+}
+
+// MarkMergedInto deactivates the user in favor of target. The auths must have
+// been moved to target already, so that they sign in to target from now on.
+func (u *User) MarkMergedInto(target ID) {
+	u.mergedInto = &target
+	u.ClearAuths()
+	u.Deactivate()
+}
+
 func (u *User) Reactivate() {
 	u.deletedAt = nil
+	u.deletionRequestedAt = nil
+	u.purgeNotifiedAt = nil
+	u.updatedAt = time.Now()
+}
+
+// RequestDeletion deactivates the user on their own request. The user is
+// purged once the retention window has passed unless they cancel it first.
+func (u *User) RequestDeletion() {
+	u.Deactivate()
+	u.deletionRequestedAt = util.CloneRef(u.deletedAt)
+}
+
+// CancelDeletion reactivates a user who requested the deletion of their
+// account. A user deactivated by an administrator cannot cancel it.
+func (u *User) CancelDeletion() error {
+	if u.deletionRequestedAt == nil {
+		return ErrDeletionNotRequested
+	}
+	u.Reactivate()
+	return nil
+}
+
+func (u *User) DeletionRequestedAt() *time.Time {
+	return u.deletionRequestedAt
+}
+
+// PurgeAt returns when the deactivated user is due to be purged given the
+// retention window, or nil when the user is active.
+func (u *User) PurgeAt(retention time.Duration) *time.Time {
+	if u.deletedAt == nil {
+		return nil
+	}
+	t := u.deletedAt.Add(retention)
+	return &t
+}
+
+// PurgeNotifiedAt returns when the user was told that their account is about
+// to be purged.
+func (u *User) PurgeNotifiedAt() *time.Time {
+	return u.purgeNotifiedAt
+}
+
+func (u *User) SetPurgeNotifiedAt(t time.Time) {
+	u.purgeNotifiedAt = &t
 	u.updatedAt = time.Now()
 }
 
@@ -229,6 +310,24 @@ func (u *User) SetPasswordReset(pr *PasswordReset) {
 	u.updatedAt = time.Now()
 }
 
+func (u *User) MagicLink() *MagicLink {
+	return u.magicLink
+}
+
+func (u *User) SetMagicLink(m *MagicLink) {
+	u.magicLink = m.Clone()
+	u.updatedAt = time.Now()
+}
+
+func (u *User) MFA() *MFA {
+	return u.mfa
+}
+
+func (u *User) SetMFA(m *MFA) {
+	u.mfa = m
+	u.updatedAt = time.Now()
+}
+
 func (u *User) SetVerification(v *Verification) {
 	u.verification = v
 	u.updatedAt = time.Now()
@@ -253,20 +352,32 @@ func (u *User) UpdatedAt() time.Time {
 
 func (u *User) Clone() *User {
 	return &User{
-		id:             u.id,
-		name:           u.name,
-		alias:          u.alias,
-		email:          u.email,
-		latestLogoutAt: u.latestLogoutAt,
-		password:       u.password,
-		workspace:      u.workspace,
-		auths:          slices.Clone(u.auths),
-		metadata:       u.metadata,
-		verification:   util.CloneRef(u.verification),
-		passwordReset:  util.CloneRef(u.passwordReset),
-		updatedAt:      time.Now(),
-		deletedAt:      u.deletedAt,
-		createdAt:      u.createdAt,
+		id:               u.id,
+		name:             u.name,
+		alias:            u.alias,
+		email:            u.email,
+		latestLogoutAt:   u.latestLogoutAt,
+		password:         u.password,
+		workspace:        u.workspace,
+		auths:            slices.Clone(u.auths),
+		authLinks:        slices.Clone(u.authLinks),
+		metadata:         u.metadata,
+		verification:     util.CloneRef(u.verification),
+		passwordReset:    util.CloneRef(u.passwordReset),
+		magicLink:        u.magicLink.Clone(),
+		mfa:              u.mfa.Clone(),
+		passkeys:         u.Passkeys(),
+		passkeyChallenge: u.passkeyChallenge.Clone(),
+		updatedAt:        time.Now(),
+		deletedAt:        u.deletedAt,
+		createdAt:        u.createdAt,
+		mergedInto:       u.mergedInto.CloneRef(),
+
+		deletionRequestedAt: util.CloneRef(u.deletionRequestedAt),
+		purgeNotifiedAt:     util.CloneRef(u.purgeNotifiedAt),
+
+		refreshTokenFamilies: slices.Clone(u.refreshTokenFamilies),
+		authCodes:            u.AuthCodes(),
 	}
 }
 
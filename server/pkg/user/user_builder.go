@@ -117,16 +117,51 @@ func (b *Builder) Auths(auths []Auth) *Builder {
 	return b
 }
 
+func (b *Builder) AuthLinks(l []AuthLink) *Builder {
+	b.u.authLinks = l
+	return b
+}
+
 func (b *Builder) PasswordReset(pr *PasswordReset) *Builder {
 	b.u.passwordReset = pr
 	return b
 }
 
+func (b *Builder) MagicLink(m *MagicLink) *Builder {
+	b.u.magicLink = m
+	return b
+}
+
+func (b *Builder) AuthCodes(c []AuthCode) *Builder {
+	b.u.authCodes = c
+	return b
+}
+
+func (b *Builder) RefreshTokenFamilies(f []RefreshTokenFamily) *Builder {
+	b.u.refreshTokenFamilies = f
+	return b
+}
+
 func (b *Builder) Verification(v *Verification) *Builder {
 	b.u.verification = v
 	return b
 }
 
+func (b *Builder) MFA(m *MFA) *Builder {
+	b.u.mfa = m
+	return b
+}
+
+func (b *Builder) Passkeys(p []Passkey) *Builder {
+	b.u.passkeys = p
+	return b
+}
+
+func (b *Builder) PasskeyChallenge(c *PasskeyChallenge) *Builder {
+	b.u.passkeyChallenge = c
+	return b
+}
+
 func (b *Builder) LatestLogoutAt(t time.Time) *Builder {
 	b.u.latestLogoutAt = t
 	return b
@@ -142,6 +177,21 @@ func (b *Builder) DeletedAt(t *time.Time) *Builder {
 	return b
 }
 
+func (b *Builder) MergedInto(id *ID) *Builder {
+	b.u.mergedInto = id.CloneRef()
+	return b
+}
+
+func (b *Builder) DeletionRequestedAt(t *time.Time) *Builder {
+	b.u.deletionRequestedAt = t
+	return b
+}
+
+func (b *Builder) PurgeNotifiedAt(t *time.Time) *Builder {
+	b.u.purgeNotifiedAt = t
+	return b
+}
+
 // CreatedAt sets the creation timestamp explicitly. Unlike UpdatedAt, there is
 // no auto-default fallback in Build(): nil means "unknown" (e.g. historical
 // users predating this field) and must never be silently replaced with
@@ -246,3 +246,36 @@ func TestUser_Clone_UpdatedAt(t *testing.T) {
 	assert.GreaterOrEqual(t, cloned.updatedAt, now)
 	assert.Greater(t, cloned.updatedAt, u.updatedAt)
 }
+
+func TestUser_MarkMergedInto(t *testing.T) {
+	target := NewID()
+	u := New().NewID().Workspace(NewWorkspaceID()).Email("a@example.com").
+		Auths([]Auth{AuthFrom("auth0|1")}).MustBuild()
+
+	u.MarkMergedInto(target)
+	assert.Equal(t, &target, u.MergedInto())
+	assert.True(t, u.IsDeleted())
+	assert.Empty(t, u.Auths())
+	assert.Equal(t, &target, u.Clone().MergedInto())
+}
+
+func TestUser_RequestDeletion(t *testing.T) {
+	u := New().NewID().Workspace(NewWorkspaceID()).Email("a@example.com").MustBuild()
+
+	u.RequestDeletion()
+	assert.True(t, u.IsDeleted())
+	assert.Equal(t, u.DeletedAt(), u.DeletionRequestedAt())
+	assert.Equal(t, u.DeletedAt().Add(time.Hour), *u.PurgeAt(time.Hour))
+	assert.Equal(t, u.DeletionRequestedAt(), u.Clone().DeletionRequestedAt())
+
+	u.SetPurgeNotifiedAt(time.Now())
+	assert.NoError(t, u.CancelDeletion())
+	assert.False(t, u.IsDeleted())
+	assert.Nil(t, u.DeletionRequestedAt())
+	assert.Nil(t, u.PurgeNotifiedAt())
+	assert.Nil(t, u.PurgeAt(time.Hour))
+
+	u.Deactivate()
+	assert.ErrorIs(t, u.CancelDeletion(), ErrDeletionNotRequested)
+	assert.True(t, u.IsDeleted())
+}
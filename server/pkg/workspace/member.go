@@ -257,6 +257,27 @@ func (m *Members) Join(u *user.User, roleType role.RoleType, i UserID) error {
 	return nil
 }
 
+// MergeUser moves the membership of from to to. If to is already a member, it
+// keeps the higher of both roles. Unlike Join and Leave it also applies to
+// personal workspaces, whose ownership passes to to.
+func (m *Members) MergeUser(from, to UserID) (role.RoleType, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	src, ok := m.users[from]
+	if !ok {
+		return "", ErrTargetUserNotInTheWorkspace
+	}
+	delete(m.users, from)
+	if dst, ok := m.users[to]; ok {
+		dst.Role = role.Higher(dst.Role, src.Role)
+		m.users[to] = dst
+		return dst.Role, nil
+	}
+	m.users[to] = src
+	return src.Role, nil
+}
+
 func (m *Members) AddIntegration(iid IntegrationID, roleType role.RoleType, i UserID) error {
 	m.mu.Lock()
 	defer m.mu.Unlock()
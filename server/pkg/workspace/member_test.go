@@ -3,6 +3,7 @@ package workspace
 import (
 	"testing"
 
+	"github.com/reearth/reearth-accounts/server/pkg/role"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -73,3 +74,26 @@ func TestMetadata_SetPhotoURL(t *testing.T) {
 	metadata.SetPhotoURL("new photo url")
 	assert.Equal(t, "new photo url", metadata.PhotoURL())
 }
+
+func TestMembers_MergeUser(t *testing.T) {
+	src, dst, other := NewUserID(), NewUserID(), NewUserID()
+
+	m := NewMembersWith(map[UserID]Member{
+		src: {Role: role.RoleMaintainer},
+		dst: {Role: role.RoleReader},
+	}, nil, false)
+	r, err := m.MergeUser(src, dst)
+	assert.NoError(t, err)
+	assert.Equal(t, role.RoleMaintainer, r)
+	assert.False(t, m.HasUser(src))
+	assert.Equal(t, role.RoleMaintainer, m.UserRole(dst))
+
+	personal := NewMembersWith(map[UserID]Member{src: {Role: role.RoleOwner}}, nil, true)
+	r, err = personal.MergeUser(src, dst)
+	assert.NoError(t, err)
+	assert.Equal(t, role.RoleOwner, r)
+	assert.Equal(t, []UserID{dst}, personal.UserIDs())
+
+	_, err = personal.MergeUser(other, dst)
+	assert.ErrorIs(t, err, ErrTargetUserNotInTheWorkspace)
+}
@@ -12,6 +12,7 @@ package workspace
 import (
 	context "context"
 	reflect "reflect"
+	time "time"
 
 	user "github.com/reearth/reearth-accounts/server/pkg/user"
 	usecasex "github.com/reearth/reearthx/usecasex"
@@ -222,6 +223,21 @@ func (mr *MockRepoMockRecorder) FindByUserWithPagination(ctx, id, pagination any
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindByUserWithPagination", reflect.TypeOf((*MockRepo)(nil).FindByUserWithPagination), ctx, id, pagination)
 }
 
+// FindDeletedBefore mocks base method.
+func (m *MockRepo) FindDeletedBefore(arg0 context.Context, arg1 time.Time) (List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "FindDeletedBefore", arg0, arg1)
+	ret0, _ := ret[0].(List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// FindDeletedBefore indicates an expected call of FindDeletedBefore.
+func (mr *MockRepoMockRecorder) FindDeletedBefore(arg0, arg1 any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "FindDeletedBefore", reflect.TypeOf((*MockRepo)(nil).FindDeletedBefore), arg0, arg1)
+}
+
 // Remove mocks base method.
 func (m *MockRepo) Remove(arg0 context.Context, arg1 ID) error {
 	m.ctrl.T.Helper()
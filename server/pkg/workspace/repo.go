@@ -3,6 +3,7 @@ package workspace
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/reearth/reearth-accounts/server/pkg/user"
 	"github.com/reearth/reearthx/usecasex"
@@ -45,6 +46,9 @@ type Repo interface {
 	FindByUserWithPagination(ctx context.Context, id user.ID, pagination *usecasex.Pagination) (List, *usecasex.PageInfo, error)
 	FindByIntegration(context.Context, IntegrationID) (List, error)
 	FindByIntegrations(context.Context, IntegrationIDList) (List, error)
+	// FindDeletedBefore returns the deactivated workspaces whose deletedAt is
+	// before the given time.
+	FindDeletedBefore(context.Context, time.Time) (List, error)
 	Create(context.Context, *Workspace) error
 	Save(context.Context, *Workspace) error
 	SaveAll(context.Context, List) error
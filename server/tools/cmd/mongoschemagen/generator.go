@@ -186,7 +186,7 @@ func (g *Generator) convertPropertyToMongo(prop *jsonschema.Schema, parentType a
 
 	// Handle array items
 	if bsonType == "array" && prop.Items != nil {
-		result["items"] = g.convertPropertyToMongo(prop.Items, nil, "")
+		result["items"] = g.convertPropertyToMongo(prop.Items, getSliceElemType(parentType, originalFieldName), "")
 	}
 
 	// Handle nested object properties
@@ -343,6 +343,37 @@ func getMapValueType(parentType any, jsonFieldName string) any {
 	return nil
 }
 
+// getSliceElemType returns the struct element type of a slice field so that
+// item properties get the same time/binData detection as top-level fields.
+func getSliceElemType(parentType any, jsonFieldName string) any {
+	if parentType == nil || jsonFieldName == "" {
+		return nil
+	}
+
+	t := reflect.TypeOf(parentType)
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	field, found := findFieldByJSONName(t, jsonFieldName)
+	if !found {
+		return nil
+	}
+
+	fieldType := field.Type
+	if fieldType.Kind() == reflect.Slice {
+		elemType := fieldType.Elem()
+		if elemType.Kind() == reflect.Struct {
+			return reflect.New(elemType).Elem().Interface()
+		}
+	}
+
+	return nil
+}
+
 func isPointerField(parentType any, jsonFieldName string) bool {
 	if parentType == nil {
 		return false
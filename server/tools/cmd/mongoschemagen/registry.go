@@ -43,4 +43,52 @@ func RegisterSchemas(g *Generator) {
 		"Config Collection Schema",
 		"Schema for config documents in the reearth-accounts database",
 	)
+	g.RegisterSchema(
+		"scimtenant",
+		mongodoc.SCIMTenantDocument{},
+		"SCIMTenant Collection Schema",
+		"Schema for scimtenant documents in the reearth-accounts database",
+	)
+	g.RegisterSchema(
+		"auditlog",
+		mongodoc.AuditLogDocument{},
+		"AuditLog Collection Schema",
+		"Schema for auditlog documents in the reearth-accounts database",
+	)
+	g.RegisterSchema(
+		"rolemapping",
+		mongodoc.RoleMappingDocument{},
+		"RoleMapping Collection Schema",
+		"Schema for rolemapping documents in the reearth-accounts database",
+	)
+	g.RegisterSchema(
+		"ldapsyncrun",
+		mongodoc.LDAPSyncRunDocument{},
+		"LDAPSyncRun Collection Schema",
+		"Schema for ldapsyncrun documents in the reearth-accounts database",
+	)
+	g.RegisterSchema(
+		"adminauditrecord",
+		mongodoc.AdminAuditRecordDocument{},
+		"AdminAuditRecord Collection Schema",
+		"Schema for adminauditrecord documents in the reearth-accounts database",
+	)
+	g.RegisterSchema(
+		"adminsession",
+		mongodoc.AdminSessionDocument{},
+		"AdminSession Collection Schema",
+		"Schema for adminsession documents in the reearth-accounts database",
+	)
+	g.RegisterSchema(
+		"adminapprovalrule",
+		mongodoc.AdminApprovalRuleDocument{},
+		"AdminApprovalRule Collection Schema",
+		"Schema for adminapprovalrule documents in the reearth-accounts database",
+	)
+	g.RegisterSchema(
+		"passkeylogin",
+		mongodoc.PasskeyLoginDocument{},
+		"PasskeyLogin Collection Schema",
+		"Schema for passkeylogin documents in the reearth-accounts database",
+	)
 }